@@ -5,44 +5,91 @@ package main
 import (
 	"context"
 	"database/sql"
+	"flag"
 	"fmt"
 	"log"
+	"net/http"
 	"os"
 	"os/signal"
 	"syscall"
 	"time"
 
+	"github.com/Ultrahd-dev/student-schedule-app/backend/internal/audit"
+	"github.com/Ultrahd-dev/student-schedule-app/backend/internal/auth"
+	"github.com/Ultrahd-dev/student-schedule-app/backend/internal/bells"
+	"github.com/Ultrahd-dev/student-schedule-app/backend/internal/breaker"
+	"github.com/Ultrahd-dev/student-schedule-app/backend/internal/buildings"
+	"github.com/Ultrahd-dev/student-schedule-app/backend/internal/cache"
+	"github.com/Ultrahd-dev/student-schedule-app/backend/internal/calendar"
+	"github.com/Ultrahd-dev/student-schedule-app/backend/internal/captcha"
 	"github.com/Ultrahd-dev/student-schedule-app/backend/internal/changes"
 	"github.com/Ultrahd-dev/student-schedule-app/backend/internal/config"
+	"github.com/Ultrahd-dev/student-schedule-app/backend/internal/consultations"
+	"github.com/Ultrahd-dev/student-schedule-app/backend/internal/dashboard"
+	"github.com/Ultrahd-dev/student-schedule-app/backend/internal/database"
+	"github.com/Ultrahd-dev/student-schedule-app/backend/internal/electives"
+	"github.com/Ultrahd-dev/student-schedule-app/backend/internal/features"
+	"github.com/Ultrahd-dev/student-schedule-app/backend/internal/feedback"
+	"github.com/Ultrahd-dev/student-schedule-app/backend/internal/files"
+	"github.com/Ultrahd-dev/student-schedule-app/backend/internal/gateway"
 	"github.com/Ultrahd-dev/student-schedule-app/backend/internal/grpc"
+	filesgrpc "github.com/Ultrahd-dev/student-schedule-app/backend/internal/grpc/files"
+	notificationsgrpc "github.com/Ultrahd-dev/student-schedule-app/backend/internal/grpc/notifications"
+	schedulegrpc "github.com/Ultrahd-dev/student-schedule-app/backend/internal/grpc/schedule"
+	"github.com/Ultrahd-dev/student-schedule-app/backend/internal/jobs"
 	"github.com/Ultrahd-dev/student-schedule-app/backend/internal/jwt"
+	"github.com/Ultrahd-dev/student-schedule-app/backend/internal/kiosk"
+	"github.com/Ultrahd-dev/student-schedule-app/backend/internal/ldap"
+	"github.com/Ultrahd-dev/student-schedule-app/backend/internal/lock"
+	"github.com/Ultrahd-dev/student-schedule-app/backend/internal/mail"
+	"github.com/Ultrahd-dev/student-schedule-app/backend/internal/maintenance"
+	"github.com/Ultrahd-dev/student-schedule-app/backend/internal/metrics"
+	"github.com/Ultrahd-dev/student-schedule-app/backend/internal/nats"
+	"github.com/Ultrahd-dev/student-schedule-app/backend/internal/notes"
 	"github.com/Ultrahd-dev/student-schedule-app/backend/internal/notifications"
+	"github.com/Ultrahd-dev/student-schedule-app/backend/internal/outbox"
+	"github.com/Ultrahd-dev/student-schedule-app/backend/internal/pdf"
+	"github.com/Ultrahd-dev/student-schedule-app/backend/internal/personal"
+	"github.com/Ultrahd-dev/student-schedule-app/backend/internal/realtime"
+	"github.com/Ultrahd-dev/student-schedule-app/backend/internal/rollover"
 	"github.com/Ultrahd-dev/student-schedule-app/backend/internal/schedule"
 	"github.com/Ultrahd-dev/student-schedule-app/backend/internal/scraper"
+	"github.com/Ultrahd-dev/student-schedule-app/backend/internal/scraper/fixture"
+	"github.com/Ultrahd-dev/student-schedule-app/backend/internal/status"
+	"github.com/Ultrahd-dev/student-schedule-app/backend/internal/storage"
+	"github.com/Ultrahd-dev/student-schedule-app/backend/internal/tenant"
+	"github.com/Ultrahd-dev/student-schedule-app/backend/internal/timetable"
+	"github.com/Ultrahd-dev/student-schedule-app/backend/internal/txn"
 	"github.com/Ultrahd-dev/student-schedule-app/backend/internal/users"
+	filespb "github.com/Ultrahd-dev/student-schedule-app/backend/proto/gen/files"
+	notificationspb "github.com/Ultrahd-dev/student-schedule-app/backend/proto/gen/notifications"
+	schedulepb "github.com/Ultrahd-dev/student-schedule-app/backend/proto/gen/schedule"
+	userspb "github.com/Ultrahd-dev/student-schedule-app/backend/proto/gen/users"
+	"github.com/google/uuid"
 	_ "github.com/lib/pq"
 )
 
 func main() {
+	configPath := flag.String("config", "", "путь к файлу конфигурации (или SCHEDULE_CONFIG, по умолчанию ./configs/config.yaml)")
+	dsn := flag.String("dsn", "", "строка подключения к PostgreSQL вместо параметров из конфигурации (или SCHEDULE_DSN)")
+	flag.Parse()
+
 	// Загружаем конфигурацию
-	// ИСПРАВЛЕНО: Указываем путь к конкретному файлу конфигурации
-	cfg, err := config.LoadConfig("./configs/config.yaml")
+	cfg, err := config.LoadConfig(config.ResolvePath(*configPath, "./configs/config.yaml"))
 	if err != nil {
 		log.Fatalf("Ошибка загрузки конфигурации: %v", err)
 	}
+	cfg.OverrideDSN(*dsn)
 
-	// ИСПРАВЛЕНО: Формируем DSN вручную из полей конфигурации
-	dsn := fmt.Sprintf("host=%s port=%d user=%s password=%s dbname=%s sslmode=%s",
-		cfg.Database.Host,
-		cfg.Database.Port,
-		cfg.Database.User,
-		cfg.Database.Password,
-		cfg.Database.DBName,
-		cfg.Database.SSLMode,
-	)
+	// Часовой пояс колледжа: все даты и время пар интерпретируются в нем
+	loc, err := cfg.College.Location()
+	if err != nil {
+		log.Fatalf("Ошибка загрузки часового пояса: %v", err)
+	}
+	log.Printf("Часовой пояс колледжа: %s", loc)
 
 	// Подключаемся к базе данных
-	db, err := sql.Open("postgres", dsn)
+	db, err := database.Open(cfg.Database.GetDSN(), cfg.Database.StatementTimeout, cfg.Database.LockTimeout)
 	if err != nil {
 		log.Fatalf("Ошибка подключения к базе данных: %v", err)
 	}
@@ -62,44 +109,650 @@ func main() {
 
 	log.Println("Успешное подключение к базе данных")
 
+	// Реплики для частых запросов на чтение; запись всегда идет в основную базу
+	replicas := make([]*sql.DB, 0, len(cfg.Database.Replicas))
+	for i, dsn := range cfg.Database.Replicas {
+		replica, err := database.Open(dsn, cfg.Database.StatementTimeout, cfg.Database.LockTimeout)
+		if err != nil {
+			log.Fatalf("Ошибка подключения к реплике #%d: %v", i+1, err)
+		}
+		replicas = append(replicas, replica)
+	}
+	dbRouter := database.NewRouter(db, replicas...)
+	defer func() {
+		if err := dbRouter.Close(); err != nil {
+			log.Printf("Ошибка закрытия соединений с репликами: %v", err)
+		}
+	}()
+	routerCtx, routerCancel := context.WithCancel(context.Background())
+	defer routerCancel()
+	go dbRouter.Start(routerCtx, cfg.Database.ReplicaCheckInterval)
+
+	// Расписания звонков из базы (если импортированы) заменяют встроенное
+	bellRepo := bells.NewRepository(db)
+	bellTimings, err := bellRepo.LoadAll(ctx)
+	if err != nil {
+		log.Fatalf("Ошибка загрузки расписания звонков: %v", err)
+	}
+	bells.SetAll(bellTimings)
+	if len(bellTimings) > 0 {
+		log.Printf("Расписание звонков загружено из базы (колледжей: %d)", len(bellTimings))
+	}
+
 	// Инициализируем компоненты
+	// Транзакции, охватывающие несколько репозиториев (данные, аудит, outbox, очередь задач)
+	txManager := txn.NewManager(db)
 	userRepo := users.NewRepository(db)
+	var userCache *users.UserCache
+	if cfg.UserCache.Enabled {
+		var redis *cache.Redis
+		if cfg.UserCache.Redis && cfg.Redis.Addr != "" {
+			redis = cache.NewRedis(cfg.Redis.Addr, time.Second)
+			defer redis.Close()
+		}
+		userCache = users.NewUserCache(cfg.UserCache.Size, cfg.UserCache.LocalTTL, redis, cfg.UserCache.RedisTTL)
+		userRepo.SetCache(userCache)
+		log.Printf("Кэш пользователей включен (Redis: %t)", redis != nil)
+	}
 	userService := users.NewService(userRepo)
+	userService.RequireInvitations(cfg.Registration.InvitationRequired)
+	twoFactorRoles := make([]users.Role, 0, len(cfg.TwoFactor.Roles))
+	for _, role := range cfg.TwoFactor.Roles {
+		twoFactorRoles = append(twoFactorRoles, users.Role(role))
+	}
+	userService.ConfigureTwoFactor(users.TwoFactorConfig{
+		Issuer: cfg.TwoFactor.Issuer,
+		Roles:  twoFactorRoles,
+	})
+	if cfg.LDAP.Enabled {
+		roleGroups := make(map[users.Role][]string, len(cfg.LDAP.RoleGroups))
+		for role, groups := range cfg.LDAP.RoleGroups {
+			roleGroups[users.Role(role)] = groups
+		}
+		userService.ConfigureLDAP(users.LDAPConfig{
+			Client: ldap.NewClient(ldap.Config{
+				URL:                cfg.LDAP.URL,
+				StartTLS:           cfg.LDAP.StartTLS,
+				InsecureSkipVerify: cfg.LDAP.InsecureSkipVerify,
+				BindDN:             cfg.LDAP.BindDN,
+				BindPassword:       cfg.LDAP.BindPassword,
+				BaseDN:             cfg.LDAP.BaseDN,
+				LoginAttribute:     cfg.LDAP.LoginAttribute,
+				ObjectClass:        cfg.LDAP.ObjectClass,
+				Attributes: []string{"memberOf", cfg.LDAP.NameAttribute, cfg.LDAP.GroupAttribute,
+					cfg.LDAP.DepartmentAttribute, cfg.LDAP.PositionAttribute},
+				Timeout: cfg.LDAP.Timeout,
+			}),
+			RoleGroups:          roleGroups,
+			DefaultRole:         users.Role(cfg.LDAP.DefaultRole),
+			NameAttribute:       cfg.LDAP.NameAttribute,
+			GroupAttribute:      cfg.LDAP.GroupAttribute,
+			DepartmentAttribute: cfg.LDAP.DepartmentAttribute,
+			PositionAttribute:   cfg.LDAP.PositionAttribute,
+		})
+		log.Printf("Вход через каталог LDAP включен: %s", cfg.LDAP.URL)
+	}
+
+	// Письма пользователям (приглашения, уведомления безопасности); nil - не отправляются
+	var mailer *mail.Sender
+	if cfg.Mail.Host != "" {
+		mailer, err = mail.NewSender(mail.Config{
+			Host:     cfg.Mail.Host,
+			Port:     cfg.Mail.Port,
+			TLS:      cfg.Mail.TLS,
+			Username: cfg.Mail.Username,
+			Password: cfg.Mail.Password,
+			From:     cfg.Mail.From,
+			Timeout:  cfg.Mail.Timeout,
+		})
+		if err != nil {
+			log.Fatalf("Ошибка настройки отправки писем: %v", err)
+		}
+		userService.SetMailer(mailer, cfg.Mail.AppURL)
+		log.Printf("Отправка писем включена: %s:%d", cfg.Mail.Host, cfg.Mail.Port)
+	}
+
+	// Создаем начального администратора, если он задан в конфигурации
+	if cfg.Admin.Email != "" {
+		admin, created, err := userService.BootstrapAdmin(ctx, cfg.Admin.Email, cfg.Admin.Password)
+		if err != nil {
+			log.Fatalf("Ошибка создания начального администратора: %v", err)
+		}
+		if created {
+			log.Printf("Создан начальный администратор %s", admin.Email)
+		}
+	}
 
 	// ИСПРАВЛЕНО: Используем cfg.JWT.Expiration вместо cfg.GetJWTTokenLifetime()
-	jwtManager := jwt.NewManager(cfg.JWT.Secret, cfg.JWT.Expiration)
+	jwtManager := jwt.NewManager(cfg.JWT.Secret, cfg.JWT.Expiration, cfg.JWT.GuestExpiration)
+	jwtManager.SetRevocationChecker(userRepo)
+
+	// Журнал событий безопасности
+	auditRepo := audit.NewRepository(db)
+	auditService := audit.NewService(auditRepo)
 
 	// Инициализируем schedule репозиторий и сервис
 	scheduleRepo := schedule.NewRepository(db)
-	scheduleService := schedule.NewService(scheduleRepo)
+	scheduleRepo.UseReplicas(dbRouter)
+	scheduleService := schedule.NewService(scheduleRepo, loc)
+
+	// Сброс кэшей расписания группы на дату после записи: на своем экземпляре сразу,
+	// на остальных - через Redis pub/sub
+	var invalidationRedis *cache.Redis
+	if cfg.ScheduleCache.Redis && cfg.Redis.Addr != "" {
+		invalidationRedis = cache.NewRedis(cfg.Redis.Addr, time.Second)
+		defer invalidationRedis.Close()
+	}
+	cacheInvalidator := cache.NewInvalidator(invalidationRedis, cfg.ScheduleCache.Channel)
+	// Сброс всего расписания колледжа рассылает и migrator import-bells: расписания
+	// звонков перечитываются из базы
+	cacheInvalidator.Subscribe(func(inv cache.Invalidation) {
+		if inv.Group != "" || inv.Date != "" || inv.UserID != uuid.Nil {
+			return
+		}
+		reloadCtx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+		defer cancel()
+		timings, err := bellRepo.LoadAll(reloadCtx)
+		if err != nil {
+			log.Printf("Ошибка обновления расписания звонков: %v", err)
+			return
+		}
+		bells.SetAll(timings)
+	})
+	scheduleRepo.SetInvalidator(cacheInvalidator)
+	// Измененные пользователи удаляются из кэша в памяти всех экземпляров через ту же шину
+	if userCache != nil {
+		userCache.UseInvalidator(cacheInvalidator)
+	}
+	if cfg.ScheduleCache.Enabled {
+		scheduleService.UseLocalCache(cfg.ScheduleCache.Size, cfg.ScheduleCache.TTL, cacheInvalidator)
+		log.Printf("Кэш расписания в памяти включен (сброс через Redis: %t)", invalidationRedis != nil)
+	}
 
 	// Инициализируем notification репозиторий и сервис
 	notificationRepo := notifications.NewRepository(db)
-	notificationService := notifications.NewService(userRepo, scheduleRepo, notificationRepo)
+	notificationRepo.UseReplicas(dbRouter)
+	notificationService := notifications.NewService(userRepo, scheduleRepo, notificationRepo, loc)
+
+	// Факультативы: занятия курсов по выбору в личном расписании студентов
+	electiveService := electives.NewService(electives.NewRepository(db), loc)
+	notificationService.UseElectives(electiveService)
+	if mailer != nil {
+		notificationService.SetMailer(mailer)
+	}
+
+	// Сигналы о новых уведомлениях для клиентов без push (long polling)
+	pollHub := realtime.NewHub()
+	notificationService.SetHub(pollHub, cfg.Poll.RecheckInterval)
+
+	// Личные заметки к парам
+	noteService := notes.NewService(notes.NewRepository(db), loc)
+	buildingService := buildings.NewService(buildings.Config{
+		TravelMinutes: cfg.College.TravelMinutes,
+	}, buildings.NewRepository(db))
+
+	// Консультации преподавателей с напоминаниями подписанным студентам
+	consultationService := consultations.NewService(consultations.Config{
+		ReminderBefore: cfg.Consultations.ReminderBefore,
+		CheckInterval:  cfg.Consultations.CheckInterval,
+	}, consultations.NewRepository(db), scheduleService)
+	consultationService.SetNotifier(notificationService)
+
+	// Личное расписание и ближайшее занятие пользователя
+	personalService := personal.NewService(userService, scheduleService, electiveService, loc)
+	personalService.SetConsultations(consultationService)
+
+	// Табло расписания в коридорах
+	kioskService := kiosk.NewService(kiosk.Config{
+		CacheTTL:  cfg.Kiosk.CacheTTL,
+		RateLimit: cfg.Kiosk.RateLimit,
+	}, kiosk.NewRepository(db), scheduleRepo, buildingService, loc)
+	cacheInvalidator.Subscribe(kioskService.Invalidate)
+
+	// Жалобы пользователей на ошибки в расписании
+	feedbackService := feedback.NewService(feedback.Config{
+		RescrapeThreshold: cfg.Feedback.RescrapeThreshold,
+		RescrapeCooldown:  cfg.Feedback.RescrapeCooldown,
+	}, feedback.NewRepository(db), scheduleService, loc)
+
+	// Переход на новый учебный год
+	rolloverService := rollover.NewService(rollover.NewRepository(db), auditRepo, userRepo, txManager)
 
 	// Инициализируем change detection сервис
-	changeService := changes.NewService(scheduleRepo)
+	changeService := changes.NewService(scheduleRepo, txManager, changes.Config{
+		BatchSize: cfg.Changes.ApplyBatchSize,
+	})
+
+	// Колледжи, обслуживаемые развертыванием: у каждого свой источник расписания
+	collegeRepo := tenant.NewRepository(db)
+	collegeRegistry := tenant.NewRegistry(collegeRepo)
+	colleges, err := collegeRepo.ListColleges(ctx)
+	if err != nil {
+		log.Fatalf("Ошибка загрузки списка колледжей: %v", err)
+	}
 
-	// Создание scraper сервиса
+	// Создание scraper сервиса (настройки по умолчанию для всех колледжей)
 	scraperConfig := scraper.Config{
 		BaseURL:          cfg.Scraper.BaseURL,
 		Timeout:          cfg.Scraper.Timeout,
 		MainScheduleGIDs: cfg.Scraper.MainScheduleGIDs, // Передаем список gid
 		ChangesGID:       cfg.Scraper.ChangesGID,       // Передаем gid изменений
+		Location:         loc,
+		ModerateChanges:  cfg.Changes.Moderated,
+		SnapshotName:     cfg.Scraper.SnapshotName,
+		Breaker: breaker.Config{
+			FailureThreshold: cfg.Scraper.BreakerThreshold,
+			OpenTimeout:      cfg.Scraper.BreakerOpenTimeout,
+		},
+	}
+
+	// Режим фикстур: вместо сайта колледжа и Google Таблиц парсер читает локальные
+	// файлы и имитирует настроенные сбои
+	if cfg.Scraper.Fixtures.Dir != "" {
+		failures := make([]fixture.Failure, 0, len(cfg.Scraper.Fixtures.Failures))
+		for _, failure := range cfg.Scraper.Fixtures.Failures {
+			failures = append(failures, fixture.Failure{
+				Target:      failure.Target,
+				Mode:        failure.Mode,
+				Probability: failure.Probability,
+			})
+		}
+		transport, err := fixture.NewTransport(fixture.Config{Dir: cfg.Scraper.Fixtures.Dir, Failures: failures}, loc)
+		if err != nil {
+			log.Fatalf("Ошибка настройки фикстур парсера: %v", err)
+		}
+		scraperConfig.Transport = transport
+		log.Printf("Парсер работает с фикстурами из %s (имитируемых сбоев: %d)", cfg.Scraper.Fixtures.Dir, len(failures))
+	}
+
+	// Отдельный scraper для каждого активного колледжа
+	var scrapers []collegeScraper
+	for _, college := range colleges {
+		if !college.IsActive {
+			continue
+		}
+		service := scraper.NewService(collegeScraperConfig(scraperConfig, college),
+			scheduleRepo, notificationService, changeService)
+		scrapers = append(scrapers, collegeScraper{college: college, service: service})
+		log.Printf("Парсинг расписания колледжа %s (%s)", college.Name, college.Slug)
+	}
+
+	// Распределенные блокировки: при нескольких экземплярах API парсинг, применение
+	// изменений и обслуживание в каждом цикле выполняет только один экземпляр
+	locker := lock.NewLocker(db)
+	for _, cs := range scrapers {
+		cs.service.SetLocker(locker)
+		feedbackService.AddScraper(cs.college.ID, cs.service)
+	}
+	changeService.SetLocker(locker)
+	log.Printf("Экземпляр API: %s", locker.Instance())
+
+	// Очередь фоновых задач: пересборка кэша расписания и рассылка уведомлений
+	// выполняются воркерами с повторными попытками, а не в цикле парсинга
+	jobQueue := jobs.NewQueue(jobs.Config{
+		Workers:      cfg.Jobs.Workers,
+		PollInterval: cfg.Jobs.PollInterval,
+		MaxAttempts:  cfg.Jobs.MaxAttempts,
+		Timeout:      cfg.Jobs.Timeout,
+		RetryBackoff: cfg.Jobs.RetryBackoff,
+		Retention:    cfg.Jobs.Retention,
+	}, jobs.NewRepository(db))
+	for _, cs := range scrapers {
+		cs.service.SetJobQueue(jobQueue)
+	}
+	notificationService.SetJobQueue(jobQueue)
+	if cfg.QuietHours.Enabled {
+		err := notificationService.SetQuietHours(notifications.QuietHours{
+			Start:      cfg.QuietHours.Start,
+			End:        cfg.QuietHours.End,
+			UrgentLead: cfg.QuietHours.UrgentLead,
+		})
+		if err != nil {
+			log.Fatalf("Ошибка настройки тихих часов: %v", err)
+		}
+		log.Printf("Тихие часы push-уведомлений: %s-%s", cfg.QuietHours.Start, cfg.QuietHours.End)
 	}
 
-	scraperService := scraper.NewService(scraperConfig, scheduleRepo, notificationService, changeService)
+	// Transactional outbox: события о снапшотах и изменениях сохраняются вместе с данными,
+	// relay передает их в очередь уведомлений и пересборки кэша
+	outboxRepo := outbox.NewRepository(db)
+	eventRelay := outbox.NewRelay(outbox.Config{
+		PollInterval: cfg.Outbox.PollInterval,
+		BatchSize:    cfg.Outbox.BatchSize,
+		RetryBackoff: cfg.Outbox.RetryBackoff,
+		Retention:    cfg.Outbox.Retention,
+	}, outboxRepo, txManager)
+	for i, cs := range scrapers {
+		// На события подписывается один scraper: подписчики работают с колледжем события
+		relay := eventRelay
+		if i > 0 {
+			relay = nil
+		}
+		cs.service.SetOutbox(outboxRepo, relay, txManager)
+	}
+	userService.SetOutbox(outboxRepo, txManager)
+
+	// Публикация доменных событий в брокер для сервисов вне ядра (аналитика, боты)
+	switch cfg.Broker.Backend {
+	case "":
+	case "nats":
+		eventRelay.Forward(nats.NewPublisher(nats.Config{
+			URL:       cfg.Broker.URL,
+			Username:  cfg.Broker.Username,
+			Password:  cfg.Broker.Password,
+			Token:     cfg.Broker.Token,
+			Name:      "student-schedule-api",
+			JetStream: cfg.Broker.JetStream,
+			Timeout:   cfg.Broker.Timeout,
+		}), cfg.Broker.SubjectPrefix)
+		log.Printf("Доменные события публикуются в NATS: %s", cfg.Broker.URL)
+	default:
+		log.Fatalf("Неизвестный брокер сообщений: %s", cfg.Broker.Backend)
+	}
+
+	// Флаги функций: рискованное поведение включается без повторного развертывания
+	flagDefaults := map[string]bool{features.FlagModeratedChanges: cfg.Changes.Moderated}
+	for name, enabled := range cfg.Features.Defaults {
+		flagDefaults[name] = enabled
+	}
+	featureFlags := features.NewClient(features.NewRepository(db), flagDefaults)
+	if err := featureFlags.Refresh(context.Background()); err != nil {
+		log.Printf("Ошибка загрузки флагов функций, используются значения по умолчанию: %v", err)
+	}
+	for _, cs := range scrapers {
+		cs.service.SetFeatureFlags(featureFlags)
+	}
+
+	jobsCtx, jobsCancel := context.WithCancel(context.Background())
+	go jobQueue.Start(jobsCtx)
+	go eventRelay.Start(jobsCtx)
+	go cacheInvalidator.Run(jobsCtx)
+	go featureFlags.Start(jobsCtx, cfg.Features.RefreshInterval)
+	consultationService.SetColleges(collegeRegistry)
+	go consultationService.Start(jobsCtx)
+
+	// Задачи обслуживания (архивация старых снапшотов и расписания прошедших семестров,
+	// секции актуального расписания)
+	maintenanceService := maintenance.NewService(maintenance.Config{
+		Interval:             cfg.Retention.Interval,
+		SnapshotsKeep:        cfg.Retention.SnapshotsKeep,
+		PartitionMonthsAhead: cfg.Retention.PartitionMonthsAhead,
+		ArchiveSchedule:      cfg.Retention.ArchiveSchedule,
+		SemesterStarts:       cfg.Retention.SemesterStarts,
+	}, scheduleService)
+	maintenanceService.SetLocker(locker)
+	maintenanceService.SetColleges(collegeRegistry)
+
+	// Хранилище файлов: аватары и вложения пользователей, исходные таблицы парсинга, PDF
+	var fileStorage storage.Storage
+	var filesHTTPServer *http.Server
+	switch cfg.Storage.Backend {
+	case "":
+		log.Println("Хранилище файлов не настроено, File Service отключен")
+	case "local":
+		localStorage, err := storage.NewLocal(cfg.Storage.LocalDir, cfg.Storage.PublicURL, cfg.Storage.SigningSecret)
+		if err != nil {
+			log.Fatalf("Ошибка инициализации хранилища файлов: %v", err)
+		}
+		fileStorage = localStorage
+
+		// HTTP-сервер для скачивания файлов по подписанным ссылкам
+		mux := http.NewServeMux()
+		mux.Handle(storage.FilesPath, localStorage.Handler())
+		filesHTTPServer = &http.Server{
+			Addr:              fmt.Sprintf(":%d", cfg.Storage.HTTPPort),
+			Handler:           mux,
+			ReadHeaderTimeout: 10 * time.Second,
+		}
+		go func() {
+			log.Printf("Раздача файлов по подписанным ссылкам на порту %d", cfg.Storage.HTTPPort)
+			if err := filesHTTPServer.ListenAndServe(); err != nil && err != http.ErrServerClosed {
+				log.Fatalf("Ошибка запуска HTTP сервера файлов: %v", err)
+			}
+		}()
+	case "s3":
+		// Файлы скачиваются из хранилища напрямую по подписанным ссылкам S3
+		s3Storage, err := storage.NewS3(storage.S3Config{
+			Endpoint:        cfg.Storage.S3.Endpoint,
+			PublicEndpoint:  cfg.Storage.S3.PublicEndpoint,
+			Region:          cfg.Storage.S3.Region,
+			Bucket:          cfg.Storage.S3.Bucket,
+			AccessKeyID:     cfg.Storage.S3.AccessKeyID,
+			SecretAccessKey: cfg.Storage.S3.SecretAccessKey,
+			PathStyle:       cfg.Storage.S3.PathStyle,
+			Timeout:         cfg.Storage.S3.Timeout,
+		})
+		if err != nil {
+			log.Fatalf("Ошибка инициализации хранилища файлов: %v", err)
+		}
+		fileStorage = s3Storage
+		log.Printf("Хранилище файлов S3: %s, бакет %s", cfg.Storage.S3.Endpoint, cfg.Storage.S3.Bucket)
+	default:
+		log.Fatalf("Неизвестный бэкенд хранилища файлов: %s", cfg.Storage.Backend)
+	}
+
+	var fileService *files.Service
+	if fileStorage != nil {
+		fileService = files.NewService(files.Config{
+			MaxAvatarSize:     cfg.Storage.MaxAvatarSize,
+			MaxAttachmentSize: cfg.Storage.MaxAttachmentSize,
+			URLTTL:            cfg.Storage.URLTTL,
+		}, files.NewRepository(db), fileStorage)
+
+		lifecycle := make([]storage.LifecycleRule, 0, len(cfg.Storage.Lifecycle))
+		for _, rule := range cfg.Storage.Lifecycle {
+			lifecycle = append(lifecycle, storage.LifecycleRule{Prefix: rule.Prefix, MaxAge: rule.MaxAge})
+		}
+		maintenanceService.SetStorage(fileStorage, lifecycle)
+		for _, cs := range scrapers {
+			cs.service.SetArtifactStorage(fileStorage)
+		}
+	}
+
+	// Метрики Prometheus: состояние circuit breaker'ов внешних зависимостей
+	var metricsHTTPServer *http.Server
+	if cfg.Metrics.Port != 0 {
+		registry := metrics.NewRegistry()
+		var breakers []*breaker.Breaker
+		for _, cs := range scrapers {
+			breakers = append(breakers, cs.service.Breakers()...)
+		}
+		breaker.RegisterMetrics(registry, breakers...)
+
+		mux := http.NewServeMux()
+		mux.Handle("/metrics", registry.Handler())
+		metricsHTTPServer = &http.Server{
+			Addr:              fmt.Sprintf(":%d", cfg.Metrics.Port),
+			Handler:           mux,
+			ReadHeaderTimeout: 10 * time.Second,
+		}
+		go func() {
+			log.Printf("Метрики доступны на порту %d (/metrics)", cfg.Metrics.Port)
+			if err := metricsHTTPServer.ListenAndServe(); err != nil && err != http.ErrServerClosed {
+				log.Fatalf("Ошибка запуска HTTP сервера метрик: %v", err)
+			}
+		}()
+	}
+
+	// Защита регистрации и входа от ботов
+	captchaVerifier, err := captcha.New(captcha.Config{
+		Provider:   cfg.Captcha.Provider,
+		SiteKey:    cfg.Captcha.SiteKey,
+		Secret:     cfg.Captcha.Secret,
+		VerifyURL:  cfg.Captcha.VerifyURL,
+		Timeout:    cfg.Captcha.Timeout,
+		Difficulty: cfg.Captcha.Difficulty,
+		TTL:        cfg.Captcha.TTL,
+	})
+	if err != nil {
+		log.Fatalf("Ошибка настройки CAPTCHA: %v", err)
+	}
+	if captchaVerifier != nil {
+		log.Printf("Проверка CAPTCHA при регистрации и входе включена: %s", captchaVerifier.Provider())
+	}
 
 	// Инициализируем gRPC сервер
-	grpcServer := grpc.NewServer(userService, jwtManager)
+	grpcServer := grpc.NewServer(userService, jwtManager, auditService, captchaVerifier)
+	grpcServer.SetColleges(collegeRegistry)
+	grpcServer.SetNewDeviceAlerter(notificationService)
+	grpcServer.SetTransport(grpc.TransportOptions{
+		MaxRecvMsgSize:        cfg.Server.MaxRecvMsgSize,
+		MaxSendMsgSize:        cfg.Server.MaxSendMsgSize,
+		CompressMinSize:       cfg.Server.CompressMinSize,
+		KeepaliveTime:         cfg.Server.Keepalive.Time,
+		KeepaliveTimeout:      cfg.Server.Keepalive.Timeout,
+		MaxConnectionIdle:     cfg.Server.Keepalive.MaxConnectionIdle,
+		MinClientPingInterval: cfg.Server.Keepalive.MinClientPingInterval,
+	})
+
+	// Подписка на личное расписание в календарных приложениях по подписанным ссылкам
+	var calendarFeed *calendar.Feed
+	var calendarHTTPServer *http.Server
+	if cfg.Calendar.HTTPPort != 0 {
+		calendarFeed = calendar.NewFeed(calendar.Config{
+			PublicURL:  cfg.Calendar.PublicURL,
+			Secret:     cfg.Calendar.SigningSecret,
+			PastDays:   cfg.Calendar.PastDays,
+			FutureDays: cfg.Calendar.FutureDays,
+		}, scheduleService, userService)
+		calendarFeed.UseElectives(electiveService)
+
+		calendarMux := http.NewServeMux()
+		calendarMux.Handle(calendar.FeedPath, calendarFeed.Handler())
+		calendarHTTPServer = &http.Server{
+			Addr:              fmt.Sprintf(":%d", cfg.Calendar.HTTPPort),
+			Handler:           calendarMux,
+			ReadHeaderTimeout: 10 * time.Second,
+		}
+		go func() {
+			log.Printf("Раздача календарей запущена на порту %d", cfg.Calendar.HTTPPort)
+			if err := calendarHTTPServer.ListenAndServe(); err != nil && err != http.ErrServerClosed {
+				log.Fatalf("Ошибка запуска HTTP сервера календарей: %v", err)
+			}
+		}()
+	}
+
+	// Печатная версия расписания (PDF); без шрифта с кириллицей отключена
+	var timetableRenderer *timetable.Renderer
+	if font, err := pdf.OpenFont(cfg.PDF.FontPath); err != nil {
+		log.Printf("Печатная версия расписания отключена: %v", err)
+	} else {
+		timetableRenderer = timetable.NewRenderer(font, scheduleService.Location())
+	}
+
+	// Административные методы доступны только администраторам,
+	// гостевым токенам - только просмотр расписания своей группы;
+	// служебные поля ответов скрываются от ролей, которым они не нужны
+	authMiddleware := auth.NewMiddleware(jwtManager, userRepo)
+	adminMethods := append(append([]string{}, schedulegrpc.AdminMethods...), grpc.AdminMethods...)
+	restrictedFields := append(append([]auth.RestrictedField{}, schedulegrpc.RestrictedFields...), grpc.RestrictedFields...)
 
 	// Запускаем gRPC сервер в отдельной горутине
 	go func() {
-		if err := grpcServer.Start(cfg.Server.Port, scheduleService, userService); err != nil {
+		scheduleDeps := schedulegrpc.Dependencies{
+			ScheduleService:     scheduleService,
+			UserService:         userService,
+			ChangeService:       changeService,
+			NotificationService: notificationService,
+			MaintenanceService:  maintenanceService,
+			JobQueue:            jobQueue,
+			FeatureFlags:        featureFlags,
+			CalendarFeed:        calendarFeed,
+			TimetableRenderer:   timetableRenderer,
+			ReportStorage:       fileStorage,
+			ReportURLTTL:        cfg.Storage.URLTTL,
+			ElectiveService:     electiveService,
+			NoteService:         noteService,
+			BuildingService:     buildingService,
+			RolloverService:     rolloverService,
+			ConsultationService: consultationService,
+			KioskService:        kioskService,
+			PersonalService:     personalService,
+			FeedbackService:     feedbackService,
+			PollTimeout:         cfg.Poll.MaxTimeout,
+		}
+		fileDeps := filesgrpc.Dependencies{
+			FileService: fileService,
+		}
+		notificationDeps := notificationsgrpc.Dependencies{
+			NotificationService: notificationService,
+		}
+		if err := grpcServer.Start(cfg.Server.Port, scheduleDeps, fileDeps, notificationDeps,
+			authMiddleware.FieldFilterInterceptor(restrictedFields...),
+			authMiddleware.PasswordChangeInterceptor(grpc.PasswordChangeMethods...),
+			authMiddleware.GuestInterceptor(schedulegrpc.GuestMethods...),
+			authMiddleware.AdminInterceptor(adminMethods...),
+			authMiddleware.TeacherGroupInterceptor(
+				schedulegrpc.NewGroupAccess(userService, scheduleService), schedulegrpc.TeacherGroupMethods...)); err != nil {
 			log.Fatalf("Ошибка запуска gRPC сервера: %v", err)
 		}
 	}()
 
+	// REST-фасад gRPC API с описанием OpenAPI для мобильного и веб-клиентов
+	var gatewayHTTPServer *http.Server
+	if cfg.Gateway.Port != 0 {
+		restGateway, err := gateway.New(gateway.Config{
+			GRPCAddr:        fmt.Sprintf("localhost:%d", cfg.Server.Port),
+			MaxRequestSize:  cfg.Server.MaxRecvMsgSize,
+			MaxResponseSize: cfg.Server.MaxSendMsgSize,
+		}, userspb.File_users_proto, schedulepb.File_schedule_proto, filespb.File_files_proto,
+			notificationspb.File_notifications_proto)
+		if err != nil {
+			log.Fatalf("Ошибка инициализации REST-фасада: %v", err)
+		}
+		defer restGateway.Close()
+
+		// Публичная страница статуса: свежесть данных расписания без аутентификации
+		statusPage := status.NewPage(status.Config{
+			MainScheduleJob: scraper.MainScheduleJob,
+			ChangesJob:      scraper.ChangesJob,
+		}, locker, scheduleRepo, collegeRegistry)
+
+		gatewayMux := http.NewServeMux()
+		gatewayMux.Handle("/", restGateway.Handler())
+		gatewayMux.Handle(status.Path, statusPage.Handler())
+		gatewayMux.Handle(kiosk.Path, kioskService.Handler())
+		gatewayHTTPServer = &http.Server{
+			Addr:              fmt.Sprintf(":%d", cfg.Gateway.Port),
+			Handler:           gatewayMux,
+			ReadHeaderTimeout: 10 * time.Second,
+		}
+		go func() {
+			log.Printf("REST-фасад запущен на порту %d (/api/v1, /openapi.json, /docs, /status, /kiosk)", cfg.Gateway.Port)
+			if err := gatewayHTTPServer.ListenAndServe(); err != nil && err != http.ErrServerClosed {
+				log.Fatalf("Ошибка запуска HTTP сервера REST-фасада: %v", err)
+			}
+		}()
+	}
+
+	// Панель администратора: сводка по парсингу, снапшоту, изменениям, рассылке и жалобам
+	var dashboardHTTPServer *http.Server
+	if cfg.Dashboard.Port != 0 {
+		adminDashboard := dashboard.New(dashboard.Config{
+			MainScheduleJob: scraper.MainScheduleJob,
+			ChangesJob:      scraper.ChangesJob,
+			SecureCookie:    cfg.Dashboard.SecureCookie,
+		}, userService, jwtManager, auditService, locker, scheduleRepo, jobQueue, collegeRegistry)
+		for _, cs := range scrapers {
+			adminDashboard.AddScraper(cs.college.ID, cs.service)
+		}
+		adminDashboard.UseErrorReports(feedbackService)
+
+		dashboardMux := http.NewServeMux()
+		dashboardMux.Handle(dashboard.Path, adminDashboard.Handler())
+		dashboardHTTPServer = &http.Server{
+			Addr:              fmt.Sprintf(":%d", cfg.Dashboard.Port),
+			Handler:           dashboardMux,
+			ReadHeaderTimeout: 10 * time.Second,
+		}
+		go func() {
+			log.Printf("Панель администратора запущена на порту %d (%s)", cfg.Dashboard.Port, dashboard.Path)
+			if err := dashboardHTTPServer.ListenAndServe(); err != nil && err != http.ErrServerClosed {
+				log.Fatalf("Ошибка запуска HTTP сервера панели администратора: %v", err)
+			}
+		}()
+	}
+
 	// Немедленный запуск парсинга при старте сервера
 	// В соответствии с ТЗ: "Немедленный запуск парсинга"
 	log.Println("Немедленный запуск парсинга при старте сервера")
@@ -108,19 +761,28 @@ func main() {
 	immediateCtx, immediateCancel := context.WithTimeout(context.Background(), 5*time.Minute)
 	defer immediateCancel()
 
-	// Запускаем немедленный парсинг основного расписания
-	if err := scraperService.ScrapeMainSchedule(immediateCtx); err != nil {
-		log.Printf("Ошибка при немедленном парсинге основного расписания: %v", err)
-	}
+	for _, cs := range scrapers {
+		collegeCtx := tenant.WithCollege(immediateCtx, cs.college.ID)
+
+		// Запускаем немедленный парсинг основного расписания
+		if err := cs.service.ScrapeMainSchedule(collegeCtx); err != nil {
+			log.Printf("Ошибка при немедленном парсинге основного расписания (%s): %v", cs.college.Slug, err)
+		}
 
-	// Запускаем немедленный парсинг изменений в расписании
-	if err := scraperService.ScrapeScheduleChanges(immediateCtx); err != nil {
-		log.Printf("Ошибка при немедленном парсинге изменений в расписании: %v", err)
+		// Запускаем немедленный парсинг изменений в расписании
+		if err := cs.service.ScrapeScheduleChanges(collegeCtx); err != nil {
+			log.Printf("Ошибка при немедленном парсинге изменений в расписании (%s): %v", cs.college.Slug, err)
+		}
 	}
 
 	// Запускаем периодический парсинг в отдельной горутине
 	scraperCtx, scraperCancel := context.WithCancel(context.Background())
-	go scraperService.StartPeriodicScraping(scraperCtx)
+	for _, cs := range scrapers {
+		go cs.service.StartPeriodicScraping(tenant.WithCollege(scraperCtx, cs.college.ID))
+	}
+
+	// Запускаем задачи обслуживания
+	go maintenanceService.Start(scraperCtx)
 
 	log.Printf("gRPC API Gateway запущен на порту %d", cfg.Server.Port)
 	log.Println("Web Scraper Service запущен")
@@ -132,6 +794,32 @@ func main() {
 	log.Println("    - RegisterTeacher")
 	log.Println("    - Login")
 	log.Println("    - GetProfile")
+	log.Println("    - GetCaptchaChallenge")
+	log.Println("    - VerifyTwoFactor")
+	log.Println("    - EnrollTwoFactor / ConfirmTwoFactor / DisableTwoFactor")
+	log.Println("    - ChangePassword")
+	log.Println("    - SetLoginAlerts")
+	log.Println("    - SetFormatPreferences")
+	log.Println("    - RevokeToken")
+	log.Println("    - SetUserRole (admin)")
+	log.Println("    - DeleteUser / RestoreUser (admin)")
+	log.Println("    - ImportStudentRoster (admin)")
+	log.Println("    - ListAuditEvents (admin)")
+	log.Println("    - CreateInvitation / ListInvitations / RevokeInvitation (admin)")
+	log.Println("  ScheduleService:")
+	log.Println("    - PollUpdates")
+	log.Println("    - GetWidgetSummary")
+	log.Println("    - GetNextLesson")
+	log.Println("    - GetBellStatus")
+	log.Println("    - GetTeacherDashboard")
+	log.Println("    - ReportScheduleError")
+	log.Println("    - ListJobs / RetryJob (admin)")
+	log.Println("    - ListFeatureFlags / SetFeatureFlag / ResetFeatureFlag (admin)")
+	log.Println("    - CreateKioskDisplay / ListKioskDisplays / RevokeKioskDisplay (admin)")
+	log.Println("  NotificationService:")
+	log.Println("    - ListNotifications")
+	log.Println("    - GetUnreadCount")
+	log.Println("    - MarkAsRead / MarkAllAsRead")
 
 	// Ожидаем сигнала завершения
 	quit := make(chan os.Signal, 1)
@@ -141,6 +829,73 @@ func main() {
 
 	// Отменяем контекст для scraper сервиса
 	scraperCancel()
+	jobsCancel()
+
+	// Завершаем ожидающие запросы PollUpdates, чтобы они не задерживали остановку
+	pollHub.Close()
+
+	if filesHTTPServer != nil {
+		shutdownCtx, shutdownCancel := context.WithTimeout(context.Background(), 5*time.Second)
+		if err := filesHTTPServer.Shutdown(shutdownCtx); err != nil {
+			log.Printf("Ошибка остановки HTTP сервера файлов: %v", err)
+		}
+		shutdownCancel()
+	}
+
+	if dashboardHTTPServer != nil {
+		shutdownCtx, shutdownCancel := context.WithTimeout(context.Background(), 5*time.Second)
+		if err := dashboardHTTPServer.Shutdown(shutdownCtx); err != nil {
+			log.Printf("Ошибка остановки HTTP сервера панели администратора: %v", err)
+		}
+		shutdownCancel()
+	}
+
+	if gatewayHTTPServer != nil {
+		shutdownCtx, shutdownCancel := context.WithTimeout(context.Background(), 5*time.Second)
+		if err := gatewayHTTPServer.Shutdown(shutdownCtx); err != nil {
+			log.Printf("Ошибка остановки HTTP сервера REST-фасада: %v", err)
+		}
+		shutdownCancel()
+	}
+
+	if calendarHTTPServer != nil {
+		shutdownCtx, shutdownCancel := context.WithTimeout(context.Background(), 5*time.Second)
+		if err := calendarHTTPServer.Shutdown(shutdownCtx); err != nil {
+			log.Printf("Ошибка остановки HTTP сервера календарей: %v", err)
+		}
+		shutdownCancel()
+	}
+
+	if metricsHTTPServer != nil {
+		shutdownCtx, shutdownCancel := context.WithTimeout(context.Background(), 5*time.Second)
+		if err := metricsHTTPServer.Shutdown(shutdownCtx); err != nil {
+			log.Printf("Ошибка остановки HTTP сервера метрик: %v", err)
+		}
+		shutdownCancel()
+	}
 
 	log.Println("Сервер остановлен")
 }
+
+// collegeScraper scraper расписания одного колледжа
+type collegeScraper struct {
+	college tenant.College
+	service *scraper.Service
+}
+
+// collegeScraperConfig возвращает настройки scraper'а колледжа: источник расписания,
+// заданный у колледжа, заменяет источник из конфигурации
+func collegeScraperConfig(base scraper.Config, college tenant.College) scraper.Config {
+	config := base
+	config.College = college.Slug
+	if college.BaseURL != "" {
+		config.BaseURL = college.BaseURL
+	}
+	if len(college.MainScheduleGIDs) > 0 {
+		config.MainScheduleGIDs = college.MainScheduleGIDs
+	}
+	if college.ChangesGID != nil {
+		config.ChangesGID = *college.ChangesGID
+	}
+	return config
+}
@@ -0,0 +1,59 @@
+package main
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+)
+
+// anonymizeQueries заменяют персональные данные детерминированными значениями,
+// вычисленными по ID пользователя: повторная обработка того же дампа дает те же
+// значения, а связи между таблицами сохраняются. ФИО преподавателей не меняются:
+// они опубликованы в самом расписании и нужны для привязки пар к преподавателям.
+var anonymizeQueries = []struct {
+	name  string
+	query string
+}{
+	{"email пользователей", `
+		UPDATE users
+		SET email = 'user-' || left(md5(id::text), 12) || '@example.invalid'`},
+	{"ФИО и номера студентов", `
+		UPDATE students
+		SET full_name = 'Студент ' || upper(left(md5('name:' || user_id::text), 8)),
+		    student_number = CASE WHEN student_number IS NULL THEN NULL
+		                          ELSE left(md5('number:' || user_id::text), 10) END`},
+	{"журнал безопасности", `
+		UPDATE audit_events a
+		SET email = CASE
+		        WHEN a.email IS NULL THEN NULL
+		        WHEN a.user_id IS NOT NULL THEN 'user-' || left(md5(a.user_id::text), 12) || '@example.invalid'
+		        ELSE 'unknown-' || left(md5(a.email), 12) || '@example.invalid' END,
+		    ip = CASE WHEN a.ip IS NULL THEN NULL ELSE '192.0.2.' || (abs(hashtext(a.ip)) % 254 + 1) END,
+		    user_agent = NULL`},
+	{"секреты двухфакторной аутентификации", `DELETE FROM user_two_factor`},
+	{"резервные коды двухфакторной аутентификации", `DELETE FROM two_factor_recovery_codes`},
+}
+
+// anonymize заменяет персональные данные в базе, чтобы дамп рабочей базы можно
+// было загрузить на тестовый стенд. Все изменения выполняются в одной транзакции.
+func anonymize(ctx context.Context, db *sql.DB) error {
+	tx, err := db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("ошибка начала транзакции: %w", err)
+	}
+	defer func() { _ = tx.Rollback() }()
+
+	for _, q := range anonymizeQueries {
+		result, err := tx.ExecContext(ctx, q.query)
+		if err != nil {
+			return fmt.Errorf("ошибка обезличивания (%s): %w", q.name, err)
+		}
+		affected, _ := result.RowsAffected()
+		fmt.Printf("  %s: %d строк\n", q.name, affected)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("ошибка коммита транзакции: %w", err)
+	}
+	return nil
+}
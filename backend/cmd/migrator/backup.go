@@ -0,0 +1,96 @@
+package main
+
+import (
+	"bufio"
+	"context"
+	"flag"
+	"fmt"
+	"os"
+	"os/exec"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/Ultrahd-dev/student-schedule-app/backend/internal/config"
+)
+
+// backup сохраняет резервную копию базы из конфигурации через pg_dump
+// в custom-формате, который восстанавливается командой restore
+func backup(ctx context.Context, db config.DatabaseConfig, dbName string, args []string) error {
+	fs := flag.NewFlagSet("backup", flag.ExitOnError)
+	output := fs.String("o", "", "файл резервной копии (по умолчанию backup_БАЗА_ДАТА.dump)")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	if *output == "" {
+		*output = fmt.Sprintf("backup_%s_%s.dump", dbName, time.Now().Format("2006-01-02_15-04-05"))
+	}
+
+	fmt.Printf("Резервное копирование базы %s в %s\n", dbName, *output)
+	return runPostgresTool(ctx, db, "pg_dump", "--format=custom", "--no-owner", "--file="+*output)
+}
+
+// restore восстанавливает базу из конфигурации из резервной копии backup через
+// pg_restore. Существующие объекты пересоздаются, все выполняется в одной транзакции.
+func restore(ctx context.Context, db config.DatabaseConfig, dbName string, args []string) error {
+	fs := flag.NewFlagSet("restore", flag.ExitOnError)
+	yes := fs.Bool("yes", false, "не запрашивать подтверждение")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if fs.NArg() < 1 {
+		return fmt.Errorf("необходимо указать файл резервной копии")
+	}
+	file := fs.Arg(0)
+	if _, err := os.Stat(file); err != nil {
+		return fmt.Errorf("ошибка открытия резервной копии: %w", err)
+	}
+
+	// Восстановление перезаписывает данные, поэтому имя базы нужно подтвердить
+	if !*yes {
+		fmt.Printf("Данные базы %s будут заменены данными из %s. Для подтверждения введите имя базы: ", dbName, file)
+		answer, _ := bufio.NewReader(os.Stdin).ReadString('\n')
+		if strings.TrimSpace(answer) != dbName {
+			return fmt.Errorf("восстановление отменено")
+		}
+	}
+
+	fmt.Printf("Восстановление базы %s из %s\n", dbName, file)
+	return runPostgresTool(ctx, db, "pg_restore",
+		"--clean", "--if-exists", "--no-owner", "--single-transaction", "--exit-on-error", file)
+}
+
+// runPostgresTool запускает утилиту PostgreSQL с параметрами подключения из конфигурации.
+// Пароль из конфигурации передается через окружение, чтобы не попасть в список процессов.
+func runPostgresTool(ctx context.Context, db config.DatabaseConfig, name string, args ...string) error {
+	path, err := exec.LookPath(name)
+	if err != nil {
+		return fmt.Errorf("%s не найден, установите клиент PostgreSQL: %w", name, err)
+	}
+
+	// Строка подключения из --dsn передается утилите целиком
+	if db.DSN != "" {
+		args = append([]string{"--dbname=" + db.DSN}, args...)
+	} else {
+		args = append([]string{"--dbname=" + db.DBName}, args...)
+	}
+
+	cmd := exec.CommandContext(ctx, path, args...)
+	cmd.Stdout = os.Stdout
+	cmd.Stderr = os.Stderr
+	cmd.Env = os.Environ()
+	if db.DSN == "" {
+		cmd.Env = append(cmd.Env,
+			"PGHOST="+db.Host,
+			"PGPORT="+strconv.Itoa(db.Port),
+			"PGUSER="+db.User,
+			"PGPASSWORD="+db.Password,
+			"PGSSLMODE="+db.SSLMode,
+		)
+	}
+	if err := cmd.Run(); err != nil {
+		return fmt.Errorf("ошибка выполнения %s: %w", name, err)
+	}
+	return nil
+}
@@ -0,0 +1,206 @@
+package main
+
+import (
+	"context"
+	"database/sql"
+	"encoding/csv"
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/Ultrahd-dev/student-schedule-app/backend/internal/bells"
+	"github.com/Ultrahd-dev/student-schedule-app/backend/internal/cache"
+	"github.com/Ultrahd-dev/student-schedule-app/backend/internal/config"
+	"github.com/Ultrahd-dev/student-schedule-app/backend/internal/tenant"
+	"gopkg.in/yaml.v2"
+)
+
+// weekdaysKey название варианта расписания звонков для всех будних дней;
+// варианты для отдельных дней имеют приоритет над ним
+const weekdaysKey = "будни"
+
+// bellRow строка файла расписания звонков
+type bellRow struct {
+	Day    string
+	Number int
+	Start  string
+	End    string
+}
+
+// importBells загружает расписание звонков из CSV или YAML файла, проверяет его
+// и заменяет им расписание звонков колледжа (--college) в базе. Если настроена
+// рассылка сброса кэшей через Redis (schedule_cache.redis), экземпляры API
+// перечитывают расписание звонков сразу - тогда возвращается true; иначе оно
+// применяется при их перезапуске.
+//
+// CSV: строки "день,номер,начало,конец" (строка заголовка необязательна).
+// YAML: словарь "день: [{number, start, end}, ...]".
+// День - название дня недели ("Понедельник", ...) или "Будни" (понедельник-пятница).
+func importBells(ctx context.Context, db *sql.DB, cfg *config.Config, args []string) (bool, error) {
+	fs := flag.NewFlagSet("import-bells", flag.ExitOnError)
+	college := fs.String("college", "", "код колледжа (по умолчанию колледж по умолчанию)")
+	if err := fs.Parse(args); err != nil {
+		return false, err
+	}
+	if fs.NArg() != 1 {
+		return false, fmt.Errorf("необходимо указать CSV или YAML файл с расписанием звонков")
+	}
+	filename := fs.Arg(0)
+
+	if *college != "" {
+		collegeID, err := tenant.NewRegistry(tenant.NewRepository(db)).CollegeIDBySlug(ctx, *college)
+		if err != nil {
+			return false, fmt.Errorf("ошибка поиска колледжа: %w", err)
+		}
+		ctx = tenant.WithCollege(ctx, collegeID)
+	}
+
+	var rows []bellRow
+	var err error
+	switch strings.ToLower(filepath.Ext(filename)) {
+	case ".csv":
+		rows, err = readBellsCSV(filename)
+	case ".yaml", ".yml":
+		rows, err = readBellsYAML(filename)
+	default:
+		return false, fmt.Errorf("неподдерживаемый формат файла %s, ожидается .csv или .yaml", filename)
+	}
+	if err != nil {
+		return false, err
+	}
+
+	timings, err := groupBells(rows)
+	if err != nil {
+		return false, err
+	}
+
+	days := make([]time.Weekday, 0, len(timings))
+	for day := range timings {
+		days = append(days, day)
+	}
+	sort.Slice(days, func(i, j int) bool { return days[i] < days[j] })
+	for _, day := range days {
+		if err := bells.Validate(timings[day]); err != nil {
+			return false, fmt.Errorf("%s: %w", bells.DayName(day), err)
+		}
+		first, last := timings[day][0], timings[day][len(timings[day])-1]
+		fmt.Printf("  %s: %d пар, %s-%s\n", bells.DayName(day), len(timings[day]), first.TimeStart, last.TimeEnd)
+	}
+
+	if err := bells.NewRepository(db).Replace(ctx, timings); err != nil {
+		return false, err
+	}
+
+	// Сброс всего расписания колледжа: экземпляры API перечитывают расписания звонков
+	if !cfg.ScheduleCache.Redis || cfg.Redis.Addr == "" {
+		return false, nil
+	}
+	redis := cache.NewRedis(cfg.Redis.Addr, time.Second)
+	defer redis.Close()
+	cache.NewInvalidator(redis, cfg.ScheduleCache.Channel).Publish(ctx, cache.Invalidation{CollegeID: tenant.CollegeID(ctx)})
+	return true, nil
+}
+
+// groupBells раскладывает строки файла по дням недели. Вариант "будни"
+// применяется к дням понедельник-пятница, для которых нет своего варианта.
+func groupBells(rows []bellRow) (map[time.Weekday][]bells.LessonTiming, error) {
+	if len(rows) == 0 {
+		return nil, fmt.Errorf("файл не содержит ни одной пары")
+	}
+
+	timings := make(map[time.Weekday][]bells.LessonTiming)
+	var weekdays []bells.LessonTiming
+	for _, row := range rows {
+		timing := bells.LessonTiming{Number: row.Number, TimeStart: row.Start, TimeEnd: row.End}
+		if strings.EqualFold(row.Day, weekdaysKey) {
+			weekdays = append(weekdays, timing)
+			continue
+		}
+
+		day, ok := parseBellDay(row.Day)
+		if !ok {
+			return nil, fmt.Errorf("неизвестный день недели %q", row.Day)
+		}
+		timings[day] = append(timings[day], timing)
+	}
+
+	if len(weekdays) > 0 {
+		for day := time.Monday; day <= time.Friday; day++ {
+			if _, ok := timings[day]; !ok {
+				timings[day] = append([]bells.LessonTiming(nil), weekdays...)
+			}
+		}
+	}
+	return timings, nil
+}
+
+// parseBellDay возвращает день недели по названию без учета регистра
+func parseBellDay(name string) (time.Weekday, bool) {
+	name = strings.TrimSpace(name)
+	for day := time.Sunday; day <= time.Saturday; day++ {
+		if strings.EqualFold(bells.DayName(day), name) {
+			return day, true
+		}
+	}
+	return time.Sunday, false
+}
+
+// readBellsCSV читает строки расписания звонков из CSV файла
+func readBellsCSV(filename string) ([]bellRow, error) {
+	file, err := os.Open(filename)
+	if err != nil {
+		return nil, fmt.Errorf("ошибка открытия файла: %w", err)
+	}
+	defer file.Close()
+
+	reader := csv.NewReader(file)
+	reader.FieldsPerRecord = 4
+	reader.TrimLeadingSpace = true
+	records, err := reader.ReadAll()
+	if err != nil {
+		return nil, fmt.Errorf("ошибка чтения CSV из файла: %w", err)
+	}
+
+	var rows []bellRow
+	for i, record := range records {
+		number, err := strconv.Atoi(strings.TrimSpace(record[1]))
+		if err != nil {
+			if i == 0 {
+				continue // Строка заголовка
+			}
+			return nil, fmt.Errorf("строка %d: некорректный номер пары %q", i+1, record[1])
+		}
+		rows = append(rows, bellRow{Day: record[0], Number: number, Start: record[2], End: record[3]})
+	}
+	return rows, nil
+}
+
+// readBellsYAML читает строки расписания звонков из YAML файла
+func readBellsYAML(filename string) ([]bellRow, error) {
+	data, err := os.ReadFile(filename)
+	if err != nil {
+		return nil, fmt.Errorf("ошибка чтения файла: %w", err)
+	}
+
+	var days map[string][]struct {
+		Number int    `yaml:"number"`
+		Start  string `yaml:"start"`
+		End    string `yaml:"end"`
+	}
+	if err := yaml.Unmarshal(data, &days); err != nil {
+		return nil, fmt.Errorf("ошибка разбора YAML: %w", err)
+	}
+
+	var rows []bellRow
+	for day, lessons := range days {
+		for _, lesson := range lessons {
+			rows = append(rows, bellRow{Day: day, Number: lesson.Number, Start: lesson.Start, End: lesson.End})
+		}
+	}
+	return rows, nil
+}
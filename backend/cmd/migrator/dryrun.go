@@ -0,0 +1,98 @@
+package main
+
+import (
+	"bufio"
+	"database/sql"
+	"flag"
+	"fmt"
+	"log"
+	"os"
+	"strings"
+
+	"github.com/pressly/goose/v3"
+)
+
+// dryRunFlag разбирает флаги команд up и down и возвращает значение --dry-run
+func dryRunFlag(command string, args []string) bool {
+	fs := flag.NewFlagSet(command, flag.ExitOnError)
+	dryRun := fs.Bool("dry-run", false, "вывести SQL миграций без применения")
+	if err := fs.Parse(args); err != nil {
+		log.Fatalf("Ошибка разбора флагов: %v", err)
+	}
+	return *dryRun
+}
+
+// printPendingMigrations выводит SQL миграций, которые выполнила бы команда up
+// (все непримененные миграции) или down (откат текущей версии), не применяя их
+func printPendingMigrations(db *sql.DB, dir string, up bool) error {
+	current, err := goose.GetDBVersion(db)
+	if err != nil {
+		return fmt.Errorf("ошибка получения версии схемы: %w", err)
+	}
+	migrations, err := goose.CollectMigrations(dir, 0, goose.MaxVersion)
+	if err != nil {
+		return fmt.Errorf("ошибка чтения миграций: %w", err)
+	}
+
+	var pending goose.Migrations
+	for _, m := range migrations {
+		if (up && m.Version > current) || (!up && m.Version == current) {
+			pending = append(pending, m)
+		}
+	}
+	if len(pending) == 0 {
+		fmt.Printf("Текущая версия схемы: %d, выполнять нечего\n", current)
+		return nil
+	}
+
+	fmt.Printf("Текущая версия схемы: %d, миграций к выполнению: %d\n", current, len(pending))
+	for _, m := range pending {
+		statements, err := migrationSection(m.Source, up)
+		if err != nil {
+			return err
+		}
+		direction := "Up"
+		if !up {
+			direction = "Down"
+		}
+		fmt.Printf("\n-- ===== %d (%s) %s =====\n", m.Version, m.Source, direction)
+		fmt.Println(statements)
+	}
+	return nil
+}
+
+// migrationSection возвращает SQL секции Up или Down файла миграции без аннотаций goose
+func migrationSection(path string, up bool) (string, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return "", fmt.Errorf("ошибка открытия миграции: %w", err)
+	}
+	defer file.Close()
+
+	want := "-- +goose Up"
+	if !up {
+		want = "-- +goose Down"
+	}
+
+	var lines []string
+	inSection := false
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		line := scanner.Text()
+		trimmed := strings.TrimSpace(line)
+		if strings.HasPrefix(trimmed, "-- +goose ") {
+			if strings.HasPrefix(trimmed, "-- +goose Up") || strings.HasPrefix(trimmed, "-- +goose Down") {
+				inSection = strings.HasPrefix(trimmed, want)
+			}
+			// StatementBegin/StatementEnd и прочие аннотации в вывод не попадают
+			continue
+		}
+		if inSection {
+			lines = append(lines, line)
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return "", fmt.Errorf("ошибка чтения миграции %s: %w", path, err)
+	}
+	return strings.TrimSpace(strings.Join(lines, "\n")), nil
+}
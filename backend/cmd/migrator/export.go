@@ -0,0 +1,197 @@
+package main
+
+import (
+	"context"
+	"database/sql"
+	"encoding/csv"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"os/user"
+	"strconv"
+	"time"
+
+	"github.com/Ultrahd-dev/student-schedule-app/backend/internal/audit"
+	"github.com/Ultrahd-dev/student-schedule-app/backend/internal/tenant"
+	"github.com/Ultrahd-dev/student-schedule-app/backend/internal/users"
+	"github.com/google/uuid"
+)
+
+// exportHeader заголовок CSV выгрузки пользователей. Хэши паролей, секреты 2FA
+// и прочие учетные данные в выгрузку не попадают.
+var exportHeader = []string{
+	"id", "email", "роль", "ФИО", "группа", "факультет", "курс", "номер студенческого",
+	"кафедра", "должность", "табельный номер", "активен", "зарегистрирован", "последний вход",
+}
+
+// exportUsers выгружает данные пользователей колледжа с профилями студентов и
+// преподавателей в CSV для деканата и записывает выгрузку в журнал безопасности
+func exportUsers(ctx context.Context, db *sql.DB, args []string) error {
+	fs := flag.NewFlagSet("export-users", flag.ExitOnError)
+	role := fs.String("role", "", "роль: student, teacher или admin (по умолчанию все)")
+	group := fs.String("group", "", "группа студентов (только для студентов)")
+	format := fs.String("format", "csv", "формат выгрузки (поддерживается csv)")
+	output := fs.String("o", "", "файл выгрузки (по умолчанию users_ДАТА.csv)")
+	actor := fs.String("actor", "", "email администратора, выполняющего выгрузку")
+	college := fs.String("college", "", "код колледжа (по умолчанию колледж по умолчанию)")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	if *format != "csv" {
+		return fmt.Errorf("неподдерживаемый формат %q, доступен только csv", *format)
+	}
+	switch users.Role(*role) {
+	case "", users.RoleStudent, users.RoleTeacher, users.RoleAdmin:
+	default:
+		return fmt.Errorf("неизвестная роль %q", *role)
+	}
+	if *group != "" && *role != "" && users.Role(*role) != users.RoleStudent {
+		return fmt.Errorf("фильтр по группе применим только к студентам")
+	}
+	if *college != "" {
+		collegeID, err := tenant.NewRegistry(tenant.NewRepository(db)).CollegeIDBySlug(ctx, *college)
+		if err != nil {
+			return fmt.Errorf("ошибка поиска колледжа: %w", err)
+		}
+		ctx = tenant.WithCollege(ctx, collegeID)
+	}
+
+	// Выгрузку от имени администратора можно найти в журнале по его ID
+	var actorID *uuid.UUID
+	if *actor != "" {
+		admin, err := users.NewRepository(db).GetUserByEmail(ctx, *actor)
+		if err != nil {
+			return fmt.Errorf("администратор %s не найден: %w", *actor, err)
+		}
+		if admin.Role != users.RoleAdmin {
+			return fmt.Errorf("пользователь %s не является администратором", *actor)
+		}
+		actorID = &admin.ID
+	}
+
+	if *output == "" {
+		*output = fmt.Sprintf("users_%s.csv", time.Now().Format("2006-01-02_15-04-05"))
+	}
+	file, err := os.Create(*output)
+	if err != nil {
+		return fmt.Errorf("ошибка создания файла: %w", err)
+	}
+	defer file.Close()
+
+	count, err := writeUsersCSV(ctx, db, file, users.Role(*role), *group)
+	if err != nil {
+		return err
+	}
+	if err := file.Close(); err != nil {
+		return fmt.Errorf("ошибка записи файла: %w", err)
+	}
+
+	details := fmt.Sprintf("Выгрузка пользователей (роль: %s, группа: %s, строк: %d) выполнена %s",
+		orDash(*role), orDash(*group), count, operator())
+	// В отличие от входа и регистрации, выгрузка без записи в журнале считается ошибкой
+	event := &audit.Event{
+		ID:        uuid.New(),
+		Type:      audit.EventUserExport,
+		UserID:    actorID,
+		ActorID:   actorID,
+		Email:     *actor,
+		UserAgent: "migrator export-users",
+		Details:   details,
+	}
+	if err := audit.NewRepository(db).CreateEvent(ctx, event); err != nil {
+		return fmt.Errorf("ошибка записи выгрузки в журнал безопасности: %w", err)
+	}
+
+	fmt.Printf("Выгружено пользователей: %d, файл: %s\n", count, *output)
+	return nil
+}
+
+// writeUsersCSV пишет пользователей текущего колледжа по фильтру и возвращает их количество
+func writeUsersCSV(ctx context.Context, db *sql.DB, w io.Writer, role users.Role, group string) (int, error) {
+	query := `
+		SELECT u.id, u.email, u.role, COALESCE(s.full_name, t.full_name, ''),
+			COALESCE(s.group_name, ''), COALESCE(s.faculty, ''), COALESCE(s.course, 0),
+			COALESCE(s.student_number, ''), COALESCE(t.department, ''), COALESCE(t.position, ''),
+			COALESCE(t.teacher_id, ''), u.deleted_at IS NULL, u.created_at, u.last_login
+		FROM users u
+		LEFT JOIN students s ON s.user_id = u.id
+		LEFT JOIN teachers t ON t.user_id = u.id
+		WHERE u.college_id = $1
+		  AND ($2 = '' OR u.role::text = $2)
+		  AND ($3 = '' OR s.group_name = $3)
+		ORDER BY u.role, s.group_name, COALESCE(s.full_name, t.full_name, ''), u.email`
+
+	rows, err := db.QueryContext(ctx, query, tenant.CollegeID(ctx), string(role), group)
+	if err != nil {
+		return 0, fmt.Errorf("ошибка получения пользователей: %w", err)
+	}
+	defer rows.Close()
+
+	writer := csv.NewWriter(w)
+	if err := writer.Write(exportHeader); err != nil {
+		return 0, fmt.Errorf("ошибка записи CSV: %w", err)
+	}
+
+	count := 0
+	for rows.Next() {
+		var (
+			id                                         uuid.UUID
+			email, userRole, fullName, groupName       string
+			faculty, studentNumber, department, status string
+			position, teacherID                        string
+			course                                     int
+			isActive                                   bool
+			createdAt                                  time.Time
+			lastLogin                                  sql.NullTime
+		)
+		if err := rows.Scan(&id, &email, &userRole, &fullName, &groupName, &faculty, &course,
+			&studentNumber, &department, &position, &teacherID, &isActive, &createdAt, &lastLogin); err != nil {
+			return 0, fmt.Errorf("ошибка чтения пользователя: %w", err)
+		}
+
+		status = "да"
+		if !isActive {
+			status = "нет"
+		}
+		courseStr := ""
+		if course > 0 {
+			courseStr = strconv.Itoa(course)
+		}
+		lastLoginStr := ""
+		if lastLogin.Valid {
+			lastLoginStr = lastLogin.Time.Local().Format("02.01.2006 15:04")
+		}
+
+		record := []string{
+			id.String(), email, userRole, fullName, groupName, faculty, courseStr, studentNumber,
+			department, position, teacherID, status, createdAt.Local().Format("02.01.2006 15:04"), lastLoginStr,
+		}
+		if err := writer.Write(record); err != nil {
+			return 0, fmt.Errorf("ошибка записи CSV: %w", err)
+		}
+		count++
+	}
+	if err := rows.Err(); err != nil {
+		return 0, fmt.Errorf("ошибка чтения пользователей: %w", err)
+	}
+
+	writer.Flush()
+	if err := writer.Error(); err != nil {
+		return 0, fmt.Errorf("ошибка записи CSV: %w", err)
+	}
+	return count, nil
+}
+
+// operator возвращает пользователя ОС и хост, с которых запущен migrator
+func operator() string {
+	name := "неизвестный пользователь"
+	if current, err := user.Current(); err == nil {
+		name = current.Username
+	}
+	if host, err := os.Hostname(); err == nil && host != "" {
+		name += "@" + host
+	}
+	return name
+}
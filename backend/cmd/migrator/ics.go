@@ -0,0 +1,95 @@
+package main
+
+import (
+	"context"
+	"database/sql"
+	"flag"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/Ultrahd-dev/student-schedule-app/backend/internal/calendar"
+	"github.com/Ultrahd-dev/student-schedule-app/backend/internal/clock"
+	"github.com/Ultrahd-dev/student-schedule-app/backend/internal/ical"
+	"github.com/Ultrahd-dev/student-schedule-app/backend/internal/schedule"
+	"github.com/Ultrahd-dev/student-schedule-app/backend/internal/tenant"
+)
+
+// defaultICSPeriod период календаря, если не указана дата окончания
+const defaultICSPeriod = 28 * 24 * time.Hour
+
+// generateICS сохраняет актуальное расписание группы за период в ICS файл
+func generateICS(ctx context.Context, db *sql.DB, loc *time.Location, args []string) error {
+	fs := flag.NewFlagSet("generate-ics", flag.ExitOnError)
+	group := fs.String("group", "", "группа (обязательно)")
+	fromStr := fs.String("from", "", "начало периода ГГГГ-ММ-ДД (по умолчанию сегодня)")
+	toStr := fs.String("to", "", "конец периода ГГГГ-ММ-ДД (по умолчанию через 4 недели)")
+	output := fs.String("o", "", "файл календаря (по умолчанию ГРУППА.ics)")
+	college := fs.String("college", "", "код колледжа (по умолчанию колледж по умолчанию)")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	if *group == "" {
+		return fmt.Errorf("необходимо указать группу (--group)")
+	}
+	from := clock.Today(loc)
+	if *fromStr != "" {
+		parsed, err := time.ParseInLocation("2006-01-02", *fromStr, loc)
+		if err != nil {
+			return fmt.Errorf("некорректная дата начала %q, ожидается ГГГГ-ММ-ДД", *fromStr)
+		}
+		from = parsed
+	}
+	to := from.Add(defaultICSPeriod)
+	if *toStr != "" {
+		parsed, err := time.ParseInLocation("2006-01-02", *toStr, loc)
+		if err != nil {
+			return fmt.Errorf("некорректная дата окончания %q, ожидается ГГГГ-ММ-ДД", *toStr)
+		}
+		to = parsed
+	}
+	if to.Before(from) {
+		return fmt.Errorf("дата окончания раньше даты начала")
+	}
+	if *output == "" {
+		*output = *group + ".ics"
+	}
+
+	if *college != "" {
+		collegeID, err := tenant.NewRegistry(tenant.NewRepository(db)).CollegeIDBySlug(ctx, *college)
+		if err != nil {
+			return fmt.Errorf("ошибка поиска колледжа: %w", err)
+		}
+		ctx = tenant.WithCollege(ctx, collegeID)
+	}
+
+	entries, err := schedule.NewRepository(db).GetCurrentScheduleForGroupRange(ctx, *group, from, to)
+	if err != nil {
+		return fmt.Errorf("ошибка получения расписания: %w", err)
+	}
+
+	events, err := calendar.Events(entries, loc, false)
+	if err != nil {
+		return err
+	}
+	cal := &ical.Calendar{
+		Name:     "Расписание " + *group,
+		TimeZone: loc.String(),
+		Events:   events,
+	}
+
+	file, err := os.Create(*output)
+	if err != nil {
+		return fmt.Errorf("ошибка создания файла: %w", err)
+	}
+	defer file.Close()
+
+	if err := cal.Write(file); err != nil {
+		return fmt.Errorf("ошибка записи календаря: %w", err)
+	}
+
+	fmt.Printf("Календарь группы %s за %s - %s (%d пар) сохранен в %s\n", *group,
+		from.Format("02.01.2006"), to.Format("02.01.2006"), len(cal.Events), *output)
+	return nil
+}
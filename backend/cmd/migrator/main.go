@@ -9,16 +9,28 @@ import (
 	"fmt"
 	"log"
 	"os"
+	"strconv"
 	"time"
 
+	"github.com/Ultrahd-dev/student-schedule-app/backend/internal/bells"
 	"github.com/Ultrahd-dev/student-schedule-app/backend/internal/config"
+	"github.com/Ultrahd-dev/student-schedule-app/backend/internal/database"
 	"github.com/Ultrahd-dev/student-schedule-app/backend/internal/scraper/gsheets"
+	"github.com/Ultrahd-dev/student-schedule-app/backend/internal/tenant"
+	"github.com/Ultrahd-dev/student-schedule-app/backend/internal/users"
+	"github.com/google/uuid"
 	_ "github.com/lib/pq"
 	"github.com/pressly/goose/v3"
 )
 
+// migrationsDir каталог с файлами миграций (флаг --migrations)
+var migrationsDir = "../../migrations"
+
 func main() {
 	// Определяем флаги командной строки
+	configPath := flag.String("config", "", "путь к файлу конфигурации (или SCHEDULE_CONFIG)")
+	dsn := flag.String("dsn", "", "строка подключения к PostgreSQL (или SCHEDULE_DSN)")
+	flag.StringVar(&migrationsDir, "migrations", migrationsDir, "каталог с файлами миграций")
 	flag.Usage = usage
 	flag.Parse()
 
@@ -31,13 +43,23 @@ func main() {
 	command := args[0]
 
 	// Загружаем конфигурацию
-	cfg, err := config.LoadConfig("../../configs/config.yaml")
+	cfg, err := config.LoadConfig(config.ResolvePath(*configPath, "../../configs/config.yaml"))
 	if err != nil {
 		log.Fatalf("Ошибка загрузки конфигурации: %v", err)
 	}
+	cfg.OverrideDSN(*dsn)
+	// Миграции и обслуживание базы могут выполняться дольше запросов API
+	cfg.Database.StatementTimeout = 0
+	cfg.Database.LockTimeout = 0
+
+	// Часовой пояс колледжа для интерпретации дат из таблиц
+	loc, err := cfg.College.Location()
+	if err != nil {
+		log.Fatalf("Ошибка загрузки часового пояса: %v", err)
+	}
 
 	// Подключаемся к базе данных
-	db, err := sql.Open("postgres", cfg.Database.GetDSN())
+	db, err := database.Open(cfg.Database.GetDSN(), cfg.Database.StatementTimeout, cfg.Database.LockTimeout)
 	if err != nil {
 		log.Fatalf("Ошибка подключения к базе данных: %v", err)
 	}
@@ -55,19 +77,54 @@ func main() {
 
 	switch command {
 	case "up":
-		if err := goose.Up(db, "../../migrations"); err != nil {
+		// С --dry-run только выводим SQL непримененных миграций для проверки перед запуском
+		if dryRunFlag(command, args[1:]) {
+			if err := printPendingMigrations(db, migrationsDir, true); err != nil {
+				log.Fatalf("Ошибка вывода миграций: %v", err)
+			}
+			return
+		}
+		if err := goose.Up(db, migrationsDir); err != nil {
 			log.Fatalf("Ошибка применения миграций: %v", err)
 		}
 		fmt.Println("Миграции успешно применены")
 	case "down":
-		if err := goose.Down(db, "../../migrations"); err != nil {
+		if dryRunFlag(command, args[1:]) {
+			if err := printPendingMigrations(db, migrationsDir, false); err != nil {
+				log.Fatalf("Ошибка вывода миграций: %v", err)
+			}
+			return
+		}
+		if err := goose.Down(db, migrationsDir); err != nil {
 			log.Fatalf("Ошибка отката миграций: %v", err)
 		}
 		fmt.Println("Миграции успешно откачены")
 	case "status":
-		if err := goose.Status(db, "../../migrations"); err != nil {
+		if err := goose.Status(db, migrationsDir); err != nil {
 			log.Fatalf("Ошибка получения статуса миграций: %v", err)
 		}
+	case "up-to":
+		version := parseVersion(args)
+		if err := goose.UpTo(db, migrationsDir, version); err != nil {
+			log.Fatalf("Ошибка применения миграций до версии %d: %v", version, err)
+		}
+		fmt.Printf("Миграции применены до версии %d\n", version)
+	case "down-to":
+		version := parseVersion(args)
+		if err := goose.DownTo(db, migrationsDir, version); err != nil {
+			log.Fatalf("Ошибка отката миграций до версии %d: %v", version, err)
+		}
+		fmt.Printf("Миграции откачены до версии %d\n", version)
+	case "redo":
+		// Откат и повторное применение последней миграции (проверка Down-части)
+		if err := goose.Redo(db, migrationsDir); err != nil {
+			log.Fatalf("Ошибка повторного применения миграции: %v", err)
+		}
+		fmt.Println("Последняя миграция откачена и применена повторно")
+	case "version":
+		if err := goose.Version(db, migrationsDir); err != nil {
+			log.Fatalf("Ошибка получения версии схемы: %v", err)
+		}
 	case "download-changes":
 		// Новая команда для скачивания таблицы изменений
 		if len(args) < 2 {
@@ -76,7 +133,7 @@ func main() {
 		changesURL := args[1]
 
 		// Создаем клиент gsheets
-		gsheetClient := gsheets.NewClient(cfg.Scraper.MainScheduleGIDs)
+		gsheetClient := gsheets.NewClient(cfg.Scraper.MainScheduleGIDs, loc)
 
 		// Скачиваем таблицу изменений в CSV
 		ctx := context.Background()
@@ -124,10 +181,10 @@ func main() {
 		}
 
 		// Создаем клиент gsheets
-		gsheetClient := gsheets.NewClient(cfg.Scraper.MainScheduleGIDs)
+		gsheetClient := gsheets.NewClient(cfg.Scraper.MainScheduleGIDs, loc)
 
-		// Парсим изменения
-		changeRecords, err := gsheetClient.ParseChangeRecords(csvRecords)
+		// Парсим изменения (время пар - по встроенному расписанию звонков)
+		changeRecords, err := gsheetClient.ParseChangeRecords(csvRecords, bells.Builtin())
 		if err != nil {
 			log.Fatalf("Ошибка парсинга изменений: %v", err)
 		}
@@ -137,25 +194,185 @@ func main() {
 			fmt.Printf("Группа: %s, Дата: %s, Предмет: %s, Тип: %s\n",
 				record.GroupName, record.Date.Format("02.01.2006"), record.Subject, record.ChangeType)
 		}
+	case "create-admin":
+		// Создание администратора (если начальный администратор не задан в конфигурации)
+		if len(args) < 3 {
+			log.Fatalf("Необходимо указать email и пароль администратора")
+		}
+
+		// Администратор колледжа, если указан его код, иначе колледжа по умолчанию
+		adminCtx := context.Background()
+		if len(args) > 3 {
+			collegeID, err := tenant.NewRegistry(tenant.NewRepository(db)).CollegeIDBySlug(adminCtx, args[3])
+			if err != nil {
+				log.Fatalf("Ошибка поиска колледжа: %v", err)
+			}
+			adminCtx = tenant.WithCollege(adminCtx, collegeID)
+		}
+
+		userService := users.NewService(users.NewRepository(db))
+		admin, created, err := userService.BootstrapAdmin(adminCtx, args[1], args[2])
+		if err != nil {
+			log.Fatalf("Ошибка создания администратора: %v", err)
+		}
+		if !created {
+			fmt.Printf("Администратор %s уже существует\n", admin.Email)
+			return
+		}
+		fmt.Printf("Администратор %s успешно создан\n", admin.Email)
+	case "preview":
+		if err := preview(context.Background(), db, cfg.Scraper.MainScheduleGIDs, loc, args[1:]); err != nil {
+			log.Fatalf("Ошибка просмотра расписания: %v", err)
+		}
+	case "generate-ics":
+		if err := generateICS(context.Background(), db, loc, args[1:]); err != nil {
+			log.Fatalf("Ошибка формирования календаря: %v", err)
+		}
+	case "import-bells":
+		fmt.Println("Импорт расписания звонков:")
+		applied, err := importBells(context.Background(), db, cfg, args[1:])
+		if err != nil {
+			log.Fatalf("Ошибка импорта расписания звонков: %v", err)
+		}
+		if applied {
+			fmt.Println("Расписание звонков успешно импортировано и применено на экземплярах API")
+		} else {
+			fmt.Println("Расписание звонков успешно импортировано, оно будет применено при перезапуске API")
+		}
+	case "import-teachers":
+		fmt.Println("Импорт справочника преподавателей:")
+		if err := importTeachers(context.Background(), db, args[1:]); err != nil {
+			log.Fatalf("Ошибка импорта справочника преподавателей: %v", err)
+		}
+		fmt.Println("Справочник преподавателей успешно импортирован")
+	case "stats":
+		if err := printStats(context.Background(), db); err != nil {
+			log.Fatalf("Ошибка получения статистики: %v", err)
+		}
+	case "anonymize":
+		// Обезличивание необратимо, поэтому имя базы нужно подтвердить явно
+		dbName := currentDatabase(db)
+		if len(args) < 2 || args[1] != dbName {
+			log.Fatalf("Для обезличивания укажите имя базы: migrator anonymize %s", dbName)
+		}
+
+		fmt.Printf("Обезличивание персональных данных в базе %s:\n", dbName)
+		if err := anonymize(context.Background(), db); err != nil {
+			log.Fatalf("Ошибка обезличивания: %v", err)
+		}
+		fmt.Println("Персональные данные успешно обезличены")
+	case "export-users":
+		if err := exportUsers(context.Background(), db, args[1:]); err != nil {
+			log.Fatalf("Ошибка выгрузки пользователей: %v", err)
+		}
+	case "backup":
+		if err := backup(context.Background(), cfg.Database, currentDatabase(db), args[1:]); err != nil {
+			log.Fatalf("Ошибка резервного копирования: %v", err)
+		}
+		fmt.Println("Резервная копия успешно создана")
+	case "restore":
+		if err := restore(context.Background(), cfg.Database, currentDatabase(db), args[1:]); err != nil {
+			log.Fatalf("Ошибка восстановления: %v", err)
+		}
+		fmt.Println("База успешно восстановлена")
+	case "rollover":
+		fmt.Println("Переход на новый учебный год:")
+		if err := runRollover(context.Background(), db, args[1:]); err != nil {
+			log.Fatalf("Ошибка перехода на новый учебный год: %v", err)
+		}
+	case "create-college":
+		// Добавление колледжа; источник расписания по умолчанию берется из конфигурации
+		if len(args) < 3 {
+			log.Fatalf("Необходимо указать код и название колледжа")
+		}
+
+		college := &tenant.College{
+			ID:       uuid.New(),
+			Slug:     args[1],
+			Name:     args[2],
+			IsActive: true,
+		}
+		if len(args) > 3 {
+			college.BaseURL = args[3]
+		}
+		if err := tenant.NewRepository(db).CreateCollege(context.Background(), college); err != nil {
+			log.Fatalf("Ошибка создания колледжа: %v", err)
+		}
+		fmt.Printf("Колледж %s (%s) успешно создан, ID: %s\n", college.Name, college.Slug, college.ID)
 	default:
 		fmt.Printf("Неизвестная команда: %s\n", command)
 		flag.Usage()
 	}
 }
 
+// parseVersion возвращает номер версии миграции из второго аргумента команды
+func parseVersion(args []string) int64 {
+	if len(args) < 2 {
+		log.Fatalf("Необходимо указать номер версии миграции")
+	}
+	version, err := strconv.ParseInt(args[1], 10, 64)
+	if err != nil || version < 0 {
+		log.Fatalf("Некорректный номер версии миграции: %s", args[1])
+	}
+	return version
+}
+
+// currentDatabase возвращает имя базы, к которой выполнено подключение
+// (оно может отличаться от конфигурации, если задан --dsn)
+func currentDatabase(db *sql.DB) string {
+	var name string
+	if err := db.QueryRow(`SELECT current_database()`).Scan(&name); err != nil {
+		log.Fatalf("Ошибка получения имени базы: %v", err)
+	}
+	return name
+}
+
 func usage() {
-	fmt.Println("Использование: migrator [команда]")
+	fmt.Println("Использование: migrator [--config FILE] [--dsn DSN] [--migrations DIR] [команда]")
+	fmt.Println("Путь к конфигурации и строку подключения можно задать переменными SCHEDULE_CONFIG и SCHEDULE_DSN")
 	fmt.Println("Доступные команды:")
-	fmt.Println("  up                   - Применить все непримененные миграции")
-	fmt.Println("  down                 - Откатить последнюю миграцию")
+	fmt.Println("  up [--dry-run]       - Применить все непримененные миграции (или только вывести их SQL)")
+	fmt.Println("  down [--dry-run]     - Откатить последнюю миграцию (или только вывести SQL отката)")
 	fmt.Println("  status               - Показать статус миграций")
+	fmt.Println("  up-to VERSION        - Применить миграции до версии VERSION включительно")
+	fmt.Println("  down-to VERSION      - Откатить миграции новее версии VERSION")
+	fmt.Println("  redo                 - Откатить и заново применить последнюю миграцию")
+	fmt.Println("  version              - Показать текущую версию схемы")
 	fmt.Println("  download-changes URL - Скачать таблицу изменений по URL в CSV файл")
 	fmt.Println("  parse-changes FILE   - Распарсить CSV файл с изменениями")
+	fmt.Println("  create-admin EMAIL PASSWORD [COLLEGE] - Создать администратора (колледжа COLLEGE)")
+	fmt.Println("  create-college SLUG NAME [BASE_URL] - Добавить колледж")
+	fmt.Println("  preview --group G [--date D] [--file F] [--college C] - Показать расписание группы на дату")
+	fmt.Println("  generate-ics --group G [--from D] [--to D] [-o FILE] [--college C] - Сохранить расписание группы в ICS файл")
+	fmt.Println("  import-bells [--college C] FILE - Заменить расписание звонков колледжа данными из CSV или YAML файла")
+	fmt.Println("  import-teachers [--replace] [--gid N] [--college C] FILE|URL - Загрузить справочник преподавателей (ФИО, кафедра, должность) из CSV или Google Таблицы")
+	fmt.Println("  stats                - Показать размер таблиц, последний парсинг и активные снапшоты")
+	fmt.Println("  anonymize DBNAME     - Обезличить персональные данные (для копии базы на тестовом стенде)")
+	fmt.Println("  export-users [--role R] [--group G] [--format csv] [-o FILE] [--actor EMAIL] [--college C] - Выгрузить пользователей для деканата")
+	fmt.Println("  backup [-o FILE]     - Сохранить резервную копию базы (pg_dump)")
+	fmt.Println("  restore [--yes] FILE - Восстановить базу из резервной копии (pg_restore)")
+	fmt.Println("  rollover --year-start D [--max-course N] [--rename OLD=NEW,...] [--retire G,...] [--dry-run] [--actor EMAIL] [--college C] - Перейти на новый учебный год")
 	fmt.Println("")
 	fmt.Println("Примеры:")
 	fmt.Println("  migrator up")
+	fmt.Println("  migrator up --dry-run")
+	fmt.Println("  migrator --config /etc/schedule/config.yaml --migrations /opt/schedule/migrations up")
 	fmt.Println("  migrator down")
 	fmt.Println("  migrator status")
+	fmt.Println("  migrator up-to 20")
+	fmt.Println("  migrator down-to 18")
 	fmt.Println("  migrator download-changes \"https://docs.google.com/spreadsheets/d/1bT7DPsioPz_OswGPXB6jgvXh20_S-i3d-C5NHCpeuIw/edit?usp=sharing\"")
 	fmt.Println("  migrator parse-changes changes_2025-08-16_14-36-07.csv")
+	fmt.Println("  migrator create-admin admin@college.ru secret123")
+	fmt.Println("  migrator preview --group ИС-21 --date 2025-09-01")
+	fmt.Println("  migrator preview --group ИС-21 --date 2025-09-01 --file schedule.csv")
+	fmt.Println("  migrator generate-ics --group ИС-21 --from 2025-09-01 --to 2025-09-30 -o is-21.ics")
+	fmt.Println("  migrator import-bells bells.yaml")
+	fmt.Println("  migrator import-teachers --replace teachers.csv")
+	fmt.Println("  migrator export-users --role student --group ИС-21 --actor admin@college.ru -o is-21.csv")
+	fmt.Println("  migrator anonymize schedule_staging")
+	fmt.Println("  migrator backup -o schedule.dump")
+	fmt.Println("  migrator restore schedule.dump")
+	fmt.Println("  migrator rollover --year-start 2026-09-01 --rename ИС-21=ИС-31,ИС-22=ИС-32 --retire ИС-41 --dry-run")
+	fmt.Println("  migrator create-college tech \"Технический колледж\" https://tech-college.ru/schedule")
 }
@@ -0,0 +1,177 @@
+package main
+
+import (
+	"context"
+	"database/sql"
+	"encoding/csv"
+	"flag"
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/Ultrahd-dev/student-schedule-app/backend/internal/bells"
+	"github.com/Ultrahd-dev/student-schedule-app/backend/internal/clock"
+	"github.com/Ultrahd-dev/student-schedule-app/backend/internal/schedule"
+	"github.com/Ultrahd-dev/student-schedule-app/backend/internal/scraper/gsheets"
+	"github.com/Ultrahd-dev/student-schedule-app/backend/internal/tenant"
+)
+
+// previewLesson строка расписания для вывода в терминал
+type previewLesson struct {
+	TimeStart string
+	TimeEnd   string
+	Subject   string
+	Teacher   string
+	Classroom string
+}
+
+// preview выводит в терминал расписание группы на дату из базы (актуальное
+// расписание с учетом изменений) или из CSV файла основного расписания
+func preview(ctx context.Context, db *sql.DB, gids []int64, loc *time.Location, args []string) error {
+	fs := flag.NewFlagSet("preview", flag.ExitOnError)
+	group := fs.String("group", "", "группа (обязательно)")
+	dateStr := fs.String("date", "", "дата в формате ГГГГ-ММ-ДД (по умолчанию сегодня)")
+	file := fs.String("file", "", "CSV файл основного расписания вместо базы")
+	college := fs.String("college", "", "код колледжа (по умолчанию колледж по умолчанию)")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	if *group == "" {
+		return fmt.Errorf("необходимо указать группу (--group)")
+	}
+	date := clock.Today(loc)
+	if *dateStr != "" {
+		parsed, err := time.ParseInLocation("2006-01-02", *dateStr, loc)
+		if err != nil {
+			return fmt.Errorf("некорректная дата %q, ожидается ГГГГ-ММ-ДД", *dateStr)
+		}
+		date = parsed
+	}
+
+	if *college != "" {
+		collegeID, err := tenant.NewRegistry(tenant.NewRepository(db)).CollegeIDBySlug(ctx, *college)
+		if err != nil {
+			return fmt.Errorf("ошибка поиска колледжа: %w", err)
+		}
+		ctx = tenant.WithCollege(ctx, collegeID)
+	}
+
+	var lessons []previewLesson
+	var source string
+	if *file != "" {
+		timings, err := bells.NewRepository(db).Load(ctx)
+		if err != nil {
+			return err
+		}
+		records, err := readScheduleCSV(*file, gids, loc, timings)
+		if err != nil {
+			return err
+		}
+		lessons = lessonsFromRecords(records, *group, date)
+		source = *file
+	} else {
+		entries, err := schedule.NewRepository(db).GetCurrentScheduleForGroup(ctx, *group, date)
+		if err != nil {
+			return fmt.Errorf("ошибка получения расписания: %w", err)
+		}
+		for _, entry := range entries {
+			lessons = append(lessons, previewLesson{
+				TimeStart: entry.TimeStart,
+				TimeEnd:   entry.TimeEnd,
+				Subject:   entry.Subject,
+				Teacher:   entry.Teacher,
+				Classroom: entry.Classroom,
+			})
+		}
+		source = "база данных"
+	}
+
+	fmt.Printf("Расписание группы %s на %s (%s), источник: %s\n\n",
+		*group, date.Format("02.01.2006"), weekdayNames[date.Weekday()], source)
+	if len(lessons) == 0 {
+		fmt.Println("Пар нет")
+		return nil
+	}
+
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "Время\tПредмет\tПреподаватель\tАудитория")
+	for _, lesson := range lessons {
+		fmt.Fprintf(w, "%s-%s\t%s\t%s\t%s\n", shortTime(lesson.TimeStart), shortTime(lesson.TimeEnd),
+			lesson.Subject, orDash(lesson.Teacher), orDash(lesson.Classroom))
+	}
+	return w.Flush()
+}
+
+// weekdayNames названия дней недели для заголовка
+var weekdayNames = map[time.Weekday]string{
+	time.Monday:    "понедельник",
+	time.Tuesday:   "вторник",
+	time.Wednesday: "среда",
+	time.Thursday:  "четверг",
+	time.Friday:    "пятница",
+	time.Saturday:  "суббота",
+	time.Sunday:    "воскресенье",
+}
+
+// readScheduleCSV читает и парсит CSV файл основного расписания (время пар - по расписанию звонков timings)
+func readScheduleCSV(filename string, gids []int64, loc *time.Location, timings bells.Timings) ([]gsheets.ScheduleRecord, error) {
+	file, err := os.Open(filename)
+	if err != nil {
+		return nil, fmt.Errorf("ошибка открытия файла: %w", err)
+	}
+	defer file.Close()
+
+	reader := csv.NewReader(file)
+	reader.FieldsPerRecord = -1
+	csvRecords, err := reader.ReadAll()
+	if err != nil {
+		return nil, fmt.Errorf("ошибка чтения CSV из файла: %w", err)
+	}
+
+	records, err := gsheets.NewClient(gids, loc).ParseScheduleRecords(csvRecords, timings)
+	if err != nil {
+		return nil, fmt.Errorf("ошибка парсинга расписания: %w", err)
+	}
+	return records, nil
+}
+
+// lessonsFromRecords отбирает пары группы на дату из записей таблицы
+func lessonsFromRecords(records []gsheets.ScheduleRecord, group string, date time.Time) []previewLesson {
+	day := date.Format("2006-01-02")
+
+	var lessons []previewLesson
+	for _, record := range records {
+		if !strings.EqualFold(record.GroupName, group) || record.Date.Format("2006-01-02") != day {
+			continue
+		}
+		lessons = append(lessons, previewLesson{
+			TimeStart: record.TimeStart,
+			TimeEnd:   record.TimeEnd,
+			Subject:   record.Subject,
+			Teacher:   record.Teacher,
+			Classroom: record.Classroom,
+		})
+	}
+	sort.SliceStable(lessons, func(i, j int) bool { return lessons[i].TimeStart < lessons[j].TimeStart })
+	return lessons
+}
+
+// shortTime убирает секунды из времени ЧЧ:ММ:СС
+func shortTime(value string) string {
+	if len(value) == len("15:04:05") {
+		return value[:5]
+	}
+	return value
+}
+
+// orDash возвращает "-" вместо пустой строки
+func orDash(value string) string {
+	if value == "" {
+		return "-"
+	}
+	return value
+}
@@ -0,0 +1,106 @@
+package main
+
+import (
+	"context"
+	"database/sql"
+	"flag"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/Ultrahd-dev/student-schedule-app/backend/internal/audit"
+	"github.com/Ultrahd-dev/student-schedule-app/backend/internal/rollover"
+	"github.com/Ultrahd-dev/student-schedule-app/backend/internal/tenant"
+	"github.com/Ultrahd-dev/student-schedule-app/backend/internal/txn"
+	"github.com/Ultrahd-dev/student-schedule-app/backend/internal/users"
+	"github.com/google/uuid"
+)
+
+// runRollover выполняет переход колледжа на новый учебный год (или только
+// показывает его итог при --dry-run)
+func runRollover(ctx context.Context, db *sql.DB, args []string) error {
+	fs := flag.NewFlagSet("rollover", flag.ExitOnError)
+	yearStartStr := fs.String("year-start", "", "первый день нового учебного года (YYYY-MM-DD)")
+	maxCourse := fs.Int("max-course", rollover.DefaultMaxCourse, "последний курс, студенты которого выпускаются")
+	renames := fs.String("rename", "", "переименования групп через запятую: ИС-21=ИС-31,ИС-22=ИС-32")
+	retire := fs.String("retire", "", "группы через запятую, выпускаемые целиком независимо от курса")
+	dryRun := fs.Bool("dry-run", false, "только показать изменения, ничего не сохраняя")
+	actor := fs.String("actor", "", "email администратора, выполняющего переход")
+	college := fs.String("college", "", "код колледжа (по умолчанию колледж по умолчанию)")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	if *yearStartStr == "" {
+		return fmt.Errorf("необходимо указать --year-start")
+	}
+	yearStart, err := time.Parse("2006-01-02", *yearStartStr)
+	if err != nil {
+		return fmt.Errorf("некорректная дата начала учебного года: %s", *yearStartStr)
+	}
+
+	plan := rollover.Plan{
+		YearStart: yearStart,
+		MaxCourse: *maxCourse,
+		DryRun:    *dryRun,
+	}
+	for _, pair := range splitList(*renames) {
+		from, to, ok := strings.Cut(pair, "=")
+		if !ok {
+			return fmt.Errorf("некорректное переименование %q, ожидается СТАРАЯ=НОВАЯ", pair)
+		}
+		plan.Renames = append(plan.Renames, rollover.GroupRename{From: from, To: to})
+	}
+	plan.Retire = splitList(*retire)
+
+	if *college != "" {
+		collegeID, err := tenant.NewRegistry(tenant.NewRepository(db)).CollegeIDBySlug(ctx, *college)
+		if err != nil {
+			return fmt.Errorf("ошибка поиска колледжа: %w", err)
+		}
+		ctx = tenant.WithCollege(ctx, collegeID)
+	}
+
+	var actorID *uuid.UUID
+	if *actor != "" {
+		admin, err := users.NewRepository(db).GetUserByEmail(ctx, *actor)
+		if err != nil {
+			return fmt.Errorf("администратор %s не найден: %w", *actor, err)
+		}
+		if admin.Role != users.RoleAdmin {
+			return fmt.Errorf("пользователь %s не является администратором", *actor)
+		}
+		actorID = &admin.ID
+	}
+
+	// Кэш пользователей API сбрасывается по истечении TTL, поэтому здесь он не нужен
+	service := rollover.NewService(rollover.NewRepository(db), audit.NewRepository(db), nil, txn.NewManager(db))
+	result, err := service.Run(ctx, plan, actorID)
+	if err != nil {
+		return err
+	}
+
+	if *dryRun {
+		fmt.Println("Проверка (изменения не сохранены):")
+	}
+	fmt.Printf("Архивировано снапшотов прошлого года: %d\n", result.SnapshotsArchived)
+	fmt.Printf("Переведено на следующий курс: %d\n", result.StudentsAdvanced)
+	fmt.Printf("Выпущено студентов: %d\n", result.StudentsGraduated)
+	fmt.Printf("Студентов в переименованных группах: %d\n", result.StudentsRenamed)
+	fmt.Printf("Выпущенные группы: %s\n", orDash(strings.Join(result.RetiredGroups, ", ")))
+	fmt.Printf("Удалено вебхуков групп: %d\n", result.WebhooksRemoved)
+	fmt.Printf("Удалено записей на факультативы: %d\n", result.EnrollmentsRemoved)
+	fmt.Printf("Сброшено подгрупп: %d\n", result.SubgroupsReset)
+	return nil
+}
+
+// splitList разбивает список через запятую, пропуская пустые элементы
+func splitList(value string) []string {
+	var items []string
+	for _, item := range strings.Split(value, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			items = append(items, item)
+		}
+	}
+	return items
+}
@@ -0,0 +1,115 @@
+package main
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"os"
+	"text/tabwriter"
+	"time"
+)
+
+// statsTables основные таблицы, по которым выводится количество строк и размер
+var statsTables = []string{
+	"schedule_snapshots",
+	"schedule_snapshot_archive",
+	"current_schedule",
+	"current_schedule_history",
+	"schedule_changes",
+	"schedule_day_cache",
+	"notifications",
+	"users",
+	"jobs",
+	"outbox_events",
+}
+
+// printStats выводит размер основных таблиц, время последнего парсинга и активные
+// снапшоты колледжей - быструю проверку состояния базы
+func printStats(ctx context.Context, db *sql.DB) error {
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+
+	fmt.Fprintln(w, "Таблица\tСтрок\tРазмер")
+	for _, table := range statsTables {
+		var rows int64
+		var size string
+		query := fmt.Sprintf(`SELECT COUNT(*), pg_size_pretty(pg_total_relation_size('%s')) FROM %s`, table, table)
+		if err := db.QueryRowContext(ctx, query).Scan(&rows, &size); err != nil {
+			return fmt.Errorf("ошибка получения статистики таблицы %s: %w", table, err)
+		}
+		fmt.Fprintf(w, "%s\t%d\t%s\n", table, rows, size)
+	}
+	var dbSize string
+	if err := db.QueryRowContext(ctx, `SELECT pg_size_pretty(pg_database_size(current_database()))`).Scan(&dbSize); err != nil {
+		return fmt.Errorf("ошибка получения размера базы: %w", err)
+	}
+	fmt.Fprintf(w, "Вся база\t\t%s\n", dbSize)
+	if err := w.Flush(); err != nil {
+		return err
+	}
+
+	// Последние завершенные циклы парсинга (по распределенной блокировке)
+	fmt.Println()
+	rows, err := db.QueryContext(ctx, `
+		SELECT name, instance, finished_at
+		FROM job_runs
+		WHERE name LIKE 'scraper:%'
+		ORDER BY name`)
+	if err != nil {
+		return fmt.Errorf("ошибка получения последних запусков парсинга: %w", err)
+	}
+	defer rows.Close()
+
+	fmt.Fprintln(w, "Парсинг\tЭкземпляр\tЗавершен")
+	found := false
+	for rows.Next() {
+		var name, instance string
+		var finishedAt time.Time
+		if err := rows.Scan(&name, &instance, &finishedAt); err != nil {
+			return fmt.Errorf("ошибка чтения запуска парсинга: %w", err)
+		}
+		fmt.Fprintf(w, "%s\t%s\t%s (%s назад)\n", name, instance,
+			finishedAt.Local().Format("02.01.2006 15:04:05"), time.Since(finishedAt).Round(time.Second))
+		found = true
+	}
+	if err := rows.Err(); err != nil {
+		return fmt.Errorf("ошибка чтения запусков парсинга: %w", err)
+	}
+	if !found {
+		fmt.Fprintln(w, "-\t-\tпарсинг еще не выполнялся")
+	}
+	if err := w.Flush(); err != nil {
+		return err
+	}
+
+	// Активные снапшоты основного расписания по колледжам
+	fmt.Println()
+	snapshots, err := db.QueryContext(ctx, `
+		SELECT c.slug, COALESCE(s.name, ''), s.period_start, s.period_end, s.created_at
+		FROM colleges c
+		LEFT JOIN schedule_snapshots s ON s.college_id = c.id AND s.is_active = true
+		ORDER BY c.created_at, c.slug, s.created_at DESC`)
+	if err != nil {
+		return fmt.Errorf("ошибка получения активных снапшотов: %w", err)
+	}
+	defer snapshots.Close()
+
+	fmt.Fprintln(w, "Колледж\tАктивный снапшот\tПериод\tЗагружен")
+	for snapshots.Next() {
+		var slug, name string
+		var periodStart, periodEnd, createdAt sql.NullTime
+		if err := snapshots.Scan(&slug, &name, &periodStart, &periodEnd, &createdAt); err != nil {
+			return fmt.Errorf("ошибка чтения снапшота: %w", err)
+		}
+		if !createdAt.Valid {
+			fmt.Fprintf(w, "%s\tнет\t-\t-\n", slug)
+			continue
+		}
+		fmt.Fprintf(w, "%s\t%s\t%s - %s\t%s\n", slug, name,
+			periodStart.Time.Format("02.01.2006"), periodEnd.Time.Format("02.01.2006"),
+			createdAt.Time.Local().Format("02.01.2006 15:04"))
+	}
+	if err := snapshots.Err(); err != nil {
+		return fmt.Errorf("ошибка чтения активных снапшотов: %w", err)
+	}
+	return w.Flush()
+}
@@ -0,0 +1,71 @@
+package main
+
+import (
+	"context"
+	"database/sql"
+	"flag"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/Ultrahd-dev/student-schedule-app/backend/internal/scraper/gsheets"
+	"github.com/Ultrahd-dev/student-schedule-app/backend/internal/tenant"
+	"github.com/Ultrahd-dev/student-schedule-app/backend/internal/users"
+)
+
+// importTeachers загружает официальный список преподавателей (ФИО, кафедра, должность)
+// в справочник колледжа из CSV файла или Google Таблицы (лист --gid)
+func importTeachers(ctx context.Context, db *sql.DB, args []string) error {
+	fs := flag.NewFlagSet("import-teachers", flag.ExitOnError)
+	replace := fs.Bool("replace", false, "удалить из справочника преподавателей, которых нет в списке")
+	gid := fs.Int64("gid", 0, "gid листа Google Таблицы")
+	college := fs.String("college", "", "код колледжа (по умолчанию колледж по умолчанию)")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if fs.NArg() != 1 {
+		return fmt.Errorf("необходимо указать CSV файл или ссылку на Google Таблицу со списком преподавателей")
+	}
+	source := fs.Arg(0)
+
+	if *college != "" {
+		collegeID, err := tenant.NewRegistry(tenant.NewRepository(db)).CollegeIDBySlug(ctx, *college)
+		if err != nil {
+			return fmt.Errorf("ошибка поиска колледжа: %w", err)
+		}
+		ctx = tenant.WithCollege(ctx, collegeID)
+	}
+
+	var records [][]string
+	if strings.HasPrefix(source, "https://") {
+		exported, err := gsheets.NewClient(nil, nil).ExportToCSVChanges(ctx, source, *gid)
+		if err != nil {
+			return fmt.Errorf("ошибка экспорта таблицы: %w", err)
+		}
+		records = exported
+	} else {
+		file, err := os.Open(source)
+		if err != nil {
+			return fmt.Errorf("ошибка открытия файла: %w", err)
+		}
+		defer file.Close()
+
+		records, err = users.ReadTeacherDirectoryCSV(file)
+		if err != nil {
+			return err
+		}
+	}
+
+	teachers, err := users.ParseTeacherDirectory(records)
+	if err != nil {
+		return err
+	}
+
+	result, err := users.NewService(users.NewRepository(db)).ImportTeacherDirectory(ctx, teachers, *replace)
+	if err != nil {
+		return err
+	}
+	fmt.Printf("  В списке: %d\n  Добавлено: %d\n  Обновлено: %d\n  Удалено: %d\n",
+		len(teachers), result.Created, result.Updated, result.Removed)
+	return nil
+}
@@ -0,0 +1,322 @@
+// Command mockgen генерирует моки интерфейсов хранилищ (users.UserStore,
+// schedule.ScheduleStore и т.д.) для модульных тестов сервисов.
+//
+// Мок - структура с полем-функцией <Метод>Func для каждого метода интерфейса:
+// тест задает только нужные поля, вызов незаданного метода вызывает панику.
+// Запускается через go generate из файла с интерфейсом:
+//
+//	//go:generate go run ../../cmd/mockgen -source store.go -interface UserStore -out ../mocks/user_store.go
+package main
+
+import (
+	"bytes"
+	"flag"
+	"fmt"
+	"go/ast"
+	"go/format"
+	"go/parser"
+	"go/printer"
+	"go/token"
+	"log"
+	"os"
+	"path"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+func main() {
+	source := flag.String("source", "", "файл с интерфейсом")
+	iface := flag.String("interface", "", "имя интерфейса")
+	out := flag.String("out", "", "файл мока")
+	flag.Parse()
+
+	if *source == "" || *iface == "" || *out == "" {
+		flag.Usage()
+		os.Exit(2)
+	}
+
+	code, err := generate(*source, *iface, *out)
+	if err != nil {
+		log.Fatalf("mockgen: %v", err)
+	}
+	if err := os.MkdirAll(filepath.Dir(*out), 0o755); err != nil {
+		log.Fatalf("mockgen: %v", err)
+	}
+	if err := os.WriteFile(*out, code, 0o644); err != nil {
+		log.Fatalf("mockgen: %v", err)
+	}
+}
+
+// method метод интерфейса, подготовленный для шаблона мока
+type method struct {
+	name     string
+	params   string // Параметры с именами: ctx context.Context, id uuid.UUID
+	args     string // Аргументы вызова поля-функции: ctx, id
+	results  string // Результаты: (*users.User, error)
+	hasValue bool   // Метод возвращает значения
+}
+
+// generate строит исходный код мока интерфейса ifaceName из файла source
+func generate(source, ifaceName, out string) ([]byte, error) {
+	fset := token.NewFileSet()
+	file, err := parser.ParseFile(fset, source, nil, parser.ParseComments)
+	if err != nil {
+		return nil, err
+	}
+
+	ifaceType := findInterface(file, ifaceName)
+	if ifaceType == nil {
+		return nil, fmt.Errorf("интерфейс %s не найден в %s", ifaceName, source)
+	}
+
+	pkgName := file.Name.Name
+	pkgPath, err := packagePath(filepath.Dir(source))
+	if err != nil {
+		return nil, err
+	}
+
+	imports := map[string]string{} // имя пакета -> путь импорта
+	for _, spec := range file.Imports {
+		importPath, _ := strconv.Unquote(spec.Path.Value)
+		name := path.Base(importPath)
+		if spec.Name != nil {
+			name = spec.Name.Name
+		}
+		imports[name] = importPath
+	}
+
+	used := map[string]string{pkgName: pkgPath}
+	var methods []method
+	for _, field := range ifaceType.Methods.List {
+		funcType, ok := field.Type.(*ast.FuncType)
+		if !ok || len(field.Names) == 0 {
+			return nil, fmt.Errorf("встроенные интерфейсы не поддерживаются")
+		}
+		qualify(funcType, pkgName, imports, used)
+		methods = append(methods, buildMethod(fset, field.Names[0].Name, funcType))
+	}
+
+	outPkg := filepath.Base(filepath.Dir(out))
+	var buf bytes.Buffer
+	fmt.Fprintf(&buf, "// Code generated by mockgen from %s. DO NOT EDIT.\n\n", path.Join(path.Base(pkgPath), filepath.Base(source)))
+	fmt.Fprintf(&buf, "package %s\n\n", outPkg)
+
+	// Стандартная библиотека отдельной группой, как у goimports
+	std := []string{"sync"}
+	var external []string
+	for _, importPath := range used {
+		if strings.Contains(strings.Split(importPath, "/")[0], ".") {
+			external = append(external, importPath)
+		} else {
+			std = append(std, importPath)
+		}
+	}
+	sort.Strings(std)
+	sort.Strings(external)
+	buf.WriteString("import (\n")
+	for _, importPath := range std {
+		fmt.Fprintf(&buf, "\t%q\n", importPath)
+	}
+	buf.WriteString("\n")
+	for _, importPath := range external {
+		fmt.Fprintf(&buf, "\t%q\n", importPath)
+	}
+	buf.WriteString(")\n\n")
+
+	fmt.Fprintf(&buf, "// %s мок %s.%s.\n", ifaceName, pkgName, ifaceName)
+	buf.WriteString("// Каждый метод вызывает поле <Метод>Func и считает вызовы;\n")
+	buf.WriteString("// вызов метода с незаданным полем вызывает панику.\n")
+	fmt.Fprintf(&buf, "type %s struct {\n", ifaceName)
+	for _, m := range methods {
+		fmt.Fprintf(&buf, "\t%sFunc func(%s) %s\n", m.name, m.params, m.results)
+	}
+	buf.WriteString("\n\tmu    sync.Mutex\n\tcalls map[string]int\n}\n\n")
+	fmt.Fprintf(&buf, "var _ %s.%s = (*%s)(nil)\n\n", pkgName, ifaceName, ifaceName)
+
+	fmt.Fprintf(&buf, `// Calls возвращает число вызовов метода method
+func (m *%[1]s) Calls(method string) int {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return m.calls[method]
+}
+
+// record запоминает вызов метода method
+func (m *%[1]s) record(method string) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if m.calls == nil {
+		m.calls = make(map[string]int)
+	}
+	m.calls[method]++
+}
+`, ifaceName)
+
+	for _, m := range methods {
+		fmt.Fprintf(&buf, "\n// %s вызывает %sFunc\n", m.name, m.name)
+		fmt.Fprintf(&buf, "func (m *%s) %s(%s) %s {\n", ifaceName, m.name, m.params, m.results)
+		fmt.Fprintf(&buf, "\tm.record(%q)\n", m.name)
+		fmt.Fprintf(&buf, "\tif m.%sFunc == nil {\n\t\tpanic(\"%s.%s: не задан %sFunc\")\n\t}\n", m.name, outPkg, ifaceName, m.name)
+		if m.hasValue {
+			fmt.Fprintf(&buf, "\treturn m.%sFunc(%s)\n}\n", m.name, m.args)
+		} else {
+			fmt.Fprintf(&buf, "\tm.%sFunc(%s)\n}\n", m.name, m.args)
+		}
+	}
+
+	code, err := format.Source(buf.Bytes())
+	if err != nil {
+		return nil, fmt.Errorf("ошибка форматирования мока: %w", err)
+	}
+	return code, nil
+}
+
+// findInterface ищет объявление интерфейса name в файле
+func findInterface(file *ast.File, name string) *ast.InterfaceType {
+	for _, decl := range file.Decls {
+		gen, ok := decl.(*ast.GenDecl)
+		if !ok || gen.Tok != token.TYPE {
+			continue
+		}
+		for _, spec := range gen.Specs {
+			typeSpec := spec.(*ast.TypeSpec)
+			if typeSpec.Name.Name != name {
+				continue
+			}
+			if iface, ok := typeSpec.Type.(*ast.InterfaceType); ok {
+				return iface
+			}
+		}
+	}
+	return nil
+}
+
+// qualify добавляет имя пакета pkgName к типам этого пакета в сигнатуре
+// (*User -> *users.User) и отмечает в used пакеты, на которые ссылается сигнатура
+func qualify(node ast.Node, pkgName string, imports, used map[string]string) {
+	ast.Inspect(node, func(n ast.Node) bool {
+		switch expr := n.(type) {
+		case *ast.SelectorExpr:
+			if pkg, ok := expr.X.(*ast.Ident); ok {
+				if importPath, ok := imports[pkg.Name]; ok {
+					used[pkg.Name] = importPath
+				}
+			}
+			return false
+		case *ast.Field:
+			// Имена параметров не квалифицируются, только тип
+			qualifyExpr(&expr.Type, pkgName)
+			qualify(expr.Type, pkgName, imports, used)
+			return false
+		}
+		return true
+	})
+}
+
+// qualifyExpr заменяет экспортируемые идентификаторы типа на pkgName.Ident
+func qualifyExpr(expr *ast.Expr, pkgName string) {
+	switch e := (*expr).(type) {
+	case *ast.Ident:
+		if e.IsExported() {
+			// Новые идентификаторы без позиций, иначе printer переносит строку
+			*expr = &ast.SelectorExpr{X: ast.NewIdent(pkgName), Sel: ast.NewIdent(e.Name)}
+		}
+	case *ast.StarExpr:
+		qualifyExpr(&e.X, pkgName)
+	case *ast.ArrayType:
+		qualifyExpr(&e.Elt, pkgName)
+	case *ast.MapType:
+		qualifyExpr(&e.Key, pkgName)
+		qualifyExpr(&e.Value, pkgName)
+	case *ast.Ellipsis:
+		qualifyExpr(&e.Elt, pkgName)
+	case *ast.ChanType:
+		qualifyExpr(&e.Value, pkgName)
+	case *ast.FuncType:
+		for _, list := range []*ast.FieldList{e.Params, e.Results} {
+			if list == nil {
+				continue
+			}
+			for _, field := range list.List {
+				qualifyExpr(&field.Type, pkgName)
+			}
+		}
+	}
+}
+
+// buildMethod формирует параметры, аргументы и результаты метода для шаблона
+func buildMethod(fset *token.FileSet, name string, funcType *ast.FuncType) method {
+	m := method{name: name}
+
+	var params, args []string
+	index := 0
+	for _, field := range funcType.Params.List {
+		typ := exprString(fset, field.Type)
+		_, variadic := field.Type.(*ast.Ellipsis)
+		names := field.Names
+		if len(names) == 0 {
+			names = []*ast.Ident{ast.NewIdent(fmt.Sprintf("p%d", index))}
+		}
+		for _, n := range names {
+			params = append(params, n.Name+" "+typ)
+			arg := n.Name
+			if variadic {
+				arg += "..."
+			}
+			args = append(args, arg)
+			index++
+		}
+	}
+	m.params = strings.Join(params, ", ")
+	m.args = strings.Join(args, ", ")
+
+	if funcType.Results != nil && len(funcType.Results.List) > 0 {
+		m.hasValue = true
+		var results []string
+		for _, field := range funcType.Results.List {
+			typ := exprString(fset, field.Type)
+			for range max(len(field.Names), 1) {
+				results = append(results, typ)
+			}
+		}
+		m.results = results[0]
+		if len(results) > 1 {
+			m.results = "(" + strings.Join(results, ", ") + ")"
+		}
+	}
+	return m
+}
+
+// exprString печатает выражение типа
+func exprString(fset *token.FileSet, expr ast.Expr) string {
+	var buf bytes.Buffer
+	_ = printer.Fprint(&buf, fset, expr)
+	return buf.String()
+}
+
+// packagePath определяет путь импорта пакета в каталоге dir по ближайшему go.mod
+func packagePath(dir string) (string, error) {
+	abs, err := filepath.Abs(dir)
+	if err != nil {
+		return "", err
+	}
+	for root := abs; ; root = filepath.Dir(root) {
+		data, err := os.ReadFile(filepath.Join(root, "go.mod"))
+		if err == nil {
+			for _, line := range strings.Split(string(data), "\n") {
+				if module, ok := strings.CutPrefix(strings.TrimSpace(line), "module "); ok {
+					rel, err := filepath.Rel(root, abs)
+					if err != nil {
+						return "", err
+					}
+					return strings.TrimSpace(module) + "/" + filepath.ToSlash(rel), nil
+				}
+			}
+			return "", fmt.Errorf("в %s/go.mod не указан module", root)
+		}
+		if filepath.Dir(root) == root {
+			return "", fmt.Errorf("go.mod не найден для %s", dir)
+		}
+	}
+}
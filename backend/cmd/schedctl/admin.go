@@ -0,0 +1,283 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+	"strings"
+	"text/tabwriter"
+
+	schedulepb "github.com/Ultrahd-dev/student-schedule-app/backend/proto/gen/schedule"
+	userspb "github.com/Ultrahd-dev/student-schedule-app/backend/proto/gen/users"
+)
+
+const (
+	// notificationJobPrefix префикс видов задач рассылки уведомлений
+	notificationJobPrefix = "notifications."
+	// maxJobsPage максимальный размер страницы ListJobs: задачи рассылки
+	// отбираются из последних задач всех видов
+	maxJobsPage = 500
+)
+
+// jobStatuses состояния задач для флага --status
+var jobStatuses = map[string]schedulepb.JobStatus{
+	"pending": schedulepb.JobStatus_JOB_STATUS_PENDING,
+	"running": schedulepb.JobStatus_JOB_STATUS_RUNNING,
+	"done":    schedulepb.JobStatus_JOB_STATUS_DONE,
+	"failed":  schedulepb.JobStatus_JOB_STATUS_FAILED,
+}
+
+// notifications выводит задачи рассылки уведомлений из очереди фоновых задач
+func (c *cli) notifications(ctx context.Context, args []string) error {
+	fs := flag.NewFlagSet("notifications", flag.ContinueOnError)
+	statusName := fs.String("status", "", "состояние: pending, running, done, failed")
+	limit := fs.Int("limit", 50, "максимальное число задач")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	req := &schedulepb.ListJobsRequest{PageSize: maxJobsPage}
+	if *statusName != "" {
+		jobStatus, ok := jobStatuses[*statusName]
+		if !ok {
+			return fmt.Errorf("неизвестное состояние %q", *statusName)
+		}
+		req.Status = jobStatus
+	}
+	resp, err := c.client.Schedule().ListJobs(ctx, req)
+	if err != nil {
+		return err
+	}
+
+	w := tabwriter.NewWriter(c.out, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "Создана\tВид\tСостояние\tПопытки\tОшибка\tID\t")
+	found := 0
+	for _, job := range resp.Jobs {
+		if !strings.HasPrefix(job.Kind, notificationJobPrefix) || found >= *limit {
+			continue
+		}
+		found++
+		fmt.Fprintf(w, "%s\t%s\t%s\t%d/%d\t%s\t%s\t\n", job.CreatedAt.AsTime().Local().Format("02.01 15:04"),
+			strings.TrimPrefix(job.Kind, notificationJobPrefix), jobStatusName(job.Status),
+			job.Attempts, job.MaxAttempts, job.LastError, job.Id)
+	}
+	if found == 0 {
+		fmt.Fprintln(c.out, "Задач рассылки нет")
+		return nil
+	}
+	w.Flush()
+	return nil
+}
+
+// jobStatusName название состояния задачи для вывода
+func jobStatusName(jobStatus schedulepb.JobStatus) string {
+	for name, value := range jobStatuses {
+		if value == jobStatus {
+			return name
+		}
+	}
+	return "-"
+}
+
+// admin выполняет действия администратора
+func (c *cli) admin(ctx context.Context, args []string) error {
+	if len(args) == 0 {
+		return errors.New("использование: schedctl admin maintenance|retry-job|flags|flag|flag-reset|delete-user|restore-user|import-roster|kiosks|kiosk-create|kiosk-revoke")
+	}
+
+	schedule := c.client.Schedule()
+	switch args[0] {
+	case "maintenance":
+		resp, err := schedule.RunMaintenance(ctx, &schedulepb.RunMaintenanceRequest{})
+		if err != nil {
+			return err
+		}
+		fmt.Fprintln(c.out, resp.Message)
+	case "retry-job":
+		if len(args) != 2 {
+			return errors.New("использование: schedctl admin retry-job ID")
+		}
+		resp, err := schedule.RetryJob(ctx, &schedulepb.RetryJobRequest{JobId: args[1]})
+		if err != nil {
+			return err
+		}
+		fmt.Fprintln(c.out, resp.Message)
+	case "flags":
+		resp, err := schedule.ListFeatureFlags(ctx, &schedulepb.ListFeatureFlagsRequest{})
+		if err != nil {
+			return err
+		}
+		w := tabwriter.NewWriter(c.out, 0, 0, 2, ' ', 0)
+		fmt.Fprintln(w, "Флаг\tВключен\tДоля\tИзменил\tОписание\t")
+		for _, featureFlag := range resp.Flags {
+			updatedBy := featureFlag.UpdatedBy
+			if featureFlag.IsDefault {
+				updatedBy = "(по умолчанию)"
+			}
+			fmt.Fprintf(w, "%s\t%t\t%d%%\t%s\t%s\t\n", featureFlag.Name, featureFlag.Enabled,
+				flagPercentage(featureFlag.Percentage), updatedBy, featureFlag.Description)
+		}
+		w.Flush()
+	case "flag":
+		return c.setFlag(ctx, args[1:])
+	case "flag-reset":
+		if len(args) != 2 {
+			return errors.New("использование: schedctl admin flag-reset NAME")
+		}
+		resp, err := schedule.ResetFeatureFlag(ctx, &schedulepb.ResetFeatureFlagRequest{Name: args[1]})
+		if err != nil {
+			return err
+		}
+		fmt.Fprintln(c.out, resp.Message)
+	case "delete-user":
+		if len(args) != 2 {
+			return errors.New("использование: schedctl admin delete-user USER_ID")
+		}
+		resp, err := c.client.Users().DeleteUser(ctx, &userspb.DeleteUserRequest{UserId: args[1]})
+		if err != nil {
+			return err
+		}
+		fmt.Fprintf(c.out, "%s: %s, подписок удалено: %d\n", resp.Message, resp.User.GetEmail(), resp.DeletedSubscriptions)
+	case "restore-user":
+		if len(args) != 2 {
+			return errors.New("использование: schedctl admin restore-user USER_ID")
+		}
+		resp, err := c.client.Users().RestoreUser(ctx, &userspb.RestoreUserRequest{UserId: args[1]})
+		if err != nil {
+			return err
+		}
+		fmt.Fprintf(c.out, "%s: %s\n", resp.Message, resp.User.GetEmail())
+	case "import-roster":
+		if len(args) != 2 {
+			return errors.New("использование: schedctl admin import-roster FILE.csv")
+		}
+		return c.importRoster(ctx, args[1])
+	case "kiosks":
+		resp, err := schedule.ListKioskDisplays(ctx, &schedulepb.ListKioskDisplaysRequest{})
+		if err != nil {
+			return err
+		}
+		w := tabwriter.NewWriter(c.out, 0, 0, 2, ' ', 0)
+		fmt.Fprintln(w, "Табло\tКорпуса\tАудитории\tПоследний запрос\tID\t")
+		for _, display := range resp.Displays {
+			lastSeen := "-"
+			if display.LastSeenAt != nil {
+				lastSeen = display.LastSeenAt.AsTime().Local().Format("02.01 15:04")
+			}
+			fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\t\n", display.Name, strings.Join(display.Buildings, ","),
+				strings.Join(display.Classrooms, ","), lastSeen, display.Id)
+		}
+		w.Flush()
+	case "kiosk-create":
+		return c.createKiosk(ctx, args[1:])
+	case "kiosk-revoke":
+		if len(args) != 2 {
+			return errors.New("использование: schedctl admin kiosk-revoke ID")
+		}
+		resp, err := schedule.RevokeKioskDisplay(ctx, &schedulepb.RevokeKioskDisplayRequest{Id: args[1]})
+		if err != nil {
+			return err
+		}
+		fmt.Fprintln(c.out, resp.Message)
+	default:
+		return fmt.Errorf("неизвестное действие администратора %q", args[0])
+	}
+	return nil
+}
+
+// setFlag включает или выключает флаг функции: NAME on|off [--percentage N]
+func (c *cli) setFlag(ctx context.Context, args []string) error {
+	if len(args) < 2 || (args[1] != "on" && args[1] != "off") {
+		return errors.New("использование: schedctl admin flag NAME on|off [--percentage N]")
+	}
+	fs := flag.NewFlagSet("flag", flag.ContinueOnError)
+	percentage := fs.Int("percentage", 0, "доля пользователей 1-100 (0 - все)")
+	if err := fs.Parse(args[2:]); err != nil {
+		return err
+	}
+
+	resp, err := c.client.Schedule().SetFeatureFlag(ctx, &schedulepb.SetFeatureFlagRequest{
+		Name:       args[0],
+		Enabled:    args[1] == "on",
+		Percentage: int32(*percentage),
+	})
+	if err != nil {
+		return err
+	}
+	fmt.Fprintf(c.out, "%s: включен %t, доля %d%%\n", resp.Flag.GetName(), resp.Flag.GetEnabled(),
+		flagPercentage(resp.Flag.GetPercentage()))
+	return nil
+}
+
+// createKiosk создает табло расписания: NAME [--buildings B1,B2] [--classrooms C1,C2]
+func (c *cli) createKiosk(ctx context.Context, args []string) error {
+	if len(args) == 0 || strings.HasPrefix(args[0], "-") {
+		return errors.New("использование: schedctl admin kiosk-create NAME [--buildings B1,B2] [--classrooms C1,C2]")
+	}
+	fs := flag.NewFlagSet("kiosk-create", flag.ContinueOnError)
+	buildingList := fs.String("buildings", "", "коды корпусов через запятую")
+	classroomList := fs.String("classrooms", "", "аудитории через запятую")
+	if err := fs.Parse(args[1:]); err != nil {
+		return err
+	}
+
+	resp, err := c.client.Schedule().CreateKioskDisplay(ctx, &schedulepb.CreateKioskDisplayRequest{
+		Name:       args[0],
+		Buildings:  splitList(*buildingList),
+		Classrooms: splitList(*classroomList),
+	})
+	if err != nil {
+		return err
+	}
+	fmt.Fprintf(c.out, "%s: %s (%s)\n", resp.Message, resp.Display.GetName(), resp.Display.GetId())
+	fmt.Fprintf(c.out, "Ключ табло (показывается один раз): %s\n", resp.Key)
+	fmt.Fprintf(c.out, "Адрес табло: http://<адрес REST-фасада>/kiosk?key=%s\n", resp.Key)
+	return nil
+}
+
+// splitList разбивает список через запятую, пустой строке соответствует пустой список
+func splitList(value string) []string {
+	if value == "" {
+		return nil
+	}
+	return strings.Split(value, ",")
+}
+
+// flagPercentage доля пользователей флага для вывода (0 - все пользователи)
+func flagPercentage(percentage int32) int32 {
+	if percentage == 0 {
+		return 100
+	}
+	return percentage
+}
+
+// importRoster регистрирует студентов по списку группы и выводит временные
+// пароли тех, кому не отправлено приглашение
+func (c *cli) importRoster(ctx context.Context, path string) error {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return err
+	}
+	resp, err := c.client.Users().ImportStudentRoster(ctx, &userspb.ImportStudentRosterRequest{Csv: data})
+	if err != nil {
+		return err
+	}
+
+	fmt.Fprintln(c.out, resp.Message)
+	w := tabwriter.NewWriter(c.out, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "Строка\tEmail\tФИО\tГруппа\tВременный пароль\t")
+	for _, account := range resp.Created {
+		password := account.TemporaryPassword
+		if account.Invited {
+			password = "(отправлен на email)"
+		}
+		fmt.Fprintf(w, "%d\t%s\t%s\t%s\t%s\t\n", account.Line, account.Email, account.FullName, account.GroupName, password)
+	}
+	for _, skip := range resp.Skipped {
+		fmt.Fprintf(w, "%d\t%s\t\t\tпропущен: %s\t\n", skip.Line, skip.Email, skip.Reason)
+	}
+	w.Flush()
+	return nil
+}
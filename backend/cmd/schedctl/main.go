@@ -0,0 +1,251 @@
+// Command schedctl - консольный клиент API расписания для отладки и работы
+// по SSH: вход, расписание группы или преподавателя, изменения, рассылки
+// уведомлений и действия администратора. Работает через pkg/client, токен
+// после входа сохраняется в файле и используется следующими командами.
+package main
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/Ultrahd-dev/student-schedule-app/backend/pkg/client"
+	"google.golang.org/grpc/status"
+)
+
+// cli выполняет команды schedctl
+type cli struct {
+	client    *client.Client
+	tokenFile string
+	in        *bufio.Reader
+	out       io.Writer
+}
+
+func main() {
+	addr := flag.String("addr", envOr("SCHEDCTL_ADDR", "localhost:50051"), "адрес gRPC сервера (или SCHEDCTL_ADDR)")
+	college := flag.String("college", os.Getenv("SCHEDCTL_COLLEGE"), "код колледжа для входа (или SCHEDCTL_COLLEGE)")
+	insecure := flag.Bool("insecure", false, "подключаться без TLS (локальный сервер)")
+	tokenFile := flag.String("token-file", defaultTokenFile(), "файл с токеном после входа")
+	timeout := flag.Duration("timeout", time.Minute, "максимальное время выполнения команды")
+	flag.Usage = usage
+	flag.Parse()
+
+	args := flag.Args()
+	if len(args) == 0 {
+		flag.Usage()
+		os.Exit(2)
+	}
+
+	opts := []client.Option{client.WithCollege(*college)}
+	if *insecure {
+		opts = append(opts, client.WithInsecure())
+	}
+	if token, err := readToken(*tokenFile); err == nil && token != "" {
+		opts = append(opts, client.WithToken(token))
+	}
+
+	c, err := client.Dial(*addr, opts...)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "schedctl: %v\n", err)
+		os.Exit(1)
+	}
+	defer c.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), *timeout)
+	defer cancel()
+
+	app := &cli{client: c, tokenFile: *tokenFile, in: bufio.NewReader(os.Stdin), out: os.Stdout}
+	if err := app.run(ctx, args[0], args[1:]); err != nil {
+		fmt.Fprintf(os.Stderr, "schedctl: %s\n", errorMessage(err))
+		os.Exit(1)
+	}
+}
+
+// run выполняет команду command с аргументами args
+func (c *cli) run(ctx context.Context, command string, args []string) error {
+	switch command {
+	case "login":
+		return c.login(ctx, args)
+	case "logout":
+		return c.logout(ctx)
+	case "whoami":
+		return c.whoami(ctx)
+	case "schedule":
+		return c.schedule(ctx, args)
+	case "changes":
+		return c.changes(ctx, args)
+	case "notifications":
+		return c.notifications(ctx, args)
+	case "admin":
+		return c.admin(ctx, args)
+	case "help":
+		usage()
+		return nil
+	default:
+		return fmt.Errorf("неизвестная команда %q (см. schedctl help)", command)
+	}
+}
+
+// login выполняет вход и сохраняет токен. Пароль читается из SCHEDCTL_PASSWORD
+// или со стандартного ввода, код 2FA - со стандартного ввода.
+func (c *cli) login(ctx context.Context, args []string) error {
+	if len(args) != 1 {
+		return errors.New("использование: schedctl login EMAIL")
+	}
+
+	password := os.Getenv("SCHEDCTL_PASSWORD")
+	if password == "" {
+		var err error
+		if password, err = c.prompt("Пароль: "); err != nil {
+			return err
+		}
+	}
+
+	user, err := c.client.Login(ctx, args[0], password)
+	var challenge *client.TwoFactorRequiredError
+	if errors.As(err, &challenge) {
+		code, promptErr := c.prompt("Код двухфакторной аутентификации: ")
+		if promptErr != nil {
+			return promptErr
+		}
+		user, err = c.client.VerifyTwoFactor(ctx, challenge, code)
+	}
+	if err != nil {
+		return err
+	}
+
+	if err := writeToken(c.tokenFile, c.client.Token()); err != nil {
+		return err
+	}
+	fmt.Fprintf(c.out, "Вход выполнен: %s (%s)\n", user.Email, user.Role)
+	if user.PasswordChangeRequired {
+		fmt.Fprintln(c.out, "Пароль временный: смените его в приложении")
+	}
+	return nil
+}
+
+// logout отзывает токен и удаляет файл токена
+func (c *cli) logout(ctx context.Context) error {
+	if err := c.client.Logout(ctx); err != nil {
+		return err
+	}
+	if err := os.Remove(c.tokenFile); err != nil && !os.IsNotExist(err) {
+		return err
+	}
+	fmt.Fprintln(c.out, "Выход выполнен")
+	return nil
+}
+
+// whoami выводит профиль текущего пользователя
+func (c *cli) whoami(ctx context.Context) error {
+	profile, err := c.client.Profile(ctx)
+	if err != nil {
+		return err
+	}
+
+	fmt.Fprintf(c.out, "%s (%s)\n", profile.User.Email, profile.User.Role)
+	if student := profile.Student; student != nil {
+		fmt.Fprintf(c.out, "%s, группа %s, курс %d\n", student.FullName, student.GroupName, student.Course)
+		if student.Subgroup > 0 {
+			fmt.Fprintf(c.out, "Подгруппа: %d\n", student.Subgroup)
+		}
+	}
+	if teacher := profile.Teacher; teacher != nil {
+		fmt.Fprintf(c.out, "%s, %s, %s\n", teacher.FullName, teacher.Position, teacher.Department)
+	}
+	return nil
+}
+
+// prompt выводит приглашение и читает строку со стандартного ввода
+func (c *cli) prompt(text string) (string, error) {
+	fmt.Fprint(os.Stderr, text)
+	line, err := c.in.ReadString('\n')
+	if err != nil && line == "" {
+		return "", fmt.Errorf("ошибка чтения ввода: %w", err)
+	}
+	return strings.TrimSpace(line), nil
+}
+
+// defaultTokenFile путь к файлу токена в каталоге настроек пользователя
+func defaultTokenFile() string {
+	dir, err := os.UserConfigDir()
+	if err != nil {
+		return ".schedctl-token"
+	}
+	return filepath.Join(dir, "schedctl", "token")
+}
+
+// readToken читает сохраненный токен
+func readToken(path string) (string, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return "", err
+	}
+	return strings.TrimSpace(string(data)), nil
+}
+
+// writeToken сохраняет токен в файл, доступный только владельцу
+func writeToken(path, token string) error {
+	if err := os.MkdirAll(filepath.Dir(path), 0o700); err != nil {
+		return fmt.Errorf("ошибка создания каталога токена: %w", err)
+	}
+	if err := os.WriteFile(path, []byte(token+"\n"), 0o600); err != nil {
+		return fmt.Errorf("ошибка сохранения токена: %w", err)
+	}
+	return nil
+}
+
+// errorMessage возвращает текст ошибки сервера без префикса gRPC
+func errorMessage(err error) string {
+	if s, ok := status.FromError(err); ok {
+		return s.Message()
+	}
+	return err.Error()
+}
+
+// envOr возвращает значение переменной окружения key или fallback
+func envOr(key, fallback string) string {
+	if value := os.Getenv(key); value != "" {
+		return value
+	}
+	return fallback
+}
+
+func usage() {
+	fmt.Println("Использование: schedctl [--addr HOST:PORT] [--college C] [--insecure] [--token-file FILE] [команда]")
+	fmt.Println("Адрес сервера и колледж можно задать переменными SCHEDCTL_ADDR и SCHEDCTL_COLLEGE")
+	fmt.Println("Доступные команды:")
+	fmt.Println("  login EMAIL          - Войти (пароль из SCHEDCTL_PASSWORD или со стандартного ввода)")
+	fmt.Println("  logout               - Выйти и отозвать токен")
+	fmt.Println("  whoami               - Показать текущего пользователя")
+	fmt.Println("  schedule [--group G | --teacher T] [--date D] [--week] - Расписание (по умолчанию свое) на день или неделю")
+	fmt.Println("  changes [--snapshot ID | --overlapping | --moderation] - Изменения активного снапшота, пересекающиеся или ожидающие модерации")
+	fmt.Println("  notifications [--status S] [--limit N] - Задачи рассылки уведомлений (администратор)")
+	fmt.Println("  admin maintenance    - Запустить задачи обслуживания")
+	fmt.Println("  admin retry-job ID   - Повторить задачу, исчерпавшую попытки")
+	fmt.Println("  admin flags          - Показать флаги функций")
+	fmt.Println("  admin flag NAME on|off [--percentage N] - Включить или выключить флаг функции")
+	fmt.Println("  admin flag-reset NAME - Вернуть флагу значение по умолчанию")
+	fmt.Println("  admin delete-user ID - Удалить пользователя (вход запрещается, подписки удаляются)")
+	fmt.Println("  admin restore-user ID - Восстановить удаленного пользователя")
+	fmt.Println("  admin import-roster FILE.csv - Зарегистрировать студентов по списку (email, ФИО, группа, номер)")
+	fmt.Println("  admin kiosks         - Показать табло расписания в коридорах")
+	fmt.Println("  admin kiosk-create NAME [--buildings B1,B2] [--classrooms C1,C2] - Создать табло и показать его ключ")
+	fmt.Println("  admin kiosk-revoke ID - Отключить табло")
+	fmt.Println("")
+	fmt.Println("Примеры:")
+	fmt.Println("  schedctl --addr schedule.example.ru:443 --college kit login admin@college.ru")
+	fmt.Println("  schedctl schedule --group ИС-21 --week")
+	fmt.Println("  schedctl schedule --teacher Иванов --date 2025-09-01")
+	fmt.Println("  schedctl changes --overlapping")
+	fmt.Println("  schedctl notifications --status failed")
+	fmt.Println("  schedctl admin flag changes.moderated on")
+	fmt.Println("  schedctl admin kiosk-create \"Корпус 2, 1 этаж\" --buildings 2 --classrooms Спортзал")
+}
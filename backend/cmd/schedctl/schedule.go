@@ -0,0 +1,194 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"sort"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/Ultrahd-dev/student-schedule-app/backend/pkg/client"
+	schedulepb "github.com/Ultrahd-dev/student-schedule-app/backend/proto/gen/schedule"
+)
+
+// maxTeacherLessons максимум занятий преподавателя, запрашиваемых поиском
+const maxTeacherLessons = 200
+
+// schedule выводит расписание группы, преподавателя или текущего пользователя
+// на день или неделю
+func (c *cli) schedule(ctx context.Context, args []string) error {
+	fs := flag.NewFlagSet("schedule", flag.ContinueOnError)
+	group := fs.String("group", "", "группа")
+	teacher := fs.String("teacher", "", "преподаватель (фамилия или ФИО)")
+	dateStr := fs.String("date", "", "дата в формате ГГГГ-ММ-ДД (по умолчанию сегодня)")
+	week := fs.Bool("week", false, "вся неделя (пн-вс), в которую входит дата")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if *group != "" && *teacher != "" {
+		return errors.New("укажите только одно из --group и --teacher")
+	}
+
+	date, err := parseDate(*dateStr)
+	if err != nil {
+		return err
+	}
+	from, to := date, date
+	if *week {
+		from = date.AddDate(0, 0, -(int(date.Weekday())+6)%7)
+		to = from.AddDate(0, 0, 6)
+	}
+
+	var lessons []client.Lesson
+	switch {
+	case *group != "":
+		for day := from; !day.After(to); day = day.AddDate(0, 0, 1) {
+			dayLessons, err := c.client.GroupSchedule(ctx, *group, day)
+			if err != nil {
+				return err
+			}
+			lessons = append(lessons, dayLessons...)
+		}
+	case *teacher != "":
+		// Поиск находит занятия и по предмету или аудитории: оставляем только
+		// занятия преподавателя
+		results, err := c.client.Search(ctx, *teacher, from, to, maxTeacherLessons)
+		if err != nil {
+			return err
+		}
+		name := strings.ToLower(*teacher)
+		for _, result := range results {
+			if strings.Contains(strings.ToLower(result.Lesson.Teacher), name) {
+				lessons = append(lessons, result.Lesson)
+			}
+		}
+	default:
+		if lessons, err = c.client.MySchedule(ctx, date, *week); err != nil {
+			return err
+		}
+	}
+
+	c.printLessons(lessons)
+	return nil
+}
+
+// printLessons выводит занятия по дням; замены отмечены звездочкой
+func (c *cli) printLessons(lessons []client.Lesson) {
+	if len(lessons) == 0 {
+		fmt.Fprintln(c.out, "Занятий нет")
+		return
+	}
+
+	sort.SliceStable(lessons, func(i, j int) bool {
+		if !lessons[i].Date.Equal(lessons[j].Date) {
+			return lessons[i].Date.Before(lessons[j].Date)
+		}
+		return lessons[i].TimeStart < lessons[j].TimeStart
+	})
+
+	w := tabwriter.NewWriter(c.out, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "Дата\tВремя\tПредмет\tПреподаватель\tАудитория\tГруппа\t")
+	for _, lesson := range lessons {
+		subject := lesson.Subject
+		if lesson.Source == client.SourceChange {
+			subject = "* " + subject
+		}
+		if lesson.Subgroup > 0 {
+			subject += fmt.Sprintf(" (подгруппа %d)", lesson.Subgroup)
+		}
+		fmt.Fprintf(w, "%s\t%s-%s\t%s\t%s\t%s\t%s\t\n", lesson.Date.Format("02.01 Mon"),
+			lesson.TimeStart, lesson.TimeEnd, subject, lesson.Teacher, lesson.Classroom, lesson.GroupName)
+	}
+	w.Flush()
+}
+
+// changes выводит изменения активного снапшота (или снапшота --snapshot),
+// изменения, пересекающиеся с другими занятиями, или ожидающие модерации
+func (c *cli) changes(ctx context.Context, args []string) error {
+	fs := flag.NewFlagSet("changes", flag.ContinueOnError)
+	snapshotID := fs.String("snapshot", "", "ID снапшота (по умолчанию активный)")
+	overlapping := fs.Bool("overlapping", false, "изменения, пересекающиеся с другими занятиями группы (администратор)")
+	moderation := fs.Bool("moderation", false, "изменения, ожидающие модерации (администратор)")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	schedule := c.client.Schedule()
+	var changes []*schedulepb.ScheduleChange
+	switch {
+	case *overlapping:
+		resp, err := schedule.ListOverlappingChanges(ctx, &schedulepb.ListOverlappingChangesRequest{})
+		if err != nil {
+			return err
+		}
+		changes = resp.Changes
+	case *moderation:
+		resp, err := schedule.ListChangesAwaitingModeration(ctx, &schedulepb.ListChangesAwaitingModerationRequest{})
+		if err != nil {
+			return err
+		}
+		changes = resp.Changes
+	default:
+		if *snapshotID == "" {
+			resp, err := schedule.GetActiveScheduleSnapshot(ctx, &schedulepb.GetActiveScheduleSnapshotRequest{})
+			if err != nil {
+				return err
+			}
+			*snapshotID = resp.GetSnapshot().GetId()
+			fmt.Fprintf(c.out, "Снапшот %s\n", resp.GetSnapshot().GetName())
+		}
+		resp, err := schedule.ListSnapshotChanges(ctx, &schedulepb.ListSnapshotChangesRequest{SnapshotId: *snapshotID})
+		if err != nil {
+			return err
+		}
+		changes = resp.Changes
+	}
+
+	if len(changes) == 0 {
+		fmt.Fprintln(c.out, "Изменений нет")
+		return nil
+	}
+
+	w := tabwriter.NewWriter(c.out, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "Дата\tГруппа\tВремя\tИзменение\tПредмет\tПреподаватель\tАудитория\tID\t")
+	for _, change := range changes {
+		subject := change.Subject
+		if change.OriginalSubject != "" && change.OriginalSubject != change.Subject {
+			subject = change.OriginalSubject + " -> " + subject
+		}
+		fmt.Fprintf(w, "%s\t%s\t%s-%s\t%s\t%s\t%s\t%s\t%s\t\n", change.Date.AsTime().Format("02.01"),
+			change.GroupName, change.TimeStart, change.TimeEnd, changeTypeName(change.ChangeType),
+			subject, change.Teacher, change.Classroom, change.Id)
+	}
+	w.Flush()
+	return nil
+}
+
+// changeTypeName название вида изменения
+func changeTypeName(changeType schedulepb.ScheduleChangeType) string {
+	switch changeType {
+	case schedulepb.ScheduleChangeType_SCHEDULE_CHANGE_TYPE_REPLACEMENT:
+		return "замена"
+	case schedulepb.ScheduleChangeType_SCHEDULE_CHANGE_TYPE_CANCELLATION:
+		return "отмена"
+	case schedulepb.ScheduleChangeType_SCHEDULE_CHANGE_TYPE_ADDITION:
+		return "добавление"
+	}
+	return "-"
+}
+
+// parseDate разбирает дату ГГГГ-ММ-ДД; пустая строка - сегодня
+func parseDate(value string) (time.Time, error) {
+	if value == "" {
+		now := time.Now()
+		return time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.Local), nil
+	}
+	date, err := time.ParseInLocation("2006-01-02", value, time.Local)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("некорректная дата %q, ожидается ГГГГ-ММ-ДД", value)
+	}
+	return date, nil
+}
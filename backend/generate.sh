@@ -21,4 +21,19 @@ protoc --proto_path=proto \
   --go-grpc_out=proto/gen \
   proto/schedule.proto
 
+# Генерируем Go код из files.proto
+protoc --proto_path=proto \
+  --go_out=proto/gen \
+  --go-grpc_out=proto/gen \
+  proto/files.proto
+
+# Генерируем Go код из notifications.proto
+protoc --proto_path=proto \
+  --go_out=proto/gen \
+  --go-grpc_out=proto/gen \
+  proto/notifications.proto
+
+# Генерируем моки хранилищ (internal/mocks)
+go generate ./internal/...
+
 echo "Генерация завершена успешно!"
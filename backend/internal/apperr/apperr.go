@@ -0,0 +1,51 @@
+// Package apperr содержит общие доменные ошибки. Сервисы оборачивают их
+// (fmt.Errorf("...: %w", apperr.ErrNotFound)), а gRPC слой по ним выбирает
+// код ответа (см. internal/grpc/middleware). Текст самих ошибок безопасно
+// показывать клиенту, в отличие от текста оборачивающих их ошибок.
+//
+// Ошибки пакетов, текст которых адресован пользователю, создаются через New
+// с одним из видов ниже: gRPC слой показывает клиенту их полный текст, включая
+// подробности вида fmt.Errorf("%w: не указано название", ErrInvalidCourse).
+// Поэтому такие ошибки не оборачиваются текстом внутренних ошибок.
+package apperr
+
+import "errors"
+
+// Виды доменных ошибок
+var (
+	ErrNotFound      = errors.New("не найдено")
+	ErrAlreadyExists = errors.New("уже существует")
+	ErrUnauthorized  = errors.New("неверные учетные данные")
+	ErrForbidden     = errors.New("недостаточно прав")
+	ErrValidation    = errors.New("некорректные данные")
+	// ErrConflict действие недоступно в текущем состоянии (например, 2FA уже подключена)
+	ErrConflict = errors.New("действие недоступно")
+)
+
+// Error доменная ошибка вида Kind с текстом для пользователя
+type Error struct {
+	Kind error
+	Text string
+}
+
+// New создает доменную ошибку вида kind (ErrNotFound, ErrValidation и т.д.)
+func New(kind error, text string) *Error {
+	return &Error{Kind: kind, Text: text}
+}
+
+// Error возвращает текст ошибки для пользователя
+func (e *Error) Error() string {
+	return e.Text
+}
+
+// Unwrap возвращает вид ошибки, чтобы errors.Is(err, apperr.ErrNotFound) находил ее
+func (e *Error) Unwrap() error {
+	return e.Kind
+}
+
+// UserFacing проверяет, что err - доменная ошибка (New), полный текст которой
+// безопасно показать пользователю
+func UserFacing(err error) bool {
+	var domainErr *Error
+	return errors.As(err, &domainErr)
+}
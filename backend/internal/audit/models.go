@@ -0,0 +1,49 @@
+// Package audit реализует журнал событий безопасности (входы, регистрации, смены ролей)
+package audit
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// EventType тип события безопасности
+type EventType string
+
+const (
+	EventRegistration    EventType = "registration"      // Регистрация пользователя
+	EventLogin           EventType = "login"             // Успешный вход
+	EventLoginFailed     EventType = "login_failed"      // Неудачный вход
+	EventPasswordChange  EventType = "password_change"   // Смена пароля
+	EventRoleChange      EventType = "role_change"       // Смена роли пользователя
+	EventTokenRevocation EventType = "token_revocation"  // Отзыв токена
+	EventTwoFactorChange EventType = "two_factor_change" // Подключение или отключение 2FA
+	EventUserExport      EventType = "user_export"       // Выгрузка данных пользователей
+	EventRollover        EventType = "academic_rollover" // Переход на новый учебный год
+	EventUserDeletion    EventType = "user_deletion"     // Удаление пользователя администратором
+	EventUserRestore     EventType = "user_restore"      // Восстановление удаленного пользователя
+)
+
+// Event событие журнала безопасности
+type Event struct {
+	ID        uuid.UUID  `db:"id"`
+	Type      EventType  `db:"event_type"`
+	UserID    *uuid.UUID `db:"user_id"`  // Пользователь, к которому относится событие
+	ActorID   *uuid.UUID `db:"actor_id"` // Кто выполнил действие, если не сам пользователь
+	Email     string     `db:"email"`
+	IP        string     `db:"ip"`
+	UserAgent string     `db:"user_agent"`
+	Details   string     `db:"details"`
+	CollegeID uuid.UUID  `db:"college_id"`
+	CreatedAt time.Time  `db:"created_at"`
+}
+
+// Filter условия выборки событий колледжа из контекста; пустые поля не ограничивают выборку
+type Filter struct {
+	UserID *uuid.UUID
+	Type   EventType
+	From   *time.Time
+	To     *time.Time
+	Limit  int
+	Offset int
+}
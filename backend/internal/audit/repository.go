@@ -0,0 +1,135 @@
+package audit
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"strings"
+
+	"github.com/Ultrahd-dev/student-schedule-app/backend/internal/tenant"
+	"github.com/Ultrahd-dev/student-schedule-app/backend/internal/txn"
+	"github.com/google/uuid"
+)
+
+// Repository предоставляет доступ к хранению журнала безопасности
+type Repository struct {
+	db *sql.DB
+}
+
+// NewRepository создает новый репозиторий журнала
+func NewRepository(db *sql.DB) *Repository {
+	return &Repository{db: db}
+}
+
+// CreateEvent сохраняет событие журнала колледжа из контекста. В транзакции из контекста (см. txn.Manager)
+// событие записывается, только если фиксируется действие, к которому оно относится.
+func (r *Repository) CreateEvent(ctx context.Context, event *Event) error {
+	query := `
+		INSERT INTO audit_events (id, event_type, user_id, actor_id, email, ip, user_agent, details, college_id)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)
+		RETURNING created_at`
+
+	event.CollegeID = tenant.CollegeID(ctx)
+	err := txn.From(ctx, r.db).QueryRowContext(ctx, query,
+		event.ID,
+		event.Type,
+		event.UserID,
+		event.ActorID,
+		event.Email,
+		event.IP,
+		event.UserAgent,
+		event.Details,
+		event.CollegeID).
+		Scan(&event.CreatedAt)
+	if err != nil {
+		return fmt.Errorf("failed to create audit event: %w", err)
+	}
+
+	return nil
+}
+
+// IsKnownLogin проверяет, входил ли пользователь раньше с этого IP-адреса и устройства
+// (User-Agent). Если пользователь еще ни разу не входил, вход тоже считается известным.
+func (r *Repository) IsKnownLogin(ctx context.Context, userID uuid.UUID, ip, userAgent string) (bool, error) {
+	query := `
+		SELECT COUNT(*) = 0 OR COALESCE(BOOL_OR(COALESCE(ip, '') = $2 AND COALESCE(user_agent, '') = $3), false)
+		FROM audit_events
+		WHERE user_id = $1 AND event_type = 'login'`
+
+	var known bool
+	if err := r.db.QueryRowContext(ctx, query, userID, ip, userAgent).Scan(&known); err != nil {
+		return false, fmt.Errorf("failed to check login history: %w", err)
+	}
+	return known, nil
+}
+
+// ListEvents получает события колледжа из контекста по фильтру, от новых к старым, и общее количество подходящих событий
+func (r *Repository) ListEvents(ctx context.Context, filter Filter) ([]Event, int, error) {
+	var conditions []string
+	var args []interface{}
+	addCondition := func(condition string, arg interface{}) {
+		args = append(args, arg)
+		conditions = append(conditions, fmt.Sprintf(condition, len(args)))
+	}
+
+	addCondition("college_id = $%d", tenant.CollegeID(ctx))
+	if filter.UserID != nil {
+		addCondition("user_id = $%d", *filter.UserID)
+	}
+	if filter.Type != "" {
+		addCondition("event_type = $%d", filter.Type)
+	}
+	if filter.From != nil {
+		addCondition("created_at >= $%d", *filter.From)
+	}
+	if filter.To != nil {
+		addCondition("created_at < $%d", *filter.To)
+	}
+
+	where := "WHERE " + strings.Join(conditions, " AND ")
+
+	var total int
+	if err := r.db.QueryRowContext(ctx, `SELECT COUNT(*) FROM audit_events `+where, args...).Scan(&total); err != nil {
+		return nil, 0, fmt.Errorf("failed to count audit events: %w", err)
+	}
+
+	query := fmt.Sprintf(`
+		SELECT id, event_type, user_id, actor_id, COALESCE(email, ''), COALESCE(ip, ''),
+		       COALESCE(user_agent, ''), COALESCE(details, ''), created_at
+		FROM audit_events
+		%s
+		ORDER BY created_at DESC, id
+		LIMIT $%d OFFSET $%d`, where, len(args)+1, len(args)+2)
+
+	rows, err := r.db.QueryContext(ctx, query, append(args, filter.Limit, filter.Offset)...)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to list audit events: %w", err)
+	}
+	defer rows.Close()
+
+	var events []Event
+	for rows.Next() {
+		var event Event
+		err := rows.Scan(
+			&event.ID,
+			&event.Type,
+			&event.UserID,
+			&event.ActorID,
+			&event.Email,
+			&event.IP,
+			&event.UserAgent,
+			&event.Details,
+			&event.CreatedAt,
+		)
+		if err != nil {
+			return nil, 0, fmt.Errorf("failed to scan audit event: %w", err)
+		}
+		events = append(events, event)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, 0, fmt.Errorf("error iterating rows: %w", err)
+	}
+
+	return events, total, nil
+}
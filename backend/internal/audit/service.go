@@ -0,0 +1,91 @@
+package audit
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"net"
+	"strings"
+
+	"github.com/google/uuid"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+)
+
+// Максимальный и стандартный размер страницы журнала
+const (
+	defaultPageSize = 50
+	maxPageSize     = 500
+)
+
+// Service записывает и выдает события журнала безопасности
+type Service struct {
+	repo *Repository
+}
+
+// NewService создает новый сервис журнала
+func NewService(repo *Repository) *Service {
+	return &Service{repo: repo}
+}
+
+// Record сохраняет событие, дополняя его IP-адресом и User-Agent клиента из контекста gRPC.
+// Ошибка записи только логируется: журнал не должен ломать вход и регистрацию.
+func (s *Service) Record(ctx context.Context, event Event) {
+	event.ID = uuid.New()
+	if event.IP == "" && event.UserAgent == "" {
+		event.IP, event.UserAgent = ClientInfo(ctx)
+	}
+
+	if err := s.repo.CreateEvent(ctx, &event); err != nil {
+		log.Printf("Ошибка записи события %s в журнал безопасности: %v", event.Type, err)
+	}
+}
+
+// List возвращает страницу событий по фильтру и общее количество подходящих событий
+func (s *Service) List(ctx context.Context, filter Filter) ([]Event, int, error) {
+	if filter.Limit <= 0 {
+		filter.Limit = defaultPageSize
+	}
+	if filter.Limit > maxPageSize {
+		filter.Limit = maxPageSize
+	}
+	if filter.Offset < 0 {
+		filter.Offset = 0
+	}
+
+	events, total, err := s.repo.ListEvents(ctx, filter)
+	if err != nil {
+		return nil, 0, fmt.Errorf("ошибка получения журнала безопасности: %w", err)
+	}
+	return events, total, nil
+}
+
+// IsKnownDevice проверяет по истории входов, входил ли пользователь раньше
+// с IP-адреса и устройства клиента gRPC вызова
+func (s *Service) IsKnownDevice(ctx context.Context, userID uuid.UUID) (bool, error) {
+	ip, userAgent := ClientInfo(ctx)
+	return s.repo.IsKnownLogin(ctx, userID, ip, userAgent)
+}
+
+// ClientInfo возвращает IP-адрес и User-Agent клиента gRPC вызова.
+// За прокси IP берется из заголовка x-forwarded-for.
+func ClientInfo(ctx context.Context) (ip, userAgent string) {
+	if md, ok := metadata.FromIncomingContext(ctx); ok {
+		if values := md.Get("user-agent"); len(values) > 0 {
+			userAgent = values[0]
+		}
+		if values := md.Get("x-forwarded-for"); len(values) > 0 {
+			ip = strings.TrimSpace(strings.Split(values[0], ",")[0])
+		}
+	}
+
+	if ip == "" {
+		if p, ok := peer.FromContext(ctx); ok && p.Addr != nil {
+			ip = p.Addr.String()
+			if host, _, err := net.SplitHostPort(ip); err == nil {
+				ip = host
+			}
+		}
+	}
+	return ip, userAgent
+}
@@ -0,0 +1,141 @@
+package auth
+
+import (
+	"context"
+	"fmt"
+
+	"google.golang.org/grpc"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/reflect/protoregistry"
+)
+
+// RestrictedField поле ответов gRPC, которое видят только роли Roles.
+// Для остальных ролей (в том числе гостевых токенов) поле очищается.
+type RestrictedField struct {
+	Field protoreflect.FullName // Полное имя поля, например "schedule.ScheduleSnapshot.source_url"
+	Roles []string              // Роли пользователя (admin, teacher, student) или guest
+}
+
+// fieldFilter очищает в ответах поля, недоступные роли вызывающего
+type fieldFilter map[protoreflect.FullName]map[string]bool
+
+// FieldFilterInterceptor возвращает gRPC interceptor, очищающий в ответах поля fields,
+// недоступные роли вызывающего, до сериализации ответа. Роль берется не из токена
+// запроса (поле token), а из пользователя, как в остальных interceptor'ах: после
+// понижения роли старый токен не должен открывать поля прежней роли. Поля ищутся
+// во всех вложенных сообщениях, списках и словарях ответа. Ответы на запросы без
+// поля token (регистрация, вход) содержат данные самого пользователя и не фильтруются.
+// Паникует, если поле не найдено в зарегистрированных proto-файлах: это ошибка
+// конфигурации, из-за которой поле не скрывалось бы.
+func (m *Middleware) FieldFilterInterceptor(fields ...RestrictedField) grpc.UnaryServerInterceptor {
+	filter := make(fieldFilter, len(fields))
+	for _, field := range fields {
+		if _, err := protoregistry.GlobalFiles.FindDescriptorByName(field.Field); err != nil {
+			panic(fmt.Sprintf("auth: поле с ограниченным доступом %s не найдено: %v", field.Field, err))
+		}
+		roles := make(map[string]bool, len(field.Roles))
+		for _, role := range field.Roles {
+			roles[role] = true
+		}
+		filter[field.Field] = roles
+	}
+
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		resp, err := handler(ctx, req)
+		if err != nil {
+			return resp, err
+		}
+		tokenReq, ok := req.(tokenRequest)
+		if !ok {
+			return resp, nil
+		}
+		msg, ok := resp.(proto.Message)
+		if !ok || !filter.strip(msg.ProtoReflect(), "", false) {
+			// Токен и пользователь проверяются только для ответов с полями с ограничениями
+			return resp, nil
+		}
+
+		return filter.apply(msg, m.requestRole(ctx, tokenReq.GetToken())), nil
+	}
+}
+
+// requestRole возвращает роль вызывающего по токену: роль пользователя из базы
+// (через кэш пользователей) или guest для гостевого токена. Без действительного
+// токена или активного пользователя возвращает пустую роль - тогда видны только
+// поля без ограничений.
+func (m *Middleware) requestRole(ctx context.Context, token string) string {
+	claims, err := m.jwtManager.ParseToken(ctx, token)
+	if err != nil {
+		return ""
+	}
+	if claims.IsGuest() {
+		return claims.Role
+	}
+
+	user, err := m.userRepo.GetUserByID(ctx, claims.UserID)
+	if err != nil || !user.IsActive {
+		return ""
+	}
+	return string(user.Role)
+}
+
+// apply возвращает ответ без полей, недоступных роли role. Ответ с такими полями
+// копируется: обработчик мог вернуть сообщение из кэша, общее для всех ролей.
+func (f fieldFilter) apply(msg proto.Message, role string) proto.Message {
+	if !f.strip(msg.ProtoReflect(), role, false) {
+		return msg
+	}
+	msg = proto.Clone(msg)
+	f.strip(msg.ProtoReflect(), role, true)
+	return msg
+}
+
+// strip ищет в сообщении m и вложенных сообщениях заполненные поля, недоступные роли
+// role, и очищает их, если clear. Возвращает true, если такие поля есть.
+func (f fieldFilter) strip(m protoreflect.Message, role string, clear bool) bool {
+	found := false
+	var hidden []protoreflect.FieldDescriptor
+	m.Range(func(fd protoreflect.FieldDescriptor, v protoreflect.Value) bool {
+		if roles, ok := f[fd.FullName()]; ok && !roles[role] {
+			found = true
+			hidden = append(hidden, fd)
+			return clear
+		}
+		if fd.Message() == nil {
+			return true
+		}
+
+		switch {
+		case fd.IsList():
+			list := v.List()
+			for i := 0; i < list.Len(); i++ {
+				if f.strip(list.Get(i).Message(), role, clear) {
+					found = true
+				}
+			}
+		case fd.IsMap():
+			if fd.MapValue().Message() == nil {
+				return true
+			}
+			v.Map().Range(func(_ protoreflect.MapKey, value protoreflect.Value) bool {
+				if f.strip(value.Message(), role, clear) {
+					found = true
+				}
+				return true
+			})
+		default:
+			if f.strip(v.Message(), role, clear) {
+				found = true
+			}
+		}
+		return clear || !found
+	})
+
+	if clear {
+		for _, fd := range hidden {
+			m.Clear(fd)
+		}
+	}
+	return found
+}
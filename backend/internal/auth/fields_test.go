@@ -0,0 +1,104 @@
+package auth
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/Ultrahd-dev/student-schedule-app/backend/internal/jwt"
+	"github.com/Ultrahd-dev/student-schedule-app/backend/internal/mocks"
+	"github.com/Ultrahd-dev/student-schedule-app/backend/internal/tenant"
+	"github.com/Ultrahd-dev/student-schedule-app/backend/internal/users"
+	schedulepb "github.com/Ultrahd-dev/student-schedule-app/backend/proto/gen/schedule"
+	userspb "github.com/Ultrahd-dev/student-schedule-app/backend/proto/gen/users"
+	"github.com/google/uuid"
+	"google.golang.org/grpc"
+)
+
+func TestFieldFilter(t *testing.T) {
+	filter := fieldFilter{
+		"schedule.ScheduleSnapshot.source_url": {"admin": true},
+		"users.TeacherProfile.teacher_id":      {"admin": true, "teacher": true},
+	}
+
+	history := &schedulepb.GetScheduleSnapshotsHistoryResponse{
+		Success: true,
+		Snapshots: []*schedulepb.ScheduleSnapshot{
+			{Name: "Весна", SourceUrl: "https://docs.google.com/a"},
+			{Name: "Осень", SourceUrl: "https://docs.google.com/b"},
+		},
+	}
+	filtered := filter.apply(history, "student").(*schedulepb.GetScheduleSnapshotsHistoryResponse)
+	for _, snapshot := range filtered.Snapshots {
+		if snapshot.SourceUrl != "" || snapshot.Name == "" {
+			t.Errorf("студенту возвращен снапшот %+v", snapshot)
+		}
+	}
+	if history.Snapshots[0].SourceUrl == "" {
+		t.Error("очищен исходный ответ обработчика")
+	}
+	if filter.apply(history, "admin") != history {
+		t.Error("ответ администратору изменен")
+	}
+
+	profile := &userspb.GetProfileResponse{Profile: &userspb.GetProfileResponse_TeacherProfile{
+		TeacherProfile: &userspb.TeacherProfile{FullName: "Иванов И.И.", TeacherId: "79001234567"},
+	}}
+	for role, want := range map[string]string{"teacher": "79001234567", "student": "", "guest": "", "": ""} {
+		filtered := filter.apply(profile, role).(*userspb.GetProfileResponse)
+		if got := filtered.GetTeacherProfile().GetTeacherId(); got != want {
+			t.Errorf("роль %q: teacher_id %q, ожидалось %q", role, got, want)
+		}
+		if filtered.GetTeacherProfile().GetFullName() == "" {
+			t.Errorf("роль %q: очищено поле без ограничений", role)
+		}
+	}
+}
+
+func TestFieldFilterInterceptorRole(t *testing.T) {
+	jwtManager := jwt.NewManager("test-secret", time.Hour, time.Hour)
+	user := &users.User{ID: uuid.New(), Email: "admin@example.com", Role: users.RoleAdmin, IsActive: true}
+	store := &mocks.UserStore{
+		GetUserByIDFunc: func(ctx context.Context, id uuid.UUID) (*users.User, error) {
+			return user, nil
+		},
+	}
+	interceptor := NewMiddleware(jwtManager, store).FieldFilterInterceptor(RestrictedField{
+		Field: "schedule.ScheduleSnapshot.source_url",
+		Roles: []string{string(users.RoleAdmin)},
+	})
+
+	// Токен выдан, пока пользователь был администратором
+	token, err := jwtManager.GenerateToken(user.ID, user.Email, string(users.RoleAdmin), tenant.DefaultCollegeID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	call := func() string {
+		t.Helper()
+		resp, err := interceptor(context.Background(), &schedulepb.GetScheduleSnapshotsHistoryRequest{Token: token},
+			&grpc.UnaryServerInfo{FullMethod: schedulepb.ScheduleService_GetScheduleSnapshotsHistory_FullMethodName},
+			func(ctx context.Context, req interface{}) (interface{}, error) {
+				return &schedulepb.GetScheduleSnapshotsHistoryResponse{
+					Snapshots: []*schedulepb.ScheduleSnapshot{{Name: "Весна", SourceUrl: "https://docs.google.com/a"}},
+				}, nil
+			})
+		if err != nil {
+			t.Fatal(err)
+		}
+		return resp.(*schedulepb.GetScheduleSnapshotsHistoryResponse).Snapshots[0].SourceUrl
+	}
+
+	if got := call(); got == "" {
+		t.Error("администратору не возвращен source_url")
+	}
+
+	user.Role = users.RoleStudent
+	if got := call(); got != "" {
+		t.Errorf("после понижения роли возвращен source_url %q", got)
+	}
+
+	user.Role, user.IsActive = users.RoleAdmin, false
+	if got := call(); got != "" {
+		t.Errorf("деактивированному пользователю возвращен source_url %q", got)
+	}
+}
@@ -0,0 +1,208 @@
+package auth
+
+import (
+	"context"
+
+	"github.com/Ultrahd-dev/student-schedule-app/backend/internal/requestid"
+	"github.com/Ultrahd-dev/student-schedule-app/backend/internal/users"
+	"github.com/google/uuid"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// tokenRequest запрос gRPC, содержащий JWT токен в поле token
+type tokenRequest interface {
+	GetToken() string
+}
+
+// groupRequest запрос gRPC к данным одной группы
+type groupRequest interface {
+	tokenRequest
+	GetGroupName() string
+}
+
+// GroupAccessChecker проверяет, что преподаватель ведет занятия у группы
+type GroupAccessChecker interface {
+	TeacherHasGroup(ctx context.Context, teacherID uuid.UUID, groupName string) (bool, error)
+}
+
+// AdminInterceptor возвращает gRPC interceptor, пропускающий вызовы методов adminMethods
+// (полные имена вида "/schedule.ScheduleService/CompareSnapshots") только для
+// активных администраторов. Токен берется из поля token запроса, а информация
+// о пользователе добавляется в контекст (см. UserFromContext).
+// Остальные методы проходят без проверки.
+func (m *Middleware) AdminInterceptor(adminMethods ...string) grpc.UnaryServerInterceptor {
+	protected := make(map[string]bool, len(adminMethods))
+	for _, method := range adminMethods {
+		protected[method] = true
+	}
+
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		if !protected[info.FullMethod] {
+			return handler(ctx, req)
+		}
+
+		tokenReq, ok := req.(tokenRequest)
+		if !ok {
+			// Метод объявлен административным, но не принимает токен - это ошибка конфигурации
+			requestid.Logf(ctx, "Административный метод %s не содержит поля token", info.FullMethod)
+			return nil, status.Errorf(codes.Internal, "Метод недоступен")
+		}
+
+		claims, err := m.jwtManager.ParseToken(ctx, tokenReq.GetToken())
+		if err != nil {
+			return nil, status.Errorf(codes.Unauthenticated, "Неверный токен")
+		}
+		if claims.IsGuest() {
+			return nil, status.Errorf(codes.PermissionDenied, "Действие доступно только администраторам")
+		}
+
+		user, err := m.userRepo.GetUserByID(ctx, claims.UserID)
+		if err != nil {
+			return nil, status.Errorf(codes.Unauthenticated, "Пользователь не найден")
+		}
+		if !user.IsActive {
+			return nil, status.Errorf(codes.PermissionDenied, "Пользователь деактивирован")
+		}
+		if user.Role != users.RoleAdmin {
+			requestid.Logf(ctx, "Отказ в доступе к %s пользователю %s с ролью %s", info.FullMethod, user.Email, user.Role)
+			return nil, status.Errorf(codes.PermissionDenied, "Действие доступно только администраторам")
+		}
+
+		ctx = context.WithValue(ctx, UserContextKey, &UserInfo{
+			ID:    user.ID,
+			Email: user.Email,
+			Role:  string(user.Role),
+		})
+		return handler(ctx, req)
+	}
+}
+
+// GuestInterceptor возвращает gRPC interceptor, ограничивающий гостевые токены
+// методами guestMethods (просмотр расписания). Вызов любого другого метода
+// с гостевым токеном отклоняется: он требует регистрации.
+// Запросы с обычными токенами и без токена проходят без изменений.
+func (m *Middleware) GuestInterceptor(guestMethods ...string) grpc.UnaryServerInterceptor {
+	allowed := make(map[string]bool, len(guestMethods))
+	for _, method := range guestMethods {
+		allowed[method] = true
+	}
+
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		if allowed[info.FullMethod] {
+			return handler(ctx, req)
+		}
+
+		tokenReq, ok := req.(tokenRequest)
+		if !ok {
+			return handler(ctx, req)
+		}
+
+		claims, err := m.jwtManager.ParseToken(ctx, tokenReq.GetToken())
+		if err == nil && claims.IsGuest() {
+			return nil, status.Errorf(codes.PermissionDenied, "Для этого действия требуется регистрация")
+		}
+		return handler(ctx, req)
+	}
+}
+
+// PasswordChangeInterceptor возвращает gRPC interceptor, отклоняющий вызовы пользователей
+// с временным паролем, выданным администратором (users.User.PasswordChangeRequired),
+// пока пароль не сменен: до смены доступны только методы allowedMethods (смена пароля,
+// профиль). Запросы без токена, с гостевым или недействительным токеном проходят
+// без изменений - их проверяют обработчики.
+func (m *Middleware) PasswordChangeInterceptor(allowedMethods ...string) grpc.UnaryServerInterceptor {
+	allowed := make(map[string]bool, len(allowedMethods))
+	for _, method := range allowedMethods {
+		allowed[method] = true
+	}
+
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		if allowed[info.FullMethod] {
+			return handler(ctx, req)
+		}
+
+		tokenReq, ok := req.(tokenRequest)
+		if !ok {
+			return handler(ctx, req)
+		}
+
+		claims, err := m.jwtManager.ParseToken(ctx, tokenReq.GetToken())
+		if err != nil || claims.IsGuest() {
+			return handler(ctx, req)
+		}
+
+		user, err := m.userRepo.GetUserByID(ctx, claims.UserID)
+		if err == nil && user.PasswordChangeRequired {
+			requestid.Logf(ctx, "Отказ в доступе к %s пользователю %s: временный пароль не сменен", info.FullMethod, user.Email)
+			return nil, status.Errorf(codes.FailedPrecondition, PasswordChangeRequiredMessage)
+		}
+		return handler(ctx, req)
+	}
+}
+
+// PasswordChangeRequiredMessage сообщение об отказе пользователю с несмененным временным паролем
+const PasswordChangeRequiredMessage = "Смените временный пароль, выданный администратором"
+
+// TeacherGroupInterceptor возвращает gRPC interceptor, пропускающий вызовы методов groupMethods
+// к данным группы (поле group_name запроса) только администраторам и преподавателям,
+// у которых есть занятия с этой группой (проверяет checker).
+// Остальные методы проходят без проверки.
+func (m *Middleware) TeacherGroupInterceptor(checker GroupAccessChecker, groupMethods ...string) grpc.UnaryServerInterceptor {
+	protected := make(map[string]bool, len(groupMethods))
+	for _, method := range groupMethods {
+		protected[method] = true
+	}
+
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		if !protected[info.FullMethod] {
+			return handler(ctx, req)
+		}
+
+		groupReq, ok := req.(groupRequest)
+		if !ok {
+			requestid.Logf(ctx, "Метод %s не содержит полей token и group_name", info.FullMethod)
+			return nil, status.Errorf(codes.Internal, "Метод недоступен")
+		}
+
+		claims, err := m.jwtManager.ParseToken(ctx, groupReq.GetToken())
+		if err != nil {
+			return nil, status.Errorf(codes.Unauthenticated, "Неверный токен")
+		}
+		if claims.IsGuest() {
+			return nil, status.Errorf(codes.PermissionDenied, "Действие доступно только преподавателям группы")
+		}
+
+		user, err := m.userRepo.GetUserByID(ctx, claims.UserID)
+		if err != nil {
+			return nil, status.Errorf(codes.Unauthenticated, "Пользователь не найден")
+		}
+		if !user.IsActive {
+			return nil, status.Errorf(codes.PermissionDenied, "Пользователь деактивирован")
+		}
+
+		switch user.Role {
+		case users.RoleAdmin:
+		case users.RoleTeacher:
+			ok, err := checker.TeacherHasGroup(ctx, user.ID, groupReq.GetGroupName())
+			if err != nil {
+				requestid.Logf(ctx, "Ошибка проверки доступа преподавателя %s к группе %q: %v", user.Email, groupReq.GetGroupName(), err)
+				return nil, status.Errorf(codes.Internal, "Ошибка проверки доступа")
+			}
+			if !ok {
+				requestid.Logf(ctx, "Отказ в доступе к %s преподавателю %s: нет занятий с группой %q", info.FullMethod, user.Email, groupReq.GetGroupName())
+				return nil, status.Errorf(codes.PermissionDenied, "У вас нет занятий с этой группой")
+			}
+		default:
+			return nil, status.Errorf(codes.PermissionDenied, "Действие доступно только преподавателям группы")
+		}
+
+		ctx = context.WithValue(ctx, UserContextKey, &UserInfo{
+			ID:    user.ID,
+			Email: user.Email,
+			Role:  string(user.Role),
+		})
+		return handler(ctx, req)
+	}
+}
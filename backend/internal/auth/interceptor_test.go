@@ -0,0 +1,54 @@
+package auth
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/Ultrahd-dev/student-schedule-app/backend/internal/jwt"
+	"github.com/Ultrahd-dev/student-schedule-app/backend/internal/mocks"
+	"github.com/Ultrahd-dev/student-schedule-app/backend/internal/tenant"
+	"github.com/Ultrahd-dev/student-schedule-app/backend/internal/users"
+	schedulepb "github.com/Ultrahd-dev/student-schedule-app/backend/proto/gen/schedule"
+	userspb "github.com/Ultrahd-dev/student-schedule-app/backend/proto/gen/users"
+	"github.com/google/uuid"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestPasswordChangeInterceptor(t *testing.T) {
+	jwtManager := jwt.NewManager("test-secret", time.Hour, time.Hour)
+	user := &users.User{ID: uuid.New(), Email: "student@example.com", Role: users.RoleStudent,
+		IsActive: true, PasswordChangeRequired: true}
+	store := &mocks.UserStore{
+		GetUserByIDFunc: func(ctx context.Context, id uuid.UUID) (*users.User, error) {
+			return user, nil
+		},
+	}
+	interceptor := NewMiddleware(jwtManager, store).PasswordChangeInterceptor(userspb.UserService_ChangePassword_FullMethodName)
+
+	token, err := jwtManager.GenerateToken(user.ID, user.Email, string(user.Role), tenant.DefaultCollegeID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	call := func(method string, req interface{}) codes.Code {
+		t.Helper()
+		_, err := interceptor(context.Background(), req, &grpc.UnaryServerInfo{FullMethod: method},
+			func(ctx context.Context, req interface{}) (interface{}, error) { return nil, nil })
+		return status.Code(err)
+	}
+	schedule := &schedulepb.GetScheduleForGroupRequest{Token: token, GroupName: "ИС-21"}
+
+	if code := call(schedulepb.ScheduleService_GetScheduleForGroup_FullMethodName, schedule); code != codes.FailedPrecondition {
+		t.Errorf("расписание с временным паролем: код %s, ожидался %s", code, codes.FailedPrecondition)
+	}
+	if code := call(userspb.UserService_ChangePassword_FullMethodName, &userspb.ChangePasswordRequest{Token: token}); code != codes.OK {
+		t.Errorf("смена временного пароля: код %s", code)
+	}
+
+	user.PasswordChangeRequired = false
+	if code := call(schedulepb.ScheduleService_GetScheduleForGroup_FullMethodName, schedule); code != codes.OK {
+		t.Errorf("расписание после смены пароля: код %s", code)
+	}
+}
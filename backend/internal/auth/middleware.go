@@ -9,6 +9,7 @@ import (
 	"strings"
 
 	"github.com/Ultrahd-dev/student-schedule-app/backend/internal/jwt"
+	"github.com/Ultrahd-dev/student-schedule-app/backend/internal/tenant"
 	"github.com/Ultrahd-dev/student-schedule-app/backend/internal/users"
 	"github.com/google/uuid"
 )
@@ -37,11 +38,11 @@ type UserInfo struct {
 // Middleware предоставляет middleware функции для аутентификации
 type Middleware struct {
 	jwtManager *jwt.Manager
-	userRepo   *users.Repository
+	userRepo   users.UserStore
 }
 
 // NewMiddleware создает новый middleware для аутентификации
-func NewMiddleware(jwtManager *jwt.Manager, userRepo *users.Repository) *Middleware {
+func NewMiddleware(jwtManager *jwt.Manager, userRepo users.UserStore) *Middleware {
 	return &Middleware{
 		jwtManager: jwtManager,
 		userRepo:   userRepo,
@@ -69,12 +70,18 @@ func (m *Middleware) Authenticate(next http.Handler) http.Handler {
 		tokenString := strings.TrimPrefix(authHeader, "Bearer ")
 
 		// Парсим и проверяем токен
-		claims, err := m.jwtManager.ParseToken(tokenString)
+		claims, err := m.jwtManager.ParseToken(r.Context(), tokenString)
 		if err != nil {
 			http.Error(w, fmt.Sprintf("Неверный токен: %v", err), http.StatusUnauthorized)
 			return
 		}
 
+		// Гостевой токен не дает доступа к HTTP API пользователей
+		if claims.IsGuest() {
+			http.Error(w, "Требуется регистрация", http.StatusForbidden)
+			return
+		}
+
 		// Проверяем, что пользователь еще существует и активен
 		user, err := m.userRepo.GetUserByID(r.Context(), claims.UserID)
 		if err != nil {
@@ -94,8 +101,9 @@ func (m *Middleware) Authenticate(next http.Handler) http.Handler {
 			Role:  string(user.Role),
 		}
 
-		// Добавляем информацию о пользователе в контекст запроса
+		// Добавляем информацию о пользователе и его колледж в контекст запроса
 		ctx := context.WithValue(r.Context(), UserContextKey, userInfo)
+		ctx = tenant.WithCollege(ctx, user.CollegeID)
 		next.ServeHTTP(w, r.WithContext(ctx))
 	})
 }
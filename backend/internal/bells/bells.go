@@ -0,0 +1,258 @@
+// Package bells предоставляет расписание звонков колледжей
+// В соответствии с ТЗ: "Расписание звонков"
+package bells
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/Ultrahd-dev/student-schedule-app/backend/internal/clock"
+	"github.com/Ultrahd-dev/student-schedule-app/backend/internal/tenant"
+	"github.com/google/uuid"
+)
+
+// LessonTiming время начала и окончания пары
+type LessonTiming struct {
+	Number    int
+	TimeStart string // "HH:MM"
+	TimeEnd   string // "HH:MM"
+}
+
+// StartMinutes возвращает время начала пары в минутах от полуночи
+func (t LessonTiming) StartMinutes() int {
+	minutes, _ := clock.ParseClock(t.TimeStart)
+	return minutes
+}
+
+// EndMinutes возвращает время окончания пары в минутах от полуночи
+func (t LessonTiming) EndMinutes() int {
+	minutes, _ := clock.ParseClock(t.TimeEnd)
+	return minutes
+}
+
+// Будние дни
+var weekdayTimings = []LessonTiming{
+	{1, "08:15", "09:00"},
+	{2, "09:00", "09:45"},
+	{3, "09:55", "10:40"},
+	{4, "10:40", "11:25"},
+	{5, "11:40", "12:25"},
+	{6, "12:25", "13:10"},
+	{7, "13:30", "14:15"},
+	{8, "14:15", "15:00"},
+	{9, "15:15", "16:00"},
+	{10, "16:00", "16:45"},
+	{11, "16:55", "17:40"},
+	{12, "17:40", "18:25"},
+}
+
+// Суббота
+var saturdayTimings = []LessonTiming{
+	{1, "08:15", "09:00"},
+	{2, "09:00", "09:45"},
+	{3, "09:50", "10:35"},
+	{4, "10:35", "11:20"},
+	{5, "11:35", "12:20"},
+	{6, "12:20", "13:05"},
+	{7, "13:20", "14:05"},
+	{8, "14:05", "14:50"},
+	{9, "15:05", "15:50"},
+	{10, "15:50", "16:35"},
+	{11, "16:40", "17:25"},
+	{12, "17:25", "18:10"},
+}
+
+// dayNames названия дней недели в том виде, в котором они встречаются в таблицах
+var dayNames = map[time.Weekday]string{
+	time.Monday:    "Понедельник",
+	time.Tuesday:   "Вторник",
+	time.Wednesday: "Среда",
+	time.Thursday:  "Четверг",
+	time.Friday:    "Пятница",
+	time.Saturday:  "Суббота",
+	time.Sunday:    "Воскресенье",
+}
+
+// Timings расписание звонков колледжа по дням недели
+type Timings struct {
+	days map[time.Weekday][]LessonTiming // nil - встроенное расписание
+}
+
+// Загруженные из базы расписания звонков по колледжам (SetAll)
+var (
+	mu     sync.RWMutex
+	loaded map[uuid.UUID]map[time.Weekday][]LessonTiming
+)
+
+// SetAll заменяет расписания звонков всех колледжей загруженными из базы
+// (Repository.LoadAll). Колледжи, которых нет в timings, получают встроенное
+// расписание; дни недели, которых нет в расписании колледжа, считаются днями без занятий.
+func SetAll(timings map[uuid.UUID]map[time.Weekday][]LessonTiming) {
+	mu.Lock()
+	defer mu.Unlock()
+	loaded = timings
+}
+
+// For возвращает расписание звонков колледжа из контекста
+func For(ctx context.Context) Timings {
+	return ForCollege(tenant.CollegeID(ctx))
+}
+
+// ForCollege возвращает расписание звонков колледжа
+func ForCollege(collegeID uuid.UUID) Timings {
+	mu.RLock()
+	defer mu.RUnlock()
+	return Timings{days: loaded[collegeID]}
+}
+
+// Builtin возвращает встроенное расписание звонков
+func Builtin() Timings {
+	return Timings{}
+}
+
+// ForWeekday возвращает расписание звонков для дня недели.
+// Для воскресенья возвращается nil - занятий нет.
+func (t Timings) ForWeekday(day time.Weekday) []LessonTiming {
+	if len(t.days) > 0 {
+		return t.days[day]
+	}
+
+	switch day {
+	case time.Sunday:
+		return nil
+	case time.Saturday:
+		return saturdayTimings
+	default:
+		return weekdayTimings
+	}
+}
+
+// ForDayName возвращает расписание звонков по названию дня недели ("Понедельник", ...)
+func (t Timings) ForDayName(name string) ([]LessonTiming, bool) {
+	day, ok := ParseDayName(name)
+	if !ok {
+		return nil, false
+	}
+	timings := t.ForWeekday(day)
+	return timings, timings != nil
+}
+
+// ParseDayName возвращает день недели по его названию на русском
+func ParseDayName(name string) (time.Weekday, bool) {
+	for day, dayName := range dayNames {
+		if dayName == name {
+			return day, true
+		}
+	}
+	return time.Sunday, false
+}
+
+// DayName возвращает название дня недели на русском
+func DayName(day time.Weekday) string {
+	return dayNames[day]
+}
+
+// Lesson возвращает время пары по номеру для дня недели
+func (t Timings) Lesson(day time.Weekday, number int) (LessonTiming, bool) {
+	for _, timing := range t.ForWeekday(day) {
+		if timing.Number == number {
+			return timing, true
+		}
+	}
+	return LessonTiming{}, false
+}
+
+// NumberAt возвращает номер пары, начинающейся в timeStart ("HH:MM")
+func (t Timings) NumberAt(day time.Weekday, timeStart string) (int, bool) {
+	start := clock.NormalizeClock(timeStart)
+	for _, timing := range t.ForWeekday(day) {
+		if timing.TimeStart == start {
+			return timing.Number, true
+		}
+	}
+	return 0, false
+}
+
+// ResolveSlot дополняет описание пары по расписанию звонков:
+// по номеру пары определяет время, а по времени начала - номер пары.
+// Явно указанное время имеет приоритет над расписанием звонков.
+func (t Timings) ResolveSlot(day time.Weekday, number int, timeStart, timeEnd string) (LessonTiming, error) {
+	slot := LessonTiming{
+		Number:    number,
+		TimeStart: clock.NormalizeClock(timeStart),
+		TimeEnd:   clock.NormalizeClock(timeEnd),
+	}
+
+	if slot.TimeStart == "" {
+		if number == 0 {
+			return slot, fmt.Errorf("не указаны ни время начала, ни номер пары")
+		}
+		timing, ok := t.Lesson(day, number)
+		if !ok {
+			return slot, fmt.Errorf("пары №%d нет в расписании звонков на %s", number, DayName(day))
+		}
+		slot.TimeStart = timing.TimeStart
+		if slot.TimeEnd == "" {
+			slot.TimeEnd = timing.TimeEnd
+		}
+		return slot, nil
+	}
+
+	if slot.Number == 0 {
+		if n, ok := t.NumberAt(day, slot.TimeStart); ok {
+			slot.Number = n
+		}
+	}
+	if slot.TimeEnd == "" {
+		timing, ok := t.Lesson(day, slot.Number)
+		if !ok {
+			return slot, fmt.Errorf("не указано время окончания пары, начинающейся в %s", slot.TimeStart)
+		}
+		slot.TimeEnd = timing.TimeEnd
+	}
+
+	return slot, nil
+}
+
+// Validate проверяет расписание звонков одного дня: время указано корректно,
+// пара заканчивается позже, чем начинается, номера пар идут подряд с первого,
+// а пары не пересекаются. Пары сортируются по номеру, а время приводится к виду ЧЧ:ММ.
+func Validate(timings []LessonTiming) error {
+	sort.Slice(timings, func(i, j int) bool { return timings[i].Number < timings[j].Number })
+
+	prevEnd := -1
+	for i := range timings {
+		timing := &timings[i]
+		if timing.Number != i+1 {
+			if i > 0 && timing.Number == timings[i-1].Number {
+				return fmt.Errorf("пара №%d указана дважды", timing.Number)
+			}
+			return fmt.Errorf("пропущена пара №%d", i+1)
+		}
+
+		start, err := clock.ParseClock(timing.TimeStart)
+		if err != nil {
+			return fmt.Errorf("пара №%d: %w", timing.Number, err)
+		}
+		end, err := clock.ParseClock(timing.TimeEnd)
+		if err != nil {
+			return fmt.Errorf("пара №%d: %w", timing.Number, err)
+		}
+		if end <= start {
+			return fmt.Errorf("пара №%d заканчивается (%s) не позже начала (%s)",
+				timing.Number, timing.TimeEnd, timing.TimeStart)
+		}
+		if start < prevEnd {
+			return fmt.Errorf("пара №%d начинается (%s) до окончания пары №%d",
+				timing.Number, timing.TimeStart, timing.Number-1)
+		}
+		prevEnd = end
+
+		timing.TimeStart = clock.FormatClock(start)
+		timing.TimeEnd = clock.FormatClock(end)
+	}
+	return nil
+}
@@ -0,0 +1,41 @@
+package bells
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/Ultrahd-dev/student-schedule-app/backend/internal/tenant"
+	"github.com/google/uuid"
+)
+
+func TestForCollege(t *testing.T) {
+	other := uuid.New()
+	t.Cleanup(func() { SetAll(nil) })
+
+	SetAll(map[uuid.UUID]map[time.Weekday][]LessonTiming{
+		other: {time.Monday: {{1, "09:00", "10:30"}}},
+	})
+
+	// Импорт другого колледжа не меняет расписание звонков колледжа по умолчанию
+	if lesson, ok := For(context.Background()).Lesson(time.Monday, 1); !ok || lesson.TimeStart != "08:15" {
+		t.Errorf("колледж по умолчанию: пара %+v (%v), ожидалось встроенное расписание", lesson, ok)
+	}
+	if _, ok := For(context.Background()).Lesson(time.Tuesday, 1); !ok {
+		t.Error("колледж по умолчанию: нет пар во вторник")
+	}
+
+	timings := For(tenant.WithCollege(context.Background(), other))
+	if lesson, ok := timings.Lesson(time.Monday, 1); !ok || lesson.TimeStart != "09:00" {
+		t.Errorf("импортированное расписание: пара %+v (%v)", lesson, ok)
+	}
+	if day := timings.ForWeekday(time.Tuesday); day != nil {
+		t.Errorf("день без строк в импортированном расписании: %v, ожидался день без занятий", day)
+	}
+
+	// Повторная загрузка без колледжа возвращает ему встроенное расписание
+	SetAll(nil)
+	if lesson, ok := ForCollege(other).Lesson(time.Monday, 1); !ok || lesson.TimeStart != "08:15" {
+		t.Errorf("после удаления: пара %+v (%v), ожидалось встроенное расписание", lesson, ok)
+	}
+}
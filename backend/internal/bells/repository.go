@@ -0,0 +1,118 @@
+package bells
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	"github.com/Ultrahd-dev/student-schedule-app/backend/internal/tenant"
+	"github.com/google/uuid"
+)
+
+// Repository предоставляет доступ к расписанию звонков в базе
+type Repository struct {
+	db *sql.DB
+}
+
+// NewRepository создает новый репозиторий расписания звонков
+func NewRepository(db *sql.DB) *Repository {
+	return &Repository{db: db}
+}
+
+// Load возвращает расписание звонков колледжа из контекста (встроенное, если
+// в базе его нет) без учета загруженных в память расписаний (SetAll)
+func (r *Repository) Load(ctx context.Context) (Timings, error) {
+	query := `
+		SELECT weekday, lesson_number, to_char(time_start, 'HH24:MI'), to_char(time_end, 'HH24:MI')
+		FROM bell_timings
+		WHERE college_id = $1
+		ORDER BY weekday, lesson_number`
+
+	rows, err := r.db.QueryContext(ctx, query, tenant.CollegeID(ctx))
+	if err != nil {
+		return Timings{}, fmt.Errorf("failed to load bell timings: %w", err)
+	}
+	defer rows.Close()
+
+	days := make(map[time.Weekday][]LessonTiming)
+	for rows.Next() {
+		var weekday int
+		var timing LessonTiming
+		if err := rows.Scan(&weekday, &timing.Number, &timing.TimeStart, &timing.TimeEnd); err != nil {
+			return Timings{}, fmt.Errorf("failed to scan bell timing: %w", err)
+		}
+		days[time.Weekday(weekday)] = append(days[time.Weekday(weekday)], timing)
+	}
+
+	if err := rows.Err(); err != nil {
+		return Timings{}, fmt.Errorf("error iterating rows: %w", err)
+	}
+
+	return Timings{days: days}, nil
+}
+
+// LoadAll возвращает расписания звонков из базы по колледжам и дням недели.
+// Колледжи без строк в результат не попадают: у них встроенное расписание.
+func (r *Repository) LoadAll(ctx context.Context) (map[uuid.UUID]map[time.Weekday][]LessonTiming, error) {
+	query := `
+		SELECT college_id, weekday, lesson_number, to_char(time_start, 'HH24:MI'), to_char(time_end, 'HH24:MI')
+		FROM bell_timings
+		ORDER BY college_id, weekday, lesson_number`
+
+	rows, err := r.db.QueryContext(ctx, query)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load bell timings: %w", err)
+	}
+	defer rows.Close()
+
+	timings := make(map[uuid.UUID]map[time.Weekday][]LessonTiming)
+	for rows.Next() {
+		var collegeID uuid.UUID
+		var weekday int
+		var timing LessonTiming
+		if err := rows.Scan(&collegeID, &weekday, &timing.Number, &timing.TimeStart, &timing.TimeEnd); err != nil {
+			return nil, fmt.Errorf("failed to scan bell timing: %w", err)
+		}
+		if timings[collegeID] == nil {
+			timings[collegeID] = make(map[time.Weekday][]LessonTiming)
+		}
+		timings[collegeID][time.Weekday(weekday)] = append(timings[collegeID][time.Weekday(weekday)], timing)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating rows: %w", err)
+	}
+
+	return timings, nil
+}
+
+// Replace заменяет расписание звонков колледжа из контекста целиком
+func (r *Repository) Replace(ctx context.Context, timings map[time.Weekday][]LessonTiming) error {
+	collegeID := tenant.CollegeID(ctx)
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer func() { _ = tx.Rollback() }()
+
+	if _, err := tx.ExecContext(ctx, `DELETE FROM bell_timings WHERE college_id = $1`, collegeID); err != nil {
+		return fmt.Errorf("failed to delete bell timings: %w", err)
+	}
+
+	query := `
+		INSERT INTO bell_timings (college_id, weekday, lesson_number, time_start, time_end)
+		VALUES ($1, $2, $3, $4, $5)`
+	for weekday, day := range timings {
+		for _, timing := range day {
+			if _, err := tx.ExecContext(ctx, query, collegeID, int(weekday), timing.Number, timing.TimeStart, timing.TimeEnd); err != nil {
+				return fmt.Errorf("failed to insert bell timing: %w", err)
+			}
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit bell timings: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,51 @@
+package bells
+
+import "time"
+
+// Состояния учебного дня по расписанию звонков
+const (
+	StateLesson        = "lesson"         // Идет пара
+	StateBreak         = "break"          // Перемена между парами
+	StateBeforeClasses = "before_classes" // Пары сегодня еще не начались
+	StateAfterClasses  = "after_classes"  // Пары сегодня закончились
+	StateDayOff        = "day_off"        // Сегодня пар нет
+)
+
+// Status состояние учебного дня в момент времени
+type Status struct {
+	State  string
+	Lesson *LessonTiming // Идущая пара (StateLesson) или следующая (StateBreak, StateBeforeClasses)
+	// Until момент смены состояния: окончание идущей пары или начало следующей;
+	// после пар и в день без пар - полночь следующего дня
+	Until time.Time
+}
+
+// StatusAt возвращает состояние учебного дня в момент now по расписанию звонков
+// дня недели now. Часовой пояс расписания звонков - часовой пояс now (колледжа).
+func (t Timings) StatusAt(now time.Time) Status {
+	date := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, now.Location())
+	at := func(minutes int) time.Time {
+		return time.Date(date.Year(), date.Month(), date.Day(), minutes/60, minutes%60, 0, 0, now.Location())
+	}
+
+	timings := t.ForWeekday(now.Weekday())
+	if len(timings) == 0 {
+		return Status{State: StateDayOff, Until: date.AddDate(0, 0, 1)}
+	}
+
+	for i := range timings {
+		timing := timings[i]
+		start, end := at(timing.StartMinutes()), at(timing.EndMinutes())
+		if now.Before(start) {
+			state := StateBreak
+			if i == 0 {
+				state = StateBeforeClasses
+			}
+			return Status{State: state, Lesson: &timing, Until: start}
+		}
+		if now.Before(end) {
+			return Status{State: StateLesson, Lesson: &timing, Until: end}
+		}
+	}
+	return Status{State: StateAfterClasses, Until: date.AddDate(0, 0, 1)}
+}
@@ -0,0 +1,38 @@
+package bells
+
+import (
+	"testing"
+	"time"
+)
+
+func TestStatusAt(t *testing.T) {
+	// Понедельник: встроенное расписание звонков будних дней
+	at := func(day, hour, minute int) time.Time {
+		return time.Date(2025, time.September, day, hour, minute, 0, 0, time.UTC)
+	}
+	tests := []struct {
+		name   string
+		now    time.Time
+		state  string
+		number int
+		until  time.Time
+	}{
+		{"до пар", at(1, 7, 50), StateBeforeClasses, 1, at(1, 8, 15)},
+		{"первая пара", at(1, 8, 15), StateLesson, 1, at(1, 9, 0)},
+		{"пары подряд без перемены", at(1, 9, 0), StateLesson, 2, at(1, 9, 45)},
+		{"перемена", at(1, 9, 50), StateBreak, 3, at(1, 9, 55)},
+		{"после пар", at(1, 18, 25), StateAfterClasses, 0, at(2, 0, 0)},
+		{"воскресенье", at(7, 12, 0), StateDayOff, 0, at(8, 0, 0)},
+	}
+	for _, tt := range tests {
+		status := Builtin().StatusAt(tt.now)
+		number := 0
+		if status.Lesson != nil {
+			number = status.Lesson.Number
+		}
+		if status.State != tt.state || number != tt.number || !status.Until.Equal(tt.until) {
+			t.Errorf("%s: получено %s, пара %d, до %v; ожидалось %s, пара %d, до %v",
+				tt.name, status.State, number, status.Until, tt.state, tt.number, tt.until)
+		}
+	}
+}
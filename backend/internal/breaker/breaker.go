@@ -0,0 +1,179 @@
+// Package breaker реализует circuit breaker для внешних зависимостей (сайт
+// колледжа, Google Таблицы): после серии ошибок запросы к зависимости
+// отклоняются сразу, не дожидаясь таймаута, а через паузу пропускается
+// один пробный запрос, по результату которого зависимость снова считается
+// доступной или пауза начинается заново
+package breaker
+
+import (
+	"errors"
+	"fmt"
+	"log"
+	"sync"
+	"time"
+)
+
+// ErrOpen означает, что запрос отклонен: зависимость недоступна и пауза еще не истекла
+var ErrOpen = errors.New("внешний сервис временно недоступен")
+
+// State состояние circuit breaker
+type State int
+
+const (
+	StateClosed   State = iota // Запросы проходят
+	StateHalfOpen              // Пропускается один пробный запрос
+	StateOpen                  // Запросы отклоняются до конца паузы
+)
+
+// String возвращает название состояния
+func (s State) String() string {
+	switch s {
+	case StateClosed:
+		return "closed"
+	case StateHalfOpen:
+		return "half-open"
+	case StateOpen:
+		return "open"
+	}
+	return "unknown"
+}
+
+// Config настройки circuit breaker
+type Config struct {
+	FailureThreshold int           // Ошибок подряд, после которых запросы отклоняются
+	OpenTimeout      time.Duration // Пауза перед пробным запросом
+}
+
+// Stats состояние и счетчики circuit breaker
+type Stats struct {
+	State               State
+	ConsecutiveFailures int
+	Failures            uint64 // Всего неудачных запросов
+	Rejected            uint64 // Всего отклоненных запросов
+	Opened              uint64 // Сколько раз запросы приостанавливались
+}
+
+// Breaker circuit breaker одной внешней зависимости
+type Breaker struct {
+	name     string
+	config   Config
+	mu       sync.Mutex
+	stats    Stats
+	openedAt time.Time
+	probing  bool // Пробный запрос уже выполняется
+}
+
+// New создает circuit breaker зависимости name
+func New(name string, config Config) *Breaker {
+	if config.FailureThreshold <= 0 {
+		config.FailureThreshold = 3
+	}
+	if config.OpenTimeout <= 0 {
+		config.OpenTimeout = 5 * time.Minute
+	}
+
+	return &Breaker{
+		name:   name,
+		config: config,
+	}
+}
+
+// Name возвращает имя зависимости
+func (b *Breaker) Name() string {
+	return b.name
+}
+
+// Allow проверяет, можно ли выполнить запрос. Если запрос разрешен,
+// по его результату нужно вызвать Success или Failure.
+func (b *Breaker) Allow() error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	switch b.stats.State {
+	case StateOpen:
+		if time.Since(b.openedAt) < b.config.OpenTimeout {
+			b.stats.Rejected++
+			return fmt.Errorf("%w: %s (повтор после %s)", ErrOpen, b.name,
+				b.openedAt.Add(b.config.OpenTimeout).Format(time.RFC3339))
+		}
+		b.setState(StateHalfOpen)
+		fallthrough
+	case StateHalfOpen:
+		if b.probing {
+			b.stats.Rejected++
+			return fmt.Errorf("%w: %s (выполняется пробный запрос)", ErrOpen, b.name)
+		}
+		b.probing = true
+	}
+	return nil
+}
+
+// Success отмечает успешный запрос
+func (b *Breaker) Success() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.probing = false
+	b.stats.ConsecutiveFailures = 0
+	if b.stats.State != StateClosed {
+		b.setState(StateClosed)
+	}
+}
+
+// Failure отмечает неудачный запрос
+func (b *Breaker) Failure() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.probing = false
+	b.stats.Failures++
+	b.stats.ConsecutiveFailures++
+
+	// Неудачный пробный запрос или серия ошибок приостанавливает запросы
+	if b.stats.State == StateHalfOpen || b.stats.ConsecutiveFailures >= b.config.FailureThreshold {
+		if b.stats.State != StateOpen {
+			b.stats.Opened++
+		}
+		b.openedAt = time.Now()
+		b.setState(StateOpen)
+	}
+}
+
+// release завершает запрос, результат которого не говорит о доступности зависимости
+func (b *Breaker) release() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.probing = false
+}
+
+// State возвращает текущее состояние
+func (b *Breaker) State() State {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.stats.State
+}
+
+// Stats возвращает состояние и счетчики
+func (b *Breaker) Stats() Stats {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.stats
+}
+
+// setState меняет состояние и пишет переход в журнал. Вызывается под b.mu.
+func (b *Breaker) setState(state State) {
+	if b.stats.State == state {
+		return
+	}
+
+	switch state {
+	case StateOpen:
+		log.Printf("Circuit breaker %s: ошибок подряд - %d, запросы приостановлены на %s",
+			b.name, b.stats.ConsecutiveFailures, b.config.OpenTimeout)
+	case StateHalfOpen:
+		log.Printf("Circuit breaker %s: пауза истекла, выполняем пробный запрос", b.name)
+	case StateClosed:
+		log.Printf("Circuit breaker %s: сервис снова доступен", b.name)
+	}
+	b.stats.State = state
+}
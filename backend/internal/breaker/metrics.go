@@ -0,0 +1,37 @@
+package breaker
+
+import (
+	"github.com/Ultrahd-dev/student-schedule-app/backend/internal/metrics"
+)
+
+// RegisterMetrics добавляет в registry состояние и счетчики circuit breaker'ов
+func RegisterMetrics(registry *metrics.Registry, breakers ...*Breaker) {
+	collect := func(value func(Stats) float64) func() []metrics.Sample {
+		return func() []metrics.Sample {
+			samples := make([]metrics.Sample, 0, len(breakers))
+			for _, b := range breakers {
+				samples = append(samples, metrics.Sample{
+					Labels: map[string]string{"name": b.Name()},
+					Value:  value(b.Stats()),
+				})
+			}
+			return samples
+		}
+	}
+
+	registry.Register("circuit_breaker_state",
+		"Состояние circuit breaker: 0 - запросы проходят, 1 - пробный запрос, 2 - запросы приостановлены",
+		metrics.Gauge, collect(func(s Stats) float64 { return float64(s.State) }))
+	registry.Register("circuit_breaker_consecutive_failures",
+		"Ошибок подряд при запросах к внешнему сервису",
+		metrics.Gauge, collect(func(s Stats) float64 { return float64(s.ConsecutiveFailures) }))
+	registry.Register("circuit_breaker_failures_total",
+		"Неудачных запросов к внешнему сервису",
+		metrics.Counter, collect(func(s Stats) float64 { return float64(s.Failures) }))
+	registry.Register("circuit_breaker_rejected_total",
+		"Запросов, отклоненных без обращения к внешнему сервису",
+		metrics.Counter, collect(func(s Stats) float64 { return float64(s.Rejected) }))
+	registry.Register("circuit_breaker_opened_total",
+		"Сколько раз запросы к внешнему сервису приостанавливались",
+		metrics.Counter, collect(func(s Stats) float64 { return float64(s.Opened) }))
+}
@@ -0,0 +1,48 @@
+package breaker
+
+import (
+	"context"
+	"errors"
+	"net/http"
+)
+
+// Transport пропускает HTTP запросы через circuit breaker.
+// Ошибкой зависимости считаются сетевые ошибки, таймауты и ответы 5xx и 429;
+// остальные ответы означают, что сервис доступен.
+type Transport struct {
+	Breaker *Breaker
+	Base    http.RoundTripper // nil - http.DefaultTransport
+}
+
+// NewTransport создает транспорт, пропускающий запросы через breaker
+func NewTransport(breaker *Breaker, base http.RoundTripper) *Transport {
+	return &Transport{Breaker: breaker, Base: base}
+}
+
+// RoundTrip реализует http.RoundTripper
+func (t *Transport) RoundTrip(req *http.Request) (*http.Response, error) {
+	if err := t.Breaker.Allow(); err != nil {
+		return nil, err
+	}
+
+	base := t.Base
+	if base == nil {
+		base = http.DefaultTransport
+	}
+
+	resp, err := base.RoundTrip(req)
+	switch {
+	case err != nil && errors.Is(req.Context().Err(), context.Canceled):
+		// Запрос отменил вызывающий (например, при остановке сервера) - это не сбой зависимости.
+		// Пробный запрос при этом не засчитывается, следующий запрос станет новым пробным.
+		// Таймаут (context.DeadlineExceeded) считается сбоем.
+		t.Breaker.release()
+	case err != nil:
+		t.Breaker.Failure()
+	case resp.StatusCode >= http.StatusInternalServerError || resp.StatusCode == http.StatusTooManyRequests:
+		t.Breaker.Failure()
+	default:
+		t.Breaker.Success()
+	}
+	return resp, err
+}
@@ -0,0 +1,27 @@
+// Package buildings хранит корпуса колледжа и аудитории в них и предупреждает
+// о переходах между корпусами: если соседние пары проходят в разных корпусах,
+// а перерыв короче времени перехода, вторая пара помечается предупреждением.
+package buildings
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Building корпус колледжа
+type Building struct {
+	ID         uuid.UUID `db:"id"`
+	Code       string    `db:"code"` // Короткое обозначение, например "К2"
+	Name       string    `db:"name"`
+	Address    string    `db:"address"`
+	Classrooms []string  // Аудитории корпуса, как в расписании
+	CreatedAt  time.Time `db:"created_at"`
+	UpdatedAt  time.Time `db:"updated_at"`
+}
+
+// Annotation корпус пары и предупреждение о переходе
+type Annotation struct {
+	Building      string // Код корпуса аудитории (пусто - корпус неизвестен)
+	TravelWarning bool   // Предыдущая пара в другом корпусе, а перерыва не хватает на переход
+}
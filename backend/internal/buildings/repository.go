@@ -0,0 +1,158 @@
+package buildings
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+
+	"github.com/Ultrahd-dev/student-schedule-app/backend/internal/tenant"
+	"github.com/google/uuid"
+)
+
+// Repository предоставляет доступ к корпусам и аудиториям в базе данных
+type Repository struct {
+	db *sql.DB
+}
+
+// NewRepository создает новый репозиторий корпусов
+func NewRepository(db *sql.DB) *Repository {
+	return &Repository{db: db}
+}
+
+// UpsertBuilding создает или изменяет корпус с кодом building.Code в колледже из контекста
+// и заменяет список его аудиторий. Аудитории, закрепленные за другими корпусами,
+// переносятся в этот корпус.
+func (r *Repository) UpsertBuilding(ctx context.Context, building *Building) error {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer func() { _ = tx.Rollback() }()
+
+	collegeID := tenant.CollegeID(ctx)
+	query := `
+		INSERT INTO buildings (id, code, name, address, college_id)
+		VALUES ($1, $2, $3, $4, $5)
+		ON CONFLICT (college_id, code) DO UPDATE
+		SET name = EXCLUDED.name, address = EXCLUDED.address, updated_at = NOW()
+		RETURNING id, created_at, updated_at`
+
+	err = tx.QueryRowContext(ctx, query, building.ID, building.Code, building.Name, building.Address, collegeID).
+		Scan(&building.ID, &building.CreatedAt, &building.UpdatedAt)
+	if err != nil {
+		return fmt.Errorf("failed to upsert building: %w", err)
+	}
+
+	if _, err := tx.ExecContext(ctx, `DELETE FROM classrooms WHERE building_id = $1`, building.ID); err != nil {
+		return fmt.Errorf("failed to clear building classrooms: %w", err)
+	}
+	for _, classroom := range building.Classrooms {
+		_, err := tx.ExecContext(ctx, `
+			INSERT INTO classrooms (name, building_id, college_id)
+			VALUES ($1, $2, $3)
+			ON CONFLICT (college_id, name) DO UPDATE SET building_id = EXCLUDED.building_id`,
+			classroom, building.ID, collegeID)
+		if err != nil {
+			return fmt.Errorf("failed to save classroom %s: %w", classroom, err)
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit building: %w", err)
+	}
+	return nil
+}
+
+// ListBuildings возвращает корпуса колледжа из контекста с аудиториями, упорядоченные по коду
+func (r *Repository) ListBuildings(ctx context.Context) ([]Building, error) {
+	collegeID := tenant.CollegeID(ctx)
+	rows, err := r.db.QueryContext(ctx, `
+		SELECT id, code, name, address, created_at, updated_at
+		FROM buildings
+		WHERE college_id = $1
+		ORDER BY code`, collegeID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get buildings: %w", err)
+	}
+	defer rows.Close()
+
+	var buildings []Building
+	index := make(map[uuid.UUID]int)
+	for rows.Next() {
+		var building Building
+		if err := rows.Scan(&building.ID, &building.Code, &building.Name, &building.Address,
+			&building.CreatedAt, &building.UpdatedAt); err != nil {
+			return nil, fmt.Errorf("failed to scan building: %w", err)
+		}
+		index[building.ID] = len(buildings)
+		buildings = append(buildings, building)
+	}
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating rows: %w", err)
+	}
+
+	classrooms, err := r.db.QueryContext(ctx,
+		`SELECT building_id, name FROM classrooms WHERE college_id = $1 ORDER BY name`, collegeID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get classrooms: %w", err)
+	}
+	defer classrooms.Close()
+
+	for classrooms.Next() {
+		var buildingID uuid.UUID
+		var name string
+		if err := classrooms.Scan(&buildingID, &name); err != nil {
+			return nil, fmt.Errorf("failed to scan classroom: %w", err)
+		}
+		if i, ok := index[buildingID]; ok {
+			buildings[i].Classrooms = append(buildings[i].Classrooms, name)
+		}
+	}
+	if err = classrooms.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating rows: %w", err)
+	}
+
+	return buildings, nil
+}
+
+// ClassroomBuildings возвращает коды корпусов аудиторий колледжа из контекста по имени аудитории
+func (r *Repository) ClassroomBuildings(ctx context.Context) (map[string]string, error) {
+	rows, err := r.db.QueryContext(ctx, `
+		SELECT c.name, b.code
+		FROM classrooms c
+		JOIN buildings b ON b.id = c.building_id
+		WHERE c.college_id = $1`, tenant.CollegeID(ctx))
+	if err != nil {
+		return nil, fmt.Errorf("failed to get classroom buildings: %w", err)
+	}
+	defer rows.Close()
+
+	byClassroom := make(map[string]string)
+	for rows.Next() {
+		var classroom, code string
+		if err := rows.Scan(&classroom, &code); err != nil {
+			return nil, fmt.Errorf("failed to scan classroom building: %w", err)
+		}
+		byClassroom[classroom] = code
+	}
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating rows: %w", err)
+	}
+
+	return byClassroom, nil
+}
+
+// DeleteBuilding удаляет корпус с аудиториями в колледже из контекста
+func (r *Repository) DeleteBuilding(ctx context.Context, code string) (bool, error) {
+	result, err := r.db.ExecContext(ctx,
+		`DELETE FROM buildings WHERE code = $1 AND college_id = $2`, code, tenant.CollegeID(ctx))
+	if err != nil {
+		return false, fmt.Errorf("failed to delete building: %w", err)
+	}
+
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return false, fmt.Errorf("failed to get affected rows: %w", err)
+	}
+	return affected > 0, nil
+}
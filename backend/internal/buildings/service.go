@@ -0,0 +1,216 @@
+package buildings
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+	"unicode/utf8"
+
+	"github.com/Ultrahd-dev/student-schedule-app/backend/internal/apperr"
+	"github.com/Ultrahd-dev/student-schedule-app/backend/internal/clock"
+	"github.com/Ultrahd-dev/student-schedule-app/backend/internal/schedule"
+	"github.com/Ultrahd-dev/student-schedule-app/backend/internal/tenant"
+	"github.com/google/uuid"
+)
+
+// ErrInvalidBuilding некорректные данные корпуса
+var ErrInvalidBuilding = apperr.New(apperr.ErrValidation, "некорректный корпус")
+
+// classroomsTTL время кэширования корпусов аудиторий: они нужны в каждом
+// ответе с расписанием, а меняются редко
+const classroomsTTL = time.Minute
+
+// Config настройки корпусов
+type Config struct {
+	TravelMinutes int // Время перехода между корпусами в минутах
+}
+
+// classroomCache корпуса аудиторий колледжа
+type classroomCache struct {
+	byClassroom map[string]string // Нормализованное имя аудитории -> код корпуса
+	loadedAt    time.Time
+}
+
+// Service управляет корпусами и размечает расписание корпусами аудиторий
+type Service struct {
+	config Config
+	repo   *Repository
+
+	mu    sync.Mutex
+	cache map[uuid.UUID]classroomCache
+}
+
+// NewService создает новый сервис корпусов
+func NewService(config Config, repo *Repository) *Service {
+	if config.TravelMinutes <= 0 {
+		config.TravelMinutes = 10
+	}
+	return &Service{
+		config: config,
+		repo:   repo,
+		cache:  make(map[uuid.UUID]classroomCache),
+	}
+}
+
+// SetBuilding создает или изменяет корпус с кодом building.Code и заменяет список его аудиторий
+func (s *Service) SetBuilding(ctx context.Context, building *Building) error {
+	building.Code = strings.TrimSpace(building.Code)
+	building.Name = strings.TrimSpace(building.Name)
+	building.Address = strings.TrimSpace(building.Address)
+	switch {
+	case building.Code == "":
+		return fmt.Errorf("%w: не указан код", ErrInvalidBuilding)
+	case utf8.RuneCountInString(building.Code) > 20:
+		return fmt.Errorf("%w: код длиннее 20 символов", ErrInvalidBuilding)
+	case building.Name == "":
+		return fmt.Errorf("%w: не указано название", ErrInvalidBuilding)
+	}
+
+	seen := make(map[string]bool)
+	classrooms := make([]string, 0, len(building.Classrooms))
+	for _, classroom := range building.Classrooms {
+		classroom = strings.TrimSpace(classroom)
+		key := normalizeClassroom(classroom)
+		if key == "" || seen[key] {
+			continue
+		}
+		if utf8.RuneCountInString(classroom) > 50 {
+			return fmt.Errorf("%w: имя аудитории %q длиннее 50 символов", ErrInvalidBuilding, classroom)
+		}
+		seen[key] = true
+		classrooms = append(classrooms, classroom)
+	}
+	building.Classrooms = classrooms
+
+	building.ID = uuid.New()
+	if err := s.repo.UpsertBuilding(ctx, building); err != nil {
+		return fmt.Errorf("ошибка сохранения корпуса: %w", err)
+	}
+	s.invalidate(ctx)
+
+	log.Printf("Корпус %s (%s) сохранен, аудиторий: %d", building.Code, building.Name, len(building.Classrooms))
+	return nil
+}
+
+// ListBuildings возвращает корпуса колледжа с аудиториями
+func (s *Service) ListBuildings(ctx context.Context) ([]Building, error) {
+	buildings, err := s.repo.ListBuildings(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("ошибка получения корпусов: %w", err)
+	}
+	return buildings, nil
+}
+
+// DeleteBuilding удаляет корпус и его аудитории
+func (s *Service) DeleteBuilding(ctx context.Context, code string) (bool, error) {
+	deleted, err := s.repo.DeleteBuilding(ctx, strings.TrimSpace(code))
+	if err != nil {
+		return false, fmt.Errorf("ошибка удаления корпуса: %w", err)
+	}
+	s.invalidate(ctx)
+	return deleted, nil
+}
+
+// TravelMinutes возвращает время перехода между корпусами в минутах
+func (s *Service) TravelMinutes() int {
+	return s.config.TravelMinutes
+}
+
+// Annotate размечает записи расписания корпусами аудиторий и предупреждениями о переходе.
+// Записи - расписание одного человека (группы, студента или преподавателя); пара получает
+// предупреждение, если предыдущая пара того же дня проходит в другом корпусе, а перерыв
+// между ними короче времени перехода. Отмененные и онлайн-пары не учитываются.
+// Результат - по разметке на каждую запись в порядке entries.
+func (s *Service) Annotate(ctx context.Context, entries []schedule.CurrentSchedule) ([]Annotation, error) {
+	annotations := make([]Annotation, len(entries))
+	if len(entries) == 0 {
+		return annotations, nil
+	}
+
+	byClassroom, err := s.classroomBuildings(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if len(byClassroom) == 0 {
+		return annotations, nil
+	}
+
+	order := make([]int, 0, len(entries))
+	for i, entry := range entries {
+		annotations[i].Building = byClassroom[normalizeClassroom(entry.Classroom)]
+		if entry.IsActive && entry.MeetingURL == "" {
+			order = append(order, i)
+		}
+	}
+	sort.SliceStable(order, func(a, b int) bool {
+		x, y := entries[order[a]], entries[order[b]]
+		if !x.Date.Equal(y.Date) {
+			return x.Date.Before(y.Date)
+		}
+		return x.TimeStart < y.TimeStart
+	})
+
+	for k := 1; k < len(order); k++ {
+		prev, cur := order[k-1], order[k]
+		if !entries[prev].Date.Equal(entries[cur].Date) {
+			continue
+		}
+		from, to := annotations[prev].Building, annotations[cur].Building
+		if from == "" || to == "" || from == to {
+			continue
+		}
+		prevEnd, err1 := clock.ParseClock(entries[prev].TimeEnd)
+		curStart, err2 := clock.ParseClock(entries[cur].TimeStart)
+		if err1 != nil || err2 != nil {
+			continue
+		}
+		// Параллельные пары (разные подгруппы) переходом не считаются
+		if gap := curStart - prevEnd; gap >= 0 && gap < s.config.TravelMinutes {
+			annotations[cur].TravelWarning = true
+		}
+	}
+	return annotations, nil
+}
+
+// classroomBuildings возвращает корпуса аудиторий колледжа из контекста из кэша или базы
+func (s *Service) classroomBuildings(ctx context.Context) (map[string]string, error) {
+	collegeID := tenant.CollegeID(ctx)
+
+	s.mu.Lock()
+	cached, ok := s.cache[collegeID]
+	s.mu.Unlock()
+	if ok && time.Since(cached.loadedAt) < classroomsTTL {
+		return cached.byClassroom, nil
+	}
+
+	stored, err := s.repo.ClassroomBuildings(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("ошибка получения корпусов аудиторий: %w", err)
+	}
+	byClassroom := make(map[string]string, len(stored))
+	for classroom, code := range stored {
+		byClassroom[normalizeClassroom(classroom)] = code
+	}
+
+	s.mu.Lock()
+	s.cache[collegeID] = classroomCache{byClassroom: byClassroom, loadedAt: time.Now()}
+	s.mu.Unlock()
+	return byClassroom, nil
+}
+
+// invalidate сбрасывает кэш корпусов аудиторий колледжа из контекста
+func (s *Service) invalidate(ctx context.Context) {
+	s.mu.Lock()
+	delete(s.cache, tenant.CollegeID(ctx))
+	s.mu.Unlock()
+}
+
+// normalizeClassroom приводит имя аудитории к виду для сравнения: без пробелов по краям
+// и без учета регистра
+func normalizeClassroom(classroom string) string {
+	return strings.ToLower(strings.TrimSpace(classroom))
+}
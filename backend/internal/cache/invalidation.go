@@ -0,0 +1,146 @@
+package cache
+
+import (
+	"context"
+	"encoding/json"
+	"log"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// DefaultInvalidationChannel канал Redis для сообщений о сбросе кэшей
+const DefaultInvalidationChannel = "cache:invalidate"
+
+// Задержки повторной подписки после обрыва соединения с Redis
+const (
+	resubscribeMin = time.Second
+	resubscribeMax = 30 * time.Second
+)
+
+// Invalidation сообщение о том, что расписание группы на дату изменилось и его
+// нужно удалить из локальных кэшей. Пустые поля означают "все": без группы -
+// все группы на дату, без даты - все даты, без колледжа - все колледжи.
+// Сообщение с UserID относится только к кэшу пользователей и расписание не затрагивает.
+type Invalidation struct {
+	CollegeID uuid.UUID `json:"college_id"`
+	Group     string    `json:"group,omitempty"`
+	Date      string    `json:"date,omitempty"`    // YYYY-MM-DD
+	UserID    uuid.UUID `json:"user_id,omitempty"` // Измененный пользователь
+	Origin    string    `json:"origin"`            // Экземпляр API, отправивший сообщение
+}
+
+// Covers сообщает, затрагивает ли сообщение расписание группы group на дату date (YYYY-MM-DD)
+func (inv Invalidation) Covers(collegeID uuid.UUID, group, date string) bool {
+	return inv.UserID == uuid.Nil &&
+		(inv.CollegeID == uuid.Nil || inv.CollegeID == collegeID) &&
+		(inv.Group == "" || inv.Group == group) &&
+		(inv.Date == "" || inv.Date == date)
+}
+
+// Invalidator шина сброса локальных кэшей расписания. Publish сразу сбрасывает
+// кэши своего экземпляра и рассылает сообщение в канал Redis, на который
+// подписаны остальные экземпляры (Run). Доставка не гарантируется: после
+// обрыва подписки экземпляр сбрасывает кэши целиком, а пропущенное сообщение
+// перекрывается временем жизни записей кэша.
+type Invalidator struct {
+	redis   *Redis // nil - только кэши своего экземпляра
+	channel string
+	origin  string
+
+	mu       sync.RWMutex
+	handlers []func(Invalidation)
+}
+
+// NewInvalidator создает шину сброса кэшей на канале channel. redis может
+// быть nil - тогда сообщения доходят только до кэшей своего экземпляра.
+func NewInvalidator(redis *Redis, channel string) *Invalidator {
+	if channel == "" {
+		channel = DefaultInvalidationChannel
+	}
+	return &Invalidator{
+		redis:   redis,
+		channel: channel,
+		origin:  uuid.NewString(),
+	}
+}
+
+// Subscribe регистрирует обработчик сообщений о сбросе кэша
+func (i *Invalidator) Subscribe(handler func(Invalidation)) {
+	i.mu.Lock()
+	defer i.mu.Unlock()
+	i.handlers = append(i.handlers, handler)
+}
+
+// Publish сбрасывает кэши своего экземпляра и рассылает сообщение остальным.
+// Ошибки Redis только логируются: остальные экземпляры увидят изменение
+// по истечении времени жизни записей кэша.
+func (i *Invalidator) Publish(ctx context.Context, inv Invalidation) {
+	inv.Origin = i.origin
+	i.dispatch(inv)
+	if i.redis == nil {
+		return
+	}
+
+	data, err := json.Marshal(inv)
+	if err != nil {
+		return
+	}
+	if err := i.redis.Publish(ctx, i.channel, data); err != nil {
+		log.Printf("Ошибка рассылки сброса кэша расписания (группа %q, дата %s): %v", inv.Group, inv.Date, err)
+	}
+}
+
+// Run подписывается на сообщения других экземпляров и переподписывается после
+// обрыва соединения, пока не отменен ctx. Без Redis сразу возвращается.
+func (i *Invalidator) Run(ctx context.Context) {
+	if i.redis == nil {
+		return
+	}
+
+	delay := resubscribeMin
+	for {
+		subscribed := time.Now()
+		err := i.redis.Subscribe(ctx, i.channel, i.receive)
+		if ctx.Err() != nil {
+			return
+		}
+		log.Printf("Подписка на сброс кэша расписания прервана, повтор через %s: %v", delay, err)
+		// Сообщения, пропущенные без подписки, уже не придут
+		i.dispatch(Invalidation{Origin: i.origin})
+
+		if time.Since(subscribed) > resubscribeMax {
+			delay = resubscribeMin
+		}
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(delay):
+		}
+		delay = min(delay*2, resubscribeMax)
+	}
+}
+
+// receive обрабатывает сообщение из канала Redis
+func (i *Invalidator) receive(message []byte) {
+	var inv Invalidation
+	if err := json.Unmarshal(message, &inv); err != nil {
+		log.Printf("Некорректное сообщение о сбросе кэша: %v", err)
+		return
+	}
+	if inv.Origin == i.origin {
+		return // Свой кэш уже сброшен в Publish
+	}
+	i.dispatch(inv)
+}
+
+// dispatch передает сообщение обработчикам
+func (i *Invalidator) dispatch(inv Invalidation) {
+	i.mu.RLock()
+	handlers := i.handlers
+	i.mu.RUnlock()
+	for _, handler := range handlers {
+		handler(inv)
+	}
+}
@@ -0,0 +1,80 @@
+package cache_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/Ultrahd-dev/student-schedule-app/backend/internal/cache"
+	"github.com/Ultrahd-dev/student-schedule-app/backend/internal/testutil"
+	"github.com/google/uuid"
+)
+
+func TestInvalidationCovers(t *testing.T) {
+	college := uuid.New()
+	tests := []struct {
+		name string
+		inv  cache.Invalidation
+		want bool
+	}{
+		{"группа и дата", cache.Invalidation{CollegeID: college, Group: "ИС-21", Date: "2025-09-01"}, true},
+		{"другая группа", cache.Invalidation{CollegeID: college, Group: "ИС-22", Date: "2025-09-01"}, false},
+		{"другая дата", cache.Invalidation{CollegeID: college, Group: "ИС-21", Date: "2025-09-02"}, false},
+		{"другой колледж", cache.Invalidation{CollegeID: uuid.New(), Date: "2025-09-01"}, false},
+		{"все группы на дату", cache.Invalidation{CollegeID: college, Date: "2025-09-01"}, true},
+		{"все", cache.Invalidation{}, true},
+		{"пользователь", cache.Invalidation{UserID: uuid.New()}, false},
+	}
+	for _, tt := range tests {
+		if got := tt.inv.Covers(college, "ИС-21", "2025-09-01"); got != tt.want {
+			t.Errorf("%s: %v, ожидалось %v", tt.name, got, tt.want)
+		}
+	}
+}
+
+// TestInvalidatorRedis проверяет, что сброс кэша на одном экземпляре доходит
+// до другого через Redis pub/sub, а свое сообщение не обрабатывается дважды
+func TestInvalidatorRedis(t *testing.T) {
+	addr := testutil.StartRedis(t)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	publisher := cache.NewInvalidator(cache.NewRedis(addr, time.Second), "")
+	subscriber := cache.NewInvalidator(cache.NewRedis(addr, time.Second), "")
+	own := make(chan cache.Invalidation, 10)
+	received := make(chan cache.Invalidation, 10)
+	publisher.Subscribe(func(inv cache.Invalidation) { own <- inv })
+	subscriber.Subscribe(func(inv cache.Invalidation) { received <- inv })
+	go publisher.Run(ctx)
+	go subscriber.Run(ctx)
+
+	college := uuid.New()
+	want := cache.Invalidation{CollegeID: college, Group: "ИС-21", Date: "2025-09-01"}
+	// Подписка устанавливается асинхронно: публикуем, пока сообщение не дойдет
+	deadline := time.After(10 * time.Second)
+	for delivered := false; !delivered; {
+		publisher.Publish(ctx, want)
+		select {
+		case inv := <-received:
+			if !inv.Covers(college, "ИС-21", "2025-09-01") || inv.Group != want.Group {
+				t.Fatalf("получено сообщение %+v", inv)
+			}
+			delivered = true
+		case <-time.After(100 * time.Millisecond):
+		case <-deadline:
+			t.Fatal("сообщение не дошло до другого экземпляра")
+		}
+	}
+
+	// Publish сбрасывает кэш своего экземпляра сразу и один раз на сообщение
+	time.Sleep(200 * time.Millisecond)
+	published := len(own)
+	if published == 0 {
+		t.Fatal("свой кэш не сброшен")
+	}
+	publisher.Publish(ctx, want)
+	time.Sleep(200 * time.Millisecond)
+	if got := len(own) - published; got != 1 {
+		t.Errorf("свое сообщение обработано %d раз, ожидался 1", got)
+	}
+}
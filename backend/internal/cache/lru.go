@@ -0,0 +1,117 @@
+// Package cache реализует кэши для частых запросов к базе: LRU кэш в памяти
+// процесса, минимальный клиент Redis для кэша, общего для экземпляров API,
+// и шину сброса локальных кэшей на всех экземплярах через Redis pub/sub
+package cache
+
+import (
+	"container/list"
+	"sync"
+	"time"
+)
+
+// LRU потокобезопасный кэш в памяти фиксированного размера с ограниченным
+// временем жизни записей. При переполнении вытесняются давно не используемые записи.
+type LRU[K comparable, V any] struct {
+	capacity int
+	ttl      time.Duration
+	mu       sync.Mutex
+	items    map[K]*list.Element
+	order    *list.List // От недавно использованных к давно не используемым
+}
+
+// lruEntry запись кэша
+type lruEntry[K comparable, V any] struct {
+	key       K
+	value     V
+	expiresAt time.Time
+}
+
+// NewLRU создает кэш на capacity записей со временем жизни ttl
+func NewLRU[K comparable, V any](capacity int, ttl time.Duration) *LRU[K, V] {
+	if capacity <= 0 {
+		capacity = 1000
+	}
+	return &LRU[K, V]{
+		capacity: capacity,
+		ttl:      ttl,
+		items:    make(map[K]*list.Element, capacity),
+		order:    list.New(),
+	}
+}
+
+// Get возвращает значение по ключу, если оно есть в кэше и не устарело
+func (c *LRU[K, V]) Get(key K) (V, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	var zero V
+	elem, ok := c.items[key]
+	if !ok {
+		return zero, false
+	}
+	entry := elem.Value.(*lruEntry[K, V])
+	if time.Now().After(entry.expiresAt) {
+		c.remove(elem)
+		return zero, false
+	}
+	c.order.MoveToFront(elem)
+	return entry.value, true
+}
+
+// Set сохраняет значение по ключу
+func (c *LRU[K, V]) Set(key K, value V) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	expiresAt := time.Now().Add(c.ttl)
+	if elem, ok := c.items[key]; ok {
+		entry := elem.Value.(*lruEntry[K, V])
+		entry.value = value
+		entry.expiresAt = expiresAt
+		c.order.MoveToFront(elem)
+		return
+	}
+
+	c.items[key] = c.order.PushFront(&lruEntry[K, V]{key: key, value: value, expiresAt: expiresAt})
+	if c.order.Len() > c.capacity {
+		c.remove(c.order.Back())
+	}
+}
+
+// Delete удаляет значение по ключу
+func (c *LRU[K, V]) Delete(key K) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if elem, ok := c.items[key]; ok {
+		c.remove(elem)
+	}
+}
+
+// DeleteFunc удаляет записи, ключи которых подходят под match, и возвращает их количество
+func (c *LRU[K, V]) DeleteFunc(match func(key K) bool) int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	deleted := 0
+	for key, elem := range c.items {
+		if match(key) {
+			c.remove(elem)
+			deleted++
+		}
+	}
+	return deleted
+}
+
+// Len возвращает количество записей в кэше, включая устаревшие
+func (c *LRU[K, V]) Len() int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.order.Len()
+}
+
+// remove удаляет запись. Вызывается под c.mu.
+func (c *LRU[K, V]) remove(elem *list.Element) {
+	c.order.Remove(elem)
+	delete(c.items, elem.Value.(*lruEntry[K, V]).key)
+}
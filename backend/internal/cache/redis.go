@@ -0,0 +1,282 @@
+package cache
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"strconv"
+	"time"
+)
+
+// ErrMiss означает, что ключа нет в кэше
+var ErrMiss = errors.New("cache miss")
+
+// maxIdleConns количество соединений с Redis, которые остаются открытыми между запросами
+const maxIdleConns = 8
+
+// Redis минимальный клиент Redis (протокол RESP) для команд GET, SET, DEL
+// и PUBLISH и подписки на канал. Соединения переиспользуются; соединение,
+// на котором произошла ошибка, закрывается.
+type Redis struct {
+	addr    string
+	timeout time.Duration
+	idle    chan *redisConn
+}
+
+// redisConn соединение с Redis с буферизованным чтением ответов
+type redisConn struct {
+	conn   net.Conn
+	reader *bufio.Reader
+}
+
+// NewRedis создает клиент Redis по адресу addr (host:port). Соединение
+// устанавливается при первом запросе, timeout ограничивает каждый запрос.
+func NewRedis(addr string, timeout time.Duration) *Redis {
+	if timeout <= 0 {
+		timeout = time.Second
+	}
+	return &Redis{
+		addr:    addr,
+		timeout: timeout,
+		idle:    make(chan *redisConn, maxIdleConns),
+	}
+}
+
+// Get возвращает значение ключа или ErrMiss, если ключа нет
+func (r *Redis) Get(ctx context.Context, key string) ([]byte, error) {
+	reply, err := r.do(ctx, "GET", key)
+	if err != nil {
+		return nil, err
+	}
+	if reply == nil {
+		return nil, ErrMiss
+	}
+	return reply, nil
+}
+
+// Set сохраняет значение ключа со временем жизни ttl
+func (r *Redis) Set(ctx context.Context, key string, value []byte, ttl time.Duration) error {
+	_, err := r.do(ctx, "SET", key, string(value), "PX", strconv.FormatInt(ttl.Milliseconds(), 10))
+	return err
+}
+
+// Del удаляет ключи
+func (r *Redis) Del(ctx context.Context, keys ...string) error {
+	if len(keys) == 0 {
+		return nil
+	}
+	_, err := r.do(ctx, "DEL", keys...)
+	return err
+}
+
+// Publish публикует сообщение в канал channel
+func (r *Redis) Publish(ctx context.Context, channel string, message []byte) error {
+	_, err := r.do(ctx, "PUBLISH", channel, string(message))
+	return err
+}
+
+// Subscribe подписывается на канал channel и передает handler каждое сообщение,
+// пока не отменен ctx или не оборвалось соединение. Подписка занимает отдельное
+// соединение; после обрыва вызывающий подписывается заново.
+func (r *Redis) Subscribe(ctx context.Context, channel string, handler func(message []byte)) error {
+	dialCtx, cancel := context.WithTimeout(ctx, r.timeout)
+	var dialer net.Dialer
+	conn, err := dialer.DialContext(dialCtx, "tcp", r.addr)
+	cancel()
+	if err != nil {
+		return fmt.Errorf("redis SUBSCRIBE: %w", err)
+	}
+	defer conn.Close()
+	c := &redisConn{conn: conn, reader: bufio.NewReader(conn)}
+
+	// Отмена ctx прерывает ожидание сообщений
+	stop := context.AfterFunc(ctx, func() { conn.Close() })
+	defer stop()
+
+	conn.SetDeadline(time.Now().Add(r.timeout))
+	if err := c.write("SUBSCRIBE", []string{channel}); err != nil {
+		return fmt.Errorf("redis SUBSCRIBE: %w", err)
+	}
+	if _, err := c.readArray(); err != nil {
+		return fmt.Errorf("redis SUBSCRIBE: %w", err)
+	}
+	conn.SetDeadline(time.Time{})
+
+	for {
+		reply, err := c.readArray()
+		if err != nil {
+			if ctx.Err() != nil {
+				return ctx.Err()
+			}
+			return fmt.Errorf("redis SUBSCRIBE: %w", err)
+		}
+		// ["message", канал, сообщение]
+		if len(reply) == 3 && string(reply[0]) == "message" {
+			handler(reply[2])
+		}
+	}
+}
+
+// Close закрывает простаивающие соединения
+func (r *Redis) Close() error {
+	for {
+		select {
+		case c := <-r.idle:
+			c.conn.Close()
+		default:
+			return nil
+		}
+	}
+}
+
+// do выполняет команду и возвращает ответ: значение для bulk string,
+// текст для simple string и integer, nil для отсутствующего значения
+func (r *Redis) do(ctx context.Context, command string, args ...string) ([]byte, error) {
+	c, err := r.conn(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("redis %s: %w", command, err)
+	}
+
+	deadline := time.Now().Add(r.timeout)
+	if d, ok := ctx.Deadline(); ok && d.Before(deadline) {
+		deadline = d
+	}
+	c.conn.SetDeadline(deadline)
+
+	reply, err := c.exec(command, args)
+	if err != nil {
+		c.conn.Close()
+		return nil, fmt.Errorf("redis %s: %w", command, err)
+	}
+	r.release(c)
+	return reply, nil
+}
+
+// conn возвращает простаивающее соединение или устанавливает новое
+func (r *Redis) conn(ctx context.Context) (*redisConn, error) {
+	select {
+	case c := <-r.idle:
+		return c, nil
+	default:
+	}
+
+	dialCtx, cancel := context.WithTimeout(ctx, r.timeout)
+	defer cancel()
+
+	var dialer net.Dialer
+	conn, err := dialer.DialContext(dialCtx, "tcp", r.addr)
+	if err != nil {
+		return nil, err
+	}
+	return &redisConn{conn: conn, reader: bufio.NewReader(conn)}, nil
+}
+
+// release возвращает соединение в пул или закрывает его, если пул заполнен
+func (r *Redis) release(c *redisConn) {
+	select {
+	case r.idle <- c:
+	default:
+		c.conn.Close()
+	}
+}
+
+// exec отправляет команду и читает ответ
+func (c *redisConn) exec(command string, args []string) ([]byte, error) {
+	if err := c.write(command, args); err != nil {
+		return nil, err
+	}
+	return c.readReply()
+}
+
+// write отправляет команду в виде массива bulk string
+func (c *redisConn) write(command string, args []string) error {
+	buf := make([]byte, 0, 64)
+	buf = append(buf, '*')
+	buf = strconv.AppendInt(buf, int64(len(args)+1), 10)
+	buf = append(buf, '\r', '\n')
+	for _, arg := range append([]string{command}, args...) {
+		buf = append(buf, '$')
+		buf = strconv.AppendInt(buf, int64(len(arg)), 10)
+		buf = append(buf, '\r', '\n')
+		buf = append(buf, arg...)
+		buf = append(buf, '\r', '\n')
+	}
+	_, err := c.conn.Write(buf)
+	return err
+}
+
+// readLine читает строку ответа без завершающего \r\n
+func (c *redisConn) readLine() (string, error) {
+	line, err := c.reader.ReadString('\n')
+	if err != nil {
+		return "", err
+	}
+	if len(line) < 3 {
+		return "", fmt.Errorf("некорректный ответ: %q", line)
+	}
+	return line[:len(line)-2], nil
+}
+
+// readReply читает ответ: значение для bulk string, текст для simple string
+// и integer, nil для отсутствующего значения
+func (c *redisConn) readReply() ([]byte, error) {
+	line, err := c.readLine()
+	if err != nil {
+		return nil, err
+	}
+	return c.readValue(line)
+}
+
+// readArray читает ответ-массив (сообщения подписки)
+func (c *redisConn) readArray() ([][]byte, error) {
+	line, err := c.readLine()
+	if err != nil {
+		return nil, err
+	}
+	if line[0] != '*' {
+		if _, err := c.readValue(line); err != nil {
+			return nil, err
+		}
+		return nil, fmt.Errorf("ожидался массив, получен ответ %q", line)
+	}
+	size, err := strconv.Atoi(line[1:])
+	if err != nil {
+		return nil, fmt.Errorf("некорректная длина массива: %q", line)
+	}
+	items := make([][]byte, 0, max(size, 0))
+	for i := 0; i < size; i++ {
+		item, err := c.readReply()
+		if err != nil {
+			return nil, err
+		}
+		items = append(items, item)
+	}
+	return items, nil
+}
+
+// readValue разбирает ответ, первая строка которого уже прочитана
+func (c *redisConn) readValue(line string) ([]byte, error) {
+	switch line[0] {
+	case '+', ':':
+		return []byte(line[1:]), nil
+	case '-':
+		return nil, fmt.Errorf("ошибка Redis: %s", line[1:])
+	case '$':
+		size, err := strconv.Atoi(line[1:])
+		if err != nil {
+			return nil, fmt.Errorf("некорректная длина ответа: %q", line)
+		}
+		if size < 0 {
+			return nil, nil
+		}
+		value := make([]byte, size+2)
+		if _, err := io.ReadFull(c.reader, value); err != nil {
+			return nil, err
+		}
+		return value[:size], nil
+	}
+	return nil, fmt.Errorf("неподдерживаемый ответ: %q", line)
+}
@@ -0,0 +1,289 @@
+// Package calendar раздает личное расписание в формате iCalendar по подписанным
+// ссылкам, чтобы студенты и преподаватели могли подписаться на него в календаре
+// телефона. Ссылка не истекает: календарные приложения обновляют подписку
+// сами, без токена пользователя. Ссылка перестает работать, если пользователь
+// деактивирован или перевыпустил ссылки (версия ссылок в подписи); смена ключа
+// подписи отзывает все выданные ссылки.
+package calendar
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"log"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/Ultrahd-dev/student-schedule-app/backend/internal/clock"
+	"github.com/Ultrahd-dev/student-schedule-app/backend/internal/electives"
+	"github.com/Ultrahd-dev/student-schedule-app/backend/internal/ical"
+	"github.com/Ultrahd-dev/student-schedule-app/backend/internal/schedule"
+	"github.com/Ultrahd-dev/student-schedule-app/backend/internal/tenant"
+	"github.com/Ultrahd-dev/student-schedule-app/backend/internal/users"
+	"github.com/google/uuid"
+)
+
+// FeedPath путь, на котором раздаются календари: /calendar/<токен>.ics
+const FeedPath = "/calendar/"
+
+// googleRenderURL адрес добавления подписки в Google Календарь
+const googleRenderURL = "https://calendar.google.com/calendar/render"
+
+// ErrNotAvailable означает, что личного расписания у пользователя нет
+// (администратор или профиль не заполнен)
+var ErrNotAvailable = errors.New("личное расписание недоступно")
+
+// Config настройки календарей
+type Config struct {
+	PublicURL  string // Внешний адрес HTTP-сервера календарей
+	Secret     string // Ключ подписи ссылок
+	PastDays   int    // Сколько прошедших дней включать в календарь
+	FutureDays int    // На сколько дней вперед включать расписание
+}
+
+// Links ссылки для подписки на календарь
+type Links struct {
+	FeedURL   string // https-ссылка на ICS
+	WebcalURL string // webcal:// - открывает подписку в Apple Календаре и других приложениях
+	GoogleURL string // Добавление подписки в Google Календарь
+}
+
+// Feed формирует ссылки на календари и раздает их
+type Feed struct {
+	config          Config
+	scheduleService *schedule.Service
+	userService     *users.Service
+	electives       *electives.Service // Факультативы студентов; nil - не включаются в календарь
+}
+
+// NewFeed создает раздачу календарей
+func NewFeed(config Config, scheduleService *schedule.Service, userService *users.Service) *Feed {
+	if config.PastDays <= 0 {
+		config.PastDays = 7
+	}
+	if config.FutureDays <= 0 {
+		config.FutureDays = 28
+	}
+	config.PublicURL = strings.TrimRight(config.PublicURL, "/")
+
+	return &Feed{
+		config:          config,
+		scheduleService: scheduleService,
+		userService:     userService,
+	}
+}
+
+// UseElectives добавляет в календарь студента занятия факультативов, на которые он записан
+func (f *Feed) UseElectives(electiveService *electives.Service) {
+	f.electives = electiveService
+}
+
+// tokenLength длина данных токена: ID пользователя, ID колледжа и версия ссылок
+const tokenLength = 16 + 16 + 4
+
+// Links возвращает ссылки на календарь пользователя колледжа из контекста
+// (текущей версии ссылок пользователя)
+func (f *Feed) Links(ctx context.Context, user *users.User) Links {
+	feedURL := f.config.PublicURL + FeedPath + f.token(user.ID, tenant.CollegeID(ctx), user.CalendarTokenVersion) + ".ics"
+
+	webcalURL := feedURL
+	if i := strings.Index(webcalURL, "://"); i >= 0 {
+		webcalURL = "webcal" + webcalURL[i:]
+	}
+
+	return Links{
+		FeedURL:   feedURL,
+		WebcalURL: webcalURL,
+		GoogleURL: googleRenderURL + "?" + url.Values{"cid": {webcalURL}}.Encode(),
+	}
+}
+
+// Handler раздает календари по подписанным ссылкам.
+// Регистрируется на пути FeedPath.
+func (f *Feed) Handler() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet && r.Method != http.MethodHead {
+			http.Error(w, "Метод не поддерживается", http.StatusMethodNotAllowed)
+			return
+		}
+
+		token := strings.TrimSuffix(strings.TrimPrefix(r.URL.Path, FeedPath), ".ics")
+		userID, collegeID, version, ok := f.parseToken(token)
+		if !ok {
+			http.Error(w, "Ссылка недействительна", http.StatusNotFound)
+			return
+		}
+		ctx := tenant.WithCollege(r.Context(), collegeID)
+
+		calendar, err := f.userCalendar(ctx, userID, version)
+		if err != nil {
+			if errors.Is(err, ErrNotAvailable) {
+				http.Error(w, "Ссылка недействительна", http.StatusNotFound)
+				return
+			}
+			log.Printf("Ошибка формирования календаря пользователя %s: %v", userID, err)
+			http.Error(w, "Ошибка формирования календаря", http.StatusInternalServerError)
+			return
+		}
+
+		w.Header().Set("Content-Type", "text/calendar; charset=utf-8")
+		w.Header().Set("Cache-Control", "private, max-age=900")
+		if r.Method == http.MethodHead {
+			return
+		}
+		if err := calendar.Write(w); err != nil {
+			log.Printf("Ошибка отправки календаря пользователя %s: %v", userID, err)
+		}
+	})
+}
+
+// userCalendar собирает календарь с личным расписанием пользователя:
+// для студента - расписание его группы и факультативов, для преподавателя - его занятия.
+// Ссылки версии, отличной от текущей версии пользователя, отозваны.
+func (f *Feed) userCalendar(ctx context.Context, userID uuid.UUID, version int) (*ical.Calendar, error) {
+	user, err := f.userService.GetUserByID(ctx, userID)
+	if err != nil || !user.IsActive || user.CalendarTokenVersion != version {
+		return nil, ErrNotAvailable
+	}
+
+	loc := f.scheduleService.Location()
+	today := clock.Today(loc)
+	from := today.AddDate(0, 0, -f.config.PastDays)
+	to := today.AddDate(0, 0, f.config.FutureDays)
+
+	var name string
+	var entries []schedule.CurrentSchedule
+	switch user.Role {
+	case users.RoleStudent:
+		student, err := f.userService.GetStudentProfile(ctx, user.ID)
+		if err != nil {
+			return nil, ErrNotAvailable
+		}
+		name = "Расписание " + student.GroupName
+		entries, err = f.scheduleService.GetScheduleForGroupRange(ctx, student.GroupName, from, to)
+		if err != nil {
+			return nil, err
+		}
+		entries = schedule.ForSubgroup(entries, student.Subgroup)
+		if f.electives != nil {
+			lessons, err := f.electives.Lessons(ctx, user.ID, student.GroupName, from, to)
+			if err != nil {
+				return nil, err
+			}
+			entries = electives.Merge(entries, lessons)
+		}
+	case users.RoleTeacher:
+		teacher, err := f.userService.GetTeacherProfile(ctx, user.ID)
+		if err != nil {
+			return nil, ErrNotAvailable
+		}
+		names, err := f.userService.TeacherNames(ctx, teacher)
+		if err != nil {
+			return nil, err
+		}
+		name = "Расписание " + teacher.FullName
+		entries, err = f.scheduleService.GetScheduleForTeacher(ctx, names, from, to)
+		if err != nil {
+			return nil, err
+		}
+	default:
+		return nil, ErrNotAvailable
+	}
+
+	events, err := Events(entries, loc, user.Role == users.RoleTeacher)
+	if err != nil {
+		return nil, err
+	}
+	return &ical.Calendar{Name: name, TimeZone: loc.String(), Events: events}, nil
+}
+
+// Events преобразует записи актуального расписания в события календаря.
+// withGroup добавляет группу в описание события (для календаря преподавателя).
+func Events(entries []schedule.CurrentSchedule, loc *time.Location, withGroup bool) ([]ical.Event, error) {
+	events := make([]ical.Event, 0, len(entries))
+	for _, entry := range entries {
+		start, err := clock.At(entry.Date, entry.TimeStart, loc)
+		if err != nil {
+			return nil, fmt.Errorf("некорректное время пары %s: %w", entry.ID, err)
+		}
+		end, err := clock.At(entry.Date, entry.TimeEnd, loc)
+		if err != nil {
+			return nil, fmt.Errorf("некорректное время пары %s: %w", entry.ID, err)
+		}
+
+		description := entry.Teacher
+		if withGroup {
+			description = "Группа " + entry.GroupName
+		}
+		if entry.Subgroup > 0 {
+			description = strings.TrimSpace(fmt.Sprintf("%s (%d п/г)", description, entry.Subgroup))
+		}
+		summary := entry.Subject
+		if entry.LessonType != "" {
+			summary = fmt.Sprintf("%s (%s)", summary, entry.LessonType)
+		}
+		location := entry.Classroom
+		if entry.MeetingURL != "" {
+			if description != "" {
+				description += "\n"
+			}
+			description += "Онлайн: " + entry.MeetingURL
+			if location == "" {
+				location = "Онлайн"
+			}
+		}
+		events = append(events, ical.Event{
+			UID:         entry.ID.String() + "@student-schedule",
+			Start:       start,
+			End:         end,
+			Summary:     summary,
+			Location:    location,
+			Description: description,
+			URL:         entry.MeetingURL,
+			Cancelled:   !entry.IsActive,
+		})
+	}
+	return events, nil
+}
+
+// token возвращает токен ссылки: ID пользователя и колледжа и версия ссылок с подписью
+func (f *Feed) token(userID, collegeID uuid.UUID, version int) string {
+	payload := make([]byte, 0, tokenLength)
+	payload = append(payload, userID[:]...)
+	payload = append(payload, collegeID[:]...)
+	payload = binary.BigEndian.AppendUint32(payload, uint32(version))
+	return base64.RawURLEncoding.EncodeToString(payload) + "." + f.sign(payload)
+}
+
+// parseToken проверяет подпись токена и возвращает ID пользователя и колледжа и версию ссылок
+func (f *Feed) parseToken(token string) (uuid.UUID, uuid.UUID, int, bool) {
+	encoded, signature, found := strings.Cut(token, ".")
+	if !found {
+		return uuid.Nil, uuid.Nil, 0, false
+	}
+	payload, err := base64.RawURLEncoding.DecodeString(encoded)
+	if err != nil || len(payload) != tokenLength {
+		return uuid.Nil, uuid.Nil, 0, false
+	}
+	if !hmac.Equal([]byte(signature), []byte(f.sign(payload))) {
+		return uuid.Nil, uuid.Nil, 0, false
+	}
+
+	userID, _ := uuid.FromBytes(payload[:16])
+	collegeID, _ := uuid.FromBytes(payload[16:32])
+	return userID, collegeID, int(binary.BigEndian.Uint32(payload[32:])), true
+}
+
+// sign вычисляет подпись токена
+func (f *Feed) sign(payload []byte) string {
+	mac := hmac.New(sha256.New, []byte(f.config.Secret))
+	mac.Write([]byte("calendar\n"))
+	mac.Write(payload)
+	return base64.RawURLEncoding.EncodeToString(mac.Sum(nil))
+}
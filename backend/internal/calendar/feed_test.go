@@ -0,0 +1,118 @@
+package calendar
+
+import (
+	"context"
+	"database/sql"
+	"encoding/base64"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/Ultrahd-dev/student-schedule-app/backend/internal/mocks"
+	"github.com/Ultrahd-dev/student-schedule-app/backend/internal/tenant"
+	"github.com/Ultrahd-dev/student-schedule-app/backend/internal/users"
+	"github.com/google/uuid"
+)
+
+func newTestFeed(store users.UserStore) *Feed {
+	return NewFeed(Config{PublicURL: "https://calendar.example.com/", Secret: "test-secret"}, nil, users.NewService(store))
+}
+
+func TestTokenRoundTrip(t *testing.T) {
+	f := newTestFeed(&mocks.UserStore{})
+	userID, collegeID := uuid.New(), uuid.New()
+
+	gotUser, gotCollege, version, ok := f.parseToken(f.token(userID, collegeID, 3))
+	if !ok || gotUser != userID || gotCollege != collegeID || version != 3 {
+		t.Errorf("разобран токен %s %s версии %d (%v)", gotUser, gotCollege, version, ok)
+	}
+
+	other := NewFeed(Config{Secret: "other-secret"}, nil, nil)
+	if _, _, _, ok := other.parseToken(f.token(userID, collegeID, 3)); ok {
+		t.Error("принят токен, подписанный другим ключом")
+	}
+}
+
+func TestTokenTampered(t *testing.T) {
+	f := newTestFeed(&mocks.UserStore{})
+	token := f.token(uuid.New(), uuid.New(), 0)
+	encoded, signature, _ := strings.Cut(token, ".")
+	payload, err := base64.RawURLEncoding.DecodeString(encoded)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Чужой пользователь и прежняя версия ссылок с исходной подписью
+	for name, change := range map[string]func([]byte){
+		"пользователь": func(p []byte) { p[0] ^= 0xff },
+		"версия":       func(p []byte) { p[tokenLength-1]++ },
+	} {
+		tampered := append([]byte(nil), payload...)
+		change(tampered)
+		if _, _, _, ok := f.parseToken(base64.RawURLEncoding.EncodeToString(tampered) + "." + signature); ok {
+			t.Errorf("%s: принят токен с измененными данными", name)
+		}
+	}
+	if _, _, _, ok := f.parseToken(encoded + "." + signature[1:]); ok {
+		t.Error("принят токен с испорченной подписью")
+	}
+}
+
+func TestTokenWrongLength(t *testing.T) {
+	f := newTestFeed(&mocks.UserStore{})
+	userID, collegeID := uuid.New(), uuid.New()
+
+	// Токен прежнего формата (без версии ссылок) и с лишними данными, подписанные верным ключом
+	for _, payload := range [][]byte{
+		append(userID[:], collegeID[:]...),
+		make([]byte, tokenLength+1),
+	} {
+		token := base64.RawURLEncoding.EncodeToString(payload) + "." + f.sign(payload)
+		if _, _, _, ok := f.parseToken(token); ok {
+			t.Errorf("принят токен длины %d", len(payload))
+		}
+	}
+	for _, token := range []string{"", "без-подписи", "!!!." + f.sign(nil)} {
+		if _, _, _, ok := f.parseToken(token); ok {
+			t.Errorf("принят токен %q", token)
+		}
+	}
+}
+
+func TestHandlerRevokedLinks(t *testing.T) {
+	deleted := uuid.New()
+	student := &users.User{ID: uuid.New(), Role: users.RoleStudent, IsActive: true, CalendarTokenVersion: 1}
+	store := &mocks.UserStore{
+		GetUserByIDFunc: func(ctx context.Context, id uuid.UUID) (*users.User, error) {
+			if id == student.ID {
+				return student, nil
+			}
+			// Удаленные пользователи не возвращаются (см. users.Repository.GetUserByID)
+			return nil, fmt.Errorf("user not found: %w", sql.ErrNoRows)
+		},
+	}
+	f := newTestFeed(store)
+
+	for name, token := range map[string]string{
+		"удаленный пользователь": f.token(deleted, tenant.DefaultCollegeID, 0),
+		"перевыпущенные ссылки":  f.token(student.ID, tenant.DefaultCollegeID, 0),
+		"неверная подпись":       f.token(student.ID, tenant.DefaultCollegeID, 1) + "x",
+	} {
+		rec := httptest.NewRecorder()
+		f.Handler().ServeHTTP(rec, httptest.NewRequest(http.MethodGet, FeedPath+token+".ics", nil))
+		if rec.Code != http.StatusNotFound {
+			t.Errorf("%s: код %d, ожидался %d", name, rec.Code, http.StatusNotFound)
+		}
+	}
+
+	links := f.Links(tenant.WithCollege(context.Background(), tenant.DefaultCollegeID), student)
+	if !strings.HasPrefix(links.FeedURL, "https://calendar.example.com"+FeedPath) || !strings.HasPrefix(links.WebcalURL, "webcal://") {
+		t.Errorf("ссылки %+v", links)
+	}
+	token := strings.TrimSuffix(strings.TrimPrefix(links.FeedURL, "https://calendar.example.com"+FeedPath), ".ics")
+	if _, _, version, ok := f.parseToken(token); !ok || version != student.CalendarTokenVersion {
+		t.Errorf("ссылка выдана для версии %d (%v), текущая %d", version, ok, student.CalendarTokenVersion)
+	}
+}
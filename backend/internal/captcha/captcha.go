@@ -0,0 +1,63 @@
+// Package captcha реализует защиту регистрации и входа от ботов:
+// проверку токенов hCaptcha/Turnstile на стороне сервера или задачу proof-of-work
+package captcha
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+)
+
+// Провайдеры проверки
+const (
+	ProviderNone        = ""              // Проверка отключена
+	ProviderHCaptcha    = "hcaptcha"      // hCaptcha
+	ProviderTurnstile   = "turnstile"     // Cloudflare Turnstile
+	ProviderProofOfWork = "proof_of_work" // Вычислительная задача, без внешних сервисов
+)
+
+// Ошибки проверки
+var (
+	ErrRequired = errors.New("требуется пройти проверку CAPTCHA")
+	ErrInvalid  = errors.New("проверка CAPTCHA не пройдена")
+)
+
+// Verifier проверяет ответ клиента на CAPTCHA
+type Verifier interface {
+	// Provider возвращает название провайдера
+	Provider() string
+	// Verify проверяет ответ клиента; remoteIP может быть пустым
+	Verify(ctx context.Context, response, remoteIP string) error
+}
+
+// Config настройки проверки
+type Config struct {
+	Provider   string        // Провайдер (см. Provider*)
+	SiteKey    string        // Публичный ключ сайта для hCaptcha/Turnstile
+	Secret     string        // Секретный ключ hCaptcha/Turnstile или ключ подписи задач proof-of-work
+	VerifyURL  string        // Адрес проверки токена (по умолчанию - адрес провайдера)
+	Timeout    time.Duration // Таймаут запроса к провайдеру
+	Difficulty int           // Сложность proof-of-work: число нулевых бит в начале хеша
+	TTL        time.Duration // Время действия задачи proof-of-work
+}
+
+// New создает проверку по настройкам; для отключенной проверки возвращает nil
+func New(cfg Config) (Verifier, error) {
+	switch cfg.Provider {
+	case ProviderNone:
+		return nil, nil
+	case ProviderHCaptcha, ProviderTurnstile:
+		if cfg.Secret == "" {
+			return nil, fmt.Errorf("не задан секретный ключ %s", cfg.Provider)
+		}
+		return NewSiteVerifier(cfg.Provider, cfg.Secret, cfg.SiteKey, cfg.VerifyURL, cfg.Timeout), nil
+	case ProviderProofOfWork:
+		if cfg.Secret == "" {
+			return nil, fmt.Errorf("не задан ключ подписи задач proof-of-work")
+		}
+		return NewProofOfWork([]byte(cfg.Secret), cfg.Difficulty, cfg.TTL), nil
+	default:
+		return nil, fmt.Errorf("неизвестный провайдер CAPTCHA: %s", cfg.Provider)
+	}
+}
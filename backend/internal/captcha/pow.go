@@ -0,0 +1,147 @@
+package captcha
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/binary"
+	"fmt"
+	"math/bits"
+	"strings"
+	"sync"
+	"time"
+)
+
+// ProofOfWork выдает клиентам вычислительные задачи и проверяет решения.
+// Задача - подписанная строка со сроком действия; решение - такой nonce,
+// что SHA-256 от "задача:nonce" начинается с Difficulty нулевых бит.
+// Каждая задача принимается только один раз.
+type ProofOfWork struct {
+	secret     []byte
+	difficulty int
+	ttl        time.Duration
+
+	mu   sync.Mutex
+	used map[string]time.Time // Решенные задачи до истечения их срока
+}
+
+// NewProofOfWork создает проверку proof-of-work
+func NewProofOfWork(secret []byte, difficulty int, ttl time.Duration) *ProofOfWork {
+	if difficulty <= 0 {
+		difficulty = 20
+	}
+	if ttl <= 0 {
+		ttl = 5 * time.Minute
+	}
+
+	return &ProofOfWork{
+		secret:     secret,
+		difficulty: difficulty,
+		ttl:        ttl,
+		used:       make(map[string]time.Time),
+	}
+}
+
+// Provider возвращает название провайдера
+func (p *ProofOfWork) Provider() string {
+	return ProviderProofOfWork
+}
+
+// Difficulty возвращает требуемое число нулевых бит в начале хеша
+func (p *ProofOfWork) Difficulty() int {
+	return p.difficulty
+}
+
+// Challenge создает новую задачу и возвращает ее вместе со временем истечения
+func (p *ProofOfWork) Challenge() (string, time.Time, error) {
+	payload := make([]byte, 24)
+	if _, err := rand.Read(payload[:16]); err != nil {
+		return "", time.Time{}, fmt.Errorf("ошибка генерации задачи: %w", err)
+	}
+	expiresAt := time.Now().Add(p.ttl)
+	binary.BigEndian.PutUint64(payload[16:], uint64(expiresAt.Unix()))
+
+	encoded := base64.RawURLEncoding.EncodeToString(payload)
+	return encoded + "." + p.sign(encoded), expiresAt, nil
+}
+
+// Verify проверяет решение в формате "задача:nonce"
+func (p *ProofOfWork) Verify(_ context.Context, response, _ string) error {
+	if response == "" {
+		return ErrRequired
+	}
+
+	challenge, nonce, ok := strings.Cut(response, ":")
+	if !ok || nonce == "" {
+		return fmt.Errorf("%w: ожидается решение в формате задача:nonce", ErrInvalid)
+	}
+
+	expiresAt, err := p.parseChallenge(challenge)
+	if err != nil {
+		return err
+	}
+	if time.Now().After(expiresAt) {
+		return fmt.Errorf("%w: срок действия задачи истек", ErrInvalid)
+	}
+
+	sum := sha256.Sum256([]byte(response))
+	if leadingZeroBits(sum[:]) < p.difficulty {
+		return fmt.Errorf("%w: неверное решение задачи", ErrInvalid)
+	}
+
+	return p.markUsed(challenge, expiresAt)
+}
+
+// parseChallenge проверяет подпись задачи и возвращает срок ее действия
+func (p *ProofOfWork) parseChallenge(challenge string) (time.Time, error) {
+	encoded, signature, ok := strings.Cut(challenge, ".")
+	if !ok || !hmac.Equal([]byte(signature), []byte(p.sign(encoded))) {
+		return time.Time{}, fmt.Errorf("%w: задача выдана не этим сервером", ErrInvalid)
+	}
+
+	payload, err := base64.RawURLEncoding.DecodeString(encoded)
+	if err != nil || len(payload) != 24 {
+		return time.Time{}, fmt.Errorf("%w: поврежденная задача", ErrInvalid)
+	}
+	return time.Unix(int64(binary.BigEndian.Uint64(payload[16:])), 0), nil
+}
+
+// markUsed запоминает решенную задачу; повторное решение отклоняется
+func (p *ProofOfWork) markUsed(challenge string, expiresAt time.Time) error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	now := time.Now()
+	for key, expires := range p.used {
+		if now.After(expires) {
+			delete(p.used, key)
+		}
+	}
+
+	if _, ok := p.used[challenge]; ok {
+		return fmt.Errorf("%w: задача уже использована", ErrInvalid)
+	}
+	p.used[challenge] = expiresAt
+	return nil
+}
+
+// sign возвращает HMAC-подпись данных задачи
+func (p *ProofOfWork) sign(data string) string {
+	mac := hmac.New(sha256.New, p.secret)
+	mac.Write([]byte(data))
+	return base64.RawURLEncoding.EncodeToString(mac.Sum(nil))
+}
+
+// leadingZeroBits считает нулевые биты в начале хеша
+func leadingZeroBits(hash []byte) int {
+	count := 0
+	for _, b := range hash {
+		if b != 0 {
+			return count + bits.LeadingZeros8(b)
+		}
+		count += 8
+	}
+	return count
+}
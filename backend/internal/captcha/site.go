@@ -0,0 +1,101 @@
+package captcha
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+)
+
+// Адреса проверки токенов по умолчанию
+var defaultVerifyURLs = map[string]string{
+	ProviderHCaptcha:  "https://api.hcaptcha.com/siteverify",
+	ProviderTurnstile: "https://challenges.cloudflare.com/turnstile/v0/siteverify",
+}
+
+// SiteVerifier проверяет токены hCaptcha и Turnstile через их API siteverify
+type SiteVerifier struct {
+	provider  string
+	secret    string
+	siteKey   string
+	verifyURL string
+	client    *http.Client
+}
+
+// NewSiteVerifier создает проверку токенов провайдера provider
+func NewSiteVerifier(provider, secret, siteKey, verifyURL string, timeout time.Duration) *SiteVerifier {
+	if verifyURL == "" {
+		verifyURL = defaultVerifyURLs[provider]
+	}
+	if timeout <= 0 {
+		timeout = 5 * time.Second
+	}
+
+	return &SiteVerifier{
+		provider:  provider,
+		secret:    secret,
+		siteKey:   siteKey,
+		verifyURL: verifyURL,
+		client:    &http.Client{Timeout: timeout},
+	}
+}
+
+// Provider возвращает название провайдера
+func (v *SiteVerifier) Provider() string {
+	return v.provider
+}
+
+// SiteKey возвращает публичный ключ сайта для виджета на клиенте
+func (v *SiteVerifier) SiteKey() string {
+	return v.siteKey
+}
+
+// siteVerifyResponse ответ API siteverify (формат общий для hCaptcha и Turnstile)
+type siteVerifyResponse struct {
+	Success    bool     `json:"success"`
+	ErrorCodes []string `json:"error-codes"`
+}
+
+// Verify проверяет токен, полученный клиентом от виджета провайдера
+func (v *SiteVerifier) Verify(ctx context.Context, response, remoteIP string) error {
+	if response == "" {
+		return ErrRequired
+	}
+
+	form := url.Values{
+		"secret":   {v.secret},
+		"response": {response},
+		"sitekey":  {v.siteKey},
+	}
+	if remoteIP != "" {
+		form.Set("remoteip", remoteIP)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, v.verifyURL, strings.NewReader(form.Encode()))
+	if err != nil {
+		return fmt.Errorf("ошибка создания запроса к %s: %w", v.provider, err)
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+
+	resp, err := v.client.Do(req)
+	if err != nil {
+		return fmt.Errorf("ошибка запроса к %s: %w", v.provider, err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("%s вернул статус %d", v.provider, resp.StatusCode)
+	}
+
+	var result siteVerifyResponse
+	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
+		return fmt.Errorf("ошибка разбора ответа %s: %w", v.provider, err)
+	}
+	if !result.Success {
+		return fmt.Errorf("%w: %s", ErrInvalid, strings.Join(result.ErrorCodes, ", "))
+	}
+	return nil
+}
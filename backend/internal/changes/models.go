@@ -0,0 +1,53 @@
+package changes
+
+import (
+	"github.com/Ultrahd-dev/student-schedule-app/backend/internal/schedule"
+	"github.com/google/uuid"
+)
+
+// Config настройки Change Detection Service
+type Config struct {
+	// BatchSize количество изменений, применяемых в одной транзакции
+	BatchSize int
+}
+
+// ChangeResult результат применения одного изменения
+type ChangeResult struct {
+	ChangeID uuid.UUID
+	Change   schedule.ScheduleChange
+	Status   string // schedule.ChangeApplyApplied, ChangeApplySkipped или ChangeApplyError
+	Error    string
+}
+
+// ApplyReport итог применения набора изменений
+type ApplyReport struct {
+	Total   int
+	Applied int
+	Skipped int
+	Failed  int
+	Results []ChangeResult
+}
+
+// AppliedChanges возвращает изменения, которые были применены к current_schedule
+func (r *ApplyReport) AppliedChanges() []schedule.ScheduleChange {
+	var applied []schedule.ScheduleChange
+	for _, result := range r.Results {
+		if result.Status == schedule.ChangeApplyApplied {
+			applied = append(applied, result.Change)
+		}
+	}
+	return applied
+}
+
+// add учитывает результат применения изменения в отчете
+func (r *ApplyReport) add(result ChangeResult) {
+	r.Results = append(r.Results, result)
+	switch result.Status {
+	case schedule.ChangeApplyApplied:
+		r.Applied++
+	case schedule.ChangeApplySkipped:
+		r.Skipped++
+	case schedule.ChangeApplyError:
+		r.Failed++
+	}
+}
@@ -0,0 +1,131 @@
+package changes
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"strings"
+	"time"
+
+	"github.com/Ultrahd-dev/student-schedule-app/backend/internal/apperr"
+	"github.com/Ultrahd-dev/student-schedule-app/backend/internal/bells"
+	"github.com/Ultrahd-dev/student-schedule-app/backend/internal/schedule"
+	"github.com/google/uuid"
+)
+
+// Ошибки модерации изменений
+var (
+	ErrNotAwaitingModeration = apperr.New(apperr.ErrConflict, "изменение не ожидает модерации")
+	ErrInvalidEdit           = apperr.New(apperr.ErrValidation, "некорректное исправление изменения")
+)
+
+// ChangeEdit исправления администратора при одобрении изменения.
+// Пустые поля оставляют значение из таблицы изменений.
+type ChangeEdit struct {
+	Date         *time.Time
+	TimeStart    string
+	TimeEnd      string
+	LessonNumber int
+	Subject      string
+	Teacher      string
+	Classroom    string
+	Reason       string
+}
+
+// ListAwaitingModeration возвращает изменения, ожидающие проверки администратором
+func (s *Service) ListAwaitingModeration(ctx context.Context) ([]schedule.ScheduleChange, error) {
+	changes, err := s.scheduleRepo.GetChangesAwaitingModeration(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("ошибка получения изменений на модерации: %w", err)
+	}
+	return changes, nil
+}
+
+// ApproveChange одобряет изменение (при необходимости с исправлениями) и применяет его
+// к current_schedule. Возвращает отчет о применении; уведомления отправляет вызывающий.
+func (s *Service) ApproveChange(ctx context.Context, changeID, moderatorID uuid.UUID, edit *ChangeEdit, comment string) (*ApplyReport, error) {
+	change, err := s.scheduleRepo.GetChangeByID(ctx, changeID)
+	if err != nil {
+		return nil, fmt.Errorf("ошибка получения изменения: %w", err)
+	}
+	if change.ModerationStatus != schedule.ChangeModerationPending {
+		return nil, ErrNotAwaitingModeration
+	}
+
+	if edit != nil {
+		if err := applyEdit(bells.For(ctx), change, edit); err != nil {
+			return nil, err
+		}
+	}
+
+	change.ModerationStatus = schedule.ChangeModerationApproved
+	change.ModeratedBy = &moderatorID
+	change.ModerationComment = strings.TrimSpace(comment)
+	if err := s.scheduleRepo.ModerateChange(ctx, change); err != nil {
+		return nil, fmt.Errorf("ошибка сохранения решения модератора: %w", err)
+	}
+
+	log.Printf("Изменение %s одобрено модератором %s", changeID, moderatorID)
+
+	report, err := s.ApplyChanges(ctx, []schedule.ScheduleChange{*change})
+	if err != nil {
+		return report, fmt.Errorf("ошибка применения одобренного изменения: %w", err)
+	}
+	return report, nil
+}
+
+// RejectChange отклоняет изменение: оно не применяется и уведомления не отправляются
+func (s *Service) RejectChange(ctx context.Context, changeID, moderatorID uuid.UUID, comment string) (*schedule.ScheduleChange, error) {
+	change, err := s.scheduleRepo.GetChangeByID(ctx, changeID)
+	if err != nil {
+		return nil, fmt.Errorf("ошибка получения изменения: %w", err)
+	}
+
+	change.ModerationStatus = schedule.ChangeModerationRejected
+	change.ModeratedBy = &moderatorID
+	change.ModerationComment = strings.TrimSpace(comment)
+	if err := s.scheduleRepo.ModerateChange(ctx, change); err != nil {
+		return nil, fmt.Errorf("ошибка сохранения решения модератора: %w", err)
+	}
+
+	log.Printf("Изменение %s отклонено модератором %s", changeID, moderatorID)
+	return change, nil
+}
+
+// applyEdit переносит исправления администратора в изменение.
+// При изменении времени или номера пары слот заново определяется по расписанию звонков timings.
+func applyEdit(timings bells.Timings, change *schedule.ScheduleChange, edit *ChangeEdit) error {
+	if edit.Date != nil {
+		change.Date = *edit.Date
+	}
+	if subject := strings.TrimSpace(edit.Subject); subject != "" {
+		change.Subject = subject
+	}
+	if teacher := strings.TrimSpace(edit.Teacher); teacher != "" {
+		change.Teacher = teacher
+	}
+	if classroom := strings.TrimSpace(edit.Classroom); classroom != "" {
+		change.Classroom = classroom
+	}
+	if reason := strings.TrimSpace(edit.Reason); reason != "" {
+		change.Reason = reason
+	}
+
+	if edit.TimeStart == "" && edit.TimeEnd == "" && edit.LessonNumber == 0 {
+		return nil
+	}
+
+	number, timeStart, timeEnd := edit.LessonNumber, edit.TimeStart, edit.TimeEnd
+	if timeStart == "" && number == 0 {
+		// Исправлено только время окончания
+		number, timeStart = change.LessonNumber, change.TimeStart
+	}
+	slot, err := timings.ResolveSlot(change.Date.Weekday(), number, timeStart, timeEnd)
+	if err != nil {
+		return fmt.Errorf("%w: %v", ErrInvalidEdit, err)
+	}
+	change.TimeStart = slot.TimeStart
+	change.TimeEnd = slot.TimeEnd
+	change.LessonNumber = slot.Number
+	return nil
+}
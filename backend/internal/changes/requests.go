@@ -0,0 +1,242 @@
+package changes
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"strings"
+
+	"github.com/Ultrahd-dev/student-schedule-app/backend/internal/apperr"
+	"github.com/Ultrahd-dev/student-schedule-app/backend/internal/bells"
+	"github.com/Ultrahd-dev/student-schedule-app/backend/internal/clock"
+	"github.com/Ultrahd-dev/student-schedule-app/backend/internal/schedule"
+	"github.com/google/uuid"
+)
+
+// Ошибки заявок преподавателей
+var (
+	ErrInvalidChangeRequest = apperr.New(apperr.ErrValidation, "некорректная заявка")
+	ErrLessonNotFound       = apperr.New(apperr.ErrNotFound, "пара не найдена в расписании")
+	ErrNotOwnLesson         = apperr.New(apperr.ErrForbidden, "можно переносить и отменять только свои пары")
+	ErrAlreadyReviewed      = apperr.New(apperr.ErrConflict, "заявка уже рассмотрена")
+)
+
+// maxTeacherRequests количество последних заявок, возвращаемых преподавателю
+const maxTeacherRequests = 50
+
+// SubmitChangeRequest сохраняет заявку преподавателя на отмену или перенос своей пары.
+// teacherNames - имена, под которыми преподаватель встречается в расписании (ФИО и подтвержденные варианты).
+// В request должны быть заполнены Kind, GroupName, Date и TimeStart пары, а для переноса -
+// NewDate и NewTimeStart или NewLessonNumber (NewClassroom - по желанию).
+// Даты должны быть календарными днями в часовом поясе колледжа.
+// Заявка ожидает одобрения администратором и до него на расписание не влияет.
+func (s *Service) SubmitChangeRequest(ctx context.Context, teacherID uuid.UUID, teacherNames []string, request *schedule.ChangeRequest) error {
+	if request.Kind != schedule.ChangeRequestCancel && request.Kind != schedule.ChangeRequestMove {
+		return fmt.Errorf("%w: неизвестный вид заявки %q", ErrInvalidChangeRequest, request.Kind)
+	}
+
+	today := clock.Today(request.Date.Location())
+	if request.Date.Before(today) {
+		return fmt.Errorf("%w: пара уже прошла", ErrInvalidChangeRequest)
+	}
+
+	lesson, err := s.findLesson(ctx, request)
+	if err != nil {
+		return err
+	}
+	if !isOwnLesson(lesson, teacherNames) {
+		return ErrNotOwnLesson
+	}
+
+	request.TimeStart = lesson.TimeStart
+	request.TimeEnd = lesson.TimeEnd
+	request.Subject = lesson.Subject
+	request.Teacher = lesson.Teacher
+	request.Classroom = lesson.Classroom
+
+	if request.Kind == schedule.ChangeRequestMove {
+		if request.NewDate == nil {
+			request.NewDate = &request.Date
+		}
+		if request.NewDate.Before(today) {
+			return fmt.Errorf("%w: нельзя перенести пару на прошедшую дату", ErrInvalidChangeRequest)
+		}
+
+		slot, err := bells.For(ctx).ResolveSlot(request.NewDate.Weekday(), request.NewLessonNumber, request.NewTimeStart, "")
+		if err != nil {
+			return fmt.Errorf("%w: %v", ErrInvalidChangeRequest, err)
+		}
+		if request.NewDate.Equal(request.Date) && slot.TimeStart == request.TimeStart {
+			return fmt.Errorf("%w: новый слот совпадает с текущим", ErrInvalidChangeRequest)
+		}
+		request.NewTimeStart = slot.TimeStart
+		request.NewTimeEnd = slot.TimeEnd
+		request.NewLessonNumber = slot.Number
+		request.NewClassroom = strings.TrimSpace(request.NewClassroom)
+	} else {
+		request.NewDate = nil
+		request.NewTimeStart, request.NewTimeEnd, request.NewLessonNumber, request.NewClassroom = "", "", 0, ""
+	}
+
+	request.ID = uuid.New()
+	request.TeacherID = teacherID
+	request.Comment = strings.TrimSpace(request.Comment)
+	request.Status = schedule.ChangeModerationPending
+
+	if err := s.scheduleRepo.CreateChangeRequest(ctx, request); err != nil {
+		return fmt.Errorf("ошибка сохранения заявки: %w", err)
+	}
+
+	log.Printf("Преподаватель %s подал заявку %s (%s) на пару %s группы %s %s в %s",
+		teacherNames[0], request.ID, request.Kind, request.Subject, request.GroupName, request.Date.Format(clock.DateLayout), request.TimeStart)
+	return nil
+}
+
+// isOwnLesson проверяет, что пару ведет преподаватель с одним из имен names
+func isOwnLesson(lesson *schedule.CurrentSchedule, names []string) bool {
+	for _, name := range names {
+		if strings.TrimSpace(lesson.Teacher) == strings.TrimSpace(name) {
+			return true
+		}
+	}
+	return false
+}
+
+// findLesson находит пару группы в актуальном расписании по дате и времени начала
+func (s *Service) findLesson(ctx context.Context, request *schedule.ChangeRequest) (*schedule.CurrentSchedule, error) {
+	entries, err := s.scheduleRepo.GetCurrentScheduleForGroup(ctx, request.GroupName, request.Date)
+	if err != nil {
+		return nil, fmt.Errorf("ошибка получения расписания группы: %w", err)
+	}
+
+	timeStart := clock.NormalizeClock(request.TimeStart)
+	for i := range entries {
+		if entries[i].TimeStart == timeStart {
+			return &entries[i], nil
+		}
+	}
+	return nil, ErrLessonNotFound
+}
+
+// ListTeacherChangeRequests возвращает последние заявки преподавателя
+func (s *Service) ListTeacherChangeRequests(ctx context.Context, teacherID uuid.UUID) ([]schedule.ChangeRequest, error) {
+	requests, err := s.scheduleRepo.GetChangeRequestsByTeacher(ctx, teacherID, maxTeacherRequests)
+	if err != nil {
+		return nil, fmt.Errorf("ошибка получения заявок преподавателя: %w", err)
+	}
+	return requests, nil
+}
+
+// ListPendingChangeRequests возвращает заявки, ожидающие рассмотрения администратором
+func (s *Service) ListPendingChangeRequests(ctx context.Context) ([]schedule.ChangeRequest, error) {
+	requests, err := s.scheduleRepo.GetPendingChangeRequests(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("ошибка получения заявок на рассмотрении: %w", err)
+	}
+	return requests, nil
+}
+
+// ApproveChangeRequest одобряет заявку преподавателя: создает по ней изменения расписания
+// (отмену пары и, для переноса, добавление в новый слот) и применяет их к current_schedule.
+// Возвращает отчет о применении; уведомления отправляет вызывающий.
+func (s *Service) ApproveChangeRequest(ctx context.Context, requestID, adminID uuid.UUID, comment string) (*schedule.ChangeRequest, *ApplyReport, error) {
+	request, err := s.reviewChangeRequest(ctx, requestID, adminID, schedule.ChangeModerationApproved, comment)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var created []schedule.ScheduleChange
+	for _, change := range requestChanges(bells.For(ctx), request) {
+		change.SnapshotID, err = s.scheduleRepo.FindSnapshotIDForDate(ctx, change.Date)
+		if err != nil {
+			log.Printf("Ошибка поиска снапшота для даты %s: %v", change.Date.Format(clock.DateLayout), err)
+		}
+		if err := s.scheduleRepo.CreateChange(ctx, &change); err != nil {
+			return request, nil, fmt.Errorf("ошибка создания изменения по заявке: %w", err)
+		}
+		created = append(created, change)
+	}
+
+	log.Printf("Заявка %s одобрена администратором %s, создано изменений: %d", requestID, adminID, len(created))
+
+	report, err := s.ApplyChanges(ctx, created)
+	if err != nil {
+		return request, report, fmt.Errorf("ошибка применения изменений по заявке: %w", err)
+	}
+	return request, report, nil
+}
+
+// RejectChangeRequest отклоняет заявку преподавателя
+func (s *Service) RejectChangeRequest(ctx context.Context, requestID, adminID uuid.UUID, comment string) (*schedule.ChangeRequest, error) {
+	request, err := s.reviewChangeRequest(ctx, requestID, adminID, schedule.ChangeModerationRejected, comment)
+	if err != nil {
+		return nil, err
+	}
+
+	log.Printf("Заявка %s отклонена администратором %s", requestID, adminID)
+	return request, nil
+}
+
+// reviewChangeRequest сохраняет решение администратора по заявке
+func (s *Service) reviewChangeRequest(ctx context.Context, requestID, adminID uuid.UUID, status, comment string) (*schedule.ChangeRequest, error) {
+	request, err := s.scheduleRepo.GetChangeRequestByID(ctx, requestID)
+	if err != nil {
+		return nil, fmt.Errorf("ошибка получения заявки: %w", err)
+	}
+	if request.Status != schedule.ChangeModerationPending {
+		return nil, ErrAlreadyReviewed
+	}
+
+	request.Status = status
+	request.ReviewedBy = &adminID
+	request.ReviewComment = strings.TrimSpace(comment)
+	if err := s.scheduleRepo.ReviewChangeRequest(ctx, request); err != nil {
+		return nil, fmt.Errorf("ошибка сохранения решения по заявке: %w", err)
+	}
+	return request, nil
+}
+
+// requestChanges формирует изменения расписания по одобренной заявке
+// (номер отменяемой пары - по расписанию звонков timings)
+func requestChanges(timings bells.Timings, request *schedule.ChangeRequest) []schedule.ScheduleChange {
+	number, _ := timings.NumberAt(request.Date.Weekday(), request.TimeStart)
+	cancellation := schedule.ScheduleChange{
+		ID:                uuid.New(),
+		GroupName:         request.GroupName,
+		Date:              request.Date,
+		TimeStart:         request.TimeStart,
+		TimeEnd:           request.TimeEnd,
+		Subject:           request.Subject,
+		Teacher:           request.Teacher,
+		Classroom:         request.Classroom,
+		ChangeType:        "cancellation",
+		OriginalSubject:   request.Subject,
+		Reason:            request.Comment,
+		LessonNumber:      number,
+		IsActive:          true,
+		ModerationStatus:  schedule.ChangeModerationApproved,
+		ModeratedBy:       request.ReviewedBy,
+		ModerationComment: request.ReviewComment,
+		RequestID:         &request.ID,
+	}
+
+	if request.Kind != schedule.ChangeRequestMove || request.NewDate == nil {
+		return []schedule.ScheduleChange{cancellation}
+	}
+
+	classroom := request.NewClassroom
+	if classroom == "" {
+		classroom = request.Classroom
+	}
+	addition := cancellation
+	addition.ID = uuid.New()
+	addition.Date = *request.NewDate
+	addition.TimeStart = request.NewTimeStart
+	addition.TimeEnd = request.NewTimeEnd
+	addition.LessonNumber = request.NewLessonNumber
+	addition.Classroom = classroom
+	addition.ChangeType = "addition"
+	addition.OriginalSubject = ""
+
+	return []schedule.ScheduleChange{cancellation, addition}
+}
@@ -0,0 +1,146 @@
+package changes
+
+import (
+	"context"
+	"crypto/sha256"
+	"database/sql"
+	"encoding/hex"
+	"fmt"
+	"log"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/Ultrahd-dev/student-schedule-app/backend/internal/clock"
+	"github.com/Ultrahd-dev/student-schedule-app/backend/internal/outbox"
+	"github.com/Ultrahd-dev/student-schedule-app/backend/internal/schedule"
+)
+
+// Fingerprint возвращает отпечаток строки таблицы изменений.
+// Считается по данным строки в том виде, в котором они пришли из таблицы,
+// поэтому одна и та же строка дает один отпечаток при каждом парсинге.
+func Fingerprint(change *schedule.ScheduleChange) string {
+	fields := []string{
+		change.GroupName,
+		change.Date.Format(clock.DateLayout),
+		clock.NormalizeClock(change.TimeStart),
+		clock.NormalizeClock(change.TimeEnd),
+		strconv.Itoa(change.LessonNumber),
+		change.ChangeType,
+		change.Subject,
+		change.Teacher,
+		change.Classroom,
+		change.OriginalSubject,
+	}
+	// Подгруппа входит в отпечаток только у изменений подгрупп: отпечатки
+	// остальных строк совпадают с сохраненными до появления подгрупп
+	if change.Subgroup > 0 {
+		fields = append(fields, strconv.Itoa(change.Subgroup))
+	}
+	// Вид занятия - так же, только если указан
+	if change.LessonType != "" {
+		fields = append(fields, "type:"+change.LessonType)
+	}
+
+	sum := sha256.Sum256([]byte(strings.Join(fields, "\x1f")))
+	return hex.EncodeToString(sum[:])
+}
+
+// RevertChanges откатывает изменения, строки которых исчезли из таблицы изменений:
+// записи current_schedule возвращаются к версии до применения изменения
+// (добавленные изменением пары деактивируются), а само изменение деактивируется.
+// Каждое изменение откатывается в своей транзакции; ошибка одного не мешает остальным.
+// Возвращает изменения, откат которых затронул current_schedule.
+func (s *Service) RevertChanges(ctx context.Context, changes []schedule.ScheduleChange) []schedule.ScheduleChange {
+	var reverted []schedule.ScheduleChange
+	for i := range changes {
+		change := changes[i]
+		wasApplied := change.ApplyStatus == schedule.ChangeApplyApplied
+
+		if err := s.revertOne(ctx, &change); err != nil {
+			log.Printf("Ошибка отката изменения %s: %v", change.ID, err)
+			continue
+		}
+
+		log.Printf("Откачено изменение %s для группы %s на %s: строка исчезла из таблицы изменений",
+			change.ID, change.GroupName, change.Date.Format(clock.DateLayout))
+		if wasApplied {
+			reverted = append(reverted, change)
+		}
+	}
+
+	return reverted
+}
+
+// revertOne откатывает одно изменение в отдельной транзакции
+func (s *Service) revertOne(ctx context.Context, change *schedule.ScheduleChange) error {
+	return s.transactor.Do(ctx, func(ctx context.Context) error {
+		// Изменение, которое не применялось, достаточно деактивировать
+		wasApplied := change.ApplyStatus == schedule.ChangeApplyApplied
+		if wasApplied {
+			if err := s.restoreEntries(ctx, change); err != nil {
+				return err
+			}
+		}
+
+		if err := s.scheduleRepo.RevertChange(ctx, change); err != nil {
+			return fmt.Errorf("ошибка деактивации изменения: %w", err)
+		}
+
+		// Об откате уведомляем, только если изменение успело попасть в расписание
+		if wasApplied {
+			if err := s.addChangeEvent(ctx, outbox.EventChangeReverted, change); err != nil {
+				return fmt.Errorf("ошибка записи события отката: %w", err)
+			}
+		}
+		return nil
+	})
+}
+
+// restoreEntries возвращает записи current_schedule, записанные изменением, к предыдущей версии.
+// Записи, которые позже перезаписало другое изменение, не трогаются.
+func (s *Service) restoreEntries(ctx context.Context, change *schedule.ScheduleChange) error {
+	entries, err := s.scheduleRepo.GetEntriesBySource(ctx, change.ID)
+	if err != nil {
+		return fmt.Errorf("ошибка получения записей изменения: %w", err)
+	}
+
+	for i := range entries {
+		entry := &entries[i]
+
+		previous, err := s.scheduleRepo.GetEntryVersionBefore(ctx, entry.ID, change.ID)
+		switch {
+		case err == sql.ErrNoRows:
+			// Запись создана самим изменением (добавленная пара) - убираем ее
+			entry.IsActive = false
+		case err != nil:
+			return fmt.Errorf("ошибка получения предыдущей версии записи: %w", err)
+		default:
+			entry.Subject = previous.Subject
+			entry.Teacher = previous.Teacher
+			entry.Classroom = previous.Classroom
+			entry.SourceType = previous.SourceType
+			entry.SourceID = previous.SourceID
+			entry.IsActive = previous.IsActive
+		}
+
+		if err := s.scheduleRepo.UpdateCurrentScheduleEntry(ctx, entry); err != nil {
+			return fmt.Errorf("ошибка восстановления записи расписания: %w", err)
+		}
+	}
+
+	return nil
+}
+
+// VanishedChanges возвращает отслеживаемые изменения на даты начиная с from,
+// строк которых нет среди отпечатков seen текущего парсинга
+func VanishedChanges(tracked []schedule.ScheduleChange, seen map[string]bool, from time.Time) []schedule.ScheduleChange {
+	var vanished []schedule.ScheduleChange
+	for _, change := range tracked {
+		if seen[change.Fingerprint] || clock.Anchor(change.Date, from.Location()).Before(from) {
+			continue
+		}
+		vanished = append(vanished, change)
+	}
+	return vanished
+}
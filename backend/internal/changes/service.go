@@ -5,24 +5,112 @@ package changes
 import (
 	"context"
 	"database/sql"
+	"errors"
 	"fmt"
 	"log"
 	"time"
 
+	"github.com/Ultrahd-dev/student-schedule-app/backend/internal/bells"
+	"github.com/Ultrahd-dev/student-schedule-app/backend/internal/outbox"
 	"github.com/Ultrahd-dev/student-schedule-app/backend/internal/schedule"
+	"github.com/Ultrahd-dev/student-schedule-app/backend/internal/tenant"
+	"github.com/Ultrahd-dev/student-schedule-app/backend/internal/txn"
 	"github.com/google/uuid"
 )
 
+// defaultBatchSize количество изменений в одной транзакции по умолчанию
+const defaultBatchSize = 50
+
+// changeSavepoint точка сохранения, изолирующая применение одного изменения в пакете
+const changeSavepoint = "apply_change"
+
+// errAlreadyApplied означает, что изменение уже отражено в current_schedule
+var errAlreadyApplied = errors.New("изменение уже применено")
+
+// applyLockName имя распределенной блокировки применения изменений
+const applyLockName = "changes:apply"
+
+// Locker распределенная блокировка между экземплярами API
+type Locker interface {
+	Lock(ctx context.Context, name string) (func(), error)
+}
+
+// EventWriter записывает доменные события в транзакции изменения данных из контекста
+type EventWriter interface {
+	Add(ctx context.Context, event outbox.Event) error
+}
+
 // Service предоставляет функции для отслеживания изменений в расписании
 type Service struct {
-	scheduleRepo *schedule.Repository
+	scheduleRepo schedule.ScheduleStore
+	transactor   txn.Transactor
+	batchSize    int
+	locker       Locker      // Блокировка применения изменений (может быть nil)
+	events       EventWriter // Outbox событий применения и отката (может быть nil)
 }
 
 // NewService создает новый сервис отслеживания изменений
-func NewService(scheduleRepo *schedule.Repository) *Service {
+func NewService(scheduleRepo schedule.ScheduleStore, transactor txn.Transactor, config Config) *Service {
+	batchSize := config.BatchSize
+	if batchSize <= 0 {
+		batchSize = defaultBatchSize
+	}
+
 	return &Service{
 		scheduleRepo: scheduleRepo,
+		transactor:   transactor,
+		batchSize:    batchSize,
+	}
+}
+
+// SetLocker включает распределенную блокировку применения изменений,
+// чтобы несколько экземпляров API не применяли изменения одновременно
+func (s *Service) SetLocker(locker Locker) {
+	s.locker = locker
+}
+
+// SetOutbox включает запись событий change.applied и change.reverted в outbox
+// в той же транзакции, что и применение или откат изменения
+func (s *Service) SetOutbox(events EventWriter) {
+	s.events = events
+}
+
+// PublishesEvents сообщает, что события изменений публикуются через outbox,
+// и уведомления по примененным изменениям рассылают подписчики relay
+func (s *Service) PublishesEvents() bool {
+	return s.events != nil
+}
+
+// addChangeEvent записывает в транзакции из контекста событие eventType об изменении, если outbox настроен
+func (s *Service) addChangeEvent(ctx context.Context, eventType string, change *schedule.ScheduleChange) error {
+	if s.events == nil {
+		return nil
+	}
+
+	event, err := outbox.NewEvent(eventType, change.ID, outbox.ChangeEvent{
+		ChangeID:     change.ID,
+		GroupName:    change.GroupName,
+		Date:         change.Date.Format("2006-01-02"),
+		LessonNumber: change.LessonNumber,
+		ChangeType:   change.ChangeType,
+		Subject:      change.Subject,
+	})
+	if err != nil {
+		return err
+	}
+	return s.events.Add(ctx, event)
+}
+
+// lockApply захватывает блокировку применения изменений колледжа, если она настроена
+func (s *Service) lockApply(ctx context.Context) (func(), error) {
+	if s.locker == nil {
+		return func() {}, nil
 	}
+	unlock, err := s.locker.Lock(ctx, tenant.Scoped(ctx, applyLockName))
+	if err != nil {
+		return nil, fmt.Errorf("ошибка блокировки применения изменений: %w", err)
+	}
+	return unlock, nil
 }
 
 // DetectChanges обнаруживает изменения в расписании
@@ -43,67 +131,202 @@ func (s *Service) DetectChanges(ctx context.Context) error {
 
 // ApplyChanges применяет обнаруженные изменения к актуальному расписанию
 // В соответствии с ТЗ: "Если есть изменения: ... Обновление current_schedule"
-func (s *Service) ApplyChanges(ctx context.Context, changes []schedule.ScheduleChange) error {
-	log.Printf("Применяем %d изменений к актуальному расписанию", len(changes))
+//
+// Изменения применяются пакетами по batchSize, каждый пакет - в своей транзакции.
+// Ошибка одного изменения откатывает только его (через точку сохранения),
+// а статус применения сохраняется в самой записи изменения. Если применение
+// прервется, оставшиеся изменения сохранят статус pending и будут применены
+// ApplyPendingChanges.
+func (s *Service) ApplyChanges(ctx context.Context, changes []schedule.ScheduleChange) (*ApplyReport, error) {
+	log.Printf("Применяем %d изменений к актуальному расписанию (пакетами по %d)", len(changes), s.batchSize)
 
-	// Начинаем транзакцию для обеспечения целостности данных
-	tx, err := s.scheduleRepo.BeginTx(ctx)
+	report := &ApplyReport{Total: len(changes)}
+	unlock, err := s.lockApply(ctx)
 	if err != nil {
-		return fmt.Errorf("ошибка начала транзакции: %w", err)
+		return report, err
 	}
-	defer func() {
-		// Откатываем транзакцию в случае ошибки
-		if err != nil {
-			_ = tx.Rollback()
+	defer unlock()
+
+	for start := 0; start < len(changes); start += s.batchSize {
+		end := start + s.batchSize
+		if end > len(changes) {
+			end = len(changes)
 		}
-	}()
 
-	// Для каждого изменения:
-	appliedCount := 0
-	for _, change := range changes {
-		// 1. Обновляем current_schedule
-		// ИСПРАВЛЕНО: Передаем ctx в updateCurrentSchedule
-		err := s.updateCurrentSchedule(ctx, tx, &change)
+		if err := s.applyBatch(ctx, changes[start:end], report); err != nil {
+			return report, fmt.Errorf("ошибка применения пакета изменений %d-%d: %w", start+1, end, err)
+		}
+	}
+
+	log.Printf("Изменения применены к актуальному расписанию: применено %d, пропущено %d, ошибок %d из %d",
+		report.Applied, report.Skipped, report.Failed, report.Total)
+	return report, nil
+}
+
+// ApplyPendingChanges применяет изменения, оставшиеся в статусе pending
+// (например, после перезапуска во время применения)
+func (s *Service) ApplyPendingChanges(ctx context.Context) (*ApplyReport, error) {
+	report := &ApplyReport{}
+	unlock, err := s.lockApply(ctx)
+	if err != nil {
+		return report, err
+	}
+	defer unlock()
+
+	for {
+		pending, err := s.scheduleRepo.GetPendingChanges(ctx, s.batchSize)
 		if err != nil {
-			log.Printf("Ошибка обновления current_schedule для изменения %s: %v", change.ID, err)
-			// Не возвращаем ошибку, продолжаем применять другие изменения
-			continue
+			return report, fmt.Errorf("ошибка получения непримененных изменений: %w", err)
+		}
+		if len(pending) == 0 {
+			break
 		}
 
-		log.Printf("Обновлено current_schedule для изменения: %s", change.ID)
-		appliedCount++
+		log.Printf("Возобновляем применение %d изменений", len(pending))
+		report.Total += len(pending)
+		if err := s.applyBatch(ctx, pending, report); err != nil {
+			return report, fmt.Errorf("ошибка применения пакета изменений: %w", err)
+		}
 	}
 
-	// Коммитим транзакцию
-	if err := tx.Commit(); err != nil {
-		return fmt.Errorf("ошибка коммита транзакции: %w", err)
+	return report, nil
+}
+
+// applyBatch применяет пакет изменений в одной транзакции и дополняет отчет.
+// Ошибка возвращается только если не удалось зафиксировать сам пакет.
+func (s *Service) applyBatch(ctx context.Context, batch []schedule.ScheduleChange, report *ApplyReport) error {
+	var results []ChangeResult
+	err := s.transactor.Do(ctx, func(ctx context.Context) error {
+		results = make([]ChangeResult, 0, len(batch))
+		for i := range batch {
+			change := batch[i]
+			result, err := s.applyOne(ctx, &change)
+			if err != nil {
+				return err
+			}
+			results = append(results, result)
+		}
+		return nil
+	})
+	if err != nil {
+		// Пакет не зафиксирован: ни одно изменение из него не применено
+		for _, result := range results {
+			if markErr := s.scheduleRepo.MarkChangeApplyError(ctx, result.ChangeID, err.Error()); markErr != nil {
+				log.Printf("Ошибка сохранения статуса изменения %s: %v", result.ChangeID, markErr)
+			}
+			result.Status = schedule.ChangeApplyError
+			result.Error = err.Error()
+			report.add(result)
+		}
+		return err
 	}
 
-	log.Printf("Изменения применены успешно к актуальному расписанию (%d из %d)", appliedCount, len(changes))
+	for _, result := range results {
+		report.add(result)
+	}
 	return nil
 }
 
+// applyOne применяет одно изменение внутри транзакции пакета и сохраняет его статус.
+// Ошибка возвращается только при сбое самой транзакции (точки сохранения).
+func (s *Service) applyOne(ctx context.Context, change *schedule.ScheduleChange) (ChangeResult, error) {
+	result := ChangeResult{ChangeID: change.ID}
+
+	if change.ApplyStatus == schedule.ChangeApplyApplied {
+		result.Change = *change
+		result.Status = schedule.ChangeApplySkipped
+		return result, nil
+	}
+
+	if err := txn.Savepoint(ctx, changeSavepoint); err != nil {
+		return result, err
+	}
+
+	applyErr := s.updateCurrentSchedule(ctx, change)
+	switch {
+	case applyErr == nil:
+		result.Status = schedule.ChangeApplyApplied
+		log.Printf("Обновлено current_schedule для изменения: %s", change.ID)
+	case errors.Is(applyErr, errAlreadyApplied):
+		result.Status = schedule.ChangeApplySkipped
+		log.Printf("Изменение %s уже применено, пропускаем", change.ID)
+	default:
+		// Откатываем только это изменение, остальные в пакете продолжают применяться
+		if err := txn.RollbackToSavepoint(ctx, changeSavepoint); err != nil {
+			return result, err
+		}
+		result.Status = schedule.ChangeApplyError
+		result.Error = applyErr.Error()
+		log.Printf("Ошибка обновления current_schedule для изменения %s: %v", change.ID, applyErr)
+	}
+
+	if err := s.scheduleRepo.SetChangeApplyStatus(ctx, change.ID, result.Status, result.Error); err != nil {
+		return result, err
+	}
+	if result.Status == schedule.ChangeApplyApplied {
+		if err := s.addChangeEvent(ctx, outbox.EventChangeApplied, change); err != nil {
+			return result, err
+		}
+	}
+	if err := txn.ReleaseSavepoint(ctx, changeSavepoint); err != nil {
+		return result, err
+	}
+
+	change.ApplyStatus = result.Status
+	change.ApplyError = result.Error
+	result.Change = *change
+	return result, nil
+}
+
 // updateCurrentSchedule обновляет запись в current_schedule на основе изменения
 // ИСПРАВЛЕНО: Добавлен ctx как первый параметр, удалён дубликат
-func (s *Service) updateCurrentSchedule(ctx context.Context, tx *sql.Tx, change *schedule.ScheduleChange) error {
+func (s *Service) updateCurrentSchedule(ctx context.Context, change *schedule.ScheduleChange) error {
+	// Добавленная пара не заменяет существующую, а встает в свой слот рядом с ней
+	if change.ChangeType == "addition" {
+		return s.applyAddition(ctx, change)
+	}
+
 	// 1. Проверяем, существует ли уже запись в current_schedule для этой пары
 	// ИСПРАВЛЕНО: Передаем ctx в вызовы методов репозитория
-	existing, err := s.scheduleRepo.GetCurrentScheduleEntry(ctx, tx, change.GroupName, change.Date, change.TimeStart)
+	// Изменение для подгруппы меняет только пару этой подгруппы
+	existing, err := s.scheduleRepo.GetCurrentScheduleEntry(ctx, change.GroupName, change.Subgroup, change.Date, change.TimeStart)
 	if err != nil && err != sql.ErrNoRows {
 		return fmt.Errorf("ошибка получения существующей записи: %w", err)
 	}
 
+	// Пару уже изменяло другое изменение: сохраняем связь, чтобы перезапись не терялась
+	if existing != nil && existing.SourceType == "change" && existing.SourceID != change.ID {
+		if err := s.supersede(ctx, change, existing.SourceID); err != nil {
+			return err
+		}
+	}
+
+	// Отмененная пара убирается из актуального расписания
+	if change.ChangeType == "cancellation" {
+		if existing == nil {
+			return errAlreadyApplied
+		}
+		existing.IsActive = false
+		existing.SourceType = "change"
+		existing.SourceID = change.ID
+		if err := s.scheduleRepo.UpdateCurrentScheduleEntry(ctx, existing); err != nil {
+			return fmt.Errorf("ошибка отмены пары: %w", err)
+		}
+		return nil
+	}
+
 	// 2. Если запись существует, обновляем её
 	if existing != nil {
 		// Обновляем существующую запись
 		existing.Subject = change.Subject
 		existing.Teacher = change.Teacher
 		existing.Classroom = change.Classroom
+		existing.LessonType = change.LessonType
 		existing.SourceType = "change"
 		existing.SourceID = change.ID
 
 		// ИСПРАВЛЕНО: Передаем ctx
-		err = s.scheduleRepo.UpdateCurrentScheduleEntry(ctx, tx, existing)
+		err = s.scheduleRepo.UpdateCurrentScheduleEntry(ctx, existing)
 		if err != nil {
 			return fmt.Errorf("ошибка обновления существующей записи: %w", err)
 		}
@@ -121,10 +344,12 @@ func (s *Service) updateCurrentSchedule(ctx context.Context, tx *sql.Tx, change
 			SourceType: "change",
 			SourceID:   change.ID,
 			IsActive:   true,
+			Subgroup:   change.Subgroup,
+			LessonType: change.LessonType,
 		}
 
 		// ИСПРАВЛЕНО: Передаем ctx
-		err = s.scheduleRepo.CreateCurrentScheduleEntry(ctx, tx, newEntry)
+		err = s.scheduleRepo.CreateCurrentScheduleEntry(ctx, newEntry)
 		if err != nil {
 			return fmt.Errorf("ошибка создания новой записи: %w", err)
 		}
@@ -133,6 +358,78 @@ func (s *Service) updateCurrentSchedule(ctx context.Context, tx *sql.Tx, change
 	return nil
 }
 
+// supersede связывает изменение с ранее примененным изменением той же пары, которое оно перезаписывает
+func (s *Service) supersede(ctx context.Context, change *schedule.ScheduleChange, supersededID uuid.UUID) error {
+	if err := s.scheduleRepo.SetChangeSupersedes(ctx, change.ID, supersededID); err != nil {
+		return fmt.Errorf("ошибка сохранения связи с перезаписанным изменением: %w", err)
+	}
+	change.SupersedesID = &supersededID
+
+	log.Printf("Изменение %s перезаписывает изменение %s (группа %s, %s в %s)",
+		change.ID, supersededID, change.GroupName, change.Date.Format("2006-01-02"), change.TimeStart)
+	return nil
+}
+
+// applyAddition добавляет в current_schedule новую пару.
+// Слот определяется по расписанию звонков (время по номеру пары и наоборот).
+// Если новая пара пересекается с уже стоящими занятиями группы, она все равно
+// добавляется, а изменение помечается has_overlap для проверки администратором.
+func (s *Service) applyAddition(ctx context.Context, change *schedule.ScheduleChange) error {
+	slot, err := bells.For(ctx).ResolveSlot(change.Date.Weekday(), change.LessonNumber, change.TimeStart, change.TimeEnd)
+	if err != nil {
+		return fmt.Errorf("ошибка определения времени добавленной пары: %w", err)
+	}
+	change.TimeStart = slot.TimeStart
+	change.TimeEnd = slot.TimeEnd
+	change.LessonNumber = slot.Number
+
+	overlapping, err := s.scheduleRepo.FindOverlappingEntries(ctx, change.GroupName, change.Date, change.TimeStart, change.TimeEnd)
+	if err != nil {
+		return fmt.Errorf("ошибка поиска пересечений: %w", err)
+	}
+
+	change.HasOverlap = false
+	for _, entry := range overlapping {
+		// Повторное применение того же изменения не считается пересечением
+		if entry.SourceID == change.ID {
+			return errAlreadyApplied
+		}
+		// Занятия разных подгрупп идут одновременно
+		if entry.Subgroup != 0 && change.Subgroup != 0 && entry.Subgroup != change.Subgroup {
+			continue
+		}
+		change.HasOverlap = true
+		log.Printf("Добавленная пара %s %s-%s группы %s пересекается с %q (%s-%s)",
+			change.Subject, change.TimeStart, change.TimeEnd, change.GroupName, entry.Subject, entry.TimeStart, entry.TimeEnd)
+	}
+
+	if err := s.scheduleRepo.UpdateChangeSlot(ctx, change); err != nil {
+		return fmt.Errorf("ошибка сохранения слота изменения: %w", err)
+	}
+
+	newEntry := &schedule.CurrentSchedule{
+		ID:         uuid.New(),
+		GroupName:  change.GroupName,
+		Date:       change.Date,
+		TimeStart:  change.TimeStart,
+		TimeEnd:    change.TimeEnd,
+		Subject:    change.Subject,
+		Teacher:    change.Teacher,
+		Classroom:  change.Classroom,
+		SourceType: "change",
+		SourceID:   change.ID,
+		IsActive:   true,
+		Subgroup:   change.Subgroup,
+		LessonType: change.LessonType,
+	}
+
+	if err := s.scheduleRepo.CreateCurrentScheduleEntry(ctx, newEntry); err != nil {
+		return fmt.Errorf("ошибка создания добавленной пары: %w", err)
+	}
+
+	return nil
+}
+
 // GetChangesForGroup получает изменения для конкретной группы на определенную дату
 // В соответствии с ТЗ: "Получение изменений для группы"
 func (s *Service) GetChangesForGroup(ctx context.Context, groupName string, date time.Time) ([]schedule.ScheduleChange, error) {
@@ -171,4 +468,3 @@ func (s *Service) CreateChange(ctx context.Context, change *schedule.ScheduleCha
 	log.Printf("Создана запись об изменении: %s для группы %s", change.ID, change.GroupName)
 	return nil
 }
-
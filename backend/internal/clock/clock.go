@@ -0,0 +1,130 @@
+// Package clock предоставляет функции для работы с датами и временем занятий
+// в часовом поясе колледжа. Все даты расписания хранятся как календарные дни
+// (DATE), а время пар - как время суток (TIME), поэтому их интерпретация
+// должна происходить в одном, явно заданном часовом поясе, а не в часовом
+// поясе сервера.
+package clock
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// DateLayout формат даты, используемый в таблицах колледжа
+const DateLayout = "02.01.2006"
+
+// ClockLayout формат времени пары ("ЧЧ:ММ")
+const ClockLayout = "15:04"
+
+// MinutesPerDay количество минут в сутках
+const MinutesPerDay = 24 * 60
+
+// LoadLocation загружает часовой пояс по имени из базы IANA
+// Пустое имя трактуется как UTC
+func LoadLocation(name string) (*time.Location, error) {
+	if name == "" {
+		return time.UTC, nil
+	}
+	loc, err := time.LoadLocation(name)
+	if err != nil {
+		return nil, fmt.Errorf("неизвестный часовой пояс %q: %w", name, err)
+	}
+	return loc, nil
+}
+
+// Now возвращает текущее время в часовом поясе колледжа
+func Now(loc *time.Location) time.Time {
+	return time.Now().In(loc)
+}
+
+// Today возвращает текущую календарную дату в часовом поясе колледжа
+func Today(loc *time.Location) time.Time {
+	return DateOf(time.Now(), loc)
+}
+
+// DateOf возвращает полночь того календарного дня, которому момент t
+// соответствует в часовом поясе loc.
+// Используется для входящих моментов времени (например, google.protobuf.Timestamp в UTC).
+func DateOf(t time.Time, loc *time.Location) time.Time {
+	t = t.In(loc)
+	return startOfDay(t.Year(), t.Month(), t.Day(), loc)
+}
+
+// Anchor переносит календарную дату d в часовой пояс loc без сдвига дня.
+// Используется для дат, прочитанных из колонок DATE, которые драйвер
+// возвращает как полночь UTC.
+func Anchor(d time.Time, loc *time.Location) time.Time {
+	return startOfDay(d.Year(), d.Month(), d.Day(), loc)
+}
+
+// startOfDay возвращает начало календарного дня в часовом поясе loc. Обычно это
+// полночь, но если часы переводятся в полночь (например, America/Sao_Paulo
+// до 2019 года), полуночи в этот день нет и time.Date возвращает момент
+// предыдущего дня - тогда берется первый час, который есть в этом дне.
+func startOfDay(year int, month time.Month, day int, loc *time.Location) time.Time {
+	t := time.Date(year, month, day, 0, 0, 0, 0, loc)
+	// Переводы часов не длиннее пары часов; дни, пропущенные целиком, не исправить
+	for i := 0; i < 3 && t.Day() != day; i++ {
+		t = t.Add(time.Hour)
+	}
+	return t
+}
+
+// WeekStart возвращает понедельник недели, в которую входит календарная дата d
+func WeekStart(d time.Time) time.Time {
+	offset := (int(d.Weekday()) + 6) % 7 // Понедельник - 0, воскресенье - 6
+	return d.AddDate(0, 0, -offset)
+}
+
+// ParseDate парсит дату в формате ДД.ММ.ГГГГ в часовом поясе колледжа
+func ParseDate(s string, loc *time.Location) (time.Time, error) {
+	return time.ParseInLocation(DateLayout, strings.TrimSpace(s), loc)
+}
+
+// ParseClock парсит время суток ("9:55", "09:55", "09:55:00") в минуты от полуночи
+func ParseClock(s string) (int, error) {
+	s = strings.TrimSpace(s)
+	parts := strings.Split(s, ":")
+	if len(parts) < 2 || len(parts) > 3 {
+		return 0, fmt.Errorf("некорректное время %q", s)
+	}
+
+	hours, err := strconv.Atoi(parts[0])
+	if err != nil || hours < 0 || hours > 23 {
+		return 0, fmt.Errorf("некорректные часы в %q", s)
+	}
+	minutes, err := strconv.Atoi(parts[1])
+	if err != nil || minutes < 0 || minutes > 59 {
+		return 0, fmt.Errorf("некорректные минуты в %q", s)
+	}
+
+	return hours*60 + minutes, nil
+}
+
+// FormatClock форматирует минуты от полуночи в строку "ЧЧ:ММ"
+func FormatClock(minutes int) string {
+	return fmt.Sprintf("%02d:%02d", minutes/60, minutes%60)
+}
+
+// NormalizeClock приводит время суток к единому формату "ЧЧ:ММ".
+// Некорректные и пустые значения возвращаются без изменений.
+func NormalizeClock(s string) string {
+	minutes, err := ParseClock(s)
+	if err != nil {
+		return strings.TrimSpace(s)
+	}
+	return FormatClock(minutes)
+}
+
+// At возвращает момент начала (или окончания) пары: календарная дата date
+// плюс время суток clockStr в часовом поясе loc
+func At(date time.Time, clockStr string, loc *time.Location) (time.Time, error) {
+	minutes, err := ParseClock(clockStr)
+	if err != nil {
+		return time.Time{}, err
+	}
+	d := Anchor(date, loc)
+	return time.Date(d.Year(), d.Month(), d.Day(), minutes/60, minutes%60, 0, 0, loc), nil
+}
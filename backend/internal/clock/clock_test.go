@@ -0,0 +1,163 @@
+package clock_test
+
+import (
+	"testing"
+	"time"
+	_ "time/tzdata" // Тесты не зависят от базы часовых поясов системы
+
+	"github.com/Ultrahd-dev/student-schedule-app/backend/internal/clock"
+)
+
+// mustLocation загружает часовой пояс или завершает тест
+func mustLocation(t *testing.T, name string) *time.Location {
+	t.Helper()
+	loc, err := clock.LoadLocation(name)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return loc
+}
+
+// withServerZone подменяет часовой пояс сервера (time.Local) на время теста
+func withServerZone(t *testing.T, name string) {
+	t.Helper()
+	local := time.Local
+	time.Local = mustLocation(t, name)
+	t.Cleanup(func() { time.Local = local })
+}
+
+func TestAnchor(t *testing.T) {
+	moscow := mustLocation(t, "Europe/Moscow")
+	berlin := mustLocation(t, "Europe/Berlin")
+	newYork := mustLocation(t, "America/New_York")
+	saoPaulo := mustLocation(t, "America/Sao_Paulo")
+
+	tests := []struct {
+		name string
+		date time.Time
+		loc  *time.Location
+		want string // Календарный день в loc
+	}{
+		{"DATE из базы (полночь UTC)", time.Date(2025, 9, 1, 0, 0, 0, 0, time.UTC), moscow, "2025-09-01"},
+		{"поздний вечер западнее UTC", time.Date(2025, 11, 2, 23, 30, 0, 0, newYork), time.UTC, "2025-11-02"},
+		{"ранее утро восточнее UTC", time.Date(2025, 9, 1, 1, 0, 0, 0, moscow), newYork, "2025-09-01"},
+		{"переход на летнее время", time.Date(2025, 3, 30, 0, 0, 0, 0, time.UTC), berlin, "2025-03-30"},
+		{"переход на зимнее время", time.Date(2025, 10, 26, 0, 0, 0, 0, time.UTC), berlin, "2025-10-26"},
+		// В Сан-Паулу 04.11.2018 часы перевели с 00:00 на 01:00 - полуночи в этот день не было
+		{"нет полуночи из-за перехода", time.Date(2018, 11, 4, 0, 0, 0, 0, time.UTC), saoPaulo, "2018-11-04"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := clock.Anchor(tt.date, tt.loc)
+			if got.Location() != tt.loc {
+				t.Errorf("часовой пояс %s, ожидался %s", got.Location(), tt.loc)
+			}
+			if day := got.Format("2006-01-02"); day != tt.want {
+				t.Errorf("день %s, ожидался %s", day, tt.want)
+			}
+		})
+	}
+}
+
+func TestAt(t *testing.T) {
+	berlin := mustLocation(t, "Europe/Berlin")
+	vladivostok := mustLocation(t, "Asia/Vladivostok")
+	// Результат не зависит от часового пояса сервера
+	withServerZone(t, "America/Los_Angeles")
+
+	tests := []struct {
+		name    string
+		date    time.Time
+		clock   string
+		loc     *time.Location
+		want    time.Time // Ожидаемый момент в UTC
+		wantErr bool
+	}{
+		{"обычный день", time.Date(2025, 3, 29, 0, 0, 0, 0, time.UTC), "08:30", berlin,
+			time.Date(2025, 3, 29, 7, 30, 0, 0, time.UTC), false},
+		{"день перехода на летнее время", time.Date(2025, 3, 30, 0, 0, 0, 0, time.UTC), "08:30", berlin,
+			time.Date(2025, 3, 30, 6, 30, 0, 0, time.UTC), false},
+		{"день перехода на зимнее время", time.Date(2025, 10, 26, 0, 0, 0, 0, time.UTC), "08:30", berlin,
+			time.Date(2025, 10, 26, 7, 30, 0, 0, time.UTC), false},
+		{"дата в поясе сервера", time.Date(2025, 9, 1, 0, 0, 0, 0, time.Local), "9:55", vladivostok,
+			time.Date(2025, 8, 31, 23, 55, 0, 0, time.UTC), false},
+		{"время с секундами", time.Date(2025, 9, 1, 0, 0, 0, 0, time.UTC), "09:55:00", vladivostok,
+			time.Date(2025, 8, 31, 23, 55, 0, 0, time.UTC), false},
+		// Время после полуночи относится к той же календарной дате, а не к следующей
+		{"после полуночи", time.Date(2025, 9, 1, 0, 0, 0, 0, time.UTC), "00:30", vladivostok,
+			time.Date(2025, 8, 31, 14, 30, 0, 0, time.UTC), false},
+		{"последняя минута суток", time.Date(2025, 9, 1, 0, 0, 0, 0, time.UTC), "23:59", vladivostok,
+			time.Date(2025, 9, 1, 13, 59, 0, 0, time.UTC), false},
+		{"24:00", time.Date(2025, 9, 1, 0, 0, 0, 0, time.UTC), "24:00", vladivostok, time.Time{}, true},
+		{"некорректное время", time.Date(2025, 9, 1, 0, 0, 0, 0, time.UTC), "9.55", vladivostok, time.Time{}, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := clock.At(tt.date, tt.clock, tt.loc)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ошибка %v, ожидалась ошибка: %v", err, tt.wantErr)
+			}
+			if tt.wantErr {
+				return
+			}
+			if !got.Equal(tt.want) {
+				t.Errorf("момент %s, ожидался %s", got.UTC(), tt.want)
+			}
+			if got.Location() != tt.loc {
+				t.Errorf("часовой пояс %s, ожидался %s", got.Location(), tt.loc)
+			}
+		})
+	}
+}
+
+func TestParseDate(t *testing.T) {
+	berlin := mustLocation(t, "Europe/Berlin")
+	withServerZone(t, "Asia/Tokyo")
+
+	tests := []struct {
+		input   string
+		want    time.Time
+		wantErr bool
+	}{
+		{"01.09.2025", time.Date(2025, 9, 1, 0, 0, 0, 0, berlin), false},
+		{" 30.03.2025 ", time.Date(2025, 3, 30, 0, 0, 0, 0, berlin), false},
+		{"26.10.2025", time.Date(2025, 10, 26, 0, 0, 0, 0, berlin), false},
+		{"31.02.2025", time.Time{}, true},
+		{"2025-09-01", time.Time{}, true},
+		{"1.9.2025", time.Time{}, true},
+		{"", time.Time{}, true},
+	}
+	for _, tt := range tests {
+		got, err := clock.ParseDate(tt.input, berlin)
+		if (err != nil) != tt.wantErr {
+			t.Errorf("%q: ошибка %v, ожидалась ошибка: %v", tt.input, err, tt.wantErr)
+			continue
+		}
+		if tt.wantErr {
+			continue
+		}
+		if !got.Equal(tt.want) || got.Location() != berlin {
+			t.Errorf("%q: %s, ожидалось %s", tt.input, got, tt.want)
+		}
+	}
+}
+
+func TestNormalizeClock(t *testing.T) {
+	tests := map[string]string{
+		"09:55":    "09:55",
+		"9:55":     "09:55",
+		"09:55:00": "09:55",
+		" 7:05 ":   "07:05",
+		"0:00":     "00:00",
+		"00:30":    "00:30",
+		"23:59":    "23:59",
+		"24:00":    "24:00", // Некорректное значение возвращается как есть
+		"9.55":     "9.55",
+		"":         "",
+	}
+	for input, want := range tests {
+		if got := clock.NormalizeClock(input); got != want {
+			t.Errorf("NormalizeClock(%q) = %q, ожидалось %q", input, got, want)
+		}
+	}
+}
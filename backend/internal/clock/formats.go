@@ -0,0 +1,44 @@
+package clock
+
+import "time"
+
+// Formats форматы даты и времени в текстах для конкретного пользователя
+// (уведомления, печатные отчеты). Значения - раскладки для time.Format.
+type Formats struct {
+	Date  string // Дата, например DateLayout
+	Clock string // Время суток, например ClockLayout
+}
+
+// DefaultFormats форматы колледжа, используемые, если пользователь их не выбрал
+var DefaultFormats = Formats{Date: DateLayout, Clock: ClockLayout}
+
+// FormatDate форматирует календарную дату
+func (f Formats) FormatDate(t time.Time) string {
+	if f.Date == "" {
+		return t.Format(DateLayout)
+	}
+	return t.Format(f.Date)
+}
+
+// FormatTime форматирует время суток момента t
+func (f Formats) FormatTime(t time.Time) string {
+	if f.Clock == "" {
+		return t.Format(ClockLayout)
+	}
+	return t.Format(f.Clock)
+}
+
+// FormatDateTime форматирует дату и время суток момента t
+func (f Formats) FormatDateTime(t time.Time) string {
+	return f.FormatDate(t) + " " + f.FormatTime(t)
+}
+
+// FormatClock переводит время пары ("9:55", "09:55:00") в формат пользователя.
+// Некорректные и пустые значения возвращаются без изменений.
+func (f Formats) FormatClock(s string) string {
+	minutes, err := ParseClock(s)
+	if err != nil {
+		return s
+	}
+	return f.FormatTime(time.Date(2000, time.January, 1, minutes/60, minutes%60, 0, 0, time.UTC))
+}
@@ -6,21 +6,69 @@ import (
 	"os"
 	"time"
 
+	"github.com/Ultrahd-dev/student-schedule-app/backend/internal/clock"
 	"gopkg.in/yaml.v2"
 )
 
+// Переменные окружения, которые используются, если путь к конфигурации
+// и строка подключения к базе не переданы флагами --config и --dsn
+const (
+	EnvConfigPath = "SCHEDULE_CONFIG"
+	EnvDSN        = "SCHEDULE_DSN"
+)
+
 // Config основная структура конфигурации приложения
 type Config struct {
-	Server   ServerConfig   `yaml:"server"`
-	Database DatabaseConfig `yaml:"database"`
-	Redis    RedisConfig    `yaml:"redis"`
-	Scraper  ScraperConfig  `yaml:"scraper"`
-	JWT      JWTConfig      `yaml:"jwt"`
+	Server        ServerConfig        `yaml:"server"`
+	Database      DatabaseConfig      `yaml:"database"`
+	Redis         RedisConfig         `yaml:"redis"`
+	Scraper       ScraperConfig       `yaml:"scraper"`
+	JWT           JWTConfig           `yaml:"jwt"`
+	College       CollegeConfig       `yaml:"college"`
+	Retention     RetentionConfig     `yaml:"retention"`
+	Changes       ChangesConfig       `yaml:"changes"`
+	Admin         AdminConfig         `yaml:"admin"`
+	Storage       StorageConfig       `yaml:"storage"`
+	Captcha       CaptchaConfig       `yaml:"captcha"`
+	Registration  RegistrationConfig  `yaml:"registration"`
+	TwoFactor     TwoFactorConfig     `yaml:"two_factor"`
+	Jobs          JobsConfig          `yaml:"jobs"`
+	Outbox        OutboxConfig        `yaml:"outbox"`
+	Metrics       MetricsConfig       `yaml:"metrics"`
+	Features      FeaturesConfig      `yaml:"features"`
+	UserCache     UserCacheConfig     `yaml:"user_cache"`
+	ScheduleCache ScheduleCacheConfig `yaml:"schedule_cache"`
+	Gateway       GatewayConfig       `yaml:"gateway"`
+	Calendar      CalendarConfig      `yaml:"calendar"`
+	PDF           PDFConfig           `yaml:"pdf"`
+	LDAP          LDAPConfig          `yaml:"ldap"`
+	Broker        BrokerConfig        `yaml:"broker"`
+	Consultations ConsultationsConfig `yaml:"consultations"`
+	Dashboard     DashboardConfig     `yaml:"dashboard"`
+	Mail          MailConfig          `yaml:"mail"`
+	Poll          PollConfig          `yaml:"poll"`
+	QuietHours    QuietHoursConfig    `yaml:"quiet_hours"`
+	Kiosk         KioskConfig         `yaml:"kiosk"`
+	Feedback      FeedbackConfig      `yaml:"feedback"`
 }
 
 // ServerConfig конфигурация сервера
 type ServerConfig struct {
-	Port int `yaml:"port"`
+	Port           int `yaml:"port"`
+	MaxRecvMsgSize int `yaml:"max_recv_msg_size"` // Максимальный размер входящего сообщения, байт
+	MaxSendMsgSize int `yaml:"max_send_msg_size"` // Максимальный размер исходящего сообщения, байт
+	// CompressMinSize ответы от этого размера (байт) сжимаются gzip, если клиент его поддерживает;
+	// отрицательное значение отключает сжатие
+	CompressMinSize int             `yaml:"compress_min_size"`
+	Keepalive       KeepaliveConfig `yaml:"keepalive"`
+}
+
+// KeepaliveConfig проверка соединений gRPC (см. grpc.TransportOptions)
+type KeepaliveConfig struct {
+	Time                  time.Duration `yaml:"time"`                     // Проверять соединение после простоя
+	Timeout               time.Duration `yaml:"timeout"`                  // Ожидание ответа на проверку
+	MaxConnectionIdle     time.Duration `yaml:"max_connection_idle"`      // Закрывать соединение без запросов
+	MinClientPingInterval time.Duration `yaml:"min_client_ping_interval"` // Допустимая частота проверок клиентом
 }
 
 // DatabaseConfig конфигурация базы данных
@@ -31,6 +79,27 @@ type DatabaseConfig struct {
 	Password string `yaml:"password"`
 	DBName   string `yaml:"dbname"`
 	SSLMode  string `yaml:"sslmode"`
+	// DSN строка подключения целиком; если задана, параметры выше не используются
+	DSN string `yaml:"dsn"`
+	// StatementTimeout ограничивает время выполнения каждого запроса на сервере
+	// (statement_timeout PostgreSQL); 0 - без ограничения. Применяется ко всем
+	// соединениям, включая DSN и реплики (см. database.Open).
+	StatementTimeout time.Duration `yaml:"statement_timeout"`
+	// LockTimeout ограничивает ожидание блокировки строки или таблицы (lock_timeout)
+	LockTimeout time.Duration `yaml:"lock_timeout"`
+	// Replicas строки подключения к репликам для частых запросов на чтение
+	// (расписание группы, уведомления); пустой список - все запросы к основной базе
+	Replicas             []string      `yaml:"replicas"`
+	ReplicaCheckInterval time.Duration `yaml:"replica_check_interval"` // Период проверки доступности реплик
+}
+
+// GetDSN формирует строку подключения к PostgreSQL
+func (c DatabaseConfig) GetDSN() string {
+	if c.DSN != "" {
+		return c.DSN
+	}
+	return fmt.Sprintf("host=%s port=%d user=%s password=%s dbname=%s sslmode=%s",
+		c.Host, c.Port, c.User, c.Password, c.DBName, c.SSLMode)
 }
 
 // RedisConfig конфигурация Redis
@@ -44,12 +113,317 @@ type ScraperConfig struct {
 	Timeout          time.Duration `yaml:"timeout"`
 	MainScheduleGIDs []int64       `yaml:"main_schedule_gids"` // Список gid листов основного расписания
 	ChangesGID       int64         `yaml:"changes_gid"`        // gid листа изменений
+	// Circuit breaker запросов к сайту колледжа и Google Таблицам
+	BreakerThreshold   int           `yaml:"breaker_threshold"`    // Ошибок подряд до приостановки запросов
+	BreakerOpenTimeout time.Duration `yaml:"breaker_open_timeout"` // Пауза перед пробным запросом
+	// SnapshotName шаблон названия снапшота: {start}, {end} - даты периода, {title} - текст ссылки на таблицу
+	SnapshotName string `yaml:"snapshot_name"`
+	// Fixtures режим локальной разработки: вместо сайта колледжа читаются локальные файлы
+	Fixtures ScraperFixturesConfig `yaml:"fixtures"`
+}
+
+// ScraperFixturesConfig настройки режима фикстур парсера
+type ScraperFixturesConfig struct {
+	Dir      string                  `yaml:"dir"` // Каталог файлов фикстур; пусто - режим выключен
+	Failures []ScraperFixtureFailure `yaml:"failures"`
+}
+
+// ScraperFixtureFailure имитируемый сбой в режиме фикстур
+type ScraperFixtureFailure struct {
+	Target      string  `yaml:"target"`      // site или ID таблицы (main, changes); пусто - все запросы
+	Mode        string  `yaml:"mode"`        // timeout, http_500, malformed_csv
+	Probability float64 `yaml:"probability"` // Доля запросов со сбоем (0 - все)
 }
 
 // JWTConfig конфигурация JWT
 type JWTConfig struct {
-	Secret     string        `yaml:"secret"`
-	Expiration time.Duration `yaml:"expiration"`
+	Secret          string        `yaml:"secret"`
+	Expiration      time.Duration `yaml:"expiration"`
+	GuestExpiration time.Duration `yaml:"guest_expiration"` // Время жизни гостевого токена
+}
+
+// CollegeConfig общие настройки колледжа
+type CollegeConfig struct {
+	// Timezone часовой пояс колледжа в формате IANA (например, "Asia/Yekaterinburg").
+	// Все даты и время занятий интерпретируются в этом часовом поясе.
+	Timezone string `yaml:"timezone"`
+	// TravelMinutes время перехода между корпусами в минутах: если перерыв между парами
+	// в разных корпусах короче, пара помечается предупреждением
+	TravelMinutes int `yaml:"travel_minutes"`
+}
+
+// Location возвращает часовой пояс колледжа
+func (c CollegeConfig) Location() (*time.Location, error) {
+	return clock.LoadLocation(c.Timezone)
+}
+
+// ConsultationsConfig настройки консультаций преподавателей
+type ConsultationsConfig struct {
+	// ReminderBefore за сколько до начала консультации напоминать подписанным студентам
+	ReminderBefore time.Duration `yaml:"reminder_before"`
+	CheckInterval  time.Duration `yaml:"check_interval"` // Период проверки предстоящих консультаций
+}
+
+// RetentionConfig настройки хранения и архивации данных
+type RetentionConfig struct {
+	// SnapshotsKeep количество последних снапшотов, хранящихся в основной таблице.
+	// Данные более старых снапшотов переносятся в архив.
+	SnapshotsKeep int           `yaml:"snapshots_keep"`
+	Interval      time.Duration `yaml:"interval"` // Период запуска задачи архивации
+	// PartitionMonthsAhead на сколько месяцев вперед создавать секции current_schedule
+	PartitionMonthsAhead int `yaml:"partition_months_ahead"`
+	// ArchiveSchedule переносить записи current_schedule прошедших семестров в архив
+	ArchiveSchedule bool `yaml:"archive_schedule"`
+	// SemesterStarts начала семестров в формате "ММ-ДД" (по умолчанию 1 сентября и 1 февраля)
+	SemesterStarts []string `yaml:"semester_starts"`
+}
+
+// ChangesConfig настройки применения изменений расписания
+type ChangesConfig struct {
+	ApplyBatchSize int `yaml:"apply_batch_size"` // Количество изменений в одной транзакции
+	// Moderated включает модерацию: изменения из таблицы применяются
+	// только после одобрения администратором
+	Moderated bool `yaml:"moderated"`
+}
+
+// AdminConfig учетная запись начального администратора.
+// Если email задан, при запуске создается администратор (если его еще нет).
+type AdminConfig struct {
+	Email    string `yaml:"email"`
+	Password string `yaml:"password"`
+}
+
+// StorageConfig настройки хранилища файлов (аватары, вложения, исходные данные
+// парсинга, отчеты)
+type StorageConfig struct {
+	Backend  string `yaml:"backend"`   // Бэкенд хранилища: "local", "s3" или "" (хранилище отключено)
+	LocalDir string `yaml:"local_dir"` // Каталог локального хранилища
+	// PublicURL внешний адрес HTTP-сервера, раздающего файлы по подписанным ссылкам
+	PublicURL         string          `yaml:"public_url"`
+	HTTPPort          int             `yaml:"http_port"`           // Порт HTTP-сервера для локального хранилища
+	SigningSecret     string          `yaml:"signing_secret"`      // Ключ подписи ссылок (по умолчанию - секрет JWT)
+	URLTTL            time.Duration   `yaml:"url_ttl"`             // Время действия подписанной ссылки
+	MaxAvatarSize     int64           `yaml:"max_avatar_size"`     // Максимальный размер аватара в байтах
+	MaxAttachmentSize int64           `yaml:"max_attachment_size"` // Максимальный размер вложения в байтах
+	S3                StorageS3Config `yaml:"s3"`
+	// Lifecycle правила удаления старых объектов (выполняются задачами обслуживания)
+	Lifecycle []StorageLifecycleRule `yaml:"lifecycle"`
+}
+
+// StorageS3Config настройки S3-совместимого хранилища (AWS S3, MinIO)
+type StorageS3Config struct {
+	Endpoint        string        `yaml:"endpoint"`        // Адрес API, например https://s3.amazonaws.com или http://minio:9000
+	PublicEndpoint  string        `yaml:"public_endpoint"` // Адрес для ссылок на скачивание (по умолчанию - endpoint)
+	Region          string        `yaml:"region"`
+	Bucket          string        `yaml:"bucket"`
+	AccessKeyID     string        `yaml:"access_key_id"`
+	SecretAccessKey string        `yaml:"secret_access_key"`
+	PathStyle       bool          `yaml:"path_style"` // Адреса вида endpoint/bucket/key (нужно для MinIO)
+	Timeout         time.Duration `yaml:"timeout"`
+}
+
+// StorageLifecycleRule удаляет объекты с префиксом ключа Prefix старше MaxAge
+type StorageLifecycleRule struct {
+	Prefix string        `yaml:"prefix"`
+	MaxAge time.Duration `yaml:"max_age"`
+}
+
+// RegistrationConfig настройки регистрации
+type RegistrationConfig struct {
+	// InvitationRequired разрешает регистрацию студентов и преподавателей
+	// только по коду приглашения, выпущенному администратором
+	InvitationRequired bool `yaml:"invitation_required"`
+}
+
+// TwoFactorConfig настройки двухфакторной аутентификации (TOTP)
+type TwoFactorConfig struct {
+	Issuer string   `yaml:"issuer"` // Название сервиса в приложении-аутентификаторе
+	Roles  []string `yaml:"roles"`  // Роли, которым доступно подключение 2FA
+}
+
+// CaptchaConfig защита регистрации и входа от ботов
+type CaptchaConfig struct {
+	// Provider провайдер проверки: "hcaptcha", "turnstile", "proof_of_work" или "" (отключено)
+	Provider   string        `yaml:"provider"`
+	SiteKey    string        `yaml:"site_key"`   // Публичный ключ сайта hCaptcha/Turnstile
+	Secret     string        `yaml:"secret"`     // Секретный ключ hCaptcha/Turnstile (для proof_of_work по умолчанию - секрет JWT)
+	VerifyURL  string        `yaml:"verify_url"` // Адрес проверки токена (по умолчанию - адрес провайдера)
+	Timeout    time.Duration `yaml:"timeout"`    // Таймаут запроса к провайдеру
+	Difficulty int           `yaml:"difficulty"` // Сложность proof-of-work (нулевых бит в начале хеша)
+	TTL        time.Duration `yaml:"ttl"`        // Время действия задачи proof-of-work
+}
+
+// JobsConfig настройки очереди фоновых задач; незаданные значения берутся по умолчанию
+type JobsConfig struct {
+	Workers      int           `yaml:"workers"`       // Число воркеров на экземпляр API
+	PollInterval time.Duration `yaml:"poll_interval"` // Период опроса очереди, когда задач нет
+	MaxAttempts  int           `yaml:"max_attempts"`  // Число попыток на задачу
+	Timeout      time.Duration `yaml:"timeout"`       // Время на одну попытку
+	RetryBackoff time.Duration `yaml:"retry_backoff"` // Задержка перед второй попыткой, далее удваивается
+	Retention    time.Duration `yaml:"retention"`     // Сколько хранить выполненные задачи
+}
+
+// OutboxConfig настройки relay доменных событий; незаданные значения берутся по умолчанию
+type OutboxConfig struct {
+	PollInterval time.Duration `yaml:"poll_interval"` // Период опроса таблицы событий
+	BatchSize    int           `yaml:"batch_size"`    // Событий за одну транзакцию публикации
+	RetryBackoff time.Duration `yaml:"retry_backoff"` // Задержка перед повторной публикацией, далее удваивается
+	Retention    time.Duration `yaml:"retention"`     // Сколько хранить опубликованные события
+}
+
+// MetricsConfig настройки HTTP сервера метрик Prometheus
+type MetricsConfig struct {
+	Port int `yaml:"port"` // Порт /metrics; 0 - метрики отключены
+}
+
+// FeaturesConfig настройки флагов функций
+type FeaturesConfig struct {
+	RefreshInterval time.Duration   `yaml:"refresh_interval"` // Период перечитывания флагов из базы
+	Defaults        map[string]bool `yaml:"defaults"`         // Значения флагов, которых нет в базе, для этого окружения
+}
+
+// GatewayConfig настройки REST-фасада gRPC API
+type GatewayConfig struct {
+	Port int `yaml:"port"` // Порт фасада, /openapi.json и Swagger UI (/docs); 0 - фасад отключен
+}
+
+// DashboardConfig настройки встроенной панели администратора
+type DashboardConfig struct {
+	Port         int  `yaml:"port"`          // Порт панели (/admin/); 0 - панель отключена
+	SecureCookie bool `yaml:"secure_cookie"` // Cookie сессии только по HTTPS (панель за TLS-прокси)
+}
+
+// MailConfig отправка писем через SMTP-сервер колледжа
+type MailConfig struct {
+	Host     string        `yaml:"host"` // Пусто - письма не отправляются
+	Port     int           `yaml:"port"`
+	TLS      bool          `yaml:"tls"` // Подключение сразу по TLS (порт 465); иначе STARTTLS
+	Username string        `yaml:"username"`
+	Password string        `yaml:"password"`
+	From     string        `yaml:"from"`    // Адрес отправителя
+	AppURL   string        `yaml:"app_url"` // Ссылка на приложение в приглашениях
+	Timeout  time.Duration `yaml:"timeout"`
+}
+
+// PollConfig настройки ожидания уведомлений клиентами без push (PollUpdates)
+type PollConfig struct {
+	MaxTimeout time.Duration `yaml:"max_timeout"` // Максимальное время ожидания одного запроса
+	// RecheckInterval период перепроверки базы во время ожидания: уведомление,
+	// созданное другим экземпляром API, обнаруживается не позже этого периода
+	RecheckInterval time.Duration `yaml:"recheck_interval"`
+}
+
+// QuietHoursConfig тихие часы push-уведомлений в часовом поясе пользователя
+// (по умолчанию - колледжа): несрочные push откладываются до их окончания
+type QuietHoursConfig struct {
+	Enabled    bool          `yaml:"enabled"`
+	Start      string        `yaml:"start"`       // Начало ЧЧ:ММ
+	End        string        `yaml:"end"`         // Окончание ЧЧ:ММ
+	UrgentLead time.Duration `yaml:"urgent_lead"` // Отмена пары, начинающейся раньше окончания плюс urgent_lead, отправляется сразу
+}
+
+// KioskConfig настройки табло расписания в коридорах (GET /kiosk на порту REST-фасада)
+type KioskConfig struct {
+	CacheTTL  time.Duration `yaml:"cache_ttl"`  // Время кэширования расписания табло
+	RateLimit int           `yaml:"rate_limit"` // Запросов в минуту с одного адреса
+}
+
+// FeedbackConfig жалобы пользователей на ошибки в расписании
+type FeedbackConfig struct {
+	RescrapeThreshold int           `yaml:"rescrape_threshold"` // Жалоб на пару до повторного парсинга ее таблицы; 0 - не парсить
+	RescrapeCooldown  time.Duration `yaml:"rescrape_cooldown"`  // Наименьший промежуток между парсингами одной таблицы по жалобам
+}
+
+// CalendarConfig настройки подписки на личное расписание в календарных приложениях
+type CalendarConfig struct {
+	HTTPPort      int    `yaml:"http_port"`      // Порт раздачи календарей (ICS); 0 - подписка отключена
+	PublicURL     string `yaml:"public_url"`     // Внешний адрес раздачи для ссылок подписки
+	SigningSecret string `yaml:"signing_secret"` // Ключ подписи ссылок (по умолчанию - секрет JWT)
+	PastDays      int    `yaml:"past_days"`      // Сколько прошедших дней включать в календарь
+	FutureDays    int    `yaml:"future_days"`    // На сколько дней вперед включать расписание
+}
+
+// PDFConfig настройки печатной версии расписания
+type PDFConfig struct {
+	// Шрифт TrueType с кириллицей; пустой - поиск в системных шрифтах (DejaVu Sans, Liberation Sans, Arial)
+	FontPath string `yaml:"font_path"`
+}
+
+// LDAPConfig вход через каталог LDAP / Active Directory колледжа
+type LDAPConfig struct {
+	Enabled            bool   `yaml:"enabled"`
+	URL                string `yaml:"url"`                  // ldap://host:389 или ldaps://host:636
+	StartTLS           bool   `yaml:"start_tls"`            // Перейти на TLS после подключения по ldap://
+	InsecureSkipVerify bool   `yaml:"insecure_skip_verify"` // Не проверять сертификат (только для тестовых стендов)
+	BindDN             string `yaml:"bind_dn"`              // Служебная учетная запись для поиска пользователей
+	BindPassword       string `yaml:"bind_password"`
+	BaseDN             string `yaml:"base_dn"`         // Где искать пользователей
+	LoginAttribute     string `yaml:"login_attribute"` // Атрибут, совпадающий с email при входе
+	ObjectClass        string `yaml:"object_class"`    // Класс записей пользователей
+	// Атрибуты для профиля пользователя, создаваемого при первом входе
+	NameAttribute       string `yaml:"name_attribute"`
+	GroupAttribute      string `yaml:"group_attribute"` // Учебная группа студента
+	DepartmentAttribute string `yaml:"department_attribute"`
+	PositionAttribute   string `yaml:"position_attribute"`
+	// RoleGroups DN групп каталога (memberOf) по ролям: admin, teacher, student
+	RoleGroups  map[string][]string `yaml:"role_groups"`
+	DefaultRole string              `yaml:"default_role"` // Роль вне групп RoleGroups; пусто - вход запрещен
+	Timeout     time.Duration       `yaml:"timeout"`
+}
+
+// BrokerConfig публикация доменных событий во внешний брокер сообщений
+type BrokerConfig struct {
+	Backend       string        `yaml:"backend"` // "nats" или "" (публикация отключена)
+	URL           string        `yaml:"url"`     // nats://host:4222 или tls://host:4222
+	Username      string        `yaml:"username"`
+	Password      string        `yaml:"password"`
+	Token         string        `yaml:"token"`          // Токен аутентификации (вместо логина и пароля)
+	JetStream     bool          `yaml:"jetstream"`      // Ждать подтверждения сохранения в потоке JetStream
+	SubjectPrefix string        `yaml:"subject_prefix"` // Префикс тем (например, "college1.")
+	Timeout       time.Duration `yaml:"timeout"`
+}
+
+// UserCacheConfig настройки кэша пользователей, которых middleware получает на каждый запрос
+type UserCacheConfig struct {
+	Enabled  bool          `yaml:"enabled"`
+	Size     int           `yaml:"size"`      // Пользователей в кэше в памяти
+	LocalTTL time.Duration `yaml:"local_ttl"` // Время жизни в памяти; изменения с других экземпляров видны не позже
+	Redis    bool          `yaml:"redis"`     // Общий кэш в Redis (адрес из раздела redis)
+	RedisTTL time.Duration `yaml:"redis_ttl"` // Время жизни в Redis
+}
+
+// ScheduleCacheConfig настройки кэша расписания групп в памяти экземпляра API
+type ScheduleCacheConfig struct {
+	Enabled bool          `yaml:"enabled"`
+	Size    int           `yaml:"size"` // Записей "группа на дату" в кэше
+	TTL     time.Duration `yaml:"ttl"`  // Время жизни; несброшенные изменения видны не позже
+	// Redis рассылать сброс кэша группы на дату другим экземплярам через Redis pub/sub
+	// (адрес из раздела redis); без него сбрасывается только кэш своего экземпляра
+	Redis   bool   `yaml:"redis"`
+	Channel string `yaml:"channel"` // Канал pub/sub (по умолчанию cache:invalidate)
+}
+
+// ResolvePath возвращает путь к файлу конфигурации: значение флага, затем
+// переменную окружения SCHEDULE_CONFIG, затем defaultPath
+func ResolvePath(flagValue, defaultPath string) string {
+	if flagValue != "" {
+		return flagValue
+	}
+	if path := os.Getenv(EnvConfigPath); path != "" {
+		return path
+	}
+	return defaultPath
+}
+
+// OverrideDSN заменяет параметры подключения к базе строкой dsn из флага
+// или, если флаг не задан, из переменной окружения SCHEDULE_DSN
+func (c *Config) OverrideDSN(dsn string) {
+	if dsn == "" {
+		dsn = os.Getenv(EnvDSN)
+	}
+	if dsn != "" {
+		c.Database.DSN = dsn
+	}
 }
 
 // LoadConfig загружает конфигурацию из YAML файла
@@ -70,10 +444,133 @@ func LoadConfig(filename string) (*Config, error) {
 		return nil, fmt.Errorf("failed to decode config file %s: %w", filename, err)
 	}
 
+	// Без секрета JWT токены (и ссылки на файлы, календарь, капча, подписанные
+	// тем же секретом по умолчанию) может подделать кто угодно
+	if cfg.JWT.Secret == "" {
+		return nil, fmt.Errorf("config file %s: jwt.secret is empty", filename)
+	}
+
 	// Устанавливаем значения по умолчанию, если они не заданы
 	if cfg.Scraper.Timeout == 0 {
 		cfg.Scraper.Timeout = 30 * time.Second
 	}
+	if cfg.College.Timezone == "" {
+		cfg.College.Timezone = "Asia/Yekaterinburg"
+	}
+	if cfg.College.TravelMinutes == 0 {
+		cfg.College.TravelMinutes = 10
+	}
+	if cfg.ScheduleCache.TTL == 0 {
+		cfg.ScheduleCache.TTL = time.Minute
+	}
+	if cfg.Consultations.ReminderBefore == 0 {
+		cfg.Consultations.ReminderBefore = time.Hour
+	}
+	if cfg.Consultations.CheckInterval == 0 {
+		cfg.Consultations.CheckInterval = time.Minute
+	}
+	if cfg.Retention.SnapshotsKeep == 0 {
+		cfg.Retention.SnapshotsKeep = 8
+	}
+	if cfg.Retention.Interval == 0 {
+		cfg.Retention.Interval = 24 * time.Hour
+	}
+	if cfg.Retention.PartitionMonthsAhead == 0 {
+		cfg.Retention.PartitionMonthsAhead = 3
+	}
+	if cfg.JWT.Expiration == 0 {
+		cfg.JWT.Expiration = 24 * time.Hour
+	}
+	if cfg.JWT.GuestExpiration == 0 {
+		cfg.JWT.GuestExpiration = 2 * time.Hour
+	}
+	if cfg.Storage.SigningSecret == "" {
+		cfg.Storage.SigningSecret = cfg.JWT.Secret
+	}
+	if cfg.Calendar.SigningSecret == "" {
+		cfg.Calendar.SigningSecret = cfg.JWT.Secret
+	}
+	if cfg.Calendar.PastDays == 0 {
+		cfg.Calendar.PastDays = 7
+	}
+	if cfg.Calendar.FutureDays == 0 {
+		cfg.Calendar.FutureDays = 28
+	}
+	if cfg.Storage.URLTTL == 0 {
+		cfg.Storage.URLTTL = 15 * time.Minute
+	}
+	if cfg.TwoFactor.Issuer == "" {
+		cfg.TwoFactor.Issuer = "Student Schedule"
+	}
+	if len(cfg.TwoFactor.Roles) == 0 {
+		cfg.TwoFactor.Roles = []string{"admin", "teacher"}
+	}
+	if cfg.Captcha.Provider == "proof_of_work" && cfg.Captcha.Secret == "" {
+		cfg.Captcha.Secret = cfg.JWT.Secret
+	}
+	if cfg.Captcha.Timeout == 0 {
+		cfg.Captcha.Timeout = 5 * time.Second
+	}
+	if cfg.Captcha.Difficulty == 0 {
+		cfg.Captcha.Difficulty = 20
+	}
+	if cfg.Captcha.TTL == 0 {
+		cfg.Captcha.TTL = 5 * time.Minute
+	}
+	if cfg.Changes.ApplyBatchSize == 0 {
+		cfg.Changes.ApplyBatchSize = 50
+	}
+	if cfg.Server.MaxRecvMsgSize == 0 {
+		cfg.Server.MaxRecvMsgSize = 4 << 20
+	}
+	if cfg.Server.MaxSendMsgSize == 0 {
+		cfg.Server.MaxSendMsgSize = 16 << 20
+	}
+	if cfg.Server.CompressMinSize == 0 {
+		cfg.Server.CompressMinSize = 4 << 10
+	}
+	if cfg.Server.Keepalive.Time == 0 {
+		cfg.Server.Keepalive.Time = 2 * time.Minute
+	}
+	if cfg.Server.Keepalive.Timeout == 0 {
+		cfg.Server.Keepalive.Timeout = 20 * time.Second
+	}
+	if cfg.Server.Keepalive.MaxConnectionIdle == 0 {
+		cfg.Server.Keepalive.MaxConnectionIdle = 30 * time.Minute
+	}
+	if cfg.Server.Keepalive.MinClientPingInterval == 0 {
+		cfg.Server.Keepalive.MinClientPingInterval = 20 * time.Second
+	}
+	if cfg.LDAP.LoginAttribute == "" {
+		cfg.LDAP.LoginAttribute = "mail"
+	}
+	if cfg.LDAP.NameAttribute == "" {
+		cfg.LDAP.NameAttribute = "displayName"
+	}
+	if cfg.LDAP.GroupAttribute == "" {
+		cfg.LDAP.GroupAttribute = "department"
+	}
+	if cfg.LDAP.DepartmentAttribute == "" {
+		cfg.LDAP.DepartmentAttribute = "department"
+	}
+	if cfg.LDAP.PositionAttribute == "" {
+		cfg.LDAP.PositionAttribute = "title"
+	}
+	if cfg.LDAP.Timeout == 0 {
+		cfg.LDAP.Timeout = 10 * time.Second
+	}
+	if cfg.Broker.Timeout == 0 {
+		cfg.Broker.Timeout = 5 * time.Second
+	}
+	if cfg.UserCache.Size == 0 {
+		cfg.UserCache.Size = 10000
+	}
+	if cfg.UserCache.LocalTTL == 0 {
+		cfg.UserCache.LocalTTL = 5 * time.Second
+	}
+	if cfg.UserCache.RedisTTL == 0 {
+		cfg.UserCache.RedisTTL = time.Minute
+	}
 
 	return cfg, nil
 }
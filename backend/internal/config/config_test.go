@@ -0,0 +1,44 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestLoadConfigRequiresJWTSecret(t *testing.T) {
+	dir := t.TempDir()
+	write := func(name, content string) string {
+		t.Helper()
+		path := filepath.Join(dir, name)
+		if err := os.WriteFile(path, []byte(content), 0o600); err != nil {
+			t.Fatal(err)
+		}
+		return path
+	}
+
+	if _, err := LoadConfig(write("empty.yaml", "server:\n  port: 8080\n")); err == nil || !strings.Contains(err.Error(), "jwt.secret") {
+		t.Errorf("конфигурация без секрета JWT загружена, ошибка %v", err)
+	}
+
+	cfg, err := LoadConfig(write("jwt.yaml", "jwt:\n  secret: \"test-secret\"\n"))
+	if err != nil {
+		t.Fatalf("LoadConfig: %v", err)
+	}
+	if cfg.Storage.SigningSecret != "test-secret" || cfg.Calendar.SigningSecret != "test-secret" || cfg.JWT.Expiration == 0 {
+		t.Errorf("значения по умолчанию из секрета JWT не заданы: %+v %+v %+v", cfg.JWT, cfg.Storage, cfg.Calendar)
+	}
+}
+
+func TestShippedConfigsLoad(t *testing.T) {
+	for _, name := range []string{"config.yaml", "config.dev.yaml"} {
+		cfg, err := LoadConfig(filepath.Join("..", "..", "configs", name))
+		if err != nil {
+			t.Fatalf("%s: %v", name, err)
+		}
+		if cfg.Gateway.Port == 0 || cfg.Calendar.HTTPPort == 0 || cfg.Kiosk.RateLimit == 0 {
+			t.Errorf("%s: не загружены разделы gateway, calendar или kiosk", name)
+		}
+	}
+}
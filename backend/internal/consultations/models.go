@@ -0,0 +1,31 @@
+// Package consultations реализует часы консультаций преподавателей: преподаватель
+// публикует еженедельные консультации, студенты групп, у которых он ведет занятия,
+// видят их на отдельной вкладке и по подписке получают напоминания.
+package consultations
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Consultation еженедельная консультация преподавателя
+type Consultation struct {
+	ID           uuid.UUID    `db:"id"`
+	TeacherID    uuid.UUID    `db:"teacher_id"`
+	Teacher      string       `db:"teacher"`       // ФИО преподавателя
+	TeacherNames []string     `db:"teacher_names"` // Имена преподавателя в расписании
+	Weekday      time.Weekday `db:"weekday"`
+	TimeStart    string       `db:"time_start"` // ЧЧ:ММ
+	TimeEnd      string       `db:"time_end"`   // ЧЧ:ММ
+	Classroom    string       `db:"classroom"`
+	Comment      string       `db:"comment"`
+	CreatedAt    time.Time    `db:"created_at"`
+	Subscribed   bool         // Пользователь, для которого получен список, подписан на напоминания
+}
+
+// NextDate возвращает ближайшую дату консультации, начиная с календарной даты from
+func (c *Consultation) NextDate(from time.Time) time.Time {
+	offset := (int(c.Weekday) - int(from.Weekday()) + 7) % 7
+	return from.AddDate(0, 0, offset)
+}
@@ -0,0 +1,273 @@
+package consultations
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	"github.com/Ultrahd-dev/student-schedule-app/backend/internal/clock"
+	"github.com/Ultrahd-dev/student-schedule-app/backend/internal/tenant"
+	"github.com/google/uuid"
+	"github.com/lib/pq"
+)
+
+// Repository предоставляет доступ к консультациям преподавателей в базе данных
+type Repository struct {
+	db *sql.DB
+}
+
+// NewRepository создает новый репозиторий консультаций
+func NewRepository(db *sql.DB) *Repository {
+	return &Repository{db: db}
+}
+
+// consultationColumns колонки консультации для queryConsultations
+const consultationColumns = `
+	c.id, c.teacher_id, c.teacher, c.teacher_names, c.weekday, c.time_start, c.time_end,
+	c.classroom, c.comment, c.created_at`
+
+// teacherNotDeleted условие выборки консультаций: консультации удаленного
+// преподавателя скрыты, пока его не восстановят
+const teacherNotDeleted = `EXISTS (SELECT 1 FROM users u WHERE u.id = c.teacher_id AND u.deleted_at IS NULL)`
+
+// ReplaceTeacherConsultations заменяет все консультации преподавателя в колледже из контекста
+func (r *Repository) ReplaceTeacherConsultations(ctx context.Context, teacherID uuid.UUID, consultations []Consultation) error {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer func() { _ = tx.Rollback() }()
+
+	collegeID := tenant.CollegeID(ctx)
+	if _, err := tx.ExecContext(ctx,
+		`DELETE FROM consultation_hours WHERE teacher_id = $1 AND college_id = $2`,
+		teacherID, collegeID); err != nil {
+		return fmt.Errorf("failed to delete consultations: %w", err)
+	}
+
+	query := `
+		INSERT INTO consultation_hours
+			(id, teacher_id, teacher, teacher_names, weekday, time_start, time_end, classroom, comment, college_id)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10)
+		RETURNING created_at`
+	for i := range consultations {
+		c := &consultations[i]
+		err := tx.QueryRowContext(ctx, query,
+			c.ID,
+			teacherID,
+			c.Teacher,
+			pq.Array(c.TeacherNames),
+			isoWeekday(c.Weekday),
+			c.TimeStart,
+			c.TimeEnd,
+			c.Classroom,
+			c.Comment,
+			collegeID).
+			Scan(&c.CreatedAt)
+		if err != nil {
+			return fmt.Errorf("failed to create consultation: %w", err)
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit consultations: %w", err)
+	}
+	return nil
+}
+
+// GetTeacherConsultations получает консультации преподавателя в колледже из контекста
+func (r *Repository) GetTeacherConsultations(ctx context.Context, teacherID uuid.UUID) ([]Consultation, error) {
+	query := `SELECT ` + consultationColumns + `
+		FROM consultation_hours c
+		WHERE c.teacher_id = $1 AND c.college_id = $2
+		ORDER BY c.weekday, c.time_start`
+
+	return r.queryConsultations(ctx, query, teacherID, tenant.CollegeID(ctx))
+}
+
+// ListConsultations получает все консультации колледжа из контекста
+func (r *Repository) ListConsultations(ctx context.Context) ([]Consultation, error) {
+	query := `SELECT ` + consultationColumns + `
+		FROM consultation_hours c
+		WHERE c.college_id = $1 AND ` + teacherNotDeleted + `
+		ORDER BY c.teacher, c.weekday, c.time_start`
+
+	return r.queryConsultations(ctx, query, tenant.CollegeID(ctx))
+}
+
+// GetGroupConsultations получает консультации преподавателей, у которых есть занятия
+// с группой groupName начиная с даты since, и отмечает консультации преподавателей,
+// на напоминания которых подписан пользователь userID
+func (r *Repository) GetGroupConsultations(ctx context.Context, groupName string, since time.Time, userID uuid.UUID) ([]Consultation, error) {
+	query := `SELECT ` + consultationColumns + `,
+			EXISTS (
+				SELECT 1 FROM consultation_subscriptions s
+				WHERE s.teacher_id = c.teacher_id AND s.user_id = $3
+			)
+		FROM consultation_hours c
+		WHERE c.college_id = $4 AND ` + teacherNotDeleted + ` AND EXISTS (
+			SELECT 1 FROM current_schedule cs
+			WHERE cs.teacher = ANY(c.teacher_names) AND cs.group_name = $1
+			  AND cs.date >= $2 AND cs.is_active = true AND cs.college_id = c.college_id
+		)
+		ORDER BY c.teacher, c.weekday, c.time_start`
+
+	rows, err := r.db.QueryContext(ctx, query, groupName, since, userID, tenant.CollegeID(ctx))
+	if err != nil {
+		return nil, fmt.Errorf("failed to get group consultations: %w", err)
+	}
+	defer rows.Close()
+
+	var consultations []Consultation
+	for rows.Next() {
+		var c Consultation
+		if err := scanConsultation(rows, &c, &c.Subscribed); err != nil {
+			return nil, err
+		}
+		consultations = append(consultations, c)
+	}
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating rows: %w", err)
+	}
+	return consultations, nil
+}
+
+// Subscribe подписывает пользователя на напоминания о консультациях преподавателя
+func (r *Repository) Subscribe(ctx context.Context, teacherID, userID uuid.UUID) error {
+	_, err := r.db.ExecContext(ctx, `
+		INSERT INTO consultation_subscriptions (teacher_id, user_id)
+		VALUES ($1, $2)
+		ON CONFLICT (teacher_id, user_id) DO NOTHING`, teacherID, userID)
+	if err != nil {
+		return fmt.Errorf("failed to subscribe to consultations: %w", err)
+	}
+	return nil
+}
+
+// Unsubscribe отменяет подписку пользователя на напоминания о консультациях преподавателя
+func (r *Repository) Unsubscribe(ctx context.Context, teacherID, userID uuid.UUID) error {
+	_, err := r.db.ExecContext(ctx,
+		`DELETE FROM consultation_subscriptions WHERE teacher_id = $1 AND user_id = $2`, teacherID, userID)
+	if err != nil {
+		return fmt.Errorf("failed to unsubscribe from consultations: %w", err)
+	}
+	return nil
+}
+
+// GetDueConsultations получает консультации колледжа из контекста в день недели даты date,
+// начинающиеся в интервале (from, to] (ЧЧ:ММ), о которых в эту дату еще не напоминали
+func (r *Repository) GetDueConsultations(ctx context.Context, date time.Time, from, to string) ([]Consultation, error) {
+	query := `SELECT ` + consultationColumns + `
+		FROM consultation_hours c
+		WHERE c.college_id = $1 AND c.weekday = $2 AND ` + teacherNotDeleted + `
+		  AND c.time_start > $3::time AND c.time_start <= $4::time
+		  AND NOT EXISTS (
+			SELECT 1 FROM consultation_reminders r
+			WHERE r.consultation_id = c.id AND r.date = $5
+		  )
+		ORDER BY c.time_start`
+
+	return r.queryConsultations(ctx, query, tenant.CollegeID(ctx), isoWeekday(date.Weekday()), from, to, date)
+}
+
+// MarkReminded отмечает напоминание о консультации в дату date. Возвращает false,
+// если напоминание уже отправлено (например, другим экземпляром API).
+func (r *Repository) MarkReminded(ctx context.Context, consultationID uuid.UUID, date time.Time) (bool, error) {
+	result, err := r.db.ExecContext(ctx, `
+		INSERT INTO consultation_reminders (consultation_id, date)
+		VALUES ($1, $2)
+		ON CONFLICT (consultation_id, date) DO NOTHING`, consultationID, date)
+	if err != nil {
+		return false, fmt.Errorf("failed to mark consultation reminder: %w", err)
+	}
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return false, fmt.Errorf("failed to get affected rows: %w", err)
+	}
+	return affected > 0, nil
+}
+
+// GetSubscribers получает активных пользователей, подписанных на напоминания
+// о консультациях преподавателя
+func (r *Repository) GetSubscribers(ctx context.Context, teacherID uuid.UUID) ([]uuid.UUID, error) {
+	query := `
+		SELECT s.user_id
+		FROM consultation_subscriptions s
+		JOIN users u ON u.id = s.user_id
+		WHERE s.teacher_id = $1 AND u.college_id = $2 AND u.deleted_at IS NULL`
+
+	rows, err := r.db.QueryContext(ctx, query, teacherID, tenant.CollegeID(ctx))
+	if err != nil {
+		return nil, fmt.Errorf("failed to get consultation subscribers: %w", err)
+	}
+	defer rows.Close()
+
+	var userIDs []uuid.UUID
+	for rows.Next() {
+		var userID uuid.UUID
+		if err := rows.Scan(&userID); err != nil {
+			return nil, fmt.Errorf("failed to scan subscriber: %w", err)
+		}
+		userIDs = append(userIDs, userID)
+	}
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating rows: %w", err)
+	}
+	return userIDs, nil
+}
+
+// queryConsultations выполняет запрос консультаций (колонки consultationColumns)
+func (r *Repository) queryConsultations(ctx context.Context, query string, args ...interface{}) ([]Consultation, error) {
+	rows, err := r.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get consultations: %w", err)
+	}
+	defer rows.Close()
+
+	var consultations []Consultation
+	for rows.Next() {
+		var c Consultation
+		if err := scanConsultation(rows, &c); err != nil {
+			return nil, err
+		}
+		consultations = append(consultations, c)
+	}
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating rows: %w", err)
+	}
+	return consultations, nil
+}
+
+// scanConsultation читает консультацию из строки с колонками consultationColumns,
+// после которых могут идти колонки suffix
+func scanConsultation(rows *sql.Rows, c *Consultation, suffix ...interface{}) error {
+	var weekday int
+	dest := append([]interface{}{
+		&c.ID,
+		&c.TeacherID,
+		&c.Teacher,
+		pq.Array(&c.TeacherNames),
+		&weekday,
+		&c.TimeStart,
+		&c.TimeEnd,
+		&c.Classroom,
+		&c.Comment,
+		&c.CreatedAt,
+	}, suffix...)
+	if err := rows.Scan(dest...); err != nil {
+		return fmt.Errorf("failed to scan consultation: %w", err)
+	}
+	c.Weekday = time.Weekday(weekday % 7)
+	c.TimeStart = clock.NormalizeClock(c.TimeStart)
+	c.TimeEnd = clock.NormalizeClock(c.TimeEnd)
+	return nil
+}
+
+// isoWeekday номер дня недели в базе: 1 - понедельник, 7 - воскресенье
+func isoWeekday(weekday time.Weekday) int {
+	if weekday == time.Sunday {
+		return 7
+	}
+	return int(weekday)
+}
@@ -0,0 +1,322 @@
+package consultations
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/Ultrahd-dev/student-schedule-app/backend/internal/apperr"
+	"github.com/Ultrahd-dev/student-schedule-app/backend/internal/clock"
+	"github.com/Ultrahd-dev/student-schedule-app/backend/internal/schedule"
+	"github.com/Ultrahd-dev/student-schedule-app/backend/internal/tenant"
+	"github.com/google/uuid"
+)
+
+// Ошибки консультаций
+var (
+	ErrInvalidConsultation = apperr.New(apperr.ErrValidation, "некорректная консультация")
+	ErrConflict            = apperr.New(apperr.ErrConflict, "консультация пересекается с занятием")
+	ErrTeacherNotFound     = apperr.New(apperr.ErrNotFound, "у вашей группы нет консультаций этого преподавателя")
+)
+
+const (
+	// maxConsultations максимальное число консультаций преподавателя в неделю
+	maxConsultations = 14
+	// conflictWeeks на сколько недель вперед консультации проверяются на пересечение
+	// с занятиями преподавателя
+	conflictWeeks = 4
+	// groupLookbackWeeks преподаватель считается ведущим занятия у группы, если у него
+	// были или будут занятия с ней за это число недель до сегодняшнего дня и позже
+	groupLookbackWeeks = 8
+)
+
+// Config настройки консультаций
+type Config struct {
+	ReminderBefore time.Duration // За сколько до начала консультации напоминать подписчикам
+	CheckInterval  time.Duration // Период проверки предстоящих консультаций
+}
+
+// Notifier отправляет напоминания о консультациях (notifications.Service)
+type Notifier interface {
+	SendConsultationReminder(ctx context.Context, consultation *Consultation, date time.Time, userIDs []uuid.UUID) error
+}
+
+// CollegeLister возвращает колледжи, о консультациях которых нужно напоминать
+type CollegeLister interface {
+	ActiveCollegeIDs(ctx context.Context) ([]uuid.UUID, error)
+}
+
+// Service управляет консультациями преподавателей и напоминаниями о них
+type Service struct {
+	config          Config
+	repo            *Repository
+	scheduleService *schedule.Service
+	loc             *time.Location // Часовой пояс колледжа
+	notifier        Notifier       // nil - напоминания не отправляются
+	colleges        CollegeLister  // nil - только колледж по умолчанию
+}
+
+// NewService создает новый сервис консультаций
+func NewService(config Config, repo *Repository, scheduleService *schedule.Service) *Service {
+	if config.ReminderBefore <= 0 {
+		config.ReminderBefore = time.Hour
+	}
+	if config.CheckInterval <= 0 {
+		config.CheckInterval = time.Minute
+	}
+
+	return &Service{
+		config:          config,
+		repo:            repo,
+		scheduleService: scheduleService,
+		loc:             scheduleService.Location(),
+	}
+}
+
+// SetNotifier включает напоминания о консультациях подписанным студентам
+func (s *Service) SetNotifier(notifier Notifier) {
+	s.notifier = notifier
+}
+
+// SetColleges включает напоминания о консультациях всех активных колледжей
+func (s *Service) SetColleges(colleges CollegeLister) {
+	s.colleges = colleges
+}
+
+// SetTeacherConsultations проверяет и сохраняет консультации преподавателя вместо прежних.
+// teacher - ФИО преподавателя, names - имена, под которыми он встречается в расписании:
+// консультации не должны пересекаться с его занятиями в ближайшие недели.
+func (s *Service) SetTeacherConsultations(ctx context.Context, teacherID uuid.UUID, teacher string, names []string, consultations []Consultation) ([]Consultation, error) {
+	if len(consultations) > maxConsultations {
+		return nil, fmt.Errorf("%w: больше %d консультаций в неделю", ErrInvalidConsultation, maxConsultations)
+	}
+
+	for i := range consultations {
+		c := &consultations[i]
+		start, err := clock.ParseClock(c.TimeStart)
+		if err != nil {
+			return nil, fmt.Errorf("%w: %v", ErrInvalidConsultation, err)
+		}
+		end, err := clock.ParseClock(c.TimeEnd)
+		if err != nil {
+			return nil, fmt.Errorf("%w: %v", ErrInvalidConsultation, err)
+		}
+		if start >= end {
+			return nil, fmt.Errorf("%w: консультация заканчивается раньше, чем начинается", ErrInvalidConsultation)
+		}
+		c.ID = uuid.New()
+		c.TeacherID = teacherID
+		c.Teacher = teacher
+		c.TeacherNames = names
+		c.TimeStart = clock.FormatClock(start)
+		c.TimeEnd = clock.FormatClock(end)
+		c.Classroom = strings.TrimSpace(c.Classroom)
+		c.Comment = strings.TrimSpace(c.Comment)
+	}
+	sort.SliceStable(consultations, func(i, j int) bool {
+		if consultations[i].Weekday != consultations[j].Weekday {
+			return isoWeekday(consultations[i].Weekday) < isoWeekday(consultations[j].Weekday)
+		}
+		return consultations[i].TimeStart < consultations[j].TimeStart
+	})
+	for i := 1; i < len(consultations); i++ {
+		prev, c := consultations[i-1], consultations[i]
+		if prev.Weekday == c.Weekday && c.TimeStart < prev.TimeEnd {
+			return nil, fmt.Errorf("%w: консультации %s-%s и %s-%s пересекаются",
+				ErrInvalidConsultation, prev.TimeStart, prev.TimeEnd, c.TimeStart, c.TimeEnd)
+		}
+	}
+
+	if err := s.checkConflicts(ctx, names, consultations); err != nil {
+		return nil, err
+	}
+
+	if err := s.repo.ReplaceTeacherConsultations(ctx, teacherID, consultations); err != nil {
+		return nil, fmt.Errorf("ошибка сохранения консультаций: %w", err)
+	}
+
+	log.Printf("Преподаватель %s опубликовал консультации, в неделю: %d", teacher, len(consultations))
+	return consultations, nil
+}
+
+// checkConflicts проверяет, что консультации не пересекаются с занятиями
+// преподавателя в ближайшие conflictWeeks недель
+func (s *Service) checkConflicts(ctx context.Context, names []string, consultations []Consultation) error {
+	if len(consultations) == 0 || len(names) == 0 {
+		return nil
+	}
+
+	today := clock.Today(s.loc)
+	lessons, err := s.scheduleService.GetScheduleForTeacher(ctx, names, today, today.AddDate(0, 0, conflictWeeks*7-1))
+	if err != nil {
+		return fmt.Errorf("ошибка получения расписания преподавателя: %w", err)
+	}
+
+	for _, c := range consultations {
+		start, _ := clock.ParseClock(c.TimeStart)
+		end, _ := clock.ParseClock(c.TimeEnd)
+		for _, lesson := range lessons {
+			if lesson.Date.Weekday() != c.Weekday {
+				continue
+			}
+			lessonStart, err := clock.ParseClock(lesson.TimeStart)
+			if err != nil {
+				continue
+			}
+			lessonEnd, err := clock.ParseClock(lesson.TimeEnd)
+			if err != nil {
+				continue
+			}
+			if lessonStart < end && start < lessonEnd {
+				return fmt.Errorf("%w: %s у группы %s %s %s-%s", ErrConflict, lesson.Subject, lesson.GroupName,
+					lesson.Date.Format(clock.DateLayout), lesson.TimeStart, lesson.TimeEnd)
+			}
+		}
+	}
+	return nil
+}
+
+// TeacherConsultations возвращает консультации преподавателя
+func (s *Service) TeacherConsultations(ctx context.Context, teacherID uuid.UUID) ([]Consultation, error) {
+	consultations, err := s.repo.GetTeacherConsultations(ctx, teacherID)
+	if err != nil {
+		return nil, fmt.Errorf("ошибка получения консультаций: %w", err)
+	}
+	return consultations, nil
+}
+
+// AllConsultations возвращает консультации всех преподавателей колледжа
+func (s *Service) AllConsultations(ctx context.Context) ([]Consultation, error) {
+	consultations, err := s.repo.ListConsultations(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("ошибка получения консультаций: %w", err)
+	}
+	return consultations, nil
+}
+
+// GroupConsultations возвращает консультации преподавателей группы groupName
+// с отметкой подписки пользователя userID на напоминания
+func (s *Service) GroupConsultations(ctx context.Context, groupName string, userID uuid.UUID) ([]Consultation, error) {
+	since := clock.Today(s.loc).AddDate(0, 0, -groupLookbackWeeks*7)
+	consultations, err := s.repo.GetGroupConsultations(ctx, groupName, since, userID)
+	if err != nil {
+		return nil, fmt.Errorf("ошибка получения консультаций группы: %w", err)
+	}
+	return consultations, nil
+}
+
+// SetReminders включает или отключает напоминания студенту группы groupName
+// о консультациях преподавателя teacherID
+func (s *Service) SetReminders(ctx context.Context, userID uuid.UUID, groupName string, teacherID uuid.UUID, enabled bool) error {
+	if !enabled {
+		if err := s.repo.Unsubscribe(ctx, teacherID, userID); err != nil {
+			return fmt.Errorf("ошибка отключения напоминаний: %w", err)
+		}
+		return nil
+	}
+
+	consultations, err := s.GroupConsultations(ctx, groupName, userID)
+	if err != nil {
+		return err
+	}
+	found := false
+	for _, c := range consultations {
+		if c.TeacherID == teacherID {
+			found = true
+			break
+		}
+	}
+	if !found {
+		return ErrTeacherNotFound
+	}
+
+	if err := s.repo.Subscribe(ctx, teacherID, userID); err != nil {
+		return fmt.Errorf("ошибка включения напоминаний: %w", err)
+	}
+	return nil
+}
+
+// Start запускает периодическую отправку напоминаний до отмены контекста
+func (s *Service) Start(ctx context.Context) {
+	if s.notifier == nil {
+		return
+	}
+
+	ticker := time.NewTicker(s.config.CheckInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+			s.remindAll(ctx)
+		case <-ctx.Done():
+			log.Println("Остановка напоминаний о консультациях")
+			return
+		}
+	}
+}
+
+// remindAll отправляет напоминания о консультациях каждого активного колледжа
+func (s *Service) remindAll(ctx context.Context) {
+	if s.colleges == nil {
+		s.remindDue(ctx)
+		return
+	}
+
+	collegeIDs, err := s.colleges.ActiveCollegeIDs(ctx)
+	if err != nil {
+		log.Printf("Ошибка получения колледжей для напоминаний о консультациях: %v", err)
+		return
+	}
+	for _, collegeID := range collegeIDs {
+		s.remindDue(tenant.WithCollege(ctx, collegeID))
+	}
+}
+
+// remindDue напоминает подписчикам о сегодняшних консультациях колледжа из контекста,
+// до начала которых осталось не больше config.ReminderBefore. Консультации после
+// полуночи не учитываются: о них напомнят в их день.
+func (s *Service) remindDue(ctx context.Context) {
+	now := time.Now().In(s.loc)
+	today := clock.DateOf(now, s.loc)
+	nowMinutes := now.Hour()*60 + now.Minute()
+	untilMinutes := nowMinutes + int(s.config.ReminderBefore/time.Minute)
+	if untilMinutes >= 24*60 {
+		untilMinutes = 24*60 - 1
+	}
+
+	due, err := s.repo.GetDueConsultations(ctx, today, clock.FormatClock(nowMinutes), clock.FormatClock(untilMinutes))
+	if err != nil {
+		log.Printf("Ошибка получения предстоящих консультаций: %v", err)
+		return
+	}
+
+	for i := range due {
+		c := &due[i]
+		// Отметка ставится до отправки: при нескольких экземплярах API
+		// напоминание отправляет тот, кто отметил его первым
+		marked, err := s.repo.MarkReminded(ctx, c.ID, today)
+		if err != nil {
+			log.Printf("Ошибка отметки напоминания о консультации %s: %v", c.ID, err)
+			continue
+		}
+		if !marked {
+			continue
+		}
+
+		userIDs, err := s.repo.GetSubscribers(ctx, c.TeacherID)
+		if err != nil {
+			log.Printf("Ошибка получения подписчиков консультаций %s: %v", c.Teacher, err)
+			continue
+		}
+		if len(userIDs) == 0 {
+			continue
+		}
+		if err := s.notifier.SendConsultationReminder(ctx, c, today, userIDs); err != nil {
+			log.Printf("Ошибка отправки напоминания о консультации %s: %v", c.ID, err)
+		}
+	}
+}
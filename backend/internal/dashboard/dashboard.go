@@ -0,0 +1,357 @@
+// Package dashboard реализует встроенную панель администратора: страницы,
+// отрисованные на сервере, со сводкой по колледжу (последние запуски парсинга,
+// активный снапшот, последние изменения, очередь рассылки уведомлений, жалобы
+// пользователей на ошибки в парах) и кнопкой внепланового парсинга. Вход по email и паролю администратора с кодом 2FA,
+// если он подключен; сессия хранится в cookie с JWT токеном.
+package dashboard
+
+import (
+	"context"
+	"html/template"
+	"log"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/Ultrahd-dev/student-schedule-app/backend/internal/audit"
+	"github.com/Ultrahd-dev/student-schedule-app/backend/internal/feedback"
+	"github.com/Ultrahd-dev/student-schedule-app/backend/internal/jobs"
+	"github.com/Ultrahd-dev/student-schedule-app/backend/internal/jwt"
+	"github.com/Ultrahd-dev/student-schedule-app/backend/internal/lock"
+	"github.com/Ultrahd-dev/student-schedule-app/backend/internal/schedule"
+	"github.com/Ultrahd-dev/student-schedule-app/backend/internal/tenant"
+	"github.com/Ultrahd-dev/student-schedule-app/backend/internal/users"
+	"github.com/google/uuid"
+)
+
+// Path путь панели администратора
+const Path = "/admin/"
+
+const (
+	// recentChangesLimit число последних изменений на главной странице
+	recentChangesLimit = 30
+	// scrapeTimeout максимальное время внепланового парсинга
+	scrapeTimeout = 10 * time.Minute
+	// notificationJobPrefix префикс видов задач рассылки уведомлений
+	notificationJobPrefix = "notifications."
+	// errorReportsLimit число пар с жалобами на главной странице
+	errorReportsLimit = 20
+)
+
+// Users пользователи и вход
+type Users interface {
+	AuthenticateUser(ctx context.Context, email, password string) (*users.User, error)
+	TwoFactorEnabled(ctx context.Context, userID uuid.UUID) (bool, error)
+	VerifyTwoFactor(ctx context.Context, userID uuid.UUID, code string) (bool, error)
+	GetUserByID(ctx context.Context, id uuid.UUID) (*users.User, error)
+	RevokeToken(ctx context.Context, jti string, userID uuid.UUID, expiresAt time.Time) error
+}
+
+// Tokens выдает и проверяет JWT токены
+type Tokens interface {
+	GenerateToken(userID uuid.UUID, email, role string, collegeID uuid.UUID) (string, error)
+	GenerateTwoFactorToken(userID uuid.UUID, email, role string, collegeID uuid.UUID) (string, time.Time, error)
+	ParseToken(ctx context.Context, tokenString string) (*jwt.Claims, error)
+	ParseTwoFactorToken(ctx context.Context, tokenString string) (*jwt.Claims, error)
+}
+
+// AuditLog журнал событий безопасности
+type AuditLog interface {
+	Record(ctx context.Context, event audit.Event)
+}
+
+// RunHistory журнал успешных запусков периодических задач
+type RunHistory interface {
+	GetRun(ctx context.Context, name string) (*lock.Run, error)
+}
+
+// DataSource сводка по расписанию колледжа из контекста
+type DataSource interface {
+	GetDataStatus(ctx context.Context) (*schedule.DataStatus, error)
+	GetRecentChanges(ctx context.Context, limit int) ([]schedule.ScheduleChange, error)
+}
+
+// JobStats статистика очереди фоновых задач колледжа из контекста
+type JobStats interface {
+	Stats(ctx context.Context) ([]jobs.KindStats, error)
+}
+
+// CollegeResolver находит колледж по коду (slug)
+type CollegeResolver interface {
+	CollegeIDBySlug(ctx context.Context, slug string) (uuid.UUID, error)
+}
+
+// Scraper парсер расписания одного колледжа
+type Scraper interface {
+	ScrapeNow(ctx context.Context) error
+}
+
+// ErrorReports жалобы пользователей на ошибки в расписании колледжа из контекста
+type ErrorReports interface {
+	ListLessonReports(ctx context.Context, limit int) ([]feedback.LessonReports, error)
+	ResolveLessonReports(ctx context.Context, groupName string, date time.Time, timeStart string, resolvedBy uuid.UUID) (int64, error)
+}
+
+// Config настройки панели
+type Config struct {
+	MainScheduleJob string // Имя задачи парсинга основного расписания в журнале запусков
+	ChangesJob      string // Имя задачи парсинга изменений в журнале запусков
+	SecureCookie    bool   // Передавать cookie сессии только по HTTPS
+}
+
+// Dashboard панель администратора
+type Dashboard struct {
+	config   Config
+	users    Users
+	tokens   Tokens
+	audit    AuditLog
+	runs     RunHistory
+	data     DataSource
+	jobs     JobStats
+	colleges CollegeResolver
+	reports  ErrorReports // nil - жалобы не показываются
+	pages    *template.Template
+
+	mu       sync.Mutex
+	scrapers map[uuid.UUID]Scraper
+	scraping map[uuid.UUID]time.Time // Начало выполняемого внепланового парсинга по колледжам
+}
+
+// New создает панель администратора
+func New(config Config, users Users, tokens Tokens, auditLog AuditLog, runs RunHistory,
+	data DataSource, jobStats JobStats, colleges CollegeResolver) *Dashboard {
+	return &Dashboard{
+		config:   config,
+		users:    users,
+		tokens:   tokens,
+		audit:    auditLog,
+		runs:     runs,
+		data:     data,
+		jobs:     jobStats,
+		colleges: colleges,
+		pages:    template.Must(template.New("dashboard").Funcs(templateFuncs).Parse(pageTemplates)),
+		scrapers: make(map[uuid.UUID]Scraper),
+		scraping: make(map[uuid.UUID]time.Time),
+	}
+}
+
+// AddScraper подключает парсер колледжа для кнопки внепланового парсинга
+func (d *Dashboard) AddScraper(collegeID uuid.UUID, scraper Scraper) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	d.scrapers[collegeID] = scraper
+}
+
+// UseErrorReports показывает на главной странице пары с жалобами пользователей
+func (d *Dashboard) UseErrorReports(reports ErrorReports) {
+	d.reports = reports
+}
+
+// Handler возвращает HTTP обработчик панели. Регистрируется на пути Path.
+func (d *Dashboard) Handler() http.Handler {
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET "+Path+"{$}", d.requireSession(d.handleOverview))
+	mux.HandleFunc("POST "+Path+"scrape", d.requireSession(d.handleScrape))
+	mux.HandleFunc("POST "+Path+"reports/resolve", d.requireSession(d.handleResolveReports))
+	mux.HandleFunc("GET "+Path+"login", d.handleLoginForm)
+	mux.HandleFunc("POST "+Path+"login", d.handleLogin)
+	mux.HandleFunc("POST "+Path+"two-factor", d.handleTwoFactor)
+	mux.HandleFunc("POST "+Path+"logout", d.requireSession(d.handleLogout))
+	return securityHeaders(mux)
+}
+
+// overviewPage данные главной страницы
+type overviewPage struct {
+	Email         string
+	CSRF          string
+	Message       string
+	MainRun       *lock.Run
+	ChangesRun    *lock.Run
+	Snapshot      *schedule.DataStatus
+	Changes       []schedule.ScheduleChange
+	Notifications []jobs.KindStats
+	ErrorReports  []feedback.LessonReports
+	ShowReports   bool // Жалобы подключены
+	CanScrape     bool
+	ScrapingSince *time.Time // Начало выполняемого внепланового парсинга
+	GeneratedAt   time.Time
+}
+
+// handleOverview отображает сводку по колледжу администратора
+func (d *Dashboard) handleOverview(w http.ResponseWriter, r *http.Request, session *session) {
+	ctx := r.Context()
+	page := &overviewPage{
+		Email:       session.user.Email,
+		CSRF:        session.csrf,
+		Message:     pageMessage(r),
+		GeneratedAt: time.Now(),
+	}
+
+	var err error
+	if page.MainRun, err = d.runs.GetRun(ctx, tenant.Scoped(ctx, d.config.MainScheduleJob)); err != nil {
+		d.fail(w, "Ошибка получения запуска парсинга основного расписания", err)
+		return
+	}
+	if page.ChangesRun, err = d.runs.GetRun(ctx, tenant.Scoped(ctx, d.config.ChangesJob)); err != nil {
+		d.fail(w, "Ошибка получения запуска парсинга изменений", err)
+		return
+	}
+	if page.Snapshot, err = d.data.GetDataStatus(ctx); err != nil {
+		d.fail(w, "Ошибка получения активного снапшота", err)
+		return
+	}
+	if page.Changes, err = d.data.GetRecentChanges(ctx, recentChangesLimit); err != nil {
+		d.fail(w, "Ошибка получения последних изменений", err)
+		return
+	}
+
+	stats, err := d.jobs.Stats(ctx)
+	if err != nil {
+		d.fail(w, "Ошибка получения статистики рассылки", err)
+		return
+	}
+	for _, kind := range stats {
+		if strings.HasPrefix(kind.Kind, notificationJobPrefix) {
+			kind.Kind = strings.TrimPrefix(kind.Kind, notificationJobPrefix)
+			page.Notifications = append(page.Notifications, kind)
+		}
+	}
+
+	if d.reports != nil {
+		page.ShowReports = true
+		if page.ErrorReports, err = d.reports.ListLessonReports(ctx, errorReportsLimit); err != nil {
+			d.fail(w, "Ошибка получения жалоб на расписание", err)
+			return
+		}
+	}
+
+	collegeID := tenant.CollegeID(ctx)
+	d.mu.Lock()
+	_, page.CanScrape = d.scrapers[collegeID]
+	if startedAt, ok := d.scraping[collegeID]; ok {
+		page.ScrapingSince = &startedAt
+	}
+	d.mu.Unlock()
+
+	d.render(w, http.StatusOK, "overview", page)
+}
+
+// scrapeMessages сообщения о запуске парсинга по параметру ?scrape=
+var scrapeMessages = map[string]string{
+	"started": "Парсинг запущен. Обновите страницу через несколько минут.",
+	"running": "Парсинг уже выполняется.",
+}
+
+// reportMessages сообщения о разборе жалоб по параметру ?reports=
+var reportMessages = map[string]string{
+	"resolved": "Жалобы на пару отмечены разобранными.",
+}
+
+// pageMessage сообщение о результате действия на главной странице
+func pageMessage(r *http.Request) string {
+	if message, ok := scrapeMessages[r.URL.Query().Get("scrape")]; ok {
+		return message
+	}
+	return reportMessages[r.URL.Query().Get("reports")]
+}
+
+// handleScrape запускает внеплановый парсинг колледжа администратора в фоне
+func (d *Dashboard) handleScrape(w http.ResponseWriter, r *http.Request, session *session) {
+	if !session.validCSRF(r.PostFormValue("csrf")) {
+		http.Error(w, "недействительная форма, обновите страницу", http.StatusForbidden)
+		return
+	}
+
+	collegeID := tenant.CollegeID(r.Context())
+	d.mu.Lock()
+	scraper, ok := d.scrapers[collegeID]
+	_, running := d.scraping[collegeID]
+	if ok && !running {
+		d.scraping[collegeID] = time.Now()
+	}
+	d.mu.Unlock()
+
+	switch {
+	case !ok:
+		http.Error(w, "парсинг колледжа не настроен", http.StatusNotFound)
+		return
+	case running:
+		http.Redirect(w, r, Path+"?scrape=running", http.StatusSeeOther)
+		return
+	}
+
+	log.Printf("Администратор %s запустил внеплановый парсинг из панели", session.user.Email)
+	go func() {
+		defer func() {
+			d.mu.Lock()
+			delete(d.scraping, collegeID)
+			d.mu.Unlock()
+		}()
+
+		// Запрос завершится раньше парсинга: контекст парсинга от него не зависит
+		ctx, cancel := context.WithTimeout(tenant.WithCollege(context.Background(), collegeID), scrapeTimeout)
+		defer cancel()
+		if err := scraper.ScrapeNow(ctx); err != nil {
+			log.Printf("Ошибка внепланового парсинга, запущенного %s: %v", session.user.Email, err)
+			return
+		}
+		log.Printf("Внеплановый парсинг, запущенный %s, завершен", session.user.Email)
+	}()
+
+	http.Redirect(w, r, Path+"?scrape=started", http.StatusSeeOther)
+}
+
+// handleResolveReports отмечает жалобы на пару разобранными
+func (d *Dashboard) handleResolveReports(w http.ResponseWriter, r *http.Request, session *session) {
+	if !session.validCSRF(r.PostFormValue("csrf")) {
+		http.Error(w, "недействительная форма, обновите страницу", http.StatusForbidden)
+		return
+	}
+	if d.reports == nil {
+		http.Error(w, "жалобы на расписание не подключены", http.StatusNotFound)
+		return
+	}
+	group, timeStart := r.PostFormValue("group"), r.PostFormValue("time")
+	date, err := time.Parse("2006-01-02", r.PostFormValue("date"))
+	if err != nil || group == "" || timeStart == "" {
+		http.Error(w, "некорректная пара", http.StatusBadRequest)
+		return
+	}
+
+	resolved, err := d.reports.ResolveLessonReports(r.Context(), group, date, timeStart, session.user.ID)
+	if err != nil {
+		d.fail(w, "Ошибка разбора жалоб на расписание", err)
+		return
+	}
+	log.Printf("Администратор %s разобрал жалобы (%d) на пару %s %s %s", session.user.Email, resolved, group, date.Format("2006-01-02"), timeStart)
+	http.Redirect(w, r, Path+"?reports=resolved", http.StatusSeeOther)
+}
+
+// render отрисовывает страницу name
+func (d *Dashboard) render(w http.ResponseWriter, statusCode int, name string, data interface{}) {
+	w.Header().Set("Content-Type", "text/html; charset=utf-8")
+	w.WriteHeader(statusCode)
+	if err := d.pages.ExecuteTemplate(w, name, data); err != nil {
+		log.Printf("Ошибка отрисовки страницы панели %s: %v", name, err)
+	}
+}
+
+// fail логирует ошибку и отвечает внутренней ошибкой без подробностей
+func (d *Dashboard) fail(w http.ResponseWriter, message string, err error) {
+	log.Printf("%s: %v", message, err)
+	http.Error(w, "внутренняя ошибка", http.StatusInternalServerError)
+}
+
+// securityHeaders запрещает встраивание страниц панели, сторонние ресурсы и кэширование
+func securityHeaders(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Security-Policy",
+			"default-src 'none'; style-src 'unsafe-inline'; form-action 'self'; frame-ancestors 'none'")
+		w.Header().Set("X-Frame-Options", "DENY")
+		w.Header().Set("X-Content-Type-Options", "nosniff")
+		w.Header().Set("Referrer-Policy", "no-referrer")
+		w.Header().Set("Cache-Control", "no-store")
+		next.ServeHTTP(w, r)
+	})
+}
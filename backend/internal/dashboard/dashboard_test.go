@@ -0,0 +1,279 @@
+package dashboard
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/Ultrahd-dev/student-schedule-app/backend/internal/audit"
+	"github.com/Ultrahd-dev/student-schedule-app/backend/internal/feedback"
+	"github.com/Ultrahd-dev/student-schedule-app/backend/internal/jobs"
+	"github.com/Ultrahd-dev/student-schedule-app/backend/internal/jwt"
+	"github.com/Ultrahd-dev/student-schedule-app/backend/internal/lock"
+	"github.com/Ultrahd-dev/student-schedule-app/backend/internal/schedule"
+	"github.com/Ultrahd-dev/student-schedule-app/backend/internal/tenant"
+	"github.com/Ultrahd-dev/student-schedule-app/backend/internal/users"
+	"github.com/google/uuid"
+)
+
+// fakeUsers пользователи для тестов панели: пароль "secret" у всех
+type fakeUsers struct {
+	byEmail map[string]*users.User
+}
+
+func (f *fakeUsers) AuthenticateUser(ctx context.Context, email, password string) (*users.User, error) {
+	user, ok := f.byEmail[email]
+	if !ok || password != "secret" {
+		return nil, errors.New("invalid credentials")
+	}
+	return user, nil
+}
+
+func (f *fakeUsers) TwoFactorEnabled(ctx context.Context, userID uuid.UUID) (bool, error) {
+	return false, nil
+}
+
+func (f *fakeUsers) VerifyTwoFactor(ctx context.Context, userID uuid.UUID, code string) (bool, error) {
+	return false, errors.New("2fa is not enabled")
+}
+
+func (f *fakeUsers) GetUserByID(ctx context.Context, id uuid.UUID) (*users.User, error) {
+	for _, user := range f.byEmail {
+		if user.ID == id {
+			return user, nil
+		}
+	}
+	return nil, errors.New("not found")
+}
+
+func (f *fakeUsers) RevokeToken(ctx context.Context, jti string, userID uuid.UUID, expiresAt time.Time) error {
+	return nil
+}
+
+// fakeTokens токены вида "token-<ID пользователя>"
+type fakeTokens struct{}
+
+func (fakeTokens) GenerateToken(userID uuid.UUID, email, role string, collegeID uuid.UUID) (string, error) {
+	return "token-" + userID.String(), nil
+}
+
+func (fakeTokens) GenerateTwoFactorToken(userID uuid.UUID, email, role string, collegeID uuid.UUID) (string, time.Time, error) {
+	return "", time.Time{}, errors.New("not supported")
+}
+
+func (fakeTokens) ParseToken(ctx context.Context, tokenString string) (*jwt.Claims, error) {
+	userID, err := uuid.Parse(strings.TrimPrefix(tokenString, "token-"))
+	if err != nil {
+		return nil, err
+	}
+	return &jwt.Claims{UserID: userID}, nil
+}
+
+func (fakeTokens) ParseTwoFactorToken(ctx context.Context, tokenString string) (*jwt.Claims, error) {
+	return nil, errors.New("not supported")
+}
+
+type fakeAudit struct{ events []audit.Event }
+
+func (f *fakeAudit) Record(ctx context.Context, event audit.Event) {
+	f.events = append(f.events, event)
+}
+
+type fakeRuns struct{}
+
+func (fakeRuns) GetRun(ctx context.Context, name string) (*lock.Run, error) {
+	return &lock.Run{Instance: "api-1", StartedAt: time.Now().Add(-time.Minute), FinishedAt: time.Now()}, nil
+}
+
+type fakeData struct{}
+
+func (fakeData) GetDataStatus(ctx context.Context) (*schedule.DataStatus, error) {
+	return &schedule.DataStatus{SnapshotName: "Весенний семестр", Groups: 12}, nil
+}
+
+func (fakeData) GetRecentChanges(ctx context.Context, limit int) ([]schedule.ScheduleChange, error) {
+	return []schedule.ScheduleChange{{GroupName: "ИС-21", Subject: "<script>", ChangeType: "replacement"}}, nil
+}
+
+type fakeJobs struct{}
+
+func (fakeJobs) Stats(ctx context.Context) ([]jobs.KindStats, error) {
+	return []jobs.KindStats{
+		{Kind: "notifications.change", Done: 7, Failed: 2},
+		{Kind: "cache.rebuild", Done: 100},
+	}, nil
+}
+
+type fakeColleges struct{}
+
+func (fakeColleges) CollegeIDBySlug(ctx context.Context, slug string) (uuid.UUID, error) {
+	return uuid.Nil, tenant.ErrUnknownCollege
+}
+
+// fakeScraper отмечает запуск внепланового парсинга
+type fakeScraper struct{ started chan struct{} }
+
+func (f *fakeScraper) ScrapeNow(ctx context.Context) error {
+	close(f.started)
+	return nil
+}
+
+// fakeReports жалобы на одну пару, запоминает разобранные пары
+type fakeReports struct{ resolved []string }
+
+func (f *fakeReports) ListLessonReports(ctx context.Context, limit int) ([]feedback.LessonReports, error) {
+	return []feedback.LessonReports{{
+		GroupName: "ИС-21", Date: time.Date(2026, 3, 2, 0, 0, 0, 0, time.UTC), TimeStart: "10:10", Subject: "Физика",
+		Reports: 3, Reasons: map[string]int{feedback.ReasonWrongClassroom: 2, feedback.ReasonWrongTime: 1},
+		Comments: []string{"Пара в 305"},
+	}}, nil
+}
+
+func (f *fakeReports) ResolveLessonReports(ctx context.Context, groupName string, date time.Time, timeStart string, resolvedBy uuid.UUID) (int64, error) {
+	f.resolved = append(f.resolved, groupName+" "+date.Format("2006-01-02")+" "+timeStart)
+	return 3, nil
+}
+
+func newTestDashboard(t *testing.T) (http.Handler, *fakeAudit, *fakeScraper) {
+	handler, auditLog, scraper, _ := newTestDashboardWithReports(t)
+	return handler, auditLog, scraper
+}
+
+func newTestDashboardWithReports(t *testing.T) (http.Handler, *fakeAudit, *fakeScraper, *fakeReports) {
+	t.Helper()
+	fakeUsers := &fakeUsers{byEmail: map[string]*users.User{
+		"admin@example.com":   {ID: uuid.New(), Email: "admin@example.com", Role: users.RoleAdmin, IsActive: true, CollegeID: tenant.DefaultCollegeID},
+		"teacher@example.com": {ID: uuid.New(), Email: "teacher@example.com", Role: users.RoleTeacher, IsActive: true, CollegeID: tenant.DefaultCollegeID},
+	}}
+	auditLog := &fakeAudit{}
+	d := New(Config{MainScheduleJob: "main", ChangesJob: "changes"},
+		fakeUsers, fakeTokens{}, auditLog, fakeRuns{}, fakeData{}, fakeJobs{}, fakeColleges{})
+	scraper := &fakeScraper{started: make(chan struct{})}
+	d.AddScraper(tenant.DefaultCollegeID, scraper)
+	reports := &fakeReports{}
+	d.UseErrorReports(reports)
+	return d.Handler(), auditLog, scraper, reports
+}
+
+func serve(handler http.Handler, method, path string, form url.Values, cookie *http.Cookie) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(method, path, strings.NewReader(form.Encode()))
+	if form != nil {
+		req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	}
+	if cookie != nil {
+		req.AddCookie(cookie)
+	}
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, req)
+	return rec
+}
+
+// login входит в панель и возвращает cookie сессии
+func login(t *testing.T, handler http.Handler, email string) (*httptest.ResponseRecorder, *http.Cookie) {
+	t.Helper()
+	rec := serve(handler, http.MethodPost, Path+"login", url.Values{"email": {email}, "password": {"secret"}}, nil)
+	for _, cookie := range rec.Result().Cookies() {
+		if cookie.Name == sessionCookie {
+			return rec, cookie
+		}
+	}
+	return rec, nil
+}
+
+func TestOverviewRequiresAdmin(t *testing.T) {
+	handler, auditLog, _ := newTestDashboard(t)
+
+	rec := serve(handler, http.MethodGet, Path, nil, nil)
+	if rec.Code != http.StatusSeeOther || rec.Header().Get("Location") != Path+"login" {
+		t.Fatalf("без сессии ответ %d %q, ожидалось перенаправление на вход", rec.Code, rec.Header().Get("Location"))
+	}
+
+	rec, cookie := login(t, handler, "teacher@example.com")
+	if rec.Code != http.StatusForbidden || cookie != nil {
+		t.Fatalf("вход преподавателя: ответ %d, cookie %v; ожидался отказ", rec.Code, cookie)
+	}
+	if len(auditLog.events) != 1 || auditLog.events[0].Type != audit.EventLoginFailed {
+		t.Errorf("события журнала %+v, ожидался неудачный вход", auditLog.events)
+	}
+}
+
+func TestOverview(t *testing.T) {
+	handler, auditLog, _ := newTestDashboard(t)
+
+	rec, cookie := login(t, handler, "admin@example.com")
+	if rec.Code != http.StatusSeeOther || cookie == nil {
+		t.Fatalf("вход администратора: ответ %d, cookie %v", rec.Code, cookie)
+	}
+	if !cookie.HttpOnly || cookie.SameSite != http.SameSiteStrictMode {
+		t.Errorf("cookie сессии доступна скриптам или сторонним сайтам: %+v", cookie)
+	}
+	if len(auditLog.events) != 1 || auditLog.events[0].Type != audit.EventLogin {
+		t.Errorf("события журнала %+v, ожидался вход", auditLog.events)
+	}
+
+	rec = serve(handler, http.MethodGet, Path, nil, cookie)
+	body := rec.Body.String()
+	if rec.Code != http.StatusOK {
+		t.Fatalf("ответ %d: %s", rec.Code, body)
+	}
+	for _, want := range []string{"Весенний семестр", "api-1", "ИС-21", "&lt;script&gt;", "<td>change</td>"} {
+		if !strings.Contains(body, want) {
+			t.Errorf("на странице нет %q", want)
+		}
+	}
+	if strings.Contains(body, "cache.rebuild") {
+		t.Error("в статистике рассылки задачи другого вида")
+	}
+}
+
+func TestScrapeRequiresCSRF(t *testing.T) {
+	handler, _, scraper := newTestDashboard(t)
+	_, cookie := login(t, handler, "admin@example.com")
+
+	rec := serve(handler, http.MethodPost, Path+"scrape", url.Values{"csrf": {"forged"}}, cookie)
+	if rec.Code != http.StatusForbidden {
+		t.Fatalf("запуск с чужим токеном формы: ответ %d, ожидался 403", rec.Code)
+	}
+
+	rec = serve(handler, http.MethodPost, Path+"scrape", url.Values{"csrf": {csrfToken(cookie.Value)}}, cookie)
+	if rec.Code != http.StatusSeeOther {
+		t.Fatalf("запуск парсинга: ответ %d", rec.Code)
+	}
+	select {
+	case <-scraper.started:
+	case <-time.After(time.Second):
+		t.Fatal("парсинг не запущен")
+	}
+}
+
+func TestErrorReports(t *testing.T) {
+	handler, _, _, reports := newTestDashboardWithReports(t)
+	_, cookie := login(t, handler, "admin@example.com")
+
+	body := serve(handler, http.MethodGet, Path, nil, cookie).Body.String()
+	for _, want := range []string{"Жалобы на расписание", "Физика", "аудитория: 2, время: 1", "Пара в 305"} {
+		if !strings.Contains(body, want) {
+			t.Errorf("на странице нет %q", want)
+		}
+	}
+
+	form := url.Values{"group": {"ИС-21"}, "date": {"2026-03-02"}, "time": {"10:10"}}
+	form.Set("csrf", "forged")
+	if rec := serve(handler, http.MethodPost, Path+"reports/resolve", form, cookie); rec.Code != http.StatusForbidden {
+		t.Fatalf("разбор с чужим токеном формы: ответ %d, ожидался 403", rec.Code)
+	}
+
+	form.Set("csrf", csrfToken(cookie.Value))
+	rec := serve(handler, http.MethodPost, Path+"reports/resolve", form, cookie)
+	if rec.Code != http.StatusSeeOther || rec.Header().Get("Location") != Path+"?reports=resolved" {
+		t.Fatalf("разбор жалоб: ответ %d %q", rec.Code, rec.Header().Get("Location"))
+	}
+	if len(reports.resolved) != 1 || reports.resolved[0] != "ИС-21 2026-03-02 10:10" {
+		t.Errorf("разобраны пары %v", reports.resolved)
+	}
+}
@@ -0,0 +1,239 @@
+package dashboard
+
+import (
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/base64"
+	"errors"
+	"log"
+	"net"
+	"net/http"
+	"strings"
+
+	"github.com/Ultrahd-dev/student-schedule-app/backend/internal/audit"
+	"github.com/Ultrahd-dev/student-schedule-app/backend/internal/jwt"
+	"github.com/Ultrahd-dev/student-schedule-app/backend/internal/tenant"
+	"github.com/Ultrahd-dev/student-schedule-app/backend/internal/users"
+)
+
+// sessionCookie cookie с JWT токеном администратора
+const sessionCookie = "schedule_admin_session"
+
+// session вошедший в панель администратор
+type session struct {
+	user   *users.User
+	claims *jwt.Claims
+	csrf   string // Токен форм панели, производный от токена сессии
+}
+
+// validCSRF проверяет токен, переданный формой
+func (s *session) validCSRF(value string) bool {
+	return value != "" && subtle.ConstantTimeCompare([]byte(value), []byte(s.csrf)) == 1
+}
+
+// csrfToken возвращает токен форм для токена сессии. Сторонний сайт не может
+// прочитать cookie сессии и поэтому не может подделать форму панели.
+func csrfToken(sessionToken string) string {
+	sum := sha256.Sum256([]byte("csrf:" + sessionToken))
+	return base64.RawURLEncoding.EncodeToString(sum[:])
+}
+
+// requireSession пропускает к обработчику только активных администраторов;
+// остальных отправляет на страницу входа. Обработчик выполняется в контексте
+// колледжа администратора.
+func (d *Dashboard) requireSession(next func(w http.ResponseWriter, r *http.Request, session *session)) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		session := d.session(r)
+		if session == nil {
+			http.Redirect(w, r, Path+"login", http.StatusSeeOther)
+			return
+		}
+		next(w, r.WithContext(tenant.WithCollege(r.Context(), session.user.CollegeID)), session)
+	}
+}
+
+// session возвращает сессию из cookie запроса; nil, если сессии нет, токен
+// недействителен или пользователь больше не активный администратор
+func (d *Dashboard) session(r *http.Request) *session {
+	cookie, err := r.Cookie(sessionCookie)
+	if err != nil || cookie.Value == "" {
+		return nil
+	}
+	claims, err := d.tokens.ParseToken(r.Context(), cookie.Value)
+	if err != nil || claims.IsGuest() {
+		return nil
+	}
+
+	user, err := d.users.GetUserByID(r.Context(), claims.UserID)
+	if err != nil {
+		log.Printf("Ошибка получения пользователя %s для панели администратора: %v", claims.UserID, err)
+		return nil
+	}
+	if !user.IsActive || user.Role != users.RoleAdmin {
+		return nil
+	}
+	return &session{user: user, claims: claims, csrf: csrfToken(cookie.Value)}
+}
+
+// loginPage данные страниц входа
+type loginPage struct {
+	College        string
+	Email          string
+	Error          string
+	TwoFactorToken string // Токен второго шага входа (страница ввода кода 2FA)
+}
+
+// handleLoginForm отображает форму входа; вошедших отправляет на главную
+func (d *Dashboard) handleLoginForm(w http.ResponseWriter, r *http.Request) {
+	if d.session(r) != nil {
+		http.Redirect(w, r, Path, http.StatusSeeOther)
+		return
+	}
+	d.render(w, http.StatusOK, "login", &loginPage{College: r.URL.Query().Get("college")})
+}
+
+// handleLogin проверяет email и пароль администратора. С подключенной 2FA
+// вход завершается на странице ввода кода.
+func (d *Dashboard) handleLogin(w http.ResponseWriter, r *http.Request) {
+	page := &loginPage{
+		College: strings.TrimSpace(r.PostFormValue("college")),
+		Email:   strings.TrimSpace(r.PostFormValue("email")),
+	}
+
+	ctx := r.Context()
+	if page.College != "" {
+		collegeID, err := d.colleges.CollegeIDBySlug(ctx, page.College)
+		if errors.Is(err, tenant.ErrUnknownCollege) {
+			page.Error = "Колледж не найден"
+			d.render(w, http.StatusNotFound, "login", page)
+			return
+		}
+		if err != nil {
+			d.fail(w, "Ошибка поиска колледжа "+page.College+" для входа в панель", err)
+			return
+		}
+		ctx = tenant.WithCollege(ctx, collegeID)
+	}
+
+	user, err := d.users.AuthenticateUser(ctx, page.Email, r.PostFormValue("password"))
+	if err != nil {
+		log.Printf("Ошибка входа в панель администратора %s: %v", page.Email, err)
+		d.recordLogin(r, audit.Event{Type: audit.EventLoginFailed, Email: page.Email, Details: "dashboard"})
+		page.Error = "Неверный email или пароль"
+		d.render(w, http.StatusUnauthorized, "login", page)
+		return
+	}
+	if user.Role != users.RoleAdmin {
+		d.recordLogin(r, audit.Event{Type: audit.EventLoginFailed, UserID: &user.ID, Email: user.Email, Details: "dashboard: not admin"})
+		page.Error = "Панель доступна только администраторам"
+		d.render(w, http.StatusForbidden, "login", page)
+		return
+	}
+
+	twoFactor, err := d.users.TwoFactorEnabled(ctx, user.ID)
+	if err != nil {
+		d.fail(w, "Ошибка проверки 2FA пользователя "+user.Email, err)
+		return
+	}
+	if twoFactor {
+		pending, _, err := d.tokens.GenerateTwoFactorToken(user.ID, user.Email, string(user.Role), user.CollegeID)
+		if err != nil {
+			d.fail(w, "Ошибка генерации токена второго шага для пользователя "+user.Email, err)
+			return
+		}
+		d.render(w, http.StatusOK, "two-factor", &loginPage{Email: user.Email, TwoFactorToken: pending})
+		return
+	}
+
+	d.startSession(w, r, user, "dashboard")
+}
+
+// handleTwoFactor завершает вход кодом 2FA. Токен второго шага одноразовый:
+// после неверного кода нужно войти заново.
+func (d *Dashboard) handleTwoFactor(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	claims, err := d.tokens.ParseTwoFactorToken(r.Context(), r.PostFormValue("token"))
+	if err != nil {
+		d.render(w, http.StatusUnauthorized, "login", &loginPage{Error: "Сессия входа истекла, войдите заново"})
+		return
+	}
+
+	user, err := d.users.GetUserByID(ctx, claims.UserID)
+	if err != nil || !user.IsActive || user.Role != users.RoleAdmin {
+		d.render(w, http.StatusForbidden, "login", &loginPage{Error: "Панель доступна только администраторам"})
+		return
+	}
+
+	usedRecovery, verifyErr := d.users.VerifyTwoFactor(ctx, user.ID, strings.TrimSpace(r.PostFormValue("code")))
+	if err := d.users.RevokeToken(ctx, claims.ID, user.ID, claims.ExpiresAt.Time); err != nil {
+		log.Printf("Ошибка отзыва токена второго шага пользователя %s: %v", user.Email, err)
+	}
+	if verifyErr != nil {
+		log.Printf("Ошибка проверки кода 2FA пользователя %s в панели: %v", user.Email, verifyErr)
+		d.recordLogin(r, audit.Event{Type: audit.EventLoginFailed, UserID: &user.ID, Email: user.Email, Details: "dashboard 2fa"})
+		d.render(w, http.StatusUnauthorized, "login", &loginPage{Email: user.Email, Error: "Неверный код, войдите заново"})
+		return
+	}
+
+	details := "dashboard 2fa"
+	if usedRecovery {
+		details = "dashboard 2fa recovery code"
+	}
+	d.startSession(w, r, user, details)
+}
+
+// startSession выдает администратору токен в cookie сессии
+func (d *Dashboard) startSession(w http.ResponseWriter, r *http.Request, user *users.User, details string) {
+	token, err := d.tokens.GenerateToken(user.ID, user.Email, string(user.Role), user.CollegeID)
+	if err != nil {
+		d.fail(w, "Ошибка генерации токена пользователя "+user.Email, err)
+		return
+	}
+
+	http.SetCookie(w, &http.Cookie{
+		Name:     sessionCookie,
+		Value:    token,
+		Path:     Path,
+		HttpOnly: true,
+		Secure:   d.config.SecureCookie,
+		SameSite: http.SameSiteStrictMode,
+	})
+	d.recordLogin(r, audit.Event{Type: audit.EventLogin, UserID: &user.ID, Email: user.Email, Details: details})
+	log.Printf("Администратор %s вошел в панель", user.Email)
+	http.Redirect(w, r, Path, http.StatusSeeOther)
+}
+
+// handleLogout отзывает токен сессии и удаляет cookie
+func (d *Dashboard) handleLogout(w http.ResponseWriter, r *http.Request, session *session) {
+	if !session.validCSRF(r.PostFormValue("csrf")) {
+		http.Error(w, "недействительная форма, обновите страницу", http.StatusForbidden)
+		return
+	}
+
+	if err := d.users.RevokeToken(r.Context(), session.claims.ID, session.user.ID, session.claims.ExpiresAt.Time); err != nil {
+		log.Printf("Ошибка отзыва токена панели пользователя %s: %v", session.user.Email, err)
+	}
+	http.SetCookie(w, &http.Cookie{
+		Name:     sessionCookie,
+		Path:     Path,
+		MaxAge:   -1,
+		HttpOnly: true,
+		Secure:   d.config.SecureCookie,
+		SameSite: http.SameSiteStrictMode,
+	})
+	http.Redirect(w, r, Path+"login", http.StatusSeeOther)
+}
+
+// recordLogin записывает событие входа в журнал с адресом клиента HTTP запроса.
+// За прокси IP берется из заголовка X-Forwarded-For, как и для gRPC вызовов.
+func (d *Dashboard) recordLogin(r *http.Request, event audit.Event) {
+	event.UserAgent = r.UserAgent()
+	if forwarded := r.Header.Get("X-Forwarded-For"); forwarded != "" {
+		event.IP = strings.TrimSpace(strings.Split(forwarded, ",")[0])
+	} else if host, _, err := net.SplitHostPort(r.RemoteAddr); err == nil {
+		event.IP = host
+	} else {
+		event.IP = r.RemoteAddr
+	}
+	d.audit.Record(r.Context(), event)
+}
@@ -0,0 +1,246 @@
+package dashboard
+
+import (
+	"fmt"
+	"html/template"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/Ultrahd-dev/student-schedule-app/backend/internal/feedback"
+	"github.com/Ultrahd-dev/student-schedule-app/backend/internal/schedule"
+)
+
+// templateFuncs функции шаблонов страниц панели
+var templateFuncs = template.FuncMap{
+	"datetime": func(t time.Time) string {
+		return t.Local().Format("02.01.2006 15:04")
+	},
+	"date": func(t time.Time) string {
+		return t.Format("02.01.2006")
+	},
+	"duration": func(from, to time.Time) string {
+		return to.Sub(from).Round(time.Second).String()
+	},
+	"since": func(t time.Time) string {
+		return time.Since(t).Round(time.Second).String()
+	},
+	"changeType":    changeTypeName,
+	"changeState":   changeState,
+	"reportReasons": reportReasons,
+}
+
+// changeTypeName название вида изменения
+func changeTypeName(changeType string) string {
+	switch changeType {
+	case "replacement":
+		return "замена"
+	case "cancellation":
+		return "отмена"
+	case "addition":
+		return "добавление"
+	}
+	return changeType
+}
+
+// changeState состояние изменения: модерация, затем применение к расписанию
+func changeState(change schedule.ScheduleChange) string {
+	switch change.ModerationStatus {
+	case schedule.ChangeModerationPending:
+		return "ожидает модерации"
+	case schedule.ChangeModerationRejected:
+		return "отклонено"
+	}
+	switch change.ApplyStatus {
+	case schedule.ChangeApplyPending:
+		return "ожидает применения"
+	case schedule.ChangeApplyApplied, schedule.ChangeApplySkipped:
+		return "применено"
+	case schedule.ChangeApplyError:
+		return "ошибка: " + change.ApplyError
+	case schedule.ChangeApplyReverted:
+		return "откачено"
+	}
+	return change.ApplyStatus
+}
+
+// reportReasonNames названия причин жалоб на пару
+var reportReasonNames = map[string]string{
+	feedback.ReasonWrongSubject:   "предмет",
+	feedback.ReasonWrongTeacher:   "преподаватель",
+	feedback.ReasonWrongClassroom: "аудитория",
+	feedback.ReasonWrongTime:      "время",
+	feedback.ReasonNotHeld:        "пары нет",
+	feedback.ReasonOther:          "другое",
+}
+
+// reportReasons причины жалоб на пару с числом жалоб, например "аудитория: 3, время: 1"
+func reportReasons(reasons map[string]int) string {
+	parts := make([]string, 0, len(reasons))
+	for reason, count := range reasons {
+		name, ok := reportReasonNames[reason]
+		if !ok {
+			name = reason
+		}
+		parts = append(parts, fmt.Sprintf("%s: %d", name, count))
+	}
+	sort.Strings(parts)
+	return strings.Join(parts, ", ")
+}
+
+// pageTemplates шаблоны страниц панели: overview, login и two-factor
+const pageTemplates = `
+{{define "head"}}<!DOCTYPE html>
+<html lang="ru">
+<head>
+  <meta charset="utf-8">
+  <meta name="viewport" content="width=device-width, initial-scale=1">
+  <title>Расписание - панель администратора</title>
+  <style>
+    body { font-family: sans-serif; margin: 2em auto; max-width: 70em; padding: 0 1em; color: #222; }
+    h1 { font-size: 1.4em; } h2 { font-size: 1.15em; margin-top: 2em; }
+    table { border-collapse: collapse; width: 100%; }
+    th, td { border-bottom: 1px solid #ddd; padding: .3em .5em; text-align: left; vertical-align: top; }
+    th { background: #f4f4f4; }
+    .muted { color: #777; } .error { color: #b00020; } .message { background: #eef6ee; padding: .6em; }
+    .bar { display: flex; justify-content: space-between; align-items: center; }
+    form.inline { display: inline; }
+    label { display: block; margin: .6em 0 .2em; }
+    input[type=text], input[type=email], input[type=password] { width: 20em; padding: .3em; }
+    button { margin-top: .8em; padding: .4em 1em; }
+  </style>
+</head>
+<body>
+{{end}}
+
+{{define "foot"}}</body>
+</html>
+{{end}}
+
+{{define "run"}}{{if .}}{{datetime .FinishedAt}} <span class="muted">(длительность {{duration .StartedAt .FinishedAt}}, экземпляр {{.Instance}})</span>{{else}}<span class="muted">не выполнялся</span>{{end}}{{end}}
+
+{{define "overview"}}{{template "head"}}
+<div class="bar">
+  <h1>Панель администратора</h1>
+  <form class="inline" method="post" action="logout">
+    <input type="hidden" name="csrf" value="{{.CSRF}}">
+    {{.Email}} <button type="submit">Выйти</button>
+  </form>
+</div>
+{{with .Message}}<p class="message">{{.}}</p>{{end}}
+
+<h2>Парсинг</h2>
+<table>
+  <tr><th>Основное расписание</th><td>{{template "run" .MainRun}}</td></tr>
+  <tr><th>Изменения</th><td>{{template "run" .ChangesRun}}</td></tr>
+</table>
+{{if .ScrapingSince}}
+<p>Внеплановый парсинг выполняется {{since .ScrapingSince}}.</p>
+{{else if .CanScrape}}
+<form method="post" action="scrape">
+  <input type="hidden" name="csrf" value="{{.CSRF}}">
+  <button type="submit">Запустить парсинг сейчас</button>
+</form>
+{{else}}
+<p class="muted">Парсинг колледжа на этом экземпляре не настроен.</p>
+{{end}}
+
+<h2>Активный снапшот</h2>
+{{with .Snapshot}}
+<table>
+  <tr><th>Снапшот</th><td>{{.SnapshotName}}</td></tr>
+  <tr><th>Период</th><td>{{date .PeriodStart}} - {{date .PeriodEnd}}</td></tr>
+  <tr><th>Загружен</th><td>{{datetime .LoadedAt}}</td></tr>
+  <tr><th>Групп / занятий / изменений</th><td>{{.Groups}} / {{.Lessons}} / {{.Changes}}</td></tr>
+</table>
+{{else}}
+<p class="muted">Расписание еще не загружено.</p>
+{{end}}
+
+<h2>Рассылка уведомлений</h2>
+{{if .Notifications}}
+<table>
+  <tr><th>Вид</th><th>Ожидают</th><th>Выполняются</th><th>Выполнены</th><th>С ошибкой</th></tr>
+  {{range .Notifications}}
+  <tr><td>{{.Kind}}</td><td>{{.Pending}}</td><td>{{.Running}}</td><td>{{.Done}}</td><td>{{if .Failed}}<span class="error">{{.Failed}}</span>{{else}}0{{end}}</td></tr>
+  {{end}}
+</table>
+{{else}}
+<p class="muted">Задач рассылки нет.</p>
+{{end}}
+
+{{if .ShowReports}}
+<h2>Жалобы на расписание</h2>
+{{if .ErrorReports}}
+<table>
+  <tr><th>Дата</th><th>Группа</th><th>Время</th><th>Предмет</th><th>Жалоб</th><th>Что неверно</th><th>Комментарии</th><th>Последняя</th><th></th></tr>
+  {{range .ErrorReports}}
+  <tr>
+    <td>{{date .Date}}</td><td>{{.GroupName}}</td><td>{{.TimeStart}}</td>
+    <td>{{.Subject}}{{if eq .SourceType "change"}} <span class="muted">(изменение)</span>{{end}}</td>
+    <td>{{.Reports}}</td><td>{{reportReasons .Reasons}}</td>
+    <td>{{range .Comments}}<div>{{.}}</div>{{end}}</td>
+    <td>{{datetime .LastReportAt}}</td>
+    <td>
+      <form class="inline" method="post" action="reports/resolve">
+        <input type="hidden" name="csrf" value="{{$.CSRF}}">
+        <input type="hidden" name="group" value="{{.GroupName}}">
+        <input type="hidden" name="date" value="{{.Date.Format "2006-01-02"}}">
+        <input type="hidden" name="time" value="{{.TimeStart}}">
+        <button type="submit">Разобрано</button>
+      </form>
+    </td>
+  </tr>
+  {{end}}
+</table>
+{{else}}
+<p class="muted">Жалоб нет.</p>
+{{end}}
+{{end}}
+
+<h2>Последние изменения</h2>
+{{if .Changes}}
+<table>
+  <tr><th>Найдено</th><th>Дата</th><th>Группа</th><th>Время</th><th>Изменение</th><th>Предмет</th><th>Преподаватель</th><th>Аудитория</th><th>Состояние</th></tr>
+  {{range .Changes}}
+  <tr>
+    <td>{{datetime .CreatedAt}}</td><td>{{date .Date}}</td><td>{{.GroupName}}</td><td>{{.TimeStart}}-{{.TimeEnd}}</td>
+    <td>{{changeType .ChangeType}}</td>
+    <td>{{if and .OriginalSubject (ne .OriginalSubject .Subject)}}{{.OriginalSubject}} &rarr; {{end}}{{.Subject}}</td>
+    <td>{{.Teacher}}</td><td>{{.Classroom}}</td>
+    <td{{if eq .ApplyStatus "error"}} class="error"{{end}}>{{changeState .}}{{if not .IsActive}} <span class="muted">(неактивно)</span>{{end}}</td>
+  </tr>
+  {{end}}
+</table>
+{{else}}
+<p class="muted">Изменений нет.</p>
+{{end}}
+
+<p class="muted">Сформировано {{datetime .GeneratedAt}}</p>
+{{template "foot"}}{{end}}
+
+{{define "login"}}{{template "head"}}
+<h1>Вход в панель администратора</h1>
+{{with .Error}}<p class="error">{{.}}</p>{{end}}
+<form method="post" action="login">
+  <label for="college">Код колледжа (пусто - колледж по умолчанию)</label>
+  <input type="text" id="college" name="college" value="{{.College}}">
+  <label for="email">Email</label>
+  <input type="email" id="email" name="email" value="{{.Email}}" required autofocus>
+  <label for="password">Пароль</label>
+  <input type="password" id="password" name="password" required>
+  <div><button type="submit">Войти</button></div>
+</form>
+{{template "foot"}}{{end}}
+
+{{define "two-factor"}}{{template "head"}}
+<h1>Двухфакторная аутентификация</h1>
+<p>{{.Email}}: введите код из приложения или код восстановления.</p>
+<form method="post" action="two-factor">
+  <input type="hidden" name="token" value="{{.TwoFactorToken}}">
+  <label for="code">Код</label>
+  <input type="text" id="code" name="code" autocomplete="one-time-code" required autofocus>
+  <div><button type="submit">Подтвердить</button></div>
+</form>
+{{template "foot"}}{{end}}
+`
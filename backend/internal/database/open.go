@@ -0,0 +1,61 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/lib/pq"
+)
+
+// Open открывает пул соединений с PostgreSQL по строке подключения dsn в любом
+// формате, который понимает lib/pq. Каждое новое соединение пула, до первого
+// запроса, получает ограничения сеанса statementTimeout (statement_timeout) и
+// lockTimeout (lock_timeout); 0 - без ограничения. Ограничения задаются командой
+// SET, поэтому действуют и для строк подключения из --dsn, SCHEDULE_DSN и реплик.
+func Open(dsn string, statementTimeout, lockTimeout time.Duration) (*sql.DB, error) {
+	connector, err := pq.NewConnector(dsn)
+	if err != nil {
+		return nil, fmt.Errorf("некорректная строка подключения: %w", err)
+	}
+
+	var settings []string
+	if statementTimeout > 0 {
+		settings = append(settings, fmt.Sprintf("SET statement_timeout = %d", statementTimeout.Milliseconds()))
+	}
+	if lockTimeout > 0 {
+		settings = append(settings, fmt.Sprintf("SET lock_timeout = %d", lockTimeout.Milliseconds()))
+	}
+	if len(settings) == 0 {
+		return sql.OpenDB(connector), nil
+	}
+	return sql.OpenDB(&sessionConnector{Connector: connector, setup: strings.Join(settings, "; ")}), nil
+}
+
+// sessionConnector выполняет setup на каждом новом соединении
+type sessionConnector struct {
+	driver.Connector
+	setup string
+}
+
+// Connect открывает соединение и настраивает сеанс
+func (c *sessionConnector) Connect(ctx context.Context) (driver.Conn, error) {
+	conn, err := c.Connector.Connect(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	execer, ok := conn.(driver.ExecerContext)
+	if !ok {
+		conn.Close()
+		return nil, fmt.Errorf("драйвер не поддерживает выполнение команд при подключении")
+	}
+	if _, err := execer.ExecContext(ctx, c.setup, nil); err != nil {
+		conn.Close()
+		return nil, fmt.Errorf("ошибка настройки сеанса: %w", err)
+	}
+	return conn, nil
+}
@@ -0,0 +1,117 @@
+// Package database распределяет запросы между основной базой и репликами:
+// запись всегда идет в основную базу, а частые запросы на чтение (расписание
+// группы, уведомления) можно направить на реплики
+package database
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"log"
+	"sync/atomic"
+	"time"
+)
+
+// Router выбирает соединение для запросов на чтение.
+// Реплики выбираются по очереди; реплика, не ответившая на проверку, исключается
+// до следующей успешной проверки. Без доступных реплик чтение идет в основную базу.
+// Реплики отстают от основной базы, поэтому читать с них можно только данные,
+// для которых допустима задержка репликации.
+type Router struct {
+	primary  *sql.DB
+	replicas []*replica
+	next     atomic.Uint64
+}
+
+// replica соединение с репликой и результат последней проверки
+type replica struct {
+	db      *sql.DB
+	name    string // Номер реплики для журнала (DSN содержит пароль)
+	healthy atomic.Bool
+}
+
+// NewRouter создает маршрутизатор запросов поверх основной базы primary и реплик replicas
+func NewRouter(primary *sql.DB, replicas ...*sql.DB) *Router {
+	router := &Router{primary: primary}
+	for i, db := range replicas {
+		r := &replica{db: db, name: fmt.Sprintf("#%d", i+1)}
+		r.healthy.Store(true)
+		router.replicas = append(router.replicas, r)
+	}
+	return router
+}
+
+// Primary возвращает основную базу (для записи и чтения сразу после записи)
+func (r *Router) Primary() *sql.DB {
+	return r.primary
+}
+
+// Reader возвращает соединение для запроса на чтение: доступную реплику или основную базу
+func (r *Router) Reader() *sql.DB {
+	n := len(r.replicas)
+	if n == 0 {
+		return r.primary
+	}
+
+	start := r.next.Add(1)
+	for i := 0; i < n; i++ {
+		replica := r.replicas[(start+uint64(i))%uint64(n)]
+		if replica.healthy.Load() {
+			return replica.db
+		}
+	}
+	return r.primary
+}
+
+// Start проверяет доступность реплик с периодом interval до отмены контекста
+func (r *Router) Start(ctx context.Context, interval time.Duration) {
+	if len(r.replicas) == 0 {
+		return
+	}
+	if interval <= 0 {
+		interval = 10 * time.Second
+	}
+
+	log.Printf("Чтение распределяется между %d репликами, проверка доступности каждые %s", len(r.replicas), interval)
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		r.check(ctx, interval)
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// check проверяет доступность реплик и сообщает о смене состояния
+func (r *Router) check(ctx context.Context, timeout time.Duration) {
+	for _, replica := range r.replicas {
+		pingCtx, cancel := context.WithTimeout(ctx, timeout)
+		err := replica.db.PingContext(pingCtx)
+		cancel()
+
+		healthy := err == nil
+		if replica.healthy.Swap(healthy) == healthy {
+			continue
+		}
+		if healthy {
+			log.Printf("Реплика %s снова доступна", replica.name)
+		} else {
+			log.Printf("Реплика %s недоступна, чтение переключено на другие реплики или основную базу: %v", replica.name, err)
+		}
+	}
+}
+
+// Close закрывает соединения с репликами (основную базу закрывает ее владелец)
+func (r *Router) Close() error {
+	var errs []error
+	for _, replica := range r.replicas {
+		errs = append(errs, replica.db.Close())
+	}
+	return errors.Join(errs...)
+}
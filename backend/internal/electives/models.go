@@ -0,0 +1,59 @@
+// Package electives реализует факультативы: администратор создает курсы по выбору
+// с еженедельными занятиями, студенты записываются на них сами, а занятия курсов
+// добавляются к расписанию группы в личном расписании студента.
+package electives
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// SourceType тип источника занятий факультатива в личном расписании
+// (schedule.CurrentSchedule.SourceType); SourceID - ID курса
+const SourceType = "elective"
+
+// Course курс по выбору
+type Course struct {
+	ID          uuid.UUID  `db:"id"`
+	Title       string     `db:"title"`
+	Teacher     string     `db:"teacher"`
+	Description string     `db:"description"`
+	GroupNames  []string   `db:"group_names"` // Группы, которым доступна запись (пусто - всем)
+	Capacity    int        `db:"capacity"`    // Максимум студентов (0 - без ограничения)
+	StartsOn    time.Time  `db:"starts_on"`
+	EndsOn      time.Time  `db:"ends_on"`
+	IsActive    bool       `db:"is_active"` // false - курс отменен
+	CreatedBy   *uuid.UUID `db:"created_by"`
+	CreatedAt   time.Time  `db:"created_at"`
+	Slots       []Slot     // Еженедельные занятия
+	Enrolled    int        // Записавшихся студентов
+	IsEnrolled  bool       // Пользователь, для которого получен список, записан на курс
+}
+
+// Slot еженедельное занятие курса
+type Slot struct {
+	ID        uuid.UUID    `db:"id"`
+	Weekday   time.Weekday `db:"weekday"`
+	TimeStart string       `db:"time_start"` // ЧЧ:ММ
+	TimeEnd   string       `db:"time_end"`   // ЧЧ:ММ
+	Classroom string       `db:"classroom"`
+}
+
+// Full проверяет, что на курсе не осталось мест
+func (c *Course) Full() bool {
+	return c.Capacity > 0 && c.Enrolled >= c.Capacity
+}
+
+// OpenTo проверяет, что студенты группы groupName могут записаться на курс
+func (c *Course) OpenTo(groupName string) bool {
+	if len(c.GroupNames) == 0 {
+		return true
+	}
+	for _, name := range c.GroupNames {
+		if name == groupName {
+			return true
+		}
+	}
+	return false
+}
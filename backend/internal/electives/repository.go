@@ -0,0 +1,391 @@
+package electives
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	"github.com/Ultrahd-dev/student-schedule-app/backend/internal/clock"
+	"github.com/Ultrahd-dev/student-schedule-app/backend/internal/tenant"
+	"github.com/google/uuid"
+	"github.com/lib/pq"
+)
+
+// Repository предоставляет доступ к факультативам в базе данных
+type Repository struct {
+	db *sql.DB
+}
+
+// NewRepository создает новый репозиторий факультативов
+func NewRepository(db *sql.DB) *Repository {
+	return &Repository{db: db}
+}
+
+// courseColumns колонки курса для scanCourses
+const courseColumns = `
+	c.id, c.title, c.teacher, c.description, c.group_names, c.capacity, c.starts_on, c.ends_on,
+	c.is_active, c.created_by, c.created_at,
+	(SELECT COUNT(*) FROM elective_enrollments e WHERE e.course_id = c.id)`
+
+// CreateCourse сохраняет курс с занятиями в колледже из контекста
+func (r *Repository) CreateCourse(ctx context.Context, course *Course) error {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer func() { _ = tx.Rollback() }()
+
+	query := `
+		INSERT INTO elective_courses
+			(id, title, teacher, description, group_names, capacity, starts_on, ends_on, created_by, college_id)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10)
+		RETURNING is_active, created_at`
+
+	err = tx.QueryRowContext(ctx, query,
+		course.ID,
+		course.Title,
+		course.Teacher,
+		course.Description,
+		pq.Array(course.GroupNames),
+		course.Capacity,
+		course.StartsOn,
+		course.EndsOn,
+		course.CreatedBy,
+		tenant.CollegeID(ctx)).
+		Scan(&course.IsActive, &course.CreatedAt)
+	if err != nil {
+		return fmt.Errorf("failed to create elective course: %w", err)
+	}
+
+	for i := range course.Slots {
+		slot := &course.Slots[i]
+		_, err := tx.ExecContext(ctx, `
+			INSERT INTO elective_slots (id, course_id, weekday, time_start, time_end, classroom)
+			VALUES ($1, $2, $3, $4, $5, $6)`,
+			slot.ID, course.ID, isoWeekday(slot.Weekday), slot.TimeStart, slot.TimeEnd, slot.Classroom)
+		if err != nil {
+			return fmt.Errorf("failed to create elective slot: %w", err)
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit elective course: %w", err)
+	}
+	return nil
+}
+
+// GetCourse получает курс колледжа из контекста с занятиями.
+// Если курса нет, возвращает sql.ErrNoRows.
+func (r *Repository) GetCourse(ctx context.Context, id uuid.UUID) (*Course, error) {
+	query := `SELECT ` + courseColumns + `
+		FROM elective_courses c
+		WHERE c.id = $1 AND c.college_id = $2`
+
+	courses, err := r.queryCourses(ctx, query, id, tenant.CollegeID(ctx))
+	if err != nil {
+		return nil, err
+	}
+	if len(courses) == 0 {
+		return nil, sql.ErrNoRows
+	}
+	return &courses[0], nil
+}
+
+// ListCourses получает активные курсы колледжа из контекста, которые не закончились
+// к дате from, и отмечает курсы, на которые записан пользователь userID
+func (r *Repository) ListCourses(ctx context.Context, userID uuid.UUID, from time.Time) ([]Course, error) {
+	query := `SELECT ` + courseColumns + `
+		FROM elective_courses c
+		WHERE c.is_active = true AND c.ends_on >= $1 AND c.college_id = $2
+		ORDER BY c.starts_on, c.title`
+
+	courses, err := r.queryCourses(ctx, query, from, tenant.CollegeID(ctx))
+	if err != nil {
+		return nil, err
+	}
+
+	enrolled, err := r.enrolledCourses(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	for i := range courses {
+		courses[i].IsEnrolled = enrolled[courses[i].ID]
+	}
+	return courses, nil
+}
+
+// GetUserCourses получает активные курсы колледжа из контекста, пересекающиеся с периодом
+// [from, to], на которые записаны пользователи userIDs, по пользователю
+func (r *Repository) GetUserCourses(ctx context.Context, userIDs []uuid.UUID, from, to time.Time) (map[uuid.UUID][]Course, error) {
+	query := `
+		SELECT e.user_id, ` + courseColumns + `
+		FROM elective_enrollments e
+		JOIN elective_courses c ON c.id = e.course_id
+		WHERE e.user_id = ANY($1) AND c.is_active = true
+		  AND c.starts_on <= $3 AND c.ends_on >= $2 AND c.college_id = $4
+		ORDER BY c.starts_on, c.title`
+
+	rows, err := r.db.QueryContext(ctx, query, pq.Array(userIDs), from, to, tenant.CollegeID(ctx))
+	if err != nil {
+		return nil, fmt.Errorf("failed to get user elective courses: %w", err)
+	}
+	defer rows.Close()
+
+	var userOrder []uuid.UUID
+	var courses []Course
+	for rows.Next() {
+		var userID uuid.UUID
+		var course Course
+		if err := scanCourse(rows, &course, &userID); err != nil {
+			return nil, err
+		}
+		userOrder = append(userOrder, userID)
+		courses = append(courses, course)
+	}
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating rows: %w", err)
+	}
+
+	if err := r.loadSlots(ctx, courses); err != nil {
+		return nil, err
+	}
+
+	byUser := make(map[uuid.UUID][]Course)
+	for i, userID := range userOrder {
+		courses[i].IsEnrolled = true
+		byUser[userID] = append(byUser[userID], courses[i])
+	}
+	return byUser, nil
+}
+
+// Enroll записывает пользователя на курс колледжа из контекста. Курс блокируется
+// на время записи, чтобы одновременные записи не превысили число мест.
+func (r *Repository) Enroll(ctx context.Context, courseID, userID uuid.UUID) error {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer func() { _ = tx.Rollback() }()
+
+	var capacity int
+	err = tx.QueryRowContext(ctx,
+		`SELECT capacity FROM elective_courses WHERE id = $1 AND college_id = $2 FOR UPDATE`,
+		courseID, tenant.CollegeID(ctx)).Scan(&capacity)
+	if err != nil {
+		return err
+	}
+
+	if capacity > 0 {
+		var enrolled int
+		err := tx.QueryRowContext(ctx,
+			`SELECT COUNT(*) FROM elective_enrollments WHERE course_id = $1`, courseID).Scan(&enrolled)
+		if err != nil {
+			return fmt.Errorf("failed to count enrollments: %w", err)
+		}
+		if enrolled >= capacity {
+			return ErrCourseFull
+		}
+	}
+
+	result, err := tx.ExecContext(ctx, `
+		INSERT INTO elective_enrollments (course_id, user_id)
+		VALUES ($1, $2)
+		ON CONFLICT (course_id, user_id) DO NOTHING`, courseID, userID)
+	if err != nil {
+		return fmt.Errorf("failed to enroll: %w", err)
+	}
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get affected rows: %w", err)
+	}
+	if affected == 0 {
+		return ErrAlreadyEnrolled
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit enrollment: %w", err)
+	}
+	return nil
+}
+
+// Unenroll отменяет запись пользователя на курс колледжа из контекста
+func (r *Repository) Unenroll(ctx context.Context, courseID, userID uuid.UUID) (bool, error) {
+	result, err := r.db.ExecContext(ctx, `
+		DELETE FROM elective_enrollments e
+		USING elective_courses c
+		WHERE e.course_id = c.id AND e.course_id = $1 AND e.user_id = $2 AND c.college_id = $3`,
+		courseID, userID, tenant.CollegeID(ctx))
+	if err != nil {
+		return false, fmt.Errorf("failed to unenroll: %w", err)
+	}
+
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return false, fmt.Errorf("failed to get affected rows: %w", err)
+	}
+	return affected > 0, nil
+}
+
+// CancelCourse отменяет активный курс колледжа из контекста и возвращает записанных
+// на него пользователей. Записи сохраняются, чтобы было видно, кого затронула отмена.
+// Если активного курса нет, возвращает sql.ErrNoRows.
+func (r *Repository) CancelCourse(ctx context.Context, id uuid.UUID) ([]uuid.UUID, error) {
+	result, err := r.db.ExecContext(ctx,
+		`UPDATE elective_courses SET is_active = false WHERE id = $1 AND is_active = true AND college_id = $2`,
+		id, tenant.CollegeID(ctx))
+	if err != nil {
+		return nil, fmt.Errorf("failed to cancel elective course: %w", err)
+	}
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get affected rows: %w", err)
+	}
+	if affected == 0 {
+		return nil, sql.ErrNoRows
+	}
+
+	rows, err := r.db.QueryContext(ctx, `SELECT user_id FROM elective_enrollments WHERE course_id = $1`, id)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get enrolled users: %w", err)
+	}
+	defer rows.Close()
+
+	var userIDs []uuid.UUID
+	for rows.Next() {
+		var userID uuid.UUID
+		if err := rows.Scan(&userID); err != nil {
+			return nil, fmt.Errorf("failed to scan enrolled user: %w", err)
+		}
+		userIDs = append(userIDs, userID)
+	}
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating rows: %w", err)
+	}
+	return userIDs, nil
+}
+
+// enrolledCourses возвращает ID курсов, на которые записан пользователь
+func (r *Repository) enrolledCourses(ctx context.Context, userID uuid.UUID) (map[uuid.UUID]bool, error) {
+	rows, err := r.db.QueryContext(ctx, `SELECT course_id FROM elective_enrollments WHERE user_id = $1`, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get enrollments: %w", err)
+	}
+	defer rows.Close()
+
+	enrolled := make(map[uuid.UUID]bool)
+	for rows.Next() {
+		var courseID uuid.UUID
+		if err := rows.Scan(&courseID); err != nil {
+			return nil, fmt.Errorf("failed to scan enrollment: %w", err)
+		}
+		enrolled[courseID] = true
+	}
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating rows: %w", err)
+	}
+	return enrolled, nil
+}
+
+// queryCourses выполняет запрос курсов (колонки courseColumns) и загружает их занятия
+func (r *Repository) queryCourses(ctx context.Context, query string, args ...interface{}) ([]Course, error) {
+	rows, err := r.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get elective courses: %w", err)
+	}
+	defer rows.Close()
+
+	var courses []Course
+	for rows.Next() {
+		var course Course
+		if err := scanCourse(rows, &course); err != nil {
+			return nil, err
+		}
+		courses = append(courses, course)
+	}
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating rows: %w", err)
+	}
+
+	if err := r.loadSlots(ctx, courses); err != nil {
+		return nil, err
+	}
+	return courses, nil
+}
+
+// scanCourse читает курс из строки с колонками courseColumns, перед которыми
+// могут идти колонки prefix
+func scanCourse(rows *sql.Rows, course *Course, prefix ...interface{}) error {
+	dest := append(prefix,
+		&course.ID,
+		&course.Title,
+		&course.Teacher,
+		&course.Description,
+		pq.Array(&course.GroupNames),
+		&course.Capacity,
+		&course.StartsOn,
+		&course.EndsOn,
+		&course.IsActive,
+		&course.CreatedBy,
+		&course.CreatedAt,
+		&course.Enrolled,
+	)
+	if err := rows.Scan(dest...); err != nil {
+		return fmt.Errorf("failed to scan elective course: %w", err)
+	}
+	return nil
+}
+
+// loadSlots загружает еженедельные занятия курсов одним запросом
+func (r *Repository) loadSlots(ctx context.Context, courses []Course) error {
+	if len(courses) == 0 {
+		return nil
+	}
+
+	ids := make([]uuid.UUID, 0, len(courses))
+	for _, course := range courses {
+		ids = append(ids, course.ID)
+	}
+
+	query := `
+		SELECT course_id, id, weekday, time_start, time_end, classroom
+		FROM elective_slots
+		WHERE course_id = ANY($1)
+		ORDER BY weekday, time_start`
+
+	rows, err := r.db.QueryContext(ctx, query, pq.Array(ids))
+	if err != nil {
+		return fmt.Errorf("failed to get elective slots: %w", err)
+	}
+	defer rows.Close()
+
+	slots := make(map[uuid.UUID][]Slot)
+	for rows.Next() {
+		var courseID uuid.UUID
+		var slot Slot
+		var weekday int
+		if err := rows.Scan(&courseID, &slot.ID, &weekday, &slot.TimeStart, &slot.TimeEnd, &slot.Classroom); err != nil {
+			return fmt.Errorf("failed to scan elective slot: %w", err)
+		}
+		slot.Weekday = time.Weekday(weekday % 7)
+		slot.TimeStart = clock.NormalizeClock(slot.TimeStart)
+		slot.TimeEnd = clock.NormalizeClock(slot.TimeEnd)
+		slots[courseID] = append(slots[courseID], slot)
+	}
+	if err = rows.Err(); err != nil {
+		return fmt.Errorf("error iterating rows: %w", err)
+	}
+
+	for i := range courses {
+		courses[i].Slots = slots[courses[i].ID]
+	}
+	return nil
+}
+
+// isoWeekday номер дня недели в базе: 1 - понедельник, 7 - воскресенье
+func isoWeekday(weekday time.Weekday) int {
+	if weekday == time.Sunday {
+		return 7
+	}
+	return int(weekday)
+}
@@ -0,0 +1,307 @@
+package electives
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"log"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/Ultrahd-dev/student-schedule-app/backend/internal/apperr"
+	"github.com/Ultrahd-dev/student-schedule-app/backend/internal/clock"
+	"github.com/Ultrahd-dev/student-schedule-app/backend/internal/schedule"
+	"github.com/google/uuid"
+)
+
+// Ошибки факультативов
+var (
+	ErrInvalidCourse   = apperr.New(apperr.ErrValidation, "некорректный факультатив")
+	ErrCourseNotFound  = apperr.New(apperr.ErrNotFound, "факультатив не найден")
+	ErrCourseClosed    = apperr.New(apperr.ErrConflict, "запись на факультатив закрыта")
+	ErrCourseFull      = apperr.New(apperr.ErrConflict, "на факультативе нет свободных мест")
+	ErrAlreadyEnrolled = apperr.New(apperr.ErrAlreadyExists, "вы уже записаны на факультатив")
+	ErrNotEnrolled     = apperr.New(apperr.ErrNotFound, "вы не записаны на факультатив")
+)
+
+// maxSlots максимальное число еженедельных занятий курса
+const maxSlots = 14
+
+// Service управляет факультативами и записью на них
+type Service struct {
+	repo *Repository
+	loc  *time.Location // Часовой пояс колледжа
+}
+
+// NewService создает новый сервис факультативов
+func NewService(repo *Repository, loc *time.Location) *Service {
+	return &Service{repo: repo, loc: loc}
+}
+
+// CreateCourse проверяет и сохраняет новый курс. Время занятий приводится к формату ЧЧ:ММ.
+func (s *Service) CreateCourse(ctx context.Context, course *Course, createdBy uuid.UUID) error {
+	course.Title = strings.TrimSpace(course.Title)
+	course.Teacher = strings.TrimSpace(course.Teacher)
+	course.Description = strings.TrimSpace(course.Description)
+	switch {
+	case course.Title == "":
+		return fmt.Errorf("%w: не указано название", ErrInvalidCourse)
+	case course.Teacher == "":
+		return fmt.Errorf("%w: не указан преподаватель", ErrInvalidCourse)
+	case course.Capacity < 0:
+		return fmt.Errorf("%w: отрицательное число мест", ErrInvalidCourse)
+	case course.StartsOn.IsZero() || course.EndsOn.IsZero():
+		return fmt.Errorf("%w: не указан период курса", ErrInvalidCourse)
+	case len(course.Slots) == 0:
+		return fmt.Errorf("%w: не указаны занятия", ErrInvalidCourse)
+	case len(course.Slots) > maxSlots:
+		return fmt.Errorf("%w: больше %d занятий в неделю", ErrInvalidCourse, maxSlots)
+	}
+
+	course.StartsOn = clock.DateOf(course.StartsOn, s.loc)
+	course.EndsOn = clock.DateOf(course.EndsOn, s.loc)
+	if course.EndsOn.Before(course.StartsOn) {
+		return fmt.Errorf("%w: курс заканчивается раньше, чем начинается", ErrInvalidCourse)
+	}
+
+	groupNames := course.GroupNames[:0]
+	for _, name := range course.GroupNames {
+		if name = strings.TrimSpace(name); name != "" {
+			groupNames = append(groupNames, name)
+		}
+	}
+	course.GroupNames = groupNames
+
+	for i := range course.Slots {
+		slot := &course.Slots[i]
+		start, err := clock.ParseClock(slot.TimeStart)
+		if err != nil {
+			return fmt.Errorf("%w: %v", ErrInvalidCourse, err)
+		}
+		end, err := clock.ParseClock(slot.TimeEnd)
+		if err != nil {
+			return fmt.Errorf("%w: %v", ErrInvalidCourse, err)
+		}
+		if start >= end {
+			return fmt.Errorf("%w: занятие заканчивается раньше, чем начинается", ErrInvalidCourse)
+		}
+		slot.ID = uuid.New()
+		slot.TimeStart = clock.FormatClock(start)
+		slot.TimeEnd = clock.FormatClock(end)
+		slot.Classroom = strings.TrimSpace(slot.Classroom)
+	}
+
+	course.ID = uuid.New()
+	course.CreatedBy = &createdBy
+	if err := s.repo.CreateCourse(ctx, course); err != nil {
+		return fmt.Errorf("ошибка сохранения факультатива: %w", err)
+	}
+
+	log.Printf("Создан факультатив %q (%s), занятий в неделю: %d", course.Title, course.Teacher, len(course.Slots))
+	return nil
+}
+
+// ListCourses возвращает курсы, которые еще не закончились, с отметкой записи пользователя
+func (s *Service) ListCourses(ctx context.Context, userID uuid.UUID) ([]Course, error) {
+	courses, err := s.repo.ListCourses(ctx, userID, clock.Today(s.loc))
+	if err != nil {
+		return nil, fmt.Errorf("ошибка получения факультативов: %w", err)
+	}
+	for i := range courses {
+		s.anchor(&courses[i])
+	}
+	return courses, nil
+}
+
+// Enroll записывает студента группы groupName на курс
+func (s *Service) Enroll(ctx context.Context, courseID, userID uuid.UUID, groupName string) (*Course, error) {
+	course, err := s.getCourse(ctx, courseID)
+	if err != nil {
+		return nil, err
+	}
+	if !course.IsActive {
+		return nil, fmt.Errorf("%w: курс отменен", ErrCourseClosed)
+	}
+	if course.EndsOn.Before(clock.Today(s.loc)) {
+		return nil, fmt.Errorf("%w: курс закончился", ErrCourseClosed)
+	}
+	if !course.OpenTo(groupName) {
+		return nil, fmt.Errorf("%w: курс недоступен группе %s", ErrCourseClosed, groupName)
+	}
+
+	if err := s.repo.Enroll(ctx, courseID, userID); err != nil {
+		switch {
+		case err == sql.ErrNoRows:
+			return nil, ErrCourseNotFound
+		case errors.Is(err, ErrCourseFull), errors.Is(err, ErrAlreadyEnrolled):
+			return nil, err
+		}
+		return nil, fmt.Errorf("ошибка записи на факультатив: %w", err)
+	}
+
+	course.Enrolled++
+	course.IsEnrolled = true
+	log.Printf("Пользователь %s записался на факультатив %q", userID, course.Title)
+	return course, nil
+}
+
+// Unenroll отменяет запись пользователя на курс
+func (s *Service) Unenroll(ctx context.Context, courseID, userID uuid.UUID) error {
+	removed, err := s.repo.Unenroll(ctx, courseID, userID)
+	if err != nil {
+		return fmt.Errorf("ошибка отмены записи на факультатив: %w", err)
+	}
+	if !removed {
+		return ErrNotEnrolled
+	}
+
+	log.Printf("Пользователь %s отменил запись на факультатив %s", userID, courseID)
+	return nil
+}
+
+// CancelCourse отменяет курс: его занятия пропадают из личного расписания.
+// Возвращает курс и записанных на него пользователей для уведомления.
+func (s *Service) CancelCourse(ctx context.Context, courseID uuid.UUID) (*Course, []uuid.UUID, error) {
+	course, err := s.getCourse(ctx, courseID)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	userIDs, err := s.repo.CancelCourse(ctx, courseID)
+	if err == sql.ErrNoRows {
+		return nil, nil, fmt.Errorf("%w: курс уже отменен", ErrCourseClosed)
+	}
+	if err != nil {
+		return nil, nil, fmt.Errorf("ошибка отмены факультатива: %w", err)
+	}
+
+	course.IsActive = false
+	log.Printf("Факультатив %q отменен, записанных студентов: %d", course.Title, len(userIDs))
+	return course, userIDs, nil
+}
+
+// Lessons возвращает занятия курсов, на которые записан пользователь, за период
+// [from, to] (включительно) в формате актуального расписания группы groupName
+func (s *Service) Lessons(ctx context.Context, userID uuid.UUID, groupName string, from, to time.Time) ([]schedule.CurrentSchedule, error) {
+	from, to = clock.DateOf(from, s.loc), clock.DateOf(to, s.loc)
+
+	byUser, err := s.repo.GetUserCourses(ctx, []uuid.UUID{userID}, from, to)
+	if err != nil {
+		return nil, fmt.Errorf("ошибка получения факультативов пользователя: %w", err)
+	}
+
+	var lessons []schedule.CurrentSchedule
+	for _, course := range byUser[userID] {
+		s.anchor(&course)
+		lessons = append(lessons, course.lessons(groupName, from, to)...)
+	}
+	return lessons, nil
+}
+
+// Conflicts находит среди пользователей userIDs тех, у кого занятие факультатива
+// в дату date пересекается с парой [timeStart, timeEnd). Возвращает названия курсов по пользователю.
+func (s *Service) Conflicts(ctx context.Context, userIDs []uuid.UUID, date time.Time, timeStart, timeEnd string) (map[uuid.UUID]string, error) {
+	if len(userIDs) == 0 {
+		return nil, nil
+	}
+	start, err := clock.ParseClock(timeStart)
+	if err != nil {
+		return nil, err
+	}
+	end, err := clock.ParseClock(timeEnd)
+	if err != nil {
+		return nil, err
+	}
+
+	date = clock.Anchor(date, s.loc)
+	byUser, err := s.repo.GetUserCourses(ctx, userIDs, date, date)
+	if err != nil {
+		return nil, fmt.Errorf("ошибка получения факультативов пользователей: %w", err)
+	}
+
+	conflicts := make(map[uuid.UUID]string)
+	for userID, courses := range byUser {
+		for _, course := range courses {
+			for _, slot := range course.Slots {
+				slotStart, _ := clock.ParseClock(slot.TimeStart)
+				slotEnd, _ := clock.ParseClock(slot.TimeEnd)
+				if slot.Weekday == date.Weekday() && slotStart < end && start < slotEnd {
+					conflicts[userID] = course.Title
+				}
+			}
+		}
+	}
+	return conflicts, nil
+}
+
+// Merge объединяет расписание группы с занятиями факультативов, упорядочивая по дате и времени
+func Merge(entries, lessons []schedule.CurrentSchedule) []schedule.CurrentSchedule {
+	if len(lessons) == 0 {
+		return entries
+	}
+
+	merged := append(append([]schedule.CurrentSchedule(nil), entries...), lessons...)
+	sort.SliceStable(merged, func(i, j int) bool {
+		if !merged[i].Date.Equal(merged[j].Date) {
+			return merged[i].Date.Before(merged[j].Date)
+		}
+		return merged[i].TimeStart < merged[j].TimeStart
+	})
+	return merged
+}
+
+// getCourse получает курс, отображая отсутствие курса в ErrCourseNotFound
+func (s *Service) getCourse(ctx context.Context, courseID uuid.UUID) (*Course, error) {
+	course, err := s.repo.GetCourse(ctx, courseID)
+	if err == sql.ErrNoRows {
+		return nil, ErrCourseNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("ошибка получения факультатива: %w", err)
+	}
+	s.anchor(course)
+	return course, nil
+}
+
+// anchor переносит даты курса, прочитанные из колонок DATE, в часовой пояс колледжа
+func (s *Service) anchor(course *Course) {
+	course.StartsOn = clock.Anchor(course.StartsOn, s.loc)
+	course.EndsOn = clock.Anchor(course.EndsOn, s.loc)
+}
+
+// lessons разворачивает еженедельные занятия курса в занятия за период [from, to].
+// ID занятия выводится из занятия курса и даты, чтобы не менялся между запросами
+// (по нему календарь узнает событие).
+func (c *Course) lessons(groupName string, from, to time.Time) []schedule.CurrentSchedule {
+	if c.StartsOn.After(from) {
+		from = c.StartsOn
+	}
+	if c.EndsOn.Before(to) {
+		to = c.EndsOn
+	}
+
+	var lessons []schedule.CurrentSchedule
+	for day := from; !day.After(to); day = day.AddDate(0, 0, 1) {
+		for _, slot := range c.Slots {
+			if slot.Weekday != day.Weekday() {
+				continue
+			}
+			lessons = append(lessons, schedule.CurrentSchedule{
+				ID:         uuid.NewSHA1(slot.ID, []byte(day.Format("2006-01-02"))),
+				GroupName:  groupName,
+				Date:       day,
+				TimeStart:  slot.TimeStart,
+				TimeEnd:    slot.TimeEnd,
+				Subject:    c.Title,
+				Teacher:    c.Teacher,
+				Classroom:  slot.Classroom,
+				SourceType: SourceType,
+				SourceID:   c.ID,
+				IsActive:   true,
+			})
+		}
+	}
+	return lessons
+}
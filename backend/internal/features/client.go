@@ -0,0 +1,180 @@
+package features
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"hash/fnv"
+	"log"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// ErrInvalidFlag означает некорректное имя или долю пользователей флага
+var ErrInvalidFlag = errors.New("некорректный флаг функции")
+
+// Client отвечает на вопрос, включен ли флаг, по копии таблицы флагов в памяти.
+// Копия обновляется периодически (Start) и сразу после изменений через Set и Reset,
+// поэтому изменения, сделанные на другом экземпляре API, действуют с задержкой
+// до одного периода обновления. Флаги, которых нет в таблице, берут значение
+// по умолчанию для окружения (из конфигурации).
+type Client struct {
+	repo     *Repository
+	defaults map[string]bool
+
+	mu    sync.RWMutex
+	flags map[string]Flag
+}
+
+// NewClient создает клиент флагов; defaults - значения флагов, отсутствующих в таблице
+func NewClient(repo *Repository, defaults map[string]bool) *Client {
+	return &Client{
+		repo:     repo,
+		defaults: defaults,
+		flags:    make(map[string]Flag),
+	}
+}
+
+// Start обновляет флаги из базы данных с периодом interval до отмены контекста
+func (c *Client) Start(ctx context.Context, interval time.Duration) {
+	if interval <= 0 {
+		interval = 30 * time.Second
+	}
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		if err := c.Refresh(ctx); err != nil && ctx.Err() == nil {
+			log.Printf("Ошибка обновления флагов функций: %v", err)
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// Refresh перечитывает флаги из базы данных
+func (c *Client) Refresh(ctx context.Context) error {
+	flags, err := c.repo.ListFlags(ctx)
+	if err != nil {
+		return err
+	}
+
+	byName := make(map[string]Flag, len(flags))
+	for _, flag := range flags {
+		byName[flag.Name] = flag
+	}
+
+	c.mu.Lock()
+	c.flags = byName
+	c.mu.Unlock()
+	return nil
+}
+
+// Enabled проверяет флаг поведения, не привязанного к пользователю: такой флаг
+// включен, только если он включен для всех пользователей
+func (c *Client) Enabled(name string) bool {
+	flag, ok := c.flag(name)
+	if !ok {
+		return c.defaults[name]
+	}
+	return flag.Enabled && flag.Percentage >= 100
+}
+
+// EnabledFor проверяет флаг для пользователя userID. Пользователь попадает в долю
+// percentage по хешу имени флага и ID, поэтому результат для него не меняется
+// между запросами, а при увеличении доли включенные пользователи остаются включенными.
+func (c *Client) EnabledFor(name string, userID uuid.UUID) bool {
+	flag, ok := c.flag(name)
+	if !ok {
+		return c.defaults[name]
+	}
+	if !flag.Enabled {
+		return false
+	}
+	return bucket(name, userID) < flag.Percentage
+}
+
+// List возвращает флаги из базы данных и флаги со значениями по умолчанию,
+// которых в базе нет (у них пустое UpdatedAt)
+func (c *Client) List(ctx context.Context) ([]Flag, error) {
+	if err := c.Refresh(ctx); err != nil {
+		return nil, err
+	}
+
+	c.mu.RLock()
+	flags := make([]Flag, 0, len(c.flags)+len(c.defaults))
+	for _, flag := range c.flags {
+		flags = append(flags, flag)
+	}
+	for name, enabled := range c.defaults {
+		if _, ok := c.flags[name]; !ok {
+			flags = append(flags, Flag{Name: name, Enabled: enabled, Percentage: 100})
+		}
+	}
+	c.mu.RUnlock()
+
+	sort.Slice(flags, func(i, j int) bool { return flags[i].Name < flags[j].Name })
+	return flags, nil
+}
+
+// Set сохраняет флаг от имени администратора updatedBy
+func (c *Client) Set(ctx context.Context, flag *Flag, updatedBy uuid.UUID) error {
+	if flag.Name == "" || len(flag.Name) > 100 {
+		return fmt.Errorf("%w: имя должно содержать от 1 до 100 символов", ErrInvalidFlag)
+	}
+	if flag.Percentage < 0 || flag.Percentage > 100 {
+		return fmt.Errorf("%w: доля пользователей должна быть от 0 до 100", ErrInvalidFlag)
+	}
+
+	flag.UpdatedBy = &updatedBy
+	if err := c.repo.UpsertFlag(ctx, flag); err != nil {
+		return fmt.Errorf("ошибка сохранения флага %s: %w", flag.Name, err)
+	}
+
+	c.mu.Lock()
+	c.flags[flag.Name] = *flag
+	c.mu.Unlock()
+
+	log.Printf("Флаг %s: включен=%t, доля пользователей %d%%", flag.Name, flag.Enabled, flag.Percentage)
+	return nil
+}
+
+// Reset удаляет флаг из базы данных, возвращая ему значение по умолчанию
+func (c *Client) Reset(ctx context.Context, name string) (bool, error) {
+	deleted, err := c.repo.DeleteFlag(ctx, name)
+	if err != nil {
+		return false, fmt.Errorf("ошибка удаления флага %s: %w", name, err)
+	}
+
+	c.mu.Lock()
+	delete(c.flags, name)
+	c.mu.Unlock()
+
+	if deleted {
+		log.Printf("Флаг %s сброшен к значению по умолчанию (%t)", name, c.defaults[name])
+	}
+	return deleted, nil
+}
+
+// flag возвращает флаг из копии таблицы
+func (c *Client) flag(name string) (Flag, bool) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	flag, ok := c.flags[name]
+	return flag, ok
+}
+
+// bucket возвращает номер группы пользователя (0-99) для флага name
+func bucket(name string, userID uuid.UUID) int {
+	h := fnv.New32a()
+	h.Write([]byte(name))
+	h.Write(userID[:])
+	return int(h.Sum32() % 100)
+}
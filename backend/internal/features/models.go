@@ -0,0 +1,27 @@
+// Package features управляет флагами функций: рискованное поведение (модерация
+// изменений, новые парсеры, дайджесты уведомлений) включается в базе данных
+// для всего окружения или для доли пользователей, без повторного развертывания
+package features
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Известные флаги
+const (
+	// FlagModeratedChanges изменения из таблицы применяются только после одобрения
+	// администратором (по умолчанию - настройка changes.moderated)
+	FlagModeratedChanges = "changes.moderated"
+)
+
+// Flag флаг функции
+type Flag struct {
+	Name        string     `db:"name"`
+	Enabled     bool       `db:"enabled"`
+	Percentage  int        `db:"percentage"` // Доля пользователей (0-100), для которых включен флаг
+	Description string     `db:"description"`
+	UpdatedBy   *uuid.UUID `db:"updated_by"`
+	UpdatedAt   time.Time  `db:"updated_at"`
+}
@@ -0,0 +1,84 @@
+package features
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+)
+
+// Repository предоставляет доступ к хранению флагов функций
+type Repository struct {
+	db *sql.DB
+}
+
+// NewRepository создает новый репозиторий флагов функций
+func NewRepository(db *sql.DB) *Repository {
+	return &Repository{db: db}
+}
+
+// ListFlags возвращает все флаги, упорядоченные по имени
+func (r *Repository) ListFlags(ctx context.Context) ([]Flag, error) {
+	query := `
+		SELECT name, enabled, percentage, description, updated_by, updated_at
+		FROM feature_flags
+		ORDER BY name`
+
+	rows, err := r.db.QueryContext(ctx, query)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list feature flags: %w", err)
+	}
+	defer rows.Close()
+
+	var flags []Flag
+	for rows.Next() {
+		var flag Flag
+		if err := rows.Scan(&flag.Name, &flag.Enabled, &flag.Percentage, &flag.Description,
+			&flag.UpdatedBy, &flag.UpdatedAt); err != nil {
+			return nil, fmt.Errorf("failed to scan feature flag: %w", err)
+		}
+		flags = append(flags, flag)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to iterate feature flags: %w", err)
+	}
+
+	return flags, nil
+}
+
+// UpsertFlag создает или обновляет флаг
+func (r *Repository) UpsertFlag(ctx context.Context, flag *Flag) error {
+	query := `
+		INSERT INTO feature_flags (name, enabled, percentage, description, updated_by, updated_at)
+		VALUES ($1, $2, $3, $4, $5, NOW())
+		ON CONFLICT (name) DO UPDATE
+		SET enabled = EXCLUDED.enabled, percentage = EXCLUDED.percentage,
+		    description = EXCLUDED.description, updated_by = EXCLUDED.updated_by, updated_at = NOW()
+		RETURNING updated_at`
+
+	err := r.db.QueryRowContext(ctx, query,
+		flag.Name,
+		flag.Enabled,
+		flag.Percentage,
+		flag.Description,
+		flag.UpdatedBy).
+		Scan(&flag.UpdatedAt)
+	if err != nil {
+		return fmt.Errorf("failed to upsert feature flag: %w", err)
+	}
+
+	return nil
+}
+
+// DeleteFlag удаляет флаг; после удаления действует значение по умолчанию
+func (r *Repository) DeleteFlag(ctx context.Context, name string) (bool, error) {
+	result, err := r.db.ExecContext(ctx, `DELETE FROM feature_flags WHERE name = $1`, name)
+	if err != nil {
+		return false, fmt.Errorf("failed to delete feature flag: %w", err)
+	}
+
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return false, fmt.Errorf("failed to get affected rows: %w", err)
+	}
+	return affected > 0, nil
+}
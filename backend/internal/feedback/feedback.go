@@ -0,0 +1,256 @@
+// Package feedback собирает жалобы пользователей на ошибки в расписании.
+// Студент отмечает конкретную пару (группа, дата, время начала) как неверную;
+// жалобы группируются по паре и показываются администратору в панели, а пара,
+// набравшая порог жалоб, запускает повторный парсинг таблицы, из которой она
+// пришла (изменения или основное расписание).
+package feedback
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"strings"
+	"sync"
+	"time"
+	"unicode/utf8"
+
+	"github.com/Ultrahd-dev/student-schedule-app/backend/internal/apperr"
+	"github.com/Ultrahd-dev/student-schedule-app/backend/internal/clock"
+	"github.com/Ultrahd-dev/student-schedule-app/backend/internal/schedule"
+	"github.com/Ultrahd-dev/student-schedule-app/backend/internal/tenant"
+	"github.com/google/uuid"
+)
+
+// Ошибки жалоб
+var (
+	ErrInvalidReport  = apperr.New(apperr.ErrValidation, "некорректная жалоба")
+	ErrLessonNotFound = apperr.New(apperr.ErrNotFound, "пара не найдена в расписании")
+	ErrNotOwnLesson   = apperr.New(apperr.ErrForbidden, "преподаватель может сообщить об ошибке только в своих парах")
+)
+
+// Что неверно в паре
+const (
+	ReasonWrongSubject   = "wrong_subject"
+	ReasonWrongTeacher   = "wrong_teacher"
+	ReasonWrongClassroom = "wrong_classroom"
+	ReasonWrongTime      = "wrong_time"
+	ReasonNotHeld        = "not_held" // Пары нет (отменена или не существует)
+	ReasonOther          = "other"
+)
+
+// reasons допустимые причины жалобы
+var reasons = map[string]bool{
+	ReasonWrongSubject:   true,
+	ReasonWrongTeacher:   true,
+	ReasonWrongClassroom: true,
+	ReasonWrongTime:      true,
+	ReasonNotHeld:        true,
+	ReasonOther:          true,
+}
+
+// maxCommentLength максимальная длина комментария к жалобе в символах
+const maxCommentLength = 500
+
+// rescrapeTimeout максимальное время повторного парсинга по жалобам
+const rescrapeTimeout = 10 * time.Minute
+
+// Report жалоба пользователя на пару
+type Report struct {
+	ID         uuid.UUID
+	UserID     uuid.UUID
+	GroupName  string
+	Date       time.Time
+	TimeStart  string     // ЧЧ:ММ
+	EntryID    *uuid.UUID // Запись current_schedule, которую видел пользователь
+	SourceType string     // Источник записи: snapshot или change
+	Subject    string     // Предмет пары на момент жалобы
+	Reason     string     // Reason*
+	Comment    string
+	CreatedAt  time.Time
+
+	// TeacherNames имена преподавателя, подающего жалобу (см. users.Service.TeacherNames):
+	// если не nil, пара должна быть его. nil - жалобу подает студент группы или администратор.
+	TeacherNames []string
+}
+
+// LessonReports неразобранные жалобы на одну пару
+type LessonReports struct {
+	GroupName     string
+	Date          time.Time
+	TimeStart     string
+	Subject       string         // Предмет из последней жалобы
+	SourceType    string         // Источник записи из последней жалобы
+	Reports       int            // Пользователей, сообщивших об ошибке
+	Reasons       map[string]int // Число жалоб по причинам
+	Comments      []string       // Последние комментарии
+	FirstReportAt time.Time
+	LastReportAt  time.Time
+}
+
+// Store хранилище жалоб
+type Store interface {
+	// SaveReport сохраняет жалобу (заменяя неразобранную жалобу пользователя на ту же
+	// пару) и возвращает число неразобранных жалоб на пару
+	SaveReport(ctx context.Context, report *Report) (int, error)
+	ListLessonReports(ctx context.Context, limit int) ([]LessonReports, error)
+	ResolveLessonReports(ctx context.Context, groupName string, date time.Time, timeStart string, resolvedBy uuid.UUID) (int64, error)
+}
+
+// Lessons актуальное расписание групп
+type Lessons interface {
+	GetScheduleForGroup(ctx context.Context, groupName string, date time.Time) ([]schedule.CurrentSchedule, error)
+}
+
+// Scraper парсер расписания одного колледжа
+type Scraper interface {
+	// ScrapeSource внепланово парсит таблицу, из которой пришла запись расписания
+	// источника sourceType
+	ScrapeSource(ctx context.Context, sourceType string) error
+}
+
+// Config настройки жалоб
+type Config struct {
+	// RescrapeThreshold жалоб на пару, после которых таблица-источник парсится
+	// заново (0 - повторный парсинг по жалобам отключен)
+	RescrapeThreshold int
+	// RescrapeCooldown наименьший промежуток между повторными парсингами одной
+	// таблицы колледжа по жалобам
+	RescrapeCooldown time.Duration
+}
+
+// Service принимает жалобы на ошибки в расписании
+type Service struct {
+	config  Config
+	store   Store
+	lessons Lessons
+	loc     *time.Location
+
+	mu        sync.Mutex
+	scrapers  map[uuid.UUID]Scraper
+	rescraped map[string]time.Time // Последний повторный парсинг по колледжу и источнику
+}
+
+// NewService создает сервис жалоб. loc - часовой пояс колледжа.
+func NewService(config Config, store Store, lessons Lessons, loc *time.Location) *Service {
+	if config.RescrapeCooldown <= 0 {
+		config.RescrapeCooldown = 30 * time.Minute
+	}
+	return &Service{
+		config:    config,
+		store:     store,
+		lessons:   lessons,
+		loc:       loc,
+		scrapers:  make(map[uuid.UUID]Scraper),
+		rescraped: make(map[string]time.Time),
+	}
+}
+
+// AddScraper подключает парсер колледжа для повторного парсинга по жалобам
+func (s *Service) AddScraper(collegeID uuid.UUID, scraper Scraper) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.scrapers[collegeID] = scraper
+}
+
+// Report сохраняет жалобу пользователя на пару и возвращает число пользователей,
+// сообщивших об ошибке в ней. Пара должна быть в актуальном расписании группы.
+func (s *Service) Report(ctx context.Context, report *Report) (int, error) {
+	report.GroupName = strings.TrimSpace(report.GroupName)
+	report.TimeStart = clock.NormalizeClock(report.TimeStart)
+	report.Comment = strings.TrimSpace(report.Comment)
+	if report.GroupName == "" || report.Date.IsZero() || report.TimeStart == "" {
+		return 0, fmt.Errorf("%w: укажите группу, дату и время начала пары", ErrInvalidReport)
+	}
+	if !reasons[report.Reason] {
+		return 0, fmt.Errorf("%w: не указано, что неверно в паре", ErrInvalidReport)
+	}
+	if report.Reason == ReasonOther && report.Comment == "" {
+		return 0, fmt.Errorf("%w: опишите ошибку в комментарии", ErrInvalidReport)
+	}
+	if utf8.RuneCountInString(report.Comment) > maxCommentLength {
+		return 0, fmt.Errorf("%w: комментарий длиннее %d символов", ErrInvalidReport, maxCommentLength)
+	}
+
+	report.Date = clock.DateOf(report.Date, s.loc)
+	entries, err := s.lessons.GetScheduleForGroup(ctx, report.GroupName, report.Date)
+	if err != nil {
+		return 0, err
+	}
+	var entry *schedule.CurrentSchedule
+	for i := range entries {
+		if clock.NormalizeClock(entries[i].TimeStart) == report.TimeStart {
+			entry = &entries[i]
+			break
+		}
+	}
+	if entry == nil {
+		return 0, ErrLessonNotFound
+	}
+	if report.TeacherNames != nil && !teachesLesson(report.TeacherNames, entry) {
+		return 0, ErrNotOwnLesson
+	}
+	report.EntryID = &entry.ID
+	report.SourceType = entry.SourceType
+	report.Subject = entry.Subject
+	report.ID = uuid.New()
+
+	reports, err := s.store.SaveReport(ctx, report)
+	if err != nil {
+		return 0, err
+	}
+	log.Printf("Жалоба на пару %s %s %s (%s): %s, всего жалоб %d",
+		report.GroupName, report.Date.Format(clock.DateLayout), report.TimeStart, report.Subject, report.Reason, reports)
+
+	if s.config.RescrapeThreshold > 0 && reports >= s.config.RescrapeThreshold {
+		s.rescrape(ctx, report.SourceType)
+	}
+	return reports, nil
+}
+
+// teachesLesson проверяет, что пару entry ведет преподаватель с одним из имен names
+func teachesLesson(names []string, entry *schedule.CurrentSchedule) bool {
+	for _, name := range names {
+		if strings.TrimSpace(entry.Teacher) == strings.TrimSpace(name) {
+			return true
+		}
+	}
+	return false
+}
+
+// ListLessonReports возвращает пары колледжа из контекста с неразобранными жалобами,
+// начиная с пар с наибольшим числом жалоб
+func (s *Service) ListLessonReports(ctx context.Context, limit int) ([]LessonReports, error) {
+	return s.store.ListLessonReports(ctx, limit)
+}
+
+// ResolveLessonReports отмечает жалобы на пару разобранными
+func (s *Service) ResolveLessonReports(ctx context.Context, groupName string, date time.Time, timeStart string, resolvedBy uuid.UUID) (int64, error) {
+	return s.store.ResolveLessonReports(ctx, groupName, date, clock.NormalizeClock(timeStart), resolvedBy)
+}
+
+// rescrape запускает в фоне повторный парсинг таблицы источника sourceType
+// колледжа из контекста, если его не запускали по жалобам недавно
+func (s *Service) rescrape(ctx context.Context, sourceType string) {
+	collegeID := tenant.CollegeID(ctx)
+	key := collegeID.String() + ":" + sourceType
+
+	s.mu.Lock()
+	scraper, ok := s.scrapers[collegeID]
+	last, scraped := s.rescraped[key]
+	if !ok || (scraped && time.Since(last) < s.config.RescrapeCooldown) {
+		s.mu.Unlock()
+		return
+	}
+	s.rescraped[key] = time.Now()
+	s.mu.Unlock()
+
+	log.Printf("Жалобы на расписание набрали порог %d, повторно парсим источник %q", s.config.RescrapeThreshold, sourceType)
+	go func() {
+		// Запрос завершится раньше парсинга: контекст парсинга от него не зависит
+		ctx, cancel := context.WithTimeout(tenant.WithCollege(context.Background(), collegeID), rescrapeTimeout)
+		defer cancel()
+		if err := scraper.ScrapeSource(ctx, sourceType); err != nil {
+			log.Printf("Ошибка повторного парсинга по жалобам (источник %q): %v", sourceType, err)
+		}
+	}()
+}
@@ -0,0 +1,171 @@
+package feedback
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/Ultrahd-dev/student-schedule-app/backend/internal/schedule"
+	"github.com/Ultrahd-dev/student-schedule-app/backend/internal/tenant"
+	"github.com/google/uuid"
+)
+
+// fakeStore считает жалобы на пару по пользователям
+type fakeStore struct {
+	users map[string]map[uuid.UUID]bool
+	saved []*Report
+}
+
+func (f *fakeStore) SaveReport(ctx context.Context, report *Report) (int, error) {
+	key := report.GroupName + " " + report.Date.Format("2006-01-02") + " " + report.TimeStart
+	if f.users[key] == nil {
+		f.users[key] = make(map[uuid.UUID]bool)
+	}
+	f.users[key][report.UserID] = true
+	f.saved = append(f.saved, report)
+	return len(f.users[key]), nil
+}
+
+func (f *fakeStore) ListLessonReports(ctx context.Context, limit int) ([]LessonReports, error) {
+	return nil, nil
+}
+
+func (f *fakeStore) ResolveLessonReports(ctx context.Context, groupName string, date time.Time, timeStart string, resolvedBy uuid.UUID) (int64, error) {
+	return 0, nil
+}
+
+type fakeLessons []schedule.CurrentSchedule
+
+func (f fakeLessons) GetScheduleForGroup(ctx context.Context, groupName string, date time.Time) ([]schedule.CurrentSchedule, error) {
+	return f, nil
+}
+
+// fakeScraper передает источники повторного парсинга в канал
+type fakeScraper struct{ sources chan string }
+
+func (f *fakeScraper) ScrapeSource(ctx context.Context, sourceType string) error {
+	f.sources <- sourceType
+	return nil
+}
+
+func newTestService(threshold int) (*Service, *fakeStore, *fakeScraper) {
+	store := &fakeStore{users: make(map[string]map[uuid.UUID]bool)}
+	lessons := fakeLessons{
+		{ID: uuid.New(), GroupName: "ИС-21", TimeStart: "08:30:00", Subject: "Математика", SourceType: "snapshot"},
+		{ID: uuid.New(), GroupName: "ИС-21", TimeStart: "10:10:00", Subject: "Физика", SourceType: "change"},
+	}
+	s := NewService(Config{RescrapeThreshold: threshold}, store, lessons, time.UTC)
+	scraper := &fakeScraper{sources: make(chan string, 10)}
+	s.AddScraper(tenant.DefaultCollegeID, scraper)
+	return s, store, scraper
+}
+
+func TestReportValidation(t *testing.T) {
+	s, _, _ := newTestService(0)
+	date := time.Date(2026, 3, 2, 0, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name   string
+		report Report
+		want   error
+	}{
+		{"без группы", Report{Date: date, TimeStart: "8:30", Reason: ReasonWrongTime}, ErrInvalidReport},
+		{"без причины", Report{GroupName: "ИС-21", Date: date, TimeStart: "8:30"}, ErrInvalidReport},
+		{"другое без комментария", Report{GroupName: "ИС-21", Date: date, TimeStart: "8:30", Reason: ReasonOther}, ErrInvalidReport},
+		{"длинный комментарий", Report{GroupName: "ИС-21", Date: date, TimeStart: "8:30", Reason: ReasonOther,
+			Comment: strings.Repeat("я", maxCommentLength+1)}, ErrInvalidReport},
+		{"пары нет в расписании", Report{GroupName: "ИС-21", Date: date, TimeStart: "12:00", Reason: ReasonNotHeld}, ErrLessonNotFound},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			report := tt.report
+			if _, err := s.Report(context.Background(), &report); !errors.Is(err, tt.want) {
+				t.Errorf("ошибка %v, ожидалась %v", err, tt.want)
+			}
+		})
+	}
+}
+
+func TestReportFindsLesson(t *testing.T) {
+	s, store, _ := newTestService(0)
+
+	report := &Report{UserID: uuid.New(), GroupName: " ИС-21 ", Date: time.Date(2026, 3, 2, 15, 0, 0, 0, time.UTC),
+		TimeStart: "8:30", Reason: ReasonWrongClassroom}
+	reports, err := s.Report(context.Background(), report)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if reports != 1 || len(store.saved) != 1 {
+		t.Fatalf("жалоб %d, сохранено %d", reports, len(store.saved))
+	}
+	saved := store.saved[0]
+	if saved.GroupName != "ИС-21" || saved.TimeStart != "08:30" || saved.Subject != "Математика" ||
+		saved.SourceType != "snapshot" || saved.EntryID == nil || !saved.Date.Equal(time.Date(2026, 3, 2, 0, 0, 0, 0, time.UTC)) {
+		t.Errorf("сохранена жалоба %+v", saved)
+	}
+}
+
+func TestReportTeacherOwnLessonOnly(t *testing.T) {
+	s, store, _ := newTestService(0)
+	s.lessons = fakeLessons{
+		{ID: uuid.New(), GroupName: "ИС-21", TimeStart: "08:30:00", Subject: "Математика", Teacher: "Иванов И.И.", SourceType: "snapshot"},
+	}
+	date := time.Date(2026, 3, 2, 0, 0, 0, 0, time.UTC)
+
+	report := &Report{UserID: uuid.New(), GroupName: "ИС-21", Date: date, TimeStart: "8:30",
+		Reason: ReasonWrongTime, TeacherNames: []string{"Петров П.П."}}
+	if _, err := s.Report(context.Background(), report); !errors.Is(err, ErrNotOwnLesson) {
+		t.Errorf("жалоба на чужую пару: ошибка %v, ожидалась %v", err, ErrNotOwnLesson)
+	}
+
+	report.TeacherNames = []string{"Иванов Иван Иванович", "Иванов И.И."}
+	if _, err := s.Report(context.Background(), report); err != nil {
+		t.Fatalf("жалоба на свою пару: %v", err)
+	}
+	if len(store.saved) != 1 {
+		t.Errorf("сохранено жалоб %d, ожидалась 1", len(store.saved))
+	}
+}
+
+func TestReportRescrapesSourceAtThreshold(t *testing.T) {
+	s, _, scraper := newTestService(2)
+	date := time.Date(2026, 3, 2, 0, 0, 0, 0, time.UTC)
+
+	report := func(userID uuid.UUID) {
+		t.Helper()
+		_, err := s.Report(context.Background(), &Report{UserID: userID, GroupName: "ИС-21", Date: date,
+			TimeStart: "10:10", Reason: ReasonWrongTeacher})
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	first := uuid.New()
+	report(first)
+	report(first) // Повторная жалоба того же пользователя не увеличивает счетчик
+	select {
+	case source := <-scraper.sources:
+		t.Fatalf("парсинг %q до порога жалоб", source)
+	case <-time.After(50 * time.Millisecond):
+	}
+
+	report(uuid.New())
+	select {
+	case source := <-scraper.sources:
+		if source != "change" {
+			t.Errorf("повторно парсится источник %q, ожидался change", source)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("повторный парсинг не запущен")
+	}
+
+	// В пределах паузы жалобы не запускают парсинг той же таблицы еще раз
+	report(uuid.New())
+	select {
+	case source := <-scraper.sources:
+		t.Fatalf("повторный парсинг %q в пределах паузы", source)
+	case <-time.After(50 * time.Millisecond):
+	}
+}
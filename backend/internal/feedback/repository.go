@@ -0,0 +1,116 @@
+package feedback
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	"github.com/Ultrahd-dev/student-schedule-app/backend/internal/tenant"
+	"github.com/google/uuid"
+	"github.com/lib/pq"
+)
+
+// listedComments сколько последних комментариев к паре возвращает ListLessonReports
+const listedComments = 3
+
+// Repository предоставляет доступ к жалобам в базе данных
+type Repository struct {
+	db *sql.DB
+}
+
+// NewRepository создает новый репозиторий жалоб
+func NewRepository(db *sql.DB) *Repository {
+	return &Repository{db: db}
+}
+
+// SaveReport сохраняет жалобу в колледже из контекста, заменяя неразобранную жалобу
+// пользователя на ту же пару, и возвращает число неразобранных жалоб на пару
+func (r *Repository) SaveReport(ctx context.Context, report *Report) (int, error) {
+	collegeID := tenant.CollegeID(ctx)
+	query := `
+		INSERT INTO schedule_error_reports
+			(id, college_id, user_id, group_name, date, time_start, entry_id, source_type, subject, reason, comment)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11)
+		ON CONFLICT (college_id, user_id, group_name, date, time_start) WHERE resolved_at IS NULL
+		DO UPDATE SET entry_id = EXCLUDED.entry_id, source_type = EXCLUDED.source_type, subject = EXCLUDED.subject,
+		              reason = EXCLUDED.reason, comment = EXCLUDED.comment, created_at = NOW()
+		RETURNING id, created_at`
+
+	err := r.db.QueryRowContext(ctx, query, report.ID, collegeID, report.UserID, report.GroupName, report.Date,
+		report.TimeStart, report.EntryID, report.SourceType, report.Subject, report.Reason, report.Comment,
+	).Scan(&report.ID, &report.CreatedAt)
+	if err != nil {
+		return 0, fmt.Errorf("failed to save schedule error report: %w", err)
+	}
+
+	var reports int
+	err = r.db.QueryRowContext(ctx, `
+		SELECT COUNT(*) FROM schedule_error_reports
+		WHERE college_id = $1 AND group_name = $2 AND date = $3 AND time_start = $4 AND resolved_at IS NULL`,
+		collegeID, report.GroupName, report.Date, report.TimeStart).Scan(&reports)
+	if err != nil {
+		return 0, fmt.Errorf("failed to count schedule error reports: %w", err)
+	}
+	return reports, nil
+}
+
+// ListLessonReports возвращает пары колледжа из контекста с неразобранными жалобами,
+// упорядоченные по числу жалоб и времени последней жалобы
+func (r *Repository) ListLessonReports(ctx context.Context, limit int) ([]LessonReports, error) {
+	query := `
+		SELECT group_name, date, time_start, COUNT(*), MIN(created_at), MAX(created_at),
+		       (array_agg(subject ORDER BY created_at DESC))[1],
+		       (array_agg(source_type ORDER BY created_at DESC))[1],
+		       array_agg(reason),
+		       COALESCE(array_agg(comment ORDER BY created_at DESC) FILTER (WHERE comment <> ''), '{}')
+		FROM schedule_error_reports
+		WHERE college_id = $1 AND resolved_at IS NULL
+		GROUP BY group_name, date, time_start
+		ORDER BY COUNT(*) DESC, MAX(created_at) DESC
+		LIMIT $2`
+
+	rows, err := r.db.QueryContext(ctx, query, tenant.CollegeID(ctx), limit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get schedule error reports: %w", err)
+	}
+	defer rows.Close()
+
+	var lessons []LessonReports
+	for rows.Next() {
+		var lesson LessonReports
+		var reasonList, comments []string
+		err := rows.Scan(&lesson.GroupName, &lesson.Date, &lesson.TimeStart, &lesson.Reports,
+			&lesson.FirstReportAt, &lesson.LastReportAt, &lesson.Subject, &lesson.SourceType,
+			pq.Array(&reasonList), pq.Array(&comments))
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan schedule error reports: %w", err)
+		}
+		lesson.Reasons = make(map[string]int, len(reasonList))
+		for _, reason := range reasonList {
+			lesson.Reasons[reason]++
+		}
+		if len(comments) > listedComments {
+			comments = comments[:listedComments]
+		}
+		lesson.Comments = comments
+		lessons = append(lessons, lesson)
+	}
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating rows: %w", err)
+	}
+	return lessons, nil
+}
+
+// ResolveLessonReports отмечает неразобранные жалобы на пару в колледже из контекста
+// разобранными и возвращает их количество
+func (r *Repository) ResolveLessonReports(ctx context.Context, groupName string, date time.Time, timeStart string, resolvedBy uuid.UUID) (int64, error) {
+	result, err := r.db.ExecContext(ctx, `
+		UPDATE schedule_error_reports SET resolved_at = NOW(), resolved_by = $5
+		WHERE college_id = $1 AND group_name = $2 AND date = $3 AND time_start = $4 AND resolved_at IS NULL`,
+		tenant.CollegeID(ctx), groupName, date, timeStart, resolvedBy)
+	if err != nil {
+		return 0, fmt.Errorf("failed to resolve schedule error reports: %w", err)
+	}
+	return result.RowsAffected()
+}
@@ -0,0 +1,28 @@
+// Package files реализует хранение файлов пользователей: аватаров и вложений
+package files
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Kind назначение файла
+type Kind string
+
+const (
+	KindAvatar     Kind = "avatar"     // Аватар пользователя
+	KindAttachment Kind = "attachment" // Вложение (например, файл домашнего задания)
+)
+
+// File метаданные файла; содержимое хранится в storage по ключу StorageKey
+type File struct {
+	ID          uuid.UUID `db:"id"`
+	OwnerID     uuid.UUID `db:"owner_id"` // Пользователь, загрузивший файл
+	Kind        Kind      `db:"kind"`
+	StorageKey  string    `db:"storage_key"`
+	Name        string    `db:"name"` // Исходное имя файла
+	ContentType string    `db:"content_type"`
+	Size        int64     `db:"size"`
+	CreatedAt   time.Time `db:"created_at"`
+}
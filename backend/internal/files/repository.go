@@ -0,0 +1,94 @@
+package files
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+
+	"github.com/google/uuid"
+)
+
+// Repository предоставляет доступ к хранению метаданных файлов
+type Repository struct {
+	db *sql.DB
+}
+
+// NewRepository создает новый репозиторий файлов
+func NewRepository(db *sql.DB) *Repository {
+	return &Repository{db: db}
+}
+
+// fileColumns список колонок файла в порядке сканирования
+const fileColumns = `id, owner_id, kind, storage_key, name, content_type, size, created_at`
+
+// CreateFile сохраняет метаданные файла
+func (r *Repository) CreateFile(ctx context.Context, file *File) error {
+	query := `
+		INSERT INTO files (id, owner_id, kind, storage_key, name, content_type, size)
+		VALUES ($1, $2, $3, $4, $5, $6, $7)
+		RETURNING created_at`
+
+	err := r.db.QueryRowContext(ctx, query,
+		file.ID, file.OwnerID, file.Kind, file.StorageKey, file.Name, file.ContentType, file.Size).
+		Scan(&file.CreatedAt)
+	if err != nil {
+		return fmt.Errorf("failed to create file: %w", err)
+	}
+
+	return nil
+}
+
+// GetFileByID получает метаданные файла по ID
+func (r *Repository) GetFileByID(ctx context.Context, id uuid.UUID) (*File, error) {
+	query := `SELECT ` + fileColumns + ` FROM files WHERE id = $1`
+
+	file := &File{}
+	err := r.db.QueryRowContext(ctx, query, id).Scan(
+		&file.ID,
+		&file.OwnerID,
+		&file.Kind,
+		&file.StorageKey,
+		&file.Name,
+		&file.ContentType,
+		&file.Size,
+		&file.CreatedAt,
+	)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, fmt.Errorf("file %s not found: %w", id, err)
+		}
+		return nil, fmt.Errorf("failed to get file: %w", err)
+	}
+
+	return file, nil
+}
+
+// DeleteFile удаляет метаданные файла
+func (r *Repository) DeleteFile(ctx context.Context, id uuid.UUID) error {
+	if _, err := r.db.ExecContext(ctx, `DELETE FROM files WHERE id = $1`, id); err != nil {
+		return fmt.Errorf("failed to delete file: %w", err)
+	}
+	return nil
+}
+
+// GetAvatarFileID получает ID текущего аватара пользователя (nil, если аватара нет)
+func (r *Repository) GetAvatarFileID(ctx context.Context, userID uuid.UUID) (*uuid.UUID, error) {
+	var fileID *uuid.UUID
+	err := r.db.QueryRowContext(ctx, `SELECT avatar_file_id FROM users WHERE id = $1`, userID).Scan(&fileID)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, fmt.Errorf("user %s not found: %w", userID, err)
+		}
+		return nil, fmt.Errorf("failed to get user avatar: %w", err)
+	}
+
+	return fileID, nil
+}
+
+// SetAvatarFileID устанавливает аватар пользователя
+func (r *Repository) SetAvatarFileID(ctx context.Context, userID, fileID uuid.UUID) error {
+	if _, err := r.db.ExecContext(ctx, `UPDATE users SET avatar_file_id = $2 WHERE id = $1`, userID, fileID); err != nil {
+		return fmt.Errorf("failed to set user avatar: %w", err)
+	}
+	return nil
+}
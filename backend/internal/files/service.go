@@ -0,0 +1,296 @@
+package files
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/Ultrahd-dev/student-schedule-app/backend/internal/storage"
+	"github.com/google/uuid"
+)
+
+// Ошибки работы с файлами
+var (
+	ErrFileTooLarge    = errors.New("файл слишком большой")
+	ErrUnsupportedType = errors.New("неподдерживаемый тип файла")
+	ErrAccessDenied    = errors.New("нет доступа к файлу")
+	ErrEmptyFile       = errors.New("пустой файл")
+)
+
+// allowedTypes допустимые типы содержимого по назначению файла
+var allowedTypes = map[Kind][]string{
+	KindAvatar: {"image/jpeg", "image/png", "image/webp"},
+	KindAttachment: {
+		"image/jpeg", "image/png", "image/webp", "application/pdf", "text/plain",
+		"application/zip",
+		"application/vnd.openxmlformats-officedocument.wordprocessingml.document",
+		"application/vnd.openxmlformats-officedocument.spreadsheetml.sheet",
+		"application/vnd.openxmlformats-officedocument.presentationml.presentation",
+	},
+}
+
+// officeTypes типы документов Office по расширению: по содержимому они неотличимы от zip
+var officeTypes = map[string]string{
+	".docx": "application/vnd.openxmlformats-officedocument.wordprocessingml.document",
+	".xlsx": "application/vnd.openxmlformats-officedocument.spreadsheetml.sheet",
+	".pptx": "application/vnd.openxmlformats-officedocument.presentationml.presentation",
+}
+
+// typeExtensions расширение ключа объекта по типу содержимого
+var typeExtensions = map[string]string{
+	"image/jpeg":      ".jpg",
+	"image/png":       ".png",
+	"image/webp":      ".webp",
+	"application/pdf": ".pdf",
+	"text/plain":      ".txt",
+	"application/zip": ".zip",
+}
+
+// Config настройки сервиса файлов
+type Config struct {
+	MaxAvatarSize     int64         // Максимальный размер аватара в байтах
+	MaxAttachmentSize int64         // Максимальный размер вложения в байтах
+	URLTTL            time.Duration // Время действия подписанной ссылки
+}
+
+// Service загружает и выдает файлы пользователей
+type Service struct {
+	config  Config
+	repo    *Repository
+	storage storage.Storage
+}
+
+// NewService создает новый сервис файлов
+func NewService(config Config, repo *Repository, store storage.Storage) *Service {
+	if config.MaxAvatarSize <= 0 {
+		config.MaxAvatarSize = 2 << 20
+	}
+	if config.MaxAttachmentSize <= 0 {
+		config.MaxAttachmentSize = 20 << 20
+	}
+	if config.URLTTL <= 0 {
+		config.URLTTL = 15 * time.Minute
+	}
+
+	return &Service{
+		config:  config,
+		repo:    repo,
+		storage: store,
+	}
+}
+
+// Upload проверяет размер и тип содержимого файла и сохраняет его.
+// Тип определяется по содержимому, а не по заявленному клиентом.
+// Загруженный аватар сразу становится аватаром пользователя, предыдущий удаляется.
+func (s *Service) Upload(ctx context.Context, ownerID uuid.UUID, kind Kind, name string, r io.Reader) (*File, error) {
+	limit, err := s.maxSize(kind)
+	if err != nil {
+		return nil, err
+	}
+
+	data, err := io.ReadAll(io.LimitReader(r, limit+1))
+	if err != nil {
+		return nil, fmt.Errorf("ошибка чтения файла: %w", err)
+	}
+	if len(data) == 0 {
+		return nil, ErrEmptyFile
+	}
+	if int64(len(data)) > limit {
+		return nil, fmt.Errorf("%w: максимум %d КБ", ErrFileTooLarge, limit>>10)
+	}
+
+	name = filepath.Base(strings.TrimSpace(name))
+	contentType := detectContentType(name, data)
+	if !isAllowed(kind, contentType) {
+		return nil, fmt.Errorf("%w: %s", ErrUnsupportedType, contentType)
+	}
+
+	file := &File{
+		ID:          uuid.New(),
+		OwnerID:     ownerID,
+		Kind:        kind,
+		Name:        name,
+		ContentType: contentType,
+		Size:        int64(len(data)),
+	}
+	file.StorageKey = fmt.Sprintf("%ss/%s/%s%s", kind, ownerID, file.ID, extension(name, contentType))
+
+	if err := s.storage.Put(ctx, file.StorageKey, bytes.NewReader(data)); err != nil {
+		return nil, fmt.Errorf("ошибка сохранения файла в хранилище: %w", err)
+	}
+	if err := s.repo.CreateFile(ctx, file); err != nil {
+		if delErr := s.storage.Delete(ctx, file.StorageKey); delErr != nil {
+			log.Printf("Ошибка удаления файла %s из хранилища: %v", file.StorageKey, delErr)
+		}
+		return nil, fmt.Errorf("ошибка сохранения файла: %w", err)
+	}
+
+	if kind == KindAvatar {
+		if err := s.replaceAvatar(ctx, ownerID, file.ID); err != nil {
+			return nil, err
+		}
+	}
+
+	log.Printf("Пользователь %s загрузил файл %s (%s, %s, %d байт)", ownerID, file.ID, kind, contentType, file.Size)
+	return file, nil
+}
+
+// replaceAvatar устанавливает новый аватар пользователя и удаляет предыдущий
+func (s *Service) replaceAvatar(ctx context.Context, userID, fileID uuid.UUID) error {
+	previous, err := s.repo.GetAvatarFileID(ctx, userID)
+	if err != nil {
+		return fmt.Errorf("ошибка получения аватара: %w", err)
+	}
+	if err := s.repo.SetAvatarFileID(ctx, userID, fileID); err != nil {
+		return fmt.Errorf("ошибка установки аватара: %w", err)
+	}
+
+	if previous != nil {
+		if err := s.removeByID(ctx, *previous); err != nil {
+			log.Printf("Ошибка удаления предыдущего аватара %s: %v", *previous, err)
+		}
+	}
+	return nil
+}
+
+// Open открывает файл на чтение, проверяя доступ пользователя viewerID
+func (s *Service) Open(ctx context.Context, fileID, viewerID uuid.UUID, admin bool) (*File, io.ReadCloser, error) {
+	file, err := s.get(ctx, fileID, viewerID, admin)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	content, err := s.storage.Open(ctx, file.StorageKey)
+	if err != nil {
+		return nil, nil, fmt.Errorf("ошибка чтения файла из хранилища: %w", err)
+	}
+	return file, content, nil
+}
+
+// URL возвращает подписанную ссылку на файл и время ее истечения
+func (s *Service) URL(ctx context.Context, fileID, viewerID uuid.UUID, admin bool) (*File, string, time.Time, error) {
+	file, err := s.get(ctx, fileID, viewerID, admin)
+	if err != nil {
+		return nil, "", time.Time{}, err
+	}
+
+	expiresAt := time.Now().Add(s.config.URLTTL)
+	url, err := s.storage.SignedURL(file.StorageKey, s.config.URLTTL)
+	if err != nil {
+		return nil, "", time.Time{}, fmt.Errorf("ошибка формирования ссылки: %w", err)
+	}
+	return file, url, expiresAt, nil
+}
+
+// AvatarURL возвращает подписанную ссылку на аватар пользователя
+// (пустую строку, если аватар не загружен) и время ее истечения
+func (s *Service) AvatarURL(ctx context.Context, userID uuid.UUID) (string, time.Time, error) {
+	fileID, err := s.repo.GetAvatarFileID(ctx, userID)
+	if err != nil {
+		return "", time.Time{}, fmt.Errorf("ошибка получения аватара: %w", err)
+	}
+	if fileID == nil {
+		return "", time.Time{}, nil
+	}
+
+	_, url, expiresAt, err := s.URL(ctx, *fileID, userID, false)
+	return url, expiresAt, err
+}
+
+// Delete удаляет файл; удалять может владелец или администратор
+func (s *Service) Delete(ctx context.Context, fileID, viewerID uuid.UUID, admin bool) error {
+	file, err := s.repo.GetFileByID(ctx, fileID)
+	if err != nil {
+		return fmt.Errorf("ошибка получения файла: %w", err)
+	}
+	if file.OwnerID != viewerID && !admin {
+		return ErrAccessDenied
+	}
+	return s.remove(ctx, file)
+}
+
+// removeByID удаляет файл по ID
+func (s *Service) removeByID(ctx context.Context, fileID uuid.UUID) error {
+	file, err := s.repo.GetFileByID(ctx, fileID)
+	if err != nil {
+		return fmt.Errorf("ошибка получения файла: %w", err)
+	}
+	return s.remove(ctx, file)
+}
+
+// remove удаляет метаданные и содержимое файла
+func (s *Service) remove(ctx context.Context, file *File) error {
+	if err := s.repo.DeleteFile(ctx, file.ID); err != nil {
+		return fmt.Errorf("ошибка удаления файла: %w", err)
+	}
+	if err := s.storage.Delete(ctx, file.StorageKey); err != nil {
+		return fmt.Errorf("ошибка удаления файла из хранилища: %w", err)
+	}
+	return nil
+}
+
+// get получает метаданные файла с проверкой доступа:
+// аватары видны всем пользователям, вложения - владельцу и администраторам
+func (s *Service) get(ctx context.Context, fileID, viewerID uuid.UUID, admin bool) (*File, error) {
+	file, err := s.repo.GetFileByID(ctx, fileID)
+	if err != nil {
+		return nil, fmt.Errorf("ошибка получения файла: %w", err)
+	}
+	if file.Kind != KindAvatar && file.OwnerID != viewerID && !admin {
+		return nil, ErrAccessDenied
+	}
+	return file, nil
+}
+
+// maxSize возвращает максимальный размер файла для назначения kind
+func (s *Service) maxSize(kind Kind) (int64, error) {
+	switch kind {
+	case KindAvatar:
+		return s.config.MaxAvatarSize, nil
+	case KindAttachment:
+		return s.config.MaxAttachmentSize, nil
+	default:
+		return 0, fmt.Errorf("%w: неизвестное назначение файла %q", ErrUnsupportedType, kind)
+	}
+}
+
+// detectContentType определяет тип содержимого по первым байтам файла.
+// Документы Office (zip-контейнеры) уточняются по расширению имени.
+func detectContentType(name string, data []byte) string {
+	contentType := http.DetectContentType(data)
+	if i := strings.Index(contentType, ";"); i >= 0 {
+		contentType = contentType[:i]
+	}
+
+	if contentType == "application/zip" {
+		if officeType, ok := officeTypes[strings.ToLower(filepath.Ext(name))]; ok {
+			return officeType
+		}
+	}
+	return contentType
+}
+
+// isAllowed проверяет, что тип содержимого допустим для назначения kind
+func isAllowed(kind Kind, contentType string) bool {
+	for _, allowed := range allowedTypes[kind] {
+		if contentType == allowed {
+			return true
+		}
+	}
+	return false
+}
+
+// extension возвращает расширение ключа объекта для типа содержимого
+func extension(name, contentType string) string {
+	if ext, ok := typeExtensions[contentType]; ok {
+		return ext
+	}
+	return strings.ToLower(filepath.Ext(name))
+}
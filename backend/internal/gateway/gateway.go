@@ -0,0 +1,251 @@
+// Package gateway реализует REST-фасад gRPC API: унарные методы сервисов доступны
+// как POST /api/v1/<сервис>/<метод> с телом в JSON, а описание фасада в формате
+// OpenAPI v3 строится из дескрипторов proto, поэтому всегда совпадает с API.
+package gateway
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"net"
+	"net/http"
+
+	"github.com/Ultrahd-dev/student-schedule-app/backend/internal/requestid"
+	"github.com/Ultrahd-dev/student-schedule-app/backend/internal/tenant"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/types/dynamicpb"
+)
+
+// PathPrefix префикс путей методов фасада
+const PathPrefix = "/api/v1/"
+
+// Config настройки REST-фасада
+type Config struct {
+	GRPCAddr        string // Адрес gRPC сервера, которому передаются запросы
+	MaxRequestSize  int    // Максимальный размер тела запроса, байт
+	MaxResponseSize int    // Максимальный размер ответа gRPC сервера, байт
+}
+
+// Gateway REST-фасад gRPC сервисов
+type Gateway struct {
+	conn    *grpc.ClientConn
+	methods []protoreflect.MethodDescriptor
+	spec    []byte
+	config  Config
+}
+
+var (
+	// Имена полей в JSON совпадают с proto, значения по умолчанию не опускаются
+	marshalOptions   = protojson.MarshalOptions{UseProtoNames: true, EmitUnpopulated: true}
+	unmarshalOptions = protojson.UnmarshalOptions{DiscardUnknown: true}
+)
+
+// New создает фасад для унарных методов всех сервисов из файлов files.
+// Потоковые методы (загрузка и скачивание файлов) доступны только по gRPC.
+func New(config Config, files ...protoreflect.FileDescriptor) (*Gateway, error) {
+	if config.MaxRequestSize <= 0 {
+		config.MaxRequestSize = 4 << 20
+	}
+
+	var methods []protoreflect.MethodDescriptor
+	for _, file := range files {
+		services := file.Services()
+		for i := 0; i < services.Len(); i++ {
+			serviceMethods := services.Get(i).Methods()
+			for j := 0; j < serviceMethods.Len(); j++ {
+				method := serviceMethods.Get(j)
+				if method.IsStreamingClient() || method.IsStreamingServer() {
+					continue
+				}
+				methods = append(methods, method)
+			}
+		}
+	}
+
+	spec, err := buildSpec(methods)
+	if err != nil {
+		return nil, fmt.Errorf("ошибка построения описания OpenAPI: %w", err)
+	}
+
+	options := []grpc.DialOption{grpc.WithTransportCredentials(insecure.NewCredentials())}
+	if config.MaxResponseSize > 0 {
+		options = append(options, grpc.WithDefaultCallOptions(grpc.MaxCallRecvMsgSize(config.MaxResponseSize)))
+	}
+	conn, err := grpc.NewClient(config.GRPCAddr, options...)
+	if err != nil {
+		return nil, fmt.Errorf("ошибка подключения к gRPC серверу %s: %w", config.GRPCAddr, err)
+	}
+
+	return &Gateway{conn: conn, methods: methods, spec: spec, config: config}, nil
+}
+
+// Handler возвращает HTTP обработчик фасада: методы API, /openapi.json и Swagger UI на /docs
+func (g *Gateway) Handler() http.Handler {
+	mux := http.NewServeMux()
+	for _, method := range g.methods {
+		mux.HandleFunc("POST "+methodPath(method), g.handleMethod(method))
+	}
+	mux.HandleFunc("GET /openapi.json", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.Write(g.spec)
+	})
+	mux.HandleFunc("GET /docs", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/html; charset=utf-8")
+		io.WriteString(w, swaggerUI)
+	})
+	return mux
+}
+
+// Close закрывает соединение с gRPC сервером
+func (g *Gateway) Close() error {
+	return g.conn.Close()
+}
+
+// methodPath возвращает путь метода фасада: /api/v1/schedule.ScheduleService/GetSchedule
+func methodPath(method protoreflect.MethodDescriptor) string {
+	return PathPrefix + string(method.Parent().FullName()) + "/" + string(method.Name())
+}
+
+// handleMethod возвращает обработчик, вызывающий метод gRPC с запросом из тела HTTP запроса
+func (g *Gateway) handleMethod(method protoreflect.MethodDescriptor) http.HandlerFunc {
+	fullMethod := "/" + string(method.Parent().FullName()) + "/" + string(method.Name())
+
+	return func(w http.ResponseWriter, r *http.Request) {
+		body, err := io.ReadAll(http.MaxBytesReader(w, r.Body, int64(g.config.MaxRequestSize)))
+		if err != nil {
+			var tooLarge *http.MaxBytesError
+			if errors.As(err, &tooLarge) {
+				writeError(w, http.StatusRequestEntityTooLarge, codes.ResourceExhausted, "слишком большой запрос")
+				return
+			}
+			writeError(w, http.StatusBadRequest, codes.InvalidArgument, "ошибка чтения запроса")
+			return
+		}
+
+		req := dynamicpb.NewMessage(method.Input())
+		if len(bytes.TrimSpace(body)) > 0 {
+			if err := unmarshalOptions.Unmarshal(body, req); err != nil {
+				writeError(w, http.StatusBadRequest, codes.InvalidArgument, fmt.Sprintf("некорректный JSON запроса: %v", err))
+				return
+			}
+		}
+
+		resp := dynamicpb.NewMessage(method.Output())
+		var header metadata.MD
+		err = g.conn.Invoke(outgoingContext(r), fullMethod, req, resp, grpc.Header(&header))
+		if values := header.Get(requestid.MetadataKey); len(values) > 0 {
+			w.Header().Set("X-Request-ID", values[0])
+		}
+		if err != nil {
+			st := status.Convert(err)
+			writeError(w, httpStatus(st.Code()), st.Code(), st.Message())
+			return
+		}
+		// Метод может разрешить клиенту кэшировать ответ (например, сводка виджета)
+		if values := header.Get("cache-control"); len(values) > 0 {
+			w.Header().Set("Cache-Control", values[0])
+		}
+
+		data, err := marshalOptions.Marshal(resp)
+		if err != nil {
+			log.Printf("Ошибка сериализации ответа %s: %v", fullMethod, err)
+			writeError(w, http.StatusInternalServerError, codes.Internal, "ошибка сериализации ответа")
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.Write(data)
+	}
+}
+
+// outgoingContext передает в метаданные gRPC заголовки, которые учитывает сервер:
+// колледж, идентификатор запроса и адрес клиента для журнала аудита
+func outgoingContext(r *http.Request) context.Context {
+	md := metadata.MD{}
+	if college := r.Header.Get("X-College"); college != "" {
+		md.Set(tenant.MetadataKey, college)
+	}
+	if id := r.Header.Get("X-Request-ID"); id != "" {
+		md.Set(requestid.MetadataKey, id)
+	}
+	forwarded := r.Header.Get("X-Forwarded-For")
+	if host, _, err := net.SplitHostPort(r.RemoteAddr); err == nil {
+		if forwarded != "" {
+			forwarded += ", "
+		}
+		forwarded += host
+	}
+	if forwarded != "" {
+		md.Set("x-forwarded-for", forwarded)
+	}
+	return metadata.NewOutgoingContext(r.Context(), md)
+}
+
+// errorBody тело ответа с ошибкой (схема Error в описании OpenAPI)
+type errorBody struct {
+	Code    codes.Code `json:"code"`
+	Message string     `json:"message"`
+}
+
+// writeError записывает ответ с ошибкой
+func writeError(w http.ResponseWriter, httpCode int, code codes.Code, message string) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(httpCode)
+	json.NewEncoder(w).Encode(errorBody{Code: code, Message: message})
+}
+
+// httpStatus возвращает HTTP статус для кода gRPC
+func httpStatus(code codes.Code) int {
+	switch code {
+	case codes.OK:
+		return http.StatusOK
+	case codes.InvalidArgument, codes.FailedPrecondition, codes.OutOfRange:
+		return http.StatusBadRequest
+	case codes.Unauthenticated:
+		return http.StatusUnauthorized
+	case codes.PermissionDenied:
+		return http.StatusForbidden
+	case codes.NotFound:
+		return http.StatusNotFound
+	case codes.AlreadyExists, codes.Aborted:
+		return http.StatusConflict
+	case codes.ResourceExhausted:
+		return http.StatusTooManyRequests
+	case codes.Canceled:
+		return 499 // Клиент закрыл соединение
+	case codes.Unimplemented:
+		return http.StatusNotImplemented
+	case codes.Unavailable:
+		return http.StatusServiceUnavailable
+	case codes.DeadlineExceeded:
+		return http.StatusGatewayTimeout
+	}
+	return http.StatusInternalServerError
+}
+
+// swaggerUI страница Swagger UI с описанием фасада
+const swaggerUI = `<!DOCTYPE html>
+<html lang="ru">
+<head>
+  <meta charset="utf-8">
+  <title>Student Schedule API</title>
+  <link rel="stylesheet" href="https://unpkg.com/swagger-ui-dist@5/swagger-ui.css">
+</head>
+<body>
+  <div id="swagger-ui"></div>
+  <script src="https://unpkg.com/swagger-ui-dist@5/swagger-ui-bundle.js"></script>
+  <script>
+    window.ui = SwaggerUIBundle({url: "/openapi.json", dom_id: "#swagger-ui"});
+  </script>
+</body>
+</html>
+`
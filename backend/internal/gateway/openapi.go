@@ -0,0 +1,145 @@
+package gateway
+
+import (
+	"encoding/json"
+
+	"google.golang.org/protobuf/reflect/protoreflect"
+)
+
+// specDescription описание фасада в документе OpenAPI
+const specDescription = `REST-фасад gRPC API. Каждый унарный метод доступен как POST /api/v1/<сервис>/<метод>
+с телом запроса в JSON (имена полей как в proto, 64-битные числа - строками).
+Токен передается в поле token запроса, колледж для запросов без токена - заголовком X-College.
+Ошибки возвращаются с HTTP статусом, соответствующим коду gRPC, и телом Error.`
+
+// buildSpec строит документ OpenAPI v3 для методов фасада
+func buildSpec(methods []protoreflect.MethodDescriptor) ([]byte, error) {
+	schemas := map[string]any{
+		"Error": map[string]any{
+			"type": "object",
+			"properties": map[string]any{
+				"code":    map[string]any{"type": "integer", "description": "Код статуса gRPC"},
+				"message": map[string]any{"type": "string"},
+			},
+		},
+	}
+	paths := map[string]any{}
+
+	for _, method := range methods {
+		service := method.Parent().(protoreflect.ServiceDescriptor)
+		paths[methodPath(method)] = map[string]any{
+			"post": map[string]any{
+				"operationId": string(service.Name()) + "_" + string(method.Name()),
+				"tags":        []string{string(service.FullName())},
+				"requestBody": map[string]any{
+					"required": true,
+					"content":  jsonContent(messageSchema(schemas, method.Input())),
+				},
+				"responses": map[string]any{
+					"200": map[string]any{
+						"description": "Успешный ответ",
+						"content":     jsonContent(messageSchema(schemas, method.Output())),
+					},
+					"default": map[string]any{
+						"description": "Ошибка",
+						"content":     jsonContent(schemaRef("Error")),
+					},
+				},
+			},
+		}
+	}
+
+	doc := map[string]any{
+		"openapi": "3.0.3",
+		"info": map[string]any{
+			"title":       "Student Schedule API",
+			"version":     "v1",
+			"description": specDescription,
+		},
+		"paths":      paths,
+		"components": map[string]any{"schemas": schemas},
+	}
+	return json.MarshalIndent(doc, "", "  ")
+}
+
+// jsonContent описание тела в JSON со схемой schema
+func jsonContent(schema map[string]any) map[string]any {
+	return map[string]any{"application/json": map[string]any{"schema": schema}}
+}
+
+// schemaRef ссылка на схему из components
+func schemaRef(name string) map[string]any {
+	return map[string]any{"$ref": "#/components/schemas/" + name}
+}
+
+// messageSchema возвращает схему сообщения: известные типы описываются на месте,
+// остальные сообщения добавляются в schemas и возвращаются ссылкой
+func messageSchema(schemas map[string]any, message protoreflect.MessageDescriptor) map[string]any {
+	switch message.FullName() {
+	case "google.protobuf.Timestamp":
+		return map[string]any{"type": "string", "format": "date-time"}
+	case "google.protobuf.Duration":
+		return map[string]any{"type": "string", "example": "1.5s"}
+	}
+
+	name := string(message.FullName())
+	if _, ok := schemas[name]; ok {
+		return schemaRef(name)
+	}
+	// Заглушка до заполнения свойств - для рекурсивных сообщений
+	schemas[name] = map[string]any{}
+
+	properties := map[string]any{}
+	fields := message.Fields()
+	for i := 0; i < fields.Len(); i++ {
+		field := fields.Get(i)
+		properties[string(field.Name())] = fieldSchema(schemas, field)
+	}
+	schemas[name] = map[string]any{"type": "object", "properties": properties}
+	return schemaRef(name)
+}
+
+// fieldSchema возвращает схему поля с учетом repeated и map
+func fieldSchema(schemas map[string]any, field protoreflect.FieldDescriptor) map[string]any {
+	if field.IsMap() {
+		return map[string]any{"type": "object", "additionalProperties": valueSchema(schemas, field.MapValue())}
+	}
+	schema := valueSchema(schemas, field)
+	if field.IsList() {
+		return map[string]any{"type": "array", "items": schema}
+	}
+	return schema
+}
+
+// valueSchema возвращает схему одного значения поля по правилам protojson
+func valueSchema(schemas map[string]any, field protoreflect.FieldDescriptor) map[string]any {
+	switch field.Kind() {
+	case protoreflect.BoolKind:
+		return map[string]any{"type": "boolean"}
+	case protoreflect.Int32Kind, protoreflect.Sint32Kind, protoreflect.Sfixed32Kind:
+		return map[string]any{"type": "integer", "format": "int32"}
+	case protoreflect.Uint32Kind, protoreflect.Fixed32Kind:
+		return map[string]any{"type": "integer", "format": "int64", "minimum": 0}
+	case protoreflect.Int64Kind, protoreflect.Sint64Kind, protoreflect.Sfixed64Kind,
+		protoreflect.Uint64Kind, protoreflect.Fixed64Kind:
+		return map[string]any{"type": "string", "format": "int64"}
+	case protoreflect.FloatKind:
+		return map[string]any{"type": "number", "format": "float"}
+	case protoreflect.DoubleKind:
+		return map[string]any{"type": "number", "format": "double"}
+	case protoreflect.StringKind:
+		return map[string]any{"type": "string"}
+	case protoreflect.BytesKind:
+		return map[string]any{"type": "string", "format": "byte"}
+	case protoreflect.EnumKind:
+		values := field.Enum().Values()
+		names := make([]string, values.Len())
+		for i := range names {
+			names[i] = string(values.Get(i).Name())
+		}
+		return map[string]any{"type": "string", "enum": names}
+	case protoreflect.MessageKind, protoreflect.GroupKind:
+		return messageSchema(schemas, field.Message())
+	}
+	return map[string]any{}
+}
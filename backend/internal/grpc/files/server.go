@@ -0,0 +1,320 @@
+// Package files реализует gRPC сервер для работы с файлами пользователей
+package files
+
+import (
+	"context"
+	"errors"
+	"io"
+	"log"
+
+	"github.com/Ultrahd-dev/student-schedule-app/backend/internal/auth"
+	"github.com/Ultrahd-dev/student-schedule-app/backend/internal/files"
+	"github.com/Ultrahd-dev/student-schedule-app/backend/internal/jwt"
+	"github.com/Ultrahd-dev/student-schedule-app/backend/internal/users"
+	pb "github.com/Ultrahd-dev/student-schedule-app/backend/proto/gen/files"
+	"github.com/google/uuid"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// chunkSize размер части файла в потоке скачивания
+const chunkSize = 64 << 10
+
+// Server реализует gRPC сервис файлов
+type Server struct {
+	pb.UnimplementedFileServiceServer
+	fileService *files.Service
+	jwtManager  *jwt.Manager
+	userService *users.Service
+}
+
+// Dependencies сервисы, используемые gRPC сервером файлов
+type Dependencies struct {
+	FileService *files.Service
+	JWTManager  *jwt.Manager
+	UserService *users.Service
+}
+
+// NewServer создает новый gRPC сервер файлов
+func NewServer(deps Dependencies) *Server {
+	return &Server{
+		fileService: deps.FileService,
+		jwtManager:  deps.JWTManager,
+		userService: deps.UserService,
+	}
+}
+
+// UploadFile принимает файл потоком: сведения о файле, затем части содержимого
+func (s *Server) UploadFile(stream pb.FileService_UploadFileServer) error {
+	ctx := stream.Context()
+
+	first, err := stream.Recv()
+	if err != nil {
+		return status.Errorf(codes.InvalidArgument, "Ожидались сведения о файле")
+	}
+	info := first.GetInfo()
+	if info == nil {
+		return status.Errorf(codes.InvalidArgument, "Первое сообщение должно содержать сведения о файле")
+	}
+
+	user, err := s.authenticate(ctx, info.Token)
+	if err != nil {
+		return err
+	}
+
+	kind, err := fromPBFileKind(info.Kind)
+	if err != nil {
+		return err
+	}
+
+	log.Printf("Получен запрос на загрузку файла %q (%s) от %s", info.Name, kind, user.Email)
+
+	file, err := s.fileService.Upload(ctx, user.ID, kind, info.Name, &chunkReader{stream: stream})
+	if err != nil {
+		return fileError("Ошибка загрузки файла", err)
+	}
+
+	response := &pb.UploadFileResponse{
+		Success: true,
+		Message: "Файл загружен",
+		File:    toPBFile(file),
+	}
+	if _, url, expiresAt, err := s.fileService.URL(ctx, file.ID, user.ID, false); err == nil {
+		response.Url = url
+		response.UrlExpiresAt = timestamppb.New(expiresAt)
+	} else {
+		log.Printf("Ошибка формирования ссылки на файл %s: %v", file.ID, err)
+	}
+
+	return stream.SendAndClose(response)
+}
+
+// DownloadFile отдает файл потоком частей; первое сообщение содержит метаданные
+func (s *Server) DownloadFile(req *pb.DownloadFileRequest, stream pb.FileService_DownloadFileServer) error {
+	ctx := stream.Context()
+
+	user, err := s.authenticate(ctx, req.Token)
+	if err != nil {
+		return err
+	}
+
+	fileID, err := uuid.Parse(req.FileId)
+	if err != nil {
+		return status.Errorf(codes.InvalidArgument, "Неверный ID файла: %s", req.FileId)
+	}
+
+	file, content, err := s.fileService.Open(ctx, fileID, user.ID, user.Role == users.RoleAdmin)
+	if err != nil {
+		return fileError("Ошибка скачивания файла", err)
+	}
+	defer content.Close()
+
+	response := &pb.DownloadFileResponse{File: toPBFile(file)}
+	buf := make([]byte, chunkSize)
+	for {
+		n, err := content.Read(buf)
+		if n > 0 {
+			response.Chunk = buf[:n]
+			if err := stream.Send(response); err != nil {
+				return err
+			}
+			response = &pb.DownloadFileResponse{}
+		}
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			log.Printf("Ошибка чтения файла %s: %v", fileID, err)
+			return status.Errorf(codes.Internal, "Ошибка чтения файла")
+		}
+	}
+
+	// Пустой файл: отправляем хотя бы метаданные
+	if response.File != nil {
+		return stream.Send(response)
+	}
+	return nil
+}
+
+// GetFileURL возвращает подписанную ссылку на файл
+func (s *Server) GetFileURL(ctx context.Context, req *pb.GetFileURLRequest) (*pb.GetFileURLResponse, error) {
+	user, err := s.authenticate(ctx, req.Token)
+	if err != nil {
+		return nil, err
+	}
+
+	fileID, err := uuid.Parse(req.FileId)
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "Неверный ID файла: %s", req.FileId)
+	}
+
+	file, url, expiresAt, err := s.fileService.URL(ctx, fileID, user.ID, user.Role == users.RoleAdmin)
+	if err != nil {
+		return nil, fileError("Ошибка получения ссылки на файл", err)
+	}
+
+	return &pb.GetFileURLResponse{
+		Success:   true,
+		Message:   "Ссылка на файл получена",
+		File:      toPBFile(file),
+		Url:       url,
+		ExpiresAt: timestamppb.New(expiresAt),
+	}, nil
+}
+
+// GetAvatarURL возвращает подписанную ссылку на аватар пользователя
+func (s *Server) GetAvatarURL(ctx context.Context, req *pb.GetAvatarURLRequest) (*pb.GetAvatarURLResponse, error) {
+	user, err := s.authenticate(ctx, req.Token)
+	if err != nil {
+		return nil, err
+	}
+
+	userID := user.ID
+	if req.UserId != "" {
+		userID, err = uuid.Parse(req.UserId)
+		if err != nil {
+			return nil, status.Errorf(codes.InvalidArgument, "Неверный ID пользователя: %s", req.UserId)
+		}
+	}
+
+	url, expiresAt, err := s.fileService.AvatarURL(ctx, userID)
+	if err != nil {
+		log.Printf("Ошибка получения аватара пользователя %s: %v", userID, err)
+		return nil, status.Errorf(codes.NotFound, "Аватар не найден")
+	}
+
+	response := &pb.GetAvatarURLResponse{
+		Success: true,
+		Message: "Аватар не загружен",
+		Url:     url,
+	}
+	if url != "" {
+		response.Message = "Ссылка на аватар получена"
+		response.ExpiresAt = timestamppb.New(expiresAt)
+	}
+	return response, nil
+}
+
+// DeleteFile удаляет файл
+func (s *Server) DeleteFile(ctx context.Context, req *pb.DeleteFileRequest) (*pb.DeleteFileResponse, error) {
+	user, err := s.authenticate(ctx, req.Token)
+	if err != nil {
+		return nil, err
+	}
+
+	fileID, err := uuid.Parse(req.FileId)
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "Неверный ID файла: %s", req.FileId)
+	}
+
+	if err := s.fileService.Delete(ctx, fileID, user.ID, user.Role == users.RoleAdmin); err != nil {
+		return nil, fileError("Ошибка удаления файла", err)
+	}
+
+	return &pb.DeleteFileResponse{
+		Success: true,
+		Message: "Файл удален",
+	}, nil
+}
+
+// authenticate проверяет JWT токен и возвращает активного пользователя.
+// Гостевые токены не дают доступа к файлам.
+func (s *Server) authenticate(ctx context.Context, token string) (*users.User, error) {
+	claims, err := s.jwtManager.ParseToken(ctx, token)
+	if err != nil {
+		log.Printf("Ошибка проверки токена: %v", err)
+		return nil, status.Errorf(codes.Unauthenticated, "Неверный токен")
+	}
+	if claims.IsGuest() {
+		return nil, status.Errorf(codes.PermissionDenied, "Действие доступно только зарегистрированным пользователям")
+	}
+
+	user, err := s.userService.GetUserByID(ctx, claims.UserID)
+	if err != nil {
+		log.Printf("Ошибка получения пользователя %s: %v", claims.UserID, err)
+		return nil, status.Errorf(codes.NotFound, "Пользователь не найден")
+	}
+
+	if !user.IsActive {
+		return nil, status.Errorf(codes.PermissionDenied, "Пользователь деактивирован")
+	}
+	// Потоковые методы не проходят auth.Middleware.PasswordChangeInterceptor
+	if user.PasswordChangeRequired {
+		return nil, status.Errorf(codes.FailedPrecondition, auth.PasswordChangeRequiredMessage)
+	}
+
+	return user, nil
+}
+
+// fileError преобразует ошибку сервиса файлов в gRPC статус
+func fileError(action string, err error) error {
+	switch {
+	case errors.Is(err, files.ErrFileTooLarge), errors.Is(err, files.ErrUnsupportedType), errors.Is(err, files.ErrEmptyFile):
+		return status.Errorf(codes.InvalidArgument, "%v", err)
+	case errors.Is(err, files.ErrAccessDenied):
+		return status.Errorf(codes.PermissionDenied, "%v", err)
+	}
+	log.Printf("%s: %v", action, err)
+	return status.Errorf(codes.Internal, "%s", action)
+}
+
+// chunkReader читает содержимое файла из потока загрузки
+type chunkReader struct {
+	stream pb.FileService_UploadFileServer
+	buf    []byte
+}
+
+// Read реализует io.Reader поверх сообщений с частями файла
+func (r *chunkReader) Read(p []byte) (int, error) {
+	for len(r.buf) == 0 {
+		msg, err := r.stream.Recv()
+		if err != nil {
+			return 0, err
+		}
+		r.buf = msg.GetChunk()
+	}
+
+	n := copy(p, r.buf)
+	r.buf = r.buf[n:]
+	return n, nil
+}
+
+// fromPBFileKind преобразует назначение файла из формата protobuf
+func fromPBFileKind(kind pb.FileKind) (files.Kind, error) {
+	switch kind {
+	case pb.FileKind_FILE_KIND_AVATAR:
+		return files.KindAvatar, nil
+	case pb.FileKind_FILE_KIND_ATTACHMENT:
+		return files.KindAttachment, nil
+	default:
+		return "", status.Errorf(codes.InvalidArgument, "Не указано назначение файла")
+	}
+}
+
+// toPBFile преобразует метаданные файла в формат protobuf
+func toPBFile(file *files.File) *pb.File {
+	var kind pb.FileKind
+	switch file.Kind {
+	case files.KindAvatar:
+		kind = pb.FileKind_FILE_KIND_AVATAR
+	case files.KindAttachment:
+		kind = pb.FileKind_FILE_KIND_ATTACHMENT
+	}
+
+	return &pb.File{
+		Id:          file.ID.String(),
+		OwnerId:     file.OwnerID.String(),
+		Kind:        kind,
+		Name:        file.Name,
+		ContentType: file.ContentType,
+		Size:        file.Size,
+		CreatedAt:   timestamppb.New(file.CreatedAt),
+	}
+}
+
+// RegisterService регистрирует сервис файлов на gRPC сервере
+func RegisterService(grpcServer *grpc.Server, deps Dependencies) {
+	pb.RegisterFileServiceServer(grpcServer, NewServer(deps))
+}
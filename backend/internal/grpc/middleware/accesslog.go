@@ -0,0 +1,64 @@
+package middleware
+
+import (
+	"context"
+	"time"
+
+	"github.com/Ultrahd-dev/student-schedule-app/backend/internal/jwt"
+	"github.com/Ultrahd-dev/student-schedule-app/backend/internal/requestid"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/status"
+)
+
+// tokenRequest запрос gRPC, содержащий JWT токен в поле token
+type tokenRequest interface {
+	GetToken() string
+}
+
+// AccessLogInterceptor возвращает interceptor, записывающий в журнал каждый вызов:
+// метод, пользователя, код ответа и время выполнения. Пользователь определяется
+// по полю token запроса (проверяется подпись tokens); без токена или с
+// недействительным токеном записывается "-".
+func AccessLogInterceptor(tokens *jwt.Manager) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		start := time.Now()
+		resp, err := handler(ctx, req)
+		logAccess(ctx, info.FullMethod, requestUser(ctx, tokens, req), start, err)
+		return resp, err
+	}
+}
+
+// StreamAccessLogInterceptor возвращает stream interceptor, записывающий в журнал
+// каждый вызов. Токен в потоковых методах передается в сообщениях, поэтому
+// пользователь в записи не указывается.
+func StreamAccessLogInterceptor() grpc.StreamServerInterceptor {
+	return func(srv interface{}, stream grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		start := time.Now()
+		err := handler(srv, stream)
+		logAccess(stream.Context(), info.FullMethod, "-", start, err)
+		return err
+	}
+}
+
+// logAccess пишет запись о вызове метода
+func logAccess(ctx context.Context, method, user string, start time.Time, err error) {
+	requestid.Logf(ctx, "gRPC %s пользователь=%s статус=%s время=%s",
+		method, user, status.Code(err), time.Since(start).Round(time.Microsecond))
+}
+
+// requestUser возвращает описание пользователя по токену запроса
+func requestUser(ctx context.Context, tokens *jwt.Manager, req interface{}) string {
+	tokenReq, ok := req.(tokenRequest)
+	if !ok || tokens == nil || tokenReq.GetToken() == "" {
+		return "-"
+	}
+
+	claims, err := tokens.ParseToken(ctx, tokenReq.GetToken())
+	if err != nil {
+		return "-"
+	}
+	if claims.IsGuest() {
+		return "guest:" + claims.GroupName
+	}
+	return claims.Email + "(" + claims.Role + ")"
+}
@@ -0,0 +1,37 @@
+package middleware
+
+import (
+	"context"
+	"slices"
+
+	"github.com/Ultrahd-dev/student-schedule-app/backend/internal/requestid"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/encoding/gzip"
+	"google.golang.org/protobuf/proto"
+)
+
+// CompressionInterceptor возвращает interceptor, сжимающий gzip ответы размером
+// от minSize байт (данные снапшота, расписание на неделю), если клиент поддерживает
+// gzip. Маленькие ответы не сжимаются: выигрыш не окупает затраты процессора.
+func CompressionInterceptor(minSize int) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		resp, err := handler(ctx, req)
+		if err != nil {
+			return resp, err
+		}
+
+		msg, ok := resp.(proto.Message)
+		if !ok || proto.Size(msg) < minSize {
+			return resp, nil
+		}
+		// Ответ унарного метода отправляется после выхода из interceptor'ов,
+		// поэтому сжатие еще можно включить
+		supported, _ := grpc.ClientSupportedCompressors(ctx)
+		if slices.Contains(supported, gzip.Name) {
+			if err := grpc.SetSendCompressor(ctx, gzip.Name); err != nil {
+				requestid.Logf(ctx, "Ошибка включения сжатия ответа %s: %v", info.FullMethod, err)
+			}
+		}
+		return resp, nil
+	}
+}
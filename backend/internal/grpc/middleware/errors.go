@@ -0,0 +1,133 @@
+package middleware
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"strings"
+
+	"github.com/Ultrahd-dev/student-schedule-app/backend/internal/apperr"
+	"github.com/Ultrahd-dev/student-schedule-app/backend/internal/breaker"
+	"github.com/Ultrahd-dev/student-schedule-app/backend/internal/requestid"
+	"github.com/Ultrahd-dev/student-schedule-app/backend/internal/storage"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// internalMessage сообщение клиенту о внутренней ошибке без подробностей
+const internalMessage = "Внутренняя ошибка сервера"
+
+// errorMapping код gRPC и сообщение клиенту для известной ошибки
+type errorMapping struct {
+	target  error
+	code    codes.Code
+	message string
+}
+
+// knownErrors известные ошибки в порядке проверки
+var knownErrors = []errorMapping{
+	{apperr.ErrNotFound, codes.NotFound, "Не найдено"},
+	{sql.ErrNoRows, codes.NotFound, "Не найдено"},
+	{storage.ErrNotFound, codes.NotFound, "Не найдено"},
+	{apperr.ErrAlreadyExists, codes.AlreadyExists, "Уже существует"},
+	{apperr.ErrUnauthorized, codes.Unauthenticated, "Требуется аутентификация"},
+	{apperr.ErrForbidden, codes.PermissionDenied, "Недостаточно прав"},
+	{apperr.ErrValidation, codes.InvalidArgument, "Некорректные данные"},
+	{apperr.ErrConflict, codes.FailedPrecondition, "Действие недоступно"},
+	{breaker.ErrOpen, codes.Unavailable, "Внешний сервис временно недоступен, попробуйте позже"},
+	{context.DeadlineExceeded, codes.DeadlineExceeded, "Превышено время ожидания"},
+	{context.Canceled, codes.Canceled, "Запрос отменен"},
+}
+
+// ErrorInterceptor возвращает interceptor, преобразующий ошибки обработчиков
+// в ответы gRPC, которые безопасно показывать клиенту:
+//   - ошибки без gRPC статуса получают код по известным доменным ошибкам
+//     (не найдено, уже существует, не аутентифицирован и т.д.), остальные -
+//     codes.Internal; клиенту передается только текст ошибок apperr.New,
+//     для прочих - общее сообщение;
+//   - у статусов Internal и Unknown сообщение обрезается до первого ": ",
+//     так как после него обработчики добавляют текст внутренней ошибки
+//     («Ошибка получения расписания: pq: ...» -> «Ошибка получения расписания»).
+//
+// Полный текст ошибки пишется в журнал.
+func ErrorInterceptor() grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		resp, err := handler(ctx, req)
+		if err != nil {
+			err = translateError(ctx, info.FullMethod, err)
+		}
+		return resp, err
+	}
+}
+
+// StreamErrorInterceptor возвращает stream interceptor, преобразующий ошибки
+// так же, как ErrorInterceptor
+func StreamErrorInterceptor() grpc.StreamServerInterceptor {
+	return func(srv interface{}, stream grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		err := handler(srv, stream)
+		if err != nil {
+			err = translateError(stream.Context(), info.FullMethod, err)
+		}
+		return err
+	}
+}
+
+// translateError преобразует ошибку обработчика method в безопасный для клиента статус
+func translateError(ctx context.Context, method string, err error) error {
+	if st, ok := status.FromError(err); ok {
+		switch st.Code() {
+		case codes.Internal, codes.Unknown:
+			requestid.Logf(ctx, "Внутренняя ошибка %s: %s", method, st.Message())
+			return status.Error(codes.Internal, scrubMessage(st.Message()))
+		}
+		return err
+	}
+
+	if st, ok := domainStatus(err); ok {
+		requestid.Logf(ctx, "Ошибка %s (%s): %v", method, st.Code(), err)
+		return st.Err()
+	}
+
+	requestid.Logf(ctx, "Внутренняя ошибка %s: %v", method, err)
+	return status.Error(codes.Internal, internalMessage)
+}
+
+// Status преобразует ошибку сервиса в ответ обработчика: известные доменные ошибки
+// получают свой код и сообщение, остальные - codes.Internal с сообщением message
+// (подробности внутренней ошибки клиенту не передаются)
+func Status(err error, message string) error {
+	if st, ok := domainStatus(err); ok {
+		return st.Err()
+	}
+	return status.Error(codes.Internal, message)
+}
+
+// domainStatus подбирает статус для известной доменной ошибки. Текст ошибок
+// apperr.New показывается клиенту полностью, для остальных - общее сообщение.
+func domainStatus(err error) (*status.Status, bool) {
+	if err == nil {
+		return nil, false
+	}
+	for _, known := range knownErrors {
+		if errors.Is(err, known.target) {
+			message := known.message
+			if apperr.UserFacing(err) {
+				message = err.Error()
+			}
+			return status.New(known.code, message), true
+		}
+	}
+	return nil, false
+}
+
+// scrubMessage оставляет от сообщения о внутренней ошибке часть до подробностей
+func scrubMessage(message string) string {
+	if i := strings.Index(message, ": "); i >= 0 {
+		message = message[:i]
+	}
+	if message == "" {
+		return internalMessage
+	}
+	return message
+}
@@ -0,0 +1,41 @@
+// Package middleware содержит общие gRPC interceptor'ы сервера: восстановление
+// после паники и преобразование ошибок обработчиков в коды gRPC
+package middleware
+
+import (
+	"context"
+	"runtime/debug"
+
+	"github.com/Ultrahd-dev/student-schedule-app/backend/internal/requestid"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// RecoveryInterceptor возвращает interceptor, превращающий панику обработчика
+// в ответ codes.Internal. Стек паники пишется в журнал, клиенту он не передается.
+func RecoveryInterceptor() grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (resp interface{}, err error) {
+		defer func() {
+			if r := recover(); r != nil {
+				requestid.Logf(ctx, "Паника в обработчике %s: %v\n%s", info.FullMethod, r, debug.Stack())
+				resp, err = nil, status.Errorf(codes.Internal, internalMessage)
+			}
+		}()
+		return handler(ctx, req)
+	}
+}
+
+// StreamRecoveryInterceptor возвращает stream interceptor, превращающий панику
+// обработчика в ответ codes.Internal
+func StreamRecoveryInterceptor() grpc.StreamServerInterceptor {
+	return func(srv interface{}, stream grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) (err error) {
+		defer func() {
+			if r := recover(); r != nil {
+				requestid.Logf(stream.Context(), "Паника в обработчике %s: %v\n%s", info.FullMethod, r, debug.Stack())
+				err = status.Errorf(codes.Internal, internalMessage)
+			}
+		}()
+		return handler(srv, stream)
+	}
+}
@@ -0,0 +1,52 @@
+package middleware
+
+import (
+	"context"
+
+	"github.com/Ultrahd-dev/student-schedule-app/backend/internal/requestid"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+)
+
+// RequestIDInterceptor возвращает interceptor, добавляющий в контекст идентификатор
+// запроса (см. requestid.FromContext). Идентификатор берется из метаданных
+// x-request-id или создается заново и возвращается клиенту в заголовке ответа.
+func RequestIDInterceptor() grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		id := incomingRequestID(ctx)
+		_ = grpc.SetHeader(ctx, metadata.Pairs(requestid.MetadataKey, id))
+		return handler(requestid.WithID(ctx, id), req)
+	}
+}
+
+// StreamRequestIDInterceptor возвращает stream interceptor, добавляющий в контекст
+// идентификатор запроса так же, как RequestIDInterceptor
+func StreamRequestIDInterceptor() grpc.StreamServerInterceptor {
+	return func(srv interface{}, stream grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		id := incomingRequestID(stream.Context())
+		_ = stream.SetHeader(metadata.Pairs(requestid.MetadataKey, id))
+		return handler(srv, &contextStream{ServerStream: stream, ctx: requestid.WithID(stream.Context(), id)})
+	}
+}
+
+// incomingRequestID возвращает идентификатор из метаданных запроса или новый,
+// если клиент его не передал или передал некорректный
+func incomingRequestID(ctx context.Context) string {
+	if md, ok := metadata.FromIncomingContext(ctx); ok {
+		if values := md.Get(requestid.MetadataKey); len(values) > 0 && requestid.Valid(values[0]) {
+			return values[0]
+		}
+	}
+	return requestid.New()
+}
+
+// contextStream поток с подмененным контекстом
+type contextStream struct {
+	grpc.ServerStream
+	ctx context.Context
+}
+
+// Context возвращает контекст потока
+func (s *contextStream) Context() context.Context {
+	return s.ctx
+}
@@ -0,0 +1,19 @@
+package middleware
+
+import (
+	"context"
+
+	"github.com/Ultrahd-dev/student-schedule-app/backend/internal/jwt"
+	"google.golang.org/grpc"
+)
+
+// RevocationCacheInterceptor возвращает interceptor, добавляющий в контекст кэш
+// проверок отзыва токенов (см. jwt.WithRevocationCache). Токен запроса разбирают
+// журнал доступа, определение колледжа, авторизация и обработчик, а в базу за
+// отзывом идет только первый из них. Потоковым методам кэш не добавляется:
+// поток живет долго, и отзыв токена должен замечаться в его сообщениях.
+func RevocationCacheInterceptor() grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		return handler(jwt.WithRevocationCache(ctx), req)
+	}
+}
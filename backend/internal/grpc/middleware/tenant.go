@@ -0,0 +1,90 @@
+package middleware
+
+import (
+	"context"
+	"errors"
+
+	"github.com/Ultrahd-dev/student-schedule-app/backend/internal/jwt"
+	"github.com/Ultrahd-dev/student-schedule-app/backend/internal/requestid"
+	"github.com/Ultrahd-dev/student-schedule-app/backend/internal/tenant"
+	"github.com/google/uuid"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+// CollegeResolver находит колледж по коду (slug) из метаданных запроса
+type CollegeResolver interface {
+	CollegeIDBySlug(ctx context.Context, slug string) (uuid.UUID, error)
+}
+
+// TenantInterceptor возвращает interceptor, добавляющий в контекст колледж запроса
+// (см. tenant.CollegeID). Колледж берется из токена запроса, а для запросов без
+// токена (регистрация, вход, гостевой токен) - из метаданных x-college через
+// colleges. Без токена и метаданных используется колледж по умолчанию.
+// colleges может быть nil - тогда метаданные x-college не учитываются.
+func TenantInterceptor(tokens *jwt.Manager, colleges CollegeResolver) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		if id := tokenCollege(ctx, tokens, req); id != uuid.Nil {
+			return handler(tenant.WithCollege(ctx, id), req)
+		}
+
+		id, err := metadataCollege(ctx, colleges)
+		if err != nil {
+			return nil, err
+		}
+		return handler(tenant.WithCollege(ctx, id), req)
+	}
+}
+
+// StreamTenantInterceptor возвращает stream interceptor, добавляющий в контекст
+// колледж из метаданных x-college. Токен в потоковых методах передается
+// в сообщениях, поэтому колледж токена проверяет обработчик.
+func StreamTenantInterceptor(colleges CollegeResolver) grpc.StreamServerInterceptor {
+	return func(srv interface{}, stream grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		id, err := metadataCollege(stream.Context(), colleges)
+		if err != nil {
+			return err
+		}
+		return handler(srv, &contextStream{ServerStream: stream, ctx: tenant.WithCollege(stream.Context(), id)})
+	}
+}
+
+// tokenCollege возвращает колледж из токена запроса или uuid.Nil, если токена нет,
+// он недействителен или выдан до появления колледжей
+func tokenCollege(ctx context.Context, tokens *jwt.Manager, req interface{}) uuid.UUID {
+	tokenReq, ok := req.(tokenRequest)
+	if !ok || tokens == nil || tokenReq.GetToken() == "" {
+		return uuid.Nil
+	}
+
+	claims, err := tokens.ParseToken(ctx, tokenReq.GetToken())
+	if err != nil {
+		return uuid.Nil
+	}
+	return claims.CollegeID
+}
+
+// metadataCollege возвращает колледж по коду из метаданных x-college
+// или колледж по умолчанию, если код не передан
+func metadataCollege(ctx context.Context, colleges CollegeResolver) (uuid.UUID, error) {
+	md, ok := metadata.FromIncomingContext(ctx)
+	if !ok || colleges == nil {
+		return tenant.DefaultCollegeID, nil
+	}
+	values := md.Get(tenant.MetadataKey)
+	if len(values) == 0 || values[0] == "" {
+		return tenant.DefaultCollegeID, nil
+	}
+
+	id, err := colleges.CollegeIDBySlug(ctx, values[0])
+	if errors.Is(err, tenant.ErrUnknownCollege) {
+		return uuid.Nil, status.Errorf(codes.NotFound, "Колледж %q не найден", values[0])
+	}
+	if err != nil {
+		requestid.Logf(ctx, "Ошибка поиска колледжа %q: %v", values[0], err)
+		return uuid.Nil, status.Errorf(codes.Internal, "Ошибка определения колледжа")
+	}
+	return id, nil
+}
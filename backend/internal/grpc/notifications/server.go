@@ -0,0 +1,190 @@
+// Package notifications реализует gRPC сервер уведомлений пользователя
+package notifications
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/Ultrahd-dev/student-schedule-app/backend/internal/grpc/middleware"
+	"github.com/Ultrahd-dev/student-schedule-app/backend/internal/jwt"
+	"github.com/Ultrahd-dev/student-schedule-app/backend/internal/notifications"
+	"github.com/Ultrahd-dev/student-schedule-app/backend/internal/requestid"
+	"github.com/Ultrahd-dev/student-schedule-app/backend/internal/users"
+	pb "github.com/Ultrahd-dev/student-schedule-app/backend/proto/gen/notifications"
+	"github.com/google/uuid"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// Server реализует gRPC сервис уведомлений
+type Server struct {
+	pb.UnimplementedNotificationServiceServer
+	notificationService *notifications.Service
+	jwtManager          *jwt.Manager
+	userService         *users.Service
+}
+
+// Dependencies сервисы, используемые gRPC сервером уведомлений
+type Dependencies struct {
+	NotificationService *notifications.Service
+	JWTManager          *jwt.Manager
+	UserService         *users.Service
+}
+
+// NewServer создает новый gRPC сервер уведомлений
+func NewServer(deps Dependencies) *Server {
+	return &Server{
+		notificationService: deps.NotificationService,
+		jwtManager:          deps.JWTManager,
+		userService:         deps.UserService,
+	}
+}
+
+// ListNotifications возвращает страницу уведомлений пользователя, от новых к старым
+func (s *Server) ListNotifications(ctx context.Context, req *pb.ListNotificationsRequest) (*pb.ListNotificationsResponse, error) {
+	user, err := s.authenticate(ctx, req.Token)
+	if err != nil {
+		return nil, err
+	}
+
+	list, total, err := s.notificationService.ListNotifications(ctx, user.ID, notifications.ListFilter{
+		UnreadOnly: req.UnreadOnly,
+		Limit:      int(req.PageSize),
+		Offset:     int(req.Offset),
+	})
+	if err != nil {
+		requestid.Logf(ctx, "Ошибка получения уведомлений пользователя %s: %v", user.Email, err)
+		return nil, middleware.Status(err, "Ошибка получения уведомлений")
+	}
+
+	response := &pb.ListNotificationsResponse{
+		Success:       true,
+		Message:       fmt.Sprintf("Найдено уведомлений: %d", total),
+		Notifications: make([]*pb.Notification, 0, len(list)),
+		Total:         int32(total),
+	}
+	for _, n := range list {
+		response.Notifications = append(response.Notifications, toPBNotification(n))
+	}
+	return response, nil
+}
+
+// GetUnreadCount возвращает число непрочитанных уведомлений пользователя
+func (s *Server) GetUnreadCount(ctx context.Context, req *pb.GetUnreadCountRequest) (*pb.GetUnreadCountResponse, error) {
+	user, err := s.authenticate(ctx, req.Token)
+	if err != nil {
+		return nil, err
+	}
+
+	count, err := s.notificationService.UnreadCount(ctx, user.ID)
+	if err != nil {
+		requestid.Logf(ctx, "Ошибка подсчета непрочитанных уведомлений пользователя %s: %v", user.Email, err)
+		return nil, middleware.Status(err, "Ошибка подсчета уведомлений")
+	}
+
+	return &pb.GetUnreadCountResponse{
+		Success: true,
+		Message: fmt.Sprintf("Непрочитанных уведомлений: %d", count),
+		Count:   int32(count),
+	}, nil
+}
+
+// MarkAsRead отмечает уведомление пользователя прочитанным
+func (s *Server) MarkAsRead(ctx context.Context, req *pb.MarkAsReadRequest) (*pb.MarkAsReadResponse, error) {
+	user, err := s.authenticate(ctx, req.Token)
+	if err != nil {
+		return nil, err
+	}
+
+	notificationID, err := uuid.Parse(req.NotificationId)
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "Неверный ID уведомления")
+	}
+
+	if err := s.notificationService.MarkAsRead(ctx, user.ID, notificationID); err != nil {
+		requestid.Logf(ctx, "Ошибка отметки уведомления %s пользователя %s: %v", notificationID, user.Email, err)
+		return nil, middleware.Status(err, "Ошибка отметки уведомления")
+	}
+
+	return &pb.MarkAsReadResponse{
+		Success: true,
+		Message: "Уведомление прочитано",
+	}, nil
+}
+
+// MarkAllAsRead отмечает все уведомления пользователя прочитанными
+func (s *Server) MarkAllAsRead(ctx context.Context, req *pb.MarkAllAsReadRequest) (*pb.MarkAllAsReadResponse, error) {
+	user, err := s.authenticate(ctx, req.Token)
+	if err != nil {
+		return nil, err
+	}
+
+	marked, err := s.notificationService.MarkAllAsRead(ctx, user.ID)
+	if err != nil {
+		requestid.Logf(ctx, "Ошибка отметки уведомлений пользователя %s: %v", user.Email, err)
+		return nil, middleware.Status(err, "Ошибка отметки уведомлений")
+	}
+
+	return &pb.MarkAllAsReadResponse{
+		Success: true,
+		Message: fmt.Sprintf("Прочитано уведомлений: %d", marked),
+		Marked:  int32(marked),
+	}, nil
+}
+
+// authenticate проверяет JWT токен и возвращает активного пользователя.
+// Гостевые токены отклоняются: у гостей нет уведомлений.
+func (s *Server) authenticate(ctx context.Context, token string) (*users.User, error) {
+	claims, err := s.jwtManager.ParseToken(ctx, token)
+	if err != nil {
+		requestid.Logf(ctx, "Ошибка проверки токена: %v", err)
+		return nil, status.Errorf(codes.Unauthenticated, "Неверный токен")
+	}
+	if claims.IsGuest() {
+		return nil, status.Errorf(codes.PermissionDenied, "Действие доступно только зарегистрированным пользователям")
+	}
+
+	user, err := s.userService.GetUserByID(ctx, claims.UserID)
+	if err != nil {
+		requestid.Logf(ctx, "Ошибка получения пользователя %s: %v", claims.UserID, err)
+		return nil, status.Errorf(codes.NotFound, "Пользователь не найден")
+	}
+
+	if !user.IsActive {
+		return nil, status.Errorf(codes.PermissionDenied, "Пользователь деактивирован")
+	}
+
+	return user, nil
+}
+
+// toPBNotification преобразует уведомление в формат protobuf
+func toPBNotification(n notifications.Notification) *pb.Notification {
+	return &pb.Notification{
+		Id:           n.ID.String(),
+		Title:        n.Title,
+		Message:      n.Message,
+		Type:         string(n.Type),
+		RelatedGroup: n.RelatedGroup,
+		RelatedDate:  timestamppb.New(n.RelatedDate),
+		CreatedAt:    timestamppb.New(n.CreatedAt),
+		IsRead:       n.IsRead,
+		Payload: &pb.NotificationPayload{
+			Type:           string(n.Payload.Type),
+			Group:          n.Payload.Group,
+			Date:           n.Payload.Date,
+			Time:           n.Payload.Time,
+			ChangeId:       n.Payload.ChangeID,
+			EntryId:        n.Payload.EntryID,
+			ConsultationId: n.Payload.ConsultationID,
+			CourseId:       n.Payload.CourseID,
+			Route:          n.Payload.Route,
+		},
+	}
+}
+
+// RegisterService регистрирует сервис уведомлений на gRPC сервере
+func RegisterService(grpcServer *grpc.Server, deps Dependencies) {
+	pb.RegisterNotificationServiceServer(grpcServer, NewServer(deps))
+}
@@ -0,0 +1,103 @@
+package schedule
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/Ultrahd-dev/student-schedule-app/backend/internal/auth"
+	"github.com/Ultrahd-dev/student-schedule-app/backend/internal/schedule"
+	"github.com/Ultrahd-dev/student-schedule-app/backend/internal/users"
+	pb "github.com/Ultrahd-dev/student-schedule-app/backend/proto/gen/schedule"
+	"github.com/google/uuid"
+)
+
+// AdminMethods методы Schedule Service, доступные только администраторам.
+// Список используется interceptor'ом авторизации (auth.Middleware.AdminInterceptor);
+// новый административный метод нужно добавить сюда.
+var AdminMethods = []string{
+	pb.ScheduleService_GetChangeStats_FullMethodName,
+	pb.ScheduleService_CompareSnapshots_FullMethodName,
+	pb.ScheduleService_UpsertSubjectMetadata_FullMethodName,
+	pb.ScheduleService_DeleteSubjectMetadata_FullMethodName,
+	pb.ScheduleService_ListOverlappingChanges_FullMethodName,
+	pb.ScheduleService_ListChangesAwaitingModeration_FullMethodName,
+	pb.ScheduleService_ReviewChange_FullMethodName,
+	pb.ScheduleService_ListPendingTeacherChangeRequests_FullMethodName,
+	pb.ScheduleService_ReviewTeacherChangeRequest_FullMethodName,
+	pb.ScheduleService_RunMaintenance_FullMethodName,
+	pb.ScheduleService_ListPendingTeacherNameClaims_FullMethodName,
+	pb.ScheduleService_ReviewTeacherNameClaim_FullMethodName,
+	pb.ScheduleService_ListJobs_FullMethodName,
+	pb.ScheduleService_RetryJob_FullMethodName,
+	pb.ScheduleService_ListFeatureFlags_FullMethodName,
+	pb.ScheduleService_SetFeatureFlag_FullMethodName,
+	pb.ScheduleService_ResetFeatureFlag_FullMethodName,
+	pb.ScheduleService_ListGroupWebhooks_FullMethodName,
+	pb.ScheduleService_SetGroupWebhook_FullMethodName,
+	pb.ScheduleService_DeleteGroupWebhook_FullMethodName,
+	pb.ScheduleService_ImportTeacherDirectory_FullMethodName,
+	pb.ScheduleService_CreateElectiveCourse_FullMethodName,
+	pb.ScheduleService_CancelElectiveCourse_FullMethodName,
+	pb.ScheduleService_GetTeacherWorkload_FullMethodName,
+	pb.ScheduleService_SetBuilding_FullMethodName,
+	pb.ScheduleService_DeleteBuilding_FullMethodName,
+	pb.ScheduleService_RunAcademicRollover_FullMethodName,
+	pb.ScheduleService_CreateKioskDisplay_FullMethodName,
+	pb.ScheduleService_ListKioskDisplays_FullMethodName,
+	pb.ScheduleService_RevokeKioskDisplay_FullMethodName,
+}
+
+// GuestMethods методы Schedule Service, доступные по гостевому токену (только чтение
+// расписания группы, к которой привязан токен; группа проверяется в обработчике).
+// Остальные методы требуют регистрации (auth.Middleware.GuestInterceptor).
+var GuestMethods = []string{
+	pb.ScheduleService_GetScheduleForGroup_FullMethodName,
+	pb.ScheduleService_GetMySchedule_FullMethodName,
+	pb.ScheduleService_GetTimetablePDF_FullMethodName,
+	pb.ScheduleService_GetWidgetSummary_FullMethodName,
+	pb.ScheduleService_GetNextLesson_FullMethodName,
+	pb.ScheduleService_GetBellStatus_FullMethodName,
+}
+
+// TeacherGroupMethods методы Schedule Service с данными группы, доступные администраторам
+// и преподавателям, у которых есть занятия с группой (auth.Middleware.TeacherGroupInterceptor)
+var TeacherGroupMethods = []string{
+	pb.ScheduleService_GetGroupRoster_FullMethodName,
+}
+
+// RestrictedFields поля ответов Schedule Service, скрываемые от остальных ролей
+// (auth.Middleware.FieldFilterInterceptor). Адрес таблицы-источника снапшота
+// нужен только администраторам.
+var RestrictedFields = []auth.RestrictedField{
+	{Field: "schedule.ScheduleSnapshot.source_url", Roles: []string{string(users.RoleAdmin)}},
+}
+
+// GroupAccess проверяет по актуальному расписанию, что преподаватель ведет занятия у группы.
+// Занятия ищутся по ФИО преподавателя и подтвержденным вариантам имени.
+type GroupAccess struct {
+	userService     *users.Service
+	scheduleService *schedule.Service
+}
+
+// NewGroupAccess создает проверку доступа преподавателей к группам
+func NewGroupAccess(userService *users.Service, scheduleService *schedule.Service) *GroupAccess {
+	return &GroupAccess{
+		userService:     userService,
+		scheduleService: scheduleService,
+	}
+}
+
+// TeacherHasGroup реализует auth.GroupAccessChecker
+func (a *GroupAccess) TeacherHasGroup(ctx context.Context, teacherID uuid.UUID, groupName string) (bool, error) {
+	teacher, err := a.userService.GetTeacherProfile(ctx, teacherID)
+	if err != nil {
+		return false, fmt.Errorf("ошибка получения профиля преподавателя: %w", err)
+	}
+
+	names, err := a.userService.TeacherNames(ctx, teacher)
+	if err != nil {
+		return false, err
+	}
+
+	return a.scheduleService.TeacherHasGroup(ctx, names, groupName)
+}
@@ -2,15 +2,45 @@
 package schedule
 
 import (
+	"bytes"
 	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
 	"log"
+	"math"
+	"strings"
+	"time"
 
+	"github.com/Ultrahd-dev/student-schedule-app/backend/internal/bells"
+	"github.com/Ultrahd-dev/student-schedule-app/backend/internal/buildings"
+	"github.com/Ultrahd-dev/student-schedule-app/backend/internal/calendar"
+	"github.com/Ultrahd-dev/student-schedule-app/backend/internal/changes"
+	"github.com/Ultrahd-dev/student-schedule-app/backend/internal/clock"
+	"github.com/Ultrahd-dev/student-schedule-app/backend/internal/consultations"
+	"github.com/Ultrahd-dev/student-schedule-app/backend/internal/electives"
+	"github.com/Ultrahd-dev/student-schedule-app/backend/internal/features"
+	"github.com/Ultrahd-dev/student-schedule-app/backend/internal/feedback"
+	"github.com/Ultrahd-dev/student-schedule-app/backend/internal/grpc/middleware"
+	"github.com/Ultrahd-dev/student-schedule-app/backend/internal/jobs"
 	"github.com/Ultrahd-dev/student-schedule-app/backend/internal/jwt"
+	"github.com/Ultrahd-dev/student-schedule-app/backend/internal/kiosk"
+	"github.com/Ultrahd-dev/student-schedule-app/backend/internal/maintenance"
+	"github.com/Ultrahd-dev/student-schedule-app/backend/internal/notes"
+	"github.com/Ultrahd-dev/student-schedule-app/backend/internal/notifications"
+	"github.com/Ultrahd-dev/student-schedule-app/backend/internal/personal"
+	"github.com/Ultrahd-dev/student-schedule-app/backend/internal/requestid"
+	"github.com/Ultrahd-dev/student-schedule-app/backend/internal/rollover"
 	"github.com/Ultrahd-dev/student-schedule-app/backend/internal/schedule"
+	"github.com/Ultrahd-dev/student-schedule-app/backend/internal/storage"
+	"github.com/Ultrahd-dev/student-schedule-app/backend/internal/tenant"
+	"github.com/Ultrahd-dev/student-schedule-app/backend/internal/timetable"
 	"github.com/Ultrahd-dev/student-schedule-app/backend/internal/users"
 	pb "github.com/Ultrahd-dev/student-schedule-app/backend/proto/gen/schedule"
+	"github.com/google/uuid"
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
 	"google.golang.org/grpc/status"
 	"google.golang.org/protobuf/types/known/timestamppb"
 )
@@ -18,77 +48,128 @@ import (
 // Server реализует gRPC сервис для работы с расписанием
 type Server struct {
 	pb.UnimplementedScheduleServiceServer
-	scheduleService *schedule.Service
-	jwtManager      *jwt.Manager
-	userService     *users.Service
+	scheduleService     *schedule.Service
+	jwtManager          *jwt.Manager
+	userService         *users.Service
+	changeService       *changes.Service
+	notificationService *notifications.Service
+	maintenanceService  *maintenance.Service
+	jobQueue            *jobs.Queue
+	featureFlags        *features.Client
+	calendarFeed        *calendar.Feed
+	timetableRenderer   *timetable.Renderer
+	reportStorage       storage.Storage
+	reportURLTTL        time.Duration
+	electiveService     *electives.Service
+	noteService         *notes.Service
+	buildingService     *buildings.Service
+	rolloverService     *rollover.Service
+	consultationService *consultations.Service
+	kioskService        *kiosk.Service
+	personalService     *personal.Service
+	feedbackService     *feedback.Service
+	pollTimeout         time.Duration
+}
+
+// Dependencies сервисы, используемые gRPC сервером расписания
+type Dependencies struct {
+	ScheduleService     *schedule.Service
+	JWTManager          *jwt.Manager
+	UserService         *users.Service
+	ChangeService       *changes.Service
+	NotificationService *notifications.Service
+	MaintenanceService  *maintenance.Service
+	JobQueue            *jobs.Queue
+	FeatureFlags        *features.Client
+	CalendarFeed        *calendar.Feed      // Подписка на календарь; nil - отключена
+	TimetableRenderer   *timetable.Renderer // Печатная версия расписания; nil - шрифт не найден
+	ReportStorage       storage.Storage     // Хранилище сформированных PDF; nil - PDF только в ответе
+	ReportURLTTL        time.Duration       // Время действия ссылки на PDF в хранилище
+	ElectiveService     *electives.Service
+	NoteService         *notes.Service
+	BuildingService     *buildings.Service
+	RolloverService     *rollover.Service
+	ConsultationService *consultations.Service
+	KioskService        *kiosk.Service
+	PersonalService     *personal.Service
+	FeedbackService     *feedback.Service // Жалобы на ошибки в расписании; nil - не принимаются
+	PollTimeout         time.Duration     // Максимальное ожидание PollUpdates (по умолчанию - минута)
 }
 
 // NewServer создает новый gRPC сервер для расписания
-func NewServer(scheduleService *schedule.Service, jwtManager *jwt.Manager, userService *users.Service) *Server {
+func NewServer(deps Dependencies) *Server {
 	return &Server{
-		scheduleService: scheduleService,
-		jwtManager:      jwtManager,
-		userService:     userService,
+		scheduleService:     deps.ScheduleService,
+		jwtManager:          deps.JWTManager,
+		userService:         deps.UserService,
+		changeService:       deps.ChangeService,
+		notificationService: deps.NotificationService,
+		maintenanceService:  deps.MaintenanceService,
+		jobQueue:            deps.JobQueue,
+		featureFlags:        deps.FeatureFlags,
+		calendarFeed:        deps.CalendarFeed,
+		timetableRenderer:   deps.TimetableRenderer,
+		reportStorage:       deps.ReportStorage,
+		reportURLTTL:        deps.ReportURLTTL,
+		electiveService:     deps.ElectiveService,
+		noteService:         deps.NoteService,
+		buildingService:     deps.BuildingService,
+		rolloverService:     deps.RolloverService,
+		consultationService: deps.ConsultationService,
+		kioskService:        deps.KioskService,
+		personalService:     deps.PersonalService,
+		feedbackService:     deps.FeedbackService,
+		pollTimeout:         deps.PollTimeout,
 	}
 }
 
 // GetScheduleForGroup получает расписание для группы на определенную дату
 func (s *Server) GetScheduleForGroup(ctx context.Context, req *pb.GetScheduleForGroupRequest) (*pb.GetScheduleForGroupResponse, error) {
-	log.Printf("Получен запрос на получение расписания для группы: %s", req.GroupName)
+	requestid.Logf(ctx, "Получен запрос на получение расписания для группы: %s", req.GroupName)
 
 	// Проверяем токен
-	claims, err := s.jwtManager.ParseToken(req.Token)
+	claims, err := s.parseToken(ctx, req.Token)
 	if err != nil {
-		log.Printf("Ошибка проверки токена: %v", err)
-		return nil, status.Errorf(codes.Unauthenticated, "Неверный токен")
+		return nil, err
 	}
 
-	// Проверяем существование пользователя (временно не используем данные)
-	_, err = s.userService.GetUserByID(ctx, claims.UserID)
-	if err != nil {
-		log.Printf("Ошибка получения пользователя %s: %v", claims.UserID, err)
-		return nil, status.Errorf(codes.NotFound, "Пользователь не найден")
+	var user *users.User
+	if claims.IsGuest() {
+		// Гостю доступно только расписание группы, к которой привязан токен
+		if req.GroupName != claims.GroupName {
+			return nil, status.Errorf(codes.PermissionDenied, "Гостевой доступ открыт только к расписанию группы %s", claims.GroupName)
+		}
+	} else if user, err = s.userFromClaims(ctx, claims); err != nil {
+		return nil, err
 	}
 
 	// TODO: Проверить права доступа пользователя к расписанию группы
 	// Например, студент может просматривать только расписание своей группы
 
-	// Получаем расписание для группы
-	scheduleEntries, err := s.scheduleService.GetScheduleForGroup(ctx, req.GroupName, req.Date.AsTime())
+	// Получаем расписание для группы (текущее, по состоянию на момент as_of
+	// или с архивом прошедших семестров)
+	var scheduleEntries []schedule.CurrentSchedule
+	if req.AsOf != nil {
+		scheduleEntries, err = s.scheduleService.GetScheduleForGroupAsOf(ctx, req.GroupName, req.Date.AsTime(), req.AsOf.AsTime())
+	} else if req.IncludeArchive {
+		scheduleEntries, err = s.scheduleService.GetScheduleForGroupWithArchive(ctx, req.GroupName, req.Date.AsTime())
+	} else {
+		scheduleEntries, err = s.scheduleService.GetScheduleForGroup(ctx, req.GroupName, req.Date.AsTime())
+	}
 	if err != nil {
-		log.Printf("Ошибка получения расписания для группы %s: %v", req.GroupName, err)
-		return nil, status.Errorf(codes.Internal, "Ошибка получения расписания: %v", err)
+		requestid.Logf(ctx, "Ошибка получения расписания для группы %s: %v", req.GroupName, err)
+		return nil, middleware.Status(err, "Ошибка получения расписания")
 	}
+	if user != nil {
+		scheduleEntries = schedule.ForSubgroup(scheduleEntries, s.studentSubgroup(ctx, user, req.GroupName))
+	}
+	scheduleEntries = schedule.ForLessonType(scheduleEntries, req.LessonType)
 
 	// Преобразуем записи расписания в формат protobuf
-	var pbSchedule []*pb.ScheduleEntry
-	for _, entry := range scheduleEntries {
-		// Преобразуем SourceType в protobuf enum
-		var sourceTypeEnum pb.ScheduleSourceType
-		switch entry.SourceType {
-		case "main":
-			sourceTypeEnum = pb.ScheduleSourceType_SCHEDULE_SOURCE_TYPE_MAIN
-		case "change":
-			sourceTypeEnum = pb.ScheduleSourceType_SCHEDULE_SOURCE_TYPE_CHANGE
-		default:
-			// По умолчанию используем UNDEFINED или логируем ошибку
-			sourceTypeEnum = pb.ScheduleSourceType_SCHEDULE_SOURCE_TYPE_UNSPECIFIED
-			log.Printf("Неизвестный тип источника: %s", entry.SourceType)
-		}
-
-		pbEntry := &pb.ScheduleEntry{
-			Id:         entry.ID.String(),
-			GroupName:  entry.GroupName,
-			Date:       timestamppb.New(entry.Date),
-			TimeStart:  entry.TimeStart,
-			TimeEnd:    entry.TimeEnd,
-			Subject:    entry.Subject,
-			Teacher:    entry.Teacher,
-			Classroom:  entry.Classroom,
-			SourceType: sourceTypeEnum,
-			SourceId:   entry.SourceID.String(),
-		}
-		pbSchedule = append(pbSchedule, pbEntry)
+	pbSchedule := s.toPBScheduleEntries(ctx, scheduleEntries)
+	s.attachBuildings(ctx, scheduleEntries, pbSchedule)
+	if user != nil {
+		s.attachNotes(ctx, user.ID, scheduleEntries, pbSchedule)
 	}
 
 	// Формируем ответ
@@ -98,25 +179,25 @@ func (s *Server) GetScheduleForGroup(ctx context.Context, req *pb.GetScheduleFor
 		Schedule: pbSchedule,
 	}
 
-	log.Printf("Расписание для группы %s на дату %s успешно получено", req.GroupName, req.Date.AsTime().Format("2006-01-02"))
+	requestid.Logf(ctx, "Расписание для группы %s на дату %s успешно получено", req.GroupName, req.Date.AsTime().Format("2006-01-02"))
 	return response, nil
 }
 
-// GetActiveScheduleSnapshot получает активный снапшот расписания
+// GetActiveScheduleSnapshot получает метаданные активного снапшота расписания
 func (s *Server) GetActiveScheduleSnapshot(ctx context.Context, req *pb.GetActiveScheduleSnapshotRequest) (*pb.GetActiveScheduleSnapshotResponse, error) {
 	log.Println("Получен запрос на получение активного снапшота расписания")
 
 	// Проверяем токен
-	claims, err := s.jwtManager.ParseToken(req.Token)
+	claims, err := s.jwtManager.ParseToken(ctx, req.Token)
 	if err != nil {
-		log.Printf("Ошибка проверки токена: %v", err)
+		requestid.Logf(ctx, "Ошибка проверки токена: %v", err)
 		return nil, status.Errorf(codes.Unauthenticated, "Неверный токен")
 	}
 
 	// Получаем информацию о пользователе (временно не используем данные)
 	_, err = s.userService.GetUserByID(ctx, claims.UserID)
 	if err != nil {
-		log.Printf("Ошибка получения пользователя %s: %v", claims.UserID, err)
+		requestid.Logf(ctx, "Ошибка получения пользователя %s: %v", claims.UserID, err)
 		return nil, status.Errorf(codes.NotFound, "Пользователь не найден")
 	}
 
@@ -125,27 +206,15 @@ func (s *Server) GetActiveScheduleSnapshot(ctx context.Context, req *pb.GetActiv
 	// Получаем активный снапшот
 	snapshot, err := s.scheduleService.GetActiveScheduleSnapshot(ctx)
 	if err != nil {
-		log.Printf("Ошибка получения активного снапшота: %v", err)
-		return nil, status.Errorf(codes.Internal, "Ошибка получения снапшота: %v", err)
-	}
-
-	// Преобразуем снапшот в формат protobuf
-	pbSnapshot := &pb.ScheduleSnapshot{
-		Id:          snapshot.ID.String(),
-		Name:        snapshot.Name,
-		PeriodStart: timestamppb.New(snapshot.PeriodStart),
-		PeriodEnd:   timestamppb.New(snapshot.PeriodEnd),
-		Data:        string(snapshot.Data),
-		CreatedAt:   timestamppb.New(snapshot.CreatedAt),
-		SourceUrl:   snapshot.SourceURL,
-		IsActive:    snapshot.IsActive,
+		requestid.Logf(ctx, "Ошибка получения активного снапшота: %v", err)
+		return nil, middleware.Status(err, "Ошибка получения снапшота")
 	}
 
-	// Формируем ответ
+	// Данные снапшота получаются отдельно через GetSnapshotData
 	response := &pb.GetActiveScheduleSnapshotResponse{
 		Success:  true,
 		Message:  "Активный снапшот получен успешно",
-		Snapshot: pbSnapshot,
+		Snapshot: toPBSnapshotMeta(snapshot),
 	}
 
 	log.Println("Активный снапшот расписания успешно получен")
@@ -157,24 +226,32 @@ func (s *Server) GetScheduleSnapshotsHistory(ctx context.Context, req *pb.GetSch
 	log.Println("Получен запрос на получение истории снапшотов расписания")
 
 	// Проверяем токен
-	claims, err := s.jwtManager.ParseToken(req.Token)
+	claims, err := s.jwtManager.ParseToken(ctx, req.Token)
 	if err != nil {
-		log.Printf("Ошибка проверки токена: %v", err)
+		requestid.Logf(ctx, "Ошибка проверки токена: %v", err)
 		return nil, status.Errorf(codes.Unauthenticated, "Неверный токен")
 	}
 
 	// Получаем информацию о пользователе (временно не используем данные)
 	_, err = s.userService.GetUserByID(ctx, claims.UserID)
 	if err != nil {
-		log.Printf("Ошибка получения пользователя %s: %v", claims.UserID, err)
+		requestid.Logf(ctx, "Ошибка получения пользователя %s: %v", claims.UserID, err)
 		return nil, status.Errorf(codes.NotFound, "Пользователь не найден")
 	}
 
 	// TODO: Проверить права доступа пользователя (администратор может видеть все)
 
-	// TODO: Реализовать получение истории снапшотов
-	// Пока возвращаем пустой список
-	pbSnapshots := []*pb.ScheduleSnapshot{}
+	snapshots, err := s.scheduleService.GetSnapshotsHistory(ctx, int(req.Limit))
+	if err != nil {
+		requestid.Logf(ctx, "Ошибка получения истории снапшотов: %v", err)
+		return nil, middleware.Status(err, "Ошибка получения истории снапшотов")
+	}
+
+	// Данные расписания в историю не включаются, только метаданные
+	pbSnapshots := make([]*pb.ScheduleSnapshot, 0, len(snapshots))
+	for i := range snapshots {
+		pbSnapshots = append(pbSnapshots, toPBSnapshotMeta(&snapshots[i]))
+	}
 
 	// Формируем ответ
 	response := &pb.GetScheduleSnapshotsHistoryResponse{
@@ -187,8 +264,2979 @@ func (s *Server) GetScheduleSnapshotsHistory(ctx context.Context, req *pb.GetSch
 	return response, nil
 }
 
-// RegisterService регистрирует сервис в gRPC сервере
-func RegisterService(grpcServer *grpc.Server, scheduleService *schedule.Service, jwtManager *jwt.Manager, userService *users.Service) {
-	pb.RegisterScheduleServiceServer(grpcServer, NewServer(scheduleService, jwtManager, userService))
+// GetSnapshotData получает JSON данные снапшота расписания
+func (s *Server) GetSnapshotData(ctx context.Context, req *pb.GetSnapshotDataRequest) (*pb.GetSnapshotDataResponse, error) {
+	requestid.Logf(ctx, "Получен запрос на получение данных снапшота %s", req.SnapshotId)
+
+	claims, err := s.jwtManager.ParseToken(ctx, req.Token)
+	if err != nil {
+		requestid.Logf(ctx, "Ошибка проверки токена: %v", err)
+		return nil, status.Errorf(codes.Unauthenticated, "Неверный токен")
+	}
+	user, err := s.userService.GetUserByID(ctx, claims.UserID)
+	if err != nil {
+		requestid.Logf(ctx, "Ошибка получения пользователя %s: %v", claims.UserID, err)
+		return nil, status.Errorf(codes.NotFound, "Пользователь не найден")
+	}
+
+	id, err := uuid.Parse(req.SnapshotId)
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "Неверный ID снапшота: %s", req.SnapshotId)
+	}
+
+	var data []byte
+	if req.GroupName != "" {
+		// Расписание одной группы выбирается в базе, весь снапшот не передается
+		days, err := s.scheduleService.GetScheduleFromSnapshot(ctx, id, req.GroupName)
+		if err != nil {
+			requestid.Logf(ctx, "Ошибка получения расписания группы %s из снапшота: %v", req.GroupName, err)
+			return nil, middleware.Status(err, "Ошибка получения данных снапшота")
+		}
+		days = schedule.DaysForSubgroup(days, s.studentSubgroup(ctx, user, req.GroupName))
+		if data, err = json.Marshal(days); err != nil {
+			return nil, status.Errorf(codes.Internal, "Ошибка получения данных снапшота")
+		}
+	} else {
+		data, err = s.scheduleService.GetSnapshotData(ctx, id)
+		if err != nil {
+			requestid.Logf(ctx, "Ошибка получения данных снапшота: %v", err)
+			return nil, middleware.Status(err, "Ошибка получения данных снапшота")
+		}
+	}
+
+	return &pb.GetSnapshotDataResponse{
+		Success:    true,
+		Message:    "Данные снапшота получены успешно",
+		SnapshotId: id.String(),
+		Data:       string(data),
+	}, nil
+}
+
+// GetMySchedule получает расписание текущего пользователя.
+// Для студента группа берется из профиля, для преподавателя - занятия по его ФИО,
+// для гостя - группа, к которой привязан гостевой токен.
+func (s *Server) GetMySchedule(ctx context.Context, req *pb.GetMyScheduleRequest) (*pb.GetMyScheduleResponse, error) {
+	log.Println("Получен запрос на получение расписания текущего пользователя")
+
+	claims, err := s.parseToken(ctx, req.Token)
+	if err != nil {
+		return nil, err
+	}
+
+	// Определяем период: один день или неделя, в которую входит дата
+	loc := s.scheduleService.Location()
+	from := clock.Today(loc)
+	if req.Date != nil {
+		from = clock.DateOf(req.Date.AsTime(), loc)
+	}
+	to := from
+	if req.Week {
+		from = clock.WeekStart(from)
+		to = from.AddDate(0, 0, 6)
+	}
+
+	if claims.IsGuest() {
+		entries, err := s.scheduleService.GetScheduleForGroupRange(ctx, claims.GroupName, from, to)
+		if err != nil {
+			requestid.Logf(ctx, "Ошибка получения расписания для группы %s: %v", claims.GroupName, err)
+			return nil, middleware.Status(err, "Ошибка получения расписания")
+		}
+		entries = schedule.ForLessonType(entries, req.LessonType)
+		return &pb.GetMyScheduleResponse{
+			Success:   true,
+			Message:   "Расписание получено успешно",
+			Schedule:  s.toPBScheduleEntries(ctx, entries),
+			GroupName: claims.GroupName,
+		}, nil
+	}
+
+	user, err := s.userFromClaims(ctx, claims)
+	if err != nil {
+		return nil, err
+	}
+
+	entries, groupName, err := s.personalService.Schedule(ctx, user, from, to)
+	if err != nil {
+		requestid.Logf(ctx, "Ошибка получения расписания пользователя %s: %v", user.ID, err)
+		return nil, middleware.Status(err, "Ошибка получения расписания")
+	}
+	entries = schedule.ForLessonType(entries, req.LessonType)
+
+	pbSchedule := s.toPBScheduleEntries(ctx, entries)
+	s.attachBuildings(ctx, entries, pbSchedule)
+	s.attachNotes(ctx, user.ID, entries, pbSchedule)
+
+	response := &pb.GetMyScheduleResponse{
+		Success:   true,
+		Message:   "Расписание получено успешно",
+		Schedule:  pbSchedule,
+		GroupName: groupName,
+	}
+
+	requestid.Logf(ctx, "Расписание пользователя %s с %s по %s успешно получено", user.Email, from.Format("2006-01-02"), to.Format("2006-01-02"))
+	return response, nil
+}
+
+// clientCacheMaxAge максимальное время кэширования клиентом ответов, зависящих от
+// времени (сводка виджета, состояние звонков): расписание может измениться раньше,
+// чем ответ устареет по времени
+const clientCacheMaxAge = 5 * time.Minute
+
+// GetWidgetSummary возвращает краткую сводку текущего дня для виджета
+func (s *Server) GetWidgetSummary(ctx context.Context, req *pb.GetWidgetSummaryRequest) (*pb.GetWidgetSummaryResponse, error) {
+	claims, err := s.parseToken(ctx, req.Token)
+	if err != nil {
+		return nil, err
+	}
+
+	now := clock.Now(s.scheduleService.Location())
+	today := clock.DateOf(now, now.Location())
+	var entries []schedule.CurrentSchedule
+	if claims.IsGuest() {
+		entries, err = s.scheduleService.GetScheduleForGroupRange(ctx, claims.GroupName, today, today)
+		if err != nil {
+			requestid.Logf(ctx, "Ошибка получения расписания для группы %s: %v", claims.GroupName, err)
+			return nil, middleware.Status(err, "Ошибка получения расписания")
+		}
+	} else {
+		user, err := s.userFromClaims(ctx, claims)
+		if err != nil {
+			return nil, err
+		}
+		if entries, _, err = s.personalService.Schedule(ctx, user, today, today); err != nil {
+			requestid.Logf(ctx, "Ошибка получения расписания пользователя %s: %v", user.ID, err)
+			return nil, middleware.Status(err, "Ошибка получения расписания")
+		}
+	}
+
+	summary := schedule.SummarizeDay(entries, bells.For(ctx), now)
+	response := &pb.GetWidgetSummaryResponse{
+		CurrentLesson:  toPBWidgetLesson(summary.Current),
+		NextLesson:     toPBWidgetLesson(summary.Next),
+		RemainingToday: int32(summary.RemainingToday),
+		ValidUntil:     timestamppb.New(summary.ValidUntil),
+	}
+	if summary.NextBell != nil {
+		response.NextBell = timestamppb.New(*summary.NextBell)
+		response.MinutesUntilNextBell = int32(math.Ceil(summary.NextBell.Sub(now).Minutes()))
+	}
+
+	setClientCache(ctx, summary.ValidUntil.Sub(now))
+	return response, nil
+}
+
+// bellStates состояния учебного дня в формате protobuf
+var bellStates = map[string]pb.BellState{
+	bells.StateLesson:        pb.BellState_BELL_STATE_LESSON,
+	bells.StateBreak:         pb.BellState_BELL_STATE_BREAK,
+	bells.StateBeforeClasses: pb.BellState_BELL_STATE_BEFORE_CLASSES,
+	bells.StateAfterClasses:  pb.BellState_BELL_STATE_AFTER_CLASSES,
+	bells.StateDayOff:        pb.BellState_BELL_STATE_DAY_OFF,
+}
+
+// GetBellStatus возвращает состояние учебного дня по расписанию звонков
+func (s *Server) GetBellStatus(ctx context.Context, req *pb.GetBellStatusRequest) (*pb.GetBellStatusResponse, error) {
+	if _, err := s.parseToken(ctx, req.Token); err != nil {
+		return nil, err
+	}
+
+	now := clock.Now(s.scheduleService.Location())
+	bell := bells.For(ctx).StatusAt(now)
+	response := &pb.GetBellStatusResponse{
+		State:       bellStates[bell.State],
+		Until:       timestamppb.New(bell.Until),
+		MinutesLeft: int32(math.Ceil(bell.Until.Sub(now).Minutes())),
+		ServerTime:  timestamppb.New(now),
+	}
+	if bell.Lesson != nil {
+		response.LessonNumber = int32(bell.Lesson.Number)
+		response.TimeStart = bell.Lesson.TimeStart
+		response.TimeEnd = bell.Lesson.TimeEnd
+	}
+
+	setClientCache(ctx, bell.Until.Sub(now))
+	return response, nil
+}
+
+// setClientCache разрешает клиентам REST-фасада кэшировать ответ на время ttl
+// (не дольше clientCacheMaxAge)
+func setClientCache(ctx context.Context, ttl time.Duration) {
+	maxAge := min(ttl, clientCacheMaxAge)
+	_ = grpc.SetHeader(ctx, metadata.Pairs("cache-control", fmt.Sprintf("private, max-age=%d", int(maxAge.Seconds()))))
+}
+
+// GetNextLesson возвращает ближайшее занятие пользователя (гостю - группы токена)
+func (s *Server) GetNextLesson(ctx context.Context, req *pb.GetNextLessonRequest) (*pb.GetNextLessonResponse, error) {
+	claims, err := s.parseToken(ctx, req.Token)
+	if err != nil {
+		return nil, err
+	}
+
+	now := clock.Now(s.scheduleService.Location())
+	var next *personal.Next
+	if claims.IsGuest() {
+		next, err = s.personalService.NextGroupLesson(ctx, claims.GroupName, now)
+	} else {
+		var user *users.User
+		if user, err = s.userFromClaims(ctx, claims); err != nil {
+			return nil, err
+		}
+		next, err = s.personalService.NextLesson(ctx, user, now)
+	}
+	if err != nil {
+		requestid.Logf(ctx, "Ошибка поиска ближайшего занятия: %v", err)
+		return nil, middleware.Status(err, "Ошибка получения расписания")
+	}
+
+	if next == nil {
+		return &pb.GetNextLessonResponse{
+			Success: true,
+			Message: "В ближайшие две недели занятий нет",
+		}, nil
+	}
+	return &pb.GetNextLessonResponse{
+		Success:         true,
+		Message:         "Ближайшее занятие найдено",
+		Lesson:          s.toPBScheduleEntries(ctx, []schedule.CurrentSchedule{next.Entry})[0],
+		Consultation:    next.Entry.SourceType == personal.ConsultationSource,
+		StartsInMinutes: int32(math.Ceil(next.Start.Sub(now).Minutes())),
+	}, nil
+}
+
+// FindFreeSlots находит общие свободные окна для групп и/или преподавателя
+func (s *Server) FindFreeSlots(ctx context.Context, req *pb.FindFreeSlotsRequest) (*pb.FindFreeSlotsResponse, error) {
+	requestid.Logf(ctx, "Получен запрос на поиск свободных окон для групп %v и преподавателя %q", req.GroupNames, req.Teacher)
+
+	if _, err := s.authenticate(ctx, req.Token); err != nil {
+		return nil, err
+	}
+
+	if len(req.GroupNames) == 0 && req.Teacher == "" {
+		return nil, status.Errorf(codes.InvalidArgument, "Необходимо указать хотя бы одну группу или преподавателя")
+	}
+	if req.Date == nil {
+		return nil, status.Errorf(codes.InvalidArgument, "Необходимо указать дату")
+	}
+
+	minDuration := time.Duration(req.MinDurationMinutes) * time.Minute
+	slots, err := s.scheduleService.FindFreeSlots(ctx, req.Date.AsTime(), req.GroupNames, req.Teacher, minDuration)
+	if err != nil {
+		requestid.Logf(ctx, "Ошибка поиска свободных окон: %v", err)
+		return nil, middleware.Status(err, "Ошибка поиска свободных окон")
+	}
+
+	pbSlots := make([]*pb.FreeSlot, 0, len(slots))
+	for _, slot := range slots {
+		pbSlot := &pb.FreeSlot{
+			TimeStart: slot.TimeStart,
+			TimeEnd:   slot.TimeEnd,
+		}
+		for _, number := range slot.LessonNumbers {
+			pbSlot.LessonNumbers = append(pbSlot.LessonNumbers, int32(number))
+		}
+		pbSlots = append(pbSlots, pbSlot)
+	}
+
+	return &pb.FindFreeSlotsResponse{
+		Success: true,
+		Message: "Свободные окна найдены",
+		Slots:   pbSlots,
+	}, nil
+}
+
+// GetWorkloadStats возвращает часы по предметам для группы или преподавателя
+func (s *Server) GetWorkloadStats(ctx context.Context, req *pb.GetWorkloadStatsRequest) (*pb.GetWorkloadStatsResponse, error) {
+	requestid.Logf(ctx, "Получен запрос статистики нагрузки: группа %q, преподаватель %q", req.GroupName, req.Teacher)
+
+	if _, err := s.authenticate(ctx, req.Token); err != nil {
+		return nil, err
+	}
+
+	if req.GroupName == "" && req.Teacher == "" {
+		return nil, status.Errorf(codes.InvalidArgument, "Необходимо указать группу или преподавателя")
+	}
+	if req.From == nil || req.To == nil {
+		return nil, status.Errorf(codes.InvalidArgument, "Необходимо указать период")
+	}
+
+	stats, err := s.scheduleService.GetWorkloadStats(ctx, schedule.WorkloadFilter{
+		GroupName: req.GroupName,
+		Teacher:   req.Teacher,
+		From:      req.From.AsTime(),
+		To:        req.To.AsTime(),
+		ByWeek:    req.ByWeek,
+	})
+	if err != nil {
+		requestid.Logf(ctx, "Ошибка получения статистики нагрузки: %v", err)
+		return nil, middleware.Status(err, "Ошибка получения статистики")
+	}
+
+	pbStats := make([]*pb.WorkloadStat, 0, len(stats))
+	for _, stat := range stats {
+		pbStats = append(pbStats, toPBWorkloadStat(stat))
+	}
+
+	return &pb.GetWorkloadStatsResponse{
+		Success: true,
+		Message: "Статистика нагрузки получена успешно",
+		Stats:   pbStats,
+	}, nil
+}
+
+// GetChangeStats возвращает статистику изменений расписания для экрана аналитики
+func (s *Server) GetChangeStats(ctx context.Context, req *pb.GetChangeStatsRequest) (*pb.GetChangeStatsResponse, error) {
+	if _, err := s.authenticateAdmin(ctx, req.Token); err != nil {
+		return nil, err
+	}
+
+	if req.From == nil || req.To == nil {
+		return nil, status.Errorf(codes.InvalidArgument, "Необходимо указать период")
+	}
+
+	stats, err := s.scheduleService.GetChangeStats(ctx, req.From.AsTime(), req.To.AsTime(), int(req.Top))
+	if err != nil {
+		requestid.Logf(ctx, "Ошибка получения статистики изменений: %v", err)
+		return nil, middleware.Status(err, "Ошибка получения статистики")
+	}
+
+	response := &pb.GetChangeStatsResponse{
+		Success: true,
+		Message: "Статистика изменений получена успешно",
+	}
+	for _, stat := range stats.ByGroupMonth {
+		response.ByGroupMonth = append(response.ByGroupMonth, &pb.GroupMonthChanges{
+			GroupName:     stat.GroupName,
+			Month:         timestamppb.New(stat.Month),
+			Total:         int32(stat.Total),
+			Replacements:  int32(stat.Replacements),
+			Cancellations: int32(stat.Cancellations),
+			Additions:     int32(stat.Additions),
+		})
+	}
+	for _, stat := range stats.CancelledSubjects {
+		response.CancelledSubjects = append(response.CancelledSubjects, &pb.SubjectCancellations{
+			Subject:       stat.Subject,
+			Cancellations: int32(stat.Cancellations),
+			Groups:        int32(stat.Groups),
+		})
+	}
+	for _, stat := range stats.ReplacementDays {
+		response.ReplacementDays = append(response.ReplacementDays, &pb.DayReplacements{
+			Date:         timestamppb.New(stat.Date),
+			Replacements: int32(stat.Replacements),
+			Groups:       int32(stat.Groups),
+		})
+	}
+
+	return response, nil
+}
+
+// RunMaintenance запускает задачи обслуживания вне расписания
+func (s *Server) RunMaintenance(ctx context.Context, req *pb.RunMaintenanceRequest) (*pb.RunMaintenanceResponse, error) {
+	admin, err := s.authenticateAdmin(ctx, req.Token)
+	if err != nil {
+		return nil, err
+	}
+
+	if s.maintenanceService == nil {
+		return nil, status.Errorf(codes.Unavailable, "Задачи обслуживания не настроены")
+	}
+
+	requestid.Logf(ctx, "Администратор %s запускает задачи обслуживания", admin.Email)
+	s.maintenanceService.RunOnce(ctx)
+
+	return &pb.RunMaintenanceResponse{
+		Success: true,
+		Message: "Задачи обслуживания выполнены",
+	}, nil
+}
+
+// SearchSchedule выполняет нечеткий поиск по предметам, преподавателям и аудиториям
+func (s *Server) SearchSchedule(ctx context.Context, req *pb.SearchScheduleRequest) (*pb.SearchScheduleResponse, error) {
+	requestid.Logf(ctx, "Получен запрос поиска по расписанию: %q", req.Query)
+
+	if _, err := s.authenticate(ctx, req.Token); err != nil {
+		return nil, err
+	}
+
+	var from, to time.Time
+	if req.From != nil {
+		from = req.From.AsTime()
+	}
+	if req.To != nil {
+		to = req.To.AsTime()
+	}
+
+	results, err := s.scheduleService.SearchSchedule(ctx, req.Query, from, to, int(req.Limit))
+	if err != nil {
+		requestid.Logf(ctx, "Ошибка поиска по расписанию: %v", err)
+		return nil, middleware.Status(err, "Ошибка поиска")
+	}
+
+	subjects := make([]string, 0, len(results))
+	for _, result := range results {
+		subjects = append(subjects, result.Entry.Subject)
+	}
+	subjectMeta := s.subjectMetadata(ctx, subjects)
+
+	pbResults := make([]*pb.SearchResult, 0, len(results))
+	for _, result := range results {
+		pbResults = append(pbResults, &pb.SearchResult{
+			Entry: toPBScheduleEntry(result.Entry, subjectMeta),
+			Rank:  result.Rank,
+		})
+	}
+
+	return &pb.SearchScheduleResponse{
+		Success: true,
+		Message: fmt.Sprintf("Найдено записей: %d", len(pbResults)),
+		Results: pbResults,
+	}, nil
+}
+
+// CompareSnapshots возвращает отличия расписания по группам между двумя снапшотами
+func (s *Server) CompareSnapshots(ctx context.Context, req *pb.CompareSnapshotsRequest) (*pb.CompareSnapshotsResponse, error) {
+	requestid.Logf(ctx, "Получен запрос на сравнение снапшотов %s и %s", req.SnapshotIdA, req.SnapshotIdB)
+
+	if _, err := s.authenticateAdmin(ctx, req.Token); err != nil {
+		return nil, err
+	}
+
+	idA, err := uuid.Parse(req.SnapshotIdA)
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "Неверный ID снапшота: %s", req.SnapshotIdA)
+	}
+	idB, err := uuid.Parse(req.SnapshotIdB)
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "Неверный ID снапшота: %s", req.SnapshotIdB)
+	}
+
+	diff, err := s.scheduleService.CompareSnapshots(ctx, idA, idB)
+	if err != nil {
+		requestid.Logf(ctx, "Ошибка сравнения снапшотов: %v", err)
+		return nil, middleware.Status(err, "Ошибка сравнения снапшотов")
+	}
+
+	pbGroups := make([]*pb.GroupDiff, 0, len(diff.Groups))
+	for _, group := range diff.Groups {
+		pbGroup := &pb.GroupDiff{
+			GroupName: group.GroupName,
+			Added:     toPBSnapshotLessons(group.Added),
+			Removed:   toPBSnapshotLessons(group.Removed),
+		}
+		switch group.Status {
+		case schedule.GroupDiffAdded:
+			pbGroup.Status = pb.GroupDiffStatus_GROUP_DIFF_STATUS_ADDED
+		case schedule.GroupDiffRemoved:
+			pbGroup.Status = pb.GroupDiffStatus_GROUP_DIFF_STATUS_REMOVED
+		case schedule.GroupDiffChanged:
+			pbGroup.Status = pb.GroupDiffStatus_GROUP_DIFF_STATUS_CHANGED
+		}
+		for _, change := range group.Changed {
+			pbGroup.Changed = append(pbGroup.Changed, &pb.LessonChange{
+				Before:        toPBSnapshotLesson(change.Before),
+				After:         toPBSnapshotLesson(change.After),
+				ChangedFields: change.ChangedFields,
+			})
+		}
+		pbGroups = append(pbGroups, pbGroup)
+	}
+
+	return &pb.CompareSnapshotsResponse{
+		Success:   true,
+		Message:   fmt.Sprintf("Групп с изменениями: %d", len(pbGroups)),
+		SnapshotA: toPBSnapshotMeta(diff.SnapshotA),
+		SnapshotB: toPBSnapshotMeta(diff.SnapshotB),
+		Groups:    pbGroups,
+	}, nil
+}
+
+// ListSubjectMetadata возвращает параметры отображения всех предметов
+func (s *Server) ListSubjectMetadata(ctx context.Context, req *pb.ListSubjectMetadataRequest) (*pb.ListSubjectMetadataResponse, error) {
+	log.Println("Получен запрос параметров отображения предметов")
+
+	if _, err := s.authenticate(ctx, req.Token); err != nil {
+		return nil, err
+	}
+
+	items, err := s.scheduleService.ListSubjectMetadata(ctx)
+	if err != nil {
+		requestid.Logf(ctx, "Ошибка получения параметров предметов: %v", err)
+		return nil, middleware.Status(err, "Ошибка получения параметров предметов")
+	}
+
+	pbItems := make([]*pb.SubjectMetadata, 0, len(items))
+	for _, meta := range items {
+		pbItems = append(pbItems, toPBSubjectMetadata(meta))
+	}
+
+	return &pb.ListSubjectMetadataResponse{
+		Success:  true,
+		Message:  "Параметры предметов получены успешно",
+		Subjects: pbItems,
+	}, nil
+}
+
+// UpsertSubjectMetadata сохраняет параметры отображения предмета
+func (s *Server) UpsertSubjectMetadata(ctx context.Context, req *pb.UpsertSubjectMetadataRequest) (*pb.UpsertSubjectMetadataResponse, error) {
+	user, err := s.authenticateAdmin(ctx, req.Token)
+	if err != nil {
+		return nil, err
+	}
+	if req.Subject == nil {
+		return nil, status.Errorf(codes.InvalidArgument, "Не указаны параметры предмета")
+	}
+
+	requestid.Logf(ctx, "Администратор %s изменяет параметры предмета %q", user.Email, req.Subject.Subject)
+
+	meta := &schedule.SubjectMetadata{
+		Subject:   req.Subject.Subject,
+		ShortName: req.Subject.ShortName,
+		Color:     req.Subject.Color,
+		Icon:      req.Subject.Icon,
+		UpdatedBy: &user.ID,
+	}
+	if err := s.scheduleService.UpsertSubjectMetadata(ctx, meta); err != nil {
+		requestid.Logf(ctx, "Ошибка сохранения параметров предмета: %v", err)
+		return nil, middleware.Status(err, "Ошибка сохранения параметров предмета")
+	}
+
+	return &pb.UpsertSubjectMetadataResponse{
+		Success: true,
+		Message: "Параметры предмета сохранены",
+		Subject: toPBSubjectMetadata(*meta),
+	}, nil
+}
+
+// DeleteSubjectMetadata удаляет параметры отображения предмета
+func (s *Server) DeleteSubjectMetadata(ctx context.Context, req *pb.DeleteSubjectMetadataRequest) (*pb.DeleteSubjectMetadataResponse, error) {
+	user, err := s.authenticateAdmin(ctx, req.Token)
+	if err != nil {
+		return nil, err
+	}
+
+	requestid.Logf(ctx, "Администратор %s удаляет параметры предмета %q", user.Email, req.Subject)
+
+	if err := s.scheduleService.DeleteSubjectMetadata(ctx, req.Subject); err != nil {
+		requestid.Logf(ctx, "Ошибка удаления параметров предмета: %v", err)
+		return nil, status.Errorf(codes.NotFound, "Параметры предмета не найдены")
+	}
+
+	return &pb.DeleteSubjectMetadataResponse{
+		Success: true,
+		Message: "Параметры предмета удалены",
+	}, nil
+}
+
+// ListOverlappingChanges возвращает изменения, добавленные пары которых
+// пересекаются с другими занятиями группы
+func (s *Server) ListOverlappingChanges(ctx context.Context, req *pb.ListOverlappingChangesRequest) (*pb.ListOverlappingChangesResponse, error) {
+	if _, err := s.authenticateAdmin(ctx, req.Token); err != nil {
+		return nil, err
+	}
+
+	loc := s.scheduleService.Location()
+	from := clock.Today(loc)
+	if req.From != nil {
+		from = req.From.AsTime()
+	}
+	to := from.AddDate(0, 0, 14)
+	if req.To != nil {
+		to = req.To.AsTime()
+	}
+
+	changes, err := s.scheduleService.GetOverlappingChanges(ctx, from, to)
+	if err != nil {
+		requestid.Logf(ctx, "Ошибка получения пересекающихся изменений: %v", err)
+		return nil, middleware.Status(err, "Ошибка получения изменений")
+	}
+
+	pbChanges := make([]*pb.ScheduleChange, 0, len(changes))
+	for _, change := range changes {
+		pbChanges = append(pbChanges, toPBScheduleChange(change))
+	}
+
+	return &pb.ListOverlappingChangesResponse{
+		Success: true,
+		Message: fmt.Sprintf("Изменений с пересечениями: %d", len(pbChanges)),
+		Changes: pbChanges,
+	}, nil
+}
+
+// ListSnapshotChanges возвращает все изменения, сделанные относительно снапшота
+func (s *Server) ListSnapshotChanges(ctx context.Context, req *pb.ListSnapshotChangesRequest) (*pb.ListSnapshotChangesResponse, error) {
+	requestid.Logf(ctx, "Получен запрос изменений снапшота %s", req.SnapshotId)
+
+	if _, err := s.authenticate(ctx, req.Token); err != nil {
+		return nil, err
+	}
+
+	snapshotID, err := uuid.Parse(req.SnapshotId)
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "Неверный ID снапшота: %s", req.SnapshotId)
+	}
+
+	changes, err := s.scheduleService.GetChangesForSnapshot(ctx, snapshotID)
+	if err != nil {
+		requestid.Logf(ctx, "Ошибка получения изменений снапшота %s: %v", snapshotID, err)
+		return nil, middleware.Status(err, "Ошибка получения изменений")
+	}
+
+	pbChanges := make([]*pb.ScheduleChange, 0, len(changes))
+	for _, change := range changes {
+		pbChanges = append(pbChanges, toPBScheduleChange(change))
+	}
+
+	return &pb.ListSnapshotChangesResponse{
+		Success: true,
+		Message: fmt.Sprintf("Изменений: %d", len(pbChanges)),
+		Changes: pbChanges,
+	}, nil
+}
+
+// ListChangesAwaitingModeration возвращает изменения, ожидающие одобрения администратором
+func (s *Server) ListChangesAwaitingModeration(ctx context.Context, req *pb.ListChangesAwaitingModerationRequest) (*pb.ListChangesAwaitingModerationResponse, error) {
+	if _, err := s.authenticateAdmin(ctx, req.Token); err != nil {
+		return nil, err
+	}
+
+	changes, err := s.changeService.ListAwaitingModeration(ctx)
+	if err != nil {
+		requestid.Logf(ctx, "Ошибка получения изменений на модерации: %v", err)
+		return nil, middleware.Status(err, "Ошибка получения изменений")
+	}
+
+	pbChanges := make([]*pb.ScheduleChange, 0, len(changes))
+	for _, change := range changes {
+		change.Date = clock.Anchor(change.Date, s.scheduleService.Location())
+		pbChanges = append(pbChanges, toPBScheduleChange(change))
+	}
+
+	return &pb.ListChangesAwaitingModerationResponse{
+		Success: true,
+		Message: fmt.Sprintf("Изменений на модерации: %d", len(pbChanges)),
+		Changes: pbChanges,
+	}, nil
 }
 
+// ReviewChange одобряет или отклоняет изменение.
+// Одобренное изменение сразу применяется к расписанию, и студентам уходят уведомления.
+func (s *Server) ReviewChange(ctx context.Context, req *pb.ReviewChangeRequest) (*pb.ReviewChangeResponse, error) {
+	admin, err := s.authenticateAdmin(ctx, req.Token)
+	if err != nil {
+		return nil, err
+	}
+
+	changeID, err := uuid.Parse(req.ChangeId)
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "Неверный ID изменения: %s", req.ChangeId)
+	}
+
+	switch req.Decision {
+	case pb.ReviewDecision_REVIEW_DECISION_APPROVE:
+		requestid.Logf(ctx, "Администратор %s одобряет изменение %s", admin.Email, changeID)
+
+		report, err := s.changeService.ApproveChange(ctx, changeID, admin.ID, toChangeEdit(req.Edit, s.scheduleService.Location()), req.Comment)
+		if err != nil {
+			requestid.Logf(ctx, "Ошибка одобрения изменения %s: %v", changeID, err)
+			return nil, middleware.Status(err, "Ошибка одобрения изменения")
+		}
+
+		s.notifyAppliedChanges(ctx, report)
+
+		response := &pb.ReviewChangeResponse{Success: true, Message: "Изменение одобрено"}
+		if len(report.Results) > 0 {
+			result := report.Results[0]
+			response.Change = toPBScheduleChange(result.Change)
+			if result.Status == schedule.ChangeApplyError {
+				response.Message = fmt.Sprintf("Изменение одобрено, но не применено: %s", result.Error)
+			}
+		}
+		return response, nil
+
+	case pb.ReviewDecision_REVIEW_DECISION_REJECT:
+		requestid.Logf(ctx, "Администратор %s отклоняет изменение %s", admin.Email, changeID)
+
+		change, err := s.changeService.RejectChange(ctx, changeID, admin.ID, req.Comment)
+		if err != nil {
+			requestid.Logf(ctx, "Ошибка отклонения изменения %s: %v", changeID, err)
+			return nil, middleware.Status(err, "Ошибка отклонения изменения")
+		}
+
+		return &pb.ReviewChangeResponse{
+			Success: true,
+			Message: "Изменение отклонено",
+			Change:  toPBScheduleChange(*change),
+		}, nil
+
+	default:
+		return nil, status.Errorf(codes.InvalidArgument, "Не указано решение модератора")
+	}
+}
+
+// SubmitTeacherChangeRequest сохраняет заявку преподавателя на отмену или перенос своей пары
+func (s *Server) SubmitTeacherChangeRequest(ctx context.Context, req *pb.SubmitTeacherChangeRequestRequest) (*pb.SubmitTeacherChangeRequestResponse, error) {
+	user, err := s.authenticate(ctx, req.Token)
+	if err != nil {
+		return nil, err
+	}
+	if user.Role != users.RoleTeacher {
+		return nil, status.Errorf(codes.PermissionDenied, "Заявки на изменение расписания доступны только преподавателям")
+	}
+
+	teacher, err := s.userService.GetTeacherProfile(ctx, user.ID)
+	if err != nil {
+		requestid.Logf(ctx, "Ошибка получения профиля преподавателя %s: %v", user.ID, err)
+		return nil, status.Errorf(codes.FailedPrecondition, "Профиль преподавателя не найден")
+	}
+
+	if req.GroupName == "" || req.Date == nil || req.TimeStart == "" {
+		return nil, status.Errorf(codes.InvalidArgument, "Необходимо указать группу, дату и время начала пары")
+	}
+
+	loc := s.scheduleService.Location()
+	request := &schedule.ChangeRequest{
+		GroupName:       req.GroupName,
+		Date:            clock.DateOf(req.Date.AsTime(), loc),
+		TimeStart:       req.TimeStart,
+		NewTimeStart:    req.NewTimeStart,
+		NewLessonNumber: int(req.NewLessonNumber),
+		NewClassroom:    req.NewClassroom,
+		Comment:         req.Comment,
+	}
+	switch req.Kind {
+	case pb.TeacherChangeRequestKind_TEACHER_CHANGE_REQUEST_KIND_CANCEL:
+		request.Kind = schedule.ChangeRequestCancel
+	case pb.TeacherChangeRequestKind_TEACHER_CHANGE_REQUEST_KIND_MOVE:
+		request.Kind = schedule.ChangeRequestMove
+	default:
+		return nil, status.Errorf(codes.InvalidArgument, "Не указан вид заявки")
+	}
+	if req.NewDate != nil {
+		newDate := clock.DateOf(req.NewDate.AsTime(), loc)
+		request.NewDate = &newDate
+	}
+
+	names, err := s.userService.TeacherNames(ctx, teacher)
+	if err != nil {
+		requestid.Logf(ctx, "Ошибка получения вариантов имени преподавателя %s: %v", teacher.FullName, err)
+		return nil, middleware.Status(err, "Ошибка сохранения заявки")
+	}
+
+	if err := s.changeService.SubmitChangeRequest(ctx, user.ID, names, request); err != nil {
+		requestid.Logf(ctx, "Ошибка сохранения заявки преподавателя %s: %v", user.Email, err)
+		return nil, middleware.Status(err, "Ошибка сохранения заявки")
+	}
+
+	return &pb.SubmitTeacherChangeRequestResponse{
+		Success: true,
+		Message: "Заявка отправлена на рассмотрение администратору",
+		Request: toPBTeacherChangeRequest(*request, loc),
+	}, nil
+}
+
+// ListMyTeacherChangeRequests возвращает заявки текущего преподавателя
+func (s *Server) ListMyTeacherChangeRequests(ctx context.Context, req *pb.ListMyTeacherChangeRequestsRequest) (*pb.ListMyTeacherChangeRequestsResponse, error) {
+	user, err := s.authenticate(ctx, req.Token)
+	if err != nil {
+		return nil, err
+	}
+	if user.Role != users.RoleTeacher {
+		return nil, status.Errorf(codes.PermissionDenied, "Заявки на изменение расписания доступны только преподавателям")
+	}
+
+	requests, err := s.changeService.ListTeacherChangeRequests(ctx, user.ID)
+	if err != nil {
+		requestid.Logf(ctx, "Ошибка получения заявок преподавателя %s: %v", user.Email, err)
+		return nil, middleware.Status(err, "Ошибка получения заявок")
+	}
+
+	return &pb.ListMyTeacherChangeRequestsResponse{
+		Success:  true,
+		Message:  fmt.Sprintf("Заявок: %d", len(requests)),
+		Requests: toPBTeacherChangeRequests(requests, s.scheduleService.Location()),
+	}, nil
+}
+
+// teacherDashboardDays на сколько дней вперед главный экран преподавателя показывает замены
+const teacherDashboardDays = 7
+
+// teacherDashboardNotifications сколько непрочитанных уведомлений показывает главный экран преподавателя
+const teacherDashboardNotifications = 20
+
+// GetTeacherDashboard возвращает данные главного экрана преподавателя
+func (s *Server) GetTeacherDashboard(ctx context.Context, req *pb.GetTeacherDashboardRequest) (*pb.GetTeacherDashboardResponse, error) {
+	user, err := s.authenticate(ctx, req.Token)
+	if err != nil {
+		return nil, err
+	}
+	if user.Role != users.RoleTeacher {
+		return nil, status.Errorf(codes.PermissionDenied, "Главный экран преподавателя доступен только преподавателям")
+	}
+
+	loc := s.scheduleService.Location()
+	today := clock.Today(loc)
+	entries, _, err := s.personalService.Schedule(ctx, user, today, today.AddDate(0, 0, teacherDashboardDays-1))
+	if err != nil {
+		requestid.Logf(ctx, "Ошибка получения расписания преподавателя %s: %v", user.Email, err)
+		return nil, middleware.Status(err, "Ошибка получения расписания")
+	}
+	var todayEntries, substitutions []schedule.CurrentSchedule
+	for _, entry := range entries {
+		if clock.Anchor(entry.Date, loc).Equal(today) {
+			todayEntries = append(todayEntries, entry)
+		}
+		if entry.SourceType == "change" {
+			substitutions = append(substitutions, entry)
+		}
+	}
+
+	requests, err := s.changeService.ListTeacherChangeRequests(ctx, user.ID)
+	if err != nil {
+		requestid.Logf(ctx, "Ошибка получения заявок преподавателя %s: %v", user.Email, err)
+		return nil, middleware.Status(err, "Ошибка получения заявок")
+	}
+	pending := make([]schedule.ChangeRequest, 0, len(requests))
+	for _, request := range requests {
+		if request.Status == schedule.ChangeModerationPending {
+			pending = append(pending, request)
+		}
+	}
+
+	unread, err := s.notificationService.UnreadNotifications(ctx, user.ID)
+	if err != nil {
+		requestid.Logf(ctx, "Ошибка получения уведомлений преподавателя %s: %v", user.Email, err)
+		return nil, middleware.Status(err, "Ошибка получения уведомлений")
+	}
+
+	pbToday := s.toPBScheduleEntries(ctx, todayEntries)
+	s.attachBuildings(ctx, todayEntries, pbToday)
+	s.attachNotes(ctx, user.ID, todayEntries, pbToday)
+	response := &pb.GetTeacherDashboardResponse{
+		Success:         true,
+		Message:         fmt.Sprintf("Занятий сегодня: %d, замен: %d", len(todayEntries), len(substitutions)),
+		Today:           pbToday,
+		Substitutions:   s.toPBScheduleEntries(ctx, substitutions),
+		PendingRequests: toPBTeacherChangeRequests(pending, loc),
+		UnreadCount:     int32(len(unread)),
+	}
+	for i, n := range unread {
+		if i == teacherDashboardNotifications {
+			break
+		}
+		response.UnreadNotifications = append(response.UnreadNotifications, toPBNotification(n))
+	}
+	return response, nil
+}
+
+// scheduleErrorReasons причины жалобы на пару
+var scheduleErrorReasons = map[pb.ScheduleErrorReason]string{
+	pb.ScheduleErrorReason_SCHEDULE_ERROR_REASON_WRONG_SUBJECT:   feedback.ReasonWrongSubject,
+	pb.ScheduleErrorReason_SCHEDULE_ERROR_REASON_WRONG_TEACHER:   feedback.ReasonWrongTeacher,
+	pb.ScheduleErrorReason_SCHEDULE_ERROR_REASON_WRONG_CLASSROOM: feedback.ReasonWrongClassroom,
+	pb.ScheduleErrorReason_SCHEDULE_ERROR_REASON_WRONG_TIME:      feedback.ReasonWrongTime,
+	pb.ScheduleErrorReason_SCHEDULE_ERROR_REASON_NOT_HELD:        feedback.ReasonNotHeld,
+	pb.ScheduleErrorReason_SCHEDULE_ERROR_REASON_OTHER:           feedback.ReasonOther,
+}
+
+// ReportScheduleError сохраняет жалобу пользователя на ошибку в паре
+func (s *Server) ReportScheduleError(ctx context.Context, req *pb.ReportScheduleErrorRequest) (*pb.ReportScheduleErrorResponse, error) {
+	user, err := s.authenticate(ctx, req.Token)
+	if err != nil {
+		return nil, err
+	}
+	if s.feedbackService == nil {
+		return nil, status.Errorf(codes.Unimplemented, "Жалобы на расписание не принимаются")
+	}
+	if req.Date == nil {
+		return nil, status.Errorf(codes.InvalidArgument, "Необходимо указать дату пары")
+	}
+
+	report := &feedback.Report{
+		UserID:    user.ID,
+		GroupName: req.GroupName,
+		Date:      req.Date.AsTime(),
+		TimeStart: req.TimeStart,
+		Reason:    scheduleErrorReasons[req.Reason],
+		Comment:   req.Comment,
+	}
+
+	// Жалобы запускают повторный парсинг, поэтому принимаются только от тех, кто
+	// видит пару в своем расписании: студентов группы и преподавателя пары
+	switch user.Role {
+	case users.RoleAdmin:
+	case users.RoleStudent:
+		student, err := s.userService.GetStudentProfile(ctx, user.ID)
+		if err != nil {
+			requestid.Logf(ctx, "Ошибка получения профиля студента %s: %v", user.ID, err)
+			return nil, status.Errorf(codes.FailedPrecondition, "Профиль студента не найден")
+		}
+		if student.GroupName != strings.TrimSpace(req.GroupName) {
+			return nil, status.Errorf(codes.PermissionDenied, "Сообщить об ошибке можно только в расписании своей группы")
+		}
+	case users.RoleTeacher:
+		teacher, err := s.userService.GetTeacherProfile(ctx, user.ID)
+		if err != nil {
+			requestid.Logf(ctx, "Ошибка получения профиля преподавателя %s: %v", user.ID, err)
+			return nil, status.Errorf(codes.FailedPrecondition, "Профиль преподавателя не найден")
+		}
+		report.TeacherNames, err = s.userService.TeacherNames(ctx, teacher)
+		if err != nil {
+			requestid.Logf(ctx, "Ошибка получения вариантов имени преподавателя %s: %v", teacher.FullName, err)
+			return nil, middleware.Status(err, "Ошибка сохранения жалобы")
+		}
+	default:
+		return nil, status.Errorf(codes.PermissionDenied, "Сообщить об ошибке в расписании могут студенты группы и преподаватель пары")
+	}
+	reports, err := s.feedbackService.Report(ctx, report)
+	if err != nil {
+		requestid.Logf(ctx, "Ошибка сохранения жалобы пользователя %s на пару %s %s: %v", user.Email, req.GroupName, req.TimeStart, err)
+		return nil, middleware.Status(err, "Ошибка сохранения жалобы")
+	}
+
+	return &pb.ReportScheduleErrorResponse{
+		Success: true,
+		Message: "Спасибо! Администратор проверит расписание",
+		Reports: int32(reports),
+	}, nil
+}
+
+// ListPendingTeacherChangeRequests возвращает заявки преподавателей, ожидающие рассмотрения
+func (s *Server) ListPendingTeacherChangeRequests(ctx context.Context, req *pb.ListPendingTeacherChangeRequestsRequest) (*pb.ListPendingTeacherChangeRequestsResponse, error) {
+	if _, err := s.authenticateAdmin(ctx, req.Token); err != nil {
+		return nil, err
+	}
+
+	requests, err := s.changeService.ListPendingChangeRequests(ctx)
+	if err != nil {
+		requestid.Logf(ctx, "Ошибка получения заявок на рассмотрении: %v", err)
+		return nil, middleware.Status(err, "Ошибка получения заявок")
+	}
+
+	return &pb.ListPendingTeacherChangeRequestsResponse{
+		Success:  true,
+		Message:  fmt.Sprintf("Заявок на рассмотрении: %d", len(requests)),
+		Requests: toPBTeacherChangeRequests(requests, s.scheduleService.Location()),
+	}, nil
+}
+
+// ReviewTeacherChangeRequest одобряет или отклоняет заявку преподавателя.
+// По одобренной заявке изменения сразу применяются к расписанию, и студентам уходят уведомления.
+func (s *Server) ReviewTeacherChangeRequest(ctx context.Context, req *pb.ReviewTeacherChangeRequestRequest) (*pb.ReviewTeacherChangeRequestResponse, error) {
+	admin, err := s.authenticateAdmin(ctx, req.Token)
+	if err != nil {
+		return nil, err
+	}
+
+	requestID, err := uuid.Parse(req.RequestId)
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "Неверный ID заявки: %s", req.RequestId)
+	}
+
+	loc := s.scheduleService.Location()
+	switch req.Decision {
+	case pb.ReviewDecision_REVIEW_DECISION_APPROVE:
+		requestid.Logf(ctx, "Администратор %s одобряет заявку %s", admin.Email, requestID)
+
+		request, report, err := s.changeService.ApproveChangeRequest(ctx, requestID, admin.ID, req.Comment)
+		if request == nil {
+			requestid.Logf(ctx, "Ошибка одобрения заявки %s: %v", requestID, err)
+			return nil, middleware.Status(err, "Ошибка одобрения заявки")
+		}
+
+		response := &pb.ReviewTeacherChangeRequestResponse{
+			Success: true,
+			Message: "Заявка одобрена",
+			Request: toPBTeacherChangeRequest(*request, loc),
+		}
+		if err != nil {
+			// Решение сохранено, но изменения применены не полностью
+			requestid.Logf(ctx, "Ошибка применения изменений по заявке %s: %v", requestID, err)
+			response.Message = fmt.Sprintf("Заявка одобрена, но изменения применены с ошибкой: %v", err)
+		}
+
+		if report != nil {
+			s.notifyAppliedChanges(ctx, report)
+			for _, result := range report.Results {
+				change := result.Change
+				change.Date = clock.Anchor(change.Date, loc)
+				response.Changes = append(response.Changes, toPBScheduleChange(change))
+			}
+		}
+		return response, nil
+
+	case pb.ReviewDecision_REVIEW_DECISION_REJECT:
+		requestid.Logf(ctx, "Администратор %s отклоняет заявку %s", admin.Email, requestID)
+
+		request, err := s.changeService.RejectChangeRequest(ctx, requestID, admin.ID, req.Comment)
+		if err != nil {
+			requestid.Logf(ctx, "Ошибка отклонения заявки %s: %v", requestID, err)
+			return nil, middleware.Status(err, "Ошибка отклонения заявки")
+		}
+
+		return &pb.ReviewTeacherChangeRequestResponse{
+			Success: true,
+			Message: "Заявка отклонена",
+			Request: toPBTeacherChangeRequest(*request, loc),
+		}, nil
+
+	default:
+		return nil, status.Errorf(codes.InvalidArgument, "Не указано решение по заявке")
+	}
+}
+
+// ClaimTeacherName сохраняет вариант имени преподавателя в расписании
+func (s *Server) ClaimTeacherName(ctx context.Context, req *pb.ClaimTeacherNameRequest) (*pb.ClaimTeacherNameResponse, error) {
+	user, err := s.authenticate(ctx, req.Token)
+	if err != nil {
+		return nil, err
+	}
+	if user.Role != users.RoleTeacher {
+		return nil, status.Errorf(codes.PermissionDenied, "Варианты имени доступны только преподавателям")
+	}
+
+	claim, err := s.userService.ClaimTeacherName(ctx, user.ID, req.ScrapedName)
+	if err != nil {
+		requestid.Logf(ctx, "Ошибка сохранения варианта имени преподавателя %s: %v", user.Email, err)
+		return nil, middleware.Status(err, "Ошибка сохранения варианта имени")
+	}
+
+	message := "Вариант имени подтвержден"
+	if claim.Status == users.TeacherNameClaimPending {
+		message = "Вариант имени ожидает подтверждения администратором"
+	}
+
+	return &pb.ClaimTeacherNameResponse{
+		Success: true,
+		Message: message,
+		Claim:   toPBTeacherNameClaim(*claim),
+	}, nil
+}
+
+// ListMyTeacherNameClaims возвращает варианты имени текущего преподавателя
+func (s *Server) ListMyTeacherNameClaims(ctx context.Context, req *pb.ListMyTeacherNameClaimsRequest) (*pb.ListMyTeacherNameClaimsResponse, error) {
+	user, err := s.authenticate(ctx, req.Token)
+	if err != nil {
+		return nil, err
+	}
+	if user.Role != users.RoleTeacher {
+		return nil, status.Errorf(codes.PermissionDenied, "Варианты имени доступны только преподавателям")
+	}
+
+	claims, err := s.userService.ListTeacherNameClaims(ctx, user.ID)
+	if err != nil {
+		requestid.Logf(ctx, "Ошибка получения вариантов имени преподавателя %s: %v", user.Email, err)
+		return nil, middleware.Status(err, "Ошибка получения вариантов имени")
+	}
+
+	return &pb.ListMyTeacherNameClaimsResponse{
+		Success: true,
+		Message: fmt.Sprintf("Вариантов имени: %d", len(claims)),
+		Claims:  toPBTeacherNameClaims(claims),
+	}, nil
+}
+
+// ListPendingTeacherNameClaims возвращает варианты имени, ожидающие подтверждения
+func (s *Server) ListPendingTeacherNameClaims(ctx context.Context, req *pb.ListPendingTeacherNameClaimsRequest) (*pb.ListPendingTeacherNameClaimsResponse, error) {
+	if _, err := s.authenticateAdmin(ctx, req.Token); err != nil {
+		return nil, err
+	}
+
+	claims, err := s.userService.ListPendingTeacherNameClaims(ctx)
+	if err != nil {
+		requestid.Logf(ctx, "Ошибка получения вариантов имени на подтверждении: %v", err)
+		return nil, middleware.Status(err, "Ошибка получения вариантов имени")
+	}
+
+	return &pb.ListPendingTeacherNameClaimsResponse{
+		Success: true,
+		Message: fmt.Sprintf("Вариантов имени на подтверждении: %d", len(claims)),
+		Claims:  toPBTeacherNameClaims(claims),
+	}, nil
+}
+
+// ReviewTeacherNameClaim подтверждает или отклоняет вариант имени преподавателя
+func (s *Server) ReviewTeacherNameClaim(ctx context.Context, req *pb.ReviewTeacherNameClaimRequest) (*pb.ReviewTeacherNameClaimResponse, error) {
+	admin, err := s.authenticateAdmin(ctx, req.Token)
+	if err != nil {
+		return nil, err
+	}
+
+	claimID, err := uuid.Parse(req.ClaimId)
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "Неверный ID заявки: %s", req.ClaimId)
+	}
+
+	var approve bool
+	switch req.Decision {
+	case pb.ReviewDecision_REVIEW_DECISION_APPROVE:
+		approve = true
+	case pb.ReviewDecision_REVIEW_DECISION_REJECT:
+	default:
+		return nil, status.Errorf(codes.InvalidArgument, "Не указано решение по заявке")
+	}
+
+	claim, err := s.userService.ReviewTeacherNameClaim(ctx, claimID, admin.ID, approve)
+	if err != nil {
+		requestid.Logf(ctx, "Ошибка рассмотрения варианта имени %s: %v", claimID, err)
+		return nil, middleware.Status(err, "Ошибка рассмотрения заявки")
+	}
+
+	message := "Вариант имени отклонен"
+	if approve {
+		message = "Вариант имени подтвержден"
+	}
+
+	return &pb.ReviewTeacherNameClaimResponse{
+		Success: true,
+		Message: message,
+		Claim:   toPBTeacherNameClaim(*claim),
+	}, nil
+}
+
+// GetGroupRoster возвращает зарегистрированных студентов группы.
+// Доступ преподавателя проверяется interceptor'ом (TeacherGroupMethods) и повторно здесь.
+func (s *Server) GetGroupRoster(ctx context.Context, req *pb.GetGroupRosterRequest) (*pb.GetGroupRosterResponse, error) {
+	user, err := s.authenticate(ctx, req.Token)
+	if err != nil {
+		return nil, err
+	}
+
+	groupName := strings.TrimSpace(req.GroupName)
+	if groupName == "" {
+		return nil, status.Errorf(codes.InvalidArgument, "Необходимо указать группу")
+	}
+
+	switch user.Role {
+	case users.RoleAdmin:
+	case users.RoleTeacher:
+		ok, err := NewGroupAccess(s.userService, s.scheduleService).TeacherHasGroup(ctx, user.ID, groupName)
+		if err != nil {
+			requestid.Logf(ctx, "Ошибка проверки доступа преподавателя %s к группе %s: %v", user.Email, groupName, err)
+			return nil, middleware.Status(err, "Ошибка проверки доступа")
+		}
+		if !ok {
+			return nil, status.Errorf(codes.PermissionDenied, "У вас нет занятий с этой группой")
+		}
+	default:
+		return nil, status.Errorf(codes.PermissionDenied, "Список группы доступен только преподавателям группы")
+	}
+
+	students, err := s.userService.GetGroupRoster(ctx, groupName)
+	if err != nil {
+		requestid.Logf(ctx, "Ошибка получения списка группы %s: %v", groupName, err)
+		return nil, middleware.Status(err, "Ошибка получения списка группы")
+	}
+
+	response := &pb.GetGroupRosterResponse{
+		Success:   true,
+		Message:   fmt.Sprintf("Студентов в группе: %d", len(students)),
+		GroupName: groupName,
+		Students:  make([]*pb.RosterStudent, 0, len(students)),
+	}
+	for _, student := range students {
+		response.Students = append(response.Students, &pb.RosterStudent{
+			UserId:        student.UserID.String(),
+			FullName:      student.FullName,
+			StudentNumber: student.StudentNumber,
+			Subgroup:      int32(student.Subgroup),
+		})
+	}
+
+	requestid.Logf(ctx, "Пользователь %s получил список группы %s", user.Email, groupName)
+	return response, nil
+}
+
+// ListJobs получает фоновые задачи по фильтру и статистику очереди
+func (s *Server) ListJobs(ctx context.Context, req *pb.ListJobsRequest) (*pb.ListJobsResponse, error) {
+	if _, err := s.authenticateAdmin(ctx, req.Token); err != nil {
+		return nil, err
+	}
+
+	if s.jobQueue == nil {
+		return nil, status.Errorf(codes.Unavailable, "Очередь задач не настроена")
+	}
+
+	list, total, err := s.jobQueue.List(ctx, jobs.Filter{
+		Kind:   strings.TrimSpace(req.Kind),
+		Status: fromPBJobStatus(req.Status),
+		Limit:  int(req.PageSize),
+		Offset: int(req.Offset),
+	})
+	if err != nil {
+		requestid.Logf(ctx, "Ошибка получения задач: %v", err)
+		return nil, middleware.Status(err, "Ошибка получения задач")
+	}
+
+	stats, err := s.jobQueue.Stats(ctx)
+	if err != nil {
+		requestid.Logf(ctx, "Ошибка получения статистики задач: %v", err)
+		return nil, middleware.Status(err, "Ошибка получения статистики задач")
+	}
+
+	response := &pb.ListJobsResponse{
+		Success: true,
+		Message: fmt.Sprintf("Найдено задач: %d", total),
+		Jobs:    make([]*pb.Job, 0, len(list)),
+		Total:   int32(total),
+		Stats:   make([]*pb.JobKindStats, 0, len(stats)),
+	}
+	for _, job := range list {
+		response.Jobs = append(response.Jobs, toPBJob(job))
+	}
+	for _, kind := range stats {
+		response.Stats = append(response.Stats, &pb.JobKindStats{
+			Kind:    kind.Kind,
+			Pending: int32(kind.Pending),
+			Running: int32(kind.Running),
+			Done:    int32(kind.Done),
+			Failed:  int32(kind.Failed),
+		})
+	}
+	return response, nil
+}
+
+// RetryJob возвращает в очередь задачу, исчерпавшую попытки
+func (s *Server) RetryJob(ctx context.Context, req *pb.RetryJobRequest) (*pb.RetryJobResponse, error) {
+	admin, err := s.authenticateAdmin(ctx, req.Token)
+	if err != nil {
+		return nil, err
+	}
+
+	if s.jobQueue == nil {
+		return nil, status.Errorf(codes.Unavailable, "Очередь задач не настроена")
+	}
+
+	jobID, err := uuid.Parse(req.JobId)
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "Неверный ID задачи")
+	}
+
+	if err := s.jobQueue.Retry(ctx, jobID); err != nil {
+		requestid.Logf(ctx, "Ошибка повтора задачи %s: %v", jobID, err)
+		return nil, middleware.Status(err, "Ошибка повтора задачи")
+	}
+
+	requestid.Logf(ctx, "Администратор %s вернул в очередь задачу %s", admin.Email, jobID)
+	return &pb.RetryJobResponse{
+		Success: true,
+		Message: "Задача возвращена в очередь",
+	}, nil
+}
+
+// ListFeatureFlags возвращает флаги функций, включая флаги со значениями по умолчанию
+func (s *Server) ListFeatureFlags(ctx context.Context, req *pb.ListFeatureFlagsRequest) (*pb.ListFeatureFlagsResponse, error) {
+	if _, err := s.authenticateAdmin(ctx, req.Token); err != nil {
+		return nil, err
+	}
+
+	if s.featureFlags == nil {
+		return nil, status.Errorf(codes.Unavailable, "Флаги функций не настроены")
+	}
+
+	flags, err := s.featureFlags.List(ctx)
+	if err != nil {
+		requestid.Logf(ctx, "Ошибка получения флагов функций: %v", err)
+		return nil, middleware.Status(err, "Ошибка получения флагов функций")
+	}
+
+	response := &pb.ListFeatureFlagsResponse{
+		Success: true,
+		Message: fmt.Sprintf("Найдено флагов: %d", len(flags)),
+		Flags:   make([]*pb.FeatureFlag, 0, len(flags)),
+	}
+	for _, flag := range flags {
+		response.Flags = append(response.Flags, toPBFeatureFlag(flag))
+	}
+	return response, nil
+}
+
+// SetFeatureFlag включает, выключает или меняет долю пользователей флага функции
+func (s *Server) SetFeatureFlag(ctx context.Context, req *pb.SetFeatureFlagRequest) (*pb.SetFeatureFlagResponse, error) {
+	admin, err := s.authenticateAdmin(ctx, req.Token)
+	if err != nil {
+		return nil, err
+	}
+
+	if s.featureFlags == nil {
+		return nil, status.Errorf(codes.Unavailable, "Флаги функций не настроены")
+	}
+
+	// Доля не указана - флаг действует для всех пользователей
+	percentage := int(req.Percentage)
+	if percentage == 0 {
+		percentage = 100
+	}
+
+	flag := &features.Flag{
+		Name:        strings.TrimSpace(req.Name),
+		Enabled:     req.Enabled,
+		Percentage:  percentage,
+		Description: strings.TrimSpace(req.Description),
+	}
+	if err := s.featureFlags.Set(ctx, flag, admin.ID); err != nil {
+		if errors.Is(err, features.ErrInvalidFlag) {
+			return nil, status.Errorf(codes.InvalidArgument, "%v", err)
+		}
+		requestid.Logf(ctx, "Ошибка изменения флага %s: %v", flag.Name, err)
+		return nil, middleware.Status(err, "Ошибка изменения флага")
+	}
+
+	requestid.Logf(ctx, "Администратор %s изменил флаг %s", admin.Email, flag.Name)
+	return &pb.SetFeatureFlagResponse{
+		Success: true,
+		Message: "Флаг сохранен",
+		Flag:    toPBFeatureFlag(*flag),
+	}, nil
+}
+
+// ResetFeatureFlag возвращает флагу функции значение по умолчанию для окружения
+func (s *Server) ResetFeatureFlag(ctx context.Context, req *pb.ResetFeatureFlagRequest) (*pb.ResetFeatureFlagResponse, error) {
+	admin, err := s.authenticateAdmin(ctx, req.Token)
+	if err != nil {
+		return nil, err
+	}
+
+	if s.featureFlags == nil {
+		return nil, status.Errorf(codes.Unavailable, "Флаги функций не настроены")
+	}
+
+	name := strings.TrimSpace(req.Name)
+	deleted, err := s.featureFlags.Reset(ctx, name)
+	if err != nil {
+		requestid.Logf(ctx, "Ошибка сброса флага %s: %v", name, err)
+		return nil, middleware.Status(err, "Ошибка сброса флага")
+	}
+	if !deleted {
+		return nil, status.Errorf(codes.NotFound, "Флаг не найден или уже имеет значение по умолчанию")
+	}
+
+	requestid.Logf(ctx, "Администратор %s сбросил флаг %s", admin.Email, name)
+	return &pb.ResetFeatureFlagResponse{
+		Success: true,
+		Message: "Флагу возвращено значение по умолчанию",
+	}, nil
+}
+
+// GetCalendarSubscription возвращает ссылки для подписки на личное расписание в календаре
+func (s *Server) GetCalendarSubscription(ctx context.Context, req *pb.GetCalendarSubscriptionRequest) (*pb.GetCalendarSubscriptionResponse, error) {
+	user, err := s.authenticate(ctx, req.Token)
+	if err != nil {
+		return nil, err
+	}
+
+	if s.calendarFeed == nil {
+		return nil, status.Errorf(codes.Unavailable, "Подписка на календарь не настроена")
+	}
+	if user.Role != users.RoleStudent && user.Role != users.RoleTeacher {
+		return nil, status.Errorf(codes.FailedPrecondition, "Личное расписание доступно только студентам и преподавателям")
+	}
+
+	message := "Ссылки для подписки на календарь получены"
+	if req.Regenerate {
+		user.CalendarTokenVersion, err = s.userService.ResetCalendarToken(ctx, user.ID)
+		if err != nil {
+			requestid.Logf(ctx, "Ошибка перевыпуска ссылок на календарь пользователя %s: %v", user.Email, err)
+			return nil, middleware.Status(err, "Ошибка перевыпуска ссылок на календарь")
+		}
+		message = "Выданы новые ссылки на календарь, прежние больше не работают"
+	}
+
+	links := s.calendarFeed.Links(ctx, user)
+	requestid.Logf(ctx, "Выданы ссылки подписки на календарь пользователю %s (версия %d)", user.Email, user.CalendarTokenVersion)
+	return &pb.GetCalendarSubscriptionResponse{
+		Success:           true,
+		Message:           message,
+		FeedUrl:           links.FeedURL,
+		WebcalUrl:         links.WebcalURL,
+		GoogleCalendarUrl: links.GoogleURL,
+	}, nil
+}
+
+// GetTimetablePDF формирует расписание группы на неделю в PDF для печати
+func (s *Server) GetTimetablePDF(ctx context.Context, req *pb.GetTimetablePDFRequest) (*pb.GetTimetablePDFResponse, error) {
+	claims, err := s.parseToken(ctx, req.Token)
+	if err != nil {
+		return nil, err
+	}
+
+	groupName := strings.TrimSpace(req.GroupName)
+	formats := clock.DefaultFormats // Гостю - форматы колледжа
+	if claims.IsGuest() {
+		// Гостю доступно только расписание группы, к которой привязан токен
+		if groupName != claims.GroupName {
+			return nil, status.Errorf(codes.PermissionDenied, "Гостевой доступ открыт только к расписанию группы %s", claims.GroupName)
+		}
+	} else {
+		user, err := s.userFromClaims(ctx, claims)
+		if err != nil {
+			return nil, err
+		}
+		formats = user.Formats()
+	}
+	if groupName == "" {
+		return nil, status.Errorf(codes.InvalidArgument, "Не указана группа")
+	}
+
+	if s.timetableRenderer == nil {
+		return nil, status.Errorf(codes.Unavailable, "Печатная версия расписания не настроена")
+	}
+
+	loc := s.scheduleService.Location()
+	weekStart := clock.WeekStart(clock.Today(loc))
+	if req.Date != nil {
+		weekStart = clock.WeekStart(clock.DateOf(req.Date.AsTime(), loc))
+	}
+	entries, err := s.scheduleService.GetScheduleForGroupRange(ctx, groupName, weekStart, weekStart.AddDate(0, 0, 6))
+	if err != nil {
+		requestid.Logf(ctx, "Ошибка получения расписания для группы %s: %v", groupName, err)
+		return nil, middleware.Status(err, "Ошибка получения расписания")
+	}
+
+	var buf bytes.Buffer
+	if err := s.timetableRenderer.RenderWeek(&buf, groupName, weekStart, entries, bells.For(ctx), formats); err != nil {
+		requestid.Logf(ctx, "Ошибка формирования PDF расписания группы %s: %v", groupName, err)
+		return nil, middleware.Status(err, "Ошибка формирования PDF")
+	}
+
+	requestid.Logf(ctx, "Сформирован PDF расписания группы %s на неделю с %s (%d пар, %d байт)",
+		groupName, weekStart.Format(clock.DateLayout), len(entries), buf.Len())
+
+	key := fmt.Sprintf("reports/timetables/%s/%s/%s.pdf", tenant.CollegeID(ctx),
+		strings.ReplaceAll(groupName, "/", "_"), weekStart.Format("2006-01-02"))
+	downloadURL := s.storeReport(ctx, key, buf.Bytes())
+
+	return &pb.GetTimetablePDFResponse{
+		Success:     true,
+		Message:     "Расписание сформировано",
+		Pdf:         buf.Bytes(),
+		FileName:    fmt.Sprintf("%s_%s.pdf", groupName, weekStart.Format("2006-01-02")),
+		DownloadUrl: downloadURL,
+	}, nil
+}
+
+// ListGroupWebhooks возвращает вебхуки групповых чатов колледжа
+func (s *Server) ListGroupWebhooks(ctx context.Context, req *pb.ListGroupWebhooksRequest) (*pb.ListGroupWebhooksResponse, error) {
+	if _, err := s.authenticateAdmin(ctx, req.Token); err != nil {
+		return nil, err
+	}
+
+	webhooks, err := s.notificationService.ListGroupWebhooks(ctx)
+	if err != nil {
+		requestid.Logf(ctx, "Ошибка получения вебхуков групп: %v", err)
+		return nil, middleware.Status(err, "Ошибка получения вебхуков групп")
+	}
+
+	response := &pb.ListGroupWebhooksResponse{
+		Success:  true,
+		Message:  fmt.Sprintf("Найдено вебхуков: %d", len(webhooks)),
+		Webhooks: make([]*pb.GroupWebhook, 0, len(webhooks)),
+	}
+	for _, webhook := range webhooks {
+		response.Webhooks = append(response.Webhooks, toPBGroupWebhook(webhook))
+	}
+	return response, nil
+}
+
+// SetGroupWebhook настраивает вебхук Discord или Slack для сводок изменений группы
+func (s *Server) SetGroupWebhook(ctx context.Context, req *pb.SetGroupWebhookRequest) (*pb.SetGroupWebhookResponse, error) {
+	admin, err := s.authenticateAdmin(ctx, req.Token)
+	if err != nil {
+		return nil, err
+	}
+
+	webhook := &notifications.GroupWebhook{
+		GroupName: req.GroupName,
+		Provider:  fromPBWebhookProvider(req.Provider),
+		URL:       req.Url,
+	}
+	if err := s.notificationService.SetGroupWebhook(ctx, webhook, admin.ID); err != nil {
+		requestid.Logf(ctx, "Ошибка сохранения вебхука группы %s: %v", webhook.GroupName, err)
+		return nil, middleware.Status(err, "Ошибка сохранения вебхука")
+	}
+
+	requestid.Logf(ctx, "Администратор %s настроил вебхук %s группы %s", admin.Email, webhook.Provider, webhook.GroupName)
+	return &pb.SetGroupWebhookResponse{
+		Success: true,
+		Message: "Вебхук сохранен",
+		Webhook: toPBGroupWebhook(*webhook),
+	}, nil
+}
+
+// DeleteGroupWebhook удаляет вебхук группы
+func (s *Server) DeleteGroupWebhook(ctx context.Context, req *pb.DeleteGroupWebhookRequest) (*pb.DeleteGroupWebhookResponse, error) {
+	admin, err := s.authenticateAdmin(ctx, req.Token)
+	if err != nil {
+		return nil, err
+	}
+
+	deleted, err := s.notificationService.DeleteGroupWebhook(ctx, req.GroupName)
+	if err != nil {
+		requestid.Logf(ctx, "Ошибка удаления вебхука группы %s: %v", req.GroupName, err)
+		return nil, middleware.Status(err, "Ошибка удаления вебхука")
+	}
+	if !deleted {
+		return nil, status.Errorf(codes.NotFound, "У группы нет вебхука")
+	}
+
+	requestid.Logf(ctx, "Администратор %s удалил вебхук группы %s", admin.Email, req.GroupName)
+	return &pb.DeleteGroupWebhookResponse{
+		Success: true,
+		Message: "Вебхук удален",
+	}, nil
+}
+
+// ImportTeacherDirectory загружает официальный список преподавателей из CSV в справочник колледжа
+func (s *Server) ImportTeacherDirectory(ctx context.Context, req *pb.ImportTeacherDirectoryRequest) (*pb.ImportTeacherDirectoryResponse, error) {
+	admin, err := s.authenticateAdmin(ctx, req.Token)
+	if err != nil {
+		return nil, err
+	}
+
+	records, err := users.ReadTeacherDirectoryCSV(bytes.NewReader(req.Csv))
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "%v", err)
+	}
+	teachers, err := users.ParseTeacherDirectory(records)
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "%v", err)
+	}
+
+	result, err := s.userService.ImportTeacherDirectory(ctx, teachers, req.Replace)
+	if err != nil {
+		requestid.Logf(ctx, "Ошибка загрузки справочника преподавателей: %v", err)
+		return nil, middleware.Status(err, "Ошибка загрузки справочника преподавателей")
+	}
+
+	requestid.Logf(ctx, "Администратор %s загрузил справочник преподавателей (%d)", admin.Email, len(teachers))
+	return &pb.ImportTeacherDirectoryResponse{
+		Success: true,
+		Message: fmt.Sprintf("Справочник загружен: добавлено %d, обновлено %d, удалено %d",
+			result.Created, result.Updated, result.Removed),
+		Total:   int32(len(teachers)),
+		Created: int32(result.Created),
+		Updated: int32(result.Updated),
+		Removed: int32(result.Removed),
+	}, nil
+}
+
+// SetLessonMeetingUrl задает ссылку на онлайн-занятие для пары и уведомляет студентов группы
+func (s *Server) SetLessonMeetingUrl(ctx context.Context, req *pb.SetLessonMeetingUrlRequest) (*pb.SetLessonMeetingUrlResponse, error) {
+	user, err := s.authenticate(ctx, req.Token)
+	if err != nil {
+		return nil, err
+	}
+
+	// Администратор задает ссылку для любой пары, преподаватель - только для своих
+	var names []string
+	switch user.Role {
+	case users.RoleAdmin:
+	case users.RoleTeacher:
+		teacher, err := s.userService.GetTeacherProfile(ctx, user.ID)
+		if err != nil {
+			requestid.Logf(ctx, "Ошибка получения профиля преподавателя %s: %v", user.ID, err)
+			return nil, status.Errorf(codes.FailedPrecondition, "Профиль преподавателя не найден")
+		}
+		names, err = s.userService.TeacherNames(ctx, teacher)
+		if err != nil {
+			requestid.Logf(ctx, "Ошибка получения вариантов имени преподавателя %s: %v", teacher.FullName, err)
+			return nil, middleware.Status(err, "Ошибка сохранения ссылки")
+		}
+	default:
+		return nil, status.Errorf(codes.PermissionDenied, "Ссылки на онлайн-занятия задают преподаватели и администраторы")
+	}
+
+	entryID, err := uuid.Parse(req.EntryId)
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "Некорректный ID занятия")
+	}
+
+	entry, err := s.scheduleService.SetMeetingURL(ctx, entryID, req.MeetingUrl, names)
+	if err != nil {
+		requestid.Logf(ctx, "Ошибка сохранения ссылки на занятие %s: %v", entryID, err)
+		return nil, middleware.Status(err, "Ошибка сохранения ссылки")
+	}
+
+	if err := s.notificationService.SendMeetingLinkNotification(ctx, entry); err != nil {
+		requestid.Logf(ctx, "Ошибка отправки уведомления о ссылке на занятие %s: %v", entryID, err)
+	}
+
+	message := "Ссылка на онлайн-занятие сохранена"
+	if entry.MeetingURL == "" {
+		message = "Ссылка удалена, занятие пройдет очно"
+	}
+	return &pb.SetLessonMeetingUrlResponse{
+		Success: true,
+		Message: message,
+		Entry:   s.toPBScheduleEntries(ctx, []schedule.CurrentSchedule{*entry})[0],
+	}, nil
+}
+
+// CreateElectiveCourse создает факультатив с еженедельными занятиями
+func (s *Server) CreateElectiveCourse(ctx context.Context, req *pb.CreateElectiveCourseRequest) (*pb.CreateElectiveCourseResponse, error) {
+	admin, err := s.authenticateAdmin(ctx, req.Token)
+	if err != nil {
+		return nil, err
+	}
+
+	course := &electives.Course{
+		Title:       req.Title,
+		Teacher:     req.Teacher,
+		Description: req.Description,
+		GroupNames:  req.GroupNames,
+		Capacity:    int(req.Capacity),
+	}
+	if req.StartsOn != nil {
+		course.StartsOn = req.StartsOn.AsTime()
+	}
+	if req.EndsOn != nil {
+		course.EndsOn = req.EndsOn.AsTime()
+	}
+	for _, slot := range req.Slots {
+		if slot.Weekday < 1 || slot.Weekday > 7 {
+			return nil, status.Errorf(codes.InvalidArgument, "Некорректный день недели занятия: %d", slot.Weekday)
+		}
+		course.Slots = append(course.Slots, electives.Slot{
+			Weekday:   time.Weekday(slot.Weekday % 7),
+			TimeStart: slot.TimeStart,
+			TimeEnd:   slot.TimeEnd,
+			Classroom: slot.Classroom,
+		})
+	}
+
+	if err := s.electiveService.CreateCourse(ctx, course, admin.ID); err != nil {
+		requestid.Logf(ctx, "Ошибка создания факультатива %q: %v", req.Title, err)
+		return nil, middleware.Status(err, "Ошибка создания факультатива")
+	}
+
+	requestid.Logf(ctx, "Администратор %s создал факультатив %q", admin.Email, course.Title)
+	return &pb.CreateElectiveCourseResponse{
+		Success: true,
+		Message: "Факультатив создан",
+		Course:  toPBElectiveCourse(*course),
+	}, nil
+}
+
+// CancelElectiveCourse отменяет факультатив и уведомляет записанных студентов
+func (s *Server) CancelElectiveCourse(ctx context.Context, req *pb.CancelElectiveCourseRequest) (*pb.CancelElectiveCourseResponse, error) {
+	admin, err := s.authenticateAdmin(ctx, req.Token)
+	if err != nil {
+		return nil, err
+	}
+
+	courseID, err := uuid.Parse(req.CourseId)
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "Некорректный ID факультатива")
+	}
+
+	course, userIDs, err := s.electiveService.CancelCourse(ctx, courseID)
+	if err != nil {
+		requestid.Logf(ctx, "Ошибка отмены факультатива %s: %v", courseID, err)
+		return nil, middleware.Status(err, "Ошибка отмены факультатива")
+	}
+
+	if err := s.notificationService.SendElectiveCancelledNotification(ctx, course, userIDs); err != nil {
+		requestid.Logf(ctx, "Ошибка отправки уведомления об отмене факультатива %s: %v", courseID, err)
+	}
+
+	requestid.Logf(ctx, "Администратор %s отменил факультатив %q", admin.Email, course.Title)
+	return &pb.CancelElectiveCourseResponse{
+		Success:          true,
+		Message:          "Факультатив отменен",
+		NotifiedStudents: int32(len(userIDs)),
+	}, nil
+}
+
+// ListElectiveCourses возвращает факультативы, которые еще не закончились
+func (s *Server) ListElectiveCourses(ctx context.Context, req *pb.ListElectiveCoursesRequest) (*pb.ListElectiveCoursesResponse, error) {
+	user, err := s.authenticate(ctx, req.Token)
+	if err != nil {
+		return nil, err
+	}
+
+	courses, err := s.electiveService.ListCourses(ctx, user.ID)
+	if err != nil {
+		requestid.Logf(ctx, "Ошибка получения факультативов: %v", err)
+		return nil, middleware.Status(err, "Ошибка получения факультативов")
+	}
+
+	response := &pb.ListElectiveCoursesResponse{
+		Success: true,
+		Message: fmt.Sprintf("Найдено факультативов: %d", len(courses)),
+		Courses: make([]*pb.ElectiveCourse, 0, len(courses)),
+	}
+	for _, course := range courses {
+		response.Courses = append(response.Courses, toPBElectiveCourse(course))
+	}
+	return response, nil
+}
+
+// EnrollElective записывает студента на факультатив
+func (s *Server) EnrollElective(ctx context.Context, req *pb.EnrollElectiveRequest) (*pb.EnrollElectiveResponse, error) {
+	user, err := s.authenticate(ctx, req.Token)
+	if err != nil {
+		return nil, err
+	}
+	if user.Role != users.RoleStudent {
+		return nil, status.Errorf(codes.PermissionDenied, "Записаться на факультатив могут только студенты")
+	}
+
+	courseID, err := uuid.Parse(req.CourseId)
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "Некорректный ID факультатива")
+	}
+
+	student, err := s.userService.GetStudentProfile(ctx, user.ID)
+	if err != nil {
+		requestid.Logf(ctx, "Ошибка получения профиля студента %s: %v", user.ID, err)
+		return nil, status.Errorf(codes.FailedPrecondition, "Профиль студента не найден")
+	}
+
+	course, err := s.electiveService.Enroll(ctx, courseID, user.ID, student.GroupName)
+	if err != nil {
+		requestid.Logf(ctx, "Ошибка записи на факультатив %s: %v", courseID, err)
+		return nil, middleware.Status(err, "Ошибка записи на факультатив")
+	}
+
+	return &pb.EnrollElectiveResponse{
+		Success: true,
+		Message: "Вы записаны на факультатив, его занятия добавлены в ваше расписание",
+		Course:  toPBElectiveCourse(*course),
+	}, nil
+}
+
+// UnenrollElective отменяет запись текущего пользователя на факультатив
+func (s *Server) UnenrollElective(ctx context.Context, req *pb.UnenrollElectiveRequest) (*pb.UnenrollElectiveResponse, error) {
+	user, err := s.authenticate(ctx, req.Token)
+	if err != nil {
+		return nil, err
+	}
+
+	courseID, err := uuid.Parse(req.CourseId)
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "Некорректный ID факультатива")
+	}
+
+	if err := s.electiveService.Unenroll(ctx, courseID, user.ID); err != nil {
+		requestid.Logf(ctx, "Ошибка отмены записи на факультатив %s: %v", courseID, err)
+		return nil, middleware.Status(err, "Ошибка отмены записи на факультатив")
+	}
+
+	return &pb.UnenrollElectiveResponse{
+		Success: true,
+		Message: "Запись на факультатив отменена",
+	}, nil
+}
+
+// GetTeacherWorkload возвращает нагрузку всех преподавателей колледжа за период
+// и, по запросу, отчет в PDF
+func (s *Server) GetTeacherWorkload(ctx context.Context, req *pb.GetTeacherWorkloadRequest) (*pb.GetTeacherWorkloadResponse, error) {
+	admin, err := s.authenticateAdmin(ctx, req.Token)
+	if err != nil {
+		return nil, err
+	}
+	if req.From == nil || req.To == nil {
+		return nil, status.Errorf(codes.InvalidArgument, "Необходимо указать период")
+	}
+	if req.Pdf && s.timetableRenderer == nil {
+		return nil, status.Errorf(codes.Unavailable, "Отчеты в PDF не настроены")
+	}
+
+	loc := s.scheduleService.Location()
+	from, to := clock.DateOf(req.From.AsTime(), loc), clock.DateOf(req.To.AsTime(), loc)
+	if to.Before(from) {
+		return nil, status.Errorf(codes.InvalidArgument, "Дата окончания периода раньше даты начала")
+	}
+
+	workloads, err := s.scheduleService.GetTeacherWorkload(ctx, schedule.WorkloadFilter{
+		From:   from,
+		To:     to,
+		ByWeek: req.ByWeek,
+	})
+	if err != nil {
+		requestid.Logf(ctx, "Ошибка получения нагрузки преподавателей: %v", err)
+		return nil, middleware.Status(err, "Ошибка получения нагрузки преподавателей")
+	}
+
+	response := &pb.GetTeacherWorkloadResponse{
+		Success:  true,
+		Message:  fmt.Sprintf("Нагрузка получена, преподавателей: %d", len(workloads)),
+		Teachers: make([]*pb.TeacherWorkload, 0, len(workloads)),
+	}
+	for _, workload := range workloads {
+		pbWorkload := &pb.TeacherWorkload{
+			Teacher:       workload.Teacher,
+			Lessons:       int32(workload.Lessons),
+			Minutes:       int32(workload.Minutes),
+			AcademicHours: workload.AcademicHours(),
+		}
+		for _, stat := range workload.Stats {
+			pbWorkload.Stats = append(pbWorkload.Stats, toPBWorkloadStat(stat))
+		}
+		response.Teachers = append(response.Teachers, pbWorkload)
+	}
+
+	if req.Pdf {
+		var buf bytes.Buffer
+		if err := s.timetableRenderer.RenderWorkload(&buf, from, to, workloads, admin.Formats()); err != nil {
+			requestid.Logf(ctx, "Ошибка формирования PDF нагрузки преподавателей: %v", err)
+			return nil, middleware.Status(err, "Ошибка формирования PDF")
+		}
+		response.Pdf = buf.Bytes()
+		response.FileName = fmt.Sprintf("workload_%s_%s.pdf", from.Format("2006-01-02"), to.Format("2006-01-02"))
+		response.DownloadUrl = s.storeReport(ctx,
+			fmt.Sprintf("reports/workload/%s/%s", tenant.CollegeID(ctx), response.FileName), response.Pdf)
+	}
+
+	requestid.Logf(ctx, "Администратор %s получил нагрузку преподавателей с %s по %s",
+		admin.Email, from.Format(clock.DateLayout), to.Format(clock.DateLayout))
+	return response, nil
+}
+
+// SetLessonNote создает или изменяет личную заметку пользователя к паре
+func (s *Server) SetLessonNote(ctx context.Context, req *pb.SetLessonNoteRequest) (*pb.SetLessonNoteResponse, error) {
+	user, err := s.authenticate(ctx, req.Token)
+	if err != nil {
+		return nil, err
+	}
+
+	note := &notes.Note{
+		UserID:    user.ID,
+		GroupName: req.GroupName,
+		TimeStart: req.TimeStart,
+		Subject:   req.Subject,
+		Text:      req.Text,
+	}
+	if req.Date != nil {
+		note.Date = req.Date.AsTime()
+	}
+	if err := s.noteService.SetNote(ctx, note); err != nil {
+		if errors.Is(err, notes.ErrTooManyNotes) {
+			return nil, status.Errorf(codes.ResourceExhausted, "%v", err)
+		}
+		requestid.Logf(ctx, "Ошибка сохранения заметки пользователя %s: %v", user.ID, err)
+		return nil, middleware.Status(err, "Ошибка сохранения заметки")
+	}
+
+	return &pb.SetLessonNoteResponse{
+		Success: true,
+		Message: "Заметка сохранена",
+		Note:    toPBLessonNote(*note),
+	}, nil
+}
+
+// ListLessonNotes возвращает заметки пользователя к парам за период
+func (s *Server) ListLessonNotes(ctx context.Context, req *pb.ListLessonNotesRequest) (*pb.ListLessonNotesResponse, error) {
+	user, err := s.authenticate(ctx, req.Token)
+	if err != nil {
+		return nil, err
+	}
+	if req.From == nil || req.To == nil {
+		return nil, status.Errorf(codes.InvalidArgument, "Необходимо указать период")
+	}
+
+	userNotes, err := s.noteService.ListNotes(ctx, user.ID, req.From.AsTime(), req.To.AsTime())
+	if err != nil {
+		requestid.Logf(ctx, "Ошибка получения заметок пользователя %s: %v", user.ID, err)
+		return nil, middleware.Status(err, "Ошибка получения заметок")
+	}
+
+	response := &pb.ListLessonNotesResponse{
+		Success: true,
+		Message: fmt.Sprintf("Найдено заметок: %d", len(userNotes)),
+		Notes:   make([]*pb.LessonNote, 0, len(userNotes)),
+	}
+	for _, note := range userNotes {
+		response.Notes = append(response.Notes, toPBLessonNote(note))
+	}
+	return response, nil
+}
+
+// DeleteLessonNote удаляет заметку пользователя к паре
+func (s *Server) DeleteLessonNote(ctx context.Context, req *pb.DeleteLessonNoteRequest) (*pb.DeleteLessonNoteResponse, error) {
+	user, err := s.authenticate(ctx, req.Token)
+	if err != nil {
+		return nil, err
+	}
+
+	noteID, err := uuid.Parse(req.NoteId)
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "Некорректный ID заметки")
+	}
+
+	if err := s.noteService.DeleteNote(ctx, user.ID, noteID); err != nil {
+		requestid.Logf(ctx, "Ошибка удаления заметки %s: %v", noteID, err)
+		return nil, middleware.Status(err, "Ошибка удаления заметки")
+	}
+
+	return &pb.DeleteLessonNoteResponse{
+		Success: true,
+		Message: "Заметка удалена",
+	}, nil
+}
+
+// ListBuildings возвращает корпуса колледжа с аудиториями
+func (s *Server) ListBuildings(ctx context.Context, req *pb.ListBuildingsRequest) (*pb.ListBuildingsResponse, error) {
+	if _, err := s.authenticate(ctx, req.Token); err != nil {
+		return nil, err
+	}
+
+	list, err := s.buildingService.ListBuildings(ctx)
+	if err != nil {
+		requestid.Logf(ctx, "Ошибка получения корпусов: %v", err)
+		return nil, middleware.Status(err, "Ошибка получения корпусов")
+	}
+
+	response := &pb.ListBuildingsResponse{
+		Success:       true,
+		Message:       fmt.Sprintf("Найдено корпусов: %d", len(list)),
+		Buildings:     make([]*pb.Building, 0, len(list)),
+		TravelMinutes: int32(s.buildingService.TravelMinutes()),
+	}
+	for _, building := range list {
+		response.Buildings = append(response.Buildings, toPBBuilding(building))
+	}
+	return response, nil
+}
+
+// SetBuilding создает или изменяет корпус и список его аудиторий
+func (s *Server) SetBuilding(ctx context.Context, req *pb.SetBuildingRequest) (*pb.SetBuildingResponse, error) {
+	admin, err := s.authenticateAdmin(ctx, req.Token)
+	if err != nil {
+		return nil, err
+	}
+	if req.Building == nil {
+		return nil, status.Errorf(codes.InvalidArgument, "Не указан корпус")
+	}
+
+	building := &buildings.Building{
+		Code:       req.Building.Code,
+		Name:       req.Building.Name,
+		Address:    req.Building.Address,
+		Classrooms: req.Building.Classrooms,
+	}
+	if err := s.buildingService.SetBuilding(ctx, building); err != nil {
+		requestid.Logf(ctx, "Ошибка сохранения корпуса %s: %v", req.Building.Code, err)
+		return nil, middleware.Status(err, "Ошибка сохранения корпуса")
+	}
+
+	requestid.Logf(ctx, "Администратор %s сохранил корпус %s", admin.Email, building.Code)
+	return &pb.SetBuildingResponse{
+		Success:  true,
+		Message:  "Корпус сохранен",
+		Building: toPBBuilding(*building),
+	}, nil
+}
+
+// DeleteBuilding удаляет корпус вместе с его аудиториями
+func (s *Server) DeleteBuilding(ctx context.Context, req *pb.DeleteBuildingRequest) (*pb.DeleteBuildingResponse, error) {
+	admin, err := s.authenticateAdmin(ctx, req.Token)
+	if err != nil {
+		return nil, err
+	}
+
+	deleted, err := s.buildingService.DeleteBuilding(ctx, req.Code)
+	if err != nil {
+		requestid.Logf(ctx, "Ошибка удаления корпуса %s: %v", req.Code, err)
+		return nil, middleware.Status(err, "Ошибка удаления корпуса")
+	}
+	if !deleted {
+		return nil, status.Errorf(codes.NotFound, "Корпус %s не найден", req.Code)
+	}
+
+	requestid.Logf(ctx, "Администратор %s удалил корпус %s", admin.Email, req.Code)
+	return &pb.DeleteBuildingResponse{
+		Success: true,
+		Message: "Корпус удален",
+	}, nil
+}
+
+// RunAcademicRollover выполняет переход колледжа на новый учебный год
+func (s *Server) RunAcademicRollover(ctx context.Context, req *pb.RunAcademicRolloverRequest) (*pb.RunAcademicRolloverResponse, error) {
+	admin, err := s.authenticateAdmin(ctx, req.Token)
+	if err != nil {
+		return nil, err
+	}
+	if req.YearStart == nil {
+		return nil, status.Errorf(codes.InvalidArgument, "Не указано начало учебного года")
+	}
+
+	plan := rollover.Plan{
+		YearStart: req.YearStart.AsTime().In(s.scheduleService.Location()),
+		MaxCourse: int(req.MaxCourse),
+		Retire:    req.RetireGroups,
+		DryRun:    req.DryRun,
+	}
+	for _, rename := range req.Renames {
+		plan.Renames = append(plan.Renames, rollover.GroupRename{From: rename.From, To: rename.To})
+	}
+
+	result, err := s.rolloverService.Run(ctx, plan, &admin.ID)
+	if err != nil {
+		requestid.Logf(ctx, "Ошибка перехода на новый учебный год: %v", err)
+		return nil, middleware.Status(err, "Ошибка перехода на новый учебный год")
+	}
+
+	resp := &pb.RunAcademicRolloverResponse{
+		Success:            true,
+		Message:            "Переход на новый учебный год выполнен",
+		SnapshotsArchived:  int32(result.SnapshotsArchived),
+		StudentsAdvanced:   int32(result.StudentsAdvanced),
+		StudentsGraduated:  int32(result.StudentsGraduated),
+		StudentsRenamed:    int32(result.StudentsRenamed),
+		RetiredGroups:      result.RetiredGroups,
+		WebhooksRemoved:    int32(result.WebhooksRemoved),
+		EnrollmentsRemoved: int32(result.EnrollmentsRemoved),
+		SubgroupsReset:     int32(result.SubgroupsReset),
+	}
+	if req.DryRun {
+		resp.Message = "Проверка перехода на новый учебный год: " + result.Summary()
+		return resp, nil
+	}
+	resp.RolloverId = result.ID.String()
+
+	requestid.Logf(ctx, "Администратор %s выполнил переход на новый учебный год: %s", admin.Email, result.Summary())
+	return resp, nil
+}
+
+// SetConsultationHours публикует еженедельные консультации преподавателя вместо прежних
+func (s *Server) SetConsultationHours(ctx context.Context, req *pb.SetConsultationHoursRequest) (*pb.SetConsultationHoursResponse, error) {
+	user, err := s.authenticate(ctx, req.Token)
+	if err != nil {
+		return nil, err
+	}
+	if user.Role != users.RoleTeacher {
+		return nil, status.Errorf(codes.PermissionDenied, "Публиковать консультации могут только преподаватели")
+	}
+
+	teacher, err := s.userService.GetTeacherProfile(ctx, user.ID)
+	if err != nil {
+		requestid.Logf(ctx, "Ошибка получения профиля преподавателя %s: %v", user.ID, err)
+		return nil, status.Errorf(codes.FailedPrecondition, "Профиль преподавателя не найден")
+	}
+
+	list := make([]consultations.Consultation, 0, len(req.Consultations))
+	for _, c := range req.Consultations {
+		if c.Weekday < 1 || c.Weekday > 7 {
+			return nil, status.Errorf(codes.InvalidArgument, "Некорректный день недели консультации: %d", c.Weekday)
+		}
+		list = append(list, consultations.Consultation{
+			Weekday:   time.Weekday(c.Weekday % 7),
+			TimeStart: c.TimeStart,
+			TimeEnd:   c.TimeEnd,
+			Classroom: c.Classroom,
+			Comment:   c.Comment,
+		})
+	}
+
+	names, err := s.userService.TeacherNames(ctx, teacher)
+	if err != nil {
+		requestid.Logf(ctx, "Ошибка получения вариантов имени преподавателя %s: %v", teacher.FullName, err)
+		return nil, middleware.Status(err, "Ошибка сохранения консультаций")
+	}
+
+	list, err = s.consultationService.SetTeacherConsultations(ctx, user.ID, teacher.FullName, names, list)
+	if err != nil {
+		requestid.Logf(ctx, "Ошибка сохранения консультаций преподавателя %s: %v", teacher.FullName, err)
+		return nil, middleware.Status(err, "Ошибка сохранения консультаций")
+	}
+
+	return &pb.SetConsultationHoursResponse{
+		Success:       true,
+		Message:       fmt.Sprintf("Опубликовано консультаций: %d", len(list)),
+		Consultations: s.toPBConsultations(list),
+	}, nil
+}
+
+// ListConsultationHours возвращает консультации: студенту - преподавателей его группы,
+// преподавателю - свои, администратору - всех преподавателей
+func (s *Server) ListConsultationHours(ctx context.Context, req *pb.ListConsultationHoursRequest) (*pb.ListConsultationHoursResponse, error) {
+	user, err := s.authenticate(ctx, req.Token)
+	if err != nil {
+		return nil, err
+	}
+
+	var list []consultations.Consultation
+	switch user.Role {
+	case users.RoleStudent:
+		student, err := s.userService.GetStudentProfile(ctx, user.ID)
+		if err != nil {
+			requestid.Logf(ctx, "Ошибка получения профиля студента %s: %v", user.ID, err)
+			return nil, status.Errorf(codes.FailedPrecondition, "Профиль студента не найден")
+		}
+		list, err = s.consultationService.GroupConsultations(ctx, student.GroupName, user.ID)
+	case users.RoleTeacher:
+		list, err = s.consultationService.TeacherConsultations(ctx, user.ID)
+	case users.RoleAdmin:
+		list, err = s.consultationService.AllConsultations(ctx)
+	default:
+		return nil, status.Errorf(codes.PermissionDenied, "Консультации недоступны")
+	}
+	if err != nil {
+		requestid.Logf(ctx, "Ошибка получения консультаций для %s: %v", user.Email, err)
+		return nil, middleware.Status(err, "Ошибка получения консультаций")
+	}
+
+	return &pb.ListConsultationHoursResponse{
+		Success:       true,
+		Message:       fmt.Sprintf("Найдено консультаций: %d", len(list)),
+		Consultations: s.toPBConsultations(list),
+	}, nil
+}
+
+// SetConsultationReminder включает или отключает студенту напоминания о консультациях преподавателя
+func (s *Server) SetConsultationReminder(ctx context.Context, req *pb.SetConsultationReminderRequest) (*pb.SetConsultationReminderResponse, error) {
+	user, err := s.authenticate(ctx, req.Token)
+	if err != nil {
+		return nil, err
+	}
+	if user.Role != users.RoleStudent {
+		return nil, status.Errorf(codes.PermissionDenied, "Напоминания о консультациях доступны только студентам")
+	}
+
+	teacherID, err := uuid.Parse(req.TeacherId)
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "Некорректный ID преподавателя")
+	}
+
+	student, err := s.userService.GetStudentProfile(ctx, user.ID)
+	if err != nil {
+		requestid.Logf(ctx, "Ошибка получения профиля студента %s: %v", user.ID, err)
+		return nil, status.Errorf(codes.FailedPrecondition, "Профиль студента не найден")
+	}
+
+	if err := s.consultationService.SetReminders(ctx, user.ID, student.GroupName, teacherID, req.Enabled); err != nil {
+		requestid.Logf(ctx, "Ошибка изменения напоминаний о консультациях %s: %v", teacherID, err)
+		return nil, middleware.Status(err, "Ошибка изменения напоминаний о консультациях")
+	}
+
+	message := "Напоминания о консультациях отключены"
+	if req.Enabled {
+		message = "Напоминания о консультациях включены"
+	}
+	return &pb.SetConsultationReminderResponse{
+		Success: true,
+		Message: message,
+	}, nil
+}
+
+// PollUpdates ждет новых уведомлений пользователя для клиентов без push
+func (s *Server) PollUpdates(ctx context.Context, req *pb.PollUpdatesRequest) (*pb.PollUpdatesResponse, error) {
+	user, err := s.authenticate(ctx, req.Token)
+	if err != nil {
+		return nil, err
+	}
+
+	if req.TimeoutSeconds < 0 {
+		return nil, status.Errorf(codes.InvalidArgument, "Время ожидания не может быть отрицательным")
+	}
+	maxTimeout := s.pollTimeout
+	if maxTimeout <= 0 {
+		maxTimeout = time.Minute
+	}
+	timeout := time.Duration(req.TimeoutSeconds) * time.Second
+	if timeout == 0 || timeout > maxTimeout {
+		timeout = maxTimeout
+	}
+	since := time.Now()
+	if req.Since != nil {
+		since = req.Since.AsTime()
+	}
+
+	list, err := s.notificationService.WaitForNotifications(ctx, user.ID, since, timeout)
+	if err != nil {
+		if ctx.Err() != nil {
+			// Клиент отключился или истек его дедлайн
+			return nil, status.FromContextError(ctx.Err()).Err()
+		}
+		requestid.Logf(ctx, "Ошибка получения уведомлений пользователя %s: %v", user.ID, err)
+		return nil, middleware.Status(err, "Ошибка получения уведомлений")
+	}
+
+	resp := &pb.PollUpdatesResponse{
+		Notifications: make([]*pb.Notification, 0, len(list)),
+		Cursor:        timestamppb.New(since),
+	}
+	for _, n := range list {
+		resp.Notifications = append(resp.Notifications, toPBNotification(n))
+		resp.Cursor = timestamppb.New(n.CreatedAt)
+	}
+	return resp, nil
+}
+
+// CreateKioskDisplay создает табло расписания и возвращает его ключ
+func (s *Server) CreateKioskDisplay(ctx context.Context, req *pb.CreateKioskDisplayRequest) (*pb.CreateKioskDisplayResponse, error) {
+	admin, err := s.authenticateAdmin(ctx, req.Token)
+	if err != nil {
+		return nil, err
+	}
+
+	display, key, err := s.kioskService.CreateDisplay(ctx, admin.ID, req.Name, req.Buildings, req.Classrooms)
+	if err != nil {
+		requestid.Logf(ctx, "Ошибка создания табло %q: %v", req.Name, err)
+		return nil, middleware.Status(err, "Ошибка создания табло")
+	}
+
+	requestid.Logf(ctx, "Администратор %s создал табло %s (%s)", admin.Email, display.Name, display.ID)
+	return &pb.CreateKioskDisplayResponse{
+		Success: true,
+		Message: "Табло создано",
+		Display: toPBKioskDisplay(*display),
+		Key:     key,
+	}, nil
+}
+
+// ListKioskDisplays возвращает действующие табло колледжа
+func (s *Server) ListKioskDisplays(ctx context.Context, req *pb.ListKioskDisplaysRequest) (*pb.ListKioskDisplaysResponse, error) {
+	if _, err := s.authenticateAdmin(ctx, req.Token); err != nil {
+		return nil, err
+	}
+
+	displays, err := s.kioskService.ListDisplays(ctx)
+	if err != nil {
+		requestid.Logf(ctx, "Ошибка получения табло: %v", err)
+		return nil, middleware.Status(err, "Ошибка получения табло")
+	}
+
+	response := &pb.ListKioskDisplaysResponse{
+		Success:  true,
+		Message:  fmt.Sprintf("Найдено табло: %d", len(displays)),
+		Displays: make([]*pb.KioskDisplay, 0, len(displays)),
+	}
+	for _, display := range displays {
+		response.Displays = append(response.Displays, toPBKioskDisplay(display))
+	}
+	return response, nil
+}
+
+// RevokeKioskDisplay отключает табло
+func (s *Server) RevokeKioskDisplay(ctx context.Context, req *pb.RevokeKioskDisplayRequest) (*pb.RevokeKioskDisplayResponse, error) {
+	admin, err := s.authenticateAdmin(ctx, req.Token)
+	if err != nil {
+		return nil, err
+	}
+
+	id, err := uuid.Parse(req.Id)
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "Неверный ID табло: %s", req.Id)
+	}
+	if err := s.kioskService.RevokeDisplay(ctx, id); err != nil {
+		requestid.Logf(ctx, "Ошибка отключения табло %s: %v", id, err)
+		return nil, middleware.Status(err, "Ошибка отключения табло")
+	}
+
+	requestid.Logf(ctx, "Администратор %s отключил табло %s", admin.Email, id)
+	return &pb.RevokeKioskDisplayResponse{
+		Success: true,
+		Message: "Табло отключено",
+	}, nil
+}
+
+// toPBConsultations преобразует консультации в формат protobuf с ближайшей датой
+func (s *Server) toPBConsultations(list []consultations.Consultation) []*pb.Consultation {
+	today := clock.Today(s.scheduleService.Location())
+	result := make([]*pb.Consultation, 0, len(list))
+	for _, c := range list {
+		weekday := int32(c.Weekday)
+		if c.Weekday == time.Sunday {
+			weekday = 7
+		}
+		result = append(result, &pb.Consultation{
+			Id:              c.ID.String(),
+			TeacherId:       c.TeacherID.String(),
+			Teacher:         c.Teacher,
+			Weekday:         weekday,
+			TimeStart:       c.TimeStart,
+			TimeEnd:         c.TimeEnd,
+			Classroom:       c.Classroom,
+			Comment:         c.Comment,
+			NextDate:        timestamppb.New(c.NextDate(today)),
+			ReminderEnabled: c.Subscribed,
+		})
+	}
+	return result
+}
+
+// attachBuildings дополняет записи расписания pbEntries (в порядке entries) корпусами
+// аудиторий и предупреждениями о нехватке времени на переход между корпусами.
+// Ошибка не прерывает выдачу расписания - записи возвращаются без корпусов.
+func (s *Server) attachBuildings(ctx context.Context, entries []schedule.CurrentSchedule, pbEntries []*pb.ScheduleEntry) {
+	annotations, err := s.buildingService.Annotate(ctx, entries)
+	if err != nil {
+		requestid.Logf(ctx, "Ошибка получения корпусов аудиторий: %v", err)
+		return
+	}
+	for i, annotation := range annotations {
+		pbEntries[i].Building = annotation.Building
+		pbEntries[i].TravelWarning = annotation.TravelWarning
+	}
+}
+
+// attachNotes дополняет записи расписания pbEntries (в порядке entries) личными
+// заметками пользователя. Ошибка не прерывает выдачу расписания - записи
+// возвращаются без заметок.
+func (s *Server) attachNotes(ctx context.Context, userID uuid.UUID, entries []schedule.CurrentSchedule, pbEntries []*pb.ScheduleEntry) {
+	if len(entries) == 0 {
+		return
+	}
+
+	from, to := entries[0].Date, entries[0].Date
+	for _, entry := range entries {
+		if entry.Date.Before(from) {
+			from = entry.Date
+		}
+		if entry.Date.After(to) {
+			to = entry.Date
+		}
+	}
+
+	byKey, err := s.noteService.NotesFor(ctx, userID, from, to)
+	if err != nil {
+		requestid.Logf(ctx, "Ошибка получения заметок пользователя %s: %v", userID, err)
+		return
+	}
+	for i, entry := range entries {
+		pbEntries[i].Note = byKey[notes.KeyOf(entry.GroupName, entry.Date, entry.TimeStart)]
+	}
+}
+
+// storeReport сохраняет копию отчета в хранилище и возвращает подписанную ссылку на нее:
+// большие файлы клиент может скачать по ссылке, не через gRPC. Без хранилища или
+// при ошибке сохранения возвращает пустую ссылку.
+func (s *Server) storeReport(ctx context.Context, key string, data []byte) string {
+	if s.reportStorage == nil {
+		return ""
+	}
+	if err := s.reportStorage.Put(ctx, key, bytes.NewReader(data)); err != nil {
+		requestid.Logf(ctx, "Ошибка сохранения отчета %s в хранилище: %v", key, err)
+		return ""
+	}
+	downloadURL, err := s.reportStorage.SignedURL(key, s.reportURLTTL)
+	if err != nil {
+		requestid.Logf(ctx, "Ошибка получения ссылки на отчет %s: %v", key, err)
+		return ""
+	}
+	return downloadURL
+}
+
+// studentSubgroup возвращает подгруппу студента, если он смотрит расписание своей
+// группы groupName. Для остальных пользователей и групп - 0 (видны все подгруппы).
+func (s *Server) studentSubgroup(ctx context.Context, user *users.User, groupName string) int {
+	if user.Role != users.RoleStudent {
+		return 0
+	}
+	student, err := s.userService.GetStudentProfile(ctx, user.ID)
+	if err != nil {
+		requestid.Logf(ctx, "Ошибка получения профиля студента %s: %v", user.ID, err)
+		return 0
+	}
+	if student.GroupName != groupName {
+		return 0
+	}
+	return student.Subgroup
+}
+
+// notifyAppliedChanges отправляет уведомления по примененным изменениям из отчета.
+// Если события изменений публикуются через outbox, уведомления рассылает подписчик relay.
+func (s *Server) notifyAppliedChanges(ctx context.Context, report *changes.ApplyReport) {
+	if s.changeService.PublishesEvents() {
+		return
+	}
+
+	if err := s.notificationService.SendScheduleChangeNotifications(ctx, report.AppliedChanges()); err != nil {
+		requestid.Logf(ctx, "Ошибка отправки уведомлений об изменениях: %v", err)
+	}
+}
+
+// toPBFeatureFlag преобразует флаг функции в формат protobuf
+func toPBFeatureFlag(flag features.Flag) *pb.FeatureFlag {
+	pbFlag := &pb.FeatureFlag{
+		Name:        flag.Name,
+		Enabled:     flag.Enabled,
+		Percentage:  int32(flag.Percentage),
+		Description: flag.Description,
+		IsDefault:   flag.UpdatedAt.IsZero(),
+	}
+	if flag.UpdatedBy != nil {
+		pbFlag.UpdatedBy = flag.UpdatedBy.String()
+	}
+	if !flag.UpdatedAt.IsZero() {
+		pbFlag.UpdatedAt = timestamppb.New(flag.UpdatedAt)
+	}
+	return pbFlag
+}
+
+// toPBGroupWebhook преобразует вебхук группы в формат protobuf
+func toPBGroupWebhook(webhook notifications.GroupWebhook) *pb.GroupWebhook {
+	pbWebhook := &pb.GroupWebhook{
+		Id:        webhook.ID.String(),
+		GroupName: webhook.GroupName,
+		Provider:  toPBWebhookProvider(webhook.Provider),
+		Url:       webhook.URL,
+		UpdatedAt: timestamppb.New(webhook.UpdatedAt),
+		LastError: webhook.LastError,
+	}
+	if webhook.LastDeliveredAt != nil {
+		pbWebhook.LastDeliveredAt = timestamppb.New(*webhook.LastDeliveredAt)
+	}
+	return pbWebhook
+}
+
+// toPBLessonNote преобразует заметку к паре в формат protobuf
+func toPBLessonNote(note notes.Note) *pb.LessonNote {
+	return &pb.LessonNote{
+		Id:        note.ID.String(),
+		GroupName: note.GroupName,
+		Date:      timestamppb.New(note.Date),
+		TimeStart: note.TimeStart,
+		Subject:   note.Subject,
+		Text:      note.Text,
+		UpdatedAt: timestamppb.New(note.UpdatedAt),
+	}
+}
+
+// toPBWorkloadStat преобразует нагрузку по предмету в формат protobuf
+func toPBWorkloadStat(stat schedule.WorkloadStat) *pb.WorkloadStat {
+	pbStat := &pb.WorkloadStat{
+		GroupName:     stat.GroupName,
+		Teacher:       stat.Teacher,
+		Subject:       stat.Subject,
+		Lessons:       int32(stat.Lessons),
+		Minutes:       int32(stat.Minutes),
+		AcademicHours: stat.AcademicHours(),
+	}
+	if stat.WeekStart != nil {
+		pbStat.WeekStart = timestamppb.New(*stat.WeekStart)
+	}
+	return pbStat
+}
+
+// toPBElectiveCourse преобразует факультатив в формат protobuf
+func toPBElectiveCourse(course electives.Course) *pb.ElectiveCourse {
+	pbCourse := &pb.ElectiveCourse{
+		Id:          course.ID.String(),
+		Title:       course.Title,
+		Teacher:     course.Teacher,
+		Description: course.Description,
+		GroupNames:  course.GroupNames,
+		Capacity:    int32(course.Capacity),
+		Enrolled:    int32(course.Enrolled),
+		StartsOn:    timestamppb.New(course.StartsOn),
+		EndsOn:      timestamppb.New(course.EndsOn),
+		IsEnrolled:  course.IsEnrolled,
+		IsActive:    course.IsActive,
+	}
+	for _, slot := range course.Slots {
+		weekday := int32(slot.Weekday)
+		if slot.Weekday == time.Sunday {
+			weekday = 7
+		}
+		pbCourse.Slots = append(pbCourse.Slots, &pb.ElectiveSlot{
+			Weekday:   weekday,
+			TimeStart: slot.TimeStart,
+			TimeEnd:   slot.TimeEnd,
+			Classroom: slot.Classroom,
+		})
+	}
+	return pbCourse
+}
+
+// toPBBuilding преобразует корпус в формат protobuf
+func toPBBuilding(building buildings.Building) *pb.Building {
+	return &pb.Building{
+		Id:         building.ID.String(),
+		Code:       building.Code,
+		Name:       building.Name,
+		Address:    building.Address,
+		Classrooms: building.Classrooms,
+	}
+}
+
+// toPBNotification преобразует уведомление в формат protobuf
+func toPBNotification(n notifications.Notification) *pb.Notification {
+	return &pb.Notification{
+		Id:           n.ID.String(),
+		Title:        n.Title,
+		Message:      n.Message,
+		Type:         string(n.Type),
+		RelatedGroup: n.RelatedGroup,
+		RelatedDate:  timestamppb.New(n.RelatedDate),
+		CreatedAt:    timestamppb.New(n.CreatedAt),
+		Payload: &pb.NotificationPayload{
+			Type:           string(n.Payload.Type),
+			Group:          n.Payload.Group,
+			Date:           n.Payload.Date,
+			Time:           n.Payload.Time,
+			ChangeId:       n.Payload.ChangeID,
+			EntryId:        n.Payload.EntryID,
+			ConsultationId: n.Payload.ConsultationID,
+			CourseId:       n.Payload.CourseID,
+			Route:          n.Payload.Route,
+		},
+	}
+}
+
+// toPBWidgetLesson преобразует пару в формат сводки виджета (nil - пары нет)
+func toPBWidgetLesson(entry *schedule.CurrentSchedule) *pb.WidgetLesson {
+	if entry == nil {
+		return nil
+	}
+	return &pb.WidgetLesson{
+		Subject:   entry.Subject,
+		TimeStart: clock.NormalizeClock(entry.TimeStart),
+		TimeEnd:   clock.NormalizeClock(entry.TimeEnd),
+		Classroom: entry.Classroom,
+		Teacher:   entry.Teacher,
+		GroupName: entry.GroupName,
+		Online:    entry.MeetingURL != "",
+	}
+}
+
+// toPBKioskDisplay преобразует табло в формат protobuf
+func toPBKioskDisplay(display kiosk.Display) *pb.KioskDisplay {
+	result := &pb.KioskDisplay{
+		Id:         display.ID.String(),
+		Name:       display.Name,
+		Buildings:  display.Buildings,
+		Classrooms: display.Classrooms,
+		CreatedAt:  timestamppb.New(display.CreatedAt),
+	}
+	if display.LastSeenAt != nil {
+		result.LastSeenAt = timestamppb.New(*display.LastSeenAt)
+	}
+	return result
+}
+
+// toPBWebhookProvider преобразует провайдера вебхука в формат protobuf
+func toPBWebhookProvider(provider notifications.WebhookProvider) pb.WebhookProvider {
+	switch provider {
+	case notifications.WebhookProviderDiscord:
+		return pb.WebhookProvider_WEBHOOK_PROVIDER_DISCORD
+	case notifications.WebhookProviderSlack:
+		return pb.WebhookProvider_WEBHOOK_PROVIDER_SLACK
+	}
+	return pb.WebhookProvider_WEBHOOK_PROVIDER_UNSPECIFIED
+}
+
+// fromPBWebhookProvider преобразует провайдера вебхука из формата protobuf
+func fromPBWebhookProvider(provider pb.WebhookProvider) notifications.WebhookProvider {
+	switch provider {
+	case pb.WebhookProvider_WEBHOOK_PROVIDER_DISCORD:
+		return notifications.WebhookProviderDiscord
+	case pb.WebhookProvider_WEBHOOK_PROVIDER_SLACK:
+		return notifications.WebhookProviderSlack
+	}
+	return ""
+}
+
+// toPBTeacherNameClaims преобразует варианты имени преподавателей в формат protobuf
+func toPBTeacherNameClaims(claims []users.TeacherNameClaim) []*pb.TeacherNameClaim {
+	pbClaims := make([]*pb.TeacherNameClaim, 0, len(claims))
+	for _, claim := range claims {
+		pbClaims = append(pbClaims, toPBTeacherNameClaim(claim))
+	}
+	return pbClaims
+}
+
+// toPBTeacherNameClaim преобразует вариант имени преподавателя в формат protobuf
+func toPBTeacherNameClaim(claim users.TeacherNameClaim) *pb.TeacherNameClaim {
+	var claimStatus pb.ChangeModerationStatus
+	switch claim.Status {
+	case users.TeacherNameClaimPending:
+		claimStatus = pb.ChangeModerationStatus_CHANGE_MODERATION_STATUS_PENDING
+	case users.TeacherNameClaimApproved:
+		claimStatus = pb.ChangeModerationStatus_CHANGE_MODERATION_STATUS_APPROVED
+	case users.TeacherNameClaimRejected:
+		claimStatus = pb.ChangeModerationStatus_CHANGE_MODERATION_STATUS_REJECTED
+	}
+
+	pbClaim := &pb.TeacherNameClaim{
+		Id:          claim.ID.String(),
+		TeacherId:   claim.TeacherID.String(),
+		TeacherName: claim.TeacherName,
+		ScrapedName: claim.ScrapedName,
+		Status:      claimStatus,
+		CreatedAt:   timestamppb.New(claim.CreatedAt),
+	}
+	if claim.ReviewedAt != nil {
+		pbClaim.ReviewedAt = timestamppb.New(*claim.ReviewedAt)
+	}
+	return pbClaim
+}
+
+// toPBTeacherChangeRequests преобразует заявки преподавателей в формат protobuf
+func toPBTeacherChangeRequests(requests []schedule.ChangeRequest, loc *time.Location) []*pb.TeacherChangeRequest {
+	pbRequests := make([]*pb.TeacherChangeRequest, 0, len(requests))
+	for _, request := range requests {
+		pbRequests = append(pbRequests, toPBTeacherChangeRequest(request, loc))
+	}
+	return pbRequests
+}
+
+// toPBTeacherChangeRequest преобразует заявку преподавателя в формат protobuf
+func toPBTeacherChangeRequest(request schedule.ChangeRequest, loc *time.Location) *pb.TeacherChangeRequest {
+	var kind pb.TeacherChangeRequestKind
+	switch request.Kind {
+	case schedule.ChangeRequestCancel:
+		kind = pb.TeacherChangeRequestKind_TEACHER_CHANGE_REQUEST_KIND_CANCEL
+	case schedule.ChangeRequestMove:
+		kind = pb.TeacherChangeRequestKind_TEACHER_CHANGE_REQUEST_KIND_MOVE
+	}
+
+	var requestStatus pb.ChangeModerationStatus
+	switch request.Status {
+	case schedule.ChangeModerationPending:
+		requestStatus = pb.ChangeModerationStatus_CHANGE_MODERATION_STATUS_PENDING
+	case schedule.ChangeModerationApproved:
+		requestStatus = pb.ChangeModerationStatus_CHANGE_MODERATION_STATUS_APPROVED
+	case schedule.ChangeModerationRejected:
+		requestStatus = pb.ChangeModerationStatus_CHANGE_MODERATION_STATUS_REJECTED
+	}
+
+	pbRequest := &pb.TeacherChangeRequest{
+		Id:              request.ID.String(),
+		TeacherId:       request.TeacherID.String(),
+		Kind:            kind,
+		GroupName:       request.GroupName,
+		Date:            timestamppb.New(clock.Anchor(request.Date, loc)),
+		TimeStart:       request.TimeStart,
+		TimeEnd:         request.TimeEnd,
+		Subject:         request.Subject,
+		Teacher:         request.Teacher,
+		Classroom:       request.Classroom,
+		NewTimeStart:    request.NewTimeStart,
+		NewTimeEnd:      request.NewTimeEnd,
+		NewLessonNumber: int32(request.NewLessonNumber),
+		NewClassroom:    request.NewClassroom,
+		Comment:         request.Comment,
+		Status:          requestStatus,
+		ReviewComment:   request.ReviewComment,
+		CreatedAt:       timestamppb.New(request.CreatedAt),
+	}
+	if request.NewDate != nil {
+		pbRequest.NewDate = timestamppb.New(clock.Anchor(*request.NewDate, loc))
+	}
+	if request.ReviewedAt != nil {
+		pbRequest.ReviewedAt = timestamppb.New(*request.ReviewedAt)
+	}
+	return pbRequest
+}
+
+// toChangeEdit преобразует исправления модератора из формата protobuf
+func toChangeEdit(edit *pb.ScheduleChange, loc *time.Location) *changes.ChangeEdit {
+	if edit == nil {
+		return nil
+	}
+
+	changeEdit := &changes.ChangeEdit{
+		TimeStart:    edit.TimeStart,
+		TimeEnd:      edit.TimeEnd,
+		LessonNumber: int(edit.LessonNumber),
+		Subject:      edit.Subject,
+		Teacher:      edit.Teacher,
+		Classroom:    edit.Classroom,
+		Reason:       edit.Reason,
+	}
+	if edit.Date != nil {
+		date := clock.DateOf(edit.Date.AsTime(), loc)
+		changeEdit.Date = &date
+	}
+	return changeEdit
+}
+
+// authenticate проверяет JWT токен и возвращает активного пользователя
+func (s *Server) authenticate(ctx context.Context, token string) (*users.User, error) {
+	claims, err := s.parseToken(ctx, token)
+	if err != nil {
+		return nil, err
+	}
+	return s.userFromClaims(ctx, claims)
+}
+
+// parseToken проверяет JWT токен (пользовательский или гостевой)
+func (s *Server) parseToken(ctx context.Context, token string) (*jwt.Claims, error) {
+	claims, err := s.jwtManager.ParseToken(ctx, token)
+	if err != nil {
+		requestid.Logf(ctx, "Ошибка проверки токена: %v", err)
+		return nil, status.Errorf(codes.Unauthenticated, "Неверный токен")
+	}
+	return claims, nil
+}
+
+// userFromClaims получает активного пользователя по данным токена.
+// Гостевые токены отклоняются: действие требует регистрации.
+func (s *Server) userFromClaims(ctx context.Context, claims *jwt.Claims) (*users.User, error) {
+	if claims.IsGuest() {
+		return nil, status.Errorf(codes.PermissionDenied, "Действие доступно только зарегистрированным пользователям")
+	}
+
+	user, err := s.userService.GetUserByID(ctx, claims.UserID)
+	if err != nil {
+		requestid.Logf(ctx, "Ошибка получения пользователя %s: %v", claims.UserID, err)
+		return nil, status.Errorf(codes.NotFound, "Пользователь не найден")
+	}
+
+	if !user.IsActive {
+		return nil, status.Errorf(codes.PermissionDenied, "Пользователь деактивирован")
+	}
+
+	return user, nil
+}
+
+// authenticateAdmin проверяет JWT токен и права администратора
+func (s *Server) authenticateAdmin(ctx context.Context, token string) (*users.User, error) {
+	user, err := s.authenticate(ctx, token)
+	if err != nil {
+		return nil, err
+	}
+
+	if user.Role != users.RoleAdmin {
+		return nil, status.Errorf(codes.PermissionDenied, "Действие доступно только администраторам")
+	}
+
+	return user, nil
+}
+
+// toPBScheduleEntries преобразует записи расписания в формат protobuf,
+// добавляя параметры отображения предметов
+func (s *Server) toPBScheduleEntries(ctx context.Context, entries []schedule.CurrentSchedule) []*pb.ScheduleEntry {
+	subjects := make([]string, 0, len(entries))
+	for _, entry := range entries {
+		subjects = append(subjects, entry.Subject)
+	}
+	subjectMeta := s.subjectMetadata(ctx, subjects)
+
+	pbSchedule := make([]*pb.ScheduleEntry, 0, len(entries))
+	for _, entry := range entries {
+		pbSchedule = append(pbSchedule, toPBScheduleEntry(entry, subjectMeta))
+	}
+	return pbSchedule
+}
+
+// subjectMetadata получает параметры отображения предметов.
+// Ошибка не прерывает выдачу расписания - записи просто возвращаются без параметров.
+func (s *Server) subjectMetadata(ctx context.Context, subjects []string) map[string]schedule.SubjectMetadata {
+	subjectMeta, err := s.scheduleService.GetSubjectMetadata(ctx, subjects)
+	if err != nil {
+		requestid.Logf(ctx, "Ошибка получения параметров отображения предметов: %v", err)
+		return nil
+	}
+	return subjectMeta
+}
+
+// toPBScheduleEntry преобразует одну запись расписания в формат protobuf
+func toPBScheduleEntry(entry schedule.CurrentSchedule, subjectMeta map[string]schedule.SubjectMetadata) *pb.ScheduleEntry {
+	// Преобразуем SourceType в protobuf enum
+	var sourceTypeEnum pb.ScheduleSourceType
+	switch entry.SourceType {
+	case "main":
+		sourceTypeEnum = pb.ScheduleSourceType_SCHEDULE_SOURCE_TYPE_MAIN
+	case "change":
+		sourceTypeEnum = pb.ScheduleSourceType_SCHEDULE_SOURCE_TYPE_CHANGE
+	case electives.SourceType:
+		sourceTypeEnum = pb.ScheduleSourceType_SCHEDULE_SOURCE_TYPE_ELECTIVE
+	case personal.ConsultationSource:
+		// Консультация в ближайшем занятии (GetNextLesson) - отдельного источника в API нет
+		sourceTypeEnum = pb.ScheduleSourceType_SCHEDULE_SOURCE_TYPE_UNSPECIFIED
+	default:
+		// По умолчанию используем UNDEFINED или логируем ошибку
+		sourceTypeEnum = pb.ScheduleSourceType_SCHEDULE_SOURCE_TYPE_UNSPECIFIED
+		log.Printf("Неизвестный тип источника: %s", entry.SourceType)
+	}
+
+	pbEntry := &pb.ScheduleEntry{
+		Id:         entry.ID.String(),
+		GroupName:  entry.GroupName,
+		Date:       timestamppb.New(entry.Date),
+		TimeStart:  entry.TimeStart,
+		TimeEnd:    entry.TimeEnd,
+		Subject:    entry.Subject,
+		Teacher:    entry.Teacher,
+		Classroom:  entry.Classroom,
+		SourceType: sourceTypeEnum,
+		SourceId:   entry.SourceID.String(),
+		MeetingUrl: entry.MeetingURL,
+		Subgroup:   int32(entry.Subgroup),
+		LessonType: entry.LessonType,
+	}
+	if meta, ok := subjectMeta[entry.Subject]; ok {
+		pbEntry.SubjectMeta = toPBSubjectMetadata(meta)
+	}
+	return pbEntry
+}
+
+// toPBSubjectMetadata преобразует параметры отображения предмета в формат protobuf
+func toPBSubjectMetadata(meta schedule.SubjectMetadata) *pb.SubjectMetadata {
+	return &pb.SubjectMetadata{
+		Subject:   meta.Subject,
+		ShortName: meta.ShortName,
+		Color:     meta.Color,
+		Icon:      meta.Icon,
+		UpdatedAt: timestamppb.New(meta.UpdatedAt),
+	}
+}
+
+// toPBScheduleChange преобразует изменение в расписании в формат protobuf
+func toPBScheduleChange(change schedule.ScheduleChange) *pb.ScheduleChange {
+	var changeType pb.ScheduleChangeType
+	switch change.ChangeType {
+	case "replacement":
+		changeType = pb.ScheduleChangeType_SCHEDULE_CHANGE_TYPE_REPLACEMENT
+	case "cancellation":
+		changeType = pb.ScheduleChangeType_SCHEDULE_CHANGE_TYPE_CANCELLATION
+	case "addition":
+		changeType = pb.ScheduleChangeType_SCHEDULE_CHANGE_TYPE_ADDITION
+	default:
+		changeType = pb.ScheduleChangeType_SCHEDULE_CHANGE_TYPE_UNSPECIFIED
+	}
+
+	var applyStatus pb.ChangeApplyStatus
+	switch change.ApplyStatus {
+	case schedule.ChangeApplyPending:
+		applyStatus = pb.ChangeApplyStatus_CHANGE_APPLY_STATUS_PENDING
+	case schedule.ChangeApplyApplied:
+		applyStatus = pb.ChangeApplyStatus_CHANGE_APPLY_STATUS_APPLIED
+	case schedule.ChangeApplySkipped:
+		applyStatus = pb.ChangeApplyStatus_CHANGE_APPLY_STATUS_SKIPPED
+	case schedule.ChangeApplyError:
+		applyStatus = pb.ChangeApplyStatus_CHANGE_APPLY_STATUS_ERROR
+	case schedule.ChangeApplyReverted:
+		applyStatus = pb.ChangeApplyStatus_CHANGE_APPLY_STATUS_REVERTED
+	}
+
+	var moderationStatus pb.ChangeModerationStatus
+	switch change.ModerationStatus {
+	case schedule.ChangeModerationPending:
+		moderationStatus = pb.ChangeModerationStatus_CHANGE_MODERATION_STATUS_PENDING
+	case schedule.ChangeModerationApproved:
+		moderationStatus = pb.ChangeModerationStatus_CHANGE_MODERATION_STATUS_APPROVED
+	case schedule.ChangeModerationRejected:
+		moderationStatus = pb.ChangeModerationStatus_CHANGE_MODERATION_STATUS_REJECTED
+	}
+
+	pbChange := &pb.ScheduleChange{
+		Id:                change.ID.String(),
+		GroupName:         change.GroupName,
+		Date:              timestamppb.New(change.Date),
+		TimeStart:         change.TimeStart,
+		TimeEnd:           change.TimeEnd,
+		Subject:           change.Subject,
+		Teacher:           change.Teacher,
+		Classroom:         change.Classroom,
+		ChangeType:        changeType,
+		OriginalSubject:   change.OriginalSubject,
+		CreatedAt:         timestamppb.New(change.CreatedAt),
+		LessonNumber:      int32(change.LessonNumber),
+		HasOverlap:        change.HasOverlap,
+		ApplyStatus:       applyStatus,
+		ApplyError:        change.ApplyError,
+		ModerationStatus:  moderationStatus,
+		ModerationComment: change.ModerationComment,
+		Reason:            change.Reason,
+		Subgroup:          int32(change.Subgroup),
+		LessonType:        change.LessonType,
+	}
+	if change.RequestID != nil {
+		pbChange.RequestId = change.RequestID.String()
+	}
+	if change.SupersedesID != nil {
+		pbChange.SupersedesId = change.SupersedesID.String()
+	}
+	if change.SnapshotID != nil {
+		pbChange.SnapshotId = change.SnapshotID.String()
+	}
+	return pbChange
+}
+
+// toPBSnapshotMeta преобразует метаданные снапшота (без данных расписания) в формат protobuf
+func toPBSnapshotMeta(snapshot *schedule.ScheduleSnapshot) *pb.ScheduleSnapshot {
+	pbSnapshot := &pb.ScheduleSnapshot{
+		Id:          snapshot.ID.String(),
+		Name:        snapshot.Name,
+		PeriodStart: timestamppb.New(snapshot.PeriodStart),
+		PeriodEnd:   timestamppb.New(snapshot.PeriodEnd),
+		CreatedAt:   timestamppb.New(snapshot.CreatedAt),
+		SourceUrl:   snapshot.SourceURL,
+		IsActive:    snapshot.IsActive,
+		IsArchived:  snapshot.ArchivedAt != nil,
+	}
+	if snapshot.ArchivedAt != nil {
+		pbSnapshot.ArchivedAt = timestamppb.New(*snapshot.ArchivedAt)
+	}
+	return pbSnapshot
+}
+
+// toPBSnapshotLessons преобразует пары из данных снапшота в формат protobuf
+func toPBSnapshotLessons(lessons []schedule.Lesson) []*pb.SnapshotLesson {
+	pbLessons := make([]*pb.SnapshotLesson, 0, len(lessons))
+	for _, lesson := range lessons {
+		pbLessons = append(pbLessons, toPBSnapshotLesson(lesson))
+	}
+	return pbLessons
+}
+
+// toPBSnapshotLesson преобразует пару из данных снапшота в формат protobuf
+func toPBSnapshotLesson(lesson schedule.Lesson) *pb.SnapshotLesson {
+	return &pb.SnapshotLesson{
+		DayOfWeek:  lesson.DayOfWeek,
+		TimeStart:  lesson.TimeStart,
+		TimeEnd:    lesson.TimeEnd,
+		Subject:    lesson.Subject,
+		Teacher:    lesson.Teacher,
+		Classroom:  lesson.Classroom,
+		Subgroup:   int32(lesson.Subgroup),
+		LessonType: lesson.LessonType,
+	}
+}
+
+// RegisterService регистрирует сервис в gRPC сервере
+func RegisterService(grpcServer *grpc.Server, deps Dependencies) {
+	pb.RegisterScheduleServiceServer(grpcServer, NewServer(deps))
+}
+
+// toPBJob преобразует фоновую задачу в формат protobuf
+func toPBJob(job jobs.Job) *pb.Job {
+	var jobStatus pb.JobStatus
+	switch job.Status {
+	case jobs.StatusPending:
+		jobStatus = pb.JobStatus_JOB_STATUS_PENDING
+	case jobs.StatusRunning:
+		jobStatus = pb.JobStatus_JOB_STATUS_RUNNING
+	case jobs.StatusDone:
+		jobStatus = pb.JobStatus_JOB_STATUS_DONE
+	case jobs.StatusFailed:
+		jobStatus = pb.JobStatus_JOB_STATUS_FAILED
+	}
+
+	pbJob := &pb.Job{
+		Id:          job.ID.String(),
+		Kind:        job.Kind,
+		Payload:     string(job.Payload),
+		Status:      jobStatus,
+		Attempts:    int32(job.Attempts),
+		MaxAttempts: int32(job.MaxAttempts),
+		RunAt:       timestamppb.New(job.RunAt),
+		LockedBy:    job.LockedBy,
+		LastError:   job.LastError,
+		CreatedAt:   timestamppb.New(job.CreatedAt),
+	}
+	if job.FinishedAt != nil {
+		pbJob.FinishedAt = timestamppb.New(*job.FinishedAt)
+	}
+	return pbJob
+}
+
+// fromPBJobStatus преобразует состояние задачи из protobuf; JOB_STATUS_UNSPECIFIED - любое
+func fromPBJobStatus(jobStatus pb.JobStatus) jobs.Status {
+	switch jobStatus {
+	case pb.JobStatus_JOB_STATUS_PENDING:
+		return jobs.StatusPending
+	case pb.JobStatus_JOB_STATUS_RUNNING:
+		return jobs.StatusRunning
+	case pb.JobStatus_JOB_STATUS_DONE:
+		return jobs.StatusDone
+	case pb.JobStatus_JOB_STATUS_FAILED:
+		return jobs.StatusFailed
+	}
+	return ""
+}
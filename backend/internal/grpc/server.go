@@ -3,41 +3,132 @@
 package grpc
 
 import (
+	"bytes"
 	"context"
+	"errors"
 	"fmt"
 	"log"
 	"net"
+	"strings"
 	"time"
 
+	"github.com/Ultrahd-dev/student-schedule-app/backend/internal/apperr"
+	"github.com/Ultrahd-dev/student-schedule-app/backend/internal/audit"
+	"github.com/Ultrahd-dev/student-schedule-app/backend/internal/auth"
+	"github.com/Ultrahd-dev/student-schedule-app/backend/internal/captcha"
+	filesgrpc "github.com/Ultrahd-dev/student-schedule-app/backend/internal/grpc/files"
+	"github.com/Ultrahd-dev/student-schedule-app/backend/internal/grpc/middleware"
+	notificationsgrpc "github.com/Ultrahd-dev/student-schedule-app/backend/internal/grpc/notifications"
 	schedulegrpc "github.com/Ultrahd-dev/student-schedule-app/backend/internal/grpc/schedule" // Для регистрации
 	"github.com/Ultrahd-dev/student-schedule-app/backend/internal/jwt"
-	"github.com/Ultrahd-dev/student-schedule-app/backend/internal/schedule" // Пакет schedule
+	"github.com/Ultrahd-dev/student-schedule-app/backend/internal/notifications"
+	"github.com/Ultrahd-dev/student-schedule-app/backend/internal/requestid"
+	"github.com/Ultrahd-dev/student-schedule-app/backend/internal/tenant"
 	"github.com/Ultrahd-dev/student-schedule-app/backend/internal/users"
 	pb "github.com/Ultrahd-dev/student-schedule-app/backend/proto/gen/users"
+	"github.com/google/uuid"
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/keepalive"
 	"google.golang.org/grpc/reflection"
 	"google.golang.org/grpc/status"
 )
 
+// AdminMethods методы сервиса пользователей, доступные только администраторам
+var AdminMethods = []string{
+	pb.UserService_SetUserRole_FullMethodName,
+	pb.UserService_DeleteUser_FullMethodName,
+	pb.UserService_RestoreUser_FullMethodName,
+	pb.UserService_ImportStudentRoster_FullMethodName,
+	pb.UserService_ListAuditEvents_FullMethodName,
+	pb.UserService_CreateInvitation_FullMethodName,
+	pb.UserService_ListInvitations_FullMethodName,
+	pb.UserService_RevokeInvitation_FullMethodName,
+}
+
+// PasswordChangeMethods методы, доступные пользователю с временным паролем до его смены
+// (auth.Middleware.PasswordChangeInterceptor): смена пароля, профиль и выход
+var PasswordChangeMethods = []string{
+	pb.UserService_ChangePassword_FullMethodName,
+	pb.UserService_GetProfile_FullMethodName,
+	pb.UserService_RevokeToken_FullMethodName,
+}
+
+// RestrictedFields поля ответов сервиса пользователей, скрываемые от остальных ролей
+// (auth.Middleware.FieldFilterInterceptor). Служебный идентификатор преподавателя
+// (teacher_id) видят только преподаватели и администраторы.
+var RestrictedFields = []auth.RestrictedField{
+	{Field: "users.TeacherProfile.teacher_id", Roles: []string{string(users.RoleAdmin), string(users.RoleTeacher)}},
+}
+
 // Server реализует gRPC сервис для работы с пользователями
 type Server struct {
 	pb.UnimplementedUserServiceServer
-	userService *users.Service
-	jwtManager  *jwt.Manager
+	userService  *users.Service
+	jwtManager   *jwt.Manager
+	auditService *audit.Service
+	captcha      captcha.Verifier           // Проверка CAPTCHA при регистрации и входе (nil - отключена)
+	colleges     middleware.CollegeResolver // Поиск колледжа по метаданным x-college (nil - только колледж по умолчанию)
+	newDevices   NewDeviceAlerter           // Уведомления о входе с нового устройства (nil - отключены)
+	transport    TransportOptions
+}
+
+// TransportOptions настройки транспорта gRPC сервера. Нулевые значения
+// оставляют настройки gRPC по умолчанию.
+type TransportOptions struct {
+	MaxRecvMsgSize int // Максимальный размер входящего сообщения, байт
+	MaxSendMsgSize int // Максимальный размер исходящего сообщения, байт
+	// CompressMinSize размер ответа, начиная с которого он сжимается gzip; 0 - без сжатия
+	CompressMinSize int
+	// Keepalive: сервер проверяет соединение, простаивающее KeepaliveTime, и закрывает его,
+	// если ответ на проверку не пришел за KeepaliveTimeout. Так освобождаются соединения
+	// мобильных клиентов, пропавших из сети без закрытия соединения.
+	KeepaliveTime     time.Duration
+	KeepaliveTimeout  time.Duration
+	MaxConnectionIdle time.Duration // Соединение без запросов закрывается через это время
+	// MinClientPingInterval минимальный интервал проверок соединения со стороны клиента;
+	// более частые проверки считаются злоупотреблением и соединение закрывается
+	MinClientPingInterval time.Duration
 }
 
 // NewServer создает новый gRPC сервер
-func NewServer(userService *users.Service, jwtManager *jwt.Manager) *Server {
+func NewServer(userService *users.Service, jwtManager *jwt.Manager, auditService *audit.Service, captchaVerifier captcha.Verifier) *Server {
 	return &Server{
-		userService: userService,
-		jwtManager:  jwtManager,
+		userService:  userService,
+		jwtManager:   jwtManager,
+		auditService: auditService,
+		captcha:      captchaVerifier,
 	}
 }
 
+// SetColleges включает выбор колледжа по метаданным x-college для запросов без токена
+func (s *Server) SetColleges(colleges middleware.CollegeResolver) {
+	s.colleges = colleges
+}
+
+// NewDeviceAlerter сообщает пользователю о входе с нового устройства
+type NewDeviceAlerter interface {
+	NotifyNewDeviceLogin(ctx context.Context, login notifications.NewDeviceLogin) error
+}
+
+// SetNewDeviceAlerter включает уведомления о входе с устройства или адреса,
+// с которых пользователь раньше не входил (по истории входов журнала)
+func (s *Server) SetNewDeviceAlerter(alerter NewDeviceAlerter) {
+	s.newDevices = alerter
+}
+
+// SetTransport задает размеры сообщений, сжатие ответов и keepalive сервера
+func (s *Server) SetTransport(options TransportOptions) {
+	s.transport = options
+}
+
 // RegisterStudent регистрирует нового студента
 func (s *Server) RegisterStudent(ctx context.Context, req *pb.RegisterStudentRequest) (*pb.RegisterResponse, error) {
-	log.Printf("Получен запрос на регистрацию студента: %s", req.Email)
+	requestid.Logf(ctx, "Получен запрос на регистрацию студента: %s", req.Email)
+
+	if err := s.verifyCaptcha(ctx, req.Captcha); err != nil {
+		return nil, err
+	}
 
 	// Подготавливаем данные для регистрации
 	input := users.RegisterStudentInput{
@@ -46,17 +137,20 @@ func (s *Server) RegisterStudent(ctx context.Context, req *pb.RegisterStudentReq
 			Password: req.Password,
 			Role:     users.RoleStudent,
 		},
-		GroupName:     req.GroupName,
-		Faculty:       req.Faculty,
-		Course:        int(req.Course),
-		StudentNumber: req.StudentNumber,
+		GroupName:      req.GroupName,
+		Faculty:        req.Faculty,
+		Course:         int(req.Course),
+		FullName:       req.FullName,
+		StudentNumber:  req.StudentNumber,
+		Subgroup:       int(req.Subgroup),
+		InvitationCode: req.InvitationCode,
 	}
 
 	// Регистрируем студента
 	user, student, err := s.userService.RegisterStudent(ctx, input)
 	if err != nil {
-		log.Printf("Ошибка регистрации студента %s: %v", req.Email, err)
-		return nil, status.Errorf(codes.Internal, "Ошибка регистрации: %v", err)
+		requestid.Logf(ctx, "Ошибка регистрации студента %s: %v", req.Email, err)
+		return nil, registrationError(err)
 	}
 
 	// Формируем ответ
@@ -71,23 +165,28 @@ func (s *Server) RegisterStudent(ctx context.Context, req *pb.RegisterStudentReq
 			IsActive:  user.IsActive,
 		},
 		Profile: &pb.RegisterResponse_StudentProfile{
-			StudentProfile: &pb.StudentProfile{
-				UserId:        student.UserID.String(),
-				GroupName:     student.GroupName,
-				Faculty:       student.Faculty,
-				Course:        int32(student.Course),
-				StudentNumber: student.StudentNumber,
-			},
+			StudentProfile: toPBStudentProfile(student),
 		},
 	}
 
-	log.Printf("Студент %s успешно зарегистрирован", req.Email)
+	s.auditService.Record(ctx, audit.Event{
+		Type:    audit.EventRegistration,
+		UserID:  &user.ID,
+		Email:   user.Email,
+		Details: string(user.Role),
+	})
+
+	requestid.Logf(ctx, "Студент %s успешно зарегистрирован", req.Email)
 	return response, nil
 }
 
 // RegisterTeacher регистрирует нового преподавателя
 func (s *Server) RegisterTeacher(ctx context.Context, req *pb.RegisterTeacherRequest) (*pb.RegisterResponse, error) {
-	log.Printf("Получен запрос на регистрацию преподавателя: %s", req.Email)
+	requestid.Logf(ctx, "Получен запрос на регистрацию преподавателя: %s", req.Email)
+
+	if err := s.verifyCaptcha(ctx, req.Captcha); err != nil {
+		return nil, err
+	}
 
 	// Подготавливаем данные для регистрации
 	input := users.RegisterTeacherInput{
@@ -96,17 +195,18 @@ func (s *Server) RegisterTeacher(ctx context.Context, req *pb.RegisterTeacherReq
 			Password: req.Password,
 			Role:     users.RoleTeacher,
 		},
-		FullName:   req.FullName,
-		Department: req.Department,
-		Position:   req.Position,
-		TeacherID:  req.TeacherId,
+		FullName:       req.FullName,
+		Department:     req.Department,
+		Position:       req.Position,
+		TeacherID:      req.TeacherId,
+		InvitationCode: req.InvitationCode,
 	}
 
 	// Регистрируем преподавателя
 	user, teacher, err := s.userService.RegisterTeacher(ctx, input)
 	if err != nil {
-		log.Printf("Ошибка регистрации преподавателя %s: %v", req.Email, err)
-		return nil, status.Errorf(codes.Internal, "Ошибка регистрации: %v", err)
+		requestid.Logf(ctx, "Ошибка регистрации преподавателя %s: %v", req.Email, err)
+		return nil, registrationError(err)
 	}
 
 	// Формируем ответ
@@ -131,26 +231,75 @@ func (s *Server) RegisterTeacher(ctx context.Context, req *pb.RegisterTeacherReq
 		},
 	}
 
-	log.Printf("Преподаватель %s успешно зарегистрирован", req.Email)
+	s.auditService.Record(ctx, audit.Event{
+		Type:    audit.EventRegistration,
+		UserID:  &user.ID,
+		Email:   user.Email,
+		Details: string(user.Role),
+	})
+
+	requestid.Logf(ctx, "Преподаватель %s успешно зарегистрирован", req.Email)
 	return response, nil
 }
 
+// registrationError преобразует ошибку регистрации в gRPC статус
+func registrationError(err error) error {
+	if errors.Is(err, apperr.ErrAlreadyExists) && !apperr.UserFacing(err) {
+		return status.Errorf(codes.AlreadyExists, "Пользователь с таким email уже зарегистрирован")
+	}
+	return middleware.Status(err, "Ошибка регистрации")
+}
+
 // Login выполняет вход пользователя в систему
 func (s *Server) Login(ctx context.Context, req *pb.LoginRequest) (*pb.LoginResponse, error) {
-	log.Printf("Получен запрос на вход: %s", req.Email)
+	requestid.Logf(ctx, "Получен запрос на вход: %s", req.Email)
+
+	if err := s.verifyCaptcha(ctx, req.Captcha); err != nil {
+		s.recordLoginFailed(ctx, req.Email, "captcha")
+		return nil, err
+	}
 
 	// Аутентифицируем пользователя
 	user, err := s.userService.AuthenticateUser(ctx, req.Email, req.Password)
 	if err != nil {
-		log.Printf("Ошибка аутентификации пользователя %s: %v", req.Email, err)
+		requestid.Logf(ctx, "Ошибка аутентификации пользователя %s: %v", req.Email, err)
+		s.recordLoginFailed(ctx, req.Email, "")
 		return nil, status.Errorf(codes.Unauthenticated, "Неверный email или пароль")
 	}
 
+	// С включенной 2FA вход завершается вторым шагом (VerifyTwoFactor)
+	twoFactor, err := s.userService.TwoFactorEnabled(ctx, user.ID)
+	if err != nil {
+		requestid.Logf(ctx, "Ошибка проверки 2FA пользователя %s: %v", user.Email, err)
+		return nil, middleware.Status(err, "Ошибка входа")
+	}
+	if twoFactor {
+		pending, expiresAt, err := s.jwtManager.GenerateTwoFactorToken(user.ID, user.Email, string(user.Role), user.CollegeID)
+		if err != nil {
+			requestid.Logf(ctx, "Ошибка генерации токена второго шага для пользователя %s: %v", user.Email, err)
+			return nil, middleware.Status(err, "Ошибка генерации токена")
+		}
+
+		requestid.Logf(ctx, "Пользователь %s прошел проверку пароля, ожидается код 2FA", user.Email)
+		return &pb.LoginResponse{
+			Success:            true,
+			Message:            "Введите код двухфакторной аутентификации",
+			TwoFactorRequired:  true,
+			TwoFactorToken:     pending,
+			TwoFactorExpiresAt: expiresAt.Format(time.RFC3339),
+		}, nil
+	}
+
+	return s.completeLogin(ctx, user, "")
+}
+
+// completeLogin выдает JWT токен пользователю, прошедшему все шаги входа
+func (s *Server) completeLogin(ctx context.Context, user *users.User, details string) (*pb.LoginResponse, error) {
 	// Генерируем JWT токен
-	token, err := s.jwtManager.GenerateToken(user.ID, user.Email, string(user.Role))
+	token, err := s.jwtManager.GenerateToken(user.ID, user.Email, string(user.Role), user.CollegeID)
 	if err != nil {
-		log.Printf("Ошибка генерации JWT токена для пользователя %s: %v", user.Email, err)
-		return nil, status.Errorf(codes.Internal, "Ошибка генерации токена")
+		requestid.Logf(ctx, "Ошибка генерации JWT токена для пользователя %s: %v", user.Email, err)
+		return nil, middleware.Status(err, "Ошибка генерации токена")
 	}
 
 	// Формируем ответ
@@ -158,34 +307,201 @@ func (s *Server) Login(ctx context.Context, req *pb.LoginRequest) (*pb.LoginResp
 		Success: true,
 		Message: "Вход выполнен успешно",
 		Token:   token,
-		User: &pb.User{
-			Id:        user.ID.String(),
-			Email:     user.Email,
-			Role:      pb.UserRole(pb.UserRole_value[string(user.Role)]),
-			CreatedAt: user.CreatedAt.Format(time.RFC3339),
-			IsActive:  user.IsActive,
-		},
+		User:    toPBUser(user),
 	}
 
-	log.Printf("Пользователь %s успешно вошел в систему", req.Email)
+	// История сравнивается до записи текущего входа
+	s.alertNewDevice(ctx, user)
+	s.auditService.Record(ctx, audit.Event{
+		Type:    audit.EventLogin,
+		UserID:  &user.ID,
+		Email:   user.Email,
+		Details: details,
+	})
+
+	requestid.Logf(ctx, "Пользователь %s успешно вошел в систему", user.Email)
 	return response, nil
 }
 
+// alertNewDevice уведомляет пользователя о входе с устройства или адреса, с которых
+// он раньше не входил. Ошибки только логируются: уведомление не должно ломать вход.
+func (s *Server) alertNewDevice(ctx context.Context, user *users.User) {
+	if s.newDevices == nil || !user.LoginAlerts {
+		return
+	}
+	known, err := s.auditService.IsKnownDevice(ctx, user.ID)
+	if err != nil {
+		requestid.Logf(ctx, "Ошибка проверки истории входов пользователя %s: %v", user.Email, err)
+		return
+	}
+	if known {
+		return
+	}
+
+	ip, userAgent := audit.ClientInfo(ctx)
+	login := notifications.NewDeviceLogin{UserID: user.ID, Email: user.Email, IP: ip, UserAgent: userAgent, At: time.Now()}
+	if err := s.newDevices.NotifyNewDeviceLogin(ctx, login); err != nil {
+		requestid.Logf(ctx, "Ошибка уведомления пользователя %s о входе с нового устройства: %v", user.Email, err)
+	}
+}
+
+// VerifyTwoFactor завершает вход с двухфакторной аутентификацией.
+// Токен второго шага одноразовый: после неверного кода нужно войти заново.
+func (s *Server) VerifyTwoFactor(ctx context.Context, req *pb.VerifyTwoFactorRequest) (*pb.LoginResponse, error) {
+	claims, err := s.jwtManager.ParseTwoFactorToken(ctx, req.TwoFactorToken)
+	if err != nil {
+		requestid.Logf(ctx, "Ошибка проверки токена второго шага: %v", err)
+		return nil, status.Errorf(codes.Unauthenticated, "Сессия входа истекла, войдите заново")
+	}
+
+	user, err := s.userService.GetUserByID(ctx, claims.UserID)
+	if err != nil {
+		requestid.Logf(ctx, "Ошибка получения пользователя %s: %v", claims.UserID, err)
+		return nil, status.Errorf(codes.NotFound, "Пользователь не найден")
+	}
+	if !user.IsActive {
+		return nil, status.Errorf(codes.PermissionDenied, "Пользователь деактивирован")
+	}
+
+	usedRecovery, verifyErr := s.userService.VerifyTwoFactor(ctx, user.ID, req.Code)
+
+	// Токен второго шага больше не нужен ни после успешной, ни после неудачной попытки
+	if err := s.userService.RevokeToken(ctx, claims.ID, user.ID, claims.ExpiresAt.Time); err != nil {
+		requestid.Logf(ctx, "Ошибка отзыва токена второго шага пользователя %s: %v", user.Email, err)
+	}
+
+	if verifyErr != nil {
+		requestid.Logf(ctx, "Ошибка проверки кода 2FA пользователя %s: %v", user.Email, verifyErr)
+		s.auditService.Record(ctx, audit.Event{
+			Type:    audit.EventLoginFailed,
+			UserID:  &user.ID,
+			Email:   user.Email,
+			Details: "2fa",
+		})
+		if errors.Is(verifyErr, users.ErrTwoFactorInvalidCode) {
+			return nil, status.Errorf(codes.Unauthenticated, "Неверный код, войдите заново")
+		}
+		return nil, twoFactorError("Ошибка проверки кода", verifyErr)
+	}
+
+	details := "2fa"
+	if usedRecovery {
+		details = "2fa recovery code"
+	}
+	return s.completeLogin(ctx, user, details)
+}
+
+// EnrollTwoFactor начинает подключение двухфакторной аутентификации
+func (s *Server) EnrollTwoFactor(ctx context.Context, req *pb.EnrollTwoFactorRequest) (*pb.EnrollTwoFactorResponse, error) {
+	user, _, err := s.authenticate(ctx, req.Token)
+	if err != nil {
+		return nil, err
+	}
+
+	secret, uri, err := s.userService.BeginTwoFactorEnrollment(ctx, user)
+	if err != nil {
+		return nil, twoFactorError("Ошибка подключения 2FA", err)
+	}
+
+	return &pb.EnrollTwoFactorResponse{
+		Success:         true,
+		Message:         "Отсканируйте QR-код в приложении и подтвердите подключение кодом",
+		Secret:          secret,
+		ProvisioningUri: uri,
+	}, nil
+}
+
+// ConfirmTwoFactor включает двухфакторную аутентификацию после проверки первого кода
+func (s *Server) ConfirmTwoFactor(ctx context.Context, req *pb.ConfirmTwoFactorRequest) (*pb.ConfirmTwoFactorResponse, error) {
+	user, _, err := s.authenticate(ctx, req.Token)
+	if err != nil {
+		return nil, err
+	}
+
+	recoveryCodes, err := s.userService.ConfirmTwoFactor(ctx, user.ID, req.Code)
+	if err != nil {
+		return nil, twoFactorError("Ошибка подключения 2FA", err)
+	}
+
+	s.auditService.Record(ctx, audit.Event{
+		Type:    audit.EventTwoFactorChange,
+		UserID:  &user.ID,
+		Email:   user.Email,
+		Details: "enabled",
+	})
+
+	return &pb.ConfirmTwoFactorResponse{
+		Success:       true,
+		Message:       "Двухфакторная аутентификация включена. Сохраните коды восстановления",
+		RecoveryCodes: recoveryCodes,
+	}, nil
+}
+
+// DisableTwoFactor отключает двухфакторную аутентификацию
+func (s *Server) DisableTwoFactor(ctx context.Context, req *pb.DisableTwoFactorRequest) (*pb.DisableTwoFactorResponse, error) {
+	user, _, err := s.authenticate(ctx, req.Token)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.userService.DisableTwoFactor(ctx, user.ID, req.Code); err != nil {
+		return nil, twoFactorError("Ошибка отключения 2FA", err)
+	}
+
+	s.auditService.Record(ctx, audit.Event{
+		Type:    audit.EventTwoFactorChange,
+		UserID:  &user.ID,
+		Email:   user.Email,
+		Details: "disabled",
+	})
+
+	return &pb.DisableTwoFactorResponse{
+		Success: true,
+		Message: "Двухфакторная аутентификация отключена",
+	}, nil
+}
+
+// twoFactorError преобразует ошибку двухфакторной аутентификации в gRPC статус
+func twoFactorError(action string, err error) error {
+	if !apperr.UserFacing(err) {
+		log.Printf("%s: %v", action, err)
+	}
+	return middleware.Status(err, action)
+}
+
+// recordLoginFailed записывает неудачный вход; если пользователь с таким email
+// существует, событие привязывается к нему
+func (s *Server) recordLoginFailed(ctx context.Context, email, details string) {
+	event := audit.Event{
+		Type:    audit.EventLoginFailed,
+		Email:   email,
+		Details: details,
+	}
+	if user, err := s.userService.GetUserByEmail(ctx, email); err == nil {
+		event.UserID = &user.ID
+	}
+	s.auditService.Record(ctx, event)
+}
+
 // GetProfile возвращает профиль текущего пользователя
 func (s *Server) GetProfile(ctx context.Context, req *pb.GetProfileRequest) (*pb.GetProfileResponse, error) {
-	log.Printf("Получен запрос на получение профиля")
+	requestid.Logf(ctx, "Получен запрос на получение профиля")
 
 	// Проверяем токен
-	claims, err := s.jwtManager.ParseToken(req.Token)
+	claims, err := s.jwtManager.ParseToken(ctx, req.Token)
 	if err != nil {
-		log.Printf("Ошибка проверки токена: %v", err)
+		requestid.Logf(ctx, "Ошибка проверки токена: %v", err)
 		return nil, status.Errorf(codes.Unauthenticated, "Неверный токен")
 	}
 
+	if claims.IsGuest() {
+		return nil, status.Errorf(codes.PermissionDenied, "Профиль доступен только зарегистрированным пользователям")
+	}
+
 	// Получаем информацию о пользователе
 	user, err := s.userService.GetUserByID(ctx, claims.UserID)
 	if err != nil {
-		log.Printf("Ошибка получения пользователя %s: %v", claims.UserID, err)
+		requestid.Logf(ctx, "Ошибка получения пользователя %s: %v", claims.UserID, err)
 		return nil, status.Errorf(codes.NotFound, "Пользователь не найден")
 	}
 
@@ -193,13 +509,7 @@ func (s *Server) GetProfile(ctx context.Context, req *pb.GetProfileRequest) (*pb
 	response := &pb.GetProfileResponse{
 		Success: true,
 		Message: "Профиль получен успешно",
-		User: &pb.User{
-			Id:        user.ID.String(),
-			Email:     user.Email,
-			Role:      pb.UserRole(pb.UserRole_value[string(user.Role)]),
-			CreatedAt: user.CreatedAt.Format(time.RFC3339),
-			IsActive:  user.IsActive,
-		},
+		User:    toPBUser(user),
 	}
 
 	// В зависимости от роли добавляем профиль
@@ -222,38 +532,796 @@ func (s *Server) GetProfile(ctx context.Context, req *pb.GetProfileRequest) (*pb
 		}
 	}
 
-	log.Printf("Профиль пользователя %s успешно получен", user.Email)
+	requestid.Logf(ctx, "Профиль пользователя %s успешно получен", user.Email)
+	return response, nil
+}
+
+// IssueGuestToken выдает гостевой токен для просмотра расписания группы без регистрации
+func (s *Server) IssueGuestToken(ctx context.Context, req *pb.IssueGuestTokenRequest) (*pb.IssueGuestTokenResponse, error) {
+	groupName := strings.TrimSpace(req.GroupName)
+	requestid.Logf(ctx, "Получен запрос на гостевой токен для группы %q", groupName)
+
+	if groupName == "" {
+		return nil, status.Errorf(codes.InvalidArgument, "Необходимо указать группу")
+	}
+
+	token, expiresAt, err := s.jwtManager.GenerateGuestToken(groupName, tenant.CollegeID(ctx))
+	if err != nil {
+		requestid.Logf(ctx, "Ошибка генерации гостевого токена: %v", err)
+		return nil, middleware.Status(err, "Ошибка генерации токена")
+	}
+
+	return &pb.IssueGuestTokenResponse{
+		Success:   true,
+		Message:   "Гостевой доступ предоставлен",
+		Token:     token,
+		GroupName: groupName,
+		ExpiresAt: expiresAt.Format(time.RFC3339),
+	}, nil
+}
+
+// GetCaptchaChallenge возвращает параметры CAPTCHA для регистрации и входа
+func (s *Server) GetCaptchaChallenge(ctx context.Context, req *pb.GetCaptchaChallengeRequest) (*pb.GetCaptchaChallengeResponse, error) {
+	response := &pb.GetCaptchaChallengeResponse{
+		Success: true,
+		Message: "Проверка CAPTCHA отключена",
+	}
+
+	switch verifier := s.captcha.(type) {
+	case *captcha.ProofOfWork:
+		challenge, expiresAt, err := verifier.Challenge()
+		if err != nil {
+			requestid.Logf(ctx, "Ошибка создания задачи proof-of-work: %v", err)
+			return nil, middleware.Status(err, "Ошибка создания задачи")
+		}
+		response.Message = "Решите задачу proof-of-work"
+		response.Provider = verifier.Provider()
+		response.Challenge = challenge
+		response.Difficulty = int32(verifier.Difficulty())
+		response.ExpiresAt = expiresAt.Format(time.RFC3339)
+	case *captcha.SiteVerifier:
+		response.Message = "Пройдите проверку CAPTCHA"
+		response.Provider = verifier.Provider()
+		response.SiteKey = verifier.SiteKey()
+	}
+
 	return response, nil
 }
 
+// verifyCaptcha проверяет ответ на CAPTCHA, если проверка включена
+func (s *Server) verifyCaptcha(ctx context.Context, response string) error {
+	if s.captcha == nil {
+		return nil
+	}
+
+	ip, _ := audit.ClientInfo(ctx)
+	err := s.captcha.Verify(ctx, response, ip)
+	switch {
+	case err == nil:
+		return nil
+	case errors.Is(err, captcha.ErrRequired):
+		return status.Errorf(codes.InvalidArgument, "Требуется пройти проверку CAPTCHA")
+	case errors.Is(err, captcha.ErrInvalid):
+		requestid.Logf(ctx, "Проверка CAPTCHA не пройдена (%s): %v", ip, err)
+		return status.Errorf(codes.PermissionDenied, "Проверка CAPTCHA не пройдена")
+	default:
+		requestid.Logf(ctx, "Ошибка проверки CAPTCHA: %v", err)
+		return status.Errorf(codes.Unavailable, "Проверка CAPTCHA временно недоступна")
+	}
+}
+
+// ChangePassword меняет пароль текущего пользователя
+func (s *Server) ChangePassword(ctx context.Context, req *pb.ChangePasswordRequest) (*pb.ChangePasswordResponse, error) {
+	user, _, err := s.authenticate(ctx, req.Token)
+	if err != nil {
+		return nil, err
+	}
+
+	requestid.Logf(ctx, "Получен запрос на смену пароля от %s", user.Email)
+
+	if err := s.userService.ChangePassword(ctx, user.ID, req.OldPassword, req.NewPassword); err != nil {
+		requestid.Logf(ctx, "Ошибка смены пароля пользователя %s: %v", user.Email, err)
+		return nil, middleware.Status(err, "Ошибка смены пароля")
+	}
+
+	s.auditService.Record(ctx, audit.Event{
+		Type:   audit.EventPasswordChange,
+		UserID: &user.ID,
+		Email:  user.Email,
+	})
+
+	return &pb.ChangePasswordResponse{
+		Success: true,
+		Message: "Пароль изменен",
+	}, nil
+}
+
+// SetLoginAlerts включает или отключает уведомления о входе с нового устройства
+func (s *Server) SetLoginAlerts(ctx context.Context, req *pb.SetLoginAlertsRequest) (*pb.SetLoginAlertsResponse, error) {
+	user, _, err := s.authenticate(ctx, req.Token)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.userService.SetLoginAlerts(ctx, user.ID, req.Enabled); err != nil {
+		requestid.Logf(ctx, "Ошибка настройки уведомлений о входе пользователя %s: %v", user.Email, err)
+		return nil, middleware.Status(err, "Ошибка настройки уведомлений о входе")
+	}
+
+	message := "Уведомления о входе с нового устройства включены"
+	if !req.Enabled {
+		message = "Уведомления о входе с нового устройства отключены"
+	}
+	requestid.Logf(ctx, "Пользователь %s изменил уведомления о входе с нового устройства: %t", user.Email, req.Enabled)
+	return &pb.SetLoginAlertsResponse{
+		Success: true,
+		Message: message,
+	}, nil
+}
+
+// SetFormatPreferences сохраняет язык и форматы даты и времени пользователя
+func (s *Server) SetFormatPreferences(ctx context.Context, req *pb.SetFormatPreferencesRequest) (*pb.SetFormatPreferencesResponse, error) {
+	user, _, err := s.authenticate(ctx, req.Token)
+	if err != nil {
+		return nil, err
+	}
+
+	prefs := users.FormatPreferences{
+		Locale:     strings.TrimSpace(req.Locale),
+		DateFormat: strings.TrimSpace(req.DateFormat),
+		TimeFormat: strings.TrimSpace(req.TimeFormat),
+		Timezone:   strings.TrimSpace(req.Timezone),
+	}
+	if err := s.userService.SetFormatPreferences(ctx, user.ID, prefs); err != nil {
+		requestid.Logf(ctx, "Ошибка сохранения форматов пользователя %s: %v", user.Email, err)
+		return nil, middleware.Status(err, "Ошибка сохранения форматов даты и времени")
+	}
+
+	updated, err := s.userService.GetUserByID(ctx, user.ID)
+	if err != nil {
+		requestid.Logf(ctx, "Ошибка получения пользователя %s: %v", user.Email, err)
+		return nil, middleware.Status(err, "Ошибка получения пользователя")
+	}
+	requestid.Logf(ctx, "Пользователь %s выбрал язык %s, форматы %q, %q и часовой пояс %q",
+		user.Email, updated.Locale, updated.DateFormat, updated.TimeFormat, updated.Timezone)
+	return &pb.SetFormatPreferencesResponse{
+		Success: true,
+		Message: "Форматы даты и времени сохранены",
+		User:    toPBUser(updated),
+	}, nil
+}
+
+// RevokeToken отзывает переданный токен (выход из системы)
+func (s *Server) RevokeToken(ctx context.Context, req *pb.RevokeTokenRequest) (*pb.RevokeTokenResponse, error) {
+	user, claims, err := s.authenticate(ctx, req.Token)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.userService.RevokeToken(ctx, claims.ID, user.ID, claims.ExpiresAt.Time); err != nil {
+		requestid.Logf(ctx, "Ошибка отзыва токена пользователя %s: %v", user.Email, err)
+		return nil, middleware.Status(err, "Ошибка отзыва токена")
+	}
+
+	s.auditService.Record(ctx, audit.Event{
+		Type:    audit.EventTokenRevocation,
+		UserID:  &user.ID,
+		Email:   user.Email,
+		Details: claims.ID,
+	})
+
+	requestid.Logf(ctx, "Пользователь %s отозвал токен %s", user.Email, claims.ID)
+	return &pb.RevokeTokenResponse{
+		Success: true,
+		Message: "Токен отозван",
+	}, nil
+}
+
+// SetUserRole меняет роль пользователя (только для администраторов)
+func (s *Server) SetUserRole(ctx context.Context, req *pb.SetUserRoleRequest) (*pb.SetUserRoleResponse, error) {
+	admin, err := s.authenticateAdmin(ctx, req.Token)
+	if err != nil {
+		return nil, err
+	}
+
+	userID, err := uuid.Parse(req.UserId)
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "Неверный ID пользователя: %s", req.UserId)
+	}
+	role, ok := fromPBUserRole(req.Role)
+	if !ok {
+		return nil, status.Errorf(codes.InvalidArgument, "Необходимо указать роль")
+	}
+
+	previous, err := s.userService.SetRole(ctx, userID, role)
+	if err != nil {
+		requestid.Logf(ctx, "Ошибка смены роли пользователя %s: %v", userID, err)
+		return nil, middleware.Status(err, "Ошибка смены роли")
+	}
+
+	user, err := s.userService.GetUserByID(ctx, userID)
+	if err != nil {
+		requestid.Logf(ctx, "Ошибка получения пользователя %s: %v", userID, err)
+		return nil, middleware.Status(err, "Ошибка получения пользователя")
+	}
+
+	if previous != role {
+		s.auditService.Record(ctx, audit.Event{
+			Type:    audit.EventRoleChange,
+			UserID:  &user.ID,
+			ActorID: &admin.ID,
+			Email:   user.Email,
+			Details: fmt.Sprintf("%s -> %s", previous, role),
+		})
+		requestid.Logf(ctx, "Администратор %s сменил роль пользователя %s: %s -> %s", admin.Email, user.Email, previous, role)
+	}
+
+	return &pb.SetUserRoleResponse{
+		Success: true,
+		Message: "Роль пользователя изменена",
+		User:    toPBUser(user),
+	}, nil
+}
+
+// DeleteUser мягко удаляет пользователя (только для администраторов)
+func (s *Server) DeleteUser(ctx context.Context, req *pb.DeleteUserRequest) (*pb.DeleteUserResponse, error) {
+	admin, err := s.authenticateAdmin(ctx, req.Token)
+	if err != nil {
+		return nil, err
+	}
+
+	userID, err := uuid.Parse(req.UserId)
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "Неверный ID пользователя: %s", req.UserId)
+	}
+
+	user, subscriptions, err := s.userService.DeleteUser(ctx, userID, admin.ID)
+	if err != nil {
+		requestid.Logf(ctx, "Ошибка удаления пользователя %s: %v", userID, err)
+		return nil, middleware.Status(err, "Ошибка удаления пользователя")
+	}
+
+	s.auditService.Record(ctx, audit.Event{
+		Type:    audit.EventUserDeletion,
+		UserID:  &user.ID,
+		ActorID: &admin.ID,
+		Email:   user.Email,
+		Details: fmt.Sprintf("subscriptions removed: %d", subscriptions),
+	})
+	requestid.Logf(ctx, "Администратор %s удалил пользователя %s (подписок удалено: %d)", admin.Email, user.Email, subscriptions)
+
+	return &pb.DeleteUserResponse{
+		Success:              true,
+		Message:              "Пользователь удален",
+		User:                 toPBUser(user),
+		DeletedSubscriptions: int32(subscriptions),
+	}, nil
+}
+
+// RestoreUser восстанавливает удаленного пользователя (только для администраторов)
+func (s *Server) RestoreUser(ctx context.Context, req *pb.RestoreUserRequest) (*pb.RestoreUserResponse, error) {
+	admin, err := s.authenticateAdmin(ctx, req.Token)
+	if err != nil {
+		return nil, err
+	}
+
+	userID, err := uuid.Parse(req.UserId)
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "Неверный ID пользователя: %s", req.UserId)
+	}
+
+	user, err := s.userService.RestoreUser(ctx, userID)
+	if err != nil {
+		requestid.Logf(ctx, "Ошибка восстановления пользователя %s: %v", userID, err)
+		return nil, middleware.Status(err, "Ошибка восстановления пользователя")
+	}
+
+	s.auditService.Record(ctx, audit.Event{
+		Type:    audit.EventUserRestore,
+		UserID:  &user.ID,
+		ActorID: &admin.ID,
+		Email:   user.Email,
+	})
+	requestid.Logf(ctx, "Администратор %s восстановил пользователя %s", admin.Email, user.Email)
+
+	return &pb.RestoreUserResponse{
+		Success: true,
+		Message: "Пользователь восстановлен",
+		User:    toPBUser(user),
+	}, nil
+}
+
+// ImportStudentRoster регистрирует студентов группы по списку CSV (только для администраторов)
+func (s *Server) ImportStudentRoster(ctx context.Context, req *pb.ImportStudentRosterRequest) (*pb.ImportStudentRosterResponse, error) {
+	admin, err := s.authenticateAdmin(ctx, req.Token)
+	if err != nil {
+		return nil, err
+	}
+
+	records, err := users.ReadRosterCSV(bytes.NewReader(req.Csv))
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "%v", err)
+	}
+	students, err := users.ParseRoster(records)
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "%v", err)
+	}
+
+	result, err := s.userService.ImportRoster(ctx, students)
+	if err != nil {
+		requestid.Logf(ctx, "Ошибка регистрации студентов по списку: %v", err)
+		return nil, middleware.Status(err, "Ошибка регистрации студентов по списку")
+	}
+
+	response := &pb.ImportStudentRosterResponse{
+		Success: true,
+		Total:   int32(len(students)),
+	}
+	invited := 0
+	for _, account := range result.Created {
+		s.auditService.Record(ctx, audit.Event{
+			Type:    audit.EventRegistration,
+			UserID:  &account.UserID,
+			ActorID: &admin.ID,
+			Email:   account.Student.Email,
+			Details: "student roster import",
+		})
+		if account.Invited {
+			invited++
+		}
+		response.Created = append(response.Created, &pb.RosterAccount{
+			Line:              int32(account.Student.Line),
+			Email:             account.Student.Email,
+			FullName:          account.Student.FullName,
+			GroupName:         account.Student.GroupName,
+			UserId:            account.UserID.String(),
+			Invited:           account.Invited,
+			TemporaryPassword: account.Password,
+		})
+	}
+	for _, skip := range result.Skipped {
+		response.Skipped = append(response.Skipped, &pb.RosterSkip{
+			Line:   int32(skip.Student.Line),
+			Email:  skip.Student.Email,
+			Reason: skip.Reason,
+		})
+	}
+	response.Message = fmt.Sprintf("Зарегистрировано %d, приглашений отправлено %d, пропущено %d",
+		len(result.Created), invited, len(result.Skipped))
+
+	requestid.Logf(ctx, "Администратор %s зарегистрировал студентов по списку: %s", admin.Email, response.Message)
+	return response, nil
+}
+
+// ListAuditEvents возвращает страницу журнала безопасности (только для администраторов)
+func (s *Server) ListAuditEvents(ctx context.Context, req *pb.ListAuditEventsRequest) (*pb.ListAuditEventsResponse, error) {
+	if _, err := s.authenticateAdmin(ctx, req.Token); err != nil {
+		return nil, err
+	}
+
+	filter := audit.Filter{
+		Type:   fromPBAuditEventType(req.Type),
+		Limit:  int(req.PageSize),
+		Offset: int(req.Offset),
+	}
+	if req.UserId != "" {
+		userID, err := uuid.Parse(req.UserId)
+		if err != nil {
+			return nil, status.Errorf(codes.InvalidArgument, "Неверный ID пользователя: %s", req.UserId)
+		}
+		filter.UserID = &userID
+	}
+	if req.From != "" {
+		from, err := time.Parse(time.RFC3339, req.From)
+		if err != nil {
+			return nil, status.Errorf(codes.InvalidArgument, "Неверный формат даты from: %s", req.From)
+		}
+		filter.From = &from
+	}
+	if req.To != "" {
+		to, err := time.Parse(time.RFC3339, req.To)
+		if err != nil {
+			return nil, status.Errorf(codes.InvalidArgument, "Неверный формат даты to: %s", req.To)
+		}
+		filter.To = &to
+	}
+
+	events, total, err := s.auditService.List(ctx, filter)
+	if err != nil {
+		requestid.Logf(ctx, "Ошибка получения журнала безопасности: %v", err)
+		return nil, middleware.Status(err, "Ошибка получения журнала безопасности")
+	}
+
+	response := &pb.ListAuditEventsResponse{
+		Success: true,
+		Message: fmt.Sprintf("Найдено событий: %d", total),
+		Total:   int32(total),
+	}
+	for _, event := range events {
+		response.Events = append(response.Events, toPBAuditEvent(event))
+	}
+	return response, nil
+}
+
+// CreateInvitation выпускает код приглашения для регистрации (только для администраторов)
+func (s *Server) CreateInvitation(ctx context.Context, req *pb.CreateInvitationRequest) (*pb.CreateInvitationResponse, error) {
+	admin, err := s.authenticateAdmin(ctx, req.Token)
+	if err != nil {
+		return nil, err
+	}
+
+	input := users.CreateInvitationInput{
+		GroupName: req.GroupName,
+		MaxUses:   int(req.MaxUses),
+	}
+	if req.Role != pb.UserRole_ROLE_UNSPECIFIED {
+		role, _ := fromPBUserRole(req.Role)
+		input.Role = role
+	}
+	if req.ExpiresAt != "" {
+		expiresAt, err := time.Parse(time.RFC3339, req.ExpiresAt)
+		if err != nil {
+			return nil, status.Errorf(codes.InvalidArgument, "Неверный формат даты expires_at: %s", req.ExpiresAt)
+		}
+		input.ExpiresAt = &expiresAt
+	}
+
+	invitation, err := s.userService.CreateInvitation(ctx, admin.ID, input)
+	if err != nil {
+		requestid.Logf(ctx, "Ошибка выпуска приглашения: %v", err)
+		return nil, middleware.Status(err, "Ошибка выпуска приглашения")
+	}
+
+	return &pb.CreateInvitationResponse{
+		Success:    true,
+		Message:    "Приглашение выпущено",
+		Invitation: toPBInvitation(invitation),
+	}, nil
+}
+
+// ListInvitations возвращает приглашения (только для администраторов)
+func (s *Server) ListInvitations(ctx context.Context, req *pb.ListInvitationsRequest) (*pb.ListInvitationsResponse, error) {
+	if _, err := s.authenticateAdmin(ctx, req.Token); err != nil {
+		return nil, err
+	}
+
+	invitations, err := s.userService.ListInvitations(ctx, req.ActiveOnly)
+	if err != nil {
+		requestid.Logf(ctx, "Ошибка получения приглашений: %v", err)
+		return nil, middleware.Status(err, "Ошибка получения приглашений")
+	}
+
+	response := &pb.ListInvitationsResponse{
+		Success: true,
+		Message: fmt.Sprintf("Найдено приглашений: %d", len(invitations)),
+	}
+	for i := range invitations {
+		response.Invitations = append(response.Invitations, toPBInvitation(&invitations[i]))
+	}
+	return response, nil
+}
+
+// RevokeInvitation отзывает приглашение (только для администраторов)
+func (s *Server) RevokeInvitation(ctx context.Context, req *pb.RevokeInvitationRequest) (*pb.RevokeInvitationResponse, error) {
+	admin, err := s.authenticateAdmin(ctx, req.Token)
+	if err != nil {
+		return nil, err
+	}
+
+	invitationID, err := uuid.Parse(req.InvitationId)
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "Неверный ID приглашения: %s", req.InvitationId)
+	}
+
+	invitation, err := s.userService.RevokeInvitation(ctx, invitationID)
+	if err != nil {
+		requestid.Logf(ctx, "Ошибка отзыва приглашения %s: %v", invitationID, err)
+		return nil, status.Errorf(codes.NotFound, "%v", err)
+	}
+
+	requestid.Logf(ctx, "Администратор %s отозвал приглашение %s", admin.Email, invitation.Code)
+	return &pb.RevokeInvitationResponse{
+		Success:    true,
+		Message:    "Приглашение отозвано",
+		Invitation: toPBInvitation(invitation),
+	}, nil
+}
+
+// SetStudentSubgroup сохраняет подгруппу текущего студента
+func (s *Server) SetStudentSubgroup(ctx context.Context, req *pb.SetStudentSubgroupRequest) (*pb.SetStudentSubgroupResponse, error) {
+	user, _, err := s.authenticate(ctx, req.Token)
+	if err != nil {
+		return nil, err
+	}
+	if user.Role != users.RoleStudent {
+		return nil, status.Errorf(codes.PermissionDenied, "Подгруппу выбирают только студенты")
+	}
+
+	student, err := s.userService.SetStudentSubgroup(ctx, user.ID, int(req.Subgroup))
+	switch {
+	case errors.Is(err, apperr.ErrNotFound):
+		return nil, status.Errorf(codes.FailedPrecondition, "Профиль студента не найден")
+	case err != nil:
+		requestid.Logf(ctx, "Ошибка сохранения подгруппы студента %s: %v", user.Email, err)
+		return nil, middleware.Status(err, "Ошибка сохранения подгруппы")
+	}
+
+	requestid.Logf(ctx, "Студент %s выбрал подгруппу %d", user.Email, student.Subgroup)
+	return &pb.SetStudentSubgroupResponse{
+		Success:        true,
+		Message:        "Подгруппа сохранена",
+		StudentProfile: toPBStudentProfile(student),
+	}, nil
+}
+
+// authenticateAdmin проверяет JWT токен и возвращает пользователя-администратора
+func (s *Server) authenticateAdmin(ctx context.Context, token string) (*users.User, error) {
+	user, _, err := s.authenticate(ctx, token)
+	if err != nil {
+		return nil, err
+	}
+	if user.Role != users.RoleAdmin {
+		return nil, status.Errorf(codes.PermissionDenied, "Действие доступно только администраторам")
+	}
+	return user, nil
+}
+
+// authenticate проверяет JWT токен и возвращает активного пользователя и данные токена.
+// Гостевые токены не принимаются.
+func (s *Server) authenticate(ctx context.Context, token string) (*users.User, *jwt.Claims, error) {
+	claims, err := s.jwtManager.ParseToken(ctx, token)
+	if err != nil {
+		requestid.Logf(ctx, "Ошибка проверки токена: %v", err)
+		return nil, nil, status.Errorf(codes.Unauthenticated, "Неверный токен")
+	}
+	if claims.IsGuest() {
+		return nil, nil, status.Errorf(codes.PermissionDenied, "Действие доступно только зарегистрированным пользователям")
+	}
+
+	user, err := s.userService.GetUserByID(ctx, claims.UserID)
+	if err != nil {
+		requestid.Logf(ctx, "Ошибка получения пользователя %s: %v", claims.UserID, err)
+		return nil, nil, status.Errorf(codes.NotFound, "Пользователь не найден")
+	}
+	if !user.IsActive {
+		return nil, nil, status.Errorf(codes.PermissionDenied, "Пользователь деактивирован")
+	}
+
+	return user, claims, nil
+}
+
+// toPBUser преобразует пользователя в формат protobuf
+func toPBUser(user *users.User) *pb.User {
+	return &pb.User{
+		Id:                     user.ID.String(),
+		Email:                  user.Email,
+		Role:                   pb.UserRole(pb.UserRole_value[string(user.Role)]),
+		CreatedAt:              user.CreatedAt.Format(time.RFC3339),
+		IsActive:               user.IsActive,
+		PasswordChangeRequired: user.PasswordChangeRequired,
+		LoginAlerts:            user.LoginAlerts,
+		Locale:                 user.Locale,
+		DateFormat:             user.DateFormat,
+		TimeFormat:             user.TimeFormat,
+		Timezone:               user.Timezone,
+	}
+}
+
+// toPBStudentProfile преобразует профиль студента в формат protobuf
+func toPBStudentProfile(student *users.Student) *pb.StudentProfile {
+	return &pb.StudentProfile{
+		UserId:        student.UserID.String(),
+		FullName:      student.FullName,
+		GroupName:     student.GroupName,
+		Faculty:       student.Faculty,
+		Course:        int32(student.Course),
+		StudentNumber: student.StudentNumber,
+		Subgroup:      int32(student.Subgroup),
+	}
+}
+
+// toPBInvitation преобразует приглашение в формат protobuf
+func toPBInvitation(invitation *users.Invitation) *pb.Invitation {
+	result := &pb.Invitation{
+		Id:        invitation.ID.String(),
+		Code:      invitation.Code,
+		GroupName: invitation.GroupName,
+		MaxUses:   int32(invitation.MaxUses),
+		UsedCount: int32(invitation.UsedCount),
+		CreatedAt: invitation.CreatedAt.Format(time.RFC3339),
+		Active:    invitation.Active(time.Now()),
+	}
+	switch invitation.Role {
+	case users.RoleStudent:
+		result.Role = pb.UserRole_ROLE_STUDENT
+	case users.RoleTeacher:
+		result.Role = pb.UserRole_ROLE_TEACHER
+	}
+	if invitation.ExpiresAt != nil {
+		result.ExpiresAt = invitation.ExpiresAt.Format(time.RFC3339)
+	}
+	if invitation.RevokedAt != nil {
+		result.RevokedAt = invitation.RevokedAt.Format(time.RFC3339)
+	}
+	return result
+}
+
+// fromPBUserRole преобразует роль из формата protobuf
+func fromPBUserRole(role pb.UserRole) (users.Role, bool) {
+	switch role {
+	case pb.UserRole_ROLE_STUDENT:
+		return users.RoleStudent, true
+	case pb.UserRole_ROLE_TEACHER:
+		return users.RoleTeacher, true
+	case pb.UserRole_ROLE_ADMIN:
+		return users.RoleAdmin, true
+	default:
+		return "", false
+	}
+}
+
+// auditEventTypes соответствие типов событий журнала и protobuf
+var auditEventTypes = map[audit.EventType]pb.AuditEventType{
+	audit.EventRegistration:    pb.AuditEventType_AUDIT_EVENT_TYPE_REGISTRATION,
+	audit.EventLogin:           pb.AuditEventType_AUDIT_EVENT_TYPE_LOGIN,
+	audit.EventLoginFailed:     pb.AuditEventType_AUDIT_EVENT_TYPE_LOGIN_FAILED,
+	audit.EventPasswordChange:  pb.AuditEventType_AUDIT_EVENT_TYPE_PASSWORD_CHANGE,
+	audit.EventRoleChange:      pb.AuditEventType_AUDIT_EVENT_TYPE_ROLE_CHANGE,
+	audit.EventTokenRevocation: pb.AuditEventType_AUDIT_EVENT_TYPE_TOKEN_REVOCATION,
+	audit.EventTwoFactorChange: pb.AuditEventType_AUDIT_EVENT_TYPE_TWO_FACTOR_CHANGE,
+	audit.EventUserExport:      pb.AuditEventType_AUDIT_EVENT_TYPE_USER_EXPORT,
+	audit.EventUserDeletion:    pb.AuditEventType_AUDIT_EVENT_TYPE_USER_DELETION,
+	audit.EventUserRestore:     pb.AuditEventType_AUDIT_EVENT_TYPE_USER_RESTORE,
+}
+
+// fromPBAuditEventType преобразует тип события из формата protobuf (пустой - любой тип)
+func fromPBAuditEventType(eventType pb.AuditEventType) audit.EventType {
+	for t, pbType := range auditEventTypes {
+		if pbType == eventType {
+			return t
+		}
+	}
+	return ""
+}
+
+// toPBAuditEvent преобразует событие журнала в формат protobuf
+func toPBAuditEvent(event audit.Event) *pb.AuditEvent {
+	result := &pb.AuditEvent{
+		Id:        event.ID.String(),
+		Type:      auditEventTypes[event.Type],
+		Email:     event.Email,
+		Ip:        event.IP,
+		UserAgent: event.UserAgent,
+		Details:   event.Details,
+		CreatedAt: event.CreatedAt.Format(time.RFC3339),
+	}
+	if event.UserID != nil {
+		result.UserId = event.UserID.String()
+	}
+	if event.ActorID != nil {
+		result.ActorId = event.ActorID.String()
+	}
+	return result
+}
+
 // Start запускает gRPC сервер
-// Исправленная сигнатура метода
-func (s *Server) Start(port int, scheduleService *schedule.Service, userService *users.Service) error {
+// scheduleDeps - сервисы для Schedule Service (JWT менеджер берется из сервера, если не задан)
+// fileDeps - сервисы для File Service (сервис не регистрируется, если хранилище не настроено)
+// notificationDeps - сервисы для Notification Service (сервис не регистрируется без сервиса уведомлений)
+// interceptors - unary interceptor'ы, выполняемые по порядку перед обработчиками (авторизация и т.п.)
+func (s *Server) Start(port int, scheduleDeps schedulegrpc.Dependencies, fileDeps filesgrpc.Dependencies, notificationDeps notificationsgrpc.Dependencies, interceptors ...grpc.UnaryServerInterceptor) error {
 	// Создаем TCP слушатель
 	lis, err := net.Listen("tcp", fmt.Sprintf(":%d", port))
 	if err != nil {
 		return fmt.Errorf("ошибка создания TCP слушателя: %w", err)
 	}
 
-	// Создаем gRPC сервер
-	grpcServer := grpc.NewServer()
+	grpcServer := s.NewGRPCServer(scheduleDeps, fileDeps, notificationDeps, interceptors...)
+
+	log.Printf("Запуск gRPC сервера на порту %d", port)
+
+	// Запускаем сервер
+	if err := grpcServer.Serve(lis); err != nil {
+		return fmt.Errorf("ошибка запуска gRPC сервера: %w", err)
+	}
+
+	return nil
+}
+
+// serverOptions возвращает параметры gRPC сервера для заданных настроек транспорта
+func (t TransportOptions) serverOptions() []grpc.ServerOption {
+	var options []grpc.ServerOption
+	if t.MaxRecvMsgSize > 0 {
+		options = append(options, grpc.MaxRecvMsgSize(t.MaxRecvMsgSize))
+	}
+	if t.MaxSendMsgSize > 0 {
+		options = append(options, grpc.MaxSendMsgSize(t.MaxSendMsgSize))
+	}
+	if t.KeepaliveTime > 0 || t.MaxConnectionIdle > 0 {
+		options = append(options, grpc.KeepaliveParams(keepalive.ServerParameters{
+			Time:              t.KeepaliveTime,
+			Timeout:           t.KeepaliveTimeout,
+			MaxConnectionIdle: t.MaxConnectionIdle,
+		}))
+	}
+	if t.MinClientPingInterval > 0 {
+		// Клиенты могут проверять соединение и без активных запросов:
+		// мобильное приложение держит соединение, пока открыт экран расписания
+		options = append(options, grpc.KeepaliveEnforcementPolicy(keepalive.EnforcementPolicy{
+			MinTime:             t.MinClientPingInterval,
+			PermitWithoutStream: true,
+		}))
+	}
+	return options
+}
+
+// NewGRPCServer создает gRPC сервер с зарегистрированными сервисами, не запуская его.
+// Используется Start и интеграционными тестами, которые обслуживают сервер на своем слушателе.
+func (s *Server) NewGRPCServer(scheduleDeps schedulegrpc.Dependencies, fileDeps filesgrpc.Dependencies, notificationDeps notificationsgrpc.Dependencies, interceptors ...grpc.UnaryServerInterceptor) *grpc.Server {
+	// Создаем gRPC сервер. Общие interceptor'ы стоят первыми, чтобы покрывать
+	// и остальные: идентификатор запроса нужен всем записям журнала, а журнал
+	// доступа должен видеть итоговый код ответа после преобразования ошибок.
+	// Колледж запроса определяется до авторизации, чтобы ее проверки шли в его данных.
+	// Кэш проверок отзыва токена стоит перед всеми, кто разбирает токен.
+	unary := []grpc.UnaryServerInterceptor{
+		middleware.RevocationCacheInterceptor(),
+		middleware.RequestIDInterceptor(),
+		middleware.AccessLogInterceptor(s.jwtManager),
+	}
+	if s.transport.CompressMinSize > 0 {
+		unary = append(unary, middleware.CompressionInterceptor(s.transport.CompressMinSize))
+	}
+	unary = append(append(unary,
+		middleware.RecoveryInterceptor(),
+		middleware.ErrorInterceptor(),
+		middleware.TenantInterceptor(s.jwtManager, s.colleges),
+	), interceptors...)
+	options := append([]grpc.ServerOption{
+		grpc.ChainUnaryInterceptor(unary...),
+		grpc.ChainStreamInterceptor(
+			middleware.StreamRequestIDInterceptor(),
+			middleware.StreamAccessLogInterceptor(),
+			middleware.StreamRecoveryInterceptor(),
+			middleware.StreamErrorInterceptor(),
+			middleware.StreamTenantInterceptor(s.colleges),
+		),
+	}, s.transport.serverOptions()...)
+	grpcServer := grpc.NewServer(options...)
 
 	// Регистрируем наши сервисы
 	pb.RegisterUserServiceServer(grpcServer, s)
 
 	// Регистрируем Schedule Service
 	// Предполагая, что у вас есть функция RegisterService в пакете schedulegrpc
-	schedulegrpc.RegisterService(grpcServer, scheduleService, s.jwtManager, userService)
-
-	// Включаем Reflection API для grpcurl и других инструментов
-	reflection.Register(grpcServer)
+	if scheduleDeps.JWTManager == nil {
+		scheduleDeps.JWTManager = s.jwtManager
+	}
+	schedulegrpc.RegisterService(grpcServer, scheduleDeps)
 
-	log.Printf("Запуск gRPC сервера на порту %d", port)
+	// Регистрируем File Service
+	if fileDeps.FileService != nil {
+		if fileDeps.JWTManager == nil {
+			fileDeps.JWTManager = s.jwtManager
+		}
+		if fileDeps.UserService == nil {
+			fileDeps.UserService = s.userService
+		}
+		filesgrpc.RegisterService(grpcServer, fileDeps)
+	}
 
-	// Запускаем сервер
-	if err := grpcServer.Serve(lis); err != nil {
-		return fmt.Errorf("ошибка запуска gRPC сервера: %w", err)
+	// Регистрируем Notification Service
+	if notificationDeps.NotificationService != nil {
+		if notificationDeps.JWTManager == nil {
+			notificationDeps.JWTManager = s.jwtManager
+		}
+		if notificationDeps.UserService == nil {
+			notificationDeps.UserService = s.userService
+		}
+		notificationsgrpc.RegisterService(grpcServer, notificationDeps)
 	}
 
-	return nil
+	// Включаем Reflection API для grpcurl и других инструментов
+	reflection.Register(grpcServer)
+
+	return grpcServer
 }
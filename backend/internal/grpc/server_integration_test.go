@@ -0,0 +1,319 @@
+package grpc_test
+
+import (
+	"context"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/Ultrahd-dev/student-schedule-app/backend/internal/audit"
+	"github.com/Ultrahd-dev/student-schedule-app/backend/internal/auth"
+	"github.com/Ultrahd-dev/student-schedule-app/backend/internal/changes"
+	"github.com/Ultrahd-dev/student-schedule-app/backend/internal/feedback"
+	servergrpc "github.com/Ultrahd-dev/student-schedule-app/backend/internal/grpc"
+	filesgrpc "github.com/Ultrahd-dev/student-schedule-app/backend/internal/grpc/files"
+	notificationsgrpc "github.com/Ultrahd-dev/student-schedule-app/backend/internal/grpc/notifications"
+	schedulegrpc "github.com/Ultrahd-dev/student-schedule-app/backend/internal/grpc/schedule"
+	"github.com/Ultrahd-dev/student-schedule-app/backend/internal/jwt"
+	"github.com/Ultrahd-dev/student-schedule-app/backend/internal/notifications"
+	"github.com/Ultrahd-dev/student-schedule-app/backend/internal/schedule"
+	"github.com/Ultrahd-dev/student-schedule-app/backend/internal/testutil"
+	"github.com/Ultrahd-dev/student-schedule-app/backend/internal/txn"
+	schedulepb "github.com/Ultrahd-dev/student-schedule-app/backend/proto/gen/schedule"
+	pb "github.com/Ultrahd-dev/student-schedule-app/backend/proto/gen/users"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// testClients клиенты сервисов, обслуживаемых тестовым gRPC сервером
+type testClients struct {
+	users    pb.UserServiceClient
+	schedule schedulepb.ScheduleServiceClient
+}
+
+// startServer поднимает gRPC сервер с той же цепочкой interceptor'ов, что и в cmd/api,
+// поверх тестовой базы и возвращает клиенты к нему
+func startServer(t *testing.T, f *testutil.Fixtures) *testClients {
+	t.Helper()
+
+	loc := time.UTC
+	jwtManager := jwt.NewManager("integration-test-secret", time.Hour, time.Hour)
+	jwtManager.SetRevocationChecker(f.UserRepo)
+
+	scheduleService := schedule.NewService(f.ScheduleRepo, loc)
+	notificationService := notifications.NewService(f.UserRepo, f.ScheduleRepo, notifications.NewRepository(f.DB), loc)
+	server := servergrpc.NewServer(f.UserService, jwtManager, audit.NewService(audit.NewRepository(f.DB)), nil)
+
+	authMiddleware := auth.NewMiddleware(jwtManager, f.UserRepo)
+	adminMethods := append(append([]string{}, schedulegrpc.AdminMethods...), servergrpc.AdminMethods...)
+	restrictedFields := append(append([]auth.RestrictedField{}, schedulegrpc.RestrictedFields...), servergrpc.RestrictedFields...)
+	grpcServer := server.NewGRPCServer(schedulegrpc.Dependencies{
+		ScheduleService:     scheduleService,
+		UserService:         f.UserService,
+		ChangeService:       changes.NewService(f.ScheduleRepo, txn.NewManager(f.DB), changes.Config{}),
+		NotificationService: notificationService,
+		FeedbackService:     feedback.NewService(feedback.Config{}, feedback.NewRepository(f.DB), scheduleService, loc),
+	}, filesgrpc.Dependencies{}, notificationsgrpc.Dependencies{NotificationService: notificationService},
+		authMiddleware.FieldFilterInterceptor(restrictedFields...),
+		authMiddleware.PasswordChangeInterceptor(servergrpc.PasswordChangeMethods...),
+		authMiddleware.GuestInterceptor(schedulegrpc.GuestMethods...),
+		authMiddleware.AdminInterceptor(adminMethods...),
+		authMiddleware.TeacherGroupInterceptor(
+			schedulegrpc.NewGroupAccess(f.UserService, scheduleService), schedulegrpc.TeacherGroupMethods...))
+
+	lis := bufconn.Listen(1 << 20)
+	go grpcServer.Serve(lis)
+	t.Cleanup(grpcServer.Stop)
+
+	conn, err := grpc.NewClient("passthrough:///bufnet",
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) {
+			return lis.DialContext(ctx)
+		}),
+		grpc.WithTransportCredentials(insecure.NewCredentials()))
+	if err != nil {
+		t.Fatalf("Ошибка подключения к тестовому серверу: %v", err)
+	}
+	t.Cleanup(func() { conn.Close() })
+
+	return &testClients{
+		users:    pb.NewUserServiceClient(conn),
+		schedule: schedulepb.NewScheduleServiceClient(conn),
+	}
+}
+
+// login выполняет вход и возвращает JWT токен
+func login(t *testing.T, client pb.UserServiceClient, email, password string) string {
+	t.Helper()
+
+	resp, err := client.Login(context.Background(), &pb.LoginRequest{Email: email, Password: password})
+	if err != nil {
+		t.Fatalf("Login(%s): %v", email, err)
+	}
+	if resp.Token == "" {
+		t.Fatalf("Login(%s) не вернул токен: %s", email, resp.Message)
+	}
+	return resp.Token
+}
+
+// assertCode проверяет gRPC код ошибки
+func assertCode(t *testing.T, err error, want codes.Code) {
+	t.Helper()
+
+	if got := status.Code(err); got != want {
+		t.Errorf("ожидался код %s, получен %s (%v)", want, got, err)
+	}
+}
+
+// TestRegisterLoginAndSchedule проверяет регистрацию студента, вход,
+// получение профиля и расписания своей группы
+func TestRegisterLoginAndSchedule(t *testing.T) {
+	db := testutil.StartPostgres(t)
+	f := testutil.NewFixtures(t, db)
+	clients := startServer(t, f)
+	ctx := context.Background()
+
+	const group = "ИС-31"
+	date := testutil.NextWeekday(time.Monday, time.UTC)
+	f.CurrentLesson(group, date, 1, "Программирование", "Сидоров С.С.", "305")
+
+	registered, err := clients.users.RegisterStudent(ctx, &pb.RegisterStudentRequest{
+		Email:     "student@test.local",
+		Password:  testutil.DefaultPassword,
+		GroupName: group,
+		Course:    2,
+		FullName:  "Смирнова Анна",
+	})
+	if err != nil {
+		t.Fatalf("RegisterStudent: %v", err)
+	}
+	if !registered.Success || registered.GetStudentProfile().GetGroupName() != group {
+		t.Fatalf("неожиданный ответ регистрации: %v", registered)
+	}
+
+	// Повторная регистрация с тем же email отклоняется
+	_, err = clients.users.RegisterStudent(ctx, &pb.RegisterStudentRequest{
+		Email: "student@test.local", Password: testutil.DefaultPassword, GroupName: group, Course: 2,
+	})
+	if err == nil {
+		t.Error("повторная регистрация с тем же email должна завершиться ошибкой")
+	}
+
+	// Неверный пароль
+	_, err = clients.users.Login(ctx, &pb.LoginRequest{Email: "student@test.local", Password: "wrong-password"})
+	assertCode(t, err, codes.Unauthenticated)
+
+	token := login(t, clients.users, "student@test.local", testutil.DefaultPassword)
+
+	profile, err := clients.users.GetProfile(ctx, &pb.GetProfileRequest{Token: token})
+	if err != nil {
+		t.Fatalf("GetProfile: %v", err)
+	}
+	if profile.GetUser().GetEmail() != "student@test.local" || profile.GetStudentProfile() == nil {
+		t.Errorf("неожиданный профиль: %v", profile)
+	}
+
+	scheduleResp, err := clients.schedule.GetScheduleForGroup(ctx, &schedulepb.GetScheduleForGroupRequest{
+		Token:     token,
+		GroupName: group,
+		Date:      timestamppb.New(date),
+	})
+	if err != nil {
+		t.Fatalf("GetScheduleForGroup: %v", err)
+	}
+	if len(scheduleResp.Schedule) != 1 || scheduleResp.Schedule[0].Subject != "Программирование" {
+		t.Errorf("неожиданное расписание: %v", scheduleResp.Schedule)
+	}
+
+	// Отозванный токен больше не принимается
+	if _, err := clients.users.RevokeToken(ctx, &pb.RevokeTokenRequest{Token: token}); err != nil {
+		t.Fatalf("RevokeToken: %v", err)
+	}
+	_, err = clients.users.GetProfile(ctx, &pb.GetProfileRequest{Token: token})
+	assertCode(t, err, codes.Unauthenticated)
+}
+
+// TestAdminMethodsAndGroupRoster проверяет, что административные методы и список группы
+// доступны только администраторам и преподавателям группы
+func TestAdminMethodsAndGroupRoster(t *testing.T) {
+	db := testutil.StartPostgres(t)
+	f := testutil.NewFixtures(t, db)
+	clients := startServer(t, f)
+	ctx := context.Background()
+
+	const group = "ИС-41"
+	f.CurrentLesson(group, testutil.NextWeekday(time.Tuesday, time.UTC), 3, "Базы данных", "Кузнецова Е.В.", "210")
+
+	admin := f.User().Admin()
+	student, _ := f.User().Group(group).FullName("Орлов Иван").Student()
+	teacher, _ := f.User().FullName("Кузнецова Е.В.").Teacher()
+	otherTeacher, _ := f.User().FullName("Федоров А.А.").Teacher()
+
+	adminToken := login(t, clients.users, admin.Email, testutil.DefaultPassword)
+	studentToken := login(t, clients.users, student.Email, testutil.DefaultPassword)
+	teacherToken := login(t, clients.users, teacher.Email, testutil.DefaultPassword)
+	otherTeacherToken := login(t, clients.users, otherTeacher.Email, testutil.DefaultPassword)
+
+	// Смена роли доступна только администратору
+	_, err := clients.users.SetUserRole(ctx, &pb.SetUserRoleRequest{
+		Token: studentToken, UserId: student.ID.String(), Role: pb.UserRole_ROLE_ADMIN,
+	})
+	assertCode(t, err, codes.PermissionDenied)
+
+	events, err := clients.users.ListAuditEvents(ctx, &pb.ListAuditEventsRequest{Token: adminToken})
+	if err != nil {
+		t.Fatalf("ListAuditEvents: %v", err)
+	}
+	if len(events.Events) == 0 {
+		t.Error("журнал безопасности пуст, ожидались события входа")
+	}
+
+	// Список группы: администратор и преподаватель группы - да, студент и чужой преподаватель - нет
+	for name, tc := range map[string]struct {
+		token string
+		code  codes.Code
+	}{
+		"администратор":        {adminToken, codes.OK},
+		"преподаватель группы": {teacherToken, codes.OK},
+		"чужой преподаватель":  {otherTeacherToken, codes.PermissionDenied},
+		"студент":              {studentToken, codes.PermissionDenied},
+	} {
+		resp, err := clients.schedule.GetGroupRoster(ctx, &schedulepb.GetGroupRosterRequest{Token: tc.token, GroupName: group})
+		if status.Code(err) != tc.code {
+			t.Errorf("%s: ожидался код %s, получен %s (%v)", name, tc.code, status.Code(err), err)
+			continue
+		}
+		if tc.code == codes.OK && (len(resp.Students) != 1 || resp.Students[0].FullName != "Орлов Иван") {
+			t.Errorf("%s: неожиданный список группы: %v", name, resp.Students)
+		}
+	}
+}
+
+// TestReportScheduleErrorAccess проверяет, что жалобу на пару принимают только
+// от студентов группы, преподавателя пары и администраторов
+func TestReportScheduleErrorAccess(t *testing.T) {
+	db := testutil.StartPostgres(t)
+	f := testutil.NewFixtures(t, db)
+	clients := startServer(t, f)
+	ctx := context.Background()
+
+	const group = "ИС-51"
+	date := testutil.NextWeekday(time.Wednesday, time.UTC)
+	lesson := f.CurrentLesson(group, date, 2, "Сети", "Белов П.П.", "112")
+
+	admin := f.User().Admin()
+	student, _ := f.User().Group(group).Student()
+	otherStudent, _ := f.User().Group("ИС-52").Student()
+	teacher, _ := f.User().FullName("Белов П.П.").Teacher()
+	otherTeacher, _ := f.User().FullName("Федоров А.А.").Teacher()
+
+	for name, tc := range map[string]struct {
+		email string
+		code  codes.Code
+	}{
+		"студент группы":        {student.Email, codes.OK},
+		"студент другой группы": {otherStudent.Email, codes.PermissionDenied},
+		"преподаватель пары":    {teacher.Email, codes.OK},
+		"чужой преподаватель":   {otherTeacher.Email, codes.PermissionDenied},
+		"администратор":         {admin.Email, codes.OK},
+	} {
+		_, err := clients.schedule.ReportScheduleError(ctx, &schedulepb.ReportScheduleErrorRequest{
+			Token:     login(t, clients.users, tc.email, testutil.DefaultPassword),
+			GroupName: group,
+			Date:      timestamppb.New(date),
+			TimeStart: lesson.TimeStart,
+			Reason:    schedulepb.ScheduleErrorReason_SCHEDULE_ERROR_REASON_WRONG_CLASSROOM,
+		})
+		if status.Code(err) != tc.code {
+			t.Errorf("%s: ожидался код %s, получен %s (%v)", name, tc.code, status.Code(err), err)
+		}
+	}
+}
+
+// TestRosterAccountMustChangePassword проверяет, что студент, зарегистрированный
+// по списку группы, до смены временного пароля не получает расписание
+func TestRosterAccountMustChangePassword(t *testing.T) {
+	db := testutil.StartPostgres(t)
+	f := testutil.NewFixtures(t, db)
+	clients := startServer(t, f)
+	ctx := context.Background()
+
+	const group = "ИС-61"
+	date := testutil.NextWeekday(time.Thursday, time.UTC)
+	f.CurrentLesson(group, date, 1, "Алгоритмы", "Зайцев Д.Д.", "401")
+
+	admin := f.User().Admin()
+	imported, err := clients.users.ImportStudentRoster(ctx, &pb.ImportStudentRosterRequest{
+		Token: login(t, clients.users, admin.Email, testutil.DefaultPassword),
+		Csv:   []byte("email;ФИО;группа\nroster@test.local;Лебедев Петр;" + group + "\n"),
+	})
+	if err != nil {
+		t.Fatalf("ImportStudentRoster: %v", err)
+	}
+	if len(imported.Created) != 1 || imported.Created[0].TemporaryPassword == "" {
+		t.Fatalf("неожиданный итог загрузки списка: %v", imported)
+	}
+	temporary := imported.Created[0].TemporaryPassword
+
+	token := login(t, clients.users, "roster@test.local", temporary)
+	getSchedule := func(token string) error {
+		_, err := clients.schedule.GetScheduleForGroup(ctx, &schedulepb.GetScheduleForGroupRequest{
+			Token: token, GroupName: group, Date: timestamppb.New(date),
+		})
+		return err
+	}
+	assertCode(t, getSchedule(token), codes.FailedPrecondition)
+
+	if _, err := clients.users.ChangePassword(ctx, &pb.ChangePasswordRequest{
+		Token: token, OldPassword: temporary, NewPassword: "new-password-123",
+	}); err != nil {
+		t.Fatalf("ChangePassword: %v", err)
+	}
+	token = login(t, clients.users, "roster@test.local", "new-password-123")
+	if err := getSchedule(token); err != nil {
+		t.Errorf("расписание после смены пароля: %v", err)
+	}
+}
@@ -0,0 +1,105 @@
+// Package ical формирует календари в формате iCalendar (RFC 5545) для импорта
+// расписания в календарные приложения. Время событий записывается в UTC,
+// поэтому календарь не требует описания часового пояса (VTIMEZONE).
+package ical
+
+import (
+	"bufio"
+	"io"
+	"strings"
+	"time"
+	"unicode/utf8"
+)
+
+// prodID идентификатор программы, сформировавшей календарь
+const prodID = "-//student-schedule-app//schedule//RU"
+
+// Event событие календаря (пара)
+type Event struct {
+	UID         string // Уникальный и постоянный идентификатор: по нему приложения обновляют событие
+	Start       time.Time
+	End         time.Time
+	Summary     string
+	Location    string
+	Description string
+	URL         string // Ссылка, связанная с событием (онлайн-занятие)
+	Cancelled   bool   // Событие отменено (STATUS:CANCELLED)
+}
+
+// Calendar календарь с событиями
+type Calendar struct {
+	Name     string // Название календаря (X-WR-CALNAME)
+	TimeZone string // Часовой пояс для отображения (X-WR-TIMEZONE), необязательно
+	Events   []Event
+}
+
+// Write записывает календарь в w
+func (c *Calendar) Write(w io.Writer) error {
+	buf := bufio.NewWriter(w)
+	stamp := formatTime(time.Now())
+
+	writeLine(buf, "BEGIN:VCALENDAR")
+	writeLine(buf, "VERSION:2.0")
+	writeLine(buf, "PRODID:"+prodID)
+	writeLine(buf, "CALSCALE:GREGORIAN")
+	writeLine(buf, "METHOD:PUBLISH")
+	if c.Name != "" {
+		writeLine(buf, "X-WR-CALNAME:"+escapeText(c.Name))
+	}
+	if c.TimeZone != "" {
+		writeLine(buf, "X-WR-TIMEZONE:"+c.TimeZone)
+	}
+
+	for _, event := range c.Events {
+		writeLine(buf, "BEGIN:VEVENT")
+		writeLine(buf, "UID:"+escapeText(event.UID))
+		writeLine(buf, "DTSTAMP:"+stamp)
+		writeLine(buf, "DTSTART:"+formatTime(event.Start))
+		writeLine(buf, "DTEND:"+formatTime(event.End))
+		writeLine(buf, "SUMMARY:"+escapeText(event.Summary))
+		if event.Location != "" {
+			writeLine(buf, "LOCATION:"+escapeText(event.Location))
+		}
+		if event.Description != "" {
+			writeLine(buf, "DESCRIPTION:"+escapeText(event.Description))
+		}
+		if event.URL != "" {
+			writeLine(buf, "URL:"+event.URL) // Значение типа URI не экранируется
+		}
+		if event.Cancelled {
+			writeLine(buf, "STATUS:CANCELLED")
+		}
+		writeLine(buf, "END:VEVENT")
+	}
+
+	writeLine(buf, "END:VCALENDAR")
+	return buf.Flush()
+}
+
+// formatTime форматирует момент времени в UTC (20060102T150405Z)
+func formatTime(t time.Time) string {
+	return t.UTC().Format("20060102T150405Z")
+}
+
+// escapeText экранирует значение текстового свойства
+func escapeText(s string) string {
+	return strings.NewReplacer(`\`, `\\`, ";", `\;`, ",", `\,`, "\r\n", `\n`, "\n", `\n`).Replace(s)
+}
+
+// writeLine записывает строку свойства, перенося ее по 75 байт (RFC 5545, 3.1)
+// без разрыва многобайтовых символов
+func writeLine(w *bufio.Writer, line string) {
+	limit := 75
+	for len(line) > limit {
+		cut := limit
+		for cut > 0 && !utf8.RuneStart(line[cut]) {
+			cut--
+		}
+		w.WriteString(line[:cut])
+		w.WriteString("\r\n ")
+		line = line[cut:]
+		limit = 74 // Строка продолжения начинается с пробела
+	}
+	w.WriteString(line)
+	w.WriteString("\r\n")
+}
@@ -0,0 +1,55 @@
+// Package jobs реализует очередь фоновых задач в Postgres: тяжелые задачи
+// (пересборка кэша расписания, рассылка уведомлений) выполняются воркерами
+// с повторными попытками, а их состояние видно администраторам
+package jobs
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Status состояние задачи
+type Status string
+
+const (
+	StatusPending Status = "pending" // Ожидает выполнения (в том числе повторной попытки)
+	StatusRunning Status = "running" // Выполняется воркером
+	StatusDone    Status = "done"    // Выполнена
+	StatusFailed  Status = "failed"  // Исчерпаны попытки
+)
+
+// Job фоновая задача
+type Job struct {
+	ID          uuid.UUID       `db:"id"`
+	Kind        string          `db:"kind"`    // Вид задачи, по которому выбирается обработчик
+	Payload     json.RawMessage `db:"payload"` // Параметры задачи в JSON
+	Status      Status          `db:"status"`
+	Attempts    int             `db:"attempts"` // Число начатых попыток
+	MaxAttempts int             `db:"max_attempts"`
+	RunAt       time.Time       `db:"run_at"` // Не раньше этого времени задача будет взята воркером
+	LockedBy    string          `db:"locked_by"`
+	LockedAt    *time.Time      `db:"locked_at"`
+	LastError   string          `db:"last_error"`
+	CreatedAt   time.Time       `db:"created_at"`
+	FinishedAt  *time.Time      `db:"finished_at"`
+	CollegeID   uuid.UUID       `db:"college_id"` // Колледж, от имени которого выполняется задача
+}
+
+// Filter условия выборки задач; пустые поля не ограничивают выборку
+type Filter struct {
+	Kind   string
+	Status Status
+	Limit  int
+	Offset int
+}
+
+// KindStats количество задач вида по состояниям
+type KindStats struct {
+	Kind    string
+	Pending int
+	Running int
+	Done    int
+	Failed  int
+}
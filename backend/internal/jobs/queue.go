@@ -0,0 +1,306 @@
+package jobs
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/Ultrahd-dev/student-schedule-app/backend/internal/apperr"
+	"github.com/Ultrahd-dev/student-schedule-app/backend/internal/tenant"
+	"github.com/google/uuid"
+)
+
+// ErrJobNotFailed означает, что повторить можно только задачу, исчерпавшую попытки
+var ErrJobNotFailed = apperr.New(apperr.ErrConflict, "повторить можно только задачу с ошибкой")
+
+// Handler обрабатывает задачу с параметрами payload.
+// Ошибка приводит к повторной попытке, пока не исчерпан лимит попыток.
+type Handler func(ctx context.Context, payload json.RawMessage) error
+
+// Config настройки очереди задач
+type Config struct {
+	Workers      int           // Число воркеров на экземпляр API
+	PollInterval time.Duration // Период опроса очереди, когда задач нет
+	MaxAttempts  int           // Число попыток по умолчанию
+	Timeout      time.Duration // Время на одну попытку; зависшие дольше задачи возвращаются в очередь
+	RetryBackoff time.Duration // Задержка перед второй попыткой, далее удваивается
+	Retention    time.Duration // Сколько хранить выполненные задачи
+}
+
+// Queue очередь фоновых задач
+type Queue struct {
+	config   Config
+	repo     *Repository
+	worker   string // Идентификатор экземпляра для locked_by
+	mu       sync.RWMutex
+	handlers map[string]Handler
+	wake     chan struct{} // Сигнал воркерам о новой задаче этого экземпляра
+}
+
+// NewQueue создает очередь задач
+func NewQueue(config Config, repo *Repository) *Queue {
+	if config.Workers <= 0 {
+		config.Workers = 4
+	}
+	if config.PollInterval <= 0 {
+		config.PollInterval = 2 * time.Second
+	}
+	if config.MaxAttempts <= 0 {
+		config.MaxAttempts = 5
+	}
+	if config.Timeout <= 0 {
+		config.Timeout = 5 * time.Minute
+	}
+	if config.RetryBackoff <= 0 {
+		config.RetryBackoff = 30 * time.Second
+	}
+	if config.Retention <= 0 {
+		config.Retention = 7 * 24 * time.Hour
+	}
+
+	host, err := os.Hostname()
+	if err != nil {
+		host = "unknown"
+	}
+
+	return &Queue{
+		config:   config,
+		repo:     repo,
+		worker:   fmt.Sprintf("%s:%d", host, os.Getpid()),
+		handlers: make(map[string]Handler),
+		wake:     make(chan struct{}, 1),
+	}
+}
+
+// Register регистрирует обработчик задач вида kind.
+// Воркеры экземпляра берут из очереди только задачи зарегистрированных видов.
+func (q *Queue) Register(kind string, handler Handler) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	q.handlers[kind] = handler
+}
+
+// Enqueue добавляет задачу вида kind с параметрами payload (сериализуются в JSON)
+func (q *Queue) Enqueue(ctx context.Context, kind string, payload interface{}) (*Job, error) {
+	return q.EnqueueAt(ctx, kind, payload, time.Now())
+}
+
+// EnqueueAt добавляет задачу вида kind, которую воркеры возьмут не раньше runAt
+func (q *Queue) EnqueueAt(ctx context.Context, kind string, payload interface{}, runAt time.Time) (*Job, error) {
+	data, err := json.Marshal(payload)
+	if err != nil {
+		return nil, fmt.Errorf("ошибка сериализации параметров задачи %s: %w", kind, err)
+	}
+
+	job := &Job{
+		ID:          uuid.New(),
+		Kind:        kind,
+		Payload:     data,
+		Status:      StatusPending,
+		MaxAttempts: q.config.MaxAttempts,
+		RunAt:       runAt,
+	}
+	if err := q.repo.CreateJob(ctx, job); err != nil {
+		return nil, fmt.Errorf("ошибка добавления задачи %s: %w", kind, err)
+	}
+
+	// Будим воркер, не дожидаясь следующего опроса
+	select {
+	case q.wake <- struct{}{}:
+	default:
+	}
+	return job, nil
+}
+
+// List получает задачи по фильтру и общее количество подходящих задач
+func (q *Queue) List(ctx context.Context, filter Filter) ([]Job, int, error) {
+	if filter.Limit <= 0 {
+		filter.Limit = 50
+	}
+	if filter.Limit > 500 {
+		filter.Limit = 500
+	}
+	if filter.Offset < 0 {
+		filter.Offset = 0
+	}
+
+	jobs, total, err := q.repo.ListJobs(ctx, filter)
+	if err != nil {
+		return nil, 0, fmt.Errorf("ошибка получения задач: %w", err)
+	}
+	return jobs, total, nil
+}
+
+// Stats получает количество задач каждого вида по состояниям
+func (q *Queue) Stats(ctx context.Context) ([]KindStats, error) {
+	stats, err := q.repo.GetStats(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("ошибка получения статистики задач: %w", err)
+	}
+	return stats, nil
+}
+
+// Retry возвращает в очередь задачу, исчерпавшую попытки
+func (q *Queue) Retry(ctx context.Context, id uuid.UUID) error {
+	requeued, err := q.repo.RequeueJob(ctx, id)
+	if err != nil {
+		return fmt.Errorf("ошибка повтора задачи: %w", err)
+	}
+	if !requeued {
+		return ErrJobNotFailed
+	}
+
+	log.Printf("Задача %s возвращена в очередь", id)
+	return nil
+}
+
+// Start запускает воркеры и обслуживание очереди до отмены контекста
+func (q *Queue) Start(ctx context.Context) {
+	var wg sync.WaitGroup
+	for i := 0; i < q.config.Workers; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			q.work(ctx)
+		}()
+	}
+
+	log.Printf("Очередь задач запущена: %d воркеров, виды задач: %v", q.config.Workers, q.kinds())
+
+	ticker := time.NewTicker(q.config.Timeout)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ticker.C:
+			q.cleanup(ctx)
+		case <-ctx.Done():
+			wg.Wait()
+			log.Println("Остановка очереди задач")
+			return
+		}
+	}
+}
+
+// work выполняет задачи, пока не будет отменен контекст
+func (q *Queue) work(ctx context.Context) {
+	for {
+		// Выполняем задачи, пока они есть, затем ждем новых
+		for ctx.Err() == nil && q.runNext(ctx) {
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-q.wake:
+		case <-time.After(q.config.PollInterval):
+		}
+	}
+}
+
+// runNext забирает и выполняет одну задачу. Возвращает false, если задач нет.
+func (q *Queue) runNext(ctx context.Context) bool {
+	kinds := q.kinds()
+	if len(kinds) == 0 {
+		return false
+	}
+
+	job, err := q.repo.ClaimJob(ctx, kinds, q.worker)
+	if err != nil {
+		log.Printf("Ошибка получения задачи из очереди: %v", err)
+		return false
+	}
+	if job == nil {
+		return false
+	}
+
+	q.run(ctx, job)
+	return true
+}
+
+// run выполняет задачу и сохраняет результат: выполнена, повтор или ошибка
+func (q *Queue) run(ctx context.Context, job *Job) {
+	q.mu.RLock()
+	handler := q.handlers[job.Kind]
+	q.mu.RUnlock()
+
+	jobCtx, cancel := context.WithTimeout(tenant.WithCollege(ctx, job.CollegeID), q.config.Timeout)
+	err := safeCall(jobCtx, handler, job.Payload)
+	cancel()
+
+	// Результат сохраняем даже при остановке сервера, иначе задача останется running
+	saveCtx, saveCancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer saveCancel()
+
+	if err == nil {
+		if err := q.repo.CompleteJob(saveCtx, job.ID); err != nil {
+			log.Printf("Ошибка сохранения результата задачи %s: %v", job.ID, err)
+		}
+		return
+	}
+
+	if job.Attempts >= job.MaxAttempts {
+		log.Printf("Задача %s (%s) завершилась ошибкой, попытки исчерпаны (%d): %v", job.ID, job.Kind, job.Attempts, err)
+		if err := q.repo.FailJob(saveCtx, job.ID, err.Error()); err != nil {
+			log.Printf("Ошибка сохранения результата задачи %s: %v", job.ID, err)
+		}
+		return
+	}
+
+	retryAt := time.Now().Add(q.backoff(job.Attempts))
+	log.Printf("Задача %s (%s) завершилась ошибкой (попытка %d из %d), повтор в %s: %v",
+		job.ID, job.Kind, job.Attempts, job.MaxAttempts, retryAt.Format(time.RFC3339), err)
+	if err := q.repo.RetryJobAt(saveCtx, job.ID, retryAt, err.Error()); err != nil {
+		log.Printf("Ошибка сохранения результата задачи %s: %v", job.ID, err)
+	}
+}
+
+// backoff возвращает задержку перед попыткой attempt+1: RetryBackoff, удваиваемый с каждой попыткой, но не более часа
+func (q *Queue) backoff(attempt int) time.Duration {
+	delay := q.config.RetryBackoff
+	for i := 1; i < attempt && delay < time.Hour; i++ {
+		delay *= 2
+	}
+	if delay > time.Hour {
+		delay = time.Hour
+	}
+	return delay
+}
+
+// cleanup возвращает в очередь зависшие задачи и удаляет старые выполненные
+func (q *Queue) cleanup(ctx context.Context) {
+	if released, err := q.repo.ReleaseStaleJobs(ctx, q.config.Timeout*2); err != nil {
+		log.Printf("Ошибка возврата зависших задач: %v", err)
+	} else if released > 0 {
+		log.Printf("Возвращено в очередь зависших задач: %d", released)
+	}
+
+	if _, err := q.repo.DeleteFinishedJobs(ctx, time.Now().Add(-q.config.Retention)); err != nil {
+		log.Printf("Ошибка удаления выполненных задач: %v", err)
+	}
+}
+
+// kinds возвращает виды задач с зарегистрированными обработчиками
+func (q *Queue) kinds() []string {
+	q.mu.RLock()
+	defer q.mu.RUnlock()
+
+	kinds := make([]string, 0, len(q.handlers))
+	for kind := range q.handlers {
+		kinds = append(kinds, kind)
+	}
+	return kinds
+}
+
+// safeCall вызывает обработчик, превращая панику в ошибку, чтобы она не остановила воркер
+func safeCall(ctx context.Context, handler Handler, payload json.RawMessage) (err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			err = fmt.Errorf("паника в обработчике задачи: %v", r)
+		}
+	}()
+	return handler(ctx, payload)
+}
@@ -0,0 +1,291 @@
+package jobs
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/Ultrahd-dev/student-schedule-app/backend/internal/tenant"
+	"github.com/Ultrahd-dev/student-schedule-app/backend/internal/txn"
+	"github.com/google/uuid"
+	"github.com/lib/pq"
+)
+
+// jobColumns колонки задачи в порядке сканирования scanJob
+const jobColumns = `id, kind, payload, status, attempts, max_attempts, run_at,
+	COALESCE(locked_by, ''), locked_at, COALESCE(last_error, ''), created_at, finished_at, college_id`
+
+// Repository предоставляет доступ к очереди задач
+type Repository struct {
+	db *sql.DB
+}
+
+// NewRepository создает новый репозиторий очереди задач
+func NewRepository(db *sql.DB) *Repository {
+	return &Repository{db: db}
+}
+
+// CreateJob добавляет задачу в очередь от имени колледжа из контекста.
+// Внутри транзакции из контекста (txn.Manager) задача появится в очереди
+// только после ее фиксации.
+func (r *Repository) CreateJob(ctx context.Context, job *Job) error {
+	query := `
+		INSERT INTO jobs (id, kind, payload, status, max_attempts, run_at, college_id)
+		VALUES ($1, $2, $3, $4, $5, $6, $7)
+		RETURNING created_at`
+
+	job.CollegeID = tenant.CollegeID(ctx)
+	err := txn.From(ctx, r.db).QueryRowContext(ctx, query,
+		job.ID,
+		job.Kind,
+		[]byte(job.Payload),
+		job.Status,
+		job.MaxAttempts,
+		job.RunAt,
+		job.CollegeID).
+		Scan(&job.CreatedAt)
+	if err != nil {
+		return fmt.Errorf("failed to create job: %w", err)
+	}
+
+	return nil
+}
+
+// ClaimJob забирает из очереди готовую к выполнению задачу одного из видов kinds
+// и помечает ее выполняемой воркером worker. Задачи, которые уже забрали другие
+// воркеры (в том числе других экземпляров API), пропускаются. Если задач нет, возвращает nil.
+func (r *Repository) ClaimJob(ctx context.Context, kinds []string, worker string) (*Job, error) {
+	query := `
+		UPDATE jobs
+		SET status = 'running', attempts = attempts + 1, locked_by = $2, locked_at = NOW()
+		WHERE id = (
+			SELECT id FROM jobs
+			WHERE status = 'pending' AND run_at <= NOW() AND kind = ANY($1)
+			ORDER BY run_at
+			LIMIT 1
+			FOR UPDATE SKIP LOCKED
+		)
+		RETURNING ` + jobColumns
+
+	job, err := scanJob(r.db.QueryRowContext(ctx, query, pq.Array(kinds), worker))
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to claim job: %w", err)
+	}
+	return job, nil
+}
+
+// CompleteJob отмечает задачу выполненной
+func (r *Repository) CompleteJob(ctx context.Context, id uuid.UUID) error {
+	query := `
+		UPDATE jobs
+		SET status = 'done', finished_at = NOW(), locked_by = NULL, locked_at = NULL, last_error = NULL
+		WHERE id = $1`
+
+	if _, err := r.db.ExecContext(ctx, query, id); err != nil {
+		return fmt.Errorf("failed to complete job: %w", err)
+	}
+	return nil
+}
+
+// RetryJobAt возвращает задачу в очередь для повторной попытки в момент runAt
+func (r *Repository) RetryJobAt(ctx context.Context, id uuid.UUID, runAt time.Time, lastError string) error {
+	query := `
+		UPDATE jobs
+		SET status = 'pending', run_at = $2, last_error = $3, locked_by = NULL, locked_at = NULL
+		WHERE id = $1`
+
+	if _, err := r.db.ExecContext(ctx, query, id, runAt, lastError); err != nil {
+		return fmt.Errorf("failed to reschedule job: %w", err)
+	}
+	return nil
+}
+
+// FailJob отмечает задачу окончательно неуспешной
+func (r *Repository) FailJob(ctx context.Context, id uuid.UUID, lastError string) error {
+	query := `
+		UPDATE jobs
+		SET status = 'failed', finished_at = NOW(), last_error = $2, locked_by = NULL, locked_at = NULL
+		WHERE id = $1`
+
+	if _, err := r.db.ExecContext(ctx, query, id, lastError); err != nil {
+		return fmt.Errorf("failed to fail job: %w", err)
+	}
+	return nil
+}
+
+// ReleaseStaleJobs возвращает в очередь задачи, которые выполняются дольше timeout:
+// воркер, скорее всего, остановился вместе с экземпляром API. Возвращает число задач.
+func (r *Repository) ReleaseStaleJobs(ctx context.Context, timeout time.Duration) (int, error) {
+	query := `
+		UPDATE jobs
+		SET status = CASE WHEN attempts >= max_attempts THEN 'failed' ELSE 'pending' END,
+		    finished_at = CASE WHEN attempts >= max_attempts THEN NOW() END,
+		    last_error = 'выполнение прервано', locked_by = NULL, locked_at = NULL
+		WHERE status = 'running' AND locked_at < NOW() - make_interval(secs => $1)`
+
+	result, err := r.db.ExecContext(ctx, query, timeout.Seconds())
+	if err != nil {
+		return 0, fmt.Errorf("failed to release stale jobs: %w", err)
+	}
+
+	released, err := result.RowsAffected()
+	if err != nil {
+		return 0, fmt.Errorf("failed to get released jobs count: %w", err)
+	}
+	return int(released), nil
+}
+
+// RequeueJob возвращает неуспешную задачу в очередь с новым запасом попыток.
+// Возвращает false, если задача не найдена или не в состоянии failed.
+func (r *Repository) RequeueJob(ctx context.Context, id uuid.UUID) (bool, error) {
+	query := `
+		UPDATE jobs
+		SET status = 'pending', run_at = NOW(), max_attempts = attempts + max_attempts, finished_at = NULL
+		WHERE id = $1 AND status = 'failed' AND college_id = $2`
+
+	result, err := r.db.ExecContext(ctx, query, id, tenant.CollegeID(ctx))
+	if err != nil {
+		return false, fmt.Errorf("failed to requeue job: %w", err)
+	}
+
+	requeued, err := result.RowsAffected()
+	if err != nil {
+		return false, fmt.Errorf("failed to get requeued jobs count: %w", err)
+	}
+	return requeued > 0, nil
+}
+
+// DeleteFinishedJobs удаляет выполненные задачи, завершенные раньше before. Возвращает число удаленных.
+func (r *Repository) DeleteFinishedJobs(ctx context.Context, before time.Time) (int, error) {
+	result, err := r.db.ExecContext(ctx, `DELETE FROM jobs WHERE status = 'done' AND finished_at < $1`, before)
+	if err != nil {
+		return 0, fmt.Errorf("failed to delete finished jobs: %w", err)
+	}
+
+	deleted, err := result.RowsAffected()
+	if err != nil {
+		return 0, fmt.Errorf("failed to get deleted jobs count: %w", err)
+	}
+	return int(deleted), nil
+}
+
+// ListJobs получает задачи колледжа по фильтру, от новых к старым, и общее количество подходящих задач
+func (r *Repository) ListJobs(ctx context.Context, filter Filter) ([]Job, int, error) {
+	var conditions []string
+	var args []interface{}
+	addCondition := func(condition string, arg interface{}) {
+		args = append(args, arg)
+		conditions = append(conditions, fmt.Sprintf(condition, len(args)))
+	}
+
+	addCondition("college_id = $%d", tenant.CollegeID(ctx))
+	if filter.Kind != "" {
+		addCondition("kind = $%d", filter.Kind)
+	}
+	if filter.Status != "" {
+		addCondition("status = $%d", filter.Status)
+	}
+
+	where := "WHERE " + strings.Join(conditions, " AND ")
+
+	var total int
+	if err := r.db.QueryRowContext(ctx, "SELECT COUNT(*) FROM jobs "+where, args...).Scan(&total); err != nil {
+		return nil, 0, fmt.Errorf("failed to count jobs: %w", err)
+	}
+
+	args = append(args, filter.Limit, filter.Offset)
+	query := fmt.Sprintf(`SELECT %s FROM jobs %s ORDER BY created_at DESC LIMIT $%d OFFSET $%d`,
+		jobColumns, where, len(args)-1, len(args))
+
+	rows, err := r.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to list jobs: %w", err)
+	}
+	defer rows.Close()
+
+	var jobs []Job
+	for rows.Next() {
+		job, err := scanJob(rows)
+		if err != nil {
+			return nil, 0, fmt.Errorf("failed to scan job: %w", err)
+		}
+		jobs = append(jobs, *job)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, 0, fmt.Errorf("failed to iterate jobs: %w", err)
+	}
+
+	return jobs, total, nil
+}
+
+// GetStats получает количество задач колледжа каждого вида по состояниям
+func (r *Repository) GetStats(ctx context.Context) ([]KindStats, error) {
+	query := `
+		SELECT kind,
+		       COUNT(*) FILTER (WHERE status = 'pending'),
+		       COUNT(*) FILTER (WHERE status = 'running'),
+		       COUNT(*) FILTER (WHERE status = 'done'),
+		       COUNT(*) FILTER (WHERE status = 'failed')
+		FROM jobs
+		WHERE college_id = $1
+		GROUP BY kind
+		ORDER BY kind`
+
+	rows, err := r.db.QueryContext(ctx, query, tenant.CollegeID(ctx))
+	if err != nil {
+		return nil, fmt.Errorf("failed to get job stats: %w", err)
+	}
+	defer rows.Close()
+
+	var stats []KindStats
+	for rows.Next() {
+		var s KindStats
+		if err := rows.Scan(&s.Kind, &s.Pending, &s.Running, &s.Done, &s.Failed); err != nil {
+			return nil, fmt.Errorf("failed to scan job stats: %w", err)
+		}
+		stats = append(stats, s)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to iterate job stats: %w", err)
+	}
+
+	return stats, nil
+}
+
+// rowScanner общий интерфейс *sql.Row и *sql.Rows
+type rowScanner interface {
+	Scan(dest ...interface{}) error
+}
+
+// scanJob сканирует задачу из строки с колонками jobColumns
+func scanJob(row rowScanner) (*Job, error) {
+	var job Job
+	var payload []byte
+	err := row.Scan(
+		&job.ID,
+		&job.Kind,
+		&payload,
+		&job.Status,
+		&job.Attempts,
+		&job.MaxAttempts,
+		&job.RunAt,
+		&job.LockedBy,
+		&job.LockedAt,
+		&job.LastError,
+		&job.CreatedAt,
+		&job.FinishedAt,
+		&job.CollegeID,
+	)
+	if err != nil {
+		return nil, err
+	}
+	job.Payload = payload
+	return &job, nil
+}
@@ -3,49 +3,104 @@
 package jwt
 
 import (
+	"context"
 	"fmt"
+	"sync"
 	"time"
 
 	"github.com/golang-jwt/jwt/v5"
 	"github.com/google/uuid"
 )
 
+// Гостевой доступ: токен без пользователя, привязанный к одной группе и только на чтение
+const (
+	RoleGuest     = "guest" // Роль владельца гостевого токена
+	ScopeReadOnly = "read"  // Область действия гостевого токена
+)
+
+// Вход с двухфакторной аутентификацией: после проверки пароля выдается короткоживущий
+// токен второго шага, который годится только для подтверждения кода (ParseTwoFactorToken)
+const (
+	ScopeTwoFactor    = "2fa"           // Область действия токена второго шага
+	twoFactorLifetime = 5 * time.Minute // Время жизни токена второго шага
+)
+
 // Claims структура для хранения данных в JWT токене
 // Содержит стандартные поля и дополнительную информацию о пользователе
 type Claims struct {
-	UserID               uuid.UUID `json:"user_id"` // Уникальный ID пользователя
-	Email                string    `json:"email"`   // Email пользователя
-	Role                 string    `json:"role"`    // Роль пользователя (student, teacher, admin)
+	UserID               uuid.UUID `json:"user_id"`              // Уникальный ID пользователя (пустой для гостя)
+	Email                string    `json:"email"`                // Email пользователя
+	Role                 string    `json:"role"`                 // Роль пользователя (student, teacher, admin, guest)
+	Scope                string    `json:"scope,omitempty"`      // Область действия (read для гостя, 2fa для второго шага входа)
+	GroupName            string    `json:"group_name,omitempty"` // Группа, к которой привязан гостевой токен
+	CollegeID            uuid.UUID `json:"college_id,omitempty"` // Колледж пользователя или гостевой группы (пустой в старых токенах)
 	jwt.RegisteredClaims           // Встроенные стандартные поля JWT
 }
 
+// IsGuest проверяет, что токен гостевой
+func (c *Claims) IsGuest() bool {
+	return c.Role == RoleGuest
+}
+
+// RevocationChecker проверяет, отозван ли токен с идентификатором jti
+type RevocationChecker interface {
+	IsTokenRevoked(ctx context.Context, jti string) (bool, error)
+}
+
+// revocationCacheKey ключ результатов проверки отзыва токенов в контексте запроса
+type revocationCacheKey struct{}
+
+// revocationCache результаты проверки отзыва токенов за время одного запроса
+type revocationCache struct {
+	mu      sync.Mutex
+	revoked map[string]bool // Ключ - jti
+}
+
+// WithRevocationCache добавляет в контекст запроса кэш проверок отзыва токенов.
+// Токен запроса разбирают несколько interceptor'ов и обработчик, а отзыв
+// проверяется по базе только при первом разборе.
+func WithRevocationCache(ctx context.Context) context.Context {
+	return context.WithValue(ctx, revocationCacheKey{}, &revocationCache{revoked: make(map[string]bool)})
+}
+
 // Manager отвечает за создание и проверку JWT токенов
 type Manager struct {
-	secretKey     []byte        // Секретный ключ для подписи токенов
-	tokenLifetime time.Duration // Время жизни токена
+	secretKey     []byte            // Секретный ключ для подписи токенов
+	tokenLifetime time.Duration     // Время жизни токена
+	guestLifetime time.Duration     // Время жизни гостевого токена
+	revocations   RevocationChecker // Проверка отозванных токенов (может быть nil)
 }
 
 // NewManager создает новый менеджер JWT
 // secretKey - секретный ключ для подписи токенов
 // lifetime - время жизни токена (например, 24 * time.Hour)
-func NewManager(secretKey string, lifetime time.Duration) *Manager {
+// guestLifetime - время жизни гостевого токена (короче обычного)
+func NewManager(secretKey string, lifetime, guestLifetime time.Duration) *Manager {
 	return &Manager{
 		secretKey:     []byte(secretKey),
 		tokenLifetime: lifetime,
+		guestLifetime: guestLifetime,
 	}
 }
 
+// SetRevocationChecker включает проверку отозванных токенов в ParseToken
+func (m *Manager) SetRevocationChecker(checker RevocationChecker) {
+	m.revocations = checker
+}
+
 // GenerateToken создает новый JWT токен для пользователя
 // userID - уникальный ID пользователя
 // email - email пользователя
 // role - роль пользователя
+// collegeID - колледж пользователя
 // Возвращает строку токена и ошибку (если есть)
-func (m *Manager) GenerateToken(userID uuid.UUID, email, role string) (string, error) {
+func (m *Manager) GenerateToken(userID uuid.UUID, email, role string, collegeID uuid.UUID) (string, error) {
 	// Создаем claims (данные, которые будут в токене)
 	claims := &Claims{
-		UserID: userID,
-		Email:  email,
-		Role:   role,
+		UserID:    userID,
+		Email:     email,
+		Role:      role,
+		CollegeID: collegeID,
 		RegisteredClaims: jwt.RegisteredClaims{
 			// Устанавливаем время истечения токена
 			ExpiresAt: jwt.NewNumericDate(time.Now().Add(m.tokenLifetime)),
@@ -56,10 +111,62 @@ func (m *Manager) GenerateToken(userID uuid.UUID, email, role string) (string, e
 		},
 	}
 
-	// Создаем токен с методом подписи HS256
+	return m.sign(claims)
+}
+
+// GenerateGuestToken создает короткоживущий гостевой токен только на чтение,
+// привязанный к группе groupName колледжа collegeID. Возвращает токен и время его истечения.
+func (m *Manager) GenerateGuestToken(groupName string, collegeID uuid.UUID) (string, time.Time, error) {
+	now := time.Now()
+	expiresAt := now.Add(m.guestLifetime)
+	claims := &Claims{
+		Role:      RoleGuest,
+		Scope:     ScopeReadOnly,
+		GroupName: groupName,
+		CollegeID: collegeID,
+		RegisteredClaims: jwt.RegisteredClaims{
+			ExpiresAt: jwt.NewNumericDate(expiresAt),
+			IssuedAt:  jwt.NewNumericDate(now),
+			ID:        uuid.New().String(),
+		},
+	}
+
+	token, err := m.sign(claims)
+	if err != nil {
+		return "", time.Time{}, err
+	}
+	return token, expiresAt, nil
+}
+
+// GenerateTwoFactorToken создает токен второго шага входа для пользователя,
+// прошедшего проверку пароля. Возвращает токен и время его истечения.
+func (m *Manager) GenerateTwoFactorToken(userID uuid.UUID, email, role string, collegeID uuid.UUID) (string, time.Time, error) {
+	now := time.Now()
+	expiresAt := now.Add(twoFactorLifetime)
+	claims := &Claims{
+		UserID:    userID,
+		Email:     email,
+		Role:      role,
+		Scope:     ScopeTwoFactor,
+		CollegeID: collegeID,
+		RegisteredClaims: jwt.RegisteredClaims{
+			ExpiresAt: jwt.NewNumericDate(expiresAt),
+			IssuedAt:  jwt.NewNumericDate(now),
+			ID:        uuid.New().String(),
+		},
+	}
+
+	token, err := m.sign(claims)
+	if err != nil {
+		return "", time.Time{}, err
+	}
+	return token, expiresAt, nil
+}
+
+// sign подписывает claims секретным ключом (HS256)
+func (m *Manager) sign(claims *Claims) (string, error) {
 	token := jwt.NewWithClaims(jwt.SigningMethodHS256, claims)
 
-	// Подписываем токен нашим секретным ключом
 	tokenString, err := token.SignedString(m.secretKey)
 	if err != nil {
 		return "", fmt.Errorf("ошибка подписи токена: %w", err)
@@ -71,7 +178,32 @@ func (m *Manager) GenerateToken(userID uuid.UUID, email, role string) (string, e
 // ParseToken проверяет и парсит JWT токен
 // tokenString - строка токена для проверки
 // Возвращает распарсенные claims и ошибку (если есть)
-func (m *Manager) ParseToken(tokenString string) (*Claims, error) {
+// Токены второго шага входа не принимаются.
+func (m *Manager) ParseToken(ctx context.Context, tokenString string) (*Claims, error) {
+	claims, err := m.parse(ctx, tokenString)
+	if err != nil {
+		return nil, err
+	}
+	if claims.Scope == ScopeTwoFactor {
+		return nil, fmt.Errorf("вход не завершен: требуется код двухфакторной аутентификации")
+	}
+	return claims, nil
+}
+
+// ParseTwoFactorToken проверяет токен второго шага входа
+func (m *Manager) ParseTwoFactorToken(ctx context.Context, tokenString string) (*Claims, error) {
+	claims, err := m.parse(ctx, tokenString)
+	if err != nil {
+		return nil, err
+	}
+	if claims.Scope != ScopeTwoFactor {
+		return nil, fmt.Errorf("токен не предназначен для второго шага входа")
+	}
+	return claims, nil
+}
+
+// parse проверяет подпись, срок действия и отзыв токена
+func (m *Manager) parse(ctx context.Context, tokenString string) (*Claims, error) {
 	// Парсим токен
 	claims := &Claims{}
 	token, err := jwt.ParseWithClaims(tokenString, claims, func(token *jwt.Token) (interface{}, error) {
@@ -91,5 +223,44 @@ func (m *Manager) ParseToken(tokenString string) (*Claims, error) {
 		return nil, fmt.Errorf("токен недействителен")
 	}
 
+	// Проверяем, что токен не отозван
+	revoked, err := m.isRevoked(ctx, claims.ID)
+	if err != nil {
+		return nil, err
+	}
+	if revoked {
+		return nil, fmt.Errorf("токен отозван")
+	}
+
 	return claims, nil
 }
+
+// isRevoked проверяет отзыв токена jti, используя кэш запроса (WithRevocationCache).
+// Если проверить отзыв не удалось, токен не принимается: иначе при недоступной базе
+// снова сработали бы отозванные токены, в том числе одноразовые токены второго шага входа.
+func (m *Manager) isRevoked(ctx context.Context, jti string) (bool, error) {
+	if m.revocations == nil || jti == "" {
+		return false, nil
+	}
+
+	cache, _ := ctx.Value(revocationCacheKey{}).(*revocationCache)
+	if cache != nil {
+		cache.mu.Lock()
+		revoked, ok := cache.revoked[jti]
+		cache.mu.Unlock()
+		if ok {
+			return revoked, nil
+		}
+	}
+
+	revoked, err := m.revocations.IsTokenRevoked(ctx, jti)
+	if err != nil {
+		return false, fmt.Errorf("ошибка проверки отзыва токена: %w", err)
+	}
+	if cache != nil {
+		cache.mu.Lock()
+		cache.revoked[jti] = revoked
+		cache.mu.Unlock()
+	}
+	return revoked, nil
+}
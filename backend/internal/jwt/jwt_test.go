@@ -0,0 +1,94 @@
+package jwt_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/Ultrahd-dev/student-schedule-app/backend/internal/jwt"
+	"github.com/google/uuid"
+)
+
+// fakeRevocations проверка отзыва с подсчетом обращений
+type fakeRevocations struct {
+	revoked bool
+	err     error
+	calls   int
+}
+
+func (f *fakeRevocations) IsTokenRevoked(ctx context.Context, jti string) (bool, error) {
+	f.calls++
+	return f.revoked, f.err
+}
+
+func TestParseTokenRevocation(t *testing.T) {
+	tests := []struct {
+		name      string
+		checker   *fakeRevocations
+		twoFactor bool
+		wantErr   bool
+	}{
+		{name: "действующий токен", checker: &fakeRevocations{}},
+		{name: "отозванный токен", checker: &fakeRevocations{revoked: true}, wantErr: true},
+		{name: "ошибка проверки отзыва", checker: &fakeRevocations{err: errors.New("база недоступна")}, wantErr: true},
+		{name: "токен второго шага при ошибке проверки", checker: &fakeRevocations{err: errors.New("база недоступна")}, twoFactor: true, wantErr: true},
+		{name: "отозванный токен второго шага", checker: &fakeRevocations{revoked: true}, twoFactor: true, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			manager := jwt.NewManager("secret", time.Hour, time.Hour)
+			manager.SetRevocationChecker(tt.checker)
+
+			var err error
+			if tt.twoFactor {
+				token, _, genErr := manager.GenerateTwoFactorToken(uuid.New(), "user@test.local", "student", uuid.New())
+				if genErr != nil {
+					t.Fatal(genErr)
+				}
+				_, err = manager.ParseTwoFactorToken(context.Background(), token)
+			} else {
+				token, genErr := manager.GenerateToken(uuid.New(), "user@test.local", "student", uuid.New())
+				if genErr != nil {
+					t.Fatal(genErr)
+				}
+				_, err = manager.ParseToken(context.Background(), token)
+			}
+			if (err != nil) != tt.wantErr {
+				t.Errorf("ошибка %v, ожидалась ошибка: %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestParseTokenRevocationCache(t *testing.T) {
+	checker := &fakeRevocations{}
+	manager := jwt.NewManager("secret", time.Hour, time.Hour)
+	manager.SetRevocationChecker(checker)
+
+	token, err := manager.GenerateToken(uuid.New(), "user@test.local", "student", uuid.New())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// В пределах одного запроса отзыв проверяется один раз
+	ctx := jwt.WithRevocationCache(context.Background())
+	for i := 0; i < 3; i++ {
+		if _, err := manager.ParseToken(ctx, token); err != nil {
+			t.Fatalf("разбор %d: %v", i, err)
+		}
+	}
+	if checker.calls != 1 {
+		t.Errorf("проверок отзыва за запрос: %d, ожидалась 1", checker.calls)
+	}
+
+	// Новый запрос проверяет отзыв заново
+	checker.revoked = true
+	if _, err := manager.ParseToken(jwt.WithRevocationCache(context.Background()), token); err == nil {
+		t.Error("отозванный токен принят в новом запросе")
+	}
+	if checker.calls != 2 {
+		t.Errorf("проверок отзыва: %d, ожидалось 2", checker.calls)
+	}
+}
@@ -0,0 +1,73 @@
+package kiosk
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"math"
+	"net"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+// Path путь запроса расписания табло
+const Path = "/kiosk"
+
+// KeyHeader заголовок с ключом табло (альтернатива параметру ?key=)
+const KeyHeader = "X-Kiosk-Key"
+
+// Handler отдает расписание табло на сегодня в JSON. Ключ табло передается
+// параметром ?key=<ключ> или заголовком X-Kiosk-Key. Число запросов с одного
+// адреса ограничено (RateLimit в минуту), сверх лимита - 429.
+// Регистрируется на пути Path.
+func (s *Service) Handler() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet && r.Method != http.MethodHead {
+			http.Error(w, "метод не поддерживается", http.StatusMethodNotAllowed)
+			return
+		}
+
+		if ok, retryAfter := s.limiter.Allow(clientAddr(r), time.Now()); !ok {
+			w.Header().Set("Retry-After", strconv.Itoa(int(math.Ceil(retryAfter.Seconds()))))
+			http.Error(w, "слишком много запросов", http.StatusTooManyRequests)
+			return
+		}
+
+		key := r.URL.Query().Get("key")
+		if key == "" {
+			key = r.Header.Get(KeyHeader)
+		}
+		if key == "" {
+			http.Error(w, "не указан ключ табло", http.StatusUnauthorized)
+			return
+		}
+
+		board, err := s.Board(r.Context(), key)
+		if errors.Is(err, ErrUnknownKey) {
+			http.Error(w, "табло не найдено или отключено", http.StatusNotFound)
+			return
+		}
+		if err != nil {
+			log.Printf("Ошибка формирования расписания табло: %v", err)
+			http.Error(w, "внутренняя ошибка", http.StatusInternalServerError)
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		// Ответ зависит от ключа, поэтому промежуточные кэши его не хранят
+		w.Header().Set("Cache-Control", fmt.Sprintf("private, max-age=%d", int(s.config.CacheTTL.Seconds())))
+		json.NewEncoder(w).Encode(board)
+	})
+}
+
+// clientAddr возвращает адрес клиента для ограничения частоты запросов.
+// X-Forwarded-For не учитывается: клиент может подставить в него любой адрес.
+func clientAddr(r *http.Request) string {
+	host, _, err := net.SplitHostPort(r.RemoteAddr)
+	if err != nil {
+		return r.RemoteAddr
+	}
+	return host
+}
@@ -0,0 +1,340 @@
+// Package kiosk отдает расписание текущего дня для табло в коридорах колледжа.
+// Табло создает администратор: оно показывает пары в аудиториях выбранных
+// корпусов и в отдельно перечисленных аудиториях. Запрос табло публичный (без
+// входа в систему) и выполняется по ключу табло, поэтому ответы кэшируются, а
+// число запросов с одного адреса ограничено.
+package kiosk
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"fmt"
+	"log"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+	"unicode/utf8"
+
+	"github.com/Ultrahd-dev/student-schedule-app/backend/internal/apperr"
+	"github.com/Ultrahd-dev/student-schedule-app/backend/internal/buildings"
+	"github.com/Ultrahd-dev/student-schedule-app/backend/internal/cache"
+	"github.com/Ultrahd-dev/student-schedule-app/backend/internal/clock"
+	"github.com/Ultrahd-dev/student-schedule-app/backend/internal/schedule"
+	"github.com/Ultrahd-dev/student-schedule-app/backend/internal/tenant"
+	"github.com/google/uuid"
+)
+
+// Ошибки табло
+var (
+	ErrInvalidDisplay = apperr.New(apperr.ErrValidation, "некорректное табло")
+	ErrUnknownKey     = apperr.New(apperr.ErrNotFound, "табло не найдено или отключено")
+)
+
+// keyBytes длина ключа табло в байтах (в ссылке - base64url)
+const keyBytes = 24
+
+// Display табло расписания
+type Display struct {
+	ID         uuid.UUID
+	CollegeID  uuid.UUID
+	Name       string
+	Buildings  []string // Коды корпусов
+	Classrooms []string // Аудитории, как в расписании
+	CreatedBy  *uuid.UUID
+	CreatedAt  time.Time
+	LastSeenAt *time.Time // Последний запрос табло (nil - ни разу)
+}
+
+// Store хранилище табло
+type Store interface {
+	CreateDisplay(ctx context.Context, display *Display, keyHash string) error
+	ListDisplays(ctx context.Context) ([]Display, error)
+	RevokeDisplay(ctx context.Context, id uuid.UUID) (bool, error)
+	GetDisplayByKeyHash(ctx context.Context, keyHash string) (*Display, error)
+	TouchDisplay(ctx context.Context, id uuid.UUID) error
+}
+
+// Lessons расписание аудиторий
+type Lessons interface {
+	GetCurrentScheduleForClassrooms(ctx context.Context, classrooms []string, date time.Time) ([]schedule.CurrentSchedule, error)
+}
+
+// Buildings корпуса колледжа с аудиториями
+type Buildings interface {
+	ListBuildings(ctx context.Context) ([]buildings.Building, error)
+}
+
+// Config настройки табло
+type Config struct {
+	CacheTTL  time.Duration // Время кэширования расписания табло
+	RateLimit int           // Запросов в минуту с одного адреса
+}
+
+// Board расписание табло на день
+type Board struct {
+	Display     string    `json:"display"`
+	Date        string    `json:"date"` // YYYY-MM-DD
+	Lessons     []Lesson  `json:"lessons"`
+	GeneratedAt time.Time `json:"generated_at"`
+}
+
+// Lesson пара на табло
+type Lesson struct {
+	TimeStart string `json:"time_start"`
+	TimeEnd   string `json:"time_end"`
+	Classroom string `json:"classroom"`
+	Building  string `json:"building,omitempty"` // Код корпуса
+	Group     string `json:"group"`
+	Subgroup  int    `json:"subgroup,omitempty"`
+	Subject   string `json:"subject"`
+	Teacher   string `json:"teacher"`
+	Cancelled bool   `json:"cancelled"`
+}
+
+// cachedBoard расписание табло в кэше
+type cachedBoard struct {
+	board     *Board
+	collegeID uuid.UUID
+	loadedAt  time.Time
+}
+
+// Service управляет табло и формирует их расписание
+type Service struct {
+	config    Config
+	store     Store
+	lessons   Lessons
+	buildings Buildings
+	loc       *time.Location
+	limiter   *limiter
+
+	mu    sync.Mutex
+	cache map[string]cachedBoard // Ключ - хэш ключа табло
+}
+
+// NewService создает сервис табло. loc - часовой пояс колледжа.
+func NewService(config Config, store Store, lessons Lessons, buildingList Buildings, loc *time.Location) *Service {
+	if config.CacheTTL <= 0 {
+		config.CacheTTL = time.Minute
+	}
+	if config.RateLimit <= 0 {
+		config.RateLimit = 30
+	}
+	return &Service{
+		config:    config,
+		store:     store,
+		lessons:   lessons,
+		buildings: buildingList,
+		loc:       loc,
+		limiter:   newLimiter(config.RateLimit, time.Minute),
+		cache:     make(map[string]cachedBoard),
+	}
+}
+
+// CreateDisplay создает табло колледжа из контекста и возвращает его ключ.
+// Ключ показывается один раз: в базе хранится только его хэш.
+func (s *Service) CreateDisplay(ctx context.Context, createdBy uuid.UUID, name string, buildingCodes, classrooms []string) (*Display, string, error) {
+	display := &Display{
+		ID:         uuid.New(),
+		Name:       strings.TrimSpace(name),
+		Buildings:  cleanList(buildingCodes),
+		Classrooms: cleanList(classrooms),
+		CreatedBy:  &createdBy,
+	}
+	if display.Name == "" || utf8.RuneCountInString(display.Name) > 100 {
+		return nil, "", fmt.Errorf("%w: название должно быть от 1 до 100 символов", ErrInvalidDisplay)
+	}
+	if len(display.Buildings) == 0 && len(display.Classrooms) == 0 {
+		return nil, "", fmt.Errorf("%w: укажите корпуса или аудитории", ErrInvalidDisplay)
+	}
+
+	if len(display.Buildings) > 0 {
+		known, err := s.buildings.ListBuildings(ctx)
+		if err != nil {
+			return nil, "", err
+		}
+		codes := make(map[string]bool, len(known))
+		for _, building := range known {
+			codes[building.Code] = true
+		}
+		for _, code := range display.Buildings {
+			if !codes[code] {
+				return nil, "", fmt.Errorf("%w: корпус %s не найден", ErrInvalidDisplay, code)
+			}
+		}
+	}
+
+	buf := make([]byte, keyBytes)
+	if _, err := rand.Read(buf); err != nil {
+		return nil, "", fmt.Errorf("ошибка генерации ключа табло: %w", err)
+	}
+	key := base64.RawURLEncoding.EncodeToString(buf)
+
+	if err := s.store.CreateDisplay(ctx, display, hashKey(key)); err != nil {
+		return nil, "", err
+	}
+	return display, key, nil
+}
+
+// ListDisplays возвращает действующие табло колледжа из контекста
+func (s *Service) ListDisplays(ctx context.Context) ([]Display, error) {
+	return s.store.ListDisplays(ctx)
+}
+
+// RevokeDisplay отключает табло. Закэшированное расписание табло перестает
+// отдаваться не позже чем через CacheTTL.
+func (s *Service) RevokeDisplay(ctx context.Context, id uuid.UUID) error {
+	revoked, err := s.store.RevokeDisplay(ctx, id)
+	if err != nil {
+		return err
+	}
+	if !revoked {
+		return apperr.New(apperr.ErrNotFound, "табло не найдено")
+	}
+	return nil
+}
+
+// Invalidate удаляет из кэша расписание табло колледжа на дату, на которую
+// изменилось расписание какой-либо группы (подписчик cache.Invalidator)
+func (s *Service) Invalidate(inv cache.Invalidation) {
+	// Табло показывает аудитории, а не группы: сбрасывается при изменении любой группы
+	inv.Group = ""
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for keyHash, cached := range s.cache {
+		if inv.Covers(cached.collegeID, "", cached.board.Date) {
+			delete(s.cache, keyHash)
+		}
+	}
+}
+
+// Board возвращает расписание табло с ключом key на сегодня (из кэша, если
+// оно сформировано не раньше CacheTTL назад)
+func (s *Service) Board(ctx context.Context, key string) (*Board, error) {
+	keyHash := hashKey(key)
+	today := clock.Today(s.loc)
+
+	s.mu.Lock()
+	cached, ok := s.cache[keyHash]
+	s.mu.Unlock()
+	if ok && time.Since(cached.loadedAt) < s.config.CacheTTL && cached.board.Date == today.Format("2006-01-02") {
+		return cached.board, nil
+	}
+
+	display, err := s.store.GetDisplayByKeyHash(ctx, keyHash)
+	if err != nil {
+		return nil, err
+	}
+	if display == nil {
+		return nil, ErrUnknownKey
+	}
+	ctx = tenant.WithCollege(ctx, display.CollegeID)
+
+	classrooms, byClassroom, err := s.displayClassrooms(ctx, display)
+	if err != nil {
+		return nil, err
+	}
+	var entries []schedule.CurrentSchedule
+	if len(classrooms) > 0 {
+		if entries, err = s.lessons.GetCurrentScheduleForClassrooms(ctx, classrooms, today); err != nil {
+			return nil, err
+		}
+	}
+
+	board := &Board{
+		Display:     display.Name,
+		Date:        today.Format("2006-01-02"),
+		Lessons:     make([]Lesson, 0, len(entries)),
+		GeneratedAt: time.Now(),
+	}
+	for _, entry := range entries {
+		if entry.MeetingURL != "" {
+			continue // Онлайн-пары в аудитории не проходят
+		}
+		board.Lessons = append(board.Lessons, Lesson{
+			TimeStart: entry.TimeStart,
+			TimeEnd:   entry.TimeEnd,
+			Classroom: strings.TrimSpace(entry.Classroom),
+			Building:  byClassroom[normalizeClassroom(entry.Classroom)],
+			Group:     entry.GroupName,
+			Subgroup:  entry.Subgroup,
+			Subject:   entry.Subject,
+			Teacher:   entry.Teacher,
+			Cancelled: !entry.IsActive,
+		})
+	}
+
+	if err := s.store.TouchDisplay(ctx, display.ID); err != nil {
+		// Время последнего запроса нужно только администратору: табло работает и без него
+		log.Printf("Ошибка обновления времени запроса табло %s: %v", display.ID, err)
+	}
+
+	s.mu.Lock()
+	s.cache[keyHash] = cachedBoard{board: board, collegeID: display.CollegeID, loadedAt: time.Now()}
+	s.mu.Unlock()
+	return board, nil
+}
+
+// displayClassrooms возвращает аудитории табло (нормализованные) и корпуса аудиторий
+func (s *Service) displayClassrooms(ctx context.Context, display *Display) ([]string, map[string]string, error) {
+	list, err := s.buildings.ListBuildings(ctx)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	selected := make(map[string]bool, len(display.Buildings))
+	for _, code := range display.Buildings {
+		selected[code] = true
+	}
+	byClassroom := make(map[string]string)
+	set := make(map[string]bool)
+	for _, building := range list {
+		for _, classroom := range building.Classrooms {
+			name := normalizeClassroom(classroom)
+			byClassroom[name] = building.Code
+			if selected[building.Code] {
+				set[name] = true
+			}
+		}
+	}
+	for _, classroom := range display.Classrooms {
+		set[normalizeClassroom(classroom)] = true
+	}
+
+	classrooms := make([]string, 0, len(set))
+	for name := range set {
+		classrooms = append(classrooms, name)
+	}
+	sort.Strings(classrooms)
+	return classrooms, byClassroom, nil
+}
+
+// hashKey возвращает хэш ключа табло для хранения и поиска
+func hashKey(key string) string {
+	sum := sha256.Sum256([]byte(key))
+	return hex.EncodeToString(sum[:])
+}
+
+// normalizeClassroom приводит имя аудитории к виду для сравнения (как в запросе к базе)
+func normalizeClassroom(classroom string) string {
+	return strings.ToLower(strings.TrimSpace(classroom))
+}
+
+// cleanList убирает пустые значения и повторы
+func cleanList(values []string) []string {
+	seen := make(map[string]bool, len(values))
+	result := make([]string, 0, len(values))
+	for _, value := range values {
+		value = strings.TrimSpace(value)
+		if value == "" || seen[value] {
+			continue
+		}
+		seen[value] = true
+		result = append(result, value)
+	}
+	return result
+}
@@ -0,0 +1,184 @@
+package kiosk
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/Ultrahd-dev/student-schedule-app/backend/internal/buildings"
+	"github.com/Ultrahd-dev/student-schedule-app/backend/internal/schedule"
+	"github.com/Ultrahd-dev/student-schedule-app/backend/internal/tenant"
+	"github.com/google/uuid"
+)
+
+// fakeStore табло в памяти
+type fakeStore struct {
+	displays map[string]*Display // Ключ - хэш ключа табло
+	touched  int
+}
+
+func (f *fakeStore) CreateDisplay(ctx context.Context, display *Display, keyHash string) error {
+	display.CollegeID = tenant.CollegeID(ctx)
+	f.displays[keyHash] = display
+	return nil
+}
+
+func (f *fakeStore) ListDisplays(ctx context.Context) ([]Display, error) {
+	var result []Display
+	for _, display := range f.displays {
+		result = append(result, *display)
+	}
+	return result, nil
+}
+
+func (f *fakeStore) RevokeDisplay(ctx context.Context, id uuid.UUID) (bool, error) {
+	for keyHash, display := range f.displays {
+		if display.ID == id {
+			delete(f.displays, keyHash)
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+func (f *fakeStore) GetDisplayByKeyHash(ctx context.Context, keyHash string) (*Display, error) {
+	return f.displays[keyHash], nil
+}
+
+func (f *fakeStore) TouchDisplay(ctx context.Context, id uuid.UUID) error {
+	f.touched++
+	return nil
+}
+
+// fakeLessons расписание аудиторий колледжа
+type fakeLessons struct {
+	collegeID uuid.UUID
+	entries   []schedule.CurrentSchedule
+	queried   []string
+}
+
+func (f *fakeLessons) GetCurrentScheduleForClassrooms(ctx context.Context, classrooms []string, date time.Time) ([]schedule.CurrentSchedule, error) {
+	if tenant.CollegeID(ctx) != f.collegeID {
+		return nil, nil
+	}
+	f.queried = classrooms
+	wanted := make(map[string]bool, len(classrooms))
+	for _, classroom := range classrooms {
+		wanted[classroom] = true
+	}
+	var result []schedule.CurrentSchedule
+	for _, entry := range f.entries {
+		if wanted[normalizeClassroom(entry.Classroom)] {
+			result = append(result, entry)
+		}
+	}
+	return result, nil
+}
+
+type fakeBuildings []buildings.Building
+
+func (f fakeBuildings) ListBuildings(ctx context.Context) ([]buildings.Building, error) {
+	return f, nil
+}
+
+func TestBoardHandler(t *testing.T) {
+	collegeID := uuid.New()
+	store := &fakeStore{displays: make(map[string]*Display)}
+	lessons := &fakeLessons{
+		collegeID: collegeID,
+		entries: []schedule.CurrentSchedule{
+			{TimeStart: "8:30", TimeEnd: "10:00", Classroom: "201", GroupName: "ИС-21", Subject: "Математика", IsActive: true},
+			{TimeStart: "8:30", TimeEnd: "10:00", Classroom: "Спортзал ", GroupName: "ИС-22", Subject: "Физкультура", IsActive: false},
+			{TimeStart: "10:10", TimeEnd: "11:40", Classroom: "305", GroupName: "ИС-23", Subject: "История", IsActive: true},
+			{TimeStart: "10:10", TimeEnd: "11:40", Classroom: "201", GroupName: "ИС-24", Subject: "Физика", IsActive: true, MeetingURL: "https://meet.example/1"},
+		},
+	}
+	corpus := fakeBuildings{
+		{Code: "К1", Classrooms: []string{"201", "202"}},
+		{Code: "К2", Classrooms: []string{"305"}},
+	}
+	service := NewService(Config{CacheTTL: time.Minute, RateLimit: 3}, store, lessons, corpus, time.UTC)
+
+	ctx := tenant.WithCollege(context.Background(), collegeID)
+	if _, _, err := service.CreateDisplay(ctx, uuid.New(), "Холл", []string{"К3"}, nil); err == nil {
+		t.Fatal("табло с неизвестным корпусом создано")
+	}
+	_, key, err := service.CreateDisplay(ctx, uuid.New(), "Холл", []string{"К1"}, []string{"спортзал"})
+	if err != nil {
+		t.Fatalf("CreateDisplay: %v", err)
+	}
+
+	request := func(key string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodGet, Path+"?key="+key, nil)
+		req.RemoteAddr = "10.0.0.1:5000"
+		rec := httptest.NewRecorder()
+		service.Handler().ServeHTTP(rec, req)
+		return rec
+	}
+
+	rec := request(key)
+	if rec.Code != http.StatusOK {
+		t.Fatalf("код ответа %d: %s", rec.Code, rec.Body.String())
+	}
+	var board Board
+	if err := json.NewDecoder(rec.Body).Decode(&board); err != nil {
+		t.Fatal(err)
+	}
+	if board.Display != "Холл" || len(board.Lessons) != 2 {
+		t.Fatalf("неверное табло: %+v", board)
+	}
+	if board.Lessons[0].Building != "К1" || board.Lessons[0].Cancelled {
+		t.Errorf("неверная пара в 201: %+v", board.Lessons[0])
+	}
+	if board.Lessons[1].Classroom != "Спортзал" || !board.Lessons[1].Cancelled {
+		t.Errorf("отмененная пара должна остаться на табло: %+v", board.Lessons[1])
+	}
+
+	// Повторный запрос берется из кэша
+	lessons.entries = nil
+	if rec := request(key); rec.Code != http.StatusOK || store.touched != 1 {
+		t.Errorf("повторный запрос не из кэша: код %d, обращений к табло %d", rec.Code, store.touched)
+	}
+
+	if rec := request("wrong"); rec.Code != http.StatusNotFound {
+		t.Errorf("неизвестный ключ: код %d", rec.Code)
+	}
+	rec = request(key)
+	if rec.Code != http.StatusTooManyRequests || rec.Header().Get("Retry-After") == "" {
+		t.Errorf("лимит запросов не сработал: код %d", rec.Code)
+	}
+}
+
+func TestLimiterFullMap(t *testing.T) {
+	l := newLimiter(2, time.Minute)
+	l.maxAddrs = 3
+	start := time.Date(2025, 9, 1, 9, 0, 0, 0, time.UTC)
+
+	for i, addr := range []string{"10.0.0.1", "10.0.0.2", "10.0.0.3"} {
+		if ok, _ := l.Allow(addr, start.Add(time.Duration(i)*10*time.Second)); !ok {
+			t.Fatalf("отклонен первый запрос с %s", addr)
+		}
+	}
+
+	now := start.Add(30 * time.Second)
+	if ok, retryAfter := l.Allow("10.0.0.4", now); ok || retryAfter != 30*time.Second {
+		t.Errorf("новый адрес при заполненной карте: %v, повтор через %s", ok, retryAfter)
+	}
+	if ok, _ := l.Allow("10.0.0.2", now); !ok {
+		t.Error("отклонен запрос с уже учтенного адреса")
+	}
+	if len(l.windows) != 3 {
+		t.Errorf("хранится %d окон, предел 3", len(l.windows))
+	}
+
+	// Окно первого адреса завершилось - его место занимает новый адрес
+	if ok, _ := l.Allow("10.0.0.4", start.Add(time.Minute)); !ok {
+		t.Error("новый адрес отклонен после завершения окна")
+	}
+	if _, kept := l.windows["10.0.0.1"]; kept || len(l.windows) != 3 {
+		t.Errorf("окна после очистки: %v", l.windows)
+	}
+}
@@ -0,0 +1,71 @@
+package kiosk
+
+import (
+	"sync"
+	"time"
+)
+
+// maxAddrs предел числа адресов, окна которых хранит limiter
+const maxAddrs = 10000
+
+// limiter ограничивает число запросов с одного адреса за окно фиксированной длины
+type limiter struct {
+	limit    int
+	window   time.Duration
+	maxAddrs int
+
+	mu      sync.Mutex
+	windows map[string]*window
+}
+
+// window счетчик запросов адреса в текущем окне
+type window struct {
+	start time.Time
+	count int
+}
+
+// newLimiter создает ограничитель на limit запросов за period
+func newLimiter(limit int, period time.Duration) *limiter {
+	return &limiter{limit: limit, window: period, maxAddrs: maxAddrs, windows: make(map[string]*window)}
+}
+
+// Allow учитывает запрос с адреса addr. Если лимит исчерпан, возвращает false и
+// время до начала следующего окна. Пока хранятся окна maxAddrs адресов и ни одно
+// не завершилось, запросы с новых адресов отклоняются до конца самого раннего окна.
+func (l *limiter) Allow(addr string, now time.Time) (bool, time.Duration) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	w := l.windows[addr]
+	if w == nil || now.Sub(w.start) >= l.window {
+		if w == nil && len(l.windows) >= l.maxAddrs {
+			if retryAfter := l.evict(now); len(l.windows) >= l.maxAddrs {
+				return false, retryAfter
+			}
+		}
+		w = &window{start: now}
+		l.windows[addr] = w
+	}
+	if w.count >= l.limit {
+		return false, w.start.Add(l.window).Sub(now)
+	}
+	w.count++
+	return true, 0
+}
+
+// evict удаляет завершившиеся окна, чтобы карта адресов не росла бесконечно,
+// и возвращает время до конца самого раннего из оставшихся окон
+func (l *limiter) evict(now time.Time) time.Duration {
+	next := l.window
+	for addr, w := range l.windows {
+		left := w.start.Add(l.window).Sub(now)
+		if left <= 0 {
+			delete(l.windows, addr)
+			continue
+		}
+		if left < next {
+			next = left
+		}
+	}
+	return next
+}
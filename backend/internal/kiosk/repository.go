@@ -0,0 +1,120 @@
+package kiosk
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+
+	"github.com/Ultrahd-dev/student-schedule-app/backend/internal/tenant"
+	"github.com/google/uuid"
+	"github.com/lib/pq"
+)
+
+// Repository предоставляет доступ к табло в базе данных
+type Repository struct {
+	db *sql.DB
+}
+
+// NewRepository создает новый репозиторий табло
+func NewRepository(db *sql.DB) *Repository {
+	return &Repository{db: db}
+}
+
+// displayColumns колонки табло в порядке scanDisplay
+const displayColumns = `id, college_id, name, buildings, classrooms, created_by, created_at, last_seen_at`
+
+// rowScanner строка результата (*sql.Row или *sql.Rows)
+type rowScanner interface {
+	Scan(dest ...interface{}) error
+}
+
+// scanDisplay читает табло из строки результата
+func scanDisplay(row rowScanner) (*Display, error) {
+	var display Display
+	err := row.Scan(&display.ID, &display.CollegeID, &display.Name, pq.Array(&display.Buildings),
+		pq.Array(&display.Classrooms), &display.CreatedBy, &display.CreatedAt, &display.LastSeenAt)
+	if err != nil {
+		return nil, err
+	}
+	return &display, nil
+}
+
+// CreateDisplay сохраняет табло в колледже из контекста
+func (r *Repository) CreateDisplay(ctx context.Context, display *Display, keyHash string) error {
+	display.CollegeID = tenant.CollegeID(ctx)
+	query := `
+		INSERT INTO kiosk_displays (id, college_id, name, key_hash, buildings, classrooms, created_by)
+		VALUES ($1, $2, $3, $4, $5, $6, $7)
+		RETURNING created_at`
+
+	err := r.db.QueryRowContext(ctx, query, display.ID, display.CollegeID, display.Name, keyHash,
+		pq.Array(display.Buildings), pq.Array(display.Classrooms), display.CreatedBy).Scan(&display.CreatedAt)
+	if err != nil {
+		return fmt.Errorf("failed to create kiosk display: %w", err)
+	}
+	return nil
+}
+
+// ListDisplays возвращает действующие табло колледжа из контекста, упорядоченные по названию
+func (r *Repository) ListDisplays(ctx context.Context) ([]Display, error) {
+	rows, err := r.db.QueryContext(ctx, `
+		SELECT `+displayColumns+`
+		FROM kiosk_displays
+		WHERE college_id = $1 AND revoked_at IS NULL
+		ORDER BY name, created_at`, tenant.CollegeID(ctx))
+	if err != nil {
+		return nil, fmt.Errorf("failed to get kiosk displays: %w", err)
+	}
+	defer rows.Close()
+
+	var displays []Display
+	for rows.Next() {
+		display, err := scanDisplay(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan kiosk display: %w", err)
+		}
+		displays = append(displays, *display)
+	}
+	return displays, rows.Err()
+}
+
+// RevokeDisplay отключает табло колледжа из контекста. Возвращает false, если
+// действующего табло с таким ID нет.
+func (r *Repository) RevokeDisplay(ctx context.Context, id uuid.UUID) (bool, error) {
+	result, err := r.db.ExecContext(ctx, `
+		UPDATE kiosk_displays SET revoked_at = NOW()
+		WHERE id = $1 AND college_id = $2 AND revoked_at IS NULL`, id, tenant.CollegeID(ctx))
+	if err != nil {
+		return false, fmt.Errorf("failed to revoke kiosk display: %w", err)
+	}
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return false, fmt.Errorf("failed to revoke kiosk display: %w", err)
+	}
+	return affected > 0, nil
+}
+
+// GetDisplayByKeyHash возвращает действующее табло по хэшу ключа (в любом колледже)
+// или nil, если такого табло нет
+func (r *Repository) GetDisplayByKeyHash(ctx context.Context, keyHash string) (*Display, error) {
+	display, err := scanDisplay(r.db.QueryRowContext(ctx, `
+		SELECT `+displayColumns+`
+		FROM kiosk_displays
+		WHERE key_hash = $1 AND revoked_at IS NULL`, keyHash))
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get kiosk display: %w", err)
+	}
+	return display, nil
+}
+
+// TouchDisplay запоминает время последнего запроса табло
+func (r *Repository) TouchDisplay(ctx context.Context, id uuid.UUID) error {
+	if _, err := r.db.ExecContext(ctx, `UPDATE kiosk_displays SET last_seen_at = NOW() WHERE id = $1`, id); err != nil {
+		return fmt.Errorf("failed to touch kiosk display: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,174 @@
+package ldap
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+)
+
+// Теги BER, используемые протоколом LDAP (RFC 4511)
+const (
+	tagBoolean     = 0x01
+	tagInteger     = 0x02
+	tagOctetString = 0x04
+	tagEnumerated  = 0x0a
+	tagSequence    = 0x30
+
+	tagBindRequest      = 0x60 // [APPLICATION 0]
+	tagBindResponse     = 0x61 // [APPLICATION 1]
+	tagUnbindRequest    = 0x42 // [APPLICATION 2], примитивный
+	tagSearchRequest    = 0x63 // [APPLICATION 3]
+	tagSearchEntry      = 0x64 // [APPLICATION 4]
+	tagSearchDone       = 0x65 // [APPLICATION 5]
+	tagExtendedRequest  = 0x77 // [APPLICATION 23]
+	tagExtendedResponse = 0x78 // [APPLICATION 24]
+
+	tagSimpleAuth     = 0x80 // [0] в BindRequest
+	tagExtendedName   = 0x80 // [0] в ExtendedRequest
+	tagFilterAnd      = 0xa0 // [0] в Filter
+	tagFilterEquality = 0xa3 // [3] в Filter
+)
+
+// maxPacketSize ограничивает размер ответа сервера
+const maxPacketSize = 1 << 20
+
+var errMalformed = errors.New("ldap: некорректный ответ сервера")
+
+// packet элемент BER: тег и содержимое
+type packet struct {
+	tag   byte
+	value []byte
+}
+
+// encode кодирует элемент с тегом tag и содержимым value
+func encode(tag byte, value []byte) []byte {
+	out := []byte{tag}
+	switch n := len(value); {
+	case n < 0x80:
+		out = append(out, byte(n))
+	case n <= 0xff:
+		out = append(out, 0x81, byte(n))
+	case n <= 0xffff:
+		out = append(out, 0x82, byte(n>>8), byte(n))
+	default:
+		out = append(out, 0x83, byte(n>>16), byte(n>>8), byte(n))
+	}
+	return append(out, value...)
+}
+
+// constructed кодирует составной элемент из уже закодированных элементов
+func constructed(tag byte, children ...[]byte) []byte {
+	var value []byte
+	for _, child := range children {
+		value = append(value, child...)
+	}
+	return encode(tag, value)
+}
+
+// octetString кодирует строку
+func octetString(tag byte, s string) []byte {
+	return encode(tag, []byte(s))
+}
+
+// integer кодирует неотрицательное целое (INTEGER или ENUMERATED)
+func integer(tag byte, v int) []byte {
+	var value []byte
+	for {
+		value = append([]byte{byte(v)}, value...)
+		v >>= 8
+		if v == 0 && value[0] < 0x80 {
+			break
+		}
+	}
+	return encode(tag, value)
+}
+
+// boolean кодирует логическое значение
+func boolean(v bool) []byte {
+	if v {
+		return encode(tagBoolean, []byte{0xff})
+	}
+	return encode(tagBoolean, []byte{0x00})
+}
+
+// readPacket читает один элемент BER из потока
+func readPacket(r *bufio.Reader) (packet, error) {
+	tag, err := r.ReadByte()
+	if err != nil {
+		return packet{}, err
+	}
+	first, err := r.ReadByte()
+	if err != nil {
+		return packet{}, err
+	}
+
+	length := int(first)
+	if first >= 0x80 {
+		size := int(first & 0x7f)
+		if size == 0 || size > 3 {
+			return packet{}, errMalformed
+		}
+		length = 0
+		for i := 0; i < size; i++ {
+			b, err := r.ReadByte()
+			if err != nil {
+				return packet{}, err
+			}
+			length = length<<8 | int(b)
+		}
+	}
+	if length > maxPacketSize {
+		return packet{}, fmt.Errorf("ldap: слишком большой ответ сервера (%d байт)", length)
+	}
+
+	value := make([]byte, length)
+	if _, err := io.ReadFull(r, value); err != nil {
+		return packet{}, err
+	}
+	return packet{tag: tag, value: value}, nil
+}
+
+// children разбирает содержимое составного элемента
+func (p packet) children() ([]packet, error) {
+	var children []packet
+	data := p.value
+	for len(data) > 0 {
+		if len(data) < 2 {
+			return nil, errMalformed
+		}
+		tag, length, header := data[0], int(data[1]), 2
+		if length >= 0x80 {
+			size := length & 0x7f
+			if size == 0 || size > 3 || len(data) < 2+size {
+				return nil, errMalformed
+			}
+			length = 0
+			for _, b := range data[2 : 2+size] {
+				length = length<<8 | int(b)
+			}
+			header += size
+		}
+		if len(data) < header+length {
+			return nil, errMalformed
+		}
+		children = append(children, packet{tag: tag, value: data[header : header+length]})
+		data = data[header+length:]
+	}
+	return children, nil
+}
+
+// int возвращает значение INTEGER или ENUMERATED
+func (p packet) int() (int, error) {
+	if (p.tag != tagInteger && p.tag != tagEnumerated) || len(p.value) == 0 || len(p.value) > 4 {
+		return 0, errMalformed
+	}
+	v := 0
+	if p.value[0]&0x80 != 0 {
+		v = -1
+	}
+	for _, b := range p.value {
+		v = v<<8 | int(b)
+	}
+	return v, nil
+}
@@ -0,0 +1,364 @@
+// Package ldap проверяет учетные данные пользователей в каталоге LDAP или
+// Active Directory колледжа. Реализована только нужная для входа часть
+// протокола (RFC 4511): простая привязка (bind), поиск записи пользователя
+// по логину и StartTLS.
+package ldap
+
+import (
+	"bufio"
+	"context"
+	"crypto/tls"
+	"errors"
+	"fmt"
+	"net"
+	"net/url"
+	"strings"
+	"time"
+)
+
+// Ошибки проверки учетных данных
+var (
+	ErrInvalidCredentials = errors.New("ldap: неверный логин или пароль")
+	ErrUserNotFound       = errors.New("ldap: пользователь не найден в каталоге")
+)
+
+// Коды результата LDAP
+const (
+	resultSuccess            = 0
+	resultSizeLimitExceeded  = 4
+	resultInvalidCredentials = 49
+)
+
+// startTLSOID идентификатор расширенной операции StartTLS
+const startTLSOID = "1.3.6.1.4.1.1466.20037"
+
+// Config настройки подключения к каталогу
+type Config struct {
+	URL                string // ldap://host:389 или ldaps://host:636
+	StartTLS           bool   // Перейти на TLS после подключения по ldap://
+	InsecureSkipVerify bool   // Не проверять сертификат сервера (только для тестовых стендов)
+	BindDN             string // Служебная учетная запись для поиска пользователей (пусто - анонимный поиск)
+	BindPassword       string
+	BaseDN             string        // Где искать пользователей
+	LoginAttribute     string        // Атрибут с логином: mail, userPrincipalName, uid ...
+	ObjectClass        string        // Класс записей пользователей (пусто - любые записи)
+	Attributes         []string      // Атрибуты, возвращаемые в Entry
+	Timeout            time.Duration // Таймаут подключения и каждой операции
+}
+
+// Entry запись пользователя в каталоге
+type Entry struct {
+	DN         string
+	Attributes map[string][]string // Ключ - имя атрибута в нижнем регистре
+}
+
+// Get возвращает первое значение атрибута (имя без учета регистра)
+func (e *Entry) Get(name string) string {
+	values := e.Attributes[strings.ToLower(name)]
+	if len(values) == 0 {
+		return ""
+	}
+	return values[0]
+}
+
+// Values возвращает все значения атрибута (имя без учета регистра)
+func (e *Entry) Values(name string) []string {
+	return e.Attributes[strings.ToLower(name)]
+}
+
+// ResultError ошибка, возвращенная сервером LDAP
+type ResultError struct {
+	Code    int
+	Message string
+}
+
+func (e *ResultError) Error() string {
+	return fmt.Sprintf("ldap: код результата %d: %s", e.Code, e.Message)
+}
+
+// Client проверяет учетные данные в каталоге. Для каждой проверки
+// устанавливается отдельное соединение.
+type Client struct {
+	config Config
+}
+
+// NewClient создает клиент каталога
+func NewClient(config Config) *Client {
+	if config.Timeout <= 0 {
+		config.Timeout = 10 * time.Second
+	}
+	if config.LoginAttribute == "" {
+		config.LoginAttribute = "mail"
+	}
+	return &Client{config: config}
+}
+
+// Authenticate находит пользователя по логину и проверяет его пароль привязкой
+// к его записи. Возвращает ErrUserNotFound, если записи нет, и
+// ErrInvalidCredentials, если пароль неверный.
+func (c *Client) Authenticate(ctx context.Context, login, password string) (*Entry, error) {
+	// Привязка с пустым паролем - анонимная и всегда успешна (RFC 4513, 5.1.2)
+	if login == "" || password == "" {
+		return nil, ErrInvalidCredentials
+	}
+
+	conn, err := c.dial(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer conn.close()
+
+	if c.config.BindDN != "" {
+		if err := conn.bind(c.config.BindDN, c.config.BindPassword); err != nil {
+			return nil, fmt.Errorf("ошибка входа служебной учетной записи: %w", err)
+		}
+	}
+
+	entry, err := conn.findUser(login)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := conn.bind(entry.DN, password); err != nil {
+		var resultErr *ResultError
+		if errors.As(err, &resultErr) && resultErr.Code == resultInvalidCredentials {
+			return nil, ErrInvalidCredentials
+		}
+		return nil, err
+	}
+	return entry, nil
+}
+
+// conn соединение с сервером каталога
+type conn struct {
+	client    *Client
+	conn      net.Conn
+	reader    *bufio.Reader
+	messageID int
+}
+
+// dial подключается к серверу и при необходимости переходит на TLS
+func (c *Client) dial(ctx context.Context) (*conn, error) {
+	parsed, err := url.Parse(c.config.URL)
+	if err != nil {
+		return nil, fmt.Errorf("ldap: некорректный адрес сервера: %w", err)
+	}
+
+	host := parsed.Host
+	if parsed.Port() == "" {
+		port := "389"
+		if parsed.Scheme == "ldaps" {
+			port = "636"
+		}
+		host = net.JoinHostPort(parsed.Hostname(), port)
+	}
+	tlsConfig := &tls.Config{ServerName: parsed.Hostname(), InsecureSkipVerify: c.config.InsecureSkipVerify}
+
+	dialCtx, cancel := context.WithTimeout(ctx, c.config.Timeout)
+	defer cancel()
+
+	var netConn net.Conn
+	switch parsed.Scheme {
+	case "ldap":
+		var dialer net.Dialer
+		netConn, err = dialer.DialContext(dialCtx, "tcp", host)
+	case "ldaps":
+		dialer := tls.Dialer{Config: tlsConfig}
+		netConn, err = dialer.DialContext(dialCtx, "tcp", host)
+	default:
+		return nil, fmt.Errorf("ldap: неподдерживаемая схема %q, ожидается ldap или ldaps", parsed.Scheme)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("ldap: ошибка подключения к %s: %w", host, err)
+	}
+
+	result := &conn{client: c, conn: netConn, reader: bufio.NewReader(netConn)}
+	if c.config.StartTLS && parsed.Scheme == "ldap" {
+		if err := result.startTLS(dialCtx, tlsConfig); err != nil {
+			netConn.Close()
+			return nil, err
+		}
+	}
+	return result, nil
+}
+
+// startTLS выполняет расширенную операцию StartTLS и рукопожатие TLS
+func (c *conn) startTLS(ctx context.Context, config *tls.Config) error {
+	responses, err := c.request(constructed(tagExtendedRequest, octetString(tagExtendedName, startTLSOID)), tagExtendedResponse)
+	if err != nil {
+		return fmt.Errorf("ldap: ошибка StartTLS: %w", err)
+	}
+	if err := checkResult(responses[len(responses)-1]); err != nil {
+		return fmt.Errorf("ldap: ошибка StartTLS: %w", err)
+	}
+
+	tlsConn := tls.Client(c.conn, config)
+	if err := tlsConn.HandshakeContext(ctx); err != nil {
+		return fmt.Errorf("ldap: ошибка StartTLS: %w", err)
+	}
+	c.conn = tlsConn
+	c.reader = bufio.NewReader(tlsConn)
+	return nil
+}
+
+// bind выполняет простую привязку от имени dn
+func (c *conn) bind(dn, password string) error {
+	op := constructed(tagBindRequest,
+		integer(tagInteger, 3),
+		octetString(tagOctetString, dn),
+		octetString(tagSimpleAuth, password))
+	responses, err := c.request(op, tagBindResponse)
+	if err != nil {
+		return err
+	}
+	return checkResult(responses[len(responses)-1])
+}
+
+// findUser ищет единственную запись пользователя с логином login
+func (c *conn) findUser(login string) (*Entry, error) {
+	config := c.client.config
+	filter := constructed(tagFilterEquality,
+		octetString(tagOctetString, config.LoginAttribute),
+		octetString(tagOctetString, login))
+	if config.ObjectClass != "" {
+		filter = constructed(tagFilterAnd, filter, constructed(tagFilterEquality,
+			octetString(tagOctetString, "objectClass"),
+			octetString(tagOctetString, config.ObjectClass)))
+	}
+
+	attributes := make([][]byte, 0, len(config.Attributes))
+	for _, attribute := range config.Attributes {
+		attributes = append(attributes, octetString(tagOctetString, attribute))
+	}
+
+	op := constructed(tagSearchRequest,
+		octetString(tagOctetString, config.BaseDN),
+		integer(tagEnumerated, 2), // wholeSubtree
+		integer(tagEnumerated, 0), // neverDerefAliases
+		integer(tagInteger, 2),    // Двух записей достаточно, чтобы обнаружить неоднозначный логин
+		integer(tagInteger, int(config.Timeout/time.Second)),
+		boolean(false),
+		filter,
+		constructed(tagSequence, attributes...))
+	responses, err := c.request(op, tagSearchDone)
+	if err != nil {
+		return nil, err
+	}
+
+	var entries []*Entry
+	for _, response := range responses[:len(responses)-1] {
+		if response.tag != tagSearchEntry {
+			continue // Ссылки на другие серверы (referral) не обрабатываются
+		}
+		entry, err := parseEntry(response)
+		if err != nil {
+			return nil, err
+		}
+		entries = append(entries, entry)
+	}
+
+	if err := checkResult(responses[len(responses)-1]); err != nil {
+		var resultErr *ResultError
+		if !errors.As(err, &resultErr) || resultErr.Code != resultSizeLimitExceeded {
+			return nil, err
+		}
+	}
+	switch len(entries) {
+	case 0:
+		return nil, ErrUserNotFound
+	case 1:
+		return entries[0], nil
+	default:
+		return nil, fmt.Errorf("ldap: логину %q соответствует несколько записей каталога", login)
+	}
+}
+
+// request отправляет операцию и читает ответы на нее до ответа с тегом last включительно
+func (c *conn) request(op []byte, last byte) ([]packet, error) {
+	c.messageID++
+	deadline := time.Now().Add(c.client.config.Timeout)
+	c.conn.SetDeadline(deadline)
+
+	message := constructed(tagSequence, integer(tagInteger, c.messageID), op)
+	if _, err := c.conn.Write(message); err != nil {
+		return nil, fmt.Errorf("ldap: ошибка отправки запроса: %w", err)
+	}
+
+	var responses []packet
+	for {
+		message, err := readPacket(c.reader)
+		if err != nil {
+			return nil, fmt.Errorf("ldap: ошибка чтения ответа: %w", err)
+		}
+		parts, err := message.children()
+		if err != nil || message.tag != tagSequence || len(parts) < 2 {
+			return nil, errMalformed
+		}
+		id, err := parts[0].int()
+		if err != nil {
+			return nil, err
+		}
+		if id != c.messageID {
+			continue // Уведомления сервера (messageID 0)
+		}
+
+		responses = append(responses, parts[1])
+		if parts[1].tag == last {
+			return responses, nil
+		}
+	}
+}
+
+// close завершает сеанс и закрывает соединение
+func (c *conn) close() {
+	c.messageID++
+	c.conn.SetDeadline(time.Now().Add(time.Second))
+	c.conn.Write(constructed(tagSequence, integer(tagInteger, c.messageID), encode(tagUnbindRequest, nil)))
+	c.conn.Close()
+}
+
+// checkResult возвращает ошибку, если код результата LDAPResult не success
+func checkResult(response packet) error {
+	parts, err := response.children()
+	if err != nil || len(parts) < 3 {
+		return errMalformed
+	}
+	code, err := parts[0].int()
+	if err != nil {
+		return err
+	}
+	if code != resultSuccess {
+		return &ResultError{Code: code, Message: string(parts[2].value)}
+	}
+	return nil
+}
+
+// parseEntry разбирает SearchResultEntry
+func parseEntry(response packet) (*Entry, error) {
+	parts, err := response.children()
+	if err != nil || len(parts) < 2 {
+		return nil, errMalformed
+	}
+
+	entry := &Entry{DN: string(parts[0].value), Attributes: make(map[string][]string)}
+	attributes, err := parts[1].children()
+	if err != nil {
+		return nil, err
+	}
+	for _, attribute := range attributes {
+		fields, err := attribute.children()
+		if err != nil || len(fields) < 2 {
+			return nil, errMalformed
+		}
+		values, err := fields[1].children()
+		if err != nil {
+			return nil, err
+		}
+
+		name := strings.ToLower(string(fields[0].value))
+		for _, value := range values {
+			entry.Attributes[name] = append(entry.Attributes[name], string(value.value))
+		}
+	}
+	return entry, nil
+}
@@ -0,0 +1,185 @@
+// Package lock реализует распределенные блокировки между экземплярами API
+// на advisory-блокировках Postgres, чтобы периодические задачи (парсинг,
+// применение изменений, обслуживание) выполнял только один экземпляр
+package lock
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"fmt"
+	"hash/fnv"
+	"log"
+	"os"
+	"time"
+)
+
+// Locker захватывает именованные блокировки в Postgres.
+// Advisory-блокировка принадлежит соединению, поэтому на время ее удержания
+// из пула берется отдельное соединение; если экземпляр падает, Postgres
+// снимает блокировку вместе с разорванным соединением.
+type Locker struct {
+	db       *sql.DB
+	instance string // Идентификатор экземпляра для журнала запусков
+}
+
+// NewLocker создает распределенные блокировки поверх базы db
+func NewLocker(db *sql.DB) *Locker {
+	host, err := os.Hostname()
+	if err != nil {
+		host = "unknown"
+	}
+
+	return &Locker{
+		db:       db,
+		instance: fmt.Sprintf("%s:%d", host, os.Getpid()),
+	}
+}
+
+// Instance возвращает идентификатор текущего экземпляра
+func (l *Locker) Instance() string {
+	return l.instance
+}
+
+// Lock ждет и захватывает блокировку name. Возвращает функцию снятия блокировки.
+func (l *Locker) Lock(ctx context.Context, name string) (func(), error) {
+	conn, err := l.db.Conn(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get connection for lock %s: %w", name, err)
+	}
+
+	if _, err := conn.ExecContext(ctx, `SELECT pg_advisory_lock($1)`, key(name)); err != nil {
+		conn.Close()
+		return nil, fmt.Errorf("failed to acquire lock %s: %w", name, err)
+	}
+
+	return l.unlocker(conn, name), nil
+}
+
+// TryLock пытается захватить блокировку name без ожидания.
+// Если блокировку держит другой экземпляр, возвращает ok = false.
+func (l *Locker) TryLock(ctx context.Context, name string) (unlock func(), ok bool, err error) {
+	conn, err := l.db.Conn(ctx)
+	if err != nil {
+		return nil, false, fmt.Errorf("failed to get connection for lock %s: %w", name, err)
+	}
+
+	if err := conn.QueryRowContext(ctx, `SELECT pg_try_advisory_lock($1)`, key(name)).Scan(&ok); err != nil {
+		conn.Close()
+		return nil, false, fmt.Errorf("failed to try lock %s: %w", name, err)
+	}
+	if !ok {
+		conn.Close()
+		return nil, false, nil
+	}
+
+	return l.unlocker(conn, name), true, nil
+}
+
+// RunOnce выполняет цикл задачи name, если его не выполняет и недавно не выполнил
+// другой экземпляр. Цикл считается уже выполненным, если с его успешного
+// завершения прошло меньше minInterval. Возвращает признак того, что цикл выполнил
+// этот экземпляр; ошибка fn возвращается как есть, и цикл не отмечается выполненным.
+func (l *Locker) RunOnce(ctx context.Context, name string, minInterval time.Duration, fn func(ctx context.Context) error) (bool, error) {
+	unlock, ok, err := l.TryLock(ctx, name)
+	if err != nil {
+		return false, err
+	}
+	if !ok {
+		log.Printf("Задачу %s выполняет другой экземпляр, пропускаем", name)
+		return false, nil
+	}
+	defer unlock()
+
+	if minInterval > 0 {
+		var finishedAt sql.NullTime
+		var instance string
+		err := l.db.QueryRowContext(ctx,
+			`SELECT finished_at, instance FROM job_runs WHERE name = $1`, name).Scan(&finishedAt, &instance)
+		if err != nil && err != sql.ErrNoRows {
+			return false, fmt.Errorf("failed to get last run of %s: %w", name, err)
+		}
+		if finishedAt.Valid && time.Since(finishedAt.Time) < minInterval {
+			log.Printf("Задача %s уже выполнена экземпляром %s в %s, пропускаем",
+				name, instance, finishedAt.Time.Format(time.RFC3339))
+			return false, nil
+		}
+	}
+
+	startedAt := time.Now()
+	if err := fn(ctx); err != nil {
+		return true, err
+	}
+
+	_, err = l.db.ExecContext(ctx, `
+		INSERT INTO job_runs (name, instance, started_at, finished_at)
+		VALUES ($1, $2, $3, NOW())
+		ON CONFLICT (name) DO UPDATE
+		SET instance = EXCLUDED.instance, started_at = EXCLUDED.started_at, finished_at = EXCLUDED.finished_at`,
+		name, l.instance, startedAt)
+	if err != nil {
+		log.Printf("Ошибка сохранения запуска задачи %s: %v", name, err)
+	}
+	return true, nil
+}
+
+// LastRun возвращает время завершения последнего успешного цикла задачи name
+// на любом экземпляре; nil, если задача еще не выполнялась
+func (l *Locker) LastRun(ctx context.Context, name string) (*time.Time, error) {
+	var finishedAt time.Time
+	err := l.db.QueryRowContext(ctx, `SELECT finished_at FROM job_runs WHERE name = $1`, name).Scan(&finishedAt)
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get last run of %s: %w", name, err)
+	}
+	return &finishedAt, nil
+}
+
+// Run последний успешный цикл периодической задачи
+type Run struct {
+	Instance   string    // Экземпляр, выполнивший цикл
+	StartedAt  time.Time // Начало цикла
+	FinishedAt time.Time // Завершение цикла
+}
+
+// GetRun возвращает последний успешный цикл задачи name на любом экземпляре;
+// nil, если задача еще не выполнялась
+func (l *Locker) GetRun(ctx context.Context, name string) (*Run, error) {
+	run := &Run{}
+	err := l.db.QueryRowContext(ctx,
+		`SELECT instance, started_at, finished_at FROM job_runs WHERE name = $1`, name).
+		Scan(&run.Instance, &run.StartedAt, &run.FinishedAt)
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get last run of %s: %w", name, err)
+	}
+	return run, nil
+}
+
+// unlocker возвращает функцию, снимающую блокировку и возвращающую соединение в пул
+func (l *Locker) unlocker(conn *sql.Conn, name string) func() {
+	return func() {
+		// Контекст вызова может быть уже отменен, а блокировку нужно снять в любом случае
+		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+
+		if _, err := conn.ExecContext(ctx, `SELECT pg_advisory_unlock($1)`, key(name)); err != nil {
+			log.Printf("Ошибка снятия блокировки %s: %v", name, err)
+			// Соединение с неснятой блокировкой нельзя возвращать в пул: закрываем его,
+			// и Postgres снимет блокировку вместе с сессией
+			conn.Raw(func(interface{}) error { return driver.ErrBadConn })
+		}
+		conn.Close()
+	}
+}
+
+// key преобразует имя блокировки в ключ advisory-блокировки
+func key(name string) int64 {
+	h := fnv.New64a()
+	h.Write([]byte(name))
+	return int64(h.Sum64())
+}
@@ -0,0 +1,134 @@
+// Package mail отправляет письма через SMTP-сервер колледжа: приглашения
+// пользователям, созданным администратором, и уведомления безопасности.
+// Письма простые текстовые (UTF-8, quoted-printable).
+package mail
+
+import (
+	"bytes"
+	"context"
+	"crypto/tls"
+	"fmt"
+	"mime"
+	"mime/quotedprintable"
+	"net"
+	"net/mail"
+	"net/smtp"
+	"strconv"
+	"time"
+)
+
+// Config настройки SMTP-сервера
+type Config struct {
+	Host     string
+	Port     int    // 587 (STARTTLS) или 465 (TLS)
+	TLS      bool   // Подключение сразу по TLS (порт 465); иначе STARTTLS, если сервер его поддерживает
+	Username string // Пусто - без аутентификации
+	Password string
+	From     string        // Адрес отправителя, например "Расписание <schedule@college.ru>"
+	Timeout  time.Duration // Таймаут отправки письма
+}
+
+// Sender отправляет письма. Для каждого письма устанавливается отдельное соединение.
+type Sender struct {
+	config Config
+	from   *mail.Address
+}
+
+// NewSender создает отправителя писем
+func NewSender(config Config) (*Sender, error) {
+	from, err := mail.ParseAddress(config.From)
+	if err != nil {
+		return nil, fmt.Errorf("некорректный адрес отправителя %q: %w", config.From, err)
+	}
+	if config.Timeout <= 0 {
+		config.Timeout = 30 * time.Second
+	}
+	return &Sender{config: config, from: from}, nil
+}
+
+// Send отправляет текстовое письмо на адрес to
+func (s *Sender) Send(ctx context.Context, to, subject, body string) error {
+	recipient, err := mail.ParseAddress(to)
+	if err != nil {
+		return fmt.Errorf("некорректный адрес получателя %q: %w", to, err)
+	}
+	message, err := s.message(recipient, subject, body)
+	if err != nil {
+		return err
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, s.config.Timeout)
+	defer cancel()
+
+	addr := net.JoinHostPort(s.config.Host, strconv.Itoa(s.config.Port))
+	dialer := &net.Dialer{}
+	conn, err := dialer.DialContext(ctx, "tcp", addr)
+	if err != nil {
+		return fmt.Errorf("ошибка подключения к SMTP-серверу %s: %w", addr, err)
+	}
+	if deadline, ok := ctx.Deadline(); ok {
+		_ = conn.SetDeadline(deadline)
+	}
+	tlsConfig := &tls.Config{ServerName: s.config.Host}
+	if s.config.TLS {
+		conn = tls.Client(conn, tlsConfig)
+	}
+
+	client, err := smtp.NewClient(conn, s.config.Host)
+	if err != nil {
+		conn.Close()
+		return fmt.Errorf("ошибка подключения к SMTP-серверу %s: %w", addr, err)
+	}
+	defer client.Close()
+
+	if ok, _ := client.Extension("STARTTLS"); ok && !s.config.TLS {
+		if err := client.StartTLS(tlsConfig); err != nil {
+			return fmt.Errorf("ошибка STARTTLS: %w", err)
+		}
+	}
+	if s.config.Username != "" {
+		auth := smtp.PlainAuth("", s.config.Username, s.config.Password, s.config.Host)
+		if err := client.Auth(auth); err != nil {
+			return fmt.Errorf("ошибка аутентификации на SMTP-сервере: %w", err)
+		}
+	}
+
+	if err := client.Mail(s.from.Address); err != nil {
+		return fmt.Errorf("ошибка отправки письма: %w", err)
+	}
+	if err := client.Rcpt(recipient.Address); err != nil {
+		return fmt.Errorf("ошибка отправки письма на %s: %w", recipient.Address, err)
+	}
+	w, err := client.Data()
+	if err != nil {
+		return fmt.Errorf("ошибка отправки письма: %w", err)
+	}
+	if _, err := w.Write(message); err != nil {
+		return fmt.Errorf("ошибка отправки письма: %w", err)
+	}
+	if err := w.Close(); err != nil {
+		return fmt.Errorf("ошибка отправки письма: %w", err)
+	}
+	return client.Quit()
+}
+
+// message формирует письмо с заголовками
+func (s *Sender) message(to *mail.Address, subject, body string) ([]byte, error) {
+	var buf bytes.Buffer
+	fmt.Fprintf(&buf, "From: %s\r\n", s.from.String())
+	fmt.Fprintf(&buf, "To: %s\r\n", to.String())
+	fmt.Fprintf(&buf, "Subject: %s\r\n", mime.QEncoding.Encode("utf-8", subject))
+	fmt.Fprintf(&buf, "Date: %s\r\n", time.Now().Format(time.RFC1123Z))
+	buf.WriteString("MIME-Version: 1.0\r\n")
+	buf.WriteString("Content-Type: text/plain; charset=utf-8\r\n")
+	buf.WriteString("Content-Transfer-Encoding: quoted-printable\r\n\r\n")
+
+	w := quotedprintable.NewWriter(&buf)
+	if _, err := w.Write([]byte(body)); err != nil {
+		return nil, fmt.Errorf("ошибка формирования письма: %w", err)
+	}
+	if err := w.Close(); err != nil {
+		return nil, fmt.Errorf("ошибка формирования письма: %w", err)
+	}
+	return buf.Bytes(), nil
+}
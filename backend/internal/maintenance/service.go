@@ -0,0 +1,188 @@
+// Package maintenance реализует фоновые задачи обслуживания базы данных
+package maintenance
+
+import (
+	"context"
+	"log"
+	"time"
+
+	"github.com/Ultrahd-dev/student-schedule-app/backend/internal/schedule"
+	"github.com/Ultrahd-dev/student-schedule-app/backend/internal/storage"
+	"github.com/Ultrahd-dev/student-schedule-app/backend/internal/tenant"
+	"github.com/google/uuid"
+)
+
+// Config настройки задач обслуживания
+type Config struct {
+	// Interval период запуска задач обслуживания
+	Interval time.Duration
+	// SnapshotsKeep количество последних снапшотов, данные которых остаются в основной таблице
+	SnapshotsKeep int
+	// PartitionMonthsAhead на сколько месяцев вперед создаются секции актуального расписания
+	PartitionMonthsAhead int
+	// ArchiveSchedule переносить расписание прошедших семестров в архив
+	ArchiveSchedule bool
+	// SemesterStarts начала семестров в формате "ММ-ДД"
+	SemesterStarts []string
+}
+
+// jobName имя задачи обслуживания для распределенной блокировки
+const jobName = "maintenance"
+
+// JobLocker выполняет цикл задачи только на одном экземпляре API
+type JobLocker interface {
+	RunOnce(ctx context.Context, name string, minInterval time.Duration, fn func(ctx context.Context) error) (bool, error)
+}
+
+// CollegeLister возвращает колледжи, данные которых нужно обслуживать
+type CollegeLister interface {
+	ActiveCollegeIDs(ctx context.Context) ([]uuid.UUID, error)
+}
+
+// Service выполняет периодическое обслуживание данных
+type Service struct {
+	config          Config
+	scheduleService *schedule.Service
+	locker          JobLocker               // Блокировка циклов обслуживания (может быть nil)
+	colleges        CollegeLister           // Колледжи для периодического обслуживания (nil - только колледж по умолчанию)
+	storage         storage.Storage         // Хранилище файлов (может быть nil)
+	lifecycle       []storage.LifecycleRule // Сроки хранения объектов в хранилище
+}
+
+// NewService создает новый сервис обслуживания
+func NewService(config Config, scheduleService *schedule.Service) *Service {
+	if config.Interval <= 0 {
+		config.Interval = 24 * time.Hour
+	}
+	if config.SnapshotsKeep <= 0 {
+		config.SnapshotsKeep = 8
+	}
+	if config.PartitionMonthsAhead <= 0 {
+		config.PartitionMonthsAhead = 3
+	}
+	if len(config.SemesterStarts) == 0 {
+		config.SemesterStarts = schedule.DefaultSemesterStarts
+	}
+
+	return &Service{
+		config:          config,
+		scheduleService: scheduleService,
+	}
+}
+
+// SetLocker включает распределенную блокировку: при нескольких экземплярах API
+// каждый цикл обслуживания выполняет только один из них
+func (s *Service) SetLocker(locker JobLocker) {
+	s.locker = locker
+}
+
+// SetColleges включает обслуживание данных всех активных колледжей в периодическом цикле
+func (s *Service) SetColleges(colleges CollegeLister) {
+	s.colleges = colleges
+}
+
+// SetStorage включает удаление устаревших объектов хранилища по правилам rules
+func (s *Service) SetStorage(store storage.Storage, rules []storage.LifecycleRule) {
+	s.storage = store
+	s.lifecycle = rules
+}
+
+// RunOnce выполняет все задачи обслуживания один раз для колледжа из контекста
+func (s *Service) RunOnce(ctx context.Context) {
+	log.Println("Запуск задач обслуживания")
+
+	// Архивация старых снапшотов расписания
+	if _, err := s.scheduleService.ArchiveOldSnapshots(ctx, s.config.SnapshotsKeep); err != nil {
+		log.Printf("Ошибка архивации снапшотов: %v", err)
+	}
+
+	// Перенос расписания прошедших семестров в архив
+	if s.config.ArchiveSchedule {
+		if _, err := s.scheduleService.ArchivePastSemesters(ctx, s.config.SemesterStarts); err != nil {
+			log.Printf("Ошибка архивации расписания: %v", err)
+		}
+	}
+
+	// Секции актуального расписания на ближайшие месяцы (общие для всех колледжей,
+	// повторный вызов ничего не создает)
+	if err := s.scheduleService.EnsureSchedulePartitions(ctx, s.config.PartitionMonthsAhead); err != nil {
+		log.Printf("Ошибка обслуживания секций расписания: %v", err)
+	}
+
+	// Пересборка кэша расписания на сегодня и удаление кэша за прошедшие дни
+	if err := s.scheduleService.RefreshTodayCache(ctx); err != nil {
+		log.Printf("Ошибка обновления кэша расписания: %v", err)
+	}
+}
+
+// Start запускает периодическое обслуживание до отмены контекста
+func (s *Service) Start(ctx context.Context) {
+	s.runCycle(ctx)
+
+	ticker := time.NewTicker(s.config.Interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+			s.runCycle(ctx)
+		case <-ctx.Done():
+			log.Println("Остановка задач обслуживания")
+			return
+		}
+	}
+}
+
+// runCycle выполняет цикл обслуживания, если его не выполняет другой экземпляр
+func (s *Service) runCycle(ctx context.Context) {
+	if s.locker == nil {
+		s.runAll(ctx)
+		return
+	}
+
+	_, err := s.locker.RunOnce(ctx, jobName, s.config.Interval/2, func(ctx context.Context) error {
+		s.runAll(ctx)
+		return nil
+	})
+	if err != nil {
+		log.Printf("Ошибка запуска задач обслуживания: %v", err)
+	}
+}
+
+// runAll выполняет задачи обслуживания для каждого активного колледжа
+// и удаляет устаревшие объекты хранилища (общего для всех колледжей)
+func (s *Service) runAll(ctx context.Context) {
+	defer s.cleanupStorage(ctx)
+
+	if s.colleges == nil {
+		s.RunOnce(ctx)
+		return
+	}
+
+	ids, err := s.colleges.ActiveCollegeIDs(ctx)
+	if err != nil {
+		log.Printf("Ошибка получения списка колледжей для обслуживания: %v", err)
+		return
+	}
+	for _, id := range ids {
+		if ctx.Err() != nil {
+			return
+		}
+		s.RunOnce(tenant.WithCollege(ctx, id))
+	}
+}
+
+// cleanupStorage удаляет объекты хранилища с истекшим сроком хранения
+func (s *Service) cleanupStorage(ctx context.Context) {
+	if s.storage == nil || len(s.lifecycle) == 0 || ctx.Err() != nil {
+		return
+	}
+
+	deleted, err := storage.ApplyLifecycle(ctx, s.storage, s.lifecycle, time.Now())
+	if err != nil {
+		log.Printf("Ошибка удаления устаревших объектов хранилища: %v", err)
+	}
+	if deleted > 0 {
+		log.Printf("Удалено устаревших объектов хранилища: %d", deleted)
+	}
+}
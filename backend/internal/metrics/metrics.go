@@ -0,0 +1,105 @@
+// Package metrics отдает метрики сервиса в текстовом формате Prometheus.
+// Значения метрик собираются функциями в момент запроса, поэтому компоненты
+// не хранят отдельных счетчиков для метрик.
+package metrics
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"net/http"
+	"sort"
+	"strings"
+	"sync"
+)
+
+// Type тип метрики Prometheus
+type Type string
+
+const (
+	Gauge   Type = "gauge"
+	Counter Type = "counter"
+)
+
+// Sample значение метрики с метками
+type Sample struct {
+	Labels map[string]string
+	Value  float64
+}
+
+// metric зарегистрированная метрика
+type metric struct {
+	name    string
+	help    string
+	typ     Type
+	collect func() []Sample
+}
+
+// Registry набор метрик сервиса
+type Registry struct {
+	mu      sync.RWMutex
+	metrics []metric
+}
+
+// NewRegistry создает пустой набор метрик
+func NewRegistry() *Registry {
+	return &Registry{}
+}
+
+// Register добавляет метрику name; collect вызывается при каждом запросе метрик
+func (r *Registry) Register(name, help string, typ Type, collect func() []Sample) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.metrics = append(r.metrics, metric{name: name, help: help, typ: typ, collect: collect})
+}
+
+// Write записывает все метрики в текстовом формате Prometheus
+func (r *Registry) Write(w io.Writer) error {
+	r.mu.RLock()
+	metrics := append([]metric(nil), r.metrics...)
+	r.mu.RUnlock()
+
+	buf := bufio.NewWriter(w)
+	for _, m := range metrics {
+		fmt.Fprintf(buf, "# HELP %s %s\n", m.name, escapeHelp(m.help))
+		fmt.Fprintf(buf, "# TYPE %s %s\n", m.name, m.typ)
+		for _, sample := range m.collect() {
+			fmt.Fprintf(buf, "%s%s %v\n", m.name, formatLabels(sample.Labels), sample.Value)
+		}
+	}
+	return buf.Flush()
+}
+
+// Handler возвращает HTTP обработчик, отдающий метрики
+func (r *Registry) Handler() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		w.Header().Set("Content-Type", "text/plain; version=0.0.4; charset=utf-8")
+		if err := r.Write(w); err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+		}
+	})
+}
+
+// formatLabels форматирует метки в виде {a="1",b="2"} с сортировкой по имени
+func formatLabels(labels map[string]string) string {
+	if len(labels) == 0 {
+		return ""
+	}
+
+	names := make([]string, 0, len(labels))
+	for name := range labels {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	parts := make([]string, 0, len(names))
+	for _, name := range names {
+		parts = append(parts, fmt.Sprintf("%s=%q", name, labels[name]))
+	}
+	return "{" + strings.Join(parts, ",") + "}"
+}
+
+// escapeHelp экранирует описание метрики
+func escapeHelp(help string) string {
+	return strings.NewReplacer(`\`, `\\`, "\n", `\n`).Replace(help)
+}
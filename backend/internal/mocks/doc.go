@@ -0,0 +1,4 @@
+// Package mocks содержит моки хранилищ для модульных тестов сервисов.
+// Файлы *_store.go генерируются cmd/mockgen (go generate ./...) и не
+// редактируются вручную.
+package mocks
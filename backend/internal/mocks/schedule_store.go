@@ -0,0 +1,660 @@
+// Code generated by mockgen from schedule/store.go. DO NOT EDIT.
+
+package mocks
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/Ultrahd-dev/student-schedule-app/backend/internal/schedule"
+	"github.com/google/uuid"
+)
+
+// ScheduleStore мок schedule.ScheduleStore.
+// Каждый метод вызывает поле <Метод>Func и считает вызовы;
+// вызов метода с незаданным полем вызывает панику.
+type ScheduleStore struct {
+	GetDataStatusFunc                   func(ctx context.Context) (*schedule.DataStatus, error)
+	GetCurrentScheduleEntryByIDFunc     func(ctx context.Context, id uuid.UUID) (*schedule.CurrentSchedule, error)
+	SetMeetingURLFunc                   func(ctx context.Context, entry *schedule.CurrentSchedule, meetingURL string) error
+	SaveSnapshotFunc                    func(ctx context.Context, snapshot *schedule.ScheduleSnapshot) (bool, error)
+	GetActiveSnapshotMetaFunc           func(ctx context.Context) (*schedule.ScheduleSnapshot, error)
+	FindSnapshotIDForDateFunc           func(ctx context.Context, date time.Time) (*uuid.UUID, error)
+	GetSnapshotMetaFunc                 func(ctx context.Context, id uuid.UUID) (*schedule.ScheduleSnapshot, error)
+	GetSnapshotDataFunc                 func(ctx context.Context, id uuid.UUID) ([]byte, error)
+	GetSnapshotGroupDataFunc            func(ctx context.Context, id uuid.UUID, groupName string) ([]byte, error)
+	ListSnapshotsFunc                   func(ctx context.Context, limit int) ([]schedule.ScheduleSnapshot, error)
+	ArchiveOldSnapshotsFunc             func(ctx context.Context, keep int) (int, error)
+	CreateChangeFunc                    func(ctx context.Context, change *schedule.ScheduleChange) error
+	GetCurrentScheduleForGroupFunc      func(ctx context.Context, groupName string, date time.Time) ([]schedule.CurrentSchedule, error)
+	GetCurrentScheduleForGroupRangeFunc func(ctx context.Context, groupName string, from time.Time, to time.Time) ([]schedule.CurrentSchedule, error)
+	GetCurrentScheduleForTeacherFunc    func(ctx context.Context, teacher string, from time.Time, to time.Time) ([]schedule.CurrentSchedule, error)
+	GetCurrentScheduleForTeachersFunc   func(ctx context.Context, teachers []string, from time.Time, to time.Time) ([]schedule.CurrentSchedule, error)
+	GetCurrentScheduleForClassroomsFunc func(ctx context.Context, classrooms []string, date time.Time) ([]schedule.CurrentSchedule, error)
+	HasTeacherLessonsWithGroupFunc      func(ctx context.Context, teachers []string, groupName string) (bool, error)
+	SearchCurrentScheduleFunc           func(ctx context.Context, search string, from time.Time, to time.Time, limit int) ([]schedule.SearchResult, error)
+	GetWorkloadStatsFunc                func(ctx context.Context, filter schedule.WorkloadFilter) ([]schedule.WorkloadStat, error)
+	GetTeacherWorkloadFunc              func(ctx context.Context, filter schedule.WorkloadFilter) ([]schedule.WorkloadStat, error)
+	GetChangesByGroupMonthFunc          func(ctx context.Context, from time.Time, to time.Time) ([]schedule.GroupMonthChanges, error)
+	GetMostCancelledSubjectsFunc        func(ctx context.Context, from time.Time, to time.Time, limit int) ([]schedule.SubjectCancellations, error)
+	GetBusiestReplacementDaysFunc       func(ctx context.Context, from time.Time, to time.Time, limit int) ([]schedule.DayReplacements, error)
+	GetDayCacheFunc                     func(ctx context.Context, groupName string, date time.Time) ([]schedule.CurrentSchedule, bool, error)
+	FillDayCacheFunc                    func(ctx context.Context, groupName string, date time.Time) error
+	RebuildDayCacheFunc                 func(ctx context.Context, date time.Time) (int, error)
+	PruneDayCacheFunc                   func(ctx context.Context, before time.Time) (int64, error)
+	EnsureSchedulePartitionsFunc        func(ctx context.Context, from time.Time, months int) (int, error)
+	ArchiveScheduleBeforeFunc           func(ctx context.Context, before time.Time) (int64, error)
+	GetArchivedScheduleForGroupFunc     func(ctx context.Context, groupName string, date time.Time) ([]schedule.CurrentSchedule, error)
+	ListSubjectMetadataFunc             func(ctx context.Context) ([]schedule.SubjectMetadata, error)
+	UpsertSubjectMetadataFunc           func(ctx context.Context, meta *schedule.SubjectMetadata) error
+	DeleteSubjectMetadataFunc           func(ctx context.Context, subject string) error
+	GetCurrentScheduleEntryFunc         func(ctx context.Context, groupName string, subgroup int, date time.Time, timeStart string) (*schedule.CurrentSchedule, error)
+	UpdateCurrentScheduleEntryFunc      func(ctx context.Context, entry *schedule.CurrentSchedule) error
+	CreateCurrentScheduleEntryFunc      func(ctx context.Context, entry *schedule.CurrentSchedule) error
+	GetScheduleForGroupAsOfFunc         func(ctx context.Context, groupName string, date time.Time, asOf time.Time) ([]schedule.CurrentSchedule, error)
+	GetChangesForGroupFunc              func(ctx context.Context, groupName string, date time.Time) ([]schedule.ScheduleChange, error)
+	GetChangesForSnapshotFunc           func(ctx context.Context, snapshotID uuid.UUID) ([]schedule.ScheduleChange, error)
+	GetOverlappingChangesFunc           func(ctx context.Context, from time.Time, to time.Time) ([]schedule.ScheduleChange, error)
+	GetPendingChangesFunc               func(ctx context.Context, limit int) ([]schedule.ScheduleChange, error)
+	GetChangeByIDFunc                   func(ctx context.Context, id uuid.UUID) (*schedule.ScheduleChange, error)
+	GetChangesByIDsFunc                 func(ctx context.Context, ids []uuid.UUID) ([]schedule.ScheduleChange, error)
+	GetChangesAwaitingModerationFunc    func(ctx context.Context) ([]schedule.ScheduleChange, error)
+	GetRecentChangesFunc                func(ctx context.Context, limit int) ([]schedule.ScheduleChange, error)
+	ModerateChangeFunc                  func(ctx context.Context, change *schedule.ScheduleChange) error
+	GetTrackedChangesFunc               func(ctx context.Context) ([]schedule.ScheduleChange, error)
+	MarkChangesSeenFunc                 func(ctx context.Context, ids []uuid.UUID) error
+	RevertChangeFunc                    func(ctx context.Context, change *schedule.ScheduleChange) error
+	GetEntriesBySourceFunc              func(ctx context.Context, sourceID uuid.UUID) ([]schedule.CurrentSchedule, error)
+	GetEntryVersionBeforeFunc           func(ctx context.Context, entryID uuid.UUID, sourceID uuid.UUID) (*schedule.CurrentSchedule, error)
+	SetChangeApplyStatusFunc            func(ctx context.Context, changeID uuid.UUID, status string, applyError string) error
+	MarkChangeApplyErrorFunc            func(ctx context.Context, changeID uuid.UUID, applyError string) error
+	UpdateChangeSlotFunc                func(ctx context.Context, change *schedule.ScheduleChange) error
+	SetChangeSupersedesFunc             func(ctx context.Context, changeID uuid.UUID, supersedesID uuid.UUID) error
+	FindOverlappingEntriesFunc          func(ctx context.Context, groupName string, date time.Time, timeStart string, timeEnd string) ([]schedule.CurrentSchedule, error)
+	CreateChangeRequestFunc             func(ctx context.Context, request *schedule.ChangeRequest) error
+	GetChangeRequestByIDFunc            func(ctx context.Context, id uuid.UUID) (*schedule.ChangeRequest, error)
+	GetChangeRequestsByTeacherFunc      func(ctx context.Context, teacherID uuid.UUID, limit int) ([]schedule.ChangeRequest, error)
+	GetPendingChangeRequestsFunc        func(ctx context.Context) ([]schedule.ChangeRequest, error)
+	ReviewChangeRequestFunc             func(ctx context.Context, request *schedule.ChangeRequest) error
+
+	mu    sync.Mutex
+	calls map[string]int
+}
+
+var _ schedule.ScheduleStore = (*ScheduleStore)(nil)
+
+// Calls возвращает число вызовов метода method
+func (m *ScheduleStore) Calls(method string) int {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return m.calls[method]
+}
+
+// record запоминает вызов метода method
+func (m *ScheduleStore) record(method string) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if m.calls == nil {
+		m.calls = make(map[string]int)
+	}
+	m.calls[method]++
+}
+
+// GetDataStatus вызывает GetDataStatusFunc
+func (m *ScheduleStore) GetDataStatus(ctx context.Context) (*schedule.DataStatus, error) {
+	m.record("GetDataStatus")
+	if m.GetDataStatusFunc == nil {
+		panic("mocks.ScheduleStore: не задан GetDataStatusFunc")
+	}
+	return m.GetDataStatusFunc(ctx)
+}
+
+// GetCurrentScheduleEntryByID вызывает GetCurrentScheduleEntryByIDFunc
+func (m *ScheduleStore) GetCurrentScheduleEntryByID(ctx context.Context, id uuid.UUID) (*schedule.CurrentSchedule, error) {
+	m.record("GetCurrentScheduleEntryByID")
+	if m.GetCurrentScheduleEntryByIDFunc == nil {
+		panic("mocks.ScheduleStore: не задан GetCurrentScheduleEntryByIDFunc")
+	}
+	return m.GetCurrentScheduleEntryByIDFunc(ctx, id)
+}
+
+// SetMeetingURL вызывает SetMeetingURLFunc
+func (m *ScheduleStore) SetMeetingURL(ctx context.Context, entry *schedule.CurrentSchedule, meetingURL string) error {
+	m.record("SetMeetingURL")
+	if m.SetMeetingURLFunc == nil {
+		panic("mocks.ScheduleStore: не задан SetMeetingURLFunc")
+	}
+	return m.SetMeetingURLFunc(ctx, entry, meetingURL)
+}
+
+// SaveSnapshot вызывает SaveSnapshotFunc
+func (m *ScheduleStore) SaveSnapshot(ctx context.Context, snapshot *schedule.ScheduleSnapshot) (bool, error) {
+	m.record("SaveSnapshot")
+	if m.SaveSnapshotFunc == nil {
+		panic("mocks.ScheduleStore: не задан SaveSnapshotFunc")
+	}
+	return m.SaveSnapshotFunc(ctx, snapshot)
+}
+
+// GetActiveSnapshotMeta вызывает GetActiveSnapshotMetaFunc
+func (m *ScheduleStore) GetActiveSnapshotMeta(ctx context.Context) (*schedule.ScheduleSnapshot, error) {
+	m.record("GetActiveSnapshotMeta")
+	if m.GetActiveSnapshotMetaFunc == nil {
+		panic("mocks.ScheduleStore: не задан GetActiveSnapshotMetaFunc")
+	}
+	return m.GetActiveSnapshotMetaFunc(ctx)
+}
+
+// FindSnapshotIDForDate вызывает FindSnapshotIDForDateFunc
+func (m *ScheduleStore) FindSnapshotIDForDate(ctx context.Context, date time.Time) (*uuid.UUID, error) {
+	m.record("FindSnapshotIDForDate")
+	if m.FindSnapshotIDForDateFunc == nil {
+		panic("mocks.ScheduleStore: не задан FindSnapshotIDForDateFunc")
+	}
+	return m.FindSnapshotIDForDateFunc(ctx, date)
+}
+
+// GetSnapshotMeta вызывает GetSnapshotMetaFunc
+func (m *ScheduleStore) GetSnapshotMeta(ctx context.Context, id uuid.UUID) (*schedule.ScheduleSnapshot, error) {
+	m.record("GetSnapshotMeta")
+	if m.GetSnapshotMetaFunc == nil {
+		panic("mocks.ScheduleStore: не задан GetSnapshotMetaFunc")
+	}
+	return m.GetSnapshotMetaFunc(ctx, id)
+}
+
+// GetSnapshotData вызывает GetSnapshotDataFunc
+func (m *ScheduleStore) GetSnapshotData(ctx context.Context, id uuid.UUID) ([]byte, error) {
+	m.record("GetSnapshotData")
+	if m.GetSnapshotDataFunc == nil {
+		panic("mocks.ScheduleStore: не задан GetSnapshotDataFunc")
+	}
+	return m.GetSnapshotDataFunc(ctx, id)
+}
+
+// GetSnapshotGroupData вызывает GetSnapshotGroupDataFunc
+func (m *ScheduleStore) GetSnapshotGroupData(ctx context.Context, id uuid.UUID, groupName string) ([]byte, error) {
+	m.record("GetSnapshotGroupData")
+	if m.GetSnapshotGroupDataFunc == nil {
+		panic("mocks.ScheduleStore: не задан GetSnapshotGroupDataFunc")
+	}
+	return m.GetSnapshotGroupDataFunc(ctx, id, groupName)
+}
+
+// ListSnapshots вызывает ListSnapshotsFunc
+func (m *ScheduleStore) ListSnapshots(ctx context.Context, limit int) ([]schedule.ScheduleSnapshot, error) {
+	m.record("ListSnapshots")
+	if m.ListSnapshotsFunc == nil {
+		panic("mocks.ScheduleStore: не задан ListSnapshotsFunc")
+	}
+	return m.ListSnapshotsFunc(ctx, limit)
+}
+
+// ArchiveOldSnapshots вызывает ArchiveOldSnapshotsFunc
+func (m *ScheduleStore) ArchiveOldSnapshots(ctx context.Context, keep int) (int, error) {
+	m.record("ArchiveOldSnapshots")
+	if m.ArchiveOldSnapshotsFunc == nil {
+		panic("mocks.ScheduleStore: не задан ArchiveOldSnapshotsFunc")
+	}
+	return m.ArchiveOldSnapshotsFunc(ctx, keep)
+}
+
+// CreateChange вызывает CreateChangeFunc
+func (m *ScheduleStore) CreateChange(ctx context.Context, change *schedule.ScheduleChange) error {
+	m.record("CreateChange")
+	if m.CreateChangeFunc == nil {
+		panic("mocks.ScheduleStore: не задан CreateChangeFunc")
+	}
+	return m.CreateChangeFunc(ctx, change)
+}
+
+// GetCurrentScheduleForGroup вызывает GetCurrentScheduleForGroupFunc
+func (m *ScheduleStore) GetCurrentScheduleForGroup(ctx context.Context, groupName string, date time.Time) ([]schedule.CurrentSchedule, error) {
+	m.record("GetCurrentScheduleForGroup")
+	if m.GetCurrentScheduleForGroupFunc == nil {
+		panic("mocks.ScheduleStore: не задан GetCurrentScheduleForGroupFunc")
+	}
+	return m.GetCurrentScheduleForGroupFunc(ctx, groupName, date)
+}
+
+// GetCurrentScheduleForGroupRange вызывает GetCurrentScheduleForGroupRangeFunc
+func (m *ScheduleStore) GetCurrentScheduleForGroupRange(ctx context.Context, groupName string, from time.Time, to time.Time) ([]schedule.CurrentSchedule, error) {
+	m.record("GetCurrentScheduleForGroupRange")
+	if m.GetCurrentScheduleForGroupRangeFunc == nil {
+		panic("mocks.ScheduleStore: не задан GetCurrentScheduleForGroupRangeFunc")
+	}
+	return m.GetCurrentScheduleForGroupRangeFunc(ctx, groupName, from, to)
+}
+
+// GetCurrentScheduleForTeacher вызывает GetCurrentScheduleForTeacherFunc
+func (m *ScheduleStore) GetCurrentScheduleForTeacher(ctx context.Context, teacher string, from time.Time, to time.Time) ([]schedule.CurrentSchedule, error) {
+	m.record("GetCurrentScheduleForTeacher")
+	if m.GetCurrentScheduleForTeacherFunc == nil {
+		panic("mocks.ScheduleStore: не задан GetCurrentScheduleForTeacherFunc")
+	}
+	return m.GetCurrentScheduleForTeacherFunc(ctx, teacher, from, to)
+}
+
+// GetCurrentScheduleForTeachers вызывает GetCurrentScheduleForTeachersFunc
+func (m *ScheduleStore) GetCurrentScheduleForTeachers(ctx context.Context, teachers []string, from time.Time, to time.Time) ([]schedule.CurrentSchedule, error) {
+	m.record("GetCurrentScheduleForTeachers")
+	if m.GetCurrentScheduleForTeachersFunc == nil {
+		panic("mocks.ScheduleStore: не задан GetCurrentScheduleForTeachersFunc")
+	}
+	return m.GetCurrentScheduleForTeachersFunc(ctx, teachers, from, to)
+}
+
+// GetCurrentScheduleForClassrooms вызывает GetCurrentScheduleForClassroomsFunc
+func (m *ScheduleStore) GetCurrentScheduleForClassrooms(ctx context.Context, classrooms []string, date time.Time) ([]schedule.CurrentSchedule, error) {
+	m.record("GetCurrentScheduleForClassrooms")
+	if m.GetCurrentScheduleForClassroomsFunc == nil {
+		panic("mocks.ScheduleStore: не задан GetCurrentScheduleForClassroomsFunc")
+	}
+	return m.GetCurrentScheduleForClassroomsFunc(ctx, classrooms, date)
+}
+
+// HasTeacherLessonsWithGroup вызывает HasTeacherLessonsWithGroupFunc
+func (m *ScheduleStore) HasTeacherLessonsWithGroup(ctx context.Context, teachers []string, groupName string) (bool, error) {
+	m.record("HasTeacherLessonsWithGroup")
+	if m.HasTeacherLessonsWithGroupFunc == nil {
+		panic("mocks.ScheduleStore: не задан HasTeacherLessonsWithGroupFunc")
+	}
+	return m.HasTeacherLessonsWithGroupFunc(ctx, teachers, groupName)
+}
+
+// SearchCurrentSchedule вызывает SearchCurrentScheduleFunc
+func (m *ScheduleStore) SearchCurrentSchedule(ctx context.Context, search string, from time.Time, to time.Time, limit int) ([]schedule.SearchResult, error) {
+	m.record("SearchCurrentSchedule")
+	if m.SearchCurrentScheduleFunc == nil {
+		panic("mocks.ScheduleStore: не задан SearchCurrentScheduleFunc")
+	}
+	return m.SearchCurrentScheduleFunc(ctx, search, from, to, limit)
+}
+
+// GetWorkloadStats вызывает GetWorkloadStatsFunc
+func (m *ScheduleStore) GetWorkloadStats(ctx context.Context, filter schedule.WorkloadFilter) ([]schedule.WorkloadStat, error) {
+	m.record("GetWorkloadStats")
+	if m.GetWorkloadStatsFunc == nil {
+		panic("mocks.ScheduleStore: не задан GetWorkloadStatsFunc")
+	}
+	return m.GetWorkloadStatsFunc(ctx, filter)
+}
+
+// GetTeacherWorkload вызывает GetTeacherWorkloadFunc
+func (m *ScheduleStore) GetTeacherWorkload(ctx context.Context, filter schedule.WorkloadFilter) ([]schedule.WorkloadStat, error) {
+	m.record("GetTeacherWorkload")
+	if m.GetTeacherWorkloadFunc == nil {
+		panic("mocks.ScheduleStore: не задан GetTeacherWorkloadFunc")
+	}
+	return m.GetTeacherWorkloadFunc(ctx, filter)
+}
+
+// GetChangesByGroupMonth вызывает GetChangesByGroupMonthFunc
+func (m *ScheduleStore) GetChangesByGroupMonth(ctx context.Context, from time.Time, to time.Time) ([]schedule.GroupMonthChanges, error) {
+	m.record("GetChangesByGroupMonth")
+	if m.GetChangesByGroupMonthFunc == nil {
+		panic("mocks.ScheduleStore: не задан GetChangesByGroupMonthFunc")
+	}
+	return m.GetChangesByGroupMonthFunc(ctx, from, to)
+}
+
+// GetMostCancelledSubjects вызывает GetMostCancelledSubjectsFunc
+func (m *ScheduleStore) GetMostCancelledSubjects(ctx context.Context, from time.Time, to time.Time, limit int) ([]schedule.SubjectCancellations, error) {
+	m.record("GetMostCancelledSubjects")
+	if m.GetMostCancelledSubjectsFunc == nil {
+		panic("mocks.ScheduleStore: не задан GetMostCancelledSubjectsFunc")
+	}
+	return m.GetMostCancelledSubjectsFunc(ctx, from, to, limit)
+}
+
+// GetBusiestReplacementDays вызывает GetBusiestReplacementDaysFunc
+func (m *ScheduleStore) GetBusiestReplacementDays(ctx context.Context, from time.Time, to time.Time, limit int) ([]schedule.DayReplacements, error) {
+	m.record("GetBusiestReplacementDays")
+	if m.GetBusiestReplacementDaysFunc == nil {
+		panic("mocks.ScheduleStore: не задан GetBusiestReplacementDaysFunc")
+	}
+	return m.GetBusiestReplacementDaysFunc(ctx, from, to, limit)
+}
+
+// GetDayCache вызывает GetDayCacheFunc
+func (m *ScheduleStore) GetDayCache(ctx context.Context, groupName string, date time.Time) ([]schedule.CurrentSchedule, bool, error) {
+	m.record("GetDayCache")
+	if m.GetDayCacheFunc == nil {
+		panic("mocks.ScheduleStore: не задан GetDayCacheFunc")
+	}
+	return m.GetDayCacheFunc(ctx, groupName, date)
+}
+
+// FillDayCache вызывает FillDayCacheFunc
+func (m *ScheduleStore) FillDayCache(ctx context.Context, groupName string, date time.Time) error {
+	m.record("FillDayCache")
+	if m.FillDayCacheFunc == nil {
+		panic("mocks.ScheduleStore: не задан FillDayCacheFunc")
+	}
+	return m.FillDayCacheFunc(ctx, groupName, date)
+}
+
+// RebuildDayCache вызывает RebuildDayCacheFunc
+func (m *ScheduleStore) RebuildDayCache(ctx context.Context, date time.Time) (int, error) {
+	m.record("RebuildDayCache")
+	if m.RebuildDayCacheFunc == nil {
+		panic("mocks.ScheduleStore: не задан RebuildDayCacheFunc")
+	}
+	return m.RebuildDayCacheFunc(ctx, date)
+}
+
+// PruneDayCache вызывает PruneDayCacheFunc
+func (m *ScheduleStore) PruneDayCache(ctx context.Context, before time.Time) (int64, error) {
+	m.record("PruneDayCache")
+	if m.PruneDayCacheFunc == nil {
+		panic("mocks.ScheduleStore: не задан PruneDayCacheFunc")
+	}
+	return m.PruneDayCacheFunc(ctx, before)
+}
+
+// EnsureSchedulePartitions вызывает EnsureSchedulePartitionsFunc
+func (m *ScheduleStore) EnsureSchedulePartitions(ctx context.Context, from time.Time, months int) (int, error) {
+	m.record("EnsureSchedulePartitions")
+	if m.EnsureSchedulePartitionsFunc == nil {
+		panic("mocks.ScheduleStore: не задан EnsureSchedulePartitionsFunc")
+	}
+	return m.EnsureSchedulePartitionsFunc(ctx, from, months)
+}
+
+// ArchiveScheduleBefore вызывает ArchiveScheduleBeforeFunc
+func (m *ScheduleStore) ArchiveScheduleBefore(ctx context.Context, before time.Time) (int64, error) {
+	m.record("ArchiveScheduleBefore")
+	if m.ArchiveScheduleBeforeFunc == nil {
+		panic("mocks.ScheduleStore: не задан ArchiveScheduleBeforeFunc")
+	}
+	return m.ArchiveScheduleBeforeFunc(ctx, before)
+}
+
+// GetArchivedScheduleForGroup вызывает GetArchivedScheduleForGroupFunc
+func (m *ScheduleStore) GetArchivedScheduleForGroup(ctx context.Context, groupName string, date time.Time) ([]schedule.CurrentSchedule, error) {
+	m.record("GetArchivedScheduleForGroup")
+	if m.GetArchivedScheduleForGroupFunc == nil {
+		panic("mocks.ScheduleStore: не задан GetArchivedScheduleForGroupFunc")
+	}
+	return m.GetArchivedScheduleForGroupFunc(ctx, groupName, date)
+}
+
+// ListSubjectMetadata вызывает ListSubjectMetadataFunc
+func (m *ScheduleStore) ListSubjectMetadata(ctx context.Context) ([]schedule.SubjectMetadata, error) {
+	m.record("ListSubjectMetadata")
+	if m.ListSubjectMetadataFunc == nil {
+		panic("mocks.ScheduleStore: не задан ListSubjectMetadataFunc")
+	}
+	return m.ListSubjectMetadataFunc(ctx)
+}
+
+// UpsertSubjectMetadata вызывает UpsertSubjectMetadataFunc
+func (m *ScheduleStore) UpsertSubjectMetadata(ctx context.Context, meta *schedule.SubjectMetadata) error {
+	m.record("UpsertSubjectMetadata")
+	if m.UpsertSubjectMetadataFunc == nil {
+		panic("mocks.ScheduleStore: не задан UpsertSubjectMetadataFunc")
+	}
+	return m.UpsertSubjectMetadataFunc(ctx, meta)
+}
+
+// DeleteSubjectMetadata вызывает DeleteSubjectMetadataFunc
+func (m *ScheduleStore) DeleteSubjectMetadata(ctx context.Context, subject string) error {
+	m.record("DeleteSubjectMetadata")
+	if m.DeleteSubjectMetadataFunc == nil {
+		panic("mocks.ScheduleStore: не задан DeleteSubjectMetadataFunc")
+	}
+	return m.DeleteSubjectMetadataFunc(ctx, subject)
+}
+
+// GetCurrentScheduleEntry вызывает GetCurrentScheduleEntryFunc
+func (m *ScheduleStore) GetCurrentScheduleEntry(ctx context.Context, groupName string, subgroup int, date time.Time, timeStart string) (*schedule.CurrentSchedule, error) {
+	m.record("GetCurrentScheduleEntry")
+	if m.GetCurrentScheduleEntryFunc == nil {
+		panic("mocks.ScheduleStore: не задан GetCurrentScheduleEntryFunc")
+	}
+	return m.GetCurrentScheduleEntryFunc(ctx, groupName, subgroup, date, timeStart)
+}
+
+// UpdateCurrentScheduleEntry вызывает UpdateCurrentScheduleEntryFunc
+func (m *ScheduleStore) UpdateCurrentScheduleEntry(ctx context.Context, entry *schedule.CurrentSchedule) error {
+	m.record("UpdateCurrentScheduleEntry")
+	if m.UpdateCurrentScheduleEntryFunc == nil {
+		panic("mocks.ScheduleStore: не задан UpdateCurrentScheduleEntryFunc")
+	}
+	return m.UpdateCurrentScheduleEntryFunc(ctx, entry)
+}
+
+// CreateCurrentScheduleEntry вызывает CreateCurrentScheduleEntryFunc
+func (m *ScheduleStore) CreateCurrentScheduleEntry(ctx context.Context, entry *schedule.CurrentSchedule) error {
+	m.record("CreateCurrentScheduleEntry")
+	if m.CreateCurrentScheduleEntryFunc == nil {
+		panic("mocks.ScheduleStore: не задан CreateCurrentScheduleEntryFunc")
+	}
+	return m.CreateCurrentScheduleEntryFunc(ctx, entry)
+}
+
+// GetScheduleForGroupAsOf вызывает GetScheduleForGroupAsOfFunc
+func (m *ScheduleStore) GetScheduleForGroupAsOf(ctx context.Context, groupName string, date time.Time, asOf time.Time) ([]schedule.CurrentSchedule, error) {
+	m.record("GetScheduleForGroupAsOf")
+	if m.GetScheduleForGroupAsOfFunc == nil {
+		panic("mocks.ScheduleStore: не задан GetScheduleForGroupAsOfFunc")
+	}
+	return m.GetScheduleForGroupAsOfFunc(ctx, groupName, date, asOf)
+}
+
+// GetChangesForGroup вызывает GetChangesForGroupFunc
+func (m *ScheduleStore) GetChangesForGroup(ctx context.Context, groupName string, date time.Time) ([]schedule.ScheduleChange, error) {
+	m.record("GetChangesForGroup")
+	if m.GetChangesForGroupFunc == nil {
+		panic("mocks.ScheduleStore: не задан GetChangesForGroupFunc")
+	}
+	return m.GetChangesForGroupFunc(ctx, groupName, date)
+}
+
+// GetChangesForSnapshot вызывает GetChangesForSnapshotFunc
+func (m *ScheduleStore) GetChangesForSnapshot(ctx context.Context, snapshotID uuid.UUID) ([]schedule.ScheduleChange, error) {
+	m.record("GetChangesForSnapshot")
+	if m.GetChangesForSnapshotFunc == nil {
+		panic("mocks.ScheduleStore: не задан GetChangesForSnapshotFunc")
+	}
+	return m.GetChangesForSnapshotFunc(ctx, snapshotID)
+}
+
+// GetOverlappingChanges вызывает GetOverlappingChangesFunc
+func (m *ScheduleStore) GetOverlappingChanges(ctx context.Context, from time.Time, to time.Time) ([]schedule.ScheduleChange, error) {
+	m.record("GetOverlappingChanges")
+	if m.GetOverlappingChangesFunc == nil {
+		panic("mocks.ScheduleStore: не задан GetOverlappingChangesFunc")
+	}
+	return m.GetOverlappingChangesFunc(ctx, from, to)
+}
+
+// GetPendingChanges вызывает GetPendingChangesFunc
+func (m *ScheduleStore) GetPendingChanges(ctx context.Context, limit int) ([]schedule.ScheduleChange, error) {
+	m.record("GetPendingChanges")
+	if m.GetPendingChangesFunc == nil {
+		panic("mocks.ScheduleStore: не задан GetPendingChangesFunc")
+	}
+	return m.GetPendingChangesFunc(ctx, limit)
+}
+
+// GetChangeByID вызывает GetChangeByIDFunc
+func (m *ScheduleStore) GetChangeByID(ctx context.Context, id uuid.UUID) (*schedule.ScheduleChange, error) {
+	m.record("GetChangeByID")
+	if m.GetChangeByIDFunc == nil {
+		panic("mocks.ScheduleStore: не задан GetChangeByIDFunc")
+	}
+	return m.GetChangeByIDFunc(ctx, id)
+}
+
+// GetChangesByIDs вызывает GetChangesByIDsFunc
+func (m *ScheduleStore) GetChangesByIDs(ctx context.Context, ids []uuid.UUID) ([]schedule.ScheduleChange, error) {
+	m.record("GetChangesByIDs")
+	if m.GetChangesByIDsFunc == nil {
+		panic("mocks.ScheduleStore: не задан GetChangesByIDsFunc")
+	}
+	return m.GetChangesByIDsFunc(ctx, ids)
+}
+
+// GetChangesAwaitingModeration вызывает GetChangesAwaitingModerationFunc
+func (m *ScheduleStore) GetChangesAwaitingModeration(ctx context.Context) ([]schedule.ScheduleChange, error) {
+	m.record("GetChangesAwaitingModeration")
+	if m.GetChangesAwaitingModerationFunc == nil {
+		panic("mocks.ScheduleStore: не задан GetChangesAwaitingModerationFunc")
+	}
+	return m.GetChangesAwaitingModerationFunc(ctx)
+}
+
+// GetRecentChanges вызывает GetRecentChangesFunc
+func (m *ScheduleStore) GetRecentChanges(ctx context.Context, limit int) ([]schedule.ScheduleChange, error) {
+	m.record("GetRecentChanges")
+	if m.GetRecentChangesFunc == nil {
+		panic("mocks.ScheduleStore: не задан GetRecentChangesFunc")
+	}
+	return m.GetRecentChangesFunc(ctx, limit)
+}
+
+// ModerateChange вызывает ModerateChangeFunc
+func (m *ScheduleStore) ModerateChange(ctx context.Context, change *schedule.ScheduleChange) error {
+	m.record("ModerateChange")
+	if m.ModerateChangeFunc == nil {
+		panic("mocks.ScheduleStore: не задан ModerateChangeFunc")
+	}
+	return m.ModerateChangeFunc(ctx, change)
+}
+
+// GetTrackedChanges вызывает GetTrackedChangesFunc
+func (m *ScheduleStore) GetTrackedChanges(ctx context.Context) ([]schedule.ScheduleChange, error) {
+	m.record("GetTrackedChanges")
+	if m.GetTrackedChangesFunc == nil {
+		panic("mocks.ScheduleStore: не задан GetTrackedChangesFunc")
+	}
+	return m.GetTrackedChangesFunc(ctx)
+}
+
+// MarkChangesSeen вызывает MarkChangesSeenFunc
+func (m *ScheduleStore) MarkChangesSeen(ctx context.Context, ids []uuid.UUID) error {
+	m.record("MarkChangesSeen")
+	if m.MarkChangesSeenFunc == nil {
+		panic("mocks.ScheduleStore: не задан MarkChangesSeenFunc")
+	}
+	return m.MarkChangesSeenFunc(ctx, ids)
+}
+
+// RevertChange вызывает RevertChangeFunc
+func (m *ScheduleStore) RevertChange(ctx context.Context, change *schedule.ScheduleChange) error {
+	m.record("RevertChange")
+	if m.RevertChangeFunc == nil {
+		panic("mocks.ScheduleStore: не задан RevertChangeFunc")
+	}
+	return m.RevertChangeFunc(ctx, change)
+}
+
+// GetEntriesBySource вызывает GetEntriesBySourceFunc
+func (m *ScheduleStore) GetEntriesBySource(ctx context.Context, sourceID uuid.UUID) ([]schedule.CurrentSchedule, error) {
+	m.record("GetEntriesBySource")
+	if m.GetEntriesBySourceFunc == nil {
+		panic("mocks.ScheduleStore: не задан GetEntriesBySourceFunc")
+	}
+	return m.GetEntriesBySourceFunc(ctx, sourceID)
+}
+
+// GetEntryVersionBefore вызывает GetEntryVersionBeforeFunc
+func (m *ScheduleStore) GetEntryVersionBefore(ctx context.Context, entryID uuid.UUID, sourceID uuid.UUID) (*schedule.CurrentSchedule, error) {
+	m.record("GetEntryVersionBefore")
+	if m.GetEntryVersionBeforeFunc == nil {
+		panic("mocks.ScheduleStore: не задан GetEntryVersionBeforeFunc")
+	}
+	return m.GetEntryVersionBeforeFunc(ctx, entryID, sourceID)
+}
+
+// SetChangeApplyStatus вызывает SetChangeApplyStatusFunc
+func (m *ScheduleStore) SetChangeApplyStatus(ctx context.Context, changeID uuid.UUID, status string, applyError string) error {
+	m.record("SetChangeApplyStatus")
+	if m.SetChangeApplyStatusFunc == nil {
+		panic("mocks.ScheduleStore: не задан SetChangeApplyStatusFunc")
+	}
+	return m.SetChangeApplyStatusFunc(ctx, changeID, status, applyError)
+}
+
+// MarkChangeApplyError вызывает MarkChangeApplyErrorFunc
+func (m *ScheduleStore) MarkChangeApplyError(ctx context.Context, changeID uuid.UUID, applyError string) error {
+	m.record("MarkChangeApplyError")
+	if m.MarkChangeApplyErrorFunc == nil {
+		panic("mocks.ScheduleStore: не задан MarkChangeApplyErrorFunc")
+	}
+	return m.MarkChangeApplyErrorFunc(ctx, changeID, applyError)
+}
+
+// UpdateChangeSlot вызывает UpdateChangeSlotFunc
+func (m *ScheduleStore) UpdateChangeSlot(ctx context.Context, change *schedule.ScheduleChange) error {
+	m.record("UpdateChangeSlot")
+	if m.UpdateChangeSlotFunc == nil {
+		panic("mocks.ScheduleStore: не задан UpdateChangeSlotFunc")
+	}
+	return m.UpdateChangeSlotFunc(ctx, change)
+}
+
+// SetChangeSupersedes вызывает SetChangeSupersedesFunc
+func (m *ScheduleStore) SetChangeSupersedes(ctx context.Context, changeID uuid.UUID, supersedesID uuid.UUID) error {
+	m.record("SetChangeSupersedes")
+	if m.SetChangeSupersedesFunc == nil {
+		panic("mocks.ScheduleStore: не задан SetChangeSupersedesFunc")
+	}
+	return m.SetChangeSupersedesFunc(ctx, changeID, supersedesID)
+}
+
+// FindOverlappingEntries вызывает FindOverlappingEntriesFunc
+func (m *ScheduleStore) FindOverlappingEntries(ctx context.Context, groupName string, date time.Time, timeStart string, timeEnd string) ([]schedule.CurrentSchedule, error) {
+	m.record("FindOverlappingEntries")
+	if m.FindOverlappingEntriesFunc == nil {
+		panic("mocks.ScheduleStore: не задан FindOverlappingEntriesFunc")
+	}
+	return m.FindOverlappingEntriesFunc(ctx, groupName, date, timeStart, timeEnd)
+}
+
+// CreateChangeRequest вызывает CreateChangeRequestFunc
+func (m *ScheduleStore) CreateChangeRequest(ctx context.Context, request *schedule.ChangeRequest) error {
+	m.record("CreateChangeRequest")
+	if m.CreateChangeRequestFunc == nil {
+		panic("mocks.ScheduleStore: не задан CreateChangeRequestFunc")
+	}
+	return m.CreateChangeRequestFunc(ctx, request)
+}
+
+// GetChangeRequestByID вызывает GetChangeRequestByIDFunc
+func (m *ScheduleStore) GetChangeRequestByID(ctx context.Context, id uuid.UUID) (*schedule.ChangeRequest, error) {
+	m.record("GetChangeRequestByID")
+	if m.GetChangeRequestByIDFunc == nil {
+		panic("mocks.ScheduleStore: не задан GetChangeRequestByIDFunc")
+	}
+	return m.GetChangeRequestByIDFunc(ctx, id)
+}
+
+// GetChangeRequestsByTeacher вызывает GetChangeRequestsByTeacherFunc
+func (m *ScheduleStore) GetChangeRequestsByTeacher(ctx context.Context, teacherID uuid.UUID, limit int) ([]schedule.ChangeRequest, error) {
+	m.record("GetChangeRequestsByTeacher")
+	if m.GetChangeRequestsByTeacherFunc == nil {
+		panic("mocks.ScheduleStore: не задан GetChangeRequestsByTeacherFunc")
+	}
+	return m.GetChangeRequestsByTeacherFunc(ctx, teacherID, limit)
+}
+
+// GetPendingChangeRequests вызывает GetPendingChangeRequestsFunc
+func (m *ScheduleStore) GetPendingChangeRequests(ctx context.Context) ([]schedule.ChangeRequest, error) {
+	m.record("GetPendingChangeRequests")
+	if m.GetPendingChangeRequestsFunc == nil {
+		panic("mocks.ScheduleStore: не задан GetPendingChangeRequestsFunc")
+	}
+	return m.GetPendingChangeRequestsFunc(ctx)
+}
+
+// ReviewChangeRequest вызывает ReviewChangeRequestFunc
+func (m *ScheduleStore) ReviewChangeRequest(ctx context.Context, request *schedule.ChangeRequest) error {
+	m.record("ReviewChangeRequest")
+	if m.ReviewChangeRequestFunc == nil {
+		panic("mocks.ScheduleStore: не задан ReviewChangeRequestFunc")
+	}
+	return m.ReviewChangeRequestFunc(ctx, request)
+}
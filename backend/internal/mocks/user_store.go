@@ -0,0 +1,550 @@
+// Code generated by mockgen from users/store.go. DO NOT EDIT.
+
+package mocks
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/Ultrahd-dev/student-schedule-app/backend/internal/users"
+	"github.com/google/uuid"
+)
+
+// UserStore мок users.UserStore.
+// Каждый метод вызывает поле <Метод>Func и считает вызовы;
+// вызов метода с незаданным полем вызывает панику.
+type UserStore struct {
+	CreateUserFunc                    func(ctx context.Context, user *users.User) error
+	GetUserByEmailFunc                func(ctx context.Context, email string) (*users.User, error)
+	GetUserByIDFunc                   func(ctx context.Context, id uuid.UUID) (*users.User, error)
+	GetPasswordHashFunc               func(ctx context.Context, userID uuid.UUID) (string, error)
+	CreateStudentFunc                 func(ctx context.Context, student *users.Student) error
+	CreateTeacherFunc                 func(ctx context.Context, teacher *users.Teacher) error
+	GetStudentByUserIDFunc            func(ctx context.Context, userID uuid.UUID) (*users.Student, error)
+	GetTeacherByUserIDFunc            func(ctx context.Context, userID uuid.UUID) (*users.Teacher, error)
+	GetStudentsByGroupFunc            func(ctx context.Context, groupName string) ([]uuid.UUID, error)
+	GetStudentsByGroupsFunc           func(ctx context.Context, groupNames []string) (map[string][]uuid.UUID, error)
+	GetGroupRosterFunc                func(ctx context.Context, groupName string) ([]users.Student, error)
+	UpdatePasswordFunc                func(ctx context.Context, userID uuid.UUID, passwordHash string) error
+	UpdateRoleFunc                    func(ctx context.Context, userID uuid.UUID, role users.Role) error
+	SetFormatPreferencesFunc          func(ctx context.Context, userID uuid.UUID, prefs users.FormatPreferences) error
+	GetFormatPreferencesFunc          func(ctx context.Context, userIDs []uuid.UUID) (map[uuid.UUID]users.FormatPreferences, error)
+	SetLoginAlertsFunc                func(ctx context.Context, userID uuid.UUID, enabled bool) error
+	ResetCalendarTokenFunc            func(ctx context.Context, userID uuid.UUID) (int, error)
+	SoftDeleteUserFunc                func(ctx context.Context, userID uuid.UUID, deletedBy uuid.UUID) (int, error)
+	GetDeletedUserFunc                func(ctx context.Context, userID uuid.UUID) (*users.User, error)
+	RestoreUserFunc                   func(ctx context.Context, userID uuid.UUID) error
+	IsEmailDeletedFunc                func(ctx context.Context, email string) (bool, error)
+	InvalidateUsersFunc               func(ctx context.Context, userIDs []uuid.UUID)
+	RevokeTokenFunc                   func(ctx context.Context, jti string, userID uuid.UUID, expiresAt time.Time) error
+	IsTokenRevokedFunc                func(ctx context.Context, jti string) (bool, error)
+	GetTeachersFunc                   func(ctx context.Context) ([]users.Teacher, error)
+	CreateTeacherNameClaimFunc        func(ctx context.Context, claim *users.TeacherNameClaim) error
+	GetTeacherNameClaimByIDFunc       func(ctx context.Context, id uuid.UUID) (*users.TeacherNameClaim, error)
+	GetTeacherNameClaimsByTeacherFunc func(ctx context.Context, teacherID uuid.UUID) ([]users.TeacherNameClaim, error)
+	GetPendingTeacherNameClaimsFunc   func(ctx context.Context) ([]users.TeacherNameClaim, error)
+	GetApprovedTeacherNameClaimFunc   func(ctx context.Context, scrapedName string) (*users.TeacherNameClaim, error)
+	ReviewTeacherNameClaimFunc        func(ctx context.Context, claim *users.TeacherNameClaim) error
+	GetApprovedTeacherNamesFunc       func(ctx context.Context, teacherID uuid.UUID) ([]string, error)
+	GetTeachersByScrapedNameFunc      func(ctx context.Context, scrapedName string) ([]uuid.UUID, error)
+	ImportTeacherDirectoryFunc        func(ctx context.Context, teachers []users.DirectoryTeacher, replace bool) (*users.DirectoryImportResult, error)
+	GetTeacherDirectoryFunc           func(ctx context.Context) ([]users.DirectoryTeacher, error)
+	CreateInvitationFunc              func(ctx context.Context, invitation *users.Invitation) error
+	GetInvitationByCodeFunc           func(ctx context.Context, code string) (*users.Invitation, error)
+	GetInvitationsFunc                func(ctx context.Context, activeOnly bool) ([]users.Invitation, error)
+	UseInvitationFunc                 func(ctx context.Context, id uuid.UUID) (bool, error)
+	ReleaseInvitationFunc             func(ctx context.Context, id uuid.UUID) error
+	SetUserInvitationFunc             func(ctx context.Context, userID uuid.UUID, invitationID uuid.UUID) error
+	RevokeInvitationFunc              func(ctx context.Context, id uuid.UUID) (*users.Invitation, error)
+	GetTwoFactorFunc                  func(ctx context.Context, userID uuid.UUID) (*users.TwoFactor, error)
+	SaveTwoFactorSecretFunc           func(ctx context.Context, userID uuid.UUID, secret string) error
+	EnableTwoFactorFunc               func(ctx context.Context, userID uuid.UUID, step int64, recoveryHashes []string) error
+	DisableTwoFactorFunc              func(ctx context.Context, userID uuid.UUID) error
+	UseTwoFactorStepFunc              func(ctx context.Context, userID uuid.UUID, step int64) (bool, error)
+	UseRecoveryCodeFunc               func(ctx context.Context, userID uuid.UUID, codeHash string) (bool, error)
+	CountRecoveryCodesFunc            func(ctx context.Context, userID uuid.UUID) (int, error)
+	AuthenticateUserFunc              func(ctx context.Context, email string, password string) (*users.User, error)
+	UpdateStudentSubgroupFunc         func(ctx context.Context, userID uuid.UUID, subgroup int) error
+
+	mu    sync.Mutex
+	calls map[string]int
+}
+
+var _ users.UserStore = (*UserStore)(nil)
+
+// Calls возвращает число вызовов метода method
+func (m *UserStore) Calls(method string) int {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return m.calls[method]
+}
+
+// record запоминает вызов метода method
+func (m *UserStore) record(method string) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if m.calls == nil {
+		m.calls = make(map[string]int)
+	}
+	m.calls[method]++
+}
+
+// CreateUser вызывает CreateUserFunc
+func (m *UserStore) CreateUser(ctx context.Context, user *users.User) error {
+	m.record("CreateUser")
+	if m.CreateUserFunc == nil {
+		panic("mocks.UserStore: не задан CreateUserFunc")
+	}
+	return m.CreateUserFunc(ctx, user)
+}
+
+// GetUserByEmail вызывает GetUserByEmailFunc
+func (m *UserStore) GetUserByEmail(ctx context.Context, email string) (*users.User, error) {
+	m.record("GetUserByEmail")
+	if m.GetUserByEmailFunc == nil {
+		panic("mocks.UserStore: не задан GetUserByEmailFunc")
+	}
+	return m.GetUserByEmailFunc(ctx, email)
+}
+
+// GetUserByID вызывает GetUserByIDFunc
+func (m *UserStore) GetUserByID(ctx context.Context, id uuid.UUID) (*users.User, error) {
+	m.record("GetUserByID")
+	if m.GetUserByIDFunc == nil {
+		panic("mocks.UserStore: не задан GetUserByIDFunc")
+	}
+	return m.GetUserByIDFunc(ctx, id)
+}
+
+// GetPasswordHash вызывает GetPasswordHashFunc
+func (m *UserStore) GetPasswordHash(ctx context.Context, userID uuid.UUID) (string, error) {
+	m.record("GetPasswordHash")
+	if m.GetPasswordHashFunc == nil {
+		panic("mocks.UserStore: не задан GetPasswordHashFunc")
+	}
+	return m.GetPasswordHashFunc(ctx, userID)
+}
+
+// CreateStudent вызывает CreateStudentFunc
+func (m *UserStore) CreateStudent(ctx context.Context, student *users.Student) error {
+	m.record("CreateStudent")
+	if m.CreateStudentFunc == nil {
+		panic("mocks.UserStore: не задан CreateStudentFunc")
+	}
+	return m.CreateStudentFunc(ctx, student)
+}
+
+// CreateTeacher вызывает CreateTeacherFunc
+func (m *UserStore) CreateTeacher(ctx context.Context, teacher *users.Teacher) error {
+	m.record("CreateTeacher")
+	if m.CreateTeacherFunc == nil {
+		panic("mocks.UserStore: не задан CreateTeacherFunc")
+	}
+	return m.CreateTeacherFunc(ctx, teacher)
+}
+
+// GetStudentByUserID вызывает GetStudentByUserIDFunc
+func (m *UserStore) GetStudentByUserID(ctx context.Context, userID uuid.UUID) (*users.Student, error) {
+	m.record("GetStudentByUserID")
+	if m.GetStudentByUserIDFunc == nil {
+		panic("mocks.UserStore: не задан GetStudentByUserIDFunc")
+	}
+	return m.GetStudentByUserIDFunc(ctx, userID)
+}
+
+// GetTeacherByUserID вызывает GetTeacherByUserIDFunc
+func (m *UserStore) GetTeacherByUserID(ctx context.Context, userID uuid.UUID) (*users.Teacher, error) {
+	m.record("GetTeacherByUserID")
+	if m.GetTeacherByUserIDFunc == nil {
+		panic("mocks.UserStore: не задан GetTeacherByUserIDFunc")
+	}
+	return m.GetTeacherByUserIDFunc(ctx, userID)
+}
+
+// GetStudentsByGroup вызывает GetStudentsByGroupFunc
+func (m *UserStore) GetStudentsByGroup(ctx context.Context, groupName string) ([]uuid.UUID, error) {
+	m.record("GetStudentsByGroup")
+	if m.GetStudentsByGroupFunc == nil {
+		panic("mocks.UserStore: не задан GetStudentsByGroupFunc")
+	}
+	return m.GetStudentsByGroupFunc(ctx, groupName)
+}
+
+// GetStudentsByGroups вызывает GetStudentsByGroupsFunc
+func (m *UserStore) GetStudentsByGroups(ctx context.Context, groupNames []string) (map[string][]uuid.UUID, error) {
+	m.record("GetStudentsByGroups")
+	if m.GetStudentsByGroupsFunc == nil {
+		panic("mocks.UserStore: не задан GetStudentsByGroupsFunc")
+	}
+	return m.GetStudentsByGroupsFunc(ctx, groupNames)
+}
+
+// GetGroupRoster вызывает GetGroupRosterFunc
+func (m *UserStore) GetGroupRoster(ctx context.Context, groupName string) ([]users.Student, error) {
+	m.record("GetGroupRoster")
+	if m.GetGroupRosterFunc == nil {
+		panic("mocks.UserStore: не задан GetGroupRosterFunc")
+	}
+	return m.GetGroupRosterFunc(ctx, groupName)
+}
+
+// UpdatePassword вызывает UpdatePasswordFunc
+func (m *UserStore) UpdatePassword(ctx context.Context, userID uuid.UUID, passwordHash string) error {
+	m.record("UpdatePassword")
+	if m.UpdatePasswordFunc == nil {
+		panic("mocks.UserStore: не задан UpdatePasswordFunc")
+	}
+	return m.UpdatePasswordFunc(ctx, userID, passwordHash)
+}
+
+// UpdateRole вызывает UpdateRoleFunc
+func (m *UserStore) UpdateRole(ctx context.Context, userID uuid.UUID, role users.Role) error {
+	m.record("UpdateRole")
+	if m.UpdateRoleFunc == nil {
+		panic("mocks.UserStore: не задан UpdateRoleFunc")
+	}
+	return m.UpdateRoleFunc(ctx, userID, role)
+}
+
+// SetFormatPreferences вызывает SetFormatPreferencesFunc
+func (m *UserStore) SetFormatPreferences(ctx context.Context, userID uuid.UUID, prefs users.FormatPreferences) error {
+	m.record("SetFormatPreferences")
+	if m.SetFormatPreferencesFunc == nil {
+		panic("mocks.UserStore: не задан SetFormatPreferencesFunc")
+	}
+	return m.SetFormatPreferencesFunc(ctx, userID, prefs)
+}
+
+// GetFormatPreferences вызывает GetFormatPreferencesFunc
+func (m *UserStore) GetFormatPreferences(ctx context.Context, userIDs []uuid.UUID) (map[uuid.UUID]users.FormatPreferences, error) {
+	m.record("GetFormatPreferences")
+	if m.GetFormatPreferencesFunc == nil {
+		panic("mocks.UserStore: не задан GetFormatPreferencesFunc")
+	}
+	return m.GetFormatPreferencesFunc(ctx, userIDs)
+}
+
+// SetLoginAlerts вызывает SetLoginAlertsFunc
+func (m *UserStore) SetLoginAlerts(ctx context.Context, userID uuid.UUID, enabled bool) error {
+	m.record("SetLoginAlerts")
+	if m.SetLoginAlertsFunc == nil {
+		panic("mocks.UserStore: не задан SetLoginAlertsFunc")
+	}
+	return m.SetLoginAlertsFunc(ctx, userID, enabled)
+}
+
+// ResetCalendarToken вызывает ResetCalendarTokenFunc
+func (m *UserStore) ResetCalendarToken(ctx context.Context, userID uuid.UUID) (int, error) {
+	m.record("ResetCalendarToken")
+	if m.ResetCalendarTokenFunc == nil {
+		panic("mocks.UserStore: не задан ResetCalendarTokenFunc")
+	}
+	return m.ResetCalendarTokenFunc(ctx, userID)
+}
+
+// SoftDeleteUser вызывает SoftDeleteUserFunc
+func (m *UserStore) SoftDeleteUser(ctx context.Context, userID uuid.UUID, deletedBy uuid.UUID) (int, error) {
+	m.record("SoftDeleteUser")
+	if m.SoftDeleteUserFunc == nil {
+		panic("mocks.UserStore: не задан SoftDeleteUserFunc")
+	}
+	return m.SoftDeleteUserFunc(ctx, userID, deletedBy)
+}
+
+// GetDeletedUser вызывает GetDeletedUserFunc
+func (m *UserStore) GetDeletedUser(ctx context.Context, userID uuid.UUID) (*users.User, error) {
+	m.record("GetDeletedUser")
+	if m.GetDeletedUserFunc == nil {
+		panic("mocks.UserStore: не задан GetDeletedUserFunc")
+	}
+	return m.GetDeletedUserFunc(ctx, userID)
+}
+
+// RestoreUser вызывает RestoreUserFunc
+func (m *UserStore) RestoreUser(ctx context.Context, userID uuid.UUID) error {
+	m.record("RestoreUser")
+	if m.RestoreUserFunc == nil {
+		panic("mocks.UserStore: не задан RestoreUserFunc")
+	}
+	return m.RestoreUserFunc(ctx, userID)
+}
+
+// IsEmailDeleted вызывает IsEmailDeletedFunc
+func (m *UserStore) IsEmailDeleted(ctx context.Context, email string) (bool, error) {
+	m.record("IsEmailDeleted")
+	if m.IsEmailDeletedFunc == nil {
+		panic("mocks.UserStore: не задан IsEmailDeletedFunc")
+	}
+	return m.IsEmailDeletedFunc(ctx, email)
+}
+
+// InvalidateUsers вызывает InvalidateUsersFunc
+func (m *UserStore) InvalidateUsers(ctx context.Context, userIDs []uuid.UUID) {
+	m.record("InvalidateUsers")
+	if m.InvalidateUsersFunc == nil {
+		panic("mocks.UserStore: не задан InvalidateUsersFunc")
+	}
+	m.InvalidateUsersFunc(ctx, userIDs)
+}
+
+// RevokeToken вызывает RevokeTokenFunc
+func (m *UserStore) RevokeToken(ctx context.Context, jti string, userID uuid.UUID, expiresAt time.Time) error {
+	m.record("RevokeToken")
+	if m.RevokeTokenFunc == nil {
+		panic("mocks.UserStore: не задан RevokeTokenFunc")
+	}
+	return m.RevokeTokenFunc(ctx, jti, userID, expiresAt)
+}
+
+// IsTokenRevoked вызывает IsTokenRevokedFunc
+func (m *UserStore) IsTokenRevoked(ctx context.Context, jti string) (bool, error) {
+	m.record("IsTokenRevoked")
+	if m.IsTokenRevokedFunc == nil {
+		panic("mocks.UserStore: не задан IsTokenRevokedFunc")
+	}
+	return m.IsTokenRevokedFunc(ctx, jti)
+}
+
+// GetTeachers вызывает GetTeachersFunc
+func (m *UserStore) GetTeachers(ctx context.Context) ([]users.Teacher, error) {
+	m.record("GetTeachers")
+	if m.GetTeachersFunc == nil {
+		panic("mocks.UserStore: не задан GetTeachersFunc")
+	}
+	return m.GetTeachersFunc(ctx)
+}
+
+// CreateTeacherNameClaim вызывает CreateTeacherNameClaimFunc
+func (m *UserStore) CreateTeacherNameClaim(ctx context.Context, claim *users.TeacherNameClaim) error {
+	m.record("CreateTeacherNameClaim")
+	if m.CreateTeacherNameClaimFunc == nil {
+		panic("mocks.UserStore: не задан CreateTeacherNameClaimFunc")
+	}
+	return m.CreateTeacherNameClaimFunc(ctx, claim)
+}
+
+// GetTeacherNameClaimByID вызывает GetTeacherNameClaimByIDFunc
+func (m *UserStore) GetTeacherNameClaimByID(ctx context.Context, id uuid.UUID) (*users.TeacherNameClaim, error) {
+	m.record("GetTeacherNameClaimByID")
+	if m.GetTeacherNameClaimByIDFunc == nil {
+		panic("mocks.UserStore: не задан GetTeacherNameClaimByIDFunc")
+	}
+	return m.GetTeacherNameClaimByIDFunc(ctx, id)
+}
+
+// GetTeacherNameClaimsByTeacher вызывает GetTeacherNameClaimsByTeacherFunc
+func (m *UserStore) GetTeacherNameClaimsByTeacher(ctx context.Context, teacherID uuid.UUID) ([]users.TeacherNameClaim, error) {
+	m.record("GetTeacherNameClaimsByTeacher")
+	if m.GetTeacherNameClaimsByTeacherFunc == nil {
+		panic("mocks.UserStore: не задан GetTeacherNameClaimsByTeacherFunc")
+	}
+	return m.GetTeacherNameClaimsByTeacherFunc(ctx, teacherID)
+}
+
+// GetPendingTeacherNameClaims вызывает GetPendingTeacherNameClaimsFunc
+func (m *UserStore) GetPendingTeacherNameClaims(ctx context.Context) ([]users.TeacherNameClaim, error) {
+	m.record("GetPendingTeacherNameClaims")
+	if m.GetPendingTeacherNameClaimsFunc == nil {
+		panic("mocks.UserStore: не задан GetPendingTeacherNameClaimsFunc")
+	}
+	return m.GetPendingTeacherNameClaimsFunc(ctx)
+}
+
+// GetApprovedTeacherNameClaim вызывает GetApprovedTeacherNameClaimFunc
+func (m *UserStore) GetApprovedTeacherNameClaim(ctx context.Context, scrapedName string) (*users.TeacherNameClaim, error) {
+	m.record("GetApprovedTeacherNameClaim")
+	if m.GetApprovedTeacherNameClaimFunc == nil {
+		panic("mocks.UserStore: не задан GetApprovedTeacherNameClaimFunc")
+	}
+	return m.GetApprovedTeacherNameClaimFunc(ctx, scrapedName)
+}
+
+// ReviewTeacherNameClaim вызывает ReviewTeacherNameClaimFunc
+func (m *UserStore) ReviewTeacherNameClaim(ctx context.Context, claim *users.TeacherNameClaim) error {
+	m.record("ReviewTeacherNameClaim")
+	if m.ReviewTeacherNameClaimFunc == nil {
+		panic("mocks.UserStore: не задан ReviewTeacherNameClaimFunc")
+	}
+	return m.ReviewTeacherNameClaimFunc(ctx, claim)
+}
+
+// GetApprovedTeacherNames вызывает GetApprovedTeacherNamesFunc
+func (m *UserStore) GetApprovedTeacherNames(ctx context.Context, teacherID uuid.UUID) ([]string, error) {
+	m.record("GetApprovedTeacherNames")
+	if m.GetApprovedTeacherNamesFunc == nil {
+		panic("mocks.UserStore: не задан GetApprovedTeacherNamesFunc")
+	}
+	return m.GetApprovedTeacherNamesFunc(ctx, teacherID)
+}
+
+// GetTeachersByScrapedName вызывает GetTeachersByScrapedNameFunc
+func (m *UserStore) GetTeachersByScrapedName(ctx context.Context, scrapedName string) ([]uuid.UUID, error) {
+	m.record("GetTeachersByScrapedName")
+	if m.GetTeachersByScrapedNameFunc == nil {
+		panic("mocks.UserStore: не задан GetTeachersByScrapedNameFunc")
+	}
+	return m.GetTeachersByScrapedNameFunc(ctx, scrapedName)
+}
+
+// ImportTeacherDirectory вызывает ImportTeacherDirectoryFunc
+func (m *UserStore) ImportTeacherDirectory(ctx context.Context, teachers []users.DirectoryTeacher, replace bool) (*users.DirectoryImportResult, error) {
+	m.record("ImportTeacherDirectory")
+	if m.ImportTeacherDirectoryFunc == nil {
+		panic("mocks.UserStore: не задан ImportTeacherDirectoryFunc")
+	}
+	return m.ImportTeacherDirectoryFunc(ctx, teachers, replace)
+}
+
+// GetTeacherDirectory вызывает GetTeacherDirectoryFunc
+func (m *UserStore) GetTeacherDirectory(ctx context.Context) ([]users.DirectoryTeacher, error) {
+	m.record("GetTeacherDirectory")
+	if m.GetTeacherDirectoryFunc == nil {
+		panic("mocks.UserStore: не задан GetTeacherDirectoryFunc")
+	}
+	return m.GetTeacherDirectoryFunc(ctx)
+}
+
+// CreateInvitation вызывает CreateInvitationFunc
+func (m *UserStore) CreateInvitation(ctx context.Context, invitation *users.Invitation) error {
+	m.record("CreateInvitation")
+	if m.CreateInvitationFunc == nil {
+		panic("mocks.UserStore: не задан CreateInvitationFunc")
+	}
+	return m.CreateInvitationFunc(ctx, invitation)
+}
+
+// GetInvitationByCode вызывает GetInvitationByCodeFunc
+func (m *UserStore) GetInvitationByCode(ctx context.Context, code string) (*users.Invitation, error) {
+	m.record("GetInvitationByCode")
+	if m.GetInvitationByCodeFunc == nil {
+		panic("mocks.UserStore: не задан GetInvitationByCodeFunc")
+	}
+	return m.GetInvitationByCodeFunc(ctx, code)
+}
+
+// GetInvitations вызывает GetInvitationsFunc
+func (m *UserStore) GetInvitations(ctx context.Context, activeOnly bool) ([]users.Invitation, error) {
+	m.record("GetInvitations")
+	if m.GetInvitationsFunc == nil {
+		panic("mocks.UserStore: не задан GetInvitationsFunc")
+	}
+	return m.GetInvitationsFunc(ctx, activeOnly)
+}
+
+// UseInvitation вызывает UseInvitationFunc
+func (m *UserStore) UseInvitation(ctx context.Context, id uuid.UUID) (bool, error) {
+	m.record("UseInvitation")
+	if m.UseInvitationFunc == nil {
+		panic("mocks.UserStore: не задан UseInvitationFunc")
+	}
+	return m.UseInvitationFunc(ctx, id)
+}
+
+// ReleaseInvitation вызывает ReleaseInvitationFunc
+func (m *UserStore) ReleaseInvitation(ctx context.Context, id uuid.UUID) error {
+	m.record("ReleaseInvitation")
+	if m.ReleaseInvitationFunc == nil {
+		panic("mocks.UserStore: не задан ReleaseInvitationFunc")
+	}
+	return m.ReleaseInvitationFunc(ctx, id)
+}
+
+// SetUserInvitation вызывает SetUserInvitationFunc
+func (m *UserStore) SetUserInvitation(ctx context.Context, userID uuid.UUID, invitationID uuid.UUID) error {
+	m.record("SetUserInvitation")
+	if m.SetUserInvitationFunc == nil {
+		panic("mocks.UserStore: не задан SetUserInvitationFunc")
+	}
+	return m.SetUserInvitationFunc(ctx, userID, invitationID)
+}
+
+// RevokeInvitation вызывает RevokeInvitationFunc
+func (m *UserStore) RevokeInvitation(ctx context.Context, id uuid.UUID) (*users.Invitation, error) {
+	m.record("RevokeInvitation")
+	if m.RevokeInvitationFunc == nil {
+		panic("mocks.UserStore: не задан RevokeInvitationFunc")
+	}
+	return m.RevokeInvitationFunc(ctx, id)
+}
+
+// GetTwoFactor вызывает GetTwoFactorFunc
+func (m *UserStore) GetTwoFactor(ctx context.Context, userID uuid.UUID) (*users.TwoFactor, error) {
+	m.record("GetTwoFactor")
+	if m.GetTwoFactorFunc == nil {
+		panic("mocks.UserStore: не задан GetTwoFactorFunc")
+	}
+	return m.GetTwoFactorFunc(ctx, userID)
+}
+
+// SaveTwoFactorSecret вызывает SaveTwoFactorSecretFunc
+func (m *UserStore) SaveTwoFactorSecret(ctx context.Context, userID uuid.UUID, secret string) error {
+	m.record("SaveTwoFactorSecret")
+	if m.SaveTwoFactorSecretFunc == nil {
+		panic("mocks.UserStore: не задан SaveTwoFactorSecretFunc")
+	}
+	return m.SaveTwoFactorSecretFunc(ctx, userID, secret)
+}
+
+// EnableTwoFactor вызывает EnableTwoFactorFunc
+func (m *UserStore) EnableTwoFactor(ctx context.Context, userID uuid.UUID, step int64, recoveryHashes []string) error {
+	m.record("EnableTwoFactor")
+	if m.EnableTwoFactorFunc == nil {
+		panic("mocks.UserStore: не задан EnableTwoFactorFunc")
+	}
+	return m.EnableTwoFactorFunc(ctx, userID, step, recoveryHashes)
+}
+
+// DisableTwoFactor вызывает DisableTwoFactorFunc
+func (m *UserStore) DisableTwoFactor(ctx context.Context, userID uuid.UUID) error {
+	m.record("DisableTwoFactor")
+	if m.DisableTwoFactorFunc == nil {
+		panic("mocks.UserStore: не задан DisableTwoFactorFunc")
+	}
+	return m.DisableTwoFactorFunc(ctx, userID)
+}
+
+// UseTwoFactorStep вызывает UseTwoFactorStepFunc
+func (m *UserStore) UseTwoFactorStep(ctx context.Context, userID uuid.UUID, step int64) (bool, error) {
+	m.record("UseTwoFactorStep")
+	if m.UseTwoFactorStepFunc == nil {
+		panic("mocks.UserStore: не задан UseTwoFactorStepFunc")
+	}
+	return m.UseTwoFactorStepFunc(ctx, userID, step)
+}
+
+// UseRecoveryCode вызывает UseRecoveryCodeFunc
+func (m *UserStore) UseRecoveryCode(ctx context.Context, userID uuid.UUID, codeHash string) (bool, error) {
+	m.record("UseRecoveryCode")
+	if m.UseRecoveryCodeFunc == nil {
+		panic("mocks.UserStore: не задан UseRecoveryCodeFunc")
+	}
+	return m.UseRecoveryCodeFunc(ctx, userID, codeHash)
+}
+
+// CountRecoveryCodes вызывает CountRecoveryCodesFunc
+func (m *UserStore) CountRecoveryCodes(ctx context.Context, userID uuid.UUID) (int, error) {
+	m.record("CountRecoveryCodes")
+	if m.CountRecoveryCodesFunc == nil {
+		panic("mocks.UserStore: не задан CountRecoveryCodesFunc")
+	}
+	return m.CountRecoveryCodesFunc(ctx, userID)
+}
+
+// AuthenticateUser вызывает AuthenticateUserFunc
+func (m *UserStore) AuthenticateUser(ctx context.Context, email string, password string) (*users.User, error) {
+	m.record("AuthenticateUser")
+	if m.AuthenticateUserFunc == nil {
+		panic("mocks.UserStore: не задан AuthenticateUserFunc")
+	}
+	return m.AuthenticateUserFunc(ctx, email, password)
+}
+
+// UpdateStudentSubgroup вызывает UpdateStudentSubgroupFunc
+func (m *UserStore) UpdateStudentSubgroup(ctx context.Context, userID uuid.UUID, subgroup int) error {
+	m.record("UpdateStudentSubgroup")
+	if m.UpdateStudentSubgroupFunc == nil {
+		panic("mocks.UserStore: не задан UpdateStudentSubgroupFunc")
+	}
+	return m.UpdateStudentSubgroupFunc(ctx, userID, subgroup)
+}
@@ -0,0 +1,361 @@
+// Package nats публикует сообщения в брокер NATS. Реализована только нужная
+// для публикации часть клиентского протокола: подключение с аутентификацией,
+// публикация с заголовками и ожидание подтверждения JetStream.
+package nats
+
+import (
+	"bufio"
+	"context"
+	"crypto/tls"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/url"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Config настройки подключения к брокеру
+type Config struct {
+	URL      string // nats://host:4222 или tls://host:4222
+	Username string
+	Password string
+	Token    string // Токен аутентификации (вместо логина и пароля)
+	Name     string // Имя клиента в мониторинге сервера
+	// JetStream ждет подтверждения сохранения сообщения в потоке; поток с темами
+	// публикуемых сообщений должен быть создан заранее. Без JetStream сообщение
+	// получают только подключенные в момент публикации подписчики.
+	JetStream bool
+	Timeout   time.Duration // Таймаут подключения и публикации
+}
+
+// Publisher публикует сообщения в NATS через одно соединение,
+// переподключаясь после ошибки
+type Publisher struct {
+	config Config
+	mu     sync.Mutex
+	conn   net.Conn
+	reader *bufio.Reader
+	inbox  string // Тема ответов JetStream этого соединения
+	seq    int
+}
+
+// NewPublisher создает издателя; подключение выполняется при первой публикации
+func NewPublisher(config Config) *Publisher {
+	if config.Timeout <= 0 {
+		config.Timeout = 5 * time.Second
+	}
+	return &Publisher{config: config}
+}
+
+// Publish публикует data в тему subject. messageID - заголовок Nats-Msg-Id,
+// по которому JetStream отбрасывает повторно опубликованные сообщения.
+func (p *Publisher) Publish(ctx context.Context, subject, messageID string, data []byte) error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if p.conn == nil {
+		if err := p.connect(ctx); err != nil {
+			return err
+		}
+	}
+	if err := p.publish(ctx, subject, messageID, data); err != nil {
+		var ackErr *AckError
+		if !errors.As(err, &ackErr) {
+			// Состояние соединения неизвестно: следующая публикация переподключится
+			p.closeConn()
+		}
+		return err
+	}
+	return nil
+}
+
+// Close закрывает соединение с брокером
+func (p *Publisher) Close() {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.closeConn()
+}
+
+func (p *Publisher) closeConn() {
+	if p.conn != nil {
+		p.conn.Close()
+		p.conn = nil
+	}
+}
+
+// AckError ошибка, которую вернул JetStream вместо подтверждения
+type AckError struct {
+	Code        int
+	Description string
+}
+
+func (e *AckError) Error() string {
+	return fmt.Sprintf("nats: JetStream отклонил сообщение (%d): %s", e.Code, e.Description)
+}
+
+// serverInfo начало сообщения INFO сервера
+type serverInfo struct {
+	TLSRequired bool `json:"tls_required"`
+	Headers     bool `json:"headers"`
+}
+
+// connectOptions сообщение CONNECT
+type connectOptions struct {
+	Verbose      bool   `json:"verbose"`
+	Pedantic     bool   `json:"pedantic"`
+	Name         string `json:"name,omitempty"`
+	Lang         string `json:"lang"`
+	Version      string `json:"version"`
+	Protocol     int    `json:"protocol"`
+	Headers      bool   `json:"headers"`
+	NoResponders bool   `json:"no_responders"`
+	User         string `json:"user,omitempty"`
+	Pass         string `json:"pass,omitempty"`
+	AuthToken    string `json:"auth_token,omitempty"`
+}
+
+// connect подключается к серверу, проходит аутентификацию и подписывается на ответы JetStream
+func (p *Publisher) connect(ctx context.Context) error {
+	parsed, err := url.Parse(p.config.URL)
+	if err != nil {
+		return fmt.Errorf("nats: некорректный адрес сервера: %w", err)
+	}
+	if parsed.Scheme != "nats" && parsed.Scheme != "tls" {
+		return fmt.Errorf("nats: неподдерживаемая схема %q, ожидается nats или tls", parsed.Scheme)
+	}
+	host := parsed.Host
+	if parsed.Port() == "" {
+		host = net.JoinHostPort(parsed.Hostname(), "4222")
+	}
+
+	dialCtx, cancel := context.WithTimeout(ctx, p.config.Timeout)
+	defer cancel()
+	var dialer net.Dialer
+	conn, err := dialer.DialContext(dialCtx, "tcp", host)
+	if err != nil {
+		return fmt.Errorf("nats: ошибка подключения к %s: %w", host, err)
+	}
+	conn.SetDeadline(time.Now().Add(p.config.Timeout))
+	reader := bufio.NewReader(conn)
+
+	// Сервер первым отправляет INFO
+	line, err := readLine(reader)
+	if err != nil || !strings.HasPrefix(line, "INFO ") {
+		conn.Close()
+		return fmt.Errorf("nats: сервер %s не прислал INFO: %v", host, err)
+	}
+	var info serverInfo
+	if err := json.Unmarshal([]byte(strings.TrimPrefix(line, "INFO ")), &info); err != nil {
+		conn.Close()
+		return fmt.Errorf("nats: некорректный INFO сервера: %w", err)
+	}
+	if !info.Headers {
+		conn.Close()
+		return fmt.Errorf("nats: сервер %s не поддерживает заголовки сообщений (нужен NATS 2.2+)", host)
+	}
+
+	if info.TLSRequired || parsed.Scheme == "tls" {
+		tlsConn := tls.Client(conn, &tls.Config{ServerName: parsed.Hostname()})
+		if err := tlsConn.HandshakeContext(dialCtx); err != nil {
+			conn.Close()
+			return fmt.Errorf("nats: ошибка TLS: %w", err)
+		}
+		conn = tlsConn
+		reader = bufio.NewReader(conn)
+	}
+
+	options := connectOptions{
+		Name:         p.config.Name,
+		Lang:         "go",
+		Version:      "1.0.0",
+		Protocol:     1,
+		Headers:      true,
+		NoResponders: true,
+		User:         p.config.Username,
+		Pass:         p.config.Password,
+		AuthToken:    p.config.Token,
+	}
+	if user := parsed.User; user != nil && options.User == "" {
+		options.User = user.Username()
+		options.Pass, _ = user.Password()
+	}
+	connect, err := json.Marshal(options)
+	if err != nil {
+		conn.Close()
+		return err
+	}
+
+	inbox := "_INBOX." + strings.ReplaceAll(uuid.NewString(), "-", "")
+	handshake := fmt.Sprintf("CONNECT %s\r\nSUB %s.* 1\r\nPING\r\n", connect, inbox)
+	if _, err := conn.Write([]byte(handshake)); err != nil {
+		conn.Close()
+		return fmt.Errorf("nats: ошибка отправки CONNECT: %w", err)
+	}
+
+	p.conn, p.reader, p.inbox = conn, reader, inbox
+	if err := p.waitPong(); err != nil {
+		p.closeConn()
+		return fmt.Errorf("nats: ошибка подключения к %s: %w", host, err)
+	}
+	return nil
+}
+
+// publish отправляет сообщение и ждет подтверждения JetStream или, без JetStream, ответа на PING
+func (p *Publisher) publish(ctx context.Context, subject, messageID string, data []byte) error {
+	deadline := time.Now().Add(p.config.Timeout)
+	if d, ok := ctx.Deadline(); ok && d.Before(deadline) {
+		deadline = d
+	}
+	p.conn.SetDeadline(deadline)
+
+	headers := "NATS/1.0\r\n"
+	if messageID != "" {
+		headers += "Nats-Msg-Id: " + messageID + "\r\n"
+	}
+	headers += "\r\n"
+
+	reply := ""
+	if p.config.JetStream {
+		p.seq++
+		reply = fmt.Sprintf(" %s.%d", p.inbox, p.seq)
+	}
+	message := fmt.Sprintf("HPUB %s%s %d %d\r\n%s", subject, reply, len(headers), len(headers)+len(data), headers)
+	buf := append([]byte(message), data...)
+	buf = append(buf, "\r\n"...)
+	if !p.config.JetStream {
+		buf = append(buf, "PING\r\n"...)
+	}
+	if _, err := p.conn.Write(buf); err != nil {
+		return fmt.Errorf("nats: ошибка публикации в %s: %w", subject, err)
+	}
+
+	if !p.config.JetStream {
+		if err := p.waitPong(); err != nil {
+			return fmt.Errorf("nats: ошибка публикации в %s: %w", subject, err)
+		}
+		return nil
+	}
+	return p.waitAck(subject, strings.TrimPrefix(reply, " "))
+}
+
+// waitPong читает ответы сервера до PONG
+func (p *Publisher) waitPong() error {
+	for {
+		line, err := p.readControl()
+		if err != nil {
+			return err
+		}
+		if line == "PONG" {
+			return nil
+		}
+		// Ответы на прошлые публикации, пришедшие после таймаута
+		if _, _, _, err := p.readMessage(line); err != nil {
+			return err
+		}
+	}
+}
+
+// pubAck ответ JetStream на публикацию
+type pubAck struct {
+	Stream string `json:"stream"`
+	Error  *struct {
+		Code        int    `json:"code"`
+		Description string `json:"description"`
+	} `json:"error"`
+}
+
+// waitAck читает ответы сервера до подтверждения JetStream, отправленного в тему reply
+func (p *Publisher) waitAck(subject, reply string) error {
+	for {
+		line, err := p.readControl()
+		if err != nil {
+			return err
+		}
+		msgSubject, headers, payload, err := p.readMessage(line)
+		if err != nil {
+			return err
+		}
+		if msgSubject != reply {
+			continue // Опоздавший ответ на прошлую публикацию
+		}
+
+		// Статус 503 в заголовках: ни один поток не принимает сообщения этой темы
+		if strings.HasPrefix(headers, "NATS/1.0 503") {
+			return &AckError{Code: 503, Description: fmt.Sprintf("нет потока JetStream для темы %s", subject)}
+		}
+		var ack pubAck
+		if err := json.Unmarshal(payload, &ack); err != nil {
+			return fmt.Errorf("nats: некорректное подтверждение JetStream: %w", err)
+		}
+		if ack.Error != nil {
+			return &AckError{Code: ack.Error.Code, Description: ack.Error.Description}
+		}
+		return nil
+	}
+}
+
+// readControl читает строку протокола, отвечая на PING сервера и пропуская +OK.
+// -ERR возвращается как ошибка.
+func (p *Publisher) readControl() (string, error) {
+	for {
+		line, err := readLine(p.reader)
+		if err != nil {
+			return "", err
+		}
+		switch {
+		case line == "PING":
+			if _, err := p.conn.Write([]byte("PONG\r\n")); err != nil {
+				return "", err
+			}
+		case line == "+OK", strings.HasPrefix(line, "INFO "):
+		case strings.HasPrefix(line, "-ERR"):
+			return "", fmt.Errorf("nats: ошибка сервера: %s", strings.Trim(strings.TrimPrefix(line, "-ERR "), "'"))
+		default:
+			return line, nil
+		}
+	}
+}
+
+// readMessage читает сообщение MSG или HMSG, заголовок которого - line.
+// Возвращает тему, заголовки и содержимое.
+func (p *Publisher) readMessage(line string) (string, string, []byte, error) {
+	fields := strings.Fields(line)
+	var headerSize, totalSize int
+	var err error
+	switch {
+	case len(fields) >= 4 && fields[0] == "MSG":
+		totalSize, err = strconv.Atoi(fields[len(fields)-1])
+	case len(fields) >= 5 && fields[0] == "HMSG":
+		headerSize, err = strconv.Atoi(fields[len(fields)-2])
+		if err == nil {
+			totalSize, err = strconv.Atoi(fields[len(fields)-1])
+		}
+	default:
+		return "", "", nil, fmt.Errorf("nats: неожиданный ответ сервера: %q", line)
+	}
+	if err != nil || headerSize > totalSize {
+		return "", "", nil, fmt.Errorf("nats: некорректный заголовок сообщения: %q", line)
+	}
+
+	body := make([]byte, totalSize+2) // С завершающим \r\n
+	if _, err := io.ReadFull(p.reader, body); err != nil {
+		return "", "", nil, err
+	}
+	return fields[1], string(body[:headerSize]), body[headerSize:totalSize], nil
+}
+
+// readLine читает строку протокола без завершающего \r\n
+func readLine(r *bufio.Reader) (string, error) {
+	line, err := r.ReadString('\n')
+	if err != nil {
+		return "", err
+	}
+	return strings.TrimRight(line, "\r\n"), nil
+}
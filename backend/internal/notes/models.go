@@ -0,0 +1,40 @@
+// Package notes реализует личные заметки студентов к занятиям: заметка привязана
+// к паре группы в конкретный день, видна только автору и возвращается вместе
+// с расписанием.
+package notes
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Note заметка к паре
+type Note struct {
+	ID        uuid.UUID `db:"id"`
+	UserID    uuid.UUID `db:"user_id"`
+	GroupName string    `db:"group_name"`
+	Date      time.Time `db:"date"`
+	TimeStart string    `db:"time_start"` // ЧЧ:ММ
+	Subject   string    `db:"subject"`    // Предмет пары на момент создания заметки
+	Text      string    `db:"text"`
+	CreatedAt time.Time `db:"created_at"`
+	UpdatedAt time.Time `db:"updated_at"`
+}
+
+// Key ключ пары, к которой относится заметка
+type Key struct {
+	GroupName string
+	Date      string // YYYY-MM-DD
+	TimeStart string // ЧЧ:ММ
+}
+
+// KeyOf возвращает ключ пары группы groupName в дату date со временем начала timeStart
+func KeyOf(groupName string, date time.Time, timeStart string) Key {
+	return Key{GroupName: groupName, Date: date.Format("2006-01-02"), TimeStart: timeStart}
+}
+
+// Key возвращает ключ пары заметки
+func (n *Note) Key() Key {
+	return KeyOf(n.GroupName, n.Date, n.TimeStart)
+}
@@ -0,0 +1,134 @@
+package notes
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	"github.com/Ultrahd-dev/student-schedule-app/backend/internal/clock"
+	"github.com/Ultrahd-dev/student-schedule-app/backend/internal/tenant"
+	"github.com/google/uuid"
+)
+
+// Repository предоставляет доступ к заметкам в базе данных
+type Repository struct {
+	db *sql.DB
+}
+
+// NewRepository создает новый репозиторий заметок
+func NewRepository(db *sql.DB) *Repository {
+	return &Repository{db: db}
+}
+
+// UpsertNote создает заметку к паре или заменяет текст существующей заметки
+// пользователя к этой паре в колледже из контекста
+func (r *Repository) UpsertNote(ctx context.Context, note *Note) error {
+	query := `
+		INSERT INTO lesson_notes (id, user_id, group_name, date, time_start, subject, text, college_id)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
+		ON CONFLICT (user_id, group_name, date, time_start) DO UPDATE
+		SET text = EXCLUDED.text,
+		    subject = CASE WHEN EXCLUDED.subject = '' THEN lesson_notes.subject ELSE EXCLUDED.subject END,
+		    updated_at = NOW()
+		RETURNING id, subject, created_at, updated_at`
+
+	err := r.db.QueryRowContext(ctx, query,
+		note.ID,
+		note.UserID,
+		note.GroupName,
+		note.Date,
+		note.TimeStart,
+		note.Subject,
+		note.Text,
+		tenant.CollegeID(ctx)).
+		Scan(&note.ID, &note.Subject, &note.CreatedAt, &note.UpdatedAt)
+	if err != nil {
+		return fmt.Errorf("failed to upsert lesson note: %w", err)
+	}
+
+	return nil
+}
+
+// HasNote проверяет, есть ли у пользователя заметка к паре
+func (r *Repository) HasNote(ctx context.Context, userID uuid.UUID, key Key) (bool, error) {
+	var exists bool
+	err := r.db.QueryRowContext(ctx, `
+		SELECT EXISTS (
+			SELECT 1 FROM lesson_notes
+			WHERE user_id = $1 AND group_name = $2 AND date = $3 AND time_start = $4
+		)`, userID, key.GroupName, key.Date, key.TimeStart).Scan(&exists)
+	if err != nil {
+		return false, fmt.Errorf("failed to check lesson note: %w", err)
+	}
+	return exists, nil
+}
+
+// CountUpcomingNotes возвращает количество заметок пользователя к парам начиная с даты from
+func (r *Repository) CountUpcomingNotes(ctx context.Context, userID uuid.UUID, from time.Time) (int, error) {
+	var count int
+	err := r.db.QueryRowContext(ctx,
+		`SELECT COUNT(*) FROM lesson_notes WHERE user_id = $1 AND date >= $2`, userID, from).Scan(&count)
+	if err != nil {
+		return 0, fmt.Errorf("failed to count lesson notes: %w", err)
+	}
+	return count, nil
+}
+
+// ListNotes получает заметки пользователя к парам за период [from, to] (включительно)
+// в колледже из контекста, упорядоченные по дате и времени
+func (r *Repository) ListNotes(ctx context.Context, userID uuid.UUID, from, to time.Time) ([]Note, error) {
+	query := `
+		SELECT id, user_id, group_name, date, time_start, subject, text, created_at, updated_at
+		FROM lesson_notes
+		WHERE user_id = $1 AND date BETWEEN $2 AND $3 AND college_id = $4
+		ORDER BY date, time_start`
+
+	rows, err := r.db.QueryContext(ctx, query, userID, from, to, tenant.CollegeID(ctx))
+	if err != nil {
+		return nil, fmt.Errorf("failed to get lesson notes: %w", err)
+	}
+	defer rows.Close()
+
+	var notes []Note
+	for rows.Next() {
+		var note Note
+		err := rows.Scan(
+			&note.ID,
+			&note.UserID,
+			&note.GroupName,
+			&note.Date,
+			&note.TimeStart,
+			&note.Subject,
+			&note.Text,
+			&note.CreatedAt,
+			&note.UpdatedAt,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan lesson note: %w", err)
+		}
+		note.TimeStart = clock.NormalizeClock(note.TimeStart)
+		notes = append(notes, note)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating rows: %w", err)
+	}
+
+	return notes, nil
+}
+
+// DeleteNote удаляет заметку пользователя
+func (r *Repository) DeleteNote(ctx context.Context, userID, noteID uuid.UUID) (bool, error) {
+	result, err := r.db.ExecContext(ctx,
+		`DELETE FROM lesson_notes WHERE id = $1 AND user_id = $2`, noteID, userID)
+	if err != nil {
+		return false, fmt.Errorf("failed to delete lesson note: %w", err)
+	}
+
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return false, fmt.Errorf("failed to get affected rows: %w", err)
+	}
+	return affected > 0, nil
+}
@@ -0,0 +1,127 @@
+package notes
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/Ultrahd-dev/student-schedule-app/backend/internal/apperr"
+	"github.com/Ultrahd-dev/student-schedule-app/backend/internal/clock"
+	"github.com/google/uuid"
+)
+
+// Ограничения заметок
+const (
+	MaxNoteLength    = 1000 // Максимальная длина заметки в символах
+	MaxUpcomingNotes = 200  // Максимум заметок пользователя к парам с сегодняшнего дня
+)
+
+// Ошибки заметок
+var (
+	ErrInvalidNote  = apperr.New(apperr.ErrValidation, "некорректная заметка")
+	ErrTooManyNotes = fmt.Errorf("слишком много заметок к предстоящим парам (не больше %d)", MaxUpcomingNotes)
+	ErrNoteNotFound = apperr.New(apperr.ErrNotFound, "заметка не найдена")
+)
+
+// Service управляет заметками к парам
+type Service struct {
+	repo *Repository
+	loc  *time.Location // Часовой пояс колледжа
+}
+
+// NewService создает новый сервис заметок
+func NewService(repo *Repository, loc *time.Location) *Service {
+	return &Service{repo: repo, loc: loc}
+}
+
+// SetNote создает заметку пользователя к паре или заменяет текст существующей
+func (s *Service) SetNote(ctx context.Context, note *Note) error {
+	note.GroupName = strings.TrimSpace(note.GroupName)
+	note.Subject = strings.TrimSpace(note.Subject)
+	note.Text = strings.TrimSpace(note.Text)
+	switch {
+	case note.GroupName == "":
+		return fmt.Errorf("%w: не указана группа", ErrInvalidNote)
+	case note.Date.IsZero():
+		return fmt.Errorf("%w: не указана дата пары", ErrInvalidNote)
+	case note.Text == "":
+		return fmt.Errorf("%w: пустой текст", ErrInvalidNote)
+	case utf8.RuneCountInString(note.Text) > MaxNoteLength:
+		return fmt.Errorf("%w: длина больше %d символов", ErrInvalidNote, MaxNoteLength)
+	}
+	start, err := clock.ParseClock(note.TimeStart)
+	if err != nil {
+		return fmt.Errorf("%w: %v", ErrInvalidNote, err)
+	}
+	note.TimeStart = clock.FormatClock(start)
+	note.Date = clock.DateOf(note.Date, s.loc)
+
+	// Ограничение проверяется только для новой заметки к предстоящей паре
+	today := clock.Today(s.loc)
+	if !note.Date.Before(today) {
+		exists, err := s.repo.HasNote(ctx, note.UserID, note.Key())
+		if err != nil {
+			return fmt.Errorf("ошибка проверки заметки: %w", err)
+		}
+		if !exists {
+			count, err := s.repo.CountUpcomingNotes(ctx, note.UserID, today)
+			if err != nil {
+				return fmt.Errorf("ошибка проверки количества заметок: %w", err)
+			}
+			if count >= MaxUpcomingNotes {
+				return ErrTooManyNotes
+			}
+		}
+	}
+
+	note.ID = uuid.New()
+	if err := s.repo.UpsertNote(ctx, note); err != nil {
+		return fmt.Errorf("ошибка сохранения заметки: %w", err)
+	}
+	return nil
+}
+
+// ListNotes возвращает заметки пользователя к парам за период [from, to] (включительно)
+func (s *Service) ListNotes(ctx context.Context, userID uuid.UUID, from, to time.Time) ([]Note, error) {
+	from, to = clock.DateOf(from, s.loc), clock.DateOf(to, s.loc)
+	if to.Before(from) {
+		return nil, fmt.Errorf("%w: дата окончания периода раньше даты начала", ErrInvalidNote)
+	}
+
+	notes, err := s.repo.ListNotes(ctx, userID, from, to)
+	if err != nil {
+		return nil, fmt.Errorf("ошибка получения заметок: %w", err)
+	}
+	for i := range notes {
+		notes[i].Date = clock.Anchor(notes[i].Date, s.loc)
+	}
+	return notes, nil
+}
+
+// NotesFor возвращает тексты заметок пользователя к парам за период [from, to] по ключу пары
+func (s *Service) NotesFor(ctx context.Context, userID uuid.UUID, from, to time.Time) (map[Key]string, error) {
+	notes, err := s.ListNotes(ctx, userID, from, to)
+	if err != nil {
+		return nil, err
+	}
+
+	byKey := make(map[Key]string, len(notes))
+	for _, note := range notes {
+		byKey[note.Key()] = note.Text
+	}
+	return byKey, nil
+}
+
+// DeleteNote удаляет заметку пользователя
+func (s *Service) DeleteNote(ctx context.Context, userID, noteID uuid.UUID) error {
+	deleted, err := s.repo.DeleteNote(ctx, userID, noteID)
+	if err != nil {
+		return fmt.Errorf("ошибка удаления заметки: %w", err)
+	}
+	if !deleted {
+		return ErrNoteNotFound
+	}
+	return nil
+}
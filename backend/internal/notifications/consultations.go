@@ -0,0 +1,53 @@
+package notifications
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"net/url"
+	"time"
+
+	"github.com/Ultrahd-dev/student-schedule-app/backend/internal/clock"
+	"github.com/Ultrahd-dev/student-schedule-app/backend/internal/consultations"
+	"github.com/google/uuid"
+)
+
+// SendConsultationReminder напоминает подписанным студентам о консультации преподавателя в дату date
+func (s *Service) SendConsultationReminder(ctx context.Context, consultation *consultations.Consultation, date time.Time, userIDs []uuid.UUID) error {
+	title := "Скоро консультация"
+	formats := s.recipientFormats(ctx, userIDs)
+	payload := Payload{
+		Type:           NotificationTypeSystem,
+		Date:           date.Format(payloadDateLayout),
+		Time:           clock.NormalizeClock(consultation.TimeStart),
+		ConsultationID: consultation.ID.String(),
+	}
+	payload.Route = route(RouteConsultations, url.Values{"id": {payload.ConsultationID}, "date": {payload.Date}})
+
+	for _, userID := range userIDs {
+		message := fmt.Sprintf("Консультация %s сегодня в %s", consultation.Teacher, formats[userID].FormatClock(consultation.TimeStart))
+		if consultation.Classroom != "" {
+			message += fmt.Sprintf(", ауд. %s", consultation.Classroom)
+		}
+		notification := &Notification{
+			ID:          uuid.New(),
+			UserID:      userID,
+			Title:       title,
+			Message:     message,
+			Type:        NotificationTypeSystem,
+			RelatedDate: date,
+			CreatedAt:   time.Now(),
+			Payload:     payload,
+		}
+		if err := s.notificationRepo.CreateNotification(ctx, notification); err != nil {
+			return fmt.Errorf("ошибка создания уведомления для пользователя %s: %w", userID, err)
+		}
+		if err := s.sendPushNotification(ctx, notification); err != nil {
+			log.Printf("Ошибка отправки push уведомления пользователю %s: %v", userID, err)
+		}
+	}
+
+	log.Printf("Напоминание о консультации %s в %s отправлено %d студентам",
+		consultation.Teacher, consultation.TimeStart, len(userIDs))
+	return nil
+}
@@ -0,0 +1,70 @@
+package notifications
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"net/url"
+	"time"
+
+	"github.com/Ultrahd-dev/student-schedule-app/backend/internal/clock"
+	"github.com/Ultrahd-dev/student-schedule-app/backend/internal/electives"
+	"github.com/Ultrahd-dev/student-schedule-app/backend/internal/schedule"
+	"github.com/google/uuid"
+)
+
+// UseElectives включает учет факультативов в уведомлениях об изменениях: студенту,
+// у которого новая пара пересекается с занятием факультатива, сообщается о пересечении
+func (s *Service) UseElectives(electiveService *electives.Service) {
+	s.electives = electiveService
+}
+
+// SendElectiveCancelledNotification сообщает записанным на курс студентам, что курс отменен
+func (s *Service) SendElectiveCancelledNotification(ctx context.Context, course *electives.Course, userIDs []uuid.UUID) error {
+	title := "Факультатив отменен"
+	message := fmt.Sprintf("Факультатив %s (%s) отменен, его занятия удалены из вашего расписания",
+		course.Title, course.Teacher)
+	today := clock.Today(s.loc)
+	payload := Payload{
+		Type:     NotificationTypeScheduleChange,
+		CourseID: course.ID.String(),
+		Route:    route(RouteElectives, url.Values{"course": {course.ID.String()}}),
+	}
+
+	for _, userID := range userIDs {
+		notification := &Notification{
+			ID:          uuid.New(),
+			UserID:      userID,
+			Title:       title,
+			Message:     message,
+			Type:        NotificationTypeScheduleChange,
+			RelatedDate: today,
+			CreatedAt:   time.Now(),
+			Payload:     payload,
+		}
+		if err := s.notificationRepo.CreateNotification(ctx, notification); err != nil {
+			return fmt.Errorf("ошибка создания уведомления для пользователя %s: %w", userID, err)
+		}
+		if err := s.sendPushNotification(ctx, notification); err != nil {
+			log.Printf("Ошибка отправки push уведомления пользователю %s: %v", userID, err)
+		}
+	}
+
+	log.Printf("Уведомление об отмене факультатива %q отправлено %d студентам", course.Title, len(userIDs))
+	return nil
+}
+
+// electiveConflicts возвращает названия факультативов студентов studentIDs, занятия
+// которых пересекаются с новой парой изменения. Для отмен пересечений нет.
+func (s *Service) electiveConflicts(ctx context.Context, change *schedule.ScheduleChange, studentIDs []uuid.UUID) map[uuid.UUID]string {
+	if s.electives == nil || change.ChangeType == "cancellation" || len(studentIDs) == 0 {
+		return nil
+	}
+
+	conflicts, err := s.electives.Conflicts(ctx, studentIDs, change.Date, change.TimeStart, change.TimeEnd)
+	if err != nil {
+		log.Printf("Ошибка проверки пересечений изменения %s с факультативами: %v", change.ID, err)
+		return nil
+	}
+	return conflicts
+}
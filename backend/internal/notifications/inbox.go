@@ -0,0 +1,68 @@
+package notifications
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/Ultrahd-dev/student-schedule-app/backend/internal/apperr"
+	"github.com/google/uuid"
+)
+
+// ErrNotificationNotFound уведомление не найдено среди уведомлений пользователя
+var ErrNotificationNotFound = apperr.New(apperr.ErrNotFound, "уведомление не найдено")
+
+// Размер страницы списка уведомлений
+const (
+	defaultPageSize = 50
+	maxPageSize     = 500
+)
+
+// ListFilter фильтр и страница списка уведомлений пользователя
+type ListFilter struct {
+	UnreadOnly bool
+	Limit      int // По умолчанию 50, не более 500
+	Offset     int
+}
+
+// ListNotifications возвращает страницу уведомлений пользователя, от новых к старым,
+// и общее число уведомлений по фильтру
+func (s *Service) ListNotifications(ctx context.Context, userID uuid.UUID, filter ListFilter) ([]Notification, int, error) {
+	if filter.Limit <= 0 {
+		filter.Limit = defaultPageSize
+	}
+	if filter.Limit > maxPageSize {
+		filter.Limit = maxPageSize
+	}
+	if filter.Offset < 0 {
+		filter.Offset = 0
+	}
+
+	notifications, total, err := s.notificationRepo.ListNotifications(ctx, userID, filter)
+	if err != nil {
+		return nil, 0, fmt.Errorf("ошибка получения уведомлений: %w", err)
+	}
+	return notifications, total, nil
+}
+
+// UnreadCount возвращает число непрочитанных уведомлений пользователя
+func (s *Service) UnreadCount(ctx context.Context, userID uuid.UUID) (int, error) {
+	return s.notificationRepo.CountUnread(ctx, userID)
+}
+
+// MarkAsRead помечает уведомление пользователя как прочитанное
+func (s *Service) MarkAsRead(ctx context.Context, userID, notificationID uuid.UUID) error {
+	updated, err := s.notificationRepo.MarkAsRead(ctx, userID, notificationID)
+	if err != nil {
+		return err
+	}
+	if !updated {
+		return ErrNotificationNotFound
+	}
+	return nil
+}
+
+// MarkAllAsRead помечает все уведомления пользователя как прочитанные
+// и возвращает число отмеченных
+func (s *Service) MarkAllAsRead(ctx context.Context, userID uuid.UUID) (int64, error) {
+	return s.notificationRepo.MarkAllAsRead(ctx, userID)
+}
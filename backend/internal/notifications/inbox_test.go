@@ -0,0 +1,54 @@
+package notifications_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/Ultrahd-dev/student-schedule-app/backend/internal/mocks"
+	"github.com/Ultrahd-dev/student-schedule-app/backend/internal/notifications"
+	"github.com/google/uuid"
+)
+
+func TestListNotificationsClampsPage(t *testing.T) {
+	var got []notifications.ListFilter
+	store := &mocks.NotificationStore{
+		ListNotificationsFunc: func(ctx context.Context, userID uuid.UUID, filter notifications.ListFilter) ([]notifications.Notification, int, error) {
+			got = append(got, filter)
+			return nil, 0, nil
+		},
+	}
+	service := notifications.NewService(nil, nil, store, time.UTC)
+
+	tests := []struct {
+		filter notifications.ListFilter
+		want   notifications.ListFilter
+	}{
+		{notifications.ListFilter{}, notifications.ListFilter{Limit: 50}},
+		{notifications.ListFilter{UnreadOnly: true, Limit: 10, Offset: 20}, notifications.ListFilter{UnreadOnly: true, Limit: 10, Offset: 20}},
+		{notifications.ListFilter{Limit: 10000, Offset: -5}, notifications.ListFilter{Limit: 500}},
+	}
+	for i, tt := range tests {
+		if _, _, err := service.ListNotifications(context.Background(), uuid.New(), tt.filter); err != nil {
+			t.Fatalf("ListNotifications: %v", err)
+		}
+		if got[i] != tt.want {
+			t.Errorf("фильтр %+v: в хранилище передан %+v, ожидался %+v", tt.filter, got[i], tt.want)
+		}
+	}
+}
+
+func TestMarkAsReadForeignNotification(t *testing.T) {
+	store := &mocks.NotificationStore{
+		MarkAsReadFunc: func(ctx context.Context, userID, notificationID uuid.UUID) (bool, error) {
+			return false, nil
+		},
+	}
+	service := notifications.NewService(nil, nil, store, time.UTC)
+
+	err := service.MarkAsRead(context.Background(), uuid.New(), uuid.New())
+	if !errors.Is(err, notifications.ErrNotificationNotFound) {
+		t.Errorf("ошибка %v, ожидалась %v", err, notifications.ErrNotificationNotFound)
+	}
+}
@@ -0,0 +1,59 @@
+package notifications
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/Ultrahd-dev/student-schedule-app/backend/internal/clock"
+	"github.com/Ultrahd-dev/student-schedule-app/backend/internal/schedule"
+	"github.com/google/uuid"
+)
+
+// SendMeetingLinkNotification сообщает студентам группы, что занятие пройдет онлайн
+// по ссылке entry.MeetingURL (или снова очно, если ссылка удалена)
+func (s *Service) SendMeetingLinkNotification(ctx context.Context, entry *schedule.CurrentSchedule) error {
+	studentIDs, err := s.userRepo.GetStudentsByGroup(ctx, entry.GroupName)
+	if err != nil {
+		return fmt.Errorf("ошибка получения студентов группы %s: %w", entry.GroupName, err)
+	}
+
+	date := clock.Anchor(entry.Date, s.loc)
+	formats := s.recipientFormats(ctx, studentIDs)
+	payload := schedulePayload(NotificationTypeScheduleChange, entry.GroupName, date, entry.TimeStart)
+	payload.EntryID = entry.ID.String()
+
+	for _, studentID := range studentIDs {
+		f := formats[studentID]
+		title := fmt.Sprintf("Онлайн-занятие %s", f.FormatDate(date))
+		message := fmt.Sprintf("Пара по %s (%s) в %s пройдет онлайн: %s",
+			entry.Subject, entry.Teacher, f.FormatClock(entry.TimeStart), entry.MeetingURL)
+		if entry.MeetingURL == "" {
+			title = fmt.Sprintf("Изменения в расписании на %s", f.FormatDate(date))
+			message = fmt.Sprintf("Пара по %s (%s) в %s пройдет очно. Кабинет: %s",
+				entry.Subject, entry.Teacher, f.FormatClock(entry.TimeStart), entry.Classroom)
+		}
+
+		notification := &Notification{
+			ID:           uuid.New(),
+			UserID:       studentID,
+			Title:        title,
+			Message:      message,
+			Type:         NotificationTypeScheduleChange,
+			RelatedGroup: entry.GroupName,
+			RelatedDate:  date,
+			CreatedAt:    time.Now(),
+			Payload:      payload,
+		}
+		if err := s.notificationRepo.CreateNotification(ctx, notification); err != nil {
+			return fmt.Errorf("ошибка создания уведомления для пользователя %s: %w", studentID, err)
+		}
+		if err := s.sendPushNotification(ctx, notification); err != nil {
+			log.Printf("Ошибка отправки push уведомления пользователю %s: %v", studentID, err)
+		}
+	}
+
+	log.Printf("Уведомление о ссылке на занятие отправлено группе %s (%d студентов)", entry.GroupName, len(studentIDs))
+	return nil
+}
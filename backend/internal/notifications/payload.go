@@ -0,0 +1,96 @@
+package notifications
+
+import (
+	"net/url"
+	"time"
+
+	"github.com/Ultrahd-dev/student-schedule-app/backend/internal/clock"
+	"github.com/google/uuid"
+)
+
+// Маршруты экранов приложения для перехода по уведомлению. Параметры маршрута
+// передаются строкой запроса: "schedule/day?date=2025-09-01&group=ИС-21&time=08:15".
+const (
+	RouteScheduleDay   = "schedule/day"      // День расписания группы (date, group, time - пара дня)
+	RouteConsultations = "consultations"     // Консультации (id - консультация, date - дата)
+	RouteElectives     = "electives"         // Факультативы (course - курс)
+	RouteSecurity      = "settings/security" // Безопасность учетной записи
+)
+
+// payloadDateLayout формат даты в данных уведомления
+const payloadDateLayout = "2006-01-02"
+
+// Payload структурированные данные уведомления, одинаковые для всех каналов
+// (уведомления в приложении, long polling, push): по ним клиент при нажатии
+// на уведомление открывает затронутый день или занятие
+type Payload struct {
+	Type           NotificationType `json:"type"`
+	Group          string           `json:"group,omitempty"`
+	Date           string           `json:"date,omitempty"` // ГГГГ-ММ-ДД в часовом поясе колледжа
+	Time           string           `json:"time,omitempty"` // Начало пары ЧЧ:ММ
+	ChangeID       string           `json:"change_id,omitempty"`
+	EntryID        string           `json:"entry_id,omitempty"`
+	ConsultationID string           `json:"consultation_id,omitempty"`
+	CourseID       string           `json:"course_id,omitempty"`
+	Route          string           `json:"route"` // Маршрут экрана с параметрами; пусто - список уведомлений
+}
+
+// PushData возвращает данные уведомления в виде пар ключ-значение для push-сервисов
+// (FCM data, APNs custom keys)
+func (p Payload) PushData(notificationID uuid.UUID) map[string]string {
+	data := map[string]string{
+		"notification_id": notificationID.String(),
+		"type":            string(p.Type),
+		"route":           p.Route,
+	}
+	for key, value := range map[string]string{
+		"group":           p.Group,
+		"date":            p.Date,
+		"time":            p.Time,
+		"change_id":       p.ChangeID,
+		"entry_id":        p.EntryID,
+		"consultation_id": p.ConsultationID,
+		"course_id":       p.CourseID,
+	} {
+		if value != "" {
+			data[key] = value
+		}
+	}
+	return data
+}
+
+// schedulePayload данные уведомления о дне расписания группы groupName
+// (пустая группа - день расписания пользователя); timeStart - пара дня (может быть пустым)
+func schedulePayload(notificationType NotificationType, groupName string, date time.Time, timeStart string) Payload {
+	p := Payload{Type: notificationType, Group: groupName, Time: clock.NormalizeClock(timeStart)}
+	if !date.IsZero() {
+		p.Date = date.Format(payloadDateLayout)
+	}
+	p.Route = route(RouteScheduleDay, url.Values{"group": {p.Group}, "date": {p.Date}, "time": {p.Time}})
+	return p
+}
+
+// route добавляет к маршруту непустые параметры
+func route(path string, params url.Values) string {
+	for key, values := range params {
+		if len(values) == 0 || values[0] == "" {
+			delete(params, key)
+		}
+	}
+	if len(params) == 0 {
+		return path
+	}
+	return path + "?" + params.Encode()
+}
+
+// withPayload заполняет данные уведомления, сохраненного до их появления:
+// уведомлению об изменении расписания - день группы, остальным - только тип
+func withPayload(notification *Notification) {
+	if notification.Payload.Type != "" {
+		return
+	}
+	notification.Payload = Payload{Type: notification.Type}
+	if notification.Type == NotificationTypeScheduleChange {
+		notification.Payload = schedulePayload(notification.Type, notification.RelatedGroup, notification.RelatedDate, "")
+	}
+}
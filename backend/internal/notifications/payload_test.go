@@ -0,0 +1,35 @@
+package notifications
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+func TestSchedulePayload(t *testing.T) {
+	date := time.Date(2025, time.September, 1, 0, 0, 0, 0, time.UTC)
+	p := schedulePayload(NotificationTypeScheduleChange, "ИС-21", date, "8:15")
+	if p.Date != "2025-09-01" || p.Time != "08:15" {
+		t.Errorf("дата и время: %q %q", p.Date, p.Time)
+	}
+	if want := "schedule/day?date=2025-09-01&group=%D0%98%D0%A1-21&time=08%3A15"; p.Route != want {
+		t.Errorf("маршрут %q, ожидался %q", p.Route, want)
+	}
+
+	id := uuid.New()
+	data := p.PushData(id)
+	if data["notification_id"] != id.String() || data["route"] != p.Route || data["group"] != "ИС-21" {
+		t.Errorf("данные push: %v", data)
+	}
+	if _, ok := data["change_id"]; ok {
+		t.Error("пустые поля не должны попадать в данные push")
+	}
+
+	// Уведомление без данных (создано до их появления) получает день группы
+	legacy := Notification{Type: NotificationTypeScheduleChange, RelatedGroup: "ИС-21", RelatedDate: date}
+	withPayload(&legacy)
+	if legacy.Payload.Route != "schedule/day?date=2025-09-01&group=%D0%98%D0%A1-21" {
+		t.Errorf("маршрут старого уведомления: %q", legacy.Payload.Route)
+	}
+}
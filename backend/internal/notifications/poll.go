@@ -0,0 +1,69 @@
+package notifications
+
+import (
+	"context"
+	"time"
+
+	"github.com/Ultrahd-dev/student-schedule-app/backend/internal/realtime"
+	"github.com/google/uuid"
+)
+
+// SetHub включает сигналы о новых уведомлениях для ожидающих запросов
+// WaitForNotifications. recheckInterval - период перепроверки базы во время
+// ожидания (уведомление могло быть создано другим экземпляром API).
+func (s *Service) SetHub(hub *realtime.Hub, recheckInterval time.Duration) {
+	s.hub = hub
+	s.pollRecheck = recheckInterval
+}
+
+// WaitForNotifications возвращает непрочитанные уведомления пользователя, созданные
+// после since, а если их нет - ждет появления нового уведомления (в том числе об
+// изменении расписания) не дольше timeout. Пустой результат означает, что за
+// время ожидания уведомлений не появилось.
+func (s *Service) WaitForNotifications(ctx context.Context, userID uuid.UUID, since time.Time, timeout time.Duration) ([]Notification, error) {
+	// Подписываемся до первой проверки, чтобы не пропустить уведомление между ними
+	var signal <-chan struct{}
+	if s.hub != nil {
+		var unsubscribe func()
+		signal, unsubscribe = s.hub.Subscribe(userID)
+		defer unsubscribe()
+	}
+
+	notifications, err := s.notificationRepo.GetNotificationsSince(ctx, userID, since)
+	if err != nil || len(notifications) > 0 || timeout <= 0 {
+		return notifications, err
+	}
+
+	deadline := time.NewTimer(timeout)
+	defer deadline.Stop()
+	var recheck <-chan time.Time
+	if s.hub == nil || s.pollRecheck > 0 {
+		interval := s.pollRecheck
+		if interval <= 0 {
+			interval = 5 * time.Second
+		}
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+		recheck = ticker.C
+	}
+
+	for {
+		select {
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		case <-deadline.C:
+			return nil, nil
+		case _, ok := <-signal:
+			if !ok {
+				// Сервер останавливается
+				return nil, nil
+			}
+		case <-recheck:
+		}
+
+		notifications, err := s.notificationRepo.GetNotificationsSince(ctx, userID, since)
+		if err != nil || len(notifications) > 0 {
+			return notifications, err
+		}
+	}
+}
@@ -0,0 +1,105 @@
+package notifications
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/Ultrahd-dev/student-schedule-app/backend/internal/clock"
+	"github.com/google/uuid"
+)
+
+// JobDeliverPush отправка push-уведомления, отложенного до конца тихих часов
+const JobDeliverPush = "notifications.deliver_push"
+
+// QuietHours тихие часы: push-уведомления, созданные в это время, отправляются
+// после их окончания. Уведомление в приложении (и long polling) появляется сразу.
+type QuietHours struct {
+	Start string // Начало ЧЧ:ММ в часовом поясе пользователя
+	End   string // Окончание ЧЧ:ММ (может быть на следующий день)
+	// UrgentLead отмена пары, которая начнется раньше окончания тихих часов
+	// плюс UrgentLead, отправляется сразу: утром ее можно не успеть прочитать
+	UrgentLead time.Duration
+}
+
+// quietWindow тихие часы в минутах от полуночи
+type quietWindow struct {
+	start, end int
+	urgentLead time.Duration
+}
+
+// SetQuietHours включает тихие часы. Отложенные уведомления отправляются
+// фоновыми задачами, поэтому без очереди (SetJobQueue) push уходит сразу.
+func (s *Service) SetQuietHours(quiet QuietHours) error {
+	start, err := clock.ParseClock(quiet.Start)
+	if err != nil {
+		return fmt.Errorf("начало тихих часов: %w", err)
+	}
+	end, err := clock.ParseClock(quiet.End)
+	if err != nil {
+		return fmt.Errorf("окончание тихих часов: %w", err)
+	}
+	if start == end {
+		return fmt.Errorf("начало и окончание тихих часов совпадают (%s)", quiet.Start)
+	}
+	s.quiet = &quietWindow{start: start, end: end, urgentLead: quiet.UrgentLead}
+	return nil
+}
+
+// quietUntil возвращает окончание тихих часов, если now попадает в них
+// (now - в часовом поясе пользователя)
+func (w quietWindow) quietUntil(now time.Time) (time.Time, bool) {
+	minutes := now.Hour()*60 + now.Minute()
+	today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, now.Location())
+	end := today.Add(time.Duration(w.end) * time.Minute)
+	switch {
+	case w.start < w.end:
+		// Окно внутри суток, например 13:00-15:00
+		if minutes >= w.start && minutes < w.end {
+			return end, true
+		}
+	case minutes >= w.start:
+		// Окно через полночь, вечерняя часть: окончание завтра
+		return today.AddDate(0, 0, 1).Add(time.Duration(w.end) * time.Minute), true
+	case minutes < w.end:
+		// Окно через полночь, утренняя часть
+		return end, true
+	}
+	return time.Time{}, false
+}
+
+// pushDeliverAt возвращает момент отправки push-уведомления, если его нужно
+// отложить до конца тихих часов получателя
+func (s *Service) pushDeliverAt(ctx context.Context, notification *Notification) (time.Time, bool) {
+	if s.quiet == nil || s.jobs == nil {
+		return time.Time{}, false
+	}
+	loc := s.loc
+	prefs, err := s.userRepo.GetFormatPreferences(ctx, []uuid.UUID{notification.UserID})
+	if err != nil {
+		log.Printf("Ошибка получения часового пояса пользователя %s: %v", notification.UserID, err)
+	} else if p, ok := prefs[notification.UserID]; ok {
+		loc = p.Location(s.loc)
+	}
+
+	deliverAt, quiet := s.quiet.quietUntil(time.Now().In(loc))
+	if !quiet {
+		return time.Time{}, false
+	}
+	// Срочная отмена: пара начнется вскоре после окончания тихих часов
+	if !notification.CancelledLessonStart.IsZero() && notification.CancelledLessonStart.Before(deliverAt.Add(s.quiet.urgentLead)) {
+		return time.Time{}, false
+	}
+	return deliverAt, true
+}
+
+// handleDeliverPush обрабатывает задачу JobDeliverPush
+func (s *Service) handleDeliverPush(ctx context.Context, payload json.RawMessage) error {
+	var notification Notification
+	if err := json.Unmarshal(payload, &notification); err != nil {
+		return fmt.Errorf("некорректные параметры задачи: %w", err)
+	}
+	return s.deliverPush(ctx, &notification)
+}
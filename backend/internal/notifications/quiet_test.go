@@ -0,0 +1,51 @@
+package notifications
+
+import (
+	"testing"
+	"time"
+)
+
+func TestQuietUntil(t *testing.T) {
+	loc := time.FixedZone("UTC+5", 5*60*60)
+	at := func(day, hour, minute int) time.Time {
+		return time.Date(2025, time.September, day, hour, minute, 0, 0, loc)
+	}
+	night := quietWindow{start: 22 * 60, end: 7 * 60}
+	lunch := quietWindow{start: 13 * 60, end: 14 * 60}
+
+	tests := []struct {
+		name   string
+		window quietWindow
+		now    time.Time
+		want   time.Time // Нулевое - не тихие часы
+	}{
+		{"вечер до начала", night, at(1, 21, 59), time.Time{}},
+		{"вечерняя часть", night, at(1, 23, 30), at(2, 7, 0)},
+		{"утренняя часть", night, at(2, 6, 15), at(2, 7, 0)},
+		{"окончание", night, at(2, 7, 0), time.Time{}},
+		{"окно внутри суток", lunch, at(1, 13, 20), at(1, 14, 0)},
+		{"после окна внутри суток", lunch, at(1, 14, 0), time.Time{}},
+	}
+	for _, tt := range tests {
+		got, ok := tt.window.quietUntil(tt.now)
+		if ok != !tt.want.IsZero() || !got.Equal(tt.want) {
+			t.Errorf("%s: получено %v (%v), ожидалось %v", tt.name, got, ok, tt.want)
+		}
+	}
+}
+
+func TestSetQuietHours(t *testing.T) {
+	s := &Service{}
+	if err := s.SetQuietHours(QuietHours{Start: "22:00", End: "22:00"}); err == nil {
+		t.Error("ожидалась ошибка для пустого окна")
+	}
+	if err := s.SetQuietHours(QuietHours{Start: "25:00", End: "07:00"}); err == nil {
+		t.Error("ожидалась ошибка для некорректного начала")
+	}
+	if err := s.SetQuietHours(QuietHours{Start: "22:00", End: "7:00", UrgentLead: 3 * time.Hour}); err != nil {
+		t.Fatalf("SetQuietHours: %v", err)
+	}
+	if s.quiet.start != 22*60 || s.quiet.end != 7*60 || s.quiet.urgentLead != 3*time.Hour {
+		t.Errorf("тихие часы: %+v", *s.quiet)
+	}
+}
@@ -4,15 +4,24 @@ package notifications
 import (
 	"context"
 	"database/sql"
+	"encoding/json"
 	"fmt"
 	"time"
 
+	"github.com/Ultrahd-dev/student-schedule-app/backend/internal/tenant"
 	"github.com/google/uuid"
+	"github.com/lib/pq"
 )
 
+// ReadRouter выбирает соединение для запросов на чтение (реплику или основную базу)
+type ReadRouter interface {
+	Reader() *sql.DB
+}
+
 // Repository предоставляет доступ к хранению уведомлений
 type Repository struct {
-	db *sql.DB
+	db      *sql.DB
+	replica ReadRouter // Реплики для чтения уведомлений (может быть nil)
 }
 
 // NewRepository создает новый репозиторий уведомлений
@@ -20,16 +29,34 @@ func NewRepository(db *sql.DB) *Repository {
 	return &Repository{db: db}
 }
 
-// CreateNotification создает новое уведомление
+// UseReplicas направляет чтение уведомлений на реплики
+func (r *Repository) UseReplicas(router ReadRouter) {
+	r.replica = router
+}
+
+// reader возвращает соединение для запросов, которые можно выполнять на реплике
+func (r *Repository) reader() *sql.DB {
+	if r.replica == nil {
+		return r.db
+	}
+	return r.replica.Reader()
+}
+
+// CreateNotification создает новое уведомление в колледже из контекста
 func (r *Repository) CreateNotification(ctx context.Context, notification *Notification) error {
 	query := `
 		INSERT INTO notifications 
-		(id, user_id, title, message, type, related_group, related_date, is_read)
-		VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
+		(id, user_id, title, message, type, related_group, related_date, is_read, college_id, payload)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10)
 		RETURNING created_at`
 
+	payload, err := json.Marshal(notification.Payload)
+	if err != nil {
+		return fmt.Errorf("failed to marshal notification payload: %w", err)
+	}
+
 	var createdAt time.Time
-	err := r.db.QueryRowContext(ctx, query,
+	err = r.db.QueryRowContext(ctx, query,
 		notification.ID,
 		notification.UserID,
 		notification.Title,
@@ -37,7 +64,9 @@ func (r *Repository) CreateNotification(ctx context.Context, notification *Notif
 		notification.Type,
 		notification.RelatedGroup,
 		notification.RelatedDate,
-		notification.IsRead).
+		notification.IsRead,
+		tenant.CollegeID(ctx),
+		payload).
 		Scan(&createdAt)
 
 	if err != nil {
@@ -51,20 +80,45 @@ func (r *Repository) CreateNotification(ctx context.Context, notification *Notif
 // GetUnreadNotifications получает непрочитанные уведомления для пользователя
 func (r *Repository) GetUnreadNotifications(ctx context.Context, userID uuid.UUID) ([]Notification, error) {
 	query := `
-		SELECT id, user_id, title, message, type, related_group, related_date, is_read, created_at
+		SELECT id, user_id, title, message, type, related_group, related_date, is_read, created_at, payload
 		FROM notifications
 		WHERE user_id = $1 AND is_read = false
 		ORDER BY created_at DESC`
 
-	rows, err := r.db.QueryContext(ctx, query, userID)
+	rows, err := r.reader().QueryContext(ctx, query, userID)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get unread notifications: %w", err)
 	}
 	defer rows.Close()
 
+	return scanNotifications(rows)
+}
+
+// GetNotificationsSince получает непрочитанные уведомления пользователя, созданные
+// после since, в порядке создания. Читает с основного сервера: запрос выполняется
+// сразу после создания уведомления, которого на реплике еще может не быть.
+func (r *Repository) GetNotificationsSince(ctx context.Context, userID uuid.UUID, since time.Time) ([]Notification, error) {
+	query := `
+		SELECT id, user_id, title, message, type, related_group, related_date, is_read, created_at, payload
+		FROM notifications
+		WHERE user_id = $1 AND is_read = false AND created_at > $2
+		ORDER BY created_at`
+
+	rows, err := r.db.QueryContext(ctx, query, userID, since)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get notifications: %w", err)
+	}
+	defer rows.Close()
+
+	return scanNotifications(rows)
+}
+
+// scanNotifications читает уведомления из результата запроса
+func scanNotifications(rows *sql.Rows) ([]Notification, error) {
 	var notifications []Notification
 	for rows.Next() {
 		var notification Notification
+		var payload []byte
 		err := rows.Scan(
 			&notification.ID,
 			&notification.UserID,
@@ -75,28 +129,215 @@ func (r *Repository) GetUnreadNotifications(ctx context.Context, userID uuid.UUI
 			&notification.RelatedDate,
 			&notification.IsRead,
 			&notification.CreatedAt,
+			&payload,
 		)
 		if err != nil {
 			return nil, fmt.Errorf("failed to scan notification: %w", err)
 		}
+		if err := json.Unmarshal(payload, &notification.Payload); err != nil {
+			return nil, fmt.Errorf("failed to unmarshal notification payload: %w", err)
+		}
+		withPayload(&notification)
 		notifications = append(notifications, notification)
 	}
 
-	if err = rows.Err(); err != nil {
+	if err := rows.Err(); err != nil {
 		return nil, fmt.Errorf("error iterating rows: %w", err)
 	}
 
 	return notifications, nil
 }
 
-// MarkAsRead помечает уведомление как прочитанное
-func (r *Repository) MarkAsRead(ctx context.Context, notificationID uuid.UUID) error {
-	query := `UPDATE notifications SET is_read = true WHERE id = $1`
+// ListNotifications получает страницу уведомлений пользователя по фильтру, от новых
+// к старым, и общее число уведомлений по фильтру
+func (r *Repository) ListNotifications(ctx context.Context, userID uuid.UUID, filter ListFilter) ([]Notification, int, error) {
+	where := "WHERE user_id = $1"
+	if filter.UnreadOnly {
+		where += " AND is_read = false"
+	}
+
+	var total int
+	if err := r.reader().QueryRowContext(ctx, "SELECT COUNT(*) FROM notifications "+where, userID).Scan(&total); err != nil {
+		return nil, 0, fmt.Errorf("failed to count notifications: %w", err)
+	}
+
+	query := `
+		SELECT id, user_id, title, message, type, related_group, related_date, is_read, created_at, payload
+		FROM notifications ` + where + `
+		ORDER BY created_at DESC, id
+		LIMIT $2 OFFSET $3`
+
+	rows, err := r.reader().QueryContext(ctx, query, userID, filter.Limit, filter.Offset)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to list notifications: %w", err)
+	}
+	defer rows.Close()
+
+	notifications, err := scanNotifications(rows)
+	if err != nil {
+		return nil, 0, err
+	}
+	return notifications, total, nil
+}
+
+// CountUnread возвращает число непрочитанных уведомлений пользователя
+func (r *Repository) CountUnread(ctx context.Context, userID uuid.UUID) (int, error) {
+	var count int
+	err := r.reader().QueryRowContext(ctx,
+		`SELECT COUNT(*) FROM notifications WHERE user_id = $1 AND is_read = false`, userID).Scan(&count)
+	if err != nil {
+		return 0, fmt.Errorf("failed to count unread notifications: %w", err)
+	}
+	return count, nil
+}
+
+// MarkAsRead помечает уведомление пользователя как прочитанное.
+// Возвращает false, если у пользователя нет такого уведомления.
+func (r *Repository) MarkAsRead(ctx context.Context, userID, notificationID uuid.UUID) (bool, error) {
+	query := `UPDATE notifications SET is_read = true WHERE id = $1 AND user_id = $2`
+
+	result, err := r.db.ExecContext(ctx, query, notificationID, userID)
+	if err != nil {
+		return false, fmt.Errorf("failed to mark notification as read: %w", err)
+	}
+
+	updated, err := result.RowsAffected()
+	if err != nil {
+		return false, fmt.Errorf("failed to mark notification as read: %w", err)
+	}
+	return updated > 0, nil
+}
+
+// MarkAllAsRead помечает все уведомления пользователя как прочитанные
+// и возвращает число отмеченных
+func (r *Repository) MarkAllAsRead(ctx context.Context, userID uuid.UUID) (int64, error) {
+	result, err := r.db.ExecContext(ctx,
+		`UPDATE notifications SET is_read = true WHERE user_id = $1 AND is_read = false`, userID)
+	if err != nil {
+		return 0, fmt.Errorf("failed to mark notifications as read: %w", err)
+	}
+	return result.RowsAffected()
+}
 
-	_, err := r.db.ExecContext(ctx, query, notificationID)
+// UpsertGroupWebhook создает или заменяет вебхук группы в колледже из контекста
+func (r *Repository) UpsertGroupWebhook(ctx context.Context, webhook *GroupWebhook) error {
+	query := `
+		INSERT INTO group_webhooks (id, group_name, provider, url, created_by, college_id)
+		VALUES ($1, $2, $3, $4, $5, $6)
+		ON CONFLICT (college_id, group_name) DO UPDATE
+		SET provider = EXCLUDED.provider, url = EXCLUDED.url, created_by = EXCLUDED.created_by,
+		    updated_at = NOW(), last_delivered_at = NULL, last_error = ''
+		RETURNING id, created_at, updated_at`
+
+	err := r.db.QueryRowContext(ctx, query,
+		webhook.ID,
+		webhook.GroupName,
+		webhook.Provider,
+		webhook.URL,
+		webhook.CreatedBy,
+		tenant.CollegeID(ctx)).
+		Scan(&webhook.ID, &webhook.CreatedAt, &webhook.UpdatedAt)
 	if err != nil {
-		return fmt.Errorf("failed to mark notification as read: %w", err)
+		return fmt.Errorf("failed to upsert group webhook: %w", err)
 	}
 
+	webhook.LastDeliveredAt = nil
+	webhook.LastError = ""
+	return nil
+}
+
+// ListGroupWebhooks возвращает вебхуки групп колледжа из контекста, упорядоченные по группе
+func (r *Repository) ListGroupWebhooks(ctx context.Context) ([]GroupWebhook, error) {
+	query := `
+		SELECT id, group_name, provider, url, created_by, created_at, updated_at, last_delivered_at, last_error
+		FROM group_webhooks
+		WHERE college_id = $1
+		ORDER BY group_name`
+
+	return r.queryGroupWebhooks(ctx, query, tenant.CollegeID(ctx))
+}
+
+// GetGroupWebhooks возвращает вебхуки групп groupNames колледжа из контекста по имени группы
+func (r *Repository) GetGroupWebhooks(ctx context.Context, groupNames []string) (map[string]GroupWebhook, error) {
+	query := `
+		SELECT id, group_name, provider, url, created_by, created_at, updated_at, last_delivered_at, last_error
+		FROM group_webhooks
+		WHERE group_name = ANY($1) AND college_id = $2`
+
+	webhooks, err := r.queryGroupWebhooks(ctx, query, pq.Array(groupNames), tenant.CollegeID(ctx))
+	if err != nil {
+		return nil, err
+	}
+
+	byGroup := make(map[string]GroupWebhook, len(webhooks))
+	for _, webhook := range webhooks {
+		byGroup[webhook.GroupName] = webhook
+	}
+	return byGroup, nil
+}
+
+// queryGroupWebhooks выполняет запрос вебхуков групп
+func (r *Repository) queryGroupWebhooks(ctx context.Context, query string, args ...interface{}) ([]GroupWebhook, error) {
+	rows, err := r.reader().QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get group webhooks: %w", err)
+	}
+	defer rows.Close()
+
+	var webhooks []GroupWebhook
+	for rows.Next() {
+		var webhook GroupWebhook
+		err := rows.Scan(
+			&webhook.ID,
+			&webhook.GroupName,
+			&webhook.Provider,
+			&webhook.URL,
+			&webhook.CreatedBy,
+			&webhook.CreatedAt,
+			&webhook.UpdatedAt,
+			&webhook.LastDeliveredAt,
+			&webhook.LastError,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan group webhook: %w", err)
+		}
+		webhooks = append(webhooks, webhook)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating rows: %w", err)
+	}
+
+	return webhooks, nil
+}
+
+// DeleteGroupWebhook удаляет вебхук группы в колледже из контекста
+func (r *Repository) DeleteGroupWebhook(ctx context.Context, groupName string) (bool, error) {
+	result, err := r.db.ExecContext(ctx,
+		`DELETE FROM group_webhooks WHERE group_name = $1 AND college_id = $2`, groupName, tenant.CollegeID(ctx))
+	if err != nil {
+		return false, fmt.Errorf("failed to delete group webhook: %w", err)
+	}
+
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return false, fmt.Errorf("failed to get affected rows: %w", err)
+	}
+	return affected > 0, nil
+}
+
+// MarkGroupWebhookDelivery сохраняет результат отправки в вебхук: время успешной
+// отправки или текст ошибки
+func (r *Repository) MarkGroupWebhookDelivery(ctx context.Context, id uuid.UUID, deliveryErr error) error {
+	query := `UPDATE group_webhooks SET last_delivered_at = NOW(), last_error = '' WHERE id = $1`
+	args := []interface{}{id}
+	if deliveryErr != nil {
+		query = `UPDATE group_webhooks SET last_error = $2 WHERE id = $1`
+		args = append(args, deliveryErr.Error())
+	}
+
+	if _, err := r.db.ExecContext(ctx, query, args...); err != nil {
+		return fmt.Errorf("failed to mark group webhook delivery: %w", err)
+	}
 	return nil
 }
@@ -0,0 +1,104 @@
+package notifications
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/Ultrahd-dev/student-schedule-app/backend/internal/clock"
+	"github.com/Ultrahd-dev/student-schedule-app/backend/internal/jobs"
+	"github.com/Ultrahd-dev/student-schedule-app/backend/internal/users"
+	"github.com/google/uuid"
+)
+
+// JobNotifyNewDeviceLogin рассылка уведомления о входе с нового устройства
+const JobNotifyNewDeviceLogin = "notifications.new_device_login"
+
+// NewDeviceLogin вход пользователя с устройства или адреса, с которых он раньше не входил
+type NewDeviceLogin struct {
+	UserID    uuid.UUID `json:"user_id"`
+	Email     string    `json:"email"`
+	IP        string    `json:"ip"`
+	UserAgent string    `json:"user_agent"`
+	At        time.Time `json:"at"`
+}
+
+// SetMailer включает отправку уведомлений безопасности на email
+func (s *Service) SetMailer(mailer users.Mailer) {
+	s.mailer = mailer
+}
+
+// SetJobQueue переносит отправку уведомлений безопасности и push-уведомлений,
+// отложенных на тихие часы, в фоновые задачи очереди queue, чтобы письмо не
+// задерживало вход, и регистрирует их обработчики
+func (s *Service) SetJobQueue(queue *jobs.Queue) {
+	s.jobs = queue
+	queue.Register(JobNotifyNewDeviceLogin, s.handleNewDeviceLogin)
+	queue.Register(JobDeliverPush, s.handleDeliverPush)
+}
+
+// NotifyNewDeviceLogin сообщает пользователю о входе с нового устройства
+// (в фоне, если настроена очередь)
+func (s *Service) NotifyNewDeviceLogin(ctx context.Context, login NewDeviceLogin) error {
+	if s.jobs != nil {
+		_, err := s.jobs.Enqueue(ctx, JobNotifyNewDeviceLogin, login)
+		if err == nil {
+			return nil
+		}
+		log.Printf("Ошибка постановки уведомления о входе %s в очередь, отправляем сразу: %v", login.Email, err)
+	}
+	return s.SendNewDeviceLoginNotification(ctx, login)
+}
+
+// SendNewDeviceLoginNotification создает системное уведомление о входе с нового
+// устройства и отправляет его push и, если настроена почта, на email
+func (s *Service) SendNewDeviceLoginNotification(ctx context.Context, login NewDeviceLogin) error {
+	at := login.At.In(s.loc)
+	device := login.UserAgent
+	if device == "" {
+		device = "неизвестное устройство"
+	}
+	formats := s.recipientFormats(ctx, []uuid.UUID{login.UserID})[login.UserID]
+	title := "Вход с нового устройства"
+	message := fmt.Sprintf("%s в %s выполнен вход в вашу учетную запись: %s, IP %s. "+
+		"Если это были не вы, смените пароль.", formats.FormatDate(at), formats.FormatTime(at), device, login.IP)
+
+	notification := &Notification{
+		ID:          uuid.New(),
+		UserID:      login.UserID,
+		Title:       title,
+		Message:     message,
+		Type:        NotificationTypeSystem,
+		RelatedDate: clock.Anchor(at, s.loc),
+		CreatedAt:   time.Now(),
+		Payload:     Payload{Type: NotificationTypeSystem, Route: RouteSecurity},
+	}
+	if err := s.notificationRepo.CreateNotification(ctx, notification); err != nil {
+		return fmt.Errorf("ошибка создания уведомления для пользователя %s: %w", login.UserID, err)
+	}
+	if err := s.sendPushNotification(ctx, notification); err != nil {
+		log.Printf("Ошибка отправки push уведомления пользователю %s: %v", login.UserID, err)
+	}
+
+	// Ошибка письма не повторяет задачу: уведомление в приложении уже создано
+	if s.mailer != nil {
+		body := message + "\n\nОтключить эти уведомления можно в настройках профиля.\n"
+		if err := s.mailer.Send(ctx, login.Email, title, body); err != nil {
+			log.Printf("Ошибка отправки письма о входе пользователю %s: %v", login.Email, err)
+		}
+	}
+
+	log.Printf("Пользователь %s уведомлен о входе с нового устройства (IP %s)", login.Email, login.IP)
+	return nil
+}
+
+// handleNewDeviceLogin обрабатывает задачу JobNotifyNewDeviceLogin
+func (s *Service) handleNewDeviceLogin(ctx context.Context, payload json.RawMessage) error {
+	var login NewDeviceLogin
+	if err := json.Unmarshal(payload, &login); err != nil {
+		return fmt.Errorf("некорректные параметры задачи: %w", err)
+	}
+	return s.SendNewDeviceLoginNotification(ctx, login)
+}
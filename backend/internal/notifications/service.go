@@ -6,8 +6,13 @@ import (
 	"context"
 	"fmt"
 	"log"
+	"net/http"
 	"time"
 
+	"github.com/Ultrahd-dev/student-schedule-app/backend/internal/clock"
+	"github.com/Ultrahd-dev/student-schedule-app/backend/internal/electives"
+	"github.com/Ultrahd-dev/student-schedule-app/backend/internal/jobs"
+	"github.com/Ultrahd-dev/student-schedule-app/backend/internal/realtime"
 	"github.com/Ultrahd-dev/student-schedule-app/backend/internal/schedule"
 	"github.com/Ultrahd-dev/student-schedule-app/backend/internal/users"
 	"github.com/google/uuid"
@@ -15,9 +20,17 @@ import (
 
 // Service предоставляет функции для отправки уведомлений
 type Service struct {
-	userRepo         *users.Repository
-	scheduleRepo     *schedule.Repository
-	notificationRepo *Repository
+	userRepo         users.UserStore
+	scheduleRepo     schedule.ScheduleStore
+	notificationRepo NotificationStore
+	loc              *time.Location     // Часовой пояс колледжа
+	httpClient       *http.Client       // Клиент для отправки в вебхуки групповых чатов
+	electives        *electives.Service // Факультативы студентов; nil - пересечения не проверяются
+	mailer           users.Mailer       // Письма с уведомлениями безопасности (nil - не отправляются)
+	jobs             *jobs.Queue        // Очередь отправки уведомлений безопасности (nil - сразу)
+	hub              *realtime.Hub      // Сигналы ожидающим запросам long polling (nil - только перепроверка базы)
+	pollRecheck      time.Duration      // Период перепроверки базы при ожидании уведомлений
+	quiet            *quietWindow       // Тихие часы push-уведомлений (nil - не соблюдаются)
 }
 
 // NotificationType тип уведомления
@@ -39,11 +52,13 @@ const (
 )
 
 // NewService создает новый сервис уведомлений
-func NewService(userRepo *users.Repository, scheduleRepo *schedule.Repository, notificationRepo *Repository) *Service {
+func NewService(userRepo users.UserStore, scheduleRepo schedule.ScheduleStore, notificationRepo NotificationStore, loc *time.Location) *Service {
 	return &Service{
 		userRepo:         userRepo,
 		scheduleRepo:     scheduleRepo,
 		notificationRepo: notificationRepo,
+		loc:              loc,
+		httpClient:       &http.Client{Timeout: 10 * time.Second},
 	}
 }
 
@@ -58,6 +73,11 @@ type Notification struct {
 	RelatedDate  time.Time        `db:"related_date"`
 	IsRead       bool             `db:"is_read"`
 	CreatedAt    time.Time        `db:"created_at"`
+	Payload      Payload          `db:"payload"` // Данные для перехода к затронутому дню или занятию
+
+	// CancelledLessonStart начало отмененной пары (не сохраняется): такое
+	// уведомление срочное, если пара начнется сразу после тихих часов
+	CancelledLessonStart time.Time `db:"-" json:"-"`
 }
 
 // SendScheduleChangeNotification отправляет уведомление об изменении в расписании
@@ -66,47 +86,177 @@ func (s *Service) SendScheduleChangeNotification(ctx context.Context, change *sc
 	log.Printf("Отправляем уведомление об изменении в расписании для группы %s", change.GroupName)
 
 	// 1. Формируем сообщение уведомления в зависимости от типа изменения
-	title, message := s.formatChangeMessage(change)
+	return s.notifyGroup(ctx, change, s.formatChangeMessage, true)
+}
+
+// SendChangeRevertedNotification отправляет уведомление об отмене изменения,
+// строка которого исчезла из таблицы изменений
+func (s *Service) SendChangeRevertedNotification(ctx context.Context, change *schedule.ScheduleChange) error {
+	log.Printf("Отправляем уведомление об отмене изменения в расписании для группы %s", change.GroupName)
+
+	return s.notifyGroup(ctx, change, s.formatRevertedMessage, false)
+}
+
+// SendScheduleChangeNotifications отправляет уведомления о нескольких изменениях.
+// Студенты всех затронутых групп получаются одним запросом.
+func (s *Service) SendScheduleChangeNotifications(ctx context.Context, changes []schedule.ScheduleChange) error {
+	return s.notifyChanges(ctx, changes, s.formatChangeMessage, true)
+}
+
+// SendChangeRevertedNotifications отправляет уведомления об отмене нескольких изменений.
+// Студенты всех затронутых групп получаются одним запросом.
+func (s *Service) SendChangeRevertedNotifications(ctx context.Context, changes []schedule.ScheduleChange) error {
+	return s.notifyChanges(ctx, changes, s.formatRevertedMessage, false)
+}
+
+// changeFormatter формирует заголовок и текст уведомления об изменении
+// в форматах даты и времени получателя
+type changeFormatter func(change *schedule.ScheduleChange, formats clock.Formats) (string, string)
+
+// notifyChanges рассылает уведомления по изменениям, получая студентов всех групп
+// одним запросом. Ошибка по одному изменению не прерывает рассылку остальных.
+// Группам с вебхуком отправляется одна сводка всех их изменений.
+// checkElectives - сообщать студентам о пересечении новой пары с факультативом.
+func (s *Service) notifyChanges(ctx context.Context, changes []schedule.ScheduleChange,
+	format changeFormatter, checkElectives bool) error {
+	if len(changes) == 0 {
+		return nil
+	}
+
+	groupSet := make(map[string]bool)
+	var groups []string
+	for _, change := range changes {
+		if !groupSet[change.GroupName] {
+			groupSet[change.GroupName] = true
+			groups = append(groups, change.GroupName)
+		}
+	}
+
+	students, err := s.userRepo.GetStudentsByGroups(ctx, groups)
+	if err != nil {
+		return fmt.Errorf("ошибка получения студентов групп: %w", err)
+	}
+	log.Printf("Отправляем уведомления о %d изменениях для %d групп", len(changes), len(groups))
+
+	var firstErr error
+	for i := range changes {
+		change := &changes[i]
+		if err := s.notifyRecipients(ctx, change, students[change.GroupName], format, checkElectives); err != nil {
+			log.Printf("Ошибка отправки уведомления об изменении %s: %v", change.ID, err)
+			if firstErr == nil {
+				firstErr = err
+			}
+		}
+	}
+
+	s.postChangeSummaries(ctx, changes, format)
+	return firstErr
+}
+
+// formatRevertedMessage форматирует сообщение уведомления об отмене изменения
+func (s *Service) formatRevertedMessage(change *schedule.ScheduleChange, formats clock.Formats) (string, string) {
+	title := fmt.Sprintf("Изменения в расписании на %s отменены", formats.FormatDate(clock.Anchor(change.Date, s.loc)))
+	timeStart := formats.FormatClock(change.TimeStart)
+
+	var message string
+	switch change.ChangeType {
+	case "cancellation":
+		message = fmt.Sprintf("Отмена пары по %s в %s отменена. Пара пройдет по основному расписанию",
+			change.Subject, timeStart)
+	case "addition":
+		message = fmt.Sprintf("Добавленная пара по %s (%s) в %s не состоится",
+			change.Subject, change.Teacher, timeStart)
+	default:
+		message = fmt.Sprintf("Замена на %s (%s) в %s отменена. Пара пройдет по основному расписанию",
+			change.Subject, change.Teacher, timeStart)
+	}
 
+	return title, message
+}
+
+// notifyGroup создает уведомление об изменении для всех студентов группы
+// и преподавателя пары (по ФИО или подтвержденному варианту имени), отправляет push
+// и публикует изменение в групповой чат, если у группы настроен вебхук
+func (s *Service) notifyGroup(ctx context.Context, change *schedule.ScheduleChange, format changeFormatter, checkElectives bool) error {
 	// 2. Получаем всех студентов группы
 	studentIDs, err := s.userRepo.GetStudentsByGroup(ctx, change.GroupName)
 	if err != nil {
 		return fmt.Errorf("ошибка получения студентов группы %s: %w", change.GroupName, err)
 	}
 
-	// Если нет студентов, выходим
-	if len(studentIDs) == 0 {
+	if err := s.notifyRecipients(ctx, change, studentIDs, format, checkElectives); err != nil {
+		return err
+	}
+
+	s.postChangeSummaries(ctx, []schedule.ScheduleChange{*change}, format)
+	return nil
+}
+
+// notifyRecipients создает уведомление об изменении для студентов studentIDs
+// и преподавателя пары (в форматах даты и времени каждого получателя) и отправляет push. С checkElectives студентам, у которых
+// пара пересекается с занятием факультатива, сообщается о пересечении.
+func (s *Service) notifyRecipients(ctx context.Context, change *schedule.ScheduleChange, studentIDs []uuid.UUID, format changeFormatter, checkElectives bool) error {
+	recipientIDs := append([]uuid.UUID(nil), studentIDs...)
+	if change.Teacher != "" {
+		teacherIDs, err := s.userRepo.GetTeachersByScrapedName(ctx, change.Teacher)
+		if err != nil {
+			log.Printf("Ошибка получения преподавателя %q для уведомления: %v", change.Teacher, err)
+		}
+		recipientIDs = append(recipientIDs, teacherIDs...)
+	}
+
+	// Если получателей нет, выходим
+	if len(recipientIDs) == 0 {
 		log.Printf("Нет студентов в группе %s для отправки уведомления", change.GroupName)
 		return nil
 	}
 
-	// 3. Создаем уведомления для каждого студента
+	var conflicts map[uuid.UUID]string
+	if checkElectives {
+		conflicts = s.electiveConflicts(ctx, change, studentIDs)
+	}
+
+	formats := s.recipientFormats(ctx, recipientIDs)
+	payload := schedulePayload(NotificationTypeScheduleChange, change.GroupName, clock.Anchor(change.Date, s.loc), change.TimeStart)
+	payload.ChangeID = change.ID.String()
+
+	// 3. Создаем уведомления для каждого получателя
 	var notificationErrors []error
-	for _, studentID := range studentIDs {
+	for _, recipientID := range recipientIDs {
+		title, message := format(change, formats[recipientID])
+		if course, ok := conflicts[recipientID]; ok {
+			message += fmt.Sprintf(". Пара пересекается с вашим факультативом %s", course)
+		}
 		notification := &Notification{
 			ID:           uuid.New(),
-			UserID:       studentID,
+			UserID:       recipientID,
 			Title:        title,
 			Message:      message,
 			Type:         NotificationTypeScheduleChange,
 			RelatedGroup: change.GroupName,
-			RelatedDate:  change.Date,
+			RelatedDate:  clock.Anchor(change.Date, s.loc),
 			IsRead:       false,
 			CreatedAt:    time.Now(),
+			Payload:      payload,
+		}
+		if change.ChangeType == string(NotificationChangeTypeCancellation) {
+			if start, err := clock.At(change.Date, change.TimeStart, s.loc); err == nil {
+				notification.CancelledLessonStart = start
+			}
 		}
 
 		// Создаем уведомление в БД
 		err := s.notificationRepo.CreateNotification(ctx, notification)
 		if err != nil {
-			notificationErrors = append(notificationErrors, fmt.Errorf("ошибка создания уведомления для студента %s: %w", studentID, err))
+			notificationErrors = append(notificationErrors, fmt.Errorf("ошибка создания уведомления для пользователя %s: %w", recipientID, err))
 			continue
 		}
 
-		log.Printf("Создано уведомление для студента %s: %s", studentID, title)
+		log.Printf("Создано уведомление для пользователя %s: %s", recipientID, title)
 
 		// Отправляем push-уведомление
 		if err := s.sendPushNotification(ctx, notification); err != nil {
-			log.Printf("Ошибка отправки push уведомления студенту %s: %v", studentID, err)
+			log.Printf("Ошибка отправки push уведомления пользователю %s: %v", recipientID, err)
 		}
 	}
 
@@ -115,28 +265,42 @@ func (s *Service) SendScheduleChangeNotification(ctx context.Context, change *sc
 		return fmt.Errorf("ошибки при создании уведомлений: %v", notificationErrors[0])
 	}
 
-	log.Printf("Уведомление об изменении отправлено для группы %s (%d студентов)", change.GroupName, len(studentIDs))
+	log.Printf("Уведомление об изменении отправлено для группы %s (%d студентов, %d преподавателей)",
+		change.GroupName, len(studentIDs), len(recipientIDs)-len(studentIDs))
 	return nil
 }
 
 // formatChangeMessage форматирует сообщение уведомления об изменении
-func (s *Service) formatChangeMessage(change *schedule.ScheduleChange) (string, string) {
-	title := fmt.Sprintf("Изменения в расписании на %s", change.Date.Format("02.01.2006"))
+func (s *Service) formatChangeMessage(change *schedule.ScheduleChange, formats clock.Formats) (string, string) {
+	date := formats.FormatDate(clock.Anchor(change.Date, s.loc))
+	title := fmt.Sprintf("Изменения в расписании на %s", date)
+	if change.SupersedesID != nil {
+		// Изменение уточняет ранее присланное изменение той же пары
+		title = fmt.Sprintf("Обновлено изменение в расписании на %s", date)
+	}
+	timeStart := formats.FormatClock(change.TimeStart)
 
 	var message string
 	switch change.ChangeType {
 	case "replacement":
 		message = fmt.Sprintf("Ваша пара по %s (%s) перенесена с %s на %s. Новый кабинет: %s",
-			change.Subject, change.Teacher, change.OriginalSubject, change.TimeStart, change.Classroom)
+			change.Subject, change.Teacher, change.OriginalSubject, timeStart, change.Classroom)
 	case "cancellation":
 		message = fmt.Sprintf("Пара по %s (%s) в %s отменена",
-			change.Subject, change.Teacher, change.TimeStart)
+			change.Subject, change.Teacher, timeStart)
 	case "addition":
 		message = fmt.Sprintf("Добавлена новая пара по %s (%s) в %s. Кабинет: %s",
-			change.Subject, change.Teacher, change.TimeStart, change.Classroom)
+			change.Subject, change.Teacher, timeStart, change.Classroom)
 	default:
 		message = fmt.Sprintf("Изменения в расписании: %s (%s) в %s. Кабинет: %s",
-			change.Subject, change.Teacher, change.TimeStart, change.Classroom)
+			change.Subject, change.Teacher, timeStart, change.Classroom)
+	}
+
+	if change.Reason != "" {
+		message += fmt.Sprintf(". Причина: %s", change.Reason)
+	}
+	if change.SupersedesID != nil {
+		message = "Предыдущее изменение по этой паре больше не действует. " + message
 	}
 
 	return title, message
@@ -144,13 +308,33 @@ func (s *Service) formatChangeMessage(change *schedule.ScheduleChange) (string,
 
 // sendPushNotification отправляет push-уведомление
 // В соответствии с ТЗ: "Получение уведомлений об изменениях"
+// В тихие часы получателя отправка откладывается до их окончания.
 func (s *Service) sendPushNotification(ctx context.Context, notification *Notification) error {
+	// Клиенты без push получают уведомление через long polling
+	if s.hub != nil {
+		s.hub.Publish(notification.UserID)
+	}
+
+	if deliverAt, ok := s.pushDeliverAt(ctx, notification); ok {
+		_, err := s.jobs.EnqueueAt(ctx, JobDeliverPush, notification, deliverAt)
+		if err == nil {
+			log.Printf("Push уведомление пользователю %s отложено до %s (тихие часы)",
+				notification.UserID, deliverAt.Format(time.RFC3339))
+			return nil
+		}
+		log.Printf("Ошибка откладывания push уведомления, отправляем сразу: %v", err)
+	}
+	return s.deliverPush(ctx, notification)
+}
+
+// deliverPush отправляет push-уведомление на устройства пользователя
+func (s *Service) deliverPush(ctx context.Context, notification *Notification) error {
 	// TODO: Здесь будет реальная логика отправки push-уведомлений
 	// Например, с использованием FCM (Firebase Cloud Messaging) или APNs (Apple Push Notification Service)
 
 	// Пока просто логируем отправку
-	log.Printf("Отправка push уведомления пользователю %s: %s - %s",
-		notification.UserID, notification.Title, notification.Message)
+	log.Printf("Отправка push уведомления пользователю %s: %s - %s (%s)",
+		notification.UserID, notification.Title, notification.Message, notification.Payload.Route)
 
 	// В реальной реализации здесь будет код для отправки через FCM/APNs
 	// Например:
@@ -158,10 +342,7 @@ func (s *Service) sendPushNotification(ctx context.Context, notification *Notifi
 	// err := fcmClient.SendMessageToDevice(deviceToken, &fcm.Message{
 	//     Title: notification.Title,
 	//     Body:  notification.Message,
-	//     Data: map[string]string{
-	//         "notification_id": notification.ID.String(),
-	//         "type":          string(notification.Type),
-	//     },
+	//     Data:  notification.Payload.PushData(notification.ID),
 	// })
 	// if err != nil {
 	//     return fmt.Errorf("ошибка отправки push уведомления: %w", err)
@@ -176,9 +357,6 @@ func (s *Service) SendNewScheduleNotification(ctx context.Context, snapshot *sch
 	log.Println("Отправляем уведомление о новом основном расписании")
 
 	title := "Обновлено расписание"
-	message := fmt.Sprintf("Доступно новое расписание на период с %s по %s",
-		snapshot.PeriodStart.Format("02.01.2006"),
-		snapshot.PeriodEnd.Format("02.01.2006"))
 
 	// TODO: Получить всех студентов и преподавателей
 	// Пока используем заглушку
@@ -192,18 +370,24 @@ func (s *Service) SendNewScheduleNotification(ctx context.Context, snapshot *sch
 		return nil
 	}
 
+	formats := s.recipientFormats(ctx, allUserIDs)
+
 	// Создаем уведомления для каждого пользователя
 	var notificationErrors []error
 	for _, userID := range allUserIDs {
+		message := fmt.Sprintf("Доступно новое расписание на период с %s по %s",
+			formats[userID].FormatDate(clock.Anchor(snapshot.PeriodStart, s.loc)),
+			formats[userID].FormatDate(clock.Anchor(snapshot.PeriodEnd, s.loc)))
 		notification := &Notification{
 			ID:          uuid.New(),
 			UserID:      userID,
 			Title:       title,
 			Message:     message,
 			Type:        NotificationTypeSystem,
-			RelatedDate: snapshot.PeriodStart,
+			RelatedDate: clock.Anchor(snapshot.PeriodStart, s.loc),
 			IsRead:      false,
 			CreatedAt:   time.Now(),
+			Payload:     schedulePayload(NotificationTypeSystem, "", clock.Anchor(snapshot.PeriodStart, s.loc), ""),
 		}
 
 		// Создаем уведомление в БД
@@ -230,7 +414,23 @@ func (s *Service) SendNewScheduleNotification(ctx context.Context, snapshot *sch
 	return nil
 }
 
-// MarkAsRead помечает уведомление как прочитанное
-func (s *Service) MarkAsRead(ctx context.Context, notificationID uuid.UUID) error {
-	return s.notificationRepo.MarkAsRead(ctx, notificationID)
+// recipientFormats возвращает форматы даты и времени получателей уведомлений.
+// Получателям без настроек (и всем при ошибке чтения) соответствуют нулевые
+// clock.Formats, которые форматируют в формате колледжа.
+func (s *Service) recipientFormats(ctx context.Context, userIDs []uuid.UUID) map[uuid.UUID]clock.Formats {
+	formats := make(map[uuid.UUID]clock.Formats, len(userIDs))
+	prefs, err := s.userRepo.GetFormatPreferences(ctx, userIDs)
+	if err != nil {
+		log.Printf("Ошибка получения форматов даты получателей уведомлений: %v", err)
+		return formats
+	}
+	for userID, p := range prefs {
+		formats[userID] = p.Formats()
+	}
+	return formats
+}
+
+// UnreadNotifications возвращает непрочитанные уведомления пользователя, от новых к старым
+func (s *Service) UnreadNotifications(ctx context.Context, userID uuid.UUID) ([]Notification, error) {
+	return s.notificationRepo.GetUnreadNotifications(ctx, userID)
 }
@@ -0,0 +1,29 @@
+package notifications
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// NotificationStore хранилище уведомлений и вебхуков групп, которое использует
+// Service. Реализуется Repository; в тестах подменяется моком из internal/mocks.
+//
+//go:generate go run ../../cmd/mockgen -source store.go -interface NotificationStore -out ../mocks/notification_store.go
+type NotificationStore interface {
+	CreateNotification(ctx context.Context, notification *Notification) error
+	GetUnreadNotifications(ctx context.Context, userID uuid.UUID) ([]Notification, error)
+	GetNotificationsSince(ctx context.Context, userID uuid.UUID, since time.Time) ([]Notification, error)
+	ListNotifications(ctx context.Context, userID uuid.UUID, filter ListFilter) ([]Notification, int, error)
+	CountUnread(ctx context.Context, userID uuid.UUID) (int, error)
+	MarkAsRead(ctx context.Context, userID, notificationID uuid.UUID) (bool, error)
+	MarkAllAsRead(ctx context.Context, userID uuid.UUID) (int64, error)
+	UpsertGroupWebhook(ctx context.Context, webhook *GroupWebhook) error
+	ListGroupWebhooks(ctx context.Context) ([]GroupWebhook, error)
+	GetGroupWebhooks(ctx context.Context, groupNames []string) (map[string]GroupWebhook, error)
+	DeleteGroupWebhook(ctx context.Context, groupName string) (bool, error)
+	MarkGroupWebhookDelivery(ctx context.Context, id uuid.UUID, deliveryErr error) error
+}
+
+var _ NotificationStore = (*Repository)(nil)
@@ -0,0 +1,206 @@
+package notifications
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/Ultrahd-dev/student-schedule-app/backend/internal/apperr"
+	"github.com/Ultrahd-dev/student-schedule-app/backend/internal/clock"
+	"github.com/Ultrahd-dev/student-schedule-app/backend/internal/schedule"
+	"github.com/google/uuid"
+)
+
+// ErrInvalidWebhook означает некорректную группу, провайдера или адрес вебхука
+var ErrInvalidWebhook = apperr.New(apperr.ErrValidation, "некорректный вебхук")
+
+// WebhookProvider сервис группового чата, в который отправляются сводки
+type WebhookProvider string
+
+const (
+	WebhookProviderDiscord WebhookProvider = "discord"
+	WebhookProviderSlack   WebhookProvider = "slack"
+)
+
+// webhookHosts допустимые хосты вебхуков провайдеров: сервер отправляет запросы
+// только на адреса чатов, а не на произвольные адреса из запроса администратора
+var webhookHosts = map[WebhookProvider][]string{
+	WebhookProviderDiscord: {"discord.com", "discordapp.com"},
+	WebhookProviderSlack:   {"hooks.slack.com"},
+}
+
+// discordMessageLimit максимальная длина сообщения Discord в символах
+const discordMessageLimit = 2000
+
+// GroupWebhook вебхук группового чата, в который публикуются изменения расписания группы
+type GroupWebhook struct {
+	ID              uuid.UUID       `db:"id"`
+	GroupName       string          `db:"group_name"`
+	Provider        WebhookProvider `db:"provider"`
+	URL             string          `db:"url"`
+	CreatedBy       *uuid.UUID      `db:"created_by"`
+	CreatedAt       time.Time       `db:"created_at"`
+	UpdatedAt       time.Time       `db:"updated_at"`
+	LastDeliveredAt *time.Time      `db:"last_delivered_at"`
+	LastError       string          `db:"last_error"`
+}
+
+// SetGroupWebhook создает или заменяет вебхук группы от имени администратора createdBy
+func (s *Service) SetGroupWebhook(ctx context.Context, webhook *GroupWebhook, createdBy uuid.UUID) error {
+	webhook.GroupName = strings.TrimSpace(webhook.GroupName)
+	webhook.URL = strings.TrimSpace(webhook.URL)
+	if webhook.GroupName == "" || utf8.RuneCountInString(webhook.GroupName) > 50 {
+		return fmt.Errorf("%w: группа должна содержать от 1 до 50 символов", ErrInvalidWebhook)
+	}
+	if err := validateWebhookURL(webhook.Provider, webhook.URL); err != nil {
+		return err
+	}
+
+	webhook.ID = uuid.New()
+	webhook.CreatedBy = &createdBy
+	return s.notificationRepo.UpsertGroupWebhook(ctx, webhook)
+}
+
+// ListGroupWebhooks возвращает вебхуки групп колледжа
+func (s *Service) ListGroupWebhooks(ctx context.Context) ([]GroupWebhook, error) {
+	return s.notificationRepo.ListGroupWebhooks(ctx)
+}
+
+// DeleteGroupWebhook удаляет вебхук группы; false - у группы не было вебхука
+func (s *Service) DeleteGroupWebhook(ctx context.Context, groupName string) (bool, error) {
+	return s.notificationRepo.DeleteGroupWebhook(ctx, strings.TrimSpace(groupName))
+}
+
+// validateWebhookURL проверяет, что адрес - https-вебхук провайдера
+func validateWebhookURL(provider WebhookProvider, rawURL string) error {
+	hosts, ok := webhookHosts[provider]
+	if !ok {
+		return fmt.Errorf("%w: неизвестный провайдер %q, ожидается discord или slack", ErrInvalidWebhook, provider)
+	}
+
+	parsed, err := url.Parse(rawURL)
+	if err != nil || parsed.Scheme != "https" || parsed.User != nil || parsed.Port() != "" {
+		return fmt.Errorf("%w: ожидается https-адрес вебхука", ErrInvalidWebhook)
+	}
+	for _, host := range hosts {
+		if strings.EqualFold(parsed.Hostname(), host) {
+			return nil
+		}
+	}
+	return fmt.Errorf("%w: адрес вебхука %s должен вести на %s", ErrInvalidWebhook, provider, strings.Join(hosts, " или "))
+}
+
+// postChangeSummaries публикует в вебхуки групп сводки изменений: одно сообщение
+// на группу со всеми ее изменениями. Ошибка отправки сохраняется у вебхука и
+// не прерывает рассылку: уведомления пользователям уже созданы. Сводки групповых
+// чатов форматируются в форматах даты и времени колледжа.
+func (s *Service) postChangeSummaries(ctx context.Context, changes []schedule.ScheduleChange,
+	format changeFormatter) {
+	var groups []string
+	byGroup := make(map[string][]*schedule.ScheduleChange)
+	for i := range changes {
+		change := &changes[i]
+		if _, ok := byGroup[change.GroupName]; !ok {
+			groups = append(groups, change.GroupName)
+		}
+		byGroup[change.GroupName] = append(byGroup[change.GroupName], change)
+	}
+
+	webhooks, err := s.notificationRepo.GetGroupWebhooks(ctx, groups)
+	if err != nil {
+		log.Printf("Ошибка получения вебхуков групп: %v", err)
+		return
+	}
+
+	for _, group := range groups {
+		webhook, ok := webhooks[group]
+		if !ok {
+			continue
+		}
+
+		items := make([]summaryItem, 0, len(byGroup[group]))
+		for _, change := range byGroup[group] {
+			title, message := format(change, clock.DefaultFormats)
+			items = append(items, summaryItem{title: title, message: message})
+		}
+
+		err := s.postWebhook(ctx, &webhook, items)
+		if err != nil {
+			log.Printf("Ошибка отправки сводки изменений группы %s в %s: %v", group, webhook.Provider, err)
+		} else {
+			log.Printf("Сводка изменений группы %s (%d) отправлена в %s", group, len(items), webhook.Provider)
+		}
+		if err := s.notificationRepo.MarkGroupWebhookDelivery(ctx, webhook.ID, err); err != nil {
+			log.Printf("Ошибка сохранения результата отправки вебхука группы %s: %v", group, err)
+		}
+	}
+}
+
+// summaryItem одно изменение в сводке для группового чата
+type summaryItem struct {
+	title   string
+	message string
+}
+
+// postWebhook отправляет сводку изменений группы в вебхук
+func (s *Service) postWebhook(ctx context.Context, webhook *GroupWebhook, items []summaryItem) error {
+	body, err := json.Marshal(webhookPayload(webhook, items))
+	if err != nil {
+		return fmt.Errorf("ошибка сериализации сообщения: %w", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, webhook.URL, bytes.NewReader(body))
+	if err != nil {
+		return fmt.Errorf("ошибка создания запроса: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := s.httpClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("ошибка запроса к вебхуку: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		detail, _ := io.ReadAll(io.LimitReader(resp.Body, 512))
+		return fmt.Errorf("вебхук вернул статус %d: %s", resp.StatusCode, strings.TrimSpace(string(detail)))
+	}
+	return nil
+}
+
+// webhookPayload формирует тело сообщения в формате провайдера:
+// жирный заголовок изменения и его описание отдельными строками
+func webhookPayload(webhook *GroupWebhook, items []summaryItem) map[string]string {
+	bold := "**" // Markdown Discord
+	if webhook.Provider == WebhookProviderSlack {
+		bold = "*" // mrkdwn Slack
+	}
+
+	var b strings.Builder
+	fmt.Fprintf(&b, "%sИзменения в расписании группы %s%s", bold, webhook.GroupName, bold)
+	for _, item := range items {
+		fmt.Fprintf(&b, "\n\n%s\n%s", item.title, item.message)
+	}
+
+	if webhook.Provider == WebhookProviderSlack {
+		return map[string]string{"text": b.String()}
+	}
+	return map[string]string{"content": truncate(b.String(), discordMessageLimit)}
+}
+
+// truncate обрезает строку до limit символов, обозначая обрезку многоточием
+func truncate(s string, limit int) string {
+	if utf8.RuneCountInString(s) <= limit {
+		return s
+	}
+	runes := []rune(s)
+	return string(runes[:limit-1]) + "…"
+}
@@ -0,0 +1,63 @@
+package outbox
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Broker публикует сообщения во внешний брокер (NATS JetStream)
+type Broker interface {
+	// Publish публикует data в тему subject; messageID позволяет брокеру
+	// отбросить сообщение, опубликованное повторно
+	Publish(ctx context.Context, subject, messageID string, data []byte) error
+}
+
+// BrokerSubjects темы брокера, в которые публикуются доменные события.
+// Сервисы вне ядра (аналитика, боты) подписываются на них, не обращаясь к базе.
+var BrokerSubjects = map[string]string{
+	EventSnapshotCreated: "schedule.snapshot.created",
+	EventChangeApplied:   "schedule.change.applied",
+	EventChangeReverted:  "schedule.change.reverted",
+	EventUserRegistered:  "user.registered",
+}
+
+// BrokerMessage сообщение о доменном событии в брокере
+type BrokerMessage struct {
+	ID          uuid.UUID       `json:"id"`
+	Type        string          `json:"type"`
+	CollegeID   uuid.UUID       `json:"college_id"`
+	AggregateID uuid.UUID       `json:"aggregate_id"`
+	CreatedAt   time.Time       `json:"created_at"`
+	Payload     json.RawMessage `json:"payload"`
+}
+
+// Forward подписывает брокер на доменные события из BrokerSubjects. Темы
+// получают префикс prefix (например, "college." дает "college.user.registered").
+// Если брокер недоступен, relay повторит публикацию события позже; ID события
+// передается брокеру для отбрасывания повторов.
+func (r *Relay) Forward(broker Broker, prefix string) {
+	for eventType, subject := range BrokerSubjects {
+		subject := prefix + subject
+		r.Subscribe(eventType, func(ctx context.Context, event Event) error {
+			data, err := json.Marshal(BrokerMessage{
+				ID:          event.ID,
+				Type:        event.Type,
+				CollegeID:   event.CollegeID,
+				AggregateID: event.AggregateID,
+				CreatedAt:   event.CreatedAt,
+				Payload:     event.Payload,
+			})
+			if err != nil {
+				return fmt.Errorf("ошибка сериализации события %s: %w", event.ID, err)
+			}
+			if err := broker.Publish(ctx, subject, event.ID.String(), data); err != nil {
+				return fmt.Errorf("ошибка публикации события %s в брокер: %w", event.ID, err)
+			}
+			return nil
+		})
+	}
+}
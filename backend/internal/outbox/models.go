@@ -0,0 +1,73 @@
+// Package outbox реализует transactional outbox: доменные события записываются
+// в таблицу outbox_events в той же транзакции, что и изменение данных, а relay
+// публикует их подписчикам. Доставка «хотя бы один раз»: подписчик может
+// получить событие повторно, если relay упадет после публикации.
+package outbox
+
+import (
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Типы доменных событий
+const (
+	EventSnapshotCreated = "snapshot.created" // Загружен новый снапшот основного расписания
+	EventChangeApplied   = "change.applied"   // Изменение применено к актуальному расписанию
+	EventChangeReverted  = "change.reverted"  // Примененное изменение откачено
+	EventUserRegistered  = "user.registered"  // Зарегистрирован пользователь
+)
+
+// Event доменное событие
+type Event struct {
+	ID          uuid.UUID       `db:"id"`
+	Type        string          `db:"event_type"`
+	AggregateID uuid.UUID       `db:"aggregate_id"` // ID снапшота или изменения
+	Payload     json.RawMessage `db:"payload"`
+	Attempts    int             `db:"attempts"` // Число неудачных попыток публикации
+	LastError   string          `db:"last_error"`
+	CreatedAt   time.Time       `db:"created_at"`
+	PublishedAt *time.Time      `db:"published_at"`
+	CollegeID   uuid.UUID       `db:"college_id"` // Колледж, в котором произошло событие
+}
+
+// SnapshotCreated данные события EventSnapshotCreated
+type SnapshotCreated struct {
+	SnapshotID  uuid.UUID `json:"snapshot_id"`
+	Name        string    `json:"name"`
+	PeriodStart string    `json:"period_start"` // YYYY-MM-DD
+	PeriodEnd   string    `json:"period_end"`   // YYYY-MM-DD
+}
+
+// ChangeEvent данные событий EventChangeApplied и EventChangeReverted
+type ChangeEvent struct {
+	ChangeID     uuid.UUID `json:"change_id"`
+	GroupName    string    `json:"group_name"`
+	Date         string    `json:"date"` // YYYY-MM-DD
+	LessonNumber int       `json:"lesson_number"`
+	ChangeType   string    `json:"change_type"`
+	Subject      string    `json:"subject"`
+}
+
+// UserRegistered данные события EventUserRegistered
+type UserRegistered struct {
+	UserID uuid.UUID `json:"user_id"`
+	Role   string    `json:"role"`
+}
+
+// NewEvent создает событие типа eventType с данными payload (сериализуются в JSON)
+func NewEvent(eventType string, aggregateID uuid.UUID, payload interface{}) (Event, error) {
+	data, err := json.Marshal(payload)
+	if err != nil {
+		return Event{}, fmt.Errorf("ошибка сериализации события %s: %w", eventType, err)
+	}
+
+	return Event{
+		ID:          uuid.New(),
+		Type:        eventType,
+		AggregateID: aggregateID,
+		Payload:     data,
+	}, nil
+}
@@ -0,0 +1,175 @@
+package outbox
+
+import (
+	"context"
+	"errors"
+	"log"
+	"sync"
+	"time"
+
+	"github.com/Ultrahd-dev/student-schedule-app/backend/internal/tenant"
+	"github.com/Ultrahd-dev/student-schedule-app/backend/internal/txn"
+)
+
+// Handler обрабатывает опубликованное событие.
+// Ошибка приводит к повторной публикации события всем подписчикам его типа.
+type Handler func(ctx context.Context, event Event) error
+
+// Config настройки relay
+type Config struct {
+	PollInterval time.Duration // Период опроса таблицы событий
+	BatchSize    int           // Событий за одну транзакцию публикации
+	RetryBackoff time.Duration // Задержка перед повторной публикацией, далее удваивается
+	Retention    time.Duration // Сколько хранить опубликованные события
+}
+
+// Relay публикует события из outbox подписчикам
+type Relay struct {
+	config      Config
+	repo        *Repository
+	transactor  txn.Transactor
+	mu          sync.RWMutex
+	subscribers map[string][]Handler
+}
+
+// NewRelay создает relay событий
+func NewRelay(config Config, repo *Repository, transactor txn.Transactor) *Relay {
+	if config.PollInterval <= 0 {
+		config.PollInterval = time.Second
+	}
+	if config.BatchSize <= 0 {
+		config.BatchSize = 100
+	}
+	if config.RetryBackoff <= 0 {
+		config.RetryBackoff = 10 * time.Second
+	}
+	if config.Retention <= 0 {
+		config.Retention = 7 * 24 * time.Hour
+	}
+
+	return &Relay{
+		config:      config,
+		repo:        repo,
+		transactor:  transactor,
+		subscribers: make(map[string][]Handler),
+	}
+}
+
+// Subscribe подписывает handler на события типа eventType
+func (r *Relay) Subscribe(eventType string, handler Handler) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.subscribers[eventType] = append(r.subscribers[eventType], handler)
+}
+
+// Start публикует события до отмены контекста
+func (r *Relay) Start(ctx context.Context) {
+	log.Printf("Relay событий запущен, период опроса %s", r.config.PollInterval)
+
+	ticker := time.NewTicker(r.config.PollInterval)
+	defer ticker.Stop()
+	cleanup := time.NewTicker(time.Hour)
+	defer cleanup.Stop()
+
+	for {
+		// Публикуем пакеты, пока есть готовые события
+		for ctx.Err() == nil {
+			published, err := r.PublishPending(ctx)
+			if err != nil {
+				log.Printf("Ошибка публикации событий: %v", err)
+				break
+			}
+			if published < r.config.BatchSize {
+				break
+			}
+		}
+
+		select {
+		case <-ctx.Done():
+			log.Println("Остановка relay событий")
+			return
+		case <-cleanup.C:
+			r.cleanup(ctx)
+		case <-ticker.C:
+		}
+	}
+}
+
+// PublishPending публикует один пакет готовых событий и возвращает число
+// обработанных событий (опубликованных и отложенных из-за ошибки подписчика)
+func (r *Relay) PublishPending(ctx context.Context) (int, error) {
+	published := 0
+	// Подписчики получают ctx без транзакции: их запросы не должны
+	// удерживать блокировки событий и откатываться вместе с пакетом
+	err := r.transactor.Do(ctx, func(txCtx context.Context) error {
+		events, err := r.repo.FetchUnpublished(txCtx, r.config.BatchSize)
+		if err != nil {
+			return err
+		}
+
+		for _, event := range events {
+			if err := r.publish(ctx, event); err != nil {
+				retryAt := time.Now().Add(r.backoff(event.Attempts))
+				log.Printf("Ошибка публикации события %s (%s), повтор в %s: %v",
+					event.ID, event.Type, retryAt.Format(time.RFC3339), err)
+				if err := r.repo.MarkFailed(txCtx, event.ID, err.Error(), retryAt); err != nil {
+					return err
+				}
+				continue
+			}
+
+			if err := r.repo.MarkPublished(txCtx, event.ID); err != nil {
+				return err
+			}
+		}
+		published = len(events)
+		return nil
+	})
+	if err != nil {
+		// Если не зафиксирован коммит, подписчики уже получили события
+		// и получат их снова при следующей попытке
+		return 0, err
+	}
+	return published, nil
+}
+
+// publish передает событие всем подписчикам его типа в контексте колледжа события
+func (r *Relay) publish(ctx context.Context, event Event) error {
+	r.mu.RLock()
+	handlers := r.subscribers[event.Type]
+	r.mu.RUnlock()
+
+	ctx = tenant.WithCollege(ctx, event.CollegeID)
+	var errs []error
+	for _, handler := range handlers {
+		if err := handler(ctx, event); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// backoff возвращает задержку перед повторной публикацией после attempts неудачных попыток:
+// RetryBackoff, удваиваемый с каждой попыткой, но не более часа
+func (r *Relay) backoff(attempts int) time.Duration {
+	delay := r.config.RetryBackoff
+	for i := 0; i < attempts && delay < time.Hour; i++ {
+		delay *= 2
+	}
+	if delay > time.Hour {
+		delay = time.Hour
+	}
+	return delay
+}
+
+// cleanup удаляет старые опубликованные события
+func (r *Relay) cleanup(ctx context.Context) {
+	deleted, err := r.repo.DeletePublished(ctx, time.Now().Add(-r.config.Retention))
+	if err != nil {
+		log.Printf("Ошибка удаления опубликованных событий: %v", err)
+		return
+	}
+	if deleted > 0 {
+		log.Printf("Удалено опубликованных событий: %d", deleted)
+	}
+}
@@ -0,0 +1,125 @@
+package outbox
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	"github.com/Ultrahd-dev/student-schedule-app/backend/internal/tenant"
+	"github.com/Ultrahd-dev/student-schedule-app/backend/internal/txn"
+	"github.com/google/uuid"
+)
+
+// Repository предоставляет доступ к таблице outbox_events
+type Repository struct {
+	db *sql.DB
+}
+
+// NewRepository создает новый репозиторий событий
+func NewRepository(db *sql.DB) *Repository {
+	return &Repository{db: db}
+}
+
+// conn возвращает транзакцию из контекста или базу
+func (r *Repository) conn(ctx context.Context) txn.Executor {
+	return txn.From(ctx, r.db)
+}
+
+// Add записывает событие колледжа из контекста в транзакции из контекста
+// (см. txn.Manager). Событие станет видно relay только после фиксации
+// транзакции вместе с изменением данных.
+func (r *Repository) Add(ctx context.Context, event Event) error {
+	query := `
+		INSERT INTO outbox_events (id, event_type, aggregate_id, payload, college_id)
+		VALUES ($1, $2, $3, $4, $5)`
+
+	_, err := r.conn(ctx).ExecContext(ctx, query, event.ID, event.Type, event.AggregateID, []byte(event.Payload),
+		tenant.CollegeID(ctx))
+	if err != nil {
+		return fmt.Errorf("failed to add outbox event %s: %w", event.Type, err)
+	}
+	return nil
+}
+
+// FetchUnpublished блокирует в транзакции из контекста до limit неопубликованных событий,
+// готовых к публикации, в порядке их создания. События, которые публикует
+// relay другого экземпляра API, пропускаются.
+func (r *Repository) FetchUnpublished(ctx context.Context, limit int) ([]Event, error) {
+	query := `
+		SELECT id, event_type, aggregate_id, payload, attempts, COALESCE(last_error, ''), created_at, published_at,
+		       college_id
+		FROM outbox_events
+		WHERE published_at IS NULL AND next_attempt_at <= NOW()
+		ORDER BY created_at, id
+		LIMIT $1
+		FOR UPDATE SKIP LOCKED`
+
+	rows, err := r.conn(ctx).QueryContext(ctx, query, limit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch outbox events: %w", err)
+	}
+	defer rows.Close()
+
+	var events []Event
+	for rows.Next() {
+		var event Event
+		var payload []byte
+		if err := rows.Scan(
+			&event.ID,
+			&event.Type,
+			&event.AggregateID,
+			&payload,
+			&event.Attempts,
+			&event.LastError,
+			&event.CreatedAt,
+			&event.PublishedAt,
+			&event.CollegeID,
+		); err != nil {
+			return nil, fmt.Errorf("failed to scan outbox event: %w", err)
+		}
+		event.Payload = payload
+		events = append(events, event)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to iterate outbox events: %w", err)
+	}
+	return events, nil
+}
+
+// MarkPublished отмечает событие опубликованным
+func (r *Repository) MarkPublished(ctx context.Context, id uuid.UUID) error {
+	query := `
+		UPDATE outbox_events
+		SET published_at = NOW(), last_error = NULL
+		WHERE id = $1`
+
+	if _, err := r.conn(ctx).ExecContext(ctx, query, id); err != nil {
+		return fmt.Errorf("failed to mark outbox event published: %w", err)
+	}
+	return nil
+}
+
+// MarkFailed сохраняет ошибку публикации и откладывает следующую попытку до nextAttemptAt
+func (r *Repository) MarkFailed(ctx context.Context, id uuid.UUID, lastError string, nextAttemptAt time.Time) error {
+	query := `
+		UPDATE outbox_events
+		SET attempts = attempts + 1, last_error = $2, next_attempt_at = $3
+		WHERE id = $1`
+
+	if _, err := r.conn(ctx).ExecContext(ctx, query, id, lastError, nextAttemptAt); err != nil {
+		return fmt.Errorf("failed to mark outbox event failed: %w", err)
+	}
+	return nil
+}
+
+// DeletePublished удаляет события, опубликованные раньше before
+func (r *Repository) DeletePublished(ctx context.Context, before time.Time) (int64, error) {
+	result, err := r.db.ExecContext(ctx,
+		`DELETE FROM outbox_events WHERE published_at IS NOT NULL AND published_at < $1`, before)
+	if err != nil {
+		return 0, fmt.Errorf("failed to delete published outbox events: %w", err)
+	}
+	return result.RowsAffected()
+}
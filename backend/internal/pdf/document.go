@@ -0,0 +1,292 @@
+// Package pdf формирует простые PDF документы (текст, линии, прямоугольники)
+// со встроенным шрифтом TrueType, чтобы кириллица отображалась одинаково
+// в любом просмотрщике. Из шрифта встраиваются только использованные глифы.
+package pdf
+
+import (
+	"bufio"
+	"bytes"
+	"compress/zlib"
+	"fmt"
+	"io"
+	"sort"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+)
+
+// Размеры страницы A4 в пунктах
+const (
+	A4Width  = 595.28
+	A4Height = 841.89
+)
+
+// Color цвет RGB, компоненты от 0 до 1
+type Color struct {
+	R, G, B float64
+}
+
+// Document PDF документ
+type Document struct {
+	font  *Font
+	pages []*Page
+	used  map[uint16]rune // Использованные глифы и их символы (для ToUnicode)
+}
+
+// Page страница документа. Координаты задаются в пунктах от левого верхнего угла.
+type Page struct {
+	doc     *Document
+	width   float64
+	height  float64
+	content bytes.Buffer
+}
+
+// NewDocument создает документ, текст которого набирается шрифтом font
+func NewDocument(font *Font) *Document {
+	return &Document{font: font, used: make(map[uint16]rune)}
+}
+
+// Font возвращает шрифт документа
+func (d *Document) Font() *Font {
+	return d.font
+}
+
+// AddPage добавляет страницу размером width x height пунктов
+func (d *Document) AddPage(width, height float64) *Page {
+	page := &Page{doc: d, width: width, height: height}
+	d.pages = append(d.pages, page)
+	return page
+}
+
+// FillRect закрашивает прямоугольник цветом color
+func (p *Page) FillRect(x, y, w, h float64, color Color) {
+	fmt.Fprintf(&p.content, "%s %s %s rg %s %s %s %s re f\n",
+		num(color.R), num(color.G), num(color.B), num(x), num(p.height-y-h), num(w), num(h))
+}
+
+// Line рисует отрезок толщиной width цветом color
+func (p *Page) Line(x1, y1, x2, y2, width float64, color Color) {
+	fmt.Fprintf(&p.content, "%s w %s %s %s RG %s %s m %s %s l S\n",
+		num(width), num(color.R), num(color.G), num(color.B),
+		num(x1), num(p.height-y1), num(x2), num(p.height-y2))
+}
+
+// Text выводит строку s шрифтом размера size; y - положение базовой линии.
+// Символы, которых нет в шрифте, выводятся глифом по умолчанию.
+func (p *Page) Text(x, y, size float64, color Color, s string) {
+	if s == "" {
+		return
+	}
+
+	var hex bytes.Buffer
+	for _, r := range s {
+		glyph := p.doc.font.glyph(r)
+		if _, ok := p.doc.used[glyph]; !ok || glyph == 0 {
+			p.doc.used[glyph] = r
+		}
+		fmt.Fprintf(&hex, "%04X", glyph)
+	}
+
+	fmt.Fprintf(&p.content, "BT %s %s %s rg /F1 %s Tf %s %s Td <%s> Tj ET\n",
+		num(color.R), num(color.G), num(color.B), num(size), num(x), num(p.height-y), hex.String())
+}
+
+// Write записывает документ в w
+func (d *Document) Write(w io.Writer) error {
+	pdf := &writer{w: bufio.NewWriter(w)}
+	pdf.printf("%%PDF-1.4\n%%\xe2\xe3\xcf\xd3\n")
+
+	// Номера объектов: 1 - каталог, 2 - дерево страниц, 3-7 - шрифт, далее страницы
+	const (
+		catalogObj = iota + 1
+		pagesObj
+		fontObj
+		cidFontObj
+		descriptorObj
+		fontFileObj
+		toUnicodeObj
+		firstPageObj
+	)
+
+	kids := make([]string, len(d.pages))
+	for i := range d.pages {
+		kids[i] = fmt.Sprintf("%d 0 R", firstPageObj+i*2)
+	}
+
+	pdf.object(catalogObj, fmt.Sprintf("<< /Type /Catalog /Pages %d 0 R >>", pagesObj))
+	pdf.object(pagesObj, fmt.Sprintf("<< /Type /Pages /Kids [%s] /Count %d >>", strings.Join(kids, " "), len(d.pages)))
+
+	font := d.font
+	pdf.object(fontObj, fmt.Sprintf("<< /Type /Font /Subtype /Type0 /BaseFont /%s /Encoding /Identity-H "+
+		"/DescendantFonts [%d 0 R] /ToUnicode %d 0 R >>", fontName, cidFontObj, toUnicodeObj))
+	pdf.object(cidFontObj, fmt.Sprintf("<< /Type /Font /Subtype /CIDFontType2 /BaseFont /%s "+
+		"/CIDSystemInfo << /Registry (Adobe) /Ordering (Identity) /Supplement 0 >> "+
+		"/FontDescriptor %d 0 R /DW %d /W [%s] /CIDToGIDMap /Identity >>",
+		fontName, descriptorObj, font.width(0), d.widths()))
+	pdf.object(descriptorObj, fmt.Sprintf("<< /Type /FontDescriptor /FontName /%s /Flags 32 "+
+		"/FontBBox [%d %d %d %d] /ItalicAngle 0 /Ascent %d /Descent %d /CapHeight %d /StemV 80 /FontFile2 %d 0 R >>",
+		fontName, font.scale(font.bbox[0]), font.scale(font.bbox[1]), font.scale(font.bbox[2]), font.scale(font.bbox[3]),
+		font.scale(font.ascent), font.scale(font.descent), font.scale(font.ascent), fontFileObj))
+
+	fontFile := font.subset(d.usedGlyphs())
+	pdf.stream(fontFileObj, fmt.Sprintf("/Length1 %d", len(fontFile)), fontFile)
+	pdf.stream(toUnicodeObj, "", d.toUnicode())
+
+	for i, page := range d.pages {
+		pageObj := firstPageObj + i*2
+		pdf.object(pageObj, fmt.Sprintf("<< /Type /Page /Parent %d 0 R /MediaBox [0 0 %s %s] "+
+			"/Resources << /Font << /F1 %d 0 R >> >> /Contents %d 0 R >>",
+			pagesObj, num(page.width), num(page.height), fontObj, pageObj+1))
+		pdf.stream(pageObj+1, "", page.content.Bytes())
+	}
+
+	return pdf.finish(catalogObj)
+}
+
+// fontName имя встроенного подмножества шрифта (префикс подмножества обязателен)
+const fontName = "SCHDLE+Font"
+
+// usedGlyphs возвращает множество использованных глифов
+func (d *Document) usedGlyphs() map[uint16]bool {
+	used := make(map[uint16]bool, len(d.used))
+	for glyph := range d.used {
+		used[glyph] = true
+	}
+	return used
+}
+
+// sortedGlyphs возвращает использованные глифы по возрастанию номера
+func (d *Document) sortedGlyphs() []uint16 {
+	glyphs := make([]uint16, 0, len(d.used))
+	for glyph := range d.used {
+		glyphs = append(glyphs, glyph)
+	}
+	sort.Slice(glyphs, func(i, j int) bool { return glyphs[i] < glyphs[j] })
+	return glyphs
+}
+
+// widths возвращает массив W шрифта: ширины использованных глифов
+func (d *Document) widths() string {
+	var b bytes.Buffer
+	for _, glyph := range d.sortedGlyphs() {
+		fmt.Fprintf(&b, "%d [%d] ", glyph, d.font.width(glyph))
+	}
+	return string(bytes.TrimSpace(b.Bytes()))
+}
+
+// toUnicode возвращает CMap соответствия глифов символам, чтобы текст документа
+// можно было копировать и искать
+func (d *Document) toUnicode() []byte {
+	var b bytes.Buffer
+	b.WriteString("/CIDInit /ProcSet findresource begin\n12 dict begin\nbegincmap\n" +
+		"/CIDSystemInfo << /Registry (Adobe) /Ordering (UCS) /Supplement 0 >> def\n" +
+		"/CMapName /Adobe-Identity-UCS def\n/CMapType 2 def\n" +
+		"1 begincodespacerange\n<0000> <FFFF>\nendcodespacerange\n")
+
+	glyphs := d.sortedGlyphs()
+	for start := 0; start < len(glyphs); start += 100 {
+		end := min(start+100, len(glyphs))
+		fmt.Fprintf(&b, "%d beginbfchar\n", end-start)
+		for _, glyph := range glyphs[start:end] {
+			fmt.Fprintf(&b, "<%04X> <%s>\n", glyph, utf16Hex(d.used[glyph]))
+		}
+		b.WriteString("endbfchar\n")
+	}
+
+	b.WriteString("endcmap\nCMapName currentdict /CMap defineresource pop\nend\nend\n")
+	return b.Bytes()
+}
+
+// utf16Hex возвращает символ в UTF-16BE в шестнадцатеричном виде
+func utf16Hex(r rune) string {
+	if !utf8.ValidRune(r) {
+		r = utf8.RuneError
+	}
+	if r < 0x10000 {
+		return fmt.Sprintf("%04X", r)
+	}
+	r -= 0x10000
+	return fmt.Sprintf("%04X%04X", 0xD800+(r>>10), 0xDC00+(r&0x3FF))
+}
+
+// writer записывает объекты PDF и запоминает их смещения для таблицы xref
+type writer struct {
+	w       *bufio.Writer
+	offset  int
+	offsets []int // Смещения объектов по номеру (с 1)
+	err     error
+}
+
+func (w *writer) printf(format string, args ...interface{}) {
+	if w.err != nil {
+		return
+	}
+	n, err := fmt.Fprintf(w.w, format, args...)
+	w.offset += n
+	w.err = err
+}
+
+func (w *writer) write(data []byte) {
+	if w.err != nil {
+		return
+	}
+	n, err := w.w.Write(data)
+	w.offset += n
+	w.err = err
+}
+
+// begin начинает объект с номером id
+func (w *writer) begin(id int) {
+	for len(w.offsets) < id {
+		w.offsets = append(w.offsets, 0)
+	}
+	w.offsets[id-1] = w.offset
+	w.printf("%d 0 obj\n", id)
+}
+
+// object записывает объект со словарем dict
+func (w *writer) object(id int, dict string) {
+	w.begin(id)
+	w.printf("%s\nendobj\n", dict)
+}
+
+// stream записывает поток со сжатием Flate; extra - дополнительные ключи словаря
+func (w *writer) stream(id int, extra string, data []byte) {
+	var compressed bytes.Buffer
+	zw := zlib.NewWriter(&compressed)
+	zw.Write(data)
+	zw.Close()
+
+	w.begin(id)
+	if extra != "" {
+		extra = " " + extra
+	}
+	w.printf("<< /Length %d /Filter /FlateDecode%s >>\nstream\n", compressed.Len(), extra)
+	w.write(compressed.Bytes())
+	w.printf("\nendstream\nendobj\n")
+}
+
+// finish записывает таблицу xref и трейлер
+func (w *writer) finish(root int) error {
+	xref := w.offset
+	w.printf("xref\n0 %d\n0000000000 65535 f \n", len(w.offsets)+1)
+	for _, offset := range w.offsets {
+		w.printf("%010d 00000 n \n", offset)
+	}
+	w.printf("trailer\n<< /Size %d /Root %d 0 R >>\nstartxref\n%d\n%%%%EOF\n", len(w.offsets)+1, root, xref)
+
+	if w.err != nil {
+		return w.err
+	}
+	return w.w.Flush()
+}
+
+// num форматирует число для PDF: не более двух знаков после запятой, без лишних нулей
+func num(v float64) string {
+	s := strconv.FormatFloat(v, 'f', 2, 64)
+	s = strings.TrimRight(strings.TrimRight(s, "0"), ".")
+	if s == "-0" {
+		return "0"
+	}
+	return s
+}
@@ -0,0 +1,453 @@
+package pdf
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"os"
+	"sort"
+)
+
+// DefaultFontPaths пути, по которым OpenFont ищет шрифт с кириллицей,
+// если путь не указан в конфигурации
+var DefaultFontPaths = []string{
+	"/usr/share/fonts/truetype/dejavu/DejaVuSans.ttf",
+	"/usr/share/fonts/TTF/DejaVuSans.ttf",
+	"/usr/share/fonts/dejavu/DejaVuSans.ttf",
+	"/usr/share/fonts/truetype/liberation/LiberationSans-Regular.ttf",
+	"/usr/share/fonts/liberation/LiberationSans-Regular.ttf",
+	"/Library/Fonts/Arial.ttf",
+	"/System/Library/Fonts/Supplemental/Arial.ttf",
+	"C:\\Windows\\Fonts\\arial.ttf",
+}
+
+// ErrFontNotFound означает, что шрифт не указан и не найден в DefaultFontPaths
+var ErrFontNotFound = errors.New("шрифт TrueType не найден")
+
+// Font шрифт TrueType. В документ встраиваются только использованные глифы.
+type Font struct {
+	tables     map[string][]byte
+	unitsPerEm float64
+	ascent     int16
+	descent    int16
+	bbox       [4]int16
+	advances   []uint16 // Ширина глифов в единицах шрифта (hmtx)
+	numGlyphs  int
+	cmap       map[rune]uint16
+	glyphs     [][]byte // Описания глифов из glyf по номеру глифа
+}
+
+// OpenFont загружает шрифт из файла path. Пустой путь - первый существующий
+// файл из DefaultFontPaths.
+func OpenFont(path string) (*Font, error) {
+	if path == "" {
+		for _, candidate := range DefaultFontPaths {
+			if _, err := os.Stat(candidate); err == nil {
+				path = candidate
+				break
+			}
+		}
+		if path == "" {
+			return nil, ErrFontNotFound
+		}
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("ошибка чтения шрифта: %w", err)
+	}
+	font, err := ParseFont(data)
+	if err != nil {
+		return nil, fmt.Errorf("шрифт %s: %w", path, err)
+	}
+	return font, nil
+}
+
+// ParseFont разбирает шрифт TrueType (контуры glyf; шрифты CFF не поддерживаются)
+func ParseFont(data []byte) (*Font, error) {
+	if len(data) < 12 {
+		return nil, errors.New("файл слишком короткий")
+	}
+	if version := binary.BigEndian.Uint32(data); version != 0x00010000 && version != 0x74727565 {
+		return nil, errors.New("поддерживаются только шрифты TrueType")
+	}
+
+	numTables := int(binary.BigEndian.Uint16(data[4:]))
+	f := &Font{tables: make(map[string][]byte, numTables)}
+	for i := 0; i < numTables; i++ {
+		record := 12 + i*16
+		if record+16 > len(data) {
+			return nil, errors.New("повреждена таблица каталога")
+		}
+		tag := string(data[record : record+4])
+		offset := int(binary.BigEndian.Uint32(data[record+8:]))
+		length := int(binary.BigEndian.Uint32(data[record+12:]))
+		if offset < 0 || length < 0 || offset+length > len(data) {
+			return nil, fmt.Errorf("таблица %s выходит за границы файла", tag)
+		}
+		f.tables[tag] = data[offset : offset+length]
+	}
+	for _, tag := range []string{"head", "hhea", "hmtx", "maxp", "cmap", "loca", "glyf"} {
+		if f.tables[tag] == nil {
+			return nil, fmt.Errorf("нет обязательной таблицы %s", tag)
+		}
+	}
+
+	if err := f.parseMetrics(); err != nil {
+		return nil, err
+	}
+	if err := f.parseGlyphs(); err != nil {
+		return nil, err
+	}
+	if err := f.parseCmap(); err != nil {
+		return nil, err
+	}
+	return f, nil
+}
+
+// parseMetrics читает размеры шрифта и ширины глифов
+func (f *Font) parseMetrics() error {
+	head, hhea, maxp := f.tables["head"], f.tables["hhea"], f.tables["maxp"]
+	if len(head) < 54 || len(hhea) < 36 || len(maxp) < 6 {
+		return errors.New("повреждены таблицы head, hhea или maxp")
+	}
+
+	f.unitsPerEm = float64(binary.BigEndian.Uint16(head[18:]))
+	if f.unitsPerEm == 0 {
+		return errors.New("некорректный размер em")
+	}
+	for i := range f.bbox {
+		f.bbox[i] = int16(binary.BigEndian.Uint16(head[36+i*2:]))
+	}
+	f.ascent = int16(binary.BigEndian.Uint16(hhea[4:]))
+	f.descent = int16(binary.BigEndian.Uint16(hhea[6:]))
+	f.numGlyphs = int(binary.BigEndian.Uint16(maxp[4:]))
+
+	numMetrics := int(binary.BigEndian.Uint16(hhea[34:]))
+	hmtx := f.tables["hmtx"]
+	if numMetrics == 0 || numMetrics > f.numGlyphs || len(hmtx) < numMetrics*4 {
+		return errors.New("повреждена таблица hmtx")
+	}
+	f.advances = make([]uint16, f.numGlyphs)
+	for i := range f.advances {
+		if i < numMetrics {
+			f.advances[i] = binary.BigEndian.Uint16(hmtx[i*4:])
+		} else {
+			f.advances[i] = f.advances[numMetrics-1]
+		}
+	}
+	return nil
+}
+
+// parseGlyphs разбивает таблицу glyf на описания глифов по таблице loca
+func (f *Font) parseGlyphs() error {
+	loca, glyf := f.tables["loca"], f.tables["glyf"]
+	longOffsets := binary.BigEndian.Uint16(f.tables["head"][50:]) == 1
+
+	offset := func(i int) (int, bool) {
+		if longOffsets {
+			if (i+1)*4 > len(loca) {
+				return 0, false
+			}
+			return int(binary.BigEndian.Uint32(loca[i*4:])), true
+		}
+		if (i+1)*2 > len(loca) {
+			return 0, false
+		}
+		return int(binary.BigEndian.Uint16(loca[i*2:])) * 2, true
+	}
+
+	f.glyphs = make([][]byte, f.numGlyphs)
+	for i := 0; i < f.numGlyphs; i++ {
+		start, ok1 := offset(i)
+		end, ok2 := offset(i + 1)
+		if !ok1 || !ok2 || start > end || end > len(glyf) {
+			return errors.New("повреждена таблица loca")
+		}
+		f.glyphs[i] = glyf[start:end]
+	}
+	return nil
+}
+
+// parseCmap читает соответствие символов глифам из подтаблицы Unicode
+// формата 12 (все плоскости) или 4 (базовая плоскость)
+func (f *Font) parseCmap() error {
+	cmap := f.tables["cmap"]
+	if len(cmap) < 4 {
+		return errors.New("повреждена таблица cmap")
+	}
+
+	var format4, format12 []byte
+	numTables := int(binary.BigEndian.Uint16(cmap[2:]))
+	for i := 0; i < numTables; i++ {
+		record := 4 + i*8
+		if record+8 > len(cmap) {
+			break
+		}
+		platform := binary.BigEndian.Uint16(cmap[record:])
+		encoding := binary.BigEndian.Uint16(cmap[record+2:])
+		offset := int(binary.BigEndian.Uint32(cmap[record+4:]))
+		if offset+4 > len(cmap) {
+			continue
+		}
+		subtable := cmap[offset:]
+		unicode := platform == 0 || (platform == 3 && (encoding == 1 || encoding == 10))
+		if !unicode {
+			continue
+		}
+		switch binary.BigEndian.Uint16(subtable) {
+		case 4:
+			format4 = subtable
+		case 12:
+			format12 = subtable
+		}
+	}
+
+	f.cmap = make(map[rune]uint16)
+	switch {
+	case format12 != nil:
+		return f.parseCmap12(format12)
+	case format4 != nil:
+		return f.parseCmap4(format4)
+	}
+	return errors.New("нет подтаблицы cmap для Unicode")
+}
+
+// parseCmap4 читает подтаблицу cmap формата 4
+func (f *Font) parseCmap4(table []byte) error {
+	if len(table) < 14 {
+		return errors.New("повреждена подтаблица cmap формата 4")
+	}
+	segCount := int(binary.BigEndian.Uint16(table[6:])) / 2
+	endCodes := 14
+	startCodes := endCodes + segCount*2 + 2
+	deltas := startCodes + segCount*2
+	rangeOffsets := deltas + segCount*2
+	if rangeOffsets+segCount*2 > len(table) {
+		return errors.New("повреждена подтаблица cmap формата 4")
+	}
+
+	for seg := 0; seg < segCount; seg++ {
+		end := int(binary.BigEndian.Uint16(table[endCodes+seg*2:]))
+		start := int(binary.BigEndian.Uint16(table[startCodes+seg*2:]))
+		delta := binary.BigEndian.Uint16(table[deltas+seg*2:])
+		rangeOffsetPos := rangeOffsets + seg*2
+		rangeOffset := int(binary.BigEndian.Uint16(table[rangeOffsetPos:]))
+
+		for c := start; c <= end && c != 0xFFFF; c++ {
+			var glyph uint16
+			if rangeOffset == 0 {
+				glyph = uint16(c) + delta
+			} else {
+				pos := rangeOffsetPos + rangeOffset + (c-start)*2
+				if pos+2 > len(table) {
+					continue
+				}
+				glyph = binary.BigEndian.Uint16(table[pos:])
+				if glyph != 0 {
+					glyph += delta
+				}
+			}
+			if glyph != 0 && int(glyph) < f.numGlyphs {
+				f.cmap[rune(c)] = glyph
+			}
+		}
+	}
+	return nil
+}
+
+// parseCmap12 читает подтаблицу cmap формата 12
+func (f *Font) parseCmap12(table []byte) error {
+	if len(table) < 16 {
+		return errors.New("повреждена подтаблица cmap формата 12")
+	}
+	numGroups := int(binary.BigEndian.Uint32(table[12:]))
+	if 16+numGroups*12 > len(table) {
+		return errors.New("повреждена подтаблица cmap формата 12")
+	}
+
+	for i := 0; i < numGroups; i++ {
+		group := table[16+i*12:]
+		start := binary.BigEndian.Uint32(group)
+		end := binary.BigEndian.Uint32(group[4:])
+		glyph := binary.BigEndian.Uint32(group[8:])
+		for c := start; c <= end && c <= 0x10FFFF; c++ {
+			if int(glyph) < f.numGlyphs {
+				f.cmap[rune(c)] = uint16(glyph)
+			}
+			glyph++
+		}
+	}
+	return nil
+}
+
+// glyph возвращает номер глифа символа; 0 - символа нет в шрифте
+func (f *Font) glyph(r rune) uint16 {
+	return f.cmap[r]
+}
+
+// TextWidth возвращает ширину строки s в пунктах при размере шрифта size
+func (f *Font) TextWidth(s string, size float64) float64 {
+	var width float64
+	for _, r := range s {
+		width += float64(f.advances[f.glyph(r)])
+	}
+	return width * size / f.unitsPerEm
+}
+
+// width возвращает ширину глифа в тысячных долях em, как ее ожидает PDF
+func (f *Font) width(glyph uint16) int {
+	return int(float64(f.advances[glyph]) * 1000 / f.unitsPerEm)
+}
+
+// scale переводит значение из единиц шрифта в тысячные доли em
+func (f *Font) scale(v int16) int {
+	return int(float64(v) * 1000 / f.unitsPerEm)
+}
+
+// Флаги составного глифа
+const (
+	compositeArgWords      = 0x0001
+	compositeHaveScale     = 0x0008
+	compositeMoreComponent = 0x0020
+	compositeHaveXYScale   = 0x0040
+	compositeHave2x2       = 0x0080
+)
+
+// subset возвращает шрифт, в котором сохранены только глифы used и глифы,
+// из которых они составлены. Номера глифов не меняются: описания остальных
+// глифов пусты, поэтому в PDF можно использовать CIDToGIDMap Identity.
+func (f *Font) subset(used map[uint16]bool) []byte {
+	keep := map[uint16]bool{0: true}
+	var queue []uint16
+	for glyph := range used {
+		queue = append(queue, glyph)
+	}
+	for len(queue) > 0 {
+		glyph := queue[len(queue)-1]
+		queue = queue[:len(queue)-1]
+		if keep[glyph] {
+			continue
+		}
+		keep[glyph] = true
+		queue = append(queue, f.components(glyph)...)
+	}
+
+	// Описания глифов выравниваются по 4 байта; loca всегда в длинном формате
+	var glyf []byte
+	loca := make([]byte, (f.numGlyphs+1)*4)
+	for i := 0; i < f.numGlyphs; i++ {
+		binary.BigEndian.PutUint32(loca[i*4:], uint32(len(glyf)))
+		if keep[uint16(i)] {
+			glyf = append(glyf, f.glyphs[i]...)
+			for len(glyf)%4 != 0 {
+				glyf = append(glyf, 0)
+			}
+		}
+	}
+	binary.BigEndian.PutUint32(loca[f.numGlyphs*4:], uint32(len(glyf)))
+
+	head := append([]byte(nil), f.tables["head"]...)
+	binary.BigEndian.PutUint32(head[8:], 0)  // checkSumAdjustment
+	binary.BigEndian.PutUint16(head[50:], 1) // indexToLocFormat: длинный формат
+
+	tables := map[string][]byte{
+		"head": head,
+		"hhea": f.tables["hhea"],
+		"hmtx": f.tables["hmtx"],
+		"maxp": f.tables["maxp"],
+		"loca": loca,
+		"glyf": glyf,
+	}
+	// Инструкции хинтинга нужны глифам, которые на них ссылаются; cmap, OS/2
+	// и post не обязательны для PDF, но без них часть просмотрщиков отвергает шрифт
+	for _, tag := range []string{"cvt ", "fpgm", "prep", "cmap", "OS/2", "post"} {
+		if table, ok := f.tables[tag]; ok {
+			tables[tag] = table
+		}
+	}
+	return writeFont(tables)
+}
+
+// components возвращает номера глифов, из которых составлен составной глиф
+func (f *Font) components(glyph uint16) []uint16 {
+	if int(glyph) >= len(f.glyphs) {
+		return nil
+	}
+	data := f.glyphs[glyph]
+	if len(data) < 10 || int16(binary.BigEndian.Uint16(data)) >= 0 {
+		return nil
+	}
+
+	var result []uint16
+	for pos := 10; pos+4 <= len(data); {
+		flags := binary.BigEndian.Uint16(data[pos:])
+		result = append(result, binary.BigEndian.Uint16(data[pos+2:]))
+		pos += 4
+		if flags&compositeArgWords != 0 {
+			pos += 4
+		} else {
+			pos += 2
+		}
+		switch {
+		case flags&compositeHaveScale != 0:
+			pos += 2
+		case flags&compositeHaveXYScale != 0:
+			pos += 4
+		case flags&compositeHave2x2 != 0:
+			pos += 8
+		}
+		if flags&compositeMoreComponent == 0 {
+			break
+		}
+	}
+	return result
+}
+
+// writeFont собирает файл шрифта из таблиц
+func writeFont(tables map[string][]byte) []byte {
+	tags := make([]string, 0, len(tables))
+	for tag := range tables {
+		tags = append(tags, tag)
+	}
+	sort.Strings(tags)
+
+	numTables := len(tags)
+	entrySelector := 0
+	for 1<<(entrySelector+1) <= numTables {
+		entrySelector++
+	}
+	searchRange := (1 << entrySelector) * 16
+
+	out := make([]byte, 12+numTables*16)
+	binary.BigEndian.PutUint32(out, 0x00010000)
+	binary.BigEndian.PutUint16(out[4:], uint16(numTables))
+	binary.BigEndian.PutUint16(out[6:], uint16(searchRange))
+	binary.BigEndian.PutUint16(out[8:], uint16(entrySelector))
+	binary.BigEndian.PutUint16(out[10:], uint16(numTables*16-searchRange))
+
+	for i, tag := range tags {
+		table := tables[tag]
+		record := out[12+i*16:]
+		copy(record, tag)
+		binary.BigEndian.PutUint32(record[4:], checksum(table))
+		binary.BigEndian.PutUint32(record[8:], uint32(len(out)))
+		binary.BigEndian.PutUint32(record[12:], uint32(len(table)))
+		out = append(out, table...)
+		for len(out)%4 != 0 {
+			out = append(out, 0)
+		}
+	}
+	return out
+}
+
+// checksum вычисляет контрольную сумму таблицы шрифта
+func checksum(table []byte) uint32 {
+	var sum uint32
+	for i := 0; i < len(table); i += 4 {
+		var word [4]byte
+		copy(word[:], table[i:])
+		sum += binary.BigEndian.Uint32(word[:])
+	}
+	return sum
+}
@@ -0,0 +1,230 @@
+// Package personal собирает личное расписание пользователя: студенту - занятия
+// его группы с учетом изменений и подгруппы и факультативы, на которые он записан,
+// преподавателю - его занятия. По нему находится следующее занятие пользователя
+// (с учетом консультаций, о которых он получает напоминания) для виджетов,
+// напоминаний и ботов.
+package personal
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/Ultrahd-dev/student-schedule-app/backend/internal/apperr"
+	"github.com/Ultrahd-dev/student-schedule-app/backend/internal/clock"
+	"github.com/Ultrahd-dev/student-schedule-app/backend/internal/consultations"
+	"github.com/Ultrahd-dev/student-schedule-app/backend/internal/electives"
+	"github.com/Ultrahd-dev/student-schedule-app/backend/internal/schedule"
+	"github.com/Ultrahd-dev/student-schedule-app/backend/internal/users"
+	"github.com/google/uuid"
+)
+
+// Ошибки личного расписания
+var (
+	ErrNoSchedule      = apperr.New(apperr.ErrConflict, "Личное расписание доступно только студентам и преподавателям")
+	ErrStudentNotFound = apperr.New(apperr.ErrConflict, "Профиль студента не найден")
+	ErrTeacherNotFound = apperr.New(apperr.ErrConflict, "Профиль преподавателя не найден")
+)
+
+// ConsultationSource источник записи личного расписания для консультации
+// (schedule.CurrentSchedule.SourceType, SourceID - ID консультации)
+const ConsultationSource = "consultation"
+
+// lookaheadDays на сколько дней вперед ищется следующее занятие (с запасом на
+// выходные и праздники)
+const lookaheadDays = 14
+
+// Profiles профили пользователей (users.Service)
+type Profiles interface {
+	GetStudentProfile(ctx context.Context, userID uuid.UUID) (*users.Student, error)
+	GetTeacherProfile(ctx context.Context, userID uuid.UUID) (*users.Teacher, error)
+	TeacherNames(ctx context.Context, teacher *users.Teacher) ([]string, error)
+}
+
+// Schedule расписание групп и преподавателей (schedule.Service)
+type Schedule interface {
+	GetScheduleForGroupRange(ctx context.Context, groupName string, from, to time.Time) ([]schedule.CurrentSchedule, error)
+	GetScheduleForTeacher(ctx context.Context, names []string, from, to time.Time) ([]schedule.CurrentSchedule, error)
+}
+
+// Electives занятия факультативов студента (electives.Service)
+type Electives interface {
+	Lessons(ctx context.Context, userID uuid.UUID, groupName string, from, to time.Time) ([]schedule.CurrentSchedule, error)
+}
+
+// Consultations консультации преподавателей (consultations.Service)
+type Consultations interface {
+	GroupConsultations(ctx context.Context, groupName string, userID uuid.UUID) ([]consultations.Consultation, error)
+	TeacherConsultations(ctx context.Context, teacherID uuid.UUID) ([]consultations.Consultation, error)
+}
+
+// Next следующее занятие пользователя
+type Next struct {
+	Entry schedule.CurrentSchedule
+	Start time.Time // Начало занятия в часовом поясе колледжа
+	End   time.Time
+}
+
+// Service собирает личное расписание пользователей
+type Service struct {
+	profiles      Profiles
+	schedule      Schedule
+	electives     Electives
+	consultations Consultations // nil - консультации не учитываются
+	loc           *time.Location
+}
+
+// NewService создает сервис личного расписания. loc - часовой пояс колледжа.
+func NewService(profiles Profiles, scheduleSource Schedule, electiveSource Electives, loc *time.Location) *Service {
+	return &Service{
+		profiles:  profiles,
+		schedule:  scheduleSource,
+		electives: electiveSource,
+		loc:       loc,
+	}
+}
+
+// SetConsultations включает учет консультаций при поиске следующего занятия
+func (s *Service) SetConsultations(source Consultations) {
+	s.consultations = source
+}
+
+// Schedule возвращает занятия пользователя с from по to: студенту - занятия его
+// группы и подгруппы и факультативов, на которые он записан (и название группы),
+// преподавателю - его занятия
+func (s *Service) Schedule(ctx context.Context, user *users.User, from, to time.Time) ([]schedule.CurrentSchedule, string, error) {
+	switch user.Role {
+	case users.RoleStudent:
+		student, err := s.profiles.GetStudentProfile(ctx, user.ID)
+		if err != nil {
+			log.Printf("Ошибка получения профиля студента %s: %v", user.ID, err)
+			return nil, "", ErrStudentNotFound
+		}
+		entries, err := s.schedule.GetScheduleForGroupRange(ctx, student.GroupName, from, to)
+		if err != nil {
+			return nil, "", fmt.Errorf("ошибка получения расписания группы %s: %w", student.GroupName, err)
+		}
+		// Студент видит занятия всей группы и своей подгруппы
+		entries = schedule.ForSubgroup(entries, student.Subgroup)
+		// и занятия факультативов, на которые записан
+		lessons, err := s.electives.Lessons(ctx, user.ID, student.GroupName, from, to)
+		if err != nil {
+			return nil, "", fmt.Errorf("ошибка получения факультативов студента %s: %w", user.ID, err)
+		}
+		return electives.Merge(entries, lessons), student.GroupName, nil
+	case users.RoleTeacher:
+		teacher, err := s.profiles.GetTeacherProfile(ctx, user.ID)
+		if err != nil {
+			log.Printf("Ошибка получения профиля преподавателя %s: %v", user.ID, err)
+			return nil, "", ErrTeacherNotFound
+		}
+		names, err := s.profiles.TeacherNames(ctx, teacher)
+		if err != nil {
+			return nil, "", fmt.Errorf("ошибка получения вариантов имени преподавателя %s: %w", teacher.FullName, err)
+		}
+		entries, err := s.schedule.GetScheduleForTeacher(ctx, names, from, to)
+		if err != nil {
+			return nil, "", fmt.Errorf("ошибка получения расписания преподавателя %s: %w", teacher.FullName, err)
+		}
+		return entries, "", nil
+	default:
+		return nil, "", ErrNoSchedule
+	}
+}
+
+// NextLesson возвращает ближайшее занятие пользователя, которое начнется не раньше
+// now: пару, факультатив или консультацию, о которой пользователь получает
+// напоминания (преподавателю - свою консультацию). nil - занятий в ближайшие
+// две недели нет.
+func (s *Service) NextLesson(ctx context.Context, user *users.User, now time.Time) (*Next, error) {
+	from, to := s.horizon(now)
+	entries, groupName, err := s.Schedule(ctx, user, from, to)
+	if err != nil {
+		return nil, err
+	}
+
+	if s.consultations != nil {
+		var list []consultations.Consultation
+		switch user.Role {
+		case users.RoleStudent:
+			all, err := s.consultations.GroupConsultations(ctx, groupName, user.ID)
+			if err != nil {
+				return nil, err
+			}
+			for _, c := range all {
+				if c.Subscribed {
+					list = append(list, c)
+				}
+			}
+		case users.RoleTeacher:
+			if list, err = s.consultations.TeacherConsultations(ctx, user.ID); err != nil {
+				return nil, err
+			}
+		}
+		entries = append(entries, consultationEntries(list, from, to)...)
+	}
+	return s.next(entries, now), nil
+}
+
+// NextGroupLesson возвращает ближайшее занятие группы, которое начнется не раньше
+// now (для гостевого доступа). nil - занятий в ближайшие две недели нет.
+func (s *Service) NextGroupLesson(ctx context.Context, groupName string, now time.Time) (*Next, error) {
+	from, to := s.horizon(now)
+	entries, err := s.schedule.GetScheduleForGroupRange(ctx, groupName, from, to)
+	if err != nil {
+		return nil, fmt.Errorf("ошибка получения расписания группы %s: %w", groupName, err)
+	}
+	return s.next(entries, now), nil
+}
+
+// horizon возвращает период поиска следующего занятия
+func (s *Service) horizon(now time.Time) (time.Time, time.Time) {
+	from := clock.DateOf(now, s.loc)
+	return from, from.AddDate(0, 0, lookaheadDays-1)
+}
+
+// next выбирает из записей ближайшее неотмененное занятие, начинающееся не раньше now
+func (s *Service) next(entries []schedule.CurrentSchedule, now time.Time) *Next {
+	var best *Next
+	for _, entry := range entries {
+		if !entry.IsActive {
+			continue
+		}
+		start, err := clock.At(entry.Date, entry.TimeStart, s.loc)
+		if err != nil || start.Before(now) {
+			continue
+		}
+		if best != nil && !start.Before(best.Start) {
+			continue
+		}
+		end, err := clock.At(entry.Date, entry.TimeEnd, s.loc)
+		if err != nil {
+			continue
+		}
+		best = &Next{Entry: entry, Start: start, End: end}
+	}
+	return best
+}
+
+// consultationEntries разворачивает еженедельные консультации в записи расписания
+// на каждую их дату с from по to
+func consultationEntries(list []consultations.Consultation, from, to time.Time) []schedule.CurrentSchedule {
+	var entries []schedule.CurrentSchedule
+	for _, c := range list {
+		for date := c.NextDate(from); !date.After(to); date = date.AddDate(0, 0, 7) {
+			entries = append(entries, schedule.CurrentSchedule{
+				Date:       date,
+				TimeStart:  c.TimeStart,
+				TimeEnd:    c.TimeEnd,
+				Subject:    "Консультация",
+				Teacher:    c.Teacher,
+				Classroom:  c.Classroom,
+				SourceType: ConsultationSource,
+				SourceID:   c.ID,
+				IsActive:   true,
+			})
+		}
+	}
+	return entries
+}
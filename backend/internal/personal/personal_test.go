@@ -0,0 +1,106 @@
+package personal
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/Ultrahd-dev/student-schedule-app/backend/internal/consultations"
+	"github.com/Ultrahd-dev/student-schedule-app/backend/internal/schedule"
+	"github.com/Ultrahd-dev/student-schedule-app/backend/internal/users"
+	"github.com/google/uuid"
+)
+
+type fakeProfiles struct {
+	student *users.Student
+}
+
+func (f fakeProfiles) GetStudentProfile(ctx context.Context, userID uuid.UUID) (*users.Student, error) {
+	if f.student == nil {
+		return nil, errors.New("not found")
+	}
+	return f.student, nil
+}
+
+func (f fakeProfiles) GetTeacherProfile(ctx context.Context, userID uuid.UUID) (*users.Teacher, error) {
+	return nil, errors.New("not found")
+}
+
+func (f fakeProfiles) TeacherNames(ctx context.Context, teacher *users.Teacher) ([]string, error) {
+	return nil, nil
+}
+
+// fakeSchedule расписание групп; записи вне запрошенного периода не возвращаются
+type fakeSchedule map[string][]schedule.CurrentSchedule
+
+func (f fakeSchedule) GetScheduleForGroupRange(ctx context.Context, groupName string, from, to time.Time) ([]schedule.CurrentSchedule, error) {
+	var result []schedule.CurrentSchedule
+	for _, entry := range f[groupName] {
+		if !entry.Date.Before(from) && !entry.Date.After(to) {
+			result = append(result, entry)
+		}
+	}
+	return result, nil
+}
+
+func (f fakeSchedule) GetScheduleForTeacher(ctx context.Context, names []string, from, to time.Time) ([]schedule.CurrentSchedule, error) {
+	return nil, nil
+}
+
+type fakeElectives []schedule.CurrentSchedule
+
+func (f fakeElectives) Lessons(ctx context.Context, userID uuid.UUID, groupName string, from, to time.Time) ([]schedule.CurrentSchedule, error) {
+	return f, nil
+}
+
+type fakeConsultations []consultations.Consultation
+
+func (f fakeConsultations) GroupConsultations(ctx context.Context, groupName string, userID uuid.UUID) ([]consultations.Consultation, error) {
+	return f, nil
+}
+
+func (f fakeConsultations) TeacherConsultations(ctx context.Context, teacherID uuid.UUID) ([]consultations.Consultation, error) {
+	return nil, nil
+}
+
+func TestNextLesson(t *testing.T) {
+	monday := time.Date(2025, time.September, 1, 0, 0, 0, 0, time.UTC)
+	tuesday := monday.AddDate(0, 0, 1)
+	groups := fakeSchedule{"ИС-21": {
+		{Date: monday, TimeStart: "08:15", TimeEnd: "09:45", Subject: "Математика", IsActive: true},
+		{Date: monday, TimeStart: "09:55", TimeEnd: "11:25", Subject: "Физика", IsActive: false},
+		{Date: monday, TimeStart: "11:40", TimeEnd: "13:10", Subject: "Химия", Subgroup: 2, IsActive: true},
+		{Date: tuesday, TimeStart: "09:55", TimeEnd: "11:25", Subject: "История", IsActive: true},
+	}}
+	student := &users.User{ID: uuid.New(), Role: users.RoleStudent}
+	service := NewService(fakeProfiles{student: &users.Student{GroupName: "ИС-21", Subgroup: 1}},
+		groups, fakeElectives{}, time.UTC)
+	ctx := context.Background()
+
+	// Идущая пара, отмененная пара и пара другой подгруппы пропускаются
+	next, err := service.NextLesson(ctx, student, monday.Add(8*time.Hour+30*time.Minute))
+	if err != nil {
+		t.Fatalf("NextLesson: %v", err)
+	}
+	if next == nil || next.Entry.Subject != "История" || !next.Start.Equal(tuesday.Add(9*time.Hour+55*time.Minute)) {
+		t.Fatalf("ожидалась История во вторник в 09:55, получено %+v", next)
+	}
+
+	// Консультация, о которой студент получает напоминания, раньше пары
+	service.SetConsultations(fakeConsultations{
+		{ID: uuid.New(), Teacher: "Петров П.П.", Weekday: time.Monday, TimeStart: "15:00", TimeEnd: "16:00", Subscribed: true},
+		{ID: uuid.New(), Teacher: "Сидоров С.С.", Weekday: time.Monday, TimeStart: "14:00", TimeEnd: "15:00"},
+	})
+	next, err = service.NextLesson(ctx, student, monday.Add(13*time.Hour))
+	if err != nil {
+		t.Fatalf("NextLesson: %v", err)
+	}
+	if next == nil || next.Entry.SourceType != ConsultationSource || next.Entry.Teacher != "Петров П.П." {
+		t.Fatalf("ожидалась консультация Петрова, получено %+v", next)
+	}
+
+	if _, err := service.NextLesson(ctx, &users.User{Role: users.RoleAdmin}, monday); !errors.Is(err, ErrNoSchedule) {
+		t.Errorf("у администратора нет личного расписания, получено %v", err)
+	}
+}
@@ -0,0 +1,88 @@
+// Package realtime сообщает ожидающим запросам внутри экземпляра API о новых
+// событиях пользователя (уведомлениях, в том числе об изменениях расписания).
+// Используется long polling для клиентов без push: запрос подписывается на
+// пользователя и ждет сигнала вместо частого опроса базы.
+//
+// Хаб работает в памяти одного экземпляра: событие, созданное на другом
+// экземпляре, подписчик не получит, поэтому ожидающий должен дополнительно
+// перепроверять базу с некоторым периодом.
+package realtime
+
+import (
+	"sync"
+
+	"github.com/google/uuid"
+)
+
+// Hub рассылает сигналы о новых событиях подписчикам пользователя
+type Hub struct {
+	mu          sync.Mutex
+	subscribers map[uuid.UUID]map[chan struct{}]struct{}
+	closed      bool
+}
+
+// NewHub создает хаб событий
+func NewHub() *Hub {
+	return &Hub{subscribers: make(map[uuid.UUID]map[chan struct{}]struct{})}
+}
+
+// Subscribe подписывается на события пользователя userID. В канал приходит сигнал
+// после каждого Publish (несколько событий подряд могут слиться в один сигнал);
+// после Close канал закрывается. Функцию отписки нужно вызвать, когда ожидание
+// закончено.
+func (h *Hub) Subscribe(userID uuid.UUID) (<-chan struct{}, func()) {
+	ch := make(chan struct{}, 1)
+
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	if h.closed {
+		close(ch)
+		return ch, func() {}
+	}
+	if h.subscribers[userID] == nil {
+		h.subscribers[userID] = make(map[chan struct{}]struct{})
+	}
+	h.subscribers[userID][ch] = struct{}{}
+
+	return ch, func() {
+		h.mu.Lock()
+		defer h.mu.Unlock()
+		if _, ok := h.subscribers[userID][ch]; !ok {
+			return
+		}
+		delete(h.subscribers[userID], ch)
+		if len(h.subscribers[userID]) == 0 {
+			delete(h.subscribers, userID)
+		}
+	}
+}
+
+// Publish сообщает подписчикам пользователя userID о новом событии. Не блокируется.
+func (h *Hub) Publish(userID uuid.UUID) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	for ch := range h.subscribers[userID] {
+		select {
+		case ch <- struct{}{}:
+		default:
+			// Предыдущий сигнал еще не прочитан
+		}
+	}
+}
+
+// Close закрывает каналы всех подписчиков, чтобы ожидающие запросы завершились
+// при остановке сервера. Новые подписки сразу получают закрытый канал.
+func (h *Hub) Close() {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	if h.closed {
+		return
+	}
+	h.closed = true
+	for _, channels := range h.subscribers {
+		for ch := range channels {
+			close(ch)
+		}
+	}
+	h.subscribers = make(map[uuid.UUID]map[chan struct{}]struct{})
+}
@@ -0,0 +1,62 @@
+package realtime
+
+import (
+	"testing"
+
+	"github.com/google/uuid"
+)
+
+func received(ch <-chan struct{}) bool {
+	select {
+	case _, ok := <-ch:
+		return ok
+	default:
+		return false
+	}
+}
+
+func TestHubPublish(t *testing.T) {
+	hub := NewHub()
+	userID, otherID := uuid.New(), uuid.New()
+
+	ch, unsubscribe := hub.Subscribe(userID)
+	other, unsubscribeOther := hub.Subscribe(otherID)
+	defer unsubscribeOther()
+
+	hub.Publish(userID)
+	hub.Publish(userID)
+	if !received(ch) {
+		t.Fatal("подписчик не получил сигнал")
+	}
+	if received(ch) {
+		t.Error("два события подряд должны слиться в один сигнал")
+	}
+	if received(other) {
+		t.Error("сигнал получил подписчик другого пользователя")
+	}
+
+	unsubscribe()
+	hub.Publish(userID)
+	if received(ch) {
+		t.Error("сигнал получен после отписки")
+	}
+	if len(hub.subscribers[userID]) != 0 {
+		t.Error("подписка не удалена")
+	}
+}
+
+func TestHubClose(t *testing.T) {
+	hub := NewHub()
+	ch, unsubscribe := hub.Subscribe(uuid.New())
+
+	hub.Close()
+	if _, ok := <-ch; ok {
+		t.Fatal("канал подписчика не закрыт")
+	}
+	unsubscribe()
+
+	late, _ := hub.Subscribe(uuid.New())
+	if _, ok := <-late; ok {
+		t.Error("подписка после остановки должна получить закрытый канал")
+	}
+}
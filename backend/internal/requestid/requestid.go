@@ -0,0 +1,59 @@
+// Package requestid хранит идентификатор запроса в контексте, чтобы записи
+// журнала, относящиеся к одному запросу клиента, можно было найти по нему.
+// Клиент может передать свой идентификатор в метаданных gRPC (MetadataKey),
+// иначе сервер создает новый и возвращает его в заголовке ответа.
+package requestid
+
+import (
+	"context"
+	"fmt"
+	"log"
+
+	"github.com/google/uuid"
+)
+
+// MetadataKey ключ метаданных gRPC с идентификатором запроса
+const MetadataKey = "x-request-id"
+
+// maxLength максимальная длина идентификатора, принимаемого от клиента
+const maxLength = 64
+
+type contextKey struct{}
+
+// New создает новый идентификатор запроса
+func New() string {
+	return uuid.NewString()
+}
+
+// Valid проверяет идентификатор, переданный клиентом: непустой, не длиннее
+// maxLength и состоит из печатных ASCII символов без пробелов
+func Valid(id string) bool {
+	if id == "" || len(id) > maxLength {
+		return false
+	}
+	for i := 0; i < len(id); i++ {
+		if id[i] <= ' ' || id[i] > '~' {
+			return false
+		}
+	}
+	return true
+}
+
+// WithID возвращает контекст с идентификатором запроса id
+func WithID(ctx context.Context, id string) context.Context {
+	return context.WithValue(ctx, contextKey{}, id)
+}
+
+// FromContext возвращает идентификатор запроса из контекста или пустую строку
+func FromContext(ctx context.Context) string {
+	id, _ := ctx.Value(contextKey{}).(string)
+	return id
+}
+
+// Logf пишет в журнал запись с идентификатором запроса из контекста
+func Logf(ctx context.Context, format string, args ...interface{}) {
+	if id := FromContext(ctx); id != "" {
+		format = "[" + id + "] " + format
+	}
+	log.Output(2, fmt.Sprintf(format, args...))
+}
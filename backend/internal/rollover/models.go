@@ -0,0 +1,43 @@
+// Package rollover реализует переход колледжа на новый учебный год: архивацию
+// расписания прошлого года, перевод студентов на следующий курс, переименование
+// и выпуск групп, сброс подгрупп, записей на факультативы и вебхуков выпущенных групп.
+package rollover
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// DefaultMaxCourse последний курс обучения: студенты этого курса выпускаются
+const DefaultMaxCourse = 4
+
+// GroupRename переименование группы при переходе на следующий курс ("ИС-21" - "ИС-31")
+type GroupRename struct {
+	From string
+	To   string
+}
+
+// Plan параметры перехода на новый учебный год
+type Plan struct {
+	YearStart time.Time     // Первый день нового учебного года
+	MaxCourse int           // Последний курс (0 - DefaultMaxCourse)
+	Renames   []GroupRename // Переименования групп остающихся студентов
+	Retire    []string      // Группы, выпускаемые целиком независимо от курса студентов
+	DryRun    bool          // Только посчитать изменения, ничего не сохраняя
+}
+
+// Result итог перехода на новый учебный год
+type Result struct {
+	ID                 uuid.UUID
+	SnapshotsArchived  int      // Снапшоты прошлого года, данные которых перенесены в архив
+	StudentsAdvanced   int      // Студенты, переведенные на следующий курс
+	StudentsGraduated  int      // Выпускники, учетные записи которых удалены
+	StudentsRenamed    int      // Студенты переименованных групп
+	RetiredGroups      []string // Выпущенные группы
+	WebhooksRemoved    int      // Вебхуки выпущенных групп
+	EnrollmentsRemoved int      // Записи на завершившиеся факультативы
+	SubgroupsReset     int      // Студенты, у которых сброшена подгруппа
+	// GraduatedUserIDs удаленные учетные записи выпускников (для сброса кэша пользователей)
+	GraduatedUserIDs []uuid.UUID
+}
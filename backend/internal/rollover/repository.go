@@ -0,0 +1,333 @@
+package rollover
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/Ultrahd-dev/student-schedule-app/backend/internal/tenant"
+	"github.com/Ultrahd-dev/student-schedule-app/backend/internal/txn"
+	"github.com/google/uuid"
+	"github.com/lib/pq"
+)
+
+// Repository выполняет шаги перехода на новый учебный год в общей транзакции
+type Repository struct {
+	db *sql.DB
+}
+
+// NewRepository создает новый репозиторий перехода на новый учебный год
+func NewRepository(db *sql.DB) *Repository {
+	return &Repository{db: db}
+}
+
+// conn возвращает транзакцию перехода из контекста или базу
+func (r *Repository) conn(ctx context.Context) txn.Executor {
+	return txn.From(ctx, r.db)
+}
+
+// CreateRollover блокирует переходы колледжа до конца транзакции и сохраняет переход
+// на год, начинающийся yearStart. Возвращает ErrAlreadyDone, если колледж уже
+// переходил на учебный год, начинающийся меньше чем за полгода до yearStart или позже.
+func (r *Repository) CreateRollover(ctx context.Context, id uuid.UUID, yearStart time.Time, performedBy *uuid.UUID) error {
+	collegeID := tenant.CollegeID(ctx)
+	if _, err := r.conn(ctx).ExecContext(ctx, `SELECT pg_advisory_xact_lock(hashtext('academic_rollover:' || $1::text))`, collegeID); err != nil {
+		return fmt.Errorf("failed to lock academic rollovers: %w", err)
+	}
+
+	var previous time.Time
+	err := r.conn(ctx).QueryRowContext(ctx, `
+		SELECT year_start FROM academic_rollovers
+		WHERE college_id = $1 AND year_start > $2::date - INTERVAL '6 months'
+		ORDER BY year_start DESC
+		LIMIT 1`,
+		collegeID, yearStart).Scan(&previous)
+	switch {
+	case err == nil:
+		return fmt.Errorf("%w (учебный год с %s)", ErrAlreadyDone, previous.Format("02.01.2006"))
+	case !errors.Is(err, sql.ErrNoRows):
+		return fmt.Errorf("failed to check previous rollovers: %w", err)
+	}
+
+	_, err = r.conn(ctx).ExecContext(ctx, `
+		INSERT INTO academic_rollovers (id, year_start, performed_by, college_id)
+		VALUES ($1, $2, $3, $4)`,
+		id, yearStart, performedBy, collegeID)
+	if err != nil {
+		return fmt.Errorf("failed to create academic rollover: %w", err)
+	}
+	return nil
+}
+
+// SetSummary сохраняет итог перехода
+func (r *Repository) SetSummary(ctx context.Context, id uuid.UUID, summary string) error {
+	if _, err := r.conn(ctx).ExecContext(ctx, `UPDATE academic_rollovers SET summary = $2 WHERE id = $1`, id, summary); err != nil {
+		return fmt.Errorf("failed to save academic rollover summary: %w", err)
+	}
+	return nil
+}
+
+// GraduateStudents удаляет (мягко, с возможностью восстановления) учетные записи студентов
+// последнего курса и групп retire. Возвращает ID удаленных пользователей и группы,
+// в которых не осталось действующих студентов.
+func (r *Repository) GraduateStudents(ctx context.Context, maxCourse int, retire []string) ([]uuid.UUID, []string, error) {
+	query := `
+		UPDATE users u
+		SET deleted_at = NOW()
+		FROM students s
+		WHERE s.user_id = u.id AND u.college_id = $1 AND u.deleted_at IS NULL
+		  AND (s.course >= $2 OR s.group_name = ANY($3))
+		RETURNING u.id, s.group_name`
+
+	rows, err := r.conn(ctx).QueryContext(ctx, query, tenant.CollegeID(ctx), maxCourse, pq.Array(retire))
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to graduate students: %w", err)
+	}
+	defer rows.Close()
+
+	var ids []uuid.UUID
+	var groups []string
+	seen := make(map[string]bool)
+	for rows.Next() {
+		var id uuid.UUID
+		var group string
+		if err := rows.Scan(&id, &group); err != nil {
+			return nil, nil, fmt.Errorf("failed to scan graduate: %w", err)
+		}
+		ids = append(ids, id)
+		if !seen[group] {
+			seen[group] = true
+			groups = append(groups, group)
+		}
+	}
+	if err := rows.Err(); err != nil {
+		return nil, nil, fmt.Errorf("error iterating rows: %w", err)
+	}
+	rows.Close()
+
+	for _, group := range retire {
+		if !seen[group] {
+			seen[group] = true
+			groups = append(groups, group)
+		}
+	}
+
+	// Группа выпущена, если в ней не осталось активных студентов
+	emptyQuery := `
+		SELECT g FROM unnest($2::text[]) AS g
+		WHERE NOT EXISTS (
+			SELECT 1 FROM students s
+			JOIN users u ON u.id = s.user_id
+			WHERE s.group_name = g AND u.college_id = $1 AND u.deleted_at IS NULL
+		)
+		ORDER BY g`
+	emptyRows, err := r.conn(ctx).QueryContext(ctx, emptyQuery, tenant.CollegeID(ctx), pq.Array(groups))
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to find retired groups: %w", err)
+	}
+	defer emptyRows.Close()
+
+	var retired []string
+	for emptyRows.Next() {
+		var group string
+		if err := emptyRows.Scan(&group); err != nil {
+			return nil, nil, fmt.Errorf("failed to scan retired group: %w", err)
+		}
+		retired = append(retired, group)
+	}
+	if err := emptyRows.Err(); err != nil {
+		return nil, nil, fmt.Errorf("error iterating rows: %w", err)
+	}
+
+	return ids, retired, nil
+}
+
+// DeleteGroupWebhooks удаляет вебхуки групп и возвращает их количество
+func (r *Repository) DeleteGroupWebhooks(ctx context.Context, groups []string) (int, error) {
+	result, err := r.conn(ctx).ExecContext(ctx,
+		`DELETE FROM group_webhooks WHERE college_id = $1 AND group_name = ANY($2)`,
+		tenant.CollegeID(ctx), pq.Array(groups))
+	if err != nil {
+		return 0, fmt.Errorf("failed to delete group webhooks: %w", err)
+	}
+	return rowsAffected(result)
+}
+
+// AdvanceCourses переводит активных студентов курса ниже maxCourse на следующий курс
+func (r *Repository) AdvanceCourses(ctx context.Context, maxCourse int) (int, error) {
+	query := `
+		UPDATE students s
+		SET course = s.course + 1
+		FROM users u
+		WHERE s.user_id = u.id AND u.college_id = $1 AND u.deleted_at IS NULL
+		  AND s.course < $2`
+
+	result, err := r.conn(ctx).ExecContext(ctx, query, tenant.CollegeID(ctx), maxCourse)
+	if err != nil {
+		return 0, fmt.Errorf("failed to advance student courses: %w", err)
+	}
+	return rowsAffected(result)
+}
+
+// RenameGroups переименовывает группы активных студентов, вебхуки групп и группы,
+// которым доступна запись на текущие и будущие факультативы. Возвращает количество
+// студентов переименованных групп.
+func (r *Repository) RenameGroups(ctx context.Context, renames []GroupRename, yearStart time.Time) (int, error) {
+	from := make([]string, len(renames))
+	to := make([]string, len(renames))
+	for i, rename := range renames {
+		from[i], to[i] = rename.From, rename.To
+	}
+	collegeID := tenant.CollegeID(ctx)
+
+	studentsQuery := `
+		UPDATE students s
+		SET group_name = m.new_name
+		FROM unnest($2::text[], $3::text[]) AS m(old_name, new_name), users u
+		WHERE s.group_name = m.old_name AND s.user_id = u.id
+		  AND u.college_id = $1 AND u.deleted_at IS NULL`
+	result, err := r.conn(ctx).ExecContext(ctx, studentsQuery, collegeID, pq.Array(from), pq.Array(to))
+	if err != nil {
+		return 0, fmt.Errorf("failed to rename student groups: %w", err)
+	}
+	renamed, err := rowsAffected(result)
+	if err != nil {
+		return 0, err
+	}
+
+	// У группы один вебхук: при цепочке переименований ("ИС-21" - "ИС-31" - "ИС-41")
+	// новое имя может быть еще занято, поэтому вебхуки сначала получают временные
+	// имена (свои ID), а затем новые имена групп
+	webhooksQuery := `
+		UPDATE group_webhooks w
+		SET group_name = w.id::text
+		FROM (
+			SELECT id, group_name FROM group_webhooks
+			WHERE college_id = $1 AND group_name = ANY($2)
+		) o
+		WHERE w.id = o.id
+		RETURNING w.id, o.group_name`
+	rows, err := r.conn(ctx).QueryContext(ctx, webhooksQuery, collegeID, pq.Array(from))
+	if err != nil {
+		return 0, fmt.Errorf("failed to rename group webhooks: %w", err)
+	}
+	defer rows.Close()
+
+	newNames := make(map[string]string, len(renames))
+	for _, rename := range renames {
+		newNames[rename.From] = rename.To
+	}
+	var webhookIDs []uuid.UUID
+	var webhookNames []string
+	for rows.Next() {
+		var id uuid.UUID
+		var oldName string
+		if err := rows.Scan(&id, &oldName); err != nil {
+			return 0, fmt.Errorf("failed to scan group webhook: %w", err)
+		}
+		webhookIDs = append(webhookIDs, id)
+		webhookNames = append(webhookNames, newNames[oldName])
+	}
+	if err := rows.Err(); err != nil {
+		return 0, fmt.Errorf("error iterating rows: %w", err)
+	}
+	rows.Close()
+
+	if len(webhookIDs) > 0 {
+		_, err = r.conn(ctx).ExecContext(ctx, `
+			UPDATE group_webhooks w
+			SET group_name = m.new_name, updated_at = NOW()
+			FROM unnest($1::uuid[], $2::text[]) AS m(id, new_name)
+			WHERE w.id = m.id`,
+			pq.Array(webhookIDs), pq.Array(webhookNames))
+		if err != nil {
+			return 0, fmt.Errorf("failed to rename group webhooks: %w", err)
+		}
+	}
+
+	electivesQuery := `
+		UPDATE elective_courses c
+		SET group_names = ARRAY(
+			SELECT COALESCE(m.new_name, x.g)
+			FROM unnest(c.group_names) WITH ORDINALITY AS x(g, n)
+			LEFT JOIN unnest($2::text[], $3::text[]) AS m(old_name, new_name) ON m.old_name = x.g
+			ORDER BY x.n
+		)
+		WHERE c.college_id = $1 AND c.group_names && $2::text[] AND c.ends_on >= $4`
+	if _, err := r.conn(ctx).ExecContext(ctx, electivesQuery, collegeID, pq.Array(from), pq.Array(to), yearStart); err != nil {
+		return 0, fmt.Errorf("failed to rename elective course groups: %w", err)
+	}
+
+	return renamed, nil
+}
+
+// ResetSubgroups сбрасывает выбранную подгруппу активных студентов: состав подгрупп
+// формируется заново в каждом учебном году
+func (r *Repository) ResetSubgroups(ctx context.Context) (int, error) {
+	query := `
+		UPDATE students s
+		SET subgroup = 0
+		FROM users u
+		WHERE s.user_id = u.id AND u.college_id = $1 AND u.deleted_at IS NULL
+		  AND s.subgroup <> 0`
+
+	result, err := r.conn(ctx).ExecContext(ctx, query, tenant.CollegeID(ctx))
+	if err != nil {
+		return 0, fmt.Errorf("failed to reset student subgroups: %w", err)
+	}
+	return rowsAffected(result)
+}
+
+// DeleteFinishedEnrollments удаляет записи на факультативы, закончившиеся до yearStart,
+// и все записи выпускников graduates
+func (r *Repository) DeleteFinishedEnrollments(ctx context.Context, yearStart time.Time, graduates []uuid.UUID) (int, error) {
+	query := `
+		DELETE FROM elective_enrollments e
+		USING elective_courses c
+		WHERE e.course_id = c.id AND c.college_id = $1
+		  AND (c.ends_on < $2 OR e.user_id = ANY($3::uuid[]))`
+
+	result, err := r.conn(ctx).ExecContext(ctx, query, tenant.CollegeID(ctx), yearStart, pq.Array(graduates))
+	if err != nil {
+		return 0, fmt.Errorf("failed to delete elective enrollments: %w", err)
+	}
+	return rowsAffected(result)
+}
+
+// ArchiveSnapshotsBefore переносит в архив данные неактивных снапшотов,
+// период которых закончился до yearStart
+func (r *Repository) ArchiveSnapshotsBefore(ctx context.Context, yearStart time.Time) (int, error) {
+	collegeID := tenant.CollegeID(ctx)
+	const scope = `
+		college_id = $1 AND period_end < $2 AND archived_at IS NULL AND COALESCE(is_active, false) = false`
+
+	archiveQuery := `
+		INSERT INTO schedule_snapshot_archive (snapshot_id, data)
+		SELECT id, data FROM schedule_snapshots
+		WHERE` + scope + `
+		ON CONFLICT (snapshot_id) DO NOTHING`
+	if _, err := r.conn(ctx).ExecContext(ctx, archiveQuery, collegeID, yearStart); err != nil {
+		return 0, fmt.Errorf("failed to archive snapshots: %w", err)
+	}
+
+	updateQuery := `
+		UPDATE schedule_snapshots
+		SET data = '{}'::jsonb, archived_at = NOW()
+		WHERE` + scope
+	result, err := r.conn(ctx).ExecContext(ctx, updateQuery, collegeID, yearStart)
+	if err != nil {
+		return 0, fmt.Errorf("failed to mark snapshots as archived: %w", err)
+	}
+	return rowsAffected(result)
+}
+
+// rowsAffected возвращает количество измененных строк
+func rowsAffected(result sql.Result) (int, error) {
+	n, err := result.RowsAffected()
+	if err != nil {
+		return 0, fmt.Errorf("failed to get affected rows: %w", err)
+	}
+	return int(n), nil
+}
@@ -0,0 +1,189 @@
+package rollover
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/Ultrahd-dev/student-schedule-app/backend/internal/apperr"
+	"github.com/Ultrahd-dev/student-schedule-app/backend/internal/audit"
+	"github.com/Ultrahd-dev/student-schedule-app/backend/internal/txn"
+	"github.com/Ultrahd-dev/student-schedule-app/backend/internal/users"
+	"github.com/google/uuid"
+)
+
+// Ошибки перехода на новый учебный год
+var (
+	ErrInvalidPlan = apperr.New(apperr.ErrValidation, "некорректные параметры перехода на новый учебный год")
+	ErrAlreadyDone = apperr.New(apperr.ErrAlreadyExists, "переход на новый учебный год уже выполнен")
+
+	// errDryRun откатывает транзакцию пробного перехода
+	errDryRun = errors.New("пробный переход")
+)
+
+// maxGroupNameLength длина поля group_name в базе
+const maxGroupNameLength = 50
+
+// Service выполняет переход колледжа на новый учебный год
+type Service struct {
+	repo       *Repository
+	auditRepo  *audit.Repository
+	userRepo   users.UserStore // Сброс кэша удаленных выпускников
+	transactor txn.Transactor
+}
+
+// NewService создает новый сервис перехода на новый учебный год
+func NewService(repo *Repository, auditRepo *audit.Repository, userRepo users.UserStore, transactor txn.Transactor) *Service {
+	return &Service{repo: repo, auditRepo: auditRepo, userRepo: userRepo, transactor: transactor}
+}
+
+// Run выполняет переход на новый учебный год в одной транзакции: либо применяются
+// все шаги, либо ни один. При plan.DryRun транзакция откатывается, а результат
+// показывает, что было бы изменено. actor - администратор, запустивший переход
+// (nil - запуск из консоли).
+func (s *Service) Run(ctx context.Context, plan Plan, actor *uuid.UUID) (*Result, error) {
+	if err := normalizePlan(&plan); err != nil {
+		return nil, err
+	}
+
+	result := &Result{ID: uuid.New()}
+	var summary string
+	err := s.transactor.Do(ctx, func(ctx context.Context) error {
+		var err error
+		if err := s.repo.CreateRollover(ctx, result.ID, plan.YearStart, actor); err != nil {
+			return err
+		}
+
+		// Сначала выпускаем студентов последнего курса: после перевода на следующий
+		// курс их уже не отличить от переведенных
+		result.GraduatedUserIDs, result.RetiredGroups, err = s.repo.GraduateStudents(ctx, plan.MaxCourse, plan.Retire)
+		if err != nil {
+			return err
+		}
+		result.StudentsGraduated = len(result.GraduatedUserIDs)
+
+		// Вебхуки выпущенных групп удаляются до переименования: имя выпущенной группы
+		// может достаться группе младшего курса
+		if result.WebhooksRemoved, err = s.repo.DeleteGroupWebhooks(ctx, result.RetiredGroups); err != nil {
+			return err
+		}
+		if result.StudentsAdvanced, err = s.repo.AdvanceCourses(ctx, plan.MaxCourse); err != nil {
+			return err
+		}
+		if len(plan.Renames) > 0 {
+			if result.StudentsRenamed, err = s.repo.RenameGroups(ctx, plan.Renames, plan.YearStart); err != nil {
+				return err
+			}
+		}
+		if result.SubgroupsReset, err = s.repo.ResetSubgroups(ctx); err != nil {
+			return err
+		}
+		if result.EnrollmentsRemoved, err = s.repo.DeleteFinishedEnrollments(ctx, plan.YearStart, result.GraduatedUserIDs); err != nil {
+			return err
+		}
+		if result.SnapshotsArchived, err = s.repo.ArchiveSnapshotsBefore(ctx, plan.YearStart); err != nil {
+			return err
+		}
+
+		summary = result.Summary()
+		if plan.DryRun {
+			return errDryRun
+		}
+
+		if err := s.repo.SetSummary(ctx, result.ID, summary); err != nil {
+			return err
+		}
+		event := &audit.Event{
+			ID:      uuid.New(),
+			Type:    audit.EventRollover,
+			ActorID: actor,
+			Details: fmt.Sprintf("учебный год с %s: %s", plan.YearStart.Format("02.01.2006"), summary),
+		}
+		return s.auditRepo.CreateEvent(ctx, event)
+	})
+	if plan.DryRun && errors.Is(err, errDryRun) {
+		return result, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	if s.userRepo != nil {
+		s.userRepo.InvalidateUsers(ctx, result.GraduatedUserIDs)
+	}
+	log.Printf("Выполнен переход на учебный год с %s: %s", plan.YearStart.Format("02.01.2006"), summary)
+	return result, nil
+}
+
+// Summary описывает итог перехода одной строкой
+func (r *Result) Summary() string {
+	summary := fmt.Sprintf(
+		"переведено студентов: %d, выпущено: %d, переименовано: %d, сброшено подгрупп: %d, "+
+			"удалено вебхуков: %d, записей на факультативы: %d, архивировано снапшотов: %d",
+		r.StudentsAdvanced, r.StudentsGraduated, r.StudentsRenamed, r.SubgroupsReset,
+		r.WebhooksRemoved, r.EnrollmentsRemoved, r.SnapshotsArchived)
+	if len(r.RetiredGroups) > 0 {
+		summary += ", выпущены группы: " + strings.Join(r.RetiredGroups, ", ")
+	}
+	return summary
+}
+
+// normalizePlan проверяет параметры перехода и убирает пробелы в названиях групп
+func normalizePlan(plan *Plan) error {
+	if plan.YearStart.IsZero() {
+		return fmt.Errorf("%w: не указано начало учебного года", ErrInvalidPlan)
+	}
+	// В базе хранится только дата
+	y, m, d := plan.YearStart.Date()
+	plan.YearStart = time.Date(y, m, d, 0, 0, 0, 0, time.UTC)
+
+	if plan.MaxCourse == 0 {
+		plan.MaxCourse = DefaultMaxCourse
+	}
+	// Курс хранится в базе в диапазоне 1-4
+	if plan.MaxCourse < 1 || plan.MaxCourse > DefaultMaxCourse {
+		return fmt.Errorf("%w: последний курс должен быть от 1 до %d", ErrInvalidPlan, DefaultMaxCourse)
+	}
+
+	retired := make(map[string]bool, len(plan.Retire))
+	retire := make([]string, 0, len(plan.Retire))
+	for _, group := range plan.Retire {
+		group = strings.TrimSpace(group)
+		if group == "" || retired[group] {
+			continue
+		}
+		retired[group] = true
+		retire = append(retire, group)
+	}
+	plan.Retire = retire
+
+	plan.Renames = append([]GroupRename(nil), plan.Renames...)
+	from := make(map[string]bool, len(plan.Renames))
+	to := make(map[string]bool, len(plan.Renames))
+	for i := range plan.Renames {
+		rename := &plan.Renames[i]
+		rename.From = strings.TrimSpace(rename.From)
+		rename.To = strings.TrimSpace(rename.To)
+		switch {
+		case rename.From == "" || rename.To == "":
+			return fmt.Errorf("%w: пустое название группы в переименовании", ErrInvalidPlan)
+		case rename.From == rename.To:
+			return fmt.Errorf("%w: группа %s переименовывается сама в себя", ErrInvalidPlan, rename.From)
+		case utf8.RuneCountInString(rename.To) > maxGroupNameLength:
+			return fmt.Errorf("%w: название группы %s длиннее %d символов", ErrInvalidPlan, rename.To, maxGroupNameLength)
+		case from[rename.From]:
+			return fmt.Errorf("%w: группа %s переименовывается дважды", ErrInvalidPlan, rename.From)
+		case to[rename.To]:
+			return fmt.Errorf("%w: две группы переименовываются в %s", ErrInvalidPlan, rename.To)
+		case retired[rename.From]:
+			return fmt.Errorf("%w: группа %s одновременно выпускается и переименовывается", ErrInvalidPlan, rename.From)
+		}
+		from[rename.From] = true
+		to[rename.To] = true
+	}
+	return nil
+}
@@ -0,0 +1,79 @@
+package schedule
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/Ultrahd-dev/student-schedule-app/backend/internal/clock"
+)
+
+// DefaultSemesterStarts начала семестров по умолчанию ("ММ-ДД")
+var DefaultSemesterStarts = []string{"09-01", "02-01"}
+
+// SemesterStart возвращает первый день семестра, в который попадает дата date,
+// по началам семестров starts в формате "ММ-ДД"
+func SemesterStart(date time.Time, starts []string) (time.Time, error) {
+	if len(starts) == 0 {
+		return time.Time{}, errors.New("не заданы начала семестров")
+	}
+
+	var result time.Time
+	for _, start := range starts {
+		parsed, err := time.Parse("01-02", start)
+		if err != nil {
+			return time.Time{}, fmt.Errorf("некорректное начало семестра %q: %w", start, err)
+		}
+		// Семестр, начавшийся в этом году, или (если его начало еще не наступило) в прошлом
+		for _, year := range []int{date.Year(), date.Year() - 1} {
+			candidate := time.Date(year, parsed.Month(), parsed.Day(), 0, 0, 0, 0, date.Location())
+			if !candidate.After(date) {
+				if candidate.After(result) {
+					result = candidate
+				}
+				break
+			}
+		}
+	}
+	return result, nil
+}
+
+// ArchivePastSemesters переносит записи актуального расписания с датами до начала
+// текущего семестра в архив. starts - начала семестров в формате "ММ-ДД".
+func (s *Service) ArchivePastSemesters(ctx context.Context, starts []string) (int64, error) {
+	before, err := SemesterStart(clock.Today(s.loc), starts)
+	if err != nil {
+		return 0, err
+	}
+
+	archived, err := s.repo.ArchiveScheduleBefore(ctx, before)
+	if err != nil {
+		return 0, fmt.Errorf("ошибка архивации расписания прошедших семестров: %w", err)
+	}
+	if archived > 0 {
+		log.Printf("Перенесено в архив записей расписания до %s: %d", before.Format("2006-01-02"), archived)
+	}
+	return archived, nil
+}
+
+// GetScheduleForGroupWithArchive получает расписание группы на дату, а если в
+// актуальном расписании записей на эту дату нет - из архива прошедших семестров.
+// Используется экранами истории; ежедневные запросы архив не читают.
+func (s *Service) GetScheduleForGroupWithArchive(ctx context.Context, groupName string, date time.Time) ([]CurrentSchedule, error) {
+	schedules, err := s.GetScheduleForGroup(ctx, groupName, date)
+	if err != nil || len(schedules) > 0 {
+		return schedules, err
+	}
+
+	date = clock.DateOf(date, s.loc)
+	schedules, err = s.repo.GetArchivedScheduleForGroup(ctx, groupName, date)
+	if err != nil {
+		return nil, fmt.Errorf("ошибка получения архивного расписания: %w", err)
+	}
+	for i := range schedules {
+		schedules[i].Date = clock.Anchor(schedules[i].Date, s.loc)
+	}
+	return schedules, nil
+}
@@ -0,0 +1,32 @@
+package schedule
+
+import (
+	"testing"
+	"time"
+)
+
+func TestSemesterStart(t *testing.T) {
+	tests := []struct {
+		date time.Time
+		want time.Time
+	}{
+		{time.Date(2025, time.October, 15, 0, 0, 0, 0, time.UTC), time.Date(2025, time.September, 1, 0, 0, 0, 0, time.UTC)},
+		{time.Date(2026, time.January, 20, 0, 0, 0, 0, time.UTC), time.Date(2025, time.September, 1, 0, 0, 0, 0, time.UTC)},
+		{time.Date(2026, time.February, 1, 0, 0, 0, 0, time.UTC), time.Date(2026, time.February, 1, 0, 0, 0, 0, time.UTC)},
+		{time.Date(2026, time.June, 30, 0, 0, 0, 0, time.UTC), time.Date(2026, time.February, 1, 0, 0, 0, 0, time.UTC)},
+	}
+	for _, tt := range tests {
+		got, err := SemesterStart(tt.date, DefaultSemesterStarts)
+		if err != nil {
+			t.Fatalf("SemesterStart(%s): %v", tt.date.Format("2006-01-02"), err)
+		}
+		if !got.Equal(tt.want) {
+			t.Errorf("SemesterStart(%s) = %s, ожидалось %s", tt.date.Format("2006-01-02"),
+				got.Format("2006-01-02"), tt.want.Format("2006-01-02"))
+		}
+	}
+
+	if _, err := SemesterStart(time.Now(), []string{"13-01"}); err == nil {
+		t.Error("ожидалась ошибка для некорректного начала семестра")
+	}
+}
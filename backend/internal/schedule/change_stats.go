@@ -0,0 +1,81 @@
+package schedule
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/Ultrahd-dev/student-schedule-app/backend/internal/clock"
+)
+
+// defaultChangeStatsTop количество строк в рейтингах статистики изменений по умолчанию
+const defaultChangeStatsTop = 10
+
+// GroupMonthChanges количество изменений группы за месяц
+type GroupMonthChanges struct {
+	GroupName     string
+	Month         time.Time // Первый день месяца
+	Total         int
+	Replacements  int
+	Cancellations int
+	Additions     int
+}
+
+// SubjectCancellations количество отмененных пар по предмету
+type SubjectCancellations struct {
+	Subject       string
+	Cancellations int
+	Groups        int // Количество затронутых групп
+}
+
+// DayReplacements количество замен за день
+type DayReplacements struct {
+	Date         time.Time
+	Replacements int
+	Groups       int // Количество затронутых групп
+}
+
+// ChangeStats статистика изменений расписания за период
+type ChangeStats struct {
+	ByGroupMonth      []GroupMonthChanges
+	CancelledSubjects []SubjectCancellations // По убыванию количества отмен
+	ReplacementDays   []DayReplacements      // По убыванию количества замен
+}
+
+// GetChangeStats возвращает статистику изменений за период [from, to]:
+// количество изменений по группам и месяцам, чаще всего отменяемые предметы
+// и дни с наибольшим количеством замен (top строк в рейтингах).
+// Учитываются действующие одобренные изменения.
+func (s *Service) GetChangeStats(ctx context.Context, from, to time.Time, top int) (*ChangeStats, error) {
+	from, to = clock.DateOf(from, s.loc), clock.DateOf(to, s.loc)
+	if to.Before(from) {
+		return nil, ErrInvalidPeriod
+	}
+	if top <= 0 || top > 100 {
+		top = defaultChangeStatsTop
+	}
+
+	log.Printf("Считаем статистику изменений с %s по %s", from.Format("2006-01-02"), to.Format("2006-01-02"))
+
+	stats := &ChangeStats{}
+	var err error
+	if stats.ByGroupMonth, err = s.repo.GetChangesByGroupMonth(ctx, from, to); err != nil {
+		return nil, fmt.Errorf("ошибка получения статистики изменений по группам: %w", err)
+	}
+	if stats.CancelledSubjects, err = s.repo.GetMostCancelledSubjects(ctx, from, to, top); err != nil {
+		return nil, fmt.Errorf("ошибка получения статистики отмен: %w", err)
+	}
+	if stats.ReplacementDays, err = s.repo.GetBusiestReplacementDays(ctx, from, to, top); err != nil {
+		return nil, fmt.Errorf("ошибка получения статистики замен: %w", err)
+	}
+
+	for i := range stats.ByGroupMonth {
+		stats.ByGroupMonth[i].Month = clock.Anchor(stats.ByGroupMonth[i].Month, s.loc)
+	}
+	for i := range stats.ReplacementDays {
+		stats.ReplacementDays[i].Date = clock.Anchor(stats.ReplacementDays[i].Date, s.loc)
+	}
+
+	return stats, nil
+}
@@ -0,0 +1,262 @@
+package schedule
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log"
+	"sort"
+
+	"github.com/Ultrahd-dev/student-schedule-app/backend/internal/bells"
+	"github.com/google/uuid"
+)
+
+// Статусы группы в сравнении снапшотов
+const (
+	GroupDiffAdded   = "added"   // Группа появилась в новом снапшоте
+	GroupDiffRemoved = "removed" // Группа отсутствует в новом снапшоте
+	GroupDiffChanged = "changed" // Расписание группы изменилось
+)
+
+// LessonChange пара, которая стоит в то же время, но отличается содержимым
+type LessonChange struct {
+	Before        Lesson
+	After         Lesson
+	ChangedFields []string // subject, teacher, classroom, time_end
+}
+
+// GroupDiff отличия расписания одной группы между двумя снапшотами
+type GroupDiff struct {
+	GroupName string
+	Status    string
+	Added     []Lesson
+	Removed   []Lesson
+	Changed   []LessonChange
+}
+
+// SnapshotDiff результат сравнения двух снапшотов.
+// В Groups попадают только группы, у которых есть отличия.
+type SnapshotDiff struct {
+	SnapshotA *ScheduleSnapshot
+	SnapshotB *ScheduleSnapshot
+	Groups    []GroupDiff
+}
+
+// CompareSnapshots сравнивает два снапшота расписания (A - старый, B - новый)
+// и возвращает отличия по группам. Архивные снапшоты сравниваются по данным из архива.
+func (s *Service) CompareSnapshots(ctx context.Context, idA, idB uuid.UUID) (*SnapshotDiff, error) {
+	log.Printf("Сравниваем снапшоты расписания %s и %s", idA, idB)
+
+	snapshotA, err := s.repo.GetSnapshotMeta(ctx, idA)
+	if err != nil {
+		return nil, fmt.Errorf("ошибка получения снапшота %s: %w", idA, err)
+	}
+	snapshotB, err := s.repo.GetSnapshotMeta(ctx, idB)
+	if err != nil {
+		return nil, fmt.Errorf("ошибка получения снапшота %s: %w", idB, err)
+	}
+
+	var dataA, dataB ScheduleData
+	if err := s.loadSnapshotData(ctx, idA, &dataA); err != nil {
+		return nil, err
+	}
+	if err := s.loadSnapshotData(ctx, idB, &dataB); err != nil {
+		return nil, err
+	}
+
+	diff := &SnapshotDiff{
+		SnapshotA: snapshotA,
+		SnapshotB: snapshotB,
+		Groups:    DiffScheduleData(&dataA, &dataB),
+	}
+
+	log.Printf("Снапшоты %s и %s отличаются для %d групп", idA, idB, len(diff.Groups))
+	return diff, nil
+}
+
+// loadSnapshotData получает и разбирает данные снапшота
+func (s *Service) loadSnapshotData(ctx context.Context, id uuid.UUID, data *ScheduleData) error {
+	raw, err := s.repo.GetSnapshotData(ctx, id)
+	if err != nil {
+		return fmt.Errorf("ошибка получения данных снапшота %s: %w", id, err)
+	}
+	if err := json.Unmarshal(raw, data); err != nil {
+		return fmt.Errorf("ошибка разбора данных снапшота %s: %w", id, err)
+	}
+	return nil
+}
+
+// DiffScheduleData сравнивает данные двух снапшотов по группам.
+// Пары сопоставляются по дню недели и времени начала.
+func DiffScheduleData(a, b *ScheduleData) []GroupDiff {
+	names := make(map[string]struct{}, len(a.Groups)+len(b.Groups))
+	for name := range a.Groups {
+		names[name] = struct{}{}
+	}
+	for name := range b.Groups {
+		names[name] = struct{}{}
+	}
+
+	sortedNames := make([]string, 0, len(names))
+	for name := range names {
+		sortedNames = append(sortedNames, name)
+	}
+	sort.Strings(sortedNames)
+
+	var diffs []GroupDiff
+	for _, name := range sortedNames {
+		daysA, inA := a.Groups[name]
+		daysB, inB := b.Groups[name]
+
+		diff := diffGroupLessons(flattenLessons(daysA), flattenLessons(daysB))
+		diff.GroupName = name
+		switch {
+		case !inA:
+			diff.Status = GroupDiffAdded
+		case !inB:
+			diff.Status = GroupDiffRemoved
+		case len(diff.Added) == 0 && len(diff.Removed) == 0 && len(diff.Changed) == 0:
+			continue
+		default:
+			diff.Status = GroupDiffChanged
+		}
+		diffs = append(diffs, diff)
+	}
+
+	return diffs
+}
+
+// lessonSlot ключ сопоставления пар: день недели и время начала
+type lessonSlot struct {
+	day       string
+	timeStart string
+}
+
+// flattenLessons собирает пары группы из всех дней, проставляя день недели
+func flattenLessons(days []DaySchedule) []Lesson {
+	var lessons []Lesson
+	for _, day := range days {
+		for _, lesson := range day.Lessons {
+			if lesson.DayOfWeek == "" {
+				lesson.DayOfWeek = day.Day
+			}
+			lessons = append(lessons, lesson)
+		}
+	}
+	return lessons
+}
+
+// diffGroupLessons сравнивает пары группы по слотам (день, время начала).
+// Совпадающие пары отбрасываются, оставшиеся в одном слоте считаются измененными,
+// а лишние - добавленными или удаленными.
+func diffGroupLessons(before, after []Lesson) GroupDiff {
+	slotsBefore := groupBySlot(before)
+	slotsAfter := groupBySlot(after)
+
+	slots := make(map[lessonSlot]struct{}, len(slotsBefore)+len(slotsAfter))
+	for slot := range slotsBefore {
+		slots[slot] = struct{}{}
+	}
+	for slot := range slotsAfter {
+		slots[slot] = struct{}{}
+	}
+
+	var diff GroupDiff
+	for _, slot := range sortSlots(slots) {
+		removed, added := subtractLessons(slotsBefore[slot], slotsAfter[slot])
+
+		paired := len(removed)
+		if len(added) < paired {
+			paired = len(added)
+		}
+		for i := 0; i < paired; i++ {
+			diff.Changed = append(diff.Changed, LessonChange{
+				Before:        removed[i],
+				After:         added[i],
+				ChangedFields: changedLessonFields(removed[i], added[i]),
+			})
+		}
+		diff.Removed = append(diff.Removed, removed[paired:]...)
+		diff.Added = append(diff.Added, added[paired:]...)
+	}
+
+	return diff
+}
+
+// groupBySlot раскладывает пары по слотам
+func groupBySlot(lessons []Lesson) map[lessonSlot][]Lesson {
+	slots := make(map[lessonSlot][]Lesson)
+	for _, lesson := range lessons {
+		slot := lessonSlot{day: lesson.DayOfWeek, timeStart: lesson.TimeStart}
+		slots[slot] = append(slots[slot], lesson)
+	}
+	return slots
+}
+
+// subtractLessons убирает пары, совпадающие в обоих списках,
+// и возвращает оставшиеся пары из before и after
+func subtractLessons(before, after []Lesson) ([]Lesson, []Lesson) {
+	remaining := append([]Lesson(nil), after...)
+	var removed []Lesson
+	for _, lesson := range before {
+		matched := false
+		for i, candidate := range remaining {
+			if candidate == lesson {
+				remaining = append(remaining[:i], remaining[i+1:]...)
+				matched = true
+				break
+			}
+		}
+		if !matched {
+			removed = append(removed, lesson)
+		}
+	}
+	return removed, remaining
+}
+
+// sortSlots упорядочивает слоты по дню недели (с понедельника) и времени начала
+func sortSlots(slots map[lessonSlot]struct{}) []lessonSlot {
+	sorted := make([]lessonSlot, 0, len(slots))
+	for slot := range slots {
+		sorted = append(sorted, slot)
+	}
+	sort.Slice(sorted, func(i, j int) bool {
+		di, dj := dayOrder(sorted[i].day), dayOrder(sorted[j].day)
+		if di != dj {
+			return di < dj
+		}
+		return sorted[i].timeStart < sorted[j].timeStart
+	})
+	return sorted
+}
+
+// dayOrder возвращает порядковый номер дня недели, начиная с понедельника.
+// Нераспознанные названия идут в конце.
+func dayOrder(name string) int {
+	day, ok := bells.ParseDayName(name)
+	if !ok {
+		return 7
+	}
+	return (int(day) + 6) % 7
+}
+
+// changedLessonFields возвращает названия полей, которыми отличаются две пары
+func changedLessonFields(before, after Lesson) []string {
+	var fields []string
+	if before.Subject != after.Subject {
+		fields = append(fields, "subject")
+	}
+	if before.Teacher != after.Teacher {
+		fields = append(fields, "teacher")
+	}
+	if before.Classroom != after.Classroom {
+		fields = append(fields, "classroom")
+	}
+	if before.TimeEnd != after.TimeEnd {
+		fields = append(fields, "time_end")
+	}
+	if before.LessonType != after.LessonType {
+		fields = append(fields, "lesson_type")
+	}
+	return fields
+}
@@ -0,0 +1,56 @@
+package schedule
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	"github.com/Ultrahd-dev/student-schedule-app/backend/internal/tenant"
+)
+
+// DataStatus сводка об актуальном расписании колледжа для публичной страницы статуса
+type DataStatus struct {
+	SnapshotName string
+	PeriodStart  time.Time
+	PeriodEnd    time.Time
+	LoadedAt     time.Time // Время последней загрузки активного снапшота
+	Groups       int       // Групп с занятиями в периоде снапшота
+	Lessons      int       // Занятий в актуальном расписании за период
+	Changes      int       // Действующих изменений за период
+}
+
+// GetDataStatus возвращает сводку по активному снапшоту колледжа из контекста.
+// Возвращает nil, если активного снапшота нет.
+func (r *Repository) GetDataStatus(ctx context.Context) (*DataStatus, error) {
+	query := `
+		SELECT s.name, s.period_start, s.period_end, COALESCE(s.updated_at, s.created_at),
+		       (SELECT COUNT(DISTINCT c.group_name) FROM current_schedule c
+		        WHERE c.college_id = s.college_id AND c.is_active = true AND c.date BETWEEN s.period_start AND s.period_end),
+		       (SELECT COUNT(*) FROM current_schedule c
+		        WHERE c.college_id = s.college_id AND c.is_active = true AND c.date BETWEEN s.period_start AND s.period_end),
+		       (SELECT COUNT(*) FROM schedule_changes ch
+		        WHERE ch.college_id = s.college_id AND ch.is_active = true AND ch.date BETWEEN s.period_start AND s.period_end)
+		FROM schedule_snapshots s
+		WHERE s.is_active = true AND s.college_id = $1
+		ORDER BY COALESCE(s.updated_at, s.created_at) DESC
+		LIMIT 1`
+
+	status := &DataStatus{}
+	err := r.reader().QueryRowContext(ctx, query, tenant.CollegeID(ctx)).Scan(
+		&status.SnapshotName,
+		&status.PeriodStart,
+		&status.PeriodEnd,
+		&status.LoadedAt,
+		&status.Groups,
+		&status.Lessons,
+		&status.Changes,
+	)
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get data status: %w", err)
+	}
+	return status, nil
+}
@@ -0,0 +1,19 @@
+package schedule
+
+import "strings"
+
+// ForLessonType оставляет занятия вида lessonType ("лекция", "практика", "лабораторная").
+// Пустой lessonType - фильтр не задан, возвращаются все занятия.
+func ForLessonType(entries []CurrentSchedule, lessonType string) []CurrentSchedule {
+	lessonType = strings.TrimSpace(lessonType)
+	if lessonType == "" {
+		return entries
+	}
+	filtered := make([]CurrentSchedule, 0, len(entries))
+	for _, entry := range entries {
+		if strings.EqualFold(entry.LessonType, lessonType) {
+			filtered = append(filtered, entry)
+		}
+	}
+	return filtered
+}
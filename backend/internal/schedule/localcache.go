@@ -0,0 +1,73 @@
+package schedule
+
+import (
+	"context"
+	"slices"
+	"sync/atomic"
+	"time"
+
+	"github.com/Ultrahd-dev/student-schedule-app/backend/internal/cache"
+	"github.com/Ultrahd-dev/student-schedule-app/backend/internal/tenant"
+	"github.com/google/uuid"
+)
+
+// dayKey ключ локального кэша: расписание группы колледжа на дату (YYYY-MM-DD)
+type dayKey struct {
+	collegeID uuid.UUID
+	group     string
+	date      string
+}
+
+// localDayCache кэш расписания групп в памяти экземпляра API. Записи сбрасываются
+// сообщениями шины cache.Invalidator, которые рассылает репозиторий после записи
+// расписания на любом экземпляре.
+type localDayCache struct {
+	entries *cache.LRU[dayKey, []CurrentSchedule]
+	// generation увеличивается при каждом сбросе: расписание, прочитанное из базы
+	// до сброса, не сохраняется в кэш после него
+	generation atomic.Uint64
+}
+
+// UseLocalCache включает кэш расписания групп в памяти на size записей со временем
+// жизни ttl. Изменения, сброс которых не дошел по шине invalidator (например, при
+// обрыве связи с Redis), становятся видны не позже чем через ttl.
+func (s *Service) UseLocalCache(size int, ttl time.Duration, invalidator *cache.Invalidator) {
+	local := &localDayCache{entries: cache.NewLRU[dayKey, []CurrentSchedule](size, ttl)}
+	invalidator.Subscribe(local.invalidate)
+	s.local = local
+}
+
+// invalidate удаляет из кэша записи, затронутые сообщением
+func (c *localDayCache) invalidate(inv cache.Invalidation) {
+	c.generation.Add(1)
+	if inv.CollegeID != uuid.Nil && inv.Group != "" && inv.Date != "" {
+		c.entries.Delete(dayKey{collegeID: inv.CollegeID, group: inv.Group, date: inv.Date})
+		return
+	}
+	c.entries.DeleteFunc(func(key dayKey) bool {
+		return inv.Covers(key.collegeID, key.group, key.date)
+	})
+}
+
+// cachedSchedule возвращает расписание группы на дату из локального кэша, а при
+// промахе загружает его load и сохраняет в кэш
+func (s *Service) cachedSchedule(ctx context.Context, groupName string, date time.Time, load func() ([]CurrentSchedule, error)) ([]CurrentSchedule, error) {
+	if s.local == nil {
+		return load()
+	}
+
+	key := dayKey{collegeID: tenant.CollegeID(ctx), group: groupName, date: date.Format("2006-01-02")}
+	if schedules, ok := s.local.entries.Get(key); ok {
+		return slices.Clone(schedules), nil
+	}
+
+	generation := s.local.generation.Load()
+	schedules, err := load()
+	if err != nil {
+		return nil, err
+	}
+	if s.local.generation.Load() == generation {
+		s.local.entries.Set(key, slices.Clone(schedules))
+	}
+	return schedules, nil
+}
@@ -0,0 +1,62 @@
+package schedule_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/Ultrahd-dev/student-schedule-app/backend/internal/cache"
+	"github.com/Ultrahd-dev/student-schedule-app/backend/internal/mocks"
+	"github.com/Ultrahd-dev/student-schedule-app/backend/internal/schedule"
+	"github.com/Ultrahd-dev/student-schedule-app/backend/internal/tenant"
+	"github.com/google/uuid"
+)
+
+func TestLocalCacheInvalidation(t *testing.T) {
+	college := uuid.New()
+	ctx := tenant.WithCollege(context.Background(), college)
+	// Не сегодня: расписание читается из current_schedule, а не из кэша дня в базе
+	date := time.Date(2020, time.September, 1, 0, 0, 0, 0, time.UTC)
+
+	subject := "Математика"
+	repo := &mocks.ScheduleStore{
+		GetCurrentScheduleForGroupFunc: func(ctx context.Context, groupName string, date time.Time) ([]schedule.CurrentSchedule, error) {
+			return []schedule.CurrentSchedule{{GroupName: groupName, Date: date, Subject: subject}}, nil
+		},
+	}
+	invalidator := cache.NewInvalidator(nil, "")
+	service := schedule.NewService(repo, time.UTC)
+	service.UseLocalCache(100, time.Hour, invalidator)
+
+	get := func() string {
+		t.Helper()
+		schedules, err := service.GetScheduleForGroup(ctx, "ИС-21", date)
+		if err != nil {
+			t.Fatalf("GetScheduleForGroup: %v", err)
+		}
+		return schedules[0].Subject
+	}
+
+	get()
+	subject = "Физика"
+	if got := get(); got != "Математика" || repo.Calls("GetCurrentScheduleForGroup") != 1 {
+		t.Fatalf("второй запрос должен прийти из кэша: %q, запросов к базе %d", got, repo.Calls("GetCurrentScheduleForGroup"))
+	}
+
+	// Сброс другой группы не затрагивает кэш
+	invalidator.Publish(ctx, cache.Invalidation{CollegeID: college, Group: "ИС-22", Date: "2020-09-01"})
+	if got := get(); got != "Математика" {
+		t.Errorf("кэш сброшен сообщением о другой группе: %q", got)
+	}
+
+	invalidator.Publish(ctx, cache.Invalidation{CollegeID: college, Group: "ИС-21", Date: "2020-09-01"})
+	if got := get(); got != "Физика" {
+		t.Errorf("после сброса ожидалось расписание из базы, получено %q", got)
+	}
+
+	subject = "Химия"
+	invalidator.Publish(ctx, cache.Invalidation{CollegeID: college, Date: "2020-09-01"})
+	if got := get(); got != "Химия" {
+		t.Errorf("после сброса всех групп на дату ожидалось расписание из базы, получено %q", got)
+	}
+}
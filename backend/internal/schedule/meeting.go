@@ -0,0 +1,129 @@
+package schedule
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"log"
+	"net/url"
+	"strings"
+
+	"github.com/Ultrahd-dev/student-schedule-app/backend/internal/apperr"
+	"github.com/Ultrahd-dev/student-schedule-app/backend/internal/clock"
+	"github.com/Ultrahd-dev/student-schedule-app/backend/internal/tenant"
+	"github.com/google/uuid"
+)
+
+// Ошибки установки ссылки на онлайн-занятие
+var (
+	ErrInvalidMeetingURL = apperr.New(apperr.ErrValidation, "некорректная ссылка на онлайн-занятие")
+	ErrEntryNotFound     = apperr.New(apperr.ErrNotFound, "занятие не найдено в расписании")
+	ErrNotOwnEntry       = apperr.New(apperr.ErrForbidden, "ссылку можно задать только для своих занятий")
+)
+
+// maxMeetingURLLength максимальная длина ссылки на онлайн-занятие
+const maxMeetingURLLength = 500
+
+// SetMeetingURL задает ссылку на онлайн-занятие для записи актуального расписания
+// (пустая ссылка делает занятие очным). teacherNames - имена, под которыми
+// преподаватель встречается в расписании: задать ссылку можно только для своих
+// занятий; nil - без проверки (администратор).
+func (s *Service) SetMeetingURL(ctx context.Context, entryID uuid.UUID, meetingURL string, teacherNames []string) (*CurrentSchedule, error) {
+	meetingURL = strings.TrimSpace(meetingURL)
+	if meetingURL != "" {
+		parsed, err := url.Parse(meetingURL)
+		if err != nil || (parsed.Scheme != "https" && parsed.Scheme != "http") || parsed.Host == "" {
+			return nil, fmt.Errorf("%w: ожидается адрес http(s)://...", ErrInvalidMeetingURL)
+		}
+		if len(meetingURL) > maxMeetingURLLength {
+			return nil, fmt.Errorf("%w: длина больше %d символов", ErrInvalidMeetingURL, maxMeetingURLLength)
+		}
+	}
+
+	entry, err := s.repo.GetCurrentScheduleEntryByID(ctx, entryID)
+	if err == sql.ErrNoRows {
+		return nil, ErrEntryNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("ошибка получения занятия: %w", err)
+	}
+
+	if teacherNames != nil {
+		own := false
+		for _, name := range teacherNames {
+			if strings.TrimSpace(entry.Teacher) == strings.TrimSpace(name) {
+				own = true
+				break
+			}
+		}
+		if !own {
+			return nil, ErrNotOwnEntry
+		}
+	}
+
+	if err := s.repo.SetMeetingURL(ctx, entry, meetingURL); err != nil {
+		return nil, fmt.Errorf("ошибка сохранения ссылки на занятие: %w", err)
+	}
+	entry.MeetingURL = meetingURL
+
+	log.Printf("Ссылка на онлайн-занятие %s группы %s %s в %s: %q",
+		entry.Subject, entry.GroupName, entry.Date.Format(clock.DateLayout), entry.TimeStart, meetingURL)
+	return entry, nil
+}
+
+// GetCurrentScheduleEntryByID получает активную запись current_schedule колледжа по ID
+func (r *Repository) GetCurrentScheduleEntryByID(ctx context.Context, id uuid.UUID) (*CurrentSchedule, error) {
+	query := `
+		SELECT id, group_name, date, time_start, time_end, subject, COALESCE(teacher, ''), COALESCE(classroom, ''),
+		       source_type, source_id, is_active, meeting_url, subgroup, lesson_type
+		FROM current_schedule
+		WHERE id = $1 AND is_active = true AND college_id = $2`
+
+	entry := &CurrentSchedule{}
+	err := r.db.QueryRowContext(ctx, query, id, tenant.CollegeID(ctx)).Scan(
+		&entry.ID,
+		&entry.GroupName,
+		&entry.Date,
+		&entry.TimeStart,
+		&entry.TimeEnd,
+		&entry.Subject,
+		&entry.Teacher,
+		&entry.Classroom,
+		&entry.SourceType,
+		&entry.SourceID,
+		&entry.IsActive,
+		&entry.MeetingURL,
+		&entry.Subgroup,
+		&entry.LessonType,
+	)
+	if err != nil {
+		return nil, err
+	}
+	entry.TimeStart = clock.NormalizeClock(entry.TimeStart)
+	entry.TimeEnd = clock.NormalizeClock(entry.TimeEnd)
+
+	return entry, nil
+}
+
+// SetMeetingURL сохраняет ссылку на онлайн-занятие и пересобирает кэш группы на дату занятия
+func (r *Repository) SetMeetingURL(ctx context.Context, entry *CurrentSchedule, meetingURL string) error {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer func() { _ = tx.Rollback() }()
+
+	query := `UPDATE current_schedule SET meeting_url = $1 WHERE id = $2 AND date = $3`
+	if _, err := tx.ExecContext(ctx, query, meetingURL, entry.ID, entry.Date); err != nil {
+		return fmt.Errorf("failed to set meeting url: %w", err)
+	}
+	if err := r.refreshDayCache(ctx, tx, entry.GroupName, entry.Date); err != nil {
+		return err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit meeting url: %w", err)
+	}
+	r.invalidate(ctx, entry.GroupName, entry.Date)
+	return nil
+}
@@ -20,8 +20,13 @@ type ScheduleSnapshot struct {
 	PeriodEnd   time.Time `db:"period_end"`
 	Data        []byte    `db:"data"` // JSON данные в байтах
 	CreatedAt   time.Time `db:"created_at"`
-	SourceURL   string    `db:"source_url"`
-	IsActive    bool      `db:"is_active"`
+	// UpdatedAt время последней повторной загрузки той же таблицы за тот же период
+	// (nil - снапшот не обновлялся)
+	UpdatedAt *time.Time `db:"updated_at"`
+	SourceURL string     `db:"source_url"`
+	IsActive  bool       `db:"is_active"`
+	// ArchivedAt время переноса данных снапшота в архив (nil - данные в основной таблице)
+	ArchivedAt *time.Time `db:"archived_at"`
 }
 
 // ScheduleChange представляет изменение в расписании
@@ -38,10 +43,80 @@ type ScheduleChange struct {
 	Classroom       string     `db:"classroom"`
 	ChangeType      string     `db:"change_type"`
 	OriginalSubject string     `db:"original_subject"`
+	Reason          string     `db:"reason"` // Причина изменения (может быть пустой)
 	CreatedAt       time.Time  `db:"created_at"`
 	IsActive        bool       `db:"is_active"`
+	LessonNumber    int        `db:"lesson_number"` // 0 - номер пары неизвестен
+	HasOverlap      bool       `db:"has_overlap"`   // Пересекается с другими занятиями группы
+	ApplyStatus     string     `db:"apply_status"`  // Статус применения к current_schedule (ChangeApply*)
+	ApplyError      string     `db:"apply_error"`   // Текст ошибки для статуса ChangeApplyError
+	AppliedAt       *time.Time `db:"applied_at"`
+	// Модерация (ChangeModeration*): в модерируемом режиме изменение применяется после одобрения
+	ModerationStatus  string     `db:"moderation_status"`
+	ModeratedBy       *uuid.UUID `db:"moderated_by"`
+	ModeratedAt       *time.Time `db:"moderated_at"`
+	ModerationComment string     `db:"moderation_comment"`
+	// Присутствие в таблице изменений: строка, исчезнувшая из таблицы, откатывается
+	Fingerprint string     `db:"fingerprint"` // Отпечаток строки таблицы (пусто - не отслеживается)
+	LastSeenAt  *time.Time `db:"last_seen_at"`
+	RevertedAt  *time.Time `db:"reverted_at"`
+	RequestID   *uuid.UUID `db:"request_id"` // Заявка преподавателя, по которой создано изменение
+	// SupersedesID ранее примененное изменение той же пары, которое это изменение перезаписало при применении
+	SupersedesID *uuid.UUID `db:"supersedes_id"`
+	Subgroup     int        `db:"subgroup"`    // Подгруппа, для которой изменяется пара (0 - вся группа)
+	LessonType   string     `db:"lesson_type"` // Вид занятия (пусто - не указан)
 }
 
+// Статусы применения изменения к current_schedule
+const (
+	ChangeApplyPending  = "pending"  // Еще не применялось
+	ChangeApplyApplied  = "applied"  // Применено
+	ChangeApplySkipped  = "skipped"  // Применять нечего (уже применено ранее)
+	ChangeApplyError    = "error"    // Ошибка применения
+	ChangeApplyReverted = "reverted" // Откачено: строка исчезла из таблицы изменений
+)
+
+// Статусы модерации изменения
+const (
+	ChangeModerationPending  = "pending"  // Ожидает проверки администратором
+	ChangeModerationApproved = "approved" // Одобрено (или модерация не требовалась)
+	ChangeModerationRejected = "rejected" // Отклонено, не применяется
+)
+
+// ChangeRequest представляет заявку преподавателя на перенос или отмену своей пары.
+// Статус заявки - один из ChangeModeration*.
+type ChangeRequest struct {
+	ID        uuid.UUID `db:"id"`
+	TeacherID uuid.UUID `db:"teacher_id"` // Пользователь-преподаватель, подавший заявку
+	Kind      string    `db:"kind"`       // ChangeRequestCancel или ChangeRequestMove
+	// Пара, которую переносят или отменяют (на момент подачи заявки)
+	GroupName string    `db:"group_name"`
+	Date      time.Time `db:"date"`
+	TimeStart string    `db:"time_start"`
+	TimeEnd   string    `db:"time_end"`
+	Subject   string    `db:"subject"`
+	Teacher   string    `db:"teacher"`
+	Classroom string    `db:"classroom"`
+	// Новый слот для переноса
+	NewDate         *time.Time `db:"new_date"`
+	NewTimeStart    string     `db:"new_time_start"`
+	NewTimeEnd      string     `db:"new_time_end"`
+	NewLessonNumber int        `db:"new_lesson_number"`
+	NewClassroom    string     `db:"new_classroom"`
+	Comment         string     `db:"comment"`
+	Status          string     `db:"status"`
+	ReviewedBy      *uuid.UUID `db:"reviewed_by"`
+	ReviewedAt      *time.Time `db:"reviewed_at"`
+	ReviewComment   string     `db:"review_comment"`
+	CreatedAt       time.Time  `db:"created_at"`
+}
+
+// Виды заявок преподавателей
+const (
+	ChangeRequestCancel = "cancel" // Отмена пары
+	ChangeRequestMove   = "move"   // Перенос пары в другой слот
+)
+
 // CurrentSchedule представляет актуальное расписание
 // Соответствует таблице current_schedule из ТЗ
 type CurrentSchedule struct {
@@ -56,6 +131,9 @@ type CurrentSchedule struct {
 	SourceType string    `db:"source_type"`
 	SourceID   uuid.UUID `db:"source_id"`
 	IsActive   bool      `db:"is_active"`
+	MeetingURL string    `db:"meeting_url"` // Ссылка на онлайн-занятие (пусто - очное)
+	Subgroup   int       `db:"subgroup"`    // Подгруппа (0 - занятие всей группы)
+	LessonType string    `db:"lesson_type"` // Вид занятия: лекция, практика, лабораторная (пусто - не указан)
 }
 
 // Notification представляет уведомление для пользователя
@@ -95,6 +173,9 @@ type Lesson struct {
 	TimeStart string `json:"time_start"`
 	TimeEnd   string `json:"time_end"`
 	DayOfWeek string `json:"day_of_week"`
+	Subgroup  int    `json:"subgroup,omitempty"` // Подгруппа (0 - занятие всей группы)
+	// LessonType вид занятия: лекция, практика, лабораторная (пусто - не указан)
+	LessonType string `json:"lesson_type,omitempty"`
 }
 
 // Value реализует интерфейс driver.Valuer для ScheduleData
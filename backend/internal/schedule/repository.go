@@ -5,13 +5,32 @@ package schedule
 import (
 	"context"
 	"database/sql"
+	"encoding/json"
 	"fmt"
 	"time"
+
+	"github.com/Ultrahd-dev/student-schedule-app/backend/internal/apperr"
+	"github.com/Ultrahd-dev/student-schedule-app/backend/internal/cache"
+	"github.com/Ultrahd-dev/student-schedule-app/backend/internal/clock"
+	"github.com/Ultrahd-dev/student-schedule-app/backend/internal/tenant"
+	"github.com/Ultrahd-dev/student-schedule-app/backend/internal/txn"
+	"github.com/google/uuid"
+	"github.com/lib/pq"
 )
 
-// Repository предоставляет доступ к хранению расписания
+// ReadRouter выбирает соединение для запросов на чтение (реплику или основную базу)
+type ReadRouter interface {
+	Reader() *sql.DB
+}
+
+// Repository предоставляет доступ к хранению расписания.
+// Запросы ограничены колледжем из контекста (tenant.CollegeID); записи,
+// выбираемые по ID из уже ограниченных запросов, повторно не проверяются.
 type Repository struct {
-	db *sql.DB
+	db      *sql.DB
+	replica ReadRouter // Реплики для частых запросов на чтение (может быть nil)
+	// invalidator рассылает сброс локальных кэшей расписания на экземплярах API (может быть nil)
+	invalidator *cache.Invalidator
 }
 
 // NewRepository создает новый репозиторий расписания
@@ -19,62 +38,305 @@ func NewRepository(db *sql.DB) *Repository {
 	return &Repository{db: db}
 }
 
-// CreateSnapshot создает новый снапшот расписания
-func (r *Repository) CreateSnapshot(ctx context.Context, snapshot *ScheduleSnapshot) error {
+// UseReplicas направляет частые запросы на чтение актуального расписания
+// и кэша расписания на сегодня на реплики
+func (r *Repository) UseReplicas(router ReadRouter) {
+	r.replica = router
+}
+
+// SetInvalidator включает рассылку сброса локальных кэшей расписания группы
+// на дату после каждой записи актуального расписания
+func (r *Repository) SetInvalidator(invalidator *cache.Invalidator) {
+	r.invalidator = invalidator
+}
+
+// invalidate рассылает сброс кэшей расписания группы на дату (пустая группа -
+// всех групп) после коммита транзакции из контекста
+func (r *Repository) invalidate(ctx context.Context, groupName string, date time.Time) {
+	if r.invalidator == nil {
+		return
+	}
+	inv := cache.Invalidation{CollegeID: tenant.CollegeID(ctx), Group: groupName, Date: date.Format("2006-01-02")}
+	txn.AfterCommit(ctx, func() {
+		r.invalidator.Publish(context.WithoutCancel(ctx), inv)
+	})
+}
+
+// reader возвращает соединение для запросов, которые можно выполнять на реплике
+func (r *Repository) reader() *sql.DB {
+	if r.replica == nil {
+		return r.db
+	}
+	return r.replica.Reader()
+}
+
+// conn возвращает транзакцию из контекста или основную базу
+func (r *Repository) conn(ctx context.Context) txn.Executor {
+	return txn.From(ctx, r.db)
+}
+
+// SaveSnapshot сохраняет снапшот расписания (в транзакции из контекста, если она есть,
+// см. txn.Manager). Снапшот определяется таблицей-источником и периодом: если снапшот
+// той же таблицы за тот же период уже есть, он обновляется, и snapshot получает его ID
+// и время создания. Возвращает true, если снапшот создан.
+func (r *Repository) SaveSnapshot(ctx context.Context, snapshot *ScheduleSnapshot) (bool, error) {
+	// Данные обновленного снапшота снова хранятся в основной таблице, а не в архиве
 	query := `
-		INSERT INTO schedule_snapshots 
-		(id, name, period_start, period_end, data, source_url, is_active)
-		VALUES ($1, $2, $3, $4, $5, $6, $7)
-		RETURNING created_at`
+		WITH saved AS (
+			INSERT INTO schedule_snapshots
+			(id, name, period_start, period_end, data, source_url, is_active, college_id)
+			VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
+			ON CONFLICT (college_id, source_url, period_start, period_end) DO UPDATE
+			SET name = EXCLUDED.name, data = EXCLUDED.data, is_active = EXCLUDED.is_active,
+			    archived_at = NULL, updated_at = NOW()
+			RETURNING id, created_at, updated_at
+		), unarchived AS (
+			DELETE FROM schedule_snapshot_archive a
+			USING saved
+			WHERE a.snapshot_id = saved.id
+		)
+		SELECT id, created_at, updated_at FROM saved`
 
-	var createdAt time.Time
-	err := r.db.QueryRowContext(ctx, query,
+	err := r.conn(ctx).QueryRowContext(ctx, query,
 		snapshot.ID,
 		snapshot.Name,
 		snapshot.PeriodStart,
 		snapshot.PeriodEnd,
 		snapshot.Data,
 		snapshot.SourceURL,
-		snapshot.IsActive).
-		Scan(&createdAt)
+		snapshot.IsActive,
+		tenant.CollegeID(ctx)).
+		Scan(&snapshot.ID, &snapshot.CreatedAt, &snapshot.UpdatedAt)
+	if err != nil {
+		return false, fmt.Errorf("failed to save schedule snapshot: %w", err)
+	}
+
+	snapshot.ArchivedAt = nil
+	return snapshot.UpdatedAt == nil, nil
+}
+
+// GetActiveSnapshotMeta получает метаданные последнего загруженного (созданного или
+// обновленного) активного снапшота расписания (без данных, см. GetSnapshotData)
+func (r *Repository) GetActiveSnapshotMeta(ctx context.Context) (*ScheduleSnapshot, error) {
+	query := `
+		SELECT id, name, period_start, period_end, created_at, updated_at, COALESCE(source_url, ''), COALESCE(is_active, false), archived_at
+		FROM schedule_snapshots
+		WHERE is_active = true AND college_id = $1
+		ORDER BY COALESCE(updated_at, created_at) DESC
+		LIMIT 1`
 
+	snapshot, err := scanSnapshotMeta(r.db.QueryRowContext(ctx, query, tenant.CollegeID(ctx)))
 	if err != nil {
-		return fmt.Errorf("failed to create schedule snapshot: %w", err)
+		if err == sql.ErrNoRows {
+			return nil, fmt.Errorf("no active schedule snapshot found")
+		}
+		return nil, fmt.Errorf("failed to get active schedule snapshot: %w", err)
 	}
 
-	snapshot.CreatedAt = createdAt
-	return nil
+	return snapshot, nil
 }
 
-// GetActiveSnapshot получает активный снапшот расписания
-func (r *Repository) GetActiveSnapshot(ctx context.Context) (*ScheduleSnapshot, error) {
+// FindSnapshotIDForDate находит снапшот, период которого покрывает дату.
+// Предпочтение отдается активному и самому свежему. Возвращает nil, если такого снапшота нет.
+func (r *Repository) FindSnapshotIDForDate(ctx context.Context, date time.Time) (*uuid.UUID, error) {
 	query := `
-		SELECT id, name, period_start, period_end, data, created_at, source_url, is_active
+		SELECT id
 		FROM schedule_snapshots
-		WHERE is_active = true
-		ORDER BY created_at DESC
+		WHERE $1 BETWEEN period_start AND period_end AND college_id = $2
+		ORDER BY COALESCE(is_active, false) DESC, COALESCE(updated_at, created_at) DESC
 		LIMIT 1`
 
+	var id uuid.UUID
+	err := r.db.QueryRowContext(ctx, query, date, tenant.CollegeID(ctx)).Scan(&id)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to find snapshot for date: %w", err)
+	}
+
+	return &id, nil
+}
+
+// GetSnapshotMeta получает метаданные снапшота по ID (без данных, см. GetSnapshotData)
+func (r *Repository) GetSnapshotMeta(ctx context.Context, id uuid.UUID) (*ScheduleSnapshot, error) {
+	query := `
+		SELECT id, name, period_start, period_end, created_at, updated_at, COALESCE(source_url, ''), COALESCE(is_active, false), archived_at
+		FROM schedule_snapshots
+		WHERE id = $1 AND college_id = $2`
+
+	snapshot, err := scanSnapshotMeta(r.db.QueryRowContext(ctx, query, id, tenant.CollegeID(ctx)))
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, fmt.Errorf("schedule snapshot %s not found: %w", id, apperr.ErrNotFound)
+		}
+		return nil, fmt.Errorf("failed to get schedule snapshot: %w", err)
+	}
+
+	return snapshot, nil
+}
+
+// GetSnapshotData получает JSON данные снапшота по ID.
+// Для архивных снапшотов данные берутся из schedule_snapshot_archive.
+func (r *Repository) GetSnapshotData(ctx context.Context, id uuid.UUID) ([]byte, error) {
+	query := `
+		SELECT COALESCE(a.data, s.data)
+		FROM schedule_snapshots s
+		LEFT JOIN schedule_snapshot_archive a ON a.snapshot_id = s.id
+		WHERE s.id = $1 AND s.college_id = $2`
+
+	var data []byte
+	err := r.db.QueryRowContext(ctx, query, id, tenant.CollegeID(ctx)).Scan(&data)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, fmt.Errorf("schedule snapshot %s not found: %w", id, apperr.ErrNotFound)
+		}
+		return nil, fmt.Errorf("failed to get schedule snapshot data: %w", err)
+	}
+
+	return data, nil
+}
+
+// GetSnapshotGroupData получает из данных снапшота только расписание группы
+// (JSON массив дней), выбирая его запросом по пути в JSONB без чтения всего снапшота
+// в приложение. Возвращает nil, если группы в снапшоте нет.
+func (r *Repository) GetSnapshotGroupData(ctx context.Context, id uuid.UUID, groupName string) ([]byte, error) {
+	query := `
+		SELECT COALESCE(a.data, s.data) #> ARRAY['groups', $3]::text[]
+		FROM schedule_snapshots s
+		LEFT JOIN schedule_snapshot_archive a ON a.snapshot_id = s.id
+		WHERE s.id = $1 AND s.college_id = $2`
+
+	var data []byte
+	err := r.db.QueryRowContext(ctx, query, id, tenant.CollegeID(ctx), groupName).Scan(&data)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, fmt.Errorf("schedule snapshot %s not found: %w", id, apperr.ErrNotFound)
+		}
+		return nil, fmt.Errorf("failed to get snapshot group data: %w", err)
+	}
+
+	return data, nil
+}
+
+// rowScanner строка результата запроса (*sql.Row или *sql.Rows)
+type rowScanner interface {
+	Scan(dest ...interface{}) error
+}
+
+// scanSnapshotMeta читает метаданные снапшота из строки результата
+func scanSnapshotMeta(row rowScanner) (*ScheduleSnapshot, error) {
 	snapshot := &ScheduleSnapshot{}
-	err := r.db.QueryRowContext(ctx, query).Scan(
+	err := row.Scan(
 		&snapshot.ID,
 		&snapshot.Name,
 		&snapshot.PeriodStart,
 		&snapshot.PeriodEnd,
-		&snapshot.Data,
 		&snapshot.CreatedAt,
+		&snapshot.UpdatedAt,
 		&snapshot.SourceURL,
 		&snapshot.IsActive,
+		&snapshot.ArchivedAt,
 	)
+	if err != nil {
+		return nil, err
+	}
+	return snapshot, nil
+}
+
+// ListSnapshots получает историю снапшотов (без данных расписания), от новых к старым
+func (r *Repository) ListSnapshots(ctx context.Context, limit int) ([]ScheduleSnapshot, error) {
+	query := `
+		SELECT id, name, period_start, period_end, created_at, updated_at, COALESCE(source_url, ''), COALESCE(is_active, false), archived_at
+		FROM schedule_snapshots
+		WHERE college_id = $2
+		ORDER BY created_at DESC
+		LIMIT $1`
 
+	rows, err := r.db.QueryContext(ctx, query, limit, tenant.CollegeID(ctx))
 	if err != nil {
-		if err == sql.ErrNoRows {
-			return nil, fmt.Errorf("no active schedule snapshot found")
+		return nil, fmt.Errorf("failed to list schedule snapshots: %w", err)
+	}
+	defer rows.Close()
+
+	var snapshots []ScheduleSnapshot
+	for rows.Next() {
+		snapshot, err := scanSnapshotMeta(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan schedule snapshot: %w", err)
 		}
-		return nil, fmt.Errorf("failed to get active schedule snapshot: %w", err)
+		snapshots = append(snapshots, *snapshot)
 	}
 
-	return snapshot, nil
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating rows: %w", err)
+	}
+
+	return snapshots, nil
+}
+
+// ArchiveOldSnapshots переносит данные всех снапшотов, кроме keep последних
+// и активных, в архивную таблицу. Возвращает количество заархивированных снапшотов.
+func (r *Repository) ArchiveOldSnapshots(ctx context.Context, keep int) (int, error) {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return 0, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer func() { _ = tx.Rollback() }()
+
+	// Выбираем кандидатов и блокируем их, чтобы параллельный запуск не заархивировал их дважды
+	selectQuery := `
+		SELECT id FROM schedule_snapshots
+		WHERE id IN (
+			SELECT id FROM schedule_snapshots
+			WHERE college_id = $2
+			ORDER BY COALESCE(updated_at, created_at) DESC
+			OFFSET $1
+		)
+		AND archived_at IS NULL AND COALESCE(is_active, false) = false
+		FOR UPDATE`
+
+	rows, err := tx.QueryContext(ctx, selectQuery, keep, tenant.CollegeID(ctx))
+	if err != nil {
+		return 0, fmt.Errorf("failed to select snapshots for archival: %w", err)
+	}
+	var ids []uuid.UUID
+	for rows.Next() {
+		var id uuid.UUID
+		if err := rows.Scan(&id); err != nil {
+			rows.Close()
+			return 0, fmt.Errorf("failed to scan snapshot id: %w", err)
+		}
+		ids = append(ids, id)
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return 0, fmt.Errorf("error iterating rows: %w", err)
+	}
+
+	for _, id := range ids {
+		archiveQuery := `
+			INSERT INTO schedule_snapshot_archive (snapshot_id, data)
+			SELECT id, data FROM schedule_snapshots WHERE id = $1
+			ON CONFLICT (snapshot_id) DO NOTHING`
+		if _, err := tx.ExecContext(ctx, archiveQuery, id); err != nil {
+			return 0, fmt.Errorf("failed to archive snapshot %s: %w", id, err)
+		}
+
+		updateQuery := `
+			UPDATE schedule_snapshots
+			SET data = '{}'::jsonb, archived_at = NOW()
+			WHERE id = $1`
+		if _, err := tx.ExecContext(ctx, updateQuery, id); err != nil {
+			return 0, fmt.Errorf("failed to mark snapshot %s as archived: %w", id, err)
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return 0, fmt.Errorf("failed to commit snapshot archival: %w", err)
+	}
+
+	return len(ids), nil
 }
 
 // CreateChange создает новое изменение в расписании
@@ -82,9 +344,13 @@ func (r *Repository) GetActiveSnapshot(ctx context.Context) (*ScheduleSnapshot,
 func (r *Repository) CreateChange(ctx context.Context, change *ScheduleChange) error {
 	query := `
 		INSERT INTO schedule_changes 
-		(id, snapshot_id, group_name, date, time_start, time_end, subject, teacher, classroom, change_type, original_subject, is_active)
-		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12)
-		RETURNING created_at`
+		(id, snapshot_id, group_name, date, time_start, time_end, subject, teacher, classroom, change_type, original_subject, is_active,
+		 lesson_number, has_overlap, moderation_status, fingerprint, last_seen_at, request_id, moderated_by, moderated_at, reason, college_id,
+		 subgroup, lesson_type)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, NULLIF($13::smallint, 0), $14, COALESCE(NULLIF($15, ''), 'approved'),
+		        NULLIF($16::text, ''), CASE WHEN $16::text <> '' THEN NOW() END, $17, $18, CASE WHEN $18::uuid IS NOT NULL THEN NOW() END,
+		        NULLIF($19, ''), $20, $21, $22)
+		RETURNING created_at, last_seen_at`
 
 	var createdAt time.Time
 	err := r.db.QueryRowContext(ctx, query,
@@ -99,8 +365,18 @@ func (r *Repository) CreateChange(ctx context.Context, change *ScheduleChange) e
 		change.Classroom,
 		change.ChangeType,
 		change.OriginalSubject,
-		change.IsActive).
-		Scan(&createdAt)
+		change.IsActive,
+		change.LessonNumber,
+		change.HasOverlap,
+		change.ModerationStatus,
+		change.Fingerprint,
+		change.RequestID,
+		change.ModeratedBy,
+		change.Reason,
+		tenant.CollegeID(ctx),
+		change.Subgroup,
+		change.LessonType).
+		Scan(&createdAt, &change.LastSeenAt)
 
 	if err != nil {
 		return fmt.Errorf("failed to create schedule change: %w", err)
@@ -113,12 +389,13 @@ func (r *Repository) CreateChange(ctx context.Context, change *ScheduleChange) e
 // GetCurrentScheduleForGroup получает актуальное расписание для группы на определенную дату
 func (r *Repository) GetCurrentScheduleForGroup(ctx context.Context, groupName string, date time.Time) ([]CurrentSchedule, error) {
 	query := `
-		SELECT id, group_name, date, time_start, time_end, subject, teacher, classroom, source_type, source_id, is_active
+		SELECT id, group_name, date, time_start, time_end, subject, teacher, classroom, source_type, source_id, is_active,
+		       meeting_url, subgroup, lesson_type
 		FROM current_schedule
-		WHERE group_name = $1 AND date = $2 AND is_active = true
+		WHERE group_name = $1 AND date = $2 AND is_active = true AND college_id = $3
 		ORDER BY time_start`
 
-	rows, err := r.db.QueryContext(ctx, query, groupName, date)
+	rows, err := r.reader().QueryContext(ctx, query, groupName, date, tenant.CollegeID(ctx))
 	if err != nil {
 		return nil, fmt.Errorf("failed to get current schedule for group: %w", err)
 	}
@@ -139,10 +416,15 @@ func (r *Repository) GetCurrentScheduleForGroup(ctx context.Context, groupName s
 			&schedule.SourceType,
 			&schedule.SourceID,
 			&schedule.IsActive,
+			&schedule.MeetingURL,
+			&schedule.Subgroup,
+			&schedule.LessonType,
 		)
 		if err != nil {
 			return nil, fmt.Errorf("failed to scan current schedule: %w", err)
 		}
+		schedule.TimeStart = clock.NormalizeClock(schedule.TimeStart)
+		schedule.TimeEnd = clock.NormalizeClock(schedule.TimeEnd)
 		schedules = append(schedules, schedule)
 	}
 
@@ -153,131 +435,1510 @@ func (r *Repository) GetCurrentScheduleForGroup(ctx context.Context, groupName s
 	return schedules, nil
 }
 
-// BeginTx начинает транзакцию
-func (r *Repository) BeginTx(ctx context.Context) (*sql.Tx, error) {
-	return r.db.BeginTx(ctx, nil)
+// GetCurrentScheduleForGroupRange получает актуальное расписание группы за период [from, to]
+func (r *Repository) GetCurrentScheduleForGroupRange(ctx context.Context, groupName string, from, to time.Time) ([]CurrentSchedule, error) {
+	query := `
+		SELECT id, group_name, date, time_start, time_end, subject, teacher, classroom, source_type, source_id, is_active,
+		       meeting_url, subgroup, lesson_type
+		FROM current_schedule
+		WHERE group_name = $1 AND date BETWEEN $2 AND $3 AND is_active = true AND college_id = $4
+		ORDER BY date, time_start`
+
+	return r.queryCurrentSchedule(ctx, query, groupName, from, to, tenant.CollegeID(ctx))
+}
+
+// GetCurrentScheduleForTeacher получает актуальное расписание преподавателя за период [from, to]
+func (r *Repository) GetCurrentScheduleForTeacher(ctx context.Context, teacher string, from, to time.Time) ([]CurrentSchedule, error) {
+	return r.GetCurrentScheduleForTeachers(ctx, []string{teacher}, from, to)
 }
 
-// GetCurrentScheduleEntry получает запись из current_schedule по группе, дате и времени начала
-// ИСПРАВЛЕНО: Добавлен ctx как параметр
-func (r *Repository) GetCurrentScheduleEntry(ctx context.Context, tx *sql.Tx, groupName string, date time.Time, timeStart string) (*CurrentSchedule, error) {
+// GetCurrentScheduleForTeachers получает актуальное расписание за период [from, to]
+// по любому из имен преподавателя teachers
+func (r *Repository) GetCurrentScheduleForTeachers(ctx context.Context, teachers []string, from, to time.Time) ([]CurrentSchedule, error) {
 	query := `
-		SELECT id, group_name, date, time_start, time_end, subject, teacher, classroom, source_type, source_id, is_active
+		SELECT id, group_name, date, time_start, time_end, subject, teacher, classroom, source_type, source_id, is_active,
+		       meeting_url, subgroup, lesson_type
 		FROM current_schedule
-		WHERE group_name = $1 AND date = $2 AND time_start = $3 AND is_active = true`
+		WHERE teacher = ANY($1) AND date BETWEEN $2 AND $3 AND is_active = true AND college_id = $4
+		ORDER BY date, time_start, group_name`
 
-	entry := &CurrentSchedule{}
-	// ИСПРАВЛЕНО: Используем QueryRowContext с переданным ctx
-	err := tx.QueryRowContext(ctx, query, groupName, date, timeStart).Scan(
-		&entry.ID,
-		&entry.GroupName,
-		&entry.Date,
-		&entry.TimeStart,
-		&entry.TimeEnd,
-		&entry.Subject,
-		&entry.Teacher,
-		&entry.Classroom,
-		&entry.SourceType,
-		&entry.SourceID,
-		&entry.IsActive,
-	)
+	return r.queryCurrentSchedule(ctx, query, pq.Array(teachers), from, to, tenant.CollegeID(ctx))
+}
 
-	if err != nil {
-		return nil, err
-	}
+// GetCurrentScheduleForClassrooms получает расписание на дату date в аудиториях classrooms
+// (имена в нижнем регистре без пробелов по краям), включая отмененные пары
+func (r *Repository) GetCurrentScheduleForClassrooms(ctx context.Context, classrooms []string, date time.Time) ([]CurrentSchedule, error) {
+	query := `
+		SELECT id, group_name, date, time_start, time_end, subject, teacher, classroom, source_type, source_id, is_active,
+		       meeting_url, subgroup, lesson_type
+		FROM current_schedule
+		WHERE college_id = $1 AND date = $2 AND lower(btrim(classroom)) = ANY($3)
+		ORDER BY time_start, classroom, group_name`
 
-	return entry, nil
+	return r.queryCurrentSchedule(ctx, query, tenant.CollegeID(ctx), date, pq.Array(classrooms))
 }
 
-// UpdateCurrentScheduleEntry обновляет запись в current_schedule
-// ИСПРАВЛЕНО: Добавлен ctx как параметр
-func (r *Repository) UpdateCurrentScheduleEntry(ctx context.Context, tx *sql.Tx, entry *CurrentSchedule) error {
+// HasTeacherLessonsWithGroup проверяет, есть ли в актуальном расписании занятия
+// преподавателя (под любым из имен teachers) с группой groupName
+func (r *Repository) HasTeacherLessonsWithGroup(ctx context.Context, teachers []string, groupName string) (bool, error) {
 	query := `
-		UPDATE current_schedule
-		SET subject = $1, teacher = $2, classroom = $3, source_type = $4, source_id = $5, is_active = $6
-		WHERE id = $7`
+		SELECT EXISTS (
+			SELECT 1 FROM current_schedule
+			WHERE teacher = ANY($1) AND group_name = $2 AND is_active = true AND college_id = $3
+		)`
 
-	// ИСПРАВЛЕНО: Используем ExecContext с переданным ctx
-	_, err := tx.ExecContext(ctx, query,
-		entry.Subject,
-		entry.Teacher,
-		entry.Classroom,
-		entry.SourceType,
-		entry.SourceID,
-		entry.IsActive,
-		entry.ID,
-	)
+	var exists bool
+	if err := r.db.QueryRowContext(ctx, query, pq.Array(teachers), groupName, tenant.CollegeID(ctx)).Scan(&exists); err != nil {
+		return false, fmt.Errorf("failed to check teacher lessons with group: %w", err)
+	}
 
-	return err
+	return exists, nil
 }
 
-// CreateCurrentScheduleEntry создает новую запись в current_schedule
-// ИСПРАВЛЕНО: Добавлен ctx как параметр
-func (r *Repository) CreateCurrentScheduleEntry(ctx context.Context, tx *sql.Tx, entry *CurrentSchedule) error {
-	query := `
-		INSERT INTO current_schedule 
-		(id, group_name, date, time_start, time_end, subject, teacher, classroom, source_type, source_id, is_active)
-		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11)`
+// queryCurrentSchedule выполняет запрос к current_schedule и сканирует результат
+func (r *Repository) queryCurrentSchedule(ctx context.Context, query string, args ...interface{}) ([]CurrentSchedule, error) {
+	rows, err := r.reader().QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query current schedule: %w", err)
+	}
+	defer rows.Close()
 
-	// ИСПРАВЛЕНО: Используем ExecContext с переданным ctx
-	_, err := tx.ExecContext(ctx, query,
-		entry.ID,
-		entry.GroupName,
-		entry.Date,
-		entry.TimeStart,
-		entry.TimeEnd,
-		entry.Subject,
-		entry.Teacher,
-		entry.Classroom,
-		entry.SourceType,
-		entry.SourceID,
-		entry.IsActive,
-	)
+	var schedules []CurrentSchedule
+	for rows.Next() {
+		var schedule CurrentSchedule
+		err := rows.Scan(
+			&schedule.ID,
+			&schedule.GroupName,
+			&schedule.Date,
+			&schedule.TimeStart,
+			&schedule.TimeEnd,
+			&schedule.Subject,
+			&schedule.Teacher,
+			&schedule.Classroom,
+			&schedule.SourceType,
+			&schedule.SourceID,
+			&schedule.IsActive,
+			&schedule.MeetingURL,
+			&schedule.Subgroup,
+			&schedule.LessonType,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan current schedule: %w", err)
+		}
+		schedule.TimeStart = clock.NormalizeClock(schedule.TimeStart)
+		schedule.TimeEnd = clock.NormalizeClock(schedule.TimeEnd)
+		schedules = append(schedules, schedule)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating rows: %w", err)
+	}
 
-	return err
+	return schedules, nil
 }
 
-// GetChangesForGroup получает изменения для группы на определенную дату
-func (r *Repository) GetChangesForGroup(ctx context.Context, groupName string, date time.Time) ([]ScheduleChange, error) {
+// SearchCurrentSchedule ищет записи актуального расписания за период [from, to]
+// по полнотекстовому индексу и триграммному сходству, сортируя по релевантности
+func (r *Repository) SearchCurrentSchedule(ctx context.Context, search string, from, to time.Time, limit int) ([]SearchResult, error) {
+	tx, err := r.db.BeginTx(ctx, &sql.TxOptions{ReadOnly: true})
+	if err != nil {
+		return nil, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer func() { _ = tx.Rollback() }()
+
+	// Порог действует только внутри транзакции и позволяет использовать индекс для оператора <%
+	thresholdQuery := `SELECT set_config('pg_trgm.word_similarity_threshold', $1, true)`
+	if _, err := tx.ExecContext(ctx, thresholdQuery, fmt.Sprintf("%g", SearchSimilarityThreshold)); err != nil {
+		return nil, fmt.Errorf("failed to set similarity threshold: %w", err)
+	}
+
 	query := `
-		SELECT id, snapshot_id, group_name, date, time_start, time_end, subject, teacher, classroom, change_type, original_subject, created_at, is_active
-		FROM schedule_changes
-		WHERE group_name = $1 AND date = $2 AND is_active = true
-		ORDER BY time_start`
+		SELECT id, group_name, date, time_start, time_end, subject, COALESCE(teacher, ''), COALESCE(classroom, ''),
+		       source_type, source_id, is_active,
+		       ts_rank(search_vector, plainto_tsquery('russian', $1)) + word_similarity($1, search_text) AS rank
+		FROM current_schedule
+		WHERE is_active = true AND college_id = $5
+		  AND date BETWEEN $2 AND $3
+		  AND (search_vector @@ plainto_tsquery('russian', $1) OR $1 <% search_text)
+		ORDER BY rank DESC, date, time_start, group_name
+		LIMIT $4`
 
-	rows, err := r.db.QueryContext(ctx, query, groupName, date)
+	rows, err := tx.QueryContext(ctx, query, search, from, to, limit, tenant.CollegeID(ctx))
 	if err != nil {
-		return nil, fmt.Errorf("failed to get changes for group: %w", err)
+		return nil, fmt.Errorf("failed to search current schedule: %w", err)
 	}
 	defer rows.Close()
 
-	var changes []ScheduleChange
+	var results []SearchResult
 	for rows.Next() {
-		var change ScheduleChange
+		var result SearchResult
 		err := rows.Scan(
-			&change.ID,
-			&change.SnapshotID,
-			&change.GroupName,
-			&change.Date,
-			&change.TimeStart,
-			&change.TimeEnd,
-			&change.Subject,
-			&change.Teacher,
-			&change.Classroom,
-			&change.ChangeType,
-			&change.OriginalSubject,
-			&change.CreatedAt,
-			&change.IsActive,
+			&result.Entry.ID,
+			&result.Entry.GroupName,
+			&result.Entry.Date,
+			&result.Entry.TimeStart,
+			&result.Entry.TimeEnd,
+			&result.Entry.Subject,
+			&result.Entry.Teacher,
+			&result.Entry.Classroom,
+			&result.Entry.SourceType,
+			&result.Entry.SourceID,
+			&result.Entry.IsActive,
+			&result.Rank,
 		)
 		if err != nil {
-			return nil, fmt.Errorf("failed to scan change: %w", err)
+			return nil, fmt.Errorf("failed to scan search result: %w", err)
 		}
-		changes = append(changes, change)
+		result.Entry.TimeStart = clock.NormalizeClock(result.Entry.TimeStart)
+		result.Entry.TimeEnd = clock.NormalizeClock(result.Entry.TimeEnd)
+		results = append(results, result)
 	}
 
 	if err = rows.Err(); err != nil {
 		return nil, fmt.Errorf("error iterating rows: %w", err)
 	}
 
+	return results, nil
+}
+
+// GetWorkloadStats агрегирует занятия из current_schedule по предметам
+// (и по неделям, если filter.ByWeek) для группы или преподавателя
+func (r *Repository) GetWorkloadStats(ctx context.Context, filter WorkloadFilter) ([]WorkloadStat, error) {
+	filterColumn, filterValue := "group_name", filter.GroupName
+	if filter.GroupName == "" {
+		filterColumn, filterValue = "teacher", filter.Teacher
+	}
+
+	query := fmt.Sprintf(`
+		SELECT group_name, COALESCE(teacher, ''), subject, %[1]s AS week_start,
+		       COUNT(*), COALESCE(SUM(EXTRACT(EPOCH FROM (time_end - time_start)) / 60), 0)::int
+		FROM current_schedule
+		WHERE %[2]s = $1 AND date BETWEEN $2 AND $3 AND is_active = true AND college_id = $4
+		GROUP BY group_name, COALESCE(teacher, ''), subject, week_start
+		ORDER BY week_start NULLS FIRST, group_name, subject`, workloadWeekExpr(filter.ByWeek), filterColumn)
+
+	rows, err := r.db.QueryContext(ctx, query, filterValue, filter.From, filter.To, tenant.CollegeID(ctx))
+	if err != nil {
+		return nil, fmt.Errorf("failed to get workload stats: %w", err)
+	}
+	defer rows.Close()
+
+	return scanWorkloadStats(rows)
+}
+
+// GetTeacherWorkload агрегирует занятия всех преподавателей колледжа из current_schedule
+// за период по преподавателям, группам и предметам (и по неделям, если filter.ByWeek).
+// Группа и преподаватель фильтра не учитываются.
+func (r *Repository) GetTeacherWorkload(ctx context.Context, filter WorkloadFilter) ([]WorkloadStat, error) {
+	query := fmt.Sprintf(`
+		SELECT group_name, COALESCE(teacher, ''), subject, %s AS week_start,
+		       COUNT(*), COALESCE(SUM(EXTRACT(EPOCH FROM (time_end - time_start)) / 60), 0)::int
+		FROM current_schedule
+		WHERE date BETWEEN $1 AND $2 AND is_active = true AND college_id = $3
+		GROUP BY COALESCE(teacher, ''), group_name, subject, week_start
+		ORDER BY COALESCE(teacher, '') = '', COALESCE(teacher, ''), week_start NULLS FIRST, group_name, subject`,
+		workloadWeekExpr(filter.ByWeek))
+
+	rows, err := r.reader().QueryContext(ctx, query, filter.From, filter.To, tenant.CollegeID(ctx))
+	if err != nil {
+		return nil, fmt.Errorf("failed to get teacher workload: %w", err)
+	}
+	defer rows.Close()
+
+	return scanWorkloadStats(rows)
+}
+
+// workloadWeekExpr выражение недели занятия для агрегации нагрузки
+func workloadWeekExpr(byWeek bool) string {
+	if byWeek {
+		return "date_trunc('week', date)::date"
+	}
+	return "NULL::date"
+}
+
+// scanWorkloadStats читает строки агрегированной нагрузки
+func scanWorkloadStats(rows *sql.Rows) ([]WorkloadStat, error) {
+	var stats []WorkloadStat
+	for rows.Next() {
+		var stat WorkloadStat
+		var weekStart sql.NullTime
+		if err := rows.Scan(&stat.GroupName, &stat.Teacher, &stat.Subject, &weekStart, &stat.Lessons, &stat.Minutes); err != nil {
+			return nil, fmt.Errorf("failed to scan workload stat: %w", err)
+		}
+		if weekStart.Valid {
+			stat.WeekStart = &weekStart.Time
+		}
+		stats = append(stats, stat)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating rows: %w", err)
+	}
+
+	return stats, nil
+}
+
+// dayCacheSelect собирает записи current_schedule группы ($1) колледжа ($3) на дату ($2)
+// в JSON-массив для schedule_day_cache
+const dayCacheSelect = `
+	SELECT $3::uuid, $1::varchar, $2::date, COALESCE(jsonb_agg(jsonb_build_object(
+		'id', id,
+		'time_start', to_char(time_start, 'HH24:MI'),
+		'time_end', to_char(time_end, 'HH24:MI'),
+		'subject', subject,
+		'teacher', COALESCE(teacher, ''),
+		'classroom', COALESCE(classroom, ''),
+		'source_type', source_type,
+		'source_id', source_id,
+		'meeting_url', meeting_url,
+		'subgroup', subgroup,
+		'lesson_type', lesson_type
+	) ORDER BY time_start, subgroup), '[]'::jsonb), NOW()
+	FROM current_schedule
+	WHERE group_name = $1 AND date = $2 AND is_active = true AND college_id = $3`
+
+// dayCacheEntry запись расписания в schedule_day_cache
+type dayCacheEntry struct {
+	ID         uuid.UUID `json:"id"`
+	TimeStart  string    `json:"time_start"`
+	TimeEnd    string    `json:"time_end"`
+	Subject    string    `json:"subject"`
+	Teacher    string    `json:"teacher"`
+	Classroom  string    `json:"classroom"`
+	SourceType string    `json:"source_type"`
+	SourceID   uuid.UUID `json:"source_id"`
+	MeetingURL string    `json:"meeting_url"`
+	Subgroup   int       `json:"subgroup"`
+	LessonType string    `json:"lesson_type"`
+}
+
+// changeStatsScope условие отбора изменений для статистики: действующие одобренные изменения
+// колледжа ($3) за период [$1, $2]
+const changeStatsScope = `is_active = true AND moderation_status = 'approved' AND date BETWEEN $1 AND $2 AND college_id = $3`
+
+// GetChangesByGroupMonth считает изменения по группам и месяцам за период
+func (r *Repository) GetChangesByGroupMonth(ctx context.Context, from, to time.Time) ([]GroupMonthChanges, error) {
+	query := `
+		SELECT group_name, date_trunc('month', date)::date AS month, COUNT(*),
+		       COUNT(*) FILTER (WHERE change_type = 'replacement'),
+		       COUNT(*) FILTER (WHERE change_type = 'cancellation'),
+		       COUNT(*) FILTER (WHERE change_type = 'addition')
+		FROM schedule_changes
+		WHERE ` + changeStatsScope + `
+		GROUP BY group_name, month
+		ORDER BY month, group_name`
+
+	rows, err := r.db.QueryContext(ctx, query, from, to, tenant.CollegeID(ctx))
+	if err != nil {
+		return nil, fmt.Errorf("failed to get changes by group and month: %w", err)
+	}
+	defer rows.Close()
+
+	var stats []GroupMonthChanges
+	for rows.Next() {
+		var stat GroupMonthChanges
+		if err := rows.Scan(&stat.GroupName, &stat.Month, &stat.Total, &stat.Replacements, &stat.Cancellations, &stat.Additions); err != nil {
+			return nil, fmt.Errorf("failed to scan group month changes: %w", err)
+		}
+		stats = append(stats, stat)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating rows: %w", err)
+	}
+
+	return stats, nil
+}
+
+// GetMostCancelledSubjects возвращает limit предметов с наибольшим количеством отмен за период
+func (r *Repository) GetMostCancelledSubjects(ctx context.Context, from, to time.Time, limit int) ([]SubjectCancellations, error) {
+	query := `
+		SELECT subject, COUNT(*) AS cancellations, COUNT(DISTINCT group_name)
+		FROM schedule_changes
+		WHERE ` + changeStatsScope + ` AND change_type = 'cancellation'
+		GROUP BY subject
+		ORDER BY cancellations DESC, subject
+		LIMIT $4`
+
+	rows, err := r.db.QueryContext(ctx, query, from, to, tenant.CollegeID(ctx), limit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get most cancelled subjects: %w", err)
+	}
+	defer rows.Close()
+
+	var stats []SubjectCancellations
+	for rows.Next() {
+		var stat SubjectCancellations
+		if err := rows.Scan(&stat.Subject, &stat.Cancellations, &stat.Groups); err != nil {
+			return nil, fmt.Errorf("failed to scan subject cancellations: %w", err)
+		}
+		stats = append(stats, stat)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating rows: %w", err)
+	}
+
+	return stats, nil
+}
+
+// GetBusiestReplacementDays возвращает limit дней с наибольшим количеством замен за период
+func (r *Repository) GetBusiestReplacementDays(ctx context.Context, from, to time.Time, limit int) ([]DayReplacements, error) {
+	query := `
+		SELECT date, COUNT(*) AS replacements, COUNT(DISTINCT group_name)
+		FROM schedule_changes
+		WHERE ` + changeStatsScope + ` AND change_type = 'replacement'
+		GROUP BY date
+		ORDER BY replacements DESC, date
+		LIMIT $4`
+
+	rows, err := r.db.QueryContext(ctx, query, from, to, tenant.CollegeID(ctx), limit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get busiest replacement days: %w", err)
+	}
+	defer rows.Close()
+
+	var stats []DayReplacements
+	for rows.Next() {
+		var stat DayReplacements
+		if err := rows.Scan(&stat.Date, &stat.Replacements, &stat.Groups); err != nil {
+			return nil, fmt.Errorf("failed to scan day replacements: %w", err)
+		}
+		stats = append(stats, stat)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating rows: %w", err)
+	}
+
+	return stats, nil
+}
+
+// GetDayCache получает предрассчитанное расписание группы на дату.
+// Второе значение false означает промах кэша.
+func (r *Repository) GetDayCache(ctx context.Context, groupName string, date time.Time) ([]CurrentSchedule, bool, error) {
+	query := `SELECT entries FROM schedule_day_cache WHERE group_name = $1 AND date = $2 AND college_id = $3`
+
+	var data []byte
+	err := r.reader().QueryRowContext(ctx, query, groupName, date, tenant.CollegeID(ctx)).Scan(&data)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, false, nil
+		}
+		return nil, false, fmt.Errorf("failed to get schedule day cache: %w", err)
+	}
+
+	var cached []dayCacheEntry
+	if err := json.Unmarshal(data, &cached); err != nil {
+		return nil, false, fmt.Errorf("failed to decode schedule day cache: %w", err)
+	}
+
+	schedules := make([]CurrentSchedule, 0, len(cached))
+	for _, entry := range cached {
+		schedules = append(schedules, CurrentSchedule{
+			ID:         entry.ID,
+			GroupName:  groupName,
+			Date:       date,
+			TimeStart:  entry.TimeStart,
+			TimeEnd:    entry.TimeEnd,
+			Subject:    entry.Subject,
+			Teacher:    entry.Teacher,
+			Classroom:  entry.Classroom,
+			SourceType: entry.SourceType,
+			SourceID:   entry.SourceID,
+			IsActive:   true,
+			MeetingURL: entry.MeetingURL,
+			Subgroup:   entry.Subgroup,
+			LessonType: entry.LessonType,
+		})
+	}
+
+	return schedules, true, nil
+}
+
+// FillDayCache заполняет кэш группы на дату, если его еще нет.
+// Уже существующую строку не перезаписывает: ее поддерживает refreshDayCache при записи.
+func (r *Repository) FillDayCache(ctx context.Context, groupName string, date time.Time) error {
+	query := `
+		INSERT INTO schedule_day_cache (college_id, group_name, date, entries, refreshed_at)` + dayCacheSelect + `
+		ON CONFLICT (college_id, group_name, date) DO NOTHING`
+
+	if _, err := r.db.ExecContext(ctx, query, groupName, date, tenant.CollegeID(ctx)); err != nil {
+		return fmt.Errorf("failed to fill schedule day cache: %w", err)
+	}
+	return nil
+}
+
+// RebuildDayCache пересобирает кэш на дату для всех групп, у которых есть занятия
+func (r *Repository) RebuildDayCache(ctx context.Context, date time.Time) (int, error) {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return 0, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer func() { _ = tx.Rollback() }()
+
+	collegeID := tenant.CollegeID(ctx)
+	if _, err := tx.ExecContext(ctx, `DELETE FROM schedule_day_cache WHERE date = $1 AND college_id = $2`, date, collegeID); err != nil {
+		return 0, fmt.Errorf("failed to clear schedule day cache: %w", err)
+	}
+
+	rows, err := tx.QueryContext(ctx,
+		`SELECT DISTINCT group_name FROM current_schedule WHERE date = $1 AND is_active = true AND college_id = $2`, date, collegeID)
+	if err != nil {
+		return 0, fmt.Errorf("failed to get groups for schedule day cache: %w", err)
+	}
+	var groups []string
+	for rows.Next() {
+		var group string
+		if err := rows.Scan(&group); err != nil {
+			rows.Close()
+			return 0, fmt.Errorf("failed to scan group name: %w", err)
+		}
+		groups = append(groups, group)
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return 0, fmt.Errorf("error iterating rows: %w", err)
+	}
+
+	for _, group := range groups {
+		if err := r.refreshDayCache(ctx, tx, group, date); err != nil {
+			return 0, err
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return 0, fmt.Errorf("failed to commit schedule day cache rebuild: %w", err)
+	}
+	r.invalidate(ctx, "", date)
+
+	return len(groups), nil
+}
+
+// PruneDayCache удаляет кэш за дни раньше before во всех колледжах
+func (r *Repository) PruneDayCache(ctx context.Context, before time.Time) (int64, error) {
+	result, err := r.db.ExecContext(ctx, `DELETE FROM schedule_day_cache WHERE date < $1`, before)
+	if err != nil {
+		return 0, fmt.Errorf("failed to prune schedule day cache: %w", err)
+	}
+	return result.RowsAffected()
+}
+
+// EnsureSchedulePartitions создает месячные секции current_schedule для months месяцев,
+// начиная с месяца from. Секции общие для всех колледжей. Возвращает количество
+// созданных секций.
+func (r *Repository) EnsureSchedulePartitions(ctx context.Context, from time.Time, months int) (int, error) {
+	created := 0
+	for i := 0; i < months; i++ {
+		month := time.Date(from.Year(), from.Month()+time.Month(i), 1, 0, 0, 0, 0, time.UTC)
+		var ok bool
+		err := r.db.QueryRowContext(ctx, `SELECT ensure_current_schedule_partition($1)`, month).Scan(&ok)
+		if err != nil {
+			return created, fmt.Errorf("failed to create current schedule partition for %s: %w", month.Format("2006-01"), err)
+		}
+		if ok {
+			created++
+		}
+	}
+	return created, nil
+}
+
+// ArchiveScheduleBefore переносит записи актуального расписания колледжа с датами
+// раньше before в current_schedule_archive. Возвращает количество перенесенных записей.
+func (r *Repository) ArchiveScheduleBefore(ctx context.Context, before time.Time) (int64, error) {
+	query := `
+		WITH moved AS (
+			DELETE FROM current_schedule
+			WHERE college_id = $1 AND date < $2
+			RETURNING id, college_id, group_name, date, time_start, time_end, subject, teacher, classroom,
+			          source_type, source_id, is_active, meeting_url, subgroup, lesson_type
+		)
+		INSERT INTO current_schedule_archive
+			(id, college_id, group_name, date, time_start, time_end, subject, teacher, classroom,
+			 source_type, source_id, is_active, meeting_url, subgroup, lesson_type)
+		SELECT id, college_id, group_name, date, time_start, time_end, subject, teacher, classroom,
+		       source_type, source_id, is_active, meeting_url, subgroup, lesson_type
+		FROM moved
+		ON CONFLICT (id, date) DO NOTHING`
+
+	result, err := r.db.ExecContext(ctx, query, tenant.CollegeID(ctx), before)
+	if err != nil {
+		return 0, fmt.Errorf("failed to archive current schedule: %w", err)
+	}
+	return result.RowsAffected()
+}
+
+// GetArchivedScheduleForGroup получает расписание группы на дату из архива прошедших семестров
+func (r *Repository) GetArchivedScheduleForGroup(ctx context.Context, groupName string, date time.Time) ([]CurrentSchedule, error) {
+	query := `
+		SELECT id, group_name, date, time_start, time_end, subject, teacher, classroom, source_type, source_id, is_active,
+		       meeting_url, subgroup, lesson_type
+		FROM current_schedule_archive
+		WHERE group_name = $1 AND date = $2 AND is_active = true AND college_id = $3
+		ORDER BY time_start`
+
+	rows, err := r.reader().QueryContext(ctx, query, groupName, date, tenant.CollegeID(ctx))
+	if err != nil {
+		return nil, fmt.Errorf("failed to get archived schedule for group: %w", err)
+	}
+	defer rows.Close()
+
+	var schedules []CurrentSchedule
+	for rows.Next() {
+		var schedule CurrentSchedule
+		err := rows.Scan(
+			&schedule.ID,
+			&schedule.GroupName,
+			&schedule.Date,
+			&schedule.TimeStart,
+			&schedule.TimeEnd,
+			&schedule.Subject,
+			&schedule.Teacher,
+			&schedule.Classroom,
+			&schedule.SourceType,
+			&schedule.SourceID,
+			&schedule.IsActive,
+			&schedule.MeetingURL,
+			&schedule.Subgroup,
+			&schedule.LessonType,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan archived schedule: %w", err)
+		}
+		schedule.TimeStart = clock.NormalizeClock(schedule.TimeStart)
+		schedule.TimeEnd = clock.NormalizeClock(schedule.TimeEnd)
+		schedules = append(schedules, schedule)
+	}
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating rows: %w", err)
+	}
+
+	return schedules, nil
+}
+
+// refreshDayCache пересобирает кэш группы на дату в транзакции записи
+func (r *Repository) refreshDayCache(ctx context.Context, q txn.Executor, groupName string, date time.Time) error {
+	query := `
+		INSERT INTO schedule_day_cache (college_id, group_name, date, entries, refreshed_at)` + dayCacheSelect + `
+		ON CONFLICT (college_id, group_name, date) DO UPDATE
+		SET entries = EXCLUDED.entries, refreshed_at = EXCLUDED.refreshed_at`
+
+	if _, err := q.ExecContext(ctx, query, groupName, date, tenant.CollegeID(ctx)); err != nil {
+		return fmt.Errorf("failed to refresh schedule day cache: %w", err)
+	}
+	return nil
+}
+
+// ListSubjectMetadata получает параметры отображения всех предметов
+func (r *Repository) ListSubjectMetadata(ctx context.Context) ([]SubjectMetadata, error) {
+	query := `
+		SELECT subject, short_name, color, icon, updated_at, updated_by
+		FROM subject_metadata
+		WHERE college_id = $1
+		ORDER BY subject`
+
+	rows, err := r.db.QueryContext(ctx, query, tenant.CollegeID(ctx))
+	if err != nil {
+		return nil, fmt.Errorf("failed to list subject metadata: %w", err)
+	}
+	defer rows.Close()
+
+	var items []SubjectMetadata
+	for rows.Next() {
+		var meta SubjectMetadata
+		if err := rows.Scan(&meta.Subject, &meta.ShortName, &meta.Color, &meta.Icon, &meta.UpdatedAt, &meta.UpdatedBy); err != nil {
+			return nil, fmt.Errorf("failed to scan subject metadata: %w", err)
+		}
+		items = append(items, meta)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating rows: %w", err)
+	}
+
+	return items, nil
+}
+
+// UpsertSubjectMetadata создает или обновляет параметры отображения предмета
+func (r *Repository) UpsertSubjectMetadata(ctx context.Context, meta *SubjectMetadata) error {
+	query := `
+		INSERT INTO subject_metadata (subject, short_name, color, icon, updated_at, updated_by, college_id)
+		VALUES ($1, $2, $3, $4, NOW(), $5, $6)
+		ON CONFLICT (college_id, subject) DO UPDATE
+		SET short_name = EXCLUDED.short_name,
+		    color = EXCLUDED.color,
+		    icon = EXCLUDED.icon,
+		    updated_at = EXCLUDED.updated_at,
+		    updated_by = EXCLUDED.updated_by
+		RETURNING updated_at`
+
+	err := r.db.QueryRowContext(ctx, query, meta.Subject, meta.ShortName, meta.Color, meta.Icon, meta.UpdatedBy,
+		tenant.CollegeID(ctx)).Scan(&meta.UpdatedAt)
+	if err != nil {
+		return fmt.Errorf("failed to upsert subject metadata: %w", err)
+	}
+	return nil
+}
+
+// DeleteSubjectMetadata удаляет параметры отображения предмета
+func (r *Repository) DeleteSubjectMetadata(ctx context.Context, subject string) error {
+	result, err := r.db.ExecContext(ctx, `DELETE FROM subject_metadata WHERE subject = $1 AND college_id = $2`,
+		subject, tenant.CollegeID(ctx))
+	if err != nil {
+		return fmt.Errorf("failed to delete subject metadata: %w", err)
+	}
+
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get affected rows: %w", err)
+	}
+	if affected == 0 {
+		return fmt.Errorf("subject metadata for %q: %w", subject, apperr.ErrNotFound)
+	}
+	return nil
+}
+
+// GetCurrentScheduleEntry получает запись из current_schedule по группе, подгруппе, дате и времени начала
+func (r *Repository) GetCurrentScheduleEntry(ctx context.Context, groupName string, subgroup int, date time.Time, timeStart string) (*CurrentSchedule, error) {
+	query := `
+		SELECT id, group_name, date, time_start, time_end, subject, teacher, classroom, source_type, source_id, is_active, subgroup, lesson_type
+		FROM current_schedule
+		WHERE group_name = $1 AND date = $2 AND time_start = $3 AND is_active = true AND college_id = $4 AND subgroup = $5`
+
+	entry := &CurrentSchedule{}
+	err := r.conn(ctx).QueryRowContext(ctx, query, groupName, date, timeStart, tenant.CollegeID(ctx), subgroup).Scan(
+		&entry.ID,
+		&entry.GroupName,
+		&entry.Date,
+		&entry.TimeStart,
+		&entry.TimeEnd,
+		&entry.Subject,
+		&entry.Teacher,
+		&entry.Classroom,
+		&entry.SourceType,
+		&entry.SourceID,
+		&entry.IsActive,
+		&entry.Subgroup,
+		&entry.LessonType,
+	)
+
+	if err != nil {
+		return nil, err
+	}
+	entry.TimeStart = clock.NormalizeClock(entry.TimeStart)
+	entry.TimeEnd = clock.NormalizeClock(entry.TimeEnd)
+
+	return entry, nil
+}
+
+// UpdateCurrentScheduleEntry обновляет запись в current_schedule.
+// Ссылка на онлайн-занятие сбрасывается, если у пары сменился преподаватель.
+func (r *Repository) UpdateCurrentScheduleEntry(ctx context.Context, entry *CurrentSchedule) error {
+	query := `
+		UPDATE current_schedule
+		SET subject = $1, teacher = $2, classroom = $3, source_type = $4, source_id = $5, is_active = $6,
+		    meeting_url = CASE WHEN teacher IS DISTINCT FROM $2 THEN '' ELSE meeting_url END,
+		    lesson_type = $9
+		WHERE id = $7 AND date = $8`
+
+	_, err := r.conn(ctx).ExecContext(ctx, query,
+		entry.Subject,
+		entry.Teacher,
+		entry.Classroom,
+		entry.SourceType,
+		entry.SourceID,
+		entry.IsActive,
+		entry.ID,
+		entry.Date, // Дата выбирает секцию таблицы
+		entry.LessonType,
+	)
+	if err != nil {
+		return err
+	}
+
+	if err := r.recordHistory(ctx, entry); err != nil {
+		return err
+	}
+
+	if err := r.refreshDayCache(ctx, r.conn(ctx), entry.GroupName, entry.Date); err != nil {
+		return err
+	}
+	r.invalidate(ctx, entry.GroupName, entry.Date)
+	return nil
+}
+
+// CreateCurrentScheduleEntry создает новую запись в current_schedule
+func (r *Repository) CreateCurrentScheduleEntry(ctx context.Context, entry *CurrentSchedule) error {
+	query := `
+		INSERT INTO current_schedule 
+		(id, group_name, date, time_start, time_end, subject, teacher, classroom, source_type, source_id, is_active, college_id, subgroup,
+		 lesson_type)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14)`
+
+	_, err := r.conn(ctx).ExecContext(ctx, query,
+		entry.ID,
+		entry.GroupName,
+		entry.Date,
+		entry.TimeStart,
+		entry.TimeEnd,
+		entry.Subject,
+		entry.Teacher,
+		entry.Classroom,
+		entry.SourceType,
+		entry.SourceID,
+		entry.IsActive,
+		tenant.CollegeID(ctx),
+		entry.Subgroup,
+		entry.LessonType,
+	)
+	if err != nil {
+		return err
+	}
+
+	if err := r.recordHistory(ctx, entry); err != nil {
+		return err
+	}
+
+	if err := r.refreshDayCache(ctx, r.conn(ctx), entry.GroupName, entry.Date); err != nil {
+		return err
+	}
+	r.invalidate(ctx, entry.GroupName, entry.Date)
+	return nil
+}
+
+// recordHistory закрывает действующую версию записи current_schedule
+// и сохраняет новую версию в current_schedule_history в той же транзакции
+func (r *Repository) recordHistory(ctx context.Context, entry *CurrentSchedule) error {
+	closeQuery := `
+		UPDATE current_schedule_history
+		SET effective_to = NOW()
+		WHERE entry_id = $1 AND effective_to IS NULL`
+
+	if _, err := r.conn(ctx).ExecContext(ctx, closeQuery, entry.ID); err != nil {
+		return fmt.Errorf("failed to close schedule history version: %w", err)
+	}
+
+	insertQuery := `
+		INSERT INTO current_schedule_history
+		(id, entry_id, group_name, date, time_start, time_end, subject, teacher, classroom, source_type, source_id, is_active, effective_from, college_id,
+		 subgroup, lesson_type)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, NOW(), $13, $14, $15)`
+
+	_, err := r.conn(ctx).ExecContext(ctx, insertQuery,
+		uuid.New(),
+		entry.ID,
+		entry.GroupName,
+		entry.Date,
+		entry.TimeStart,
+		entry.TimeEnd,
+		entry.Subject,
+		entry.Teacher,
+		entry.Classroom,
+		entry.SourceType,
+		entry.SourceID,
+		entry.IsActive,
+		tenant.CollegeID(ctx),
+		entry.Subgroup,
+		entry.LessonType,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to record schedule history version: %w", err)
+	}
+
+	return nil
+}
+
+// GetScheduleForGroupAsOf восстанавливает расписание группы на дату
+// в том виде, в котором оно было в момент asOf
+func (r *Repository) GetScheduleForGroupAsOf(ctx context.Context, groupName string, date time.Time, asOf time.Time) ([]CurrentSchedule, error) {
+	query := `
+		SELECT entry_id, group_name, date, time_start, time_end, subject, teacher, classroom, source_type, source_id, is_active, subgroup, lesson_type
+		FROM current_schedule_history
+		WHERE group_name = $1 AND date = $2 AND college_id = $4
+		  AND effective_from <= $3 AND (effective_to IS NULL OR effective_to > $3)
+		  AND is_active = true
+		ORDER BY time_start`
+
+	rows, err := r.db.QueryContext(ctx, query, groupName, date, asOf, tenant.CollegeID(ctx))
+	if err != nil {
+		return nil, fmt.Errorf("failed to get schedule history for group: %w", err)
+	}
+	defer rows.Close()
+
+	var schedules []CurrentSchedule
+	for rows.Next() {
+		var schedule CurrentSchedule
+		err := rows.Scan(
+			&schedule.ID,
+			&schedule.GroupName,
+			&schedule.Date,
+			&schedule.TimeStart,
+			&schedule.TimeEnd,
+			&schedule.Subject,
+			&schedule.Teacher,
+			&schedule.Classroom,
+			&schedule.SourceType,
+			&schedule.SourceID,
+			&schedule.IsActive,
+			&schedule.Subgroup,
+			&schedule.LessonType,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan schedule history: %w", err)
+		}
+		schedule.TimeStart = clock.NormalizeClock(schedule.TimeStart)
+		schedule.TimeEnd = clock.NormalizeClock(schedule.TimeEnd)
+		schedules = append(schedules, schedule)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating rows: %w", err)
+	}
+
+	return schedules, nil
+}
+
+// GetChangesForGroup получает изменения для группы на определенную дату
+func (r *Repository) GetChangesForGroup(ctx context.Context, groupName string, date time.Time) ([]ScheduleChange, error) {
+	query := `
+		SELECT ` + changeColumns + `
+		FROM schedule_changes
+		WHERE group_name = $1 AND date = $2 AND is_active = true AND college_id = $3
+		ORDER BY time_start`
+
+	rows, err := r.db.QueryContext(ctx, query, groupName, date, tenant.CollegeID(ctx))
+	if err != nil {
+		return nil, fmt.Errorf("failed to get changes for group: %w", err)
+	}
+	defer rows.Close()
+
+	return scanChanges(rows)
+}
+
+// GetChangesForSnapshot получает все изменения, сделанные относительно снапшота
+func (r *Repository) GetChangesForSnapshot(ctx context.Context, snapshotID uuid.UUID) ([]ScheduleChange, error) {
+	query := `
+		SELECT ` + changeColumns + `
+		FROM schedule_changes
+		WHERE snapshot_id = $1 AND college_id = $2
+		ORDER BY date, group_name, time_start`
+
+	rows, err := r.db.QueryContext(ctx, query, snapshotID, tenant.CollegeID(ctx))
+	if err != nil {
+		return nil, fmt.Errorf("failed to get changes for snapshot: %w", err)
+	}
+	defer rows.Close()
+
+	return scanChanges(rows)
+}
+
+// GetOverlappingChanges получает активные изменения за период [from, to],
+// пересекающиеся с другими занятиями группы (требуют внимания администратора)
+func (r *Repository) GetOverlappingChanges(ctx context.Context, from, to time.Time) ([]ScheduleChange, error) {
+	query := `
+		SELECT ` + changeColumns + `
+		FROM schedule_changes
+		WHERE has_overlap AND is_active = true AND date BETWEEN $1 AND $2 AND college_id = $3
+		ORDER BY date, group_name, time_start`
+
+	rows, err := r.db.QueryContext(ctx, query, from, to, tenant.CollegeID(ctx))
+	if err != nil {
+		return nil, fmt.Errorf("failed to get overlapping changes: %w", err)
+	}
+	defer rows.Close()
+
+	return scanChanges(rows)
+}
+
+// GetPendingChanges получает активные изменения, которые еще не применялись
+// (например, применение было прервано), от старых к новым
+func (r *Repository) GetPendingChanges(ctx context.Context, limit int) ([]ScheduleChange, error) {
+	query := `
+		SELECT ` + changeColumns + `
+		FROM schedule_changes
+		WHERE apply_status = 'pending' AND moderation_status = 'approved' AND is_active = true AND college_id = $2
+		ORDER BY created_at
+		LIMIT $1`
+
+	rows, err := r.db.QueryContext(ctx, query, limit, tenant.CollegeID(ctx))
+	if err != nil {
+		return nil, fmt.Errorf("failed to get pending changes: %w", err)
+	}
+	defer rows.Close()
+
+	return scanChanges(rows)
+}
+
+// GetChangeByID получает изменение по ID
+func (r *Repository) GetChangeByID(ctx context.Context, id uuid.UUID) (*ScheduleChange, error) {
+	query := `SELECT ` + changeColumns + ` FROM schedule_changes WHERE id = $1 AND college_id = $2`
+
+	rows, err := r.db.QueryContext(ctx, query, id, tenant.CollegeID(ctx))
+	if err != nil {
+		return nil, fmt.Errorf("failed to get schedule change: %w", err)
+	}
+	defer rows.Close()
+
+	changes, err := scanChanges(rows)
+	if err != nil {
+		return nil, err
+	}
+	if len(changes) == 0 {
+		return nil, fmt.Errorf("schedule change %s: %w", id, apperr.ErrNotFound)
+	}
+
+	return &changes[0], nil
+}
+
+// GetChangesByIDs получает изменения по списку ID. Ненайденные ID пропускаются.
+func (r *Repository) GetChangesByIDs(ctx context.Context, ids []uuid.UUID) ([]ScheduleChange, error) {
+	if len(ids) == 0 {
+		return nil, nil
+	}
+
+	values := make([]string, len(ids))
+	for i, id := range ids {
+		values[i] = id.String()
+	}
+	query := `SELECT ` + changeColumns + ` FROM schedule_changes WHERE id = ANY($1::uuid[]) AND college_id = $2 ORDER BY date, group_name, time_start`
+
+	rows, err := r.db.QueryContext(ctx, query, pq.Array(values), tenant.CollegeID(ctx))
+	if err != nil {
+		return nil, fmt.Errorf("failed to get schedule changes: %w", err)
+	}
+	defer rows.Close()
+
+	return scanChanges(rows)
+}
+
+// GetChangesAwaitingModeration получает изменения, ожидающие проверки администратором
+func (r *Repository) GetChangesAwaitingModeration(ctx context.Context) ([]ScheduleChange, error) {
+	query := `
+		SELECT ` + changeColumns + `
+		FROM schedule_changes
+		WHERE moderation_status = 'pending' AND is_active = true AND college_id = $1
+		ORDER BY date, group_name, time_start`
+
+	rows, err := r.db.QueryContext(ctx, query, tenant.CollegeID(ctx))
+	if err != nil {
+		return nil, fmt.Errorf("failed to get changes awaiting moderation: %w", err)
+	}
+	defer rows.Close()
+
+	return scanChanges(rows)
+}
+
+// GetRecentChanges получает последние найденные изменения колледжа, от новых к старым
+func (r *Repository) GetRecentChanges(ctx context.Context, limit int) ([]ScheduleChange, error) {
+	query := `
+		SELECT ` + changeColumns + `
+		FROM schedule_changes
+		WHERE college_id = $1
+		ORDER BY created_at DESC
+		LIMIT $2`
+
+	rows, err := r.reader().QueryContext(ctx, query, tenant.CollegeID(ctx), limit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get recent changes: %w", err)
+	}
+	defer rows.Close()
+
+	return scanChanges(rows)
+}
+
+// ModerateChange сохраняет решение модератора и (при редактировании) исправленное содержимое изменения.
+// Решение принимается только для изменений, ожидающих модерации.
+func (r *Repository) ModerateChange(ctx context.Context, change *ScheduleChange) error {
+	query := `
+		UPDATE schedule_changes
+		SET date = $2, time_start = $3, time_end = $4, subject = $5, teacher = $6, classroom = $7,
+		    change_type = $8, original_subject = $9, lesson_number = NULLIF($10::smallint, 0), reason = NULLIF($14, ''),
+		    moderation_status = $11, moderated_by = $12, moderated_at = NOW(), moderation_comment = NULLIF($13, '')
+		WHERE id = $1 AND moderation_status = 'pending' AND college_id = $15
+		RETURNING moderated_at`
+
+	err := r.db.QueryRowContext(ctx, query,
+		change.ID,
+		change.Date,
+		change.TimeStart,
+		change.TimeEnd,
+		change.Subject,
+		change.Teacher,
+		change.Classroom,
+		change.ChangeType,
+		change.OriginalSubject,
+		change.LessonNumber,
+		change.ModerationStatus,
+		change.ModeratedBy,
+		change.ModerationComment,
+		change.Reason,
+		tenant.CollegeID(ctx),
+	).Scan(&change.ModeratedAt)
+
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return fmt.Errorf("schedule change %s is not awaiting moderation", change.ID)
+		}
+		return fmt.Errorf("failed to moderate schedule change: %w", err)
+	}
+	return nil
+}
+
+// GetTrackedChanges получает активные изменения, присутствие которых в таблице изменений отслеживается
+func (r *Repository) GetTrackedChanges(ctx context.Context) ([]ScheduleChange, error) {
+	query := `
+		SELECT ` + changeColumns + `
+		FROM schedule_changes
+		WHERE is_active = true AND fingerprint IS NOT NULL AND college_id = $1
+		ORDER BY created_at`
+
+	rows, err := r.db.QueryContext(ctx, query, tenant.CollegeID(ctx))
+	if err != nil {
+		return nil, fmt.Errorf("failed to get tracked changes: %w", err)
+	}
+	defer rows.Close()
+
+	return scanChanges(rows)
+}
+
+// MarkChangesSeen отмечает, что строки изменений присутствуют в таблице при текущем парсинге
+func (r *Repository) MarkChangesSeen(ctx context.Context, ids []uuid.UUID) error {
+	if len(ids) == 0 {
+		return nil
+	}
+
+	query := `UPDATE schedule_changes SET last_seen_at = NOW() WHERE id = ANY($1::uuid[])`
+
+	values := make([]string, len(ids))
+	for i, id := range ids {
+		values[i] = id.String()
+	}
+
+	if _, err := r.db.ExecContext(ctx, query, pq.Array(values)); err != nil {
+		return fmt.Errorf("failed to mark changes seen: %w", err)
+	}
+	return nil
+}
+
+// RevertChange деактивирует изменение, строка которого исчезла из таблицы изменений
+func (r *Repository) RevertChange(ctx context.Context, change *ScheduleChange) error {
+	query := `
+		UPDATE schedule_changes
+		SET is_active = false, apply_status = 'reverted', apply_error = NULL, reverted_at = NOW()
+		WHERE id = $1
+		RETURNING reverted_at`
+
+	if err := r.conn(ctx).QueryRowContext(ctx, query, change.ID).Scan(&change.RevertedAt); err != nil {
+		return fmt.Errorf("failed to revert schedule change: %w", err)
+	}
+	change.IsActive = false
+	change.ApplyStatus = ChangeApplyReverted
+	change.ApplyError = ""
+	return nil
+}
+
+// GetEntriesBySource получает записи current_schedule, последняя версия которых записана изменением sourceID
+func (r *Repository) GetEntriesBySource(ctx context.Context, sourceID uuid.UUID) ([]CurrentSchedule, error) {
+	query := `
+		SELECT id, group_name, date, time_start, time_end, subject, teacher, classroom, source_type, source_id, is_active, subgroup, lesson_type
+		FROM current_schedule
+		WHERE source_id = $1
+		ORDER BY time_start
+		FOR UPDATE`
+
+	rows, err := r.conn(ctx).QueryContext(ctx, query, sourceID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get entries by source: %w", err)
+	}
+	defer rows.Close()
+
+	var entries []CurrentSchedule
+	for rows.Next() {
+		var entry CurrentSchedule
+		err := rows.Scan(
+			&entry.ID,
+			&entry.GroupName,
+			&entry.Date,
+			&entry.TimeStart,
+			&entry.TimeEnd,
+			&entry.Subject,
+			&entry.Teacher,
+			&entry.Classroom,
+			&entry.SourceType,
+			&entry.SourceID,
+			&entry.IsActive,
+			&entry.Subgroup,
+			&entry.LessonType,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan current schedule: %w", err)
+		}
+		entry.TimeStart = clock.NormalizeClock(entry.TimeStart)
+		entry.TimeEnd = clock.NormalizeClock(entry.TimeEnd)
+		entries = append(entries, entry)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating rows: %w", err)
+	}
+
+	return entries, nil
+}
+
+// GetEntryVersionBefore получает версию записи current_schedule из истории,
+// действовавшую до того, как ее впервые записало изменение sourceID.
+// Возвращает sql.ErrNoRows, если запись была создана самим изменением.
+func (r *Repository) GetEntryVersionBefore(ctx context.Context, entryID, sourceID uuid.UUID) (*CurrentSchedule, error) {
+	query := `
+		SELECT entry_id, group_name, date, time_start, time_end, subject, teacher, classroom, source_type, source_id, is_active
+		FROM current_schedule_history
+		WHERE entry_id = $1
+		  AND effective_from < (
+		      SELECT MIN(effective_from) FROM current_schedule_history
+		      WHERE entry_id = $1 AND source_id = $2)
+		ORDER BY effective_from DESC
+		LIMIT 1`
+
+	entry := &CurrentSchedule{}
+	err := r.conn(ctx).QueryRowContext(ctx, query, entryID, sourceID).Scan(
+		&entry.ID,
+		&entry.GroupName,
+		&entry.Date,
+		&entry.TimeStart,
+		&entry.TimeEnd,
+		&entry.Subject,
+		&entry.Teacher,
+		&entry.Classroom,
+		&entry.SourceType,
+		&entry.SourceID,
+		&entry.IsActive,
+	)
+	if err != nil {
+		return nil, err
+	}
+	entry.TimeStart = clock.NormalizeClock(entry.TimeStart)
+	entry.TimeEnd = clock.NormalizeClock(entry.TimeEnd)
+
+	return entry, nil
+}
+
+// SetChangeApplyStatus сохраняет статус применения изменения в транзакции применения
+func (r *Repository) SetChangeApplyStatus(ctx context.Context, changeID uuid.UUID, status, applyError string) error {
+	query := `
+		UPDATE schedule_changes
+		SET apply_status = $2,
+		    apply_error = NULLIF($3, ''),
+		    applied_at = CASE WHEN $2 = 'applied' THEN NOW() ELSE applied_at END
+		WHERE id = $1`
+
+	if _, err := r.conn(ctx).ExecContext(ctx, query, changeID, status, applyError); err != nil {
+		return fmt.Errorf("failed to set change apply status: %w", err)
+	}
+	return nil
+}
+
+// MarkChangeApplyError помечает изменение как не примененное с ошибкой (вне транзакции применения)
+func (r *Repository) MarkChangeApplyError(ctx context.Context, changeID uuid.UUID, applyError string) error {
+	query := `UPDATE schedule_changes SET apply_status = 'error', apply_error = $2 WHERE id = $1`
+
+	if _, err := r.db.ExecContext(ctx, query, changeID, applyError); err != nil {
+		return fmt.Errorf("failed to mark change apply error: %w", err)
+	}
+	return nil
+}
+
+// UpdateChangeSlot сохраняет уточненное время, номер пары и признак пересечения изменения
+func (r *Repository) UpdateChangeSlot(ctx context.Context, change *ScheduleChange) error {
+	query := `
+		UPDATE schedule_changes
+		SET time_start = $2, time_end = $3, lesson_number = NULLIF($4::smallint, 0), has_overlap = $5
+		WHERE id = $1`
+
+	_, err := r.conn(ctx).ExecContext(ctx, query, change.ID, change.TimeStart, change.TimeEnd, change.LessonNumber, change.HasOverlap)
+	if err != nil {
+		return fmt.Errorf("failed to update schedule change slot: %w", err)
+	}
+	return nil
+}
+
+// SetChangeSupersedes сохраняет ссылку на изменение той же пары, которое перезаписывает изменение changeID
+func (r *Repository) SetChangeSupersedes(ctx context.Context, changeID, supersedesID uuid.UUID) error {
+	query := `UPDATE schedule_changes SET supersedes_id = $2 WHERE id = $1`
+
+	if _, err := r.conn(ctx).ExecContext(ctx, query, changeID, supersedesID); err != nil {
+		return fmt.Errorf("failed to set superseded change: %w", err)
+	}
+	return nil
+}
+
+// FindOverlappingEntries получает активные записи current_schedule группы на дату,
+// пересекающиеся по времени с интервалом [timeStart, timeEnd)
+func (r *Repository) FindOverlappingEntries(ctx context.Context, groupName string, date time.Time, timeStart, timeEnd string) ([]CurrentSchedule, error) {
+	query := `
+		SELECT id, group_name, date, time_start, time_end, subject, teacher, classroom, source_type, source_id, is_active, subgroup, lesson_type
+		FROM current_schedule
+		WHERE group_name = $1 AND date = $2 AND is_active = true AND college_id = $5
+		  AND time_start < $4::time AND time_end > $3::time
+		ORDER BY time_start`
+
+	rows, err := r.conn(ctx).QueryContext(ctx, query, groupName, date, timeStart, timeEnd, tenant.CollegeID(ctx))
+	if err != nil {
+		return nil, fmt.Errorf("failed to find overlapping entries: %w", err)
+	}
+	defer rows.Close()
+
+	var entries []CurrentSchedule
+	for rows.Next() {
+		var entry CurrentSchedule
+		err := rows.Scan(
+			&entry.ID,
+			&entry.GroupName,
+			&entry.Date,
+			&entry.TimeStart,
+			&entry.TimeEnd,
+			&entry.Subject,
+			&entry.Teacher,
+			&entry.Classroom,
+			&entry.SourceType,
+			&entry.SourceID,
+			&entry.IsActive,
+			&entry.Subgroup,
+			&entry.LessonType,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan current schedule: %w", err)
+		}
+		entry.TimeStart = clock.NormalizeClock(entry.TimeStart)
+		entry.TimeEnd = clock.NormalizeClock(entry.TimeEnd)
+		entries = append(entries, entry)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating rows: %w", err)
+	}
+
+	return entries, nil
+}
+
+// changeRequestColumns список колонок change_requests в порядке сканирования scanChangeRequests
+const changeRequestColumns = `id, teacher_id, kind, group_name, date, time_start, time_end, subject, teacher, COALESCE(classroom, ''),
+		new_date, COALESCE(new_time_start::text, ''), COALESCE(new_time_end::text, ''), COALESCE(new_lesson_number, 0),
+		COALESCE(new_classroom, ''), COALESCE(comment, ''), status, reviewed_by, reviewed_at, COALESCE(review_comment, ''), created_at`
+
+// CreateChangeRequest сохраняет заявку преподавателя
+func (r *Repository) CreateChangeRequest(ctx context.Context, request *ChangeRequest) error {
+	query := `
+		INSERT INTO change_requests
+		(id, teacher_id, kind, group_name, date, time_start, time_end, subject, teacher, classroom,
+		 new_date, new_time_start, new_time_end, new_lesson_number, new_classroom, comment, status, college_id)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, NULLIF($10, ''),
+		        $11, NULLIF($12, '')::time, NULLIF($13, '')::time, NULLIF($14::smallint, 0), NULLIF($15, ''), NULLIF($16, ''), $17, $18)
+		RETURNING created_at`
+
+	err := r.db.QueryRowContext(ctx, query,
+		request.ID,
+		request.TeacherID,
+		request.Kind,
+		request.GroupName,
+		request.Date,
+		request.TimeStart,
+		request.TimeEnd,
+		request.Subject,
+		request.Teacher,
+		request.Classroom,
+		request.NewDate,
+		request.NewTimeStart,
+		request.NewTimeEnd,
+		request.NewLessonNumber,
+		request.NewClassroom,
+		request.Comment,
+		request.Status,
+		tenant.CollegeID(ctx),
+	).Scan(&request.CreatedAt)
+	if err != nil {
+		return fmt.Errorf("failed to create change request: %w", err)
+	}
+	return nil
+}
+
+// GetChangeRequestByID получает заявку преподавателя по ID
+func (r *Repository) GetChangeRequestByID(ctx context.Context, id uuid.UUID) (*ChangeRequest, error) {
+	query := `SELECT ` + changeRequestColumns + ` FROM change_requests WHERE id = $1 AND college_id = $2`
+
+	rows, err := r.db.QueryContext(ctx, query, id, tenant.CollegeID(ctx))
+	if err != nil {
+		return nil, fmt.Errorf("failed to get change request: %w", err)
+	}
+	defer rows.Close()
+
+	requests, err := scanChangeRequests(rows)
+	if err != nil {
+		return nil, err
+	}
+	if len(requests) == 0 {
+		return nil, fmt.Errorf("change request %s: %w", id, apperr.ErrNotFound)
+	}
+
+	return &requests[0], nil
+}
+
+// GetChangeRequestsByTeacher получает заявки преподавателя, от новых к старым
+func (r *Repository) GetChangeRequestsByTeacher(ctx context.Context, teacherID uuid.UUID, limit int) ([]ChangeRequest, error) {
+	query := `
+		SELECT ` + changeRequestColumns + `
+		FROM change_requests
+		WHERE teacher_id = $1
+		ORDER BY created_at DESC
+		LIMIT $2`
+
+	rows, err := r.db.QueryContext(ctx, query, teacherID, limit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get teacher change requests: %w", err)
+	}
+	defer rows.Close()
+
+	return scanChangeRequests(rows)
+}
+
+// GetPendingChangeRequests получает заявки, ожидающие рассмотрения, от старых к новым
+func (r *Repository) GetPendingChangeRequests(ctx context.Context) ([]ChangeRequest, error) {
+	query := `
+		SELECT ` + changeRequestColumns + `
+		FROM change_requests
+		WHERE status = 'pending' AND college_id = $1
+		ORDER BY created_at`
+
+	rows, err := r.db.QueryContext(ctx, query, tenant.CollegeID(ctx))
+	if err != nil {
+		return nil, fmt.Errorf("failed to get pending change requests: %w", err)
+	}
+	defer rows.Close()
+
+	return scanChangeRequests(rows)
+}
+
+// ReviewChangeRequest сохраняет решение администратора по заявке.
+// Решение принимается только для заявок, ожидающих рассмотрения.
+func (r *Repository) ReviewChangeRequest(ctx context.Context, request *ChangeRequest) error {
+	query := `
+		UPDATE change_requests
+		SET status = $2, reviewed_by = $3, reviewed_at = NOW(), review_comment = NULLIF($4, '')
+		WHERE id = $1 AND status = 'pending' AND college_id = $5
+		RETURNING reviewed_at`
+
+	err := r.db.QueryRowContext(ctx, query, request.ID, request.Status, request.ReviewedBy, request.ReviewComment,
+		tenant.CollegeID(ctx)).
+		Scan(&request.ReviewedAt)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return fmt.Errorf("change request %s is not pending: %w", request.ID, apperr.ErrConflict)
+		}
+		return fmt.Errorf("failed to review change request: %w", err)
+	}
+	return nil
+}
+
+// scanChangeRequests сканирует строки change_requests, выбранные с колонками changeRequestColumns
+func scanChangeRequests(rows *sql.Rows) ([]ChangeRequest, error) {
+	var requests []ChangeRequest
+	for rows.Next() {
+		var request ChangeRequest
+		err := rows.Scan(
+			&request.ID,
+			&request.TeacherID,
+			&request.Kind,
+			&request.GroupName,
+			&request.Date,
+			&request.TimeStart,
+			&request.TimeEnd,
+			&request.Subject,
+			&request.Teacher,
+			&request.Classroom,
+			&request.NewDate,
+			&request.NewTimeStart,
+			&request.NewTimeEnd,
+			&request.NewLessonNumber,
+			&request.NewClassroom,
+			&request.Comment,
+			&request.Status,
+			&request.ReviewedBy,
+			&request.ReviewedAt,
+			&request.ReviewComment,
+			&request.CreatedAt,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan change request: %w", err)
+		}
+		request.TimeStart = clock.NormalizeClock(request.TimeStart)
+		request.TimeEnd = clock.NormalizeClock(request.TimeEnd)
+		request.NewTimeStart = clock.NormalizeClock(request.NewTimeStart)
+		request.NewTimeEnd = clock.NormalizeClock(request.NewTimeEnd)
+		requests = append(requests, request)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating rows: %w", err)
+	}
+
+	return requests, nil
+}
+
+// changeColumns список колонок schedule_changes в порядке сканирования scanChanges
+const changeColumns = `id, snapshot_id, group_name, date, time_start, time_end, subject, teacher, classroom,
+		change_type, original_subject, created_at, is_active, COALESCE(lesson_number, 0), has_overlap,
+		apply_status, COALESCE(apply_error, ''), applied_at,
+		moderation_status, moderated_by, moderated_at, COALESCE(moderation_comment, ''),
+		COALESCE(fingerprint, ''), last_seen_at, reverted_at, request_id, supersedes_id, COALESCE(reason, ''), subgroup,
+		lesson_type`
+
+// scanChanges сканирует строки schedule_changes, выбранные с колонками changeColumns
+func scanChanges(rows *sql.Rows) ([]ScheduleChange, error) {
+	var changes []ScheduleChange
+	for rows.Next() {
+		var change ScheduleChange
+		err := rows.Scan(
+			&change.ID,
+			&change.SnapshotID,
+			&change.GroupName,
+			&change.Date,
+			&change.TimeStart,
+			&change.TimeEnd,
+			&change.Subject,
+			&change.Teacher,
+			&change.Classroom,
+			&change.ChangeType,
+			&change.OriginalSubject,
+			&change.CreatedAt,
+			&change.IsActive,
+			&change.LessonNumber,
+			&change.HasOverlap,
+			&change.ApplyStatus,
+			&change.ApplyError,
+			&change.AppliedAt,
+			&change.ModerationStatus,
+			&change.ModeratedBy,
+			&change.ModeratedAt,
+			&change.ModerationComment,
+			&change.Fingerprint,
+			&change.LastSeenAt,
+			&change.RevertedAt,
+			&change.RequestID,
+			&change.SupersedesID,
+			&change.Reason,
+			&change.Subgroup,
+			&change.LessonType,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan change: %w", err)
+		}
+		change.TimeStart = clock.NormalizeClock(change.TimeStart)
+		change.TimeEnd = clock.NormalizeClock(change.TimeEnd)
+		changes = append(changes, change)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating rows: %w", err)
+	}
+
 	return changes, nil
 }
@@ -0,0 +1,78 @@
+package schedule
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/Ultrahd-dev/student-schedule-app/backend/internal/apperr"
+	"github.com/Ultrahd-dev/student-schedule-app/backend/internal/clock"
+)
+
+const (
+	// searchMinQueryLength минимальная длина поискового запроса в символах
+	searchMinQueryLength = 2
+	// searchDefaultLimit количество результатов поиска по умолчанию
+	searchDefaultLimit = 50
+	// searchMaxLimit максимальное количество результатов поиска
+	searchMaxLimit = 200
+	// searchDefaultDays период поиска по умолчанию (в днях от сегодняшнего)
+	searchDefaultDays = 30
+	// SearchSimilarityThreshold порог сходства (word_similarity) для нечетких совпадений.
+	// Ниже стандартного 0.6, чтобы сокращения вроде "матан" находили "Математический анализ".
+	SearchSimilarityThreshold = 0.3
+)
+
+// SearchResult запись расписания, найденная поиском, с оценкой релевантности
+type SearchResult struct {
+	Entry CurrentSchedule
+	Rank  float64
+}
+
+// SearchSchedule выполняет нечеткий поиск по предметам, преподавателям и аудиториям
+// в актуальном расписании за период [from, to]. Нулевые даты означают период
+// от сегодняшнего дня на searchDefaultDays дней вперед.
+func (s *Service) SearchSchedule(ctx context.Context, query string, from, to time.Time, limit int) ([]SearchResult, error) {
+	query = strings.TrimSpace(query)
+	if utf8.RuneCountInString(query) < searchMinQueryLength {
+		return nil, apperr.New(apperr.ErrValidation,
+			fmt.Sprintf("поисковый запрос должен содержать не менее %d символов", searchMinQueryLength))
+	}
+
+	if from.IsZero() {
+		from = clock.Today(s.loc)
+	} else {
+		from = clock.DateOf(from, s.loc)
+	}
+	if to.IsZero() {
+		to = from.AddDate(0, 0, searchDefaultDays)
+	} else {
+		to = clock.DateOf(to, s.loc)
+	}
+	if to.Before(from) {
+		return nil, ErrInvalidPeriod
+	}
+
+	if limit <= 0 {
+		limit = searchDefaultLimit
+	}
+	if limit > searchMaxLimit {
+		limit = searchMaxLimit
+	}
+
+	log.Printf("Поиск в расписании %q с %s по %s", query, from.Format("2006-01-02"), to.Format("2006-01-02"))
+
+	results, err := s.repo.SearchCurrentSchedule(ctx, query, from, to, limit)
+	if err != nil {
+		return nil, fmt.Errorf("ошибка поиска в расписании: %w", err)
+	}
+
+	for i := range results {
+		results[i].Entry.Date = clock.Anchor(results[i].Entry.Date, s.loc)
+	}
+
+	return results, nil
+}
@@ -4,37 +4,140 @@ package schedule
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
 	"log"
+	"strings"
 	"time"
+
+	"github.com/Ultrahd-dev/student-schedule-app/backend/internal/clock"
+	"github.com/google/uuid"
 )
 
 // Service предоставляет функции для обработки расписания
 type Service struct {
-	repo *Repository
+	repo ScheduleStore
+	loc  *time.Location // Часовой пояс колледжа
+
+	subjects subjectMetadataCache
+	local    *localDayCache // Кэш расписания групп в памяти (nil - выключен)
 }
 
 // NewService создает новый сервис обработки расписания
-func NewService(repo *Repository) *Service {
+// loc - часовой пояс колледжа, в котором интерпретируются даты расписания
+func NewService(repo ScheduleStore, loc *time.Location) *Service {
 	return &Service{
 		repo: repo,
+		loc:  loc,
 	}
 }
 
 // GetScheduleForGroup получает расписание для группы на определенную дату
 func (s *Service) GetScheduleForGroup(ctx context.Context, groupName string, date time.Time) ([]CurrentSchedule, error) {
+	// Приводим момент времени к календарному дню в часовом поясе колледжа,
+	// иначе полночь по местному времени, переданная клиентом в UTC, попадет на предыдущий день
+	date = clock.DateOf(date, s.loc)
+	return s.cachedSchedule(ctx, groupName, date, func() ([]CurrentSchedule, error) {
+		return s.loadScheduleForGroup(ctx, groupName, date)
+	})
+}
+
+// loadScheduleForGroup получает расписание группы на календарный день date из базы
+func (s *Service) loadScheduleForGroup(ctx context.Context, groupName string, date time.Time) ([]CurrentSchedule, error) {
 	log.Printf("Получаем расписание для группы %s на дату %s", groupName, date.Format("2006-01-02"))
 
+	// Расписание на сегодня - самый частый запрос, отдаем его из предрассчитанного кэша
+	if date.Equal(clock.Today(s.loc)) {
+		schedules, found, err := s.repo.GetDayCache(ctx, groupName, date)
+		if err != nil {
+			log.Printf("Ошибка чтения кэша расписания на сегодня для группы %s: %v", groupName, err)
+		} else if found {
+			return schedules, nil
+		} else if err := s.repo.FillDayCache(ctx, groupName, date); err != nil {
+			log.Printf("Ошибка заполнения кэша расписания на сегодня для группы %s: %v", groupName, err)
+		}
+	}
+
 	// Получаем актуальное расписание из БД
 	schedules, err := s.repo.GetCurrentScheduleForGroup(ctx, groupName, date)
 	if err != nil {
 		return nil, fmt.Errorf("ошибка получения расписания: %w", err)
 	}
 
+	for i := range schedules {
+		schedules[i].Date = clock.Anchor(schedules[i].Date, s.loc)
+	}
+
 	log.Printf("Получено %d записей расписания для группы %s", len(schedules), groupName)
 	return schedules, nil
 }
 
+// GetScheduleForGroupAsOf получает расписание группы на дату в том виде,
+// в котором его видели пользователи в момент asOf (для разбора спорных ситуаций)
+func (s *Service) GetScheduleForGroupAsOf(ctx context.Context, groupName string, date time.Time, asOf time.Time) ([]CurrentSchedule, error) {
+	date = clock.DateOf(date, s.loc)
+	log.Printf("Получаем расписание для группы %s на дату %s по состоянию на %s",
+		groupName, date.Format("2006-01-02"), asOf.In(s.loc).Format(time.RFC3339))
+
+	schedules, err := s.repo.GetScheduleForGroupAsOf(ctx, groupName, date, asOf)
+	if err != nil {
+		return nil, fmt.Errorf("ошибка получения истории расписания: %w", err)
+	}
+
+	for i := range schedules {
+		schedules[i].Date = clock.Anchor(schedules[i].Date, s.loc)
+	}
+
+	return schedules, nil
+}
+
+// GetScheduleForGroupRange получает расписание группы за период [from, to] (включительно)
+func (s *Service) GetScheduleForGroupRange(ctx context.Context, groupName string, from, to time.Time) ([]CurrentSchedule, error) {
+	from, to = clock.DateOf(from, s.loc), clock.DateOf(to, s.loc)
+
+	schedules, err := s.repo.GetCurrentScheduleForGroupRange(ctx, groupName, from, to)
+	if err != nil {
+		return nil, fmt.Errorf("ошибка получения расписания группы за период: %w", err)
+	}
+
+	for i := range schedules {
+		schedules[i].Date = clock.Anchor(schedules[i].Date, s.loc)
+	}
+
+	return schedules, nil
+}
+
+// TeacherHasGroup проверяет, что преподаватель (под любым из имен names) ведет занятия у группы
+func (s *Service) TeacherHasGroup(ctx context.Context, names []string, groupName string) (bool, error) {
+	ok, err := s.repo.HasTeacherLessonsWithGroup(ctx, names, groupName)
+	if err != nil {
+		return false, fmt.Errorf("ошибка проверки занятий преподавателя с группой: %w", err)
+	}
+	return ok, nil
+}
+
+// GetScheduleForTeacher получает расписание преподавателя за период [from, to] (включительно).
+// names - все имена, под которыми преподаватель встречается в расписании (ФИО и его варианты).
+func (s *Service) GetScheduleForTeacher(ctx context.Context, names []string, from, to time.Time) ([]CurrentSchedule, error) {
+	from, to = clock.DateOf(from, s.loc), clock.DateOf(to, s.loc)
+
+	schedules, err := s.repo.GetCurrentScheduleForTeachers(ctx, names, from, to)
+	if err != nil {
+		return nil, fmt.Errorf("ошибка получения расписания преподавателя: %w", err)
+	}
+
+	for i := range schedules {
+		schedules[i].Date = clock.Anchor(schedules[i].Date, s.loc)
+	}
+
+	return schedules, nil
+}
+
+// Location возвращает часовой пояс колледжа, в котором работает сервис
+func (s *Service) Location() *time.Location {
+	return s.loc
+}
+
 // ProcessScheduleSnapshot обрабатывает новый снапшот расписания
 func (s *Service) ProcessScheduleSnapshot(ctx context.Context, snapshot *ScheduleSnapshot) error {
 	log.Printf("Обрабатываем снапшот расписания: %s", snapshot.Name)
@@ -63,11 +166,101 @@ func (s *Service) ApplyScheduleChanges(ctx context.Context, changes []ScheduleCh
 	return nil
 }
 
-// GetActiveScheduleSnapshot получает активный снапшот расписания
+// GetSnapshotsHistory получает историю снапшотов (без данных расписания)
+func (s *Service) GetSnapshotsHistory(ctx context.Context, limit int) ([]ScheduleSnapshot, error) {
+	if limit <= 0 || limit > 100 {
+		limit = 20
+	}
+
+	snapshots, err := s.repo.ListSnapshots(ctx, limit)
+	if err != nil {
+		return nil, fmt.Errorf("ошибка получения истории снапшотов: %w", err)
+	}
+
+	return snapshots, nil
+}
+
+// ArchiveOldSnapshots оставляет в основной таблице данные keep последних снапшотов,
+// а данные остальных переносит в архив
+func (s *Service) ArchiveOldSnapshots(ctx context.Context, keep int) (int, error) {
+	archived, err := s.repo.ArchiveOldSnapshots(ctx, keep)
+	if err != nil {
+		return 0, fmt.Errorf("ошибка архивации снапшотов: %w", err)
+	}
+
+	if archived > 0 {
+		log.Printf("Заархивировано снапшотов расписания: %d", archived)
+	}
+	return archived, nil
+}
+
+// RefreshTodayCache пересобирает кэш расписания на сегодня для всех групп
+// и удаляет кэш за прошедшие дни
+func (s *Service) RefreshTodayCache(ctx context.Context) error {
+	today := clock.Today(s.loc)
+
+	if _, err := s.repo.PruneDayCache(ctx, today); err != nil {
+		return fmt.Errorf("ошибка очистки кэша расписания: %w", err)
+	}
+
+	groups, err := s.repo.RebuildDayCache(ctx, today)
+	if err != nil {
+		return fmt.Errorf("ошибка пересборки кэша расписания на сегодня: %w", err)
+	}
+
+	log.Printf("Кэш расписания на %s пересобран для %d групп", today.Format("2006-01-02"), groups)
+	return nil
+}
+
+// EnsureSchedulePartitions создает секции актуального расписания на текущий
+// и monthsAhead следующих месяцев, чтобы новые записи не попадали в секцию по умолчанию
+func (s *Service) EnsureSchedulePartitions(ctx context.Context, monthsAhead int) error {
+	created, err := s.repo.EnsureSchedulePartitions(ctx, clock.Today(s.loc), monthsAhead+1)
+	if err != nil {
+		return fmt.Errorf("ошибка создания секций актуального расписания: %w", err)
+	}
+	if created > 0 {
+		log.Printf("Создано секций актуального расписания: %d", created)
+	}
+	return nil
+}
+
+// GetChangesForSnapshot получает все изменения относительно снапшота
+func (s *Service) GetChangesForSnapshot(ctx context.Context, snapshotID uuid.UUID) ([]ScheduleChange, error) {
+	changes, err := s.repo.GetChangesForSnapshot(ctx, snapshotID)
+	if err != nil {
+		return nil, fmt.Errorf("ошибка получения изменений снапшота: %w", err)
+	}
+
+	for i := range changes {
+		changes[i].Date = clock.Anchor(changes[i].Date, s.loc)
+	}
+	return changes, nil
+}
+
+// GetOverlappingChanges получает изменения за период [from, to], добавленные пары
+// которых пересекаются с другими занятиями группы
+func (s *Service) GetOverlappingChanges(ctx context.Context, from, to time.Time) ([]ScheduleChange, error) {
+	from = clock.DateOf(from, s.loc)
+	to = clock.DateOf(to, s.loc)
+
+	changes, err := s.repo.GetOverlappingChanges(ctx, from, to)
+	if err != nil {
+		return nil, fmt.Errorf("ошибка получения пересекающихся изменений: %w", err)
+	}
+
+	for i := range changes {
+		changes[i].Date = clock.Anchor(changes[i].Date, s.loc)
+	}
+	return changes, nil
+}
+
+// GetActiveScheduleSnapshot получает метаданные активного снапшота расписания
+// (данные получаются отдельно через GetSnapshotData)
 func (s *Service) GetActiveScheduleSnapshot(ctx context.Context) (*ScheduleSnapshot, error) {
 	log.Println("Получаем активный снапшот расписания")
 
-	snapshot, err := s.repo.GetActiveSnapshot(ctx)
+	snapshot, err := s.repo.GetActiveSnapshotMeta(ctx)
 	if err != nil {
 		return nil, fmt.Errorf("ошибка получения активного снапшота: %w", err)
 	}
@@ -75,3 +268,31 @@ func (s *Service) GetActiveScheduleSnapshot(ctx context.Context) (*ScheduleSnaps
 	log.Printf("Получен активный снапшот: %s", snapshot.Name)
 	return snapshot, nil
 }
+
+// GetScheduleFromSnapshot получает расписание группы по дням из снапшота.
+// Из базы читается только часть данных снапшота, относящаяся к группе.
+// Возвращает пустой список, если группы в снапшоте нет.
+func (s *Service) GetScheduleFromSnapshot(ctx context.Context, id uuid.UUID, groupName string) ([]DaySchedule, error) {
+	raw, err := s.repo.GetSnapshotGroupData(ctx, id, strings.TrimSpace(groupName))
+	if err != nil {
+		return nil, fmt.Errorf("ошибка получения расписания группы из снапшота %s: %w", id, err)
+	}
+	if raw == nil {
+		return []DaySchedule{}, nil
+	}
+
+	var days []DaySchedule
+	if err := json.Unmarshal(raw, &days); err != nil {
+		return nil, fmt.Errorf("ошибка разбора расписания группы из снапшота %s: %w", id, err)
+	}
+	return days, nil
+}
+
+// GetSnapshotData получает JSON данные снапшота, в том числе архивного
+func (s *Service) GetSnapshotData(ctx context.Context, id uuid.UUID) ([]byte, error) {
+	data, err := s.repo.GetSnapshotData(ctx, id)
+	if err != nil {
+		return nil, fmt.Errorf("ошибка получения данных снапшота %s: %w", id, err)
+	}
+	return data, nil
+}
@@ -0,0 +1,121 @@
+package schedule
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"sort"
+	"time"
+
+	"github.com/Ultrahd-dev/student-schedule-app/backend/internal/bells"
+	"github.com/Ultrahd-dev/student-schedule-app/backend/internal/clock"
+)
+
+// FreeSlot свободное окно в расписании
+type FreeSlot struct {
+	TimeStart     string // "HH:MM"
+	TimeEnd       string // "HH:MM"
+	LessonNumbers []int  // Номера пар, целиком попадающих в окно
+}
+
+// interval промежуток времени в минутах от полуночи [start, end)
+type interval struct {
+	start, end int
+}
+
+// FindFreeSlots находит общие свободные окна на дату для набора групп и/или преподавателя.
+// Учебный день ограничивается первой и последней парой по расписанию звонков,
+// занятость берется из current_schedule. Окна короче minDuration отбрасываются.
+func (s *Service) FindFreeSlots(ctx context.Context, date time.Time, groupNames []string, teacher string, minDuration time.Duration) ([]FreeSlot, error) {
+	date = clock.DateOf(date, s.loc)
+	log.Printf("Ищем свободные окна на %s для групп %v и преподавателя %q", date.Format("2006-01-02"), groupNames, teacher)
+
+	timings := bells.For(ctx).ForWeekday(date.Weekday())
+	if len(timings) == 0 {
+		return []FreeSlot{}, nil
+	}
+
+	var busy []interval
+	for _, groupName := range groupNames {
+		entries, err := s.repo.GetCurrentScheduleForGroup(ctx, groupName, date)
+		if err != nil {
+			return nil, fmt.Errorf("ошибка получения расписания группы %s: %w", groupName, err)
+		}
+		busy = append(busy, entriesToIntervals(entries)...)
+	}
+	if teacher != "" {
+		entries, err := s.repo.GetCurrentScheduleForTeacher(ctx, teacher, date, date)
+		if err != nil {
+			return nil, fmt.Errorf("ошибка получения расписания преподавателя %s: %w", teacher, err)
+		}
+		busy = append(busy, entriesToIntervals(entries)...)
+	}
+
+	dayStart := timings[0].StartMinutes()
+	dayEnd := timings[len(timings)-1].EndMinutes()
+
+	var slots []FreeSlot
+	for _, window := range freeWindows(dayStart, dayEnd, busy) {
+		if time.Duration(window.end-window.start)*time.Minute < minDuration {
+			continue
+		}
+
+		slot := FreeSlot{
+			TimeStart: clock.FormatClock(window.start),
+			TimeEnd:   clock.FormatClock(window.end),
+		}
+		for _, timing := range timings {
+			if timing.StartMinutes() >= window.start && timing.EndMinutes() <= window.end {
+				slot.LessonNumbers = append(slot.LessonNumbers, timing.Number)
+			}
+		}
+		slots = append(slots, slot)
+	}
+
+	log.Printf("Найдено %d свободных окон", len(slots))
+	return slots, nil
+}
+
+// entriesToIntervals преобразует записи расписания в интервалы занятости
+func entriesToIntervals(entries []CurrentSchedule) []interval {
+	intervals := make([]interval, 0, len(entries))
+	for _, entry := range entries {
+		start, err := clock.ParseClock(entry.TimeStart)
+		if err != nil {
+			continue
+		}
+		end, err := clock.ParseClock(entry.TimeEnd)
+		if err != nil || end <= start {
+			continue
+		}
+		intervals = append(intervals, interval{start: start, end: end})
+	}
+	return intervals
+}
+
+// freeWindows вычитает интервалы занятости из промежутка [dayStart, dayEnd)
+func freeWindows(dayStart, dayEnd int, busy []interval) []interval {
+	sort.Slice(busy, func(i, j int) bool {
+		return busy[i].start < busy[j].start
+	})
+
+	var windows []interval
+	cursor := dayStart
+	for _, b := range busy {
+		if b.end <= cursor {
+			continue
+		}
+		if b.start >= dayEnd {
+			break
+		}
+		if b.start > cursor {
+			windows = append(windows, interval{start: cursor, end: b.start})
+		}
+		cursor = b.end
+	}
+	if cursor < dayEnd {
+		windows = append(windows, interval{start: cursor, end: dayEnd})
+	}
+
+	return windows
+}
@@ -0,0 +1,120 @@
+package schedule
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/Ultrahd-dev/student-schedule-app/backend/internal/apperr"
+	"github.com/Ultrahd-dev/student-schedule-app/backend/internal/clock"
+)
+
+// ErrInvalidPeriod период статистики или поиска задан в обратном порядке
+var ErrInvalidPeriod = apperr.New(apperr.ErrValidation, "дата окончания периода раньше даты начала")
+
+// AcademicHourMinutes длительность академического часа в минутах
+const AcademicHourMinutes = 45
+
+// WorkloadStat агрегированная нагрузка по предмету
+type WorkloadStat struct {
+	GroupName string
+	Teacher   string
+	Subject   string
+	WeekStart *time.Time // Понедельник недели; nil для итога за весь период
+	Lessons   int        // Количество занятий
+	Minutes   int        // Суммарная длительность занятий в минутах
+}
+
+// AcademicHours возвращает нагрузку в академических часах
+func (w WorkloadStat) AcademicHours() float64 {
+	return float64(w.Minutes) / AcademicHourMinutes
+}
+
+// WorkloadFilter параметры агрегации нагрузки
+type WorkloadFilter struct {
+	GroupName string // Нагрузка группы
+	Teacher   string // Нагрузка преподавателя (если GroupName пуст)
+	From      time.Time
+	To        time.Time
+	ByWeek    bool // Разбивать по неделям, иначе - итог за период (семестр)
+}
+
+// GetWorkloadStats возвращает количество часов по предметам для группы или преподавателя
+func (s *Service) GetWorkloadStats(ctx context.Context, filter WorkloadFilter) ([]WorkloadStat, error) {
+	if filter.GroupName == "" && filter.Teacher == "" {
+		return nil, apperr.New(apperr.ErrValidation, "необходимо указать группу или преподавателя")
+	}
+
+	filter.From = clock.DateOf(filter.From, s.loc)
+	filter.To = clock.DateOf(filter.To, s.loc)
+	if filter.To.Before(filter.From) {
+		return nil, ErrInvalidPeriod
+	}
+
+	log.Printf("Считаем нагрузку (группа %q, преподаватель %q) с %s по %s",
+		filter.GroupName, filter.Teacher, filter.From.Format("2006-01-02"), filter.To.Format("2006-01-02"))
+
+	stats, err := s.repo.GetWorkloadStats(ctx, filter)
+	if err != nil {
+		return nil, fmt.Errorf("ошибка получения статистики нагрузки: %w", err)
+	}
+
+	for i := range stats {
+		if stats[i].WeekStart != nil {
+			weekStart := clock.Anchor(*stats[i].WeekStart, s.loc)
+			stats[i].WeekStart = &weekStart
+		}
+	}
+
+	return stats, nil
+}
+
+// TeacherWorkload нагрузка преподавателя за период
+type TeacherWorkload struct {
+	Teacher string         // Пусто - занятия без преподавателя
+	Stats   []WorkloadStat // Нагрузка по группам и предметам (и неделям)
+	Lessons int            // Всего занятий за период
+	Minutes int            // Всего минут за период
+}
+
+// AcademicHours возвращает нагрузку преподавателя в академических часах
+func (t TeacherWorkload) AcademicHours() float64 {
+	return float64(t.Minutes) / AcademicHourMinutes
+}
+
+// GetTeacherWorkload возвращает нагрузку всех преподавателей колледжа за период
+// (неделю или семестр) в часах по группам и предметам, по неделям, если filter.ByWeek.
+// Занятия без преподавателя собираются в последнюю запись с пустым Teacher.
+func (s *Service) GetTeacherWorkload(ctx context.Context, filter WorkloadFilter) ([]TeacherWorkload, error) {
+	filter.From = clock.DateOf(filter.From, s.loc)
+	filter.To = clock.DateOf(filter.To, s.loc)
+	if filter.To.Before(filter.From) {
+		return nil, ErrInvalidPeriod
+	}
+
+	log.Printf("Считаем нагрузку преподавателей с %s по %s",
+		filter.From.Format("2006-01-02"), filter.To.Format("2006-01-02"))
+
+	stats, err := s.repo.GetTeacherWorkload(ctx, filter)
+	if err != nil {
+		return nil, fmt.Errorf("ошибка получения нагрузки преподавателей: %w", err)
+	}
+
+	var workloads []TeacherWorkload
+	for _, stat := range stats {
+		if stat.WeekStart != nil {
+			weekStart := clock.Anchor(*stat.WeekStart, s.loc)
+			stat.WeekStart = &weekStart
+		}
+		if len(workloads) == 0 || workloads[len(workloads)-1].Teacher != stat.Teacher {
+			workloads = append(workloads, TeacherWorkload{Teacher: stat.Teacher})
+		}
+		workload := &workloads[len(workloads)-1]
+		workload.Stats = append(workload.Stats, stat)
+		workload.Lessons += stat.Lessons
+		workload.Minutes += stat.Minutes
+	}
+
+	return workloads, nil
+}
@@ -0,0 +1,80 @@
+package schedule
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// ScheduleStore хранилище расписания, которое используют сервисы расписания,
+// изменений, уведомлений и парсера. Реализуется Repository; в тестах
+// подменяется моком из internal/mocks.
+//
+//go:generate go run ../../cmd/mockgen -source store.go -interface ScheduleStore -out ../mocks/schedule_store.go
+type ScheduleStore interface {
+	GetDataStatus(ctx context.Context) (*DataStatus, error)
+	GetCurrentScheduleEntryByID(ctx context.Context, id uuid.UUID) (*CurrentSchedule, error)
+	SetMeetingURL(ctx context.Context, entry *CurrentSchedule, meetingURL string) error
+	SaveSnapshot(ctx context.Context, snapshot *ScheduleSnapshot) (bool, error)
+	GetActiveSnapshotMeta(ctx context.Context) (*ScheduleSnapshot, error)
+	FindSnapshotIDForDate(ctx context.Context, date time.Time) (*uuid.UUID, error)
+	GetSnapshotMeta(ctx context.Context, id uuid.UUID) (*ScheduleSnapshot, error)
+	GetSnapshotData(ctx context.Context, id uuid.UUID) ([]byte, error)
+	GetSnapshotGroupData(ctx context.Context, id uuid.UUID, groupName string) ([]byte, error)
+	ListSnapshots(ctx context.Context, limit int) ([]ScheduleSnapshot, error)
+	ArchiveOldSnapshots(ctx context.Context, keep int) (int, error)
+	CreateChange(ctx context.Context, change *ScheduleChange) error
+	GetCurrentScheduleForGroup(ctx context.Context, groupName string, date time.Time) ([]CurrentSchedule, error)
+	GetCurrentScheduleForGroupRange(ctx context.Context, groupName string, from, to time.Time) ([]CurrentSchedule, error)
+	GetCurrentScheduleForTeacher(ctx context.Context, teacher string, from, to time.Time) ([]CurrentSchedule, error)
+	GetCurrentScheduleForTeachers(ctx context.Context, teachers []string, from, to time.Time) ([]CurrentSchedule, error)
+	GetCurrentScheduleForClassrooms(ctx context.Context, classrooms []string, date time.Time) ([]CurrentSchedule, error)
+	HasTeacherLessonsWithGroup(ctx context.Context, teachers []string, groupName string) (bool, error)
+	SearchCurrentSchedule(ctx context.Context, search string, from, to time.Time, limit int) ([]SearchResult, error)
+	GetWorkloadStats(ctx context.Context, filter WorkloadFilter) ([]WorkloadStat, error)
+	GetTeacherWorkload(ctx context.Context, filter WorkloadFilter) ([]WorkloadStat, error)
+	GetChangesByGroupMonth(ctx context.Context, from, to time.Time) ([]GroupMonthChanges, error)
+	GetMostCancelledSubjects(ctx context.Context, from, to time.Time, limit int) ([]SubjectCancellations, error)
+	GetBusiestReplacementDays(ctx context.Context, from, to time.Time, limit int) ([]DayReplacements, error)
+	GetDayCache(ctx context.Context, groupName string, date time.Time) ([]CurrentSchedule, bool, error)
+	FillDayCache(ctx context.Context, groupName string, date time.Time) error
+	RebuildDayCache(ctx context.Context, date time.Time) (int, error)
+	PruneDayCache(ctx context.Context, before time.Time) (int64, error)
+	EnsureSchedulePartitions(ctx context.Context, from time.Time, months int) (int, error)
+	ArchiveScheduleBefore(ctx context.Context, before time.Time) (int64, error)
+	GetArchivedScheduleForGroup(ctx context.Context, groupName string, date time.Time) ([]CurrentSchedule, error)
+	ListSubjectMetadata(ctx context.Context) ([]SubjectMetadata, error)
+	UpsertSubjectMetadata(ctx context.Context, meta *SubjectMetadata) error
+	DeleteSubjectMetadata(ctx context.Context, subject string) error
+	GetCurrentScheduleEntry(ctx context.Context, groupName string, subgroup int, date time.Time, timeStart string) (*CurrentSchedule, error)
+	UpdateCurrentScheduleEntry(ctx context.Context, entry *CurrentSchedule) error
+	CreateCurrentScheduleEntry(ctx context.Context, entry *CurrentSchedule) error
+	GetScheduleForGroupAsOf(ctx context.Context, groupName string, date time.Time, asOf time.Time) ([]CurrentSchedule, error)
+	GetChangesForGroup(ctx context.Context, groupName string, date time.Time) ([]ScheduleChange, error)
+	GetChangesForSnapshot(ctx context.Context, snapshotID uuid.UUID) ([]ScheduleChange, error)
+	GetOverlappingChanges(ctx context.Context, from, to time.Time) ([]ScheduleChange, error)
+	GetPendingChanges(ctx context.Context, limit int) ([]ScheduleChange, error)
+	GetChangeByID(ctx context.Context, id uuid.UUID) (*ScheduleChange, error)
+	GetChangesByIDs(ctx context.Context, ids []uuid.UUID) ([]ScheduleChange, error)
+	GetChangesAwaitingModeration(ctx context.Context) ([]ScheduleChange, error)
+	GetRecentChanges(ctx context.Context, limit int) ([]ScheduleChange, error)
+	ModerateChange(ctx context.Context, change *ScheduleChange) error
+	GetTrackedChanges(ctx context.Context) ([]ScheduleChange, error)
+	MarkChangesSeen(ctx context.Context, ids []uuid.UUID) error
+	RevertChange(ctx context.Context, change *ScheduleChange) error
+	GetEntriesBySource(ctx context.Context, sourceID uuid.UUID) ([]CurrentSchedule, error)
+	GetEntryVersionBefore(ctx context.Context, entryID, sourceID uuid.UUID) (*CurrentSchedule, error)
+	SetChangeApplyStatus(ctx context.Context, changeID uuid.UUID, status, applyError string) error
+	MarkChangeApplyError(ctx context.Context, changeID uuid.UUID, applyError string) error
+	UpdateChangeSlot(ctx context.Context, change *ScheduleChange) error
+	SetChangeSupersedes(ctx context.Context, changeID, supersedesID uuid.UUID) error
+	FindOverlappingEntries(ctx context.Context, groupName string, date time.Time, timeStart, timeEnd string) ([]CurrentSchedule, error)
+	CreateChangeRequest(ctx context.Context, request *ChangeRequest) error
+	GetChangeRequestByID(ctx context.Context, id uuid.UUID) (*ChangeRequest, error)
+	GetChangeRequestsByTeacher(ctx context.Context, teacherID uuid.UUID, limit int) ([]ChangeRequest, error)
+	GetPendingChangeRequests(ctx context.Context) ([]ChangeRequest, error)
+	ReviewChangeRequest(ctx context.Context, request *ChangeRequest) error
+}
+
+var _ ScheduleStore = (*Repository)(nil)
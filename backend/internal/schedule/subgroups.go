@@ -0,0 +1,35 @@
+package schedule
+
+// ForSubgroup оставляет занятия всей группы и подгруппы subgroup.
+// subgroup 0 (подгруппа не выбрана) - возвращаются все занятия.
+func ForSubgroup(entries []CurrentSchedule, subgroup int) []CurrentSchedule {
+	if subgroup == 0 {
+		return entries
+	}
+	filtered := make([]CurrentSchedule, 0, len(entries))
+	for _, entry := range entries {
+		if entry.Subgroup == 0 || entry.Subgroup == subgroup {
+			filtered = append(filtered, entry)
+		}
+	}
+	return filtered
+}
+
+// DaysForSubgroup оставляет в расписании снапшота по дням занятия всей группы
+// и подгруппы subgroup (0 - все занятия)
+func DaysForSubgroup(days []DaySchedule, subgroup int) []DaySchedule {
+	if subgroup == 0 {
+		return days
+	}
+	filtered := make([]DaySchedule, 0, len(days))
+	for _, day := range days {
+		lessons := make([]Lesson, 0, len(day.Lessons))
+		for _, lesson := range day.Lessons {
+			if lesson.Subgroup == 0 || lesson.Subgroup == subgroup {
+				lessons = append(lessons, lesson)
+			}
+		}
+		filtered = append(filtered, DaySchedule{Day: day.Day, Lessons: lessons})
+	}
+	return filtered
+}
@@ -0,0 +1,138 @@
+package schedule
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"strings"
+	"sync"
+	"time"
+	"unicode/utf8"
+
+	"github.com/Ultrahd-dev/student-schedule-app/backend/internal/apperr"
+	"github.com/google/uuid"
+)
+
+// subjectMetadataTTL время жизни кэша параметров отображения предметов
+const subjectMetadataTTL = 5 * time.Minute
+
+// subjectColorPattern допустимый формат цвета: #RRGGBB
+var subjectColorPattern = regexp.MustCompile(`^#[0-9A-Fa-f]{6}$`)
+
+// SubjectMetadata параметры отображения предмета
+// Соответствует таблице subject_metadata
+type SubjectMetadata struct {
+	Subject   string     `db:"subject"`
+	ShortName string     `db:"short_name"`
+	Color     string     `db:"color"` // #RRGGBB
+	Icon      string     `db:"icon"`
+	UpdatedAt time.Time  `db:"updated_at"`
+	UpdatedBy *uuid.UUID `db:"updated_by"`
+}
+
+// subjectMetadataCache кэш параметров отображения: таблица маленькая и меняется редко,
+// а нужна при каждой выдаче расписания
+type subjectMetadataCache struct {
+	mu       sync.RWMutex
+	items    map[string]SubjectMetadata
+	loadedAt time.Time
+}
+
+// GetSubjectMetadata возвращает параметры отображения для указанных предметов.
+// Предметы без настроек в результат не попадают.
+func (s *Service) GetSubjectMetadata(ctx context.Context, subjects []string) (map[string]SubjectMetadata, error) {
+	all, err := s.subjectMetadata(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	result := make(map[string]SubjectMetadata, len(subjects))
+	for _, subject := range subjects {
+		if meta, ok := all[subject]; ok {
+			result[subject] = meta
+		}
+	}
+	return result, nil
+}
+
+// ListSubjectMetadata возвращает параметры отображения всех предметов
+func (s *Service) ListSubjectMetadata(ctx context.Context) ([]SubjectMetadata, error) {
+	items, err := s.repo.ListSubjectMetadata(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("ошибка получения параметров предметов: %w", err)
+	}
+	return items, nil
+}
+
+// UpsertSubjectMetadata создает или обновляет параметры отображения предмета
+func (s *Service) UpsertSubjectMetadata(ctx context.Context, meta *SubjectMetadata) error {
+	meta.Subject = strings.TrimSpace(meta.Subject)
+	meta.ShortName = strings.TrimSpace(meta.ShortName)
+	meta.Color = strings.ToUpper(strings.TrimSpace(meta.Color))
+	meta.Icon = strings.TrimSpace(meta.Icon)
+
+	if meta.Subject == "" {
+		return apperr.New(apperr.ErrValidation, "не указан предмет")
+	}
+	if utf8.RuneCountInString(meta.ShortName) > 32 {
+		return apperr.New(apperr.ErrValidation, "короткое название не должно превышать 32 символа")
+	}
+	if meta.Color != "" && !subjectColorPattern.MatchString(meta.Color) {
+		return apperr.New(apperr.ErrValidation, "цвет должен быть в формате #RRGGBB")
+	}
+	if utf8.RuneCountInString(meta.Icon) > 64 {
+		return apperr.New(apperr.ErrValidation, "название иконки не должно превышать 64 символа")
+	}
+
+	if err := s.repo.UpsertSubjectMetadata(ctx, meta); err != nil {
+		return fmt.Errorf("ошибка сохранения параметров предмета: %w", err)
+	}
+
+	s.invalidateSubjectMetadata()
+	return nil
+}
+
+// DeleteSubjectMetadata удаляет параметры отображения предмета
+func (s *Service) DeleteSubjectMetadata(ctx context.Context, subject string) error {
+	if err := s.repo.DeleteSubjectMetadata(ctx, subject); err != nil {
+		return fmt.Errorf("ошибка удаления параметров предмета: %w", err)
+	}
+
+	s.invalidateSubjectMetadata()
+	return nil
+}
+
+// subjectMetadata возвращает все параметры отображения из кэша, перечитывая их по истечении TTL
+func (s *Service) subjectMetadata(ctx context.Context) (map[string]SubjectMetadata, error) {
+	s.subjects.mu.RLock()
+	if s.subjects.items != nil && time.Since(s.subjects.loadedAt) < subjectMetadataTTL {
+		items := s.subjects.items
+		s.subjects.mu.RUnlock()
+		return items, nil
+	}
+	s.subjects.mu.RUnlock()
+
+	list, err := s.repo.ListSubjectMetadata(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("ошибка получения параметров предметов: %w", err)
+	}
+
+	items := make(map[string]SubjectMetadata, len(list))
+	for _, meta := range list {
+		items[meta.Subject] = meta
+	}
+
+	s.subjects.mu.Lock()
+	s.subjects.items = items
+	s.subjects.loadedAt = time.Now()
+	s.subjects.mu.Unlock()
+
+	return items, nil
+}
+
+// invalidateSubjectMetadata сбрасывает кэш параметров отображения
+func (s *Service) invalidateSubjectMetadata() {
+	s.subjects.mu.Lock()
+	s.subjects.items = nil
+	s.subjects.mu.Unlock()
+}
@@ -0,0 +1,79 @@
+package schedule
+
+import (
+	"sort"
+	"time"
+
+	"github.com/Ultrahd-dev/student-schedule-app/backend/internal/bells"
+	"github.com/Ultrahd-dev/student-schedule-app/backend/internal/clock"
+)
+
+// DaySummary краткая сводка дня для виджета на главном экране
+type DaySummary struct {
+	Current        *CurrentSchedule // Идущая пара (nil - сейчас пары нет)
+	Next           *CurrentSchedule // Следующая пара сегодня (nil - пар больше нет)
+	NextBell       *time.Time       // Ближайший звонок по расписанию звонков (nil - звонков сегодня больше нет)
+	RemainingToday int              // Сколько пар сегодня еще не закончилось, включая идущую
+	ValidUntil     time.Time        // Сводка не изменится до этого момента (если не изменится расписание)
+}
+
+// SummarizeDay составляет сводку дня на момент now по расписанию пользователя на
+// этот день и расписанию звонков колледжа timings. Отмененные пары и пары
+// с некорректным временем не учитываются.
+func SummarizeDay(entries []CurrentSchedule, timings bells.Timings, now time.Time) DaySummary {
+	loc := now.Location()
+	date := clock.DateOf(now, loc)
+	summary := DaySummary{ValidUntil: date.AddDate(0, 0, 1)}
+
+	// boundary сдвигает ValidUntil к ближайшему будущему моменту изменения сводки
+	boundary := func(t time.Time) {
+		if t.After(now) && t.Before(summary.ValidUntil) {
+			summary.ValidUntil = t
+		}
+	}
+
+	type lesson struct {
+		entry      *CurrentSchedule
+		start, end time.Time
+	}
+	var lessons []lesson
+	for i := range entries {
+		entry := &entries[i]
+		if !entry.IsActive {
+			continue
+		}
+		start, err := clock.At(date, entry.TimeStart, loc)
+		if err != nil {
+			continue
+		}
+		end, err := clock.At(date, entry.TimeEnd, loc)
+		if err != nil {
+			continue
+		}
+		lessons = append(lessons, lesson{entry: entry, start: start, end: end})
+	}
+	sort.SliceStable(lessons, func(i, j int) bool { return lessons[i].start.Before(lessons[j].start) })
+
+	for _, l := range lessons {
+		if !l.end.After(now) {
+			continue
+		}
+		summary.RemainingToday++
+		boundary(l.start)
+		boundary(l.end)
+		switch {
+		case !l.start.After(now):
+			if summary.Current == nil {
+				summary.Current = l.entry
+			}
+		case summary.Next == nil:
+			summary.Next = l.entry
+		}
+	}
+
+	if bell := timings.StatusAt(now); bell.State != bells.StateAfterClasses && bell.State != bells.StateDayOff {
+		summary.NextBell = &bell.Until
+		boundary(bell.Until)
+	}
+	return summary
+}
@@ -0,0 +1,51 @@
+package schedule
+
+import (
+	"testing"
+	"time"
+
+	"github.com/Ultrahd-dev/student-schedule-app/backend/internal/bells"
+)
+
+func TestSummarizeDay(t *testing.T) {
+	// Вторник: встроенное расписание звонков будних дней
+	at := func(hour, minute int) time.Time {
+		return time.Date(2025, time.September, 2, hour, minute, 0, 0, time.UTC)
+	}
+	entries := []CurrentSchedule{
+		{Subject: "История", TimeStart: "11:40", TimeEnd: "13:10", IsActive: true},
+		{Subject: "Математика", TimeStart: "08:15", TimeEnd: "09:45", IsActive: true},
+		{Subject: "Физика", TimeStart: "09:55", TimeEnd: "11:25", IsActive: false},
+		{Subject: "Химия", TimeStart: "13:30", TimeEnd: "15:00", IsActive: true},
+	}
+
+	summary := SummarizeDay(entries, bells.Builtin(), at(8, 30))
+	if summary.Current == nil || summary.Current.Subject != "Математика" {
+		t.Errorf("идущая пара: %+v", summary.Current)
+	}
+	if summary.Next == nil || summary.Next.Subject != "История" {
+		t.Errorf("следующая пара (отмененная не учитывается): %+v", summary.Next)
+	}
+	if summary.RemainingToday != 3 {
+		t.Errorf("осталось пар: %d, ожидалось 3", summary.RemainingToday)
+	}
+	if summary.NextBell == nil || !summary.NextBell.Equal(at(9, 0)) {
+		t.Errorf("следующий звонок: %v, ожидался 09:00", summary.NextBell)
+	}
+	if !summary.ValidUntil.Equal(at(9, 0)) {
+		t.Errorf("сводка действительна до %v, ожидалось 09:00", summary.ValidUntil)
+	}
+
+	summary = SummarizeDay(entries, bells.Builtin(), at(13, 20))
+	if summary.Current != nil || summary.Next == nil || summary.Next.Subject != "Химия" || summary.RemainingToday != 1 {
+		t.Errorf("перемена перед последней парой: %+v", summary)
+	}
+
+	summary = SummarizeDay(entries, bells.Builtin(), at(19, 0))
+	if summary.Current != nil || summary.Next != nil || summary.NextBell != nil || summary.RemainingToday != 0 {
+		t.Errorf("после занятий: %+v", summary)
+	}
+	if !summary.ValidUntil.Equal(time.Date(2025, time.September, 3, 0, 0, 0, 0, time.UTC)) {
+		t.Errorf("после занятий сводка действительна до полуночи, получено %v", summary.ValidUntil)
+	}
+}
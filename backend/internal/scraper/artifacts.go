@@ -0,0 +1,41 @@
+package scraper
+
+import (
+	"bytes"
+	"context"
+	"encoding/csv"
+	"fmt"
+	"log"
+
+	"github.com/Ultrahd-dev/student-schedule-app/backend/internal/clock"
+	"github.com/Ultrahd-dev/student-schedule-app/backend/internal/storage"
+	"github.com/Ultrahd-dev/student-schedule-app/backend/internal/tenant"
+)
+
+// SetArtifactStorage включает сохранение исходных таблиц парсинга (CSV) в хранилище
+// файлов: по ним можно разобрать ошибку парсинга после того, как таблицу изменили.
+// Старые таблицы удаляет очистка хранилища по правилам lifecycle для префикса scrapes/.
+func (s *Service) SetArtifactStorage(store storage.Storage) {
+	s.artifacts = store
+}
+
+// saveArtifact сохраняет исходную таблицу kind (main, changes) под ключом
+// scrapes/<колледж>/<дата>/<время>-<kind>.csv. Ошибка сохранения не прерывает парсинг.
+func (s *Service) saveArtifact(ctx context.Context, kind string, records [][]string) {
+	if s.artifacts == nil {
+		return
+	}
+
+	var buf bytes.Buffer
+	writer := csv.NewWriter(&buf)
+	if err := writer.WriteAll(records); err != nil {
+		log.Printf("Ошибка сохранения исходной таблицы %s: %v", kind, err)
+		return
+	}
+
+	now := clock.Now(s.loc)
+	key := fmt.Sprintf("scrapes/%s/%s/%s-%s.csv", tenant.CollegeID(ctx), now.Format("2006-01-02"), now.Format("150405"), kind)
+	if err := s.artifacts.Put(ctx, key, &buf); err != nil {
+		log.Printf("Ошибка сохранения исходной таблицы %s: %v", kind, err)
+	}
+}
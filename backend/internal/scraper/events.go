@@ -0,0 +1,85 @@
+package scraper
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/Ultrahd-dev/student-schedule-app/backend/internal/clock"
+	"github.com/Ultrahd-dev/student-schedule-app/backend/internal/outbox"
+	"github.com/Ultrahd-dev/student-schedule-app/backend/internal/schedule"
+	"github.com/Ultrahd-dev/student-schedule-app/backend/internal/txn"
+)
+
+// SetOutbox включает публикацию доменных событий через transactional outbox:
+// снапшоты и изменения сохраняются вместе с событиями snapshot.created,
+// change.applied и change.reverted, а пересборку кэша и рассылку уведомлений
+// запускают подписчики relay. Если процесс упадет после сохранения данных,
+// relay опубликует событие после перезапуска.
+// relay может быть nil, если на события уже подписан scraper другого колледжа:
+// подписчики получают колледж события из контекста.
+func (s *Service) SetOutbox(repo *outbox.Repository, relay *outbox.Relay, transactor txn.Transactor) {
+	s.outbox = repo
+	s.transactor = transactor
+	s.changeService.SetOutbox(repo)
+
+	if relay == nil {
+		return
+	}
+	relay.Subscribe(outbox.EventSnapshotCreated, s.handleSnapshotCreated)
+	relay.Subscribe(outbox.EventChangeApplied, s.changeEventHandler(JobNotifyChange))
+	relay.Subscribe(outbox.EventChangeReverted, s.changeEventHandler(JobNotifyChangeReverted))
+}
+
+// saveSnapshot сохраняет снапшот (обновляя снапшот той же таблицы за тот же период,
+// см. schedule.Repository.SaveSnapshot); если настроен outbox, в той же транзакции
+// записывает событие snapshot.created. Возвращает true, если снапшот создан.
+func (s *Service) saveSnapshot(ctx context.Context, snapshot *schedule.ScheduleSnapshot) (bool, error) {
+	if s.outbox == nil {
+		return s.scheduleRepo.SaveSnapshot(ctx, snapshot)
+	}
+
+	var created bool
+	err := s.transactor.Do(ctx, func(ctx context.Context) error {
+		var err error
+		if created, err = s.scheduleRepo.SaveSnapshot(ctx, snapshot); err != nil {
+			return err
+		}
+		// ID известен после сохранения: у обновленного снапшота он прежний.
+		// Подписчики пересобирают кэш и после обновления данных снапшота.
+		event, err := outbox.NewEvent(outbox.EventSnapshotCreated, snapshot.ID, outbox.SnapshotCreated{
+			SnapshotID:  snapshot.ID,
+			Name:        snapshot.Name,
+			PeriodStart: snapshot.PeriodStart.Format("2006-01-02"),
+			PeriodEnd:   snapshot.PeriodEnd.Format("2006-01-02"),
+		})
+		if err != nil {
+			return err
+		}
+		return s.outbox.Add(ctx, event)
+	})
+	return created, err
+}
+
+// handleSnapshotCreated пересобирает кэш расписания на сегодня после загрузки снапшота
+func (s *Service) handleSnapshotCreated(ctx context.Context, event outbox.Event) error {
+	today := clock.Today(s.loc)
+	if err := s.rebuildDayCache(ctx, today); err != nil {
+		return fmt.Errorf("ошибка пересборки кэша расписания на %s после снапшота %s: %w",
+			today.Format(clock.DateLayout), event.AggregateID, err)
+	}
+	return nil
+}
+
+// changeEventHandler возвращает подписчика событий изменения, рассылающего уведомления вида kind
+func (s *Service) changeEventHandler(kind string) outbox.Handler {
+	return func(ctx context.Context, event outbox.Event) error {
+		change, err := s.scheduleRepo.GetChangeByID(ctx, event.AggregateID)
+		if err != nil {
+			return err
+		}
+		if err := s.notifyChange(ctx, kind, change); err != nil {
+			return fmt.Errorf("ошибка отправки уведомления об изменении %s: %w", change.ID, err)
+		}
+		return nil
+	}
+}
@@ -0,0 +1,200 @@
+// Package fixture реализует режим локальной разработки парсера: HTTP-транспорт
+// отдает вместо сайта колледжа и Google Таблиц локальные файлы и по настройкам
+// имитирует сбои (таймауты, ответы 500, испорченный CSV). Так весь путь
+// парсинг - применение - уведомления и обработку его ошибок можно проверить,
+// не обращаясь к сайту колледжа.
+//
+// Файлы каталога фикстур:
+//
+//	site.html           - страница сайта колледжа со ссылками на таблицы
+//	<id>_<gid>.csv      - лист gid таблицы docs.google.com/spreadsheets/d/<id>
+//	<id>.csv            - таблица <id>, если для листа нет отдельного файла
+//
+// В файлах подставляются даты относительно текущего дня в часовом поясе
+// колледжа (ДД.ММ.ГГГГ): {{today}}, {{today+N}}, {{today-N}} и {{monday+N}} -
+// понедельник текущей недели плюс N дней.
+package fixture
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"math/rand"
+	"net/http"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/Ultrahd-dev/student-schedule-app/backend/internal/clock"
+)
+
+// Виды имитируемых сбоев
+const (
+	ModeTimeout      = "timeout"       // Запрос зависает до таймаута клиента
+	ModeHTTP500      = "http_500"      // Ответ 500 Internal Server Error
+	ModeMalformedCSV = "malformed_csv" // Ответ 200 с некорректным CSV
+)
+
+// TargetSite цель сбоя - страница сайта колледжа (остальные цели - ID таблиц)
+const TargetSite = "site"
+
+// siteFile файл страницы сайта колледжа
+const siteFile = "site.html"
+
+// timeoutLimit сколько самое большее ждет запрос со сбоем timeout, если у клиента нет таймаута
+const timeoutLimit = 5 * time.Minute
+
+// malformedCSV ответ со сбоем malformed_csv: незакрытая кавычка
+const malformedCSV = "Группа,Дата,\"Предмет\nИС-21,01.09.2025,\"Математика"
+
+// sheetPathRe путь экспорта Google Таблицы
+var sheetPathRe = regexp.MustCompile(`^/spreadsheets/d/([a-zA-Z0-9_-]+)`)
+
+// placeholderRe подстановки дат в файлах фикстур
+var placeholderRe = regexp.MustCompile(`\{\{(today|monday)([+-]\d+)?\}\}`)
+
+// Failure имитируемый сбой
+type Failure struct {
+	Target      string  // TargetSite или ID таблицы; пусто - все запросы
+	Mode        string  // ModeTimeout, ModeHTTP500, ModeMalformedCSV
+	Probability float64 // Доля запросов со сбоем (0 - все запросы)
+}
+
+// Config настройки режима фикстур
+type Config struct {
+	Dir      string    // Каталог файлов фикстур
+	Failures []Failure // Имитируемые сбои; применяется первый сработавший
+}
+
+// Transport отдает файлы фикстур вместо ответов сайта колледжа и Google Таблиц
+type Transport struct {
+	config Config
+	loc    *time.Location
+	random func() float64
+}
+
+// NewTransport создает транспорт фикстур. loc - часовой пояс колледжа для подстановки дат.
+func NewTransport(config Config, loc *time.Location) (*Transport, error) {
+	info, err := os.Stat(config.Dir)
+	if err != nil {
+		return nil, fmt.Errorf("каталог фикстур парсера недоступен: %w", err)
+	}
+	if !info.IsDir() {
+		return nil, fmt.Errorf("%s не является каталогом", config.Dir)
+	}
+	for _, failure := range config.Failures {
+		switch failure.Mode {
+		case ModeTimeout, ModeHTTP500, ModeMalformedCSV:
+		default:
+			return nil, fmt.Errorf("неизвестный вид сбоя %q", failure.Mode)
+		}
+		if failure.Probability < 0 || failure.Probability > 1 {
+			return nil, fmt.Errorf("доля запросов со сбоем %s должна быть от 0 до 1", failure.Mode)
+		}
+	}
+	if loc == nil {
+		loc = time.UTC
+	}
+	return &Transport{config: config, loc: loc, random: rand.Float64}, nil
+}
+
+// RoundTrip реализует http.RoundTripper
+func (t *Transport) RoundTrip(req *http.Request) (*http.Response, error) {
+	target, file := TargetSite, siteFile
+	var candidates []string
+	if m := sheetPathRe.FindStringSubmatch(req.URL.Path); m != nil && strings.HasSuffix(req.URL.Host, "docs.google.com") {
+		target = m[1]
+		if gid := req.URL.Query().Get("gid"); gid != "" {
+			candidates = append(candidates, target+"_"+gid+".csv")
+		}
+		file = target + ".csv"
+	}
+	candidates = append(candidates, file)
+
+	if failure, ok := t.failure(target); ok {
+		log.Printf("Фикстуры парсера: имитация сбоя %s для %s", failure.Mode, req.URL)
+		switch failure.Mode {
+		case ModeTimeout:
+			return nil, t.timeout(req)
+		case ModeHTTP500:
+			return response(req, http.StatusInternalServerError, []byte("Internal Server Error")), nil
+		case ModeMalformedCSV:
+			return response(req, http.StatusOK, []byte(malformedCSV)), nil
+		}
+	}
+
+	for _, name := range candidates {
+		data, err := os.ReadFile(filepath.Join(t.config.Dir, name))
+		if errors.Is(err, os.ErrNotExist) {
+			continue
+		}
+		if err != nil {
+			return nil, fmt.Errorf("ошибка чтения фикстуры %s: %w", name, err)
+		}
+		return response(req, http.StatusOK, t.expand(data)), nil
+	}
+	log.Printf("Фикстуры парсера: нет файла %s для %s", strings.Join(candidates, " или "), req.URL)
+	return response(req, http.StatusNotFound, []byte("Not Found")), nil
+}
+
+// failure возвращает сбой, который нужно имитировать для запроса к target
+func (t *Transport) failure(target string) (Failure, bool) {
+	for _, failure := range t.config.Failures {
+		if failure.Target != "" && failure.Target != target {
+			continue
+		}
+		if failure.Probability == 0 || t.random() < failure.Probability {
+			return failure, true
+		}
+	}
+	return Failure{}, false
+}
+
+// timeout ждет отмены запроса по таймауту клиента
+func (t *Transport) timeout(req *http.Request) error {
+	timer := time.NewTimer(timeoutLimit)
+	defer timer.Stop()
+	select {
+	case <-req.Context().Done():
+		return req.Context().Err()
+	case <-timer.C:
+		return fmt.Errorf("фикстуры парсера: имитация таймаута: %w", os.ErrDeadlineExceeded)
+	}
+}
+
+// expand подставляет даты относительно текущего дня
+func (t *Transport) expand(data []byte) []byte {
+	today := clock.Today(t.loc)
+	return placeholderRe.ReplaceAllFunc(data, func(match []byte) []byte {
+		m := placeholderRe.FindSubmatch(match)
+		date := today
+		if string(m[1]) == "monday" {
+			date = clock.WeekStart(today)
+		}
+		if len(m[2]) > 0 {
+			days, _ := strconv.Atoi(string(m[2]))
+			date = date.AddDate(0, 0, days)
+		}
+		return []byte(date.Format(clock.DateLayout))
+	})
+}
+
+// response создает ответ на запрос req
+func response(req *http.Request, status int, body []byte) *http.Response {
+	return &http.Response{
+		Status:        fmt.Sprintf("%d %s", status, http.StatusText(status)),
+		StatusCode:    status,
+		Proto:         "HTTP/1.1",
+		ProtoMajor:    1,
+		ProtoMinor:    1,
+		Header:        http.Header{},
+		Body:          io.NopCloser(bytes.NewReader(body)),
+		ContentLength: int64(len(body)),
+		Request:       req,
+	}
+}
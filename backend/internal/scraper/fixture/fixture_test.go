@@ -0,0 +1,95 @@
+package fixture
+
+import (
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/Ultrahd-dev/student-schedule-app/backend/internal/clock"
+)
+
+func get(t *testing.T, transport *Transport, url string) (int, string) {
+	t.Helper()
+	req, err := http.NewRequest(http.MethodGet, url, nil)
+	if err != nil {
+		t.Fatalf("NewRequest: %v", err)
+	}
+	resp, err := transport.RoundTrip(req)
+	if err != nil {
+		t.Fatalf("RoundTrip(%s): %v", url, err)
+	}
+	defer resp.Body.Close()
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		t.Fatalf("ReadAll: %v", err)
+	}
+	return resp.StatusCode, string(body)
+}
+
+func TestTransport(t *testing.T) {
+	dir := t.TempDir()
+	files := map[string]string{
+		"site.html":   `<a href="https://docs.google.com/spreadsheets/d/main/edit">Расписание с {{monday}}</a>`,
+		"main.csv":    "Дата\n{{today+1}}\n",
+		"main_42.csv": "Лист 42\n",
+	}
+	for name, content := range files {
+		if err := os.WriteFile(filepath.Join(dir, name), []byte(content), 0o644); err != nil {
+			t.Fatalf("WriteFile: %v", err)
+		}
+	}
+	transport, err := NewTransport(Config{Dir: dir}, time.UTC)
+	if err != nil {
+		t.Fatalf("NewTransport: %v", err)
+	}
+	today := clock.Today(time.UTC)
+
+	status, body := get(t, transport, "https://kcpt72.ru/schedule/")
+	if status != http.StatusOK || !strings.Contains(body, clock.WeekStart(today).Format(clock.DateLayout)) {
+		t.Errorf("страница сайта: %d %q", status, body)
+	}
+	status, body = get(t, transport, "https://docs.google.com/spreadsheets/d/main/export?format=csv&gid=7")
+	if want := today.AddDate(0, 0, 1).Format(clock.DateLayout); status != http.StatusOK || !strings.Contains(body, want) {
+		t.Errorf("таблица без отдельного листа: %d %q, ожидалась дата %s", status, body, want)
+	}
+	if _, body = get(t, transport, "https://docs.google.com/spreadsheets/d/main/export?format=csv&gid=42"); body != "Лист 42\n" {
+		t.Errorf("лист 42: %q", body)
+	}
+	if status, _ = get(t, transport, "https://docs.google.com/spreadsheets/d/other/export?format=csv&gid=0"); status != http.StatusNotFound {
+		t.Errorf("таблица без фикстуры: статус %d, ожидался 404", status)
+	}
+}
+
+func TestTransportFailures(t *testing.T) {
+	dir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(dir, "site.html"), []byte("<html></html>"), 0o644); err != nil {
+		t.Fatalf("WriteFile: %v", err)
+	}
+	transport, err := NewTransport(Config{Dir: dir, Failures: []Failure{
+		{Target: TargetSite, Mode: ModeHTTP500, Probability: 0.5},
+		{Target: "changes", Mode: ModeMalformedCSV},
+	}}, time.UTC)
+	if err != nil {
+		t.Fatalf("NewTransport: %v", err)
+	}
+
+	transport.random = func() float64 { return 0.9 }
+	if status, _ := get(t, transport, "https://kcpt72.ru/schedule/"); status != http.StatusOK {
+		t.Errorf("сбой не должен сработать: статус %d", status)
+	}
+	transport.random = func() float64 { return 0.1 }
+	if status, _ := get(t, transport, "https://kcpt72.ru/schedule/"); status != http.StatusInternalServerError {
+		t.Errorf("ожидался статус 500, получен %d", status)
+	}
+	if _, body := get(t, transport, "https://docs.google.com/spreadsheets/d/changes/export?format=csv&gid=0"); body != malformedCSV {
+		t.Errorf("ожидался некорректный CSV, получено %q", body)
+	}
+
+	if _, err := NewTransport(Config{Dir: dir, Failures: []Failure{{Mode: "crash"}}}, time.UTC); err == nil {
+		t.Error("ожидалась ошибка для неизвестного вида сбоя")
+	}
+}
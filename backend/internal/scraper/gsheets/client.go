@@ -17,6 +17,10 @@ import (
 	"strings"
 	"time"
 	"unicode"
+	"unicode/utf8"
+
+	"github.com/Ultrahd-dev/student-schedule-app/backend/internal/bells"
+	"github.com/Ultrahd-dev/student-schedule-app/backend/internal/clock"
 )
 
 // Client клиент для работы с Google Таблицами через HTTP-запросы
@@ -26,12 +30,15 @@ type Client struct {
 	// Передается извне или задается по умолчанию.
 	// Для таблицы изменений обычно используется gid=0 или он берется из конфига.
 	sheetGIDs []int64
+	// loc - часовой пояс колледжа, в котором интерпретируются даты из таблиц
+	loc *time.Location
 }
 
 // NewClient создает новый клиент для Google Таблиц через HTTP-запросы.
 // credentialsFile больше не используется, но сохранен для совместимости сигнатуры.
 // sheetGIDs - список gid листов основного расписания.
-func NewClient(sheetGIDs []int64) *Client {
+// loc - часовой пояс колледжа (nil - UTC).
+func NewClient(sheetGIDs []int64, loc *time.Location) *Client {
 	// Создаем HTTP клиент с таймаутом
 	client := &http.Client{
 		Timeout: 30 * time.Second,
@@ -42,12 +49,23 @@ func NewClient(sheetGIDs []int64) *Client {
 		sheetGIDs = []int64{}
 	}
 
+	if loc == nil {
+		loc = time.UTC
+	}
+
 	return &Client{
 		httpClient: client,
 		sheetGIDs:  sheetGIDs,
+		loc:        loc,
 	}
 }
 
+// SetTransport задает транспорт HTTP запросов к Google Таблицам
+// (например, с circuit breaker)
+func (c *Client) SetTransport(transport http.RoundTripper) {
+	c.httpClient.Transport = transport
+}
+
 // ExportToCSVMainSchedule экспортирует основное расписание из Google Таблицы в CSV формат
 // через HTTP-запросы для каждого листа (gid) и объединяет результаты.
 // В соответствии с ТЗ: "Экспорт таблицы в CSV формат"
@@ -198,75 +216,32 @@ func (c *Client) ExportToCSVChanges(ctx context.Context, sheetURL string, gid in
 	return records, nil
 }
 
-// ScheduleBellTimings представляет расписание звонков из ТЗ
-// Структура для хранения времени начала и окончания пар
-type ScheduleBellTimings struct {
-	DayOfWeek string
-	Lessons   []LessonTiming
-}
-
-type LessonTiming struct {
-	Number    int
-	TimeStart string // "HH:MM"
-	TimeEnd   string // "HH:MM"
-}
-
-// getBellTimings возвращает расписание звонков из ТЗ
-func getBellTimings() map[string][]LessonTiming {
-	// Данные из ТЗ
-	// Преобразуем данные в более удобный для поиска формат
-	timings := make(map[string][]LessonTiming)
-
-	// Будние дни
-	weekdayTimings := []LessonTiming{
-		{1, "08:15", "09:00"},
-		{2, "09:00", "09:45"},
-		{3, "09:55", "10:40"},
-		{4, "10:40", "11:25"},
-		{5, "11:40", "12:25"},
-		{6, "12:25", "13:10"},
-		{7, "13:30", "14:15"},
-		{8, "14:15", "15:00"},
-		{9, "15:15", "16:00"},
-		{10, "16:00", "16:45"},
-		{11, "16:55", "17:40"},
-		{12, "17:40", "18:25"},
-	}
-
-	// Суббота
-	saturdayTimings := []LessonTiming{
-		{1, "08:15", "09:00"},
-		{2, "09:00", "09:45"},
-		{3, "09:50", "10:35"},
-		{4, "10:35", "11:20"},
-		{5, "11:35", "12:20"},
-		{6, "12:20", "13:05"},
-		{7, "13:20", "14:05"},
-		{8, "14:05", "14:50"},
-		{9, "15:05", "15:50"},
-		{10, "15:50", "16:35"},
-		{11, "16:40", "17:25"},
-		{12, "17:25", "18:10"},
-	}
-
-	// Заполняем карту для каждого дня недели
-	weekdays := []string{"Понедельник", "Вторник", "Среда", "Четверг", "Пятница"}
-	for _, day := range weekdays {
-		timings[day] = weekdayTimings
-	}
-	timings["Суббота"] = saturdayTimings
-
-	return timings
-}
-
-// removeNonPrintable удаляет непечатаемые символы из строки
+// removeNonPrintable удаляет непечатаемые символы из строки.
+// Строка без таких символов возвращается как есть, без копирования.
 func removeNonPrintable(s string) string {
-	return strings.Map(func(r rune) rune {
-		if unicode.IsPrint(r) {
-			return r
+	for _, r := range s {
+		if !isPrint(r) {
+			return strings.Map(func(r rune) rune {
+				if isPrint(r) {
+					return r
+				}
+				return -1
+			}, s)
 		}
-		return -1
-	}, s)
+	}
+	return s
+}
+
+// isPrint аналог unicode.IsPrint с быстрой проверкой ASCII и кириллицы,
+// из которых состоят почти все ячейки таблиц
+func isPrint(r rune) bool {
+	switch {
+	case r < utf8.RuneSelf:
+		return r >= ' ' && r != 0x7f
+	case r >= 0x0400 && r <= 0x04ff:
+		return true
+	}
+	return unicode.IsPrint(r)
 }
 
 // ScheduleRecord представляет запись из таблицы расписания
@@ -275,9 +250,12 @@ type ScheduleRecord struct {
 	Subject   string `json:"subject"`
 	Teacher   string `json:"teacher"`
 	Classroom string `json:"classroom"`
-	TimeStart string `json:"time_start"`
-	TimeEnd   string `json:"time_end"`
-	DayOfWeek string `json:"day_of_week"`
+	Subgroup  int    `json:"subgroup,omitempty"` // Подгруппа ("1 п/г"); 0 - занятие всей группы
+	// LessonType вид занятия ("лекция", "практика", "лабораторная"); пусто - не указан
+	LessonType string `json:"lesson_type,omitempty"`
+	TimeStart  string `json:"time_start"`
+	TimeEnd    string `json:"time_end"`
+	DayOfWeek  string `json:"day_of_week"`
 	// Добавим поля для номера пары и даты, если они понадобятся
 	LessonNumber int       `json:"lesson_number"`
 	Date         time.Time `json:"date"`
@@ -294,12 +272,17 @@ type ChangeRecord struct {
 	Classroom       string    `json:"classroom"`
 	ChangeType      string    `json:"change_type"` // "replacement", "cancellation", "addition"
 	OriginalSubject string    `json:"original_subject"`
+	LessonNumber    int       `json:"lesson_number"` // Номер пары (0 - не указан и не определен)
+	Reason          string    `json:"reason"`        // Причина изменения (необязательная колонка)
+	Subgroup        int       `json:"subgroup"`      // Подгруппа (0 - вся группа)
+	LessonType      string    `json:"lesson_type"`   // Вид занятия (необязательная колонка)
 }
 
 // ParseScheduleRecords парсит записи расписания из данных таблицы с горизонтальной структурой
 // В соответствии с примером из ТЗ:
 // Группа | Предмет | Преподаватель | Аудитория | Время начала | Время окончания | День недели
-func (c *Client) ParseScheduleRecords(csvRecords [][]string) ([]ScheduleRecord, error) {
+// Время пар берется из расписания звонков колледжа timings.
+func (c *Client) ParseScheduleRecords(csvRecords [][]string, timings bells.Timings) ([]ScheduleRecord, error) {
 	if len(csvRecords) < 5 {
 		return nil, fmt.Errorf("недостаточно данных в таблице (меньше 5 строк), получено: %d", len(csvRecords))
 	}
@@ -312,9 +295,6 @@ func (c *Client) ParseScheduleRecords(csvRecords [][]string) ([]ScheduleRecord,
 	}
 	// -----------------------------
 
-	// Получаем расписание звонков из ТЗ
-	bellTimings := getBellTimings()
-
 	// --- ИСПРАВЛЕНА ЛОГИКА ИЗВЛЕЧЕНИЯ ГРУПП ---
 	// Извлекаем список групп из строки CSV[1]
 	// Пример: ["Группы - АТ 22-11, АТ 23-11, АТ 24-11, ДО 22-11-1, ДО 22-11-2" "" "" ...]
@@ -376,24 +356,28 @@ func (c *Client) ParseScheduleRecords(csvRecords [][]string) ([]ScheduleRecord,
 		}
 		// Индекс столбца с "Предмет..." для этой группы (тот же, что и заголовок группы)
 		groupColumnIndices = append(groupColumnIndices, expectedHeaderIndex)
-		log.Printf("DEBUG: Группа '%s' начинается со столбца %d (данные в %d и %d)", groupName, expectedHeaderIndex, expectedHeaderIndex, expectedHeaderIndex+1)
 	}
 	// --- КОНЕЦ ИСПРАВЛЕННОЙ ЛОГИКИ ИНДЕКСОВ ---
 
 	// Количество "подстолбцов" на одну группу
 	const subColumnsPerGroup = 2 // Предмет+Аудитория
 
-	// Инициализируем список для результатов
-	var records []ScheduleRecord
+	// Итерируемся по строкам с данными, начиная с CSV[5]
+	// НО! Нужно учитывать, что строки с "День - ..." тоже могут встречаться
+	dataStartRowIndex := 5
+
+	// Таблица содержит тысячи ячеек, поэтому результат выделяется сразу под все
+	// ячейки групп, а одинаковые предметы, преподаватели и аудитории хранятся
+	// одной строкой, не удерживающей в памяти строки CSV
+	records := make([]ScheduleRecord, 0, max(len(csvRecords)-dataStartRowIndex, 0)*len(groupNames))
+	strs := newInterner()
 
-	// Переменные для хранения текущего дня недели и даты
+	// Переменные для хранения текущего дня недели, даты и звонков этого дня
 	currentDayOfWeek := ""
 	currentDateStr := ""
 	var currentDate time.Time
-
-	// Итерируемся по строкам с данными, начиная с CSV[5]
-	// НО! Нужно учитывать, что строки с "День - ..." тоже могут встречаться
-	dataStartRowIndex := 5
+	var currentTimings []bells.LessonTiming
+	var variants []lessonVariant
 	for i := dataStartRowIndex; i < len(csvRecords); i++ {
 		row := csvRecords[i]
 
@@ -404,7 +388,7 @@ func (c *Client) ParseScheduleRecords(csvRecords [][]string) ([]ScheduleRecord,
 
 		// Проверяем, является ли строка заголовком дня (содержит "День -")
 		// Пример: [День - Понедельник, 23.06.2025          ]
-		if len(row) > 0 && strings.Contains(strings.ToLower(row[0]), "день -") {
+		if len(row) > 0 && isDayHeader(row[0]) {
 			// Извлекаем день недели и дату
 			// row[0] = "День - Понедельник, 23.06.2025"
 			parts := strings.Split(row[0], ",")
@@ -413,12 +397,13 @@ func (c *Client) ParseScheduleRecords(csvRecords [][]string) ([]ScheduleRecord,
 				dayPart := strings.TrimSpace(parts[0])
 				dayParts := strings.Split(dayPart, "-")
 				if len(dayParts) >= 2 {
-					currentDayOfWeek = strings.TrimSpace(dayParts[1])
+					currentDayOfWeek = strs.intern(strings.TrimSpace(dayParts[1]))
 				}
+				currentTimings, _ = timings.ForDayName(currentDayOfWeek)
 				// parts[1] = " 23.06.2025"
 				currentDateStr = strings.TrimSpace(parts[1])
 				var err error
-				currentDate, err = time.Parse("02.01.2006", currentDateStr)
+				currentDate, err = clock.ParseDate(currentDateStr, c.loc)
 				if err != nil {
 					log.Printf("Предупреждение: Не удалось распарсить дату '%s' в строке %d: %v", currentDateStr, i, err)
 					currentDate = time.Time{} // Обнуляем дату в случае ошибки
@@ -458,13 +443,11 @@ func (c *Client) ParseScheduleRecords(csvRecords [][]string) ([]ScheduleRecord,
 		// Получаем время начала и окончания для текущей пары и дня
 		var timeStart, timeEnd string = "", ""
 		if currentDayOfWeek != "" {
-			if timingsForDay, ok := bellTimings[currentDayOfWeek]; ok {
-				for _, timing := range timingsForDay {
-					if timing.Number == lessonNumber {
-						timeStart = timing.TimeStart
-						timeEnd = timing.TimeEnd
-						break
-					}
+			for _, timing := range currentTimings {
+				if timing.Number == lessonNumber {
+					timeStart = timing.TimeStart
+					timeEnd = timing.TimeEnd
+					break
 				}
 			}
 			if timeStart == "" || timeEnd == "" {
@@ -481,48 +464,31 @@ func (c *Client) ParseScheduleRecords(csvRecords [][]string) ([]ScheduleRecord,
 			// Извлекаем данные для группы
 			// row[startColIndex] = Предмет, вид занятия, преподаватель
 			// row[startColIndex+1] = Аудитория
-			subjectCell := strings.TrimSpace(removeNonPrintable(row[startColIndex]))
-			classroom := strings.TrimSpace(removeNonPrintable(row[startColIndex+1]))
-
 			// Пропускаем пустые записи
-			if subjectCell == "" {
+			if strings.TrimSpace(removeNonPrintable(row[startColIndex])) == "" {
 				continue
 			}
 
-			// Простая логика разделения subjectCell
-			// Предполагаем формат: "Предмет / Вид занятия / Преподаватель" или "Предмет / Преподаватель"
-			// или просто "Предмет"
-			var subject, teacher string
-			parts := strings.Split(subjectCell, "/")
-			if len(parts) >= 3 {
-				// Предмет / Вид / Препод
-				subject = strings.TrimSpace(parts[0])
-				// Вид игнорируем
-				teacher = strings.TrimSpace(parts[2])
-			} else if len(parts) == 2 {
-				// Предмет / Препод
-				subject = strings.TrimSpace(parts[0])
-				teacher = strings.TrimSpace(parts[1])
-			} else {
-				// Только предмет
-				subject = strings.TrimSpace(subjectCell)
-			}
+			// В ячейке может быть несколько занятий для подгрупп ("1 п/г", "2 п/г")
+			variants = splitSubgroups(variants[:0], row[startColIndex], row[startColIndex+1])
+			for _, variant := range variants {
+				record := ScheduleRecord{
+					GroupName:  groupName,
+					Subject:    strs.intern(variant.subject),
+					Teacher:    strs.intern(variant.teacher),
+					Classroom:  strs.intern(variant.classroom),
+					Subgroup:   variant.subgroup,
+					LessonType: strs.intern(variant.lessonType),
+					TimeStart:  timeStart,
+					TimeEnd:    timeEnd,
+					DayOfWeek:  currentDayOfWeek,
+					// Добавим поля для номера пары и даты, если они понадобятся
+					LessonNumber: lessonNumber,
+					Date:         currentDate,
+				}
 
-			// Создаем запись
-			record := ScheduleRecord{
-				GroupName: groupName,
-				Subject:   subject,
-				Teacher:   teacher,
-				Classroom: classroom,
-				TimeStart: timeStart,
-				TimeEnd:   timeEnd,
-				DayOfWeek: currentDayOfWeek,
-				// Добавим поля для номера пары и даты, если они понадобятся
-				LessonNumber: lessonNumber,
-				Date:         currentDate,
+				records = append(records, record)
 			}
-
-			records = append(records, record)
 		}
 	}
 
@@ -533,10 +499,71 @@ func (c *Client) ParseScheduleRecords(csvRecords [][]string) ([]ScheduleRecord,
 	return records, nil
 }
 
+// splitSubjectCell разделяет ячейку с занятием на предмет, вид занятия и преподавателя.
+// Формат: "Предмет / Вид занятия / Преподаватель", "Предмет / Преподаватель",
+// "Предмет / Вид занятия" или просто "Предмет". Части после преподавателя игнорируются.
+func splitSubjectCell(cell string) (subject, lessonType, teacher string) {
+	subject, rest, found := strings.Cut(cell, "/")
+	if !found {
+		return strings.TrimSpace(cell), "", ""
+	}
+	teacher = rest
+	if kind, afterKind, ok := strings.Cut(rest, "/"); ok {
+		// Предмет / Вид / Препод
+		lessonType, _ = normalizeLessonType(kind)
+		teacher, _, _ = strings.Cut(afterKind, "/")
+	} else if kind, known := normalizeLessonType(rest); known {
+		// Предмет / Вид
+		lessonType, teacher = kind, ""
+	}
+	return strings.TrimSpace(subject), lessonType, strings.TrimSpace(teacher)
+}
+
+// isDayHeader проверяет, является ли ячейка заголовком дня ("День - Понедельник, 23.06.2025"),
+// без учета регистра и без выделения памяти под строку в нижнем регистре
+func isDayHeader(cell string) bool {
+	const marker = "день -"
+	for i := range cell {
+		if len(cell)-i < len(marker) {
+			return false
+		}
+		if strings.EqualFold(cell[i:i+len(marker)], marker) {
+			return true
+		}
+	}
+	return false
+}
+
+// interner хранит по одному экземпляру одинаковых строк. Строки копируются,
+// поэтому результат не удерживает в памяти исходные строки CSV.
+type interner map[string]string
+
+func newInterner() interner {
+	return make(interner)
+}
+
+// intern возвращает сохраненную копию строки s
+func (in interner) intern(s string) string {
+	if s == "" {
+		return ""
+	}
+	if v, ok := in[s]; ok {
+		return v
+	}
+	v := strings.Clone(s)
+	in[v] = v
+	return v
+}
+
 // ParseChangeRecords парсит записи об изменениях из данных таблицы
 // В соответствии с примером из ТЗ:
 // Группа | Дата | Время начала | Время окончания | Предмет | Преподаватель | Аудитория | Тип изменения | Оригинальный предмет
-func (c *Client) ParseChangeRecords(csvRecords [][]string) ([]ChangeRecord, error) {
+// Вместо времени может быть указан номер пары (колонка "Номер пары" или "Пара"),
+// тогда время берется из расписания звонков колледжа timings. Необязательная колонка "Причина"
+// содержит причину изменения, "Подгруппа" - номер подгруппы, для которой
+// изменяется пара (подгруппу можно отметить и в предмете: "Физика (1 п/г)"),
+// "Вид занятия" - лекция, практика, лабораторная и т.п.
+func (c *Client) ParseChangeRecords(csvRecords [][]string, timings bells.Timings) ([]ChangeRecord, error) {
 	if len(csvRecords) < 2 {
 		return nil, fmt.Errorf("недостаточно данных в таблице изменений (меньше 2 строк)")
 	}
@@ -544,6 +571,7 @@ func (c *Client) ParseChangeRecords(csvRecords [][]string) ([]ChangeRecord, erro
 	// Находим индексы колонок в заголовке
 	headers := csvRecords[0]
 	var groupCol, dateCol, timeStartCol, timeEndCol, subjectCol, teacherCol, classroomCol, changeTypeCol, originalSubjectCol int = -1, -1, -1, -1, -1, -1, -1, -1, -1
+	lessonNumberCol, reasonCol, subgroupCol, lessonTypeCol := -1, -1, -1, -1
 
 	for i, header := range headers {
 		headerStr := strings.TrimSpace(strings.ToLower(header))
@@ -567,13 +595,23 @@ func (c *Client) ParseChangeRecords(csvRecords [][]string) ([]ChangeRecord, erro
 			changeTypeCol = i
 		case "оригинальный предмет":
 			originalSubjectCol = i
+		case "номер пары", "пара", "№ пары":
+			lessonNumberCol = i
+		case "причина", "причина изменения":
+			reasonCol = i
+		case "подгруппа", "п/г":
+			subgroupCol = i
+		case "вид занятия", "тип занятия", "вид":
+			lessonTypeCol = i
 		}
 	}
 
 	// Проверяем, что обязательные колонки найдены
-	if groupCol == -1 || dateCol == -1 || timeStartCol == -1 || timeEndCol == -1 || subjectCol == -1 || changeTypeCol == -1 {
-		return nil, fmt.Errorf("обязательные колонки для изменений не найдены в CSV заголовках. Найдено: группа=%d, дата=%d, время начала=%d, время окончания=%d, предмет=%d, тип изменения=%d",
-			groupCol, dateCol, timeStartCol, timeEndCol, subjectCol, changeTypeCol)
+	// Время пары можно задать либо колонками времени, либо номером пары
+	hasTimeCols := timeStartCol != -1 && timeEndCol != -1
+	if groupCol == -1 || dateCol == -1 || (!hasTimeCols && lessonNumberCol == -1) || subjectCol == -1 || changeTypeCol == -1 {
+		return nil, fmt.Errorf("обязательные колонки для изменений не найдены в CSV заголовках. Найдено: группа=%d, дата=%d, время начала=%d, время окончания=%d, номер пары=%d, предмет=%d, тип изменения=%d",
+			groupCol, dateCol, timeStartCol, timeEndCol, lessonNumberCol, subjectCol, changeTypeCol)
 	}
 
 	var records []ChangeRecord
@@ -586,7 +624,7 @@ func (c *Client) ParseChangeRecords(csvRecords [][]string) ([]ChangeRecord, erro
 
 		dateStr := strings.TrimSpace(row[dateCol])
 		// Ожидаемый формат даты из ТЗ: DD.MM.YYYY
-		parsedDate, err := time.Parse("02.01.2006", dateStr)
+		parsedDate, err := clock.ParseDate(dateStr, c.loc)
 		if err != nil {
 			// Если не удалось распарсить дату, пропускаем строку
 			// ИСПРАВЛЕНО: Добавлен индекс строки в лог (rowIndex+2, так как заголовок + сдвиг индекса)
@@ -613,13 +651,43 @@ func (c *Client) ParseChangeRecords(csvRecords [][]string) ([]ChangeRecord, erro
 		record := ChangeRecord{
 			GroupName:       strings.TrimSpace(row[groupCol]),
 			Date:            parsedDate,
-			TimeStart:       strings.TrimSpace(row[timeStartCol]),
-			TimeEnd:         strings.TrimSpace(row[timeEndCol]),
+			TimeStart:       cellAt(row, timeStartCol),
+			TimeEnd:         cellAt(row, timeEndCol),
 			Subject:         strings.TrimSpace(row[subjectCol]),
-			Teacher:         strings.TrimSpace(row[teacherCol]),
-			Classroom:       strings.TrimSpace(row[classroomCol]),
+			Teacher:         cellAt(row, teacherCol),
+			Classroom:       cellAt(row, classroomCol),
 			ChangeType:      changeType,
 			OriginalSubject: "", // По умолчанию пусто
+			Reason:          cellAt(row, reasonCol),
+		}
+
+		// Номер пары, если указан
+		if numberStr := cellAt(row, lessonNumberCol); numberStr != "" {
+			number, err := strconv.Atoi(numberStr)
+			if err != nil {
+				log.Printf("Некорректный номер пары '%s' в строке %d: %v", numberStr, rowIndex+2, err)
+			} else {
+				record.LessonNumber = number
+			}
+		}
+
+		// Подгруппа: колонка "Подгруппа" или отметка в предмете ("Физика (1 п/г)")
+		if subgroupStr := cellAt(row, subgroupCol); subgroupStr != "" {
+			subgroup, err := strconv.Atoi(subgroupStr)
+			if err != nil {
+				subgroup, _ = cutSubgroup(subgroupStr) // "2 п/г"
+			}
+			if subgroup > 0 {
+				record.Subgroup = subgroup
+			} else {
+				log.Printf("Некорректная подгруппа '%s' в строке %d", subgroupStr, rowIndex+2)
+			}
+		} else if hasSubgroupHint(record.Subject) {
+			record.Subgroup, record.Subject = cutSubgroup(record.Subject)
+		}
+
+		if lessonType := cellAt(row, lessonTypeCol); lessonType != "" {
+			record.LessonType, _ = normalizeLessonType(lessonType)
 		}
 
 		// Если есть колонка "Оригинальный предмет", заполняем её
@@ -634,16 +702,26 @@ func (c *Client) ParseChangeRecords(csvRecords [][]string) ([]ChangeRecord, erro
 			continue
 		}
 
+		// Определяем время по номеру пары (и номер по времени) по расписанию звонков
+		slot, err := timings.ResolveSlot(parsedDate.Weekday(), record.LessonNumber, record.TimeStart, record.TimeEnd)
+		if err != nil {
+			log.Printf("Не удалось определить время пары в строке %d: %v", rowIndex+2, err)
+			continue
+		}
+		record.TimeStart = slot.TimeStart
+		record.TimeEnd = slot.TimeEnd
+		record.LessonNumber = slot.Number
+
 		// Валидация времени (если указаны)
 		if record.TimeStart != "" {
-			if _, err := time.Parse("15:04", record.TimeStart); err != nil {
+			if _, err := clock.ParseClock(record.TimeStart); err != nil {
 				// ИСПРАВЛЕНО: Добавлен индекс строки в лог
 				log.Printf("Некорректное время начала '%s' в строке %d: %v", record.TimeStart, rowIndex+2, err)
 				// Не пропускаем, так как время может быть опциональным для некоторых типов изменений
 			}
 		}
 		if record.TimeEnd != "" {
-			if _, err := time.Parse("15:04", record.TimeEnd); err != nil {
+			if _, err := clock.ParseClock(record.TimeEnd); err != nil {
 				// ИСПРАВЛЕНО: Добавлен индекс строки в лог
 				log.Printf("Некорректное время окончания '%s' в строке %d: %v", record.TimeEnd, rowIndex+2, err)
 				// Не пропускаем, так как время может быть опциональным для некоторых типов изменений
@@ -680,6 +758,14 @@ func (c *Client) extractSpreadsheetID(sheetURL string) string {
 	return ""
 }
 
+// cellAt возвращает очищенное значение ячейки или пустую строку, если колонки нет
+func cellAt(row []string, col int) string {
+	if col < 0 || col >= len(row) {
+		return ""
+	}
+	return strings.TrimSpace(row[col])
+}
+
 // max вспомогательная функция для нахождения максимума из списка int
 func max(values ...int) int {
 	if len(values) == 0 {
@@ -0,0 +1,86 @@
+package gsheets
+
+import (
+	"fmt"
+	"io"
+	"log"
+	"os"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/Ultrahd-dev/student-schedule-app/backend/internal/bells"
+)
+
+// benchmarkSheet строит лист основного расписания в формате выгрузки CSV:
+// groups групп, 6 учебных дней по lessons пар. Примерно каждая третья ячейка пуста,
+// за столбцами групп, как и в выгрузке, идет пустой столбец.
+func benchmarkSheet(groups, lessons int) [][]string {
+	names := make([]string, groups)
+	for i := range names {
+		names[i] = fmt.Sprintf("АТ %02d-%d", 20+i/10, 11+i%10)
+	}
+	width := 2 + groups*2
+
+	row := func(cells ...string) []string {
+		r := make([]string, width)
+		copy(r, cells)
+		return r
+	}
+
+	sheet := [][]string{
+		row("Расписание занятий"),
+		row("Группы - " + strings.Join(names, ", ")),
+		row(),
+	}
+	headers := row("№")
+	subheaders := row("")
+	for i, name := range names {
+		headers[1+i*2] = name
+		subheaders[1+i*2] = "Предмет, вид занятия, преподаватель"
+		subheaders[2+i*2] = "Ауд."
+	}
+	sheet = append(sheet, headers, subheaders)
+
+	days := []string{"Понедельник", "Вторник", "Среда", "Четверг", "Пятница", "Суббота"}
+	monday := time.Date(2025, time.June, 23, 0, 0, 0, 0, time.UTC)
+	for d, day := range days {
+		sheet = append(sheet, row(fmt.Sprintf("День - %s, %s          ", day, monday.AddDate(0, 0, d).Format("02.01.2006"))))
+		for n := 1; n <= lessons; n++ {
+			r := row(fmt.Sprint(n))
+			for g := range names {
+				if (g+n+d)%3 == 0 {
+					continue
+				}
+				r[1+g*2] = fmt.Sprintf(" Дисциплина %d / Лекция / Преподаватель %d ", (g+n)%15, (g*7+n)%25)
+				r[2+g*2] = fmt.Sprint(100 + (g+n*3)%40)
+			}
+			sheet = append(sheet, r)
+		}
+	}
+	return sheet
+}
+
+// discardLogs отключает логи парсера на время теста или бенчмарка
+func discardLogs(tb testing.TB) {
+	log.SetOutput(io.Discard)
+	tb.Cleanup(func() { log.SetOutput(os.Stderr) })
+}
+
+func BenchmarkParseScheduleRecords(b *testing.B) {
+	for _, groups := range []int{10, 60} {
+		b.Run(fmt.Sprintf("groups=%d", groups), func(b *testing.B) {
+			discardLogs(b)
+			sheet := benchmarkSheet(groups, 7)
+			client := NewClient(nil, time.UTC)
+
+			b.ReportAllocs()
+			b.ResetTimer()
+			for i := 0; i < b.N; i++ {
+				if _, err := client.ParseScheduleRecords(sheet, bells.Builtin()); err != nil {
+					b.Fatal(err)
+				}
+			}
+		})
+	}
+}
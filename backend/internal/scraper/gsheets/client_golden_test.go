@@ -0,0 +1,104 @@
+package gsheets
+
+import (
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"flag"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/Ultrahd-dev/student-schedule-app/backend/internal/bells"
+)
+
+// update перезаписывает эталонные результаты парсера:
+//
+//	go test ./internal/scraper/gsheets -run Golden -update
+var update = flag.Bool("update", false, "перезаписать эталонные файлы testdata/*.golden.json")
+
+// Корпус testdata/*.csv - выгрузки листов основного расписания (обезличенные):
+// каждой выгрузке соответствует эталон testdata/<имя>.golden.json с записями,
+// которые должен вернуть ParseScheduleRecords. Изменение разбора таблицы,
+// меняющее результат, видно в диффе эталона.
+
+// corpus возвращает пути к выгрузкам корпуса
+func corpus(tb testing.TB) []string {
+	tb.Helper()
+	files, err := filepath.Glob(filepath.Join("testdata", "*.csv"))
+	if err != nil {
+		tb.Fatal(err)
+	}
+	if len(files) == 0 {
+		tb.Fatal("в testdata нет выгрузок")
+	}
+	return files
+}
+
+// readSheet читает выгрузку так же, как ExportToCSVMainSchedule читает ответ Google Таблиц
+func readSheet(tb testing.TB, path string) [][]string {
+	tb.Helper()
+	data, err := os.ReadFile(path)
+	if err != nil {
+		tb.Fatal(err)
+	}
+	records, err := csv.NewReader(bytes.NewReader(data)).ReadAll()
+	if err != nil {
+		tb.Fatalf("%s: %v", path, err)
+	}
+	return records
+}
+
+func TestParseScheduleRecordsGolden(t *testing.T) {
+	for _, path := range corpus(t) {
+		name := strings.TrimSuffix(filepath.Base(path), ".csv")
+		t.Run(name, func(t *testing.T) {
+			discardLogs(t)
+			records, err := NewClient(nil, time.UTC).ParseScheduleRecords(readSheet(t, path), bells.Builtin())
+			if err != nil {
+				t.Fatalf("ParseScheduleRecords: %v", err)
+			}
+
+			got, err := json.MarshalIndent(records, "", "  ")
+			if err != nil {
+				t.Fatal(err)
+			}
+			got = append(got, '\n')
+
+			golden := filepath.Join("testdata", name+".golden.json")
+			if *update {
+				if err := os.WriteFile(golden, got, 0o644); err != nil {
+					t.Fatal(err)
+				}
+				return
+			}
+			want, err := os.ReadFile(golden)
+			if err != nil {
+				t.Fatalf("нет эталона (запустите с -update): %v", err)
+			}
+			if !bytes.Equal(got, want) {
+				t.Errorf("результат разбора %s отличается от %s; проверьте дифф после запуска с -update", path, golden)
+			}
+		})
+	}
+}
+
+func BenchmarkParseScheduleRecordsCorpus(b *testing.B) {
+	for _, path := range corpus(b) {
+		b.Run(strings.TrimSuffix(filepath.Base(path), ".csv"), func(b *testing.B) {
+			discardLogs(b)
+			sheet := readSheet(b, path)
+			client := NewClient(nil, time.UTC)
+
+			b.ReportAllocs()
+			b.ResetTimer()
+			for i := 0; i < b.N; i++ {
+				if _, err := client.ParseScheduleRecords(sheet, bells.Builtin()); err != nil {
+					b.Fatal(err)
+				}
+			}
+		})
+	}
+}
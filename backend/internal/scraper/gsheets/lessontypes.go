@@ -0,0 +1,52 @@
+package gsheets
+
+import "strings"
+
+// lessonTypes названия видов занятия в таблице (без точки, в нижнем регистре)
+// и их единое написание
+var lessonTypes = map[string]string{
+	"лекция":   "лекция",
+	"лекции":   "лекция",
+	"лек":      "лекция",
+	"лк":       "лекция",
+	"практика": "практика",
+	"практическое занятие": "практика",
+	"практическая работа":  "практика",
+	"практ":                "практика",
+	"пр":                   "практика",
+	"пз":                   "практика",
+	"лабораторная":         "лабораторная",
+	"лабораторная работа":  "лабораторная",
+	"лабораторное занятие": "лабораторная",
+	"лаб":          "лабораторная",
+	"лр":           "лабораторная",
+	"семинар":      "семинар",
+	"сем":          "семинар",
+	"консультация": "консультация",
+	"конс":         "консультация",
+	"экзамен":      "экзамен",
+	"зачет":        "зачет",
+	"зачёт":        "зачет",
+	"дифференцированный зачет": "зачет",
+}
+
+// normalizeLessonType приводит вид занятия к единому написанию ("Лек." - "лекция",
+// "Лаб. работа" - "лабораторная"). Нераспознанный вид возвращается как есть
+// (без пробелов по краям), known - распознан ли вид.
+func normalizeLessonType(kind string) (normalized string, known bool) {
+	kind = strings.TrimSpace(kind)
+	key := strings.ToLower(strings.Join(strings.Fields(strings.ReplaceAll(kind, ".", " ")), " "))
+	if key == "" {
+		return "", false
+	}
+	if normalized, ok := lessonTypes[key]; ok {
+		return normalized, true
+	}
+	// Сокращения с точкой: "Лаб. работа", "Практ. занятие"
+	if first, _, _ := strings.Cut(key, " "); first != key {
+		if normalized, ok := lessonTypes[first]; ok {
+			return normalized, true
+		}
+	}
+	return kind, false
+}
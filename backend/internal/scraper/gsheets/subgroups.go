@@ -0,0 +1,165 @@
+package gsheets
+
+import (
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// subgroupMarker отметка подгруппы в ячейке: "1 п/г", "(2 п/г)", "1 подгр.", "2 подгруппа"
+var subgroupMarker = regexp.MustCompile(`(?i)\(?\s*([1-9])\s*-?\s*(?:п[/\\]г|подгр(?:уппа)?)\.?\s*\)?`)
+
+// lessonVariant занятие из ячейки таблицы: всей группы (subgroup 0) или одной подгруппы
+type lessonVariant struct {
+	subgroup   int
+	subject    string
+	lessonType string
+	teacher    string
+	classroom  string
+}
+
+// splitSubgroups разбирает ячейки занятия и аудитории на занятия подгрупп.
+// Ячейка без отметок подгрупп - одно занятие всей группы.
+// Занятия подгрупп записываются отдельными строками ячейки ("1 п/г Информатика / Иванов"
+// и "2 п/г Информатика / Петров") или подряд в одной строке, если строка начинается
+// с отметки. Одна отметка на всю ячейку - занятие только этой подгруппы.
+// Аудитории подгрупп - строки ячейки аудитории или части через "/" ("301/302");
+// аудитория с отметкой подгруппы относится к этой подгруппе.
+// Занятия добавляются к buf, чтобы разбор таблицы не выделял память на каждую ячейку.
+func splitSubgroups(buf []lessonVariant, subjectCell, classroomCell string) []lessonVariant {
+	cell := strings.TrimSpace(removeNonPrintable(subjectCell))
+	classroom := strings.TrimSpace(removeNonPrintable(classroomCell))
+	if !hasSubgroupHint(cell) || !subgroupMarker.MatchString(cell) {
+		subject, lessonType, teacher := splitSubjectCell(cell)
+		return append(buf, lessonVariant{subject: subject, lessonType: lessonType, teacher: teacher, classroom: classroom})
+	}
+
+	var segments []string
+	for _, line := range strings.Split(subjectCell, "\n") {
+		line = strings.TrimSpace(removeNonPrintable(line))
+		if line != "" {
+			segments = append(segments, splitAtMarkers(line)...)
+		}
+	}
+
+	variants := make([]lessonVariant, 0, len(segments))
+	for _, segment := range segments {
+		if len(subgroupMarker.FindAllStringIndex(segment, -1)) != 1 {
+			variants = nil
+			break
+		}
+		subgroup, text := cutSubgroup(segment)
+		subject, lessonType, teacher := splitSubjectCell(text)
+		variants = append(variants, lessonVariant{subgroup: subgroup, subject: subject, lessonType: lessonType, teacher: teacher})
+	}
+
+	switch {
+	case len(variants) > 1:
+		assignClassrooms(variants, classroomCell)
+		return append(buf, variants...)
+	case len(subgroupMarker.FindAllStringIndex(cell, -1)) == 1:
+		// Одна отметка на ячейку, возможно, в середине многострочного текста
+		subgroup, text := cutSubgroup(cell)
+		subject, lessonType, teacher := splitSubjectCell(text)
+		_, classroom = cutSubgroup(classroom)
+		return append(buf, lessonVariant{subgroup: subgroup, subject: subject, lessonType: lessonType, teacher: teacher, classroom: classroom})
+	default:
+		// Отметки не удалось сопоставить с занятиями: вся ячейка - занятие группы
+		subject, lessonType, teacher := splitSubjectCell(cell)
+		return append(buf, lessonVariant{subject: subject, lessonType: lessonType, teacher: teacher, classroom: classroom})
+	}
+}
+
+// subgroupHints начала отметок подгрупп для быстрой проверки ячейки
+var subgroupHints = [...]string{"п/г", "п\\г", "подгр"}
+
+// hasSubgroupHint проверяет без учета регистра, что в ячейке может быть отметка
+// подгруппы: регулярное выражение запускается только для таких ячеек
+func hasSubgroupHint(cell string) bool {
+	for i, r := range cell {
+		if r != 'п' && r != 'П' {
+			continue
+		}
+		for _, hint := range subgroupHints {
+			if len(cell)-i >= len(hint) && strings.EqualFold(cell[i:i+len(hint)], hint) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// splitAtMarkers делит строку, начинающуюся с отметки подгруппы, на части
+// по отметкам: "1 п/г Физика / Иванов 2 п/г Химия / Петров"
+func splitAtMarkers(line string) []string {
+	locs := subgroupMarker.FindAllStringIndex(line, -1)
+	if len(locs) < 2 || strings.TrimSpace(line[:locs[0][0]]) != "" {
+		return []string{line}
+	}
+
+	parts := make([]string, 0, len(locs))
+	for i, loc := range locs {
+		end := len(line)
+		if i+1 < len(locs) {
+			end = locs[i+1][0]
+		}
+		if part := strings.TrimSpace(line[loc[0]:end]); part != "" {
+			parts = append(parts, part)
+		}
+	}
+	return parts
+}
+
+// cutSubgroup возвращает номер подгруппы из первой отметки и текст без нее
+// (0 и исходный текст, если отметки нет)
+func cutSubgroup(text string) (int, string) {
+	loc := subgroupMarker.FindStringSubmatchIndex(text)
+	if loc == nil {
+		return 0, text
+	}
+	subgroup, _ := strconv.Atoi(text[loc[2]:loc[3]])
+	rest := strings.TrimSpace(text[:loc[0]]) + " " + strings.TrimSpace(text[loc[1]:])
+	return subgroup, strings.Trim(rest, " ,;:")
+}
+
+// assignClassrooms распределяет аудитории ячейки по занятиям подгрупп.
+// Если аудитории нельзя сопоставить с подгруппами, каждой достается вся ячейка.
+func assignClassrooms(variants []lessonVariant, classroomCell string) {
+	var parts []string
+	for _, line := range strings.Split(classroomCell, "\n") {
+		if line = strings.TrimSpace(removeNonPrintable(line)); line != "" {
+			parts = append(parts, line)
+		}
+	}
+	if len(parts) == 1 && len(variants) > 1 {
+		parts = strings.Split(parts[0], "/")
+	}
+
+	if len(parts) != len(variants) {
+		classroom := strings.TrimSpace(removeNonPrintable(classroomCell))
+		for i := range variants {
+			variants[i].classroom = classroom
+		}
+		return
+	}
+
+	byIndex := make([]string, 0, len(parts))
+	for _, part := range parts {
+		subgroup, classroom := cutSubgroup(strings.TrimSpace(part))
+		if subgroup == 0 {
+			byIndex = append(byIndex, classroom)
+			continue
+		}
+		for i := range variants {
+			if variants[i].subgroup == subgroup && variants[i].classroom == "" {
+				variants[i].classroom = classroom
+				break
+			}
+		}
+	}
+	for i := range variants {
+		if variants[i].classroom == "" && len(byIndex) > 0 {
+			variants[i].classroom, byIndex = byIndex[0], byIndex[1:]
+		}
+	}
+}
@@ -0,0 +1,168 @@
+package scraper
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/Ultrahd-dev/student-schedule-app/backend/internal/clock"
+	"github.com/Ultrahd-dev/student-schedule-app/backend/internal/jobs"
+	"github.com/Ultrahd-dev/student-schedule-app/backend/internal/schedule"
+	"github.com/google/uuid"
+)
+
+// Виды фоновых задач scraper сервиса
+const (
+	JobRebuildDayCache      = "schedule.rebuild_day_cache"    // Пересборка кэша расписания на дату
+	JobNotifyChange         = "notifications.change"          // Рассылка уведомлений о примененном изменении
+	JobNotifyChangeReverted = "notifications.change_reverted" // Рассылка уведомлений об откате изменения
+)
+
+// dayCacheJob параметры задачи JobRebuildDayCache
+type dayCacheJob struct {
+	Date string `json:"date"` // YYYY-MM-DD
+}
+
+// changeJobBatch сколько изменений рассылается одной задачей уведомлений
+const changeJobBatch = 100
+
+// changeJob параметры задач рассылки уведомлений об изменениях
+type changeJob struct {
+	ChangeID  uuid.UUID   `json:"change_id,omitempty"`  // Одно изменение (задачи, поставленные до пакетной рассылки)
+	ChangeIDs []uuid.UUID `json:"change_ids,omitempty"` // Пакет изменений
+}
+
+// SetJobQueue переносит тяжелые шаги парсинга (пересборку кэша расписания и рассылку
+// уведомлений) в фоновые задачи очереди queue и регистрирует их обработчики.
+// Без очереди эти шаги выполняются прямо в цикле парсинга.
+func (s *Service) SetJobQueue(queue *jobs.Queue) {
+	s.jobs = queue
+	queue.Register(JobRebuildDayCache, s.handleRebuildDayCache)
+	queue.Register(JobNotifyChange, s.handleNotifyChange)
+	queue.Register(JobNotifyChangeReverted, s.handleNotifyChangeReverted)
+}
+
+// rebuildDayCache пересобирает кэш расписания на дату (в фоне, если настроена очередь)
+func (s *Service) rebuildDayCache(ctx context.Context, date time.Time) error {
+	if s.jobs != nil {
+		_, err := s.jobs.Enqueue(ctx, JobRebuildDayCache, dayCacheJob{Date: date.Format("2006-01-02")})
+		if err == nil {
+			return nil
+		}
+		log.Printf("Ошибка постановки пересборки кэша в очередь, выполняем сразу: %v", err)
+	}
+
+	_, err := s.scheduleRepo.RebuildDayCache(ctx, date)
+	return err
+}
+
+// notifyChange рассылает уведомление об изменении (в фоне, если настроена очередь).
+// kind - JobNotifyChange или JobNotifyChangeReverted.
+func (s *Service) notifyChange(ctx context.Context, kind string, change *schedule.ScheduleChange) error {
+	if s.jobs != nil {
+		_, err := s.jobs.Enqueue(ctx, kind, changeJob{ChangeID: change.ID})
+		if err == nil {
+			return nil
+		}
+		log.Printf("Ошибка постановки уведомления об изменении %s в очередь, отправляем сразу: %v", change.ID, err)
+	}
+
+	return s.sendChangeNotification(ctx, kind, change)
+}
+
+// notifyChanges рассылает уведомления вида kind по изменениям. С очередью изменения
+// ставятся задачами по changeJobBatch штук, без нее уведомления отправляются сразу.
+// В обоих случаях студенты всех групп пакета получаются одним запросом.
+func (s *Service) notifyChanges(ctx context.Context, kind string, changes []schedule.ScheduleChange) {
+	for start := 0; start < len(changes); start += changeJobBatch {
+		batch := changes[start:min(start+changeJobBatch, len(changes))]
+		if s.jobs != nil {
+			ids := make([]uuid.UUID, len(batch))
+			for i := range batch {
+				ids[i] = batch[i].ID
+			}
+			_, err := s.jobs.Enqueue(ctx, kind, changeJob{ChangeIDs: ids})
+			if err == nil {
+				continue
+			}
+			log.Printf("Ошибка постановки уведомлений о %d изменениях в очередь, отправляем сразу: %v", len(batch), err)
+		}
+
+		if err := s.sendChangeNotifications(ctx, kind, batch); err != nil {
+			log.Printf("Ошибка отправки уведомлений об изменениях: %v", err)
+		}
+	}
+}
+
+// sendChangeNotification отправляет уведомление вида kind об изменении
+func (s *Service) sendChangeNotification(ctx context.Context, kind string, change *schedule.ScheduleChange) error {
+	if kind == JobNotifyChangeReverted {
+		return s.notificationService.SendChangeRevertedNotification(ctx, change)
+	}
+	return s.notificationService.SendScheduleChangeNotification(ctx, change)
+}
+
+// sendChangeNotifications отправляет уведомления вида kind об изменениях
+func (s *Service) sendChangeNotifications(ctx context.Context, kind string, changes []schedule.ScheduleChange) error {
+	if kind == JobNotifyChangeReverted {
+		return s.notificationService.SendChangeRevertedNotifications(ctx, changes)
+	}
+	return s.notificationService.SendScheduleChangeNotifications(ctx, changes)
+}
+
+// handleRebuildDayCache обрабатывает задачу JobRebuildDayCache
+func (s *Service) handleRebuildDayCache(ctx context.Context, payload json.RawMessage) error {
+	var job dayCacheJob
+	if err := json.Unmarshal(payload, &job); err != nil {
+		return fmt.Errorf("некорректные параметры задачи: %w", err)
+	}
+	date, err := time.ParseInLocation("2006-01-02", job.Date, s.loc)
+	if err != nil {
+		return fmt.Errorf("некорректная дата %q: %w", job.Date, err)
+	}
+
+	groups, err := s.scheduleRepo.RebuildDayCache(ctx, date)
+	if err != nil {
+		return err
+	}
+	log.Printf("Кэш расписания на %s пересобран для %d групп", date.Format(clock.DateLayout), groups)
+	return nil
+}
+
+// handleNotifyChange обрабатывает задачу JobNotifyChange.
+// При повторной попытке уведомление может дойти до части получателей второй раз.
+func (s *Service) handleNotifyChange(ctx context.Context, payload json.RawMessage) error {
+	return s.handleChangeJob(ctx, JobNotifyChange, payload)
+}
+
+// handleNotifyChangeReverted обрабатывает задачу JobNotifyChangeReverted
+func (s *Service) handleNotifyChangeReverted(ctx context.Context, payload json.RawMessage) error {
+	return s.handleChangeJob(ctx, JobNotifyChangeReverted, payload)
+}
+
+// handleChangeJob загружает изменения из задачи и отправляет по ним уведомления вида kind
+func (s *Service) handleChangeJob(ctx context.Context, kind string, payload json.RawMessage) error {
+	var job changeJob
+	if err := json.Unmarshal(payload, &job); err != nil {
+		return fmt.Errorf("некорректные параметры задачи: %w", err)
+	}
+
+	if job.ChangeID != uuid.Nil {
+		change, err := s.scheduleRepo.GetChangeByID(ctx, job.ChangeID)
+		if err != nil {
+			return err
+		}
+		return s.sendChangeNotification(ctx, kind, change)
+	}
+
+	changes, err := s.scheduleRepo.GetChangesByIDs(ctx, job.ChangeIDs)
+	if err != nil {
+		return err
+	}
+	if len(changes) == 0 {
+		return nil
+	}
+	return s.sendChangeNotifications(ctx, kind, changes)
+}
@@ -0,0 +1,28 @@
+package scraper
+
+import (
+	"testing"
+	"time"
+
+	gsheet "github.com/Ultrahd-dev/student-schedule-app/backend/internal/scraper/gsheets"
+)
+
+func TestSheetPeriod(t *testing.T) {
+	day := func(d int) time.Time { return time.Date(2026, 3, d, 0, 0, 0, 0, time.UTC) }
+
+	records := []gsheet.ScheduleRecord{
+		{GroupName: "ИС-21", Date: day(4)},
+		{GroupName: "ИС-21", Date: day(2)},
+		{GroupName: "ИС-22"}, // Дата дня не распознана
+		{GroupName: "ИС-22", Date: day(7)},
+	}
+	start, end := sheetPeriod(records, day(20))
+	if !start.Equal(day(2)) || !end.Equal(day(7)) {
+		t.Errorf("период %s - %s, ожидался по датам таблицы", start, end)
+	}
+
+	start, end = sheetPeriod([]gsheet.ScheduleRecord{{GroupName: "ИС-21"}}, day(20))
+	if !start.Equal(day(20)) || !end.Equal(day(27)) {
+		t.Errorf("период таблицы без дат %s - %s, ожидалась неделя с даты из названия", start, end)
+	}
+}
@@ -16,10 +16,19 @@ import (
 	"time"
 
 	"github.com/PuerkitoBio/goquery"
+	"github.com/Ultrahd-dev/student-schedule-app/backend/internal/bells"
+	"github.com/Ultrahd-dev/student-schedule-app/backend/internal/breaker"
 	"github.com/Ultrahd-dev/student-schedule-app/backend/internal/changes"
+	"github.com/Ultrahd-dev/student-schedule-app/backend/internal/clock"
+	"github.com/Ultrahd-dev/student-schedule-app/backend/internal/features"
+	"github.com/Ultrahd-dev/student-schedule-app/backend/internal/jobs"
 	"github.com/Ultrahd-dev/student-schedule-app/backend/internal/notifications"
+	"github.com/Ultrahd-dev/student-schedule-app/backend/internal/outbox"
 	"github.com/Ultrahd-dev/student-schedule-app/backend/internal/schedule"
 	gsheet "github.com/Ultrahd-dev/student-schedule-app/backend/internal/scraper/gsheets" // Обновляем импорт
+	"github.com/Ultrahd-dev/student-schedule-app/backend/internal/storage"
+	"github.com/Ultrahd-dev/student-schedule-app/backend/internal/tenant"
+	"github.com/Ultrahd-dev/student-schedule-app/backend/internal/txn"
 	"github.com/google/uuid"
 )
 
@@ -28,7 +37,7 @@ type Service struct {
 	httpClient *http.Client
 	// gsheetClient теперь принимает список gid в конструкторе
 	gsheetClient        *gsheet.Client
-	scheduleRepo        *schedule.Repository
+	scheduleRepo        schedule.ScheduleStore
 	notificationService *notifications.Service
 	changeService       *changes.Service
 	baseURL             string
@@ -37,6 +46,51 @@ type Service struct {
 	mainScheduleGIDs []int64
 	// Добавляем gid для таблицы изменений (по умолчанию 0)
 	changesGID int64
+	// Часовой пояс колледжа
+	loc *time.Location
+	// Изменения требуют одобрения администратором перед применением
+	// (значение по умолчанию, если не заданы флаги функций)
+	moderateChanges bool
+	// Флаги функций (может быть nil)
+	flags FlagChecker
+	// Блокировка циклов парсинга между экземплярами API (может быть nil)
+	locker JobLocker
+	// Очередь фоновых задач для пересборки кэша и рассылки уведомлений (может быть nil)
+	jobs *jobs.Queue
+	// Outbox доменных событий; при нем уведомления и пересборку кэша запускают подписчики relay (может быть nil)
+	outbox *outbox.Repository
+	// Транзакции сохранения снапшота вместе с событием outbox
+	transactor txn.Transactor
+	// Circuit breaker'ы запросов к сайту колледжа и Google Таблицам
+	siteBreaker   *breaker.Breaker
+	sheetsBreaker *breaker.Breaker
+	// Хранилище исходных таблиц парсинга (может быть nil)
+	artifacts storage.Storage
+	// Шаблон названия снапшота основного расписания (см. Config.SnapshotName)
+	snapshotName string
+}
+
+// Имена задач парсинга для распределенной блокировки и журнала запусков (job_runs)
+const (
+	MainScheduleJob = "scraper:main_schedule"
+	ChangesJob      = "scraper:changes"
+)
+
+// Периоды парсинга. Цикл, выполненный другим экземпляром менее полупериода назад,
+// считается текущим и повторно не выполняется.
+const (
+	mainScheduleInterval = 1 * time.Hour // В production будет 168 часов (неделя)
+	changesInterval      = 10 * time.Minute
+)
+
+// JobLocker выполняет цикл задачи только на одном экземпляре API
+type JobLocker interface {
+	RunOnce(ctx context.Context, name string, minInterval time.Duration, fn func(ctx context.Context) error) (bool, error)
+}
+
+// FlagChecker проверяет флаги функций
+type FlagChecker interface {
+	Enabled(name string) bool
 }
 
 // Config конфигурация scraper сервиса
@@ -46,10 +100,31 @@ type Config struct {
 	// Добавляем поля для конфигурации gid
 	MainScheduleGIDs []int64 `json:"main_schedule_gids"` // Список gid листов основного расписания
 	ChangesGID       int64   `json:"changes_gid"`        // gid листа изменений (по умолчанию 0)
+	// Location часовой пояс колледжа (по умолчанию UTC)
+	Location *time.Location `json:"-"`
+	// ModerateChanges включает модерацию: изменения из таблицы не применяются
+	// и не рассылаются, пока их не одобрит администратор
+	ModerateChanges bool `json:"moderate_changes"`
+	// Breaker настройки circuit breaker'ов сайта колледжа и Google Таблиц
+	Breaker breaker.Config `json:"-"`
+	// College код колледжа, расписание которого парсит сервис. Для колледжей,
+	// кроме колледжа по умолчанию, код добавляется к именам circuit breaker'ов.
+	// Сам колледж определяется контекстом, переданным в методы парсинга.
+	College string `json:"college"`
+	// Transport транспорт запросов к сайту колледжа и Google Таблицам
+	// (nil - http.DefaultTransport; в режиме фикстур - fixture.Transport)
+	Transport http.RoundTripper `json:"-"`
+	// SnapshotName шаблон названия снапшота основного расписания: {start} и {end} -
+	// даты периода, {title} - текст ссылки на таблицу на сайте колледжа
+	// (по умолчанию DefaultSnapshotName)
+	SnapshotName string `json:"snapshot_name"`
 }
 
+// DefaultSnapshotName шаблон названия снапшота по умолчанию
+const DefaultSnapshotName = "Расписание с {start}"
+
 // NewService создает новый scraper сервис
-func NewService(config Config, scheduleRepo *schedule.Repository,
+func NewService(config Config, scheduleRepo schedule.ScheduleStore,
 	notificationService *notifications.Service, changeService *changes.Service) *Service {
 
 	// Устанавливаем значения по умолчанию, если не заданы в конфиге
@@ -63,24 +138,123 @@ func NewService(config Config, scheduleRepo *schedule.Repository,
 		changesGID = 0 // По умолчанию 0
 	}
 
+	loc := config.Location
+	if loc == nil {
+		loc = time.UTC
+	}
+
+	snapshotName := config.SnapshotName
+	if snapshotName == "" {
+		snapshotName = DefaultSnapshotName
+	}
+
+	// Недоступность сайта или таблиц не должна каждый цикл занимать парсинг на время таймаута
+	siteBreaker := breaker.New(breakerName("college_site", config.College), config.Breaker)
+	sheetsBreaker := breaker.New(breakerName("google_sheets", config.College), config.Breaker)
+	gsheetClient := gsheet.NewClient(mainGIDs, loc)
+	gsheetClient.SetTransport(breaker.NewTransport(sheetsBreaker, config.Transport))
+
 	return &Service{
 		httpClient: &http.Client{
-			Timeout: config.Timeout,
+			Timeout:   config.Timeout,
+			Transport: breaker.NewTransport(siteBreaker, config.Transport),
 		},
 		// Передаем список gid в конструктор клиента
-		gsheetClient:        gsheet.NewClient(mainGIDs),
+		gsheetClient:        gsheetClient,
 		scheduleRepo:        scheduleRepo,
 		notificationService: notificationService,
 		changeService:       changeService,
 		baseURL:             config.BaseURL,
 		mainScheduleGIDs:    mainGIDs,   // Сохраняем для логирования
 		changesGID:          changesGID, // Сохраняем для логирования
+		loc:                 loc,
+		moderateChanges:     config.ModerateChanges,
+		siteBreaker:         siteBreaker,
+		sheetsBreaker:       sheetsBreaker,
+		snapshotName:        snapshotName,
+	}
+}
+
+// breakerName возвращает имя circuit breaker'а зависимости name для колледжа college
+func breakerName(name, college string) string {
+	if college == "" || college == "default" {
+		return name
+	}
+	return name + ":" + college
+}
+
+// Breakers возвращает circuit breaker'ы внешних зависимостей (для метрик)
+func (s *Service) Breakers() []*breaker.Breaker {
+	return []*breaker.Breaker{s.siteBreaker, s.sheetsBreaker}
+}
+
+// SetLocker включает распределенную блокировку циклов парсинга: при нескольких
+// экземплярах API каждый цикл выполняет только один из них
+func (s *Service) SetLocker(locker JobLocker) {
+	s.locker = locker
+}
+
+// SetFeatureFlags включает управление модерацией изменений флагом
+// features.FlagModeratedChanges вместо настройки из конфигурации
+func (s *Service) SetFeatureFlags(flags FlagChecker) {
+	s.flags = flags
+}
+
+// moderationEnabled проверяет, требуют ли новые изменения одобрения администратором
+func (s *Service) moderationEnabled() bool {
+	if s.flags != nil {
+		return s.flags.Enabled(features.FlagModeratedChanges)
 	}
+	return s.moderateChanges
+}
+
+// runExclusive выполняет цикл задачи job, если его не выполняет другой экземпляр.
+// Блокировка отдельная для каждого колледжа.
+func (s *Service) runExclusive(ctx context.Context, job string, interval time.Duration, fn func(ctx context.Context) error) error {
+	if s.locker == nil {
+		return fn(ctx)
+	}
+	_, err := s.locker.RunOnce(ctx, tenant.Scoped(ctx, job), interval/2, fn)
+	return err
+}
+
+// ScrapeNow парсит основное расписание и изменения по запросу администратора.
+// В отличие от периодических циклов парсинг не пропускается, если недавно
+// выполнен, но по-прежнему не выполняется одновременно с другим экземпляром.
+func (s *Service) ScrapeNow(ctx context.Context) error {
+	if err := s.runExclusive(ctx, MainScheduleJob, 0, s.scrapeMainSchedule); err != nil {
+		return fmt.Errorf("ошибка парсинга основного расписания: %w", err)
+	}
+	if err := s.runExclusive(ctx, ChangesJob, 0, s.scrapeScheduleChanges); err != nil {
+		return fmt.Errorf("ошибка парсинга изменений: %w", err)
+	}
+	return nil
+}
+
+// ScrapeSource внепланово парсит таблицу, из которой пришла запись актуального
+// расписания: таблицу изменений для записей из изменений (source_type "change"),
+// иначе основное расписание. Используется при жалобах пользователей на ошибку в паре.
+func (s *Service) ScrapeSource(ctx context.Context, sourceType string) error {
+	if sourceType == "change" {
+		if err := s.runExclusive(ctx, ChangesJob, 0, s.scrapeScheduleChanges); err != nil {
+			return fmt.Errorf("ошибка парсинга изменений: %w", err)
+		}
+		return nil
+	}
+	if err := s.runExclusive(ctx, MainScheduleJob, 0, s.scrapeMainSchedule); err != nil {
+		return fmt.Errorf("ошибка парсинга основного расписания: %w", err)
+	}
+	return nil
 }
 
 // ScrapeMainSchedule парсит основное расписание с сайта колледжа
 // В соответствии с ТЗ: "Процесс парсинга основного расписания"
 func (s *Service) ScrapeMainSchedule(ctx context.Context) error {
+	return s.runExclusive(ctx, MainScheduleJob, mainScheduleInterval, s.scrapeMainSchedule)
+}
+
+// scrapeMainSchedule выполняет цикл парсинга основного расписания
+func (s *Service) scrapeMainSchedule(ctx context.Context) error {
 	log.Println("Начинаем парсинг основного расписания с сайта колледжа")
 
 	// 1. Запрос к https://kcpt72.ru/schedule/
@@ -123,10 +297,10 @@ func (s *Service) ScrapeMainSchedule(ctx context.Context) error {
 				var date time.Time
 				if len(dates) > 0 {
 					// Берем первую найденную дату как дату начала периода
-					date, _ = time.Parse("02.01.2006", dates[0])
+					date, _ = clock.ParseDate(dates[0], s.loc)
 				} else {
 					// Если дату не нашли, используем текущее время как fallback
-					date = time.Now()
+					date = clock.Now(s.loc)
 				}
 
 				sheetLinks = append(sheetLinks, struct {
@@ -157,7 +331,7 @@ func (s *Service) ScrapeMainSchedule(ctx context.Context) error {
 					}{
 						URL:  href,
 						Text: text,
-						Date: time.Now(),
+						Date: clock.Now(s.loc),
 					})
 				}
 			}
@@ -178,6 +352,7 @@ func (s *Service) ScrapeMainSchedule(ctx context.Context) error {
 
 	// Берем первую (самую свежую) таблицу
 	sheetURL := sheetLinks[0].URL
+	sheetTitle := sheetLinks[0].Text
 	log.Printf("Выбрана таблица: %s (дата: %s)", sheetLinks[0].Text, sheetLinks[0].Date.Format("02.01.2006"))
 
 	// 4. Экспорт таблицы в CSV формат
@@ -189,35 +364,37 @@ func (s *Service) ScrapeMainSchedule(ctx context.Context) error {
 	}
 
 	log.Printf("Получено %d записей из таблицы", len(csvRecords))
+	s.saveArtifact(ctx, "main", csvRecords)
 
 	// 5. Парсинг данных о расписании
 	log.Println("Парсим данные о расписании")
 
-	scheduleRecords, err := s.gsheetClient.ParseScheduleRecords(csvRecords)
+	scheduleRecords, err := s.gsheetClient.ParseScheduleRecords(csvRecords, bells.For(ctx))
 	if err != nil {
 		return fmt.Errorf("ошибка парсинга данных расписания: %w", err)
 	}
 
 	log.Printf("Успешно распаршено %d записей расписания", len(scheduleRecords))
 
-	// 6. Создание нового снапшота в БД
-	log.Println("Создаем новый снапшот расписания")
+	// 6. Сохранение снапшота в БД: повторный парсинг той же таблицы за тот же период
+	// обновляет уже созданный снапшот
+	log.Println("Сохраняем снапшот расписания")
+
+	// Определяем период действия расписания по датам из таблицы: от дня парсинга
+	// он не зависит, поэтому повторный парсинг той же таблицы обновляет ее снапшот
+	periodStart, periodEnd := sheetPeriod(scheduleRecords, clock.DateOf(sheetLinks[0].Date, s.loc))
 
 	// Преобразуем данные в формат JSON для хранения в БД
-	scheduleData := s.convertToScheduleData(scheduleRecords)
+	scheduleData := s.convertToScheduleData(scheduleRecords, periodStart, periodEnd)
 	jsonData, err := json.Marshal(scheduleData)
 	if err != nil {
 		return fmt.Errorf("ошибка сериализации данных расписания в JSON: %w", err)
 	}
 
-	// Определяем период действия расписания
-	periodStart := time.Now()
-	periodEnd := periodStart.Add(7 * 24 * time.Hour) // +1 неделя
-
 	// Создаем снапшот
 	snapshot := &schedule.ScheduleSnapshot{
 		ID:          uuid.New(),
-		Name:        fmt.Sprintf("Расписание с %s", periodStart.Format("02.01.2006")),
+		Name:        formatSnapshotName(s.snapshotName, periodStart, periodEnd, sheetTitle),
 		PeriodStart: periodStart,
 		PeriodEnd:   periodEnd,
 		Data:        jsonData,
@@ -225,21 +402,76 @@ func (s *Service) ScrapeMainSchedule(ctx context.Context) error {
 		IsActive:    true,
 	}
 
-	err = s.scheduleRepo.CreateSnapshot(ctx, snapshot)
+	created, err := s.saveSnapshot(ctx, snapshot)
 	if err != nil {
-		return fmt.Errorf("ошибка создания снапшота расписания: %w", err)
+		return fmt.Errorf("ошибка сохранения снапшота расписания: %w", err)
 	}
 
-	log.Printf("Создан новый снапшот расписания: %s", snapshot.ID)
+	if created {
+		log.Printf("Создан новый снапшот расписания: %s (%s)", snapshot.ID, snapshot.Name)
+	} else {
+		log.Printf("Обновлен снапшот расписания за тот же период: %s (%s)", snapshot.ID, snapshot.Name)
+	}
+
+	// Пересобираем кэш расписания на сегодня после загрузки нового расписания.
+	// С outbox кэш пересобирает подписчик события snapshot.created.
+	if s.outbox == nil {
+		if err := s.rebuildDayCache(ctx, clock.Today(s.loc)); err != nil {
+			log.Printf("Ошибка пересборки кэша расписания: %v", err)
+		}
+	}
 	log.Println("Парсинг основного расписания завершен успешно")
 	return nil
 }
 
+// sheetPeriod возвращает период действия таблицы основного расписания - первую
+// и последнюю даты занятий в ней (календарные дни в часовом поясе колледжа).
+// Если дат в таблице нет, период - неделя с даты fallback (дата из названия
+// ссылки на таблицу).
+func sheetPeriod(records []gsheet.ScheduleRecord, fallback time.Time) (time.Time, time.Time) {
+	var start, end time.Time
+	for _, record := range records {
+		if record.Date.IsZero() {
+			continue
+		}
+		if start.IsZero() || record.Date.Before(start) {
+			start = record.Date
+		}
+		if record.Date.After(end) {
+			end = record.Date
+		}
+	}
+	if start.IsZero() {
+		return fallback, fallback.AddDate(0, 0, 7)
+	}
+	return start, end
+}
+
+// formatSnapshotName возвращает название снапшота по шаблону template
+func formatSnapshotName(template string, periodStart, periodEnd time.Time, title string) string {
+	return strings.NewReplacer(
+		"{start}", periodStart.Format(clock.DateLayout),
+		"{end}", periodEnd.Format(clock.DateLayout),
+		"{title}", title,
+	).Replace(template)
+}
+
 // ScrapeScheduleChanges парсит изменения в расписании
 // В соответствии с ТЗ: "Процесс парсинга изменений"
 func (s *Service) ScrapeScheduleChanges(ctx context.Context) error {
+	return s.runExclusive(ctx, ChangesJob, changesInterval, s.scrapeScheduleChanges)
+}
+
+// scrapeScheduleChanges выполняет цикл парсинга изменений
+func (s *Service) scrapeScheduleChanges(ctx context.Context) error {
 	log.Println("Начинаем парсинг изменений в расписании")
 
+	// Режим модерации фиксируем на весь цикл
+	moderate := s.moderationEnabled()
+
+	// Сначала доприменяем изменения, оставшиеся с прошлого запуска
+	s.resumePendingChanges(ctx)
+
 	// 1. Запрос к сайту колледжа для поиска ссылки на таблицу изменений
 	log.Printf("Отправляем запрос к %s для поиска таблицы изменений", s.baseURL)
 
@@ -335,7 +567,7 @@ func (s *Service) ScrapeScheduleChanges(ctx context.Context) error {
 	// 4. Парсинг данных об изменениях
 	log.Println("Парсим данные об изменениях")
 
-	changeRecords, errParse := s.gsheetClient.ParseChangeRecords(csvRecords)
+	changeRecords, errParse := s.gsheetClient.ParseChangeRecords(csvRecords, bells.For(ctx))
 	if errParse != nil {
 		return fmt.Errorf("ошибка парсинга данных изменений: %w", errParse)
 	}
@@ -356,10 +588,24 @@ func (s *Service) ScrapeScheduleChanges(ctx context.Context) error {
 
 	log.Println("Обнаружены новые изменения в расписании")
 	s.lastChangeHash = currentHash
+	s.saveArtifact(ctx, "changes", csvRecords)
+
+	// Изменения, строки которых уже были в таблице, узнаем по отпечатку и не создаем повторно
+	tracked, err := s.scheduleRepo.GetTrackedChanges(ctx)
+	if err != nil {
+		return fmt.Errorf("ошибка получения отслеживаемых изменений: %w", err)
+	}
+	trackedByFingerprint := make(map[string]uuid.UUID, len(tracked))
+	for _, change := range tracked {
+		trackedByFingerprint[change.Fingerprint] = change.ID
+	}
 
 	// 6. Если есть изменения - парсинг новых данных
 	// 7. Создание записей в schedule_changes
 	var createdChanges []schedule.ScheduleChange
+	var seenIDs []uuid.UUID
+	seen := make(map[string]bool, len(changeRecords))
+	snapshotsByDate := make(map[time.Time]*uuid.UUID)
 	for _, record := range changeRecords {
 		change := &schedule.ScheduleChange{
 			ID:              uuid.New(),
@@ -372,8 +618,36 @@ func (s *Service) ScrapeScheduleChanges(ctx context.Context) error {
 			Classroom:       record.Classroom,
 			ChangeType:      record.ChangeType,
 			OriginalSubject: record.OriginalSubject,
+			LessonNumber:    record.LessonNumber,
+			Reason:          record.Reason,
+			Subgroup:        record.Subgroup,
+			LessonType:      record.LessonType,
 			IsActive:        true,
 		}
+		change.Fingerprint = changes.Fingerprint(change)
+		if seen[change.Fingerprint] {
+			continue
+		}
+		seen[change.Fingerprint] = true
+		if id, ok := trackedByFingerprint[change.Fingerprint]; ok {
+			seenIDs = append(seenIDs, id)
+			continue
+		}
+
+		if moderate {
+			change.ModerationStatus = schedule.ChangeModerationPending
+		}
+
+		// Привязываем изменение к снапшоту, период которого покрывает дату изменения
+		snapshotID, cached := snapshotsByDate[record.Date]
+		if !cached {
+			snapshotID, err = s.scheduleRepo.FindSnapshotIDForDate(ctx, record.Date)
+			if err != nil {
+				log.Printf("Ошибка поиска снапшота для даты %s: %v", record.Date.Format(clock.DateLayout), err)
+			}
+			snapshotsByDate[record.Date] = snapshotID
+		}
+		change.SnapshotID = snapshotID
 
 		err := s.scheduleRepo.CreateChange(ctx, change)
 		if err != nil {
@@ -385,31 +659,85 @@ func (s *Service) ScrapeScheduleChanges(ctx context.Context) error {
 		createdChanges = append(createdChanges, *change)
 	}
 
+	if err := s.scheduleRepo.MarkChangesSeen(ctx, seenIDs); err != nil {
+		log.Printf("Ошибка обновления присутствия изменений в таблице: %v", err)
+	}
+
+	// Изменения, строки которых исчезли из таблицы, откатываем
+	s.revertVanishedChanges(ctx, tracked, seen)
+
+	// В модерируемом режиме изменения ждут одобрения администратором
+	if moderate {
+		if len(createdChanges) > 0 {
+			log.Printf("Изменения (%d) ожидают одобрения администратором", len(createdChanges))
+		}
+		log.Println("Парсинг изменений в расписании завершен успешно")
+		return nil
+	}
+
 	// 8. Обновление current_schedule
 	// Вызываем Change Detection Service для применения изменений
 	if len(createdChanges) > 0 {
-		if err := s.changeService.ApplyChanges(ctx, createdChanges); err != nil {
+		report, err := s.changeService.ApplyChanges(ctx, createdChanges)
+		if err != nil {
+			// Не возвращаем ошибку: непримененные изменения останутся в статусе pending
+			// и будут применены при следующем запуске, а уведомления уйдут по примененным
 			log.Printf("Ошибка применения изменений: %v", err)
-			// Не возвращаем ошибку, чтобы не прерывать отправку уведомлений
-		} else {
-			log.Println("Изменения успешно применены к актуальному расписанию")
 		}
-	}
 
-	// 9. Отправка уведомлений
-	for _, change := range createdChanges {
-		// Отправляем уведомление через Notification Service
-		if err := s.notificationService.SendScheduleChangeNotification(ctx, &change); err != nil {
-			log.Printf("Ошибка отправки уведомления об изменении: %v", err)
-		}
+		// 9. Отправка уведомлений только по изменениям, которые реально попали в расписание
+		s.notifyAppliedChanges(ctx, report)
 	}
 
 	log.Println("Парсинг изменений в расписании завершен успешно")
 	return nil
 }
 
+// revertVanishedChanges откатывает изменения на сегодня и будущие даты, строк которых
+// больше нет в таблице изменений (например, колледж отменил замену), и уведомляет студентов
+func (s *Service) revertVanishedChanges(ctx context.Context, tracked []schedule.ScheduleChange, seen map[string]bool) {
+	// Пустая таблица скорее означает сбой выгрузки, чем отмену всех изменений
+	if len(seen) == 0 {
+		log.Println("Таблица изменений пуста, откат исчезнувших изменений пропущен")
+		return
+	}
+
+	vanished := changes.VanishedChanges(tracked, seen, clock.Today(s.loc))
+	if len(vanished) == 0 {
+		return
+	}
+
+	log.Printf("Из таблицы изменений исчезло %d изменений, откатываем", len(vanished))
+	reverted := s.changeService.RevertChanges(ctx, vanished)
+	if s.changeService.PublishesEvents() {
+		// Уведомления об откате рассылает подписчик события change.reverted
+		return
+	}
+	s.notifyChanges(ctx, JobNotifyChangeReverted, reverted)
+}
+
+// resumePendingChanges применяет изменения, применение которых было прервано
+// (например, перезапуском сервера), и рассылает по ним уведомления
+func (s *Service) resumePendingChanges(ctx context.Context) {
+	report, err := s.changeService.ApplyPendingChanges(ctx)
+	if err != nil {
+		log.Printf("Ошибка возобновления применения изменений: %v", err)
+	}
+	s.notifyAppliedChanges(ctx, report)
+}
+
+// notifyAppliedChanges отправляет уведомления по примененным изменениям из отчета
+func (s *Service) notifyAppliedChanges(ctx context.Context, report *changes.ApplyReport) {
+	// С outbox уведомления рассылает подписчик события change.applied
+	if report == nil || s.changeService.PublishesEvents() {
+		return
+	}
+
+	s.notifyChanges(ctx, JobNotifyChange, report.AppliedChanges())
+}
+
 // convertToScheduleData преобразует записи расписания в структуру данных для JSON
-func (s *Service) convertToScheduleData(records []gsheet.ScheduleRecord) *schedule.ScheduleData {
+func (s *Service) convertToScheduleData(records []gsheet.ScheduleRecord, periodStart, periodEnd time.Time) *schedule.ScheduleData {
 	// Группируем записи по группам и дням недели
 	groups := make(map[string]map[string][]gsheet.ScheduleRecord)
 
@@ -427,7 +755,7 @@ func (s *Service) convertToScheduleData(records []gsheet.ScheduleRecord) *schedu
 
 	// Преобразуем в формат ScheduleData
 	scheduleData := &schedule.ScheduleData{
-		Period: fmt.Sprintf("%s - %s", time.Now().Format("02.01.2006"), time.Now().Add(7*24*time.Hour).Format("02.01.2006")),
+		Period: fmt.Sprintf("%s - %s", periodStart.Format(clock.DateLayout), periodEnd.Format(clock.DateLayout)),
 		Groups: make(map[string][]schedule.DaySchedule),
 	}
 
@@ -439,13 +767,15 @@ func (s *Service) convertToScheduleData(records []gsheet.ScheduleRecord) *schedu
 
 			for _, record := range dayRecords {
 				lesson := schedule.Lesson{
-					GroupName: record.GroupName,
-					Subject:   record.Subject,
-					Teacher:   record.Teacher,
-					Classroom: record.Classroom,
-					TimeStart: record.TimeStart,
-					TimeEnd:   record.TimeEnd,
-					DayOfWeek: record.DayOfWeek,
+					GroupName:  record.GroupName,
+					Subject:    record.Subject,
+					Teacher:    record.Teacher,
+					Classroom:  record.Classroom,
+					TimeStart:  record.TimeStart,
+					TimeEnd:    record.TimeEnd,
+					DayOfWeek:  record.DayOfWeek,
+					Subgroup:   record.Subgroup,
+					LessonType: record.LessonType,
 				}
 				lessons = append(lessons, lesson)
 			}
@@ -482,14 +812,14 @@ func (s *Service) StartPeriodicScraping(ctx context.Context) {
 		// Создаем таймер для еженедельного запуска (суббота ночью)
 		// Пока используем более частый интервал для тестирования
 		// В production будет 168 часов (неделя)
-		ticker := time.NewTicker(1 * time.Hour) // В production будет time.NewTicker(168 * time.Hour)
+		ticker := time.NewTicker(mainScheduleInterval)
 		defer ticker.Stop()
 
 		for {
 			select {
 			case <-ticker.C:
-				// Проверяем, что сегодня суббота
-				if time.Now().Weekday() == time.Saturday {
+				// Проверяем, что сегодня суббота (по времени колледжа, а не сервера)
+				if clock.Now(s.loc).Weekday() == time.Saturday {
 					if err := s.ScrapeMainSchedule(ctx); err != nil {
 						log.Printf("Ошибка при парсинге основного расписания: %v", err)
 					}
@@ -503,7 +833,7 @@ func (s *Service) StartPeriodicScraping(ctx context.Context) {
 
 	// Горутина для парсинга изменений (каждые 10 минут)
 	go func() {
-		ticker := time.NewTicker(10 * time.Minute)
+		ticker := time.NewTicker(changesInterval)
 		defer ticker.Stop()
 
 		for {
@@ -0,0 +1,107 @@
+package scraper_test
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/Ultrahd-dev/student-schedule-app/backend/internal/changes"
+	"github.com/Ultrahd-dev/student-schedule-app/backend/internal/clock"
+	"github.com/Ultrahd-dev/student-schedule-app/backend/internal/notifications"
+	"github.com/Ultrahd-dev/student-schedule-app/backend/internal/scraper"
+	"github.com/Ultrahd-dev/student-schedule-app/backend/internal/testutil"
+	"github.com/Ultrahd-dev/student-schedule-app/backend/internal/txn"
+	"github.com/google/uuid"
+)
+
+// collegePage страница сайта колледжа со ссылкой на таблицу изменений
+const collegePage = `<html><body>
+<a href="https://docs.google.com/spreadsheets/d/changes-sheet/edit?usp=sharing">Изменения в расписании</a>
+</body></html>`
+
+// TestScrapeApplyNotify проверяет полный путь изменения: таблица изменений на сайте
+// колледжа -> schedule_changes -> current_schedule -> уведомления студентам и преподавателю
+func TestScrapeApplyNotify(t *testing.T) {
+	db := testutil.StartPostgres(t)
+	f := testutil.NewFixtures(t, db)
+	ctx := context.Background()
+	loc := time.UTC
+
+	const group = "ИС-21"
+	date := testutil.NextWeekday(time.Wednesday, loc)
+
+	student, _ := f.User().Group(group).Student()
+	teacher, _ := f.User().FullName("Петров П.П.").Teacher()
+	f.CurrentLesson(group, date, 2, "Математика", "Иванов И.И.", "101")
+
+	changesCSV := strings.Join([]string{
+		"Группа,Дата,Номер пары,Предмет,Преподаватель,Аудитория,Тип изменения",
+		fmt.Sprintf("%s,%s,2,Физика,Петров П.П.,204,замена", group, date.Format(clock.DateLayout)),
+	}, "\n")
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/schedule/", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, collegePage)
+	})
+	mux.HandleFunc("/spreadsheets/d/changes-sheet/export", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/csv; charset=utf-8")
+		fmt.Fprint(w, changesCSV)
+	})
+	server := httptest.NewServer(mux)
+	defer server.Close()
+	testutil.RouteHosts(t, server, "docs.google.com")
+
+	scheduleRepo := f.ScheduleRepo
+	notificationRepo := notifications.NewRepository(db)
+	notificationService := notifications.NewService(f.UserRepo, scheduleRepo, notificationRepo, loc)
+	changeService := changes.NewService(scheduleRepo, txn.NewManager(db), changes.Config{})
+	scraperService := scraper.NewService(scraper.Config{
+		BaseURL:  server.URL + "/schedule/",
+		Timeout:  5 * time.Second,
+		Location: loc,
+	}, scheduleRepo, notificationService, changeService)
+
+	if err := scraperService.ScrapeScheduleChanges(ctx); err != nil {
+		t.Fatalf("ScrapeScheduleChanges: %v", err)
+	}
+
+	// Замена попала в актуальное расписание вместо исходной пары
+	entries, err := scheduleRepo.GetCurrentScheduleForGroup(ctx, group, date)
+	if err != nil {
+		t.Fatalf("GetCurrentScheduleForGroup: %v", err)
+	}
+	if len(entries) != 1 {
+		t.Fatalf("ожидалась 1 пара в актуальном расписании, получено %d", len(entries))
+	}
+	if got := entries[0]; got.Subject != "Физика" || got.Teacher != "Петров П.П." || got.Classroom != "204" || got.SourceType != "change" {
+		t.Errorf("пара не заменена: %+v", got)
+	}
+
+	// Уведомления получили студент группы и преподаватель замены
+	recipients := map[string]uuid.UUID{"студент": student.ID, "преподаватель": teacher.ID}
+	for name, userID := range recipients {
+		unread, err := notificationRepo.GetUnreadNotifications(ctx, userID)
+		if err != nil {
+			t.Fatalf("GetUnreadNotifications(%s): %v", name, err)
+		}
+		if len(unread) != 1 {
+			t.Errorf("%s: ожидалось 1 уведомление, получено %d", name, len(unread))
+		}
+	}
+
+	// Повторный запуск с той же таблицей не создает изменений и уведомлений
+	if err := scraperService.ScrapeScheduleChanges(ctx); err != nil {
+		t.Fatalf("повторный ScrapeScheduleChanges: %v", err)
+	}
+	unread, err := notificationRepo.GetUnreadNotifications(ctx, student.ID)
+	if err != nil {
+		t.Fatalf("GetUnreadNotifications: %v", err)
+	}
+	if len(unread) != 1 {
+		t.Errorf("после повторного парсинга ожидалось 1 уведомление, получено %d", len(unread))
+	}
+}
@@ -0,0 +1,205 @@
+// Package status отдает публичную сводку о свежести данных расписания: когда
+// последний раз успешно загружались основное расписание и изменения, какой
+// период покрывает активный снапшот. По ней сотрудники ИТ-отдела колледжа и
+// студенты проверяют актуальность расписания без доступа администратора.
+package status
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/Ultrahd-dev/student-schedule-app/backend/internal/schedule"
+	"github.com/Ultrahd-dev/student-schedule-app/backend/internal/tenant"
+	"github.com/google/uuid"
+)
+
+// Path путь страницы статуса
+const Path = "/status"
+
+// Состояние данных колледжа
+const (
+	StateOK     = "ok"      // Парсинг выполняется вовремя
+	StateStale  = "stale"   // Парсинг давно не завершался успешно
+	StateNoData = "no_data" // Расписание еще не загружено
+)
+
+// RunHistory журнал успешных запусков периодических задач
+type RunHistory interface {
+	LastRun(ctx context.Context, name string) (*time.Time, error)
+}
+
+// DataSource сводка по актуальному расписанию колледжа из контекста
+type DataSource interface {
+	GetDataStatus(ctx context.Context) (*schedule.DataStatus, error)
+}
+
+// CollegeResolver находит колледж по коду (slug)
+type CollegeResolver interface {
+	CollegeIDBySlug(ctx context.Context, slug string) (uuid.UUID, error)
+}
+
+// Config настройки страницы статуса
+type Config struct {
+	MainScheduleJob string        // Имя задачи парсинга основного расписания в журнале запусков
+	ChangesJob      string        // Имя задачи парсинга изменений в журнале запусков
+	MainMaxAge      time.Duration // Данные устарели, если основное расписание не загружалось дольше
+	ChangesMaxAge   time.Duration // Данные устарели, если изменения не загружались дольше
+	CacheTTL        time.Duration // Время кэширования ответа (страница открыта без аутентификации)
+}
+
+// Report ответ страницы статуса
+type Report struct {
+	State             string     `json:"status"`
+	LastMainScrape    *time.Time `json:"last_main_scrape"`
+	LastChangesScrape *time.Time `json:"last_changes_scrape"`
+	ActiveSnapshot    *Snapshot  `json:"active_snapshot"`
+	GeneratedAt       time.Time  `json:"generated_at"`
+}
+
+// Snapshot активный снапшот расписания
+type Snapshot struct {
+	Name        string    `json:"name"`
+	PeriodStart string    `json:"period_start"` // YYYY-MM-DD
+	PeriodEnd   string    `json:"period_end"`   // YYYY-MM-DD
+	LoadedAt    time.Time `json:"loaded_at"`
+	Groups      int       `json:"groups"`
+	Lessons     int       `json:"lessons"`
+	Changes     int       `json:"changes"`
+}
+
+// Page формирует сводки и кэширует их по колледжам
+type Page struct {
+	config   Config
+	runs     RunHistory
+	data     DataSource
+	colleges CollegeResolver
+
+	mu    sync.Mutex
+	cache map[uuid.UUID]*Report
+}
+
+// NewPage создает страницу статуса
+func NewPage(config Config, runs RunHistory, data DataSource, colleges CollegeResolver) *Page {
+	if config.MainMaxAge <= 0 {
+		config.MainMaxAge = 3 * time.Hour
+	}
+	if config.ChangesMaxAge <= 0 {
+		config.ChangesMaxAge = 30 * time.Minute
+	}
+	if config.CacheTTL <= 0 {
+		config.CacheTTL = 30 * time.Second
+	}
+	return &Page{
+		config:   config,
+		runs:     runs,
+		data:     data,
+		colleges: colleges,
+		cache:    make(map[uuid.UUID]*Report),
+	}
+}
+
+// Report возвращает сводку колледжа из контекста
+func (p *Page) Report(ctx context.Context) (*Report, error) {
+	collegeID := tenant.CollegeID(ctx)
+	now := time.Now()
+
+	p.mu.Lock()
+	cached := p.cache[collegeID]
+	p.mu.Unlock()
+	if cached != nil && now.Sub(cached.GeneratedAt) < p.config.CacheTTL {
+		return cached, nil
+	}
+
+	report := &Report{GeneratedAt: now}
+	var err error
+	if report.LastMainScrape, err = p.runs.LastRun(ctx, tenant.Scoped(ctx, p.config.MainScheduleJob)); err != nil {
+		return nil, err
+	}
+	if report.LastChangesScrape, err = p.runs.LastRun(ctx, tenant.Scoped(ctx, p.config.ChangesJob)); err != nil {
+		return nil, err
+	}
+
+	data, err := p.data.GetDataStatus(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if data != nil {
+		report.ActiveSnapshot = &Snapshot{
+			Name:        data.SnapshotName,
+			PeriodStart: data.PeriodStart.Format("2006-01-02"),
+			PeriodEnd:   data.PeriodEnd.Format("2006-01-02"),
+			LoadedAt:    data.LoadedAt,
+			Groups:      data.Groups,
+			Lessons:     data.Lessons,
+			Changes:     data.Changes,
+		}
+	}
+
+	switch {
+	case data == nil:
+		report.State = StateNoData
+	case olderThan(report.LastMainScrape, now, p.config.MainMaxAge),
+		olderThan(report.LastChangesScrape, now, p.config.ChangesMaxAge):
+		report.State = StateStale
+	default:
+		report.State = StateOK
+	}
+
+	p.mu.Lock()
+	p.cache[collegeID] = report
+	p.mu.Unlock()
+	return report, nil
+}
+
+// olderThan проверяет, что запуск не выполнялся или выполнен раньше now-maxAge
+func olderThan(run *time.Time, now time.Time, maxAge time.Duration) bool {
+	return run == nil || now.Sub(*run) > maxAge
+}
+
+// Handler отдает сводку в JSON. Колледж выбирается параметром ?college=<код>
+// или заголовком X-College, по умолчанию - колледж по умолчанию.
+// Регистрируется на пути Path.
+func (p *Page) Handler() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet && r.Method != http.MethodHead {
+			http.Error(w, "метод не поддерживается", http.StatusMethodNotAllowed)
+			return
+		}
+
+		ctx := r.Context()
+		slug := r.URL.Query().Get("college")
+		if slug == "" {
+			slug = r.Header.Get("X-College")
+		}
+		if slug != "" {
+			collegeID, err := p.colleges.CollegeIDBySlug(ctx, slug)
+			if errors.Is(err, tenant.ErrUnknownCollege) {
+				http.Error(w, "колледж не найден", http.StatusNotFound)
+				return
+			}
+			if err != nil {
+				log.Printf("Ошибка поиска колледжа %s для страницы статуса: %v", slug, err)
+				http.Error(w, "внутренняя ошибка", http.StatusInternalServerError)
+				return
+			}
+			ctx = tenant.WithCollege(ctx, collegeID)
+		}
+
+		report, err := p.Report(ctx)
+		if err != nil {
+			log.Printf("Ошибка формирования страницы статуса: %v", err)
+			http.Error(w, "внутренняя ошибка", http.StatusInternalServerError)
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		w.Header().Set("Cache-Control", fmt.Sprintf("public, max-age=%d", int(p.config.CacheTTL.Seconds())))
+		json.NewEncoder(w).Encode(report)
+	})
+}
@@ -0,0 +1,45 @@
+package storage
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"time"
+)
+
+// LifecycleRule правило хранения: объекты с ключами, начинающимися с Prefix,
+// удаляются, когда с их последнего изменения прошло MaxAge
+type LifecycleRule struct {
+	Prefix string
+	MaxAge time.Duration
+}
+
+// ApplyLifecycle удаляет устаревшие по правилам rules объекты и возвращает их количество.
+// Правила применяются одинаково для локального хранилища и S3, поэтому
+// настраивать правила жизненного цикла бакета не нужно.
+func ApplyLifecycle(ctx context.Context, store Storage, rules []LifecycleRule, now time.Time) (int, error) {
+	deleted := 0
+	for _, rule := range rules {
+		if rule.Prefix == "" || rule.MaxAge <= 0 {
+			continue // Правило без префикса удалило бы аватары и вложения
+		}
+
+		objects, err := store.List(ctx, rule.Prefix)
+		if err != nil {
+			return deleted, fmt.Errorf("ошибка получения объектов %s: %w", rule.Prefix, err)
+		}
+		for _, object := range objects {
+			if now.Sub(object.ModTime) < rule.MaxAge {
+				continue
+			}
+			if err := store.Delete(ctx, object.Key); err != nil {
+				return deleted, err
+			}
+			deleted++
+		}
+		if len(objects) > 0 {
+			log.Printf("Правило хранения %s (%s): проверено %d объектов", rule.Prefix, rule.MaxAge, len(objects))
+		}
+	}
+	return deleted, nil
+}
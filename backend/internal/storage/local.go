@@ -0,0 +1,214 @@
+package storage
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// FilesPath префикс HTTP-пути, по которому Local раздает файлы по подписанным ссылкам
+const FilesPath = "/files/"
+
+// Local хранит объекты в локальном каталоге и раздает их по HTTP
+// по подписанным ссылкам (см. Handler)
+type Local struct {
+	dir     string // Корневой каталог хранилища
+	baseURL string // Внешний адрес HTTP-сервера с Handler ("http://localhost:8081")
+	secret  []byte // Ключ подписи ссылок
+}
+
+// NewLocal создает локальное хранилище в каталоге dir (каталог создается при необходимости)
+func NewLocal(dir, baseURL, secret string) (*Local, error) {
+	if secret == "" {
+		return nil, fmt.Errorf("не задан ключ подписи ссылок")
+	}
+	if err := os.MkdirAll(dir, 0o750); err != nil {
+		return nil, fmt.Errorf("ошибка создания каталога хранилища: %w", err)
+	}
+
+	return &Local{
+		dir:     dir,
+		baseURL: strings.TrimRight(baseURL, "/"),
+		secret:  []byte(secret),
+	}, nil
+}
+
+// Put сохраняет объект во временный файл и атомарно переименовывает его
+func (l *Local) Put(ctx context.Context, key string, r io.Reader) error {
+	filename, err := l.path(key)
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(filename), 0o750); err != nil {
+		return fmt.Errorf("ошибка создания каталога: %w", err)
+	}
+
+	tmp, err := os.CreateTemp(filepath.Dir(filename), ".upload-*")
+	if err != nil {
+		return fmt.Errorf("ошибка создания временного файла: %w", err)
+	}
+	defer os.Remove(tmp.Name())
+
+	if _, err := io.Copy(tmp, r); err != nil {
+		tmp.Close()
+		return fmt.Errorf("ошибка записи файла: %w", err)
+	}
+	if err := tmp.Close(); err != nil {
+		return fmt.Errorf("ошибка записи файла: %w", err)
+	}
+
+	if err := os.Rename(tmp.Name(), filename); err != nil {
+		return fmt.Errorf("ошибка сохранения файла: %w", err)
+	}
+	return nil
+}
+
+// Open открывает объект на чтение
+func (l *Local) Open(ctx context.Context, key string) (io.ReadCloser, error) {
+	filename, err := l.path(key)
+	if err != nil {
+		return nil, err
+	}
+
+	file, err := os.Open(filename)
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, ErrNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("ошибка открытия файла: %w", err)
+	}
+	return file, nil
+}
+
+// Delete удаляет объект
+func (l *Local) Delete(ctx context.Context, key string) error {
+	filename, err := l.path(key)
+	if err != nil {
+		return err
+	}
+
+	if err := os.Remove(filename); err != nil && !errors.Is(err, fs.ErrNotExist) {
+		return fmt.Errorf("ошибка удаления файла: %w", err)
+	}
+	return nil
+}
+
+// List обходит каталог хранилища и возвращает объекты с ключами, начинающимися с prefix
+func (l *Local) List(ctx context.Context, prefix string) ([]Object, error) {
+	var objects []Object
+	err := filepath.WalkDir(l.dir, func(filename string, entry fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if entry.IsDir() || strings.HasPrefix(entry.Name(), ".upload-") {
+			return nil
+		}
+
+		rel, err := filepath.Rel(l.dir, filename)
+		if err != nil {
+			return err
+		}
+		key := filepath.ToSlash(rel)
+		if !strings.HasPrefix(key, prefix) {
+			return nil
+		}
+
+		info, err := entry.Info()
+		if err != nil {
+			return err
+		}
+		objects = append(objects, Object{Key: key, Size: info.Size(), ModTime: info.ModTime()})
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("ошибка обхода каталога хранилища: %w", err)
+	}
+	return objects, nil
+}
+
+// SignedURL возвращает ссылку вида <baseURL>/files/<key>?expires=<unix>&signature=<hmac>
+func (l *Local) SignedURL(key string, ttl time.Duration) (string, error) {
+	if err := ValidateKey(key); err != nil {
+		return "", err
+	}
+
+	expires := strconv.FormatInt(time.Now().Add(ttl).Unix(), 10)
+	query := url.Values{
+		"expires":   {expires},
+		"signature": {l.sign(key, expires)},
+	}
+	return l.baseURL + FilesPath + key + "?" + query.Encode(), nil
+}
+
+// Handler раздает объекты по подписанным ссылкам.
+// Регистрируется на пути FilesPath.
+func (l *Local) Handler() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet && r.Method != http.MethodHead {
+			http.Error(w, "Метод не поддерживается", http.StatusMethodNotAllowed)
+			return
+		}
+
+		key := strings.TrimPrefix(r.URL.Path, FilesPath)
+		expires := r.URL.Query().Get("expires")
+		signature := r.URL.Query().Get("signature")
+
+		unix, err := strconv.ParseInt(expires, 10, 64)
+		if err != nil || time.Now().Unix() > unix {
+			http.Error(w, "Ссылка недействительна или устарела", http.StatusForbidden)
+			return
+		}
+		if !hmac.Equal([]byte(signature), []byte(l.sign(key, expires))) {
+			http.Error(w, "Неверная подпись ссылки", http.StatusForbidden)
+			return
+		}
+
+		filename, err := l.path(key)
+		if err != nil {
+			http.Error(w, "Файл не найден", http.StatusNotFound)
+			return
+		}
+		file, err := os.Open(filename)
+		if err != nil {
+			http.Error(w, "Файл не найден", http.StatusNotFound)
+			return
+		}
+		defer file.Close()
+
+		info, err := file.Stat()
+		if err != nil {
+			http.Error(w, "Ошибка чтения файла", http.StatusInternalServerError)
+			return
+		}
+
+		w.Header().Set("Cache-Control", "private, max-age=300")
+		http.ServeContent(w, r, filepath.Base(filename), info.ModTime(), file)
+	})
+}
+
+// sign вычисляет подпись ключа и срока действия ссылки
+func (l *Local) sign(key, expires string) string {
+	mac := hmac.New(sha256.New, l.secret)
+	mac.Write([]byte(key + "\n" + expires))
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+// path возвращает путь к файлу объекта
+func (l *Local) path(key string) (string, error) {
+	if err := ValidateKey(key); err != nil {
+		return "", err
+	}
+	return filepath.Join(l.dir, filepath.FromSlash(key)), nil
+}
@@ -0,0 +1,369 @@
+package storage
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// maxPresignTTL максимальный срок действия подписанной ссылки S3 (7 дней)
+const maxPresignTTL = 7 * 24 * time.Hour
+
+// S3Config настройки S3-совместимого хранилища
+type S3Config struct {
+	Endpoint        string // Адрес API: "https://s3.amazonaws.com", "http://minio:9000"
+	PublicEndpoint  string // Адрес для ссылок на скачивание, если клиенты видят хранилище по другому адресу
+	Region          string
+	Bucket          string
+	AccessKeyID     string
+	SecretAccessKey string
+	// PathStyle адресует бакет в пути (endpoint/bucket/key), а не в имени хоста
+	// (bucket.endpoint/key); нужен для MinIO
+	PathStyle bool
+	Timeout   time.Duration
+}
+
+// S3 хранит объекты в бакете S3-совместимого хранилища (Amazon S3, MinIO, Yandex
+// Object Storage). Запросы подписываются AWS Signature Version 4, ссылки на
+// скачивание - предподписанные URL, которые клиенты открывают напрямую в хранилище.
+type S3 struct {
+	config         S3Config
+	endpoint       *url.URL
+	publicEndpoint *url.URL
+	httpClient     *http.Client
+}
+
+// NewS3 создает клиент S3-совместимого хранилища
+func NewS3(config S3Config) (*S3, error) {
+	if config.Bucket == "" || config.AccessKeyID == "" || config.SecretAccessKey == "" {
+		return nil, fmt.Errorf("не заданы бакет или ключи доступа S3")
+	}
+	if config.Region == "" {
+		config.Region = "us-east-1"
+	}
+	if config.Timeout <= 0 {
+		config.Timeout = time.Minute
+	}
+
+	endpoint, err := parseEndpoint(config.Endpoint)
+	if err != nil {
+		return nil, err
+	}
+	publicEndpoint := endpoint
+	if config.PublicEndpoint != "" {
+		if publicEndpoint, err = parseEndpoint(config.PublicEndpoint); err != nil {
+			return nil, err
+		}
+	}
+
+	return &S3{
+		config:         config,
+		endpoint:       endpoint,
+		publicEndpoint: publicEndpoint,
+		httpClient:     &http.Client{Timeout: config.Timeout},
+	}, nil
+}
+
+// parseEndpoint проверяет адрес API хранилища
+func parseEndpoint(raw string) (*url.URL, error) {
+	endpoint, err := url.Parse(strings.TrimRight(raw, "/"))
+	if err != nil || (endpoint.Scheme != "http" && endpoint.Scheme != "https") || endpoint.Host == "" {
+		return nil, fmt.Errorf("некорректный адрес S3 %q", raw)
+	}
+	return endpoint, nil
+}
+
+// Put загружает объект. Содержимое читается в память: S3 требует длину
+// и хеш содержимого до начала загрузки.
+func (s *S3) Put(ctx context.Context, key string, r io.Reader) error {
+	if err := ValidateKey(key); err != nil {
+		return err
+	}
+	data, err := io.ReadAll(r)
+	if err != nil {
+		return fmt.Errorf("ошибка чтения объекта: %w", err)
+	}
+
+	resp, err := s.do(ctx, http.MethodPut, key, nil, data)
+	if err != nil {
+		return fmt.Errorf("ошибка загрузки объекта %s: %w", key, err)
+	}
+	resp.Body.Close()
+	return nil
+}
+
+// Open скачивает объект
+func (s *S3) Open(ctx context.Context, key string) (io.ReadCloser, error) {
+	if err := ValidateKey(key); err != nil {
+		return nil, err
+	}
+
+	resp, err := s.do(ctx, http.MethodGet, key, nil, nil)
+	if err != nil {
+		if isS3NotFound(err) {
+			return nil, ErrNotFound
+		}
+		return nil, fmt.Errorf("ошибка скачивания объекта %s: %w", key, err)
+	}
+	return resp.Body, nil
+}
+
+// Delete удаляет объект
+func (s *S3) Delete(ctx context.Context, key string) error {
+	if err := ValidateKey(key); err != nil {
+		return err
+	}
+
+	resp, err := s.do(ctx, http.MethodDelete, key, nil, nil)
+	if err != nil {
+		if isS3NotFound(err) {
+			return nil
+		}
+		return fmt.Errorf("ошибка удаления объекта %s: %w", key, err)
+	}
+	resp.Body.Close()
+	return nil
+}
+
+// SignedURL возвращает предподписанную ссылку на скачивание из хранилища
+// (не более 7 дней - ограничение S3)
+func (s *S3) SignedURL(key string, ttl time.Duration) (string, error) {
+	if err := ValidateKey(key); err != nil {
+		return "", err
+	}
+	ttl = min(ttl, maxPresignTTL)
+
+	now := time.Now().UTC()
+	target := s.objectURL(s.publicEndpoint, key)
+	query := url.Values{
+		"X-Amz-Algorithm":     {"AWS4-HMAC-SHA256"},
+		"X-Amz-Credential":    {s.config.AccessKeyID + "/" + s.scope(now)},
+		"X-Amz-Date":          {now.Format(amzDateLayout)},
+		"X-Amz-Expires":       {strconv.Itoa(int(ttl.Seconds()))},
+		"X-Amz-SignedHeaders": {"host"},
+	}
+	target.RawQuery = canonicalQuery(query)
+
+	headers := http.Header{}
+	headers.Set("Host", target.Host)
+	signature := s.signature(http.MethodGet, target, headers, unsignedPayload, now)
+	return target.String() + "&X-Amz-Signature=" + signature, nil
+}
+
+// List возвращает объекты с ключами, начинающимися с prefix (ListObjectsV2)
+func (s *S3) List(ctx context.Context, prefix string) ([]Object, error) {
+	var objects []Object
+	query := url.Values{"list-type": {"2"}, "prefix": {prefix}}
+	for {
+		resp, err := s.do(ctx, http.MethodGet, "", query, nil)
+		if err != nil {
+			return nil, fmt.Errorf("ошибка получения списка объектов: %w", err)
+		}
+
+		var result struct {
+			Contents []struct {
+				Key          string    `xml:"Key"`
+				Size         int64     `xml:"Size"`
+				LastModified time.Time `xml:"LastModified"`
+			} `xml:"Contents"`
+			IsTruncated           bool   `xml:"IsTruncated"`
+			NextContinuationToken string `xml:"NextContinuationToken"`
+		}
+		err = xml.NewDecoder(resp.Body).Decode(&result)
+		resp.Body.Close()
+		if err != nil {
+			return nil, fmt.Errorf("ошибка разбора списка объектов: %w", err)
+		}
+
+		for _, item := range result.Contents {
+			objects = append(objects, Object{Key: item.Key, Size: item.Size, ModTime: item.LastModified})
+		}
+		if !result.IsTruncated || result.NextContinuationToken == "" {
+			return objects, nil
+		}
+		query.Set("continuation-token", result.NextContinuationToken)
+	}
+}
+
+// s3Error ответ хранилища с кодом ошибки
+type s3Error struct {
+	status  int
+	code    string
+	message string
+}
+
+func (e *s3Error) Error() string {
+	return fmt.Sprintf("S3 вернул статус %d: %s %s", e.status, e.code, e.message)
+}
+
+// isS3NotFound проверяет, что хранилище ответило "объект не найден"
+func isS3NotFound(err error) bool {
+	s3err, ok := err.(*s3Error)
+	return ok && s3err.status == http.StatusNotFound
+}
+
+// do выполняет подписанный запрос к объекту key (пустой key - к бакету).
+// Ответ с кодом ошибки возвращается как *s3Error.
+func (s *S3) do(ctx context.Context, method, key string, query url.Values, body []byte) (*http.Response, error) {
+	target := s.objectURL(s.endpoint, key)
+	target.RawQuery = canonicalQuery(query)
+
+	req, err := http.NewRequestWithContext(ctx, method, target.String(), bytes.NewReader(body))
+	if err != nil {
+		return nil, err
+	}
+	req.ContentLength = int64(len(body))
+	if body == nil {
+		req.Body = http.NoBody
+	}
+
+	now := time.Now().UTC()
+	payloadHash := sha256.Sum256(body)
+	req.Header.Set("Host", target.Host)
+	req.Header.Set("X-Amz-Date", now.Format(amzDateLayout))
+	req.Header.Set("X-Amz-Content-Sha256", hex.EncodeToString(payloadHash[:]))
+	signature := s.signature(method, target, req.Header, hex.EncodeToString(payloadHash[:]), now)
+	req.Header.Set("Authorization", fmt.Sprintf("AWS4-HMAC-SHA256 Credential=%s/%s, SignedHeaders=%s, Signature=%s",
+		s.config.AccessKeyID, s.scope(now), signedHeaders(req.Header), signature))
+	req.Header.Del("Host") // Go берет заголовок Host из URL
+
+	resp, err := s.httpClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		defer resp.Body.Close()
+		var details struct {
+			Code    string `xml:"Code"`
+			Message string `xml:"Message"`
+		}
+		_ = xml.NewDecoder(io.LimitReader(resp.Body, 4096)).Decode(&details)
+		return nil, &s3Error{status: resp.StatusCode, code: details.Code, message: details.Message}
+	}
+	return resp, nil
+}
+
+// objectURL возвращает адрес объекта key в бакете
+func (s *S3) objectURL(endpoint *url.URL, key string) *url.URL {
+	target := *endpoint
+	path := "/" + key
+	if s.config.PathStyle {
+		path = "/" + s.config.Bucket + path
+	} else {
+		target.Host = s.config.Bucket + "." + target.Host
+	}
+	target.Path = endpoint.Path + path
+	target.RawPath = endpoint.Path + encodePath(path)
+	return &target
+}
+
+// Подпись запросов AWS Signature Version 4
+const (
+	amzDateLayout   = "20060102T150405Z"
+	unsignedPayload = "UNSIGNED-PAYLOAD"
+)
+
+// scope возвращает область действия ключа подписи: дата/регион/s3/aws4_request
+func (s *S3) scope(now time.Time) string {
+	return now.Format("20060102") + "/" + s.config.Region + "/s3/aws4_request"
+}
+
+// signature вычисляет подпись запроса по заголовкам headers (подписываются все)
+func (s *S3) signature(method string, target *url.URL, headers http.Header, payloadHash string, now time.Time) string {
+	names := strings.Split(signedHeaders(headers), ";")
+	var canonicalHeaders strings.Builder
+	for _, name := range names {
+		fmt.Fprintf(&canonicalHeaders, "%s:%s\n", name, strings.TrimSpace(headers.Get(name)))
+	}
+
+	canonicalRequest := strings.Join([]string{
+		method,
+		target.EscapedPath(),
+		target.RawQuery,
+		canonicalHeaders.String(),
+		strings.Join(names, ";"),
+		payloadHash,
+	}, "\n")
+	requestHash := sha256.Sum256([]byte(canonicalRequest))
+
+	stringToSign := strings.Join([]string{
+		"AWS4-HMAC-SHA256",
+		now.Format(amzDateLayout),
+		s.scope(now),
+		hex.EncodeToString(requestHash[:]),
+	}, "\n")
+
+	key := hmacSHA256([]byte("AWS4"+s.config.SecretAccessKey), now.Format("20060102"))
+	key = hmacSHA256(key, s.config.Region)
+	key = hmacSHA256(key, "s3")
+	key = hmacSHA256(key, "aws4_request")
+	return hex.EncodeToString(hmacSHA256(key, stringToSign))
+}
+
+// signedHeaders возвращает отсортированные имена заголовков в нижнем регистре через ";"
+func signedHeaders(headers http.Header) string {
+	names := make([]string, 0, len(headers))
+	for name := range headers {
+		names = append(names, strings.ToLower(name))
+	}
+	sort.Strings(names)
+	return strings.Join(names, ";")
+}
+
+func hmacSHA256(key []byte, data string) []byte {
+	mac := hmac.New(sha256.New, key)
+	mac.Write([]byte(data))
+	return mac.Sum(nil)
+}
+
+// canonicalQuery кодирует параметры запроса, отсортированные по имени, как требует SigV4
+func canonicalQuery(query url.Values) string {
+	names := make([]string, 0, len(query))
+	for name := range query {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	var parts []string
+	for _, name := range names {
+		for _, value := range query[name] {
+			parts = append(parts, encodeURIComponent(name)+"="+encodeURIComponent(value))
+		}
+	}
+	return strings.Join(parts, "&")
+}
+
+// encodePath кодирует путь объекта, сохраняя разделители "/"
+func encodePath(path string) string {
+	segments := strings.Split(path, "/")
+	for i, segment := range segments {
+		segments[i] = encodeURIComponent(segment)
+	}
+	return strings.Join(segments, "/")
+}
+
+// encodeURIComponent кодирует все символы, кроме A-Z a-z 0-9 - _ . ~ (RFC 3986)
+func encodeURIComponent(s string) string {
+	var b strings.Builder
+	for i := 0; i < len(s); i++ {
+		c := s[i]
+		if 'A' <= c && c <= 'Z' || 'a' <= c && c <= 'z' || '0' <= c && c <= '9' ||
+			c == '-' || c == '_' || c == '.' || c == '~' {
+			b.WriteByte(c)
+			continue
+		}
+		fmt.Fprintf(&b, "%%%02X", c)
+	}
+	return b.String()
+}
@@ -0,0 +1,46 @@
+// Package storage предоставляет хранилище файлов: аватары и вложения пользователей,
+// исходные данные парсинга и сформированные отчеты (PDF)
+package storage
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"path"
+	"strings"
+	"time"
+)
+
+// ErrNotFound объект с таким ключом отсутствует в хранилище
+var ErrNotFound = errors.New("объект не найден")
+
+// Storage хранилище объектов по ключу ("avatars/<id>.png").
+// Реализации: локальный каталог (Local) и S3-совместимое хранилище (S3, MinIO).
+type Storage interface {
+	// Put сохраняет объект, перезаписывая существующий
+	Put(ctx context.Context, key string, r io.Reader) error
+	// Open открывает объект на чтение; ErrNotFound, если объекта нет
+	Open(ctx context.Context, key string) (io.ReadCloser, error)
+	// Delete удаляет объект; отсутствие объекта не считается ошибкой
+	Delete(ctx context.Context, key string) error
+	// SignedURL возвращает подписанную ссылку на скачивание, действующую ttl
+	SignedURL(key string, ttl time.Duration) (string, error)
+	// List возвращает объекты, ключи которых начинаются с prefix
+	List(ctx context.Context, prefix string) ([]Object, error)
+}
+
+// Object описание объекта в хранилище
+type Object struct {
+	Key     string
+	Size    int64
+	ModTime time.Time
+}
+
+// ValidateKey проверяет, что ключ - относительный путь без выхода за пределы хранилища
+func ValidateKey(key string) error {
+	if key == "" || strings.HasPrefix(key, "/") || path.Clean(key) != key || strings.HasPrefix(key, "..") {
+		return fmt.Errorf("недопустимый ключ объекта %q", key)
+	}
+	return nil
+}
@@ -0,0 +1,19 @@
+package tenant
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// College колледж и источники его расписания
+type College struct {
+	ID               uuid.UUID `db:"id"`
+	Slug             string    `db:"slug"` // Код колледжа в метаданных запросов
+	Name             string    `db:"name"`
+	BaseURL          string    `db:"base_url"`           // Сайт колледжа; пусто - из конфигурации scraper
+	MainScheduleGIDs []int64   `db:"main_schedule_gids"` // Листы основного расписания; пусто - из конфигурации
+	ChangesGID       *int64    `db:"changes_gid"`        // Лист изменений; nil - из конфигурации
+	IsActive         bool      `db:"is_active"`
+	CreatedAt        time.Time `db:"created_at"`
+}
@@ -0,0 +1,70 @@
+package tenant
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"sync"
+
+	"github.com/google/uuid"
+)
+
+// ErrUnknownCollege означает, что колледжа с таким кодом нет или он отключен
+var ErrUnknownCollege = errors.New("колледж не найден")
+
+// Registry определяет колледж по коду из метаданных запроса.
+// Коды кэшируются: колледжи добавляются редко, а код нужен почти каждому запросу без токена.
+type Registry struct {
+	repo *Repository
+
+	mu     sync.RWMutex
+	bySlug map[string]uuid.UUID
+}
+
+// NewRegistry создает реестр колледжей
+func NewRegistry(repo *Repository) *Registry {
+	return &Registry{repo: repo, bySlug: make(map[string]uuid.UUID)}
+}
+
+// CollegeIDBySlug возвращает ID активного колледжа по коду
+func (r *Registry) CollegeIDBySlug(ctx context.Context, slug string) (uuid.UUID, error) {
+	r.mu.RLock()
+	id, ok := r.bySlug[slug]
+	r.mu.RUnlock()
+	if ok {
+		return id, nil
+	}
+
+	college, err := r.repo.GetCollegeBySlug(ctx, slug)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return uuid.Nil, fmt.Errorf("%w: %s", ErrUnknownCollege, slug)
+		}
+		return uuid.Nil, err
+	}
+	if !college.IsActive {
+		return uuid.Nil, fmt.Errorf("%w: %s", ErrUnknownCollege, slug)
+	}
+
+	r.mu.Lock()
+	r.bySlug[slug] = college.ID
+	r.mu.Unlock()
+	return college.ID, nil
+}
+
+// ActiveCollegeIDs возвращает ID активных колледжей, колледж по умолчанию первым
+func (r *Registry) ActiveCollegeIDs(ctx context.Context) ([]uuid.UUID, error) {
+	colleges, err := r.repo.ListColleges(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	var ids []uuid.UUID
+	for _, college := range colleges {
+		if college.IsActive {
+			ids = append(ids, college.ID)
+		}
+	}
+	return ids, nil
+}
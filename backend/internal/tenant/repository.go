@@ -0,0 +1,116 @@
+package tenant
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+
+	"github.com/lib/pq"
+)
+
+// collegeColumns колонки колледжа в порядке сканирования scanCollege
+const collegeColumns = `id, slug, name, base_url, main_schedule_gids, changes_gid, is_active, created_at`
+
+// Repository предоставляет доступ к хранению колледжей
+type Repository struct {
+	db *sql.DB
+}
+
+// NewRepository создает новый репозиторий колледжей
+func NewRepository(db *sql.DB) *Repository {
+	return &Repository{db: db}
+}
+
+// CreateCollege добавляет колледж
+func (r *Repository) CreateCollege(ctx context.Context, college *College) error {
+	query := `
+		INSERT INTO colleges (id, slug, name, base_url, main_schedule_gids, changes_gid, is_active)
+		VALUES ($1, $2, $3, $4, $5, $6, $7)
+		RETURNING created_at`
+
+	gids := college.MainScheduleGIDs
+	if gids == nil {
+		gids = []int64{} // Колонка NOT NULL: пустой список вместо NULL
+	}
+
+	err := r.db.QueryRowContext(ctx, query,
+		college.ID,
+		college.Slug,
+		college.Name,
+		college.BaseURL,
+		pq.Array(gids),
+		college.ChangesGID,
+		college.IsActive).
+		Scan(&college.CreatedAt)
+	if err != nil {
+		return fmt.Errorf("failed to create college: %w", err)
+	}
+
+	return nil
+}
+
+// ListColleges возвращает все колледжи, колледж по умолчанию первым
+func (r *Repository) ListColleges(ctx context.Context) ([]College, error) {
+	query := `SELECT ` + collegeColumns + ` FROM colleges ORDER BY created_at, slug`
+
+	rows, err := r.db.QueryContext(ctx, query)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list colleges: %w", err)
+	}
+	defer rows.Close()
+
+	var colleges []College
+	for rows.Next() {
+		college, err := scanCollege(rows)
+		if err != nil {
+			return nil, err
+		}
+		colleges = append(colleges, *college)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating rows: %w", err)
+	}
+
+	return colleges, nil
+}
+
+// GetCollegeBySlug получает колледж по коду
+func (r *Repository) GetCollegeBySlug(ctx context.Context, slug string) (*College, error) {
+	query := `SELECT ` + collegeColumns + ` FROM colleges WHERE slug = $1`
+
+	college, err := scanCollege(r.db.QueryRowContext(ctx, query, slug))
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, fmt.Errorf("college %q not found: %w", slug, err)
+		}
+		return nil, err
+	}
+	return college, nil
+}
+
+// rowScanner строка результата (*sql.Row или *sql.Rows)
+type rowScanner interface {
+	Scan(dest ...interface{}) error
+}
+
+// scanCollege сканирует колледж, выбранный с колонками collegeColumns
+func scanCollege(row rowScanner) (*College, error) {
+	college := &College{}
+	err := row.Scan(
+		&college.ID,
+		&college.Slug,
+		&college.Name,
+		&college.BaseURL,
+		pq.Array(&college.MainScheduleGIDs),
+		&college.ChangesGID,
+		&college.IsActive,
+		&college.CreatedAt,
+	)
+	if err == sql.ErrNoRows {
+		return nil, err
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to scan college: %w", err)
+	}
+	return college, nil
+}
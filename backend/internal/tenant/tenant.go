@@ -0,0 +1,45 @@
+// Package tenant позволяет одному развертыванию обслуживать несколько колледжей.
+// Колледж текущего запроса хранится в контексте (WithCollege): его определяет
+// gRPC interceptor по токену или метаданным x-college, а фоновые задачи - по
+// колледжу, который их создал. Репозитории ограничивают запросы колледжем
+// из контекста, поэтому сервисы не передают его явно.
+package tenant
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+)
+
+// DefaultCollegeID колледж по умолчанию: ему принадлежат данные, созданные
+// до появления нескольких колледжей, и запросы, в которых колледж не указан
+var DefaultCollegeID = uuid.MustParse("00000000-0000-0000-0000-000000000001")
+
+// MetadataKey ключ метаданных gRPC с кодом колледжа (slug) для запросов без токена
+const MetadataKey = "x-college"
+
+type contextKey struct{}
+
+// WithCollege возвращает контекст с колледжем collegeID
+func WithCollege(ctx context.Context, collegeID uuid.UUID) context.Context {
+	return context.WithValue(ctx, contextKey{}, collegeID)
+}
+
+// CollegeID возвращает колледж из контекста или колледж по умолчанию
+func CollegeID(ctx context.Context) uuid.UUID {
+	if id, ok := ctx.Value(contextKey{}).(uuid.UUID); ok && id != uuid.Nil {
+		return id
+	}
+	return DefaultCollegeID
+}
+
+// Scoped возвращает имя name (блокировки, задачи) для колледжа из контекста.
+// Для колледжа по умолчанию имя не меняется, чтобы развертывание с одним
+// колледжем продолжало использовать прежние блокировки.
+func Scoped(ctx context.Context, name string) string {
+	id := CollegeID(ctx)
+	if id == DefaultCollegeID {
+		return name
+	}
+	return name + ":" + id.String()
+}
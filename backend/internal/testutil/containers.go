@@ -0,0 +1,200 @@
+// Package testutil содержит общие средства интеграционных тестов:
+// запуск Postgres и Redis в контейнерах, применение миграций
+// и построители тестовых данных (пользователи, снапшоты, изменения).
+//
+// Контейнеры запускаются через docker CLI. Если docker недоступен или тесты
+// запущены с -short, интеграционные тесты пропускаются. Вместо контейнеров
+// можно указать готовые экземпляры через переменные окружения
+// TESTUTIL_POSTGRES_DSN и TESTUTIL_REDIS_ADDR.
+package testutil
+
+import (
+	"bufio"
+	"context"
+	"database/sql"
+	"fmt"
+	"net"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"testing"
+	"time"
+
+	_ "github.com/lib/pq"
+	"github.com/pressly/goose/v3"
+)
+
+// Образы контейнеров и время ожидания их готовности
+const (
+	PostgresImage = "postgres:16-alpine"
+	RedisImage    = "redis:7-alpine"
+
+	startupTimeout = time.Minute
+)
+
+// Переменные окружения с адресами уже запущенных экземпляров
+const (
+	envPostgresDSN = "TESTUTIL_POSTGRES_DSN"
+	envRedisAddr   = "TESTUTIL_REDIS_ADDR"
+)
+
+// Container запущенный тестовый контейнер
+type Container struct {
+	ID   string
+	Addr string // host:port опубликованного порта
+}
+
+// StartPostgres запускает Postgres в контейнере, применяет миграции
+// и возвращает подключение к базе. Контейнер удаляется по завершении теста.
+func StartPostgres(t testing.TB) *sql.DB {
+	t.Helper()
+
+	dsn := os.Getenv(envPostgresDSN)
+	if dsn == "" {
+		container := runContainer(t, PostgresImage, "5432/tcp",
+			"POSTGRES_USER=test", "POSTGRES_PASSWORD=test", "POSTGRES_DB=schedule_test")
+		dsn = fmt.Sprintf("postgres://test:test@%s/schedule_test?sslmode=disable", container.Addr)
+	}
+
+	db, err := sql.Open("postgres", dsn)
+	if err != nil {
+		t.Fatalf("Ошибка подключения к тестовой базе: %v", err)
+	}
+	t.Cleanup(func() { db.Close() })
+
+	// Postgres принимает соединения не сразу после запуска контейнера
+	waitFor(t, "Postgres", func(ctx context.Context) error {
+		return db.PingContext(ctx)
+	})
+
+	ApplyMigrations(t, db)
+	return db
+}
+
+// ApplyMigrations применяет миграции из каталога backend/migrations
+func ApplyMigrations(t testing.TB, db *sql.DB) {
+	t.Helper()
+
+	goose.SetLogger(goose.NopLogger())
+	if err := goose.SetDialect("postgres"); err != nil {
+		t.Fatalf("Ошибка настройки goose: %v", err)
+	}
+	if err := goose.Up(db, MigrationsDir()); err != nil {
+		t.Fatalf("Ошибка применения миграций: %v", err)
+	}
+}
+
+// MigrationsDir возвращает путь к каталогу миграций относительно исходников пакета,
+// чтобы тесты из любого пакета находили миграции независимо от рабочего каталога
+func MigrationsDir() string {
+	_, file, _, _ := runtime.Caller(0)
+	return filepath.Join(filepath.Dir(file), "..", "..", "migrations")
+}
+
+// StartRedis запускает Redis в контейнере и возвращает его адрес (host:port)
+func StartRedis(t testing.TB) string {
+	t.Helper()
+
+	addr := os.Getenv(envRedisAddr)
+	if addr == "" {
+		addr = runContainer(t, RedisImage, "6379/tcp").Addr
+	}
+
+	waitFor(t, "Redis", func(ctx context.Context) error {
+		return pingRedis(ctx, addr)
+	})
+	return addr
+}
+
+// pingRedis отправляет команду PING по протоколу RESP и ожидает ответ PONG
+func pingRedis(ctx context.Context, addr string) error {
+	var dialer net.Dialer
+	conn, err := dialer.DialContext(ctx, "tcp", addr)
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+
+	if deadline, ok := ctx.Deadline(); ok {
+		conn.SetDeadline(deadline)
+	}
+	if _, err := conn.Write([]byte("*1\r\n$4\r\nPING\r\n")); err != nil {
+		return err
+	}
+	reply, err := bufio.NewReader(conn).ReadString('\n')
+	if err != nil {
+		return err
+	}
+	if strings.TrimSpace(reply) != "+PONG" {
+		return fmt.Errorf("неожиданный ответ Redis: %q", reply)
+	}
+	return nil
+}
+
+// runContainer запускает контейнер из образа image с опубликованным портом port
+// на случайном порту localhost и регистрирует его удаление по завершении теста
+func runContainer(t testing.TB, image, port string, env ...string) *Container {
+	t.Helper()
+
+	if testing.Short() {
+		t.Skip("Интеграционный тест пропущен в режиме -short")
+	}
+	if _, err := exec.LookPath("docker"); err != nil {
+		t.Skip("docker недоступен, интеграционный тест пропущен")
+	}
+
+	args := []string{"run", "-d", "--rm", "-p", "127.0.0.1::" + port}
+	for _, e := range env {
+		args = append(args, "-e", e)
+	}
+	args = append(args, image)
+
+	out, err := exec.Command("docker", args...).Output()
+	if err != nil {
+		t.Skipf("Не удалось запустить контейнер %s: %v", image, commandError(err))
+	}
+	container := &Container{ID: strings.TrimSpace(string(out))}
+	t.Cleanup(func() {
+		if err := exec.Command("docker", "rm", "-f", container.ID).Run(); err != nil {
+			t.Logf("Ошибка удаления контейнера %s: %v", container.ID, err)
+		}
+	})
+
+	out, err = exec.Command("docker", "port", container.ID, port).Output()
+	if err != nil {
+		t.Fatalf("Ошибка получения порта контейнера %s: %v", image, commandError(err))
+	}
+	// docker port может вернуть несколько строк (IPv4 и IPv6), берем первую
+	container.Addr = strings.TrimSpace(strings.SplitN(string(out), "\n", 2)[0])
+
+	return container
+}
+
+// waitFor повторяет check, пока он не завершится успешно или не истечет startupTimeout
+func waitFor(t testing.TB, name string, check func(ctx context.Context) error) {
+	t.Helper()
+
+	deadline := time.Now().Add(startupTimeout)
+	for {
+		ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
+		err := check(ctx)
+		cancel()
+		if err == nil {
+			return
+		}
+		if time.Now().After(deadline) {
+			t.Fatalf("%s не запустился за %s: %v", name, startupTimeout, err)
+		}
+		time.Sleep(250 * time.Millisecond)
+	}
+}
+
+// commandError дополняет ошибку команды ее выводом в stderr
+func commandError(err error) error {
+	if exitErr, ok := err.(*exec.ExitError); ok && len(exitErr.Stderr) > 0 {
+		return fmt.Errorf("%w: %s", err, strings.TrimSpace(string(exitErr.Stderr)))
+	}
+	return err
+}
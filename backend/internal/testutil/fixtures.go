@@ -0,0 +1,354 @@
+package testutil
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/Ultrahd-dev/student-schedule-app/backend/internal/bells"
+	"github.com/Ultrahd-dev/student-schedule-app/backend/internal/clock"
+	"github.com/Ultrahd-dev/student-schedule-app/backend/internal/schedule"
+	"github.com/Ultrahd-dev/student-schedule-app/backend/internal/users"
+	"github.com/google/uuid"
+)
+
+// DefaultPassword пароль пользователей, создаваемых построителями
+const DefaultPassword = "password123"
+
+// Fixtures создает тестовые данные через сервисы и репозитории приложения,
+// чтобы данные проходили ту же валидацию и нормализацию, что и в работе
+type Fixtures struct {
+	t            testing.TB
+	ctx          context.Context
+	DB           *sql.DB
+	UserRepo     *users.Repository
+	UserService  *users.Service
+	ScheduleRepo *schedule.Repository
+}
+
+// NewFixtures создает построитель тестовых данных поверх базы db
+func NewFixtures(t testing.TB, db *sql.DB) *Fixtures {
+	userRepo := users.NewRepository(db)
+	return &Fixtures{
+		t:            t,
+		ctx:          context.Background(),
+		DB:           db,
+		UserRepo:     userRepo,
+		UserService:  users.NewService(userRepo),
+		ScheduleRepo: schedule.NewRepository(db),
+	}
+}
+
+// UserBuilder построитель пользователя. Незаданные поля заполняются
+// уникальными значениями, поэтому тесты могут не задавать ничего лишнего.
+type UserBuilder struct {
+	f             *Fixtures
+	email         string
+	password      string
+	fullName      string
+	groupName     string
+	course        int
+	studentNumber string
+	department    string
+}
+
+// User начинает построение пользователя
+func (f *Fixtures) User() *UserBuilder {
+	suffix := uuid.NewString()[:8]
+	return &UserBuilder{
+		f:         f,
+		email:     fmt.Sprintf("user-%s@test.local", suffix),
+		password:  DefaultPassword,
+		fullName:  "Тестовый Пользователь " + suffix,
+		groupName: "ТЕСТ-1",
+		course:    1,
+	}
+}
+
+// Email задает email пользователя
+func (b *UserBuilder) Email(email string) *UserBuilder {
+	b.email = email
+	return b
+}
+
+// Password задает пароль пользователя
+func (b *UserBuilder) Password(password string) *UserBuilder {
+	b.password = password
+	return b
+}
+
+// FullName задает ФИО студента или преподавателя
+func (b *UserBuilder) FullName(fullName string) *UserBuilder {
+	b.fullName = fullName
+	return b
+}
+
+// Group задает группу студента
+func (b *UserBuilder) Group(groupName string) *UserBuilder {
+	b.groupName = groupName
+	return b
+}
+
+// StudentNumber задает номер студенческого билета
+func (b *UserBuilder) StudentNumber(number string) *UserBuilder {
+	b.studentNumber = number
+	return b
+}
+
+// Department задает кафедру преподавателя
+func (b *UserBuilder) Department(department string) *UserBuilder {
+	b.department = department
+	return b
+}
+
+// Student регистрирует студента
+func (b *UserBuilder) Student() (*users.User, *users.Student) {
+	b.f.t.Helper()
+
+	user, student, err := b.f.UserService.RegisterStudent(b.f.ctx, users.RegisterStudentInput{
+		RegisterUserInput: users.RegisterUserInput{Email: b.email, Password: b.password},
+		FullName:          b.fullName,
+		GroupName:         b.groupName,
+		Course:            b.course,
+		StudentNumber:     b.studentNumber,
+	})
+	if err != nil {
+		b.f.t.Fatalf("Ошибка создания студента %s: %v", b.email, err)
+	}
+	return user, student
+}
+
+// Teacher регистрирует преподавателя
+func (b *UserBuilder) Teacher() (*users.User, *users.Teacher) {
+	b.f.t.Helper()
+
+	user, teacher, err := b.f.UserService.RegisterTeacher(b.f.ctx, users.RegisterTeacherInput{
+		RegisterUserInput: users.RegisterUserInput{Email: b.email, Password: b.password},
+		FullName:          b.fullName,
+		Department:        b.department,
+	})
+	if err != nil {
+		b.f.t.Fatalf("Ошибка создания преподавателя %s: %v", b.email, err)
+	}
+	return user, teacher
+}
+
+// Admin создает администратора
+func (b *UserBuilder) Admin() *users.User {
+	b.f.t.Helper()
+
+	user, _, err := b.f.UserService.BootstrapAdmin(b.f.ctx, b.email, b.password)
+	if err != nil {
+		b.f.t.Fatalf("Ошибка создания администратора %s: %v", b.email, err)
+	}
+	return user
+}
+
+// SnapshotBuilder построитель снапшота основного расписания
+type SnapshotBuilder struct {
+	f           *Fixtures
+	name        string
+	periodStart time.Time
+	periodEnd   time.Time
+	sourceURL   string
+	data        schedule.ScheduleData
+}
+
+// Snapshot начинает построение активного снапшота на период from-to
+func (f *Fixtures) Snapshot(from, to time.Time) *SnapshotBuilder {
+	return &SnapshotBuilder{
+		f:           f,
+		name:        "Тестовое расписание",
+		periodStart: from,
+		periodEnd:   to,
+		sourceURL:   "https://docs.google.com/spreadsheets/d/test/edit",
+		data: schedule.ScheduleData{
+			Period: fmt.Sprintf("%s - %s", from.Format("02.01.2006"), to.Format("02.01.2006")),
+			Groups: make(map[string][]schedule.DaySchedule),
+		},
+	}
+}
+
+// Name задает название снапшота
+func (b *SnapshotBuilder) Name(name string) *SnapshotBuilder {
+	b.name = name
+	return b
+}
+
+// Lesson добавляет пару number группы в день недели day; время берется из расписания звонков
+func (b *SnapshotBuilder) Lesson(groupName string, day time.Weekday, number int, subject, teacher, classroom string) *SnapshotBuilder {
+	b.f.t.Helper()
+
+	slot, ok := bells.For(b.f.ctx).Lesson(day, number)
+	if !ok {
+		b.f.t.Fatalf("Нет пары %d в расписании звонков на %s", number, day)
+	}
+	dayName := bells.DayName(day)
+
+	lesson := schedule.Lesson{
+		GroupName: groupName,
+		Subject:   subject,
+		Teacher:   teacher,
+		Classroom: classroom,
+		TimeStart: slot.TimeStart,
+		TimeEnd:   slot.TimeEnd,
+		DayOfWeek: dayName,
+	}
+
+	days := b.data.Groups[groupName]
+	for i := range days {
+		if days[i].Day == dayName {
+			days[i].Lessons = append(days[i].Lessons, lesson)
+			return b
+		}
+	}
+	b.data.Groups[groupName] = append(days, schedule.DaySchedule{Day: dayName, Lessons: []schedule.Lesson{lesson}})
+	return b
+}
+
+// Create сохраняет снапшот
+func (b *SnapshotBuilder) Create() *schedule.ScheduleSnapshot {
+	b.f.t.Helper()
+
+	data, err := json.Marshal(b.data)
+	if err != nil {
+		b.f.t.Fatalf("Ошибка сериализации данных снапшота: %v", err)
+	}
+
+	snapshot := &schedule.ScheduleSnapshot{
+		ID:          uuid.New(),
+		Name:        b.name,
+		PeriodStart: b.periodStart,
+		PeriodEnd:   b.periodEnd,
+		Data:        data,
+		SourceURL:   b.sourceURL,
+		IsActive:    true,
+	}
+	if _, err := b.f.ScheduleRepo.SaveSnapshot(b.f.ctx, snapshot); err != nil {
+		b.f.t.Fatalf("Ошибка создания снапшота: %v", err)
+	}
+	return snapshot
+}
+
+// CurrentLesson добавляет пару number группы на дату date в актуальное расписание
+func (f *Fixtures) CurrentLesson(groupName string, date time.Time, number int, subject, teacher, classroom string) *schedule.CurrentSchedule {
+	f.t.Helper()
+
+	slot, ok := bells.For(f.ctx).Lesson(date.Weekday(), number)
+	if !ok {
+		f.t.Fatalf("Нет пары %d в расписании звонков на %s", number, date.Weekday())
+	}
+
+	entry := &schedule.CurrentSchedule{
+		ID:         uuid.New(),
+		GroupName:  groupName,
+		Date:       date,
+		TimeStart:  slot.TimeStart,
+		TimeEnd:    slot.TimeEnd,
+		Subject:    subject,
+		Teacher:    teacher,
+		Classroom:  classroom,
+		SourceType: "main",
+		SourceID:   uuid.New(),
+		IsActive:   true,
+	}
+
+	if err := f.ScheduleRepo.CreateCurrentScheduleEntry(f.ctx, entry); err != nil {
+		f.t.Fatalf("Ошибка создания пары в актуальном расписании: %v", err)
+	}
+	return entry
+}
+
+// ChangeBuilder построитель изменения в расписании
+type ChangeBuilder struct {
+	f      *Fixtures
+	change schedule.ScheduleChange
+}
+
+// Change начинает построение замены пары number группы на дату date
+func (f *Fixtures) Change(groupName string, date time.Time, number int) *ChangeBuilder {
+	f.t.Helper()
+
+	slot, ok := bells.For(f.ctx).Lesson(date.Weekday(), number)
+	if !ok {
+		f.t.Fatalf("Нет пары %d в расписании звонков на %s", number, date.Weekday())
+	}
+
+	return &ChangeBuilder{
+		f: f,
+		change: schedule.ScheduleChange{
+			GroupName:    groupName,
+			Date:         date,
+			TimeStart:    slot.TimeStart,
+			TimeEnd:      slot.TimeEnd,
+			LessonNumber: slot.Number,
+			Subject:      "Тестовый предмет",
+			ChangeType:   "replacement",
+			IsActive:     true,
+		},
+	}
+}
+
+// Replacement делает изменение заменой на предмет subject
+func (b *ChangeBuilder) Replacement(subject, teacher, classroom string) *ChangeBuilder {
+	b.change.ChangeType = "replacement"
+	b.change.Subject, b.change.Teacher, b.change.Classroom = subject, teacher, classroom
+	return b
+}
+
+// Cancellation делает изменение отменой пары по предмету subject
+func (b *ChangeBuilder) Cancellation(subject string) *ChangeBuilder {
+	b.change.ChangeType = "cancellation"
+	b.change.Subject = subject
+	return b
+}
+
+// Addition делает изменение добавлением пары
+func (b *ChangeBuilder) Addition(subject, teacher, classroom string) *ChangeBuilder {
+	b.change.ChangeType = "addition"
+	b.change.Subject, b.change.Teacher, b.change.Classroom = subject, teacher, classroom
+	return b
+}
+
+// Snapshot привязывает изменение к снапшоту
+func (b *ChangeBuilder) Snapshot(snapshot *schedule.ScheduleSnapshot) *ChangeBuilder {
+	b.change.SnapshotID = &snapshot.ID
+	return b
+}
+
+// Pending оставляет изменение на модерации
+func (b *ChangeBuilder) Pending() *ChangeBuilder {
+	b.change.ModerationStatus = schedule.ChangeModerationPending
+	return b
+}
+
+// Reason задает причину изменения
+func (b *ChangeBuilder) Reason(reason string) *ChangeBuilder {
+	b.change.Reason = reason
+	return b
+}
+
+// Create сохраняет изменение (без применения к актуальному расписанию)
+func (b *ChangeBuilder) Create() *schedule.ScheduleChange {
+	b.f.t.Helper()
+
+	change := b.change
+	change.ID = uuid.New()
+	if err := b.f.ScheduleRepo.CreateChange(b.f.ctx, &change); err != nil {
+		b.f.t.Fatalf("Ошибка создания изменения: %v", err)
+	}
+	return &change
+}
+
+// NextWeekday возвращает ближайшую будущую дату с днем недели day
+// (не сегодня), чтобы изменения не попадали в прошлое
+func NextWeekday(day time.Weekday, loc *time.Location) time.Time {
+	date := clock.Today(loc).AddDate(0, 0, 1)
+	for date.Weekday() != day {
+		date = date.AddDate(0, 0, 1)
+	}
+	return date
+}
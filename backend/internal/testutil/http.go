@@ -0,0 +1,48 @@
+package testutil
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+)
+
+// RouteHosts направляет HTTP-запросы к хостам hosts (например, docs.google.com)
+// на тестовый сервер server. Подменяет http.DefaultTransport до завершения теста,
+// поэтому клиенты без собственного транспорта (scraper, gsheets) ходят в тестовый
+// сервер, не зная об этом. Тесты, вызывающие RouteHosts, нельзя запускать параллельно.
+func RouteHosts(t testing.TB, server *httptest.Server, hosts ...string) {
+	t.Helper()
+
+	target, err := url.Parse(server.URL)
+	if err != nil {
+		t.Fatalf("Некорректный адрес тестового сервера: %v", err)
+	}
+
+	routed := make(map[string]bool, len(hosts))
+	for _, host := range hosts {
+		routed[host] = true
+	}
+
+	original := http.DefaultTransport
+	http.DefaultTransport = &routingTransport{base: original, target: target, routed: routed}
+	t.Cleanup(func() { http.DefaultTransport = original })
+}
+
+// routingTransport переписывает адрес запросов к перенаправляемым хостам
+type routingTransport struct {
+	base   http.RoundTripper
+	target *url.URL
+	routed map[string]bool
+}
+
+// RoundTrip реализует http.RoundTripper
+func (rt *routingTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	if rt.routed[req.URL.Hostname()] {
+		req = req.Clone(req.Context())
+		req.URL.Scheme = rt.target.Scheme
+		req.URL.Host = rt.target.Host
+		req.Host = rt.target.Host
+	}
+	return rt.base.RoundTrip(req)
+}
@@ -0,0 +1,308 @@
+// Package timetable формирует печатную версию расписания группы на неделю
+// в PDF: сетка «пары × дни недели» с номерами пар, предметами, преподавателями
+// и кабинетами, чтобы студенты могли распечатать расписание или переслать его.
+// Здесь же формируется отчет о нагрузке преподавателей для учебной части.
+package timetable
+
+import (
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/Ultrahd-dev/student-schedule-app/backend/internal/bells"
+	"github.com/Ultrahd-dev/student-schedule-app/backend/internal/clock"
+	"github.com/Ultrahd-dev/student-schedule-app/backend/internal/pdf"
+	"github.com/Ultrahd-dev/student-schedule-app/backend/internal/schedule"
+)
+
+// Размеры элементов страницы в пунктах (A4 альбомной ориентации)
+const (
+	pageWidth    = pdf.A4Height
+	pageHeight   = pdf.A4Width
+	margin       = 28.0
+	labelWidth   = 62.0 // Колонка с номерами пар
+	headerHeight = 28.0 // Строка с днями недели
+	maxRowHeight = 90.0
+	cellPadding  = 4.0
+)
+
+// Цвета и размеры шрифта
+var (
+	colorText      = pdf.Color{R: 0.1, G: 0.1, B: 0.1}
+	colorMuted     = pdf.Color{R: 0.4, G: 0.4, B: 0.4}
+	colorCancelled = pdf.Color{R: 0.75, G: 0.1, B: 0.1}
+	colorGrid      = pdf.Color{R: 0.7, G: 0.7, B: 0.7}
+	colorHeader    = pdf.Color{R: 0.92, G: 0.93, B: 0.95}
+	colorLabel     = pdf.Color{R: 0.97, G: 0.97, B: 0.98}
+)
+
+const (
+	titleSize   = 16.0
+	headingSize = 10.0
+	subjectSize = 8.5
+	detailSize  = 7.0
+)
+
+// Renderer формирует PDF с расписанием группы на неделю
+type Renderer struct {
+	font *pdf.Font
+	loc  *time.Location // Часовой пояс колледжа
+}
+
+// NewRenderer создает формирование PDF со шрифтом font
+func NewRenderer(font *pdf.Font, loc *time.Location) *Renderer {
+	return &Renderer{font: font, loc: loc}
+}
+
+// row строка сетки: пары, начинающиеся с одного номера пары
+type row struct {
+	number int    // Номер первой пары; 0 - время не совпадает с расписанием звонков
+	start  string // Время начала для пар без номера
+	label  string // Номера пар, например "1-2"
+	time   string // Время по расписанию звонков будних дней
+}
+
+// RenderWeek записывает в w расписание группы на неделю, начинающуюся с weekStart.
+// entries - актуальное расписание группы за эту неделю, строки сетки - пары по
+// расписанию звонков колледжа timings. Даты выводятся в форматах formats
+// пользователя, запросившего расписание.
+func (r *Renderer) RenderWeek(w io.Writer, group string, weekStart time.Time, entries []schedule.CurrentSchedule, timings bells.Timings, formats clock.Formats) error {
+	weekStart = clock.Anchor(weekStart, r.loc)
+	days := 6
+	cells := make(map[string][]schedule.CurrentSchedule) // Ключ - дата и строка
+	rows := make(map[string]*row)
+	for _, entry := range entries {
+		date := clock.Anchor(entry.Date, r.loc)
+		if date.Weekday() == time.Sunday {
+			days = 7
+		}
+		key, lessonRow := r.rowFor(timings, date.Weekday(), entry)
+		if _, ok := rows[key]; !ok {
+			rows[key] = lessonRow
+		}
+		cellKey := date.Format("2006-01-02") + "|" + key
+		cells[cellKey] = append(cells[cellKey], entry)
+	}
+
+	sorted := make([]string, 0, len(rows))
+	for key := range rows {
+		sorted = append(sorted, key)
+	}
+	sort.Slice(sorted, func(i, j int) bool {
+		a, b := rows[sorted[i]], rows[sorted[j]]
+		if (a.number == 0) != (b.number == 0) {
+			return a.number != 0
+		}
+		if a.number != b.number {
+			return a.number < b.number
+		}
+		return a.start < b.start
+	})
+
+	doc := pdf.NewDocument(r.font)
+	page := doc.AddPage(pageWidth, pageHeight)
+	weekEnd := weekStart.AddDate(0, 0, days-1)
+	page.Text(margin, margin+titleSize, titleSize, colorText, "Расписание группы "+group)
+	page.Text(margin, margin+titleSize+16, headingSize, colorMuted,
+		fmt.Sprintf("Неделя %s - %s", formats.FormatDate(weekStart), formats.FormatDate(weekEnd)))
+
+	gridTop := margin + titleSize + 30
+	gridBottom := pageHeight - margin - 12
+	dayWidth := (pageWidth - 2*margin - labelWidth) / float64(days)
+	rowHeight := maxRowHeight
+	if len(sorted) > 0 {
+		rowHeight = min(maxRowHeight, (gridBottom-gridTop-headerHeight)/float64(len(sorted)))
+	}
+	gridHeight := headerHeight + rowHeight*float64(max(len(sorted), 1))
+
+	// Фон заголовков
+	page.FillRect(margin, gridTop, pageWidth-2*margin, headerHeight, colorHeader)
+	page.FillRect(margin, gridTop+headerHeight, labelWidth, gridHeight-headerHeight, colorLabel)
+
+	// Дни недели
+	page.Text(margin+cellPadding, gridTop+17, detailSize, colorMuted, "Пара")
+	for day := 0; day < days; day++ {
+		date := weekStart.AddDate(0, 0, day)
+		x := margin + labelWidth + float64(day)*dayWidth + cellPadding
+		page.Text(x, gridTop+12, headingSize, colorText, bells.DayName(date.Weekday()))
+		page.Text(x, gridTop+23, detailSize, colorMuted, date.Format("02.01"))
+	}
+
+	// Пары
+	for i, key := range sorted {
+		lessonRow := rows[key]
+		y := gridTop + headerHeight + float64(i)*rowHeight
+		page.Text(margin+cellPadding, y+12, headingSize, colorText, lessonRow.label)
+		page.Text(margin+cellPadding, y+22, detailSize, colorMuted, lessonRow.time)
+
+		for day := 0; day < days; day++ {
+			date := weekStart.AddDate(0, 0, day)
+			x := margin + labelWidth + float64(day)*dayWidth
+			r.drawCell(page, x, y, dayWidth, rowHeight, lessonRow, cells[date.Format("2006-01-02")+"|"+key])
+		}
+	}
+	if len(sorted) == 0 {
+		page.Text(margin+labelWidth+cellPadding, gridTop+headerHeight+16, headingSize, colorMuted, "Занятий на этой неделе нет")
+	}
+
+	// Сетка
+	right := pageWidth - margin
+	page.Line(margin, gridTop, right, gridTop, 0.5, colorGrid)
+	page.Line(margin, gridTop+headerHeight, right, gridTop+headerHeight, 0.5, colorGrid)
+	for i := 1; i <= max(len(sorted), 1); i++ {
+		y := gridTop + headerHeight + float64(i)*rowHeight
+		page.Line(margin, y, right, y, 0.5, colorGrid)
+	}
+	page.Line(margin, gridTop, margin, gridTop+gridHeight, 0.5, colorGrid)
+	for day := 0; day <= days; day++ {
+		x := margin + labelWidth + float64(day)*dayWidth
+		page.Line(x, gridTop, x, gridTop+gridHeight, 0.5, colorGrid)
+	}
+
+	page.Text(margin, pageHeight-margin, detailSize, colorMuted,
+		"Сформировано "+formats.FormatDateTime(clock.Now(r.loc)))
+
+	return doc.Write(w)
+}
+
+// rowFor возвращает ключ и строку сетки для пары по расписанию звонков timings дня недели
+func (r *Renderer) rowFor(timings bells.Timings, day time.Weekday, entry schedule.CurrentSchedule) (string, *row) {
+	start := clock.NormalizeClock(entry.TimeStart)
+	end := clock.NormalizeClock(entry.TimeEnd)
+	number, ok := timings.NumberAt(day, start)
+	if !ok {
+		return "t" + start, &row{start: start, label: start, time: start + "-" + end}
+	}
+
+	// Пара обычно занимает два урока по расписанию звонков: 1-2, 3-4 ...
+	last := number
+	for _, timing := range timings.ForWeekday(day) {
+		if timing.Number > number && timing.TimeEnd == end {
+			last = timing.Number
+			break
+		}
+	}
+	label := fmt.Sprint(number)
+	if last != number {
+		label = fmt.Sprintf("%d-%d", number, last)
+	}
+
+	// Время строки - по расписанию звонков будних дней, в субботу оно может отличаться
+	rowTime := start + "-" + end
+	first, ok1 := timings.Lesson(time.Monday, number)
+	final, ok2 := timings.Lesson(time.Monday, last)
+	if ok1 && ok2 {
+		rowTime = first.TimeStart + "-" + final.TimeEnd
+	}
+	return fmt.Sprintf("n%03d", number), &row{number: number, label: label, time: rowTime}
+}
+
+// drawCell выводит пары ячейки: время (если отличается от строки), предмет,
+// преподавателя и кабинет. Текст, не поместившийся в ячейку, обрезается.
+func (r *Renderer) drawCell(page *pdf.Page, x, y, width, height float64, lessonRow *row, entries []schedule.CurrentSchedule) {
+	sort.Slice(entries, func(i, j int) bool { return entries[i].TimeStart < entries[j].TimeStart })
+
+	type line struct {
+		text  string
+		size  float64
+		color pdf.Color
+	}
+	var lines []line
+	textWidth := width - 2*cellPadding
+	for _, entry := range entries {
+		entryTime := clock.NormalizeClock(entry.TimeStart) + "-" + clock.NormalizeClock(entry.TimeEnd)
+		if entryTime != lessonRow.time {
+			lines = append(lines, line{entryTime, detailSize, colorMuted})
+		}
+
+		subject, color := entry.Subject, colorText
+		if !entry.IsActive {
+			subject, color = "Отменено: "+subject, colorCancelled
+		}
+		for _, text := range r.wrap(subject, subjectSize, textWidth) {
+			lines = append(lines, line{text, subjectSize, color})
+		}
+
+		var details []string
+		if entry.Teacher != "" {
+			details = append(details, entry.Teacher)
+		}
+		if entry.Classroom != "" {
+			details = append(details, "ауд. "+entry.Classroom)
+		}
+		for _, text := range r.wrap(strings.Join(details, ", "), detailSize, textWidth) {
+			lines = append(lines, line{text, detailSize, colorMuted})
+		}
+	}
+
+	baseline := y + cellPadding
+	bottom := y + height - cellPadding
+	for i, l := range lines {
+		next := baseline + l.size*1.2
+		if next > bottom {
+			break
+		}
+		text := l.text
+		// Последняя помещающаяся строка, за которой есть еще текст
+		if i+1 < len(lines) && next+lines[i+1].size*1.2 > bottom {
+			text = r.ellipsis(text+" …", l.size, textWidth)
+		}
+		page.Text(x+cellPadding, next-l.size*0.2, l.size, l.color, text)
+		baseline = next
+	}
+}
+
+// wrap разбивает текст на строки шириной не более width по словам.
+// Слово длиннее строки разбивается посимвольно.
+func (r *Renderer) wrap(text string, size, width float64) []string {
+	var lines []string
+	var current string
+	for _, word := range strings.Fields(text) {
+		candidate := word
+		if current != "" {
+			candidate = current + " " + word
+		}
+		if r.font.TextWidth(candidate, size) <= width {
+			current = candidate
+			continue
+		}
+		if current != "" {
+			lines = append(lines, current)
+		}
+		for r.font.TextWidth(word, size) > width && utf8.RuneCountInString(word) > 1 {
+			part := r.fit(word, size, width)
+			lines = append(lines, part)
+			word = word[len(part):]
+		}
+		current = word
+	}
+	if current != "" {
+		lines = append(lines, current)
+	}
+	return lines
+}
+
+// fit возвращает самое длинное начало строки (хотя бы один символ), помещающееся в width
+func (r *Renderer) fit(text string, size, width float64) string {
+	end := 0
+	for i, ch := range text {
+		next := i + utf8.RuneLen(ch)
+		if end > 0 && r.font.TextWidth(text[:next], size) > width {
+			break
+		}
+		end = next
+	}
+	return text[:end]
+}
+
+// ellipsis обрезает строку с многоточием на конце до ширины width
+func (r *Renderer) ellipsis(text string, size, width float64) string {
+	if r.font.TextWidth(text, size) <= width {
+		return text
+	}
+	text = strings.TrimSuffix(text, " …")
+	return strings.TrimRight(r.fit(text, size, width-r.font.TextWidth("…", size)), " ") + "…"
+}
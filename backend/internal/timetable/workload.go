@@ -0,0 +1,147 @@
+package timetable
+
+import (
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/Ultrahd-dev/student-schedule-app/backend/internal/clock"
+	"github.com/Ultrahd-dev/student-schedule-app/backend/internal/pdf"
+	"github.com/Ultrahd-dev/student-schedule-app/backend/internal/schedule"
+)
+
+// Размеры таблицы нагрузки (A4 книжной ориентации)
+const (
+	workloadPageWidth  = pdf.A4Width
+	workloadPageHeight = pdf.A4Height
+	workloadRowHeight  = 14.0
+)
+
+// workloadColumn колонка таблицы нагрузки
+type workloadColumn struct {
+	title string
+	width float64 // Доля ширины таблицы
+	right bool    // Выравнивание по правому краю (числа)
+}
+
+// RenderWorkload записывает в w отчет о нагрузке преподавателей за период [from, to]:
+// для каждого преподавателя - часы по группам и предметам (и неделям, если
+// нагрузка посчитана по неделям) и итог. Длинный отчет занимает несколько страниц.
+// Даты выводятся в форматах formats пользователя, запросившего отчет.
+func (r *Renderer) RenderWorkload(w io.Writer, from, to time.Time, workloads []schedule.TeacherWorkload, formats clock.Formats) error {
+	byWeek := false
+	for _, workload := range workloads {
+		for _, stat := range workload.Stats {
+			byWeek = byWeek || stat.WeekStart != nil
+		}
+	}
+
+	columns := []workloadColumn{{"Группа", 0.18, false}, {"Предмет", 0.52, false}}
+	if byWeek {
+		columns = []workloadColumn{{"Неделя", 0.14, false}, {"Группа", 0.16, false}, {"Предмет", 0.40, false}}
+	}
+	totals := []workloadColumn{{"Пар", 0.12, true}, {"Ак. часов", 0.18, true}}
+	columns = append(columns, totals...)
+	// Строка преподавателя: имя занимает все текстовые колонки
+	teacherColumns := append([]workloadColumn{{"", 1 - totals[0].width - totals[1].width, false}}, totals...)
+
+	tableWidth := workloadPageWidth - 2*margin
+	bottom := workloadPageHeight - margin - 16
+
+	doc := pdf.NewDocument(r.font)
+	var page *pdf.Page
+	var y float64
+
+	// row выводит строку таблицы; cells - значения колонок
+	row := func(columns []workloadColumn, cells []string, size float64, color pdf.Color, fill *pdf.Color) {
+		if fill != nil {
+			page.FillRect(margin, y, tableWidth, workloadRowHeight, *fill)
+		}
+		x := margin
+		for i, column := range columns {
+			width := column.width * tableWidth
+			text := r.ellipsis(cells[i], size, width-2*cellPadding)
+			textX := x + cellPadding
+			if column.right {
+				textX = x + width - cellPadding - r.font.TextWidth(text, size)
+			}
+			page.Text(textX, y+workloadRowHeight-4, size, color, text)
+			x += width
+		}
+		y += workloadRowHeight
+		page.Line(margin, y, margin+tableWidth, y, 0.5, colorGrid)
+	}
+
+	titles := make([]string, len(columns))
+	for i, column := range columns {
+		titles[i] = column.title
+	}
+	newPage := func() {
+		page = doc.AddPage(workloadPageWidth, workloadPageHeight)
+		page.Text(margin, margin+titleSize, titleSize, colorText, "Нагрузка преподавателей")
+		page.Text(margin, margin+titleSize+16, headingSize, colorMuted,
+			fmt.Sprintf("Период %s - %s", formats.FormatDate(from), formats.FormatDate(to)))
+		page.Text(margin, workloadPageHeight-margin, detailSize, colorMuted,
+			"Сформировано "+formats.FormatDateTime(clock.Now(r.loc)))
+		y = margin + titleSize + 30
+		row(columns, titles, detailSize, colorMuted, &colorHeader)
+	}
+	newPage()
+
+	// cells формирует значения колонок строки нагрузки
+	cells := func(stat schedule.WorkloadStat) []string {
+		values := []string{stat.GroupName, stat.Subject}
+		if byWeek {
+			week := ""
+			if stat.WeekStart != nil {
+				week = formats.FormatDate(*stat.WeekStart)
+			}
+			values = append([]string{week}, values...)
+		}
+		return append(values, fmt.Sprint(stat.Lessons), formatHours(stat.AcademicHours()))
+	}
+
+	totalMinutes := 0
+	for _, workload := range workloads {
+		// Заголовок преподавателя не отрывается от его первой строки
+		if y+2*workloadRowHeight > bottom {
+			newPage()
+		}
+		teacher := workload.Teacher
+		if teacher == "" {
+			teacher = "Преподаватель не указан"
+		}
+		row(teacherColumns, []string{teacher, fmt.Sprint(workload.Lessons), formatHours(workload.AcademicHours())},
+			headingSize, colorText, &colorLabel)
+
+		for _, stat := range workload.Stats {
+			if y+workloadRowHeight > bottom {
+				newPage()
+			}
+			row(columns, cells(stat), subjectSize, colorText, nil)
+		}
+		totalMinutes += workload.Minutes
+	}
+
+	if len(workloads) == 0 {
+		page.Text(margin+cellPadding, y+workloadRowHeight, headingSize, colorMuted, "Занятий за период нет")
+	} else {
+		if y+workloadRowHeight > bottom {
+			newPage()
+		}
+		page.Text(margin+cellPadding, y+workloadRowHeight, headingSize, colorText,
+			fmt.Sprintf("Итого: %s ак. часов, преподавателей: %d",
+				formatHours(float64(totalMinutes)/schedule.AcademicHourMinutes), len(workloads)))
+	}
+
+	return doc.Write(w)
+}
+
+// formatHours форматирует академические часы с точностью до десятых без лишнего нуля
+func formatHours(hours float64) string {
+	text := fmt.Sprintf("%.1f", hours)
+	if len(text) > 2 && text[len(text)-2:] == ".0" {
+		text = text[:len(text)-2]
+	}
+	return text
+}
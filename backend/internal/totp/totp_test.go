@@ -0,0 +1,73 @@
+package totp
+
+import (
+	"testing"
+	"time"
+)
+
+// rfcSecret секрет SHA-1 из RFC 6238, Appendix B ("12345678901234567890") в base32
+const rfcSecret = "GEZDGNBVGY3TQOJQGEZDGNBVGY3TQOJQ"
+
+func TestCodeRFC6238(t *testing.T) {
+	// Коды RFC 6238 восьмизначные; шестизначный код - их последние шесть цифр
+	tests := []struct {
+		unix int64
+		want string
+	}{
+		{59, "287082"},          // 94287082
+		{1111111109, "081804"},  // 07081804
+		{1111111111, "050471"},  // 14050471
+		{1234567890, "005924"},  // 89005924
+		{2000000000, "279037"},  // 69279037
+		{20000000000, "353130"}, // 65353130
+	}
+	for _, tt := range tests {
+		got, err := Code(rfcSecret, time.Unix(tt.unix, 0))
+		if err != nil {
+			t.Fatalf("%d: %v", tt.unix, err)
+		}
+		if got != tt.want {
+			t.Errorf("%d: код %s, ожидался %s", tt.unix, got, tt.want)
+		}
+	}
+}
+
+func TestValidate(t *testing.T) {
+	now := time.Unix(1111111111, 0)
+	current := now.Unix() / int64(Period.Seconds())
+	codeAt := func(offset time.Duration) string {
+		code, err := Code(rfcSecret, now.Add(offset))
+		if err != nil {
+			t.Fatal(err)
+		}
+		return code
+	}
+
+	tests := []struct {
+		name     string
+		secret   string
+		code     string
+		wantStep int64
+		wantOK   bool
+	}{
+		{"текущий период", rfcSecret, codeAt(0), current, true},
+		{"предыдущий период", rfcSecret, codeAt(-Period), current - 1, true},
+		{"следующий период", rfcSecret, codeAt(Period), current + 1, true},
+		{"два периода назад", rfcSecret, codeAt(-2 * Period), 0, false},
+		{"через два периода", rfcSecret, codeAt(2 * Period), 0, false},
+		{"пробелы в коде", rfcSecret, " 050 471 ", current, true},
+		{"секрет в нижнем регистре", "gezdgnbvgy3tqojqgezdgnbvgy3tqojq", "050471", current, true},
+		{"неверный код", rfcSecret, "000000", 0, false},
+		{"короткий код", rfcSecret, "05047", 0, false},
+		{"восьмизначный код", rfcSecret, "14050471", 0, false},
+		{"неверный секрет", "не base32", "050471", 0, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			step, ok := Validate(tt.secret, tt.code, now)
+			if ok != tt.wantOK || step != tt.wantStep {
+				t.Errorf("Validate(%q) = %d, %v, ожидалось %d, %v", tt.code, step, ok, tt.wantStep, tt.wantOK)
+			}
+		})
+	}
+}
@@ -0,0 +1,130 @@
+// Package txn реализует транзакции, охватывающие несколько репозиториев.
+// Manager.Do открывает транзакцию и передает ее через контекст, а репозитории
+// выполняют запросы через From(ctx, db): внутри Do - в транзакции, вне ее -
+// напрямую в базе. Так применение изменения, запись в журнал и постановка
+// уведомления в очередь фиксируются вместе, а *sql.Tx не попадает в сигнатуры
+// сервисов и репозиториев.
+package txn
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+)
+
+// ErrNoTx операция требует транзакции, а в контексте ее нет
+var ErrNoTx = errors.New("операция выполняется только в транзакции")
+
+// Executor выполняет запросы (*sql.DB или *sql.Tx)
+type Executor interface {
+	ExecContext(ctx context.Context, query string, args ...interface{}) (sql.Result, error)
+	QueryContext(ctx context.Context, query string, args ...interface{}) (*sql.Rows, error)
+	QueryRowContext(ctx context.Context, query string, args ...interface{}) *sql.Row
+}
+
+// Transactor выполняет функцию в транзакции, передаваемой через контекст.
+// Реализуется Manager; сервисы зависят от интерфейса, чтобы в тестах
+// подставлять реализацию без базы.
+type Transactor interface {
+	Do(ctx context.Context, fn func(ctx context.Context) error) error
+}
+
+// txKey ключ транзакции в контексте
+type txKey struct{}
+
+// afterCommitKey ключ функций, выполняемых после коммита транзакции из контекста
+type afterCommitKey struct{}
+
+var _ Transactor = (*Manager)(nil)
+
+// Manager открывает транзакции в базе db
+type Manager struct {
+	db *sql.DB
+}
+
+// NewManager создает менеджер транзакций
+func NewManager(db *sql.DB) *Manager {
+	return &Manager{db: db}
+}
+
+// Do выполняет fn в транзакции: репозитории, получившие контекст fn, работают
+// в ней. Транзакция фиксируется, если fn вернула nil, иначе откатывается.
+// Если ctx уже содержит транзакцию, fn выполняется в ней, а фиксирует ее
+// внешний Do.
+func (m *Manager) Do(ctx context.Context, fn func(ctx context.Context) error) error {
+	if _, ok := ctx.Value(txKey{}).(*sql.Tx); ok {
+		return fn(ctx)
+	}
+
+	tx, err := m.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("ошибка начала транзакции: %w", err)
+	}
+	defer func() { _ = tx.Rollback() }()
+
+	var afterCommit []func()
+	txCtx := context.WithValue(context.WithValue(ctx, txKey{}, tx), afterCommitKey{}, &afterCommit)
+	if err := fn(txCtx); err != nil {
+		return err
+	}
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("ошибка коммита транзакции: %w", err)
+	}
+	for _, f := range afterCommit {
+		f()
+	}
+	return nil
+}
+
+// AfterCommit выполняет f после коммита транзакции из контекста (при откате -
+// не выполняет), а вне транзакции Manager.Do - сразу. Так другие экземпляры API
+// узнают об изменении данных не раньше, чем смогут его прочитать.
+func AfterCommit(ctx context.Context, f func()) {
+	if hooks, ok := ctx.Value(afterCommitKey{}).(*[]func()); ok {
+		*hooks = append(*hooks, f)
+		return
+	}
+	f()
+}
+
+// From возвращает транзакцию из контекста или db, если транзакции нет
+func From(ctx context.Context, db *sql.DB) Executor {
+	if tx, ok := ctx.Value(txKey{}).(*sql.Tx); ok {
+		return tx
+	}
+	return db
+}
+
+// InTx сообщает, выполняется ли код с контекстом ctx в транзакции
+func InTx(ctx context.Context) bool {
+	_, ok := ctx.Value(txKey{}).(*sql.Tx)
+	return ok
+}
+
+// Savepoint создает точку сохранения name в транзакции из контекста
+func Savepoint(ctx context.Context, name string) error {
+	return execInTx(ctx, "SAVEPOINT "+name)
+}
+
+// RollbackToSavepoint откатывает транзакцию из контекста до точки сохранения name
+func RollbackToSavepoint(ctx context.Context, name string) error {
+	return execInTx(ctx, "ROLLBACK TO SAVEPOINT "+name)
+}
+
+// ReleaseSavepoint освобождает точку сохранения name
+func ReleaseSavepoint(ctx context.Context, name string) error {
+	return execInTx(ctx, "RELEASE SAVEPOINT "+name)
+}
+
+// execInTx выполняет команду в транзакции из контекста
+func execInTx(ctx context.Context, query string) error {
+	tx, ok := ctx.Value(txKey{}).(*sql.Tx)
+	if !ok {
+		return ErrNoTx
+	}
+	if _, err := tx.ExecContext(ctx, query); err != nil {
+		return fmt.Errorf("failed to execute %q: %w", query, err)
+	}
+	return nil
+}
@@ -0,0 +1,113 @@
+package users
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"log"
+	"time"
+
+	"github.com/Ultrahd-dev/student-schedule-app/backend/internal/cache"
+	"github.com/google/uuid"
+)
+
+// UserCache кэширует пользователей, которых middleware и обработчики получают
+// по ID на каждый запрос. Первый уровень - LRU кэш в памяти с коротким временем
+// жизни, второй - Redis, общий для экземпляров API. Хэш пароля в кэш не попадает.
+type UserCache struct {
+	local       *cache.LRU[uuid.UUID, User]
+	redis       *cache.Redis
+	redisTTL    time.Duration
+	invalidator *cache.Invalidator // Сброс кэша в памяти других экземпляров (может быть nil)
+}
+
+// NewUserCache создает кэш пользователей на size записей в памяти со временем
+// жизни localTTL. redis может быть nil - тогда используется только кэш в памяти.
+// Без шины сброса (UseInvalidator) изменения, сделанные другим экземпляром API,
+// становятся видны здесь не позже чем через localTTL, поэтому он должен быть коротким.
+func NewUserCache(size int, localTTL time.Duration, redis *cache.Redis, redisTTL time.Duration) *UserCache {
+	return &UserCache{
+		local:    cache.NewLRU[uuid.UUID, User](size, localTTL),
+		redis:    redis,
+		redisTTL: redisTTL,
+	}
+}
+
+// get возвращает пользователя из кэша. Ошибки Redis только логируются:
+// при недоступности Redis пользователь читается из базы.
+func (c *UserCache) get(ctx context.Context, id uuid.UUID) (*User, bool) {
+	if user, ok := c.local.Get(id); ok {
+		return &user, true
+	}
+	if c.redis == nil {
+		return nil, false
+	}
+
+	data, err := c.redis.Get(ctx, userCacheKey(id))
+	if err != nil {
+		if !errors.Is(err, cache.ErrMiss) {
+			log.Printf("Ошибка чтения пользователя %s из кэша: %v", id, err)
+		}
+		return nil, false
+	}
+	var user User
+	if err := json.Unmarshal(data, &user); err != nil {
+		log.Printf("Некорректная запись пользователя %s в кэше: %v", id, err)
+		return nil, false
+	}
+	c.local.Set(id, user)
+	return &user, true
+}
+
+// set сохраняет пользователя в кэш без хэша пароля
+func (c *UserCache) set(ctx context.Context, user User) {
+	user.Password = ""
+	c.local.Set(user.ID, user)
+	if c.redis == nil {
+		return
+	}
+
+	data, err := json.Marshal(user)
+	if err != nil {
+		return
+	}
+	if err := c.redis.Set(ctx, userCacheKey(user.ID), data, c.redisTTL); err != nil {
+		log.Printf("Ошибка записи пользователя %s в кэш: %v", user.ID, err)
+	}
+}
+
+// UseInvalidator рассылает сброс пользователей через шину invalidator, чтобы
+// измененный пользователь сразу удалялся из кэша в памяти всех экземпляров API,
+// а не по истечении localTTL. Доставка через шину не гарантируется, поэтому
+// localTTL остается верхней границей задержки. После обрыва подписки на шину
+// кэш в памяти очищается целиком.
+func (c *UserCache) UseInvalidator(invalidator *cache.Invalidator) {
+	c.invalidator = invalidator
+	invalidator.Subscribe(func(inv cache.Invalidation) {
+		switch {
+		case inv.UserID != uuid.Nil:
+			c.local.Delete(inv.UserID)
+		case inv == cache.Invalidation{Origin: inv.Origin}:
+			c.local.DeleteFunc(func(uuid.UUID) bool { return true })
+		}
+	})
+}
+
+// Invalidate удаляет пользователя из кэша; вызывается после изменения
+// роли, пароля или активности пользователя
+func (c *UserCache) Invalidate(ctx context.Context, id uuid.UUID) {
+	c.local.Delete(id)
+	if c.redis != nil {
+		if err := c.redis.Del(ctx, userCacheKey(id)); err != nil {
+			log.Printf("Ошибка удаления пользователя %s из кэша: %v", id, err)
+		}
+	}
+	if c.invalidator != nil {
+		c.invalidator.Publish(ctx, cache.Invalidation{UserID: id})
+	}
+}
+
+// userCacheKey ключ пользователя в Redis
+func userCacheKey(id uuid.UUID) string {
+	return "user:" + id.String()
+}
@@ -0,0 +1,59 @@
+package users_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/Ultrahd-dev/student-schedule-app/backend/internal/testutil"
+	"github.com/Ultrahd-dev/student-schedule-app/backend/internal/users"
+)
+
+// TestRepositoryUserCache проверяет чтение пользователя через кэш и его сброс
+// после изменения роли и удаления пользователя
+func TestRepositoryUserCache(t *testing.T) {
+	db := testutil.StartPostgres(t)
+	f := testutil.NewFixtures(t, db)
+	f.UserRepo.SetCache(users.NewUserCache(10, time.Hour, nil, 0))
+	ctx := context.Background()
+
+	user, _ := f.User().Student()
+	admin := f.User().Admin()
+
+	// Промах: пользователь читается из базы и сохраняется в кэш
+	if _, err := f.UserRepo.GetUserByID(ctx, user.ID); err != nil {
+		t.Fatal(err)
+	}
+
+	// Попадание: изменение в обход репозитория не видно, пока запись в кэше
+	if _, err := db.ExecContext(ctx, `UPDATE users SET role = 'teacher' WHERE id = $1`, user.ID); err != nil {
+		t.Fatal(err)
+	}
+	cached, err := f.UserRepo.GetUserByID(ctx, user.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if cached.Role != users.RoleStudent {
+		t.Errorf("роль %s, ожидалась роль из кэша %s", cached.Role, users.RoleStudent)
+	}
+
+	// UpdateRole сбрасывает пользователя в кэше
+	if err := f.UserRepo.UpdateRole(ctx, user.ID, users.RoleAdmin); err != nil {
+		t.Fatal(err)
+	}
+	updated, err := f.UserRepo.GetUserByID(ctx, user.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if updated.Role != users.RoleAdmin {
+		t.Errorf("после UpdateRole роль %s, ожидалась %s", updated.Role, users.RoleAdmin)
+	}
+
+	// SoftDeleteUser сбрасывает пользователя в кэше: удаленный пользователь не находится
+	if _, err := f.UserRepo.SoftDeleteUser(ctx, user.ID, admin.ID); err != nil {
+		t.Fatal(err)
+	}
+	if deleted, err := f.UserRepo.GetUserByID(ctx, user.ID); err == nil {
+		t.Errorf("удаленный пользователь получен из кэша: %+v", deleted)
+	}
+}
@@ -0,0 +1,74 @@
+package users
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/Ultrahd-dev/student-schedule-app/backend/internal/cache"
+	"github.com/google/uuid"
+)
+
+func TestUserCache(t *testing.T) {
+	ctx := context.Background()
+	c := NewUserCache(10, time.Minute, nil, 0)
+	user := User{ID: uuid.New(), Email: "student@example.com", Password: "hash", Role: RoleStudent, IsActive: true}
+
+	if _, ok := c.get(ctx, user.ID); ok {
+		t.Fatal("пустой кэш вернул пользователя")
+	}
+
+	c.set(ctx, user)
+	cached, ok := c.get(ctx, user.ID)
+	if !ok {
+		t.Fatal("пользователь не найден в кэше")
+	}
+	if cached.Email != user.Email || cached.Role != user.Role {
+		t.Errorf("из кэша получен %+v", cached)
+	}
+	if cached.Password != "" {
+		t.Error("хэш пароля сохранен в кэше")
+	}
+
+	c.Invalidate(ctx, user.ID)
+	if _, ok := c.get(ctx, user.ID); ok {
+		t.Error("пользователь остался в кэше после сброса")
+	}
+}
+
+// TestUserCacheInvalidator проверяет, что сброс пользователя на одном экземпляре
+// удаляет его из кэша в памяти другого, а сброс расписания кэш пользователей не трогает
+func TestUserCacheInvalidator(t *testing.T) {
+	ctx := context.Background()
+	// Без Redis шина доставляет сообщения подписчикам своего экземпляра:
+	// два кэша на одной шине ведут себя как кэши двух экземпляров API
+	invalidator := cache.NewInvalidator(nil, "")
+	changed, other := NewUserCache(10, time.Hour, nil, 0), NewUserCache(10, time.Hour, nil, 0)
+	changed.UseInvalidator(invalidator)
+	other.UseInvalidator(invalidator)
+
+	first, second := User{ID: uuid.New(), Role: RoleAdmin}, User{ID: uuid.New(), Role: RoleStudent}
+	for _, c := range []*UserCache{changed, other} {
+		c.set(ctx, first)
+		c.set(ctx, second)
+	}
+
+	invalidator.Publish(ctx, cache.Invalidation{CollegeID: uuid.New(), Date: "2025-09-01"})
+	if _, ok := other.get(ctx, first.ID); !ok {
+		t.Error("сброс расписания удалил пользователя из кэша")
+	}
+
+	changed.Invalidate(ctx, first.ID)
+	if _, ok := other.get(ctx, first.ID); ok {
+		t.Error("измененный пользователь остался в кэше другого экземпляра")
+	}
+	if _, ok := other.get(ctx, second.ID); !ok {
+		t.Error("сброшен кэш другого пользователя")
+	}
+
+	// Сообщение "все" (после обрыва подписки) очищает кэш целиком
+	invalidator.Publish(ctx, cache.Invalidation{})
+	if _, ok := other.get(ctx, second.ID); ok {
+		t.Error("кэш не очищен после сброса всего")
+	}
+}
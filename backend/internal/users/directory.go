@@ -0,0 +1,150 @@
+package users
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/csv"
+	"fmt"
+	"io"
+	"log"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/Ultrahd-dev/student-schedule-app/backend/internal/apperr"
+)
+
+// ErrInvalidDirectory означает некорректный файл справочника преподавателей
+var ErrInvalidDirectory = apperr.New(apperr.ErrValidation, "некорректный справочник преподавателей")
+
+// directoryColumns названия колонок справочника в строке заголовка (в нижнем регистре)
+var directoryColumns = map[string][]string{
+	"full_name":  {"фио", "преподаватель", "ф.и.о.", "full_name"},
+	"department": {"кафедра", "подразделение", "цикловая комиссия", "department"},
+	"position":   {"должность", "position"},
+}
+
+// ReadTeacherDirectoryCSV читает строки справочника из CSV. Разделитель - запятая
+// или точка с запятой (так сохраняет CSV русская версия Excel).
+func ReadTeacherDirectoryCSV(r io.Reader) ([][]string, error) {
+	return readCSV(r, ErrInvalidDirectory)
+}
+
+// readCSV читает строки таблицы CSV из Excel; ошибки формата оборачивают invalid
+func readCSV(r io.Reader, invalid error) ([][]string, error) {
+	data, err := io.ReadAll(r)
+	if err != nil {
+		return nil, fmt.Errorf("ошибка чтения файла: %w", err)
+	}
+	data = bytes.TrimPrefix(data, []byte("\xef\xbb\xbf")) // BOM Excel
+	if !utf8.Valid(data) {
+		return nil, fmt.Errorf("%w: ожидается файл в кодировке UTF-8", invalid)
+	}
+
+	firstLine, _, _ := bufio.NewReader(bytes.NewReader(data)).ReadLine()
+	reader := csv.NewReader(bytes.NewReader(data))
+	if strings.Count(string(firstLine), ";") > strings.Count(string(firstLine), ",") {
+		reader.Comma = ';'
+	}
+	reader.FieldsPerRecord = -1
+	reader.TrimLeadingSpace = true
+
+	records, err := reader.ReadAll()
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", invalid, err)
+	}
+	return records, nil
+}
+
+// ParseTeacherDirectory разбирает строки таблицы со списком преподавателей.
+// Колонки определяются по строке заголовка (ФИО, кафедра, должность); без
+// заголовка используются первые три колонки в этом порядке. Пустые строки
+// пропускаются, при повторе ФИО используется последняя строка.
+func ParseTeacherDirectory(records [][]string) ([]DirectoryTeacher, error) {
+	columns := map[string]int{"full_name": 0, "department": 1, "position": 2}
+	firstLine := 1 // Номер строки файла для первой записи (для сообщений об ошибках)
+	if len(records) > 0 {
+		if header, ok := csvHeader(records[0], directoryColumns, "full_name"); ok {
+			columns = header
+			records = records[1:]
+			firstLine = 2
+		}
+	}
+
+	var teachers []DirectoryTeacher
+	index := make(map[string]int)
+	for i, record := range records {
+		cell := func(column string) string {
+			j, ok := columns[column]
+			if !ok || j >= len(record) {
+				return ""
+			}
+			return strings.Join(strings.Fields(record[j]), " ")
+		}
+
+		teacher := DirectoryTeacher{
+			FullName:   cell("full_name"),
+			Department: cell("department"),
+			Position:   cell("position"),
+		}
+		if teacher.FullName == "" {
+			continue
+		}
+		if len(nameParts(teacher.FullName)) < 2 {
+			return nil, fmt.Errorf("%w: строка %d: ожидается ФИО полностью, получено %q", ErrInvalidDirectory, i+firstLine, teacher.FullName)
+		}
+		if utf8.RuneCountInString(teacher.FullName) > 255 ||
+			utf8.RuneCountInString(teacher.Department) > 100 || utf8.RuneCountInString(teacher.Position) > 100 {
+			return nil, fmt.Errorf("%w: строка %d: слишком длинное значение", ErrInvalidDirectory, i+firstLine)
+		}
+
+		if j, ok := index[teacher.FullName]; ok {
+			teachers[j] = teacher
+			continue
+		}
+		index[teacher.FullName] = len(teachers)
+		teachers = append(teachers, teacher)
+	}
+
+	if len(teachers) == 0 {
+		return nil, fmt.Errorf("%w: файл не содержит ни одного преподавателя", ErrInvalidDirectory)
+	}
+	return teachers, nil
+}
+
+// csvHeader находит колонки columnNames в строке заголовка. Возвращает false,
+// если строка не заголовок (нет обязательной колонки required).
+func csvHeader(record []string, columnNames map[string][]string, required string) (map[string]int, bool) {
+	columns := make(map[string]int)
+	for i, cell := range record {
+		cell = strings.ToLower(strings.TrimSpace(cell))
+		for column, names := range columnNames {
+			for _, name := range names {
+				if _, found := columns[column]; !found && cell == name {
+					columns[column] = i
+				}
+			}
+		}
+	}
+
+	_, ok := columns[required]
+	return columns, ok
+}
+
+// ImportTeacherDirectory загружает официальный список преподавателей в справочник
+// колледжа. replace удаляет из справочника преподавателей, которых нет в списке
+// (например, уволившихся); иначе список только добавляется к справочнику.
+func (s *Service) ImportTeacherDirectory(ctx context.Context, teachers []DirectoryTeacher, replace bool) (*DirectoryImportResult, error) {
+	if len(teachers) == 0 {
+		return nil, fmt.Errorf("%w: список преподавателей пуст", ErrInvalidDirectory)
+	}
+
+	result, err := s.repo.ImportTeacherDirectory(ctx, teachers, replace)
+	if err != nil {
+		return nil, fmt.Errorf("ошибка сохранения справочника преподавателей: %w", err)
+	}
+
+	log.Printf("Справочник преподавателей загружен: %d в списке, добавлено %d, обновлено %d, удалено %d",
+		len(teachers), result.Created, result.Updated, result.Removed)
+	return result, nil
+}
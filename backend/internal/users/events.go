@@ -0,0 +1,42 @@
+package users
+
+import (
+	"context"
+
+	"github.com/Ultrahd-dev/student-schedule-app/backend/internal/outbox"
+	"github.com/Ultrahd-dev/student-schedule-app/backend/internal/txn"
+)
+
+// EventWriter записывает доменные события в транзакции из контекста
+type EventWriter interface {
+	Add(ctx context.Context, event outbox.Event) error
+}
+
+// SetOutbox включает запись события user.registered в outbox в той же
+// транзакции, что и создание пользователя
+func (s *Service) SetOutbox(events EventWriter, transactor txn.Transactor) {
+	s.events = events
+	s.transactor = transactor
+}
+
+// createUser создает пользователя и, если outbox настроен, событие о регистрации
+func (s *Service) createUser(ctx context.Context, user *User) error {
+	if s.events == nil {
+		return s.repo.CreateUser(ctx, user)
+	}
+
+	event, err := outbox.NewEvent(outbox.EventUserRegistered, user.ID, outbox.UserRegistered{
+		UserID: user.ID,
+		Role:   string(user.Role),
+	})
+	if err != nil {
+		return err
+	}
+
+	return s.transactor.Do(ctx, func(ctx context.Context) error {
+		if err := s.repo.CreateUser(ctx, user); err != nil {
+			return err
+		}
+		return s.events.Add(ctx, event)
+	})
+}
@@ -38,6 +38,7 @@ type RegisterRequest struct {
 	Faculty       string `json:"faculty,omitempty"`
 	Course        int    `json:"course,omitempty"`
 	StudentNumber string `json:"student_number,omitempty"`
+	Subgroup      int    `json:"subgroup,omitempty"`
 
 	// Поля для преподавателей
 	FullName   string `json:"full_name,omitempty"`
@@ -94,6 +95,7 @@ func (h *AuthHandler) Register(w http.ResponseWriter, r *http.Request) {
 			Faculty:           req.Faculty,
 			Course:            req.Course,
 			StudentNumber:     req.StudentNumber,
+			Subgroup:          req.Subgroup,
 		}
 
 		// Устанавливаем роль
@@ -122,6 +124,7 @@ func (h *AuthHandler) Register(w http.ResponseWriter, r *http.Request) {
 				"faculty":        student.Faculty,
 				"course":         student.Course,
 				"student_number": student.StudentNumber,
+				"subgroup":       student.Subgroup,
 			},
 		}
 
@@ -212,7 +215,7 @@ func (h *AuthHandler) Login(w http.ResponseWriter, r *http.Request) {
 	}
 
 	// Генерируем JWT токен
-	token, err := h.jwtManager.GenerateToken(user.ID, user.Email, string(user.Role))
+	token, err := h.jwtManager.GenerateToken(user.ID, user.Email, string(user.Role), user.CollegeID)
 	if err != nil {
 		log.Printf("Ошибка генерации JWT токена для пользователя %s: %v", user.Email, err)
 		http.Error(w, "Ошибка генерации токена", http.StatusInternalServerError)
@@ -0,0 +1,203 @@
+package users
+
+import (
+	"context"
+	"crypto/rand"
+	"database/sql"
+	"errors"
+	"fmt"
+	"log"
+	"strings"
+	"time"
+
+	"github.com/Ultrahd-dev/student-schedule-app/backend/internal/apperr"
+	"github.com/google/uuid"
+)
+
+// Ошибки приглашений
+var (
+	ErrInvitationRequired = apperr.New(apperr.ErrValidation, "для регистрации требуется код приглашения")
+	ErrInvitationInvalid  = apperr.New(apperr.ErrForbidden, "код приглашения недействителен, истек или исчерпан")
+	ErrInvitationMismatch = apperr.New(apperr.ErrForbidden, "код приглашения выдан для другой роли или группы")
+)
+
+// codeAlphabet символы кодов приглашений и восстановления (без похожих 0/O, 1/I/L)
+const codeAlphabet = "ABCDEFGHJKMNPQRSTUVWXYZ23456789"
+
+// invitationCodeLength длина кода приглашения
+const invitationCodeLength = 10
+
+// CreateInvitationInput параметры нового приглашения
+type CreateInvitationInput struct {
+	Role      Role       // Пусто - любая роль
+	GroupName string     // Пусто - любая группа; задает роль студента
+	MaxUses   int        // 0 - без ограничения
+	ExpiresAt *time.Time // nil - бессрочно
+}
+
+// RequireInvitations включает обязательную регистрацию по приглашениям
+func (s *Service) RequireInvitations(required bool) {
+	s.invitationRequired = required
+}
+
+// CreateInvitation выпускает новый код приглашения
+func (s *Service) CreateInvitation(ctx context.Context, createdBy uuid.UUID, input CreateInvitationInput) (*Invitation, error) {
+	input.GroupName = strings.TrimSpace(input.GroupName)
+	if input.GroupName != "" && input.Role == "" {
+		input.Role = RoleStudent
+	}
+
+	switch input.Role {
+	case "", RoleStudent:
+	case RoleTeacher:
+		if input.GroupName != "" {
+			return nil, apperr.New(apperr.ErrValidation, "приглашение для преподавателя не привязывается к группе")
+		}
+	default:
+		return nil, apperr.New(apperr.ErrValidation, "по приглашению можно зарегистрировать только студента или преподавателя")
+	}
+	if input.MaxUses < 0 {
+		return nil, apperr.New(apperr.ErrValidation, "число использований не может быть отрицательным")
+	}
+	if input.ExpiresAt != nil && !input.ExpiresAt.After(time.Now()) {
+		return nil, apperr.New(apperr.ErrValidation, "срок действия приглашения уже истек")
+	}
+
+	code, err := generateInvitationCode()
+	if err != nil {
+		return nil, err
+	}
+
+	invitation := &Invitation{
+		ID:        uuid.New(),
+		Code:      code,
+		Role:      input.Role,
+		GroupName: input.GroupName,
+		MaxUses:   input.MaxUses,
+		ExpiresAt: input.ExpiresAt,
+		CreatedBy: &createdBy,
+	}
+	if err := s.repo.CreateInvitation(ctx, invitation); err != nil {
+		return nil, fmt.Errorf("ошибка сохранения приглашения: %w", err)
+	}
+
+	log.Printf("Администратор %s выпустил приглашение %s (роль %q, группа %q, использований %d)",
+		createdBy, invitation.Code, invitation.Role, invitation.GroupName, invitation.MaxUses)
+	return invitation, nil
+}
+
+// ListInvitations возвращает приглашения; activeOnly - только действующие
+func (s *Service) ListInvitations(ctx context.Context, activeOnly bool) ([]Invitation, error) {
+	invitations, err := s.repo.GetInvitations(ctx, activeOnly)
+	if err != nil {
+		return nil, fmt.Errorf("ошибка получения приглашений: %w", err)
+	}
+	return invitations, nil
+}
+
+// RevokeInvitation отзывает приглашение: по нему больше нельзя зарегистрироваться
+func (s *Service) RevokeInvitation(ctx context.Context, id uuid.UUID) (*Invitation, error) {
+	invitation, err := s.repo.RevokeInvitation(ctx, id)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, fmt.Errorf("приглашение %s не найдено или уже отозвано", id)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("ошибка отзыва приглашения: %w", err)
+	}
+	return invitation, nil
+}
+
+// redeemInvitation проверяет код приглашения для регистрации с ролью role и занимает одно использование.
+// Для студента пустая группа заполняется группой из приглашения.
+// Без кода возвращает nil, если приглашения не обязательны.
+func (s *Service) redeemInvitation(ctx context.Context, code string, role Role, groupName *string) (*Invitation, error) {
+	code = NormalizeInvitationCode(code)
+	if code == "" {
+		if s.invitationRequired {
+			return nil, ErrInvitationRequired
+		}
+		return nil, nil
+	}
+
+	invitation, err := s.repo.GetInvitationByCode(ctx, code)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, ErrInvitationInvalid
+	}
+	if err != nil {
+		return nil, fmt.Errorf("ошибка проверки приглашения: %w", err)
+	}
+	if !invitation.Active(time.Now()) {
+		return nil, ErrInvitationInvalid
+	}
+
+	if invitation.Role != "" && invitation.Role != role {
+		return nil, ErrInvitationMismatch
+	}
+	if invitation.GroupName != "" {
+		if strings.TrimSpace(*groupName) == "" {
+			*groupName = invitation.GroupName
+		} else if !strings.EqualFold(strings.TrimSpace(*groupName), invitation.GroupName) {
+			return nil, ErrInvitationMismatch
+		}
+	}
+
+	// Счетчик проверяется повторно в UPDATE: код могли исчерпать параллельной регистрацией
+	ok, err := s.repo.UseInvitation(ctx, invitation.ID)
+	if err != nil {
+		return nil, fmt.Errorf("ошибка использования приглашения: %w", err)
+	}
+	if !ok {
+		return nil, ErrInvitationInvalid
+	}
+
+	return invitation, nil
+}
+
+// completeInvitation привязывает приглашение к зарегистрированному пользователю
+// либо, если регистрация не удалась (user == nil), возвращает занятое использование
+func (s *Service) completeInvitation(ctx context.Context, invitation *Invitation, user *User) {
+	if invitation == nil {
+		return
+	}
+
+	if user == nil {
+		if err := s.repo.ReleaseInvitation(ctx, invitation.ID); err != nil {
+			log.Printf("Ошибка возврата использования приглашения %s: %v", invitation.Code, err)
+		}
+		return
+	}
+
+	if err := s.repo.SetUserInvitation(ctx, user.ID, invitation.ID); err != nil {
+		log.Printf("Ошибка привязки приглашения %s к пользователю %s: %v", invitation.Code, user.Email, err)
+	}
+}
+
+// NormalizeInvitationCode приводит код к виду, в котором он хранится:
+// верхний регистр, без пробелов и дефисов
+func NormalizeInvitationCode(code string) string {
+	return strings.Map(func(r rune) rune {
+		if r == '-' || r == ' ' {
+			return -1
+		}
+		return r
+	}, strings.ToUpper(strings.TrimSpace(code)))
+}
+
+// generateInvitationCode создает случайный код приглашения
+func generateInvitationCode() (string, error) {
+	return randomCode(invitationCodeLength)
+}
+
+// randomCode создает случайный код длины n из символов без похожих друг на друга
+func randomCode(n int) (string, error) {
+	buf := make([]byte, n)
+	if _, err := rand.Read(buf); err != nil {
+		return "", fmt.Errorf("ошибка генерации кода: %w", err)
+	}
+
+	code := make([]byte, n)
+	for i, b := range buf {
+		code[i] = codeAlphabet[int(b)%len(codeAlphabet)]
+	}
+	return string(code), nil
+}
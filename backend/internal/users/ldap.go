@@ -0,0 +1,153 @@
+package users
+
+import (
+	"context"
+	"crypto/rand"
+	"database/sql"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"log"
+	"strings"
+
+	"github.com/Ultrahd-dev/student-schedule-app/backend/internal/apperr"
+	"github.com/Ultrahd-dev/student-schedule-app/backend/internal/ldap"
+)
+
+// LDAPConfig настройки входа через каталог LDAP / Active Directory колледжа
+type LDAPConfig struct {
+	Client *ldap.Client // nil - вход только по локальным паролям
+	// RoleGroups DN групп каталога (memberOf), участники которых получают роль.
+	// При членстве в нескольких группах выбирается admin, затем teacher, затем student.
+	RoleGroups          map[Role][]string
+	DefaultRole         Role   // Роль пользователя вне групп RoleGroups; пусто - вход запрещен
+	NameAttribute       string // Атрибут с ФИО
+	GroupAttribute      string // Атрибут с учебной группой студента
+	DepartmentAttribute string // Атрибут с кафедрой преподавателя
+	PositionAttribute   string // Атрибут с должностью преподавателя
+}
+
+// errLocalAuth означает, что пользователя нужно проверить по локальному паролю
+var errLocalAuth = errors.New("вход по локальному паролю")
+
+// ConfigureLDAP включает вход через каталог LDAP
+func (s *Service) ConfigureLDAP(config LDAPConfig) {
+	s.ldap = config
+}
+
+// authenticateLDAP проверяет пароль в каталоге и возвращает локального пользователя,
+// создавая его при первом входе. errLocalAuth - пользователя нет в каталоге
+// (например, начальный администратор) или каталог недоступен.
+func (s *Service) authenticateLDAP(ctx context.Context, email, password string) (*User, error) {
+	entry, err := s.ldap.Client.Authenticate(ctx, email, password)
+	switch {
+	case errors.Is(err, ldap.ErrUserNotFound):
+		return nil, errLocalAuth
+	case errors.Is(err, ldap.ErrInvalidCredentials):
+		return nil, fmt.Errorf("invalid credentials: %w", apperr.ErrUnauthorized)
+	case err != nil:
+		// Созданные из каталога пользователи не знают локального пароля и войти не смогут
+		log.Printf("Каталог LDAP недоступен, вход %s по локальному паролю: %v", email, err)
+		return nil, errLocalAuth
+	}
+
+	user, err := s.repo.GetUserByEmail(ctx, email)
+	switch {
+	case errors.Is(err, sql.ErrNoRows):
+		// Удаленного администратором пользователя каталог не создает заново
+		deleted, err := s.repo.IsEmailDeleted(ctx, email)
+		if err != nil {
+			return nil, err
+		}
+		if deleted {
+			return nil, fmt.Errorf("user account is deleted: %w", apperr.ErrUnauthorized)
+		}
+		return s.provisionLDAPUser(ctx, email, entry)
+	case err != nil:
+		return nil, err
+	}
+	return user, nil
+}
+
+// provisionLDAPUser создает пользователя из записи каталога при первом входе.
+// Роль определяется по группам каталога, профиль заполняется атрибутами записи.
+// Пользователь и профиль создаются в одной транзакции, если настроен менеджер транзакций.
+// Приглашение не требуется: доступ уже выдан каталогом колледжа.
+func (s *Service) provisionLDAPUser(ctx context.Context, email string, entry *ldap.Entry) (*User, error) {
+	role := s.ldapRole(entry)
+	if role == "" {
+		return nil, fmt.Errorf("учетная запись каталога %s не входит в группы с доступом: %w", entry.DN, apperr.ErrUnauthorized)
+	}
+
+	fullName := strings.TrimSpace(entry.Get(s.ldap.NameAttribute))
+	groupName := strings.TrimSpace(entry.Get(s.ldap.GroupAttribute))
+	switch {
+	case role == RoleStudent && groupName == "":
+		return nil, fmt.Errorf("в каталоге не указана группа студента %s: %w", entry.DN, apperr.ErrUnauthorized)
+	case role == RoleTeacher && fullName == "":
+		return nil, fmt.Errorf("в каталоге не указано ФИО преподавателя %s: %w", entry.DN, apperr.ErrUnauthorized)
+	}
+
+	// Пароль хранится в каталоге; локальный пароль случайный и никому не известен
+	secret := make([]byte, 32)
+	if _, err := rand.Read(secret); err != nil {
+		return nil, fmt.Errorf("failed to generate password: %w", err)
+	}
+	var user *User
+	create := func(ctx context.Context) error {
+		var err error
+		user, err = s.RegisterUser(ctx, RegisterUserInput{
+			Email:    email,
+			Password: hex.EncodeToString(secret),
+			Role:     role,
+		})
+		if err != nil {
+			return err
+		}
+
+		switch role {
+		case RoleStudent:
+			err = s.repo.CreateStudent(ctx, &Student{UserID: user.ID, FullName: fullName, GroupName: groupName, Course: 1})
+		case RoleTeacher:
+			err = s.repo.CreateTeacher(ctx, &Teacher{
+				UserID:     user.ID,
+				FullName:   fullName,
+				Department: strings.TrimSpace(entry.Get(s.ldap.DepartmentAttribute)),
+				Position:   strings.TrimSpace(entry.Get(s.ldap.PositionAttribute)),
+			})
+		}
+		if err != nil {
+			return fmt.Errorf("failed to create %s profile: %w", role, err)
+		}
+		return nil
+	}
+	// Пользователь без профиля иначе остался бы в базе и при следующем входе
+	// считался бы уже созданным
+	var err error
+	if s.transactor != nil {
+		err = s.transactor.Do(ctx, create)
+	} else {
+		err = create(ctx)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	log.Printf("Пользователь %s (%s) создан из каталога LDAP: %s", email, role, entry.DN)
+	return user, nil
+}
+
+// ldapRole возвращает роль пользователя каталога по его группам
+func (s *Service) ldapRole(entry *ldap.Entry) Role {
+	memberOf := entry.Values("memberOf")
+	for _, role := range []Role{RoleAdmin, RoleTeacher, RoleStudent} {
+		for _, group := range s.ldap.RoleGroups[role] {
+			for _, member := range memberOf {
+				if strings.EqualFold(strings.TrimSpace(group), member) {
+					return role
+				}
+			}
+		}
+	}
+	return s.ldap.DefaultRole
+}
@@ -25,16 +25,26 @@ type User struct {
 	Role      Role       `db:"role"`
 	CreatedAt time.Time  `db:"created_at"`
 	LastLogin *time.Time `db:"last_login"` // Pointer to handle NULL values
-	IsActive  bool       `db:"is_active"`
+	IsActive  bool       // Не удален (deleted_at пусто)
+	CollegeID uuid.UUID  `db:"college_id"`
+	DeletedAt *time.Time `db:"deleted_at"` // Время мягкого удаления (nil - действующий пользователь)
+	// PasswordChangeRequired пароль выдан администратором и должен быть сменен после входа
+	PasswordChangeRequired bool `db:"password_change_required"`
+	LoginAlerts            bool `db:"login_alerts"` // Уведомлять о входе с нового устройства
+	// CalendarTokenVersion версия ссылок на личный календарь; ссылки другой версии недействительны
+	CalendarTokenVersion int `db:"calendar_token_version"`
+	FormatPreferences        // Язык и форматы даты и времени в уведомлениях и отчетах, часовой пояс
 }
 
 // Student представляет дополнительную информацию для студента
 type Student struct {
 	UserID        uuid.UUID `db:"user_id"`
+	FullName      string    `db:"full_name"`
 	GroupName     string    `db:"group_name"`
 	Faculty       string    `db:"faculty"`
 	Course        int       `db:"course"`
 	StudentNumber string    `db:"student_number"`
+	Subgroup      int       `db:"subgroup"` // Подгруппа (0 - не выбрана)
 }
 
 // Teacher представляет дополнительную информацию для преподавателя
@@ -45,3 +55,76 @@ type Teacher struct {
 	Position   string    `db:"position"`
 	TeacherID  string    `db:"teacher_id"`
 }
+
+// Статусы заявки преподавателя на вариант своего имени
+const (
+	TeacherNameClaimPending  = "pending"  // Ожидает подтверждения администратором
+	TeacherNameClaimApproved = "approved" // Подтверждено (автоматически или администратором)
+	TeacherNameClaimRejected = "rejected" // Отклонено
+)
+
+// TeacherNameClaim связывает преподавателя с вариантом написания его имени
+// в таблицах расписания (например, "Иванов И.И." для "Иванов Иван Иванович")
+type TeacherNameClaim struct {
+	ID          uuid.UUID  `db:"id"`
+	TeacherID   uuid.UUID  `db:"teacher_id"`   // Пользователь-преподаватель
+	TeacherName string     `db:"full_name"`    // ФИО из профиля преподавателя
+	ScrapedName string     `db:"scraped_name"` // Имя в том виде, в котором оно встречается в расписании
+	Status      string     `db:"status"`       // TeacherNameClaim*
+	ReviewedBy  *uuid.UUID `db:"reviewed_by"`
+	ReviewedAt  *time.Time `db:"reviewed_at"`
+	CreatedAt   time.Time  `db:"created_at"`
+}
+
+// DirectoryTeacher преподаватель из справочника колледжа - официального списка,
+// загружаемого администратором из таблицы. Запись справочника не связана с
+// пользователем: преподаватель может быть не зарегистрирован в приложении.
+type DirectoryTeacher struct {
+	ID         uuid.UUID `db:"id"`
+	FullName   string    `db:"full_name"`
+	Department string    `db:"department"`
+	Position   string    `db:"position"`
+	UpdatedAt  time.Time `db:"updated_at"`
+}
+
+// DirectoryImportResult итог загрузки справочника преподавателей
+type DirectoryImportResult struct {
+	Created int // Добавлено новых преподавателей
+	Updated int // Обновлены кафедра или должность
+	Removed int // Удалено отсутствующих в списке (при полной замене)
+}
+
+// Invitation код приглашения для регистрации
+type Invitation struct {
+	ID        uuid.UUID  `db:"id"`
+	Code      string     `db:"code"`
+	Role      Role       `db:"role"`       // Пусто - любая роль
+	GroupName string     `db:"group_name"` // Пусто - любая группа (только для студентов)
+	MaxUses   int        `db:"max_uses"`   // 0 - без ограничения
+	UsedCount int        `db:"used_count"`
+	ExpiresAt *time.Time `db:"expires_at"`
+	CreatedBy *uuid.UUID `db:"created_by"`
+	CreatedAt time.Time  `db:"created_at"`
+	RevokedAt *time.Time `db:"revoked_at"`
+}
+
+// Active проверяет, что приглашение не отозвано, не истекло и не исчерпано
+func (i *Invitation) Active(now time.Time) bool {
+	if i.RevokedAt != nil {
+		return false
+	}
+	if i.ExpiresAt != nil && !now.Before(*i.ExpiresAt) {
+		return false
+	}
+	return i.MaxUses == 0 || i.UsedCount < i.MaxUses
+}
+
+// TwoFactor настройки двухфакторной аутентификации пользователя
+type TwoFactor struct {
+	UserID       uuid.UUID  `db:"user_id"`
+	Secret       string     `db:"secret"` // Секрет TOTP в base32
+	Enabled      bool       `db:"enabled"`
+	LastUsedStep int64      `db:"last_used_step"` // Период последнего принятого кода
+	EnabledAt    *time.Time `db:"enabled_at"`
+	CreatedAt    time.Time  `db:"created_at"`
+}
@@ -0,0 +1,105 @@
+package users
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/Ultrahd-dev/student-schedule-app/backend/internal/apperr"
+	"github.com/Ultrahd-dev/student-schedule-app/backend/internal/clock"
+	"github.com/google/uuid"
+)
+
+// ErrInvalidFormatPreferences означает неподдерживаемый язык, формат даты/времени или часовой пояс
+var ErrInvalidFormatPreferences = apperr.New(apperr.ErrValidation, "неподдерживаемый язык, формат даты и времени или часовой пояс")
+
+// DefaultLocale язык пользователя по умолчанию
+const DefaultLocale = "ru"
+
+// locales поддерживаемые языки и их форматы по умолчанию. Тексты уведомлений
+// пока только на русском; язык определяет форматы, которые пользователь не выбрал.
+var locales = map[string]clock.Formats{
+	"ru": {Date: clock.DateLayout, Clock: clock.ClockLayout},
+	"en": {Date: "01/02/2006", Clock: "3:04 PM"},
+}
+
+// dateFormats форматы даты, которые может выбрать пользователь
+var dateFormats = map[string]string{
+	"dd.mm.yyyy": clock.DateLayout,
+	"dd/mm/yyyy": "02/01/2006",
+	"mm/dd/yyyy": "01/02/2006",
+	"yyyy-mm-dd": "2006-01-02",
+}
+
+// timeFormats форматы времени, которые может выбрать пользователь
+var timeFormats = map[string]string{
+	"24h": clock.ClockLayout,
+	"12h": "3:04 PM",
+}
+
+// FormatPreferences язык, форматы даты и времени и часовой пояс пользователя.
+// Пустой формат - формат по умолчанию для языка.
+type FormatPreferences struct {
+	Locale     string `db:"locale"`      // ru или en
+	DateFormat string `db:"date_format"` // dd.mm.yyyy, dd/mm/yyyy, mm/dd/yyyy, yyyy-mm-dd или пусто
+	TimeFormat string `db:"time_format"` // 24h, 12h или пусто
+	Timezone   string `db:"timezone"`    // Часовой пояс IANA; пусто - часовой пояс колледжа
+}
+
+// Formats возвращает раскладки даты и времени для текстов пользователю
+func (p FormatPreferences) Formats() clock.Formats {
+	formats, ok := locales[p.Locale]
+	if !ok {
+		formats = clock.DefaultFormats
+	}
+	if layout, ok := dateFormats[p.DateFormat]; ok {
+		formats.Date = layout
+	}
+	if layout, ok := timeFormats[p.TimeFormat]; ok {
+		formats.Clock = layout
+	}
+	return formats
+}
+
+// Location возвращает часовой пояс пользователя или college, если он не выбран
+func (p FormatPreferences) Location(college *time.Location) *time.Location {
+	if p.Timezone == "" {
+		return college
+	}
+	loc, err := clock.LoadLocation(p.Timezone)
+	if err != nil {
+		return college
+	}
+	return loc
+}
+
+// Validate проверяет, что язык, форматы и часовой пояс поддерживаются
+func (p FormatPreferences) Validate() error {
+	if _, ok := locales[p.Locale]; !ok {
+		return fmt.Errorf("%w: язык %q (поддерживаются ru, en)", ErrInvalidFormatPreferences, p.Locale)
+	}
+	if _, ok := dateFormats[p.DateFormat]; !ok && p.DateFormat != "" {
+		return fmt.Errorf("%w: формат даты %q (поддерживаются dd.mm.yyyy, dd/mm/yyyy, mm/dd/yyyy, yyyy-mm-dd)",
+			ErrInvalidFormatPreferences, p.DateFormat)
+	}
+	if _, ok := timeFormats[p.TimeFormat]; !ok && p.TimeFormat != "" {
+		return fmt.Errorf("%w: формат времени %q (поддерживаются 24h, 12h)", ErrInvalidFormatPreferences, p.TimeFormat)
+	}
+	if p.Timezone != "" {
+		if _, err := clock.LoadLocation(p.Timezone); err != nil {
+			return fmt.Errorf("%w: %v", ErrInvalidFormatPreferences, err)
+		}
+	}
+	return nil
+}
+
+// SetFormatPreferences сохраняет язык, форматы даты и времени и часовой пояс пользователя
+func (s *Service) SetFormatPreferences(ctx context.Context, userID uuid.UUID, prefs FormatPreferences) error {
+	if prefs.Locale == "" {
+		prefs.Locale = DefaultLocale
+	}
+	if err := prefs.Validate(); err != nil {
+		return err
+	}
+	return s.repo.SetFormatPreferences(ctx, userID, prefs)
+}
@@ -8,13 +8,18 @@ import (
 	"fmt"
 	"time"
 
+	"github.com/Ultrahd-dev/student-schedule-app/backend/internal/apperr"
+	"github.com/Ultrahd-dev/student-schedule-app/backend/internal/tenant"
+	"github.com/Ultrahd-dev/student-schedule-app/backend/internal/txn"
 	"github.com/google/uuid"
+	"github.com/lib/pq"
 	"golang.org/x/crypto/bcrypt"
 )
 
 // Repository предоставляет доступ к хранению пользователей
 type Repository struct {
-	db *sql.DB
+	db    *sql.DB
+	cache *UserCache // Кэш GetUserByID; nil - без кэша
 }
 
 // NewRepository создает новый репозиторий пользователей
@@ -22,15 +27,24 @@ func NewRepository(db *sql.DB) *Repository {
 	return &Repository{db: db}
 }
 
-// CreateUser создает нового пользователя в базе данных
+// SetCache включает кэширование пользователей, получаемых по ID
+func (r *Repository) SetCache(cache *UserCache) {
+	r.cache = cache
+}
+
+// CreateUser создает нового пользователя в базе данных в колледже из контекста
+// (в транзакции из контекста, если она есть, см. txn.Manager)
 func (r *Repository) CreateUser(ctx context.Context, user *User) error {
 	query := `
-		INSERT INTO users (id, email, password_hash, role, is_active)
-		VALUES ($1, $2, $3, $4, $5)
+		INSERT INTO users (id, email, password_hash, role, college_id, password_change_required)
+		VALUES ($1, $2, $3, $4, $5, $6)
 		RETURNING created_at`
 
+	user.CollegeID = tenant.CollegeID(ctx)
+
 	var createdAt time.Time
-	err := r.db.QueryRowContext(ctx, query, user.ID, user.Email, user.Password, user.Role, user.IsActive).
+	err := txn.From(ctx, r.db).QueryRowContext(ctx, query, user.ID, user.Email, user.Password, user.Role, user.CollegeID,
+		user.PasswordChangeRequired).
 		Scan(&createdAt)
 
 	if err != nil {
@@ -38,15 +52,19 @@ func (r *Repository) CreateUser(ctx context.Context, user *User) error {
 	}
 
 	user.CreatedAt = createdAt
+	user.IsActive = true
+	user.LoginAlerts = true
+	user.Locale = DefaultLocale
 	return nil
 }
 
-// GetUserByEmail получает пользователя по email
+// GetUserByEmail получает действующего (не удаленного) пользователя по email
 func (r *Repository) GetUserByEmail(ctx context.Context, email string) (*User, error) {
 	query := `
-		SELECT id, email, password_hash, role, created_at, last_login, is_active
+		SELECT id, email, password_hash, role, created_at, last_login, college_id, password_change_required, login_alerts,
+		       calendar_token_version, locale, date_format, time_format, timezone
 		FROM users
-		WHERE email = $1`
+		WHERE email = $1 AND deleted_at IS NULL`
 
 	user := &User{}
 	err := r.db.QueryRowContext(ctx, query, email).Scan(
@@ -56,7 +74,14 @@ func (r *Repository) GetUserByEmail(ctx context.Context, email string) (*User, e
 		&user.Role,
 		&user.CreatedAt,
 		&user.LastLogin,
-		&user.IsActive,
+		&user.CollegeID,
+		&user.PasswordChangeRequired,
+		&user.LoginAlerts,
+		&user.CalendarTokenVersion,
+		&user.Locale,
+		&user.DateFormat,
+		&user.TimeFormat,
+		&user.Timezone,
 	)
 
 	if err != nil {
@@ -66,15 +91,25 @@ func (r *Repository) GetUserByEmail(ctx context.Context, email string) (*User, e
 		return nil, fmt.Errorf("failed to get user by email: %w", err)
 	}
 
+	user.IsActive = true
 	return user, nil
 }
 
-// GetUserByID получает пользователя по ID
+// GetUserByID получает действующего (не удаленного) пользователя по ID. Если включен
+// кэш, пользователь может быть получен из него и возвращается без хэша пароля
+// (см. GetPasswordHash).
 func (r *Repository) GetUserByID(ctx context.Context, id uuid.UUID) (*User, error) {
+	if r.cache != nil {
+		if user, ok := r.cache.get(ctx, id); ok {
+			return user, nil
+		}
+	}
+
 	query := `
-		SELECT id, email, password_hash, role, created_at, last_login, is_active
+		SELECT id, email, password_hash, role, created_at, last_login, college_id, password_change_required, login_alerts,
+		       calendar_token_version, locale, date_format, time_format, timezone
 		FROM users
-		WHERE id = $1`
+		WHERE id = $1 AND deleted_at IS NULL`
 
 	user := &User{}
 	err := r.db.QueryRowContext(ctx, query, id).Scan(
@@ -84,7 +119,14 @@ func (r *Repository) GetUserByID(ctx context.Context, id uuid.UUID) (*User, erro
 		&user.Role,
 		&user.CreatedAt,
 		&user.LastLogin,
-		&user.IsActive,
+		&user.CollegeID,
+		&user.PasswordChangeRequired,
+		&user.LoginAlerts,
+		&user.CalendarTokenVersion,
+		&user.Locale,
+		&user.DateFormat,
+		&user.TimeFormat,
+		&user.Timezone,
 	)
 
 	if err != nil {
@@ -93,17 +135,36 @@ func (r *Repository) GetUserByID(ctx context.Context, id uuid.UUID) (*User, erro
 		}
 		return nil, fmt.Errorf("failed to get user by ID: %w", err)
 	}
+	user.IsActive = true
 
+	if r.cache != nil {
+		r.cache.set(ctx, *user)
+	}
 	return user, nil
 }
 
-// CreateStudent создает профиль студента
+// GetPasswordHash получает хэш пароля пользователя в обход кэша
+func (r *Repository) GetPasswordHash(ctx context.Context, userID uuid.UUID) (string, error) {
+	var hash string
+	err := r.db.QueryRowContext(ctx, `SELECT password_hash FROM users WHERE id = $1`, userID).Scan(&hash)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return "", fmt.Errorf("user not found: %w", err)
+		}
+		return "", fmt.Errorf("failed to get password hash: %w", err)
+	}
+	return hash, nil
+}
+
+// CreateStudent создает профиль студента (в транзакции из контекста, если она есть)
 func (r *Repository) CreateStudent(ctx context.Context, student *Student) error {
+	// Пустой номер студенческого сохраняется как NULL, чтобы не нарушать уникальность
 	query := `
-		INSERT INTO students (user_id, group_name, faculty, course, student_number)
-		VALUES ($1, $2, $3, $4, $5)`
+		INSERT INTO students (user_id, full_name, group_name, faculty, course, student_number, subgroup)
+		VALUES ($1, $2, $3, $4, $5, NULLIF($6, ''), $7)`
 
-	_, err := r.db.ExecContext(ctx, query, student.UserID, student.GroupName, student.Faculty, student.Course, student.StudentNumber)
+	_, err := txn.From(ctx, r.db).ExecContext(ctx, query, student.UserID, student.FullName, student.GroupName, student.Faculty, student.Course,
+		student.StudentNumber, student.Subgroup)
 	if err != nil {
 		return fmt.Errorf("failed to create student profile: %w", err)
 	}
@@ -113,11 +174,12 @@ func (r *Repository) CreateStudent(ctx context.Context, student *Student) error
 
 // CreateTeacher создает профиль преподавателя
 func (r *Repository) CreateTeacher(ctx context.Context, teacher *Teacher) error {
+	// Пустой ID преподавателя сохраняется как NULL, чтобы не нарушать уникальность
 	query := `
 		INSERT INTO teachers (user_id, full_name, department, position, teacher_id)
-		VALUES ($1, $2, $3, $4, $5)`
+		VALUES ($1, $2, $3, $4, NULLIF($5, ''))`
 
-	_, err := r.db.ExecContext(ctx, query, teacher.UserID, teacher.FullName, teacher.Department, teacher.Position, teacher.TeacherID)
+	_, err := txn.From(ctx, r.db).ExecContext(ctx, query, teacher.UserID, teacher.FullName, teacher.Department, teacher.Position, teacher.TeacherID)
 	if err != nil {
 		return fmt.Errorf("failed to create teacher profile: %w", err)
 	}
@@ -125,15 +187,70 @@ func (r *Repository) CreateTeacher(ctx context.Context, teacher *Teacher) error
 	return nil
 }
 
+// GetStudentByUserID получает профиль студента по ID пользователя
+func (r *Repository) GetStudentByUserID(ctx context.Context, userID uuid.UUID) (*Student, error) {
+	query := `
+		SELECT user_id, full_name, group_name, COALESCE(faculty, ''), COALESCE(course, 0), COALESCE(student_number, ''),
+		       subgroup
+		FROM students
+		WHERE user_id = $1`
+
+	student := &Student{}
+	err := r.db.QueryRowContext(ctx, query, userID).Scan(
+		&student.UserID,
+		&student.FullName,
+		&student.GroupName,
+		&student.Faculty,
+		&student.Course,
+		&student.StudentNumber,
+		&student.Subgroup,
+	)
+
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, fmt.Errorf("student profile not found: %w", err)
+		}
+		return nil, fmt.Errorf("failed to get student profile: %w", err)
+	}
+
+	return student, nil
+}
+
+// GetTeacherByUserID получает профиль преподавателя по ID пользователя
+func (r *Repository) GetTeacherByUserID(ctx context.Context, userID uuid.UUID) (*Teacher, error) {
+	query := `
+		SELECT user_id, full_name, COALESCE(department, ''), COALESCE(position, ''), COALESCE(teacher_id, '')
+		FROM teachers
+		WHERE user_id = $1`
+
+	teacher := &Teacher{}
+	err := r.db.QueryRowContext(ctx, query, userID).Scan(
+		&teacher.UserID,
+		&teacher.FullName,
+		&teacher.Department,
+		&teacher.Position,
+		&teacher.TeacherID,
+	)
+
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, fmt.Errorf("teacher profile not found: %w", err)
+		}
+		return nil, fmt.Errorf("failed to get teacher profile: %w", err)
+	}
+
+	return teacher, nil
+}
+
 // GetStudentsByGroup получает всех студентов определенной группы
 func (r *Repository) GetStudentsByGroup(ctx context.Context, groupName string) ([]uuid.UUID, error) {
 	query := `
 		SELECT s.user_id
 		FROM students s
 		JOIN users u ON s.user_id = u.id
-		WHERE s.group_name = $1 AND u.is_active = true`
+		WHERE s.group_name = $1 AND u.deleted_at IS NULL AND u.college_id = $2`
 
-	rows, err := r.db.QueryContext(ctx, query, groupName)
+	rows, err := r.db.QueryContext(ctx, query, groupName, tenant.CollegeID(ctx))
 	if err != nil {
 		return nil, fmt.Errorf("failed to get students by group: %w", err)
 	}
@@ -156,23 +273,943 @@ func (r *Repository) GetStudentsByGroup(ctx context.Context, groupName string) (
 	return studentIDs, nil
 }
 
+// GetStudentsByGroups получает активных студентов нескольких групп одним запросом.
+// В результате есть только группы, в которых нашлись студенты.
+func (r *Repository) GetStudentsByGroups(ctx context.Context, groupNames []string) (map[string][]uuid.UUID, error) {
+	studentIDs := make(map[string][]uuid.UUID)
+	if len(groupNames) == 0 {
+		return studentIDs, nil
+	}
+
+	query := `
+		SELECT s.group_name, s.user_id
+		FROM students s
+		JOIN users u ON s.user_id = u.id
+		WHERE s.group_name = ANY($1) AND u.deleted_at IS NULL AND u.college_id = $2`
+
+	rows, err := r.db.QueryContext(ctx, query, pq.Array(groupNames), tenant.CollegeID(ctx))
+	if err != nil {
+		return nil, fmt.Errorf("failed to get students by groups: %w", err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var groupName string
+		var studentID uuid.UUID
+		if err := rows.Scan(&groupName, &studentID); err != nil {
+			return nil, fmt.Errorf("failed to scan student ID: %w", err)
+		}
+		studentIDs[groupName] = append(studentIDs[groupName], studentID)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating rows: %w", err)
+	}
+
+	return studentIDs, nil
+}
+
+// GetGroupRoster получает профили активных студентов группы, упорядоченные по ФИО
+func (r *Repository) GetGroupRoster(ctx context.Context, groupName string) ([]Student, error) {
+	query := `
+		SELECT s.user_id, s.full_name, s.group_name, COALESCE(s.faculty, ''), COALESCE(s.course, 0),
+			COALESCE(s.student_number, ''), s.subgroup
+		FROM students s
+		JOIN users u ON s.user_id = u.id
+		WHERE s.group_name = $1 AND u.deleted_at IS NULL AND u.college_id = $2
+		ORDER BY s.full_name, s.student_number`
+
+	rows, err := r.db.QueryContext(ctx, query, groupName, tenant.CollegeID(ctx))
+	if err != nil {
+		return nil, fmt.Errorf("failed to get group roster: %w", err)
+	}
+	defer rows.Close()
+
+	var students []Student
+	for rows.Next() {
+		var student Student
+		err := rows.Scan(&student.UserID, &student.FullName, &student.GroupName, &student.Faculty,
+			&student.Course, &student.StudentNumber, &student.Subgroup)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan student: %w", err)
+		}
+		students = append(students, student)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating rows: %w", err)
+	}
+
+	return students, nil
+}
+
+// UpdatePassword сохраняет новый хэш пароля пользователя; временный пароль
+// после этого больше не требует смены
+func (r *Repository) UpdatePassword(ctx context.Context, userID uuid.UUID, passwordHash string) error {
+	_, err := r.db.ExecContext(ctx, `
+		UPDATE users SET password_hash = $2, password_change_required = false WHERE id = $1`, userID, passwordHash)
+	if err != nil {
+		return fmt.Errorf("failed to update password: %w", err)
+	}
+	r.invalidate(ctx, userID)
+	return nil
+}
+
+// UpdateRole изменяет роль пользователя колледжа из контекста
+func (r *Repository) UpdateRole(ctx context.Context, userID uuid.UUID, role Role) error {
+	result, err := r.db.ExecContext(ctx, `
+		UPDATE users SET role = $2
+		WHERE id = $1 AND college_id = $3 AND deleted_at IS NULL`, userID, role, tenant.CollegeID(ctx))
+	if err != nil {
+		return fmt.Errorf("failed to update role: %w", err)
+	}
+	if updated, err := result.RowsAffected(); err != nil {
+		return fmt.Errorf("failed to update role: %w", err)
+	} else if updated == 0 {
+		return fmt.Errorf("user %s: %w", userID, apperr.ErrNotFound)
+	}
+	r.invalidate(ctx, userID)
+	return nil
+}
+
+// SetFormatPreferences сохраняет язык, форматы даты и времени и часовой пояс пользователя
+func (r *Repository) SetFormatPreferences(ctx context.Context, userID uuid.UUID, prefs FormatPreferences) error {
+	_, err := r.db.ExecContext(ctx, `UPDATE users SET locale = $2, date_format = $3, time_format = $4, timezone = $5 WHERE id = $1`,
+		userID, prefs.Locale, prefs.DateFormat, prefs.TimeFormat, prefs.Timezone)
+	if err != nil {
+		return fmt.Errorf("failed to update format preferences: %w", err)
+	}
+	r.invalidate(ctx, userID)
+	return nil
+}
+
+// GetFormatPreferences получает язык, форматы даты и времени и часовые пояса пользователей одним запросом.
+// Пользователей, которых нет в результате, нужно считать использующими форматы по умолчанию.
+func (r *Repository) GetFormatPreferences(ctx context.Context, userIDs []uuid.UUID) (map[uuid.UUID]FormatPreferences, error) {
+	prefs := make(map[uuid.UUID]FormatPreferences, len(userIDs))
+	if len(userIDs) == 0 {
+		return prefs, nil
+	}
+
+	ids := make([]string, len(userIDs))
+	for i, id := range userIDs {
+		ids[i] = id.String()
+	}
+	rows, err := r.db.QueryContext(ctx,
+		`SELECT id, locale, date_format, time_format, timezone FROM users WHERE id = ANY($1::uuid[])`, pq.Array(ids))
+	if err != nil {
+		return nil, fmt.Errorf("failed to get format preferences: %w", err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var id uuid.UUID
+		var p FormatPreferences
+		if err := rows.Scan(&id, &p.Locale, &p.DateFormat, &p.TimeFormat, &p.Timezone); err != nil {
+			return nil, fmt.Errorf("failed to scan format preferences: %w", err)
+		}
+		prefs[id] = p
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating rows: %w", err)
+	}
+	return prefs, nil
+}
+
+// SetLoginAlerts включает или отключает уведомления о входе с нового устройства
+func (r *Repository) SetLoginAlerts(ctx context.Context, userID uuid.UUID, enabled bool) error {
+	_, err := r.db.ExecContext(ctx, `UPDATE users SET login_alerts = $2 WHERE id = $1`, userID, enabled)
+	if err != nil {
+		return fmt.Errorf("failed to update login alerts: %w", err)
+	}
+	r.invalidate(ctx, userID)
+	return nil
+}
+
+// ResetCalendarToken увеличивает версию ссылок пользователя на личный календарь
+// и возвращает новую версию
+func (r *Repository) ResetCalendarToken(ctx context.Context, userID uuid.UUID) (int, error) {
+	var version int
+	err := r.db.QueryRowContext(ctx, `
+		UPDATE users SET calendar_token_version = calendar_token_version + 1
+		WHERE id = $1 AND deleted_at IS NULL
+		RETURNING calendar_token_version`, userID).Scan(&version)
+	if err != nil {
+		return 0, fmt.Errorf("failed to reset calendar token: %w", err)
+	}
+	r.invalidate(ctx, userID)
+	return version, nil
+}
+
+// SoftDeleteUser мягко удаляет пользователя колледжа из контекста: он перестает
+// попадать в выборки и не может войти. Вместе с пользователем удаляются его подписки
+// на консультации и подписки студентов на консультации удаленного преподавателя.
+// Возвращает число удаленных подписок.
+func (r *Repository) SoftDeleteUser(ctx context.Context, userID, deletedBy uuid.UUID) (int, error) {
+	query := `
+		WITH deleted AS (
+			UPDATE users SET deleted_at = NOW(), deleted_by = $2
+			WHERE id = $1 AND college_id = $3 AND deleted_at IS NULL
+			RETURNING id
+		), subscriptions AS (
+			DELETE FROM consultation_subscriptions cs
+			USING deleted d
+			WHERE cs.user_id = d.id OR cs.teacher_id = d.id
+			RETURNING 1
+		)
+		SELECT (SELECT COUNT(*) FROM deleted), (SELECT COUNT(*) FROM subscriptions)`
+
+	var deleted, subscriptions int
+	err := r.db.QueryRowContext(ctx, query, userID, deletedBy, tenant.CollegeID(ctx)).Scan(&deleted, &subscriptions)
+	if err != nil {
+		return 0, fmt.Errorf("failed to delete user: %w", err)
+	}
+	if deleted == 0 {
+		return 0, fmt.Errorf("user %s: %w", userID, apperr.ErrNotFound)
+	}
+	r.invalidate(ctx, userID)
+	return subscriptions, nil
+}
+
+// GetDeletedUser получает удаленного пользователя колледжа из контекста
+func (r *Repository) GetDeletedUser(ctx context.Context, userID uuid.UUID) (*User, error) {
+	query := `
+		SELECT id, email, role, created_at, last_login, college_id, deleted_at
+		FROM users
+		WHERE id = $1 AND college_id = $2 AND deleted_at IS NOT NULL`
+
+	user := &User{}
+	err := r.db.QueryRowContext(ctx, query, userID, tenant.CollegeID(ctx)).Scan(
+		&user.ID,
+		&user.Email,
+		&user.Role,
+		&user.CreatedAt,
+		&user.LastLogin,
+		&user.CollegeID,
+		&user.DeletedAt,
+	)
+	if err == sql.ErrNoRows {
+		return nil, fmt.Errorf("deleted user %s: %w", userID, apperr.ErrNotFound)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get deleted user: %w", err)
+	}
+	return user, nil
+}
+
+// RestoreUser восстанавливает удаленного пользователя колледжа из контекста.
+// Удаленные вместе с пользователем подписки не восстанавливаются.
+func (r *Repository) RestoreUser(ctx context.Context, userID uuid.UUID) error {
+	result, err := r.db.ExecContext(ctx, `
+		UPDATE users SET deleted_at = NULL, deleted_by = NULL
+		WHERE id = $1 AND college_id = $2 AND deleted_at IS NOT NULL`, userID, tenant.CollegeID(ctx))
+	if err != nil {
+		return fmt.Errorf("failed to restore user: %w", err)
+	}
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get affected rows: %w", err)
+	}
+	if affected == 0 {
+		return fmt.Errorf("deleted user %s: %w", userID, apperr.ErrNotFound)
+	}
+	r.invalidate(ctx, userID)
+	return nil
+}
+
+// IsEmailDeleted проверяет, что email принадлежал удаленному пользователю
+func (r *Repository) IsEmailDeleted(ctx context.Context, email string) (bool, error) {
+	var deleted bool
+	err := r.db.QueryRowContext(ctx,
+		`SELECT EXISTS (SELECT 1 FROM users WHERE email = $1 AND deleted_at IS NOT NULL)`, email).Scan(&deleted)
+	if err != nil {
+		return false, fmt.Errorf("failed to check deleted email: %w", err)
+	}
+	return deleted, nil
+}
+
+// InvalidateUsers удаляет из кэша пользователей, измененных в обход репозитория
+func (r *Repository) InvalidateUsers(ctx context.Context, userIDs []uuid.UUID) {
+	for _, id := range userIDs {
+		r.invalidate(ctx, id)
+	}
+}
+
+// invalidate удаляет пользователя из кэша после изменения
+func (r *Repository) invalidate(ctx context.Context, userID uuid.UUID) {
+	if r.cache != nil {
+		r.cache.Invalidate(ctx, userID)
+	}
+}
+
+// RevokeToken добавляет токен в список отозванных и удаляет из списка истекшие токены
+func (r *Repository) RevokeToken(ctx context.Context, jti string, userID uuid.UUID, expiresAt time.Time) error {
+	query := `
+		INSERT INTO revoked_tokens (jti, user_id, expires_at)
+		VALUES ($1, $2, $3)
+		ON CONFLICT (jti) DO NOTHING`
+
+	if _, err := r.db.ExecContext(ctx, query, jti, userID, expiresAt); err != nil {
+		return fmt.Errorf("failed to revoke token: %w", err)
+	}
+
+	if _, err := r.db.ExecContext(ctx, `DELETE FROM revoked_tokens WHERE expires_at < NOW()`); err != nil {
+		return fmt.Errorf("failed to delete expired revoked tokens: %w", err)
+	}
+	return nil
+}
+
+// IsTokenRevoked проверяет, отозван ли токен (реализует jwt.RevocationChecker)
+func (r *Repository) IsTokenRevoked(ctx context.Context, jti string) (bool, error) {
+	var revoked bool
+	err := r.db.QueryRowContext(ctx, `SELECT EXISTS (SELECT 1 FROM revoked_tokens WHERE jti = $1)`, jti).Scan(&revoked)
+	if err != nil {
+		return false, fmt.Errorf("failed to check token revocation: %w", err)
+	}
+	return revoked, nil
+}
+
+// GetTeachers получает профили всех активных преподавателей
+func (r *Repository) GetTeachers(ctx context.Context) ([]Teacher, error) {
+	query := `
+		SELECT t.user_id, t.full_name, COALESCE(t.department, ''), COALESCE(t.position, ''), COALESCE(t.teacher_id, '')
+		FROM teachers t
+		JOIN users u ON t.user_id = u.id
+		WHERE u.deleted_at IS NULL AND u.college_id = $1`
+
+	rows, err := r.db.QueryContext(ctx, query, tenant.CollegeID(ctx))
+	if err != nil {
+		return nil, fmt.Errorf("failed to get teachers: %w", err)
+	}
+	defer rows.Close()
+
+	var teachers []Teacher
+	for rows.Next() {
+		var teacher Teacher
+		if err := rows.Scan(&teacher.UserID, &teacher.FullName, &teacher.Department, &teacher.Position, &teacher.TeacherID); err != nil {
+			return nil, fmt.Errorf("failed to scan teacher: %w", err)
+		}
+		teachers = append(teachers, teacher)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating rows: %w", err)
+	}
+
+	return teachers, nil
+}
+
+// teacherNameClaimColumns список колонок заявки на вариант имени (с ФИО из профиля)
+const teacherNameClaimColumns = `c.id, c.teacher_id, t.full_name, c.scraped_name, c.status, c.reviewed_by, c.reviewed_at, c.created_at`
+
+// CreateTeacherNameClaim сохраняет заявку преподавателя колледжа из контекста на вариант имени
+func (r *Repository) CreateTeacherNameClaim(ctx context.Context, claim *TeacherNameClaim) error {
+	query := `
+		INSERT INTO teacher_name_claims (id, teacher_id, scraped_name, status, reviewed_at, college_id)
+		VALUES ($1, $2, $3, $4, CASE WHEN $4 = 'pending' THEN NULL ELSE NOW() END, $5)
+		RETURNING created_at, reviewed_at`
+
+	err := r.db.QueryRowContext(ctx, query, claim.ID, claim.TeacherID, claim.ScrapedName, claim.Status, tenant.CollegeID(ctx)).
+		Scan(&claim.CreatedAt, &claim.ReviewedAt)
+	if err != nil {
+		return fmt.Errorf("failed to create teacher name claim: %w", err)
+	}
+
+	return nil
+}
+
+// GetTeacherNameClaimByID получает заявку на вариант имени колледжа из контекста по ID
+func (r *Repository) GetTeacherNameClaimByID(ctx context.Context, id uuid.UUID) (*TeacherNameClaim, error) {
+	query := `
+		SELECT ` + teacherNameClaimColumns + `
+		FROM teacher_name_claims c
+		JOIN teachers t ON t.user_id = c.teacher_id
+		WHERE c.id = $1 AND c.college_id = $2`
+
+	rows, err := r.db.QueryContext(ctx, query, id, tenant.CollegeID(ctx))
+	if err != nil {
+		return nil, fmt.Errorf("failed to get teacher name claim: %w", err)
+	}
+	defer rows.Close()
+
+	claims, err := scanTeacherNameClaims(rows)
+	if err != nil {
+		return nil, err
+	}
+	if len(claims) == 0 {
+		return nil, fmt.Errorf("teacher name claim not found: %w", sql.ErrNoRows)
+	}
+
+	return &claims[0], nil
+}
+
+// GetTeacherNameClaimsByTeacher получает все заявки преподавателя на варианты имени
+func (r *Repository) GetTeacherNameClaimsByTeacher(ctx context.Context, teacherID uuid.UUID) ([]TeacherNameClaim, error) {
+	query := `
+		SELECT ` + teacherNameClaimColumns + `
+		FROM teacher_name_claims c
+		JOIN teachers t ON t.user_id = c.teacher_id
+		WHERE c.teacher_id = $1
+		ORDER BY c.created_at`
+
+	rows, err := r.db.QueryContext(ctx, query, teacherID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get teacher name claims: %w", err)
+	}
+	defer rows.Close()
+
+	return scanTeacherNameClaims(rows)
+}
+
+// GetPendingTeacherNameClaims получает заявки на варианты имени, ожидающие подтверждения
+func (r *Repository) GetPendingTeacherNameClaims(ctx context.Context) ([]TeacherNameClaim, error) {
+	query := `
+		SELECT ` + teacherNameClaimColumns + `
+		FROM teacher_name_claims c
+		JOIN teachers t ON t.user_id = c.teacher_id
+		JOIN users u ON u.id = c.teacher_id
+		WHERE c.status = 'pending' AND u.college_id = $1
+		ORDER BY c.created_at`
+
+	rows, err := r.db.QueryContext(ctx, query, tenant.CollegeID(ctx))
+	if err != nil {
+		return nil, fmt.Errorf("failed to get pending teacher name claims: %w", err)
+	}
+	defer rows.Close()
+
+	return scanTeacherNameClaims(rows)
+}
+
+// GetApprovedTeacherNameClaim получает подтвержденную заявку на вариант имени.
+// Возвращает sql.ErrNoRows, если вариант никому не принадлежит.
+func (r *Repository) GetApprovedTeacherNameClaim(ctx context.Context, scrapedName string) (*TeacherNameClaim, error) {
+	query := `
+		SELECT ` + teacherNameClaimColumns + `
+		FROM teacher_name_claims c
+		JOIN teachers t ON t.user_id = c.teacher_id
+		WHERE c.scraped_name = $1 AND c.status = 'approved' AND c.college_id = $2`
+
+	rows, err := r.db.QueryContext(ctx, query, scrapedName, tenant.CollegeID(ctx))
+	if err != nil {
+		return nil, fmt.Errorf("failed to get approved teacher name claim: %w", err)
+	}
+	defer rows.Close()
+
+	claims, err := scanTeacherNameClaims(rows)
+	if err != nil {
+		return nil, err
+	}
+	if len(claims) == 0 {
+		return nil, sql.ErrNoRows
+	}
+
+	return &claims[0], nil
+}
+
+// ReviewTeacherNameClaim сохраняет решение администратора по заявке на вариант имени
+// колледжа из контекста. Возвращает sql.ErrNoRows, если заявки в колледже нет.
+func (r *Repository) ReviewTeacherNameClaim(ctx context.Context, claim *TeacherNameClaim) error {
+	query := `
+		UPDATE teacher_name_claims
+		SET status = $2, reviewed_by = $3, reviewed_at = NOW()
+		WHERE id = $1 AND college_id = $4
+		RETURNING reviewed_at`
+
+	err := r.db.QueryRowContext(ctx, query, claim.ID, claim.Status, claim.ReviewedBy, tenant.CollegeID(ctx)).Scan(&claim.ReviewedAt)
+	if err != nil {
+		return fmt.Errorf("failed to review teacher name claim: %w", err)
+	}
+
+	return nil
+}
+
+// GetApprovedTeacherNames получает подтвержденные варианты имени преподавателя
+func (r *Repository) GetApprovedTeacherNames(ctx context.Context, teacherID uuid.UUID) ([]string, error) {
+	query := `
+		SELECT scraped_name
+		FROM teacher_name_claims
+		WHERE teacher_id = $1 AND status = 'approved'
+		ORDER BY scraped_name`
+
+	rows, err := r.db.QueryContext(ctx, query, teacherID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get approved teacher names: %w", err)
+	}
+	defer rows.Close()
+
+	var names []string
+	for rows.Next() {
+		var name string
+		if err := rows.Scan(&name); err != nil {
+			return nil, fmt.Errorf("failed to scan teacher name: %w", err)
+		}
+		names = append(names, name)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating rows: %w", err)
+	}
+
+	return names, nil
+}
+
+// GetTeachersByScrapedName получает активных преподавателей, которым принадлежит
+// имя из расписания: по подтвержденному варианту или по точному совпадению ФИО
+func (r *Repository) GetTeachersByScrapedName(ctx context.Context, scrapedName string) ([]uuid.UUID, error) {
+	query := `
+		SELECT t.user_id
+		FROM teachers t
+		JOIN users u ON t.user_id = u.id
+		WHERE u.deleted_at IS NULL AND u.college_id = $2 AND (
+			t.full_name = $1 OR EXISTS (
+				SELECT 1 FROM teacher_name_claims c
+				WHERE c.teacher_id = t.user_id AND c.scraped_name = $1 AND c.status = 'approved'))`
+
+	rows, err := r.db.QueryContext(ctx, query, scrapedName, tenant.CollegeID(ctx))
+	if err != nil {
+		return nil, fmt.Errorf("failed to get teachers by scraped name: %w", err)
+	}
+	defer rows.Close()
+
+	var teacherIDs []uuid.UUID
+	for rows.Next() {
+		var teacherID uuid.UUID
+		if err := rows.Scan(&teacherID); err != nil {
+			return nil, fmt.Errorf("failed to scan teacher ID: %w", err)
+		}
+		teacherIDs = append(teacherIDs, teacherID)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating rows: %w", err)
+	}
+
+	return teacherIDs, nil
+}
+
+// scanTeacherNameClaims сканирует строки заявок на варианты имени
+func scanTeacherNameClaims(rows *sql.Rows) ([]TeacherNameClaim, error) {
+	var claims []TeacherNameClaim
+	for rows.Next() {
+		var claim TeacherNameClaim
+		err := rows.Scan(&claim.ID, &claim.TeacherID, &claim.TeacherName, &claim.ScrapedName,
+			&claim.Status, &claim.ReviewedBy, &claim.ReviewedAt, &claim.CreatedAt)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan teacher name claim: %w", err)
+		}
+		claims = append(claims, claim)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating rows: %w", err)
+	}
+
+	return claims, nil
+}
+
+// ImportTeacherDirectory добавляет и обновляет преподавателей справочника колледжа
+// из контекста одной транзакцией. replace удаляет преподавателей, которых нет в списке.
+func (r *Repository) ImportTeacherDirectory(ctx context.Context, teachers []DirectoryTeacher, replace bool) (*DirectoryImportResult, error) {
+	collegeID := tenant.CollegeID(ctx)
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer func() { _ = tx.Rollback() }()
+
+	// xmax = 0 только у только что вставленной строки; обновление без изменений пропускается
+	upsertQuery := `
+		INSERT INTO teacher_directory (id, full_name, department, position, college_id)
+		VALUES ($1, $2, $3, $4, $5)
+		ON CONFLICT (college_id, full_name) DO UPDATE
+		SET department = EXCLUDED.department, position = EXCLUDED.position, updated_at = NOW()
+		WHERE teacher_directory.department <> EXCLUDED.department
+		   OR teacher_directory.position <> EXCLUDED.position
+		RETURNING xmax = 0`
+
+	result := &DirectoryImportResult{}
+	names := make([]string, 0, len(teachers))
+	for _, teacher := range teachers {
+		names = append(names, teacher.FullName)
+
+		var inserted bool
+		err := tx.QueryRowContext(ctx, upsertQuery, uuid.New(), teacher.FullName, teacher.Department,
+			teacher.Position, collegeID).Scan(&inserted)
+		switch {
+		case err == sql.ErrNoRows:
+			continue // Запись не изменилась
+		case err != nil:
+			return nil, fmt.Errorf("failed to upsert directory teacher: %w", err)
+		case inserted:
+			result.Created++
+		default:
+			result.Updated++
+		}
+	}
+
+	if replace {
+		deleteQuery := `DELETE FROM teacher_directory WHERE college_id = $1 AND NOT (full_name = ANY($2))`
+		res, err := tx.ExecContext(ctx, deleteQuery, collegeID, pq.Array(names))
+		if err != nil {
+			return nil, fmt.Errorf("failed to delete directory teachers: %w", err)
+		}
+		removed, err := res.RowsAffected()
+		if err != nil {
+			return nil, fmt.Errorf("failed to get rows affected: %w", err)
+		}
+		result.Removed = int(removed)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, fmt.Errorf("failed to commit teacher directory: %w", err)
+	}
+
+	return result, nil
+}
+
+// GetTeacherDirectory возвращает справочник преподавателей колледжа из контекста
+func (r *Repository) GetTeacherDirectory(ctx context.Context) ([]DirectoryTeacher, error) {
+	query := `
+		SELECT id, full_name, department, position, updated_at
+		FROM teacher_directory
+		WHERE college_id = $1
+		ORDER BY full_name`
+
+	rows, err := r.db.QueryContext(ctx, query, tenant.CollegeID(ctx))
+	if err != nil {
+		return nil, fmt.Errorf("failed to get teacher directory: %w", err)
+	}
+	defer rows.Close()
+
+	var teachers []DirectoryTeacher
+	for rows.Next() {
+		var teacher DirectoryTeacher
+		if err := rows.Scan(&teacher.ID, &teacher.FullName, &teacher.Department, &teacher.Position, &teacher.UpdatedAt); err != nil {
+			return nil, fmt.Errorf("failed to scan directory teacher: %w", err)
+		}
+		teachers = append(teachers, teacher)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating rows: %w", err)
+	}
+
+	return teachers, nil
+}
+
+// invitationColumns список колонок приглашения
+const invitationColumns = `id, code, COALESCE(role, ''), COALESCE(group_name, ''), max_uses, used_count,
+	expires_at, created_by, created_at, revoked_at`
+
+// CreateInvitation сохраняет новое приглашение
+func (r *Repository) CreateInvitation(ctx context.Context, invitation *Invitation) error {
+	query := `
+		INSERT INTO invitations (id, code, role, group_name, max_uses, expires_at, created_by, college_id)
+		VALUES ($1, $2, NULLIF($3, ''), NULLIF($4, ''), $5, $6, $7, $8)
+		RETURNING created_at`
+
+	err := r.db.QueryRowContext(ctx, query, invitation.ID, invitation.Code, invitation.Role, invitation.GroupName,
+		invitation.MaxUses, invitation.ExpiresAt, invitation.CreatedBy, tenant.CollegeID(ctx)).Scan(&invitation.CreatedAt)
+	if err != nil {
+		return fmt.Errorf("failed to create invitation: %w", err)
+	}
+
+	return nil
+}
+
+// GetInvitationByCode получает приглашение по коду.
+// Возвращает sql.ErrNoRows, если приглашения нет.
+func (r *Repository) GetInvitationByCode(ctx context.Context, code string) (*Invitation, error) {
+	query := `SELECT ` + invitationColumns + ` FROM invitations WHERE code = $1 AND college_id = $2`
+
+	rows, err := r.db.QueryContext(ctx, query, code, tenant.CollegeID(ctx))
+	if err != nil {
+		return nil, fmt.Errorf("failed to get invitation: %w", err)
+	}
+	defer rows.Close()
+
+	invitations, err := scanInvitations(rows)
+	if err != nil {
+		return nil, err
+	}
+	if len(invitations) == 0 {
+		return nil, sql.ErrNoRows
+	}
+
+	return &invitations[0], nil
+}
+
+// GetInvitations получает приглашения, новые первыми.
+// activeOnly - только не отозванные, не истекшие и не исчерпанные.
+func (r *Repository) GetInvitations(ctx context.Context, activeOnly bool) ([]Invitation, error) {
+	query := `
+		SELECT ` + invitationColumns + `
+		FROM invitations
+		WHERE college_id = $2 AND (NOT $1 OR (revoked_at IS NULL
+			AND (expires_at IS NULL OR expires_at > NOW())
+			AND (max_uses = 0 OR used_count < max_uses)))
+		ORDER BY created_at DESC`
+
+	rows, err := r.db.QueryContext(ctx, query, activeOnly, tenant.CollegeID(ctx))
+	if err != nil {
+		return nil, fmt.Errorf("failed to get invitations: %w", err)
+	}
+	defer rows.Close()
+
+	return scanInvitations(rows)
+}
+
+// UseInvitation атомарно увеличивает счетчик использований действующего приглашения.
+// Возвращает false, если приглашение уже отозвано, истекло или исчерпано.
+func (r *Repository) UseInvitation(ctx context.Context, id uuid.UUID) (bool, error) {
+	query := `
+		UPDATE invitations
+		SET used_count = used_count + 1
+		WHERE id = $1 AND revoked_at IS NULL
+			AND (expires_at IS NULL OR expires_at > NOW())
+			AND (max_uses = 0 OR used_count < max_uses)`
+
+	result, err := r.db.ExecContext(ctx, query, id)
+	if err != nil {
+		return false, fmt.Errorf("failed to use invitation: %w", err)
+	}
+
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return false, fmt.Errorf("failed to get affected rows: %w", err)
+	}
+
+	return affected > 0, nil
+}
+
+// ReleaseInvitation возвращает использование приглашения (если регистрация не удалась)
+func (r *Repository) ReleaseInvitation(ctx context.Context, id uuid.UUID) error {
+	query := `UPDATE invitations SET used_count = GREATEST(used_count - 1, 0) WHERE id = $1`
+
+	if _, err := r.db.ExecContext(ctx, query, id); err != nil {
+		return fmt.Errorf("failed to release invitation: %w", err)
+	}
+
+	return nil
+}
+
+// SetUserInvitation запоминает, по какому приглашению зарегистрирован пользователь
+func (r *Repository) SetUserInvitation(ctx context.Context, userID, invitationID uuid.UUID) error {
+	query := `UPDATE users SET invitation_id = $2 WHERE id = $1`
+
+	if _, err := r.db.ExecContext(ctx, query, userID, invitationID); err != nil {
+		return fmt.Errorf("failed to set user invitation: %w", err)
+	}
+
+	return nil
+}
+
+// RevokeInvitation отзывает приглашение.
+// Возвращает sql.ErrNoRows, если приглашения нет или оно уже отозвано.
+func (r *Repository) RevokeInvitation(ctx context.Context, id uuid.UUID) (*Invitation, error) {
+	query := `
+		UPDATE invitations
+		SET revoked_at = NOW()
+		WHERE id = $1 AND revoked_at IS NULL AND college_id = $2
+		RETURNING ` + invitationColumns
+
+	rows, err := r.db.QueryContext(ctx, query, id, tenant.CollegeID(ctx))
+	if err != nil {
+		return nil, fmt.Errorf("failed to revoke invitation: %w", err)
+	}
+	defer rows.Close()
+
+	invitations, err := scanInvitations(rows)
+	if err != nil {
+		return nil, err
+	}
+	if len(invitations) == 0 {
+		return nil, sql.ErrNoRows
+	}
+
+	return &invitations[0], nil
+}
+
+// scanInvitations сканирует строки приглашений
+func scanInvitations(rows *sql.Rows) ([]Invitation, error) {
+	var invitations []Invitation
+	for rows.Next() {
+		var invitation Invitation
+		err := rows.Scan(&invitation.ID, &invitation.Code, &invitation.Role, &invitation.GroupName,
+			&invitation.MaxUses, &invitation.UsedCount, &invitation.ExpiresAt, &invitation.CreatedBy,
+			&invitation.CreatedAt, &invitation.RevokedAt)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan invitation: %w", err)
+		}
+		invitations = append(invitations, invitation)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating rows: %w", err)
+	}
+
+	return invitations, nil
+}
+
+// GetTwoFactor получает настройки 2FA пользователя.
+// Возвращает sql.ErrNoRows, если 2FA не подключалась.
+func (r *Repository) GetTwoFactor(ctx context.Context, userID uuid.UUID) (*TwoFactor, error) {
+	query := `
+		SELECT user_id, secret, enabled, last_used_step, enabled_at, created_at
+		FROM user_two_factor
+		WHERE user_id = $1`
+
+	var tf TwoFactor
+	err := r.db.QueryRowContext(ctx, query, userID).Scan(&tf.UserID, &tf.Secret, &tf.Enabled,
+		&tf.LastUsedStep, &tf.EnabledAt, &tf.CreatedAt)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, err
+		}
+		return nil, fmt.Errorf("failed to get two-factor settings: %w", err)
+	}
+
+	return &tf, nil
+}
+
+// SaveTwoFactorSecret сохраняет новый секрет неподтвержденной 2FA.
+// Включенная 2FA не перезаписывается.
+func (r *Repository) SaveTwoFactorSecret(ctx context.Context, userID uuid.UUID, secret string) error {
+	query := `
+		INSERT INTO user_two_factor (user_id, secret)
+		VALUES ($1, $2)
+		ON CONFLICT (user_id) DO UPDATE
+		SET secret = EXCLUDED.secret, last_used_step = 0, created_at = NOW()
+		WHERE user_two_factor.enabled = FALSE`
+
+	if _, err := r.db.ExecContext(ctx, query, userID, secret); err != nil {
+		return fmt.Errorf("failed to save two-factor secret: %w", err)
+	}
+
+	return nil
+}
+
+// EnableTwoFactor включает 2FA и заменяет коды восстановления (хеши) одной транзакцией
+func (r *Repository) EnableTwoFactor(ctx context.Context, userID uuid.UUID, step int64, recoveryHashes []string) error {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer func() { _ = tx.Rollback() }()
+
+	enableQuery := `
+		UPDATE user_two_factor
+		SET enabled = TRUE, enabled_at = NOW(), last_used_step = $2
+		WHERE user_id = $1`
+	if _, err := tx.ExecContext(ctx, enableQuery, userID, step); err != nil {
+		return fmt.Errorf("failed to enable two-factor: %w", err)
+	}
+
+	if _, err := tx.ExecContext(ctx, `DELETE FROM two_factor_recovery_codes WHERE user_id = $1`, userID); err != nil {
+		return fmt.Errorf("failed to delete recovery codes: %w", err)
+	}
+	for _, hash := range recoveryHashes {
+		insertQuery := `INSERT INTO two_factor_recovery_codes (user_id, code_hash) VALUES ($1, $2)`
+		if _, err := tx.ExecContext(ctx, insertQuery, userID, hash); err != nil {
+			return fmt.Errorf("failed to save recovery code: %w", err)
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit two-factor enabling: %w", err)
+	}
+
+	return nil
+}
+
+// DisableTwoFactor отключает 2FA и удаляет коды восстановления
+func (r *Repository) DisableTwoFactor(ctx context.Context, userID uuid.UUID) error {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer func() { _ = tx.Rollback() }()
+
+	if _, err := tx.ExecContext(ctx, `DELETE FROM two_factor_recovery_codes WHERE user_id = $1`, userID); err != nil {
+		return fmt.Errorf("failed to delete recovery codes: %w", err)
+	}
+	if _, err := tx.ExecContext(ctx, `DELETE FROM user_two_factor WHERE user_id = $1`, userID); err != nil {
+		return fmt.Errorf("failed to disable two-factor: %w", err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit two-factor disabling: %w", err)
+	}
+
+	return nil
+}
+
+// UseTwoFactorStep запоминает период принятого кода.
+// Возвращает false, если код этого или более позднего периода уже использовался.
+func (r *Repository) UseTwoFactorStep(ctx context.Context, userID uuid.UUID, step int64) (bool, error) {
+	query := `
+		UPDATE user_two_factor
+		SET last_used_step = $2
+		WHERE user_id = $1 AND last_used_step < $2`
+
+	result, err := r.db.ExecContext(ctx, query, userID, step)
+	if err != nil {
+		return false, fmt.Errorf("failed to update two-factor step: %w", err)
+	}
+
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return false, fmt.Errorf("failed to get affected rows: %w", err)
+	}
+
+	return affected > 0, nil
+}
+
+// UseRecoveryCode погашает код восстановления по хешу.
+// Возвращает false, если такого неиспользованного кода нет.
+func (r *Repository) UseRecoveryCode(ctx context.Context, userID uuid.UUID, codeHash string) (bool, error) {
+	query := `
+		UPDATE two_factor_recovery_codes
+		SET used_at = NOW()
+		WHERE user_id = $1 AND code_hash = $2 AND used_at IS NULL`
+
+	result, err := r.db.ExecContext(ctx, query, userID, codeHash)
+	if err != nil {
+		return false, fmt.Errorf("failed to use recovery code: %w", err)
+	}
+
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return false, fmt.Errorf("failed to get affected rows: %w", err)
+	}
+
+	return affected > 0, nil
+}
+
+// CountRecoveryCodes возвращает количество неиспользованных кодов восстановления
+func (r *Repository) CountRecoveryCodes(ctx context.Context, userID uuid.UUID) (int, error) {
+	query := `SELECT COUNT(*) FROM two_factor_recovery_codes WHERE user_id = $1 AND used_at IS NULL`
+
+	var count int
+	if err := r.db.QueryRowContext(ctx, query, userID).Scan(&count); err != nil {
+		return 0, fmt.Errorf("failed to count recovery codes: %w", err)
+	}
+
+	return count, nil
+}
+
 // AuthenticateUser аутентифицирует пользователя по email и паролю
 func (r *Repository) AuthenticateUser(ctx context.Context, email, password string) (*User, error) {
 	// Получаем пользователя по email
 	user, err := r.GetUserByEmail(ctx, email)
 	if err != nil {
-		return nil, fmt.Errorf("invalid credentials")
-	}
-
-	// Проверяем, что пользователь активен
-	if !user.IsActive {
-		return nil, fmt.Errorf("user account is deactivated")
+		return nil, fmt.Errorf("invalid credentials: %w", apperr.ErrUnauthorized)
 	}
 
 	// Сравниваем хэш пароля
 	err = bcrypt.CompareHashAndPassword([]byte(user.Password), []byte(password))
 	if err != nil {
-		return nil, fmt.Errorf("invalid credentials")
+		return nil, fmt.Errorf("invalid credentials: %w", apperr.ErrUnauthorized)
 	}
 
 	return user, nil
@@ -0,0 +1,253 @@
+package users
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"net/mail"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/Ultrahd-dev/student-schedule-app/backend/internal/apperr"
+	"github.com/google/uuid"
+	"golang.org/x/crypto/bcrypt"
+)
+
+// ErrInvalidRoster означает некорректный файл списка студентов
+var ErrInvalidRoster = apperr.New(apperr.ErrValidation, "некорректный список студентов")
+
+// rosterPasswordLength длина временного пароля студента из списка группы
+const rosterPasswordLength = 10
+
+// rosterColumns названия колонок списка студентов в строке заголовка (в нижнем регистре)
+var rosterColumns = map[string][]string{
+	"email":          {"email", "e-mail", "почта", "эл. почта", "электронная почта"},
+	"full_name":      {"фио", "ф.и.о.", "студент", "full_name"},
+	"group_name":     {"группа", "group", "group_name"},
+	"student_number": {"номер", "номер зачетки", "номер зачетной книжки", "студенческий билет", "student_number"},
+	"course":         {"курс", "course"},
+}
+
+// Mailer отправляет письма пользователям
+type Mailer interface {
+	Send(ctx context.Context, to, subject, body string) error
+}
+
+// SetMailer включает отправку приглашений студентам, созданным из списка группы.
+// appURL - ссылка на приложение в тексте письма (может быть пустой).
+func (s *Service) SetMailer(mailer Mailer, appURL string) {
+	s.mailer = mailer
+	s.appURL = appURL
+}
+
+// RosterStudent студент из списка группы
+type RosterStudent struct {
+	Line          int // Строка файла (для сообщений об ошибках)
+	Email         string
+	FullName      string
+	GroupName     string
+	StudentNumber string
+	Course        int
+}
+
+// RosterAccount учетная запись, созданная из списка группы
+type RosterAccount struct {
+	Student  RosterStudent
+	UserID   uuid.UUID
+	Invited  bool   // Приглашение с временным паролем отправлено на email
+	Password string // Временный пароль, если приглашение не отправлено
+}
+
+// RosterSkip студент из списка, для которого учетная запись не создана
+type RosterSkip struct {
+	Student RosterStudent
+	Reason  string
+}
+
+// RosterImportResult итог загрузки списка группы
+type RosterImportResult struct {
+	Created []RosterAccount
+	Skipped []RosterSkip
+}
+
+// ReadRosterCSV читает строки списка студентов из CSV (разделитель "," или ";")
+func ReadRosterCSV(r io.Reader) ([][]string, error) {
+	return readCSV(r, ErrInvalidRoster)
+}
+
+// ParseRoster разбирает строки списка студентов. Колонки определяются по строке
+// заголовка (email, ФИО, группа, номер, курс); без заголовка используются первые
+// колонки в этом порядке. Пустые строки пропускаются, повтор email - ошибка.
+func ParseRoster(records [][]string) ([]RosterStudent, error) {
+	columns := map[string]int{"email": 0, "full_name": 1, "group_name": 2, "student_number": 3, "course": 4}
+	firstLine := 1
+	if len(records) > 0 {
+		if header, ok := csvHeader(records[0], rosterColumns, "email"); ok {
+			columns = header
+			records = records[1:]
+			firstLine = 2
+		}
+	}
+
+	var students []RosterStudent
+	emails := make(map[string]int)
+	for i, record := range records {
+		line := i + firstLine
+		cell := func(column string) string {
+			j, ok := columns[column]
+			if !ok || j >= len(record) {
+				return ""
+			}
+			return strings.Join(strings.Fields(record[j]), " ")
+		}
+
+		student := RosterStudent{
+			Line:          line,
+			Email:         cell("email"),
+			FullName:      cell("full_name"),
+			GroupName:     cell("group_name"),
+			StudentNumber: cell("student_number"),
+			Course:        1,
+		}
+		if student.Email == "" && student.FullName == "" && student.GroupName == "" {
+			continue
+		}
+
+		if address, err := mail.ParseAddress(student.Email); err != nil || address.Address != student.Email {
+			return nil, fmt.Errorf("%w: строка %d: некорректный email %q", ErrInvalidRoster, line, student.Email)
+		}
+		if len(nameParts(student.FullName)) < 2 {
+			return nil, fmt.Errorf("%w: строка %d: ожидается ФИО полностью, получено %q", ErrInvalidRoster, line, student.FullName)
+		}
+		if student.GroupName == "" {
+			return nil, fmt.Errorf("%w: строка %d: не указана группа", ErrInvalidRoster, line)
+		}
+		if course := cell("course"); course != "" {
+			n, err := strconv.Atoi(course)
+			if err != nil || n < 1 || n > 4 {
+				return nil, fmt.Errorf("%w: строка %d: курс должен быть от 1 до 4, получено %q", ErrInvalidRoster, line, course)
+			}
+			student.Course = n
+		}
+		if utf8.RuneCountInString(student.Email) > 255 || utf8.RuneCountInString(student.FullName) > 255 ||
+			utf8.RuneCountInString(student.GroupName) > 50 || utf8.RuneCountInString(student.StudentNumber) > 50 {
+			return nil, fmt.Errorf("%w: строка %d: слишком длинное значение", ErrInvalidRoster, line)
+		}
+
+		key := strings.ToLower(student.Email)
+		if previous, ok := emails[key]; ok {
+			return nil, fmt.Errorf("%w: строка %d: email %s уже указан в строке %d", ErrInvalidRoster, line, student.Email, previous)
+		}
+		emails[key] = line
+		students = append(students, student)
+	}
+
+	if len(students) == 0 {
+		return nil, fmt.Errorf("%w: файл не содержит ни одного студента", ErrInvalidRoster)
+	}
+	return students, nil
+}
+
+// ImportRoster создает учетные записи студентов из списка группы с временными
+// паролями, которые нужно сменить после первого входа. Приглашение по коду не
+// требуется. Если отправка писем настроена, пароль отправляется студенту в
+// приглашении; иначе (или при ошибке отправки) возвращается администратору.
+// Студенты с уже зарегистрированным email пропускаются.
+func (s *Service) ImportRoster(ctx context.Context, students []RosterStudent) (*RosterImportResult, error) {
+	if len(students) == 0 {
+		return nil, fmt.Errorf("%w: список студентов пуст", ErrInvalidRoster)
+	}
+
+	result := &RosterImportResult{}
+	for _, student := range students {
+		password, err := randomCode(rosterPasswordLength)
+		if err != nil {
+			return nil, err
+		}
+
+		userID, err := s.createRosterStudent(ctx, student, password)
+		if errors.Is(err, apperr.ErrAlreadyExists) {
+			result.Skipped = append(result.Skipped, RosterSkip{Student: student, Reason: "email уже зарегистрирован"})
+			continue
+		}
+		if err != nil {
+			log.Printf("Ошибка создания студента %s из списка группы: %v", student.Email, err)
+			result.Skipped = append(result.Skipped, RosterSkip{Student: student, Reason: "ошибка создания учетной записи (возможно, номер уже занят)"})
+			continue
+		}
+
+		account := RosterAccount{Student: student, UserID: userID, Password: password}
+		if s.mailer != nil {
+			if err := s.sendRosterInvitation(ctx, student, password); err != nil {
+				log.Printf("Ошибка отправки приглашения студенту %s: %v", student.Email, err)
+			} else {
+				account.Invited = true
+				account.Password = ""
+			}
+		}
+		result.Created = append(result.Created, account)
+	}
+
+	log.Printf("Список студентов загружен: %d в списке, создано %d, пропущено %d",
+		len(students), len(result.Created), len(result.Skipped))
+	return result, nil
+}
+
+// createRosterStudent создает пользователя с временным паролем и профиль студента
+// (в одной транзакции, если настроен менеджер транзакций)
+func (s *Service) createRosterStudent(ctx context.Context, student RosterStudent, password string) (uuid.UUID, error) {
+	if _, err := s.repo.GetUserByEmail(ctx, student.Email); err == nil {
+		return uuid.Nil, fmt.Errorf("user with email %s: %w", student.Email, apperr.ErrAlreadyExists)
+	}
+
+	hashedPassword, err := bcrypt.GenerateFromPassword([]byte(password), bcrypt.DefaultCost)
+	if err != nil {
+		return uuid.Nil, fmt.Errorf("failed to hash password: %w", err)
+	}
+	user := &User{
+		ID:                     uuid.New(),
+		Email:                  student.Email,
+		Password:               string(hashedPassword),
+		Role:                   RoleStudent,
+		IsActive:               true,
+		PasswordChangeRequired: true,
+	}
+
+	create := func(ctx context.Context) error {
+		if err := s.createUser(ctx, user); err != nil {
+			return fmt.Errorf("failed to create user: %w", err)
+		}
+		return s.repo.CreateStudent(ctx, &Student{
+			UserID:        user.ID,
+			FullName:      student.FullName,
+			GroupName:     student.GroupName,
+			Course:        student.Course,
+			StudentNumber: student.StudentNumber,
+		})
+	}
+	if s.transactor != nil {
+		err = s.transactor.Do(ctx, create)
+	} else {
+		err = create(ctx)
+	}
+	if err != nil {
+		return uuid.Nil, err
+	}
+	return user.ID, nil
+}
+
+// sendRosterInvitation отправляет студенту письмо с временным паролем
+func (s *Service) sendRosterInvitation(ctx context.Context, student RosterStudent, password string) error {
+	var body strings.Builder
+	fmt.Fprintf(&body, "Здравствуйте, %s!\n\n", student.FullName)
+	fmt.Fprintf(&body, "Для вас создана учетная запись в приложении \"Расписание\" (группа %s).\n\n", student.GroupName)
+	fmt.Fprintf(&body, "Логин: %s\nВременный пароль: %s\n\n", student.Email, password)
+	body.WriteString("После первого входа смените пароль в профиле.\n")
+	if s.appURL != "" {
+		fmt.Fprintf(&body, "\nПриложение: %s\n", s.appURL)
+	}
+	return s.mailer.Send(ctx, student.Email, "Приглашение в приложение \"Расписание\"", body.String())
+}
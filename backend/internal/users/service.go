@@ -4,19 +4,42 @@ package users
 
 import (
 	"context"
+	"database/sql"
+	"errors"
 	"fmt"
+	"strings"
+	"time"
 
+	"github.com/Ultrahd-dev/student-schedule-app/backend/internal/apperr"
+	"github.com/Ultrahd-dev/student-schedule-app/backend/internal/tenant"
+	"github.com/Ultrahd-dev/student-schedule-app/backend/internal/txn"
 	"github.com/google/uuid"
 	"golang.org/x/crypto/bcrypt"
 )
 
+// Ошибки смены пароля и роли
+var (
+	ErrPasswordTooShort = apperr.New(apperr.ErrValidation, "новый пароль должен быть не короче 6 символов")
+	ErrWrongPassword    = apperr.New(apperr.ErrValidation, "неверный текущий пароль")
+	ErrUnknownRole      = apperr.New(apperr.ErrValidation, "неизвестная роль")
+	ErrDeleteSelf       = apperr.New(apperr.ErrValidation, "нельзя удалить собственную учетную запись")
+	ErrEmailTaken       = apperr.New(apperr.ErrAlreadyExists, "email занят другим пользователем")
+)
+
 // Service предоставляет бизнес-логику для работы с пользователями
 type Service struct {
-	repo *Repository
+	repo               UserStore
+	invitationRequired bool            // Регистрация студентов и преподавателей только по приглашениям
+	twoFactor          TwoFactorConfig // Настройки двухфакторной аутентификации
+	ldap               LDAPConfig      // Вход через каталог LDAP (отключен без клиента)
+	events             EventWriter     // Outbox события user.registered (может быть nil)
+	transactor         txn.Transactor  // Транзакции создания пользователя с событием (вместе с events)
+	mailer             Mailer          // Письма с приглашениями (может быть nil)
+	appURL             string          // Ссылка на приложение в приглашениях
 }
 
 // NewService создает новый сервис пользователей
-func NewService(repo *Repository) *Service {
+func NewService(repo UserStore) *Service {
 	return &Service{repo: repo}
 }
 
@@ -30,10 +53,14 @@ type RegisterUserInput struct {
 // RegisterStudentInput содержит данные для регистрации студента
 type RegisterStudentInput struct {
 	RegisterUserInput
+	FullName      string `json:"full_name"`
 	GroupName     string `json:"group_name" validate:"required"`
 	Faculty       string `json:"faculty"`
 	Course        int    `json:"course" validate:"min=1,max=4"`
 	StudentNumber string `json:"student_number"`
+	Subgroup      int    `json:"subgroup"` // Подгруппа (0 - не выбрана)
+	// InvitationCode код приглашения; обязателен, если включена регистрация по приглашениям
+	InvitationCode string `json:"invitation_code"`
 }
 
 // RegisterTeacherInput содержит данные для регистрации преподавателя
@@ -43,6 +70,8 @@ type RegisterTeacherInput struct {
 	Department string `json:"department"`
 	Position   string `json:"position"`
 	TeacherID  string `json:"teacher_id"`
+	// InvitationCode код приглашения; обязателен, если включена регистрация по приглашениям
+	InvitationCode string `json:"invitation_code"`
 }
 
 // RegisterUser регистрирует нового пользователя
@@ -50,7 +79,7 @@ func (s *Service) RegisterUser(ctx context.Context, input RegisterUserInput) (*U
 	// Проверяем, что пользователя с таким email еще нет
 	_, err := s.repo.GetUserByEmail(ctx, input.Email)
 	if err == nil {
-		return nil, fmt.Errorf("user with email %s already exists", input.Email)
+		return nil, fmt.Errorf("user with email %s: %w", input.Email, apperr.ErrAlreadyExists)
 	}
 
 	// Хэшируем пароль
@@ -68,7 +97,7 @@ func (s *Service) RegisterUser(ctx context.Context, input RegisterUserInput) (*U
 		IsActive: true,
 	}
 
-	err = s.repo.CreateUser(ctx, user)
+	err = s.createUser(ctx, user)
 	if err != nil {
 		return nil, fmt.Errorf("failed to create user: %w", err)
 	}
@@ -76,31 +105,72 @@ func (s *Service) RegisterUser(ctx context.Context, input RegisterUserInput) (*U
 	return user, nil
 }
 
+// BootstrapAdmin создает начального администратора, если пользователя с таким email еще нет.
+// Существующий пользователь с другой ролью не повышается до администратора.
+// Возвращает пользователя и признак того, что он был создан.
+func (s *Service) BootstrapAdmin(ctx context.Context, email, password string) (*User, bool, error) {
+	if email == "" || len(password) < 6 {
+		return nil, false, fmt.Errorf("admin email and password (at least 6 characters) are required")
+	}
+
+	existing, err := s.repo.GetUserByEmail(ctx, email)
+	if err == nil {
+		if existing.Role != RoleAdmin {
+			return nil, false, fmt.Errorf("user with email %s (role %s): %w", email, existing.Role, apperr.ErrAlreadyExists)
+		}
+		return existing, false, nil
+	}
+
+	user, err := s.RegisterUser(ctx, RegisterUserInput{
+		Email:    email,
+		Password: password,
+		Role:     RoleAdmin,
+	})
+	if err != nil {
+		return nil, false, err
+	}
+	return user, true, nil
+}
+
 // RegisterStudent регистрирует нового студента
 func (s *Service) RegisterStudent(ctx context.Context, input RegisterStudentInput) (*User, *Student, error) {
 	// Устанавливаем роль студента
 	input.Role = RoleStudent
+	if err := validateSubgroup(input.Subgroup); err != nil {
+		return nil, nil, err
+	}
+
+	// Проверяем приглашение (группа может быть задана приглашением)
+	invitation, err := s.redeemInvitation(ctx, input.InvitationCode, RoleStudent, &input.GroupName)
+	if err != nil {
+		return nil, nil, err
+	}
 
 	// Регистрируем пользователя
 	user, err := s.RegisterUser(ctx, input.RegisterUserInput)
 	if err != nil {
+		s.completeInvitation(ctx, invitation, nil)
 		return nil, nil, fmt.Errorf("failed to register user: %w", err)
 	}
 
 	// Создаем профиль студента
 	student := &Student{
 		UserID:        user.ID,
+		FullName:      strings.TrimSpace(input.FullName),
 		GroupName:     input.GroupName,
 		Faculty:       input.Faculty,
 		Course:        input.Course,
 		StudentNumber: input.StudentNumber,
+		Subgroup:      input.Subgroup,
 	}
 
 	err = s.repo.CreateStudent(ctx, student)
 	if err != nil {
 		// Note: In a real application, you might want to rollback user creation here
+		s.completeInvitation(ctx, invitation, nil)
 		return nil, nil, fmt.Errorf("failed to create student profile: %w", err)
 	}
+	s.completeInvitation(ctx, invitation, user)
 
 	return user, student, nil
 }
@@ -110,9 +180,17 @@ func (s *Service) RegisterTeacher(ctx context.Context, input RegisterTeacherInpu
 	// Устанавливаем роль преподавателя
 	input.Role = RoleTeacher
 
+	// Проверяем приглашение
+	var noGroup string
+	invitation, err := s.redeemInvitation(ctx, input.InvitationCode, RoleTeacher, &noGroup)
+	if err != nil {
+		return nil, nil, err
+	}
+
 	// Регистрируем пользователя
 	user, err := s.RegisterUser(ctx, input.RegisterUserInput)
 	if err != nil {
+		s.completeInvitation(ctx, invitation, nil)
 		return nil, nil, fmt.Errorf("failed to register user: %w", err)
 	}
 
@@ -128,22 +206,155 @@ func (s *Service) RegisterTeacher(ctx context.Context, input RegisterTeacherInpu
 	err = s.repo.CreateTeacher(ctx, teacher)
 	if err != nil {
 		// Note: In a real application, you might want to rollback user creation here
+		s.completeInvitation(ctx, invitation, nil)
 		return nil, nil, fmt.Errorf("failed to create teacher profile: %w", err)
 	}
+	s.completeInvitation(ctx, invitation, user)
 
 	return user, teacher, nil
 }
 
-// AuthenticateUser аутентифицирует пользователя по email и паролю
+// AuthenticateUser аутентифицирует пользователя по email и паролю.
+// Если включен вход через каталог LDAP, пароль проверяется в каталоге,
+// а пользователи, которых в каталоге нет, входят по локальному паролю.
 func (s *Service) AuthenticateUser(ctx context.Context, email, password string) (*User, error) {
+	if s.ldap.Client != nil {
+		user, err := s.authenticateLDAP(ctx, email, password)
+		if !errors.Is(err, errLocalAuth) {
+			return user, err
+		}
+	}
 	return s.repo.AuthenticateUser(ctx, email, password)
 }
 
+// ChangePassword меняет пароль пользователя после проверки текущего
+func (s *Service) ChangePassword(ctx context.Context, userID uuid.UUID, oldPassword, newPassword string) error {
+	if len(newPassword) < 6 {
+		return ErrPasswordTooShort
+	}
+
+	passwordHash, err := s.repo.GetPasswordHash(ctx, userID)
+	if err != nil {
+		return err
+	}
+	if err := bcrypt.CompareHashAndPassword([]byte(passwordHash), []byte(oldPassword)); err != nil {
+		return ErrWrongPassword
+	}
+
+	hashedPassword, err := bcrypt.GenerateFromPassword([]byte(newPassword), bcrypt.DefaultCost)
+	if err != nil {
+		return fmt.Errorf("failed to hash password: %w", err)
+	}
+	return s.repo.UpdatePassword(ctx, userID, string(hashedPassword))
+}
+
+// SetRole изменяет роль пользователя колледжа из контекста и возвращает предыдущую
+func (s *Service) SetRole(ctx context.Context, userID uuid.UUID, role Role) (Role, error) {
+	switch role {
+	case RoleStudent, RoleTeacher, RoleAdmin:
+	default:
+		return "", fmt.Errorf("%w: %q", ErrUnknownRole, role)
+	}
+
+	user, err := s.repo.GetUserByID(ctx, userID)
+	if err != nil {
+		return "", err
+	}
+	// GetUserByID не ограничен колледжем: пользователи других колледжей для администратора не существуют
+	if user.CollegeID != tenant.CollegeID(ctx) {
+		return "", fmt.Errorf("user %s: %w", userID, apperr.ErrNotFound)
+	}
+	if user.Role == role {
+		return role, nil
+	}
+
+	if err := s.repo.UpdateRole(ctx, userID, role); err != nil {
+		return "", err
+	}
+	return user.Role, nil
+}
+
+// SetLoginAlerts включает или отключает уведомления о входе с нового устройства
+func (s *Service) SetLoginAlerts(ctx context.Context, userID uuid.UUID, enabled bool) error {
+	return s.repo.SetLoginAlerts(ctx, userID, enabled)
+}
+
+// ResetCalendarToken перевыпускает ссылки пользователя на личный календарь: выданные
+// ранее ссылки перестают работать. Возвращает новую версию ссылок.
+func (s *Service) ResetCalendarToken(ctx context.Context, userID uuid.UUID) (int, error) {
+	return s.repo.ResetCalendarToken(ctx, userID)
+}
+
+// DeleteUser мягко удаляет пользователя по решению администратора actorID.
+// Возвращает удаленного пользователя и число удаленных вместе с ним подписок.
+func (s *Service) DeleteUser(ctx context.Context, userID, actorID uuid.UUID) (*User, int, error) {
+	if userID == actorID {
+		return nil, 0, ErrDeleteSelf
+	}
+
+	user, err := s.repo.GetUserByID(ctx, userID)
+	if err != nil {
+		return nil, 0, err
+	}
+	subscriptions, err := s.repo.SoftDeleteUser(ctx, userID, actorID)
+	if err != nil {
+		return nil, 0, err
+	}
+	user.IsActive = false
+	return user, subscriptions, nil
+}
+
+// RestoreUser восстанавливает удаленного пользователя. Если его email уже занят
+// зарегистрированным позже пользователем, восстановление невозможно.
+func (s *Service) RestoreUser(ctx context.Context, userID uuid.UUID) (*User, error) {
+	user, err := s.repo.GetDeletedUser(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	_, err = s.repo.GetUserByEmail(ctx, user.Email)
+	if err == nil {
+		return nil, fmt.Errorf("%w: %s", ErrEmailTaken, user.Email)
+	}
+	if !errors.Is(err, sql.ErrNoRows) {
+		return nil, err
+	}
+
+	if err := s.repo.RestoreUser(ctx, userID); err != nil {
+		return nil, err
+	}
+	user.IsActive = true
+	user.DeletedAt = nil
+	return user, nil
+}
+
+// RevokeToken отзывает токен с идентификатором jti до истечения его срока действия
+func (s *Service) RevokeToken(ctx context.Context, jti string, userID uuid.UUID, expiresAt time.Time) error {
+	if jti == "" {
+		return fmt.Errorf("token has no id")
+	}
+	return s.repo.RevokeToken(ctx, jti, userID, expiresAt)
+}
+
+// GetGroupRoster возвращает зарегистрированных студентов группы
+func (s *Service) GetGroupRoster(ctx context.Context, groupName string) ([]Student, error) {
+	return s.repo.GetGroupRoster(ctx, strings.TrimSpace(groupName))
+}
+
 // GetUserByID получает пользователя по ID
 func (s *Service) GetUserByID(ctx context.Context, id uuid.UUID) (*User, error) {
 	return s.repo.GetUserByID(ctx, id)
 }
 
+// GetStudentProfile получает профиль студента по ID пользователя
+func (s *Service) GetStudentProfile(ctx context.Context, userID uuid.UUID) (*Student, error) {
+	return s.repo.GetStudentByUserID(ctx, userID)
+}
+
+// GetTeacherProfile получает профиль преподавателя по ID пользователя
+func (s *Service) GetTeacherProfile(ctx context.Context, userID uuid.UUID) (*Teacher, error) {
+	return s.repo.GetTeacherByUserID(ctx, userID)
+}
+
 // GetUserByEmail получает пользователя по email
 func (s *Service) GetUserByEmail(ctx context.Context, email string) (*User, error) {
 	return s.repo.GetUserByEmail(ctx, email)
@@ -0,0 +1,270 @@
+package users_test
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/Ultrahd-dev/student-schedule-app/backend/internal/apperr"
+	"github.com/Ultrahd-dev/student-schedule-app/backend/internal/mocks"
+	"github.com/Ultrahd-dev/student-schedule-app/backend/internal/tenant"
+	"github.com/Ultrahd-dev/student-schedule-app/backend/internal/totp"
+	"github.com/Ultrahd-dev/student-schedule-app/backend/internal/users"
+	"github.com/google/uuid"
+	"golang.org/x/crypto/bcrypt"
+)
+
+func TestChangePassword(t *testing.T) {
+	hash, err := bcrypt.GenerateFromPassword([]byte("old-password"), bcrypt.MinCost)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var saved string
+	store := &mocks.UserStore{
+		GetPasswordHashFunc: func(ctx context.Context, userID uuid.UUID) (string, error) {
+			return string(hash), nil
+		},
+		UpdatePasswordFunc: func(ctx context.Context, userID uuid.UUID, passwordHash string) error {
+			saved = passwordHash
+			return nil
+		},
+	}
+	service := users.NewService(store)
+	userID := uuid.New()
+
+	if err := service.ChangePassword(context.Background(), userID, "old-password", "123"); !errors.Is(err, users.ErrPasswordTooShort) {
+		t.Errorf("короткий пароль: ошибка %v, ожидалась %v", err, users.ErrPasswordTooShort)
+	}
+	if err := service.ChangePassword(context.Background(), userID, "wrong-password", "new-password"); !errors.Is(err, users.ErrWrongPassword) {
+		t.Errorf("неверный текущий пароль: ошибка %v, ожидалась %v", err, users.ErrWrongPassword)
+	}
+	if calls := store.Calls("UpdatePassword"); calls != 0 {
+		t.Fatalf("пароль сохранен при ошибке проверки (%d раз)", calls)
+	}
+
+	if err := service.ChangePassword(context.Background(), userID, "old-password", "new-password"); err != nil {
+		t.Fatalf("ChangePassword: %v", err)
+	}
+	if bcrypt.CompareHashAndPassword([]byte(saved), []byte("new-password")) != nil {
+		t.Error("сохранен хэш, не соответствующий новому паролю")
+	}
+}
+
+func TestSetRole(t *testing.T) {
+	userID, otherCollegeUserID := uuid.New(), uuid.New()
+	store := &mocks.UserStore{
+		GetUserByIDFunc: func(ctx context.Context, id uuid.UUID) (*users.User, error) {
+			if id == otherCollegeUserID {
+				return &users.User{ID: id, Role: users.RoleStudent, CollegeID: uuid.New()}, nil
+			}
+			return &users.User{ID: id, Role: users.RoleStudent, CollegeID: tenant.DefaultCollegeID}, nil
+		},
+		UpdateRoleFunc: func(ctx context.Context, id uuid.UUID, role users.Role) error {
+			return nil
+		},
+	}
+	service := users.NewService(store)
+
+	if _, err := service.SetRole(context.Background(), userID, "superuser"); !errors.Is(err, users.ErrUnknownRole) {
+		t.Errorf("неизвестная роль: ошибка %v, ожидалась %v", err, users.ErrUnknownRole)
+	}
+	if _, err := service.SetRole(context.Background(), otherCollegeUserID, users.RoleAdmin); !errors.Is(err, apperr.ErrNotFound) {
+		t.Errorf("пользователь другого колледжа: ошибка %v, ожидалась %v", err, apperr.ErrNotFound)
+	}
+
+	previous, err := service.SetRole(context.Background(), userID, users.RoleTeacher)
+	if err != nil {
+		t.Fatalf("SetRole: %v", err)
+	}
+	if previous != users.RoleStudent {
+		t.Errorf("предыдущая роль %q, ожидалась %q", previous, users.RoleStudent)
+	}
+	if calls := store.Calls("UpdateRole"); calls != 1 {
+		t.Errorf("UpdateRole вызван %d раз, ожидался 1", calls)
+	}
+}
+
+func TestSetFormatPreferences(t *testing.T) {
+	var saved users.FormatPreferences
+	store := &mocks.UserStore{
+		SetFormatPreferencesFunc: func(ctx context.Context, userID uuid.UUID, prefs users.FormatPreferences) error {
+			saved = prefs
+			return nil
+		},
+	}
+	service := users.NewService(store)
+	userID := uuid.New()
+
+	for _, prefs := range []users.FormatPreferences{
+		{Locale: "de"},
+		{Locale: "ru", DateFormat: "d/m/y"},
+		{Locale: "en", TimeFormat: "am/pm"},
+	} {
+		if err := service.SetFormatPreferences(context.Background(), userID, prefs); !errors.Is(err, users.ErrInvalidFormatPreferences) {
+			t.Errorf("%+v: ошибка %v, ожидалась %v", prefs, err, users.ErrInvalidFormatPreferences)
+		}
+	}
+	if calls := store.Calls("SetFormatPreferences"); calls != 0 {
+		t.Fatalf("некорректные форматы сохранены %d раз", calls)
+	}
+
+	if err := service.SetFormatPreferences(context.Background(), userID, users.FormatPreferences{TimeFormat: "12h"}); err != nil {
+		t.Fatalf("SetFormatPreferences: %v", err)
+	}
+	if saved.Locale != users.DefaultLocale {
+		t.Errorf("язык %q, ожидался язык по умолчанию", saved.Locale)
+	}
+
+	at := time.Date(2026, time.March, 5, 14, 30, 0, 0, time.UTC)
+	formats := saved.Formats()
+	if got := formats.FormatDateTime(at); got != "05.03.2026 2:30 PM" {
+		t.Errorf("ru с 12-часовым временем: %q", got)
+	}
+	formats = users.FormatPreferences{Locale: "en", DateFormat: "yyyy-mm-dd"}.Formats()
+	if got := formats.FormatDate(at) + " " + formats.FormatClock("9:05"); got != "2026-03-05 9:05 AM" {
+		t.Errorf("en с форматом ISO: %q", got)
+	}
+}
+
+// fakeMailer запоминает отправленные письма; на адрес fail отправка не удается
+type fakeMailer struct {
+	sent map[string]string
+	fail string
+}
+
+func (m *fakeMailer) Send(ctx context.Context, to, subject, body string) error {
+	if to == m.fail {
+		return errors.New("mailbox unavailable")
+	}
+	m.sent[to] = body
+	return nil
+}
+
+func TestImportRoster(t *testing.T) {
+	csv := "\xef\xbb\xbfФИО;Email;Группа;Номер\n" +
+		"Иванов Иван Иванович;ivanov@example.com;ИС-21;1001\n" +
+		";;;\n" +
+		"Петров Петр;petrov@example.com;ИС-21;1002\n" +
+		"Сидорова Анна;old@example.com;ИС-21;\n"
+	records, err := users.ReadRosterCSV(strings.NewReader(csv))
+	if err != nil {
+		t.Fatal(err)
+	}
+	students, err := users.ParseRoster(records)
+	if err != nil {
+		t.Fatalf("ParseRoster: %v", err)
+	}
+	if len(students) != 3 || students[0].Email != "ivanov@example.com" || students[0].StudentNumber != "1001" ||
+		students[0].Course != 1 || students[2].Line != 5 {
+		t.Fatalf("разобран список %+v", students)
+	}
+
+	if _, err := users.ParseRoster([][]string{{"ivanov@example.com", "Иванов Иван", "ИС-21"}, {"IVANOV@example.com", "Иванов Иван", "ИС-22"}}); !errors.Is(err, users.ErrInvalidRoster) {
+		t.Errorf("повтор email: ошибка %v, ожидалась %v", err, users.ErrInvalidRoster)
+	}
+
+	created := make(map[string]*users.User)
+	store := &mocks.UserStore{
+		GetUserByEmailFunc: func(ctx context.Context, email string) (*users.User, error) {
+			if email == "old@example.com" {
+				return &users.User{ID: uuid.New(), Email: email}, nil
+			}
+			return nil, sql.ErrNoRows
+		},
+		CreateUserFunc: func(ctx context.Context, user *users.User) error {
+			created[user.Email] = user
+			return nil
+		},
+		CreateStudentFunc: func(ctx context.Context, student *users.Student) error {
+			return nil
+		},
+	}
+	mailer := &fakeMailer{sent: make(map[string]string), fail: "petrov@example.com"}
+	service := users.NewService(store)
+	service.SetMailer(mailer, "")
+
+	result, err := service.ImportRoster(context.Background(), students)
+	if err != nil {
+		t.Fatalf("ImportRoster: %v", err)
+	}
+	if len(result.Created) != 2 || len(result.Skipped) != 1 || result.Skipped[0].Student.Email != "old@example.com" {
+		t.Fatalf("итог загрузки %+v", result)
+	}
+
+	invited, notInvited := result.Created[0], result.Created[1]
+	if !invited.Invited || invited.Password != "" {
+		t.Errorf("приглашение отправлено, но пароль возвращен администратору: %+v", invited)
+	}
+	if notInvited.Invited || notInvited.Password == "" {
+		t.Errorf("приглашение не отправлено, а пароля в итоге нет: %+v", notInvited)
+	}
+	if user := created["petrov@example.com"]; user == nil || !user.PasswordChangeRequired ||
+		bcrypt.CompareHashAndPassword([]byte(user.Password), []byte(notInvited.Password)) != nil {
+		t.Errorf("создан пользователь %+v, ожидался временный пароль %q", user, notInvited.Password)
+	}
+	if user := created["ivanov@example.com"]; user == nil ||
+		!strings.Contains(mailer.sent["ivanov@example.com"], "ivanov@example.com") {
+		t.Errorf("письмо с приглашением: %q", mailer.sent["ivanov@example.com"])
+	}
+}
+
+func TestVerifyTwoFactorReplay(t *testing.T) {
+	secret, err := totp.GenerateSecret()
+	if err != nil {
+		t.Fatal(err)
+	}
+	twoFactor := &users.TwoFactor{Secret: secret, Enabled: true}
+	store := &mocks.UserStore{
+		GetTwoFactorFunc: func(ctx context.Context, userID uuid.UUID) (*users.TwoFactor, error) {
+			return twoFactor, nil
+		},
+		// Как в репозитории: принимается только период позже последнего принятого
+		UseTwoFactorStepFunc: func(ctx context.Context, userID uuid.UUID, step int64) (bool, error) {
+			if step <= twoFactor.LastUsedStep {
+				return false, nil
+			}
+			twoFactor.LastUsedStep = step
+			return true, nil
+		},
+		UseRecoveryCodeFunc: func(ctx context.Context, userID uuid.UUID, codeHash string) (bool, error) {
+			return false, nil
+		},
+	}
+	service := users.NewService(store)
+	userID := uuid.New()
+
+	codeAt := func(t *testing.T, at time.Time) string {
+		t.Helper()
+		code, err := totp.Code(secret, at)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return code
+	}
+	now := time.Now()
+
+	tests := []struct {
+		name    string
+		code    string
+		wantErr error
+	}{
+		{"код текущего периода", codeAt(t, now), nil},
+		{"повтор того же кода", codeAt(t, now), users.ErrTwoFactorInvalidCode},
+		{"код предыдущего периода после текущего", codeAt(t, now.Add(-totp.Period)), users.ErrTwoFactorInvalidCode},
+		{"код следующего периода", codeAt(t, now.Add(totp.Period)), nil},
+		{"повтор кода следующего периода", codeAt(t, now.Add(totp.Period)), users.ErrTwoFactorInvalidCode},
+	}
+	for _, tt := range tests {
+		recovery, err := service.VerifyTwoFactor(context.Background(), userID, tt.code)
+		if !errors.Is(err, tt.wantErr) {
+			t.Errorf("%s: ошибка %v, ожидалась %v", tt.name, err, tt.wantErr)
+		}
+		if recovery {
+			t.Errorf("%s: код принят как код восстановления", tt.name)
+		}
+	}
+}
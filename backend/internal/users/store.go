@@ -0,0 +1,68 @@
+package users
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// UserStore хранилище пользователей, которое использует Service. Реализуется
+// Repository; в тестах подменяется моком из internal/mocks.
+//
+//go:generate go run ../../cmd/mockgen -source store.go -interface UserStore -out ../mocks/user_store.go
+type UserStore interface {
+	CreateUser(ctx context.Context, user *User) error
+	GetUserByEmail(ctx context.Context, email string) (*User, error)
+	GetUserByID(ctx context.Context, id uuid.UUID) (*User, error)
+	GetPasswordHash(ctx context.Context, userID uuid.UUID) (string, error)
+	CreateStudent(ctx context.Context, student *Student) error
+	CreateTeacher(ctx context.Context, teacher *Teacher) error
+	GetStudentByUserID(ctx context.Context, userID uuid.UUID) (*Student, error)
+	GetTeacherByUserID(ctx context.Context, userID uuid.UUID) (*Teacher, error)
+	GetStudentsByGroup(ctx context.Context, groupName string) ([]uuid.UUID, error)
+	GetStudentsByGroups(ctx context.Context, groupNames []string) (map[string][]uuid.UUID, error)
+	GetGroupRoster(ctx context.Context, groupName string) ([]Student, error)
+	UpdatePassword(ctx context.Context, userID uuid.UUID, passwordHash string) error
+	UpdateRole(ctx context.Context, userID uuid.UUID, role Role) error
+	SetFormatPreferences(ctx context.Context, userID uuid.UUID, prefs FormatPreferences) error
+	GetFormatPreferences(ctx context.Context, userIDs []uuid.UUID) (map[uuid.UUID]FormatPreferences, error)
+	SetLoginAlerts(ctx context.Context, userID uuid.UUID, enabled bool) error
+	ResetCalendarToken(ctx context.Context, userID uuid.UUID) (int, error)
+	SoftDeleteUser(ctx context.Context, userID, deletedBy uuid.UUID) (int, error)
+	GetDeletedUser(ctx context.Context, userID uuid.UUID) (*User, error)
+	RestoreUser(ctx context.Context, userID uuid.UUID) error
+	IsEmailDeleted(ctx context.Context, email string) (bool, error)
+	InvalidateUsers(ctx context.Context, userIDs []uuid.UUID)
+	RevokeToken(ctx context.Context, jti string, userID uuid.UUID, expiresAt time.Time) error
+	IsTokenRevoked(ctx context.Context, jti string) (bool, error)
+	GetTeachers(ctx context.Context) ([]Teacher, error)
+	CreateTeacherNameClaim(ctx context.Context, claim *TeacherNameClaim) error
+	GetTeacherNameClaimByID(ctx context.Context, id uuid.UUID) (*TeacherNameClaim, error)
+	GetTeacherNameClaimsByTeacher(ctx context.Context, teacherID uuid.UUID) ([]TeacherNameClaim, error)
+	GetPendingTeacherNameClaims(ctx context.Context) ([]TeacherNameClaim, error)
+	GetApprovedTeacherNameClaim(ctx context.Context, scrapedName string) (*TeacherNameClaim, error)
+	ReviewTeacherNameClaim(ctx context.Context, claim *TeacherNameClaim) error
+	GetApprovedTeacherNames(ctx context.Context, teacherID uuid.UUID) ([]string, error)
+	GetTeachersByScrapedName(ctx context.Context, scrapedName string) ([]uuid.UUID, error)
+	ImportTeacherDirectory(ctx context.Context, teachers []DirectoryTeacher, replace bool) (*DirectoryImportResult, error)
+	GetTeacherDirectory(ctx context.Context) ([]DirectoryTeacher, error)
+	CreateInvitation(ctx context.Context, invitation *Invitation) error
+	GetInvitationByCode(ctx context.Context, code string) (*Invitation, error)
+	GetInvitations(ctx context.Context, activeOnly bool) ([]Invitation, error)
+	UseInvitation(ctx context.Context, id uuid.UUID) (bool, error)
+	ReleaseInvitation(ctx context.Context, id uuid.UUID) error
+	SetUserInvitation(ctx context.Context, userID, invitationID uuid.UUID) error
+	RevokeInvitation(ctx context.Context, id uuid.UUID) (*Invitation, error)
+	GetTwoFactor(ctx context.Context, userID uuid.UUID) (*TwoFactor, error)
+	SaveTwoFactorSecret(ctx context.Context, userID uuid.UUID, secret string) error
+	EnableTwoFactor(ctx context.Context, userID uuid.UUID, step int64, recoveryHashes []string) error
+	DisableTwoFactor(ctx context.Context, userID uuid.UUID) error
+	UseTwoFactorStep(ctx context.Context, userID uuid.UUID, step int64) (bool, error)
+	UseRecoveryCode(ctx context.Context, userID uuid.UUID, codeHash string) (bool, error)
+	CountRecoveryCodes(ctx context.Context, userID uuid.UUID) (int, error)
+	AuthenticateUser(ctx context.Context, email, password string) (*User, error)
+	UpdateStudentSubgroup(ctx context.Context, userID uuid.UUID, subgroup int) error
+}
+
+var _ UserStore = (*Repository)(nil)
@@ -0,0 +1,53 @@
+package users
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/Ultrahd-dev/student-schedule-app/backend/internal/apperr"
+	"github.com/google/uuid"
+)
+
+// MaxSubgroup наибольший номер подгруппы (в таблице расписания - "1 п/г" ... "9 п/г")
+const MaxSubgroup = 9
+
+// ErrInvalidSubgroup некорректный номер подгруппы
+var ErrInvalidSubgroup = apperr.New(apperr.ErrValidation, "некорректный номер подгруппы")
+
+// validateSubgroup проверяет номер подгруппы студента (0 - не выбрана)
+func validateSubgroup(subgroup int) error {
+	if subgroup < 0 || subgroup > MaxSubgroup {
+		return fmt.Errorf("%w: ожидается число от 0 до %d", ErrInvalidSubgroup, MaxSubgroup)
+	}
+	return nil
+}
+
+// SetStudentSubgroup сохраняет подгруппу студента и возвращает обновленный профиль.
+// 0 сбрасывает выбор: в расписании видны занятия всех подгрупп.
+func (s *Service) SetStudentSubgroup(ctx context.Context, userID uuid.UUID, subgroup int) (*Student, error) {
+	if err := validateSubgroup(subgroup); err != nil {
+		return nil, err
+	}
+	if err := s.repo.UpdateStudentSubgroup(ctx, userID, subgroup); err != nil {
+		return nil, err
+	}
+	return s.repo.GetStudentByUserID(ctx, userID)
+}
+
+// UpdateStudentSubgroup обновляет подгруппу в профиле студента
+func (r *Repository) UpdateStudentSubgroup(ctx context.Context, userID uuid.UUID, subgroup int) error {
+	query := `UPDATE students SET subgroup = $1 WHERE user_id = $2`
+
+	result, err := r.db.ExecContext(ctx, query, subgroup, userID)
+	if err != nil {
+		return fmt.Errorf("failed to update student subgroup: %w", err)
+	}
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to update student subgroup: %w", err)
+	}
+	if affected == 0 {
+		return fmt.Errorf("student profile not found: %w", apperr.ErrNotFound)
+	}
+	return nil
+}
@@ -0,0 +1,214 @@
+package users
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"log"
+	"strings"
+	"unicode"
+
+	"github.com/Ultrahd-dev/student-schedule-app/backend/internal/apperr"
+	"github.com/google/uuid"
+)
+
+// Ошибки заявки преподавателя на вариант имени
+var (
+	ErrInvalidTeacherName = apperr.New(apperr.ErrValidation, "некорректный вариант имени")
+	ErrTeacherNameTaken   = apperr.New(apperr.ErrAlreadyExists, "этот вариант имени уже закреплен за другим преподавателем")
+	ErrClaimReviewed      = apperr.New(apperr.ErrConflict, "заявка уже рассмотрена")
+)
+
+// ClaimTeacherName сохраняет заявку преподавателя на вариант своего имени в расписании.
+// Если вариант однозначно совпадает с ФИО из профиля (и ни с чьим другим),
+// заявка подтверждается автоматически, иначе ждет подтверждения администратором.
+// Повторная заявка на тот же вариант возвращает существующую.
+func (s *Service) ClaimTeacherName(ctx context.Context, teacherID uuid.UUID, scrapedName string) (*TeacherNameClaim, error) {
+	scrapedName = strings.Join(strings.Fields(scrapedName), " ")
+	if scrapedName == "" {
+		return nil, ErrInvalidTeacherName
+	}
+
+	teacher, err := s.repo.GetTeacherByUserID(ctx, teacherID)
+	if err != nil {
+		return nil, fmt.Errorf("ошибка получения профиля преподавателя: %w", err)
+	}
+
+	existing, err := s.repo.GetTeacherNameClaimsByTeacher(ctx, teacherID)
+	if err != nil {
+		return nil, fmt.Errorf("ошибка получения заявок преподавателя: %w", err)
+	}
+	for i := range existing {
+		if existing[i].ScrapedName == scrapedName && existing[i].Status != TeacherNameClaimRejected {
+			return &existing[i], nil
+		}
+	}
+
+	owner, err := s.repo.GetApprovedTeacherNameClaim(ctx, scrapedName)
+	switch {
+	case err == nil:
+		if owner.TeacherID != teacherID {
+			return nil, ErrTeacherNameTaken
+		}
+	case !errors.Is(err, sql.ErrNoRows):
+		return nil, fmt.Errorf("ошибка проверки варианта имени: %w", err)
+	}
+
+	status := TeacherNameClaimPending
+	if NameMatches(teacher.FullName, scrapedName) {
+		unique, err := s.uniqueNameMatch(ctx, teacher, scrapedName)
+		if err != nil {
+			return nil, err
+		}
+		if unique {
+			status = TeacherNameClaimApproved
+		}
+	}
+
+	claim := &TeacherNameClaim{
+		ID:          uuid.New(),
+		TeacherID:   teacherID,
+		TeacherName: teacher.FullName,
+		ScrapedName: scrapedName,
+		Status:      status,
+	}
+	if err := s.repo.CreateTeacherNameClaim(ctx, claim); err != nil {
+		return nil, fmt.Errorf("ошибка сохранения заявки: %w", err)
+	}
+
+	log.Printf("Преподаватель %s заявил вариант имени %q (%s)", teacher.FullName, scrapedName, status)
+	return claim, nil
+}
+
+// uniqueNameMatch проверяет, что вариант имени подходит только к ФИО преподавателя teacher:
+// ни к другим зарегистрированным преподавателям, ни к другим ФИО из справочника колледжа
+// (однофамилец может быть еще не зарегистрирован)
+func (s *Service) uniqueNameMatch(ctx context.Context, teacher *Teacher, scrapedName string) (bool, error) {
+	teachers, err := s.repo.GetTeachers(ctx)
+	if err != nil {
+		return false, fmt.Errorf("ошибка получения преподавателей: %w", err)
+	}
+
+	for _, other := range teachers {
+		if other.UserID != teacher.UserID && NameMatches(other.FullName, scrapedName) {
+			return false, nil
+		}
+	}
+
+	directory, err := s.repo.GetTeacherDirectory(ctx)
+	if err != nil {
+		return false, fmt.Errorf("ошибка получения справочника преподавателей: %w", err)
+	}
+
+	own := strings.Join(nameParts(teacher.FullName), " ")
+	for _, other := range directory {
+		if strings.Join(nameParts(other.FullName), " ") != own && NameMatches(other.FullName, scrapedName) {
+			return false, nil
+		}
+	}
+	return true, nil
+}
+
+// ListTeacherNameClaims возвращает заявки преподавателя на варианты имени
+func (s *Service) ListTeacherNameClaims(ctx context.Context, teacherID uuid.UUID) ([]TeacherNameClaim, error) {
+	claims, err := s.repo.GetTeacherNameClaimsByTeacher(ctx, teacherID)
+	if err != nil {
+		return nil, fmt.Errorf("ошибка получения заявок преподавателя: %w", err)
+	}
+	return claims, nil
+}
+
+// ListPendingTeacherNameClaims возвращает заявки на варианты имени, ожидающие подтверждения
+func (s *Service) ListPendingTeacherNameClaims(ctx context.Context) ([]TeacherNameClaim, error) {
+	claims, err := s.repo.GetPendingTeacherNameClaims(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("ошибка получения заявок на рассмотрении: %w", err)
+	}
+	return claims, nil
+}
+
+// ReviewTeacherNameClaim подтверждает или отклоняет заявку на вариант имени
+func (s *Service) ReviewTeacherNameClaim(ctx context.Context, claimID, adminID uuid.UUID, approve bool) (*TeacherNameClaim, error) {
+	claim, err := s.repo.GetTeacherNameClaimByID(ctx, claimID)
+	if err != nil {
+		return nil, fmt.Errorf("ошибка получения заявки: %w", err)
+	}
+	if claim.Status != TeacherNameClaimPending {
+		return nil, ErrClaimReviewed
+	}
+
+	claim.Status = TeacherNameClaimRejected
+	if approve {
+		owner, err := s.repo.GetApprovedTeacherNameClaim(ctx, claim.ScrapedName)
+		switch {
+		case err == nil && owner.TeacherID != claim.TeacherID:
+			return nil, ErrTeacherNameTaken
+		case err != nil && !errors.Is(err, sql.ErrNoRows):
+			return nil, fmt.Errorf("ошибка проверки варианта имени: %w", err)
+		}
+		claim.Status = TeacherNameClaimApproved
+	}
+
+	claim.ReviewedBy = &adminID
+	if err := s.repo.ReviewTeacherNameClaim(ctx, claim); err != nil {
+		return nil, fmt.Errorf("ошибка сохранения решения по заявке: %w", err)
+	}
+
+	log.Printf("Заявка %s на вариант имени %q рассмотрена администратором %s: %s", claimID, claim.ScrapedName, adminID, claim.Status)
+	return claim, nil
+}
+
+// TeacherNames возвращает все имена, под которыми преподаватель встречается в расписании:
+// ФИО из профиля и подтвержденные варианты
+func (s *Service) TeacherNames(ctx context.Context, teacher *Teacher) ([]string, error) {
+	claimed, err := s.repo.GetApprovedTeacherNames(ctx, teacher.UserID)
+	if err != nil {
+		return nil, fmt.Errorf("ошибка получения вариантов имени преподавателя: %w", err)
+	}
+
+	names := []string{teacher.FullName}
+	for _, name := range claimed {
+		if name != teacher.FullName {
+			names = append(names, name)
+		}
+	}
+	return names, nil
+}
+
+// NameMatches проверяет, что имя из расписания соответствует ФИО преподавателя:
+// фамилии совпадают, а остальные части - полностью или по инициалам
+// ("Иванов И.И.", "Иванов Иван И.", "Иванов И." для "Иванов Иван Иванович").
+// Регистр, точки и "ё" не учитываются.
+func NameMatches(fullName, scrapedName string) bool {
+	full := nameParts(fullName)
+	scraped := nameParts(scrapedName)
+	if len(full) == 0 || len(scraped) == 0 || len(scraped) > len(full) {
+		return false
+	}
+	if scraped[0] != full[0] {
+		return false
+	}
+
+	for i := 1; i < len(scraped); i++ {
+		part, want := []rune(scraped[i]), []rune(full[i])
+		if len(part) == 1 {
+			if part[0] != want[0] {
+				return false
+			}
+			continue
+		}
+		if scraped[i] != full[i] {
+			return false
+		}
+	}
+	return true
+}
+
+// nameParts разбивает имя на части в нижнем регистре, считая точки разделителями
+func nameParts(name string) []string {
+	name = strings.ReplaceAll(strings.ToLower(name), "ё", "е")
+	return strings.FieldsFunc(name, func(r rune) bool {
+		return unicode.IsSpace(r) || r == '.'
+	})
+}
@@ -0,0 +1,189 @@
+package users
+
+import (
+	"context"
+	"crypto/sha256"
+	"database/sql"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"log"
+	"strings"
+	"time"
+
+	"github.com/Ultrahd-dev/student-schedule-app/backend/internal/apperr"
+	"github.com/Ultrahd-dev/student-schedule-app/backend/internal/totp"
+	"github.com/google/uuid"
+)
+
+// Ошибки двухфакторной аутентификации
+var (
+	ErrTwoFactorNotAllowed     = apperr.New(apperr.ErrForbidden, "двухфакторная аутентификация недоступна для этой роли")
+	ErrTwoFactorNotEnabled     = apperr.New(apperr.ErrConflict, "двухфакторная аутентификация не подключена")
+	ErrTwoFactorAlreadyEnabled = apperr.New(apperr.ErrConflict, "двухфакторная аутентификация уже подключена")
+	ErrTwoFactorInvalidCode    = apperr.New(apperr.ErrValidation, "неверный код подтверждения")
+)
+
+// recoveryCodeCount количество выдаваемых кодов восстановления
+const recoveryCodeCount = 10
+
+// TwoFactorConfig настройки двухфакторной аутентификации
+type TwoFactorConfig struct {
+	Issuer string // Название сервиса в приложении-аутентификаторе
+	Roles  []Role // Роли, которым доступна 2FA (пусто - всем)
+}
+
+// ConfigureTwoFactor задает настройки двухфакторной аутентификации
+func (s *Service) ConfigureTwoFactor(config TwoFactorConfig) {
+	s.twoFactor = config
+}
+
+// twoFactorAllowed проверяет, что роль может подключить 2FA
+func (s *Service) twoFactorAllowed(role Role) bool {
+	if len(s.twoFactor.Roles) == 0 {
+		return true
+	}
+	for _, allowed := range s.twoFactor.Roles {
+		if allowed == role {
+			return true
+		}
+	}
+	return false
+}
+
+// BeginTwoFactorEnrollment создает новый секрет TOTP и возвращает его вместе с otpauth URI для QR-кода.
+// 2FA включается только после подтверждения кодом (ConfirmTwoFactor).
+func (s *Service) BeginTwoFactorEnrollment(ctx context.Context, user *User) (string, string, error) {
+	if !s.twoFactorAllowed(user.Role) {
+		return "", "", ErrTwoFactorNotAllowed
+	}
+
+	current, err := s.repo.GetTwoFactor(ctx, user.ID)
+	if err != nil && !errors.Is(err, sql.ErrNoRows) {
+		return "", "", fmt.Errorf("ошибка получения настроек 2FA: %w", err)
+	}
+	if current != nil && current.Enabled {
+		return "", "", ErrTwoFactorAlreadyEnabled
+	}
+
+	secret, err := totp.GenerateSecret()
+	if err != nil {
+		return "", "", err
+	}
+	if err := s.repo.SaveTwoFactorSecret(ctx, user.ID, secret); err != nil {
+		return "", "", fmt.Errorf("ошибка сохранения секрета 2FA: %w", err)
+	}
+
+	return secret, totp.ProvisioningURI(s.twoFactor.Issuer, user.Email, secret), nil
+}
+
+// ConfirmTwoFactor включает 2FA после проверки первого кода из приложения
+// и возвращает коды восстановления. Коды показываются один раз и хранятся только в виде хешей.
+func (s *Service) ConfirmTwoFactor(ctx context.Context, userID uuid.UUID, code string) ([]string, error) {
+	tf, err := s.repo.GetTwoFactor(ctx, userID)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, ErrTwoFactorNotEnabled
+	}
+	if err != nil {
+		return nil, fmt.Errorf("ошибка получения настроек 2FA: %w", err)
+	}
+	if tf.Enabled {
+		return nil, ErrTwoFactorAlreadyEnabled
+	}
+
+	step, ok := totp.Validate(tf.Secret, code, time.Now())
+	if !ok {
+		return nil, ErrTwoFactorInvalidCode
+	}
+
+	codes := make([]string, recoveryCodeCount)
+	hashes := make([]string, recoveryCodeCount)
+	for i := range codes {
+		raw, err := randomCode(8)
+		if err != nil {
+			return nil, err
+		}
+		codes[i] = strings.ToLower(raw[:4] + "-" + raw[4:])
+		hashes[i] = hashRecoveryCode(codes[i])
+	}
+
+	if err := s.repo.EnableTwoFactor(ctx, userID, step, hashes); err != nil {
+		return nil, fmt.Errorf("ошибка включения 2FA: %w", err)
+	}
+
+	log.Printf("Пользователь %s подключил двухфакторную аутентификацию", userID)
+	return codes, nil
+}
+
+// TwoFactorEnabled проверяет, включена ли у пользователя 2FA
+func (s *Service) TwoFactorEnabled(ctx context.Context, userID uuid.UUID) (bool, error) {
+	tf, err := s.repo.GetTwoFactor(ctx, userID)
+	if errors.Is(err, sql.ErrNoRows) {
+		return false, nil
+	}
+	if err != nil {
+		return false, fmt.Errorf("ошибка получения настроек 2FA: %w", err)
+	}
+	return tf.Enabled, nil
+}
+
+// VerifyTwoFactor проверяет код из приложения или код восстановления.
+// Каждый код принимается только один раз. Возвращает признак использования кода восстановления.
+func (s *Service) VerifyTwoFactor(ctx context.Context, userID uuid.UUID, code string) (bool, error) {
+	tf, err := s.repo.GetTwoFactor(ctx, userID)
+	if errors.Is(err, sql.ErrNoRows) {
+		return false, ErrTwoFactorNotEnabled
+	}
+	if err != nil {
+		return false, fmt.Errorf("ошибка получения настроек 2FA: %w", err)
+	}
+	if !tf.Enabled {
+		return false, ErrTwoFactorNotEnabled
+	}
+
+	if step, ok := totp.Validate(tf.Secret, code, time.Now()); ok {
+		fresh, err := s.repo.UseTwoFactorStep(ctx, userID, step)
+		if err != nil {
+			return false, fmt.Errorf("ошибка проверки кода 2FA: %w", err)
+		}
+		if !fresh {
+			return false, ErrTwoFactorInvalidCode
+		}
+		return false, nil
+	}
+
+	used, err := s.repo.UseRecoveryCode(ctx, userID, hashRecoveryCode(code))
+	if err != nil {
+		return false, fmt.Errorf("ошибка проверки кода восстановления: %w", err)
+	}
+	if !used {
+		return false, ErrTwoFactorInvalidCode
+	}
+
+	remaining, err := s.repo.CountRecoveryCodes(ctx, userID)
+	if err == nil {
+		log.Printf("Пользователь %s вошел по коду восстановления, осталось кодов: %d", userID, remaining)
+	}
+	return true, nil
+}
+
+// DisableTwoFactor отключает 2FA после проверки кода из приложения или кода восстановления
+func (s *Service) DisableTwoFactor(ctx context.Context, userID uuid.UUID, code string) error {
+	if _, err := s.VerifyTwoFactor(ctx, userID, code); err != nil {
+		return err
+	}
+
+	if err := s.repo.DisableTwoFactor(ctx, userID); err != nil {
+		return fmt.Errorf("ошибка отключения 2FA: %w", err)
+	}
+
+	log.Printf("Пользователь %s отключил двухфакторную аутентификацию", userID)
+	return nil
+}
+
+// hashRecoveryCode возвращает хеш кода восстановления (регистр и дефисы не учитываются)
+func hashRecoveryCode(code string) string {
+	normalized := strings.ToLower(strings.NewReplacer("-", "", " ", "").Replace(strings.TrimSpace(code)))
+	sum := sha256.Sum256([]byte(normalized))
+	return hex.EncodeToString(sum[:])
+}
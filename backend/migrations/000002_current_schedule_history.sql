@@ -0,0 +1,41 @@
+-- +goose Up
+-- +goose StatementBegin
+
+-- История версий записей актуального расписания.
+-- Каждая запись current_schedule при создании и каждом изменении получает
+-- новую версию с периодом действия [effective_from, effective_to),
+-- что позволяет восстановить состояние расписания на любой момент в прошлом.
+CREATE TABLE current_schedule_history (
+    id UUID PRIMARY KEY,
+    entry_id UUID NOT NULL, -- ID записи в current_schedule
+    group_name VARCHAR(50) NOT NULL,
+    date DATE NOT NULL,
+    time_start TIME WITHOUT TIME ZONE NOT NULL,
+    time_end TIME WITHOUT TIME ZONE NOT NULL,
+    subject VARCHAR(255) NOT NULL,
+    teacher VARCHAR(255),
+    classroom VARCHAR(50),
+    source_type schedule_source_type NOT NULL,
+    source_id UUID NOT NULL,
+    is_active BOOLEAN NOT NULL,
+    effective_from TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    effective_to TIMESTAMP WITH TIME ZONE -- NULL для действующей версии
+);
+
+-- Индекс для запросов "расписание группы на дату по состоянию на момент"
+CREATE INDEX idx_current_schedule_history_group_date ON current_schedule_history(group_name, date, effective_from);
+-- У каждой записи может быть только одна действующая версия
+CREATE UNIQUE INDEX idx_current_schedule_history_open ON current_schedule_history(entry_id) WHERE effective_to IS NULL;
+
+-- Переносим существующие записи как начальные версии
+INSERT INTO current_schedule_history
+    (id, entry_id, group_name, date, time_start, time_end, subject, teacher, classroom, source_type, source_id, is_active)
+SELECT gen_random_uuid(), id, group_name, date, time_start, time_end, subject, teacher, classroom, source_type, source_id, COALESCE(is_active, TRUE)
+FROM current_schedule;
+
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE IF EXISTS current_schedule_history;
+-- +goose StatementEnd
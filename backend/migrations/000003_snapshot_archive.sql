@@ -0,0 +1,30 @@
+-- +goose Up
+-- +goose StatementBegin
+
+-- Архив данных снапшотов расписания.
+-- Строка снапшота остается в schedule_snapshots (на нее ссылаются изменения),
+-- а тяжелый JSON переносится в отдельную таблицу.
+CREATE TABLE schedule_snapshot_archive (
+    snapshot_id UUID PRIMARY KEY REFERENCES schedule_snapshots(id) ON DELETE CASCADE,
+    data JSONB NOT NULL,
+    archived_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW()
+);
+
+ALTER TABLE schedule_snapshots ADD COLUMN archived_at TIMESTAMP WITH TIME ZONE;
+
+CREATE INDEX idx_schedule_snapshots_created ON schedule_snapshots(created_at DESC);
+
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+-- Возвращаем данные из архива перед удалением таблицы
+UPDATE schedule_snapshots s
+SET data = a.data
+FROM schedule_snapshot_archive a
+WHERE a.snapshot_id = s.id;
+
+DROP INDEX IF EXISTS idx_schedule_snapshots_created;
+ALTER TABLE schedule_snapshots DROP COLUMN IF EXISTS archived_at;
+DROP TABLE IF EXISTS schedule_snapshot_archive;
+-- +goose StatementEnd
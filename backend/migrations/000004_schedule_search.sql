@@ -0,0 +1,30 @@
+-- +goose Up
+-- +goose StatementBegin
+
+-- Нечеткий поиск по предметам, преподавателям и аудиториям
+CREATE EXTENSION IF NOT EXISTS pg_trgm;
+
+-- Текст для поиска и его полнотекстовое представление
+ALTER TABLE current_schedule
+    ADD COLUMN search_text TEXT GENERATED ALWAYS AS (
+        subject || ' ' || COALESCE(teacher, '') || ' ' || COALESCE(classroom, '')
+    ) STORED,
+    ADD COLUMN search_vector TSVECTOR GENERATED ALWAYS AS (
+        setweight(to_tsvector('russian', subject), 'A') ||
+        setweight(to_tsvector('russian', COALESCE(teacher, '')), 'B') ||
+        setweight(to_tsvector('simple', COALESCE(classroom, '')), 'C')
+    ) STORED;
+
+CREATE INDEX idx_current_schedule_search_vector ON current_schedule USING GIN (search_vector);
+CREATE INDEX idx_current_schedule_search_trgm ON current_schedule USING GIN (search_text gin_trgm_ops);
+
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP INDEX IF EXISTS idx_current_schedule_search_trgm;
+DROP INDEX IF EXISTS idx_current_schedule_search_vector;
+ALTER TABLE current_schedule
+    DROP COLUMN IF EXISTS search_vector,
+    DROP COLUMN IF EXISTS search_text;
+-- +goose StatementEnd
@@ -0,0 +1,23 @@
+-- +goose Up
+-- +goose StatementBegin
+
+-- Предрассчитанное расписание группы на день (в первую очередь - на сегодня).
+-- Строка пересобирается в той же транзакции, что и запись в current_schedule,
+-- поэтому кэш не расходится с актуальным расписанием.
+-- Отсутствие строки означает промах кэша, пустой массив - день без пар.
+CREATE TABLE schedule_day_cache (
+    group_name VARCHAR(50) NOT NULL,
+    date DATE NOT NULL,
+    entries JSONB NOT NULL DEFAULT '[]'::jsonb,
+    refreshed_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    PRIMARY KEY (group_name, date)
+);
+
+CREATE INDEX idx_schedule_day_cache_date ON schedule_day_cache(date);
+
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE IF EXISTS schedule_day_cache;
+-- +goose StatementEnd
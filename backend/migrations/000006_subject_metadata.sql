@@ -0,0 +1,20 @@
+-- +goose Up
+-- +goose StatementBegin
+
+-- Параметры отображения предметов (цвет, короткое название, иконка),
+-- чтобы все клиенты показывали предметы одинаково. Редактируется администраторами.
+CREATE TABLE subject_metadata (
+    subject VARCHAR(255) PRIMARY KEY, -- Название предмета, как в расписании
+    short_name VARCHAR(32) NOT NULL DEFAULT '',
+    color VARCHAR(7) NOT NULL DEFAULT '' CHECK (color = '' OR color ~ '^#[0-9A-Fa-f]{6}$'),
+    icon VARCHAR(64) NOT NULL DEFAULT '', -- Подсказка для клиента (имя иконки)
+    updated_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    updated_by UUID REFERENCES users(id) ON DELETE SET NULL
+);
+
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE IF EXISTS subject_metadata;
+-- +goose StatementEnd
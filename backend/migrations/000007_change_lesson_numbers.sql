@@ -0,0 +1,20 @@
+-- +goose Up
+-- +goose StatementBegin
+
+-- Номер пары изменения и признак пересечения с существующими занятиями.
+-- Пересечения возникают у добавленных пар и требуют внимания администратора.
+ALTER TABLE schedule_changes
+    ADD COLUMN lesson_number SMALLINT,
+    ADD COLUMN has_overlap BOOLEAN NOT NULL DEFAULT FALSE;
+
+CREATE INDEX idx_schedule_changes_overlap ON schedule_changes(date) WHERE has_overlap;
+
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP INDEX IF EXISTS idx_schedule_changes_overlap;
+ALTER TABLE schedule_changes
+    DROP COLUMN IF EXISTS has_overlap,
+    DROP COLUMN IF EXISTS lesson_number;
+-- +goose StatementEnd
@@ -0,0 +1,24 @@
+-- +goose Up
+-- +goose StatementBegin
+
+-- Выборка всех изменений относительно снапшота
+CREATE INDEX idx_schedule_changes_snapshot ON schedule_changes(snapshot_id, date);
+
+-- Привязываем уже сохраненные изменения к снапшоту, период которого покрывает дату изменения
+UPDATE schedule_changes c
+SET snapshot_id = (
+    SELECT s.id
+    FROM schedule_snapshots s
+    WHERE c.date BETWEEN s.period_start AND s.period_end
+      AND s.created_at <= c.created_at
+    ORDER BY s.created_at DESC
+    LIMIT 1
+)
+WHERE c.snapshot_id IS NULL;
+
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP INDEX IF EXISTS idx_schedule_changes_snapshot;
+-- +goose StatementEnd
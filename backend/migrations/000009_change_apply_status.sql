@@ -0,0 +1,28 @@
+-- +goose Up
+-- +goose StatementBegin
+
+-- Статус применения изменения к current_schedule.
+-- pending - еще не применялось (или применение прервано), applied - применено,
+-- skipped - применять нечего (уже применено ранее), error - ошибка применения (см. apply_error).
+ALTER TABLE schedule_changes
+    ADD COLUMN apply_status VARCHAR(16) NOT NULL DEFAULT 'pending'
+        CHECK (apply_status IN ('pending', 'applied', 'skipped', 'error')),
+    ADD COLUMN apply_error TEXT,
+    ADD COLUMN applied_at TIMESTAMP WITH TIME ZONE;
+
+-- Изменения, сохраненные до появления статуса, уже были применены
+UPDATE schedule_changes SET apply_status = 'applied', applied_at = created_at;
+
+-- Поиск непримененных изменений для возобновления
+CREATE INDEX idx_schedule_changes_apply_pending ON schedule_changes(created_at) WHERE apply_status = 'pending';
+
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP INDEX IF EXISTS idx_schedule_changes_apply_pending;
+ALTER TABLE schedule_changes
+    DROP COLUMN IF EXISTS applied_at,
+    DROP COLUMN IF EXISTS apply_error,
+    DROP COLUMN IF EXISTS apply_status;
+-- +goose StatementEnd
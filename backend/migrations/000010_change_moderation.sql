@@ -0,0 +1,25 @@
+-- +goose Up
+-- +goose StatementBegin
+
+-- Модерация изменений: в модерируемом режиме изменения из таблицы попадают
+-- в статус pending и применяются только после одобрения администратором.
+ALTER TABLE schedule_changes
+    ADD COLUMN moderation_status VARCHAR(16) NOT NULL DEFAULT 'approved'
+        CHECK (moderation_status IN ('pending', 'approved', 'rejected')),
+    ADD COLUMN moderated_by UUID REFERENCES users(id) ON DELETE SET NULL,
+    ADD COLUMN moderated_at TIMESTAMP WITH TIME ZONE,
+    ADD COLUMN moderation_comment TEXT;
+
+CREATE INDEX idx_schedule_changes_moderation_pending ON schedule_changes(created_at) WHERE moderation_status = 'pending';
+
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP INDEX IF EXISTS idx_schedule_changes_moderation_pending;
+ALTER TABLE schedule_changes
+    DROP COLUMN IF EXISTS moderation_comment,
+    DROP COLUMN IF EXISTS moderated_at,
+    DROP COLUMN IF EXISTS moderated_by,
+    DROP COLUMN IF EXISTS moderation_status;
+-- +goose StatementEnd
@@ -0,0 +1,36 @@
+-- +goose Up
+-- +goose StatementBegin
+
+-- Отслеживание присутствия изменений в таблице изменений.
+-- fingerprint - отпечаток строки таблицы, по которому изменение узнается при следующих парсингах;
+-- last_seen_at - время последнего парсинга, в котором строка была в таблице;
+-- reverted_at - время отката изменения, строка которого исчезла из таблицы.
+-- Изменения, сохраненные до появления отпечатков, не отслеживаются и не откатываются.
+ALTER TABLE schedule_changes
+    ADD COLUMN fingerprint VARCHAR(64),
+    ADD COLUMN last_seen_at TIMESTAMP WITH TIME ZONE,
+    ADD COLUMN reverted_at TIMESTAMP WITH TIME ZONE;
+
+-- reverted - изменение отменено (строка исчезла из таблицы), current_schedule восстановлено
+ALTER TABLE schedule_changes
+    DROP CONSTRAINT IF EXISTS schedule_changes_apply_status_check,
+    ADD CONSTRAINT schedule_changes_apply_status_check
+        CHECK (apply_status IN ('pending', 'applied', 'skipped', 'error', 'reverted'));
+
+CREATE INDEX idx_schedule_changes_fingerprint ON schedule_changes(fingerprint) WHERE is_active = true AND fingerprint IS NOT NULL;
+
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP INDEX IF EXISTS idx_schedule_changes_fingerprint;
+UPDATE schedule_changes SET apply_status = 'applied' WHERE apply_status = 'reverted';
+ALTER TABLE schedule_changes
+    DROP CONSTRAINT IF EXISTS schedule_changes_apply_status_check,
+    ADD CONSTRAINT schedule_changes_apply_status_check
+        CHECK (apply_status IN ('pending', 'applied', 'skipped', 'error'));
+ALTER TABLE schedule_changes
+    DROP COLUMN IF EXISTS reverted_at,
+    DROP COLUMN IF EXISTS last_seen_at,
+    DROP COLUMN IF EXISTS fingerprint;
+-- +goose StatementEnd
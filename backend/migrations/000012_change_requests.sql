@@ -0,0 +1,47 @@
+-- +goose Up
+-- +goose StatementBegin
+
+-- Заявки преподавателей на перенос или отмену своей пары.
+-- Заявка ожидает одобрения администратором; после одобрения по ней создаются
+-- изменения расписания (отмена и, для переноса, добавление), которые применяются
+-- к current_schedule как обычные изменения.
+CREATE TABLE change_requests (
+    id UUID PRIMARY KEY,
+    teacher_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    kind VARCHAR(16) NOT NULL CHECK (kind IN ('cancel', 'move')),
+    group_name VARCHAR(50) NOT NULL,
+    date DATE NOT NULL,
+    time_start TIME WITHOUT TIME ZONE NOT NULL,
+    time_end TIME WITHOUT TIME ZONE NOT NULL,
+    subject VARCHAR(255) NOT NULL,
+    teacher VARCHAR(255) NOT NULL,
+    classroom VARCHAR(50),
+    -- Новый слот для переноса
+    new_date DATE,
+    new_time_start TIME WITHOUT TIME ZONE,
+    new_time_end TIME WITHOUT TIME ZONE,
+    new_lesson_number SMALLINT,
+    new_classroom VARCHAR(50),
+    comment TEXT,
+    status VARCHAR(16) NOT NULL DEFAULT 'pending' CHECK (status IN ('pending', 'approved', 'rejected')),
+    reviewed_by UUID REFERENCES users(id) ON DELETE SET NULL,
+    reviewed_at TIMESTAMP WITH TIME ZONE,
+    review_comment TEXT,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT NOW(),
+    CHECK (kind <> 'move' OR (new_date IS NOT NULL AND new_time_start IS NOT NULL AND new_time_end IS NOT NULL))
+);
+
+CREATE INDEX idx_change_requests_teacher ON change_requests(teacher_id, created_at DESC);
+CREATE INDEX idx_change_requests_pending ON change_requests(created_at) WHERE status = 'pending';
+
+-- Изменения, созданные по заявке преподавателя
+ALTER TABLE schedule_changes
+    ADD COLUMN request_id UUID REFERENCES change_requests(id) ON DELETE SET NULL;
+
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+ALTER TABLE schedule_changes DROP COLUMN IF EXISTS request_id;
+DROP TABLE IF EXISTS change_requests;
+-- +goose StatementEnd
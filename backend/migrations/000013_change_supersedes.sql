@@ -0,0 +1,17 @@
+-- +goose Up
+-- +goose StatementBegin
+
+-- Изменение, перезаписавшее в current_schedule пару, которую до него уже изменило
+-- другое изменение (та же группа, дата и время), ссылается на него через supersedes_id.
+ALTER TABLE schedule_changes
+    ADD COLUMN supersedes_id UUID REFERENCES schedule_changes(id) ON DELETE SET NULL;
+
+CREATE INDEX idx_schedule_changes_supersedes ON schedule_changes(supersedes_id) WHERE supersedes_id IS NOT NULL;
+
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP INDEX IF EXISTS idx_schedule_changes_supersedes;
+ALTER TABLE schedule_changes DROP COLUMN IF EXISTS supersedes_id;
+-- +goose StatementEnd
@@ -0,0 +1,12 @@
+-- +goose Up
+-- +goose StatementBegin
+
+-- Причина изменения ("преподаватель болен", "олимпиада"), показывается студентам
+ALTER TABLE schedule_changes ADD COLUMN reason TEXT;
+
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+ALTER TABLE schedule_changes DROP COLUMN IF EXISTS reason;
+-- +goose StatementEnd
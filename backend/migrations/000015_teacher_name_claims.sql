@@ -0,0 +1,29 @@
+-- +goose Up
+-- +goose StatementBegin
+
+-- Варианты написания ФИО преподавателя в таблицах расписания ("Иванов И.И.", "Иванов И.")
+-- Преподаватель заявляет свои варианты; однозначное совпадение с ФИО из профиля
+-- подтверждается автоматически, остальные - администратором.
+-- Подтвержденные варианты используются для "моего расписания", заявок на изменения
+-- и уведомлений преподавателю об изменениях его пар.
+CREATE TABLE teacher_name_claims (
+    id UUID PRIMARY KEY,
+    teacher_id UUID NOT NULL REFERENCES teachers(user_id) ON DELETE CASCADE,
+    scraped_name VARCHAR(255) NOT NULL,
+    status VARCHAR(16) NOT NULL DEFAULT 'pending' CHECK (status IN ('pending', 'approved', 'rejected')),
+    reviewed_by UUID REFERENCES users(id) ON DELETE SET NULL,
+    reviewed_at TIMESTAMP WITH TIME ZONE,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT NOW(),
+    UNIQUE (teacher_id, scraped_name)
+);
+
+-- Подтвержденный вариант имени принадлежит одному преподавателю
+CREATE UNIQUE INDEX idx_teacher_name_claims_approved ON teacher_name_claims(scraped_name) WHERE status = 'approved';
+CREATE INDEX idx_teacher_name_claims_pending ON teacher_name_claims(created_at) WHERE status = 'pending';
+
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE IF EXISTS teacher_name_claims;
+-- +goose StatementEnd
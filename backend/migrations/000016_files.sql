@@ -0,0 +1,29 @@
+-- +goose Up
+-- +goose StatementBegin
+
+-- Файлы пользователей (аватары, вложения). Содержимое лежит в хранилище (storage),
+-- здесь - метаданные и ключ объекта.
+CREATE TABLE files (
+    id UUID PRIMARY KEY,
+    owner_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    kind VARCHAR(16) NOT NULL CHECK (kind IN ('avatar', 'attachment')),
+    storage_key VARCHAR(255) NOT NULL UNIQUE,
+    name VARCHAR(255) NOT NULL,
+    content_type VARCHAR(100) NOT NULL,
+    size BIGINT NOT NULL CHECK (size >= 0),
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT NOW()
+);
+
+CREATE INDEX idx_files_owner ON files(owner_id, created_at DESC);
+
+-- Текущий аватар пользователя
+ALTER TABLE users
+    ADD COLUMN avatar_file_id UUID REFERENCES files(id) ON DELETE SET NULL;
+
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+ALTER TABLE users DROP COLUMN IF EXISTS avatar_file_id;
+DROP TABLE IF EXISTS files;
+-- +goose StatementEnd
@@ -0,0 +1,38 @@
+-- +goose Up
+-- +goose StatementBegin
+
+-- Журнал событий безопасности: регистрации, входы (в том числе неудачные),
+-- смены пароля и роли, отзывы токенов
+CREATE TABLE audit_events (
+    id UUID PRIMARY KEY,
+    event_type VARCHAR(32) NOT NULL CHECK (event_type IN (
+        'registration', 'login', 'login_failed', 'password_change', 'role_change', 'token_revocation')),
+    user_id UUID REFERENCES users(id) ON DELETE SET NULL, -- Пользователь, к которому относится событие
+    actor_id UUID REFERENCES users(id) ON DELETE SET NULL, -- Кто выполнил действие (администратор при смене роли)
+    email VARCHAR(255), -- Email, указанный при входе (в том числе несуществующий)
+    ip VARCHAR(64),
+    user_agent TEXT,
+    details TEXT,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT NOW()
+);
+
+CREATE INDEX idx_audit_events_created ON audit_events(created_at DESC);
+CREATE INDEX idx_audit_events_user ON audit_events(user_id, created_at DESC);
+
+-- Отозванные токены (по jti) до истечения их срока действия
+CREATE TABLE revoked_tokens (
+    jti VARCHAR(64) PRIMARY KEY,
+    user_id UUID REFERENCES users(id) ON DELETE CASCADE,
+    expires_at TIMESTAMP WITH TIME ZONE NOT NULL,
+    revoked_at TIMESTAMP WITH TIME ZONE DEFAULT NOW()
+);
+
+CREATE INDEX idx_revoked_tokens_expires ON revoked_tokens(expires_at);
+
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE IF EXISTS revoked_tokens;
+DROP TABLE IF EXISTS audit_events;
+-- +goose StatementEnd
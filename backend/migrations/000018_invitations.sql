@@ -0,0 +1,30 @@
+-- +goose Up
+-- +goose StatementBegin
+
+-- Коды приглашений для регистрации. Администратор выпускает код,
+-- при необходимости привязанный к роли и группе, с ограничением числа
+-- использований и сроком действия. Если регистрация по приглашениям
+-- включена в конфигурации, без действующего кода зарегистрироваться нельзя.
+CREATE TABLE invitations (
+    id UUID PRIMARY KEY,
+    code VARCHAR(32) NOT NULL UNIQUE,
+    role VARCHAR(50) CHECK (role IN ('student', 'teacher')), -- NULL - любая роль
+    group_name VARCHAR(100),                                  -- NULL - любая группа
+    max_uses INTEGER NOT NULL DEFAULT 1 CHECK (max_uses >= 0), -- 0 - без ограничения
+    used_count INTEGER NOT NULL DEFAULT 0,
+    expires_at TIMESTAMP WITH TIME ZONE,
+    created_by UUID REFERENCES users(id) ON DELETE SET NULL,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT NOW(),
+    revoked_at TIMESTAMP WITH TIME ZONE
+);
+
+-- По какому приглашению зарегистрирован пользователь
+ALTER TABLE users ADD COLUMN invitation_id UUID REFERENCES invitations(id) ON DELETE SET NULL;
+
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+ALTER TABLE users DROP COLUMN IF EXISTS invitation_id;
+DROP TABLE IF EXISTS invitations;
+-- +goose StatementEnd
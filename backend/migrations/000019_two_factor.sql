@@ -0,0 +1,40 @@
+-- +goose Up
+-- +goose StatementBegin
+
+-- Двухфакторная аутентификация (TOTP). Секрет сохраняется при начале подключения,
+-- 2FA включается после подтверждения первым кодом из приложения.
+-- last_used_step - номер периода последнего принятого кода (защита от повторного использования).
+CREATE TABLE user_two_factor (
+    user_id UUID PRIMARY KEY REFERENCES users(id) ON DELETE CASCADE,
+    secret VARCHAR(64) NOT NULL,
+    enabled BOOLEAN NOT NULL DEFAULT FALSE,
+    last_used_step BIGINT NOT NULL DEFAULT 0,
+    enabled_at TIMESTAMP WITH TIME ZONE,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT NOW()
+);
+
+-- Одноразовые коды восстановления на случай потери устройства (хранятся хеши)
+CREATE TABLE two_factor_recovery_codes (
+    user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    code_hash VARCHAR(64) NOT NULL,
+    used_at TIMESTAMP WITH TIME ZONE,
+    PRIMARY KEY (user_id, code_hash)
+);
+
+-- Подключение и отключение 2FA попадают в журнал безопасности
+ALTER TABLE audit_events DROP CONSTRAINT audit_events_event_type_check;
+ALTER TABLE audit_events ADD CONSTRAINT audit_events_event_type_check CHECK (event_type IN (
+    'registration', 'login', 'login_failed', 'password_change', 'role_change', 'token_revocation',
+    'two_factor_change'));
+
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DELETE FROM audit_events WHERE event_type = 'two_factor_change';
+ALTER TABLE audit_events DROP CONSTRAINT audit_events_event_type_check;
+ALTER TABLE audit_events ADD CONSTRAINT audit_events_event_type_check CHECK (event_type IN (
+    'registration', 'login', 'login_failed', 'password_change', 'role_change', 'token_revocation'));
+DROP TABLE IF EXISTS two_factor_recovery_codes;
+DROP TABLE IF EXISTS user_two_factor;
+-- +goose StatementEnd
@@ -0,0 +1,12 @@
+-- +goose Up
+-- +goose StatementBegin
+
+-- ФИО студента для списка группы, который видят преподаватели группы
+ALTER TABLE students ADD COLUMN full_name VARCHAR(255) NOT NULL DEFAULT '';
+
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+ALTER TABLE students DROP COLUMN IF EXISTS full_name;
+-- +goose StatementEnd
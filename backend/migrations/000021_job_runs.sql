@@ -0,0 +1,19 @@
+-- +goose Up
+-- +goose StatementBegin
+
+-- Последние успешные запуски периодических задач (парсинг, обслуживание).
+-- Задача выполняется под advisory-блокировкой, а по времени завершения другие
+-- экземпляры API понимают, что текущий цикл уже выполнен, и пропускают его.
+CREATE TABLE job_runs (
+    name VARCHAR(100) PRIMARY KEY,
+    instance VARCHAR(255) NOT NULL, -- Экземпляр, выполнивший последний запуск
+    started_at TIMESTAMP WITH TIME ZONE NOT NULL,
+    finished_at TIMESTAMP WITH TIME ZONE NOT NULL
+);
+
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE IF EXISTS job_runs;
+-- +goose StatementEnd
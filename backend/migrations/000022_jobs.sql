@@ -0,0 +1,35 @@
+-- +goose Up
+-- +goose StatementBegin
+
+-- Очередь фоновых задач. Воркеры всех экземпляров API забирают задачи
+-- через SELECT ... FOR UPDATE SKIP LOCKED, поэтому каждую задачу выполняет
+-- один воркер; при ошибке задача возвращается в очередь с задержкой.
+CREATE TABLE jobs (
+    id UUID PRIMARY KEY,
+    kind VARCHAR(100) NOT NULL,
+    payload JSONB NOT NULL DEFAULT '{}',
+    status VARCHAR(20) NOT NULL DEFAULT 'pending'
+        CHECK (status IN ('pending', 'running', 'done', 'failed')),
+    attempts INTEGER NOT NULL DEFAULT 0,
+    max_attempts INTEGER NOT NULL DEFAULT 5,
+    run_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    locked_by VARCHAR(255),
+    locked_at TIMESTAMP WITH TIME ZONE,
+    last_error TEXT,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT NOW(),
+    finished_at TIMESTAMP WITH TIME ZONE
+);
+
+-- Выборка готовых задач воркерами
+CREATE INDEX idx_jobs_pending ON jobs(run_at) WHERE status = 'pending';
+-- Поиск зависших задач
+CREATE INDEX idx_jobs_running ON jobs(locked_at) WHERE status = 'running';
+-- Просмотр задач администратором
+CREATE INDEX idx_jobs_kind_status ON jobs(kind, status, created_at DESC);
+
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE IF EXISTS jobs;
+-- +goose StatementEnd
@@ -0,0 +1,32 @@
+-- +goose Up
+-- +goose StatementBegin
+
+-- Transactional outbox: доменные события записываются в той же транзакции,
+-- что и изменение данных, а relay публикует их подписчикам (рассылка
+-- уведомлений, вебхуки). Событие не теряется, если процесс упадет между
+-- фиксацией изменения и отправкой уведомлений.
+CREATE TABLE outbox_events (
+    id UUID PRIMARY KEY,
+    event_type VARCHAR(100) NOT NULL,
+    aggregate_id UUID NOT NULL, -- ID снапшота или изменения, к которому относится событие
+    payload JSONB NOT NULL DEFAULT '{}',
+    attempts INTEGER NOT NULL DEFAULT 0,
+    next_attempt_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    last_error TEXT,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT NOW(),
+    published_at TIMESTAMP WITH TIME ZONE
+);
+
+-- Выборка неопубликованных событий relay
+CREATE INDEX idx_outbox_events_unpublished ON outbox_events(next_attempt_at, created_at)
+    WHERE published_at IS NULL;
+-- Удаление старых опубликованных событий
+CREATE INDEX idx_outbox_events_published ON outbox_events(published_at)
+    WHERE published_at IS NOT NULL;
+
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE IF EXISTS outbox_events;
+-- +goose StatementEnd
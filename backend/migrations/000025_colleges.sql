@@ -0,0 +1,106 @@
+-- +goose Up
+-- +goose StatementBegin
+
+-- Колледжи. Одно развертывание обслуживает несколько колледжей: пользователи,
+-- расписание, изменения и уведомления принадлежат колледжу, а парсер каждого
+-- колледжа работает со своим сайтом и таблицами. Существующие данные
+-- переносятся в колледж по умолчанию с фиксированным ID.
+CREATE TABLE colleges (
+    id UUID PRIMARY KEY,
+    slug VARCHAR(50) NOT NULL UNIQUE, -- Код колледжа в метаданных запросов (x-college)
+    name VARCHAR(255) NOT NULL,
+    -- Источники расписания; пустые значения берутся из раздела scraper конфигурации
+    base_url TEXT NOT NULL DEFAULT '',
+    main_schedule_gids BIGINT[] NOT NULL DEFAULT '{}',
+    changes_gid BIGINT,
+    is_active BOOLEAN NOT NULL DEFAULT TRUE,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT NOW()
+);
+
+INSERT INTO colleges (id, slug, name)
+VALUES ('00000000-0000-0000-0000-000000000001', 'default', 'Колледж по умолчанию');
+
+ALTER TABLE users ADD COLUMN college_id UUID NOT NULL
+    DEFAULT '00000000-0000-0000-0000-000000000001' REFERENCES colleges(id);
+ALTER TABLE invitations ADD COLUMN college_id UUID NOT NULL
+    DEFAULT '00000000-0000-0000-0000-000000000001' REFERENCES colleges(id);
+ALTER TABLE schedule_snapshots ADD COLUMN college_id UUID NOT NULL
+    DEFAULT '00000000-0000-0000-0000-000000000001' REFERENCES colleges(id);
+ALTER TABLE schedule_changes ADD COLUMN college_id UUID NOT NULL
+    DEFAULT '00000000-0000-0000-0000-000000000001' REFERENCES colleges(id);
+ALTER TABLE change_requests ADD COLUMN college_id UUID NOT NULL
+    DEFAULT '00000000-0000-0000-0000-000000000001' REFERENCES colleges(id);
+ALTER TABLE current_schedule ADD COLUMN college_id UUID NOT NULL
+    DEFAULT '00000000-0000-0000-0000-000000000001' REFERENCES colleges(id);
+ALTER TABLE current_schedule_history ADD COLUMN college_id UUID NOT NULL
+    DEFAULT '00000000-0000-0000-0000-000000000001' REFERENCES colleges(id);
+ALTER TABLE schedule_day_cache ADD COLUMN college_id UUID NOT NULL
+    DEFAULT '00000000-0000-0000-0000-000000000001' REFERENCES colleges(id);
+ALTER TABLE subject_metadata ADD COLUMN college_id UUID NOT NULL
+    DEFAULT '00000000-0000-0000-0000-000000000001' REFERENCES colleges(id);
+ALTER TABLE notifications ADD COLUMN college_id UUID NOT NULL
+    DEFAULT '00000000-0000-0000-0000-000000000001' REFERENCES colleges(id);
+-- Фоновые задачи и события выполняются в контексте колледжа, который их создал
+ALTER TABLE jobs ADD COLUMN college_id UUID NOT NULL
+    DEFAULT '00000000-0000-0000-0000-000000000001' REFERENCES colleges(id);
+ALTER TABLE outbox_events ADD COLUMN college_id UUID NOT NULL
+    DEFAULT '00000000-0000-0000-0000-000000000001' REFERENCES colleges(id);
+-- Варианты имени закрепляются за преподавателем внутри колледжа
+ALTER TABLE teacher_name_claims ADD COLUMN college_id UUID NOT NULL
+    DEFAULT '00000000-0000-0000-0000-000000000001' REFERENCES colleges(id);
+-- Журнал безопасности администратор видит только по своему колледжу
+ALTER TABLE audit_events ADD COLUMN college_id UUID NOT NULL
+    DEFAULT '00000000-0000-0000-0000-000000000001' REFERENCES colleges(id);
+
+-- Названия групп и предметов уникальны только внутри колледжа
+ALTER TABLE schedule_day_cache DROP CONSTRAINT schedule_day_cache_pkey;
+ALTER TABLE schedule_day_cache ADD PRIMARY KEY (college_id, group_name, date);
+ALTER TABLE subject_metadata DROP CONSTRAINT subject_metadata_pkey;
+ALTER TABLE subject_metadata ADD PRIMARY KEY (college_id, subject);
+DROP INDEX idx_teacher_name_claims_approved;
+CREATE UNIQUE INDEX idx_teacher_name_claims_approved
+    ON teacher_name_claims(college_id, scraped_name) WHERE status = 'approved';
+
+CREATE INDEX idx_users_college ON users(college_id, role);
+CREATE INDEX idx_schedule_snapshots_college ON schedule_snapshots(college_id, created_at DESC);
+CREATE INDEX idx_schedule_changes_college_date_group ON schedule_changes(college_id, date, group_name);
+CREATE INDEX idx_current_schedule_college_date_group ON current_schedule(college_id, date, group_name);
+CREATE INDEX idx_current_schedule_history_college_group_date
+    ON current_schedule_history(college_id, group_name, date, effective_from);
+CREATE INDEX idx_audit_events_college_created ON audit_events(college_id, created_at DESC);
+
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP INDEX IF EXISTS idx_audit_events_college_created;
+DROP INDEX IF EXISTS idx_current_schedule_history_college_group_date;
+DROP INDEX IF EXISTS idx_current_schedule_college_date_group;
+DROP INDEX IF EXISTS idx_schedule_changes_college_date_group;
+DROP INDEX IF EXISTS idx_schedule_snapshots_college;
+DROP INDEX IF EXISTS idx_users_college;
+
+DROP INDEX IF EXISTS idx_teacher_name_claims_approved;
+CREATE UNIQUE INDEX idx_teacher_name_claims_approved ON teacher_name_claims(scraped_name) WHERE status = 'approved';
+ALTER TABLE subject_metadata DROP CONSTRAINT subject_metadata_pkey;
+ALTER TABLE subject_metadata ADD PRIMARY KEY (subject);
+ALTER TABLE schedule_day_cache DROP CONSTRAINT schedule_day_cache_pkey;
+ALTER TABLE schedule_day_cache ADD PRIMARY KEY (group_name, date);
+
+ALTER TABLE audit_events DROP COLUMN IF EXISTS college_id;
+ALTER TABLE teacher_name_claims DROP COLUMN IF EXISTS college_id;
+ALTER TABLE outbox_events DROP COLUMN IF EXISTS college_id;
+ALTER TABLE jobs DROP COLUMN IF EXISTS college_id;
+ALTER TABLE notifications DROP COLUMN IF EXISTS college_id;
+ALTER TABLE subject_metadata DROP COLUMN IF EXISTS college_id;
+ALTER TABLE schedule_day_cache DROP COLUMN IF EXISTS college_id;
+ALTER TABLE current_schedule_history DROP COLUMN IF EXISTS college_id;
+ALTER TABLE current_schedule DROP COLUMN IF EXISTS college_id;
+ALTER TABLE change_requests DROP COLUMN IF EXISTS college_id;
+ALTER TABLE schedule_changes DROP COLUMN IF EXISTS college_id;
+ALTER TABLE schedule_snapshots DROP COLUMN IF EXISTS college_id;
+ALTER TABLE invitations DROP COLUMN IF EXISTS college_id;
+ALTER TABLE users DROP COLUMN IF EXISTS college_id;
+
+DROP TABLE IF EXISTS colleges;
+-- +goose StatementEnd
@@ -0,0 +1,23 @@
+-- +goose Up
+-- +goose StatementBegin
+
+-- Расписание звонков колледжа. Пока у колледжа нет строк, используется встроенное
+-- расписание звонков; импортированное расписание (migrator import-bells) заменяет
+-- его целиком: день недели без строк считается днем без занятий.
+-- weekday - день недели как в Go time.Weekday (0 - воскресенье).
+CREATE TABLE bell_timings (
+    college_id UUID NOT NULL REFERENCES colleges(id),
+    weekday SMALLINT NOT NULL CHECK (weekday BETWEEN 0 AND 6),
+    lesson_number SMALLINT NOT NULL CHECK (lesson_number > 0),
+    time_start TIME NOT NULL,
+    time_end TIME NOT NULL CHECK (time_end > time_start),
+    updated_at TIMESTAMP WITH TIME ZONE DEFAULT NOW(),
+    PRIMARY KEY (college_id, weekday, lesson_number)
+);
+
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE IF EXISTS bell_timings;
+-- +goose StatementEnd
@@ -0,0 +1,19 @@
+-- +goose Up
+-- +goose StatementBegin
+
+-- Выгрузка данных пользователей (migrator export-users) попадает в журнал безопасности
+ALTER TABLE audit_events DROP CONSTRAINT audit_events_event_type_check;
+ALTER TABLE audit_events ADD CONSTRAINT audit_events_event_type_check CHECK (event_type IN (
+    'registration', 'login', 'login_failed', 'password_change', 'role_change', 'token_revocation',
+    'two_factor_change', 'user_export'));
+
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DELETE FROM audit_events WHERE event_type = 'user_export';
+ALTER TABLE audit_events DROP CONSTRAINT audit_events_event_type_check;
+ALTER TABLE audit_events ADD CONSTRAINT audit_events_event_type_check CHECK (event_type IN (
+    'registration', 'login', 'login_failed', 'password_change', 'role_change', 'token_revocation',
+    'two_factor_change'));
+-- +goose StatementEnd
@@ -0,0 +1,151 @@
+-- +goose Up
+-- +goose StatementBegin
+
+-- Секционирование актуального расписания по месяцам.
+-- Все запросы к current_schedule фильтруют по дате, поэтому с секциями по
+-- месяцам они читают только секции нужных недель, а таблица растет без
+-- замедления ежедневных запросов. Секции создаются заранее задачей
+-- обслуживания (ensure_current_schedule_partition); строки с датами, для
+-- которых секции еще нет, попадают в секцию по умолчанию.
+ALTER TABLE current_schedule RENAME TO current_schedule_unpartitioned;
+ALTER INDEX current_schedule_pkey RENAME TO current_schedule_unpartitioned_pkey;
+
+-- Первичный ключ секционированной таблицы обязан включать ключ секционирования
+CREATE TABLE current_schedule (
+    id UUID NOT NULL,
+    group_name VARCHAR(50) NOT NULL,
+    date DATE NOT NULL,
+    time_start TIME WITHOUT TIME ZONE NOT NULL,
+    time_end TIME WITHOUT TIME ZONE NOT NULL,
+    subject VARCHAR(255) NOT NULL,
+    teacher VARCHAR(255),
+    classroom VARCHAR(50),
+    source_type schedule_source_type NOT NULL,
+    source_id UUID NOT NULL, -- ID снапшота или изменения
+    is_active BOOLEAN DEFAULT TRUE,
+    search_text TEXT GENERATED ALWAYS AS (
+        subject || ' ' || COALESCE(teacher, '') || ' ' || COALESCE(classroom, '')
+    ) STORED,
+    search_vector TSVECTOR GENERATED ALWAYS AS (
+        setweight(to_tsvector('russian', subject), 'A') ||
+        setweight(to_tsvector('russian', COALESCE(teacher, '')), 'B') ||
+        setweight(to_tsvector('simple', COALESCE(classroom, '')), 'C')
+    ) STORED,
+    college_id UUID NOT NULL
+        DEFAULT '00000000-0000-0000-0000-000000000001' REFERENCES colleges(id),
+    PRIMARY KEY (id, date)
+) PARTITION BY RANGE (date);
+
+CREATE TABLE current_schedule_default PARTITION OF current_schedule DEFAULT;
+
+-- Создает секцию current_schedule_pYYYY_MM для месяца, в который попадает дата for_date.
+-- Строки этого месяца, уже попавшие в секцию по умолчанию, переносятся в новую секцию.
+-- Возвращает false, если секция уже существует.
+CREATE OR REPLACE FUNCTION ensure_current_schedule_partition(for_date DATE) RETURNS BOOLEAN AS $$
+DECLARE
+    start_date DATE := date_trunc('month', for_date)::date;
+    end_date DATE := (date_trunc('month', for_date) + INTERVAL '1 month')::date;
+    partition_name TEXT := 'current_schedule_p' || to_char(for_date, 'YYYY_MM');
+    column_list TEXT;
+BEGIN
+    IF to_regclass(partition_name) IS NOT NULL THEN
+        RETURN FALSE;
+    END IF;
+
+    IF NOT EXISTS (SELECT 1 FROM current_schedule_default WHERE date >= start_date AND date < end_date) THEN
+        EXECUTE format('CREATE TABLE %I PARTITION OF current_schedule FOR VALUES FROM (%L) TO (%L)',
+            partition_name, start_date, end_date);
+        RETURN TRUE;
+    END IF;
+
+    -- Секцию нельзя создать, пока строки ее месяца лежат в секции по умолчанию.
+    -- Вычисляемые столбцы не переносятся - они будут вычислены заново.
+    SELECT string_agg(quote_ident(attname), ', ' ORDER BY attnum) INTO column_list
+    FROM pg_attribute
+    WHERE attrelid = 'current_schedule'::regclass AND attnum > 0 AND NOT attisdropped AND attgenerated = '';
+
+    EXECUTE format('CREATE TEMP TABLE current_schedule_moved ON COMMIT DROP AS SELECT %s FROM current_schedule_default WHERE date >= %L AND date < %L',
+        column_list, start_date, end_date);
+    DELETE FROM current_schedule_default WHERE date >= start_date AND date < end_date;
+    EXECUTE format('CREATE TABLE %I PARTITION OF current_schedule FOR VALUES FROM (%L) TO (%L)',
+        partition_name, start_date, end_date);
+    EXECUTE format('INSERT INTO current_schedule (%1$s) SELECT %1$s FROM current_schedule_moved', column_list);
+    DROP TABLE current_schedule_moved;
+    RETURN TRUE;
+END;
+$$ LANGUAGE plpgsql;
+
+-- Секции для месяцев с существующими записями, текущего и трех следующих месяцев
+DO $$
+DECLARE
+    m DATE;
+BEGIN
+    FOR m IN
+        SELECT DISTINCT date_trunc('month', date)::date FROM current_schedule_unpartitioned
+        UNION
+        SELECT (date_trunc('month', CURRENT_DATE) + n * INTERVAL '1 month')::date FROM generate_series(0, 3) AS n
+    LOOP
+        PERFORM ensure_current_schedule_partition(m);
+    END LOOP;
+END;
+$$;
+
+INSERT INTO current_schedule
+    (id, group_name, date, time_start, time_end, subject, teacher, classroom, source_type, source_id, is_active, college_id)
+SELECT id, group_name, date, time_start, time_end, subject, teacher, classroom, source_type, source_id, is_active, college_id
+FROM current_schedule_unpartitioned;
+
+DROP TABLE current_schedule_unpartitioned;
+
+-- Индексы создаются на секционированной таблице и наследуются секциями
+CREATE INDEX idx_current_schedule_date_group ON current_schedule(date, group_name);
+CREATE INDEX idx_current_schedule_active ON current_schedule(is_active);
+CREATE INDEX idx_current_schedule_search_vector ON current_schedule USING GIN (search_vector);
+CREATE INDEX idx_current_schedule_search_trgm ON current_schedule USING GIN (search_text gin_trgm_ops);
+CREATE INDEX idx_current_schedule_college_date_group ON current_schedule(college_id, date, group_name);
+
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+ALTER TABLE current_schedule RENAME TO current_schedule_partitioned;
+ALTER INDEX current_schedule_pkey RENAME TO current_schedule_partitioned_pkey;
+
+CREATE TABLE current_schedule (
+    id UUID PRIMARY KEY,
+    group_name VARCHAR(50) NOT NULL,
+    date DATE NOT NULL,
+    time_start TIME WITHOUT TIME ZONE NOT NULL,
+    time_end TIME WITHOUT TIME ZONE NOT NULL,
+    subject VARCHAR(255) NOT NULL,
+    teacher VARCHAR(255),
+    classroom VARCHAR(50),
+    source_type schedule_source_type NOT NULL,
+    source_id UUID NOT NULL,
+    is_active BOOLEAN DEFAULT TRUE,
+    search_text TEXT GENERATED ALWAYS AS (
+        subject || ' ' || COALESCE(teacher, '') || ' ' || COALESCE(classroom, '')
+    ) STORED,
+    search_vector TSVECTOR GENERATED ALWAYS AS (
+        setweight(to_tsvector('russian', subject), 'A') ||
+        setweight(to_tsvector('russian', COALESCE(teacher, '')), 'B') ||
+        setweight(to_tsvector('simple', COALESCE(classroom, '')), 'C')
+    ) STORED,
+    college_id UUID NOT NULL
+        DEFAULT '00000000-0000-0000-0000-000000000001' REFERENCES colleges(id)
+);
+
+INSERT INTO current_schedule
+    (id, group_name, date, time_start, time_end, subject, teacher, classroom, source_type, source_id, is_active, college_id)
+SELECT id, group_name, date, time_start, time_end, subject, teacher, classroom, source_type, source_id, is_active, college_id
+FROM current_schedule_partitioned;
+
+DROP TABLE current_schedule_partitioned;
+DROP FUNCTION IF EXISTS ensure_current_schedule_partition(DATE);
+
+CREATE INDEX idx_current_schedule_date_group ON current_schedule(date, group_name);
+CREATE INDEX idx_current_schedule_active ON current_schedule(is_active);
+CREATE INDEX idx_current_schedule_search_vector ON current_schedule USING GIN (search_vector);
+CREATE INDEX idx_current_schedule_search_trgm ON current_schedule USING GIN (search_text gin_trgm_ops);
+CREATE INDEX idx_current_schedule_college_date_group ON current_schedule(college_id, date, group_name);
+-- +goose StatementEnd
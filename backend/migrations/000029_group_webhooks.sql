@@ -0,0 +1,26 @@
+-- +goose Up
+-- +goose StatementBegin
+
+-- Вебхуки групповых чатов: сводка изменений расписания группы публикуется
+-- в чат Discord или Slack, который ведет староста. У группы один вебхук.
+CREATE TABLE group_webhooks (
+    id UUID PRIMARY KEY,
+    group_name VARCHAR(50) NOT NULL,
+    provider VARCHAR(20) NOT NULL CHECK (provider IN ('discord', 'slack')),
+    url TEXT NOT NULL,
+    created_by UUID REFERENCES users(id) ON DELETE SET NULL,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT NOW(),
+    updated_at TIMESTAMP WITH TIME ZONE DEFAULT NOW(),
+    last_delivered_at TIMESTAMP WITH TIME ZONE, -- Последняя успешная отправка
+    last_error TEXT NOT NULL DEFAULT '', -- Ошибка последней отправки (пусто - успешна)
+    college_id UUID NOT NULL
+        DEFAULT '00000000-0000-0000-0000-000000000001' REFERENCES colleges(id),
+    UNIQUE (college_id, group_name)
+);
+
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE IF EXISTS group_webhooks;
+-- +goose StatementEnd
@@ -0,0 +1,24 @@
+-- +goose Up
+-- +goose StatementBegin
+
+-- Справочник преподавателей: официальный список колледжа (ФИО, кафедра, должность),
+-- загружаемый администратором из таблицы. В отличие от teachers не требует
+-- регистрации и используется при сопоставлении имен из расписания с преподавателями.
+CREATE TABLE teacher_directory (
+    id UUID PRIMARY KEY,
+    full_name VARCHAR(255) NOT NULL,
+    department VARCHAR(100) NOT NULL DEFAULT '',
+    position VARCHAR(100) NOT NULL DEFAULT '',
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT NOW(),
+    updated_at TIMESTAMP WITH TIME ZONE DEFAULT NOW(),
+    college_id UUID NOT NULL
+        DEFAULT '00000000-0000-0000-0000-000000000001' REFERENCES colleges(id),
+    UNIQUE (college_id, full_name)
+);
+
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE IF EXISTS teacher_directory;
+-- +goose StatementEnd
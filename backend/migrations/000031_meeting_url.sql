@@ -0,0 +1,14 @@
+-- +goose Up
+-- +goose StatementBegin
+
+-- Ссылка на онлайн-занятие (Zoom, Телемост и т.п.), которую задает преподаватель
+-- или администратор. Пусто - занятие очное. При замене преподавателя пары
+-- ссылка сбрасывается.
+ALTER TABLE current_schedule ADD COLUMN meeting_url TEXT NOT NULL DEFAULT '';
+
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+ALTER TABLE current_schedule DROP COLUMN IF EXISTS meeting_url;
+-- +goose StatementEnd
@@ -0,0 +1,23 @@
+-- +goose Up
+-- +goose StatementBegin
+
+-- Подгруппы: лабораторные и языковые пары часто проводятся отдельно для
+-- подгрупп (в таблице - отметки "1 п/г", "2 п/г") в разных аудиториях и
+-- с разными преподавателями. 0 - занятие всей группы.
+ALTER TABLE schedule_changes ADD COLUMN subgroup SMALLINT NOT NULL DEFAULT 0 CHECK (subgroup >= 0);
+ALTER TABLE current_schedule ADD COLUMN subgroup SMALLINT NOT NULL DEFAULT 0 CHECK (subgroup >= 0);
+ALTER TABLE current_schedule_history ADD COLUMN subgroup SMALLINT NOT NULL DEFAULT 0;
+
+-- Подгруппа студента: студент видит занятия всей группы и своей подгруппы.
+-- 0 - подгруппа не выбрана, видны занятия всех подгрупп.
+ALTER TABLE students ADD COLUMN subgroup SMALLINT NOT NULL DEFAULT 0 CHECK (subgroup >= 0);
+
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+ALTER TABLE students DROP COLUMN IF EXISTS subgroup;
+ALTER TABLE current_schedule_history DROP COLUMN IF EXISTS subgroup;
+ALTER TABLE current_schedule DROP COLUMN IF EXISTS subgroup;
+ALTER TABLE schedule_changes DROP COLUMN IF EXISTS subgroup;
+-- +goose StatementEnd
@@ -0,0 +1,56 @@
+-- +goose Up
+-- +goose StatementBegin
+
+-- Факультативы: курсы по выбору со своим расписанием, на которые студенты
+-- записываются сами. Занятия записавшегося студента добавляются к расписанию
+-- его группы в личном расписании и календаре.
+CREATE TABLE elective_courses (
+    id UUID PRIMARY KEY,
+    title VARCHAR(255) NOT NULL,
+    teacher VARCHAR(255) NOT NULL,
+    description TEXT NOT NULL DEFAULT '',
+    group_names TEXT[] NOT NULL DEFAULT '{}', -- Группы, которым доступна запись (пусто - всем)
+    capacity INTEGER NOT NULL DEFAULT 0 CHECK (capacity >= 0), -- 0 - без ограничения
+    starts_on DATE NOT NULL,
+    ends_on DATE NOT NULL,
+    is_active BOOLEAN NOT NULL DEFAULT TRUE, -- FALSE - курс отменен
+    created_by UUID REFERENCES users(id) ON DELETE SET NULL,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT NOW(),
+    college_id UUID NOT NULL
+        DEFAULT '00000000-0000-0000-0000-000000000001' REFERENCES colleges(id),
+    CHECK (starts_on <= ends_on)
+);
+
+CREATE INDEX idx_elective_courses_college ON elective_courses(college_id, is_active, ends_on);
+
+-- Еженедельные занятия курса: день недели (1 - понедельник, 7 - воскресенье) и время
+CREATE TABLE elective_slots (
+    id UUID PRIMARY KEY,
+    course_id UUID NOT NULL REFERENCES elective_courses(id) ON DELETE CASCADE,
+    weekday SMALLINT NOT NULL CHECK (weekday BETWEEN 1 AND 7),
+    time_start TIME WITHOUT TIME ZONE NOT NULL,
+    time_end TIME WITHOUT TIME ZONE NOT NULL,
+    classroom VARCHAR(50) NOT NULL DEFAULT '',
+    CHECK (time_start < time_end)
+);
+
+CREATE INDEX idx_elective_slots_course ON elective_slots(course_id);
+
+-- Записи студентов на курсы
+CREATE TABLE elective_enrollments (
+    course_id UUID NOT NULL REFERENCES elective_courses(id) ON DELETE CASCADE,
+    user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT NOW(),
+    PRIMARY KEY (course_id, user_id)
+);
+
+CREATE INDEX idx_elective_enrollments_user ON elective_enrollments(user_id);
+
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE IF EXISTS elective_enrollments;
+DROP TABLE IF EXISTS elective_slots;
+DROP TABLE IF EXISTS elective_courses;
+-- +goose StatementEnd
@@ -0,0 +1,28 @@
+-- +goose Up
+-- +goose StatementBegin
+
+-- Личные заметки к занятиям ("принести отчет по лабораторной"). Заметка
+-- привязана к паре группы в конкретный день и видна только ее автору.
+CREATE TABLE lesson_notes (
+    id UUID PRIMARY KEY,
+    user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    group_name VARCHAR(50) NOT NULL,
+    date DATE NOT NULL,
+    time_start TIME WITHOUT TIME ZONE NOT NULL,
+    subject VARCHAR(255) NOT NULL DEFAULT '', -- Предмет пары на момент создания заметки
+    text TEXT NOT NULL,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT NOW(),
+    updated_at TIMESTAMP WITH TIME ZONE DEFAULT NOW(),
+    college_id UUID NOT NULL
+        DEFAULT '00000000-0000-0000-0000-000000000001' REFERENCES colleges(id),
+    UNIQUE (user_id, group_name, date, time_start)
+);
+
+CREATE INDEX idx_lesson_notes_user_date ON lesson_notes(user_id, date);
+
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE IF EXISTS lesson_notes;
+-- +goose StatementEnd
@@ -0,0 +1,36 @@
+-- +goose Up
+-- +goose StatementBegin
+
+-- Корпуса колледжа и аудитории в них. По корпусам аудиторий соседних пар
+-- студенты и преподаватели предупреждаются, что перерыва может не хватить
+-- на переход в другой корпус.
+CREATE TABLE buildings (
+    id UUID PRIMARY KEY,
+    code VARCHAR(20) NOT NULL, -- Короткое обозначение в расписании, например "К2"
+    name VARCHAR(255) NOT NULL,
+    address TEXT NOT NULL DEFAULT '',
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT NOW(),
+    updated_at TIMESTAMP WITH TIME ZONE DEFAULT NOW(),
+    college_id UUID NOT NULL
+        DEFAULT '00000000-0000-0000-0000-000000000001' REFERENCES colleges(id),
+    UNIQUE (college_id, code)
+);
+
+-- Аудитории корпусов; имя аудитории - как в расписании ("301", "Спортзал")
+CREATE TABLE classrooms (
+    name VARCHAR(50) NOT NULL,
+    building_id UUID NOT NULL REFERENCES buildings(id) ON DELETE CASCADE,
+    college_id UUID NOT NULL
+        DEFAULT '00000000-0000-0000-0000-000000000001' REFERENCES colleges(id),
+    PRIMARY KEY (college_id, name)
+);
+
+CREATE INDEX idx_classrooms_building ON classrooms(building_id);
+
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE IF EXISTS classrooms;
+DROP TABLE IF EXISTS buildings;
+-- +goose StatementEnd
@@ -0,0 +1,18 @@
+-- +goose Up
+-- +goose StatementBegin
+
+-- Вид занятия (лекция, практика, лабораторная и т.п.) из ячейки таблицы
+-- "Предмет / Вид занятия / Преподаватель" или колонки "Вид занятия" таблицы
+-- изменений. Пустая строка - вид не указан.
+ALTER TABLE schedule_changes ADD COLUMN lesson_type VARCHAR(50) NOT NULL DEFAULT '';
+ALTER TABLE current_schedule ADD COLUMN lesson_type VARCHAR(50) NOT NULL DEFAULT '';
+ALTER TABLE current_schedule_history ADD COLUMN lesson_type VARCHAR(50) NOT NULL DEFAULT '';
+
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+ALTER TABLE current_schedule_history DROP COLUMN IF EXISTS lesson_type;
+ALTER TABLE current_schedule DROP COLUMN IF EXISTS lesson_type;
+ALTER TABLE schedule_changes DROP COLUMN IF EXISTS lesson_type;
+-- +goose StatementEnd
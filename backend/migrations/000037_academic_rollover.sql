@@ -0,0 +1,34 @@
+-- +goose Up
+-- +goose StatementBegin
+
+-- Переходы на новый учебный год: архивация расписания прошлого года, перевод
+-- студентов на следующий курс, переименование и выпуск групп. Переход на год
+-- выполняется один раз: повторный запуск перевел бы студентов еще на курс.
+CREATE TABLE academic_rollovers (
+    id UUID PRIMARY KEY,
+    year_start DATE NOT NULL, -- Первый день нового учебного года
+    performed_by UUID REFERENCES users(id) ON DELETE SET NULL,
+    summary TEXT NOT NULL DEFAULT '', -- Итог перехода для журнала
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT NOW(),
+    college_id UUID NOT NULL
+        DEFAULT '00000000-0000-0000-0000-000000000001' REFERENCES colleges(id),
+    UNIQUE (college_id, year_start)
+);
+
+-- Переход на новый учебный год попадает в журнал безопасности
+ALTER TABLE audit_events DROP CONSTRAINT audit_events_event_type_check;
+ALTER TABLE audit_events ADD CONSTRAINT audit_events_event_type_check CHECK (event_type IN (
+    'registration', 'login', 'login_failed', 'password_change', 'role_change', 'token_revocation',
+    'two_factor_change', 'user_export', 'academic_rollover'));
+
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DELETE FROM audit_events WHERE event_type = 'academic_rollover';
+ALTER TABLE audit_events DROP CONSTRAINT audit_events_event_type_check;
+ALTER TABLE audit_events ADD CONSTRAINT audit_events_event_type_check CHECK (event_type IN (
+    'registration', 'login', 'login_failed', 'password_change', 'role_change', 'token_revocation',
+    'two_factor_change', 'user_export'));
+DROP TABLE IF EXISTS academic_rollovers;
+-- +goose StatementEnd
@@ -0,0 +1,53 @@
+-- +goose Up
+-- +goose StatementBegin
+
+-- Часы консультаций преподавателей: еженедельные слоты, которые преподаватель
+-- публикует сам. Консультации видят студенты групп, у которых он ведет занятия.
+CREATE TABLE consultation_hours (
+    id UUID PRIMARY KEY,
+    teacher_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    teacher VARCHAR(255) NOT NULL, -- ФИО преподавателя для отображения
+    -- Имена, под которыми преподаватель встречается в расписании, на момент публикации:
+    -- по ним находятся группы, которым видны консультации
+    teacher_names TEXT[] NOT NULL DEFAULT '{}',
+    weekday SMALLINT NOT NULL CHECK (weekday BETWEEN 1 AND 7), -- 1 - понедельник, 7 - воскресенье
+    time_start TIME WITHOUT TIME ZONE NOT NULL,
+    time_end TIME WITHOUT TIME ZONE NOT NULL,
+    classroom VARCHAR(50) NOT NULL DEFAULT '',
+    comment TEXT NOT NULL DEFAULT '',
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT NOW(),
+    college_id UUID NOT NULL
+        DEFAULT '00000000-0000-0000-0000-000000000001' REFERENCES colleges(id),
+    CHECK (time_start < time_end)
+);
+
+CREATE INDEX idx_consultation_hours_teacher ON consultation_hours(teacher_id);
+CREATE INDEX idx_consultation_hours_college ON consultation_hours(college_id, weekday);
+
+-- Подписки студентов на напоминания о консультациях преподавателя
+CREATE TABLE consultation_subscriptions (
+    teacher_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT NOW(),
+    PRIMARY KEY (teacher_id, user_id)
+);
+
+CREATE INDEX idx_consultation_subscriptions_user ON consultation_subscriptions(user_id);
+
+-- Отправленные напоминания: о каждой консультации в конкретный день напоминаем
+-- один раз, даже при нескольких экземплярах API
+CREATE TABLE consultation_reminders (
+    consultation_id UUID NOT NULL REFERENCES consultation_hours(id) ON DELETE CASCADE,
+    date DATE NOT NULL,
+    sent_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    PRIMARY KEY (consultation_id, date)
+);
+
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE IF EXISTS consultation_reminders;
+DROP TABLE IF EXISTS consultation_subscriptions;
+DROP TABLE IF EXISTS consultation_hours;
+-- +goose StatementEnd
@@ -0,0 +1,40 @@
+-- +goose Up
+-- +goose StatementBegin
+
+-- Мягкое удаление пользователей вместо флага is_active: удаленный пользователь
+-- не виден в выборках, не может войти и восстанавливается администратором
+ALTER TABLE users
+    ADD COLUMN deleted_at TIMESTAMP WITH TIME ZONE,
+    ADD COLUMN deleted_by UUID REFERENCES users(id) ON DELETE SET NULL;
+
+UPDATE users SET deleted_at = NOW() WHERE is_active = false;
+ALTER TABLE users DROP COLUMN is_active;
+
+-- Email занят только действующими пользователями: адрес удаленного пользователя
+-- можно зарегистрировать заново
+ALTER TABLE users DROP CONSTRAINT users_email_key;
+CREATE UNIQUE INDEX idx_users_email_active ON users(email) WHERE deleted_at IS NULL;
+
+-- Удаление и восстановление пользователей попадают в журнал безопасности
+ALTER TABLE audit_events DROP CONSTRAINT audit_events_event_type_check;
+ALTER TABLE audit_events ADD CONSTRAINT audit_events_event_type_check CHECK (event_type IN (
+    'registration', 'login', 'login_failed', 'password_change', 'role_change', 'token_revocation',
+    'two_factor_change', 'user_export', 'academic_rollover', 'user_deletion', 'user_restore'));
+
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DELETE FROM audit_events WHERE event_type IN ('user_deletion', 'user_restore');
+ALTER TABLE audit_events DROP CONSTRAINT audit_events_event_type_check;
+ALTER TABLE audit_events ADD CONSTRAINT audit_events_event_type_check CHECK (event_type IN (
+    'registration', 'login', 'login_failed', 'password_change', 'role_change', 'token_revocation',
+    'two_factor_change', 'user_export', 'academic_rollover'));
+
+DROP INDEX IF EXISTS idx_users_email_active;
+ALTER TABLE users ADD CONSTRAINT users_email_key UNIQUE (email);
+
+ALTER TABLE users ADD COLUMN is_active BOOLEAN DEFAULT TRUE;
+UPDATE users SET is_active = false WHERE deleted_at IS NOT NULL;
+ALTER TABLE users DROP COLUMN deleted_by, DROP COLUMN deleted_at;
+-- +goose StatementEnd
@@ -0,0 +1,13 @@
+-- +goose Up
+-- +goose StatementBegin
+
+-- Пароль, выданный администратором при загрузке списка группы, временный:
+-- пользователь должен сменить его после первого входа
+ALTER TABLE users ADD COLUMN password_change_required BOOLEAN NOT NULL DEFAULT false;
+
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+ALTER TABLE users DROP COLUMN IF EXISTS password_change_required;
+-- +goose StatementEnd
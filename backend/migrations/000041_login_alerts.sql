@@ -0,0 +1,16 @@
+-- +goose Up
+-- +goose StatementBegin
+
+-- Уведомление о входе с нового устройства или адреса; пользователь может его отключить
+ALTER TABLE users ADD COLUMN login_alerts BOOLEAN NOT NULL DEFAULT true;
+
+-- История входов пользователя для сравнения с новым входом
+CREATE INDEX idx_audit_events_user_login ON audit_events(user_id, ip, user_agent) WHERE event_type = 'login';
+
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP INDEX IF EXISTS idx_audit_events_user_login;
+ALTER TABLE users DROP COLUMN IF EXISTS login_alerts;
+-- +goose StatementEnd
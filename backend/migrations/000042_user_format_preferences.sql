@@ -0,0 +1,16 @@
+-- +goose Up
+-- +goose StatementBegin
+
+-- Язык и форматы даты и времени в уведомлениях и печатных отчетах пользователя.
+-- Пустой формат - формат по умолчанию для языка
+ALTER TABLE users
+    ADD COLUMN locale VARCHAR(10) NOT NULL DEFAULT 'ru',
+    ADD COLUMN date_format VARCHAR(20) NOT NULL DEFAULT '',
+    ADD COLUMN time_format VARCHAR(10) NOT NULL DEFAULT '';
+
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+ALTER TABLE users DROP COLUMN IF EXISTS time_format, DROP COLUMN IF EXISTS date_format, DROP COLUMN IF EXISTS locale;
+-- +goose StatementEnd
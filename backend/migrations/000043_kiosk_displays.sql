@@ -0,0 +1,30 @@
+-- +goose Up
+-- +goose StatementBegin
+
+-- Табло расписания в коридорах: публичный запрос по ключу табло возвращает пары
+-- дня в аудиториях выбранных корпусов и в отдельно перечисленных аудиториях
+CREATE TABLE kiosk_displays (
+    id UUID PRIMARY KEY,
+    college_id UUID NOT NULL REFERENCES colleges(id),
+    name VARCHAR(100) NOT NULL,                      -- Например "Корпус 2, 3 этаж"
+    key_hash VARCHAR(64) NOT NULL UNIQUE,            -- SHA-256 ключа табло (сам ключ не хранится)
+    buildings TEXT[] NOT NULL DEFAULT '{}',          -- Коды корпусов
+    classrooms TEXT[] NOT NULL DEFAULT '{}',         -- Аудитории, как в расписании
+    created_by UUID REFERENCES users(id) ON DELETE SET NULL,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    last_seen_at TIMESTAMP WITH TIME ZONE,           -- Последний запрос табло
+    revoked_at TIMESTAMP WITH TIME ZONE
+);
+
+CREATE INDEX idx_kiosk_displays_college ON kiosk_displays(college_id) WHERE revoked_at IS NULL;
+
+-- Пары дня в аудиториях табло
+CREATE INDEX idx_current_schedule_date_classroom ON current_schedule(college_id, date, lower(btrim(classroom)));
+
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP INDEX IF EXISTS idx_current_schedule_date_classroom;
+DROP TABLE IF EXISTS kiosk_displays;
+-- +goose StatementEnd
@@ -0,0 +1,44 @@
+-- +goose Up
+-- +goose StatementBegin
+
+-- Архив актуального расписания прошедших семестров. Задача обслуживания
+-- переносит сюда записи current_schedule с датами до начала текущего семестра,
+-- чтобы основная таблица оставалась небольшой для ежедневных запросов.
+-- Архив читается только экранами истории по явному запросу клиента.
+CREATE TABLE current_schedule_archive (
+    id UUID NOT NULL,
+    college_id UUID NOT NULL REFERENCES colleges(id),
+    group_name VARCHAR(50) NOT NULL,
+    date DATE NOT NULL,
+    time_start TIME WITHOUT TIME ZONE NOT NULL,
+    time_end TIME WITHOUT TIME ZONE NOT NULL,
+    subject VARCHAR(255) NOT NULL,
+    teacher VARCHAR(255),
+    classroom VARCHAR(50),
+    source_type schedule_source_type NOT NULL,
+    source_id UUID NOT NULL,
+    is_active BOOLEAN DEFAULT TRUE,
+    meeting_url TEXT NOT NULL DEFAULT '',
+    subgroup SMALLINT NOT NULL DEFAULT 0,
+    lesson_type VARCHAR(50) NOT NULL DEFAULT '',
+    archived_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    PRIMARY KEY (id, date)
+);
+
+CREATE INDEX idx_current_schedule_archive_college_group_date ON current_schedule_archive(college_id, group_name, date);
+
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+-- Записи архива возвращаются в основную таблицу
+INSERT INTO current_schedule
+    (id, group_name, date, time_start, time_end, subject, teacher, classroom, source_type, source_id, is_active,
+     college_id, meeting_url, subgroup, lesson_type)
+SELECT id, group_name, date, time_start, time_end, subject, teacher, classroom, source_type, source_id, is_active,
+       college_id, meeting_url, subgroup, lesson_type
+FROM current_schedule_archive
+ON CONFLICT DO NOTHING;
+
+DROP TABLE IF EXISTS current_schedule_archive;
+-- +goose StatementEnd
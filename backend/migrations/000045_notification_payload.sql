@@ -0,0 +1,15 @@
+-- +goose Up
+-- +goose StatementBegin
+
+-- Структурированные данные уведомления (тип, группа, дата, ID изменения, занятия,
+-- консультации или курса и маршрут экрана приложения), одинаковые для уведомлений
+-- в приложении и push: по ним клиент открывает затронутый день или занятие.
+-- Для уведомлений, созданных раньше, данные восстанавливаются по группе и дате.
+ALTER TABLE notifications ADD COLUMN payload JSONB NOT NULL DEFAULT '{}'::jsonb;
+
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+ALTER TABLE notifications DROP COLUMN IF EXISTS payload;
+-- +goose StatementEnd
@@ -0,0 +1,37 @@
+-- +goose Up
+-- +goose StatementBegin
+
+-- Жалобы пользователей на ошибки в расписании. Пара определяется группой, датой
+-- и временем начала; жалобы на пару группируются для панели администратора
+CREATE TABLE schedule_error_reports (
+    id UUID PRIMARY KEY,
+    college_id UUID NOT NULL REFERENCES colleges(id),
+    user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    group_name VARCHAR(50) NOT NULL,
+    date DATE NOT NULL,
+    time_start VARCHAR(5) NOT NULL,                  -- ЧЧ:ММ
+    entry_id UUID,                                   -- Запись current_schedule, которую видел пользователь
+    source_type VARCHAR(20) NOT NULL DEFAULT '',     -- Источник записи: snapshot или change
+    subject VARCHAR(255) NOT NULL DEFAULT '',        -- Предмет пары на момент жалобы
+    reason VARCHAR(20) NOT NULL,                     -- wrong_subject, wrong_teacher, wrong_classroom, wrong_time, not_held, other
+    comment TEXT NOT NULL DEFAULT '',
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    resolved_at TIMESTAMP WITH TIME ZONE,            -- Жалобы на пару разобраны администратором
+    resolved_by UUID REFERENCES users(id) ON DELETE SET NULL
+);
+
+-- Одна неразобранная жалоба пользователя на пару
+CREATE UNIQUE INDEX idx_schedule_error_reports_user_lesson
+    ON schedule_error_reports(college_id, user_id, group_name, date, time_start)
+    WHERE resolved_at IS NULL;
+
+CREATE INDEX idx_schedule_error_reports_lesson
+    ON schedule_error_reports(college_id, group_name, date, time_start)
+    WHERE resolved_at IS NULL;
+
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE IF EXISTS schedule_error_reports;
+-- +goose StatementEnd
@@ -0,0 +1,59 @@
+-- +goose Up
+-- +goose StatementBegin
+
+-- Снапшот определяется таблицей-источником и периодом: повторный парсинг той же
+-- таблицы за тот же период обновляет снапшот, а не создает новый
+ALTER TABLE schedule_snapshots ADD COLUMN updated_at TIMESTAMP WITH TIME ZONE; -- Последняя повторная загрузка
+
+-- Из уже созданных повторов остается последний; изменения переносятся на него
+CREATE TEMPORARY TABLE snapshot_duplicates ON COMMIT DROP AS
+SELECT id, keep_id
+FROM (
+    SELECT id, FIRST_VALUE(id) OVER (
+        PARTITION BY college_id, source_url, period_start, period_end
+        ORDER BY created_at DESC, id
+    ) AS keep_id
+    FROM schedule_snapshots
+    WHERE source_url IS NOT NULL
+) ranked
+WHERE id <> keep_id;
+
+UPDATE schedule_changes c
+SET snapshot_id = d.keep_id
+FROM snapshot_duplicates d
+WHERE c.snapshot_id = d.id;
+
+-- Пары основного расписания ссылаются на снапшот через source_id
+UPDATE current_schedule cs
+SET source_id = d.keep_id
+FROM snapshot_duplicates d
+WHERE cs.source_type = 'main' AND cs.source_id = d.id;
+
+UPDATE current_schedule_history h
+SET source_id = d.keep_id
+FROM snapshot_duplicates d
+WHERE h.source_type = 'main' AND h.source_id = d.id;
+
+UPDATE current_schedule_archive a
+SET source_id = d.keep_id
+FROM snapshot_duplicates d
+WHERE a.source_type = 'main' AND a.source_id = d.id;
+
+-- updated_at оставшихся снапшотов остается пустым: оставлен последний из повторов,
+-- и его загрузка уже отражена в created_at
+
+DELETE FROM schedule_snapshots s
+USING snapshot_duplicates d
+WHERE s.id = d.id;
+
+CREATE UNIQUE INDEX idx_schedule_snapshots_source_period
+    ON schedule_snapshots(college_id, source_url, period_start, period_end);
+
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+-- Удаленные повторы не восстанавливаются
+DROP INDEX IF EXISTS idx_schedule_snapshots_source_period;
+ALTER TABLE schedule_snapshots DROP COLUMN IF EXISTS updated_at;
+-- +goose StatementEnd
@@ -0,0 +1,13 @@
+-- +goose Up
+-- +goose StatementBegin
+
+-- Версия ссылок на личный календарь входит в подпись ссылки: пользователь может
+-- перевыпустить ссылки, и выданные ранее перестают работать
+ALTER TABLE users ADD COLUMN calendar_token_version INTEGER NOT NULL DEFAULT 0;
+
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+ALTER TABLE users DROP COLUMN IF EXISTS calendar_token_version;
+-- +goose StatementEnd
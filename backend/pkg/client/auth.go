@@ -0,0 +1,92 @@
+package client
+
+import (
+	"context"
+	"time"
+
+	userspb "github.com/Ultrahd-dev/student-schedule-app/backend/proto/gen/users"
+)
+
+// TwoFactorRequiredError пароль верен, но у пользователя включена двухфакторная
+// аутентификация: вход завершается вызовом VerifyTwoFactor с Token и кодом
+// из приложения до ExpiresAt
+type TwoFactorRequiredError struct {
+	Token     string
+	ExpiresAt time.Time
+}
+
+// Error возвращает текст ошибки
+func (e *TwoFactorRequiredError) Error() string {
+	return "требуется код двухфакторной аутентификации"
+}
+
+// Login выполняет вход по email и паролю и сохраняет полученный токен в клиенте.
+// Если у пользователя включена 2FA, возвращает *TwoFactorRequiredError.
+// Для входа с CAPTCHA используйте Users().Login и SetToken.
+func (c *Client) Login(ctx context.Context, email, password string) (*User, error) {
+	resp, err := c.users.Login(ctx, &userspb.LoginRequest{Email: email, Password: password})
+	if err != nil {
+		return nil, err
+	}
+	return c.completeLogin(resp)
+}
+
+// VerifyTwoFactor завершает вход кодом 2FA (или кодом восстановления)
+// и сохраняет полученный токен в клиенте
+func (c *Client) VerifyTwoFactor(ctx context.Context, challenge *TwoFactorRequiredError, code string) (*User, error) {
+	resp, err := c.users.VerifyTwoFactor(ctx, &userspb.VerifyTwoFactorRequest{
+		TwoFactorToken: challenge.Token,
+		Code:           code,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return c.completeLogin(resp)
+}
+
+// completeLogin сохраняет токен из ответа на вход
+func (c *Client) completeLogin(resp *userspb.LoginResponse) (*User, error) {
+	if resp.TwoFactorRequired {
+		expiresAt, _ := time.Parse(time.RFC3339, resp.TwoFactorExpiresAt)
+		return nil, &TwoFactorRequiredError{Token: resp.TwoFactorToken, ExpiresAt: expiresAt}
+	}
+
+	c.SetToken(resp.Token)
+	user := fromPBUser(resp.User)
+	return &user, nil
+}
+
+// GuestToken получает гостевой токен для просмотра расписания группы без
+// регистрации, сохраняет его в клиенте и возвращает время его истечения
+func (c *Client) GuestToken(ctx context.Context, groupName string) (time.Time, error) {
+	resp, err := c.users.IssueGuestToken(ctx, &userspb.IssueGuestTokenRequest{GroupName: groupName})
+	if err != nil {
+		return time.Time{}, err
+	}
+
+	c.SetToken(resp.Token)
+	expiresAt, _ := time.Parse(time.RFC3339, resp.ExpiresAt)
+	return expiresAt, nil
+}
+
+// Logout отзывает токен клиента на сервере и удаляет его из клиента
+func (c *Client) Logout(ctx context.Context) error {
+	token := c.Token()
+	if token == "" {
+		return nil
+	}
+	if _, err := c.users.RevokeToken(ctx, &userspb.RevokeTokenRequest{Token: token}); err != nil {
+		return err
+	}
+	c.SetToken("")
+	return nil
+}
+
+// Profile возвращает профиль текущего пользователя
+func (c *Client) Profile(ctx context.Context) (*Profile, error) {
+	resp, err := c.users.GetProfile(ctx, &userspb.GetProfileRequest{})
+	if err != nil {
+		return nil, err
+	}
+	return fromPBProfile(resp), nil
+}
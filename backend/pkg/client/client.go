@@ -0,0 +1,181 @@
+// Package client - Go SDK API расписания колледжа для ботов, скриптов и
+// внешних сервисов. Client оборачивает gRPC сервисы: подставляет токен
+// пользователя в запросы, повторяет запросы при недоступности сервера
+// и возвращает типизированные модели вместо сообщений proto.
+//
+//	c, err := client.Dial("schedule.example.ru:443", client.WithCollege("kit"))
+//	if err != nil {
+//		return err
+//	}
+//	defer c.Close()
+//
+//	if _, err := c.Login(ctx, email, password); err != nil {
+//		return err
+//	}
+//	lessons, err := c.MySchedule(ctx, time.Now(), false)
+//
+// Методы без типизированной обертки доступны через Users, Schedule и Files:
+// токен в их запросы подставляется так же. Ошибки сервера возвращаются
+// как статусы gRPC (status.Code(err) - код, status.Convert(err).Message() - текст).
+package client
+
+import (
+	"crypto/tls"
+	"fmt"
+	"sync"
+	"time"
+
+	filespb "github.com/Ultrahd-dev/student-schedule-app/backend/proto/gen/files"
+	notificationspb "github.com/Ultrahd-dev/student-schedule-app/backend/proto/gen/notifications"
+	schedulepb "github.com/Ultrahd-dev/student-schedule-app/backend/proto/gen/schedule"
+	userspb "github.com/Ultrahd-dev/student-schedule-app/backend/proto/gen/users"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/credentials/insecure"
+)
+
+// RetryPolicy повтор унарных запросов, на которые сервер ответил Unavailable
+// (перезапуск, балансировщик без живых экземпляров). Повторяются и изменяющие
+// запросы: Unavailable означает, что сервер запрос не обработал.
+type RetryPolicy struct {
+	MaxAttempts int           // Максимальное число попыток, включая первую (1 - без повторов)
+	Backoff     time.Duration // Пауза перед первым повтором, далее удваивается
+	MaxBackoff  time.Duration // Максимальная пауза между попытками
+}
+
+// DefaultRetryPolicy повтор запросов по умолчанию
+var DefaultRetryPolicy = RetryPolicy{
+	MaxAttempts: 3,
+	Backoff:     200 * time.Millisecond,
+	MaxBackoff:  2 * time.Second,
+}
+
+// options настройки клиента
+type options struct {
+	token       string
+	college     string
+	creds       credentials.TransportCredentials
+	retry       RetryPolicy
+	dialOptions []grpc.DialOption
+}
+
+// Option настройка клиента для Dial
+type Option func(*options)
+
+// WithToken задает JWT токен пользователя (например, сохраненный после Login
+// или гостевой токен), который подставляется в запросы
+func WithToken(token string) Option {
+	return func(o *options) { o.token = token }
+}
+
+// WithCollege задает код колледжа (slug) для запросов без токена: вход,
+// регистрация, гостевой токен. С токеном колледж определяется по токену.
+func WithCollege(college string) Option {
+	return func(o *options) { o.college = college }
+}
+
+// WithTLS задает настройки TLS соединения (по умолчанию - системные корневые сертификаты)
+func WithTLS(config *tls.Config) Option {
+	return func(o *options) { o.creds = credentials.NewTLS(config) }
+}
+
+// WithInsecure отключает TLS (локальный сервер, внутренняя сеть)
+func WithInsecure() Option {
+	return func(o *options) { o.creds = insecure.NewCredentials() }
+}
+
+// WithRetry задает повтор запросов; RetryPolicy{MaxAttempts: 1} отключает повторы
+func WithRetry(policy RetryPolicy) Option {
+	return func(o *options) { o.retry = policy }
+}
+
+// WithDialOptions добавляет параметры gRPC соединения (перехватчики, размер сообщений и т.д.)
+func WithDialOptions(dialOptions ...grpc.DialOption) Option {
+	return func(o *options) { o.dialOptions = append(o.dialOptions, dialOptions...) }
+}
+
+// Client клиент API расписания. Безопасен для использования из нескольких горутин.
+type Client struct {
+	conn          *grpc.ClientConn
+	users         userspb.UserServiceClient
+	schedule      schedulepb.ScheduleServiceClient
+	files         filespb.FileServiceClient
+	notifications notificationspb.NotificationServiceClient
+	college       string
+	retry         RetryPolicy
+
+	mu    sync.RWMutex
+	token string
+}
+
+// Dial создает клиент сервера addr (host:port). Соединение устанавливается
+// при первом запросе.
+func Dial(addr string, opts ...Option) (*Client, error) {
+	o := options{
+		creds: credentials.NewTLS(nil),
+		retry: DefaultRetryPolicy,
+	}
+	for _, opt := range opts {
+		opt(&o)
+	}
+	if o.retry.MaxAttempts <= 0 {
+		o.retry.MaxAttempts = 1
+	}
+
+	c := &Client{college: o.college, retry: o.retry, token: o.token}
+	dialOptions := append([]grpc.DialOption{
+		grpc.WithTransportCredentials(o.creds),
+		grpc.WithChainUnaryInterceptor(c.unaryInterceptor),
+		grpc.WithChainStreamInterceptor(c.streamInterceptor),
+	}, o.dialOptions...)
+
+	conn, err := grpc.NewClient(addr, dialOptions...)
+	if err != nil {
+		return nil, fmt.Errorf("ошибка подключения к %s: %w", addr, err)
+	}
+	c.conn = conn
+	c.users = userspb.NewUserServiceClient(conn)
+	c.schedule = schedulepb.NewScheduleServiceClient(conn)
+	c.files = filespb.NewFileServiceClient(conn)
+	c.notifications = notificationspb.NewNotificationServiceClient(conn)
+	return c, nil
+}
+
+// Close закрывает соединение с сервером
+func (c *Client) Close() error {
+	return c.conn.Close()
+}
+
+// Token возвращает текущий токен пользователя (пусто - клиент не авторизован)
+func (c *Client) Token() string {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c.token
+}
+
+// SetToken заменяет токен пользователя, подставляемый в запросы
+func (c *Client) SetToken(token string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.token = token
+}
+
+// Users возвращает gRPC клиент сервиса пользователей
+func (c *Client) Users() userspb.UserServiceClient {
+	return c.users
+}
+
+// Schedule возвращает gRPC клиент сервиса расписания
+func (c *Client) Schedule() schedulepb.ScheduleServiceClient {
+	return c.schedule
+}
+
+// Files возвращает gRPC клиент файлового сервиса
+func (c *Client) Files() filespb.FileServiceClient {
+	return c.files
+}
+
+// Notifications возвращает gRPC клиент сервиса уведомлений
+func (c *Client) Notifications() notificationspb.NotificationServiceClient {
+	return c.notifications
+}
@@ -0,0 +1,151 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"net"
+	"testing"
+	"time"
+
+	schedulepb "github.com/Ultrahd-dev/student-schedule-app/backend/proto/gen/schedule"
+	userspb "github.com/Ultrahd-dev/student-schedule-app/backend/proto/gen/users"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+)
+
+// fakeUsers сервис пользователей для тестов клиента
+type fakeUsers struct {
+	userspb.UnimplementedUserServiceServer
+	college string
+}
+
+func (s *fakeUsers) Login(ctx context.Context, req *userspb.LoginRequest) (*userspb.LoginResponse, error) {
+	md, _ := metadata.FromIncomingContext(ctx)
+	if values := md.Get(collegeMetadataKey); len(values) > 0 {
+		s.college = values[0]
+	}
+	if req.Password == "2fa" {
+		return &userspb.LoginResponse{Success: true, TwoFactorRequired: true, TwoFactorToken: "pending"}, nil
+	}
+	return &userspb.LoginResponse{
+		Success: true,
+		Token:   "user-token",
+		User:    &userspb.User{Id: "1", Email: req.Email, Role: userspb.UserRole_ROLE_STUDENT},
+	}, nil
+}
+
+// fakeSchedule сервис расписания, отвечающий Unavailable первые failures раз
+type fakeSchedule struct {
+	schedulepb.UnimplementedScheduleServiceServer
+	failures int
+	calls    int
+	tokens   []string
+}
+
+func (s *fakeSchedule) GetMySchedule(ctx context.Context, req *schedulepb.GetMyScheduleRequest) (*schedulepb.GetMyScheduleResponse, error) {
+	s.calls++
+	s.tokens = append(s.tokens, req.Token)
+	if s.calls <= s.failures {
+		return nil, status.Error(codes.Unavailable, "перезапуск")
+	}
+	return &schedulepb.GetMyScheduleResponse{
+		Success: true,
+		Schedule: []*schedulepb.ScheduleEntry{{
+			Id:         "lesson",
+			Subject:    "Математика",
+			SourceType: schedulepb.ScheduleSourceType_SCHEDULE_SOURCE_TYPE_CHANGE,
+		}},
+	}, nil
+}
+
+// startServer запускает фейковые сервисы и возвращает подключенный к ним клиент
+func startServer(t *testing.T, users *fakeUsers, schedule *fakeSchedule, opts ...Option) *Client {
+	t.Helper()
+
+	listener := bufconn.Listen(1 << 20)
+	server := grpc.NewServer()
+	userspb.RegisterUserServiceServer(server, users)
+	schedulepb.RegisterScheduleServiceServer(server, schedule)
+	go server.Serve(listener)
+	t.Cleanup(server.Stop)
+
+	opts = append([]Option{
+		WithInsecure(),
+		WithDialOptions(grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) {
+			return listener.DialContext(ctx)
+		})),
+	}, opts...)
+	c, err := Dial("passthrough:///bufnet", opts...)
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { c.Close() })
+	return c
+}
+
+func TestLoginStoresToken(t *testing.T) {
+	users := &fakeUsers{}
+	schedule := &fakeSchedule{failures: 2}
+	c := startServer(t, users, schedule,
+		WithCollege("kit"),
+		WithRetry(RetryPolicy{MaxAttempts: 3, Backoff: time.Millisecond}))
+	ctx := context.Background()
+
+	user, err := c.Login(ctx, "student@example.com", "password")
+	if err != nil {
+		t.Fatalf("Login: %v", err)
+	}
+	if user.Role != RoleStudent {
+		t.Errorf("роль %q, ожидалась %q", user.Role, RoleStudent)
+	}
+	if users.college != "kit" {
+		t.Errorf("колледж в метаданных %q, ожидался kit", users.college)
+	}
+
+	lessons, err := c.MySchedule(ctx, time.Now(), false)
+	if err != nil {
+		t.Fatalf("MySchedule: %v", err)
+	}
+	if len(lessons) != 1 || lessons[0].Source != SourceChange {
+		t.Errorf("занятия %+v, ожидалась одна замена", lessons)
+	}
+	if schedule.calls != 3 {
+		t.Errorf("запрос выполнен %d раз, ожидалось 3 (два повтора)", schedule.calls)
+	}
+	for _, token := range schedule.tokens {
+		if token != "user-token" {
+			t.Errorf("в запросе токен %q, ожидался токен после входа", token)
+		}
+	}
+}
+
+func TestLoginTwoFactor(t *testing.T) {
+	c := startServer(t, &fakeUsers{}, &fakeSchedule{})
+
+	_, err := c.Login(context.Background(), "teacher@example.com", "2fa")
+	var challenge *TwoFactorRequiredError
+	if !errors.As(err, &challenge) || challenge.Token != "pending" {
+		t.Fatalf("ошибка %v, ожидалось требование кода 2FA", err)
+	}
+	if c.Token() != "" {
+		t.Error("токен сохранен до ввода кода 2FA")
+	}
+}
+
+func TestRetryAttemptsLimit(t *testing.T) {
+	schedule := &fakeSchedule{failures: 5}
+	c := startServer(t, &fakeUsers{}, schedule,
+		WithToken("token"),
+		WithRetry(RetryPolicy{MaxAttempts: 2, Backoff: time.Millisecond}))
+
+	_, err := c.MySchedule(context.Background(), time.Now(), true)
+	if status.Code(err) != codes.Unavailable {
+		t.Fatalf("код ошибки %v, ожидался Unavailable", status.Code(err))
+	}
+	if schedule.calls != 2 {
+		t.Errorf("запрос выполнен %d раз, ожидалось 2", schedule.calls)
+	}
+}
@@ -0,0 +1,141 @@
+package client
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+)
+
+// Ключи метаданных gRPC, которые читает сервер
+const (
+	collegeMetadataKey   = "x-college"    // Код колледжа для запросов без токена
+	requestIDMetadataKey = "x-request-id" // Идентификатор запроса в журнале сервера
+)
+
+// tokenField имя поля запроса с JWT токеном
+const tokenField = "token"
+
+// unaryInterceptor подставляет токен и метаданные в запрос и повторяет его
+// по RetryPolicy. Все попытки идут с одним идентификатором запроса.
+func (c *Client) unaryInterceptor(ctx context.Context, method string, req, reply interface{},
+	cc *grpc.ClientConn, invoker grpc.UnaryInvoker, opts ...grpc.CallOption) error {
+	ctx = c.outgoingContext(ctx)
+	req = c.withToken(req)
+
+	backoff := c.retry.Backoff
+	for attempt := 1; ; attempt++ {
+		err := invoker(ctx, method, req, reply, cc, opts...)
+		if err == nil || status.Code(err) != codes.Unavailable || attempt >= c.retry.MaxAttempts {
+			return err
+		}
+
+		timer := time.NewTimer(backoff)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return err
+		case <-timer.C:
+		}
+		backoff *= 2
+		if c.retry.MaxBackoff > 0 && backoff > c.retry.MaxBackoff {
+			backoff = c.retry.MaxBackoff
+		}
+	}
+}
+
+// streamInterceptor подставляет токен и метаданные в потоковые запросы.
+// Потоковые запросы не повторяются: часть потока уже могла быть передана.
+func (c *Client) streamInterceptor(ctx context.Context, desc *grpc.StreamDesc, cc *grpc.ClientConn,
+	method string, streamer grpc.Streamer, opts ...grpc.CallOption) (grpc.ClientStream, error) {
+	stream, err := streamer(c.outgoingContext(ctx), desc, cc, method, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return &tokenStream{ClientStream: stream, client: c}, nil
+}
+
+// tokenStream подставляет токен в отправляемые сообщения потока
+type tokenStream struct {
+	grpc.ClientStream
+	client *Client
+}
+
+// SendMsg отправляет сообщение с подставленным токеном
+func (s *tokenStream) SendMsg(m interface{}) error {
+	return s.ClientStream.SendMsg(s.client.withToken(m))
+}
+
+// outgoingContext добавляет в метаданные код колледжа и идентификатор запроса,
+// если вызывающий их не задал
+func (c *Client) outgoingContext(ctx context.Context) context.Context {
+	md, _ := metadata.FromOutgoingContext(ctx)
+	if c.college != "" && len(md.Get(collegeMetadataKey)) == 0 {
+		ctx = metadata.AppendToOutgoingContext(ctx, collegeMetadataKey, c.college)
+	}
+	if len(md.Get(requestIDMetadataKey)) == 0 {
+		ctx = metadata.AppendToOutgoingContext(ctx, requestIDMetadataKey, uuid.NewString())
+	}
+	return ctx
+}
+
+// withToken возвращает копию запроса с токеном клиента в незаполненном поле token
+// (в самом запросе или во вложенном сообщении, например UploadFileInfo).
+// Запрос вызывающего не изменяется; запрос без такого поля возвращается как есть.
+func (c *Client) withToken(req interface{}) interface{} {
+	msg, ok := req.(proto.Message)
+	if !ok {
+		return req
+	}
+	token := c.Token()
+	if token == "" {
+		return req
+	}
+
+	target := tokenTarget(msg.ProtoReflect())
+	if target == nil {
+		return req
+	}
+	clone := proto.Clone(msg)
+	setToken(clone.ProtoReflect(), token)
+	return clone
+}
+
+// tokenTarget находит сообщение с пустым полем token: сам запрос
+// или заполненное вложенное сообщение первого уровня
+func tokenTarget(msg protoreflect.Message) protoreflect.Message {
+	if field := msg.Descriptor().Fields().ByName(tokenField); field != nil {
+		if field.Kind() == protoreflect.StringKind && !msg.Has(field) {
+			return msg
+		}
+		return nil
+	}
+
+	var target protoreflect.Message
+	msg.Range(func(field protoreflect.FieldDescriptor, value protoreflect.Value) bool {
+		if field.Kind() != protoreflect.MessageKind || field.IsList() || field.IsMap() {
+			return true
+		}
+		nested := value.Message()
+		if nestedField := nested.Descriptor().Fields().ByName(tokenField); nestedField != nil &&
+			nestedField.Kind() == protoreflect.StringKind && !nested.Has(nestedField) {
+			target = nested
+			return false
+		}
+		return true
+	})
+	return target
+}
+
+// setToken записывает токен в сообщение, найденное tokenTarget
+func setToken(msg protoreflect.Message, token string) {
+	if target := tokenTarget(msg); target != nil {
+		target.Set(target.Descriptor().Fields().ByName(tokenField), protoreflect.ValueOfString(token))
+	}
+}
@@ -0,0 +1,180 @@
+package client
+
+import (
+	"time"
+
+	schedulepb "github.com/Ultrahd-dev/student-schedule-app/backend/proto/gen/schedule"
+	userspb "github.com/Ultrahd-dev/student-schedule-app/backend/proto/gen/users"
+)
+
+// Role роль пользователя
+type Role string
+
+// Роли пользователей
+const (
+	RoleStudent Role = "student"
+	RoleTeacher Role = "teacher"
+	RoleAdmin   Role = "admin"
+)
+
+// User пользователь
+type User struct {
+	ID        string
+	Email     string
+	Role      Role // Пусто, если сервер не передал роль
+	CreatedAt time.Time
+	IsActive  bool
+	// PasswordChangeRequired пароль временный (выдан администратором), его нужно сменить
+	PasswordChangeRequired bool
+	LoginAlerts            bool   // Уведомления о входе с нового устройства включены
+	Locale                 string // Язык пользователя (ru, en)
+	DateFormat             string // Выбранный формат даты; пусто - по языку
+	TimeFormat             string // Выбранный формат времени; пусто - по языку
+}
+
+// StudentProfile профиль студента
+type StudentProfile struct {
+	GroupName     string
+	Faculty       string
+	Course        int
+	StudentNumber string
+	FullName      string
+	Subgroup      int // 0 - подгруппа не выбрана
+}
+
+// TeacherProfile профиль преподавателя
+type TeacherProfile struct {
+	FullName   string
+	Department string
+	Position   string
+}
+
+// Profile пользователь с профилем студента или преподавателя
+type Profile struct {
+	User    User
+	Student *StudentProfile // nil, если пользователь не студент
+	Teacher *TeacherProfile // nil, если пользователь не преподаватель
+}
+
+// Источник занятия в расписании
+const (
+	SourceMain         = "main"         // Основное расписание
+	SourceChange       = "change"       // Замена
+	SourceElective     = "elective"     // Факультатив
+	SourceConsultation = "consultation" // Консультация преподавателя (только в NextLesson)
+)
+
+// Lesson занятие в расписании
+type Lesson struct {
+	ID            string
+	GroupName     string
+	Date          time.Time
+	TimeStart     string // ЧЧ:ММ
+	TimeEnd       string // ЧЧ:ММ
+	Subject       string
+	Teacher       string
+	Classroom     string
+	Building      string // Код корпуса (пусто - не указан)
+	Subgroup      int    // 0 - занятие всей группы
+	LessonType    string // Лекция, практика, лабораторная (пусто - не указан)
+	Source        string // SourceMain, SourceChange, SourceElective или SourceConsultation
+	MeetingURL    string // Ссылка на онлайн-занятие (пусто - очное)
+	Note          string // Личная заметка пользователя (пусто - заметки нет)
+	TravelWarning bool   // Не хватает перерыва на переход из другого корпуса
+}
+
+// fromPBUser преобразует пользователя из формата protobuf
+func fromPBUser(user *userspb.User) User {
+	if user == nil {
+		return User{}
+	}
+	createdAt, _ := time.Parse(time.RFC3339, user.CreatedAt)
+	return User{
+		ID:                     user.Id,
+		Email:                  user.Email,
+		Role:                   fromPBRole(user.Role),
+		CreatedAt:              createdAt,
+		IsActive:               user.IsActive,
+		PasswordChangeRequired: user.PasswordChangeRequired,
+		LoginAlerts:            user.LoginAlerts,
+		Locale:                 user.Locale,
+		DateFormat:             user.DateFormat,
+		TimeFormat:             user.TimeFormat,
+	}
+}
+
+// fromPBRole преобразует роль из формата protobuf
+func fromPBRole(role userspb.UserRole) Role {
+	switch role {
+	case userspb.UserRole_ROLE_STUDENT:
+		return RoleStudent
+	case userspb.UserRole_ROLE_TEACHER:
+		return RoleTeacher
+	case userspb.UserRole_ROLE_ADMIN:
+		return RoleAdmin
+	}
+	return ""
+}
+
+// fromPBProfile преобразует профиль из формата protobuf
+func fromPBProfile(resp *userspb.GetProfileResponse) *Profile {
+	profile := &Profile{User: fromPBUser(resp.User)}
+	if student := resp.GetStudentProfile(); student != nil {
+		profile.Student = &StudentProfile{
+			GroupName:     student.GroupName,
+			Faculty:       student.Faculty,
+			Course:        int(student.Course),
+			StudentNumber: student.StudentNumber,
+			FullName:      student.FullName,
+			Subgroup:      int(student.Subgroup),
+		}
+	}
+	if teacher := resp.GetTeacherProfile(); teacher != nil {
+		profile.Teacher = &TeacherProfile{
+			FullName:   teacher.FullName,
+			Department: teacher.Department,
+			Position:   teacher.Position,
+		}
+	}
+	return profile
+}
+
+// fromPBLessons преобразует записи расписания из формата protobuf
+func fromPBLessons(entries []*schedulepb.ScheduleEntry) []Lesson {
+	lessons := make([]Lesson, 0, len(entries))
+	for _, entry := range entries {
+		lessons = append(lessons, fromPBLesson(entry))
+	}
+	return lessons
+}
+
+// fromPBLesson преобразует запись расписания из формата protobuf
+func fromPBLesson(entry *schedulepb.ScheduleEntry) Lesson {
+	lesson := Lesson{
+		ID:            entry.Id,
+		GroupName:     entry.GroupName,
+		TimeStart:     entry.TimeStart,
+		TimeEnd:       entry.TimeEnd,
+		Subject:       entry.Subject,
+		Teacher:       entry.Teacher,
+		Classroom:     entry.Classroom,
+		Building:      entry.Building,
+		Subgroup:      int(entry.Subgroup),
+		LessonType:    entry.LessonType,
+		MeetingURL:    entry.MeetingUrl,
+		Note:          entry.Note,
+		TravelWarning: entry.TravelWarning,
+	}
+	if entry.Date != nil {
+		lesson.Date = entry.Date.AsTime()
+	}
+	switch entry.SourceType {
+	case schedulepb.ScheduleSourceType_SCHEDULE_SOURCE_TYPE_MAIN:
+		lesson.Source = SourceMain
+	case schedulepb.ScheduleSourceType_SCHEDULE_SOURCE_TYPE_CHANGE:
+		lesson.Source = SourceChange
+	case schedulepb.ScheduleSourceType_SCHEDULE_SOURCE_TYPE_ELECTIVE:
+		lesson.Source = SourceElective
+	}
+	return lesson
+}
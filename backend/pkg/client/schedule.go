@@ -0,0 +1,84 @@
+package client
+
+import (
+	"context"
+	"time"
+
+	schedulepb "github.com/Ultrahd-dev/student-schedule-app/backend/proto/gen/schedule"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// SearchResult найденное занятие
+type SearchResult struct {
+	Lesson Lesson
+	Rank   float64 // Релевантность (чем больше, тем лучше)
+}
+
+// GroupSchedule возвращает расписание группы на день date
+func (c *Client) GroupSchedule(ctx context.Context, groupName string, date time.Time) ([]Lesson, error) {
+	resp, err := c.schedule.GetScheduleForGroup(ctx, &schedulepb.GetScheduleForGroupRequest{
+		GroupName: groupName,
+		Date:      timestamppb.New(date),
+	})
+	if err != nil {
+		return nil, err
+	}
+	return fromPBLessons(resp.Schedule), nil
+}
+
+// MySchedule возвращает расписание текущего пользователя на день date
+// или, если week, на всю неделю (пн-вс), в которую входит date
+func (c *Client) MySchedule(ctx context.Context, date time.Time, week bool) ([]Lesson, error) {
+	resp, err := c.schedule.GetMySchedule(ctx, &schedulepb.GetMyScheduleRequest{
+		Date: timestamppb.New(date),
+		Week: week,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return fromPBLessons(resp.Schedule), nil
+}
+
+// NextLesson возвращает ближайшее занятие пользователя (гостю - группы токена) и
+// время до его начала. nil - занятий в ближайшие две недели нет.
+func (c *Client) NextLesson(ctx context.Context) (*Lesson, time.Duration, error) {
+	resp, err := c.schedule.GetNextLesson(ctx, &schedulepb.GetNextLessonRequest{})
+	if err != nil {
+		return nil, 0, err
+	}
+	if resp.Lesson == nil {
+		return nil, 0, nil
+	}
+	lesson := fromPBLesson(resp.Lesson)
+	if resp.Consultation {
+		lesson.Source = SourceConsultation
+	}
+	return &lesson, time.Duration(resp.StartsInMinutes) * time.Minute, nil
+}
+
+// Search ищет занятия по предмету, преподавателю или аудитории в периоде
+// [from, to]. Нулевые from и to и limit - значения сервера по умолчанию
+// (сегодня, +30 дней, 50 результатов).
+func (c *Client) Search(ctx context.Context, query string, from, to time.Time, limit int) ([]SearchResult, error) {
+	req := &schedulepb.SearchScheduleRequest{Query: query, Limit: int32(limit)}
+	if !from.IsZero() {
+		req.From = timestamppb.New(from)
+	}
+	if !to.IsZero() {
+		req.To = timestamppb.New(to)
+	}
+
+	resp, err := c.schedule.SearchSchedule(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+
+	results := make([]SearchResult, 0, len(resp.Results))
+	for _, result := range resp.Results {
+		if result.Entry == nil {
+			continue
+		}
+		results = append(results, SearchResult{Lesson: fromPBLesson(result.Entry), Rank: result.Rank})
+	}
+	return results, nil
+}
@@ -31,6 +31,7 @@ const (
 	ScheduleSourceType_SCHEDULE_SOURCE_TYPE_UNSPECIFIED ScheduleSourceType = 0
 	ScheduleSourceType_SCHEDULE_SOURCE_TYPE_MAIN        ScheduleSourceType = 1
 	ScheduleSourceType_SCHEDULE_SOURCE_TYPE_CHANGE      ScheduleSourceType = 2
+	ScheduleSourceType_SCHEDULE_SOURCE_TYPE_ELECTIVE    ScheduleSourceType = 3 // Занятие факультатива (source_id - ID курса)
 )
 
 // Enum value maps for ScheduleSourceType.
@@ -39,11 +40,13 @@ var (
 		0: "SCHEDULE_SOURCE_TYPE_UNSPECIFIED",
 		1: "SCHEDULE_SOURCE_TYPE_MAIN",
 		2: "SCHEDULE_SOURCE_TYPE_CHANGE",
+		3: "SCHEDULE_SOURCE_TYPE_ELECTIVE",
 	}
 	ScheduleSourceType_value = map[string]int32{
 		"SCHEDULE_SOURCE_TYPE_UNSPECIFIED": 0,
 		"SCHEDULE_SOURCE_TYPE_MAIN":        1,
 		"SCHEDULE_SOURCE_TYPE_CHANGE":      2,
+		"SCHEDULE_SOURCE_TYPE_ELECTIVE":    3,
 	}
 )
 
@@ -74,6 +77,65 @@ func (ScheduleSourceType) EnumDescriptor() ([]byte, []int) {
 	return file_schedule_proto_rawDescGZIP(), []int{0}
 }
 
+// Состояние учебного дня по расписанию звонков
+type BellState int32
+
+const (
+	BellState_BELL_STATE_UNSPECIFIED    BellState = 0
+	BellState_BELL_STATE_LESSON         BellState = 1 // Идет пара
+	BellState_BELL_STATE_BREAK          BellState = 2 // Перемена между парами
+	BellState_BELL_STATE_BEFORE_CLASSES BellState = 3 // Пары сегодня еще не начались
+	BellState_BELL_STATE_AFTER_CLASSES  BellState = 4 // Пары сегодня закончились
+	BellState_BELL_STATE_DAY_OFF        BellState = 5 // Сегодня пар нет
+)
+
+// Enum value maps for BellState.
+var (
+	BellState_name = map[int32]string{
+		0: "BELL_STATE_UNSPECIFIED",
+		1: "BELL_STATE_LESSON",
+		2: "BELL_STATE_BREAK",
+		3: "BELL_STATE_BEFORE_CLASSES",
+		4: "BELL_STATE_AFTER_CLASSES",
+		5: "BELL_STATE_DAY_OFF",
+	}
+	BellState_value = map[string]int32{
+		"BELL_STATE_UNSPECIFIED":    0,
+		"BELL_STATE_LESSON":         1,
+		"BELL_STATE_BREAK":          2,
+		"BELL_STATE_BEFORE_CLASSES": 3,
+		"BELL_STATE_AFTER_CLASSES":  4,
+		"BELL_STATE_DAY_OFF":        5,
+	}
+)
+
+func (x BellState) Enum() *BellState {
+	p := new(BellState)
+	*p = x
+	return p
+}
+
+func (x BellState) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (BellState) Descriptor() protoreflect.EnumDescriptor {
+	return file_schedule_proto_enumTypes[1].Descriptor()
+}
+
+func (BellState) Type() protoreflect.EnumType {
+	return &file_schedule_proto_enumTypes[1]
+}
+
+func (x BellState) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use BellState.Descriptor instead.
+func (BellState) EnumDescriptor() ([]byte, []int) {
+	return file_schedule_proto_rawDescGZIP(), []int{1}
+}
+
 // Типы изменений в расписании
 type ScheduleChangeType int32
 
@@ -106,52 +168,10812 @@ func (x ScheduleChangeType) Enum() *ScheduleChangeType {
 	return p
 }
 
-func (x ScheduleChangeType) String() string {
-	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+func (x ScheduleChangeType) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (ScheduleChangeType) Descriptor() protoreflect.EnumDescriptor {
+	return file_schedule_proto_enumTypes[2].Descriptor()
+}
+
+func (ScheduleChangeType) Type() protoreflect.EnumType {
+	return &file_schedule_proto_enumTypes[2]
+}
+
+func (x ScheduleChangeType) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use ScheduleChangeType.Descriptor instead.
+func (ScheduleChangeType) EnumDescriptor() ([]byte, []int) {
+	return file_schedule_proto_rawDescGZIP(), []int{2}
+}
+
+// Статус группы в сравнении снапшотов
+type GroupDiffStatus int32
+
+const (
+	GroupDiffStatus_GROUP_DIFF_STATUS_UNSPECIFIED GroupDiffStatus = 0
+	GroupDiffStatus_GROUP_DIFF_STATUS_ADDED       GroupDiffStatus = 1
+	GroupDiffStatus_GROUP_DIFF_STATUS_REMOVED     GroupDiffStatus = 2
+	GroupDiffStatus_GROUP_DIFF_STATUS_CHANGED     GroupDiffStatus = 3
+)
+
+// Enum value maps for GroupDiffStatus.
+var (
+	GroupDiffStatus_name = map[int32]string{
+		0: "GROUP_DIFF_STATUS_UNSPECIFIED",
+		1: "GROUP_DIFF_STATUS_ADDED",
+		2: "GROUP_DIFF_STATUS_REMOVED",
+		3: "GROUP_DIFF_STATUS_CHANGED",
+	}
+	GroupDiffStatus_value = map[string]int32{
+		"GROUP_DIFF_STATUS_UNSPECIFIED": 0,
+		"GROUP_DIFF_STATUS_ADDED":       1,
+		"GROUP_DIFF_STATUS_REMOVED":     2,
+		"GROUP_DIFF_STATUS_CHANGED":     3,
+	}
+)
+
+func (x GroupDiffStatus) Enum() *GroupDiffStatus {
+	p := new(GroupDiffStatus)
+	*p = x
+	return p
+}
+
+func (x GroupDiffStatus) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (GroupDiffStatus) Descriptor() protoreflect.EnumDescriptor {
+	return file_schedule_proto_enumTypes[3].Descriptor()
+}
+
+func (GroupDiffStatus) Type() protoreflect.EnumType {
+	return &file_schedule_proto_enumTypes[3]
+}
+
+func (x GroupDiffStatus) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use GroupDiffStatus.Descriptor instead.
+func (GroupDiffStatus) EnumDescriptor() ([]byte, []int) {
+	return file_schedule_proto_rawDescGZIP(), []int{3}
+}
+
+// Статус применения изменения к актуальному расписанию
+type ChangeApplyStatus int32
+
+const (
+	ChangeApplyStatus_CHANGE_APPLY_STATUS_UNSPECIFIED ChangeApplyStatus = 0
+	ChangeApplyStatus_CHANGE_APPLY_STATUS_PENDING     ChangeApplyStatus = 1
+	ChangeApplyStatus_CHANGE_APPLY_STATUS_APPLIED     ChangeApplyStatus = 2
+	ChangeApplyStatus_CHANGE_APPLY_STATUS_SKIPPED     ChangeApplyStatus = 3
+	ChangeApplyStatus_CHANGE_APPLY_STATUS_ERROR       ChangeApplyStatus = 4
+	ChangeApplyStatus_CHANGE_APPLY_STATUS_REVERTED    ChangeApplyStatus = 5 // Строка исчезла из таблицы изменений, изменение откачено
+)
+
+// Enum value maps for ChangeApplyStatus.
+var (
+	ChangeApplyStatus_name = map[int32]string{
+		0: "CHANGE_APPLY_STATUS_UNSPECIFIED",
+		1: "CHANGE_APPLY_STATUS_PENDING",
+		2: "CHANGE_APPLY_STATUS_APPLIED",
+		3: "CHANGE_APPLY_STATUS_SKIPPED",
+		4: "CHANGE_APPLY_STATUS_ERROR",
+		5: "CHANGE_APPLY_STATUS_REVERTED",
+	}
+	ChangeApplyStatus_value = map[string]int32{
+		"CHANGE_APPLY_STATUS_UNSPECIFIED": 0,
+		"CHANGE_APPLY_STATUS_PENDING":     1,
+		"CHANGE_APPLY_STATUS_APPLIED":     2,
+		"CHANGE_APPLY_STATUS_SKIPPED":     3,
+		"CHANGE_APPLY_STATUS_ERROR":       4,
+		"CHANGE_APPLY_STATUS_REVERTED":    5,
+	}
+)
+
+func (x ChangeApplyStatus) Enum() *ChangeApplyStatus {
+	p := new(ChangeApplyStatus)
+	*p = x
+	return p
+}
+
+func (x ChangeApplyStatus) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (ChangeApplyStatus) Descriptor() protoreflect.EnumDescriptor {
+	return file_schedule_proto_enumTypes[4].Descriptor()
+}
+
+func (ChangeApplyStatus) Type() protoreflect.EnumType {
+	return &file_schedule_proto_enumTypes[4]
+}
+
+func (x ChangeApplyStatus) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use ChangeApplyStatus.Descriptor instead.
+func (ChangeApplyStatus) EnumDescriptor() ([]byte, []int) {
+	return file_schedule_proto_rawDescGZIP(), []int{4}
+}
+
+// Статус модерации изменения
+type ChangeModerationStatus int32
+
+const (
+	ChangeModerationStatus_CHANGE_MODERATION_STATUS_UNSPECIFIED ChangeModerationStatus = 0
+	ChangeModerationStatus_CHANGE_MODERATION_STATUS_PENDING     ChangeModerationStatus = 1
+	ChangeModerationStatus_CHANGE_MODERATION_STATUS_APPROVED    ChangeModerationStatus = 2
+	ChangeModerationStatus_CHANGE_MODERATION_STATUS_REJECTED    ChangeModerationStatus = 3
+)
+
+// Enum value maps for ChangeModerationStatus.
+var (
+	ChangeModerationStatus_name = map[int32]string{
+		0: "CHANGE_MODERATION_STATUS_UNSPECIFIED",
+		1: "CHANGE_MODERATION_STATUS_PENDING",
+		2: "CHANGE_MODERATION_STATUS_APPROVED",
+		3: "CHANGE_MODERATION_STATUS_REJECTED",
+	}
+	ChangeModerationStatus_value = map[string]int32{
+		"CHANGE_MODERATION_STATUS_UNSPECIFIED": 0,
+		"CHANGE_MODERATION_STATUS_PENDING":     1,
+		"CHANGE_MODERATION_STATUS_APPROVED":    2,
+		"CHANGE_MODERATION_STATUS_REJECTED":    3,
+	}
+)
+
+func (x ChangeModerationStatus) Enum() *ChangeModerationStatus {
+	p := new(ChangeModerationStatus)
+	*p = x
+	return p
+}
+
+func (x ChangeModerationStatus) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (ChangeModerationStatus) Descriptor() protoreflect.EnumDescriptor {
+	return file_schedule_proto_enumTypes[5].Descriptor()
+}
+
+func (ChangeModerationStatus) Type() protoreflect.EnumType {
+	return &file_schedule_proto_enumTypes[5]
+}
+
+func (x ChangeModerationStatus) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use ChangeModerationStatus.Descriptor instead.
+func (ChangeModerationStatus) EnumDescriptor() ([]byte, []int) {
+	return file_schedule_proto_rawDescGZIP(), []int{5}
+}
+
+// Решение модератора
+type ReviewDecision int32
+
+const (
+	ReviewDecision_REVIEW_DECISION_UNSPECIFIED ReviewDecision = 0
+	ReviewDecision_REVIEW_DECISION_APPROVE     ReviewDecision = 1
+	ReviewDecision_REVIEW_DECISION_REJECT      ReviewDecision = 2
+)
+
+// Enum value maps for ReviewDecision.
+var (
+	ReviewDecision_name = map[int32]string{
+		0: "REVIEW_DECISION_UNSPECIFIED",
+		1: "REVIEW_DECISION_APPROVE",
+		2: "REVIEW_DECISION_REJECT",
+	}
+	ReviewDecision_value = map[string]int32{
+		"REVIEW_DECISION_UNSPECIFIED": 0,
+		"REVIEW_DECISION_APPROVE":     1,
+		"REVIEW_DECISION_REJECT":      2,
+	}
+)
+
+func (x ReviewDecision) Enum() *ReviewDecision {
+	p := new(ReviewDecision)
+	*p = x
+	return p
+}
+
+func (x ReviewDecision) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (ReviewDecision) Descriptor() protoreflect.EnumDescriptor {
+	return file_schedule_proto_enumTypes[6].Descriptor()
+}
+
+func (ReviewDecision) Type() protoreflect.EnumType {
+	return &file_schedule_proto_enumTypes[6]
+}
+
+func (x ReviewDecision) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use ReviewDecision.Descriptor instead.
+func (ReviewDecision) EnumDescriptor() ([]byte, []int) {
+	return file_schedule_proto_rawDescGZIP(), []int{6}
+}
+
+// Вид заявки преподавателя
+type TeacherChangeRequestKind int32
+
+const (
+	TeacherChangeRequestKind_TEACHER_CHANGE_REQUEST_KIND_UNSPECIFIED TeacherChangeRequestKind = 0
+	TeacherChangeRequestKind_TEACHER_CHANGE_REQUEST_KIND_CANCEL      TeacherChangeRequestKind = 1 // Отмена пары
+	TeacherChangeRequestKind_TEACHER_CHANGE_REQUEST_KIND_MOVE        TeacherChangeRequestKind = 2 // Перенос пары в другой слот
+)
+
+// Enum value maps for TeacherChangeRequestKind.
+var (
+	TeacherChangeRequestKind_name = map[int32]string{
+		0: "TEACHER_CHANGE_REQUEST_KIND_UNSPECIFIED",
+		1: "TEACHER_CHANGE_REQUEST_KIND_CANCEL",
+		2: "TEACHER_CHANGE_REQUEST_KIND_MOVE",
+	}
+	TeacherChangeRequestKind_value = map[string]int32{
+		"TEACHER_CHANGE_REQUEST_KIND_UNSPECIFIED": 0,
+		"TEACHER_CHANGE_REQUEST_KIND_CANCEL":      1,
+		"TEACHER_CHANGE_REQUEST_KIND_MOVE":        2,
+	}
+)
+
+func (x TeacherChangeRequestKind) Enum() *TeacherChangeRequestKind {
+	p := new(TeacherChangeRequestKind)
+	*p = x
+	return p
+}
+
+func (x TeacherChangeRequestKind) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (TeacherChangeRequestKind) Descriptor() protoreflect.EnumDescriptor {
+	return file_schedule_proto_enumTypes[7].Descriptor()
+}
+
+func (TeacherChangeRequestKind) Type() protoreflect.EnumType {
+	return &file_schedule_proto_enumTypes[7]
+}
+
+func (x TeacherChangeRequestKind) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use TeacherChangeRequestKind.Descriptor instead.
+func (TeacherChangeRequestKind) EnumDescriptor() ([]byte, []int) {
+	return file_schedule_proto_rawDescGZIP(), []int{7}
+}
+
+// Состояние фоновой задачи
+type JobStatus int32
+
+const (
+	JobStatus_JOB_STATUS_UNSPECIFIED JobStatus = 0
+	JobStatus_JOB_STATUS_PENDING     JobStatus = 1 // Ожидает выполнения (в том числе повторной попытки)
+	JobStatus_JOB_STATUS_RUNNING     JobStatus = 2
+	JobStatus_JOB_STATUS_DONE        JobStatus = 3
+	JobStatus_JOB_STATUS_FAILED      JobStatus = 4 // Попытки исчерпаны
+)
+
+// Enum value maps for JobStatus.
+var (
+	JobStatus_name = map[int32]string{
+		0: "JOB_STATUS_UNSPECIFIED",
+		1: "JOB_STATUS_PENDING",
+		2: "JOB_STATUS_RUNNING",
+		3: "JOB_STATUS_DONE",
+		4: "JOB_STATUS_FAILED",
+	}
+	JobStatus_value = map[string]int32{
+		"JOB_STATUS_UNSPECIFIED": 0,
+		"JOB_STATUS_PENDING":     1,
+		"JOB_STATUS_RUNNING":     2,
+		"JOB_STATUS_DONE":        3,
+		"JOB_STATUS_FAILED":      4,
+	}
+)
+
+func (x JobStatus) Enum() *JobStatus {
+	p := new(JobStatus)
+	*p = x
+	return p
+}
+
+func (x JobStatus) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (JobStatus) Descriptor() protoreflect.EnumDescriptor {
+	return file_schedule_proto_enumTypes[8].Descriptor()
+}
+
+func (JobStatus) Type() protoreflect.EnumType {
+	return &file_schedule_proto_enumTypes[8]
+}
+
+func (x JobStatus) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use JobStatus.Descriptor instead.
+func (JobStatus) EnumDescriptor() ([]byte, []int) {
+	return file_schedule_proto_rawDescGZIP(), []int{8}
+}
+
+// Сервис группового чата для вебхука
+type WebhookProvider int32
+
+const (
+	WebhookProvider_WEBHOOK_PROVIDER_UNSPECIFIED WebhookProvider = 0
+	WebhookProvider_WEBHOOK_PROVIDER_DISCORD     WebhookProvider = 1
+	WebhookProvider_WEBHOOK_PROVIDER_SLACK       WebhookProvider = 2
+)
+
+// Enum value maps for WebhookProvider.
+var (
+	WebhookProvider_name = map[int32]string{
+		0: "WEBHOOK_PROVIDER_UNSPECIFIED",
+		1: "WEBHOOK_PROVIDER_DISCORD",
+		2: "WEBHOOK_PROVIDER_SLACK",
+	}
+	WebhookProvider_value = map[string]int32{
+		"WEBHOOK_PROVIDER_UNSPECIFIED": 0,
+		"WEBHOOK_PROVIDER_DISCORD":     1,
+		"WEBHOOK_PROVIDER_SLACK":       2,
+	}
+)
+
+func (x WebhookProvider) Enum() *WebhookProvider {
+	p := new(WebhookProvider)
+	*p = x
+	return p
+}
+
+func (x WebhookProvider) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (WebhookProvider) Descriptor() protoreflect.EnumDescriptor {
+	return file_schedule_proto_enumTypes[9].Descriptor()
+}
+
+func (WebhookProvider) Type() protoreflect.EnumType {
+	return &file_schedule_proto_enumTypes[9]
+}
+
+func (x WebhookProvider) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use WebhookProvider.Descriptor instead.
+func (WebhookProvider) EnumDescriptor() ([]byte, []int) {
+	return file_schedule_proto_rawDescGZIP(), []int{9}
+}
+
+// Что неверно в паре по мнению пользователя
+type ScheduleErrorReason int32
+
+const (
+	ScheduleErrorReason_SCHEDULE_ERROR_REASON_UNSPECIFIED     ScheduleErrorReason = 0
+	ScheduleErrorReason_SCHEDULE_ERROR_REASON_WRONG_SUBJECT   ScheduleErrorReason = 1 // Другой предмет
+	ScheduleErrorReason_SCHEDULE_ERROR_REASON_WRONG_TEACHER   ScheduleErrorReason = 2 // Другой преподаватель
+	ScheduleErrorReason_SCHEDULE_ERROR_REASON_WRONG_CLASSROOM ScheduleErrorReason = 3 // Другая аудитория
+	ScheduleErrorReason_SCHEDULE_ERROR_REASON_WRONG_TIME      ScheduleErrorReason = 4 // Другое время
+	ScheduleErrorReason_SCHEDULE_ERROR_REASON_NOT_HELD        ScheduleErrorReason = 5 // Пары нет (отменена или не существует)
+	ScheduleErrorReason_SCHEDULE_ERROR_REASON_OTHER           ScheduleErrorReason = 6 // Другое (в комментарии)
+)
+
+// Enum value maps for ScheduleErrorReason.
+var (
+	ScheduleErrorReason_name = map[int32]string{
+		0: "SCHEDULE_ERROR_REASON_UNSPECIFIED",
+		1: "SCHEDULE_ERROR_REASON_WRONG_SUBJECT",
+		2: "SCHEDULE_ERROR_REASON_WRONG_TEACHER",
+		3: "SCHEDULE_ERROR_REASON_WRONG_CLASSROOM",
+		4: "SCHEDULE_ERROR_REASON_WRONG_TIME",
+		5: "SCHEDULE_ERROR_REASON_NOT_HELD",
+		6: "SCHEDULE_ERROR_REASON_OTHER",
+	}
+	ScheduleErrorReason_value = map[string]int32{
+		"SCHEDULE_ERROR_REASON_UNSPECIFIED":     0,
+		"SCHEDULE_ERROR_REASON_WRONG_SUBJECT":   1,
+		"SCHEDULE_ERROR_REASON_WRONG_TEACHER":   2,
+		"SCHEDULE_ERROR_REASON_WRONG_CLASSROOM": 3,
+		"SCHEDULE_ERROR_REASON_WRONG_TIME":      4,
+		"SCHEDULE_ERROR_REASON_NOT_HELD":        5,
+		"SCHEDULE_ERROR_REASON_OTHER":           6,
+	}
+)
+
+func (x ScheduleErrorReason) Enum() *ScheduleErrorReason {
+	p := new(ScheduleErrorReason)
+	*p = x
+	return p
+}
+
+func (x ScheduleErrorReason) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (ScheduleErrorReason) Descriptor() protoreflect.EnumDescriptor {
+	return file_schedule_proto_enumTypes[10].Descriptor()
+}
+
+func (ScheduleErrorReason) Type() protoreflect.EnumType {
+	return &file_schedule_proto_enumTypes[10]
+}
+
+func (x ScheduleErrorReason) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use ScheduleErrorReason.Descriptor instead.
+func (ScheduleErrorReason) EnumDescriptor() ([]byte, []int) {
+	return file_schedule_proto_rawDescGZIP(), []int{10}
+}
+
+// Запрос на получение расписания для группы
+type GetScheduleForGroupRequest struct {
+	state     protoimpl.MessageState `protogen:"open.v1"`
+	GroupName string                 `protobuf:"bytes,1,opt,name=group_name,json=groupName,proto3" json:"group_name,omitempty"`
+	Date      *timestamppb.Timestamp `protobuf:"bytes,2,opt,name=date,proto3" json:"date,omitempty"`
+	Token     string                 `protobuf:"bytes,3,opt,name=token,proto3" json:"token,omitempty"` // JWT токен для аутентификации
+	// Необязательный момент времени: если задан, возвращается расписание
+	// в том виде, в котором оно было на этот момент
+	AsOf *timestamppb.Timestamp `protobuf:"bytes,4,opt,name=as_of,json=asOf,proto3" json:"as_of,omitempty"`
+	// Необязательный фильтр по виду занятия ("лекция", "практика", "лабораторная")
+	LessonType string `protobuf:"bytes,5,opt,name=lesson_type,json=lessonType,proto3" json:"lesson_type,omitempty"`
+	// Искать расписание прошедших семестров в архиве, если в актуальном
+	// расписании записей на дату нет (для экранов истории)
+	IncludeArchive bool `protobuf:"varint,6,opt,name=include_archive,json=includeArchive,proto3" json:"include_archive,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *GetScheduleForGroupRequest) Reset() {
+	*x = GetScheduleForGroupRequest{}
+	mi := &file_schedule_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetScheduleForGroupRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetScheduleForGroupRequest) ProtoMessage() {}
+
+func (x *GetScheduleForGroupRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_schedule_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetScheduleForGroupRequest.ProtoReflect.Descriptor instead.
+func (*GetScheduleForGroupRequest) Descriptor() ([]byte, []int) {
+	return file_schedule_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *GetScheduleForGroupRequest) GetGroupName() string {
+	if x != nil {
+		return x.GroupName
+	}
+	return ""
+}
+
+func (x *GetScheduleForGroupRequest) GetDate() *timestamppb.Timestamp {
+	if x != nil {
+		return x.Date
+	}
+	return nil
+}
+
+func (x *GetScheduleForGroupRequest) GetToken() string {
+	if x != nil {
+		return x.Token
+	}
+	return ""
+}
+
+func (x *GetScheduleForGroupRequest) GetAsOf() *timestamppb.Timestamp {
+	if x != nil {
+		return x.AsOf
+	}
+	return nil
+}
+
+func (x *GetScheduleForGroupRequest) GetLessonType() string {
+	if x != nil {
+		return x.LessonType
+	}
+	return ""
+}
+
+func (x *GetScheduleForGroupRequest) GetIncludeArchive() bool {
+	if x != nil {
+		return x.IncludeArchive
+	}
+	return false
+}
+
+// Ответ с расписанием для группы
+type GetScheduleForGroupResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Success       bool                   `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
+	Message       string                 `protobuf:"bytes,2,opt,name=message,proto3" json:"message,omitempty"`
+	Schedule      []*ScheduleEntry       `protobuf:"bytes,3,rep,name=schedule,proto3" json:"schedule,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetScheduleForGroupResponse) Reset() {
+	*x = GetScheduleForGroupResponse{}
+	mi := &file_schedule_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetScheduleForGroupResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetScheduleForGroupResponse) ProtoMessage() {}
+
+func (x *GetScheduleForGroupResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_schedule_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetScheduleForGroupResponse.ProtoReflect.Descriptor instead.
+func (*GetScheduleForGroupResponse) Descriptor() ([]byte, []int) {
+	return file_schedule_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *GetScheduleForGroupResponse) GetSuccess() bool {
+	if x != nil {
+		return x.Success
+	}
+	return false
+}
+
+func (x *GetScheduleForGroupResponse) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+func (x *GetScheduleForGroupResponse) GetSchedule() []*ScheduleEntry {
+	if x != nil {
+		return x.Schedule
+	}
+	return nil
+}
+
+// Запись в расписании
+type ScheduleEntry struct {
+	state       protoimpl.MessageState `protogen:"open.v1"`
+	Id          string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	GroupName   string                 `protobuf:"bytes,2,opt,name=group_name,json=groupName,proto3" json:"group_name,omitempty"`
+	Date        *timestamppb.Timestamp `protobuf:"bytes,3,opt,name=date,proto3" json:"date,omitempty"`
+	TimeStart   string                 `protobuf:"bytes,4,opt,name=time_start,json=timeStart,proto3" json:"time_start,omitempty"`
+	TimeEnd     string                 `protobuf:"bytes,5,opt,name=time_end,json=timeEnd,proto3" json:"time_end,omitempty"`
+	Subject     string                 `protobuf:"bytes,6,opt,name=subject,proto3" json:"subject,omitempty"`
+	Teacher     string                 `protobuf:"bytes,7,opt,name=teacher,proto3" json:"teacher,omitempty"`
+	Classroom   string                 `protobuf:"bytes,8,opt,name=classroom,proto3" json:"classroom,omitempty"`
+	SourceType  ScheduleSourceType     `protobuf:"varint,9,opt,name=source_type,json=sourceType,proto3,enum=schedule.ScheduleSourceType" json:"source_type,omitempty"`
+	SourceId    string                 `protobuf:"bytes,10,opt,name=source_id,json=sourceId,proto3" json:"source_id,omitempty"`
+	SubjectMeta *SubjectMetadata       `protobuf:"bytes,11,opt,name=subject_meta,json=subjectMeta,proto3" json:"subject_meta,omitempty"` // Не задано, если для предмета нет настроек
+	MeetingUrl  string                 `protobuf:"bytes,12,opt,name=meeting_url,json=meetingUrl,proto3" json:"meeting_url,omitempty"`    // Ссылка на онлайн-занятие (пусто - занятие очное)
+	Subgroup    int32                  `protobuf:"varint,13,opt,name=subgroup,proto3" json:"subgroup,omitempty"`                         // Подгруппа (0 - занятие всей группы)
+	Note        string                 `protobuf:"bytes,14,opt,name=note,proto3" json:"note,omitempty"`                                  // Личная заметка пользователя к паре (пусто - заметки нет)
+	Building    string                 `protobuf:"bytes,15,opt,name=building,proto3" json:"building,omitempty"`                          // Код корпуса аудитории (пусто - корпус не указан)
+	// Предыдущая пара этого дня в другом корпусе, и перерыва не хватает на переход
+	TravelWarning bool   `protobuf:"varint,16,opt,name=travel_warning,json=travelWarning,proto3" json:"travel_warning,omitempty"`
+	LessonType    string `protobuf:"bytes,17,opt,name=lesson_type,json=lessonType,proto3" json:"lesson_type,omitempty"` // Вид занятия: лекция, практика, лабораторная (пусто - не указан)
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ScheduleEntry) Reset() {
+	*x = ScheduleEntry{}
+	mi := &file_schedule_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ScheduleEntry) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ScheduleEntry) ProtoMessage() {}
+
+func (x *ScheduleEntry) ProtoReflect() protoreflect.Message {
+	mi := &file_schedule_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ScheduleEntry.ProtoReflect.Descriptor instead.
+func (*ScheduleEntry) Descriptor() ([]byte, []int) {
+	return file_schedule_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *ScheduleEntry) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *ScheduleEntry) GetGroupName() string {
+	if x != nil {
+		return x.GroupName
+	}
+	return ""
+}
+
+func (x *ScheduleEntry) GetDate() *timestamppb.Timestamp {
+	if x != nil {
+		return x.Date
+	}
+	return nil
+}
+
+func (x *ScheduleEntry) GetTimeStart() string {
+	if x != nil {
+		return x.TimeStart
+	}
+	return ""
+}
+
+func (x *ScheduleEntry) GetTimeEnd() string {
+	if x != nil {
+		return x.TimeEnd
+	}
+	return ""
+}
+
+func (x *ScheduleEntry) GetSubject() string {
+	if x != nil {
+		return x.Subject
+	}
+	return ""
+}
+
+func (x *ScheduleEntry) GetTeacher() string {
+	if x != nil {
+		return x.Teacher
+	}
+	return ""
+}
+
+func (x *ScheduleEntry) GetClassroom() string {
+	if x != nil {
+		return x.Classroom
+	}
+	return ""
+}
+
+func (x *ScheduleEntry) GetSourceType() ScheduleSourceType {
+	if x != nil {
+		return x.SourceType
+	}
+	return ScheduleSourceType_SCHEDULE_SOURCE_TYPE_UNSPECIFIED
+}
+
+func (x *ScheduleEntry) GetSourceId() string {
+	if x != nil {
+		return x.SourceId
+	}
+	return ""
+}
+
+func (x *ScheduleEntry) GetSubjectMeta() *SubjectMetadata {
+	if x != nil {
+		return x.SubjectMeta
+	}
+	return nil
+}
+
+func (x *ScheduleEntry) GetMeetingUrl() string {
+	if x != nil {
+		return x.MeetingUrl
+	}
+	return ""
+}
+
+func (x *ScheduleEntry) GetSubgroup() int32 {
+	if x != nil {
+		return x.Subgroup
+	}
+	return 0
+}
+
+func (x *ScheduleEntry) GetNote() string {
+	if x != nil {
+		return x.Note
+	}
+	return ""
+}
+
+func (x *ScheduleEntry) GetBuilding() string {
+	if x != nil {
+		return x.Building
+	}
+	return ""
+}
+
+func (x *ScheduleEntry) GetTravelWarning() bool {
+	if x != nil {
+		return x.TravelWarning
+	}
+	return false
+}
+
+func (x *ScheduleEntry) GetLessonType() string {
+	if x != nil {
+		return x.LessonType
+	}
+	return ""
+}
+
+// Запрос на получение активного снапшота расписания
+type GetActiveScheduleSnapshotRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Token         string                 `protobuf:"bytes,1,opt,name=token,proto3" json:"token,omitempty"` // JWT токен для аутентификации
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetActiveScheduleSnapshotRequest) Reset() {
+	*x = GetActiveScheduleSnapshotRequest{}
+	mi := &file_schedule_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetActiveScheduleSnapshotRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetActiveScheduleSnapshotRequest) ProtoMessage() {}
+
+func (x *GetActiveScheduleSnapshotRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_schedule_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetActiveScheduleSnapshotRequest.ProtoReflect.Descriptor instead.
+func (*GetActiveScheduleSnapshotRequest) Descriptor() ([]byte, []int) {
+	return file_schedule_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *GetActiveScheduleSnapshotRequest) GetToken() string {
+	if x != nil {
+		return x.Token
+	}
+	return ""
+}
+
+// Ответ с активным снапшотом расписания
+type GetActiveScheduleSnapshotResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Success       bool                   `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
+	Message       string                 `protobuf:"bytes,2,opt,name=message,proto3" json:"message,omitempty"`
+	Snapshot      *ScheduleSnapshot      `protobuf:"bytes,3,opt,name=snapshot,proto3" json:"snapshot,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetActiveScheduleSnapshotResponse) Reset() {
+	*x = GetActiveScheduleSnapshotResponse{}
+	mi := &file_schedule_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetActiveScheduleSnapshotResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetActiveScheduleSnapshotResponse) ProtoMessage() {}
+
+func (x *GetActiveScheduleSnapshotResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_schedule_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetActiveScheduleSnapshotResponse.ProtoReflect.Descriptor instead.
+func (*GetActiveScheduleSnapshotResponse) Descriptor() ([]byte, []int) {
+	return file_schedule_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *GetActiveScheduleSnapshotResponse) GetSuccess() bool {
+	if x != nil {
+		return x.Success
+	}
+	return false
+}
+
+func (x *GetActiveScheduleSnapshotResponse) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+func (x *GetActiveScheduleSnapshotResponse) GetSnapshot() *ScheduleSnapshot {
+	if x != nil {
+		return x.Snapshot
+	}
+	return nil
+}
+
+// Снапшот расписания
+type ScheduleSnapshot struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Name          string                 `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	PeriodStart   *timestamppb.Timestamp `protobuf:"bytes,3,opt,name=period_start,json=periodStart,proto3" json:"period_start,omitempty"`
+	PeriodEnd     *timestamppb.Timestamp `protobuf:"bytes,4,opt,name=period_end,json=periodEnd,proto3" json:"period_end,omitempty"`
+	Data          string                 `protobuf:"bytes,5,opt,name=data,proto3" json:"data,omitempty"` // Не заполняется: данные снапшота получаются через GetSnapshotData
+	CreatedAt     *timestamppb.Timestamp `protobuf:"bytes,6,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	SourceUrl     string                 `protobuf:"bytes,7,opt,name=source_url,json=sourceUrl,proto3" json:"source_url,omitempty"`
+	IsActive      bool                   `protobuf:"varint,8,opt,name=is_active,json=isActive,proto3" json:"is_active,omitempty"`
+	IsArchived    bool                   `protobuf:"varint,9,opt,name=is_archived,json=isArchived,proto3" json:"is_archived,omitempty"` // Данные снапшота перенесены в архив
+	ArchivedAt    *timestamppb.Timestamp `protobuf:"bytes,10,opt,name=archived_at,json=archivedAt,proto3" json:"archived_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ScheduleSnapshot) Reset() {
+	*x = ScheduleSnapshot{}
+	mi := &file_schedule_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ScheduleSnapshot) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ScheduleSnapshot) ProtoMessage() {}
+
+func (x *ScheduleSnapshot) ProtoReflect() protoreflect.Message {
+	mi := &file_schedule_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ScheduleSnapshot.ProtoReflect.Descriptor instead.
+func (*ScheduleSnapshot) Descriptor() ([]byte, []int) {
+	return file_schedule_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *ScheduleSnapshot) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *ScheduleSnapshot) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *ScheduleSnapshot) GetPeriodStart() *timestamppb.Timestamp {
+	if x != nil {
+		return x.PeriodStart
+	}
+	return nil
+}
+
+func (x *ScheduleSnapshot) GetPeriodEnd() *timestamppb.Timestamp {
+	if x != nil {
+		return x.PeriodEnd
+	}
+	return nil
+}
+
+func (x *ScheduleSnapshot) GetData() string {
+	if x != nil {
+		return x.Data
+	}
+	return ""
+}
+
+func (x *ScheduleSnapshot) GetCreatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return nil
+}
+
+func (x *ScheduleSnapshot) GetSourceUrl() string {
+	if x != nil {
+		return x.SourceUrl
+	}
+	return ""
+}
+
+func (x *ScheduleSnapshot) GetIsActive() bool {
+	if x != nil {
+		return x.IsActive
+	}
+	return false
+}
+
+func (x *ScheduleSnapshot) GetIsArchived() bool {
+	if x != nil {
+		return x.IsArchived
+	}
+	return false
+}
+
+func (x *ScheduleSnapshot) GetArchivedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.ArchivedAt
+	}
+	return nil
+}
+
+// Запрос на получение истории снапшотов
+type GetScheduleSnapshotsHistoryRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Token         string                 `protobuf:"bytes,1,opt,name=token,proto3" json:"token,omitempty"`  // JWT токен для аутентификации
+	Limit         int32                  `protobuf:"varint,2,opt,name=limit,proto3" json:"limit,omitempty"` // Максимальное количество снапшотов (по умолчанию 20)
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetScheduleSnapshotsHistoryRequest) Reset() {
+	*x = GetScheduleSnapshotsHistoryRequest{}
+	mi := &file_schedule_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetScheduleSnapshotsHistoryRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetScheduleSnapshotsHistoryRequest) ProtoMessage() {}
+
+func (x *GetScheduleSnapshotsHistoryRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_schedule_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetScheduleSnapshotsHistoryRequest.ProtoReflect.Descriptor instead.
+func (*GetScheduleSnapshotsHistoryRequest) Descriptor() ([]byte, []int) {
+	return file_schedule_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *GetScheduleSnapshotsHistoryRequest) GetToken() string {
+	if x != nil {
+		return x.Token
+	}
+	return ""
+}
+
+func (x *GetScheduleSnapshotsHistoryRequest) GetLimit() int32 {
+	if x != nil {
+		return x.Limit
+	}
+	return 0
+}
+
+// Ответ с историей снапшотов
+type GetScheduleSnapshotsHistoryResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Success       bool                   `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
+	Message       string                 `protobuf:"bytes,2,opt,name=message,proto3" json:"message,omitempty"`
+	Snapshots     []*ScheduleSnapshot    `protobuf:"bytes,3,rep,name=snapshots,proto3" json:"snapshots,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetScheduleSnapshotsHistoryResponse) Reset() {
+	*x = GetScheduleSnapshotsHistoryResponse{}
+	mi := &file_schedule_proto_msgTypes[7]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetScheduleSnapshotsHistoryResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetScheduleSnapshotsHistoryResponse) ProtoMessage() {}
+
+func (x *GetScheduleSnapshotsHistoryResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_schedule_proto_msgTypes[7]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetScheduleSnapshotsHistoryResponse.ProtoReflect.Descriptor instead.
+func (*GetScheduleSnapshotsHistoryResponse) Descriptor() ([]byte, []int) {
+	return file_schedule_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *GetScheduleSnapshotsHistoryResponse) GetSuccess() bool {
+	if x != nil {
+		return x.Success
+	}
+	return false
+}
+
+func (x *GetScheduleSnapshotsHistoryResponse) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+func (x *GetScheduleSnapshotsHistoryResponse) GetSnapshots() []*ScheduleSnapshot {
+	if x != nil {
+		return x.Snapshots
+	}
+	return nil
+}
+
+// Запрос данных снапшота
+type GetSnapshotDataRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Token         string                 `protobuf:"bytes,1,opt,name=token,proto3" json:"token,omitempty"` // JWT токен для аутентификации
+	SnapshotId    string                 `protobuf:"bytes,2,opt,name=snapshot_id,json=snapshotId,proto3" json:"snapshot_id,omitempty"`
+	GroupName     string                 `protobuf:"bytes,3,opt,name=group_name,json=groupName,proto3" json:"group_name,omitempty"` // Если задано, возвращается только расписание группы (JSON массив дней)
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetSnapshotDataRequest) Reset() {
+	*x = GetSnapshotDataRequest{}
+	mi := &file_schedule_proto_msgTypes[8]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetSnapshotDataRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetSnapshotDataRequest) ProtoMessage() {}
+
+func (x *GetSnapshotDataRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_schedule_proto_msgTypes[8]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetSnapshotDataRequest.ProtoReflect.Descriptor instead.
+func (*GetSnapshotDataRequest) Descriptor() ([]byte, []int) {
+	return file_schedule_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *GetSnapshotDataRequest) GetToken() string {
+	if x != nil {
+		return x.Token
+	}
+	return ""
+}
+
+func (x *GetSnapshotDataRequest) GetSnapshotId() string {
+	if x != nil {
+		return x.SnapshotId
+	}
+	return ""
+}
+
+func (x *GetSnapshotDataRequest) GetGroupName() string {
+	if x != nil {
+		return x.GroupName
+	}
+	return ""
+}
+
+// Ответ с данными снапшота
+type GetSnapshotDataResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Success       bool                   `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
+	Message       string                 `protobuf:"bytes,2,opt,name=message,proto3" json:"message,omitempty"`
+	SnapshotId    string                 `protobuf:"bytes,3,opt,name=snapshot_id,json=snapshotId,proto3" json:"snapshot_id,omitempty"`
+	Data          string                 `protobuf:"bytes,4,opt,name=data,proto3" json:"data,omitempty"` // JSON данные в виде строки (для архивных снапшотов - из архива)
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetSnapshotDataResponse) Reset() {
+	*x = GetSnapshotDataResponse{}
+	mi := &file_schedule_proto_msgTypes[9]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetSnapshotDataResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetSnapshotDataResponse) ProtoMessage() {}
+
+func (x *GetSnapshotDataResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_schedule_proto_msgTypes[9]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetSnapshotDataResponse.ProtoReflect.Descriptor instead.
+func (*GetSnapshotDataResponse) Descriptor() ([]byte, []int) {
+	return file_schedule_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *GetSnapshotDataResponse) GetSuccess() bool {
+	if x != nil {
+		return x.Success
+	}
+	return false
+}
+
+func (x *GetSnapshotDataResponse) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+func (x *GetSnapshotDataResponse) GetSnapshotId() string {
+	if x != nil {
+		return x.SnapshotId
+	}
+	return ""
+}
+
+func (x *GetSnapshotDataResponse) GetData() string {
+	if x != nil {
+		return x.Data
+	}
+	return ""
+}
+
+// Запрос на получение расписания текущего пользователя
+type GetMyScheduleRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Token         string                 `protobuf:"bytes,1,opt,name=token,proto3" json:"token,omitempty"` // JWT токен для аутентификации
+	Date          *timestamppb.Timestamp `protobuf:"bytes,2,opt,name=date,proto3" json:"date,omitempty"`
+	Week          bool                   `protobuf:"varint,3,opt,name=week,proto3" json:"week,omitempty"`                              // true - вернуть всю неделю (пн-вс), в которую входит date
+	LessonType    string                 `protobuf:"bytes,4,opt,name=lesson_type,json=lessonType,proto3" json:"lesson_type,omitempty"` // Необязательный фильтр по виду занятия
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetMyScheduleRequest) Reset() {
+	*x = GetMyScheduleRequest{}
+	mi := &file_schedule_proto_msgTypes[10]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetMyScheduleRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetMyScheduleRequest) ProtoMessage() {}
+
+func (x *GetMyScheduleRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_schedule_proto_msgTypes[10]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetMyScheduleRequest.ProtoReflect.Descriptor instead.
+func (*GetMyScheduleRequest) Descriptor() ([]byte, []int) {
+	return file_schedule_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *GetMyScheduleRequest) GetToken() string {
+	if x != nil {
+		return x.Token
+	}
+	return ""
+}
+
+func (x *GetMyScheduleRequest) GetDate() *timestamppb.Timestamp {
+	if x != nil {
+		return x.Date
+	}
+	return nil
+}
+
+func (x *GetMyScheduleRequest) GetWeek() bool {
+	if x != nil {
+		return x.Week
+	}
+	return false
+}
+
+func (x *GetMyScheduleRequest) GetLessonType() string {
+	if x != nil {
+		return x.LessonType
+	}
+	return ""
+}
+
+// Ответ с расписанием текущего пользователя
+type GetMyScheduleResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Success       bool                   `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
+	Message       string                 `protobuf:"bytes,2,opt,name=message,proto3" json:"message,omitempty"`
+	Schedule      []*ScheduleEntry       `protobuf:"bytes,3,rep,name=schedule,proto3" json:"schedule,omitempty"`
+	GroupName     string                 `protobuf:"bytes,4,opt,name=group_name,json=groupName,proto3" json:"group_name,omitempty"` // Группа студента (пусто для преподавателя)
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetMyScheduleResponse) Reset() {
+	*x = GetMyScheduleResponse{}
+	mi := &file_schedule_proto_msgTypes[11]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetMyScheduleResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetMyScheduleResponse) ProtoMessage() {}
+
+func (x *GetMyScheduleResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_schedule_proto_msgTypes[11]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetMyScheduleResponse.ProtoReflect.Descriptor instead.
+func (*GetMyScheduleResponse) Descriptor() ([]byte, []int) {
+	return file_schedule_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *GetMyScheduleResponse) GetSuccess() bool {
+	if x != nil {
+		return x.Success
+	}
+	return false
+}
+
+func (x *GetMyScheduleResponse) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+func (x *GetMyScheduleResponse) GetSchedule() []*ScheduleEntry {
+	if x != nil {
+		return x.Schedule
+	}
+	return nil
+}
+
+func (x *GetMyScheduleResponse) GetGroupName() string {
+	if x != nil {
+		return x.GroupName
+	}
+	return ""
+}
+
+// Запрос на поиск свободных окон
+type FindFreeSlotsRequest struct {
+	state              protoimpl.MessageState `protogen:"open.v1"`
+	Token              string                 `protobuf:"bytes,1,opt,name=token,proto3" json:"token,omitempty"` // JWT токен для аутентификации
+	Date               *timestamppb.Timestamp `protobuf:"bytes,2,opt,name=date,proto3" json:"date,omitempty"`
+	GroupNames         []string               `protobuf:"bytes,3,rep,name=group_names,json=groupNames,proto3" json:"group_names,omitempty"`                            // Группы, которые должны быть свободны
+	Teacher            string                 `protobuf:"bytes,4,opt,name=teacher,proto3" json:"teacher,omitempty"`                                                    // Преподаватель, который должен быть свободен (необязательно)
+	MinDurationMinutes int32                  `protobuf:"varint,5,opt,name=min_duration_minutes,json=minDurationMinutes,proto3" json:"min_duration_minutes,omitempty"` // Минимальная длительность окна
+	unknownFields      protoimpl.UnknownFields
+	sizeCache          protoimpl.SizeCache
+}
+
+func (x *FindFreeSlotsRequest) Reset() {
+	*x = FindFreeSlotsRequest{}
+	mi := &file_schedule_proto_msgTypes[12]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *FindFreeSlotsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*FindFreeSlotsRequest) ProtoMessage() {}
+
+func (x *FindFreeSlotsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_schedule_proto_msgTypes[12]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use FindFreeSlotsRequest.ProtoReflect.Descriptor instead.
+func (*FindFreeSlotsRequest) Descriptor() ([]byte, []int) {
+	return file_schedule_proto_rawDescGZIP(), []int{12}
+}
+
+func (x *FindFreeSlotsRequest) GetToken() string {
+	if x != nil {
+		return x.Token
+	}
+	return ""
+}
+
+func (x *FindFreeSlotsRequest) GetDate() *timestamppb.Timestamp {
+	if x != nil {
+		return x.Date
+	}
+	return nil
+}
+
+func (x *FindFreeSlotsRequest) GetGroupNames() []string {
+	if x != nil {
+		return x.GroupNames
+	}
+	return nil
+}
+
+func (x *FindFreeSlotsRequest) GetTeacher() string {
+	if x != nil {
+		return x.Teacher
+	}
+	return ""
+}
+
+func (x *FindFreeSlotsRequest) GetMinDurationMinutes() int32 {
+	if x != nil {
+		return x.MinDurationMinutes
+	}
+	return 0
+}
+
+// Свободное окно в расписании
+type FreeSlot struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	TimeStart     string                 `protobuf:"bytes,1,opt,name=time_start,json=timeStart,proto3" json:"time_start,omitempty"`
+	TimeEnd       string                 `protobuf:"bytes,2,opt,name=time_end,json=timeEnd,proto3" json:"time_end,omitempty"`
+	LessonNumbers []int32                `protobuf:"varint,3,rep,packed,name=lesson_numbers,json=lessonNumbers,proto3" json:"lesson_numbers,omitempty"` // Номера пар, целиком попадающих в окно
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *FreeSlot) Reset() {
+	*x = FreeSlot{}
+	mi := &file_schedule_proto_msgTypes[13]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *FreeSlot) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*FreeSlot) ProtoMessage() {}
+
+func (x *FreeSlot) ProtoReflect() protoreflect.Message {
+	mi := &file_schedule_proto_msgTypes[13]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use FreeSlot.ProtoReflect.Descriptor instead.
+func (*FreeSlot) Descriptor() ([]byte, []int) {
+	return file_schedule_proto_rawDescGZIP(), []int{13}
+}
+
+func (x *FreeSlot) GetTimeStart() string {
+	if x != nil {
+		return x.TimeStart
+	}
+	return ""
+}
+
+func (x *FreeSlot) GetTimeEnd() string {
+	if x != nil {
+		return x.TimeEnd
+	}
+	return ""
+}
+
+func (x *FreeSlot) GetLessonNumbers() []int32 {
+	if x != nil {
+		return x.LessonNumbers
+	}
+	return nil
+}
+
+// Ответ со свободными окнами
+type FindFreeSlotsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Success       bool                   `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
+	Message       string                 `protobuf:"bytes,2,opt,name=message,proto3" json:"message,omitempty"`
+	Slots         []*FreeSlot            `protobuf:"bytes,3,rep,name=slots,proto3" json:"slots,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *FindFreeSlotsResponse) Reset() {
+	*x = FindFreeSlotsResponse{}
+	mi := &file_schedule_proto_msgTypes[14]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *FindFreeSlotsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*FindFreeSlotsResponse) ProtoMessage() {}
+
+func (x *FindFreeSlotsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_schedule_proto_msgTypes[14]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use FindFreeSlotsResponse.ProtoReflect.Descriptor instead.
+func (*FindFreeSlotsResponse) Descriptor() ([]byte, []int) {
+	return file_schedule_proto_rawDescGZIP(), []int{14}
+}
+
+func (x *FindFreeSlotsResponse) GetSuccess() bool {
+	if x != nil {
+		return x.Success
+	}
+	return false
+}
+
+func (x *FindFreeSlotsResponse) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+func (x *FindFreeSlotsResponse) GetSlots() []*FreeSlot {
+	if x != nil {
+		return x.Slots
+	}
+	return nil
+}
+
+// Запрос статистики нагрузки
+type GetWorkloadStatsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Token         string                 `protobuf:"bytes,1,opt,name=token,proto3" json:"token,omitempty"`                          // JWT токен для аутентификации
+	GroupName     string                 `protobuf:"bytes,2,opt,name=group_name,json=groupName,proto3" json:"group_name,omitempty"` // Группа (приоритетнее преподавателя)
+	Teacher       string                 `protobuf:"bytes,3,opt,name=teacher,proto3" json:"teacher,omitempty"`                      // Преподаватель
+	From          *timestamppb.Timestamp `protobuf:"bytes,4,opt,name=from,proto3" json:"from,omitempty"`
+	To            *timestamppb.Timestamp `protobuf:"bytes,5,opt,name=to,proto3" json:"to,omitempty"`
+	ByWeek        bool                   `protobuf:"varint,6,opt,name=by_week,json=byWeek,proto3" json:"by_week,omitempty"` // Разбивать по неделям, иначе - итог за период
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetWorkloadStatsRequest) Reset() {
+	*x = GetWorkloadStatsRequest{}
+	mi := &file_schedule_proto_msgTypes[15]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetWorkloadStatsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetWorkloadStatsRequest) ProtoMessage() {}
+
+func (x *GetWorkloadStatsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_schedule_proto_msgTypes[15]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetWorkloadStatsRequest.ProtoReflect.Descriptor instead.
+func (*GetWorkloadStatsRequest) Descriptor() ([]byte, []int) {
+	return file_schedule_proto_rawDescGZIP(), []int{15}
+}
+
+func (x *GetWorkloadStatsRequest) GetToken() string {
+	if x != nil {
+		return x.Token
+	}
+	return ""
+}
+
+func (x *GetWorkloadStatsRequest) GetGroupName() string {
+	if x != nil {
+		return x.GroupName
+	}
+	return ""
+}
+
+func (x *GetWorkloadStatsRequest) GetTeacher() string {
+	if x != nil {
+		return x.Teacher
+	}
+	return ""
+}
+
+func (x *GetWorkloadStatsRequest) GetFrom() *timestamppb.Timestamp {
+	if x != nil {
+		return x.From
+	}
+	return nil
+}
+
+func (x *GetWorkloadStatsRequest) GetTo() *timestamppb.Timestamp {
+	if x != nil {
+		return x.To
+	}
+	return nil
+}
+
+func (x *GetWorkloadStatsRequest) GetByWeek() bool {
+	if x != nil {
+		return x.ByWeek
+	}
+	return false
+}
+
+// Нагрузка по предмету
+type WorkloadStat struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	GroupName     string                 `protobuf:"bytes,1,opt,name=group_name,json=groupName,proto3" json:"group_name,omitempty"`
+	Teacher       string                 `protobuf:"bytes,2,opt,name=teacher,proto3" json:"teacher,omitempty"`
+	Subject       string                 `protobuf:"bytes,3,opt,name=subject,proto3" json:"subject,omitempty"`
+	WeekStart     *timestamppb.Timestamp `protobuf:"bytes,4,opt,name=week_start,json=weekStart,proto3" json:"week_start,omitempty"` // Не задано для итога за период
+	Lessons       int32                  `protobuf:"varint,5,opt,name=lessons,proto3" json:"lessons,omitempty"`
+	Minutes       int32                  `protobuf:"varint,6,opt,name=minutes,proto3" json:"minutes,omitempty"`
+	AcademicHours float64                `protobuf:"fixed64,7,opt,name=academic_hours,json=academicHours,proto3" json:"academic_hours,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *WorkloadStat) Reset() {
+	*x = WorkloadStat{}
+	mi := &file_schedule_proto_msgTypes[16]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *WorkloadStat) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*WorkloadStat) ProtoMessage() {}
+
+func (x *WorkloadStat) ProtoReflect() protoreflect.Message {
+	mi := &file_schedule_proto_msgTypes[16]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use WorkloadStat.ProtoReflect.Descriptor instead.
+func (*WorkloadStat) Descriptor() ([]byte, []int) {
+	return file_schedule_proto_rawDescGZIP(), []int{16}
+}
+
+func (x *WorkloadStat) GetGroupName() string {
+	if x != nil {
+		return x.GroupName
+	}
+	return ""
+}
+
+func (x *WorkloadStat) GetTeacher() string {
+	if x != nil {
+		return x.Teacher
+	}
+	return ""
+}
+
+func (x *WorkloadStat) GetSubject() string {
+	if x != nil {
+		return x.Subject
+	}
+	return ""
+}
+
+func (x *WorkloadStat) GetWeekStart() *timestamppb.Timestamp {
+	if x != nil {
+		return x.WeekStart
+	}
+	return nil
+}
+
+func (x *WorkloadStat) GetLessons() int32 {
+	if x != nil {
+		return x.Lessons
+	}
+	return 0
+}
+
+func (x *WorkloadStat) GetMinutes() int32 {
+	if x != nil {
+		return x.Minutes
+	}
+	return 0
+}
+
+func (x *WorkloadStat) GetAcademicHours() float64 {
+	if x != nil {
+		return x.AcademicHours
+	}
+	return 0
+}
+
+// Ответ со статистикой нагрузки
+type GetWorkloadStatsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Success       bool                   `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
+	Message       string                 `protobuf:"bytes,2,opt,name=message,proto3" json:"message,omitempty"`
+	Stats         []*WorkloadStat        `protobuf:"bytes,3,rep,name=stats,proto3" json:"stats,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetWorkloadStatsResponse) Reset() {
+	*x = GetWorkloadStatsResponse{}
+	mi := &file_schedule_proto_msgTypes[17]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetWorkloadStatsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetWorkloadStatsResponse) ProtoMessage() {}
+
+func (x *GetWorkloadStatsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_schedule_proto_msgTypes[17]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetWorkloadStatsResponse.ProtoReflect.Descriptor instead.
+func (*GetWorkloadStatsResponse) Descriptor() ([]byte, []int) {
+	return file_schedule_proto_rawDescGZIP(), []int{17}
+}
+
+func (x *GetWorkloadStatsResponse) GetSuccess() bool {
+	if x != nil {
+		return x.Success
+	}
+	return false
+}
+
+func (x *GetWorkloadStatsResponse) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+func (x *GetWorkloadStatsResponse) GetStats() []*WorkloadStat {
+	if x != nil {
+		return x.Stats
+	}
+	return nil
+}
+
+// Запрос статистики изменений расписания
+type GetChangeStatsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Token         string                 `protobuf:"bytes,1,opt,name=token,proto3" json:"token,omitempty"` // JWT токен для аутентификации
+	From          *timestamppb.Timestamp `protobuf:"bytes,2,opt,name=from,proto3" json:"from,omitempty"`
+	To            *timestamppb.Timestamp `protobuf:"bytes,3,opt,name=to,proto3" json:"to,omitempty"`
+	Top           int32                  `protobuf:"varint,4,opt,name=top,proto3" json:"top,omitempty"` // Количество строк в рейтингах (по умолчанию 10)
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetChangeStatsRequest) Reset() {
+	*x = GetChangeStatsRequest{}
+	mi := &file_schedule_proto_msgTypes[18]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetChangeStatsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetChangeStatsRequest) ProtoMessage() {}
+
+func (x *GetChangeStatsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_schedule_proto_msgTypes[18]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetChangeStatsRequest.ProtoReflect.Descriptor instead.
+func (*GetChangeStatsRequest) Descriptor() ([]byte, []int) {
+	return file_schedule_proto_rawDescGZIP(), []int{18}
+}
+
+func (x *GetChangeStatsRequest) GetToken() string {
+	if x != nil {
+		return x.Token
+	}
+	return ""
+}
+
+func (x *GetChangeStatsRequest) GetFrom() *timestamppb.Timestamp {
+	if x != nil {
+		return x.From
+	}
+	return nil
+}
+
+func (x *GetChangeStatsRequest) GetTo() *timestamppb.Timestamp {
+	if x != nil {
+		return x.To
+	}
+	return nil
+}
+
+func (x *GetChangeStatsRequest) GetTop() int32 {
+	if x != nil {
+		return x.Top
+	}
+	return 0
+}
+
+// Количество изменений группы за месяц
+type GroupMonthChanges struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	GroupName     string                 `protobuf:"bytes,1,opt,name=group_name,json=groupName,proto3" json:"group_name,omitempty"`
+	Month         *timestamppb.Timestamp `protobuf:"bytes,2,opt,name=month,proto3" json:"month,omitempty"` // Первый день месяца
+	Total         int32                  `protobuf:"varint,3,opt,name=total,proto3" json:"total,omitempty"`
+	Replacements  int32                  `protobuf:"varint,4,opt,name=replacements,proto3" json:"replacements,omitempty"`
+	Cancellations int32                  `protobuf:"varint,5,opt,name=cancellations,proto3" json:"cancellations,omitempty"`
+	Additions     int32                  `protobuf:"varint,6,opt,name=additions,proto3" json:"additions,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GroupMonthChanges) Reset() {
+	*x = GroupMonthChanges{}
+	mi := &file_schedule_proto_msgTypes[19]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GroupMonthChanges) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GroupMonthChanges) ProtoMessage() {}
+
+func (x *GroupMonthChanges) ProtoReflect() protoreflect.Message {
+	mi := &file_schedule_proto_msgTypes[19]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GroupMonthChanges.ProtoReflect.Descriptor instead.
+func (*GroupMonthChanges) Descriptor() ([]byte, []int) {
+	return file_schedule_proto_rawDescGZIP(), []int{19}
+}
+
+func (x *GroupMonthChanges) GetGroupName() string {
+	if x != nil {
+		return x.GroupName
+	}
+	return ""
+}
+
+func (x *GroupMonthChanges) GetMonth() *timestamppb.Timestamp {
+	if x != nil {
+		return x.Month
+	}
+	return nil
+}
+
+func (x *GroupMonthChanges) GetTotal() int32 {
+	if x != nil {
+		return x.Total
+	}
+	return 0
+}
+
+func (x *GroupMonthChanges) GetReplacements() int32 {
+	if x != nil {
+		return x.Replacements
+	}
+	return 0
+}
+
+func (x *GroupMonthChanges) GetCancellations() int32 {
+	if x != nil {
+		return x.Cancellations
+	}
+	return 0
+}
+
+func (x *GroupMonthChanges) GetAdditions() int32 {
+	if x != nil {
+		return x.Additions
+	}
+	return 0
+}
+
+// Количество отмен по предмету
+type SubjectCancellations struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Subject       string                 `protobuf:"bytes,1,opt,name=subject,proto3" json:"subject,omitempty"`
+	Cancellations int32                  `protobuf:"varint,2,opt,name=cancellations,proto3" json:"cancellations,omitempty"`
+	Groups        int32                  `protobuf:"varint,3,opt,name=groups,proto3" json:"groups,omitempty"` // Количество затронутых групп
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SubjectCancellations) Reset() {
+	*x = SubjectCancellations{}
+	mi := &file_schedule_proto_msgTypes[20]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SubjectCancellations) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SubjectCancellations) ProtoMessage() {}
+
+func (x *SubjectCancellations) ProtoReflect() protoreflect.Message {
+	mi := &file_schedule_proto_msgTypes[20]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SubjectCancellations.ProtoReflect.Descriptor instead.
+func (*SubjectCancellations) Descriptor() ([]byte, []int) {
+	return file_schedule_proto_rawDescGZIP(), []int{20}
+}
+
+func (x *SubjectCancellations) GetSubject() string {
+	if x != nil {
+		return x.Subject
+	}
+	return ""
+}
+
+func (x *SubjectCancellations) GetCancellations() int32 {
+	if x != nil {
+		return x.Cancellations
+	}
+	return 0
+}
+
+func (x *SubjectCancellations) GetGroups() int32 {
+	if x != nil {
+		return x.Groups
+	}
+	return 0
+}
+
+// Количество замен за день
+type DayReplacements struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Date          *timestamppb.Timestamp `protobuf:"bytes,1,opt,name=date,proto3" json:"date,omitempty"`
+	Replacements  int32                  `protobuf:"varint,2,opt,name=replacements,proto3" json:"replacements,omitempty"`
+	Groups        int32                  `protobuf:"varint,3,opt,name=groups,proto3" json:"groups,omitempty"` // Количество затронутых групп
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DayReplacements) Reset() {
+	*x = DayReplacements{}
+	mi := &file_schedule_proto_msgTypes[21]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DayReplacements) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DayReplacements) ProtoMessage() {}
+
+func (x *DayReplacements) ProtoReflect() protoreflect.Message {
+	mi := &file_schedule_proto_msgTypes[21]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DayReplacements.ProtoReflect.Descriptor instead.
+func (*DayReplacements) Descriptor() ([]byte, []int) {
+	return file_schedule_proto_rawDescGZIP(), []int{21}
+}
+
+func (x *DayReplacements) GetDate() *timestamppb.Timestamp {
+	if x != nil {
+		return x.Date
+	}
+	return nil
+}
+
+func (x *DayReplacements) GetReplacements() int32 {
+	if x != nil {
+		return x.Replacements
+	}
+	return 0
+}
+
+func (x *DayReplacements) GetGroups() int32 {
+	if x != nil {
+		return x.Groups
+	}
+	return 0
+}
+
+// Ответ со статистикой изменений
+type GetChangeStatsResponse struct {
+	state             protoimpl.MessageState  `protogen:"open.v1"`
+	Success           bool                    `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
+	Message           string                  `protobuf:"bytes,2,opt,name=message,proto3" json:"message,omitempty"`
+	ByGroupMonth      []*GroupMonthChanges    `protobuf:"bytes,3,rep,name=by_group_month,json=byGroupMonth,proto3" json:"by_group_month,omitempty"`
+	CancelledSubjects []*SubjectCancellations `protobuf:"bytes,4,rep,name=cancelled_subjects,json=cancelledSubjects,proto3" json:"cancelled_subjects,omitempty"` // По убыванию количества отмен
+	ReplacementDays   []*DayReplacements      `protobuf:"bytes,5,rep,name=replacement_days,json=replacementDays,proto3" json:"replacement_days,omitempty"`       // По убыванию количества замен
+	unknownFields     protoimpl.UnknownFields
+	sizeCache         protoimpl.SizeCache
+}
+
+func (x *GetChangeStatsResponse) Reset() {
+	*x = GetChangeStatsResponse{}
+	mi := &file_schedule_proto_msgTypes[22]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetChangeStatsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetChangeStatsResponse) ProtoMessage() {}
+
+func (x *GetChangeStatsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_schedule_proto_msgTypes[22]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetChangeStatsResponse.ProtoReflect.Descriptor instead.
+func (*GetChangeStatsResponse) Descriptor() ([]byte, []int) {
+	return file_schedule_proto_rawDescGZIP(), []int{22}
+}
+
+func (x *GetChangeStatsResponse) GetSuccess() bool {
+	if x != nil {
+		return x.Success
+	}
+	return false
+}
+
+func (x *GetChangeStatsResponse) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+func (x *GetChangeStatsResponse) GetByGroupMonth() []*GroupMonthChanges {
+	if x != nil {
+		return x.ByGroupMonth
+	}
+	return nil
+}
+
+func (x *GetChangeStatsResponse) GetCancelledSubjects() []*SubjectCancellations {
+	if x != nil {
+		return x.CancelledSubjects
+	}
+	return nil
+}
+
+func (x *GetChangeStatsResponse) GetReplacementDays() []*DayReplacements {
+	if x != nil {
+		return x.ReplacementDays
+	}
+	return nil
+}
+
+// Вариант имени преподавателя в расписании
+type TeacherNameClaim struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	TeacherId     string                 `protobuf:"bytes,2,opt,name=teacher_id,json=teacherId,proto3" json:"teacher_id,omitempty"`
+	TeacherName   string                 `protobuf:"bytes,3,opt,name=teacher_name,json=teacherName,proto3" json:"teacher_name,omitempty"` // ФИО из профиля
+	ScrapedName   string                 `protobuf:"bytes,4,opt,name=scraped_name,json=scrapedName,proto3" json:"scraped_name,omitempty"` // Имя в том виде, в котором оно встречается в расписании
+	Status        ChangeModerationStatus `protobuf:"varint,5,opt,name=status,proto3,enum=schedule.ChangeModerationStatus" json:"status,omitempty"`
+	CreatedAt     *timestamppb.Timestamp `protobuf:"bytes,6,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	ReviewedAt    *timestamppb.Timestamp `protobuf:"bytes,7,opt,name=reviewed_at,json=reviewedAt,proto3" json:"reviewed_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *TeacherNameClaim) Reset() {
+	*x = TeacherNameClaim{}
+	mi := &file_schedule_proto_msgTypes[23]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *TeacherNameClaim) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TeacherNameClaim) ProtoMessage() {}
+
+func (x *TeacherNameClaim) ProtoReflect() protoreflect.Message {
+	mi := &file_schedule_proto_msgTypes[23]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TeacherNameClaim.ProtoReflect.Descriptor instead.
+func (*TeacherNameClaim) Descriptor() ([]byte, []int) {
+	return file_schedule_proto_rawDescGZIP(), []int{23}
+}
+
+func (x *TeacherNameClaim) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *TeacherNameClaim) GetTeacherId() string {
+	if x != nil {
+		return x.TeacherId
+	}
+	return ""
+}
+
+func (x *TeacherNameClaim) GetTeacherName() string {
+	if x != nil {
+		return x.TeacherName
+	}
+	return ""
+}
+
+func (x *TeacherNameClaim) GetScrapedName() string {
+	if x != nil {
+		return x.ScrapedName
+	}
+	return ""
+}
+
+func (x *TeacherNameClaim) GetStatus() ChangeModerationStatus {
+	if x != nil {
+		return x.Status
+	}
+	return ChangeModerationStatus_CHANGE_MODERATION_STATUS_UNSPECIFIED
+}
+
+func (x *TeacherNameClaim) GetCreatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return nil
+}
+
+func (x *TeacherNameClaim) GetReviewedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.ReviewedAt
+	}
+	return nil
+}
+
+// Запрос на вариант имени
+type ClaimTeacherNameRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Token         string                 `protobuf:"bytes,1,opt,name=token,proto3" json:"token,omitempty"` // JWT токен для аутентификации
+	ScrapedName   string                 `protobuf:"bytes,2,opt,name=scraped_name,json=scrapedName,proto3" json:"scraped_name,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ClaimTeacherNameRequest) Reset() {
+	*x = ClaimTeacherNameRequest{}
+	mi := &file_schedule_proto_msgTypes[24]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ClaimTeacherNameRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ClaimTeacherNameRequest) ProtoMessage() {}
+
+func (x *ClaimTeacherNameRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_schedule_proto_msgTypes[24]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ClaimTeacherNameRequest.ProtoReflect.Descriptor instead.
+func (*ClaimTeacherNameRequest) Descriptor() ([]byte, []int) {
+	return file_schedule_proto_rawDescGZIP(), []int{24}
+}
+
+func (x *ClaimTeacherNameRequest) GetToken() string {
+	if x != nil {
+		return x.Token
+	}
+	return ""
+}
+
+func (x *ClaimTeacherNameRequest) GetScrapedName() string {
+	if x != nil {
+		return x.ScrapedName
+	}
+	return ""
+}
+
+// Ответ на вариант имени
+type ClaimTeacherNameResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Success       bool                   `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
+	Message       string                 `protobuf:"bytes,2,opt,name=message,proto3" json:"message,omitempty"`
+	Claim         *TeacherNameClaim      `protobuf:"bytes,3,opt,name=claim,proto3" json:"claim,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ClaimTeacherNameResponse) Reset() {
+	*x = ClaimTeacherNameResponse{}
+	mi := &file_schedule_proto_msgTypes[25]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ClaimTeacherNameResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ClaimTeacherNameResponse) ProtoMessage() {}
+
+func (x *ClaimTeacherNameResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_schedule_proto_msgTypes[25]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ClaimTeacherNameResponse.ProtoReflect.Descriptor instead.
+func (*ClaimTeacherNameResponse) Descriptor() ([]byte, []int) {
+	return file_schedule_proto_rawDescGZIP(), []int{25}
+}
+
+func (x *ClaimTeacherNameResponse) GetSuccess() bool {
+	if x != nil {
+		return x.Success
+	}
+	return false
+}
+
+func (x *ClaimTeacherNameResponse) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+func (x *ClaimTeacherNameResponse) GetClaim() *TeacherNameClaim {
+	if x != nil {
+		return x.Claim
+	}
+	return nil
+}
+
+// Запрос своих вариантов имени
+type ListMyTeacherNameClaimsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Token         string                 `protobuf:"bytes,1,opt,name=token,proto3" json:"token,omitempty"` // JWT токен для аутентификации
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListMyTeacherNameClaimsRequest) Reset() {
+	*x = ListMyTeacherNameClaimsRequest{}
+	mi := &file_schedule_proto_msgTypes[26]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListMyTeacherNameClaimsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListMyTeacherNameClaimsRequest) ProtoMessage() {}
+
+func (x *ListMyTeacherNameClaimsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_schedule_proto_msgTypes[26]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListMyTeacherNameClaimsRequest.ProtoReflect.Descriptor instead.
+func (*ListMyTeacherNameClaimsRequest) Descriptor() ([]byte, []int) {
+	return file_schedule_proto_rawDescGZIP(), []int{26}
+}
+
+func (x *ListMyTeacherNameClaimsRequest) GetToken() string {
+	if x != nil {
+		return x.Token
+	}
+	return ""
+}
+
+// Ответ со своими вариантами имени
+type ListMyTeacherNameClaimsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Success       bool                   `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
+	Message       string                 `protobuf:"bytes,2,opt,name=message,proto3" json:"message,omitempty"`
+	Claims        []*TeacherNameClaim    `protobuf:"bytes,3,rep,name=claims,proto3" json:"claims,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListMyTeacherNameClaimsResponse) Reset() {
+	*x = ListMyTeacherNameClaimsResponse{}
+	mi := &file_schedule_proto_msgTypes[27]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListMyTeacherNameClaimsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListMyTeacherNameClaimsResponse) ProtoMessage() {}
+
+func (x *ListMyTeacherNameClaimsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_schedule_proto_msgTypes[27]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListMyTeacherNameClaimsResponse.ProtoReflect.Descriptor instead.
+func (*ListMyTeacherNameClaimsResponse) Descriptor() ([]byte, []int) {
+	return file_schedule_proto_rawDescGZIP(), []int{27}
+}
+
+func (x *ListMyTeacherNameClaimsResponse) GetSuccess() bool {
+	if x != nil {
+		return x.Success
+	}
+	return false
+}
+
+func (x *ListMyTeacherNameClaimsResponse) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+func (x *ListMyTeacherNameClaimsResponse) GetClaims() []*TeacherNameClaim {
+	if x != nil {
+		return x.Claims
+	}
+	return nil
+}
+
+// Запрос вариантов имени на подтверждении
+type ListPendingTeacherNameClaimsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Token         string                 `protobuf:"bytes,1,opt,name=token,proto3" json:"token,omitempty"` // JWT токен для аутентификации
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListPendingTeacherNameClaimsRequest) Reset() {
+	*x = ListPendingTeacherNameClaimsRequest{}
+	mi := &file_schedule_proto_msgTypes[28]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListPendingTeacherNameClaimsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListPendingTeacherNameClaimsRequest) ProtoMessage() {}
+
+func (x *ListPendingTeacherNameClaimsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_schedule_proto_msgTypes[28]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListPendingTeacherNameClaimsRequest.ProtoReflect.Descriptor instead.
+func (*ListPendingTeacherNameClaimsRequest) Descriptor() ([]byte, []int) {
+	return file_schedule_proto_rawDescGZIP(), []int{28}
+}
+
+func (x *ListPendingTeacherNameClaimsRequest) GetToken() string {
+	if x != nil {
+		return x.Token
+	}
+	return ""
+}
+
+// Ответ с вариантами имени на подтверждении
+type ListPendingTeacherNameClaimsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Success       bool                   `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
+	Message       string                 `protobuf:"bytes,2,opt,name=message,proto3" json:"message,omitempty"`
+	Claims        []*TeacherNameClaim    `protobuf:"bytes,3,rep,name=claims,proto3" json:"claims,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListPendingTeacherNameClaimsResponse) Reset() {
+	*x = ListPendingTeacherNameClaimsResponse{}
+	mi := &file_schedule_proto_msgTypes[29]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListPendingTeacherNameClaimsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListPendingTeacherNameClaimsResponse) ProtoMessage() {}
+
+func (x *ListPendingTeacherNameClaimsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_schedule_proto_msgTypes[29]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListPendingTeacherNameClaimsResponse.ProtoReflect.Descriptor instead.
+func (*ListPendingTeacherNameClaimsResponse) Descriptor() ([]byte, []int) {
+	return file_schedule_proto_rawDescGZIP(), []int{29}
+}
+
+func (x *ListPendingTeacherNameClaimsResponse) GetSuccess() bool {
+	if x != nil {
+		return x.Success
+	}
+	return false
+}
+
+func (x *ListPendingTeacherNameClaimsResponse) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+func (x *ListPendingTeacherNameClaimsResponse) GetClaims() []*TeacherNameClaim {
+	if x != nil {
+		return x.Claims
+	}
+	return nil
+}
+
+// Запрос на подтверждение варианта имени
+type ReviewTeacherNameClaimRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Token         string                 `protobuf:"bytes,1,opt,name=token,proto3" json:"token,omitempty"` // JWT токен для аутентификации
+	ClaimId       string                 `protobuf:"bytes,2,opt,name=claim_id,json=claimId,proto3" json:"claim_id,omitempty"`
+	Decision      ReviewDecision         `protobuf:"varint,3,opt,name=decision,proto3,enum=schedule.ReviewDecision" json:"decision,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ReviewTeacherNameClaimRequest) Reset() {
+	*x = ReviewTeacherNameClaimRequest{}
+	mi := &file_schedule_proto_msgTypes[30]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ReviewTeacherNameClaimRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ReviewTeacherNameClaimRequest) ProtoMessage() {}
+
+func (x *ReviewTeacherNameClaimRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_schedule_proto_msgTypes[30]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ReviewTeacherNameClaimRequest.ProtoReflect.Descriptor instead.
+func (*ReviewTeacherNameClaimRequest) Descriptor() ([]byte, []int) {
+	return file_schedule_proto_rawDescGZIP(), []int{30}
+}
+
+func (x *ReviewTeacherNameClaimRequest) GetToken() string {
+	if x != nil {
+		return x.Token
+	}
+	return ""
+}
+
+func (x *ReviewTeacherNameClaimRequest) GetClaimId() string {
+	if x != nil {
+		return x.ClaimId
+	}
+	return ""
+}
+
+func (x *ReviewTeacherNameClaimRequest) GetDecision() ReviewDecision {
+	if x != nil {
+		return x.Decision
+	}
+	return ReviewDecision_REVIEW_DECISION_UNSPECIFIED
+}
+
+// Ответ на подтверждение варианта имени
+type ReviewTeacherNameClaimResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Success       bool                   `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
+	Message       string                 `protobuf:"bytes,2,opt,name=message,proto3" json:"message,omitempty"`
+	Claim         *TeacherNameClaim      `protobuf:"bytes,3,opt,name=claim,proto3" json:"claim,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ReviewTeacherNameClaimResponse) Reset() {
+	*x = ReviewTeacherNameClaimResponse{}
+	mi := &file_schedule_proto_msgTypes[31]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ReviewTeacherNameClaimResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ReviewTeacherNameClaimResponse) ProtoMessage() {}
+
+func (x *ReviewTeacherNameClaimResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_schedule_proto_msgTypes[31]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ReviewTeacherNameClaimResponse.ProtoReflect.Descriptor instead.
+func (*ReviewTeacherNameClaimResponse) Descriptor() ([]byte, []int) {
+	return file_schedule_proto_rawDescGZIP(), []int{31}
+}
+
+func (x *ReviewTeacherNameClaimResponse) GetSuccess() bool {
+	if x != nil {
+		return x.Success
+	}
+	return false
+}
+
+func (x *ReviewTeacherNameClaimResponse) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+func (x *ReviewTeacherNameClaimResponse) GetClaim() *TeacherNameClaim {
+	if x != nil {
+		return x.Claim
+	}
+	return nil
+}
+
+// Запрос на запуск задач обслуживания
+type RunMaintenanceRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Token         string                 `protobuf:"bytes,1,opt,name=token,proto3" json:"token,omitempty"` // JWT токен для аутентификации
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RunMaintenanceRequest) Reset() {
+	*x = RunMaintenanceRequest{}
+	mi := &file_schedule_proto_msgTypes[32]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RunMaintenanceRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RunMaintenanceRequest) ProtoMessage() {}
+
+func (x *RunMaintenanceRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_schedule_proto_msgTypes[32]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RunMaintenanceRequest.ProtoReflect.Descriptor instead.
+func (*RunMaintenanceRequest) Descriptor() ([]byte, []int) {
+	return file_schedule_proto_rawDescGZIP(), []int{32}
+}
+
+func (x *RunMaintenanceRequest) GetToken() string {
+	if x != nil {
+		return x.Token
+	}
+	return ""
+}
+
+// Ответ на запуск задач обслуживания
+type RunMaintenanceResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Success       bool                   `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
+	Message       string                 `protobuf:"bytes,2,opt,name=message,proto3" json:"message,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RunMaintenanceResponse) Reset() {
+	*x = RunMaintenanceResponse{}
+	mi := &file_schedule_proto_msgTypes[33]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RunMaintenanceResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RunMaintenanceResponse) ProtoMessage() {}
+
+func (x *RunMaintenanceResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_schedule_proto_msgTypes[33]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RunMaintenanceResponse.ProtoReflect.Descriptor instead.
+func (*RunMaintenanceResponse) Descriptor() ([]byte, []int) {
+	return file_schedule_proto_rawDescGZIP(), []int{33}
+}
+
+func (x *RunMaintenanceResponse) GetSuccess() bool {
+	if x != nil {
+		return x.Success
+	}
+	return false
+}
+
+func (x *RunMaintenanceResponse) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+// Запрос поиска по расписанию
+type SearchScheduleRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Token         string                 `protobuf:"bytes,1,opt,name=token,proto3" json:"token,omitempty"`  // JWT токен для аутентификации
+	Query         string                 `protobuf:"bytes,2,opt,name=query,proto3" json:"query,omitempty"`  // Поисковый запрос ("матан", "Иванов", "305")
+	From          *timestamppb.Timestamp `protobuf:"bytes,3,opt,name=from,proto3" json:"from,omitempty"`    // Начало периода (по умолчанию сегодня)
+	To            *timestamppb.Timestamp `protobuf:"bytes,4,opt,name=to,proto3" json:"to,omitempty"`        // Конец периода (по умолчанию +30 дней)
+	Limit         int32                  `protobuf:"varint,5,opt,name=limit,proto3" json:"limit,omitempty"` // Максимальное количество результатов (по умолчанию 50)
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SearchScheduleRequest) Reset() {
+	*x = SearchScheduleRequest{}
+	mi := &file_schedule_proto_msgTypes[34]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SearchScheduleRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SearchScheduleRequest) ProtoMessage() {}
+
+func (x *SearchScheduleRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_schedule_proto_msgTypes[34]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SearchScheduleRequest.ProtoReflect.Descriptor instead.
+func (*SearchScheduleRequest) Descriptor() ([]byte, []int) {
+	return file_schedule_proto_rawDescGZIP(), []int{34}
+}
+
+func (x *SearchScheduleRequest) GetToken() string {
+	if x != nil {
+		return x.Token
+	}
+	return ""
+}
+
+func (x *SearchScheduleRequest) GetQuery() string {
+	if x != nil {
+		return x.Query
+	}
+	return ""
+}
+
+func (x *SearchScheduleRequest) GetFrom() *timestamppb.Timestamp {
+	if x != nil {
+		return x.From
+	}
+	return nil
+}
+
+func (x *SearchScheduleRequest) GetTo() *timestamppb.Timestamp {
+	if x != nil {
+		return x.To
+	}
+	return nil
+}
+
+func (x *SearchScheduleRequest) GetLimit() int32 {
+	if x != nil {
+		return x.Limit
+	}
+	return 0
+}
+
+// Найденная запись расписания
+type SearchResult struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Entry         *ScheduleEntry         `protobuf:"bytes,1,opt,name=entry,proto3" json:"entry,omitempty"`
+	Rank          float64                `protobuf:"fixed64,2,opt,name=rank,proto3" json:"rank,omitempty"` // Релевантность (чем больше, тем лучше)
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SearchResult) Reset() {
+	*x = SearchResult{}
+	mi := &file_schedule_proto_msgTypes[35]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SearchResult) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SearchResult) ProtoMessage() {}
+
+func (x *SearchResult) ProtoReflect() protoreflect.Message {
+	mi := &file_schedule_proto_msgTypes[35]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SearchResult.ProtoReflect.Descriptor instead.
+func (*SearchResult) Descriptor() ([]byte, []int) {
+	return file_schedule_proto_rawDescGZIP(), []int{35}
+}
+
+func (x *SearchResult) GetEntry() *ScheduleEntry {
+	if x != nil {
+		return x.Entry
+	}
+	return nil
+}
+
+func (x *SearchResult) GetRank() float64 {
+	if x != nil {
+		return x.Rank
+	}
+	return 0
+}
+
+// Ответ с результатами поиска, отсортированными по релевантности
+type SearchScheduleResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Success       bool                   `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
+	Message       string                 `protobuf:"bytes,2,opt,name=message,proto3" json:"message,omitempty"`
+	Results       []*SearchResult        `protobuf:"bytes,3,rep,name=results,proto3" json:"results,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SearchScheduleResponse) Reset() {
+	*x = SearchScheduleResponse{}
+	mi := &file_schedule_proto_msgTypes[36]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SearchScheduleResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SearchScheduleResponse) ProtoMessage() {}
+
+func (x *SearchScheduleResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_schedule_proto_msgTypes[36]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SearchScheduleResponse.ProtoReflect.Descriptor instead.
+func (*SearchScheduleResponse) Descriptor() ([]byte, []int) {
+	return file_schedule_proto_rawDescGZIP(), []int{36}
+}
+
+func (x *SearchScheduleResponse) GetSuccess() bool {
+	if x != nil {
+		return x.Success
+	}
+	return false
+}
+
+func (x *SearchScheduleResponse) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+func (x *SearchScheduleResponse) GetResults() []*SearchResult {
+	if x != nil {
+		return x.Results
+	}
+	return nil
+}
+
+// Запрос на сравнение двух снапшотов
+type CompareSnapshotsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Token         string                 `protobuf:"bytes,1,opt,name=token,proto3" json:"token,omitempty"`                                  // JWT токен для аутентификации
+	SnapshotIdA   string                 `protobuf:"bytes,2,opt,name=snapshot_id_a,json=snapshotIdA,proto3" json:"snapshot_id_a,omitempty"` // Старый снапшот
+	SnapshotIdB   string                 `protobuf:"bytes,3,opt,name=snapshot_id_b,json=snapshotIdB,proto3" json:"snapshot_id_b,omitempty"` // Новый снапшот
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CompareSnapshotsRequest) Reset() {
+	*x = CompareSnapshotsRequest{}
+	mi := &file_schedule_proto_msgTypes[37]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CompareSnapshotsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CompareSnapshotsRequest) ProtoMessage() {}
+
+func (x *CompareSnapshotsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_schedule_proto_msgTypes[37]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CompareSnapshotsRequest.ProtoReflect.Descriptor instead.
+func (*CompareSnapshotsRequest) Descriptor() ([]byte, []int) {
+	return file_schedule_proto_rawDescGZIP(), []int{37}
+}
+
+func (x *CompareSnapshotsRequest) GetToken() string {
+	if x != nil {
+		return x.Token
+	}
+	return ""
+}
+
+func (x *CompareSnapshotsRequest) GetSnapshotIdA() string {
+	if x != nil {
+		return x.SnapshotIdA
+	}
+	return ""
+}
+
+func (x *CompareSnapshotsRequest) GetSnapshotIdB() string {
+	if x != nil {
+		return x.SnapshotIdB
+	}
+	return ""
+}
+
+// Пара из данных снапшота
+type SnapshotLesson struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	DayOfWeek     string                 `protobuf:"bytes,1,opt,name=day_of_week,json=dayOfWeek,proto3" json:"day_of_week,omitempty"`
+	TimeStart     string                 `protobuf:"bytes,2,opt,name=time_start,json=timeStart,proto3" json:"time_start,omitempty"`
+	TimeEnd       string                 `protobuf:"bytes,3,opt,name=time_end,json=timeEnd,proto3" json:"time_end,omitempty"`
+	Subject       string                 `protobuf:"bytes,4,opt,name=subject,proto3" json:"subject,omitempty"`
+	Teacher       string                 `protobuf:"bytes,5,opt,name=teacher,proto3" json:"teacher,omitempty"`
+	Classroom     string                 `protobuf:"bytes,6,opt,name=classroom,proto3" json:"classroom,omitempty"`
+	Subgroup      int32                  `protobuf:"varint,7,opt,name=subgroup,proto3" json:"subgroup,omitempty"`                      // Подгруппа (0 - занятие всей группы)
+	LessonType    string                 `protobuf:"bytes,8,opt,name=lesson_type,json=lessonType,proto3" json:"lesson_type,omitempty"` // Вид занятия (пусто - не указан)
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SnapshotLesson) Reset() {
+	*x = SnapshotLesson{}
+	mi := &file_schedule_proto_msgTypes[38]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SnapshotLesson) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SnapshotLesson) ProtoMessage() {}
+
+func (x *SnapshotLesson) ProtoReflect() protoreflect.Message {
+	mi := &file_schedule_proto_msgTypes[38]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SnapshotLesson.ProtoReflect.Descriptor instead.
+func (*SnapshotLesson) Descriptor() ([]byte, []int) {
+	return file_schedule_proto_rawDescGZIP(), []int{38}
+}
+
+func (x *SnapshotLesson) GetDayOfWeek() string {
+	if x != nil {
+		return x.DayOfWeek
+	}
+	return ""
+}
+
+func (x *SnapshotLesson) GetTimeStart() string {
+	if x != nil {
+		return x.TimeStart
+	}
+	return ""
+}
+
+func (x *SnapshotLesson) GetTimeEnd() string {
+	if x != nil {
+		return x.TimeEnd
+	}
+	return ""
+}
+
+func (x *SnapshotLesson) GetSubject() string {
+	if x != nil {
+		return x.Subject
+	}
+	return ""
+}
+
+func (x *SnapshotLesson) GetTeacher() string {
+	if x != nil {
+		return x.Teacher
+	}
+	return ""
+}
+
+func (x *SnapshotLesson) GetClassroom() string {
+	if x != nil {
+		return x.Classroom
+	}
+	return ""
+}
+
+func (x *SnapshotLesson) GetSubgroup() int32 {
+	if x != nil {
+		return x.Subgroup
+	}
+	return 0
+}
+
+func (x *SnapshotLesson) GetLessonType() string {
+	if x != nil {
+		return x.LessonType
+	}
+	return ""
+}
+
+// Пара, изменившаяся между снапшотами
+type LessonChange struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Before        *SnapshotLesson        `protobuf:"bytes,1,opt,name=before,proto3" json:"before,omitempty"`
+	After         *SnapshotLesson        `protobuf:"bytes,2,opt,name=after,proto3" json:"after,omitempty"`
+	ChangedFields []string               `protobuf:"bytes,3,rep,name=changed_fields,json=changedFields,proto3" json:"changed_fields,omitempty"` // subject, teacher, classroom, time_end
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *LessonChange) Reset() {
+	*x = LessonChange{}
+	mi := &file_schedule_proto_msgTypes[39]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *LessonChange) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*LessonChange) ProtoMessage() {}
+
+func (x *LessonChange) ProtoReflect() protoreflect.Message {
+	mi := &file_schedule_proto_msgTypes[39]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use LessonChange.ProtoReflect.Descriptor instead.
+func (*LessonChange) Descriptor() ([]byte, []int) {
+	return file_schedule_proto_rawDescGZIP(), []int{39}
+}
+
+func (x *LessonChange) GetBefore() *SnapshotLesson {
+	if x != nil {
+		return x.Before
+	}
+	return nil
+}
+
+func (x *LessonChange) GetAfter() *SnapshotLesson {
+	if x != nil {
+		return x.After
+	}
+	return nil
+}
+
+func (x *LessonChange) GetChangedFields() []string {
+	if x != nil {
+		return x.ChangedFields
+	}
+	return nil
+}
+
+// Отличия расписания группы
+type GroupDiff struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	GroupName     string                 `protobuf:"bytes,1,opt,name=group_name,json=groupName,proto3" json:"group_name,omitempty"`
+	Status        GroupDiffStatus        `protobuf:"varint,2,opt,name=status,proto3,enum=schedule.GroupDiffStatus" json:"status,omitempty"`
+	Added         []*SnapshotLesson      `protobuf:"bytes,3,rep,name=added,proto3" json:"added,omitempty"`
+	Removed       []*SnapshotLesson      `protobuf:"bytes,4,rep,name=removed,proto3" json:"removed,omitempty"`
+	Changed       []*LessonChange        `protobuf:"bytes,5,rep,name=changed,proto3" json:"changed,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GroupDiff) Reset() {
+	*x = GroupDiff{}
+	mi := &file_schedule_proto_msgTypes[40]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GroupDiff) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GroupDiff) ProtoMessage() {}
+
+func (x *GroupDiff) ProtoReflect() protoreflect.Message {
+	mi := &file_schedule_proto_msgTypes[40]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GroupDiff.ProtoReflect.Descriptor instead.
+func (*GroupDiff) Descriptor() ([]byte, []int) {
+	return file_schedule_proto_rawDescGZIP(), []int{40}
+}
+
+func (x *GroupDiff) GetGroupName() string {
+	if x != nil {
+		return x.GroupName
+	}
+	return ""
+}
+
+func (x *GroupDiff) GetStatus() GroupDiffStatus {
+	if x != nil {
+		return x.Status
+	}
+	return GroupDiffStatus_GROUP_DIFF_STATUS_UNSPECIFIED
+}
+
+func (x *GroupDiff) GetAdded() []*SnapshotLesson {
+	if x != nil {
+		return x.Added
+	}
+	return nil
+}
+
+func (x *GroupDiff) GetRemoved() []*SnapshotLesson {
+	if x != nil {
+		return x.Removed
+	}
+	return nil
+}
+
+func (x *GroupDiff) GetChanged() []*LessonChange {
+	if x != nil {
+		return x.Changed
+	}
+	return nil
+}
+
+// Ответ со сравнением снапшотов
+type CompareSnapshotsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Success       bool                   `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
+	Message       string                 `protobuf:"bytes,2,opt,name=message,proto3" json:"message,omitempty"`
+	SnapshotA     *ScheduleSnapshot      `protobuf:"bytes,3,opt,name=snapshot_a,json=snapshotA,proto3" json:"snapshot_a,omitempty"` // Метаданные снапшотов (без данных)
+	SnapshotB     *ScheduleSnapshot      `protobuf:"bytes,4,opt,name=snapshot_b,json=snapshotB,proto3" json:"snapshot_b,omitempty"`
+	Groups        []*GroupDiff           `protobuf:"bytes,5,rep,name=groups,proto3" json:"groups,omitempty"` // Только группы с отличиями
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CompareSnapshotsResponse) Reset() {
+	*x = CompareSnapshotsResponse{}
+	mi := &file_schedule_proto_msgTypes[41]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CompareSnapshotsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CompareSnapshotsResponse) ProtoMessage() {}
+
+func (x *CompareSnapshotsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_schedule_proto_msgTypes[41]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CompareSnapshotsResponse.ProtoReflect.Descriptor instead.
+func (*CompareSnapshotsResponse) Descriptor() ([]byte, []int) {
+	return file_schedule_proto_rawDescGZIP(), []int{41}
+}
+
+func (x *CompareSnapshotsResponse) GetSuccess() bool {
+	if x != nil {
+		return x.Success
+	}
+	return false
+}
+
+func (x *CompareSnapshotsResponse) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+func (x *CompareSnapshotsResponse) GetSnapshotA() *ScheduleSnapshot {
+	if x != nil {
+		return x.SnapshotA
+	}
+	return nil
+}
+
+func (x *CompareSnapshotsResponse) GetSnapshotB() *ScheduleSnapshot {
+	if x != nil {
+		return x.SnapshotB
+	}
+	return nil
+}
+
+func (x *CompareSnapshotsResponse) GetGroups() []*GroupDiff {
+	if x != nil {
+		return x.Groups
+	}
+	return nil
+}
+
+// Параметры отображения предмета
+type SubjectMetadata struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Subject       string                 `protobuf:"bytes,1,opt,name=subject,proto3" json:"subject,omitempty"` // Название предмета, как в расписании
+	ShortName     string                 `protobuf:"bytes,2,opt,name=short_name,json=shortName,proto3" json:"short_name,omitempty"`
+	Color         string                 `protobuf:"bytes,3,opt,name=color,proto3" json:"color,omitempty"` // #RRGGBB
+	Icon          string                 `protobuf:"bytes,4,opt,name=icon,proto3" json:"icon,omitempty"`   // Подсказка для клиента (имя иконки)
+	UpdatedAt     *timestamppb.Timestamp `protobuf:"bytes,5,opt,name=updated_at,json=updatedAt,proto3" json:"updated_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SubjectMetadata) Reset() {
+	*x = SubjectMetadata{}
+	mi := &file_schedule_proto_msgTypes[42]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SubjectMetadata) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SubjectMetadata) ProtoMessage() {}
+
+func (x *SubjectMetadata) ProtoReflect() protoreflect.Message {
+	mi := &file_schedule_proto_msgTypes[42]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SubjectMetadata.ProtoReflect.Descriptor instead.
+func (*SubjectMetadata) Descriptor() ([]byte, []int) {
+	return file_schedule_proto_rawDescGZIP(), []int{42}
+}
+
+func (x *SubjectMetadata) GetSubject() string {
+	if x != nil {
+		return x.Subject
+	}
+	return ""
+}
+
+func (x *SubjectMetadata) GetShortName() string {
+	if x != nil {
+		return x.ShortName
+	}
+	return ""
+}
+
+func (x *SubjectMetadata) GetColor() string {
+	if x != nil {
+		return x.Color
+	}
+	return ""
+}
+
+func (x *SubjectMetadata) GetIcon() string {
+	if x != nil {
+		return x.Icon
+	}
+	return ""
+}
+
+func (x *SubjectMetadata) GetUpdatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.UpdatedAt
+	}
+	return nil
+}
+
+// Запрос параметров отображения предметов
+type ListSubjectMetadataRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Token         string                 `protobuf:"bytes,1,opt,name=token,proto3" json:"token,omitempty"` // JWT токен для аутентификации
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListSubjectMetadataRequest) Reset() {
+	*x = ListSubjectMetadataRequest{}
+	mi := &file_schedule_proto_msgTypes[43]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListSubjectMetadataRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListSubjectMetadataRequest) ProtoMessage() {}
+
+func (x *ListSubjectMetadataRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_schedule_proto_msgTypes[43]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListSubjectMetadataRequest.ProtoReflect.Descriptor instead.
+func (*ListSubjectMetadataRequest) Descriptor() ([]byte, []int) {
+	return file_schedule_proto_rawDescGZIP(), []int{43}
+}
+
+func (x *ListSubjectMetadataRequest) GetToken() string {
+	if x != nil {
+		return x.Token
+	}
+	return ""
+}
+
+// Ответ с параметрами отображения предметов
+type ListSubjectMetadataResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Success       bool                   `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
+	Message       string                 `protobuf:"bytes,2,opt,name=message,proto3" json:"message,omitempty"`
+	Subjects      []*SubjectMetadata     `protobuf:"bytes,3,rep,name=subjects,proto3" json:"subjects,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListSubjectMetadataResponse) Reset() {
+	*x = ListSubjectMetadataResponse{}
+	mi := &file_schedule_proto_msgTypes[44]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListSubjectMetadataResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListSubjectMetadataResponse) ProtoMessage() {}
+
+func (x *ListSubjectMetadataResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_schedule_proto_msgTypes[44]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListSubjectMetadataResponse.ProtoReflect.Descriptor instead.
+func (*ListSubjectMetadataResponse) Descriptor() ([]byte, []int) {
+	return file_schedule_proto_rawDescGZIP(), []int{44}
+}
+
+func (x *ListSubjectMetadataResponse) GetSuccess() bool {
+	if x != nil {
+		return x.Success
+	}
+	return false
+}
+
+func (x *ListSubjectMetadataResponse) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+func (x *ListSubjectMetadataResponse) GetSubjects() []*SubjectMetadata {
+	if x != nil {
+		return x.Subjects
+	}
+	return nil
+}
+
+// Запрос на сохранение параметров отображения предмета
+type UpsertSubjectMetadataRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Token         string                 `protobuf:"bytes,1,opt,name=token,proto3" json:"token,omitempty"` // JWT токен для аутентификации
+	Subject       *SubjectMetadata       `protobuf:"bytes,2,opt,name=subject,proto3" json:"subject,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UpsertSubjectMetadataRequest) Reset() {
+	*x = UpsertSubjectMetadataRequest{}
+	mi := &file_schedule_proto_msgTypes[45]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UpsertSubjectMetadataRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpsertSubjectMetadataRequest) ProtoMessage() {}
+
+func (x *UpsertSubjectMetadataRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_schedule_proto_msgTypes[45]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UpsertSubjectMetadataRequest.ProtoReflect.Descriptor instead.
+func (*UpsertSubjectMetadataRequest) Descriptor() ([]byte, []int) {
+	return file_schedule_proto_rawDescGZIP(), []int{45}
+}
+
+func (x *UpsertSubjectMetadataRequest) GetToken() string {
+	if x != nil {
+		return x.Token
+	}
+	return ""
+}
+
+func (x *UpsertSubjectMetadataRequest) GetSubject() *SubjectMetadata {
+	if x != nil {
+		return x.Subject
+	}
+	return nil
+}
+
+// Ответ на сохранение параметров отображения предмета
+type UpsertSubjectMetadataResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Success       bool                   `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
+	Message       string                 `protobuf:"bytes,2,opt,name=message,proto3" json:"message,omitempty"`
+	Subject       *SubjectMetadata       `protobuf:"bytes,3,opt,name=subject,proto3" json:"subject,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UpsertSubjectMetadataResponse) Reset() {
+	*x = UpsertSubjectMetadataResponse{}
+	mi := &file_schedule_proto_msgTypes[46]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UpsertSubjectMetadataResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpsertSubjectMetadataResponse) ProtoMessage() {}
+
+func (x *UpsertSubjectMetadataResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_schedule_proto_msgTypes[46]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UpsertSubjectMetadataResponse.ProtoReflect.Descriptor instead.
+func (*UpsertSubjectMetadataResponse) Descriptor() ([]byte, []int) {
+	return file_schedule_proto_rawDescGZIP(), []int{46}
+}
+
+func (x *UpsertSubjectMetadataResponse) GetSuccess() bool {
+	if x != nil {
+		return x.Success
+	}
+	return false
+}
+
+func (x *UpsertSubjectMetadataResponse) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+func (x *UpsertSubjectMetadataResponse) GetSubject() *SubjectMetadata {
+	if x != nil {
+		return x.Subject
+	}
+	return nil
+}
+
+// Запрос на удаление параметров отображения предмета
+type DeleteSubjectMetadataRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Token         string                 `protobuf:"bytes,1,opt,name=token,proto3" json:"token,omitempty"` // JWT токен для аутентификации
+	Subject       string                 `protobuf:"bytes,2,opt,name=subject,proto3" json:"subject,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeleteSubjectMetadataRequest) Reset() {
+	*x = DeleteSubjectMetadataRequest{}
+	mi := &file_schedule_proto_msgTypes[47]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteSubjectMetadataRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteSubjectMetadataRequest) ProtoMessage() {}
+
+func (x *DeleteSubjectMetadataRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_schedule_proto_msgTypes[47]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteSubjectMetadataRequest.ProtoReflect.Descriptor instead.
+func (*DeleteSubjectMetadataRequest) Descriptor() ([]byte, []int) {
+	return file_schedule_proto_rawDescGZIP(), []int{47}
+}
+
+func (x *DeleteSubjectMetadataRequest) GetToken() string {
+	if x != nil {
+		return x.Token
+	}
+	return ""
+}
+
+func (x *DeleteSubjectMetadataRequest) GetSubject() string {
+	if x != nil {
+		return x.Subject
+	}
+	return ""
+}
+
+// Ответ на удаление параметров отображения предмета
+type DeleteSubjectMetadataResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Success       bool                   `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
+	Message       string                 `protobuf:"bytes,2,opt,name=message,proto3" json:"message,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeleteSubjectMetadataResponse) Reset() {
+	*x = DeleteSubjectMetadataResponse{}
+	mi := &file_schedule_proto_msgTypes[48]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteSubjectMetadataResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteSubjectMetadataResponse) ProtoMessage() {}
+
+func (x *DeleteSubjectMetadataResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_schedule_proto_msgTypes[48]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteSubjectMetadataResponse.ProtoReflect.Descriptor instead.
+func (*DeleteSubjectMetadataResponse) Descriptor() ([]byte, []int) {
+	return file_schedule_proto_rawDescGZIP(), []int{48}
+}
+
+func (x *DeleteSubjectMetadataResponse) GetSuccess() bool {
+	if x != nil {
+		return x.Success
+	}
+	return false
+}
+
+func (x *DeleteSubjectMetadataResponse) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+// Изменение в расписании
+type ScheduleChange struct {
+	state             protoimpl.MessageState `protogen:"open.v1"`
+	Id                string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	SnapshotId        string                 `protobuf:"bytes,2,opt,name=snapshot_id,json=snapshotId,proto3" json:"snapshot_id,omitempty"`
+	GroupName         string                 `protobuf:"bytes,3,opt,name=group_name,json=groupName,proto3" json:"group_name,omitempty"`
+	Date              *timestamppb.Timestamp `protobuf:"bytes,4,opt,name=date,proto3" json:"date,omitempty"`
+	TimeStart         string                 `protobuf:"bytes,5,opt,name=time_start,json=timeStart,proto3" json:"time_start,omitempty"`
+	TimeEnd           string                 `protobuf:"bytes,6,opt,name=time_end,json=timeEnd,proto3" json:"time_end,omitempty"`
+	Subject           string                 `protobuf:"bytes,7,opt,name=subject,proto3" json:"subject,omitempty"`
+	Teacher           string                 `protobuf:"bytes,8,opt,name=teacher,proto3" json:"teacher,omitempty"`
+	Classroom         string                 `protobuf:"bytes,9,opt,name=classroom,proto3" json:"classroom,omitempty"`
+	ChangeType        ScheduleChangeType     `protobuf:"varint,10,opt,name=change_type,json=changeType,proto3,enum=schedule.ScheduleChangeType" json:"change_type,omitempty"`
+	OriginalSubject   string                 `protobuf:"bytes,11,opt,name=original_subject,json=originalSubject,proto3" json:"original_subject,omitempty"`
+	CreatedAt         *timestamppb.Timestamp `protobuf:"bytes,12,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	LessonNumber      int32                  `protobuf:"varint,13,opt,name=lesson_number,json=lessonNumber,proto3" json:"lesson_number,omitempty"` // 0 - номер пары неизвестен
+	HasOverlap        bool                   `protobuf:"varint,14,opt,name=has_overlap,json=hasOverlap,proto3" json:"has_overlap,omitempty"`       // Пересекается с другими занятиями группы
+	ApplyStatus       ChangeApplyStatus      `protobuf:"varint,15,opt,name=apply_status,json=applyStatus,proto3,enum=schedule.ChangeApplyStatus" json:"apply_status,omitempty"`
+	ApplyError        string                 `protobuf:"bytes,16,opt,name=apply_error,json=applyError,proto3" json:"apply_error,omitempty"`
+	ModerationStatus  ChangeModerationStatus `protobuf:"varint,17,opt,name=moderation_status,json=moderationStatus,proto3,enum=schedule.ChangeModerationStatus" json:"moderation_status,omitempty"`
+	ModerationComment string                 `protobuf:"bytes,18,opt,name=moderation_comment,json=moderationComment,proto3" json:"moderation_comment,omitempty"`
+	RequestId         string                 `protobuf:"bytes,19,opt,name=request_id,json=requestId,proto3" json:"request_id,omitempty"`          // Заявка преподавателя, по которой создано изменение
+	SupersedesId      string                 `protobuf:"bytes,20,opt,name=supersedes_id,json=supersedesId,proto3" json:"supersedes_id,omitempty"` // Ранее примененное изменение той же пары, которое перезаписано этим
+	Reason            string                 `protobuf:"bytes,21,opt,name=reason,proto3" json:"reason,omitempty"`                                 // Причина изменения (может быть пустой)
+	Subgroup          int32                  `protobuf:"varint,22,opt,name=subgroup,proto3" json:"subgroup,omitempty"`                            // Подгруппа, для которой изменяется пара (0 - вся группа)
+	LessonType        string                 `protobuf:"bytes,23,opt,name=lesson_type,json=lessonType,proto3" json:"lesson_type,omitempty"`       // Вид занятия (пусто - не указан)
+	unknownFields     protoimpl.UnknownFields
+	sizeCache         protoimpl.SizeCache
+}
+
+func (x *ScheduleChange) Reset() {
+	*x = ScheduleChange{}
+	mi := &file_schedule_proto_msgTypes[49]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ScheduleChange) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ScheduleChange) ProtoMessage() {}
+
+func (x *ScheduleChange) ProtoReflect() protoreflect.Message {
+	mi := &file_schedule_proto_msgTypes[49]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ScheduleChange.ProtoReflect.Descriptor instead.
+func (*ScheduleChange) Descriptor() ([]byte, []int) {
+	return file_schedule_proto_rawDescGZIP(), []int{49}
+}
+
+func (x *ScheduleChange) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *ScheduleChange) GetSnapshotId() string {
+	if x != nil {
+		return x.SnapshotId
+	}
+	return ""
+}
+
+func (x *ScheduleChange) GetGroupName() string {
+	if x != nil {
+		return x.GroupName
+	}
+	return ""
+}
+
+func (x *ScheduleChange) GetDate() *timestamppb.Timestamp {
+	if x != nil {
+		return x.Date
+	}
+	return nil
+}
+
+func (x *ScheduleChange) GetTimeStart() string {
+	if x != nil {
+		return x.TimeStart
+	}
+	return ""
+}
+
+func (x *ScheduleChange) GetTimeEnd() string {
+	if x != nil {
+		return x.TimeEnd
+	}
+	return ""
+}
+
+func (x *ScheduleChange) GetSubject() string {
+	if x != nil {
+		return x.Subject
+	}
+	return ""
+}
+
+func (x *ScheduleChange) GetTeacher() string {
+	if x != nil {
+		return x.Teacher
+	}
+	return ""
+}
+
+func (x *ScheduleChange) GetClassroom() string {
+	if x != nil {
+		return x.Classroom
+	}
+	return ""
+}
+
+func (x *ScheduleChange) GetChangeType() ScheduleChangeType {
+	if x != nil {
+		return x.ChangeType
+	}
+	return ScheduleChangeType_SCHEDULE_CHANGE_TYPE_UNSPECIFIED
+}
+
+func (x *ScheduleChange) GetOriginalSubject() string {
+	if x != nil {
+		return x.OriginalSubject
+	}
+	return ""
+}
+
+func (x *ScheduleChange) GetCreatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return nil
+}
+
+func (x *ScheduleChange) GetLessonNumber() int32 {
+	if x != nil {
+		return x.LessonNumber
+	}
+	return 0
+}
+
+func (x *ScheduleChange) GetHasOverlap() bool {
+	if x != nil {
+		return x.HasOverlap
+	}
+	return false
+}
+
+func (x *ScheduleChange) GetApplyStatus() ChangeApplyStatus {
+	if x != nil {
+		return x.ApplyStatus
+	}
+	return ChangeApplyStatus_CHANGE_APPLY_STATUS_UNSPECIFIED
+}
+
+func (x *ScheduleChange) GetApplyError() string {
+	if x != nil {
+		return x.ApplyError
+	}
+	return ""
+}
+
+func (x *ScheduleChange) GetModerationStatus() ChangeModerationStatus {
+	if x != nil {
+		return x.ModerationStatus
+	}
+	return ChangeModerationStatus_CHANGE_MODERATION_STATUS_UNSPECIFIED
+}
+
+func (x *ScheduleChange) GetModerationComment() string {
+	if x != nil {
+		return x.ModerationComment
+	}
+	return ""
+}
+
+func (x *ScheduleChange) GetRequestId() string {
+	if x != nil {
+		return x.RequestId
+	}
+	return ""
+}
+
+func (x *ScheduleChange) GetSupersedesId() string {
+	if x != nil {
+		return x.SupersedesId
+	}
+	return ""
+}
+
+func (x *ScheduleChange) GetReason() string {
+	if x != nil {
+		return x.Reason
+	}
+	return ""
+}
+
+func (x *ScheduleChange) GetSubgroup() int32 {
+	if x != nil {
+		return x.Subgroup
+	}
+	return 0
+}
+
+func (x *ScheduleChange) GetLessonType() string {
+	if x != nil {
+		return x.LessonType
+	}
+	return ""
+}
+
+// Запрос изменений с пересечениями
+type ListOverlappingChangesRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Token         string                 `protobuf:"bytes,1,opt,name=token,proto3" json:"token,omitempty"` // JWT токен для аутентификации
+	From          *timestamppb.Timestamp `protobuf:"bytes,2,opt,name=from,proto3" json:"from,omitempty"`   // По умолчанию сегодня
+	To            *timestamppb.Timestamp `protobuf:"bytes,3,opt,name=to,proto3" json:"to,omitempty"`       // По умолчанию +14 дней
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListOverlappingChangesRequest) Reset() {
+	*x = ListOverlappingChangesRequest{}
+	mi := &file_schedule_proto_msgTypes[50]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListOverlappingChangesRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListOverlappingChangesRequest) ProtoMessage() {}
+
+func (x *ListOverlappingChangesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_schedule_proto_msgTypes[50]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListOverlappingChangesRequest.ProtoReflect.Descriptor instead.
+func (*ListOverlappingChangesRequest) Descriptor() ([]byte, []int) {
+	return file_schedule_proto_rawDescGZIP(), []int{50}
+}
+
+func (x *ListOverlappingChangesRequest) GetToken() string {
+	if x != nil {
+		return x.Token
+	}
+	return ""
+}
+
+func (x *ListOverlappingChangesRequest) GetFrom() *timestamppb.Timestamp {
+	if x != nil {
+		return x.From
+	}
+	return nil
+}
+
+func (x *ListOverlappingChangesRequest) GetTo() *timestamppb.Timestamp {
+	if x != nil {
+		return x.To
+	}
+	return nil
+}
+
+// Ответ с изменениями, требующими внимания администратора
+type ListOverlappingChangesResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Success       bool                   `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
+	Message       string                 `protobuf:"bytes,2,opt,name=message,proto3" json:"message,omitempty"`
+	Changes       []*ScheduleChange      `protobuf:"bytes,3,rep,name=changes,proto3" json:"changes,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListOverlappingChangesResponse) Reset() {
+	*x = ListOverlappingChangesResponse{}
+	mi := &file_schedule_proto_msgTypes[51]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListOverlappingChangesResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListOverlappingChangesResponse) ProtoMessage() {}
+
+func (x *ListOverlappingChangesResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_schedule_proto_msgTypes[51]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListOverlappingChangesResponse.ProtoReflect.Descriptor instead.
+func (*ListOverlappingChangesResponse) Descriptor() ([]byte, []int) {
+	return file_schedule_proto_rawDescGZIP(), []int{51}
+}
+
+func (x *ListOverlappingChangesResponse) GetSuccess() bool {
+	if x != nil {
+		return x.Success
+	}
+	return false
+}
+
+func (x *ListOverlappingChangesResponse) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+func (x *ListOverlappingChangesResponse) GetChanges() []*ScheduleChange {
+	if x != nil {
+		return x.Changes
+	}
+	return nil
+}
+
+// Запрос изменений относительно снапшота
+type ListSnapshotChangesRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Token         string                 `protobuf:"bytes,1,opt,name=token,proto3" json:"token,omitempty"` // JWT токен для аутентификации
+	SnapshotId    string                 `protobuf:"bytes,2,opt,name=snapshot_id,json=snapshotId,proto3" json:"snapshot_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListSnapshotChangesRequest) Reset() {
+	*x = ListSnapshotChangesRequest{}
+	mi := &file_schedule_proto_msgTypes[52]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListSnapshotChangesRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListSnapshotChangesRequest) ProtoMessage() {}
+
+func (x *ListSnapshotChangesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_schedule_proto_msgTypes[52]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListSnapshotChangesRequest.ProtoReflect.Descriptor instead.
+func (*ListSnapshotChangesRequest) Descriptor() ([]byte, []int) {
+	return file_schedule_proto_rawDescGZIP(), []int{52}
+}
+
+func (x *ListSnapshotChangesRequest) GetToken() string {
+	if x != nil {
+		return x.Token
+	}
+	return ""
+}
+
+func (x *ListSnapshotChangesRequest) GetSnapshotId() string {
+	if x != nil {
+		return x.SnapshotId
+	}
+	return ""
+}
+
+// Ответ с изменениями относительно снапшота
+type ListSnapshotChangesResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Success       bool                   `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
+	Message       string                 `protobuf:"bytes,2,opt,name=message,proto3" json:"message,omitempty"`
+	Changes       []*ScheduleChange      `protobuf:"bytes,3,rep,name=changes,proto3" json:"changes,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListSnapshotChangesResponse) Reset() {
+	*x = ListSnapshotChangesResponse{}
+	mi := &file_schedule_proto_msgTypes[53]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListSnapshotChangesResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListSnapshotChangesResponse) ProtoMessage() {}
+
+func (x *ListSnapshotChangesResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_schedule_proto_msgTypes[53]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListSnapshotChangesResponse.ProtoReflect.Descriptor instead.
+func (*ListSnapshotChangesResponse) Descriptor() ([]byte, []int) {
+	return file_schedule_proto_rawDescGZIP(), []int{53}
+}
+
+func (x *ListSnapshotChangesResponse) GetSuccess() bool {
+	if x != nil {
+		return x.Success
+	}
+	return false
+}
+
+func (x *ListSnapshotChangesResponse) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+func (x *ListSnapshotChangesResponse) GetChanges() []*ScheduleChange {
+	if x != nil {
+		return x.Changes
+	}
+	return nil
+}
+
+// Запрос изменений, ожидающих модерации
+type ListChangesAwaitingModerationRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Token         string                 `protobuf:"bytes,1,opt,name=token,proto3" json:"token,omitempty"` // JWT токен для аутентификации
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListChangesAwaitingModerationRequest) Reset() {
+	*x = ListChangesAwaitingModerationRequest{}
+	mi := &file_schedule_proto_msgTypes[54]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListChangesAwaitingModerationRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListChangesAwaitingModerationRequest) ProtoMessage() {}
+
+func (x *ListChangesAwaitingModerationRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_schedule_proto_msgTypes[54]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListChangesAwaitingModerationRequest.ProtoReflect.Descriptor instead.
+func (*ListChangesAwaitingModerationRequest) Descriptor() ([]byte, []int) {
+	return file_schedule_proto_rawDescGZIP(), []int{54}
+}
+
+func (x *ListChangesAwaitingModerationRequest) GetToken() string {
+	if x != nil {
+		return x.Token
+	}
+	return ""
+}
+
+// Ответ с изменениями, ожидающими модерации
+type ListChangesAwaitingModerationResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Success       bool                   `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
+	Message       string                 `protobuf:"bytes,2,opt,name=message,proto3" json:"message,omitempty"`
+	Changes       []*ScheduleChange      `protobuf:"bytes,3,rep,name=changes,proto3" json:"changes,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListChangesAwaitingModerationResponse) Reset() {
+	*x = ListChangesAwaitingModerationResponse{}
+	mi := &file_schedule_proto_msgTypes[55]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListChangesAwaitingModerationResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListChangesAwaitingModerationResponse) ProtoMessage() {}
+
+func (x *ListChangesAwaitingModerationResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_schedule_proto_msgTypes[55]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListChangesAwaitingModerationResponse.ProtoReflect.Descriptor instead.
+func (*ListChangesAwaitingModerationResponse) Descriptor() ([]byte, []int) {
+	return file_schedule_proto_rawDescGZIP(), []int{55}
+}
+
+func (x *ListChangesAwaitingModerationResponse) GetSuccess() bool {
+	if x != nil {
+		return x.Success
+	}
+	return false
+}
+
+func (x *ListChangesAwaitingModerationResponse) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+func (x *ListChangesAwaitingModerationResponse) GetChanges() []*ScheduleChange {
+	if x != nil {
+		return x.Changes
+	}
+	return nil
+}
+
+// Запрос на модерацию изменения
+type ReviewChangeRequest struct {
+	state    protoimpl.MessageState `protogen:"open.v1"`
+	Token    string                 `protobuf:"bytes,1,opt,name=token,proto3" json:"token,omitempty"` // JWT токен для аутентификации
+	ChangeId string                 `protobuf:"bytes,2,opt,name=change_id,json=changeId,proto3" json:"change_id,omitempty"`
+	Decision ReviewDecision         `protobuf:"varint,3,opt,name=decision,proto3,enum=schedule.ReviewDecision" json:"decision,omitempty"`
+	// Исправления при одобрении: заполненные поля заменяют значения из таблицы
+	// (используются date, time_start, time_end, lesson_number, subject, teacher, classroom, reason)
+	Edit          *ScheduleChange `protobuf:"bytes,4,opt,name=edit,proto3" json:"edit,omitempty"`
+	Comment       string          `protobuf:"bytes,5,opt,name=comment,proto3" json:"comment,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ReviewChangeRequest) Reset() {
+	*x = ReviewChangeRequest{}
+	mi := &file_schedule_proto_msgTypes[56]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ReviewChangeRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ReviewChangeRequest) ProtoMessage() {}
+
+func (x *ReviewChangeRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_schedule_proto_msgTypes[56]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ReviewChangeRequest.ProtoReflect.Descriptor instead.
+func (*ReviewChangeRequest) Descriptor() ([]byte, []int) {
+	return file_schedule_proto_rawDescGZIP(), []int{56}
+}
+
+func (x *ReviewChangeRequest) GetToken() string {
+	if x != nil {
+		return x.Token
+	}
+	return ""
+}
+
+func (x *ReviewChangeRequest) GetChangeId() string {
+	if x != nil {
+		return x.ChangeId
+	}
+	return ""
+}
+
+func (x *ReviewChangeRequest) GetDecision() ReviewDecision {
+	if x != nil {
+		return x.Decision
+	}
+	return ReviewDecision_REVIEW_DECISION_UNSPECIFIED
+}
+
+func (x *ReviewChangeRequest) GetEdit() *ScheduleChange {
+	if x != nil {
+		return x.Edit
+	}
+	return nil
+}
+
+func (x *ReviewChangeRequest) GetComment() string {
+	if x != nil {
+		return x.Comment
+	}
+	return ""
+}
+
+// Ответ на модерацию изменения
+type ReviewChangeResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Success       bool                   `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
+	Message       string                 `protobuf:"bytes,2,opt,name=message,proto3" json:"message,omitempty"`
+	Change        *ScheduleChange        `protobuf:"bytes,3,opt,name=change,proto3" json:"change,omitempty"` // Изменение после модерации (и применения)
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ReviewChangeResponse) Reset() {
+	*x = ReviewChangeResponse{}
+	mi := &file_schedule_proto_msgTypes[57]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ReviewChangeResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ReviewChangeResponse) ProtoMessage() {}
+
+func (x *ReviewChangeResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_schedule_proto_msgTypes[57]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ReviewChangeResponse.ProtoReflect.Descriptor instead.
+func (*ReviewChangeResponse) Descriptor() ([]byte, []int) {
+	return file_schedule_proto_rawDescGZIP(), []int{57}
+}
+
+func (x *ReviewChangeResponse) GetSuccess() bool {
+	if x != nil {
+		return x.Success
+	}
+	return false
+}
+
+func (x *ReviewChangeResponse) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+func (x *ReviewChangeResponse) GetChange() *ScheduleChange {
+	if x != nil {
+		return x.Change
+	}
+	return nil
+}
+
+// Заявка преподавателя на отмену или перенос пары
+type TeacherChangeRequest struct {
+	state           protoimpl.MessageState   `protogen:"open.v1"`
+	Id              string                   `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	TeacherId       string                   `protobuf:"bytes,2,opt,name=teacher_id,json=teacherId,proto3" json:"teacher_id,omitempty"`
+	Kind            TeacherChangeRequestKind `protobuf:"varint,3,opt,name=kind,proto3,enum=schedule.TeacherChangeRequestKind" json:"kind,omitempty"`
+	GroupName       string                   `protobuf:"bytes,4,opt,name=group_name,json=groupName,proto3" json:"group_name,omitempty"`
+	Date            *timestamppb.Timestamp   `protobuf:"bytes,5,opt,name=date,proto3" json:"date,omitempty"`
+	TimeStart       string                   `protobuf:"bytes,6,opt,name=time_start,json=timeStart,proto3" json:"time_start,omitempty"`
+	TimeEnd         string                   `protobuf:"bytes,7,opt,name=time_end,json=timeEnd,proto3" json:"time_end,omitempty"`
+	Subject         string                   `protobuf:"bytes,8,opt,name=subject,proto3" json:"subject,omitempty"`
+	Teacher         string                   `protobuf:"bytes,9,opt,name=teacher,proto3" json:"teacher,omitempty"`
+	Classroom       string                   `protobuf:"bytes,10,opt,name=classroom,proto3" json:"classroom,omitempty"`
+	NewDate         *timestamppb.Timestamp   `protobuf:"bytes,11,opt,name=new_date,json=newDate,proto3" json:"new_date,omitempty"` // Новый слот (для переноса)
+	NewTimeStart    string                   `protobuf:"bytes,12,opt,name=new_time_start,json=newTimeStart,proto3" json:"new_time_start,omitempty"`
+	NewTimeEnd      string                   `protobuf:"bytes,13,opt,name=new_time_end,json=newTimeEnd,proto3" json:"new_time_end,omitempty"`
+	NewLessonNumber int32                    `protobuf:"varint,14,opt,name=new_lesson_number,json=newLessonNumber,proto3" json:"new_lesson_number,omitempty"`
+	NewClassroom    string                   `protobuf:"bytes,15,opt,name=new_classroom,json=newClassroom,proto3" json:"new_classroom,omitempty"`
+	Comment         string                   `protobuf:"bytes,16,opt,name=comment,proto3" json:"comment,omitempty"`
+	Status          ChangeModerationStatus   `protobuf:"varint,17,opt,name=status,proto3,enum=schedule.ChangeModerationStatus" json:"status,omitempty"`
+	ReviewComment   string                   `protobuf:"bytes,18,opt,name=review_comment,json=reviewComment,proto3" json:"review_comment,omitempty"`
+	CreatedAt       *timestamppb.Timestamp   `protobuf:"bytes,19,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	ReviewedAt      *timestamppb.Timestamp   `protobuf:"bytes,20,opt,name=reviewed_at,json=reviewedAt,proto3" json:"reviewed_at,omitempty"`
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *TeacherChangeRequest) Reset() {
+	*x = TeacherChangeRequest{}
+	mi := &file_schedule_proto_msgTypes[58]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *TeacherChangeRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TeacherChangeRequest) ProtoMessage() {}
+
+func (x *TeacherChangeRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_schedule_proto_msgTypes[58]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TeacherChangeRequest.ProtoReflect.Descriptor instead.
+func (*TeacherChangeRequest) Descriptor() ([]byte, []int) {
+	return file_schedule_proto_rawDescGZIP(), []int{58}
+}
+
+func (x *TeacherChangeRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *TeacherChangeRequest) GetTeacherId() string {
+	if x != nil {
+		return x.TeacherId
+	}
+	return ""
+}
+
+func (x *TeacherChangeRequest) GetKind() TeacherChangeRequestKind {
+	if x != nil {
+		return x.Kind
+	}
+	return TeacherChangeRequestKind_TEACHER_CHANGE_REQUEST_KIND_UNSPECIFIED
+}
+
+func (x *TeacherChangeRequest) GetGroupName() string {
+	if x != nil {
+		return x.GroupName
+	}
+	return ""
+}
+
+func (x *TeacherChangeRequest) GetDate() *timestamppb.Timestamp {
+	if x != nil {
+		return x.Date
+	}
+	return nil
+}
+
+func (x *TeacherChangeRequest) GetTimeStart() string {
+	if x != nil {
+		return x.TimeStart
+	}
+	return ""
+}
+
+func (x *TeacherChangeRequest) GetTimeEnd() string {
+	if x != nil {
+		return x.TimeEnd
+	}
+	return ""
+}
+
+func (x *TeacherChangeRequest) GetSubject() string {
+	if x != nil {
+		return x.Subject
+	}
+	return ""
+}
+
+func (x *TeacherChangeRequest) GetTeacher() string {
+	if x != nil {
+		return x.Teacher
+	}
+	return ""
+}
+
+func (x *TeacherChangeRequest) GetClassroom() string {
+	if x != nil {
+		return x.Classroom
+	}
+	return ""
+}
+
+func (x *TeacherChangeRequest) GetNewDate() *timestamppb.Timestamp {
+	if x != nil {
+		return x.NewDate
+	}
+	return nil
+}
+
+func (x *TeacherChangeRequest) GetNewTimeStart() string {
+	if x != nil {
+		return x.NewTimeStart
+	}
+	return ""
+}
+
+func (x *TeacherChangeRequest) GetNewTimeEnd() string {
+	if x != nil {
+		return x.NewTimeEnd
+	}
+	return ""
+}
+
+func (x *TeacherChangeRequest) GetNewLessonNumber() int32 {
+	if x != nil {
+		return x.NewLessonNumber
+	}
+	return 0
+}
+
+func (x *TeacherChangeRequest) GetNewClassroom() string {
+	if x != nil {
+		return x.NewClassroom
+	}
+	return ""
+}
+
+func (x *TeacherChangeRequest) GetComment() string {
+	if x != nil {
+		return x.Comment
+	}
+	return ""
+}
+
+func (x *TeacherChangeRequest) GetStatus() ChangeModerationStatus {
+	if x != nil {
+		return x.Status
+	}
+	return ChangeModerationStatus_CHANGE_MODERATION_STATUS_UNSPECIFIED
+}
+
+func (x *TeacherChangeRequest) GetReviewComment() string {
+	if x != nil {
+		return x.ReviewComment
+	}
+	return ""
+}
+
+func (x *TeacherChangeRequest) GetCreatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return nil
+}
+
+func (x *TeacherChangeRequest) GetReviewedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.ReviewedAt
+	}
+	return nil
+}
+
+// Запрос на подачу заявки преподавателем
+type SubmitTeacherChangeRequestRequest struct {
+	state     protoimpl.MessageState   `protogen:"open.v1"`
+	Token     string                   `protobuf:"bytes,1,opt,name=token,proto3" json:"token,omitempty"` // JWT токен для аутентификации
+	Kind      TeacherChangeRequestKind `protobuf:"varint,2,opt,name=kind,proto3,enum=schedule.TeacherChangeRequestKind" json:"kind,omitempty"`
+	GroupName string                   `protobuf:"bytes,3,opt,name=group_name,json=groupName,proto3" json:"group_name,omitempty"`
+	Date      *timestamppb.Timestamp   `protobuf:"bytes,4,opt,name=date,proto3" json:"date,omitempty"`                            // Дата пары
+	TimeStart string                   `protobuf:"bytes,5,opt,name=time_start,json=timeStart,proto3" json:"time_start,omitempty"` // Время начала пары
+	// Новый слот для переноса: дата (по умолчанию та же) и время начала или номер пары
+	NewDate         *timestamppb.Timestamp `protobuf:"bytes,6,opt,name=new_date,json=newDate,proto3" json:"new_date,omitempty"`
+	NewTimeStart    string                 `protobuf:"bytes,7,opt,name=new_time_start,json=newTimeStart,proto3" json:"new_time_start,omitempty"`
+	NewLessonNumber int32                  `protobuf:"varint,8,opt,name=new_lesson_number,json=newLessonNumber,proto3" json:"new_lesson_number,omitempty"`
+	NewClassroom    string                 `protobuf:"bytes,9,opt,name=new_classroom,json=newClassroom,proto3" json:"new_classroom,omitempty"` // Пусто - тот же кабинет
+	Comment         string                 `protobuf:"bytes,10,opt,name=comment,proto3" json:"comment,omitempty"`
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *SubmitTeacherChangeRequestRequest) Reset() {
+	*x = SubmitTeacherChangeRequestRequest{}
+	mi := &file_schedule_proto_msgTypes[59]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SubmitTeacherChangeRequestRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SubmitTeacherChangeRequestRequest) ProtoMessage() {}
+
+func (x *SubmitTeacherChangeRequestRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_schedule_proto_msgTypes[59]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SubmitTeacherChangeRequestRequest.ProtoReflect.Descriptor instead.
+func (*SubmitTeacherChangeRequestRequest) Descriptor() ([]byte, []int) {
+	return file_schedule_proto_rawDescGZIP(), []int{59}
+}
+
+func (x *SubmitTeacherChangeRequestRequest) GetToken() string {
+	if x != nil {
+		return x.Token
+	}
+	return ""
+}
+
+func (x *SubmitTeacherChangeRequestRequest) GetKind() TeacherChangeRequestKind {
+	if x != nil {
+		return x.Kind
+	}
+	return TeacherChangeRequestKind_TEACHER_CHANGE_REQUEST_KIND_UNSPECIFIED
+}
+
+func (x *SubmitTeacherChangeRequestRequest) GetGroupName() string {
+	if x != nil {
+		return x.GroupName
+	}
+	return ""
+}
+
+func (x *SubmitTeacherChangeRequestRequest) GetDate() *timestamppb.Timestamp {
+	if x != nil {
+		return x.Date
+	}
+	return nil
+}
+
+func (x *SubmitTeacherChangeRequestRequest) GetTimeStart() string {
+	if x != nil {
+		return x.TimeStart
+	}
+	return ""
+}
+
+func (x *SubmitTeacherChangeRequestRequest) GetNewDate() *timestamppb.Timestamp {
+	if x != nil {
+		return x.NewDate
+	}
+	return nil
+}
+
+func (x *SubmitTeacherChangeRequestRequest) GetNewTimeStart() string {
+	if x != nil {
+		return x.NewTimeStart
+	}
+	return ""
+}
+
+func (x *SubmitTeacherChangeRequestRequest) GetNewLessonNumber() int32 {
+	if x != nil {
+		return x.NewLessonNumber
+	}
+	return 0
+}
+
+func (x *SubmitTeacherChangeRequestRequest) GetNewClassroom() string {
+	if x != nil {
+		return x.NewClassroom
+	}
+	return ""
+}
+
+func (x *SubmitTeacherChangeRequestRequest) GetComment() string {
+	if x != nil {
+		return x.Comment
+	}
+	return ""
+}
+
+// Ответ на подачу заявки
+type SubmitTeacherChangeRequestResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Success       bool                   `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
+	Message       string                 `protobuf:"bytes,2,opt,name=message,proto3" json:"message,omitempty"`
+	Request       *TeacherChangeRequest  `protobuf:"bytes,3,opt,name=request,proto3" json:"request,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SubmitTeacherChangeRequestResponse) Reset() {
+	*x = SubmitTeacherChangeRequestResponse{}
+	mi := &file_schedule_proto_msgTypes[60]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SubmitTeacherChangeRequestResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SubmitTeacherChangeRequestResponse) ProtoMessage() {}
+
+func (x *SubmitTeacherChangeRequestResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_schedule_proto_msgTypes[60]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SubmitTeacherChangeRequestResponse.ProtoReflect.Descriptor instead.
+func (*SubmitTeacherChangeRequestResponse) Descriptor() ([]byte, []int) {
+	return file_schedule_proto_rawDescGZIP(), []int{60}
+}
+
+func (x *SubmitTeacherChangeRequestResponse) GetSuccess() bool {
+	if x != nil {
+		return x.Success
+	}
+	return false
+}
+
+func (x *SubmitTeacherChangeRequestResponse) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+func (x *SubmitTeacherChangeRequestResponse) GetRequest() *TeacherChangeRequest {
+	if x != nil {
+		return x.Request
+	}
+	return nil
+}
+
+// Запрос своих заявок преподавателя
+type ListMyTeacherChangeRequestsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Token         string                 `protobuf:"bytes,1,opt,name=token,proto3" json:"token,omitempty"` // JWT токен для аутентификации
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListMyTeacherChangeRequestsRequest) Reset() {
+	*x = ListMyTeacherChangeRequestsRequest{}
+	mi := &file_schedule_proto_msgTypes[61]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListMyTeacherChangeRequestsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListMyTeacherChangeRequestsRequest) ProtoMessage() {}
+
+func (x *ListMyTeacherChangeRequestsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_schedule_proto_msgTypes[61]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListMyTeacherChangeRequestsRequest.ProtoReflect.Descriptor instead.
+func (*ListMyTeacherChangeRequestsRequest) Descriptor() ([]byte, []int) {
+	return file_schedule_proto_rawDescGZIP(), []int{61}
+}
+
+func (x *ListMyTeacherChangeRequestsRequest) GetToken() string {
+	if x != nil {
+		return x.Token
+	}
+	return ""
+}
+
+// Ответ со своими заявками преподавателя
+type ListMyTeacherChangeRequestsResponse struct {
+	state         protoimpl.MessageState  `protogen:"open.v1"`
+	Success       bool                    `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
+	Message       string                  `protobuf:"bytes,2,opt,name=message,proto3" json:"message,omitempty"`
+	Requests      []*TeacherChangeRequest `protobuf:"bytes,3,rep,name=requests,proto3" json:"requests,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListMyTeacherChangeRequestsResponse) Reset() {
+	*x = ListMyTeacherChangeRequestsResponse{}
+	mi := &file_schedule_proto_msgTypes[62]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListMyTeacherChangeRequestsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListMyTeacherChangeRequestsResponse) ProtoMessage() {}
+
+func (x *ListMyTeacherChangeRequestsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_schedule_proto_msgTypes[62]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListMyTeacherChangeRequestsResponse.ProtoReflect.Descriptor instead.
+func (*ListMyTeacherChangeRequestsResponse) Descriptor() ([]byte, []int) {
+	return file_schedule_proto_rawDescGZIP(), []int{62}
+}
+
+func (x *ListMyTeacherChangeRequestsResponse) GetSuccess() bool {
+	if x != nil {
+		return x.Success
+	}
+	return false
+}
+
+func (x *ListMyTeacherChangeRequestsResponse) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+func (x *ListMyTeacherChangeRequestsResponse) GetRequests() []*TeacherChangeRequest {
+	if x != nil {
+		return x.Requests
+	}
+	return nil
+}
+
+// Запрос заявок на рассмотрении
+type ListPendingTeacherChangeRequestsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Token         string                 `protobuf:"bytes,1,opt,name=token,proto3" json:"token,omitempty"` // JWT токен для аутентификации
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListPendingTeacherChangeRequestsRequest) Reset() {
+	*x = ListPendingTeacherChangeRequestsRequest{}
+	mi := &file_schedule_proto_msgTypes[63]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListPendingTeacherChangeRequestsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListPendingTeacherChangeRequestsRequest) ProtoMessage() {}
+
+func (x *ListPendingTeacherChangeRequestsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_schedule_proto_msgTypes[63]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListPendingTeacherChangeRequestsRequest.ProtoReflect.Descriptor instead.
+func (*ListPendingTeacherChangeRequestsRequest) Descriptor() ([]byte, []int) {
+	return file_schedule_proto_rawDescGZIP(), []int{63}
+}
+
+func (x *ListPendingTeacherChangeRequestsRequest) GetToken() string {
+	if x != nil {
+		return x.Token
+	}
+	return ""
+}
+
+// Ответ с заявками на рассмотрении
+type ListPendingTeacherChangeRequestsResponse struct {
+	state         protoimpl.MessageState  `protogen:"open.v1"`
+	Success       bool                    `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
+	Message       string                  `protobuf:"bytes,2,opt,name=message,proto3" json:"message,omitempty"`
+	Requests      []*TeacherChangeRequest `protobuf:"bytes,3,rep,name=requests,proto3" json:"requests,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListPendingTeacherChangeRequestsResponse) Reset() {
+	*x = ListPendingTeacherChangeRequestsResponse{}
+	mi := &file_schedule_proto_msgTypes[64]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListPendingTeacherChangeRequestsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListPendingTeacherChangeRequestsResponse) ProtoMessage() {}
+
+func (x *ListPendingTeacherChangeRequestsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_schedule_proto_msgTypes[64]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListPendingTeacherChangeRequestsResponse.ProtoReflect.Descriptor instead.
+func (*ListPendingTeacherChangeRequestsResponse) Descriptor() ([]byte, []int) {
+	return file_schedule_proto_rawDescGZIP(), []int{64}
+}
+
+func (x *ListPendingTeacherChangeRequestsResponse) GetSuccess() bool {
+	if x != nil {
+		return x.Success
+	}
+	return false
+}
+
+func (x *ListPendingTeacherChangeRequestsResponse) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+func (x *ListPendingTeacherChangeRequestsResponse) GetRequests() []*TeacherChangeRequest {
+	if x != nil {
+		return x.Requests
+	}
+	return nil
+}
+
+// Запрос на рассмотрение заявки преподавателя
+type ReviewTeacherChangeRequestRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Token         string                 `protobuf:"bytes,1,opt,name=token,proto3" json:"token,omitempty"` // JWT токен для аутентификации
+	RequestId     string                 `protobuf:"bytes,2,opt,name=request_id,json=requestId,proto3" json:"request_id,omitempty"`
+	Decision      ReviewDecision         `protobuf:"varint,3,opt,name=decision,proto3,enum=schedule.ReviewDecision" json:"decision,omitempty"`
+	Comment       string                 `protobuf:"bytes,4,opt,name=comment,proto3" json:"comment,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ReviewTeacherChangeRequestRequest) Reset() {
+	*x = ReviewTeacherChangeRequestRequest{}
+	mi := &file_schedule_proto_msgTypes[65]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ReviewTeacherChangeRequestRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ReviewTeacherChangeRequestRequest) ProtoMessage() {}
+
+func (x *ReviewTeacherChangeRequestRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_schedule_proto_msgTypes[65]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ReviewTeacherChangeRequestRequest.ProtoReflect.Descriptor instead.
+func (*ReviewTeacherChangeRequestRequest) Descriptor() ([]byte, []int) {
+	return file_schedule_proto_rawDescGZIP(), []int{65}
+}
+
+func (x *ReviewTeacherChangeRequestRequest) GetToken() string {
+	if x != nil {
+		return x.Token
+	}
+	return ""
+}
+
+func (x *ReviewTeacherChangeRequestRequest) GetRequestId() string {
+	if x != nil {
+		return x.RequestId
+	}
+	return ""
+}
+
+func (x *ReviewTeacherChangeRequestRequest) GetDecision() ReviewDecision {
+	if x != nil {
+		return x.Decision
+	}
+	return ReviewDecision_REVIEW_DECISION_UNSPECIFIED
+}
+
+func (x *ReviewTeacherChangeRequestRequest) GetComment() string {
+	if x != nil {
+		return x.Comment
+	}
+	return ""
+}
+
+// Ответ на рассмотрение заявки
+type ReviewTeacherChangeRequestResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Success       bool                   `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
+	Message       string                 `protobuf:"bytes,2,opt,name=message,proto3" json:"message,omitempty"`
+	Request       *TeacherChangeRequest  `protobuf:"bytes,3,opt,name=request,proto3" json:"request,omitempty"`
+	Changes       []*ScheduleChange      `protobuf:"bytes,4,rep,name=changes,proto3" json:"changes,omitempty"` // Изменения расписания, созданные по одобренной заявке
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ReviewTeacherChangeRequestResponse) Reset() {
+	*x = ReviewTeacherChangeRequestResponse{}
+	mi := &file_schedule_proto_msgTypes[66]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ReviewTeacherChangeRequestResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ReviewTeacherChangeRequestResponse) ProtoMessage() {}
+
+func (x *ReviewTeacherChangeRequestResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_schedule_proto_msgTypes[66]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ReviewTeacherChangeRequestResponse.ProtoReflect.Descriptor instead.
+func (*ReviewTeacherChangeRequestResponse) Descriptor() ([]byte, []int) {
+	return file_schedule_proto_rawDescGZIP(), []int{66}
+}
+
+func (x *ReviewTeacherChangeRequestResponse) GetSuccess() bool {
+	if x != nil {
+		return x.Success
+	}
+	return false
+}
+
+func (x *ReviewTeacherChangeRequestResponse) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+func (x *ReviewTeacherChangeRequestResponse) GetRequest() *TeacherChangeRequest {
+	if x != nil {
+		return x.Request
+	}
+	return nil
+}
+
+func (x *ReviewTeacherChangeRequestResponse) GetChanges() []*ScheduleChange {
+	if x != nil {
+		return x.Changes
+	}
+	return nil
+}
+
+// Запрос списка студентов группы
+type GetGroupRosterRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Token         string                 `protobuf:"bytes,1,opt,name=token,proto3" json:"token,omitempty"` // JWT токен для аутентификации
+	GroupName     string                 `protobuf:"bytes,2,opt,name=group_name,json=groupName,proto3" json:"group_name,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetGroupRosterRequest) Reset() {
+	*x = GetGroupRosterRequest{}
+	mi := &file_schedule_proto_msgTypes[67]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetGroupRosterRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetGroupRosterRequest) ProtoMessage() {}
+
+func (x *GetGroupRosterRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_schedule_proto_msgTypes[67]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetGroupRosterRequest.ProtoReflect.Descriptor instead.
+func (*GetGroupRosterRequest) Descriptor() ([]byte, []int) {
+	return file_schedule_proto_rawDescGZIP(), []int{67}
+}
+
+func (x *GetGroupRosterRequest) GetToken() string {
+	if x != nil {
+		return x.Token
+	}
+	return ""
+}
+
+func (x *GetGroupRosterRequest) GetGroupName() string {
+	if x != nil {
+		return x.GroupName
+	}
+	return ""
+}
+
+// Студент в списке группы
+type RosterStudent struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	UserId        string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	FullName      string                 `protobuf:"bytes,2,opt,name=full_name,json=fullName,proto3" json:"full_name,omitempty"`
+	StudentNumber string                 `protobuf:"bytes,3,opt,name=student_number,json=studentNumber,proto3" json:"student_number,omitempty"`
+	Subgroup      int32                  `protobuf:"varint,4,opt,name=subgroup,proto3" json:"subgroup,omitempty"` // Подгруппа студента (0 - не выбрана)
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RosterStudent) Reset() {
+	*x = RosterStudent{}
+	mi := &file_schedule_proto_msgTypes[68]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RosterStudent) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RosterStudent) ProtoMessage() {}
+
+func (x *RosterStudent) ProtoReflect() protoreflect.Message {
+	mi := &file_schedule_proto_msgTypes[68]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RosterStudent.ProtoReflect.Descriptor instead.
+func (*RosterStudent) Descriptor() ([]byte, []int) {
+	return file_schedule_proto_rawDescGZIP(), []int{68}
+}
+
+func (x *RosterStudent) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *RosterStudent) GetFullName() string {
+	if x != nil {
+		return x.FullName
+	}
+	return ""
+}
+
+func (x *RosterStudent) GetStudentNumber() string {
+	if x != nil {
+		return x.StudentNumber
+	}
+	return ""
+}
+
+func (x *RosterStudent) GetSubgroup() int32 {
+	if x != nil {
+		return x.Subgroup
+	}
+	return 0
+}
+
+// Ответ со списком студентов группы
+type GetGroupRosterResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Success       bool                   `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
+	Message       string                 `protobuf:"bytes,2,opt,name=message,proto3" json:"message,omitempty"`
+	GroupName     string                 `protobuf:"bytes,3,opt,name=group_name,json=groupName,proto3" json:"group_name,omitempty"`
+	Students      []*RosterStudent       `protobuf:"bytes,4,rep,name=students,proto3" json:"students,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetGroupRosterResponse) Reset() {
+	*x = GetGroupRosterResponse{}
+	mi := &file_schedule_proto_msgTypes[69]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetGroupRosterResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetGroupRosterResponse) ProtoMessage() {}
+
+func (x *GetGroupRosterResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_schedule_proto_msgTypes[69]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetGroupRosterResponse.ProtoReflect.Descriptor instead.
+func (*GetGroupRosterResponse) Descriptor() ([]byte, []int) {
+	return file_schedule_proto_rawDescGZIP(), []int{69}
+}
+
+func (x *GetGroupRosterResponse) GetSuccess() bool {
+	if x != nil {
+		return x.Success
+	}
+	return false
+}
+
+func (x *GetGroupRosterResponse) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+func (x *GetGroupRosterResponse) GetGroupName() string {
+	if x != nil {
+		return x.GroupName
+	}
+	return ""
+}
+
+func (x *GetGroupRosterResponse) GetStudents() []*RosterStudent {
+	if x != nil {
+		return x.Students
+	}
+	return nil
+}
+
+// Фоновая задача
+type Job struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Kind          string                 `protobuf:"bytes,2,opt,name=kind,proto3" json:"kind,omitempty"`       // Вид задачи, например "notifications.change"
+	Payload       string                 `protobuf:"bytes,3,opt,name=payload,proto3" json:"payload,omitempty"` // Параметры задачи в JSON
+	Status        JobStatus              `protobuf:"varint,4,opt,name=status,proto3,enum=schedule.JobStatus" json:"status,omitempty"`
+	Attempts      int32                  `protobuf:"varint,5,opt,name=attempts,proto3" json:"attempts,omitempty"`
+	MaxAttempts   int32                  `protobuf:"varint,6,opt,name=max_attempts,json=maxAttempts,proto3" json:"max_attempts,omitempty"`
+	RunAt         *timestamppb.Timestamp `protobuf:"bytes,7,opt,name=run_at,json=runAt,proto3" json:"run_at,omitempty"`          // Время следующей попытки
+	LockedBy      string                 `protobuf:"bytes,8,opt,name=locked_by,json=lockedBy,proto3" json:"locked_by,omitempty"` // Экземпляр API, выполняющий задачу
+	LastError     string                 `protobuf:"bytes,9,opt,name=last_error,json=lastError,proto3" json:"last_error,omitempty"`
+	CreatedAt     *timestamppb.Timestamp `protobuf:"bytes,10,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	FinishedAt    *timestamppb.Timestamp `protobuf:"bytes,11,opt,name=finished_at,json=finishedAt,proto3" json:"finished_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Job) Reset() {
+	*x = Job{}
+	mi := &file_schedule_proto_msgTypes[70]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Job) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Job) ProtoMessage() {}
+
+func (x *Job) ProtoReflect() protoreflect.Message {
+	mi := &file_schedule_proto_msgTypes[70]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Job.ProtoReflect.Descriptor instead.
+func (*Job) Descriptor() ([]byte, []int) {
+	return file_schedule_proto_rawDescGZIP(), []int{70}
+}
+
+func (x *Job) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *Job) GetKind() string {
+	if x != nil {
+		return x.Kind
+	}
+	return ""
+}
+
+func (x *Job) GetPayload() string {
+	if x != nil {
+		return x.Payload
+	}
+	return ""
+}
+
+func (x *Job) GetStatus() JobStatus {
+	if x != nil {
+		return x.Status
+	}
+	return JobStatus_JOB_STATUS_UNSPECIFIED
+}
+
+func (x *Job) GetAttempts() int32 {
+	if x != nil {
+		return x.Attempts
+	}
+	return 0
+}
+
+func (x *Job) GetMaxAttempts() int32 {
+	if x != nil {
+		return x.MaxAttempts
+	}
+	return 0
+}
+
+func (x *Job) GetRunAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.RunAt
+	}
+	return nil
+}
+
+func (x *Job) GetLockedBy() string {
+	if x != nil {
+		return x.LockedBy
+	}
+	return ""
+}
+
+func (x *Job) GetLastError() string {
+	if x != nil {
+		return x.LastError
+	}
+	return ""
+}
+
+func (x *Job) GetCreatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return nil
+}
+
+func (x *Job) GetFinishedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.FinishedAt
+	}
+	return nil
+}
+
+// Количество задач одного вида по состояниям
+type JobKindStats struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Kind          string                 `protobuf:"bytes,1,opt,name=kind,proto3" json:"kind,omitempty"`
+	Pending       int32                  `protobuf:"varint,2,opt,name=pending,proto3" json:"pending,omitempty"`
+	Running       int32                  `protobuf:"varint,3,opt,name=running,proto3" json:"running,omitempty"`
+	Done          int32                  `protobuf:"varint,4,opt,name=done,proto3" json:"done,omitempty"`
+	Failed        int32                  `protobuf:"varint,5,opt,name=failed,proto3" json:"failed,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *JobKindStats) Reset() {
+	*x = JobKindStats{}
+	mi := &file_schedule_proto_msgTypes[71]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *JobKindStats) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*JobKindStats) ProtoMessage() {}
+
+func (x *JobKindStats) ProtoReflect() protoreflect.Message {
+	mi := &file_schedule_proto_msgTypes[71]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use JobKindStats.ProtoReflect.Descriptor instead.
+func (*JobKindStats) Descriptor() ([]byte, []int) {
+	return file_schedule_proto_rawDescGZIP(), []int{71}
+}
+
+func (x *JobKindStats) GetKind() string {
+	if x != nil {
+		return x.Kind
+	}
+	return ""
+}
+
+func (x *JobKindStats) GetPending() int32 {
+	if x != nil {
+		return x.Pending
+	}
+	return 0
+}
+
+func (x *JobKindStats) GetRunning() int32 {
+	if x != nil {
+		return x.Running
+	}
+	return 0
+}
+
+func (x *JobKindStats) GetDone() int32 {
+	if x != nil {
+		return x.Done
+	}
+	return 0
+}
+
+func (x *JobKindStats) GetFailed() int32 {
+	if x != nil {
+		return x.Failed
+	}
+	return 0
+}
+
+// Запрос списка фоновых задач; пустые фильтры не ограничивают выборку
+type ListJobsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Token         string                 `protobuf:"bytes,1,opt,name=token,proto3" json:"token,omitempty"` // JWT токен для аутентификации
+	Kind          string                 `protobuf:"bytes,2,opt,name=kind,proto3" json:"kind,omitempty"`
+	Status        JobStatus              `protobuf:"varint,3,opt,name=status,proto3,enum=schedule.JobStatus" json:"status,omitempty"`
+	PageSize      int32                  `protobuf:"varint,4,opt,name=page_size,json=pageSize,proto3" json:"page_size,omitempty"` // По умолчанию 50, не более 500
+	Offset        int32                  `protobuf:"varint,5,opt,name=offset,proto3" json:"offset,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListJobsRequest) Reset() {
+	*x = ListJobsRequest{}
+	mi := &file_schedule_proto_msgTypes[72]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListJobsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListJobsRequest) ProtoMessage() {}
+
+func (x *ListJobsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_schedule_proto_msgTypes[72]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListJobsRequest.ProtoReflect.Descriptor instead.
+func (*ListJobsRequest) Descriptor() ([]byte, []int) {
+	return file_schedule_proto_rawDescGZIP(), []int{72}
+}
+
+func (x *ListJobsRequest) GetToken() string {
+	if x != nil {
+		return x.Token
+	}
+	return ""
+}
+
+func (x *ListJobsRequest) GetKind() string {
+	if x != nil {
+		return x.Kind
+	}
+	return ""
+}
+
+func (x *ListJobsRequest) GetStatus() JobStatus {
+	if x != nil {
+		return x.Status
+	}
+	return JobStatus_JOB_STATUS_UNSPECIFIED
+}
+
+func (x *ListJobsRequest) GetPageSize() int32 {
+	if x != nil {
+		return x.PageSize
+	}
+	return 0
+}
+
+func (x *ListJobsRequest) GetOffset() int32 {
+	if x != nil {
+		return x.Offset
+	}
+	return 0
+}
+
+// Ответ со списком фоновых задач
+type ListJobsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Success       bool                   `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
+	Message       string                 `protobuf:"bytes,2,opt,name=message,proto3" json:"message,omitempty"`
+	Jobs          []*Job                 `protobuf:"bytes,3,rep,name=jobs,proto3" json:"jobs,omitempty"`
+	Total         int32                  `protobuf:"varint,4,opt,name=total,proto3" json:"total,omitempty"` // Всего задач по фильтру
+	Stats         []*JobKindStats        `protobuf:"bytes,5,rep,name=stats,proto3" json:"stats,omitempty"`  // Статистика по всем задачам очереди
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListJobsResponse) Reset() {
+	*x = ListJobsResponse{}
+	mi := &file_schedule_proto_msgTypes[73]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListJobsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListJobsResponse) ProtoMessage() {}
+
+func (x *ListJobsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_schedule_proto_msgTypes[73]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListJobsResponse.ProtoReflect.Descriptor instead.
+func (*ListJobsResponse) Descriptor() ([]byte, []int) {
+	return file_schedule_proto_rawDescGZIP(), []int{73}
+}
+
+func (x *ListJobsResponse) GetSuccess() bool {
+	if x != nil {
+		return x.Success
+	}
+	return false
+}
+
+func (x *ListJobsResponse) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+func (x *ListJobsResponse) GetJobs() []*Job {
+	if x != nil {
+		return x.Jobs
+	}
+	return nil
+}
+
+func (x *ListJobsResponse) GetTotal() int32 {
+	if x != nil {
+		return x.Total
+	}
+	return 0
+}
+
+func (x *ListJobsResponse) GetStats() []*JobKindStats {
+	if x != nil {
+		return x.Stats
+	}
+	return nil
+}
+
+// Запрос на повтор задачи
+type RetryJobRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Token         string                 `protobuf:"bytes,1,opt,name=token,proto3" json:"token,omitempty"` // JWT токен для аутентификации
+	JobId         string                 `protobuf:"bytes,2,opt,name=job_id,json=jobId,proto3" json:"job_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RetryJobRequest) Reset() {
+	*x = RetryJobRequest{}
+	mi := &file_schedule_proto_msgTypes[74]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RetryJobRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RetryJobRequest) ProtoMessage() {}
+
+func (x *RetryJobRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_schedule_proto_msgTypes[74]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RetryJobRequest.ProtoReflect.Descriptor instead.
+func (*RetryJobRequest) Descriptor() ([]byte, []int) {
+	return file_schedule_proto_rawDescGZIP(), []int{74}
+}
+
+func (x *RetryJobRequest) GetToken() string {
+	if x != nil {
+		return x.Token
+	}
+	return ""
+}
+
+func (x *RetryJobRequest) GetJobId() string {
+	if x != nil {
+		return x.JobId
+	}
+	return ""
+}
+
+// Ответ на повтор задачи
+type RetryJobResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Success       bool                   `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
+	Message       string                 `protobuf:"bytes,2,opt,name=message,proto3" json:"message,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RetryJobResponse) Reset() {
+	*x = RetryJobResponse{}
+	mi := &file_schedule_proto_msgTypes[75]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RetryJobResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RetryJobResponse) ProtoMessage() {}
+
+func (x *RetryJobResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_schedule_proto_msgTypes[75]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RetryJobResponse.ProtoReflect.Descriptor instead.
+func (*RetryJobResponse) Descriptor() ([]byte, []int) {
+	return file_schedule_proto_rawDescGZIP(), []int{75}
+}
+
+func (x *RetryJobResponse) GetSuccess() bool {
+	if x != nil {
+		return x.Success
+	}
+	return false
+}
+
+func (x *RetryJobResponse) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+// Флаг функции
+type FeatureFlag struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Name          string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"` // Например "changes.moderated"
+	Enabled       bool                   `protobuf:"varint,2,opt,name=enabled,proto3" json:"enabled,omitempty"`
+	Percentage    int32                  `protobuf:"varint,3,opt,name=percentage,proto3" json:"percentage,omitempty"` // Доля пользователей (0-100), для которых включен флаг
+	Description   string                 `protobuf:"bytes,4,opt,name=description,proto3" json:"description,omitempty"`
+	IsDefault     bool                   `protobuf:"varint,5,opt,name=is_default,json=isDefault,proto3" json:"is_default,omitempty"` // Флага нет в базе, действует значение по умолчанию для окружения
+	UpdatedBy     string                 `protobuf:"bytes,6,opt,name=updated_by,json=updatedBy,proto3" json:"updated_by,omitempty"`
+	UpdatedAt     *timestamppb.Timestamp `protobuf:"bytes,7,opt,name=updated_at,json=updatedAt,proto3" json:"updated_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *FeatureFlag) Reset() {
+	*x = FeatureFlag{}
+	mi := &file_schedule_proto_msgTypes[76]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *FeatureFlag) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*FeatureFlag) ProtoMessage() {}
+
+func (x *FeatureFlag) ProtoReflect() protoreflect.Message {
+	mi := &file_schedule_proto_msgTypes[76]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use FeatureFlag.ProtoReflect.Descriptor instead.
+func (*FeatureFlag) Descriptor() ([]byte, []int) {
+	return file_schedule_proto_rawDescGZIP(), []int{76}
+}
+
+func (x *FeatureFlag) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *FeatureFlag) GetEnabled() bool {
+	if x != nil {
+		return x.Enabled
+	}
+	return false
+}
+
+func (x *FeatureFlag) GetPercentage() int32 {
+	if x != nil {
+		return x.Percentage
+	}
+	return 0
+}
+
+func (x *FeatureFlag) GetDescription() string {
+	if x != nil {
+		return x.Description
+	}
+	return ""
+}
+
+func (x *FeatureFlag) GetIsDefault() bool {
+	if x != nil {
+		return x.IsDefault
+	}
+	return false
+}
+
+func (x *FeatureFlag) GetUpdatedBy() string {
+	if x != nil {
+		return x.UpdatedBy
+	}
+	return ""
+}
+
+func (x *FeatureFlag) GetUpdatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.UpdatedAt
+	}
+	return nil
+}
+
+// Запрос списка флагов функций
+type ListFeatureFlagsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Token         string                 `protobuf:"bytes,1,opt,name=token,proto3" json:"token,omitempty"` // JWT токен для аутентификации
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListFeatureFlagsRequest) Reset() {
+	*x = ListFeatureFlagsRequest{}
+	mi := &file_schedule_proto_msgTypes[77]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListFeatureFlagsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListFeatureFlagsRequest) ProtoMessage() {}
+
+func (x *ListFeatureFlagsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_schedule_proto_msgTypes[77]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListFeatureFlagsRequest.ProtoReflect.Descriptor instead.
+func (*ListFeatureFlagsRequest) Descriptor() ([]byte, []int) {
+	return file_schedule_proto_rawDescGZIP(), []int{77}
+}
+
+func (x *ListFeatureFlagsRequest) GetToken() string {
+	if x != nil {
+		return x.Token
+	}
+	return ""
+}
+
+// Ответ со списком флагов функций
+type ListFeatureFlagsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Success       bool                   `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
+	Message       string                 `protobuf:"bytes,2,opt,name=message,proto3" json:"message,omitempty"`
+	Flags         []*FeatureFlag         `protobuf:"bytes,3,rep,name=flags,proto3" json:"flags,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListFeatureFlagsResponse) Reset() {
+	*x = ListFeatureFlagsResponse{}
+	mi := &file_schedule_proto_msgTypes[78]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListFeatureFlagsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListFeatureFlagsResponse) ProtoMessage() {}
+
+func (x *ListFeatureFlagsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_schedule_proto_msgTypes[78]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListFeatureFlagsResponse.ProtoReflect.Descriptor instead.
+func (*ListFeatureFlagsResponse) Descriptor() ([]byte, []int) {
+	return file_schedule_proto_rawDescGZIP(), []int{78}
+}
+
+func (x *ListFeatureFlagsResponse) GetSuccess() bool {
+	if x != nil {
+		return x.Success
+	}
+	return false
+}
+
+func (x *ListFeatureFlagsResponse) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+func (x *ListFeatureFlagsResponse) GetFlags() []*FeatureFlag {
+	if x != nil {
+		return x.Flags
+	}
+	return nil
+}
+
+// Запрос на изменение флага функции
+type SetFeatureFlagRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Token         string                 `protobuf:"bytes,1,opt,name=token,proto3" json:"token,omitempty"` // JWT токен для аутентификации
+	Name          string                 `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	Enabled       bool                   `protobuf:"varint,3,opt,name=enabled,proto3" json:"enabled,omitempty"`
+	Percentage    int32                  `protobuf:"varint,4,opt,name=percentage,proto3" json:"percentage,omitempty"` // 0-100; 0 - для всех пользователей
+	Description   string                 `protobuf:"bytes,5,opt,name=description,proto3" json:"description,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SetFeatureFlagRequest) Reset() {
+	*x = SetFeatureFlagRequest{}
+	mi := &file_schedule_proto_msgTypes[79]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SetFeatureFlagRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SetFeatureFlagRequest) ProtoMessage() {}
+
+func (x *SetFeatureFlagRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_schedule_proto_msgTypes[79]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SetFeatureFlagRequest.ProtoReflect.Descriptor instead.
+func (*SetFeatureFlagRequest) Descriptor() ([]byte, []int) {
+	return file_schedule_proto_rawDescGZIP(), []int{79}
+}
+
+func (x *SetFeatureFlagRequest) GetToken() string {
+	if x != nil {
+		return x.Token
+	}
+	return ""
+}
+
+func (x *SetFeatureFlagRequest) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *SetFeatureFlagRequest) GetEnabled() bool {
+	if x != nil {
+		return x.Enabled
+	}
+	return false
+}
+
+func (x *SetFeatureFlagRequest) GetPercentage() int32 {
+	if x != nil {
+		return x.Percentage
+	}
+	return 0
+}
+
+func (x *SetFeatureFlagRequest) GetDescription() string {
+	if x != nil {
+		return x.Description
+	}
+	return ""
+}
+
+// Ответ на изменение флага функции
+type SetFeatureFlagResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Success       bool                   `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
+	Message       string                 `protobuf:"bytes,2,opt,name=message,proto3" json:"message,omitempty"`
+	Flag          *FeatureFlag           `protobuf:"bytes,3,opt,name=flag,proto3" json:"flag,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SetFeatureFlagResponse) Reset() {
+	*x = SetFeatureFlagResponse{}
+	mi := &file_schedule_proto_msgTypes[80]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SetFeatureFlagResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SetFeatureFlagResponse) ProtoMessage() {}
+
+func (x *SetFeatureFlagResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_schedule_proto_msgTypes[80]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SetFeatureFlagResponse.ProtoReflect.Descriptor instead.
+func (*SetFeatureFlagResponse) Descriptor() ([]byte, []int) {
+	return file_schedule_proto_rawDescGZIP(), []int{80}
+}
+
+func (x *SetFeatureFlagResponse) GetSuccess() bool {
+	if x != nil {
+		return x.Success
+	}
+	return false
+}
+
+func (x *SetFeatureFlagResponse) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+func (x *SetFeatureFlagResponse) GetFlag() *FeatureFlag {
+	if x != nil {
+		return x.Flag
+	}
+	return nil
+}
+
+// Запрос на сброс флага функции
+type ResetFeatureFlagRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Token         string                 `protobuf:"bytes,1,opt,name=token,proto3" json:"token,omitempty"` // JWT токен для аутентификации
+	Name          string                 `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ResetFeatureFlagRequest) Reset() {
+	*x = ResetFeatureFlagRequest{}
+	mi := &file_schedule_proto_msgTypes[81]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ResetFeatureFlagRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ResetFeatureFlagRequest) ProtoMessage() {}
+
+func (x *ResetFeatureFlagRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_schedule_proto_msgTypes[81]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ResetFeatureFlagRequest.ProtoReflect.Descriptor instead.
+func (*ResetFeatureFlagRequest) Descriptor() ([]byte, []int) {
+	return file_schedule_proto_rawDescGZIP(), []int{81}
+}
+
+func (x *ResetFeatureFlagRequest) GetToken() string {
+	if x != nil {
+		return x.Token
+	}
+	return ""
+}
+
+func (x *ResetFeatureFlagRequest) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+// Ответ на сброс флага функции
+type ResetFeatureFlagResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Success       bool                   `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
+	Message       string                 `protobuf:"bytes,2,opt,name=message,proto3" json:"message,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ResetFeatureFlagResponse) Reset() {
+	*x = ResetFeatureFlagResponse{}
+	mi := &file_schedule_proto_msgTypes[82]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ResetFeatureFlagResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ResetFeatureFlagResponse) ProtoMessage() {}
+
+func (x *ResetFeatureFlagResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_schedule_proto_msgTypes[82]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ResetFeatureFlagResponse.ProtoReflect.Descriptor instead.
+func (*ResetFeatureFlagResponse) Descriptor() ([]byte, []int) {
+	return file_schedule_proto_rawDescGZIP(), []int{82}
+}
+
+func (x *ResetFeatureFlagResponse) GetSuccess() bool {
+	if x != nil {
+		return x.Success
+	}
+	return false
+}
+
+func (x *ResetFeatureFlagResponse) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+// Запрос ссылок для подписки на календарь
+type GetCalendarSubscriptionRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Token         string                 `protobuf:"bytes,1,opt,name=token,proto3" json:"token,omitempty"`            // JWT токен для аутентификации
+	Regenerate    bool                   `protobuf:"varint,2,opt,name=regenerate,proto3" json:"regenerate,omitempty"` // Выдать новые ссылки: выданные ранее перестают работать
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetCalendarSubscriptionRequest) Reset() {
+	*x = GetCalendarSubscriptionRequest{}
+	mi := &file_schedule_proto_msgTypes[83]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetCalendarSubscriptionRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetCalendarSubscriptionRequest) ProtoMessage() {}
+
+func (x *GetCalendarSubscriptionRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_schedule_proto_msgTypes[83]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetCalendarSubscriptionRequest.ProtoReflect.Descriptor instead.
+func (*GetCalendarSubscriptionRequest) Descriptor() ([]byte, []int) {
+	return file_schedule_proto_rawDescGZIP(), []int{83}
+}
+
+func (x *GetCalendarSubscriptionRequest) GetToken() string {
+	if x != nil {
+		return x.Token
+	}
+	return ""
+}
+
+func (x *GetCalendarSubscriptionRequest) GetRegenerate() bool {
+	if x != nil {
+		return x.Regenerate
+	}
+	return false
+}
+
+// Ссылки для подписки на личное расписание. Ссылки постоянные (до перевыпуска
+// с regenerate): календарь обновляется приложением без входа в аккаунт
+type GetCalendarSubscriptionResponse struct {
+	state             protoimpl.MessageState `protogen:"open.v1"`
+	Success           bool                   `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
+	Message           string                 `protobuf:"bytes,2,opt,name=message,proto3" json:"message,omitempty"`
+	FeedUrl           string                 `protobuf:"bytes,3,opt,name=feed_url,json=feedUrl,proto3" json:"feed_url,omitempty"`                                 // Ссылка на файл ICS
+	WebcalUrl         string                 `protobuf:"bytes,4,opt,name=webcal_url,json=webcalUrl,proto3" json:"webcal_url,omitempty"`                           // webcal:// - открывает подписку в календаре устройства
+	GoogleCalendarUrl string                 `protobuf:"bytes,5,opt,name=google_calendar_url,json=googleCalendarUrl,proto3" json:"google_calendar_url,omitempty"` // Добавление подписки в Google Календарь
+	unknownFields     protoimpl.UnknownFields
+	sizeCache         protoimpl.SizeCache
+}
+
+func (x *GetCalendarSubscriptionResponse) Reset() {
+	*x = GetCalendarSubscriptionResponse{}
+	mi := &file_schedule_proto_msgTypes[84]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetCalendarSubscriptionResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetCalendarSubscriptionResponse) ProtoMessage() {}
+
+func (x *GetCalendarSubscriptionResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_schedule_proto_msgTypes[84]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetCalendarSubscriptionResponse.ProtoReflect.Descriptor instead.
+func (*GetCalendarSubscriptionResponse) Descriptor() ([]byte, []int) {
+	return file_schedule_proto_rawDescGZIP(), []int{84}
+}
+
+func (x *GetCalendarSubscriptionResponse) GetSuccess() bool {
+	if x != nil {
+		return x.Success
+	}
+	return false
+}
+
+func (x *GetCalendarSubscriptionResponse) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+func (x *GetCalendarSubscriptionResponse) GetFeedUrl() string {
+	if x != nil {
+		return x.FeedUrl
+	}
+	return ""
+}
+
+func (x *GetCalendarSubscriptionResponse) GetWebcalUrl() string {
+	if x != nil {
+		return x.WebcalUrl
+	}
+	return ""
+}
+
+func (x *GetCalendarSubscriptionResponse) GetGoogleCalendarUrl() string {
+	if x != nil {
+		return x.GoogleCalendarUrl
+	}
+	return ""
+}
+
+// Вебхук группового чата, в который публикуются сводки изменений группы
+type GroupWebhook struct {
+	state           protoimpl.MessageState `protogen:"open.v1"`
+	Id              string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	GroupName       string                 `protobuf:"bytes,2,opt,name=group_name,json=groupName,proto3" json:"group_name,omitempty"`
+	Provider        WebhookProvider        `protobuf:"varint,3,opt,name=provider,proto3,enum=schedule.WebhookProvider" json:"provider,omitempty"`
+	Url             string                 `protobuf:"bytes,4,opt,name=url,proto3" json:"url,omitempty"`
+	UpdatedAt       *timestamppb.Timestamp `protobuf:"bytes,5,opt,name=updated_at,json=updatedAt,proto3" json:"updated_at,omitempty"`
+	LastDeliveredAt *timestamppb.Timestamp `protobuf:"bytes,6,opt,name=last_delivered_at,json=lastDeliveredAt,proto3" json:"last_delivered_at,omitempty"` // Последняя успешная отправка
+	LastError       string                 `protobuf:"bytes,7,opt,name=last_error,json=lastError,proto3" json:"last_error,omitempty"`                     // Ошибка последней отправки (пусто - успешна)
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *GroupWebhook) Reset() {
+	*x = GroupWebhook{}
+	mi := &file_schedule_proto_msgTypes[85]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GroupWebhook) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GroupWebhook) ProtoMessage() {}
+
+func (x *GroupWebhook) ProtoReflect() protoreflect.Message {
+	mi := &file_schedule_proto_msgTypes[85]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GroupWebhook.ProtoReflect.Descriptor instead.
+func (*GroupWebhook) Descriptor() ([]byte, []int) {
+	return file_schedule_proto_rawDescGZIP(), []int{85}
+}
+
+func (x *GroupWebhook) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *GroupWebhook) GetGroupName() string {
+	if x != nil {
+		return x.GroupName
+	}
+	return ""
+}
+
+func (x *GroupWebhook) GetProvider() WebhookProvider {
+	if x != nil {
+		return x.Provider
+	}
+	return WebhookProvider_WEBHOOK_PROVIDER_UNSPECIFIED
+}
+
+func (x *GroupWebhook) GetUrl() string {
+	if x != nil {
+		return x.Url
+	}
+	return ""
+}
+
+func (x *GroupWebhook) GetUpdatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.UpdatedAt
+	}
+	return nil
+}
+
+func (x *GroupWebhook) GetLastDeliveredAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.LastDeliveredAt
+	}
+	return nil
+}
+
+func (x *GroupWebhook) GetLastError() string {
+	if x != nil {
+		return x.LastError
+	}
+	return ""
+}
+
+// Запрос на получение вебхуков групп
+type ListGroupWebhooksRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Token         string                 `protobuf:"bytes,1,opt,name=token,proto3" json:"token,omitempty"` // JWT токен для аутентификации
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListGroupWebhooksRequest) Reset() {
+	*x = ListGroupWebhooksRequest{}
+	mi := &file_schedule_proto_msgTypes[86]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListGroupWebhooksRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListGroupWebhooksRequest) ProtoMessage() {}
+
+func (x *ListGroupWebhooksRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_schedule_proto_msgTypes[86]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListGroupWebhooksRequest.ProtoReflect.Descriptor instead.
+func (*ListGroupWebhooksRequest) Descriptor() ([]byte, []int) {
+	return file_schedule_proto_rawDescGZIP(), []int{86}
+}
+
+func (x *ListGroupWebhooksRequest) GetToken() string {
+	if x != nil {
+		return x.Token
+	}
+	return ""
+}
+
+// Ответ с вебхуками групп
+type ListGroupWebhooksResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Success       bool                   `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
+	Message       string                 `protobuf:"bytes,2,opt,name=message,proto3" json:"message,omitempty"`
+	Webhooks      []*GroupWebhook        `protobuf:"bytes,3,rep,name=webhooks,proto3" json:"webhooks,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListGroupWebhooksResponse) Reset() {
+	*x = ListGroupWebhooksResponse{}
+	mi := &file_schedule_proto_msgTypes[87]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListGroupWebhooksResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListGroupWebhooksResponse) ProtoMessage() {}
+
+func (x *ListGroupWebhooksResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_schedule_proto_msgTypes[87]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListGroupWebhooksResponse.ProtoReflect.Descriptor instead.
+func (*ListGroupWebhooksResponse) Descriptor() ([]byte, []int) {
+	return file_schedule_proto_rawDescGZIP(), []int{87}
+}
+
+func (x *ListGroupWebhooksResponse) GetSuccess() bool {
+	if x != nil {
+		return x.Success
+	}
+	return false
+}
+
+func (x *ListGroupWebhooksResponse) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+func (x *ListGroupWebhooksResponse) GetWebhooks() []*GroupWebhook {
+	if x != nil {
+		return x.Webhooks
+	}
+	return nil
+}
+
+// Запрос на настройку вебхука группы; заменяет существующий вебхук группы
+type SetGroupWebhookRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Token         string                 `protobuf:"bytes,1,opt,name=token,proto3" json:"token,omitempty"` // JWT токен для аутентификации
+	GroupName     string                 `protobuf:"bytes,2,opt,name=group_name,json=groupName,proto3" json:"group_name,omitempty"`
+	Provider      WebhookProvider        `protobuf:"varint,3,opt,name=provider,proto3,enum=schedule.WebhookProvider" json:"provider,omitempty"`
+	Url           string                 `protobuf:"bytes,4,opt,name=url,proto3" json:"url,omitempty"` // https://discord.com/api/webhooks/... или https://hooks.slack.com/...
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SetGroupWebhookRequest) Reset() {
+	*x = SetGroupWebhookRequest{}
+	mi := &file_schedule_proto_msgTypes[88]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SetGroupWebhookRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SetGroupWebhookRequest) ProtoMessage() {}
+
+func (x *SetGroupWebhookRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_schedule_proto_msgTypes[88]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SetGroupWebhookRequest.ProtoReflect.Descriptor instead.
+func (*SetGroupWebhookRequest) Descriptor() ([]byte, []int) {
+	return file_schedule_proto_rawDescGZIP(), []int{88}
+}
+
+func (x *SetGroupWebhookRequest) GetToken() string {
+	if x != nil {
+		return x.Token
+	}
+	return ""
+}
+
+func (x *SetGroupWebhookRequest) GetGroupName() string {
+	if x != nil {
+		return x.GroupName
+	}
+	return ""
+}
+
+func (x *SetGroupWebhookRequest) GetProvider() WebhookProvider {
+	if x != nil {
+		return x.Provider
+	}
+	return WebhookProvider_WEBHOOK_PROVIDER_UNSPECIFIED
+}
+
+func (x *SetGroupWebhookRequest) GetUrl() string {
+	if x != nil {
+		return x.Url
+	}
+	return ""
+}
+
+// Ответ на настройку вебхука группы
+type SetGroupWebhookResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Success       bool                   `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
+	Message       string                 `protobuf:"bytes,2,opt,name=message,proto3" json:"message,omitempty"`
+	Webhook       *GroupWebhook          `protobuf:"bytes,3,opt,name=webhook,proto3" json:"webhook,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SetGroupWebhookResponse) Reset() {
+	*x = SetGroupWebhookResponse{}
+	mi := &file_schedule_proto_msgTypes[89]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SetGroupWebhookResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SetGroupWebhookResponse) ProtoMessage() {}
+
+func (x *SetGroupWebhookResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_schedule_proto_msgTypes[89]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SetGroupWebhookResponse.ProtoReflect.Descriptor instead.
+func (*SetGroupWebhookResponse) Descriptor() ([]byte, []int) {
+	return file_schedule_proto_rawDescGZIP(), []int{89}
+}
+
+func (x *SetGroupWebhookResponse) GetSuccess() bool {
+	if x != nil {
+		return x.Success
+	}
+	return false
+}
+
+func (x *SetGroupWebhookResponse) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+func (x *SetGroupWebhookResponse) GetWebhook() *GroupWebhook {
+	if x != nil {
+		return x.Webhook
+	}
+	return nil
+}
+
+// Запрос на удаление вебхука группы
+type DeleteGroupWebhookRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Token         string                 `protobuf:"bytes,1,opt,name=token,proto3" json:"token,omitempty"` // JWT токен для аутентификации
+	GroupName     string                 `protobuf:"bytes,2,opt,name=group_name,json=groupName,proto3" json:"group_name,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeleteGroupWebhookRequest) Reset() {
+	*x = DeleteGroupWebhookRequest{}
+	mi := &file_schedule_proto_msgTypes[90]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteGroupWebhookRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteGroupWebhookRequest) ProtoMessage() {}
+
+func (x *DeleteGroupWebhookRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_schedule_proto_msgTypes[90]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteGroupWebhookRequest.ProtoReflect.Descriptor instead.
+func (*DeleteGroupWebhookRequest) Descriptor() ([]byte, []int) {
+	return file_schedule_proto_rawDescGZIP(), []int{90}
+}
+
+func (x *DeleteGroupWebhookRequest) GetToken() string {
+	if x != nil {
+		return x.Token
+	}
+	return ""
+}
+
+func (x *DeleteGroupWebhookRequest) GetGroupName() string {
+	if x != nil {
+		return x.GroupName
+	}
+	return ""
+}
+
+// Ответ на удаление вебхука группы
+type DeleteGroupWebhookResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Success       bool                   `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
+	Message       string                 `protobuf:"bytes,2,opt,name=message,proto3" json:"message,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeleteGroupWebhookResponse) Reset() {
+	*x = DeleteGroupWebhookResponse{}
+	mi := &file_schedule_proto_msgTypes[91]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteGroupWebhookResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteGroupWebhookResponse) ProtoMessage() {}
+
+func (x *DeleteGroupWebhookResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_schedule_proto_msgTypes[91]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteGroupWebhookResponse.ProtoReflect.Descriptor instead.
+func (*DeleteGroupWebhookResponse) Descriptor() ([]byte, []int) {
+	return file_schedule_proto_rawDescGZIP(), []int{91}
+}
+
+func (x *DeleteGroupWebhookResponse) GetSuccess() bool {
+	if x != nil {
+		return x.Success
+	}
+	return false
+}
+
+func (x *DeleteGroupWebhookResponse) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+// Запрос расписания группы на неделю в PDF
+type GetTimetablePDFRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Token         string                 `protobuf:"bytes,1,opt,name=token,proto3" json:"token,omitempty"` // JWT токен для аутентификации
+	GroupName     string                 `protobuf:"bytes,2,opt,name=group_name,json=groupName,proto3" json:"group_name,omitempty"`
+	Date          *timestamppb.Timestamp `protobuf:"bytes,3,opt,name=date,proto3" json:"date,omitempty"` // Любой день недели (по умолчанию текущая неделя)
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetTimetablePDFRequest) Reset() {
+	*x = GetTimetablePDFRequest{}
+	mi := &file_schedule_proto_msgTypes[92]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetTimetablePDFRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetTimetablePDFRequest) ProtoMessage() {}
+
+func (x *GetTimetablePDFRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_schedule_proto_msgTypes[92]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetTimetablePDFRequest.ProtoReflect.Descriptor instead.
+func (*GetTimetablePDFRequest) Descriptor() ([]byte, []int) {
+	return file_schedule_proto_rawDescGZIP(), []int{92}
+}
+
+func (x *GetTimetablePDFRequest) GetToken() string {
+	if x != nil {
+		return x.Token
+	}
+	return ""
+}
+
+func (x *GetTimetablePDFRequest) GetGroupName() string {
+	if x != nil {
+		return x.GroupName
+	}
+	return ""
+}
+
+func (x *GetTimetablePDFRequest) GetDate() *timestamppb.Timestamp {
+	if x != nil {
+		return x.Date
+	}
+	return nil
+}
+
+// Расписание группы на неделю в PDF
+type GetTimetablePDFResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Success       bool                   `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
+	Message       string                 `protobuf:"bytes,2,opt,name=message,proto3" json:"message,omitempty"`
+	Pdf           []byte                 `protobuf:"bytes,3,opt,name=pdf,proto3" json:"pdf,omitempty"`
+	FileName      string                 `protobuf:"bytes,4,opt,name=file_name,json=fileName,proto3" json:"file_name,omitempty"`          // Имя файла для сохранения, например "ИС-21_2026-02-02.pdf"
+	DownloadUrl   string                 `protobuf:"bytes,5,opt,name=download_url,json=downloadUrl,proto3" json:"download_url,omitempty"` // Подписанная ссылка на копию в хранилище файлов (пусто, если хранилище не настроено)
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetTimetablePDFResponse) Reset() {
+	*x = GetTimetablePDFResponse{}
+	mi := &file_schedule_proto_msgTypes[93]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetTimetablePDFResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetTimetablePDFResponse) ProtoMessage() {}
+
+func (x *GetTimetablePDFResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_schedule_proto_msgTypes[93]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetTimetablePDFResponse.ProtoReflect.Descriptor instead.
+func (*GetTimetablePDFResponse) Descriptor() ([]byte, []int) {
+	return file_schedule_proto_rawDescGZIP(), []int{93}
+}
+
+func (x *GetTimetablePDFResponse) GetSuccess() bool {
+	if x != nil {
+		return x.Success
+	}
+	return false
+}
+
+func (x *GetTimetablePDFResponse) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+func (x *GetTimetablePDFResponse) GetPdf() []byte {
+	if x != nil {
+		return x.Pdf
+	}
+	return nil
+}
+
+func (x *GetTimetablePDFResponse) GetFileName() string {
+	if x != nil {
+		return x.FileName
+	}
+	return ""
+}
+
+func (x *GetTimetablePDFResponse) GetDownloadUrl() string {
+	if x != nil {
+		return x.DownloadUrl
+	}
+	return ""
+}
+
+// Запрос загрузки справочника преподавателей
+type ImportTeacherDirectoryRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Token         string                 `protobuf:"bytes,1,opt,name=token,proto3" json:"token,omitempty"`      // JWT токен для аутентификации
+	Csv           []byte                 `protobuf:"bytes,2,opt,name=csv,proto3" json:"csv,omitempty"`          // Таблица с колонками ФИО, кафедра, должность (разделитель "," или ";")
+	Replace       bool                   `protobuf:"varint,3,opt,name=replace,proto3" json:"replace,omitempty"` // Удалить из справочника преподавателей, которых нет в списке
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ImportTeacherDirectoryRequest) Reset() {
+	*x = ImportTeacherDirectoryRequest{}
+	mi := &file_schedule_proto_msgTypes[94]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ImportTeacherDirectoryRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ImportTeacherDirectoryRequest) ProtoMessage() {}
+
+func (x *ImportTeacherDirectoryRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_schedule_proto_msgTypes[94]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ImportTeacherDirectoryRequest.ProtoReflect.Descriptor instead.
+func (*ImportTeacherDirectoryRequest) Descriptor() ([]byte, []int) {
+	return file_schedule_proto_rawDescGZIP(), []int{94}
+}
+
+func (x *ImportTeacherDirectoryRequest) GetToken() string {
+	if x != nil {
+		return x.Token
+	}
+	return ""
+}
+
+func (x *ImportTeacherDirectoryRequest) GetCsv() []byte {
+	if x != nil {
+		return x.Csv
+	}
+	return nil
+}
+
+func (x *ImportTeacherDirectoryRequest) GetReplace() bool {
+	if x != nil {
+		return x.Replace
+	}
+	return false
+}
+
+// Итог загрузки справочника преподавателей
+type ImportTeacherDirectoryResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Success       bool                   `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
+	Message       string                 `protobuf:"bytes,2,opt,name=message,proto3" json:"message,omitempty"`
+	Total         int32                  `protobuf:"varint,3,opt,name=total,proto3" json:"total,omitempty"` // Преподавателей в списке
+	Created       int32                  `protobuf:"varint,4,opt,name=created,proto3" json:"created,omitempty"`
+	Updated       int32                  `protobuf:"varint,5,opt,name=updated,proto3" json:"updated,omitempty"`
+	Removed       int32                  `protobuf:"varint,6,opt,name=removed,proto3" json:"removed,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ImportTeacherDirectoryResponse) Reset() {
+	*x = ImportTeacherDirectoryResponse{}
+	mi := &file_schedule_proto_msgTypes[95]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ImportTeacherDirectoryResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ImportTeacherDirectoryResponse) ProtoMessage() {}
+
+func (x *ImportTeacherDirectoryResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_schedule_proto_msgTypes[95]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ImportTeacherDirectoryResponse.ProtoReflect.Descriptor instead.
+func (*ImportTeacherDirectoryResponse) Descriptor() ([]byte, []int) {
+	return file_schedule_proto_rawDescGZIP(), []int{95}
+}
+
+func (x *ImportTeacherDirectoryResponse) GetSuccess() bool {
+	if x != nil {
+		return x.Success
+	}
+	return false
+}
+
+func (x *ImportTeacherDirectoryResponse) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+func (x *ImportTeacherDirectoryResponse) GetTotal() int32 {
+	if x != nil {
+		return x.Total
+	}
+	return 0
+}
+
+func (x *ImportTeacherDirectoryResponse) GetCreated() int32 {
+	if x != nil {
+		return x.Created
+	}
+	return 0
+}
+
+func (x *ImportTeacherDirectoryResponse) GetUpdated() int32 {
+	if x != nil {
+		return x.Updated
+	}
+	return 0
+}
+
+func (x *ImportTeacherDirectoryResponse) GetRemoved() int32 {
+	if x != nil {
+		return x.Removed
+	}
+	return 0
+}
+
+// Запрос установки ссылки на онлайн-занятие
+type SetLessonMeetingUrlRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Token         string                 `protobuf:"bytes,1,opt,name=token,proto3" json:"token,omitempty"`                             // JWT токен для аутентификации
+	EntryId       string                 `protobuf:"bytes,2,opt,name=entry_id,json=entryId,proto3" json:"entry_id,omitempty"`          // ID записи актуального расписания (ScheduleEntry.id)
+	MeetingUrl    string                 `protobuf:"bytes,3,opt,name=meeting_url,json=meetingUrl,proto3" json:"meeting_url,omitempty"` // Ссылка http(s); пусто - занятие снова очное
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SetLessonMeetingUrlRequest) Reset() {
+	*x = SetLessonMeetingUrlRequest{}
+	mi := &file_schedule_proto_msgTypes[96]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SetLessonMeetingUrlRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SetLessonMeetingUrlRequest) ProtoMessage() {}
+
+func (x *SetLessonMeetingUrlRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_schedule_proto_msgTypes[96]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SetLessonMeetingUrlRequest.ProtoReflect.Descriptor instead.
+func (*SetLessonMeetingUrlRequest) Descriptor() ([]byte, []int) {
+	return file_schedule_proto_rawDescGZIP(), []int{96}
+}
+
+func (x *SetLessonMeetingUrlRequest) GetToken() string {
+	if x != nil {
+		return x.Token
+	}
+	return ""
+}
+
+func (x *SetLessonMeetingUrlRequest) GetEntryId() string {
+	if x != nil {
+		return x.EntryId
+	}
+	return ""
+}
+
+func (x *SetLessonMeetingUrlRequest) GetMeetingUrl() string {
+	if x != nil {
+		return x.MeetingUrl
+	}
+	return ""
+}
+
+// Результат установки ссылки на онлайн-занятие
+type SetLessonMeetingUrlResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Success       bool                   `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
+	Message       string                 `protobuf:"bytes,2,opt,name=message,proto3" json:"message,omitempty"`
+	Entry         *ScheduleEntry         `protobuf:"bytes,3,opt,name=entry,proto3" json:"entry,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SetLessonMeetingUrlResponse) Reset() {
+	*x = SetLessonMeetingUrlResponse{}
+	mi := &file_schedule_proto_msgTypes[97]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SetLessonMeetingUrlResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SetLessonMeetingUrlResponse) ProtoMessage() {}
+
+func (x *SetLessonMeetingUrlResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_schedule_proto_msgTypes[97]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SetLessonMeetingUrlResponse.ProtoReflect.Descriptor instead.
+func (*SetLessonMeetingUrlResponse) Descriptor() ([]byte, []int) {
+	return file_schedule_proto_rawDescGZIP(), []int{97}
+}
+
+func (x *SetLessonMeetingUrlResponse) GetSuccess() bool {
+	if x != nil {
+		return x.Success
+	}
+	return false
+}
+
+func (x *SetLessonMeetingUrlResponse) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+func (x *SetLessonMeetingUrlResponse) GetEntry() *ScheduleEntry {
+	if x != nil {
+		return x.Entry
+	}
+	return nil
+}
+
+// Еженедельное занятие факультатива
+type ElectiveSlot struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Weekday       int32                  `protobuf:"varint,1,opt,name=weekday,proto3" json:"weekday,omitempty"`                     // День недели: 1 - понедельник, 7 - воскресенье
+	TimeStart     string                 `protobuf:"bytes,2,opt,name=time_start,json=timeStart,proto3" json:"time_start,omitempty"` // ЧЧ:ММ
+	TimeEnd       string                 `protobuf:"bytes,3,opt,name=time_end,json=timeEnd,proto3" json:"time_end,omitempty"`       // ЧЧ:ММ
+	Classroom     string                 `protobuf:"bytes,4,opt,name=classroom,proto3" json:"classroom,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ElectiveSlot) Reset() {
+	*x = ElectiveSlot{}
+	mi := &file_schedule_proto_msgTypes[98]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ElectiveSlot) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ElectiveSlot) ProtoMessage() {}
+
+func (x *ElectiveSlot) ProtoReflect() protoreflect.Message {
+	mi := &file_schedule_proto_msgTypes[98]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ElectiveSlot.ProtoReflect.Descriptor instead.
+func (*ElectiveSlot) Descriptor() ([]byte, []int) {
+	return file_schedule_proto_rawDescGZIP(), []int{98}
+}
+
+func (x *ElectiveSlot) GetWeekday() int32 {
+	if x != nil {
+		return x.Weekday
+	}
+	return 0
+}
+
+func (x *ElectiveSlot) GetTimeStart() string {
+	if x != nil {
+		return x.TimeStart
+	}
+	return ""
+}
+
+func (x *ElectiveSlot) GetTimeEnd() string {
+	if x != nil {
+		return x.TimeEnd
+	}
+	return ""
+}
+
+func (x *ElectiveSlot) GetClassroom() string {
+	if x != nil {
+		return x.Classroom
+	}
+	return ""
+}
+
+// Факультатив
+type ElectiveCourse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Title         string                 `protobuf:"bytes,2,opt,name=title,proto3" json:"title,omitempty"`
+	Teacher       string                 `protobuf:"bytes,3,opt,name=teacher,proto3" json:"teacher,omitempty"`
+	Description   string                 `protobuf:"bytes,4,opt,name=description,proto3" json:"description,omitempty"`
+	GroupNames    []string               `protobuf:"bytes,5,rep,name=group_names,json=groupNames,proto3" json:"group_names,omitempty"` // Группы, которым доступна запись (пусто - всем)
+	Capacity      int32                  `protobuf:"varint,6,opt,name=capacity,proto3" json:"capacity,omitempty"`                      // Максимум студентов (0 - без ограничения)
+	Enrolled      int32                  `protobuf:"varint,7,opt,name=enrolled,proto3" json:"enrolled,omitempty"`                      // Записавшихся студентов
+	StartsOn      *timestamppb.Timestamp `protobuf:"bytes,8,opt,name=starts_on,json=startsOn,proto3" json:"starts_on,omitempty"`
+	EndsOn        *timestamppb.Timestamp `protobuf:"bytes,9,opt,name=ends_on,json=endsOn,proto3" json:"ends_on,omitempty"`
+	Slots         []*ElectiveSlot        `protobuf:"bytes,10,rep,name=slots,proto3" json:"slots,omitempty"`
+	IsEnrolled    bool                   `protobuf:"varint,11,opt,name=is_enrolled,json=isEnrolled,proto3" json:"is_enrolled,omitempty"` // Текущий пользователь записан на курс
+	IsActive      bool                   `protobuf:"varint,12,opt,name=is_active,json=isActive,proto3" json:"is_active,omitempty"`       // false - курс отменен
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ElectiveCourse) Reset() {
+	*x = ElectiveCourse{}
+	mi := &file_schedule_proto_msgTypes[99]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ElectiveCourse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ElectiveCourse) ProtoMessage() {}
+
+func (x *ElectiveCourse) ProtoReflect() protoreflect.Message {
+	mi := &file_schedule_proto_msgTypes[99]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ElectiveCourse.ProtoReflect.Descriptor instead.
+func (*ElectiveCourse) Descriptor() ([]byte, []int) {
+	return file_schedule_proto_rawDescGZIP(), []int{99}
+}
+
+func (x *ElectiveCourse) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *ElectiveCourse) GetTitle() string {
+	if x != nil {
+		return x.Title
+	}
+	return ""
+}
+
+func (x *ElectiveCourse) GetTeacher() string {
+	if x != nil {
+		return x.Teacher
+	}
+	return ""
+}
+
+func (x *ElectiveCourse) GetDescription() string {
+	if x != nil {
+		return x.Description
+	}
+	return ""
+}
+
+func (x *ElectiveCourse) GetGroupNames() []string {
+	if x != nil {
+		return x.GroupNames
+	}
+	return nil
+}
+
+func (x *ElectiveCourse) GetCapacity() int32 {
+	if x != nil {
+		return x.Capacity
+	}
+	return 0
+}
+
+func (x *ElectiveCourse) GetEnrolled() int32 {
+	if x != nil {
+		return x.Enrolled
+	}
+	return 0
+}
+
+func (x *ElectiveCourse) GetStartsOn() *timestamppb.Timestamp {
+	if x != nil {
+		return x.StartsOn
+	}
+	return nil
+}
+
+func (x *ElectiveCourse) GetEndsOn() *timestamppb.Timestamp {
+	if x != nil {
+		return x.EndsOn
+	}
+	return nil
+}
+
+func (x *ElectiveCourse) GetSlots() []*ElectiveSlot {
+	if x != nil {
+		return x.Slots
+	}
+	return nil
+}
+
+func (x *ElectiveCourse) GetIsEnrolled() bool {
+	if x != nil {
+		return x.IsEnrolled
+	}
+	return false
+}
+
+func (x *ElectiveCourse) GetIsActive() bool {
+	if x != nil {
+		return x.IsActive
+	}
+	return false
+}
+
+// Запрос создания факультатива
+type CreateElectiveCourseRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Token         string                 `protobuf:"bytes,1,opt,name=token,proto3" json:"token,omitempty"` // JWT токен для аутентификации
+	Title         string                 `protobuf:"bytes,2,opt,name=title,proto3" json:"title,omitempty"`
+	Teacher       string                 `protobuf:"bytes,3,opt,name=teacher,proto3" json:"teacher,omitempty"`
+	Description   string                 `protobuf:"bytes,4,opt,name=description,proto3" json:"description,omitempty"`
+	GroupNames    []string               `protobuf:"bytes,5,rep,name=group_names,json=groupNames,proto3" json:"group_names,omitempty"`
+	Capacity      int32                  `protobuf:"varint,6,opt,name=capacity,proto3" json:"capacity,omitempty"`
+	StartsOn      *timestamppb.Timestamp `protobuf:"bytes,7,opt,name=starts_on,json=startsOn,proto3" json:"starts_on,omitempty"`
+	EndsOn        *timestamppb.Timestamp `protobuf:"bytes,8,opt,name=ends_on,json=endsOn,proto3" json:"ends_on,omitempty"`
+	Slots         []*ElectiveSlot        `protobuf:"bytes,9,rep,name=slots,proto3" json:"slots,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CreateElectiveCourseRequest) Reset() {
+	*x = CreateElectiveCourseRequest{}
+	mi := &file_schedule_proto_msgTypes[100]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateElectiveCourseRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateElectiveCourseRequest) ProtoMessage() {}
+
+func (x *CreateElectiveCourseRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_schedule_proto_msgTypes[100]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateElectiveCourseRequest.ProtoReflect.Descriptor instead.
+func (*CreateElectiveCourseRequest) Descriptor() ([]byte, []int) {
+	return file_schedule_proto_rawDescGZIP(), []int{100}
+}
+
+func (x *CreateElectiveCourseRequest) GetToken() string {
+	if x != nil {
+		return x.Token
+	}
+	return ""
+}
+
+func (x *CreateElectiveCourseRequest) GetTitle() string {
+	if x != nil {
+		return x.Title
+	}
+	return ""
+}
+
+func (x *CreateElectiveCourseRequest) GetTeacher() string {
+	if x != nil {
+		return x.Teacher
+	}
+	return ""
+}
+
+func (x *CreateElectiveCourseRequest) GetDescription() string {
+	if x != nil {
+		return x.Description
+	}
+	return ""
+}
+
+func (x *CreateElectiveCourseRequest) GetGroupNames() []string {
+	if x != nil {
+		return x.GroupNames
+	}
+	return nil
+}
+
+func (x *CreateElectiveCourseRequest) GetCapacity() int32 {
+	if x != nil {
+		return x.Capacity
+	}
+	return 0
+}
+
+func (x *CreateElectiveCourseRequest) GetStartsOn() *timestamppb.Timestamp {
+	if x != nil {
+		return x.StartsOn
+	}
+	return nil
+}
+
+func (x *CreateElectiveCourseRequest) GetEndsOn() *timestamppb.Timestamp {
+	if x != nil {
+		return x.EndsOn
+	}
+	return nil
+}
+
+func (x *CreateElectiveCourseRequest) GetSlots() []*ElectiveSlot {
+	if x != nil {
+		return x.Slots
+	}
+	return nil
+}
+
+// Ответ на создание факультатива
+type CreateElectiveCourseResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Success       bool                   `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
+	Message       string                 `protobuf:"bytes,2,opt,name=message,proto3" json:"message,omitempty"`
+	Course        *ElectiveCourse        `protobuf:"bytes,3,opt,name=course,proto3" json:"course,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CreateElectiveCourseResponse) Reset() {
+	*x = CreateElectiveCourseResponse{}
+	mi := &file_schedule_proto_msgTypes[101]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateElectiveCourseResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateElectiveCourseResponse) ProtoMessage() {}
+
+func (x *CreateElectiveCourseResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_schedule_proto_msgTypes[101]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateElectiveCourseResponse.ProtoReflect.Descriptor instead.
+func (*CreateElectiveCourseResponse) Descriptor() ([]byte, []int) {
+	return file_schedule_proto_rawDescGZIP(), []int{101}
+}
+
+func (x *CreateElectiveCourseResponse) GetSuccess() bool {
+	if x != nil {
+		return x.Success
+	}
+	return false
+}
+
+func (x *CreateElectiveCourseResponse) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+func (x *CreateElectiveCourseResponse) GetCourse() *ElectiveCourse {
+	if x != nil {
+		return x.Course
+	}
+	return nil
+}
+
+// Запрос отмены факультатива
+type CancelElectiveCourseRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Token         string                 `protobuf:"bytes,1,opt,name=token,proto3" json:"token,omitempty"` // JWT токен для аутентификации
+	CourseId      string                 `protobuf:"bytes,2,opt,name=course_id,json=courseId,proto3" json:"course_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CancelElectiveCourseRequest) Reset() {
+	*x = CancelElectiveCourseRequest{}
+	mi := &file_schedule_proto_msgTypes[102]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CancelElectiveCourseRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CancelElectiveCourseRequest) ProtoMessage() {}
+
+func (x *CancelElectiveCourseRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_schedule_proto_msgTypes[102]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CancelElectiveCourseRequest.ProtoReflect.Descriptor instead.
+func (*CancelElectiveCourseRequest) Descriptor() ([]byte, []int) {
+	return file_schedule_proto_rawDescGZIP(), []int{102}
+}
+
+func (x *CancelElectiveCourseRequest) GetToken() string {
+	if x != nil {
+		return x.Token
+	}
+	return ""
+}
+
+func (x *CancelElectiveCourseRequest) GetCourseId() string {
+	if x != nil {
+		return x.CourseId
+	}
+	return ""
+}
+
+// Ответ на отмену факультатива
+type CancelElectiveCourseResponse struct {
+	state            protoimpl.MessageState `protogen:"open.v1"`
+	Success          bool                   `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
+	Message          string                 `protobuf:"bytes,2,opt,name=message,proto3" json:"message,omitempty"`
+	NotifiedStudents int32                  `protobuf:"varint,3,opt,name=notified_students,json=notifiedStudents,proto3" json:"notified_students,omitempty"` // Записанные студенты, получившие уведомление
+	unknownFields    protoimpl.UnknownFields
+	sizeCache        protoimpl.SizeCache
+}
+
+func (x *CancelElectiveCourseResponse) Reset() {
+	*x = CancelElectiveCourseResponse{}
+	mi := &file_schedule_proto_msgTypes[103]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CancelElectiveCourseResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CancelElectiveCourseResponse) ProtoMessage() {}
+
+func (x *CancelElectiveCourseResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_schedule_proto_msgTypes[103]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CancelElectiveCourseResponse.ProtoReflect.Descriptor instead.
+func (*CancelElectiveCourseResponse) Descriptor() ([]byte, []int) {
+	return file_schedule_proto_rawDescGZIP(), []int{103}
+}
+
+func (x *CancelElectiveCourseResponse) GetSuccess() bool {
+	if x != nil {
+		return x.Success
+	}
+	return false
+}
+
+func (x *CancelElectiveCourseResponse) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+func (x *CancelElectiveCourseResponse) GetNotifiedStudents() int32 {
+	if x != nil {
+		return x.NotifiedStudents
+	}
+	return 0
+}
+
+// Запрос списка факультативов
+type ListElectiveCoursesRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Token         string                 `protobuf:"bytes,1,opt,name=token,proto3" json:"token,omitempty"` // JWT токен для аутентификации
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListElectiveCoursesRequest) Reset() {
+	*x = ListElectiveCoursesRequest{}
+	mi := &file_schedule_proto_msgTypes[104]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListElectiveCoursesRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListElectiveCoursesRequest) ProtoMessage() {}
+
+func (x *ListElectiveCoursesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_schedule_proto_msgTypes[104]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListElectiveCoursesRequest.ProtoReflect.Descriptor instead.
+func (*ListElectiveCoursesRequest) Descriptor() ([]byte, []int) {
+	return file_schedule_proto_rawDescGZIP(), []int{104}
+}
+
+func (x *ListElectiveCoursesRequest) GetToken() string {
+	if x != nil {
+		return x.Token
+	}
+	return ""
+}
+
+// Ответ со списком факультативов
+type ListElectiveCoursesResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Success       bool                   `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
+	Message       string                 `protobuf:"bytes,2,opt,name=message,proto3" json:"message,omitempty"`
+	Courses       []*ElectiveCourse      `protobuf:"bytes,3,rep,name=courses,proto3" json:"courses,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListElectiveCoursesResponse) Reset() {
+	*x = ListElectiveCoursesResponse{}
+	mi := &file_schedule_proto_msgTypes[105]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListElectiveCoursesResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListElectiveCoursesResponse) ProtoMessage() {}
+
+func (x *ListElectiveCoursesResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_schedule_proto_msgTypes[105]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListElectiveCoursesResponse.ProtoReflect.Descriptor instead.
+func (*ListElectiveCoursesResponse) Descriptor() ([]byte, []int) {
+	return file_schedule_proto_rawDescGZIP(), []int{105}
+}
+
+func (x *ListElectiveCoursesResponse) GetSuccess() bool {
+	if x != nil {
+		return x.Success
+	}
+	return false
+}
+
+func (x *ListElectiveCoursesResponse) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+func (x *ListElectiveCoursesResponse) GetCourses() []*ElectiveCourse {
+	if x != nil {
+		return x.Courses
+	}
+	return nil
+}
+
+// Запрос записи на факультатив
+type EnrollElectiveRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Token         string                 `protobuf:"bytes,1,opt,name=token,proto3" json:"token,omitempty"` // JWT токен для аутентификации
+	CourseId      string                 `protobuf:"bytes,2,opt,name=course_id,json=courseId,proto3" json:"course_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *EnrollElectiveRequest) Reset() {
+	*x = EnrollElectiveRequest{}
+	mi := &file_schedule_proto_msgTypes[106]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *EnrollElectiveRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*EnrollElectiveRequest) ProtoMessage() {}
+
+func (x *EnrollElectiveRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_schedule_proto_msgTypes[106]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use EnrollElectiveRequest.ProtoReflect.Descriptor instead.
+func (*EnrollElectiveRequest) Descriptor() ([]byte, []int) {
+	return file_schedule_proto_rawDescGZIP(), []int{106}
+}
+
+func (x *EnrollElectiveRequest) GetToken() string {
+	if x != nil {
+		return x.Token
+	}
+	return ""
+}
+
+func (x *EnrollElectiveRequest) GetCourseId() string {
+	if x != nil {
+		return x.CourseId
+	}
+	return ""
+}
+
+// Ответ на запись на факультатив
+type EnrollElectiveResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Success       bool                   `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
+	Message       string                 `protobuf:"bytes,2,opt,name=message,proto3" json:"message,omitempty"`
+	Course        *ElectiveCourse        `protobuf:"bytes,3,opt,name=course,proto3" json:"course,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *EnrollElectiveResponse) Reset() {
+	*x = EnrollElectiveResponse{}
+	mi := &file_schedule_proto_msgTypes[107]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *EnrollElectiveResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*EnrollElectiveResponse) ProtoMessage() {}
+
+func (x *EnrollElectiveResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_schedule_proto_msgTypes[107]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use EnrollElectiveResponse.ProtoReflect.Descriptor instead.
+func (*EnrollElectiveResponse) Descriptor() ([]byte, []int) {
+	return file_schedule_proto_rawDescGZIP(), []int{107}
+}
+
+func (x *EnrollElectiveResponse) GetSuccess() bool {
+	if x != nil {
+		return x.Success
+	}
+	return false
+}
+
+func (x *EnrollElectiveResponse) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+func (x *EnrollElectiveResponse) GetCourse() *ElectiveCourse {
+	if x != nil {
+		return x.Course
+	}
+	return nil
+}
+
+// Запрос отмены записи на факультатив
+type UnenrollElectiveRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Token         string                 `protobuf:"bytes,1,opt,name=token,proto3" json:"token,omitempty"` // JWT токен для аутентификации
+	CourseId      string                 `protobuf:"bytes,2,opt,name=course_id,json=courseId,proto3" json:"course_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UnenrollElectiveRequest) Reset() {
+	*x = UnenrollElectiveRequest{}
+	mi := &file_schedule_proto_msgTypes[108]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UnenrollElectiveRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UnenrollElectiveRequest) ProtoMessage() {}
+
+func (x *UnenrollElectiveRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_schedule_proto_msgTypes[108]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UnenrollElectiveRequest.ProtoReflect.Descriptor instead.
+func (*UnenrollElectiveRequest) Descriptor() ([]byte, []int) {
+	return file_schedule_proto_rawDescGZIP(), []int{108}
+}
+
+func (x *UnenrollElectiveRequest) GetToken() string {
+	if x != nil {
+		return x.Token
+	}
+	return ""
+}
+
+func (x *UnenrollElectiveRequest) GetCourseId() string {
+	if x != nil {
+		return x.CourseId
+	}
+	return ""
+}
+
+// Ответ на отмену записи на факультатив
+type UnenrollElectiveResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Success       bool                   `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
+	Message       string                 `protobuf:"bytes,2,opt,name=message,proto3" json:"message,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UnenrollElectiveResponse) Reset() {
+	*x = UnenrollElectiveResponse{}
+	mi := &file_schedule_proto_msgTypes[109]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UnenrollElectiveResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UnenrollElectiveResponse) ProtoMessage() {}
+
+func (x *UnenrollElectiveResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_schedule_proto_msgTypes[109]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UnenrollElectiveResponse.ProtoReflect.Descriptor instead.
+func (*UnenrollElectiveResponse) Descriptor() ([]byte, []int) {
+	return file_schedule_proto_rawDescGZIP(), []int{109}
+}
+
+func (x *UnenrollElectiveResponse) GetSuccess() bool {
+	if x != nil {
+		return x.Success
+	}
+	return false
+}
+
+func (x *UnenrollElectiveResponse) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+// Запрос нагрузки преподавателей
+type GetTeacherWorkloadRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Token         string                 `protobuf:"bytes,1,opt,name=token,proto3" json:"token,omitempty"` // JWT токен для аутентификации
+	From          *timestamppb.Timestamp `protobuf:"bytes,2,opt,name=from,proto3" json:"from,omitempty"`
+	To            *timestamppb.Timestamp `protobuf:"bytes,3,opt,name=to,proto3" json:"to,omitempty"`
+	ByWeek        bool                   `protobuf:"varint,4,opt,name=by_week,json=byWeek,proto3" json:"by_week,omitempty"` // Разбивать по неделям, иначе - итог за период (семестр)
+	Pdf           bool                   `protobuf:"varint,5,opt,name=pdf,proto3" json:"pdf,omitempty"`                     // Сформировать отчет в PDF
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetTeacherWorkloadRequest) Reset() {
+	*x = GetTeacherWorkloadRequest{}
+	mi := &file_schedule_proto_msgTypes[110]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetTeacherWorkloadRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetTeacherWorkloadRequest) ProtoMessage() {}
+
+func (x *GetTeacherWorkloadRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_schedule_proto_msgTypes[110]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetTeacherWorkloadRequest.ProtoReflect.Descriptor instead.
+func (*GetTeacherWorkloadRequest) Descriptor() ([]byte, []int) {
+	return file_schedule_proto_rawDescGZIP(), []int{110}
+}
+
+func (x *GetTeacherWorkloadRequest) GetToken() string {
+	if x != nil {
+		return x.Token
+	}
+	return ""
+}
+
+func (x *GetTeacherWorkloadRequest) GetFrom() *timestamppb.Timestamp {
+	if x != nil {
+		return x.From
+	}
+	return nil
+}
+
+func (x *GetTeacherWorkloadRequest) GetTo() *timestamppb.Timestamp {
+	if x != nil {
+		return x.To
+	}
+	return nil
+}
+
+func (x *GetTeacherWorkloadRequest) GetByWeek() bool {
+	if x != nil {
+		return x.ByWeek
+	}
+	return false
+}
+
+func (x *GetTeacherWorkloadRequest) GetPdf() bool {
+	if x != nil {
+		return x.Pdf
+	}
+	return false
+}
+
+// Нагрузка преподавателя за период
+type TeacherWorkload struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Teacher       string                 `protobuf:"bytes,1,opt,name=teacher,proto3" json:"teacher,omitempty"` // Пусто - занятия без преподавателя
+	Stats         []*WorkloadStat        `protobuf:"bytes,2,rep,name=stats,proto3" json:"stats,omitempty"`     // По группам и предметам (и неделям)
+	Lessons       int32                  `protobuf:"varint,3,opt,name=lessons,proto3" json:"lessons,omitempty"`
+	Minutes       int32                  `protobuf:"varint,4,opt,name=minutes,proto3" json:"minutes,omitempty"`
+	AcademicHours float64                `protobuf:"fixed64,5,opt,name=academic_hours,json=academicHours,proto3" json:"academic_hours,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *TeacherWorkload) Reset() {
+	*x = TeacherWorkload{}
+	mi := &file_schedule_proto_msgTypes[111]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *TeacherWorkload) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TeacherWorkload) ProtoMessage() {}
+
+func (x *TeacherWorkload) ProtoReflect() protoreflect.Message {
+	mi := &file_schedule_proto_msgTypes[111]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TeacherWorkload.ProtoReflect.Descriptor instead.
+func (*TeacherWorkload) Descriptor() ([]byte, []int) {
+	return file_schedule_proto_rawDescGZIP(), []int{111}
+}
+
+func (x *TeacherWorkload) GetTeacher() string {
+	if x != nil {
+		return x.Teacher
+	}
+	return ""
+}
+
+func (x *TeacherWorkload) GetStats() []*WorkloadStat {
+	if x != nil {
+		return x.Stats
+	}
+	return nil
+}
+
+func (x *TeacherWorkload) GetLessons() int32 {
+	if x != nil {
+		return x.Lessons
+	}
+	return 0
+}
+
+func (x *TeacherWorkload) GetMinutes() int32 {
+	if x != nil {
+		return x.Minutes
+	}
+	return 0
+}
+
+func (x *TeacherWorkload) GetAcademicHours() float64 {
+	if x != nil {
+		return x.AcademicHours
+	}
+	return 0
+}
+
+// Ответ с нагрузкой преподавателей
+type GetTeacherWorkloadResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Success       bool                   `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
+	Message       string                 `protobuf:"bytes,2,opt,name=message,proto3" json:"message,omitempty"`
+	Teachers      []*TeacherWorkload     `protobuf:"bytes,3,rep,name=teachers,proto3" json:"teachers,omitempty"`
+	Pdf           []byte                 `protobuf:"bytes,4,opt,name=pdf,proto3" json:"pdf,omitempty"`                                    // Отчет в PDF, если запрошен
+	FileName      string                 `protobuf:"bytes,5,opt,name=file_name,json=fileName,proto3" json:"file_name,omitempty"`          // Имя файла отчета, например "workload_2026-09-01_2026-12-31.pdf"
+	DownloadUrl   string                 `protobuf:"bytes,6,opt,name=download_url,json=downloadUrl,proto3" json:"download_url,omitempty"` // Подписанная ссылка на копию отчета в хранилище файлов (пусто, если хранилище не настроено)
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetTeacherWorkloadResponse) Reset() {
+	*x = GetTeacherWorkloadResponse{}
+	mi := &file_schedule_proto_msgTypes[112]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetTeacherWorkloadResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetTeacherWorkloadResponse) ProtoMessage() {}
+
+func (x *GetTeacherWorkloadResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_schedule_proto_msgTypes[112]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetTeacherWorkloadResponse.ProtoReflect.Descriptor instead.
+func (*GetTeacherWorkloadResponse) Descriptor() ([]byte, []int) {
+	return file_schedule_proto_rawDescGZIP(), []int{112}
+}
+
+func (x *GetTeacherWorkloadResponse) GetSuccess() bool {
+	if x != nil {
+		return x.Success
+	}
+	return false
+}
+
+func (x *GetTeacherWorkloadResponse) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+func (x *GetTeacherWorkloadResponse) GetTeachers() []*TeacherWorkload {
+	if x != nil {
+		return x.Teachers
+	}
+	return nil
+}
+
+func (x *GetTeacherWorkloadResponse) GetPdf() []byte {
+	if x != nil {
+		return x.Pdf
+	}
+	return nil
+}
+
+func (x *GetTeacherWorkloadResponse) GetFileName() string {
+	if x != nil {
+		return x.FileName
+	}
+	return ""
+}
+
+func (x *GetTeacherWorkloadResponse) GetDownloadUrl() string {
+	if x != nil {
+		return x.DownloadUrl
+	}
+	return ""
+}
+
+// Личная заметка к паре группы в конкретный день
+type LessonNote struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	GroupName     string                 `protobuf:"bytes,2,opt,name=group_name,json=groupName,proto3" json:"group_name,omitempty"`
+	Date          *timestamppb.Timestamp `protobuf:"bytes,3,opt,name=date,proto3" json:"date,omitempty"`
+	TimeStart     string                 `protobuf:"bytes,4,opt,name=time_start,json=timeStart,proto3" json:"time_start,omitempty"` // ЧЧ:ММ
+	Subject       string                 `protobuf:"bytes,5,opt,name=subject,proto3" json:"subject,omitempty"`                      // Предмет пары на момент создания заметки
+	Text          string                 `protobuf:"bytes,6,opt,name=text,proto3" json:"text,omitempty"`
+	UpdatedAt     *timestamppb.Timestamp `protobuf:"bytes,7,opt,name=updated_at,json=updatedAt,proto3" json:"updated_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *LessonNote) Reset() {
+	*x = LessonNote{}
+	mi := &file_schedule_proto_msgTypes[113]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *LessonNote) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*LessonNote) ProtoMessage() {}
+
+func (x *LessonNote) ProtoReflect() protoreflect.Message {
+	mi := &file_schedule_proto_msgTypes[113]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use LessonNote.ProtoReflect.Descriptor instead.
+func (*LessonNote) Descriptor() ([]byte, []int) {
+	return file_schedule_proto_rawDescGZIP(), []int{113}
+}
+
+func (x *LessonNote) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *LessonNote) GetGroupName() string {
+	if x != nil {
+		return x.GroupName
+	}
+	return ""
+}
+
+func (x *LessonNote) GetDate() *timestamppb.Timestamp {
+	if x != nil {
+		return x.Date
+	}
+	return nil
+}
+
+func (x *LessonNote) GetTimeStart() string {
+	if x != nil {
+		return x.TimeStart
+	}
+	return ""
+}
+
+func (x *LessonNote) GetSubject() string {
+	if x != nil {
+		return x.Subject
+	}
+	return ""
+}
+
+func (x *LessonNote) GetText() string {
+	if x != nil {
+		return x.Text
+	}
+	return ""
+}
+
+func (x *LessonNote) GetUpdatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.UpdatedAt
+	}
+	return nil
+}
+
+// Запрос создания или изменения заметки; пара определяется группой, датой и временем начала
+type SetLessonNoteRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Token         string                 `protobuf:"bytes,1,opt,name=token,proto3" json:"token,omitempty"` // JWT токен для аутентификации
+	GroupName     string                 `protobuf:"bytes,2,opt,name=group_name,json=groupName,proto3" json:"group_name,omitempty"`
+	Date          *timestamppb.Timestamp `protobuf:"bytes,3,opt,name=date,proto3" json:"date,omitempty"`
+	TimeStart     string                 `protobuf:"bytes,4,opt,name=time_start,json=timeStart,proto3" json:"time_start,omitempty"` // ЧЧ:ММ (ScheduleEntry.time_start)
+	Subject       string                 `protobuf:"bytes,5,opt,name=subject,proto3" json:"subject,omitempty"`                      // Предмет пары для списка заметок
+	Text          string                 `protobuf:"bytes,6,opt,name=text,proto3" json:"text,omitempty"`                            // До 1000 символов
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SetLessonNoteRequest) Reset() {
+	*x = SetLessonNoteRequest{}
+	mi := &file_schedule_proto_msgTypes[114]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SetLessonNoteRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SetLessonNoteRequest) ProtoMessage() {}
+
+func (x *SetLessonNoteRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_schedule_proto_msgTypes[114]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SetLessonNoteRequest.ProtoReflect.Descriptor instead.
+func (*SetLessonNoteRequest) Descriptor() ([]byte, []int) {
+	return file_schedule_proto_rawDescGZIP(), []int{114}
+}
+
+func (x *SetLessonNoteRequest) GetToken() string {
+	if x != nil {
+		return x.Token
+	}
+	return ""
+}
+
+func (x *SetLessonNoteRequest) GetGroupName() string {
+	if x != nil {
+		return x.GroupName
+	}
+	return ""
+}
+
+func (x *SetLessonNoteRequest) GetDate() *timestamppb.Timestamp {
+	if x != nil {
+		return x.Date
+	}
+	return nil
+}
+
+func (x *SetLessonNoteRequest) GetTimeStart() string {
+	if x != nil {
+		return x.TimeStart
+	}
+	return ""
+}
+
+func (x *SetLessonNoteRequest) GetSubject() string {
+	if x != nil {
+		return x.Subject
+	}
+	return ""
+}
+
+func (x *SetLessonNoteRequest) GetText() string {
+	if x != nil {
+		return x.Text
+	}
+	return ""
+}
+
+// Ответ на сохранение заметки
+type SetLessonNoteResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Success       bool                   `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
+	Message       string                 `protobuf:"bytes,2,opt,name=message,proto3" json:"message,omitempty"`
+	Note          *LessonNote            `protobuf:"bytes,3,opt,name=note,proto3" json:"note,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SetLessonNoteResponse) Reset() {
+	*x = SetLessonNoteResponse{}
+	mi := &file_schedule_proto_msgTypes[115]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SetLessonNoteResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SetLessonNoteResponse) ProtoMessage() {}
+
+func (x *SetLessonNoteResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_schedule_proto_msgTypes[115]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SetLessonNoteResponse.ProtoReflect.Descriptor instead.
+func (*SetLessonNoteResponse) Descriptor() ([]byte, []int) {
+	return file_schedule_proto_rawDescGZIP(), []int{115}
+}
+
+func (x *SetLessonNoteResponse) GetSuccess() bool {
+	if x != nil {
+		return x.Success
+	}
+	return false
+}
+
+func (x *SetLessonNoteResponse) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+func (x *SetLessonNoteResponse) GetNote() *LessonNote {
+	if x != nil {
+		return x.Note
+	}
+	return nil
+}
+
+// Запрос заметок за период
+type ListLessonNotesRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Token         string                 `protobuf:"bytes,1,opt,name=token,proto3" json:"token,omitempty"` // JWT токен для аутентификации
+	From          *timestamppb.Timestamp `protobuf:"bytes,2,opt,name=from,proto3" json:"from,omitempty"`
+	To            *timestamppb.Timestamp `protobuf:"bytes,3,opt,name=to,proto3" json:"to,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListLessonNotesRequest) Reset() {
+	*x = ListLessonNotesRequest{}
+	mi := &file_schedule_proto_msgTypes[116]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListLessonNotesRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListLessonNotesRequest) ProtoMessage() {}
+
+func (x *ListLessonNotesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_schedule_proto_msgTypes[116]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListLessonNotesRequest.ProtoReflect.Descriptor instead.
+func (*ListLessonNotesRequest) Descriptor() ([]byte, []int) {
+	return file_schedule_proto_rawDescGZIP(), []int{116}
+}
+
+func (x *ListLessonNotesRequest) GetToken() string {
+	if x != nil {
+		return x.Token
+	}
+	return ""
+}
+
+func (x *ListLessonNotesRequest) GetFrom() *timestamppb.Timestamp {
+	if x != nil {
+		return x.From
+	}
+	return nil
+}
+
+func (x *ListLessonNotesRequest) GetTo() *timestamppb.Timestamp {
+	if x != nil {
+		return x.To
+	}
+	return nil
+}
+
+// Ответ с заметками
+type ListLessonNotesResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Success       bool                   `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
+	Message       string                 `protobuf:"bytes,2,opt,name=message,proto3" json:"message,omitempty"`
+	Notes         []*LessonNote          `protobuf:"bytes,3,rep,name=notes,proto3" json:"notes,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListLessonNotesResponse) Reset() {
+	*x = ListLessonNotesResponse{}
+	mi := &file_schedule_proto_msgTypes[117]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListLessonNotesResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListLessonNotesResponse) ProtoMessage() {}
+
+func (x *ListLessonNotesResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_schedule_proto_msgTypes[117]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListLessonNotesResponse.ProtoReflect.Descriptor instead.
+func (*ListLessonNotesResponse) Descriptor() ([]byte, []int) {
+	return file_schedule_proto_rawDescGZIP(), []int{117}
+}
+
+func (x *ListLessonNotesResponse) GetSuccess() bool {
+	if x != nil {
+		return x.Success
+	}
+	return false
+}
+
+func (x *ListLessonNotesResponse) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+func (x *ListLessonNotesResponse) GetNotes() []*LessonNote {
+	if x != nil {
+		return x.Notes
+	}
+	return nil
+}
+
+// Запрос удаления заметки
+type DeleteLessonNoteRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Token         string                 `protobuf:"bytes,1,opt,name=token,proto3" json:"token,omitempty"` // JWT токен для аутентификации
+	NoteId        string                 `protobuf:"bytes,2,opt,name=note_id,json=noteId,proto3" json:"note_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeleteLessonNoteRequest) Reset() {
+	*x = DeleteLessonNoteRequest{}
+	mi := &file_schedule_proto_msgTypes[118]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteLessonNoteRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteLessonNoteRequest) ProtoMessage() {}
+
+func (x *DeleteLessonNoteRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_schedule_proto_msgTypes[118]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteLessonNoteRequest.ProtoReflect.Descriptor instead.
+func (*DeleteLessonNoteRequest) Descriptor() ([]byte, []int) {
+	return file_schedule_proto_rawDescGZIP(), []int{118}
+}
+
+func (x *DeleteLessonNoteRequest) GetToken() string {
+	if x != nil {
+		return x.Token
+	}
+	return ""
+}
+
+func (x *DeleteLessonNoteRequest) GetNoteId() string {
+	if x != nil {
+		return x.NoteId
+	}
+	return ""
+}
+
+// Ответ на удаление заметки
+type DeleteLessonNoteResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Success       bool                   `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
+	Message       string                 `protobuf:"bytes,2,opt,name=message,proto3" json:"message,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeleteLessonNoteResponse) Reset() {
+	*x = DeleteLessonNoteResponse{}
+	mi := &file_schedule_proto_msgTypes[119]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteLessonNoteResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteLessonNoteResponse) ProtoMessage() {}
+
+func (x *DeleteLessonNoteResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_schedule_proto_msgTypes[119]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteLessonNoteResponse.ProtoReflect.Descriptor instead.
+func (*DeleteLessonNoteResponse) Descriptor() ([]byte, []int) {
+	return file_schedule_proto_rawDescGZIP(), []int{119}
+}
+
+func (x *DeleteLessonNoteResponse) GetSuccess() bool {
+	if x != nil {
+		return x.Success
+	}
+	return false
+}
+
+func (x *DeleteLessonNoteResponse) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+// Корпус колледжа
+type Building struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Code          string                 `protobuf:"bytes,2,opt,name=code,proto3" json:"code,omitempty"` // Короткий код корпуса (например, "А" или "2")
+	Name          string                 `protobuf:"bytes,3,opt,name=name,proto3" json:"name,omitempty"`
+	Address       string                 `protobuf:"bytes,4,opt,name=address,proto3" json:"address,omitempty"`
+	Classrooms    []string               `protobuf:"bytes,5,rep,name=classrooms,proto3" json:"classrooms,omitempty"` // Аудитории корпуса
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Building) Reset() {
+	*x = Building{}
+	mi := &file_schedule_proto_msgTypes[120]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Building) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Building) ProtoMessage() {}
+
+func (x *Building) ProtoReflect() protoreflect.Message {
+	mi := &file_schedule_proto_msgTypes[120]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Building.ProtoReflect.Descriptor instead.
+func (*Building) Descriptor() ([]byte, []int) {
+	return file_schedule_proto_rawDescGZIP(), []int{120}
+}
+
+func (x *Building) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *Building) GetCode() string {
+	if x != nil {
+		return x.Code
+	}
+	return ""
+}
+
+func (x *Building) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *Building) GetAddress() string {
+	if x != nil {
+		return x.Address
+	}
+	return ""
+}
+
+func (x *Building) GetClassrooms() []string {
+	if x != nil {
+		return x.Classrooms
+	}
+	return nil
+}
+
+// Запрос корпусов
+type ListBuildingsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Token         string                 `protobuf:"bytes,1,opt,name=token,proto3" json:"token,omitempty"` // JWT токен для аутентификации
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListBuildingsRequest) Reset() {
+	*x = ListBuildingsRequest{}
+	mi := &file_schedule_proto_msgTypes[121]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListBuildingsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListBuildingsRequest) ProtoMessage() {}
+
+func (x *ListBuildingsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_schedule_proto_msgTypes[121]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListBuildingsRequest.ProtoReflect.Descriptor instead.
+func (*ListBuildingsRequest) Descriptor() ([]byte, []int) {
+	return file_schedule_proto_rawDescGZIP(), []int{121}
+}
+
+func (x *ListBuildingsRequest) GetToken() string {
+	if x != nil {
+		return x.Token
+	}
+	return ""
+}
+
+// Ответ с корпусами
+type ListBuildingsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Success       bool                   `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
+	Message       string                 `protobuf:"bytes,2,opt,name=message,proto3" json:"message,omitempty"`
+	Buildings     []*Building            `protobuf:"bytes,3,rep,name=buildings,proto3" json:"buildings,omitempty"`
+	TravelMinutes int32                  `protobuf:"varint,4,opt,name=travel_minutes,json=travelMinutes,proto3" json:"travel_minutes,omitempty"` // Время перехода между корпусами в минутах
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListBuildingsResponse) Reset() {
+	*x = ListBuildingsResponse{}
+	mi := &file_schedule_proto_msgTypes[122]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListBuildingsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListBuildingsResponse) ProtoMessage() {}
+
+func (x *ListBuildingsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_schedule_proto_msgTypes[122]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListBuildingsResponse.ProtoReflect.Descriptor instead.
+func (*ListBuildingsResponse) Descriptor() ([]byte, []int) {
+	return file_schedule_proto_rawDescGZIP(), []int{122}
+}
+
+func (x *ListBuildingsResponse) GetSuccess() bool {
+	if x != nil {
+		return x.Success
+	}
+	return false
+}
+
+func (x *ListBuildingsResponse) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+func (x *ListBuildingsResponse) GetBuildings() []*Building {
+	if x != nil {
+		return x.Buildings
+	}
+	return nil
+}
+
+func (x *ListBuildingsResponse) GetTravelMinutes() int32 {
+	if x != nil {
+		return x.TravelMinutes
+	}
+	return 0
+}
+
+// Запрос создания или изменения корпуса
+type SetBuildingRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Token         string                 `protobuf:"bytes,1,opt,name=token,proto3" json:"token,omitempty"`       // JWT токен для аутентификации
+	Building      *Building              `protobuf:"bytes,2,opt,name=building,proto3" json:"building,omitempty"` // id игнорируется, корпус определяется по code
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SetBuildingRequest) Reset() {
+	*x = SetBuildingRequest{}
+	mi := &file_schedule_proto_msgTypes[123]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SetBuildingRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SetBuildingRequest) ProtoMessage() {}
+
+func (x *SetBuildingRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_schedule_proto_msgTypes[123]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SetBuildingRequest.ProtoReflect.Descriptor instead.
+func (*SetBuildingRequest) Descriptor() ([]byte, []int) {
+	return file_schedule_proto_rawDescGZIP(), []int{123}
+}
+
+func (x *SetBuildingRequest) GetToken() string {
+	if x != nil {
+		return x.Token
+	}
+	return ""
+}
+
+func (x *SetBuildingRequest) GetBuilding() *Building {
+	if x != nil {
+		return x.Building
+	}
+	return nil
+}
+
+// Ответ на сохранение корпуса
+type SetBuildingResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Success       bool                   `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
+	Message       string                 `protobuf:"bytes,2,opt,name=message,proto3" json:"message,omitempty"`
+	Building      *Building              `protobuf:"bytes,3,opt,name=building,proto3" json:"building,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SetBuildingResponse) Reset() {
+	*x = SetBuildingResponse{}
+	mi := &file_schedule_proto_msgTypes[124]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SetBuildingResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SetBuildingResponse) ProtoMessage() {}
+
+func (x *SetBuildingResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_schedule_proto_msgTypes[124]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SetBuildingResponse.ProtoReflect.Descriptor instead.
+func (*SetBuildingResponse) Descriptor() ([]byte, []int) {
+	return file_schedule_proto_rawDescGZIP(), []int{124}
+}
+
+func (x *SetBuildingResponse) GetSuccess() bool {
+	if x != nil {
+		return x.Success
+	}
+	return false
+}
+
+func (x *SetBuildingResponse) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+func (x *SetBuildingResponse) GetBuilding() *Building {
+	if x != nil {
+		return x.Building
+	}
+	return nil
+}
+
+// Запрос удаления корпуса
+type DeleteBuildingRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Token         string                 `protobuf:"bytes,1,opt,name=token,proto3" json:"token,omitempty"` // JWT токен для аутентификации
+	Code          string                 `protobuf:"bytes,2,opt,name=code,proto3" json:"code,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeleteBuildingRequest) Reset() {
+	*x = DeleteBuildingRequest{}
+	mi := &file_schedule_proto_msgTypes[125]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteBuildingRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteBuildingRequest) ProtoMessage() {}
+
+func (x *DeleteBuildingRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_schedule_proto_msgTypes[125]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteBuildingRequest.ProtoReflect.Descriptor instead.
+func (*DeleteBuildingRequest) Descriptor() ([]byte, []int) {
+	return file_schedule_proto_rawDescGZIP(), []int{125}
+}
+
+func (x *DeleteBuildingRequest) GetToken() string {
+	if x != nil {
+		return x.Token
+	}
+	return ""
+}
+
+func (x *DeleteBuildingRequest) GetCode() string {
+	if x != nil {
+		return x.Code
+	}
+	return ""
+}
+
+// Ответ на удаление корпуса
+type DeleteBuildingResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Success       bool                   `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
+	Message       string                 `protobuf:"bytes,2,opt,name=message,proto3" json:"message,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeleteBuildingResponse) Reset() {
+	*x = DeleteBuildingResponse{}
+	mi := &file_schedule_proto_msgTypes[126]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteBuildingResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteBuildingResponse) ProtoMessage() {}
+
+func (x *DeleteBuildingResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_schedule_proto_msgTypes[126]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteBuildingResponse.ProtoReflect.Descriptor instead.
+func (*DeleteBuildingResponse) Descriptor() ([]byte, []int) {
+	return file_schedule_proto_rawDescGZIP(), []int{126}
+}
+
+func (x *DeleteBuildingResponse) GetSuccess() bool {
+	if x != nil {
+		return x.Success
+	}
+	return false
+}
+
+func (x *DeleteBuildingResponse) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+// Переименование группы при переходе на новый учебный год
+type GroupRename struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	From          string                 `protobuf:"bytes,1,opt,name=from,proto3" json:"from,omitempty"` // Например "ИС-21"
+	To            string                 `protobuf:"bytes,2,opt,name=to,proto3" json:"to,omitempty"`     // Например "ИС-31"
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GroupRename) Reset() {
+	*x = GroupRename{}
+	mi := &file_schedule_proto_msgTypes[127]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GroupRename) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GroupRename) ProtoMessage() {}
+
+func (x *GroupRename) ProtoReflect() protoreflect.Message {
+	mi := &file_schedule_proto_msgTypes[127]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GroupRename.ProtoReflect.Descriptor instead.
+func (*GroupRename) Descriptor() ([]byte, []int) {
+	return file_schedule_proto_rawDescGZIP(), []int{127}
+}
+
+func (x *GroupRename) GetFrom() string {
+	if x != nil {
+		return x.From
+	}
+	return ""
+}
+
+func (x *GroupRename) GetTo() string {
+	if x != nil {
+		return x.To
+	}
+	return ""
+}
+
+// Запрос перехода на новый учебный год
+type RunAcademicRolloverRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Token         string                 `protobuf:"bytes,1,opt,name=token,proto3" json:"token,omitempty"`                                   // JWT токен для аутентификации
+	YearStart     *timestamppb.Timestamp `protobuf:"bytes,2,opt,name=year_start,json=yearStart,proto3" json:"year_start,omitempty"`          // Первый день нового учебного года
+	MaxCourse     int32                  `protobuf:"varint,3,opt,name=max_course,json=maxCourse,proto3" json:"max_course,omitempty"`         // Последний курс, студенты которого выпускаются (0 - 4)
+	Renames       []*GroupRename         `protobuf:"bytes,4,rep,name=renames,proto3" json:"renames,omitempty"`                               // Переименования групп остающихся студентов
+	RetireGroups  []string               `protobuf:"bytes,5,rep,name=retire_groups,json=retireGroups,proto3" json:"retire_groups,omitempty"` // Группы, выпускаемые целиком независимо от курса
+	DryRun        bool                   `protobuf:"varint,6,opt,name=dry_run,json=dryRun,proto3" json:"dry_run,omitempty"`                  // Только посчитать изменения, ничего не сохраняя
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RunAcademicRolloverRequest) Reset() {
+	*x = RunAcademicRolloverRequest{}
+	mi := &file_schedule_proto_msgTypes[128]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RunAcademicRolloverRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RunAcademicRolloverRequest) ProtoMessage() {}
+
+func (x *RunAcademicRolloverRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_schedule_proto_msgTypes[128]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RunAcademicRolloverRequest.ProtoReflect.Descriptor instead.
+func (*RunAcademicRolloverRequest) Descriptor() ([]byte, []int) {
+	return file_schedule_proto_rawDescGZIP(), []int{128}
+}
+
+func (x *RunAcademicRolloverRequest) GetToken() string {
+	if x != nil {
+		return x.Token
+	}
+	return ""
+}
+
+func (x *RunAcademicRolloverRequest) GetYearStart() *timestamppb.Timestamp {
+	if x != nil {
+		return x.YearStart
+	}
+	return nil
+}
+
+func (x *RunAcademicRolloverRequest) GetMaxCourse() int32 {
+	if x != nil {
+		return x.MaxCourse
+	}
+	return 0
+}
+
+func (x *RunAcademicRolloverRequest) GetRenames() []*GroupRename {
+	if x != nil {
+		return x.Renames
+	}
+	return nil
+}
+
+func (x *RunAcademicRolloverRequest) GetRetireGroups() []string {
+	if x != nil {
+		return x.RetireGroups
+	}
+	return nil
+}
+
+func (x *RunAcademicRolloverRequest) GetDryRun() bool {
+	if x != nil {
+		return x.DryRun
+	}
+	return false
+}
+
+// Ответ с итогом перехода на новый учебный год
+type RunAcademicRolloverResponse struct {
+	state              protoimpl.MessageState `protogen:"open.v1"`
+	Success            bool                   `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
+	Message            string                 `protobuf:"bytes,2,opt,name=message,proto3" json:"message,omitempty"`
+	RolloverId         string                 `protobuf:"bytes,3,opt,name=rollover_id,json=rolloverId,proto3" json:"rollover_id,omitempty"` // Пусто при dry_run
+	SnapshotsArchived  int32                  `protobuf:"varint,4,opt,name=snapshots_archived,json=snapshotsArchived,proto3" json:"snapshots_archived,omitempty"`
+	StudentsAdvanced   int32                  `protobuf:"varint,5,opt,name=students_advanced,json=studentsAdvanced,proto3" json:"students_advanced,omitempty"`
+	StudentsGraduated  int32                  `protobuf:"varint,6,opt,name=students_graduated,json=studentsGraduated,proto3" json:"students_graduated,omitempty"`
+	StudentsRenamed    int32                  `protobuf:"varint,7,opt,name=students_renamed,json=studentsRenamed,proto3" json:"students_renamed,omitempty"`
+	RetiredGroups      []string               `protobuf:"bytes,8,rep,name=retired_groups,json=retiredGroups,proto3" json:"retired_groups,omitempty"`
+	WebhooksRemoved    int32                  `protobuf:"varint,9,opt,name=webhooks_removed,json=webhooksRemoved,proto3" json:"webhooks_removed,omitempty"`
+	EnrollmentsRemoved int32                  `protobuf:"varint,10,opt,name=enrollments_removed,json=enrollmentsRemoved,proto3" json:"enrollments_removed,omitempty"`
+	SubgroupsReset     int32                  `protobuf:"varint,11,opt,name=subgroups_reset,json=subgroupsReset,proto3" json:"subgroups_reset,omitempty"`
+	unknownFields      protoimpl.UnknownFields
+	sizeCache          protoimpl.SizeCache
+}
+
+func (x *RunAcademicRolloverResponse) Reset() {
+	*x = RunAcademicRolloverResponse{}
+	mi := &file_schedule_proto_msgTypes[129]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RunAcademicRolloverResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RunAcademicRolloverResponse) ProtoMessage() {}
+
+func (x *RunAcademicRolloverResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_schedule_proto_msgTypes[129]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RunAcademicRolloverResponse.ProtoReflect.Descriptor instead.
+func (*RunAcademicRolloverResponse) Descriptor() ([]byte, []int) {
+	return file_schedule_proto_rawDescGZIP(), []int{129}
+}
+
+func (x *RunAcademicRolloverResponse) GetSuccess() bool {
+	if x != nil {
+		return x.Success
+	}
+	return false
+}
+
+func (x *RunAcademicRolloverResponse) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+func (x *RunAcademicRolloverResponse) GetRolloverId() string {
+	if x != nil {
+		return x.RolloverId
+	}
+	return ""
+}
+
+func (x *RunAcademicRolloverResponse) GetSnapshotsArchived() int32 {
+	if x != nil {
+		return x.SnapshotsArchived
+	}
+	return 0
+}
+
+func (x *RunAcademicRolloverResponse) GetStudentsAdvanced() int32 {
+	if x != nil {
+		return x.StudentsAdvanced
+	}
+	return 0
+}
+
+func (x *RunAcademicRolloverResponse) GetStudentsGraduated() int32 {
+	if x != nil {
+		return x.StudentsGraduated
+	}
+	return 0
+}
+
+func (x *RunAcademicRolloverResponse) GetStudentsRenamed() int32 {
+	if x != nil {
+		return x.StudentsRenamed
+	}
+	return 0
+}
+
+func (x *RunAcademicRolloverResponse) GetRetiredGroups() []string {
+	if x != nil {
+		return x.RetiredGroups
+	}
+	return nil
+}
+
+func (x *RunAcademicRolloverResponse) GetWebhooksRemoved() int32 {
+	if x != nil {
+		return x.WebhooksRemoved
+	}
+	return 0
+}
+
+func (x *RunAcademicRolloverResponse) GetEnrollmentsRemoved() int32 {
+	if x != nil {
+		return x.EnrollmentsRemoved
+	}
+	return 0
+}
+
+func (x *RunAcademicRolloverResponse) GetSubgroupsReset() int32 {
+	if x != nil {
+		return x.SubgroupsReset
+	}
+	return 0
+}
+
+// Еженедельная консультация преподавателя
+type Consultation struct {
+	state           protoimpl.MessageState `protogen:"open.v1"`
+	Id              string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	TeacherId       string                 `protobuf:"bytes,2,opt,name=teacher_id,json=teacherId,proto3" json:"teacher_id,omitempty"`
+	Teacher         string                 `protobuf:"bytes,3,opt,name=teacher,proto3" json:"teacher,omitempty"`                      // ФИО преподавателя
+	Weekday         int32                  `protobuf:"varint,4,opt,name=weekday,proto3" json:"weekday,omitempty"`                     // День недели: 1 - понедельник, 7 - воскресенье
+	TimeStart       string                 `protobuf:"bytes,5,opt,name=time_start,json=timeStart,proto3" json:"time_start,omitempty"` // ЧЧ:ММ
+	TimeEnd         string                 `protobuf:"bytes,6,opt,name=time_end,json=timeEnd,proto3" json:"time_end,omitempty"`       // ЧЧ:ММ
+	Classroom       string                 `protobuf:"bytes,7,opt,name=classroom,proto3" json:"classroom,omitempty"`
+	Comment         string                 `protobuf:"bytes,8,opt,name=comment,proto3" json:"comment,omitempty"`
+	NextDate        *timestamppb.Timestamp `protobuf:"bytes,9,opt,name=next_date,json=nextDate,proto3" json:"next_date,omitempty"`                        // Ближайшая дата консультации
+	ReminderEnabled bool                   `protobuf:"varint,10,opt,name=reminder_enabled,json=reminderEnabled,proto3" json:"reminder_enabled,omitempty"` // Пользователь подписан на напоминания о консультациях преподавателя
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *Consultation) Reset() {
+	*x = Consultation{}
+	mi := &file_schedule_proto_msgTypes[130]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Consultation) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Consultation) ProtoMessage() {}
+
+func (x *Consultation) ProtoReflect() protoreflect.Message {
+	mi := &file_schedule_proto_msgTypes[130]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Consultation.ProtoReflect.Descriptor instead.
+func (*Consultation) Descriptor() ([]byte, []int) {
+	return file_schedule_proto_rawDescGZIP(), []int{130}
+}
+
+func (x *Consultation) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *Consultation) GetTeacherId() string {
+	if x != nil {
+		return x.TeacherId
+	}
+	return ""
+}
+
+func (x *Consultation) GetTeacher() string {
+	if x != nil {
+		return x.Teacher
+	}
+	return ""
+}
+
+func (x *Consultation) GetWeekday() int32 {
+	if x != nil {
+		return x.Weekday
+	}
+	return 0
+}
+
+func (x *Consultation) GetTimeStart() string {
+	if x != nil {
+		return x.TimeStart
+	}
+	return ""
+}
+
+func (x *Consultation) GetTimeEnd() string {
+	if x != nil {
+		return x.TimeEnd
+	}
+	return ""
+}
+
+func (x *Consultation) GetClassroom() string {
+	if x != nil {
+		return x.Classroom
+	}
+	return ""
+}
+
+func (x *Consultation) GetComment() string {
+	if x != nil {
+		return x.Comment
+	}
+	return ""
+}
+
+func (x *Consultation) GetNextDate() *timestamppb.Timestamp {
+	if x != nil {
+		return x.NextDate
+	}
+	return nil
+}
+
+func (x *Consultation) GetReminderEnabled() bool {
+	if x != nil {
+		return x.ReminderEnabled
+	}
+	return false
+}
+
+// Запрос публикации консультаций
+type SetConsultationHoursRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Token         string                 `protobuf:"bytes,1,opt,name=token,proto3" json:"token,omitempty"`                 // JWT токен для аутентификации
+	Consultations []*Consultation        `protobuf:"bytes,2,rep,name=consultations,proto3" json:"consultations,omitempty"` // id, teacher и next_date игнорируются; пусто - удалить все
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SetConsultationHoursRequest) Reset() {
+	*x = SetConsultationHoursRequest{}
+	mi := &file_schedule_proto_msgTypes[131]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SetConsultationHoursRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SetConsultationHoursRequest) ProtoMessage() {}
+
+func (x *SetConsultationHoursRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_schedule_proto_msgTypes[131]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SetConsultationHoursRequest.ProtoReflect.Descriptor instead.
+func (*SetConsultationHoursRequest) Descriptor() ([]byte, []int) {
+	return file_schedule_proto_rawDescGZIP(), []int{131}
+}
+
+func (x *SetConsultationHoursRequest) GetToken() string {
+	if x != nil {
+		return x.Token
+	}
+	return ""
+}
+
+func (x *SetConsultationHoursRequest) GetConsultations() []*Consultation {
+	if x != nil {
+		return x.Consultations
+	}
+	return nil
+}
+
+// Ответ с опубликованными консультациями
+type SetConsultationHoursResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Success       bool                   `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
+	Message       string                 `protobuf:"bytes,2,opt,name=message,proto3" json:"message,omitempty"`
+	Consultations []*Consultation        `protobuf:"bytes,3,rep,name=consultations,proto3" json:"consultations,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SetConsultationHoursResponse) Reset() {
+	*x = SetConsultationHoursResponse{}
+	mi := &file_schedule_proto_msgTypes[132]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SetConsultationHoursResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SetConsultationHoursResponse) ProtoMessage() {}
+
+func (x *SetConsultationHoursResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_schedule_proto_msgTypes[132]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SetConsultationHoursResponse.ProtoReflect.Descriptor instead.
+func (*SetConsultationHoursResponse) Descriptor() ([]byte, []int) {
+	return file_schedule_proto_rawDescGZIP(), []int{132}
+}
+
+func (x *SetConsultationHoursResponse) GetSuccess() bool {
+	if x != nil {
+		return x.Success
+	}
+	return false
+}
+
+func (x *SetConsultationHoursResponse) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+func (x *SetConsultationHoursResponse) GetConsultations() []*Consultation {
+	if x != nil {
+		return x.Consultations
+	}
+	return nil
+}
+
+// Запрос консультаций
+type ListConsultationHoursRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Token         string                 `protobuf:"bytes,1,opt,name=token,proto3" json:"token,omitempty"` // JWT токен для аутентификации
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListConsultationHoursRequest) Reset() {
+	*x = ListConsultationHoursRequest{}
+	mi := &file_schedule_proto_msgTypes[133]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListConsultationHoursRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListConsultationHoursRequest) ProtoMessage() {}
+
+func (x *ListConsultationHoursRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_schedule_proto_msgTypes[133]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListConsultationHoursRequest.ProtoReflect.Descriptor instead.
+func (*ListConsultationHoursRequest) Descriptor() ([]byte, []int) {
+	return file_schedule_proto_rawDescGZIP(), []int{133}
+}
+
+func (x *ListConsultationHoursRequest) GetToken() string {
+	if x != nil {
+		return x.Token
+	}
+	return ""
+}
+
+// Ответ с консультациями
+type ListConsultationHoursResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Success       bool                   `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
+	Message       string                 `protobuf:"bytes,2,opt,name=message,proto3" json:"message,omitempty"`
+	Consultations []*Consultation        `protobuf:"bytes,3,rep,name=consultations,proto3" json:"consultations,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListConsultationHoursResponse) Reset() {
+	*x = ListConsultationHoursResponse{}
+	mi := &file_schedule_proto_msgTypes[134]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListConsultationHoursResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListConsultationHoursResponse) ProtoMessage() {}
+
+func (x *ListConsultationHoursResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_schedule_proto_msgTypes[134]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListConsultationHoursResponse.ProtoReflect.Descriptor instead.
+func (*ListConsultationHoursResponse) Descriptor() ([]byte, []int) {
+	return file_schedule_proto_rawDescGZIP(), []int{134}
+}
+
+func (x *ListConsultationHoursResponse) GetSuccess() bool {
+	if x != nil {
+		return x.Success
+	}
+	return false
+}
+
+func (x *ListConsultationHoursResponse) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+func (x *ListConsultationHoursResponse) GetConsultations() []*Consultation {
+	if x != nil {
+		return x.Consultations
+	}
+	return nil
+}
+
+// Запрос подписки на напоминания о консультациях
+type SetConsultationReminderRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Token         string                 `protobuf:"bytes,1,opt,name=token,proto3" json:"token,omitempty"` // JWT токен для аутентификации
+	TeacherId     string                 `protobuf:"bytes,2,opt,name=teacher_id,json=teacherId,proto3" json:"teacher_id,omitempty"`
+	Enabled       bool                   `protobuf:"varint,3,opt,name=enabled,proto3" json:"enabled,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SetConsultationReminderRequest) Reset() {
+	*x = SetConsultationReminderRequest{}
+	mi := &file_schedule_proto_msgTypes[135]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SetConsultationReminderRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SetConsultationReminderRequest) ProtoMessage() {}
+
+func (x *SetConsultationReminderRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_schedule_proto_msgTypes[135]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SetConsultationReminderRequest.ProtoReflect.Descriptor instead.
+func (*SetConsultationReminderRequest) Descriptor() ([]byte, []int) {
+	return file_schedule_proto_rawDescGZIP(), []int{135}
+}
+
+func (x *SetConsultationReminderRequest) GetToken() string {
+	if x != nil {
+		return x.Token
+	}
+	return ""
+}
+
+func (x *SetConsultationReminderRequest) GetTeacherId() string {
+	if x != nil {
+		return x.TeacherId
+	}
+	return ""
+}
+
+func (x *SetConsultationReminderRequest) GetEnabled() bool {
+	if x != nil {
+		return x.Enabled
+	}
+	return false
+}
+
+// Ответ на подписку на напоминания о консультациях
+type SetConsultationReminderResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Success       bool                   `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
+	Message       string                 `protobuf:"bytes,2,opt,name=message,proto3" json:"message,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SetConsultationReminderResponse) Reset() {
+	*x = SetConsultationReminderResponse{}
+	mi := &file_schedule_proto_msgTypes[136]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SetConsultationReminderResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SetConsultationReminderResponse) ProtoMessage() {}
+
+func (x *SetConsultationReminderResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_schedule_proto_msgTypes[136]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SetConsultationReminderResponse.ProtoReflect.Descriptor instead.
+func (*SetConsultationReminderResponse) Descriptor() ([]byte, []int) {
+	return file_schedule_proto_rawDescGZIP(), []int{136}
+}
+
+func (x *SetConsultationReminderResponse) GetSuccess() bool {
+	if x != nil {
+		return x.Success
+	}
+	return false
+}
+
+func (x *SetConsultationReminderResponse) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+// Уведомление пользователя
+type Notification struct {
+	state        protoimpl.MessageState `protogen:"open.v1"`
+	Id           string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Title        string                 `protobuf:"bytes,2,opt,name=title,proto3" json:"title,omitempty"`
+	Message      string                 `protobuf:"bytes,3,opt,name=message,proto3" json:"message,omitempty"`
+	Type         string                 `protobuf:"bytes,4,opt,name=type,proto3" json:"type,omitempty"`                                     // schedule_change, system или important
+	RelatedGroup string                 `protobuf:"bytes,5,opt,name=related_group,json=relatedGroup,proto3" json:"related_group,omitempty"` // Группа изменения расписания (может быть пустой)
+	RelatedDate  *timestamppb.Timestamp `protobuf:"bytes,6,opt,name=related_date,json=relatedDate,proto3" json:"related_date,omitempty"`
+	CreatedAt    *timestamppb.Timestamp `protobuf:"bytes,7,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	// Данные для перехода к затронутому дню или занятию при нажатии на уведомление
+	// (те же, что в push-уведомлении)
+	Payload       *NotificationPayload `protobuf:"bytes,8,opt,name=payload,proto3" json:"payload,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Notification) Reset() {
+	*x = Notification{}
+	mi := &file_schedule_proto_msgTypes[137]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Notification) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Notification) ProtoMessage() {}
+
+func (x *Notification) ProtoReflect() protoreflect.Message {
+	mi := &file_schedule_proto_msgTypes[137]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Notification.ProtoReflect.Descriptor instead.
+func (*Notification) Descriptor() ([]byte, []int) {
+	return file_schedule_proto_rawDescGZIP(), []int{137}
+}
+
+func (x *Notification) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *Notification) GetTitle() string {
+	if x != nil {
+		return x.Title
+	}
+	return ""
+}
+
+func (x *Notification) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+func (x *Notification) GetType() string {
+	if x != nil {
+		return x.Type
+	}
+	return ""
+}
+
+func (x *Notification) GetRelatedGroup() string {
+	if x != nil {
+		return x.RelatedGroup
+	}
+	return ""
+}
+
+func (x *Notification) GetRelatedDate() *timestamppb.Timestamp {
+	if x != nil {
+		return x.RelatedDate
+	}
+	return nil
+}
+
+func (x *Notification) GetCreatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return nil
+}
+
+func (x *Notification) GetPayload() *NotificationPayload {
+	if x != nil {
+		return x.Payload
+	}
+	return nil
+}
+
+// Данные уведомления для перехода в приложении
+type NotificationPayload struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	Type           string                 `protobuf:"bytes,1,opt,name=type,proto3" json:"type,omitempty"`
+	Group          string                 `protobuf:"bytes,2,opt,name=group,proto3" json:"group,omitempty"`
+	Date           string                 `protobuf:"bytes,3,opt,name=date,proto3" json:"date,omitempty"` // ГГГГ-ММ-ДД в часовом поясе колледжа
+	Time           string                 `protobuf:"bytes,4,opt,name=time,proto3" json:"time,omitempty"` // Начало пары ЧЧ:ММ
+	ChangeId       string                 `protobuf:"bytes,5,opt,name=change_id,json=changeId,proto3" json:"change_id,omitempty"`
+	EntryId        string                 `protobuf:"bytes,6,opt,name=entry_id,json=entryId,proto3" json:"entry_id,omitempty"`
+	ConsultationId string                 `protobuf:"bytes,7,opt,name=consultation_id,json=consultationId,proto3" json:"consultation_id,omitempty"`
+	CourseId       string                 `protobuf:"bytes,8,opt,name=course_id,json=courseId,proto3" json:"course_id,omitempty"`
+	// Маршрут экрана приложения с параметрами: schedule/day?date=...&group=...&time=...,
+	// consultations?id=..., electives?course=..., settings/security; пусто - список уведомлений
+	Route         string `protobuf:"bytes,9,opt,name=route,proto3" json:"route,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *NotificationPayload) Reset() {
+	*x = NotificationPayload{}
+	mi := &file_schedule_proto_msgTypes[138]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *NotificationPayload) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*NotificationPayload) ProtoMessage() {}
+
+func (x *NotificationPayload) ProtoReflect() protoreflect.Message {
+	mi := &file_schedule_proto_msgTypes[138]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use NotificationPayload.ProtoReflect.Descriptor instead.
+func (*NotificationPayload) Descriptor() ([]byte, []int) {
+	return file_schedule_proto_rawDescGZIP(), []int{138}
+}
+
+func (x *NotificationPayload) GetType() string {
+	if x != nil {
+		return x.Type
+	}
+	return ""
+}
+
+func (x *NotificationPayload) GetGroup() string {
+	if x != nil {
+		return x.Group
+	}
+	return ""
+}
+
+func (x *NotificationPayload) GetDate() string {
+	if x != nil {
+		return x.Date
+	}
+	return ""
+}
+
+func (x *NotificationPayload) GetTime() string {
+	if x != nil {
+		return x.Time
+	}
+	return ""
+}
+
+func (x *NotificationPayload) GetChangeId() string {
+	if x != nil {
+		return x.ChangeId
+	}
+	return ""
+}
+
+func (x *NotificationPayload) GetEntryId() string {
+	if x != nil {
+		return x.EntryId
+	}
+	return ""
+}
+
+func (x *NotificationPayload) GetConsultationId() string {
+	if x != nil {
+		return x.ConsultationId
+	}
+	return ""
+}
+
+func (x *NotificationPayload) GetCourseId() string {
+	if x != nil {
+		return x.CourseId
+	}
+	return ""
+}
+
+func (x *NotificationPayload) GetRoute() string {
+	if x != nil {
+		return x.Route
+	}
+	return ""
+}
+
+// Запрос ожидания новых уведомлений
+type PollUpdatesRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	Token string                 `protobuf:"bytes,1,opt,name=token,proto3" json:"token,omitempty"` // JWT токен для аутентификации
+	// Вернуть уведомления, созданные после этого момента; обычно cursor из
+	// предыдущего ответа. Без значения - уведомления, созданные после запроса
+	Since          *timestamppb.Timestamp `protobuf:"bytes,2,opt,name=since,proto3" json:"since,omitempty"`
+	TimeoutSeconds int32                  `protobuf:"varint,3,opt,name=timeout_seconds,json=timeoutSeconds,proto3" json:"timeout_seconds,omitempty"` // Максимальное время ожидания (0 - по умолчанию сервера)
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *PollUpdatesRequest) Reset() {
+	*x = PollUpdatesRequest{}
+	mi := &file_schedule_proto_msgTypes[139]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PollUpdatesRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PollUpdatesRequest) ProtoMessage() {}
+
+func (x *PollUpdatesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_schedule_proto_msgTypes[139]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PollUpdatesRequest.ProtoReflect.Descriptor instead.
+func (*PollUpdatesRequest) Descriptor() ([]byte, []int) {
+	return file_schedule_proto_rawDescGZIP(), []int{139}
+}
+
+func (x *PollUpdatesRequest) GetToken() string {
+	if x != nil {
+		return x.Token
+	}
+	return ""
+}
+
+func (x *PollUpdatesRequest) GetSince() *timestamppb.Timestamp {
+	if x != nil {
+		return x.Since
+	}
+	return nil
+}
+
+func (x *PollUpdatesRequest) GetTimeoutSeconds() int32 {
+	if x != nil {
+		return x.TimeoutSeconds
+	}
+	return 0
+}
+
+// Ответ с новыми уведомлениями
+type PollUpdatesResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Notifications []*Notification        `protobuf:"bytes,1,rep,name=notifications,proto3" json:"notifications,omitempty"` // В порядке создания; пусто - истек таймаут
+	Cursor        *timestamppb.Timestamp `protobuf:"bytes,2,opt,name=cursor,proto3" json:"cursor,omitempty"`               // Значение since для следующего запроса
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PollUpdatesResponse) Reset() {
+	*x = PollUpdatesResponse{}
+	mi := &file_schedule_proto_msgTypes[140]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PollUpdatesResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PollUpdatesResponse) ProtoMessage() {}
+
+func (x *PollUpdatesResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_schedule_proto_msgTypes[140]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PollUpdatesResponse.ProtoReflect.Descriptor instead.
+func (*PollUpdatesResponse) Descriptor() ([]byte, []int) {
+	return file_schedule_proto_rawDescGZIP(), []int{140}
+}
+
+func (x *PollUpdatesResponse) GetNotifications() []*Notification {
+	if x != nil {
+		return x.Notifications
+	}
+	return nil
+}
+
+func (x *PollUpdatesResponse) GetCursor() *timestamppb.Timestamp {
+	if x != nil {
+		return x.Cursor
+	}
+	return nil
+}
+
+// Табло расписания в коридоре
+type KioskDisplay struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Name          string                 `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`             // Например "Корпус 2, 3 этаж"
+	Buildings     []string               `protobuf:"bytes,3,rep,name=buildings,proto3" json:"buildings,omitempty"`   // Коды корпусов, все аудитории которых показываются
+	Classrooms    []string               `protobuf:"bytes,4,rep,name=classrooms,proto3" json:"classrooms,omitempty"` // Дополнительные аудитории
+	CreatedAt     *timestamppb.Timestamp `protobuf:"bytes,5,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	LastSeenAt    *timestamppb.Timestamp `protobuf:"bytes,6,opt,name=last_seen_at,json=lastSeenAt,proto3" json:"last_seen_at,omitempty"` // Последний запрос табло (пусто - ни разу)
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *KioskDisplay) Reset() {
+	*x = KioskDisplay{}
+	mi := &file_schedule_proto_msgTypes[141]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *KioskDisplay) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*KioskDisplay) ProtoMessage() {}
+
+func (x *KioskDisplay) ProtoReflect() protoreflect.Message {
+	mi := &file_schedule_proto_msgTypes[141]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use KioskDisplay.ProtoReflect.Descriptor instead.
+func (*KioskDisplay) Descriptor() ([]byte, []int) {
+	return file_schedule_proto_rawDescGZIP(), []int{141}
+}
+
+func (x *KioskDisplay) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *KioskDisplay) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *KioskDisplay) GetBuildings() []string {
+	if x != nil {
+		return x.Buildings
+	}
+	return nil
+}
+
+func (x *KioskDisplay) GetClassrooms() []string {
+	if x != nil {
+		return x.Classrooms
+	}
+	return nil
+}
+
+func (x *KioskDisplay) GetCreatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return nil
+}
+
+func (x *KioskDisplay) GetLastSeenAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.LastSeenAt
+	}
+	return nil
+}
+
+// Запрос создания табло
+type CreateKioskDisplayRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Token         string                 `protobuf:"bytes,1,opt,name=token,proto3" json:"token,omitempty"` // JWT токен для аутентификации
+	Name          string                 `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	Buildings     []string               `protobuf:"bytes,3,rep,name=buildings,proto3" json:"buildings,omitempty"`
+	Classrooms    []string               `protobuf:"bytes,4,rep,name=classrooms,proto3" json:"classrooms,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CreateKioskDisplayRequest) Reset() {
+	*x = CreateKioskDisplayRequest{}
+	mi := &file_schedule_proto_msgTypes[142]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateKioskDisplayRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateKioskDisplayRequest) ProtoMessage() {}
+
+func (x *CreateKioskDisplayRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_schedule_proto_msgTypes[142]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateKioskDisplayRequest.ProtoReflect.Descriptor instead.
+func (*CreateKioskDisplayRequest) Descriptor() ([]byte, []int) {
+	return file_schedule_proto_rawDescGZIP(), []int{142}
+}
+
+func (x *CreateKioskDisplayRequest) GetToken() string {
+	if x != nil {
+		return x.Token
+	}
+	return ""
+}
+
+func (x *CreateKioskDisplayRequest) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *CreateKioskDisplayRequest) GetBuildings() []string {
+	if x != nil {
+		return x.Buildings
+	}
+	return nil
+}
+
+func (x *CreateKioskDisplayRequest) GetClassrooms() []string {
+	if x != nil {
+		return x.Classrooms
+	}
+	return nil
+}
+
+// Ответ с созданным табло
+type CreateKioskDisplayResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Success       bool                   `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
+	Message       string                 `protobuf:"bytes,2,opt,name=message,proto3" json:"message,omitempty"`
+	Display       *KioskDisplay          `protobuf:"bytes,3,opt,name=display,proto3" json:"display,omitempty"`
+	Key           string                 `protobuf:"bytes,4,opt,name=key,proto3" json:"key,omitempty"` // Ключ табло; сохраните его, повторно он не показывается
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CreateKioskDisplayResponse) Reset() {
+	*x = CreateKioskDisplayResponse{}
+	mi := &file_schedule_proto_msgTypes[143]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateKioskDisplayResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateKioskDisplayResponse) ProtoMessage() {}
+
+func (x *CreateKioskDisplayResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_schedule_proto_msgTypes[143]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateKioskDisplayResponse.ProtoReflect.Descriptor instead.
+func (*CreateKioskDisplayResponse) Descriptor() ([]byte, []int) {
+	return file_schedule_proto_rawDescGZIP(), []int{143}
+}
+
+func (x *CreateKioskDisplayResponse) GetSuccess() bool {
+	if x != nil {
+		return x.Success
+	}
+	return false
+}
+
+func (x *CreateKioskDisplayResponse) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+func (x *CreateKioskDisplayResponse) GetDisplay() *KioskDisplay {
+	if x != nil {
+		return x.Display
+	}
+	return nil
+}
+
+func (x *CreateKioskDisplayResponse) GetKey() string {
+	if x != nil {
+		return x.Key
+	}
+	return ""
+}
+
+// Запрос табло колледжа
+type ListKioskDisplaysRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Token         string                 `protobuf:"bytes,1,opt,name=token,proto3" json:"token,omitempty"` // JWT токен для аутентификации
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListKioskDisplaysRequest) Reset() {
+	*x = ListKioskDisplaysRequest{}
+	mi := &file_schedule_proto_msgTypes[144]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListKioskDisplaysRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListKioskDisplaysRequest) ProtoMessage() {}
+
+func (x *ListKioskDisplaysRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_schedule_proto_msgTypes[144]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListKioskDisplaysRequest.ProtoReflect.Descriptor instead.
+func (*ListKioskDisplaysRequest) Descriptor() ([]byte, []int) {
+	return file_schedule_proto_rawDescGZIP(), []int{144}
+}
+
+func (x *ListKioskDisplaysRequest) GetToken() string {
+	if x != nil {
+		return x.Token
+	}
+	return ""
+}
+
+// Ответ с табло колледжа
+type ListKioskDisplaysResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Success       bool                   `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
+	Message       string                 `protobuf:"bytes,2,opt,name=message,proto3" json:"message,omitempty"`
+	Displays      []*KioskDisplay        `protobuf:"bytes,3,rep,name=displays,proto3" json:"displays,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListKioskDisplaysResponse) Reset() {
+	*x = ListKioskDisplaysResponse{}
+	mi := &file_schedule_proto_msgTypes[145]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListKioskDisplaysResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListKioskDisplaysResponse) ProtoMessage() {}
+
+func (x *ListKioskDisplaysResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_schedule_proto_msgTypes[145]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListKioskDisplaysResponse.ProtoReflect.Descriptor instead.
+func (*ListKioskDisplaysResponse) Descriptor() ([]byte, []int) {
+	return file_schedule_proto_rawDescGZIP(), []int{145}
+}
+
+func (x *ListKioskDisplaysResponse) GetSuccess() bool {
+	if x != nil {
+		return x.Success
+	}
+	return false
+}
+
+func (x *ListKioskDisplaysResponse) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+func (x *ListKioskDisplaysResponse) GetDisplays() []*KioskDisplay {
+	if x != nil {
+		return x.Displays
+	}
+	return nil
+}
+
+// Запрос отключения табло
+type RevokeKioskDisplayRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Token         string                 `protobuf:"bytes,1,opt,name=token,proto3" json:"token,omitempty"` // JWT токен для аутентификации
+	Id            string                 `protobuf:"bytes,2,opt,name=id,proto3" json:"id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RevokeKioskDisplayRequest) Reset() {
+	*x = RevokeKioskDisplayRequest{}
+	mi := &file_schedule_proto_msgTypes[146]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RevokeKioskDisplayRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RevokeKioskDisplayRequest) ProtoMessage() {}
+
+func (x *RevokeKioskDisplayRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_schedule_proto_msgTypes[146]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RevokeKioskDisplayRequest.ProtoReflect.Descriptor instead.
+func (*RevokeKioskDisplayRequest) Descriptor() ([]byte, []int) {
+	return file_schedule_proto_rawDescGZIP(), []int{146}
+}
+
+func (x *RevokeKioskDisplayRequest) GetToken() string {
+	if x != nil {
+		return x.Token
+	}
+	return ""
+}
+
+func (x *RevokeKioskDisplayRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+// Ответ на отключение табло
+type RevokeKioskDisplayResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Success       bool                   `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
+	Message       string                 `protobuf:"bytes,2,opt,name=message,proto3" json:"message,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RevokeKioskDisplayResponse) Reset() {
+	*x = RevokeKioskDisplayResponse{}
+	mi := &file_schedule_proto_msgTypes[147]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RevokeKioskDisplayResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RevokeKioskDisplayResponse) ProtoMessage() {}
+
+func (x *RevokeKioskDisplayResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_schedule_proto_msgTypes[147]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RevokeKioskDisplayResponse.ProtoReflect.Descriptor instead.
+func (*RevokeKioskDisplayResponse) Descriptor() ([]byte, []int) {
+	return file_schedule_proto_rawDescGZIP(), []int{147}
+}
+
+func (x *RevokeKioskDisplayResponse) GetSuccess() bool {
+	if x != nil {
+		return x.Success
+	}
+	return false
+}
+
+func (x *RevokeKioskDisplayResponse) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+// Пара в сводке виджета
+type WidgetLesson struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Subject       string                 `protobuf:"bytes,1,opt,name=subject,proto3" json:"subject,omitempty"`
+	TimeStart     string                 `protobuf:"bytes,2,opt,name=time_start,json=timeStart,proto3" json:"time_start,omitempty"` // ЧЧ:ММ
+	TimeEnd       string                 `protobuf:"bytes,3,opt,name=time_end,json=timeEnd,proto3" json:"time_end,omitempty"`       // ЧЧ:ММ
+	Classroom     string                 `protobuf:"bytes,4,opt,name=classroom,proto3" json:"classroom,omitempty"`
+	Teacher       string                 `protobuf:"bytes,5,opt,name=teacher,proto3" json:"teacher,omitempty"`
+	GroupName     string                 `protobuf:"bytes,6,opt,name=group_name,json=groupName,proto3" json:"group_name,omitempty"`
+	Online        bool                   `protobuf:"varint,7,opt,name=online,proto3" json:"online,omitempty"` // Онлайн-занятие
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *WidgetLesson) Reset() {
+	*x = WidgetLesson{}
+	mi := &file_schedule_proto_msgTypes[148]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *WidgetLesson) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*WidgetLesson) ProtoMessage() {}
+
+func (x *WidgetLesson) ProtoReflect() protoreflect.Message {
+	mi := &file_schedule_proto_msgTypes[148]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use WidgetLesson.ProtoReflect.Descriptor instead.
+func (*WidgetLesson) Descriptor() ([]byte, []int) {
+	return file_schedule_proto_rawDescGZIP(), []int{148}
+}
+
+func (x *WidgetLesson) GetSubject() string {
+	if x != nil {
+		return x.Subject
+	}
+	return ""
+}
+
+func (x *WidgetLesson) GetTimeStart() string {
+	if x != nil {
+		return x.TimeStart
+	}
+	return ""
+}
+
+func (x *WidgetLesson) GetTimeEnd() string {
+	if x != nil {
+		return x.TimeEnd
+	}
+	return ""
 }
 
-func (ScheduleChangeType) Descriptor() protoreflect.EnumDescriptor {
-	return file_schedule_proto_enumTypes[1].Descriptor()
+func (x *WidgetLesson) GetClassroom() string {
+	if x != nil {
+		return x.Classroom
+	}
+	return ""
 }
 
-func (ScheduleChangeType) Type() protoreflect.EnumType {
-	return &file_schedule_proto_enumTypes[1]
+func (x *WidgetLesson) GetTeacher() string {
+	if x != nil {
+		return x.Teacher
+	}
+	return ""
 }
 
-func (x ScheduleChangeType) Number() protoreflect.EnumNumber {
-	return protoreflect.EnumNumber(x)
+func (x *WidgetLesson) GetGroupName() string {
+	if x != nil {
+		return x.GroupName
+	}
+	return ""
 }
 
-// Deprecated: Use ScheduleChangeType.Descriptor instead.
-func (ScheduleChangeType) EnumDescriptor() ([]byte, []int) {
-	return file_schedule_proto_rawDescGZIP(), []int{1}
+func (x *WidgetLesson) GetOnline() bool {
+	if x != nil {
+		return x.Online
+	}
+	return false
 }
 
-// Запрос на получение расписания для группы
-type GetScheduleForGroupRequest struct {
+// Запрос сводки виджета
+type GetWidgetSummaryRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	GroupName     string                 `protobuf:"bytes,1,opt,name=group_name,json=groupName,proto3" json:"group_name,omitempty"`
-	Date          *timestamppb.Timestamp `protobuf:"bytes,2,opt,name=date,proto3" json:"date,omitempty"`
-	Token         string                 `protobuf:"bytes,3,opt,name=token,proto3" json:"token,omitempty"` // JWT токен для аутентификации
+	Token         string                 `protobuf:"bytes,1,opt,name=token,proto3" json:"token,omitempty"` // JWT токен для аутентификации (в том числе гостевой)
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *GetScheduleForGroupRequest) Reset() {
-	*x = GetScheduleForGroupRequest{}
-	mi := &file_schedule_proto_msgTypes[0]
+func (x *GetWidgetSummaryRequest) Reset() {
+	*x = GetWidgetSummaryRequest{}
+	mi := &file_schedule_proto_msgTypes[149]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *GetScheduleForGroupRequest) String() string {
+func (x *GetWidgetSummaryRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*GetScheduleForGroupRequest) ProtoMessage() {}
+func (*GetWidgetSummaryRequest) ProtoMessage() {}
 
-func (x *GetScheduleForGroupRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_schedule_proto_msgTypes[0]
+func (x *GetWidgetSummaryRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_schedule_proto_msgTypes[149]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -162,57 +10984,46 @@ func (x *GetScheduleForGroupRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use GetScheduleForGroupRequest.ProtoReflect.Descriptor instead.
-func (*GetScheduleForGroupRequest) Descriptor() ([]byte, []int) {
-	return file_schedule_proto_rawDescGZIP(), []int{0}
-}
-
-func (x *GetScheduleForGroupRequest) GetGroupName() string {
-	if x != nil {
-		return x.GroupName
-	}
-	return ""
-}
-
-func (x *GetScheduleForGroupRequest) GetDate() *timestamppb.Timestamp {
-	if x != nil {
-		return x.Date
-	}
-	return nil
+// Deprecated: Use GetWidgetSummaryRequest.ProtoReflect.Descriptor instead.
+func (*GetWidgetSummaryRequest) Descriptor() ([]byte, []int) {
+	return file_schedule_proto_rawDescGZIP(), []int{149}
 }
 
-func (x *GetScheduleForGroupRequest) GetToken() string {
+func (x *GetWidgetSummaryRequest) GetToken() string {
 	if x != nil {
 		return x.Token
 	}
 	return ""
 }
 
-// Ответ с расписанием для группы
-type GetScheduleForGroupResponse struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Success       bool                   `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
-	Message       string                 `protobuf:"bytes,2,opt,name=message,proto3" json:"message,omitempty"`
-	Schedule      []*ScheduleEntry       `protobuf:"bytes,3,rep,name=schedule,proto3" json:"schedule,omitempty"`
-	unknownFields protoimpl.UnknownFields
-	sizeCache     protoimpl.SizeCache
+// Сводка дня для виджета (без success/message, чтобы ответ был минимальным)
+type GetWidgetSummaryResponse struct {
+	state                protoimpl.MessageState `protogen:"open.v1"`
+	CurrentLesson        *WidgetLesson          `protobuf:"bytes,1,opt,name=current_lesson,json=currentLesson,proto3" json:"current_lesson,omitempty"`                           // Идущая пара; не задана - сейчас пары нет
+	NextLesson           *WidgetLesson          `protobuf:"bytes,2,opt,name=next_lesson,json=nextLesson,proto3" json:"next_lesson,omitempty"`                                    // Следующая пара сегодня; не задана - пар больше нет
+	MinutesUntilNextBell int32                  `protobuf:"varint,3,opt,name=minutes_until_next_bell,json=minutesUntilNextBell,proto3" json:"minutes_until_next_bell,omitempty"` // Минут до ближайшего звонка (0 - звонков сегодня больше нет)
+	NextBell             *timestamppb.Timestamp `protobuf:"bytes,4,opt,name=next_bell,json=nextBell,proto3" json:"next_bell,omitempty"`                                          // Ближайший звонок
+	RemainingToday       int32                  `protobuf:"varint,5,opt,name=remaining_today,json=remainingToday,proto3" json:"remaining_today,omitempty"`                       // Пар сегодня, которые еще не закончились, включая идущую
+	ValidUntil           *timestamppb.Timestamp `protobuf:"bytes,6,opt,name=valid_until,json=validUntil,proto3" json:"valid_until,omitempty"`                                    // Сводка не изменится до этого момента
+	unknownFields        protoimpl.UnknownFields
+	sizeCache            protoimpl.SizeCache
 }
 
-func (x *GetScheduleForGroupResponse) Reset() {
-	*x = GetScheduleForGroupResponse{}
-	mi := &file_schedule_proto_msgTypes[1]
+func (x *GetWidgetSummaryResponse) Reset() {
+	*x = GetWidgetSummaryResponse{}
+	mi := &file_schedule_proto_msgTypes[150]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *GetScheduleForGroupResponse) String() string {
+func (x *GetWidgetSummaryResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*GetScheduleForGroupResponse) ProtoMessage() {}
+func (*GetWidgetSummaryResponse) ProtoMessage() {}
 
-func (x *GetScheduleForGroupResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_schedule_proto_msgTypes[1]
+func (x *GetWidgetSummaryResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_schedule_proto_msgTypes[150]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -223,64 +11034,76 @@ func (x *GetScheduleForGroupResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use GetScheduleForGroupResponse.ProtoReflect.Descriptor instead.
-func (*GetScheduleForGroupResponse) Descriptor() ([]byte, []int) {
-	return file_schedule_proto_rawDescGZIP(), []int{1}
+// Deprecated: Use GetWidgetSummaryResponse.ProtoReflect.Descriptor instead.
+func (*GetWidgetSummaryResponse) Descriptor() ([]byte, []int) {
+	return file_schedule_proto_rawDescGZIP(), []int{150}
 }
 
-func (x *GetScheduleForGroupResponse) GetSuccess() bool {
+func (x *GetWidgetSummaryResponse) GetCurrentLesson() *WidgetLesson {
 	if x != nil {
-		return x.Success
+		return x.CurrentLesson
 	}
-	return false
+	return nil
 }
 
-func (x *GetScheduleForGroupResponse) GetMessage() string {
+func (x *GetWidgetSummaryResponse) GetNextLesson() *WidgetLesson {
 	if x != nil {
-		return x.Message
+		return x.NextLesson
 	}
-	return ""
+	return nil
 }
 
-func (x *GetScheduleForGroupResponse) GetSchedule() []*ScheduleEntry {
+func (x *GetWidgetSummaryResponse) GetMinutesUntilNextBell() int32 {
 	if x != nil {
-		return x.Schedule
+		return x.MinutesUntilNextBell
+	}
+	return 0
+}
+
+func (x *GetWidgetSummaryResponse) GetNextBell() *timestamppb.Timestamp {
+	if x != nil {
+		return x.NextBell
 	}
 	return nil
 }
 
-// Запись в расписании
-type ScheduleEntry struct {
+func (x *GetWidgetSummaryResponse) GetRemainingToday() int32 {
+	if x != nil {
+		return x.RemainingToday
+	}
+	return 0
+}
+
+func (x *GetWidgetSummaryResponse) GetValidUntil() *timestamppb.Timestamp {
+	if x != nil {
+		return x.ValidUntil
+	}
+	return nil
+}
+
+// Запрос ближайшего занятия
+type GetNextLessonRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
-	GroupName     string                 `protobuf:"bytes,2,opt,name=group_name,json=groupName,proto3" json:"group_name,omitempty"`
-	Date          *timestamppb.Timestamp `protobuf:"bytes,3,opt,name=date,proto3" json:"date,omitempty"`
-	TimeStart     string                 `protobuf:"bytes,4,opt,name=time_start,json=timeStart,proto3" json:"time_start,omitempty"`
-	TimeEnd       string                 `protobuf:"bytes,5,opt,name=time_end,json=timeEnd,proto3" json:"time_end,omitempty"`
-	Subject       string                 `protobuf:"bytes,6,opt,name=subject,proto3" json:"subject,omitempty"`
-	Teacher       string                 `protobuf:"bytes,7,opt,name=teacher,proto3" json:"teacher,omitempty"`
-	Classroom     string                 `protobuf:"bytes,8,opt,name=classroom,proto3" json:"classroom,omitempty"`
-	SourceType    ScheduleSourceType     `protobuf:"varint,9,opt,name=source_type,json=sourceType,proto3,enum=schedule.ScheduleSourceType" json:"source_type,omitempty"`
-	SourceId      string                 `protobuf:"bytes,10,opt,name=source_id,json=sourceId,proto3" json:"source_id,omitempty"`
+	Token         string                 `protobuf:"bytes,1,opt,name=token,proto3" json:"token,omitempty"` // JWT токен для аутентификации (в том числе гостевой)
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *ScheduleEntry) Reset() {
-	*x = ScheduleEntry{}
-	mi := &file_schedule_proto_msgTypes[2]
+func (x *GetNextLessonRequest) Reset() {
+	*x = GetNextLessonRequest{}
+	mi := &file_schedule_proto_msgTypes[151]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *ScheduleEntry) String() string {
+func (x *GetNextLessonRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*ScheduleEntry) ProtoMessage() {}
+func (*GetNextLessonRequest) ProtoMessage() {}
 
-func (x *ScheduleEntry) ProtoReflect() protoreflect.Message {
-	mi := &file_schedule_proto_msgTypes[2]
+func (x *GetNextLessonRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_schedule_proto_msgTypes[151]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -291,104 +11114,120 @@ func (x *ScheduleEntry) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use ScheduleEntry.ProtoReflect.Descriptor instead.
-func (*ScheduleEntry) Descriptor() ([]byte, []int) {
-	return file_schedule_proto_rawDescGZIP(), []int{2}
+// Deprecated: Use GetNextLessonRequest.ProtoReflect.Descriptor instead.
+func (*GetNextLessonRequest) Descriptor() ([]byte, []int) {
+	return file_schedule_proto_rawDescGZIP(), []int{151}
 }
 
-func (x *ScheduleEntry) GetId() string {
+func (x *GetNextLessonRequest) GetToken() string {
 	if x != nil {
-		return x.Id
+		return x.Token
 	}
 	return ""
 }
 
-func (x *ScheduleEntry) GetGroupName() string {
-	if x != nil {
-		return x.GroupName
-	}
-	return ""
+// Ответ с ближайшим занятием
+type GetNextLessonResponse struct {
+	state   protoimpl.MessageState `protogen:"open.v1"`
+	Success bool                   `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
+	Message string                 `protobuf:"bytes,2,opt,name=message,proto3" json:"message,omitempty"`
+	// Ближайшее занятие; не задано - занятий в ближайшие две недели нет.
+	// Для консультации source_type не задан, subject - "Консультация"
+	Lesson          *ScheduleEntry `protobuf:"bytes,3,opt,name=lesson,proto3" json:"lesson,omitempty"`
+	Consultation    bool           `protobuf:"varint,4,opt,name=consultation,proto3" json:"consultation,omitempty"`                                // Занятие - консультация преподавателя
+	StartsInMinutes int32          `protobuf:"varint,5,opt,name=starts_in_minutes,json=startsInMinutes,proto3" json:"starts_in_minutes,omitempty"` // Минут до начала занятия
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
 }
 
-func (x *ScheduleEntry) GetDate() *timestamppb.Timestamp {
-	if x != nil {
-		return x.Date
-	}
-	return nil
+func (x *GetNextLessonResponse) Reset() {
+	*x = GetNextLessonResponse{}
+	mi := &file_schedule_proto_msgTypes[152]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
 }
 
-func (x *ScheduleEntry) GetTimeStart() string {
-	if x != nil {
-		return x.TimeStart
-	}
-	return ""
+func (x *GetNextLessonResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
 }
 
-func (x *ScheduleEntry) GetTimeEnd() string {
+func (*GetNextLessonResponse) ProtoMessage() {}
+
+func (x *GetNextLessonResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_schedule_proto_msgTypes[152]
 	if x != nil {
-		return x.TimeEnd
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
 	}
-	return ""
+	return mi.MessageOf(x)
 }
 
-func (x *ScheduleEntry) GetSubject() string {
+// Deprecated: Use GetNextLessonResponse.ProtoReflect.Descriptor instead.
+func (*GetNextLessonResponse) Descriptor() ([]byte, []int) {
+	return file_schedule_proto_rawDescGZIP(), []int{152}
+}
+
+func (x *GetNextLessonResponse) GetSuccess() bool {
 	if x != nil {
-		return x.Subject
+		return x.Success
 	}
-	return ""
+	return false
 }
 
-func (x *ScheduleEntry) GetTeacher() string {
+func (x *GetNextLessonResponse) GetMessage() string {
 	if x != nil {
-		return x.Teacher
+		return x.Message
 	}
 	return ""
 }
 
-func (x *ScheduleEntry) GetClassroom() string {
+func (x *GetNextLessonResponse) GetLesson() *ScheduleEntry {
 	if x != nil {
-		return x.Classroom
+		return x.Lesson
 	}
-	return ""
+	return nil
 }
 
-func (x *ScheduleEntry) GetSourceType() ScheduleSourceType {
+func (x *GetNextLessonResponse) GetConsultation() bool {
 	if x != nil {
-		return x.SourceType
+		return x.Consultation
 	}
-	return ScheduleSourceType_SCHEDULE_SOURCE_TYPE_UNSPECIFIED
+	return false
 }
 
-func (x *ScheduleEntry) GetSourceId() string {
+func (x *GetNextLessonResponse) GetStartsInMinutes() int32 {
 	if x != nil {
-		return x.SourceId
+		return x.StartsInMinutes
 	}
-	return ""
+	return 0
 }
 
-// Запрос на получение активного снапшота расписания
-type GetActiveScheduleSnapshotRequest struct {
+// Запрос состояния учебного дня
+type GetBellStatusRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Token         string                 `protobuf:"bytes,1,opt,name=token,proto3" json:"token,omitempty"` // JWT токен для аутентификации
+	Token         string                 `protobuf:"bytes,1,opt,name=token,proto3" json:"token,omitempty"` // JWT токен для аутентификации (в том числе гостевой)
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *GetActiveScheduleSnapshotRequest) Reset() {
-	*x = GetActiveScheduleSnapshotRequest{}
-	mi := &file_schedule_proto_msgTypes[3]
+func (x *GetBellStatusRequest) Reset() {
+	*x = GetBellStatusRequest{}
+	mi := &file_schedule_proto_msgTypes[153]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *GetActiveScheduleSnapshotRequest) String() string {
+func (x *GetBellStatusRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*GetActiveScheduleSnapshotRequest) ProtoMessage() {}
+func (*GetBellStatusRequest) ProtoMessage() {}
 
-func (x *GetActiveScheduleSnapshotRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_schedule_proto_msgTypes[3]
+func (x *GetBellStatusRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_schedule_proto_msgTypes[153]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -399,43 +11238,49 @@ func (x *GetActiveScheduleSnapshotRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use GetActiveScheduleSnapshotRequest.ProtoReflect.Descriptor instead.
-func (*GetActiveScheduleSnapshotRequest) Descriptor() ([]byte, []int) {
-	return file_schedule_proto_rawDescGZIP(), []int{3}
+// Deprecated: Use GetBellStatusRequest.ProtoReflect.Descriptor instead.
+func (*GetBellStatusRequest) Descriptor() ([]byte, []int) {
+	return file_schedule_proto_rawDescGZIP(), []int{153}
 }
 
-func (x *GetActiveScheduleSnapshotRequest) GetToken() string {
+func (x *GetBellStatusRequest) GetToken() string {
 	if x != nil {
 		return x.Token
 	}
 	return ""
 }
 
-// Ответ с активным снапшотом расписания
-type GetActiveScheduleSnapshotResponse struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Success       bool                   `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
-	Message       string                 `protobuf:"bytes,2,opt,name=message,proto3" json:"message,omitempty"`
-	Snapshot      *ScheduleSnapshot      `protobuf:"bytes,3,opt,name=snapshot,proto3" json:"snapshot,omitempty"`
+// Состояние учебного дня
+type GetBellStatusResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	State BellState              `protobuf:"varint,1,opt,name=state,proto3,enum=schedule.BellState" json:"state,omitempty"`
+	// Номер идущей пары (BELL_STATE_LESSON) или следующей (BELL_STATE_BREAK,
+	// BELL_STATE_BEFORE_CLASSES); 0 - пар сегодня больше нет
+	LessonNumber  int32                  `protobuf:"varint,2,opt,name=lesson_number,json=lessonNumber,proto3" json:"lesson_number,omitempty"`
+	TimeStart     string                 `protobuf:"bytes,3,opt,name=time_start,json=timeStart,proto3" json:"time_start,omitempty"`        // Начало этой пары, ЧЧ:ММ
+	TimeEnd       string                 `protobuf:"bytes,4,opt,name=time_end,json=timeEnd,proto3" json:"time_end,omitempty"`              // Окончание этой пары, ЧЧ:ММ
+	Until         *timestamppb.Timestamp `protobuf:"bytes,5,opt,name=until,proto3" json:"until,omitempty"`                                 // Смена состояния: окончание пары или начало следующей
+	MinutesLeft   int32                  `protobuf:"varint,6,opt,name=minutes_left,json=minutesLeft,proto3" json:"minutes_left,omitempty"` // Минут до смены состояния
+	ServerTime    *timestamppb.Timestamp `protobuf:"bytes,7,opt,name=server_time,json=serverTime,proto3" json:"server_time,omitempty"`     // Время сервера для синхронизации отсчета
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *GetActiveScheduleSnapshotResponse) Reset() {
-	*x = GetActiveScheduleSnapshotResponse{}
-	mi := &file_schedule_proto_msgTypes[4]
+func (x *GetBellStatusResponse) Reset() {
+	*x = GetBellStatusResponse{}
+	mi := &file_schedule_proto_msgTypes[154]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *GetActiveScheduleSnapshotResponse) String() string {
+func (x *GetBellStatusResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*GetActiveScheduleSnapshotResponse) ProtoMessage() {}
+func (*GetBellStatusResponse) ProtoMessage() {}
 
-func (x *GetActiveScheduleSnapshotResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_schedule_proto_msgTypes[4]
+func (x *GetBellStatusResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_schedule_proto_msgTypes[154]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -446,62 +11291,136 @@ func (x *GetActiveScheduleSnapshotResponse) ProtoReflect() protoreflect.Message
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use GetActiveScheduleSnapshotResponse.ProtoReflect.Descriptor instead.
-func (*GetActiveScheduleSnapshotResponse) Descriptor() ([]byte, []int) {
-	return file_schedule_proto_rawDescGZIP(), []int{4}
+// Deprecated: Use GetBellStatusResponse.ProtoReflect.Descriptor instead.
+func (*GetBellStatusResponse) Descriptor() ([]byte, []int) {
+	return file_schedule_proto_rawDescGZIP(), []int{154}
 }
 
-func (x *GetActiveScheduleSnapshotResponse) GetSuccess() bool {
+func (x *GetBellStatusResponse) GetState() BellState {
 	if x != nil {
-		return x.Success
+		return x.State
 	}
-	return false
+	return BellState_BELL_STATE_UNSPECIFIED
 }
 
-func (x *GetActiveScheduleSnapshotResponse) GetMessage() string {
+func (x *GetBellStatusResponse) GetLessonNumber() int32 {
 	if x != nil {
-		return x.Message
+		return x.LessonNumber
+	}
+	return 0
+}
+
+func (x *GetBellStatusResponse) GetTimeStart() string {
+	if x != nil {
+		return x.TimeStart
 	}
 	return ""
 }
 
-func (x *GetActiveScheduleSnapshotResponse) GetSnapshot() *ScheduleSnapshot {
+func (x *GetBellStatusResponse) GetTimeEnd() string {
+	if x != nil {
+		return x.TimeEnd
+	}
+	return ""
+}
+
+func (x *GetBellStatusResponse) GetUntil() *timestamppb.Timestamp {
+	if x != nil {
+		return x.Until
+	}
+	return nil
+}
+
+func (x *GetBellStatusResponse) GetMinutesLeft() int32 {
+	if x != nil {
+		return x.MinutesLeft
+	}
+	return 0
+}
+
+func (x *GetBellStatusResponse) GetServerTime() *timestamppb.Timestamp {
+	if x != nil {
+		return x.ServerTime
+	}
+	return nil
+}
+
+// Запрос главного экрана преподавателя
+type GetTeacherDashboardRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Token         string                 `protobuf:"bytes,1,opt,name=token,proto3" json:"token,omitempty"` // JWT токен для аутентификации
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetTeacherDashboardRequest) Reset() {
+	*x = GetTeacherDashboardRequest{}
+	mi := &file_schedule_proto_msgTypes[155]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetTeacherDashboardRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetTeacherDashboardRequest) ProtoMessage() {}
+
+func (x *GetTeacherDashboardRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_schedule_proto_msgTypes[155]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetTeacherDashboardRequest.ProtoReflect.Descriptor instead.
+func (*GetTeacherDashboardRequest) Descriptor() ([]byte, []int) {
+	return file_schedule_proto_rawDescGZIP(), []int{155}
+}
+
+func (x *GetTeacherDashboardRequest) GetToken() string {
 	if x != nil {
-		return x.Snapshot
+		return x.Token
 	}
-	return nil
+	return ""
 }
 
-// Снапшот расписания
-type ScheduleSnapshot struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
-	Name          string                 `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
-	PeriodStart   *timestamppb.Timestamp `protobuf:"bytes,3,opt,name=period_start,json=periodStart,proto3" json:"period_start,omitempty"`
-	PeriodEnd     *timestamppb.Timestamp `protobuf:"bytes,4,opt,name=period_end,json=periodEnd,proto3" json:"period_end,omitempty"`
-	Data          string                 `protobuf:"bytes,5,opt,name=data,proto3" json:"data,omitempty"` // JSON данные в виде строки
-	CreatedAt     *timestamppb.Timestamp `protobuf:"bytes,6,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
-	SourceUrl     string                 `protobuf:"bytes,7,opt,name=source_url,json=sourceUrl,proto3" json:"source_url,omitempty"`
-	IsActive      bool                   `protobuf:"varint,8,opt,name=is_active,json=isActive,proto3" json:"is_active,omitempty"`
-	unknownFields protoimpl.UnknownFields
-	sizeCache     protoimpl.SizeCache
+// Главный экран преподавателя
+type GetTeacherDashboardResponse struct {
+	state   protoimpl.MessageState `protogen:"open.v1"`
+	Success bool                   `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
+	Message string                 `protobuf:"bytes,2,opt,name=message,proto3" json:"message,omitempty"`
+	Today   []*ScheduleEntry       `protobuf:"bytes,3,rep,name=today,proto3" json:"today,omitempty"` // Занятия на сегодня
+	// Занятия преподавателя на ближайшие 7 дней, назначенные или измененные
+	// изменениями расписания (замены, переносы, дополнительные пары)
+	Substitutions       []*ScheduleEntry        `protobuf:"bytes,4,rep,name=substitutions,proto3" json:"substitutions,omitempty"`
+	PendingRequests     []*TeacherChangeRequest `protobuf:"bytes,5,rep,name=pending_requests,json=pendingRequests,proto3" json:"pending_requests,omitempty"`             // Свои заявки, ожидающие рассмотрения
+	UnreadNotifications []*Notification         `protobuf:"bytes,6,rep,name=unread_notifications,json=unreadNotifications,proto3" json:"unread_notifications,omitempty"` // Последние непрочитанные уведомления
+	UnreadCount         int32                   `protobuf:"varint,7,opt,name=unread_count,json=unreadCount,proto3" json:"unread_count,omitempty"`                        // Всего непрочитанных уведомлений
+	unknownFields       protoimpl.UnknownFields
+	sizeCache           protoimpl.SizeCache
 }
 
-func (x *ScheduleSnapshot) Reset() {
-	*x = ScheduleSnapshot{}
-	mi := &file_schedule_proto_msgTypes[5]
+func (x *GetTeacherDashboardResponse) Reset() {
+	*x = GetTeacherDashboardResponse{}
+	mi := &file_schedule_proto_msgTypes[156]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *ScheduleSnapshot) String() string {
+func (x *GetTeacherDashboardResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*ScheduleSnapshot) ProtoMessage() {}
+func (*GetTeacherDashboardResponse) ProtoMessage() {}
 
-func (x *ScheduleSnapshot) ProtoReflect() protoreflect.Message {
-	mi := &file_schedule_proto_msgTypes[5]
+func (x *GetTeacherDashboardResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_schedule_proto_msgTypes[156]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -512,90 +11431,88 @@ func (x *ScheduleSnapshot) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use ScheduleSnapshot.ProtoReflect.Descriptor instead.
-func (*ScheduleSnapshot) Descriptor() ([]byte, []int) {
-	return file_schedule_proto_rawDescGZIP(), []int{5}
+// Deprecated: Use GetTeacherDashboardResponse.ProtoReflect.Descriptor instead.
+func (*GetTeacherDashboardResponse) Descriptor() ([]byte, []int) {
+	return file_schedule_proto_rawDescGZIP(), []int{156}
 }
 
-func (x *ScheduleSnapshot) GetId() string {
+func (x *GetTeacherDashboardResponse) GetSuccess() bool {
 	if x != nil {
-		return x.Id
+		return x.Success
 	}
-	return ""
+	return false
 }
 
-func (x *ScheduleSnapshot) GetName() string {
+func (x *GetTeacherDashboardResponse) GetMessage() string {
 	if x != nil {
-		return x.Name
+		return x.Message
 	}
 	return ""
 }
 
-func (x *ScheduleSnapshot) GetPeriodStart() *timestamppb.Timestamp {
+func (x *GetTeacherDashboardResponse) GetToday() []*ScheduleEntry {
 	if x != nil {
-		return x.PeriodStart
+		return x.Today
 	}
 	return nil
 }
 
-func (x *ScheduleSnapshot) GetPeriodEnd() *timestamppb.Timestamp {
+func (x *GetTeacherDashboardResponse) GetSubstitutions() []*ScheduleEntry {
 	if x != nil {
-		return x.PeriodEnd
+		return x.Substitutions
 	}
 	return nil
 }
 
-func (x *ScheduleSnapshot) GetData() string {
-	if x != nil {
-		return x.Data
-	}
-	return ""
-}
-
-func (x *ScheduleSnapshot) GetCreatedAt() *timestamppb.Timestamp {
+func (x *GetTeacherDashboardResponse) GetPendingRequests() []*TeacherChangeRequest {
 	if x != nil {
-		return x.CreatedAt
+		return x.PendingRequests
 	}
 	return nil
 }
 
-func (x *ScheduleSnapshot) GetSourceUrl() string {
+func (x *GetTeacherDashboardResponse) GetUnreadNotifications() []*Notification {
 	if x != nil {
-		return x.SourceUrl
+		return x.UnreadNotifications
 	}
-	return ""
+	return nil
 }
 
-func (x *ScheduleSnapshot) GetIsActive() bool {
+func (x *GetTeacherDashboardResponse) GetUnreadCount() int32 {
 	if x != nil {
-		return x.IsActive
+		return x.UnreadCount
 	}
-	return false
+	return 0
 }
 
-// Запрос на получение истории снапшотов
-type GetScheduleSnapshotsHistoryRequest struct {
+// Жалоба на ошибку в паре. Повторная жалоба пользователя на ту же пару заменяет предыдущую.
+type ReportScheduleErrorRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Token         string                 `protobuf:"bytes,1,opt,name=token,proto3" json:"token,omitempty"` // JWT токен для аутентификации
+	GroupName     string                 `protobuf:"bytes,2,opt,name=group_name,json=groupName,proto3" json:"group_name,omitempty"`
+	Date          *timestamppb.Timestamp `protobuf:"bytes,3,opt,name=date,proto3" json:"date,omitempty"`                            // Дата пары
+	TimeStart     string                 `protobuf:"bytes,4,opt,name=time_start,json=timeStart,proto3" json:"time_start,omitempty"` // Время начала пары
+	Reason        ScheduleErrorReason    `protobuf:"varint,5,opt,name=reason,proto3,enum=schedule.ScheduleErrorReason" json:"reason,omitempty"`
+	Comment       string                 `protobuf:"bytes,6,opt,name=comment,proto3" json:"comment,omitempty"` // Необязательно, до 500 символов
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *GetScheduleSnapshotsHistoryRequest) Reset() {
-	*x = GetScheduleSnapshotsHistoryRequest{}
-	mi := &file_schedule_proto_msgTypes[6]
+func (x *ReportScheduleErrorRequest) Reset() {
+	*x = ReportScheduleErrorRequest{}
+	mi := &file_schedule_proto_msgTypes[157]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *GetScheduleSnapshotsHistoryRequest) String() string {
+func (x *ReportScheduleErrorRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*GetScheduleSnapshotsHistoryRequest) ProtoMessage() {}
+func (*ReportScheduleErrorRequest) ProtoMessage() {}
 
-func (x *GetScheduleSnapshotsHistoryRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_schedule_proto_msgTypes[6]
+func (x *ReportScheduleErrorRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_schedule_proto_msgTypes[157]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -606,43 +11523,78 @@ func (x *GetScheduleSnapshotsHistoryRequest) ProtoReflect() protoreflect.Message
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use GetScheduleSnapshotsHistoryRequest.ProtoReflect.Descriptor instead.
-func (*GetScheduleSnapshotsHistoryRequest) Descriptor() ([]byte, []int) {
-	return file_schedule_proto_rawDescGZIP(), []int{6}
+// Deprecated: Use ReportScheduleErrorRequest.ProtoReflect.Descriptor instead.
+func (*ReportScheduleErrorRequest) Descriptor() ([]byte, []int) {
+	return file_schedule_proto_rawDescGZIP(), []int{157}
 }
 
-func (x *GetScheduleSnapshotsHistoryRequest) GetToken() string {
+func (x *ReportScheduleErrorRequest) GetToken() string {
 	if x != nil {
 		return x.Token
 	}
 	return ""
 }
 
-// Ответ с историей снапшотов
-type GetScheduleSnapshotsHistoryResponse struct {
+func (x *ReportScheduleErrorRequest) GetGroupName() string {
+	if x != nil {
+		return x.GroupName
+	}
+	return ""
+}
+
+func (x *ReportScheduleErrorRequest) GetDate() *timestamppb.Timestamp {
+	if x != nil {
+		return x.Date
+	}
+	return nil
+}
+
+func (x *ReportScheduleErrorRequest) GetTimeStart() string {
+	if x != nil {
+		return x.TimeStart
+	}
+	return ""
+}
+
+func (x *ReportScheduleErrorRequest) GetReason() ScheduleErrorReason {
+	if x != nil {
+		return x.Reason
+	}
+	return ScheduleErrorReason_SCHEDULE_ERROR_REASON_UNSPECIFIED
+}
+
+func (x *ReportScheduleErrorRequest) GetComment() string {
+	if x != nil {
+		return x.Comment
+	}
+	return ""
+}
+
+// Ответ на жалобу
+type ReportScheduleErrorResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Success       bool                   `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
 	Message       string                 `protobuf:"bytes,2,opt,name=message,proto3" json:"message,omitempty"`
-	Snapshots     []*ScheduleSnapshot    `protobuf:"bytes,3,rep,name=snapshots,proto3" json:"snapshots,omitempty"`
+	Reports       int32                  `protobuf:"varint,3,opt,name=reports,proto3" json:"reports,omitempty"` // Пользователей, сообщивших об ошибке в этой паре
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *GetScheduleSnapshotsHistoryResponse) Reset() {
-	*x = GetScheduleSnapshotsHistoryResponse{}
-	mi := &file_schedule_proto_msgTypes[7]
+func (x *ReportScheduleErrorResponse) Reset() {
+	*x = ReportScheduleErrorResponse{}
+	mi := &file_schedule_proto_msgTypes[158]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *GetScheduleSnapshotsHistoryResponse) String() string {
+func (x *ReportScheduleErrorResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*GetScheduleSnapshotsHistoryResponse) ProtoMessage() {}
+func (*ReportScheduleErrorResponse) ProtoMessage() {}
 
-func (x *GetScheduleSnapshotsHistoryResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_schedule_proto_msgTypes[7]
+func (x *ReportScheduleErrorResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_schedule_proto_msgTypes[158]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -653,46 +11605,50 @@ func (x *GetScheduleSnapshotsHistoryResponse) ProtoReflect() protoreflect.Messag
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use GetScheduleSnapshotsHistoryResponse.ProtoReflect.Descriptor instead.
-func (*GetScheduleSnapshotsHistoryResponse) Descriptor() ([]byte, []int) {
-	return file_schedule_proto_rawDescGZIP(), []int{7}
+// Deprecated: Use ReportScheduleErrorResponse.ProtoReflect.Descriptor instead.
+func (*ReportScheduleErrorResponse) Descriptor() ([]byte, []int) {
+	return file_schedule_proto_rawDescGZIP(), []int{158}
 }
 
-func (x *GetScheduleSnapshotsHistoryResponse) GetSuccess() bool {
+func (x *ReportScheduleErrorResponse) GetSuccess() bool {
 	if x != nil {
 		return x.Success
 	}
 	return false
 }
 
-func (x *GetScheduleSnapshotsHistoryResponse) GetMessage() string {
+func (x *ReportScheduleErrorResponse) GetMessage() string {
 	if x != nil {
 		return x.Message
 	}
 	return ""
 }
 
-func (x *GetScheduleSnapshotsHistoryResponse) GetSnapshots() []*ScheduleSnapshot {
+func (x *ReportScheduleErrorResponse) GetReports() int32 {
 	if x != nil {
-		return x.Snapshots
+		return x.Reports
 	}
-	return nil
+	return 0
 }
 
 var File_schedule_proto protoreflect.FileDescriptor
 
 const file_schedule_proto_rawDesc = "" +
 	"\n" +
-	"\x0eschedule.proto\x12\bschedule\x1a\x1fgoogle/protobuf/timestamp.proto\"\x81\x01\n" +
+	"\x0eschedule.proto\x12\bschedule\x1a\x1fgoogle/protobuf/timestamp.proto\"\xfc\x01\n" +
 	"\x1aGetScheduleForGroupRequest\x12\x1d\n" +
 	"\n" +
 	"group_name\x18\x01 \x01(\tR\tgroupName\x12.\n" +
 	"\x04date\x18\x02 \x01(\v2\x1a.google.protobuf.TimestampR\x04date\x12\x14\n" +
-	"\x05token\x18\x03 \x01(\tR\x05token\"\x86\x01\n" +
+	"\x05token\x18\x03 \x01(\tR\x05token\x12/\n" +
+	"\x05as_of\x18\x04 \x01(\v2\x1a.google.protobuf.TimestampR\x04asOf\x12\x1f\n" +
+	"\vlesson_type\x18\x05 \x01(\tR\n" +
+	"lessonType\x12'\n" +
+	"\x0finclude_archive\x18\x06 \x01(\bR\x0eincludeArchive\"\x86\x01\n" +
 	"\x1bGetScheduleForGroupResponse\x12\x18\n" +
 	"\asuccess\x18\x01 \x01(\bR\asuccess\x12\x18\n" +
 	"\amessage\x18\x02 \x01(\tR\amessage\x123\n" +
-	"\bschedule\x18\x03 \x03(\v2\x17.schedule.ScheduleEntryR\bschedule\"\xd6\x02\n" +
+	"\bschedule\x18\x03 \x03(\v2\x17.schedule.ScheduleEntryR\bschedule\"\xc9\x04\n" +
 	"\rScheduleEntry\x12\x0e\n" +
 	"\x02id\x18\x01 \x01(\tR\x02id\x12\x1d\n" +
 	"\n" +
@@ -707,13 +11663,22 @@ const file_schedule_proto_rawDesc = "" +
 	"\vsource_type\x18\t \x01(\x0e2\x1c.schedule.ScheduleSourceTypeR\n" +
 	"sourceType\x12\x1b\n" +
 	"\tsource_id\x18\n" +
-	" \x01(\tR\bsourceId\"8\n" +
+	" \x01(\tR\bsourceId\x12<\n" +
+	"\fsubject_meta\x18\v \x01(\v2\x19.schedule.SubjectMetadataR\vsubjectMeta\x12\x1f\n" +
+	"\vmeeting_url\x18\f \x01(\tR\n" +
+	"meetingUrl\x12\x1a\n" +
+	"\bsubgroup\x18\r \x01(\x05R\bsubgroup\x12\x12\n" +
+	"\x04note\x18\x0e \x01(\tR\x04note\x12\x1a\n" +
+	"\bbuilding\x18\x0f \x01(\tR\bbuilding\x12%\n" +
+	"\x0etravel_warning\x18\x10 \x01(\bR\rtravelWarning\x12\x1f\n" +
+	"\vlesson_type\x18\x11 \x01(\tR\n" +
+	"lessonType\"8\n" +
 	" GetActiveScheduleSnapshotRequest\x12\x14\n" +
 	"\x05token\x18\x01 \x01(\tR\x05token\"\x8f\x01\n" +
 	"!GetActiveScheduleSnapshotResponse\x12\x18\n" +
 	"\asuccess\x18\x01 \x01(\bR\asuccess\x12\x18\n" +
 	"\amessage\x18\x02 \x01(\tR\amessage\x126\n" +
-	"\bsnapshot\x18\x03 \x01(\v2\x1a.schedule.ScheduleSnapshotR\bsnapshot\"\xbb\x02\n" +
+	"\bsnapshot\x18\x03 \x01(\v2\x1a.schedule.ScheduleSnapshotR\bsnapshot\"\x99\x03\n" +
 	"\x10ScheduleSnapshot\x12\x0e\n" +
 	"\x02id\x18\x01 \x01(\tR\x02id\x12\x12\n" +
 	"\x04name\x18\x02 \x01(\tR\x04name\x12=\n" +
@@ -725,26 +11690,990 @@ const file_schedule_proto_rawDesc = "" +
 	"created_at\x18\x06 \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\x12\x1d\n" +
 	"\n" +
 	"source_url\x18\a \x01(\tR\tsourceUrl\x12\x1b\n" +
-	"\tis_active\x18\b \x01(\bR\bisActive\":\n" +
+	"\tis_active\x18\b \x01(\bR\bisActive\x12\x1f\n" +
+	"\vis_archived\x18\t \x01(\bR\n" +
+	"isArchived\x12;\n" +
+	"\varchived_at\x18\n" +
+	" \x01(\v2\x1a.google.protobuf.TimestampR\n" +
+	"archivedAt\"P\n" +
 	"\"GetScheduleSnapshotsHistoryRequest\x12\x14\n" +
-	"\x05token\x18\x01 \x01(\tR\x05token\"\x93\x01\n" +
+	"\x05token\x18\x01 \x01(\tR\x05token\x12\x14\n" +
+	"\x05limit\x18\x02 \x01(\x05R\x05limit\"\x93\x01\n" +
 	"#GetScheduleSnapshotsHistoryResponse\x12\x18\n" +
 	"\asuccess\x18\x01 \x01(\bR\asuccess\x12\x18\n" +
 	"\amessage\x18\x02 \x01(\tR\amessage\x128\n" +
-	"\tsnapshots\x18\x03 \x03(\v2\x1a.schedule.ScheduleSnapshotR\tsnapshots*z\n" +
+	"\tsnapshots\x18\x03 \x03(\v2\x1a.schedule.ScheduleSnapshotR\tsnapshots\"n\n" +
+	"\x16GetSnapshotDataRequest\x12\x14\n" +
+	"\x05token\x18\x01 \x01(\tR\x05token\x12\x1f\n" +
+	"\vsnapshot_id\x18\x02 \x01(\tR\n" +
+	"snapshotId\x12\x1d\n" +
+	"\n" +
+	"group_name\x18\x03 \x01(\tR\tgroupName\"\x82\x01\n" +
+	"\x17GetSnapshotDataResponse\x12\x18\n" +
+	"\asuccess\x18\x01 \x01(\bR\asuccess\x12\x18\n" +
+	"\amessage\x18\x02 \x01(\tR\amessage\x12\x1f\n" +
+	"\vsnapshot_id\x18\x03 \x01(\tR\n" +
+	"snapshotId\x12\x12\n" +
+	"\x04data\x18\x04 \x01(\tR\x04data\"\x91\x01\n" +
+	"\x14GetMyScheduleRequest\x12\x14\n" +
+	"\x05token\x18\x01 \x01(\tR\x05token\x12.\n" +
+	"\x04date\x18\x02 \x01(\v2\x1a.google.protobuf.TimestampR\x04date\x12\x12\n" +
+	"\x04week\x18\x03 \x01(\bR\x04week\x12\x1f\n" +
+	"\vlesson_type\x18\x04 \x01(\tR\n" +
+	"lessonType\"\x9f\x01\n" +
+	"\x15GetMyScheduleResponse\x12\x18\n" +
+	"\asuccess\x18\x01 \x01(\bR\asuccess\x12\x18\n" +
+	"\amessage\x18\x02 \x01(\tR\amessage\x123\n" +
+	"\bschedule\x18\x03 \x03(\v2\x17.schedule.ScheduleEntryR\bschedule\x12\x1d\n" +
+	"\n" +
+	"group_name\x18\x04 \x01(\tR\tgroupName\"\xc9\x01\n" +
+	"\x14FindFreeSlotsRequest\x12\x14\n" +
+	"\x05token\x18\x01 \x01(\tR\x05token\x12.\n" +
+	"\x04date\x18\x02 \x01(\v2\x1a.google.protobuf.TimestampR\x04date\x12\x1f\n" +
+	"\vgroup_names\x18\x03 \x03(\tR\n" +
+	"groupNames\x12\x18\n" +
+	"\ateacher\x18\x04 \x01(\tR\ateacher\x120\n" +
+	"\x14min_duration_minutes\x18\x05 \x01(\x05R\x12minDurationMinutes\"k\n" +
+	"\bFreeSlot\x12\x1d\n" +
+	"\n" +
+	"time_start\x18\x01 \x01(\tR\ttimeStart\x12\x19\n" +
+	"\btime_end\x18\x02 \x01(\tR\atimeEnd\x12%\n" +
+	"\x0elesson_numbers\x18\x03 \x03(\x05R\rlessonNumbers\"u\n" +
+	"\x15FindFreeSlotsResponse\x12\x18\n" +
+	"\asuccess\x18\x01 \x01(\bR\asuccess\x12\x18\n" +
+	"\amessage\x18\x02 \x01(\tR\amessage\x12(\n" +
+	"\x05slots\x18\x03 \x03(\v2\x12.schedule.FreeSlotR\x05slots\"\xdd\x01\n" +
+	"\x17GetWorkloadStatsRequest\x12\x14\n" +
+	"\x05token\x18\x01 \x01(\tR\x05token\x12\x1d\n" +
+	"\n" +
+	"group_name\x18\x02 \x01(\tR\tgroupName\x12\x18\n" +
+	"\ateacher\x18\x03 \x01(\tR\ateacher\x12.\n" +
+	"\x04from\x18\x04 \x01(\v2\x1a.google.protobuf.TimestampR\x04from\x12*\n" +
+	"\x02to\x18\x05 \x01(\v2\x1a.google.protobuf.TimestampR\x02to\x12\x17\n" +
+	"\aby_week\x18\x06 \x01(\bR\x06byWeek\"\xf7\x01\n" +
+	"\fWorkloadStat\x12\x1d\n" +
+	"\n" +
+	"group_name\x18\x01 \x01(\tR\tgroupName\x12\x18\n" +
+	"\ateacher\x18\x02 \x01(\tR\ateacher\x12\x18\n" +
+	"\asubject\x18\x03 \x01(\tR\asubject\x129\n" +
+	"\n" +
+	"week_start\x18\x04 \x01(\v2\x1a.google.protobuf.TimestampR\tweekStart\x12\x18\n" +
+	"\alessons\x18\x05 \x01(\x05R\alessons\x12\x18\n" +
+	"\aminutes\x18\x06 \x01(\x05R\aminutes\x12%\n" +
+	"\x0eacademic_hours\x18\a \x01(\x01R\racademicHours\"|\n" +
+	"\x18GetWorkloadStatsResponse\x12\x18\n" +
+	"\asuccess\x18\x01 \x01(\bR\asuccess\x12\x18\n" +
+	"\amessage\x18\x02 \x01(\tR\amessage\x12,\n" +
+	"\x05stats\x18\x03 \x03(\v2\x16.schedule.WorkloadStatR\x05stats\"\x9b\x01\n" +
+	"\x15GetChangeStatsRequest\x12\x14\n" +
+	"\x05token\x18\x01 \x01(\tR\x05token\x12.\n" +
+	"\x04from\x18\x02 \x01(\v2\x1a.google.protobuf.TimestampR\x04from\x12*\n" +
+	"\x02to\x18\x03 \x01(\v2\x1a.google.protobuf.TimestampR\x02to\x12\x10\n" +
+	"\x03top\x18\x04 \x01(\x05R\x03top\"\xe2\x01\n" +
+	"\x11GroupMonthChanges\x12\x1d\n" +
+	"\n" +
+	"group_name\x18\x01 \x01(\tR\tgroupName\x120\n" +
+	"\x05month\x18\x02 \x01(\v2\x1a.google.protobuf.TimestampR\x05month\x12\x14\n" +
+	"\x05total\x18\x03 \x01(\x05R\x05total\x12\"\n" +
+	"\freplacements\x18\x04 \x01(\x05R\freplacements\x12$\n" +
+	"\rcancellations\x18\x05 \x01(\x05R\rcancellations\x12\x1c\n" +
+	"\tadditions\x18\x06 \x01(\x05R\tadditions\"n\n" +
+	"\x14SubjectCancellations\x12\x18\n" +
+	"\asubject\x18\x01 \x01(\tR\asubject\x12$\n" +
+	"\rcancellations\x18\x02 \x01(\x05R\rcancellations\x12\x16\n" +
+	"\x06groups\x18\x03 \x01(\x05R\x06groups\"}\n" +
+	"\x0fDayReplacements\x12.\n" +
+	"\x04date\x18\x01 \x01(\v2\x1a.google.protobuf.TimestampR\x04date\x12\"\n" +
+	"\freplacements\x18\x02 \x01(\x05R\freplacements\x12\x16\n" +
+	"\x06groups\x18\x03 \x01(\x05R\x06groups\"\xa4\x02\n" +
+	"\x16GetChangeStatsResponse\x12\x18\n" +
+	"\asuccess\x18\x01 \x01(\bR\asuccess\x12\x18\n" +
+	"\amessage\x18\x02 \x01(\tR\amessage\x12A\n" +
+	"\x0eby_group_month\x18\x03 \x03(\v2\x1b.schedule.GroupMonthChangesR\fbyGroupMonth\x12M\n" +
+	"\x12cancelled_subjects\x18\x04 \x03(\v2\x1e.schedule.SubjectCancellationsR\x11cancelledSubjects\x12D\n" +
+	"\x10replacement_days\x18\x05 \x03(\v2\x19.schedule.DayReplacementsR\x0freplacementDays\"\xb9\x02\n" +
+	"\x10TeacherNameClaim\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x1d\n" +
+	"\n" +
+	"teacher_id\x18\x02 \x01(\tR\tteacherId\x12!\n" +
+	"\fteacher_name\x18\x03 \x01(\tR\vteacherName\x12!\n" +
+	"\fscraped_name\x18\x04 \x01(\tR\vscrapedName\x128\n" +
+	"\x06status\x18\x05 \x01(\x0e2 .schedule.ChangeModerationStatusR\x06status\x129\n" +
+	"\n" +
+	"created_at\x18\x06 \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\x12;\n" +
+	"\vreviewed_at\x18\a \x01(\v2\x1a.google.protobuf.TimestampR\n" +
+	"reviewedAt\"R\n" +
+	"\x17ClaimTeacherNameRequest\x12\x14\n" +
+	"\x05token\x18\x01 \x01(\tR\x05token\x12!\n" +
+	"\fscraped_name\x18\x02 \x01(\tR\vscrapedName\"\x80\x01\n" +
+	"\x18ClaimTeacherNameResponse\x12\x18\n" +
+	"\asuccess\x18\x01 \x01(\bR\asuccess\x12\x18\n" +
+	"\amessage\x18\x02 \x01(\tR\amessage\x120\n" +
+	"\x05claim\x18\x03 \x01(\v2\x1a.schedule.TeacherNameClaimR\x05claim\"6\n" +
+	"\x1eListMyTeacherNameClaimsRequest\x12\x14\n" +
+	"\x05token\x18\x01 \x01(\tR\x05token\"\x89\x01\n" +
+	"\x1fListMyTeacherNameClaimsResponse\x12\x18\n" +
+	"\asuccess\x18\x01 \x01(\bR\asuccess\x12\x18\n" +
+	"\amessage\x18\x02 \x01(\tR\amessage\x122\n" +
+	"\x06claims\x18\x03 \x03(\v2\x1a.schedule.TeacherNameClaimR\x06claims\";\n" +
+	"#ListPendingTeacherNameClaimsRequest\x12\x14\n" +
+	"\x05token\x18\x01 \x01(\tR\x05token\"\x8e\x01\n" +
+	"$ListPendingTeacherNameClaimsResponse\x12\x18\n" +
+	"\asuccess\x18\x01 \x01(\bR\asuccess\x12\x18\n" +
+	"\amessage\x18\x02 \x01(\tR\amessage\x122\n" +
+	"\x06claims\x18\x03 \x03(\v2\x1a.schedule.TeacherNameClaimR\x06claims\"\x86\x01\n" +
+	"\x1dReviewTeacherNameClaimRequest\x12\x14\n" +
+	"\x05token\x18\x01 \x01(\tR\x05token\x12\x19\n" +
+	"\bclaim_id\x18\x02 \x01(\tR\aclaimId\x124\n" +
+	"\bdecision\x18\x03 \x01(\x0e2\x18.schedule.ReviewDecisionR\bdecision\"\x86\x01\n" +
+	"\x1eReviewTeacherNameClaimResponse\x12\x18\n" +
+	"\asuccess\x18\x01 \x01(\bR\asuccess\x12\x18\n" +
+	"\amessage\x18\x02 \x01(\tR\amessage\x120\n" +
+	"\x05claim\x18\x03 \x01(\v2\x1a.schedule.TeacherNameClaimR\x05claim\"-\n" +
+	"\x15RunMaintenanceRequest\x12\x14\n" +
+	"\x05token\x18\x01 \x01(\tR\x05token\"L\n" +
+	"\x16RunMaintenanceResponse\x12\x18\n" +
+	"\asuccess\x18\x01 \x01(\bR\asuccess\x12\x18\n" +
+	"\amessage\x18\x02 \x01(\tR\amessage\"\xb5\x01\n" +
+	"\x15SearchScheduleRequest\x12\x14\n" +
+	"\x05token\x18\x01 \x01(\tR\x05token\x12\x14\n" +
+	"\x05query\x18\x02 \x01(\tR\x05query\x12.\n" +
+	"\x04from\x18\x03 \x01(\v2\x1a.google.protobuf.TimestampR\x04from\x12*\n" +
+	"\x02to\x18\x04 \x01(\v2\x1a.google.protobuf.TimestampR\x02to\x12\x14\n" +
+	"\x05limit\x18\x05 \x01(\x05R\x05limit\"Q\n" +
+	"\fSearchResult\x12-\n" +
+	"\x05entry\x18\x01 \x01(\v2\x17.schedule.ScheduleEntryR\x05entry\x12\x12\n" +
+	"\x04rank\x18\x02 \x01(\x01R\x04rank\"~\n" +
+	"\x16SearchScheduleResponse\x12\x18\n" +
+	"\asuccess\x18\x01 \x01(\bR\asuccess\x12\x18\n" +
+	"\amessage\x18\x02 \x01(\tR\amessage\x120\n" +
+	"\aresults\x18\x03 \x03(\v2\x16.schedule.SearchResultR\aresults\"w\n" +
+	"\x17CompareSnapshotsRequest\x12\x14\n" +
+	"\x05token\x18\x01 \x01(\tR\x05token\x12\"\n" +
+	"\rsnapshot_id_a\x18\x02 \x01(\tR\vsnapshotIdA\x12\"\n" +
+	"\rsnapshot_id_b\x18\x03 \x01(\tR\vsnapshotIdB\"\xf9\x01\n" +
+	"\x0eSnapshotLesson\x12\x1e\n" +
+	"\vday_of_week\x18\x01 \x01(\tR\tdayOfWeek\x12\x1d\n" +
+	"\n" +
+	"time_start\x18\x02 \x01(\tR\ttimeStart\x12\x19\n" +
+	"\btime_end\x18\x03 \x01(\tR\atimeEnd\x12\x18\n" +
+	"\asubject\x18\x04 \x01(\tR\asubject\x12\x18\n" +
+	"\ateacher\x18\x05 \x01(\tR\ateacher\x12\x1c\n" +
+	"\tclassroom\x18\x06 \x01(\tR\tclassroom\x12\x1a\n" +
+	"\bsubgroup\x18\a \x01(\x05R\bsubgroup\x12\x1f\n" +
+	"\vlesson_type\x18\b \x01(\tR\n" +
+	"lessonType\"\x97\x01\n" +
+	"\fLessonChange\x120\n" +
+	"\x06before\x18\x01 \x01(\v2\x18.schedule.SnapshotLessonR\x06before\x12.\n" +
+	"\x05after\x18\x02 \x01(\v2\x18.schedule.SnapshotLessonR\x05after\x12%\n" +
+	"\x0echanged_fields\x18\x03 \x03(\tR\rchangedFields\"\xf3\x01\n" +
+	"\tGroupDiff\x12\x1d\n" +
+	"\n" +
+	"group_name\x18\x01 \x01(\tR\tgroupName\x121\n" +
+	"\x06status\x18\x02 \x01(\x0e2\x19.schedule.GroupDiffStatusR\x06status\x12.\n" +
+	"\x05added\x18\x03 \x03(\v2\x18.schedule.SnapshotLessonR\x05added\x122\n" +
+	"\aremoved\x18\x04 \x03(\v2\x18.schedule.SnapshotLessonR\aremoved\x120\n" +
+	"\achanged\x18\x05 \x03(\v2\x16.schedule.LessonChangeR\achanged\"\xf1\x01\n" +
+	"\x18CompareSnapshotsResponse\x12\x18\n" +
+	"\asuccess\x18\x01 \x01(\bR\asuccess\x12\x18\n" +
+	"\amessage\x18\x02 \x01(\tR\amessage\x129\n" +
+	"\n" +
+	"snapshot_a\x18\x03 \x01(\v2\x1a.schedule.ScheduleSnapshotR\tsnapshotA\x129\n" +
+	"\n" +
+	"snapshot_b\x18\x04 \x01(\v2\x1a.schedule.ScheduleSnapshotR\tsnapshotB\x12+\n" +
+	"\x06groups\x18\x05 \x03(\v2\x13.schedule.GroupDiffR\x06groups\"\xaf\x01\n" +
+	"\x0fSubjectMetadata\x12\x18\n" +
+	"\asubject\x18\x01 \x01(\tR\asubject\x12\x1d\n" +
+	"\n" +
+	"short_name\x18\x02 \x01(\tR\tshortName\x12\x14\n" +
+	"\x05color\x18\x03 \x01(\tR\x05color\x12\x12\n" +
+	"\x04icon\x18\x04 \x01(\tR\x04icon\x129\n" +
+	"\n" +
+	"updated_at\x18\x05 \x01(\v2\x1a.google.protobuf.TimestampR\tupdatedAt\"2\n" +
+	"\x1aListSubjectMetadataRequest\x12\x14\n" +
+	"\x05token\x18\x01 \x01(\tR\x05token\"\x88\x01\n" +
+	"\x1bListSubjectMetadataResponse\x12\x18\n" +
+	"\asuccess\x18\x01 \x01(\bR\asuccess\x12\x18\n" +
+	"\amessage\x18\x02 \x01(\tR\amessage\x125\n" +
+	"\bsubjects\x18\x03 \x03(\v2\x19.schedule.SubjectMetadataR\bsubjects\"i\n" +
+	"\x1cUpsertSubjectMetadataRequest\x12\x14\n" +
+	"\x05token\x18\x01 \x01(\tR\x05token\x123\n" +
+	"\asubject\x18\x02 \x01(\v2\x19.schedule.SubjectMetadataR\asubject\"\x88\x01\n" +
+	"\x1dUpsertSubjectMetadataResponse\x12\x18\n" +
+	"\asuccess\x18\x01 \x01(\bR\asuccess\x12\x18\n" +
+	"\amessage\x18\x02 \x01(\tR\amessage\x123\n" +
+	"\asubject\x18\x03 \x01(\v2\x19.schedule.SubjectMetadataR\asubject\"N\n" +
+	"\x1cDeleteSubjectMetadataRequest\x12\x14\n" +
+	"\x05token\x18\x01 \x01(\tR\x05token\x12\x18\n" +
+	"\asubject\x18\x02 \x01(\tR\asubject\"S\n" +
+	"\x1dDeleteSubjectMetadataResponse\x12\x18\n" +
+	"\asuccess\x18\x01 \x01(\bR\asuccess\x12\x18\n" +
+	"\amessage\x18\x02 \x01(\tR\amessage\"\xff\x06\n" +
+	"\x0eScheduleChange\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x1f\n" +
+	"\vsnapshot_id\x18\x02 \x01(\tR\n" +
+	"snapshotId\x12\x1d\n" +
+	"\n" +
+	"group_name\x18\x03 \x01(\tR\tgroupName\x12.\n" +
+	"\x04date\x18\x04 \x01(\v2\x1a.google.protobuf.TimestampR\x04date\x12\x1d\n" +
+	"\n" +
+	"time_start\x18\x05 \x01(\tR\ttimeStart\x12\x19\n" +
+	"\btime_end\x18\x06 \x01(\tR\atimeEnd\x12\x18\n" +
+	"\asubject\x18\a \x01(\tR\asubject\x12\x18\n" +
+	"\ateacher\x18\b \x01(\tR\ateacher\x12\x1c\n" +
+	"\tclassroom\x18\t \x01(\tR\tclassroom\x12=\n" +
+	"\vchange_type\x18\n" +
+	" \x01(\x0e2\x1c.schedule.ScheduleChangeTypeR\n" +
+	"changeType\x12)\n" +
+	"\x10original_subject\x18\v \x01(\tR\x0foriginalSubject\x129\n" +
+	"\n" +
+	"created_at\x18\f \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\x12#\n" +
+	"\rlesson_number\x18\r \x01(\x05R\flessonNumber\x12\x1f\n" +
+	"\vhas_overlap\x18\x0e \x01(\bR\n" +
+	"hasOverlap\x12>\n" +
+	"\fapply_status\x18\x0f \x01(\x0e2\x1b.schedule.ChangeApplyStatusR\vapplyStatus\x12\x1f\n" +
+	"\vapply_error\x18\x10 \x01(\tR\n" +
+	"applyError\x12M\n" +
+	"\x11moderation_status\x18\x11 \x01(\x0e2 .schedule.ChangeModerationStatusR\x10moderationStatus\x12-\n" +
+	"\x12moderation_comment\x18\x12 \x01(\tR\x11moderationComment\x12\x1d\n" +
+	"\n" +
+	"request_id\x18\x13 \x01(\tR\trequestId\x12#\n" +
+	"\rsupersedes_id\x18\x14 \x01(\tR\fsupersedesId\x12\x16\n" +
+	"\x06reason\x18\x15 \x01(\tR\x06reason\x12\x1a\n" +
+	"\bsubgroup\x18\x16 \x01(\x05R\bsubgroup\x12\x1f\n" +
+	"\vlesson_type\x18\x17 \x01(\tR\n" +
+	"lessonType\"\x91\x01\n" +
+	"\x1dListOverlappingChangesRequest\x12\x14\n" +
+	"\x05token\x18\x01 \x01(\tR\x05token\x12.\n" +
+	"\x04from\x18\x02 \x01(\v2\x1a.google.protobuf.TimestampR\x04from\x12*\n" +
+	"\x02to\x18\x03 \x01(\v2\x1a.google.protobuf.TimestampR\x02to\"\x88\x01\n" +
+	"\x1eListOverlappingChangesResponse\x12\x18\n" +
+	"\asuccess\x18\x01 \x01(\bR\asuccess\x12\x18\n" +
+	"\amessage\x18\x02 \x01(\tR\amessage\x122\n" +
+	"\achanges\x18\x03 \x03(\v2\x18.schedule.ScheduleChangeR\achanges\"S\n" +
+	"\x1aListSnapshotChangesRequest\x12\x14\n" +
+	"\x05token\x18\x01 \x01(\tR\x05token\x12\x1f\n" +
+	"\vsnapshot_id\x18\x02 \x01(\tR\n" +
+	"snapshotId\"\x85\x01\n" +
+	"\x1bListSnapshotChangesResponse\x12\x18\n" +
+	"\asuccess\x18\x01 \x01(\bR\asuccess\x12\x18\n" +
+	"\amessage\x18\x02 \x01(\tR\amessage\x122\n" +
+	"\achanges\x18\x03 \x03(\v2\x18.schedule.ScheduleChangeR\achanges\"<\n" +
+	"$ListChangesAwaitingModerationRequest\x12\x14\n" +
+	"\x05token\x18\x01 \x01(\tR\x05token\"\x8f\x01\n" +
+	"%ListChangesAwaitingModerationResponse\x12\x18\n" +
+	"\asuccess\x18\x01 \x01(\bR\asuccess\x12\x18\n" +
+	"\amessage\x18\x02 \x01(\tR\amessage\x122\n" +
+	"\achanges\x18\x03 \x03(\v2\x18.schedule.ScheduleChangeR\achanges\"\xc6\x01\n" +
+	"\x13ReviewChangeRequest\x12\x14\n" +
+	"\x05token\x18\x01 \x01(\tR\x05token\x12\x1b\n" +
+	"\tchange_id\x18\x02 \x01(\tR\bchangeId\x124\n" +
+	"\bdecision\x18\x03 \x01(\x0e2\x18.schedule.ReviewDecisionR\bdecision\x12,\n" +
+	"\x04edit\x18\x04 \x01(\v2\x18.schedule.ScheduleChangeR\x04edit\x12\x18\n" +
+	"\acomment\x18\x05 \x01(\tR\acomment\"|\n" +
+	"\x14ReviewChangeResponse\x12\x18\n" +
+	"\asuccess\x18\x01 \x01(\bR\asuccess\x12\x18\n" +
+	"\amessage\x18\x02 \x01(\tR\amessage\x120\n" +
+	"\x06change\x18\x03 \x01(\v2\x18.schedule.ScheduleChangeR\x06change\"\x9b\x06\n" +
+	"\x14TeacherChangeRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x1d\n" +
+	"\n" +
+	"teacher_id\x18\x02 \x01(\tR\tteacherId\x126\n" +
+	"\x04kind\x18\x03 \x01(\x0e2\".schedule.TeacherChangeRequestKindR\x04kind\x12\x1d\n" +
+	"\n" +
+	"group_name\x18\x04 \x01(\tR\tgroupName\x12.\n" +
+	"\x04date\x18\x05 \x01(\v2\x1a.google.protobuf.TimestampR\x04date\x12\x1d\n" +
+	"\n" +
+	"time_start\x18\x06 \x01(\tR\ttimeStart\x12\x19\n" +
+	"\btime_end\x18\a \x01(\tR\atimeEnd\x12\x18\n" +
+	"\asubject\x18\b \x01(\tR\asubject\x12\x18\n" +
+	"\ateacher\x18\t \x01(\tR\ateacher\x12\x1c\n" +
+	"\tclassroom\x18\n" +
+	" \x01(\tR\tclassroom\x125\n" +
+	"\bnew_date\x18\v \x01(\v2\x1a.google.protobuf.TimestampR\anewDate\x12$\n" +
+	"\x0enew_time_start\x18\f \x01(\tR\fnewTimeStart\x12 \n" +
+	"\fnew_time_end\x18\r \x01(\tR\n" +
+	"newTimeEnd\x12*\n" +
+	"\x11new_lesson_number\x18\x0e \x01(\x05R\x0fnewLessonNumber\x12#\n" +
+	"\rnew_classroom\x18\x0f \x01(\tR\fnewClassroom\x12\x18\n" +
+	"\acomment\x18\x10 \x01(\tR\acomment\x128\n" +
+	"\x06status\x18\x11 \x01(\x0e2 .schedule.ChangeModerationStatusR\x06status\x12%\n" +
+	"\x0ereview_comment\x18\x12 \x01(\tR\rreviewComment\x129\n" +
+	"\n" +
+	"created_at\x18\x13 \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\x12;\n" +
+	"\vreviewed_at\x18\x14 \x01(\v2\x1a.google.protobuf.TimestampR\n" +
+	"reviewedAt\"\xa7\x03\n" +
+	"!SubmitTeacherChangeRequestRequest\x12\x14\n" +
+	"\x05token\x18\x01 \x01(\tR\x05token\x126\n" +
+	"\x04kind\x18\x02 \x01(\x0e2\".schedule.TeacherChangeRequestKindR\x04kind\x12\x1d\n" +
+	"\n" +
+	"group_name\x18\x03 \x01(\tR\tgroupName\x12.\n" +
+	"\x04date\x18\x04 \x01(\v2\x1a.google.protobuf.TimestampR\x04date\x12\x1d\n" +
+	"\n" +
+	"time_start\x18\x05 \x01(\tR\ttimeStart\x125\n" +
+	"\bnew_date\x18\x06 \x01(\v2\x1a.google.protobuf.TimestampR\anewDate\x12$\n" +
+	"\x0enew_time_start\x18\a \x01(\tR\fnewTimeStart\x12*\n" +
+	"\x11new_lesson_number\x18\b \x01(\x05R\x0fnewLessonNumber\x12#\n" +
+	"\rnew_classroom\x18\t \x01(\tR\fnewClassroom\x12\x18\n" +
+	"\acomment\x18\n" +
+	" \x01(\tR\acomment\"\x92\x01\n" +
+	"\"SubmitTeacherChangeRequestResponse\x12\x18\n" +
+	"\asuccess\x18\x01 \x01(\bR\asuccess\x12\x18\n" +
+	"\amessage\x18\x02 \x01(\tR\amessage\x128\n" +
+	"\arequest\x18\x03 \x01(\v2\x1e.schedule.TeacherChangeRequestR\arequest\":\n" +
+	"\"ListMyTeacherChangeRequestsRequest\x12\x14\n" +
+	"\x05token\x18\x01 \x01(\tR\x05token\"\x95\x01\n" +
+	"#ListMyTeacherChangeRequestsResponse\x12\x18\n" +
+	"\asuccess\x18\x01 \x01(\bR\asuccess\x12\x18\n" +
+	"\amessage\x18\x02 \x01(\tR\amessage\x12:\n" +
+	"\brequests\x18\x03 \x03(\v2\x1e.schedule.TeacherChangeRequestR\brequests\"?\n" +
+	"'ListPendingTeacherChangeRequestsRequest\x12\x14\n" +
+	"\x05token\x18\x01 \x01(\tR\x05token\"\x9a\x01\n" +
+	"(ListPendingTeacherChangeRequestsResponse\x12\x18\n" +
+	"\asuccess\x18\x01 \x01(\bR\asuccess\x12\x18\n" +
+	"\amessage\x18\x02 \x01(\tR\amessage\x12:\n" +
+	"\brequests\x18\x03 \x03(\v2\x1e.schedule.TeacherChangeRequestR\brequests\"\xa8\x01\n" +
+	"!ReviewTeacherChangeRequestRequest\x12\x14\n" +
+	"\x05token\x18\x01 \x01(\tR\x05token\x12\x1d\n" +
+	"\n" +
+	"request_id\x18\x02 \x01(\tR\trequestId\x124\n" +
+	"\bdecision\x18\x03 \x01(\x0e2\x18.schedule.ReviewDecisionR\bdecision\x12\x18\n" +
+	"\acomment\x18\x04 \x01(\tR\acomment\"\xc6\x01\n" +
+	"\"ReviewTeacherChangeRequestResponse\x12\x18\n" +
+	"\asuccess\x18\x01 \x01(\bR\asuccess\x12\x18\n" +
+	"\amessage\x18\x02 \x01(\tR\amessage\x128\n" +
+	"\arequest\x18\x03 \x01(\v2\x1e.schedule.TeacherChangeRequestR\arequest\x122\n" +
+	"\achanges\x18\x04 \x03(\v2\x18.schedule.ScheduleChangeR\achanges\"L\n" +
+	"\x15GetGroupRosterRequest\x12\x14\n" +
+	"\x05token\x18\x01 \x01(\tR\x05token\x12\x1d\n" +
+	"\n" +
+	"group_name\x18\x02 \x01(\tR\tgroupName\"\x88\x01\n" +
+	"\rRosterStudent\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\tR\x06userId\x12\x1b\n" +
+	"\tfull_name\x18\x02 \x01(\tR\bfullName\x12%\n" +
+	"\x0estudent_number\x18\x03 \x01(\tR\rstudentNumber\x12\x1a\n" +
+	"\bsubgroup\x18\x04 \x01(\x05R\bsubgroup\"\xa0\x01\n" +
+	"\x16GetGroupRosterResponse\x12\x18\n" +
+	"\asuccess\x18\x01 \x01(\bR\asuccess\x12\x18\n" +
+	"\amessage\x18\x02 \x01(\tR\amessage\x12\x1d\n" +
+	"\n" +
+	"group_name\x18\x03 \x01(\tR\tgroupName\x123\n" +
+	"\bstudents\x18\x04 \x03(\v2\x17.schedule.RosterStudentR\bstudents\"\x96\x03\n" +
+	"\x03Job\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x12\n" +
+	"\x04kind\x18\x02 \x01(\tR\x04kind\x12\x18\n" +
+	"\apayload\x18\x03 \x01(\tR\apayload\x12+\n" +
+	"\x06status\x18\x04 \x01(\x0e2\x13.schedule.JobStatusR\x06status\x12\x1a\n" +
+	"\battempts\x18\x05 \x01(\x05R\battempts\x12!\n" +
+	"\fmax_attempts\x18\x06 \x01(\x05R\vmaxAttempts\x121\n" +
+	"\x06run_at\x18\a \x01(\v2\x1a.google.protobuf.TimestampR\x05runAt\x12\x1b\n" +
+	"\tlocked_by\x18\b \x01(\tR\blockedBy\x12\x1d\n" +
+	"\n" +
+	"last_error\x18\t \x01(\tR\tlastError\x129\n" +
+	"\n" +
+	"created_at\x18\n" +
+	" \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\x12;\n" +
+	"\vfinished_at\x18\v \x01(\v2\x1a.google.protobuf.TimestampR\n" +
+	"finishedAt\"\x82\x01\n" +
+	"\fJobKindStats\x12\x12\n" +
+	"\x04kind\x18\x01 \x01(\tR\x04kind\x12\x18\n" +
+	"\apending\x18\x02 \x01(\x05R\apending\x12\x18\n" +
+	"\arunning\x18\x03 \x01(\x05R\arunning\x12\x12\n" +
+	"\x04done\x18\x04 \x01(\x05R\x04done\x12\x16\n" +
+	"\x06failed\x18\x05 \x01(\x05R\x06failed\"\x9d\x01\n" +
+	"\x0fListJobsRequest\x12\x14\n" +
+	"\x05token\x18\x01 \x01(\tR\x05token\x12\x12\n" +
+	"\x04kind\x18\x02 \x01(\tR\x04kind\x12+\n" +
+	"\x06status\x18\x03 \x01(\x0e2\x13.schedule.JobStatusR\x06status\x12\x1b\n" +
+	"\tpage_size\x18\x04 \x01(\x05R\bpageSize\x12\x16\n" +
+	"\x06offset\x18\x05 \x01(\x05R\x06offset\"\xad\x01\n" +
+	"\x10ListJobsResponse\x12\x18\n" +
+	"\asuccess\x18\x01 \x01(\bR\asuccess\x12\x18\n" +
+	"\amessage\x18\x02 \x01(\tR\amessage\x12!\n" +
+	"\x04jobs\x18\x03 \x03(\v2\r.schedule.JobR\x04jobs\x12\x14\n" +
+	"\x05total\x18\x04 \x01(\x05R\x05total\x12,\n" +
+	"\x05stats\x18\x05 \x03(\v2\x16.schedule.JobKindStatsR\x05stats\">\n" +
+	"\x0fRetryJobRequest\x12\x14\n" +
+	"\x05token\x18\x01 \x01(\tR\x05token\x12\x15\n" +
+	"\x06job_id\x18\x02 \x01(\tR\x05jobId\"F\n" +
+	"\x10RetryJobResponse\x12\x18\n" +
+	"\asuccess\x18\x01 \x01(\bR\asuccess\x12\x18\n" +
+	"\amessage\x18\x02 \x01(\tR\amessage\"\xf6\x01\n" +
+	"\vFeatureFlag\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\x12\x18\n" +
+	"\aenabled\x18\x02 \x01(\bR\aenabled\x12\x1e\n" +
+	"\n" +
+	"percentage\x18\x03 \x01(\x05R\n" +
+	"percentage\x12 \n" +
+	"\vdescription\x18\x04 \x01(\tR\vdescription\x12\x1d\n" +
+	"\n" +
+	"is_default\x18\x05 \x01(\bR\tisDefault\x12\x1d\n" +
+	"\n" +
+	"updated_by\x18\x06 \x01(\tR\tupdatedBy\x129\n" +
+	"\n" +
+	"updated_at\x18\a \x01(\v2\x1a.google.protobuf.TimestampR\tupdatedAt\"/\n" +
+	"\x17ListFeatureFlagsRequest\x12\x14\n" +
+	"\x05token\x18\x01 \x01(\tR\x05token\"{\n" +
+	"\x18ListFeatureFlagsResponse\x12\x18\n" +
+	"\asuccess\x18\x01 \x01(\bR\asuccess\x12\x18\n" +
+	"\amessage\x18\x02 \x01(\tR\amessage\x12+\n" +
+	"\x05flags\x18\x03 \x03(\v2\x15.schedule.FeatureFlagR\x05flags\"\x9d\x01\n" +
+	"\x15SetFeatureFlagRequest\x12\x14\n" +
+	"\x05token\x18\x01 \x01(\tR\x05token\x12\x12\n" +
+	"\x04name\x18\x02 \x01(\tR\x04name\x12\x18\n" +
+	"\aenabled\x18\x03 \x01(\bR\aenabled\x12\x1e\n" +
+	"\n" +
+	"percentage\x18\x04 \x01(\x05R\n" +
+	"percentage\x12 \n" +
+	"\vdescription\x18\x05 \x01(\tR\vdescription\"w\n" +
+	"\x16SetFeatureFlagResponse\x12\x18\n" +
+	"\asuccess\x18\x01 \x01(\bR\asuccess\x12\x18\n" +
+	"\amessage\x18\x02 \x01(\tR\amessage\x12)\n" +
+	"\x04flag\x18\x03 \x01(\v2\x15.schedule.FeatureFlagR\x04flag\"C\n" +
+	"\x17ResetFeatureFlagRequest\x12\x14\n" +
+	"\x05token\x18\x01 \x01(\tR\x05token\x12\x12\n" +
+	"\x04name\x18\x02 \x01(\tR\x04name\"N\n" +
+	"\x18ResetFeatureFlagResponse\x12\x18\n" +
+	"\asuccess\x18\x01 \x01(\bR\asuccess\x12\x18\n" +
+	"\amessage\x18\x02 \x01(\tR\amessage\"V\n" +
+	"\x1eGetCalendarSubscriptionRequest\x12\x14\n" +
+	"\x05token\x18\x01 \x01(\tR\x05token\x12\x1e\n" +
+	"\n" +
+	"regenerate\x18\x02 \x01(\bR\n" +
+	"regenerate\"\xbf\x01\n" +
+	"\x1fGetCalendarSubscriptionResponse\x12\x18\n" +
+	"\asuccess\x18\x01 \x01(\bR\asuccess\x12\x18\n" +
+	"\amessage\x18\x02 \x01(\tR\amessage\x12\x19\n" +
+	"\bfeed_url\x18\x03 \x01(\tR\afeedUrl\x12\x1d\n" +
+	"\n" +
+	"webcal_url\x18\x04 \x01(\tR\twebcalUrl\x12.\n" +
+	"\x13google_calendar_url\x18\x05 \x01(\tR\x11googleCalendarUrl\"\xa8\x02\n" +
+	"\fGroupWebhook\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x1d\n" +
+	"\n" +
+	"group_name\x18\x02 \x01(\tR\tgroupName\x125\n" +
+	"\bprovider\x18\x03 \x01(\x0e2\x19.schedule.WebhookProviderR\bprovider\x12\x10\n" +
+	"\x03url\x18\x04 \x01(\tR\x03url\x129\n" +
+	"\n" +
+	"updated_at\x18\x05 \x01(\v2\x1a.google.protobuf.TimestampR\tupdatedAt\x12F\n" +
+	"\x11last_delivered_at\x18\x06 \x01(\v2\x1a.google.protobuf.TimestampR\x0flastDeliveredAt\x12\x1d\n" +
+	"\n" +
+	"last_error\x18\a \x01(\tR\tlastError\"0\n" +
+	"\x18ListGroupWebhooksRequest\x12\x14\n" +
+	"\x05token\x18\x01 \x01(\tR\x05token\"\x83\x01\n" +
+	"\x19ListGroupWebhooksResponse\x12\x18\n" +
+	"\asuccess\x18\x01 \x01(\bR\asuccess\x12\x18\n" +
+	"\amessage\x18\x02 \x01(\tR\amessage\x122\n" +
+	"\bwebhooks\x18\x03 \x03(\v2\x16.schedule.GroupWebhookR\bwebhooks\"\x96\x01\n" +
+	"\x16SetGroupWebhookRequest\x12\x14\n" +
+	"\x05token\x18\x01 \x01(\tR\x05token\x12\x1d\n" +
+	"\n" +
+	"group_name\x18\x02 \x01(\tR\tgroupName\x125\n" +
+	"\bprovider\x18\x03 \x01(\x0e2\x19.schedule.WebhookProviderR\bprovider\x12\x10\n" +
+	"\x03url\x18\x04 \x01(\tR\x03url\"\x7f\n" +
+	"\x17SetGroupWebhookResponse\x12\x18\n" +
+	"\asuccess\x18\x01 \x01(\bR\asuccess\x12\x18\n" +
+	"\amessage\x18\x02 \x01(\tR\amessage\x120\n" +
+	"\awebhook\x18\x03 \x01(\v2\x16.schedule.GroupWebhookR\awebhook\"P\n" +
+	"\x19DeleteGroupWebhookRequest\x12\x14\n" +
+	"\x05token\x18\x01 \x01(\tR\x05token\x12\x1d\n" +
+	"\n" +
+	"group_name\x18\x02 \x01(\tR\tgroupName\"P\n" +
+	"\x1aDeleteGroupWebhookResponse\x12\x18\n" +
+	"\asuccess\x18\x01 \x01(\bR\asuccess\x12\x18\n" +
+	"\amessage\x18\x02 \x01(\tR\amessage\"}\n" +
+	"\x16GetTimetablePDFRequest\x12\x14\n" +
+	"\x05token\x18\x01 \x01(\tR\x05token\x12\x1d\n" +
+	"\n" +
+	"group_name\x18\x02 \x01(\tR\tgroupName\x12.\n" +
+	"\x04date\x18\x03 \x01(\v2\x1a.google.protobuf.TimestampR\x04date\"\x9f\x01\n" +
+	"\x17GetTimetablePDFResponse\x12\x18\n" +
+	"\asuccess\x18\x01 \x01(\bR\asuccess\x12\x18\n" +
+	"\amessage\x18\x02 \x01(\tR\amessage\x12\x10\n" +
+	"\x03pdf\x18\x03 \x01(\fR\x03pdf\x12\x1b\n" +
+	"\tfile_name\x18\x04 \x01(\tR\bfileName\x12!\n" +
+	"\fdownload_url\x18\x05 \x01(\tR\vdownloadUrl\"a\n" +
+	"\x1dImportTeacherDirectoryRequest\x12\x14\n" +
+	"\x05token\x18\x01 \x01(\tR\x05token\x12\x10\n" +
+	"\x03csv\x18\x02 \x01(\fR\x03csv\x12\x18\n" +
+	"\areplace\x18\x03 \x01(\bR\areplace\"\xb8\x01\n" +
+	"\x1eImportTeacherDirectoryResponse\x12\x18\n" +
+	"\asuccess\x18\x01 \x01(\bR\asuccess\x12\x18\n" +
+	"\amessage\x18\x02 \x01(\tR\amessage\x12\x14\n" +
+	"\x05total\x18\x03 \x01(\x05R\x05total\x12\x18\n" +
+	"\acreated\x18\x04 \x01(\x05R\acreated\x12\x18\n" +
+	"\aupdated\x18\x05 \x01(\x05R\aupdated\x12\x18\n" +
+	"\aremoved\x18\x06 \x01(\x05R\aremoved\"n\n" +
+	"\x1aSetLessonMeetingUrlRequest\x12\x14\n" +
+	"\x05token\x18\x01 \x01(\tR\x05token\x12\x19\n" +
+	"\bentry_id\x18\x02 \x01(\tR\aentryId\x12\x1f\n" +
+	"\vmeeting_url\x18\x03 \x01(\tR\n" +
+	"meetingUrl\"\x80\x01\n" +
+	"\x1bSetLessonMeetingUrlResponse\x12\x18\n" +
+	"\asuccess\x18\x01 \x01(\bR\asuccess\x12\x18\n" +
+	"\amessage\x18\x02 \x01(\tR\amessage\x12-\n" +
+	"\x05entry\x18\x03 \x01(\v2\x17.schedule.ScheduleEntryR\x05entry\"\x80\x01\n" +
+	"\fElectiveSlot\x12\x18\n" +
+	"\aweekday\x18\x01 \x01(\x05R\aweekday\x12\x1d\n" +
+	"\n" +
+	"time_start\x18\x02 \x01(\tR\ttimeStart\x12\x19\n" +
+	"\btime_end\x18\x03 \x01(\tR\atimeEnd\x12\x1c\n" +
+	"\tclassroom\x18\x04 \x01(\tR\tclassroom\"\xa5\x03\n" +
+	"\x0eElectiveCourse\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x14\n" +
+	"\x05title\x18\x02 \x01(\tR\x05title\x12\x18\n" +
+	"\ateacher\x18\x03 \x01(\tR\ateacher\x12 \n" +
+	"\vdescription\x18\x04 \x01(\tR\vdescription\x12\x1f\n" +
+	"\vgroup_names\x18\x05 \x03(\tR\n" +
+	"groupNames\x12\x1a\n" +
+	"\bcapacity\x18\x06 \x01(\x05R\bcapacity\x12\x1a\n" +
+	"\benrolled\x18\a \x01(\x05R\benrolled\x127\n" +
+	"\tstarts_on\x18\b \x01(\v2\x1a.google.protobuf.TimestampR\bstartsOn\x123\n" +
+	"\aends_on\x18\t \x01(\v2\x1a.google.protobuf.TimestampR\x06endsOn\x12,\n" +
+	"\x05slots\x18\n" +
+	" \x03(\v2\x16.schedule.ElectiveSlotR\x05slots\x12\x1f\n" +
+	"\vis_enrolled\x18\v \x01(\bR\n" +
+	"isEnrolled\x12\x1b\n" +
+	"\tis_active\x18\f \x01(\bR\bisActive\"\xde\x02\n" +
+	"\x1bCreateElectiveCourseRequest\x12\x14\n" +
+	"\x05token\x18\x01 \x01(\tR\x05token\x12\x14\n" +
+	"\x05title\x18\x02 \x01(\tR\x05title\x12\x18\n" +
+	"\ateacher\x18\x03 \x01(\tR\ateacher\x12 \n" +
+	"\vdescription\x18\x04 \x01(\tR\vdescription\x12\x1f\n" +
+	"\vgroup_names\x18\x05 \x03(\tR\n" +
+	"groupNames\x12\x1a\n" +
+	"\bcapacity\x18\x06 \x01(\x05R\bcapacity\x127\n" +
+	"\tstarts_on\x18\a \x01(\v2\x1a.google.protobuf.TimestampR\bstartsOn\x123\n" +
+	"\aends_on\x18\b \x01(\v2\x1a.google.protobuf.TimestampR\x06endsOn\x12,\n" +
+	"\x05slots\x18\t \x03(\v2\x16.schedule.ElectiveSlotR\x05slots\"\x84\x01\n" +
+	"\x1cCreateElectiveCourseResponse\x12\x18\n" +
+	"\asuccess\x18\x01 \x01(\bR\asuccess\x12\x18\n" +
+	"\amessage\x18\x02 \x01(\tR\amessage\x120\n" +
+	"\x06course\x18\x03 \x01(\v2\x18.schedule.ElectiveCourseR\x06course\"P\n" +
+	"\x1bCancelElectiveCourseRequest\x12\x14\n" +
+	"\x05token\x18\x01 \x01(\tR\x05token\x12\x1b\n" +
+	"\tcourse_id\x18\x02 \x01(\tR\bcourseId\"\x7f\n" +
+	"\x1cCancelElectiveCourseResponse\x12\x18\n" +
+	"\asuccess\x18\x01 \x01(\bR\asuccess\x12\x18\n" +
+	"\amessage\x18\x02 \x01(\tR\amessage\x12+\n" +
+	"\x11notified_students\x18\x03 \x01(\x05R\x10notifiedStudents\"2\n" +
+	"\x1aListElectiveCoursesRequest\x12\x14\n" +
+	"\x05token\x18\x01 \x01(\tR\x05token\"\x85\x01\n" +
+	"\x1bListElectiveCoursesResponse\x12\x18\n" +
+	"\asuccess\x18\x01 \x01(\bR\asuccess\x12\x18\n" +
+	"\amessage\x18\x02 \x01(\tR\amessage\x122\n" +
+	"\acourses\x18\x03 \x03(\v2\x18.schedule.ElectiveCourseR\acourses\"J\n" +
+	"\x15EnrollElectiveRequest\x12\x14\n" +
+	"\x05token\x18\x01 \x01(\tR\x05token\x12\x1b\n" +
+	"\tcourse_id\x18\x02 \x01(\tR\bcourseId\"~\n" +
+	"\x16EnrollElectiveResponse\x12\x18\n" +
+	"\asuccess\x18\x01 \x01(\bR\asuccess\x12\x18\n" +
+	"\amessage\x18\x02 \x01(\tR\amessage\x120\n" +
+	"\x06course\x18\x03 \x01(\v2\x18.schedule.ElectiveCourseR\x06course\"L\n" +
+	"\x17UnenrollElectiveRequest\x12\x14\n" +
+	"\x05token\x18\x01 \x01(\tR\x05token\x12\x1b\n" +
+	"\tcourse_id\x18\x02 \x01(\tR\bcourseId\"N\n" +
+	"\x18UnenrollElectiveResponse\x12\x18\n" +
+	"\asuccess\x18\x01 \x01(\bR\asuccess\x12\x18\n" +
+	"\amessage\x18\x02 \x01(\tR\amessage\"\xb8\x01\n" +
+	"\x19GetTeacherWorkloadRequest\x12\x14\n" +
+	"\x05token\x18\x01 \x01(\tR\x05token\x12.\n" +
+	"\x04from\x18\x02 \x01(\v2\x1a.google.protobuf.TimestampR\x04from\x12*\n" +
+	"\x02to\x18\x03 \x01(\v2\x1a.google.protobuf.TimestampR\x02to\x12\x17\n" +
+	"\aby_week\x18\x04 \x01(\bR\x06byWeek\x12\x10\n" +
+	"\x03pdf\x18\x05 \x01(\bR\x03pdf\"\xb4\x01\n" +
+	"\x0fTeacherWorkload\x12\x18\n" +
+	"\ateacher\x18\x01 \x01(\tR\ateacher\x12,\n" +
+	"\x05stats\x18\x02 \x03(\v2\x16.schedule.WorkloadStatR\x05stats\x12\x18\n" +
+	"\alessons\x18\x03 \x01(\x05R\alessons\x12\x18\n" +
+	"\aminutes\x18\x04 \x01(\x05R\aminutes\x12%\n" +
+	"\x0eacademic_hours\x18\x05 \x01(\x01R\racademicHours\"\xd9\x01\n" +
+	"\x1aGetTeacherWorkloadResponse\x12\x18\n" +
+	"\asuccess\x18\x01 \x01(\bR\asuccess\x12\x18\n" +
+	"\amessage\x18\x02 \x01(\tR\amessage\x125\n" +
+	"\bteachers\x18\x03 \x03(\v2\x19.schedule.TeacherWorkloadR\bteachers\x12\x10\n" +
+	"\x03pdf\x18\x04 \x01(\fR\x03pdf\x12\x1b\n" +
+	"\tfile_name\x18\x05 \x01(\tR\bfileName\x12!\n" +
+	"\fdownload_url\x18\x06 \x01(\tR\vdownloadUrl\"\xf3\x01\n" +
+	"\n" +
+	"LessonNote\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x1d\n" +
+	"\n" +
+	"group_name\x18\x02 \x01(\tR\tgroupName\x12.\n" +
+	"\x04date\x18\x03 \x01(\v2\x1a.google.protobuf.TimestampR\x04date\x12\x1d\n" +
+	"\n" +
+	"time_start\x18\x04 \x01(\tR\ttimeStart\x12\x18\n" +
+	"\asubject\x18\x05 \x01(\tR\asubject\x12\x12\n" +
+	"\x04text\x18\x06 \x01(\tR\x04text\x129\n" +
+	"\n" +
+	"updated_at\x18\a \x01(\v2\x1a.google.protobuf.TimestampR\tupdatedAt\"\xc8\x01\n" +
+	"\x14SetLessonNoteRequest\x12\x14\n" +
+	"\x05token\x18\x01 \x01(\tR\x05token\x12\x1d\n" +
+	"\n" +
+	"group_name\x18\x02 \x01(\tR\tgroupName\x12.\n" +
+	"\x04date\x18\x03 \x01(\v2\x1a.google.protobuf.TimestampR\x04date\x12\x1d\n" +
+	"\n" +
+	"time_start\x18\x04 \x01(\tR\ttimeStart\x12\x18\n" +
+	"\asubject\x18\x05 \x01(\tR\asubject\x12\x12\n" +
+	"\x04text\x18\x06 \x01(\tR\x04text\"u\n" +
+	"\x15SetLessonNoteResponse\x12\x18\n" +
+	"\asuccess\x18\x01 \x01(\bR\asuccess\x12\x18\n" +
+	"\amessage\x18\x02 \x01(\tR\amessage\x12(\n" +
+	"\x04note\x18\x03 \x01(\v2\x14.schedule.LessonNoteR\x04note\"\x8a\x01\n" +
+	"\x16ListLessonNotesRequest\x12\x14\n" +
+	"\x05token\x18\x01 \x01(\tR\x05token\x12.\n" +
+	"\x04from\x18\x02 \x01(\v2\x1a.google.protobuf.TimestampR\x04from\x12*\n" +
+	"\x02to\x18\x03 \x01(\v2\x1a.google.protobuf.TimestampR\x02to\"y\n" +
+	"\x17ListLessonNotesResponse\x12\x18\n" +
+	"\asuccess\x18\x01 \x01(\bR\asuccess\x12\x18\n" +
+	"\amessage\x18\x02 \x01(\tR\amessage\x12*\n" +
+	"\x05notes\x18\x03 \x03(\v2\x14.schedule.LessonNoteR\x05notes\"H\n" +
+	"\x17DeleteLessonNoteRequest\x12\x14\n" +
+	"\x05token\x18\x01 \x01(\tR\x05token\x12\x17\n" +
+	"\anote_id\x18\x02 \x01(\tR\x06noteId\"N\n" +
+	"\x18DeleteLessonNoteResponse\x12\x18\n" +
+	"\asuccess\x18\x01 \x01(\bR\asuccess\x12\x18\n" +
+	"\amessage\x18\x02 \x01(\tR\amessage\"|\n" +
+	"\bBuilding\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x12\n" +
+	"\x04code\x18\x02 \x01(\tR\x04code\x12\x12\n" +
+	"\x04name\x18\x03 \x01(\tR\x04name\x12\x18\n" +
+	"\aaddress\x18\x04 \x01(\tR\aaddress\x12\x1e\n" +
+	"\n" +
+	"classrooms\x18\x05 \x03(\tR\n" +
+	"classrooms\",\n" +
+	"\x14ListBuildingsRequest\x12\x14\n" +
+	"\x05token\x18\x01 \x01(\tR\x05token\"\xa4\x01\n" +
+	"\x15ListBuildingsResponse\x12\x18\n" +
+	"\asuccess\x18\x01 \x01(\bR\asuccess\x12\x18\n" +
+	"\amessage\x18\x02 \x01(\tR\amessage\x120\n" +
+	"\tbuildings\x18\x03 \x03(\v2\x12.schedule.BuildingR\tbuildings\x12%\n" +
+	"\x0etravel_minutes\x18\x04 \x01(\x05R\rtravelMinutes\"Z\n" +
+	"\x12SetBuildingRequest\x12\x14\n" +
+	"\x05token\x18\x01 \x01(\tR\x05token\x12.\n" +
+	"\bbuilding\x18\x02 \x01(\v2\x12.schedule.BuildingR\bbuilding\"y\n" +
+	"\x13SetBuildingResponse\x12\x18\n" +
+	"\asuccess\x18\x01 \x01(\bR\asuccess\x12\x18\n" +
+	"\amessage\x18\x02 \x01(\tR\amessage\x12.\n" +
+	"\bbuilding\x18\x03 \x01(\v2\x12.schedule.BuildingR\bbuilding\"A\n" +
+	"\x15DeleteBuildingRequest\x12\x14\n" +
+	"\x05token\x18\x01 \x01(\tR\x05token\x12\x12\n" +
+	"\x04code\x18\x02 \x01(\tR\x04code\"L\n" +
+	"\x16DeleteBuildingResponse\x12\x18\n" +
+	"\asuccess\x18\x01 \x01(\bR\asuccess\x12\x18\n" +
+	"\amessage\x18\x02 \x01(\tR\amessage\"1\n" +
+	"\vGroupRename\x12\x12\n" +
+	"\x04from\x18\x01 \x01(\tR\x04from\x12\x0e\n" +
+	"\x02to\x18\x02 \x01(\tR\x02to\"\xfb\x01\n" +
+	"\x1aRunAcademicRolloverRequest\x12\x14\n" +
+	"\x05token\x18\x01 \x01(\tR\x05token\x129\n" +
+	"\n" +
+	"year_start\x18\x02 \x01(\v2\x1a.google.protobuf.TimestampR\tyearStart\x12\x1d\n" +
+	"\n" +
+	"max_course\x18\x03 \x01(\x05R\tmaxCourse\x12/\n" +
+	"\arenames\x18\x04 \x03(\v2\x15.schedule.GroupRenameR\arenames\x12#\n" +
+	"\rretire_groups\x18\x05 \x03(\tR\fretireGroups\x12\x17\n" +
+	"\adry_run\x18\x06 \x01(\bR\x06dryRun\"\xd4\x03\n" +
+	"\x1bRunAcademicRolloverResponse\x12\x18\n" +
+	"\asuccess\x18\x01 \x01(\bR\asuccess\x12\x18\n" +
+	"\amessage\x18\x02 \x01(\tR\amessage\x12\x1f\n" +
+	"\vrollover_id\x18\x03 \x01(\tR\n" +
+	"rolloverId\x12-\n" +
+	"\x12snapshots_archived\x18\x04 \x01(\x05R\x11snapshotsArchived\x12+\n" +
+	"\x11students_advanced\x18\x05 \x01(\x05R\x10studentsAdvanced\x12-\n" +
+	"\x12students_graduated\x18\x06 \x01(\x05R\x11studentsGraduated\x12)\n" +
+	"\x10students_renamed\x18\a \x01(\x05R\x0fstudentsRenamed\x12%\n" +
+	"\x0eretired_groups\x18\b \x03(\tR\rretiredGroups\x12)\n" +
+	"\x10webhooks_removed\x18\t \x01(\x05R\x0fwebhooksRemoved\x12/\n" +
+	"\x13enrollments_removed\x18\n" +
+	" \x01(\x05R\x12enrollmentsRemoved\x12'\n" +
+	"\x0fsubgroups_reset\x18\v \x01(\x05R\x0esubgroupsReset\"\xc7\x02\n" +
+	"\fConsultation\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x1d\n" +
+	"\n" +
+	"teacher_id\x18\x02 \x01(\tR\tteacherId\x12\x18\n" +
+	"\ateacher\x18\x03 \x01(\tR\ateacher\x12\x18\n" +
+	"\aweekday\x18\x04 \x01(\x05R\aweekday\x12\x1d\n" +
+	"\n" +
+	"time_start\x18\x05 \x01(\tR\ttimeStart\x12\x19\n" +
+	"\btime_end\x18\x06 \x01(\tR\atimeEnd\x12\x1c\n" +
+	"\tclassroom\x18\a \x01(\tR\tclassroom\x12\x18\n" +
+	"\acomment\x18\b \x01(\tR\acomment\x127\n" +
+	"\tnext_date\x18\t \x01(\v2\x1a.google.protobuf.TimestampR\bnextDate\x12)\n" +
+	"\x10reminder_enabled\x18\n" +
+	" \x01(\bR\x0freminderEnabled\"q\n" +
+	"\x1bSetConsultationHoursRequest\x12\x14\n" +
+	"\x05token\x18\x01 \x01(\tR\x05token\x12<\n" +
+	"\rconsultations\x18\x02 \x03(\v2\x16.schedule.ConsultationR\rconsultations\"\x90\x01\n" +
+	"\x1cSetConsultationHoursResponse\x12\x18\n" +
+	"\asuccess\x18\x01 \x01(\bR\asuccess\x12\x18\n" +
+	"\amessage\x18\x02 \x01(\tR\amessage\x12<\n" +
+	"\rconsultations\x18\x03 \x03(\v2\x16.schedule.ConsultationR\rconsultations\"4\n" +
+	"\x1cListConsultationHoursRequest\x12\x14\n" +
+	"\x05token\x18\x01 \x01(\tR\x05token\"\x91\x01\n" +
+	"\x1dListConsultationHoursResponse\x12\x18\n" +
+	"\asuccess\x18\x01 \x01(\bR\asuccess\x12\x18\n" +
+	"\amessage\x18\x02 \x01(\tR\amessage\x12<\n" +
+	"\rconsultations\x18\x03 \x03(\v2\x16.schedule.ConsultationR\rconsultations\"o\n" +
+	"\x1eSetConsultationReminderRequest\x12\x14\n" +
+	"\x05token\x18\x01 \x01(\tR\x05token\x12\x1d\n" +
+	"\n" +
+	"teacher_id\x18\x02 \x01(\tR\tteacherId\x12\x18\n" +
+	"\aenabled\x18\x03 \x01(\bR\aenabled\"U\n" +
+	"\x1fSetConsultationReminderResponse\x12\x18\n" +
+	"\asuccess\x18\x01 \x01(\bR\asuccess\x12\x18\n" +
+	"\amessage\x18\x02 \x01(\tR\amessage\"\xba\x02\n" +
+	"\fNotification\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x14\n" +
+	"\x05title\x18\x02 \x01(\tR\x05title\x12\x18\n" +
+	"\amessage\x18\x03 \x01(\tR\amessage\x12\x12\n" +
+	"\x04type\x18\x04 \x01(\tR\x04type\x12#\n" +
+	"\rrelated_group\x18\x05 \x01(\tR\frelatedGroup\x12=\n" +
+	"\frelated_date\x18\x06 \x01(\v2\x1a.google.protobuf.TimestampR\vrelatedDate\x129\n" +
+	"\n" +
+	"created_at\x18\a \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\x127\n" +
+	"\apayload\x18\b \x01(\v2\x1d.schedule.NotificationPayloadR\apayload\"\xfb\x01\n" +
+	"\x13NotificationPayload\x12\x12\n" +
+	"\x04type\x18\x01 \x01(\tR\x04type\x12\x14\n" +
+	"\x05group\x18\x02 \x01(\tR\x05group\x12\x12\n" +
+	"\x04date\x18\x03 \x01(\tR\x04date\x12\x12\n" +
+	"\x04time\x18\x04 \x01(\tR\x04time\x12\x1b\n" +
+	"\tchange_id\x18\x05 \x01(\tR\bchangeId\x12\x19\n" +
+	"\bentry_id\x18\x06 \x01(\tR\aentryId\x12'\n" +
+	"\x0fconsultation_id\x18\a \x01(\tR\x0econsultationId\x12\x1b\n" +
+	"\tcourse_id\x18\b \x01(\tR\bcourseId\x12\x14\n" +
+	"\x05route\x18\t \x01(\tR\x05route\"\x85\x01\n" +
+	"\x12PollUpdatesRequest\x12\x14\n" +
+	"\x05token\x18\x01 \x01(\tR\x05token\x120\n" +
+	"\x05since\x18\x02 \x01(\v2\x1a.google.protobuf.TimestampR\x05since\x12'\n" +
+	"\x0ftimeout_seconds\x18\x03 \x01(\x05R\x0etimeoutSeconds\"\x87\x01\n" +
+	"\x13PollUpdatesResponse\x12<\n" +
+	"\rnotifications\x18\x01 \x03(\v2\x16.schedule.NotificationR\rnotifications\x122\n" +
+	"\x06cursor\x18\x02 \x01(\v2\x1a.google.protobuf.TimestampR\x06cursor\"\xe9\x01\n" +
+	"\fKioskDisplay\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x12\n" +
+	"\x04name\x18\x02 \x01(\tR\x04name\x12\x1c\n" +
+	"\tbuildings\x18\x03 \x03(\tR\tbuildings\x12\x1e\n" +
+	"\n" +
+	"classrooms\x18\x04 \x03(\tR\n" +
+	"classrooms\x129\n" +
+	"\n" +
+	"created_at\x18\x05 \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\x12<\n" +
+	"\flast_seen_at\x18\x06 \x01(\v2\x1a.google.protobuf.TimestampR\n" +
+	"lastSeenAt\"\x83\x01\n" +
+	"\x19CreateKioskDisplayRequest\x12\x14\n" +
+	"\x05token\x18\x01 \x01(\tR\x05token\x12\x12\n" +
+	"\x04name\x18\x02 \x01(\tR\x04name\x12\x1c\n" +
+	"\tbuildings\x18\x03 \x03(\tR\tbuildings\x12\x1e\n" +
+	"\n" +
+	"classrooms\x18\x04 \x03(\tR\n" +
+	"classrooms\"\x94\x01\n" +
+	"\x1aCreateKioskDisplayResponse\x12\x18\n" +
+	"\asuccess\x18\x01 \x01(\bR\asuccess\x12\x18\n" +
+	"\amessage\x18\x02 \x01(\tR\amessage\x120\n" +
+	"\adisplay\x18\x03 \x01(\v2\x16.schedule.KioskDisplayR\adisplay\x12\x10\n" +
+	"\x03key\x18\x04 \x01(\tR\x03key\"0\n" +
+	"\x18ListKioskDisplaysRequest\x12\x14\n" +
+	"\x05token\x18\x01 \x01(\tR\x05token\"\x83\x01\n" +
+	"\x19ListKioskDisplaysResponse\x12\x18\n" +
+	"\asuccess\x18\x01 \x01(\bR\asuccess\x12\x18\n" +
+	"\amessage\x18\x02 \x01(\tR\amessage\x122\n" +
+	"\bdisplays\x18\x03 \x03(\v2\x16.schedule.KioskDisplayR\bdisplays\"A\n" +
+	"\x19RevokeKioskDisplayRequest\x12\x14\n" +
+	"\x05token\x18\x01 \x01(\tR\x05token\x12\x0e\n" +
+	"\x02id\x18\x02 \x01(\tR\x02id\"P\n" +
+	"\x1aRevokeKioskDisplayResponse\x12\x18\n" +
+	"\asuccess\x18\x01 \x01(\bR\asuccess\x12\x18\n" +
+	"\amessage\x18\x02 \x01(\tR\amessage\"\xd1\x01\n" +
+	"\fWidgetLesson\x12\x18\n" +
+	"\asubject\x18\x01 \x01(\tR\asubject\x12\x1d\n" +
+	"\n" +
+	"time_start\x18\x02 \x01(\tR\ttimeStart\x12\x19\n" +
+	"\btime_end\x18\x03 \x01(\tR\atimeEnd\x12\x1c\n" +
+	"\tclassroom\x18\x04 \x01(\tR\tclassroom\x12\x18\n" +
+	"\ateacher\x18\x05 \x01(\tR\ateacher\x12\x1d\n" +
+	"\n" +
+	"group_name\x18\x06 \x01(\tR\tgroupName\x12\x16\n" +
+	"\x06online\x18\a \x01(\bR\x06online\"/\n" +
+	"\x17GetWidgetSummaryRequest\x12\x14\n" +
+	"\x05token\x18\x01 \x01(\tR\x05token\"\xe8\x02\n" +
+	"\x18GetWidgetSummaryResponse\x12=\n" +
+	"\x0ecurrent_lesson\x18\x01 \x01(\v2\x16.schedule.WidgetLessonR\rcurrentLesson\x127\n" +
+	"\vnext_lesson\x18\x02 \x01(\v2\x16.schedule.WidgetLessonR\n" +
+	"nextLesson\x125\n" +
+	"\x17minutes_until_next_bell\x18\x03 \x01(\x05R\x14minutesUntilNextBell\x127\n" +
+	"\tnext_bell\x18\x04 \x01(\v2\x1a.google.protobuf.TimestampR\bnextBell\x12'\n" +
+	"\x0fremaining_today\x18\x05 \x01(\x05R\x0eremainingToday\x12;\n" +
+	"\vvalid_until\x18\x06 \x01(\v2\x1a.google.protobuf.TimestampR\n" +
+	"validUntil\",\n" +
+	"\x14GetNextLessonRequest\x12\x14\n" +
+	"\x05token\x18\x01 \x01(\tR\x05token\"\xcc\x01\n" +
+	"\x15GetNextLessonResponse\x12\x18\n" +
+	"\asuccess\x18\x01 \x01(\bR\asuccess\x12\x18\n" +
+	"\amessage\x18\x02 \x01(\tR\amessage\x12/\n" +
+	"\x06lesson\x18\x03 \x01(\v2\x17.schedule.ScheduleEntryR\x06lesson\x12\"\n" +
+	"\fconsultation\x18\x04 \x01(\bR\fconsultation\x12*\n" +
+	"\x11starts_in_minutes\x18\x05 \x01(\x05R\x0fstartsInMinutes\",\n" +
+	"\x14GetBellStatusRequest\x12\x14\n" +
+	"\x05token\x18\x01 \x01(\tR\x05token\"\xb3\x02\n" +
+	"\x15GetBellStatusResponse\x12)\n" +
+	"\x05state\x18\x01 \x01(\x0e2\x13.schedule.BellStateR\x05state\x12#\n" +
+	"\rlesson_number\x18\x02 \x01(\x05R\flessonNumber\x12\x1d\n" +
+	"\n" +
+	"time_start\x18\x03 \x01(\tR\ttimeStart\x12\x19\n" +
+	"\btime_end\x18\x04 \x01(\tR\atimeEnd\x120\n" +
+	"\x05until\x18\x05 \x01(\v2\x1a.google.protobuf.TimestampR\x05until\x12!\n" +
+	"\fminutes_left\x18\x06 \x01(\x05R\vminutesLeft\x12;\n" +
+	"\vserver_time\x18\a \x01(\v2\x1a.google.protobuf.TimestampR\n" +
+	"serverTime\"2\n" +
+	"\x1aGetTeacherDashboardRequest\x12\x14\n" +
+	"\x05token\x18\x01 \x01(\tR\x05token\"\xf8\x02\n" +
+	"\x1bGetTeacherDashboardResponse\x12\x18\n" +
+	"\asuccess\x18\x01 \x01(\bR\asuccess\x12\x18\n" +
+	"\amessage\x18\x02 \x01(\tR\amessage\x12-\n" +
+	"\x05today\x18\x03 \x03(\v2\x17.schedule.ScheduleEntryR\x05today\x12=\n" +
+	"\rsubstitutions\x18\x04 \x03(\v2\x17.schedule.ScheduleEntryR\rsubstitutions\x12I\n" +
+	"\x10pending_requests\x18\x05 \x03(\v2\x1e.schedule.TeacherChangeRequestR\x0fpendingRequests\x12I\n" +
+	"\x14unread_notifications\x18\x06 \x03(\v2\x16.schedule.NotificationR\x13unreadNotifications\x12!\n" +
+	"\funread_count\x18\a \x01(\x05R\vunreadCount\"\xf1\x01\n" +
+	"\x1aReportScheduleErrorRequest\x12\x14\n" +
+	"\x05token\x18\x01 \x01(\tR\x05token\x12\x1d\n" +
+	"\n" +
+	"group_name\x18\x02 \x01(\tR\tgroupName\x12.\n" +
+	"\x04date\x18\x03 \x01(\v2\x1a.google.protobuf.TimestampR\x04date\x12\x1d\n" +
+	"\n" +
+	"time_start\x18\x04 \x01(\tR\ttimeStart\x125\n" +
+	"\x06reason\x18\x05 \x01(\x0e2\x1d.schedule.ScheduleErrorReasonR\x06reason\x12\x18\n" +
+	"\acomment\x18\x06 \x01(\tR\acomment\"k\n" +
+	"\x1bReportScheduleErrorResponse\x12\x18\n" +
+	"\asuccess\x18\x01 \x01(\bR\asuccess\x12\x18\n" +
+	"\amessage\x18\x02 \x01(\tR\amessage\x12\x18\n" +
+	"\areports\x18\x03 \x01(\x05R\areports*\x9d\x01\n" +
 	"\x12ScheduleSourceType\x12$\n" +
 	" SCHEDULE_SOURCE_TYPE_UNSPECIFIED\x10\x00\x12\x1d\n" +
 	"\x19SCHEDULE_SOURCE_TYPE_MAIN\x10\x01\x12\x1f\n" +
-	"\x1bSCHEDULE_SOURCE_TYPE_CHANGE\x10\x02*\xaa\x01\n" +
+	"\x1bSCHEDULE_SOURCE_TYPE_CHANGE\x10\x02\x12!\n" +
+	"\x1dSCHEDULE_SOURCE_TYPE_ELECTIVE\x10\x03*\xa9\x01\n" +
+	"\tBellState\x12\x1a\n" +
+	"\x16BELL_STATE_UNSPECIFIED\x10\x00\x12\x15\n" +
+	"\x11BELL_STATE_LESSON\x10\x01\x12\x14\n" +
+	"\x10BELL_STATE_BREAK\x10\x02\x12\x1d\n" +
+	"\x19BELL_STATE_BEFORE_CLASSES\x10\x03\x12\x1c\n" +
+	"\x18BELL_STATE_AFTER_CLASSES\x10\x04\x12\x16\n" +
+	"\x12BELL_STATE_DAY_OFF\x10\x05*\xaa\x01\n" +
 	"\x12ScheduleChangeType\x12$\n" +
 	" SCHEDULE_CHANGE_TYPE_UNSPECIFIED\x10\x00\x12$\n" +
 	" SCHEDULE_CHANGE_TYPE_REPLACEMENT\x10\x01\x12%\n" +
 	"!SCHEDULE_CHANGE_TYPE_CANCELLATION\x10\x02\x12!\n" +
-	"\x1dSCHEDULE_CHANGE_TYPE_ADDITION\x10\x032\xe7\x02\n" +
+	"\x1dSCHEDULE_CHANGE_TYPE_ADDITION\x10\x03*\x8f\x01\n" +
+	"\x0fGroupDiffStatus\x12!\n" +
+	"\x1dGROUP_DIFF_STATUS_UNSPECIFIED\x10\x00\x12\x1b\n" +
+	"\x17GROUP_DIFF_STATUS_ADDED\x10\x01\x12\x1d\n" +
+	"\x19GROUP_DIFF_STATUS_REMOVED\x10\x02\x12\x1d\n" +
+	"\x19GROUP_DIFF_STATUS_CHANGED\x10\x03*\xdc\x01\n" +
+	"\x11ChangeApplyStatus\x12#\n" +
+	"\x1fCHANGE_APPLY_STATUS_UNSPECIFIED\x10\x00\x12\x1f\n" +
+	"\x1bCHANGE_APPLY_STATUS_PENDING\x10\x01\x12\x1f\n" +
+	"\x1bCHANGE_APPLY_STATUS_APPLIED\x10\x02\x12\x1f\n" +
+	"\x1bCHANGE_APPLY_STATUS_SKIPPED\x10\x03\x12\x1d\n" +
+	"\x19CHANGE_APPLY_STATUS_ERROR\x10\x04\x12 \n" +
+	"\x1cCHANGE_APPLY_STATUS_REVERTED\x10\x05*\xb6\x01\n" +
+	"\x16ChangeModerationStatus\x12(\n" +
+	"$CHANGE_MODERATION_STATUS_UNSPECIFIED\x10\x00\x12$\n" +
+	" CHANGE_MODERATION_STATUS_PENDING\x10\x01\x12%\n" +
+	"!CHANGE_MODERATION_STATUS_APPROVED\x10\x02\x12%\n" +
+	"!CHANGE_MODERATION_STATUS_REJECTED\x10\x03*j\n" +
+	"\x0eReviewDecision\x12\x1f\n" +
+	"\x1bREVIEW_DECISION_UNSPECIFIED\x10\x00\x12\x1b\n" +
+	"\x17REVIEW_DECISION_APPROVE\x10\x01\x12\x1a\n" +
+	"\x16REVIEW_DECISION_REJECT\x10\x02*\x95\x01\n" +
+	"\x18TeacherChangeRequestKind\x12+\n" +
+	"'TEACHER_CHANGE_REQUEST_KIND_UNSPECIFIED\x10\x00\x12&\n" +
+	"\"TEACHER_CHANGE_REQUEST_KIND_CANCEL\x10\x01\x12$\n" +
+	" TEACHER_CHANGE_REQUEST_KIND_MOVE\x10\x02*\x83\x01\n" +
+	"\tJobStatus\x12\x1a\n" +
+	"\x16JOB_STATUS_UNSPECIFIED\x10\x00\x12\x16\n" +
+	"\x12JOB_STATUS_PENDING\x10\x01\x12\x16\n" +
+	"\x12JOB_STATUS_RUNNING\x10\x02\x12\x13\n" +
+	"\x0fJOB_STATUS_DONE\x10\x03\x12\x15\n" +
+	"\x11JOB_STATUS_FAILED\x10\x04*m\n" +
+	"\x0fWebhookProvider\x12 \n" +
+	"\x1cWEBHOOK_PROVIDER_UNSPECIFIED\x10\x00\x12\x1c\n" +
+	"\x18WEBHOOK_PROVIDER_DISCORD\x10\x01\x12\x1a\n" +
+	"\x16WEBHOOK_PROVIDER_SLACK\x10\x02*\xa4\x02\n" +
+	"\x13ScheduleErrorReason\x12%\n" +
+	"!SCHEDULE_ERROR_REASON_UNSPECIFIED\x10\x00\x12'\n" +
+	"#SCHEDULE_ERROR_REASON_WRONG_SUBJECT\x10\x01\x12'\n" +
+	"#SCHEDULE_ERROR_REASON_WRONG_TEACHER\x10\x02\x12)\n" +
+	"%SCHEDULE_ERROR_REASON_WRONG_CLASSROOM\x10\x03\x12$\n" +
+	" SCHEDULE_ERROR_REASON_WRONG_TIME\x10\x04\x12\"\n" +
+	"\x1eSCHEDULE_ERROR_REASON_NOT_HELD\x10\x05\x12\x1f\n" +
+	"\x1bSCHEDULE_ERROR_REASON_OTHER\x10\x062\xcd0\n" +
 	"\x0fScheduleService\x12b\n" +
 	"\x13GetScheduleForGroup\x12$.schedule.GetScheduleForGroupRequest\x1a%.schedule.GetScheduleForGroupResponse\x12t\n" +
 	"\x19GetActiveScheduleSnapshot\x12*.schedule.GetActiveScheduleSnapshotRequest\x1a+.schedule.GetActiveScheduleSnapshotResponse\x12z\n" +
-	"\x1bGetScheduleSnapshotsHistory\x12,.schedule.GetScheduleSnapshotsHistoryRequest\x1a-.schedule.GetScheduleSnapshotsHistoryResponseB\fZ\n" +
+	"\x1bGetScheduleSnapshotsHistory\x12,.schedule.GetScheduleSnapshotsHistoryRequest\x1a-.schedule.GetScheduleSnapshotsHistoryResponse\x12V\n" +
+	"\x0fGetSnapshotData\x12 .schedule.GetSnapshotDataRequest\x1a!.schedule.GetSnapshotDataResponse\x12P\n" +
+	"\rGetMySchedule\x12\x1e.schedule.GetMyScheduleRequest\x1a\x1f.schedule.GetMyScheduleResponse\x12Y\n" +
+	"\x10GetWidgetSummary\x12!.schedule.GetWidgetSummaryRequest\x1a\".schedule.GetWidgetSummaryResponse\x12P\n" +
+	"\rGetNextLesson\x12\x1e.schedule.GetNextLessonRequest\x1a\x1f.schedule.GetNextLessonResponse\x12P\n" +
+	"\rGetBellStatus\x12\x1e.schedule.GetBellStatusRequest\x1a\x1f.schedule.GetBellStatusResponse\x12b\n" +
+	"\x13GetTeacherDashboard\x12$.schedule.GetTeacherDashboardRequest\x1a%.schedule.GetTeacherDashboardResponse\x12b\n" +
+	"\x13ReportScheduleError\x12$.schedule.ReportScheduleErrorRequest\x1a%.schedule.ReportScheduleErrorResponse\x12P\n" +
+	"\rFindFreeSlots\x12\x1e.schedule.FindFreeSlotsRequest\x1a\x1f.schedule.FindFreeSlotsResponse\x12Y\n" +
+	"\x10GetWorkloadStats\x12!.schedule.GetWorkloadStatsRequest\x1a\".schedule.GetWorkloadStatsResponse\x12S\n" +
+	"\x0eGetChangeStats\x12\x1f.schedule.GetChangeStatsRequest\x1a .schedule.GetChangeStatsResponse\x12S\n" +
+	"\x0eRunMaintenance\x12\x1f.schedule.RunMaintenanceRequest\x1a .schedule.RunMaintenanceResponse\x12S\n" +
+	"\x0eSearchSchedule\x12\x1f.schedule.SearchScheduleRequest\x1a .schedule.SearchScheduleResponse\x12Y\n" +
+	"\x10CompareSnapshots\x12!.schedule.CompareSnapshotsRequest\x1a\".schedule.CompareSnapshotsResponse\x12b\n" +
+	"\x13ListSubjectMetadata\x12$.schedule.ListSubjectMetadataRequest\x1a%.schedule.ListSubjectMetadataResponse\x12h\n" +
+	"\x15UpsertSubjectMetadata\x12&.schedule.UpsertSubjectMetadataRequest\x1a'.schedule.UpsertSubjectMetadataResponse\x12h\n" +
+	"\x15DeleteSubjectMetadata\x12&.schedule.DeleteSubjectMetadataRequest\x1a'.schedule.DeleteSubjectMetadataResponse\x12k\n" +
+	"\x16ListOverlappingChanges\x12'.schedule.ListOverlappingChangesRequest\x1a(.schedule.ListOverlappingChangesResponse\x12b\n" +
+	"\x13ListSnapshotChanges\x12$.schedule.ListSnapshotChangesRequest\x1a%.schedule.ListSnapshotChangesResponse\x12\x80\x01\n" +
+	"\x1dListChangesAwaitingModeration\x12..schedule.ListChangesAwaitingModerationRequest\x1a/.schedule.ListChangesAwaitingModerationResponse\x12M\n" +
+	"\fReviewChange\x12\x1d.schedule.ReviewChangeRequest\x1a\x1e.schedule.ReviewChangeResponse\x12w\n" +
+	"\x1aSubmitTeacherChangeRequest\x12+.schedule.SubmitTeacherChangeRequestRequest\x1a,.schedule.SubmitTeacherChangeRequestResponse\x12z\n" +
+	"\x1bListMyTeacherChangeRequests\x12,.schedule.ListMyTeacherChangeRequestsRequest\x1a-.schedule.ListMyTeacherChangeRequestsResponse\x12\x89\x01\n" +
+	" ListPendingTeacherChangeRequests\x121.schedule.ListPendingTeacherChangeRequestsRequest\x1a2.schedule.ListPendingTeacherChangeRequestsResponse\x12w\n" +
+	"\x1aReviewTeacherChangeRequest\x12+.schedule.ReviewTeacherChangeRequestRequest\x1a,.schedule.ReviewTeacherChangeRequestResponse\x12Y\n" +
+	"\x10ClaimTeacherName\x12!.schedule.ClaimTeacherNameRequest\x1a\".schedule.ClaimTeacherNameResponse\x12n\n" +
+	"\x17ListMyTeacherNameClaims\x12(.schedule.ListMyTeacherNameClaimsRequest\x1a).schedule.ListMyTeacherNameClaimsResponse\x12}\n" +
+	"\x1cListPendingTeacherNameClaims\x12-.schedule.ListPendingTeacherNameClaimsRequest\x1a..schedule.ListPendingTeacherNameClaimsResponse\x12k\n" +
+	"\x16ReviewTeacherNameClaim\x12'.schedule.ReviewTeacherNameClaimRequest\x1a(.schedule.ReviewTeacherNameClaimResponse\x12S\n" +
+	"\x0eGetGroupRoster\x12\x1f.schedule.GetGroupRosterRequest\x1a .schedule.GetGroupRosterResponse\x12A\n" +
+	"\bListJobs\x12\x19.schedule.ListJobsRequest\x1a\x1a.schedule.ListJobsResponse\x12A\n" +
+	"\bRetryJob\x12\x19.schedule.RetryJobRequest\x1a\x1a.schedule.RetryJobResponse\x12Y\n" +
+	"\x10ListFeatureFlags\x12!.schedule.ListFeatureFlagsRequest\x1a\".schedule.ListFeatureFlagsResponse\x12S\n" +
+	"\x0eSetFeatureFlag\x12\x1f.schedule.SetFeatureFlagRequest\x1a .schedule.SetFeatureFlagResponse\x12Y\n" +
+	"\x10ResetFeatureFlag\x12!.schedule.ResetFeatureFlagRequest\x1a\".schedule.ResetFeatureFlagResponse\x12n\n" +
+	"\x17GetCalendarSubscription\x12(.schedule.GetCalendarSubscriptionRequest\x1a).schedule.GetCalendarSubscriptionResponse\x12\\\n" +
+	"\x11ListGroupWebhooks\x12\".schedule.ListGroupWebhooksRequest\x1a#.schedule.ListGroupWebhooksResponse\x12V\n" +
+	"\x0fSetGroupWebhook\x12 .schedule.SetGroupWebhookRequest\x1a!.schedule.SetGroupWebhookResponse\x12_\n" +
+	"\x12DeleteGroupWebhook\x12#.schedule.DeleteGroupWebhookRequest\x1a$.schedule.DeleteGroupWebhookResponse\x12V\n" +
+	"\x0fGetTimetablePDF\x12 .schedule.GetTimetablePDFRequest\x1a!.schedule.GetTimetablePDFResponse\x12k\n" +
+	"\x16ImportTeacherDirectory\x12'.schedule.ImportTeacherDirectoryRequest\x1a(.schedule.ImportTeacherDirectoryResponse\x12b\n" +
+	"\x13SetLessonMeetingUrl\x12$.schedule.SetLessonMeetingUrlRequest\x1a%.schedule.SetLessonMeetingUrlResponse\x12e\n" +
+	"\x14CreateElectiveCourse\x12%.schedule.CreateElectiveCourseRequest\x1a&.schedule.CreateElectiveCourseResponse\x12e\n" +
+	"\x14CancelElectiveCourse\x12%.schedule.CancelElectiveCourseRequest\x1a&.schedule.CancelElectiveCourseResponse\x12b\n" +
+	"\x13ListElectiveCourses\x12$.schedule.ListElectiveCoursesRequest\x1a%.schedule.ListElectiveCoursesResponse\x12S\n" +
+	"\x0eEnrollElective\x12\x1f.schedule.EnrollElectiveRequest\x1a .schedule.EnrollElectiveResponse\x12Y\n" +
+	"\x10UnenrollElective\x12!.schedule.UnenrollElectiveRequest\x1a\".schedule.UnenrollElectiveResponse\x12_\n" +
+	"\x12GetTeacherWorkload\x12#.schedule.GetTeacherWorkloadRequest\x1a$.schedule.GetTeacherWorkloadResponse\x12P\n" +
+	"\rSetLessonNote\x12\x1e.schedule.SetLessonNoteRequest\x1a\x1f.schedule.SetLessonNoteResponse\x12V\n" +
+	"\x0fListLessonNotes\x12 .schedule.ListLessonNotesRequest\x1a!.schedule.ListLessonNotesResponse\x12Y\n" +
+	"\x10DeleteLessonNote\x12!.schedule.DeleteLessonNoteRequest\x1a\".schedule.DeleteLessonNoteResponse\x12P\n" +
+	"\rListBuildings\x12\x1e.schedule.ListBuildingsRequest\x1a\x1f.schedule.ListBuildingsResponse\x12J\n" +
+	"\vSetBuilding\x12\x1c.schedule.SetBuildingRequest\x1a\x1d.schedule.SetBuildingResponse\x12S\n" +
+	"\x0eDeleteBuilding\x12\x1f.schedule.DeleteBuildingRequest\x1a .schedule.DeleteBuildingResponse\x12b\n" +
+	"\x13RunAcademicRollover\x12$.schedule.RunAcademicRolloverRequest\x1a%.schedule.RunAcademicRolloverResponse\x12e\n" +
+	"\x14SetConsultationHours\x12%.schedule.SetConsultationHoursRequest\x1a&.schedule.SetConsultationHoursResponse\x12h\n" +
+	"\x15ListConsultationHours\x12&.schedule.ListConsultationHoursRequest\x1a'.schedule.ListConsultationHoursResponse\x12n\n" +
+	"\x17SetConsultationReminder\x12(.schedule.SetConsultationReminderRequest\x1a).schedule.SetConsultationReminderResponse\x12J\n" +
+	"\vPollUpdates\x12\x1c.schedule.PollUpdatesRequest\x1a\x1d.schedule.PollUpdatesResponse\x12_\n" +
+	"\x12CreateKioskDisplay\x12#.schedule.CreateKioskDisplayRequest\x1a$.schedule.CreateKioskDisplayResponse\x12\\\n" +
+	"\x11ListKioskDisplays\x12\".schedule.ListKioskDisplaysRequest\x1a#.schedule.ListKioskDisplaysResponse\x12_\n" +
+	"\x12RevokeKioskDisplay\x12#.schedule.RevokeKioskDisplayRequest\x1a$.schedule.RevokeKioskDisplayResponseB\fZ\n" +
 	"./scheduleb\x06proto3"
 
 var (
@@ -759,42 +12688,467 @@ func file_schedule_proto_rawDescGZIP() []byte {
 	return file_schedule_proto_rawDescData
 }
 
-var file_schedule_proto_enumTypes = make([]protoimpl.EnumInfo, 2)
-var file_schedule_proto_msgTypes = make([]protoimpl.MessageInfo, 8)
+var file_schedule_proto_enumTypes = make([]protoimpl.EnumInfo, 11)
+var file_schedule_proto_msgTypes = make([]protoimpl.MessageInfo, 159)
 var file_schedule_proto_goTypes = []any{
-	(ScheduleSourceType)(0),                     // 0: schedule.ScheduleSourceType
-	(ScheduleChangeType)(0),                     // 1: schedule.ScheduleChangeType
-	(*GetScheduleForGroupRequest)(nil),          // 2: schedule.GetScheduleForGroupRequest
-	(*GetScheduleForGroupResponse)(nil),         // 3: schedule.GetScheduleForGroupResponse
-	(*ScheduleEntry)(nil),                       // 4: schedule.ScheduleEntry
-	(*GetActiveScheduleSnapshotRequest)(nil),    // 5: schedule.GetActiveScheduleSnapshotRequest
-	(*GetActiveScheduleSnapshotResponse)(nil),   // 6: schedule.GetActiveScheduleSnapshotResponse
-	(*ScheduleSnapshot)(nil),                    // 7: schedule.ScheduleSnapshot
-	(*GetScheduleSnapshotsHistoryRequest)(nil),  // 8: schedule.GetScheduleSnapshotsHistoryRequest
-	(*GetScheduleSnapshotsHistoryResponse)(nil), // 9: schedule.GetScheduleSnapshotsHistoryResponse
-	(*timestamppb.Timestamp)(nil),               // 10: google.protobuf.Timestamp
+	(ScheduleSourceType)(0),                          // 0: schedule.ScheduleSourceType
+	(BellState)(0),                                   // 1: schedule.BellState
+	(ScheduleChangeType)(0),                          // 2: schedule.ScheduleChangeType
+	(GroupDiffStatus)(0),                             // 3: schedule.GroupDiffStatus
+	(ChangeApplyStatus)(0),                           // 4: schedule.ChangeApplyStatus
+	(ChangeModerationStatus)(0),                      // 5: schedule.ChangeModerationStatus
+	(ReviewDecision)(0),                              // 6: schedule.ReviewDecision
+	(TeacherChangeRequestKind)(0),                    // 7: schedule.TeacherChangeRequestKind
+	(JobStatus)(0),                                   // 8: schedule.JobStatus
+	(WebhookProvider)(0),                             // 9: schedule.WebhookProvider
+	(ScheduleErrorReason)(0),                         // 10: schedule.ScheduleErrorReason
+	(*GetScheduleForGroupRequest)(nil),               // 11: schedule.GetScheduleForGroupRequest
+	(*GetScheduleForGroupResponse)(nil),              // 12: schedule.GetScheduleForGroupResponse
+	(*ScheduleEntry)(nil),                            // 13: schedule.ScheduleEntry
+	(*GetActiveScheduleSnapshotRequest)(nil),         // 14: schedule.GetActiveScheduleSnapshotRequest
+	(*GetActiveScheduleSnapshotResponse)(nil),        // 15: schedule.GetActiveScheduleSnapshotResponse
+	(*ScheduleSnapshot)(nil),                         // 16: schedule.ScheduleSnapshot
+	(*GetScheduleSnapshotsHistoryRequest)(nil),       // 17: schedule.GetScheduleSnapshotsHistoryRequest
+	(*GetScheduleSnapshotsHistoryResponse)(nil),      // 18: schedule.GetScheduleSnapshotsHistoryResponse
+	(*GetSnapshotDataRequest)(nil),                   // 19: schedule.GetSnapshotDataRequest
+	(*GetSnapshotDataResponse)(nil),                  // 20: schedule.GetSnapshotDataResponse
+	(*GetMyScheduleRequest)(nil),                     // 21: schedule.GetMyScheduleRequest
+	(*GetMyScheduleResponse)(nil),                    // 22: schedule.GetMyScheduleResponse
+	(*FindFreeSlotsRequest)(nil),                     // 23: schedule.FindFreeSlotsRequest
+	(*FreeSlot)(nil),                                 // 24: schedule.FreeSlot
+	(*FindFreeSlotsResponse)(nil),                    // 25: schedule.FindFreeSlotsResponse
+	(*GetWorkloadStatsRequest)(nil),                  // 26: schedule.GetWorkloadStatsRequest
+	(*WorkloadStat)(nil),                             // 27: schedule.WorkloadStat
+	(*GetWorkloadStatsResponse)(nil),                 // 28: schedule.GetWorkloadStatsResponse
+	(*GetChangeStatsRequest)(nil),                    // 29: schedule.GetChangeStatsRequest
+	(*GroupMonthChanges)(nil),                        // 30: schedule.GroupMonthChanges
+	(*SubjectCancellations)(nil),                     // 31: schedule.SubjectCancellations
+	(*DayReplacements)(nil),                          // 32: schedule.DayReplacements
+	(*GetChangeStatsResponse)(nil),                   // 33: schedule.GetChangeStatsResponse
+	(*TeacherNameClaim)(nil),                         // 34: schedule.TeacherNameClaim
+	(*ClaimTeacherNameRequest)(nil),                  // 35: schedule.ClaimTeacherNameRequest
+	(*ClaimTeacherNameResponse)(nil),                 // 36: schedule.ClaimTeacherNameResponse
+	(*ListMyTeacherNameClaimsRequest)(nil),           // 37: schedule.ListMyTeacherNameClaimsRequest
+	(*ListMyTeacherNameClaimsResponse)(nil),          // 38: schedule.ListMyTeacherNameClaimsResponse
+	(*ListPendingTeacherNameClaimsRequest)(nil),      // 39: schedule.ListPendingTeacherNameClaimsRequest
+	(*ListPendingTeacherNameClaimsResponse)(nil),     // 40: schedule.ListPendingTeacherNameClaimsResponse
+	(*ReviewTeacherNameClaimRequest)(nil),            // 41: schedule.ReviewTeacherNameClaimRequest
+	(*ReviewTeacherNameClaimResponse)(nil),           // 42: schedule.ReviewTeacherNameClaimResponse
+	(*RunMaintenanceRequest)(nil),                    // 43: schedule.RunMaintenanceRequest
+	(*RunMaintenanceResponse)(nil),                   // 44: schedule.RunMaintenanceResponse
+	(*SearchScheduleRequest)(nil),                    // 45: schedule.SearchScheduleRequest
+	(*SearchResult)(nil),                             // 46: schedule.SearchResult
+	(*SearchScheduleResponse)(nil),                   // 47: schedule.SearchScheduleResponse
+	(*CompareSnapshotsRequest)(nil),                  // 48: schedule.CompareSnapshotsRequest
+	(*SnapshotLesson)(nil),                           // 49: schedule.SnapshotLesson
+	(*LessonChange)(nil),                             // 50: schedule.LessonChange
+	(*GroupDiff)(nil),                                // 51: schedule.GroupDiff
+	(*CompareSnapshotsResponse)(nil),                 // 52: schedule.CompareSnapshotsResponse
+	(*SubjectMetadata)(nil),                          // 53: schedule.SubjectMetadata
+	(*ListSubjectMetadataRequest)(nil),               // 54: schedule.ListSubjectMetadataRequest
+	(*ListSubjectMetadataResponse)(nil),              // 55: schedule.ListSubjectMetadataResponse
+	(*UpsertSubjectMetadataRequest)(nil),             // 56: schedule.UpsertSubjectMetadataRequest
+	(*UpsertSubjectMetadataResponse)(nil),            // 57: schedule.UpsertSubjectMetadataResponse
+	(*DeleteSubjectMetadataRequest)(nil),             // 58: schedule.DeleteSubjectMetadataRequest
+	(*DeleteSubjectMetadataResponse)(nil),            // 59: schedule.DeleteSubjectMetadataResponse
+	(*ScheduleChange)(nil),                           // 60: schedule.ScheduleChange
+	(*ListOverlappingChangesRequest)(nil),            // 61: schedule.ListOverlappingChangesRequest
+	(*ListOverlappingChangesResponse)(nil),           // 62: schedule.ListOverlappingChangesResponse
+	(*ListSnapshotChangesRequest)(nil),               // 63: schedule.ListSnapshotChangesRequest
+	(*ListSnapshotChangesResponse)(nil),              // 64: schedule.ListSnapshotChangesResponse
+	(*ListChangesAwaitingModerationRequest)(nil),     // 65: schedule.ListChangesAwaitingModerationRequest
+	(*ListChangesAwaitingModerationResponse)(nil),    // 66: schedule.ListChangesAwaitingModerationResponse
+	(*ReviewChangeRequest)(nil),                      // 67: schedule.ReviewChangeRequest
+	(*ReviewChangeResponse)(nil),                     // 68: schedule.ReviewChangeResponse
+	(*TeacherChangeRequest)(nil),                     // 69: schedule.TeacherChangeRequest
+	(*SubmitTeacherChangeRequestRequest)(nil),        // 70: schedule.SubmitTeacherChangeRequestRequest
+	(*SubmitTeacherChangeRequestResponse)(nil),       // 71: schedule.SubmitTeacherChangeRequestResponse
+	(*ListMyTeacherChangeRequestsRequest)(nil),       // 72: schedule.ListMyTeacherChangeRequestsRequest
+	(*ListMyTeacherChangeRequestsResponse)(nil),      // 73: schedule.ListMyTeacherChangeRequestsResponse
+	(*ListPendingTeacherChangeRequestsRequest)(nil),  // 74: schedule.ListPendingTeacherChangeRequestsRequest
+	(*ListPendingTeacherChangeRequestsResponse)(nil), // 75: schedule.ListPendingTeacherChangeRequestsResponse
+	(*ReviewTeacherChangeRequestRequest)(nil),        // 76: schedule.ReviewTeacherChangeRequestRequest
+	(*ReviewTeacherChangeRequestResponse)(nil),       // 77: schedule.ReviewTeacherChangeRequestResponse
+	(*GetGroupRosterRequest)(nil),                    // 78: schedule.GetGroupRosterRequest
+	(*RosterStudent)(nil),                            // 79: schedule.RosterStudent
+	(*GetGroupRosterResponse)(nil),                   // 80: schedule.GetGroupRosterResponse
+	(*Job)(nil),                                      // 81: schedule.Job
+	(*JobKindStats)(nil),                             // 82: schedule.JobKindStats
+	(*ListJobsRequest)(nil),                          // 83: schedule.ListJobsRequest
+	(*ListJobsResponse)(nil),                         // 84: schedule.ListJobsResponse
+	(*RetryJobRequest)(nil),                          // 85: schedule.RetryJobRequest
+	(*RetryJobResponse)(nil),                         // 86: schedule.RetryJobResponse
+	(*FeatureFlag)(nil),                              // 87: schedule.FeatureFlag
+	(*ListFeatureFlagsRequest)(nil),                  // 88: schedule.ListFeatureFlagsRequest
+	(*ListFeatureFlagsResponse)(nil),                 // 89: schedule.ListFeatureFlagsResponse
+	(*SetFeatureFlagRequest)(nil),                    // 90: schedule.SetFeatureFlagRequest
+	(*SetFeatureFlagResponse)(nil),                   // 91: schedule.SetFeatureFlagResponse
+	(*ResetFeatureFlagRequest)(nil),                  // 92: schedule.ResetFeatureFlagRequest
+	(*ResetFeatureFlagResponse)(nil),                 // 93: schedule.ResetFeatureFlagResponse
+	(*GetCalendarSubscriptionRequest)(nil),           // 94: schedule.GetCalendarSubscriptionRequest
+	(*GetCalendarSubscriptionResponse)(nil),          // 95: schedule.GetCalendarSubscriptionResponse
+	(*GroupWebhook)(nil),                             // 96: schedule.GroupWebhook
+	(*ListGroupWebhooksRequest)(nil),                 // 97: schedule.ListGroupWebhooksRequest
+	(*ListGroupWebhooksResponse)(nil),                // 98: schedule.ListGroupWebhooksResponse
+	(*SetGroupWebhookRequest)(nil),                   // 99: schedule.SetGroupWebhookRequest
+	(*SetGroupWebhookResponse)(nil),                  // 100: schedule.SetGroupWebhookResponse
+	(*DeleteGroupWebhookRequest)(nil),                // 101: schedule.DeleteGroupWebhookRequest
+	(*DeleteGroupWebhookResponse)(nil),               // 102: schedule.DeleteGroupWebhookResponse
+	(*GetTimetablePDFRequest)(nil),                   // 103: schedule.GetTimetablePDFRequest
+	(*GetTimetablePDFResponse)(nil),                  // 104: schedule.GetTimetablePDFResponse
+	(*ImportTeacherDirectoryRequest)(nil),            // 105: schedule.ImportTeacherDirectoryRequest
+	(*ImportTeacherDirectoryResponse)(nil),           // 106: schedule.ImportTeacherDirectoryResponse
+	(*SetLessonMeetingUrlRequest)(nil),               // 107: schedule.SetLessonMeetingUrlRequest
+	(*SetLessonMeetingUrlResponse)(nil),              // 108: schedule.SetLessonMeetingUrlResponse
+	(*ElectiveSlot)(nil),                             // 109: schedule.ElectiveSlot
+	(*ElectiveCourse)(nil),                           // 110: schedule.ElectiveCourse
+	(*CreateElectiveCourseRequest)(nil),              // 111: schedule.CreateElectiveCourseRequest
+	(*CreateElectiveCourseResponse)(nil),             // 112: schedule.CreateElectiveCourseResponse
+	(*CancelElectiveCourseRequest)(nil),              // 113: schedule.CancelElectiveCourseRequest
+	(*CancelElectiveCourseResponse)(nil),             // 114: schedule.CancelElectiveCourseResponse
+	(*ListElectiveCoursesRequest)(nil),               // 115: schedule.ListElectiveCoursesRequest
+	(*ListElectiveCoursesResponse)(nil),              // 116: schedule.ListElectiveCoursesResponse
+	(*EnrollElectiveRequest)(nil),                    // 117: schedule.EnrollElectiveRequest
+	(*EnrollElectiveResponse)(nil),                   // 118: schedule.EnrollElectiveResponse
+	(*UnenrollElectiveRequest)(nil),                  // 119: schedule.UnenrollElectiveRequest
+	(*UnenrollElectiveResponse)(nil),                 // 120: schedule.UnenrollElectiveResponse
+	(*GetTeacherWorkloadRequest)(nil),                // 121: schedule.GetTeacherWorkloadRequest
+	(*TeacherWorkload)(nil),                          // 122: schedule.TeacherWorkload
+	(*GetTeacherWorkloadResponse)(nil),               // 123: schedule.GetTeacherWorkloadResponse
+	(*LessonNote)(nil),                               // 124: schedule.LessonNote
+	(*SetLessonNoteRequest)(nil),                     // 125: schedule.SetLessonNoteRequest
+	(*SetLessonNoteResponse)(nil),                    // 126: schedule.SetLessonNoteResponse
+	(*ListLessonNotesRequest)(nil),                   // 127: schedule.ListLessonNotesRequest
+	(*ListLessonNotesResponse)(nil),                  // 128: schedule.ListLessonNotesResponse
+	(*DeleteLessonNoteRequest)(nil),                  // 129: schedule.DeleteLessonNoteRequest
+	(*DeleteLessonNoteResponse)(nil),                 // 130: schedule.DeleteLessonNoteResponse
+	(*Building)(nil),                                 // 131: schedule.Building
+	(*ListBuildingsRequest)(nil),                     // 132: schedule.ListBuildingsRequest
+	(*ListBuildingsResponse)(nil),                    // 133: schedule.ListBuildingsResponse
+	(*SetBuildingRequest)(nil),                       // 134: schedule.SetBuildingRequest
+	(*SetBuildingResponse)(nil),                      // 135: schedule.SetBuildingResponse
+	(*DeleteBuildingRequest)(nil),                    // 136: schedule.DeleteBuildingRequest
+	(*DeleteBuildingResponse)(nil),                   // 137: schedule.DeleteBuildingResponse
+	(*GroupRename)(nil),                              // 138: schedule.GroupRename
+	(*RunAcademicRolloverRequest)(nil),               // 139: schedule.RunAcademicRolloverRequest
+	(*RunAcademicRolloverResponse)(nil),              // 140: schedule.RunAcademicRolloverResponse
+	(*Consultation)(nil),                             // 141: schedule.Consultation
+	(*SetConsultationHoursRequest)(nil),              // 142: schedule.SetConsultationHoursRequest
+	(*SetConsultationHoursResponse)(nil),             // 143: schedule.SetConsultationHoursResponse
+	(*ListConsultationHoursRequest)(nil),             // 144: schedule.ListConsultationHoursRequest
+	(*ListConsultationHoursResponse)(nil),            // 145: schedule.ListConsultationHoursResponse
+	(*SetConsultationReminderRequest)(nil),           // 146: schedule.SetConsultationReminderRequest
+	(*SetConsultationReminderResponse)(nil),          // 147: schedule.SetConsultationReminderResponse
+	(*Notification)(nil),                             // 148: schedule.Notification
+	(*NotificationPayload)(nil),                      // 149: schedule.NotificationPayload
+	(*PollUpdatesRequest)(nil),                       // 150: schedule.PollUpdatesRequest
+	(*PollUpdatesResponse)(nil),                      // 151: schedule.PollUpdatesResponse
+	(*KioskDisplay)(nil),                             // 152: schedule.KioskDisplay
+	(*CreateKioskDisplayRequest)(nil),                // 153: schedule.CreateKioskDisplayRequest
+	(*CreateKioskDisplayResponse)(nil),               // 154: schedule.CreateKioskDisplayResponse
+	(*ListKioskDisplaysRequest)(nil),                 // 155: schedule.ListKioskDisplaysRequest
+	(*ListKioskDisplaysResponse)(nil),                // 156: schedule.ListKioskDisplaysResponse
+	(*RevokeKioskDisplayRequest)(nil),                // 157: schedule.RevokeKioskDisplayRequest
+	(*RevokeKioskDisplayResponse)(nil),               // 158: schedule.RevokeKioskDisplayResponse
+	(*WidgetLesson)(nil),                             // 159: schedule.WidgetLesson
+	(*GetWidgetSummaryRequest)(nil),                  // 160: schedule.GetWidgetSummaryRequest
+	(*GetWidgetSummaryResponse)(nil),                 // 161: schedule.GetWidgetSummaryResponse
+	(*GetNextLessonRequest)(nil),                     // 162: schedule.GetNextLessonRequest
+	(*GetNextLessonResponse)(nil),                    // 163: schedule.GetNextLessonResponse
+	(*GetBellStatusRequest)(nil),                     // 164: schedule.GetBellStatusRequest
+	(*GetBellStatusResponse)(nil),                    // 165: schedule.GetBellStatusResponse
+	(*GetTeacherDashboardRequest)(nil),               // 166: schedule.GetTeacherDashboardRequest
+	(*GetTeacherDashboardResponse)(nil),              // 167: schedule.GetTeacherDashboardResponse
+	(*ReportScheduleErrorRequest)(nil),               // 168: schedule.ReportScheduleErrorRequest
+	(*ReportScheduleErrorResponse)(nil),              // 169: schedule.ReportScheduleErrorResponse
+	(*timestamppb.Timestamp)(nil),                    // 170: google.protobuf.Timestamp
 }
 var file_schedule_proto_depIdxs = []int32{
-	10, // 0: schedule.GetScheduleForGroupRequest.date:type_name -> google.protobuf.Timestamp
-	4,  // 1: schedule.GetScheduleForGroupResponse.schedule:type_name -> schedule.ScheduleEntry
-	10, // 2: schedule.ScheduleEntry.date:type_name -> google.protobuf.Timestamp
-	0,  // 3: schedule.ScheduleEntry.source_type:type_name -> schedule.ScheduleSourceType
-	7,  // 4: schedule.GetActiveScheduleSnapshotResponse.snapshot:type_name -> schedule.ScheduleSnapshot
-	10, // 5: schedule.ScheduleSnapshot.period_start:type_name -> google.protobuf.Timestamp
-	10, // 6: schedule.ScheduleSnapshot.period_end:type_name -> google.protobuf.Timestamp
-	10, // 7: schedule.ScheduleSnapshot.created_at:type_name -> google.protobuf.Timestamp
-	7,  // 8: schedule.GetScheduleSnapshotsHistoryResponse.snapshots:type_name -> schedule.ScheduleSnapshot
-	2,  // 9: schedule.ScheduleService.GetScheduleForGroup:input_type -> schedule.GetScheduleForGroupRequest
-	5,  // 10: schedule.ScheduleService.GetActiveScheduleSnapshot:input_type -> schedule.GetActiveScheduleSnapshotRequest
-	8,  // 11: schedule.ScheduleService.GetScheduleSnapshotsHistory:input_type -> schedule.GetScheduleSnapshotsHistoryRequest
-	3,  // 12: schedule.ScheduleService.GetScheduleForGroup:output_type -> schedule.GetScheduleForGroupResponse
-	6,  // 13: schedule.ScheduleService.GetActiveScheduleSnapshot:output_type -> schedule.GetActiveScheduleSnapshotResponse
-	9,  // 14: schedule.ScheduleService.GetScheduleSnapshotsHistory:output_type -> schedule.GetScheduleSnapshotsHistoryResponse
-	12, // [12:15] is the sub-list for method output_type
-	9,  // [9:12] is the sub-list for method input_type
-	9,  // [9:9] is the sub-list for extension type_name
-	9,  // [9:9] is the sub-list for extension extendee
-	0,  // [0:9] is the sub-list for field type_name
+	170, // 0: schedule.GetScheduleForGroupRequest.date:type_name -> google.protobuf.Timestamp
+	170, // 1: schedule.GetScheduleForGroupRequest.as_of:type_name -> google.protobuf.Timestamp
+	13,  // 2: schedule.GetScheduleForGroupResponse.schedule:type_name -> schedule.ScheduleEntry
+	170, // 3: schedule.ScheduleEntry.date:type_name -> google.protobuf.Timestamp
+	0,   // 4: schedule.ScheduleEntry.source_type:type_name -> schedule.ScheduleSourceType
+	53,  // 5: schedule.ScheduleEntry.subject_meta:type_name -> schedule.SubjectMetadata
+	16,  // 6: schedule.GetActiveScheduleSnapshotResponse.snapshot:type_name -> schedule.ScheduleSnapshot
+	170, // 7: schedule.ScheduleSnapshot.period_start:type_name -> google.protobuf.Timestamp
+	170, // 8: schedule.ScheduleSnapshot.period_end:type_name -> google.protobuf.Timestamp
+	170, // 9: schedule.ScheduleSnapshot.created_at:type_name -> google.protobuf.Timestamp
+	170, // 10: schedule.ScheduleSnapshot.archived_at:type_name -> google.protobuf.Timestamp
+	16,  // 11: schedule.GetScheduleSnapshotsHistoryResponse.snapshots:type_name -> schedule.ScheduleSnapshot
+	170, // 12: schedule.GetMyScheduleRequest.date:type_name -> google.protobuf.Timestamp
+	13,  // 13: schedule.GetMyScheduleResponse.schedule:type_name -> schedule.ScheduleEntry
+	170, // 14: schedule.FindFreeSlotsRequest.date:type_name -> google.protobuf.Timestamp
+	24,  // 15: schedule.FindFreeSlotsResponse.slots:type_name -> schedule.FreeSlot
+	170, // 16: schedule.GetWorkloadStatsRequest.from:type_name -> google.protobuf.Timestamp
+	170, // 17: schedule.GetWorkloadStatsRequest.to:type_name -> google.protobuf.Timestamp
+	170, // 18: schedule.WorkloadStat.week_start:type_name -> google.protobuf.Timestamp
+	27,  // 19: schedule.GetWorkloadStatsResponse.stats:type_name -> schedule.WorkloadStat
+	170, // 20: schedule.GetChangeStatsRequest.from:type_name -> google.protobuf.Timestamp
+	170, // 21: schedule.GetChangeStatsRequest.to:type_name -> google.protobuf.Timestamp
+	170, // 22: schedule.GroupMonthChanges.month:type_name -> google.protobuf.Timestamp
+	170, // 23: schedule.DayReplacements.date:type_name -> google.protobuf.Timestamp
+	30,  // 24: schedule.GetChangeStatsResponse.by_group_month:type_name -> schedule.GroupMonthChanges
+	31,  // 25: schedule.GetChangeStatsResponse.cancelled_subjects:type_name -> schedule.SubjectCancellations
+	32,  // 26: schedule.GetChangeStatsResponse.replacement_days:type_name -> schedule.DayReplacements
+	5,   // 27: schedule.TeacherNameClaim.status:type_name -> schedule.ChangeModerationStatus
+	170, // 28: schedule.TeacherNameClaim.created_at:type_name -> google.protobuf.Timestamp
+	170, // 29: schedule.TeacherNameClaim.reviewed_at:type_name -> google.protobuf.Timestamp
+	34,  // 30: schedule.ClaimTeacherNameResponse.claim:type_name -> schedule.TeacherNameClaim
+	34,  // 31: schedule.ListMyTeacherNameClaimsResponse.claims:type_name -> schedule.TeacherNameClaim
+	34,  // 32: schedule.ListPendingTeacherNameClaimsResponse.claims:type_name -> schedule.TeacherNameClaim
+	6,   // 33: schedule.ReviewTeacherNameClaimRequest.decision:type_name -> schedule.ReviewDecision
+	34,  // 34: schedule.ReviewTeacherNameClaimResponse.claim:type_name -> schedule.TeacherNameClaim
+	170, // 35: schedule.SearchScheduleRequest.from:type_name -> google.protobuf.Timestamp
+	170, // 36: schedule.SearchScheduleRequest.to:type_name -> google.protobuf.Timestamp
+	13,  // 37: schedule.SearchResult.entry:type_name -> schedule.ScheduleEntry
+	46,  // 38: schedule.SearchScheduleResponse.results:type_name -> schedule.SearchResult
+	49,  // 39: schedule.LessonChange.before:type_name -> schedule.SnapshotLesson
+	49,  // 40: schedule.LessonChange.after:type_name -> schedule.SnapshotLesson
+	3,   // 41: schedule.GroupDiff.status:type_name -> schedule.GroupDiffStatus
+	49,  // 42: schedule.GroupDiff.added:type_name -> schedule.SnapshotLesson
+	49,  // 43: schedule.GroupDiff.removed:type_name -> schedule.SnapshotLesson
+	50,  // 44: schedule.GroupDiff.changed:type_name -> schedule.LessonChange
+	16,  // 45: schedule.CompareSnapshotsResponse.snapshot_a:type_name -> schedule.ScheduleSnapshot
+	16,  // 46: schedule.CompareSnapshotsResponse.snapshot_b:type_name -> schedule.ScheduleSnapshot
+	51,  // 47: schedule.CompareSnapshotsResponse.groups:type_name -> schedule.GroupDiff
+	170, // 48: schedule.SubjectMetadata.updated_at:type_name -> google.protobuf.Timestamp
+	53,  // 49: schedule.ListSubjectMetadataResponse.subjects:type_name -> schedule.SubjectMetadata
+	53,  // 50: schedule.UpsertSubjectMetadataRequest.subject:type_name -> schedule.SubjectMetadata
+	53,  // 51: schedule.UpsertSubjectMetadataResponse.subject:type_name -> schedule.SubjectMetadata
+	170, // 52: schedule.ScheduleChange.date:type_name -> google.protobuf.Timestamp
+	2,   // 53: schedule.ScheduleChange.change_type:type_name -> schedule.ScheduleChangeType
+	170, // 54: schedule.ScheduleChange.created_at:type_name -> google.protobuf.Timestamp
+	4,   // 55: schedule.ScheduleChange.apply_status:type_name -> schedule.ChangeApplyStatus
+	5,   // 56: schedule.ScheduleChange.moderation_status:type_name -> schedule.ChangeModerationStatus
+	170, // 57: schedule.ListOverlappingChangesRequest.from:type_name -> google.protobuf.Timestamp
+	170, // 58: schedule.ListOverlappingChangesRequest.to:type_name -> google.protobuf.Timestamp
+	60,  // 59: schedule.ListOverlappingChangesResponse.changes:type_name -> schedule.ScheduleChange
+	60,  // 60: schedule.ListSnapshotChangesResponse.changes:type_name -> schedule.ScheduleChange
+	60,  // 61: schedule.ListChangesAwaitingModerationResponse.changes:type_name -> schedule.ScheduleChange
+	6,   // 62: schedule.ReviewChangeRequest.decision:type_name -> schedule.ReviewDecision
+	60,  // 63: schedule.ReviewChangeRequest.edit:type_name -> schedule.ScheduleChange
+	60,  // 64: schedule.ReviewChangeResponse.change:type_name -> schedule.ScheduleChange
+	7,   // 65: schedule.TeacherChangeRequest.kind:type_name -> schedule.TeacherChangeRequestKind
+	170, // 66: schedule.TeacherChangeRequest.date:type_name -> google.protobuf.Timestamp
+	170, // 67: schedule.TeacherChangeRequest.new_date:type_name -> google.protobuf.Timestamp
+	5,   // 68: schedule.TeacherChangeRequest.status:type_name -> schedule.ChangeModerationStatus
+	170, // 69: schedule.TeacherChangeRequest.created_at:type_name -> google.protobuf.Timestamp
+	170, // 70: schedule.TeacherChangeRequest.reviewed_at:type_name -> google.protobuf.Timestamp
+	7,   // 71: schedule.SubmitTeacherChangeRequestRequest.kind:type_name -> schedule.TeacherChangeRequestKind
+	170, // 72: schedule.SubmitTeacherChangeRequestRequest.date:type_name -> google.protobuf.Timestamp
+	170, // 73: schedule.SubmitTeacherChangeRequestRequest.new_date:type_name -> google.protobuf.Timestamp
+	69,  // 74: schedule.SubmitTeacherChangeRequestResponse.request:type_name -> schedule.TeacherChangeRequest
+	69,  // 75: schedule.ListMyTeacherChangeRequestsResponse.requests:type_name -> schedule.TeacherChangeRequest
+	69,  // 76: schedule.ListPendingTeacherChangeRequestsResponse.requests:type_name -> schedule.TeacherChangeRequest
+	6,   // 77: schedule.ReviewTeacherChangeRequestRequest.decision:type_name -> schedule.ReviewDecision
+	69,  // 78: schedule.ReviewTeacherChangeRequestResponse.request:type_name -> schedule.TeacherChangeRequest
+	60,  // 79: schedule.ReviewTeacherChangeRequestResponse.changes:type_name -> schedule.ScheduleChange
+	79,  // 80: schedule.GetGroupRosterResponse.students:type_name -> schedule.RosterStudent
+	8,   // 81: schedule.Job.status:type_name -> schedule.JobStatus
+	170, // 82: schedule.Job.run_at:type_name -> google.protobuf.Timestamp
+	170, // 83: schedule.Job.created_at:type_name -> google.protobuf.Timestamp
+	170, // 84: schedule.Job.finished_at:type_name -> google.protobuf.Timestamp
+	8,   // 85: schedule.ListJobsRequest.status:type_name -> schedule.JobStatus
+	81,  // 86: schedule.ListJobsResponse.jobs:type_name -> schedule.Job
+	82,  // 87: schedule.ListJobsResponse.stats:type_name -> schedule.JobKindStats
+	170, // 88: schedule.FeatureFlag.updated_at:type_name -> google.protobuf.Timestamp
+	87,  // 89: schedule.ListFeatureFlagsResponse.flags:type_name -> schedule.FeatureFlag
+	87,  // 90: schedule.SetFeatureFlagResponse.flag:type_name -> schedule.FeatureFlag
+	9,   // 91: schedule.GroupWebhook.provider:type_name -> schedule.WebhookProvider
+	170, // 92: schedule.GroupWebhook.updated_at:type_name -> google.protobuf.Timestamp
+	170, // 93: schedule.GroupWebhook.last_delivered_at:type_name -> google.protobuf.Timestamp
+	96,  // 94: schedule.ListGroupWebhooksResponse.webhooks:type_name -> schedule.GroupWebhook
+	9,   // 95: schedule.SetGroupWebhookRequest.provider:type_name -> schedule.WebhookProvider
+	96,  // 96: schedule.SetGroupWebhookResponse.webhook:type_name -> schedule.GroupWebhook
+	170, // 97: schedule.GetTimetablePDFRequest.date:type_name -> google.protobuf.Timestamp
+	13,  // 98: schedule.SetLessonMeetingUrlResponse.entry:type_name -> schedule.ScheduleEntry
+	170, // 99: schedule.ElectiveCourse.starts_on:type_name -> google.protobuf.Timestamp
+	170, // 100: schedule.ElectiveCourse.ends_on:type_name -> google.protobuf.Timestamp
+	109, // 101: schedule.ElectiveCourse.slots:type_name -> schedule.ElectiveSlot
+	170, // 102: schedule.CreateElectiveCourseRequest.starts_on:type_name -> google.protobuf.Timestamp
+	170, // 103: schedule.CreateElectiveCourseRequest.ends_on:type_name -> google.protobuf.Timestamp
+	109, // 104: schedule.CreateElectiveCourseRequest.slots:type_name -> schedule.ElectiveSlot
+	110, // 105: schedule.CreateElectiveCourseResponse.course:type_name -> schedule.ElectiveCourse
+	110, // 106: schedule.ListElectiveCoursesResponse.courses:type_name -> schedule.ElectiveCourse
+	110, // 107: schedule.EnrollElectiveResponse.course:type_name -> schedule.ElectiveCourse
+	170, // 108: schedule.GetTeacherWorkloadRequest.from:type_name -> google.protobuf.Timestamp
+	170, // 109: schedule.GetTeacherWorkloadRequest.to:type_name -> google.protobuf.Timestamp
+	27,  // 110: schedule.TeacherWorkload.stats:type_name -> schedule.WorkloadStat
+	122, // 111: schedule.GetTeacherWorkloadResponse.teachers:type_name -> schedule.TeacherWorkload
+	170, // 112: schedule.LessonNote.date:type_name -> google.protobuf.Timestamp
+	170, // 113: schedule.LessonNote.updated_at:type_name -> google.protobuf.Timestamp
+	170, // 114: schedule.SetLessonNoteRequest.date:type_name -> google.protobuf.Timestamp
+	124, // 115: schedule.SetLessonNoteResponse.note:type_name -> schedule.LessonNote
+	170, // 116: schedule.ListLessonNotesRequest.from:type_name -> google.protobuf.Timestamp
+	170, // 117: schedule.ListLessonNotesRequest.to:type_name -> google.protobuf.Timestamp
+	124, // 118: schedule.ListLessonNotesResponse.notes:type_name -> schedule.LessonNote
+	131, // 119: schedule.ListBuildingsResponse.buildings:type_name -> schedule.Building
+	131, // 120: schedule.SetBuildingRequest.building:type_name -> schedule.Building
+	131, // 121: schedule.SetBuildingResponse.building:type_name -> schedule.Building
+	170, // 122: schedule.RunAcademicRolloverRequest.year_start:type_name -> google.protobuf.Timestamp
+	138, // 123: schedule.RunAcademicRolloverRequest.renames:type_name -> schedule.GroupRename
+	170, // 124: schedule.Consultation.next_date:type_name -> google.protobuf.Timestamp
+	141, // 125: schedule.SetConsultationHoursRequest.consultations:type_name -> schedule.Consultation
+	141, // 126: schedule.SetConsultationHoursResponse.consultations:type_name -> schedule.Consultation
+	141, // 127: schedule.ListConsultationHoursResponse.consultations:type_name -> schedule.Consultation
+	170, // 128: schedule.Notification.related_date:type_name -> google.protobuf.Timestamp
+	170, // 129: schedule.Notification.created_at:type_name -> google.protobuf.Timestamp
+	149, // 130: schedule.Notification.payload:type_name -> schedule.NotificationPayload
+	170, // 131: schedule.PollUpdatesRequest.since:type_name -> google.protobuf.Timestamp
+	148, // 132: schedule.PollUpdatesResponse.notifications:type_name -> schedule.Notification
+	170, // 133: schedule.PollUpdatesResponse.cursor:type_name -> google.protobuf.Timestamp
+	170, // 134: schedule.KioskDisplay.created_at:type_name -> google.protobuf.Timestamp
+	170, // 135: schedule.KioskDisplay.last_seen_at:type_name -> google.protobuf.Timestamp
+	152, // 136: schedule.CreateKioskDisplayResponse.display:type_name -> schedule.KioskDisplay
+	152, // 137: schedule.ListKioskDisplaysResponse.displays:type_name -> schedule.KioskDisplay
+	159, // 138: schedule.GetWidgetSummaryResponse.current_lesson:type_name -> schedule.WidgetLesson
+	159, // 139: schedule.GetWidgetSummaryResponse.next_lesson:type_name -> schedule.WidgetLesson
+	170, // 140: schedule.GetWidgetSummaryResponse.next_bell:type_name -> google.protobuf.Timestamp
+	170, // 141: schedule.GetWidgetSummaryResponse.valid_until:type_name -> google.protobuf.Timestamp
+	13,  // 142: schedule.GetNextLessonResponse.lesson:type_name -> schedule.ScheduleEntry
+	1,   // 143: schedule.GetBellStatusResponse.state:type_name -> schedule.BellState
+	170, // 144: schedule.GetBellStatusResponse.until:type_name -> google.protobuf.Timestamp
+	170, // 145: schedule.GetBellStatusResponse.server_time:type_name -> google.protobuf.Timestamp
+	13,  // 146: schedule.GetTeacherDashboardResponse.today:type_name -> schedule.ScheduleEntry
+	13,  // 147: schedule.GetTeacherDashboardResponse.substitutions:type_name -> schedule.ScheduleEntry
+	69,  // 148: schedule.GetTeacherDashboardResponse.pending_requests:type_name -> schedule.TeacherChangeRequest
+	148, // 149: schedule.GetTeacherDashboardResponse.unread_notifications:type_name -> schedule.Notification
+	170, // 150: schedule.ReportScheduleErrorRequest.date:type_name -> google.protobuf.Timestamp
+	10,  // 151: schedule.ReportScheduleErrorRequest.reason:type_name -> schedule.ScheduleErrorReason
+	11,  // 152: schedule.ScheduleService.GetScheduleForGroup:input_type -> schedule.GetScheduleForGroupRequest
+	14,  // 153: schedule.ScheduleService.GetActiveScheduleSnapshot:input_type -> schedule.GetActiveScheduleSnapshotRequest
+	17,  // 154: schedule.ScheduleService.GetScheduleSnapshotsHistory:input_type -> schedule.GetScheduleSnapshotsHistoryRequest
+	19,  // 155: schedule.ScheduleService.GetSnapshotData:input_type -> schedule.GetSnapshotDataRequest
+	21,  // 156: schedule.ScheduleService.GetMySchedule:input_type -> schedule.GetMyScheduleRequest
+	160, // 157: schedule.ScheduleService.GetWidgetSummary:input_type -> schedule.GetWidgetSummaryRequest
+	162, // 158: schedule.ScheduleService.GetNextLesson:input_type -> schedule.GetNextLessonRequest
+	164, // 159: schedule.ScheduleService.GetBellStatus:input_type -> schedule.GetBellStatusRequest
+	166, // 160: schedule.ScheduleService.GetTeacherDashboard:input_type -> schedule.GetTeacherDashboardRequest
+	168, // 161: schedule.ScheduleService.ReportScheduleError:input_type -> schedule.ReportScheduleErrorRequest
+	23,  // 162: schedule.ScheduleService.FindFreeSlots:input_type -> schedule.FindFreeSlotsRequest
+	26,  // 163: schedule.ScheduleService.GetWorkloadStats:input_type -> schedule.GetWorkloadStatsRequest
+	29,  // 164: schedule.ScheduleService.GetChangeStats:input_type -> schedule.GetChangeStatsRequest
+	43,  // 165: schedule.ScheduleService.RunMaintenance:input_type -> schedule.RunMaintenanceRequest
+	45,  // 166: schedule.ScheduleService.SearchSchedule:input_type -> schedule.SearchScheduleRequest
+	48,  // 167: schedule.ScheduleService.CompareSnapshots:input_type -> schedule.CompareSnapshotsRequest
+	54,  // 168: schedule.ScheduleService.ListSubjectMetadata:input_type -> schedule.ListSubjectMetadataRequest
+	56,  // 169: schedule.ScheduleService.UpsertSubjectMetadata:input_type -> schedule.UpsertSubjectMetadataRequest
+	58,  // 170: schedule.ScheduleService.DeleteSubjectMetadata:input_type -> schedule.DeleteSubjectMetadataRequest
+	61,  // 171: schedule.ScheduleService.ListOverlappingChanges:input_type -> schedule.ListOverlappingChangesRequest
+	63,  // 172: schedule.ScheduleService.ListSnapshotChanges:input_type -> schedule.ListSnapshotChangesRequest
+	65,  // 173: schedule.ScheduleService.ListChangesAwaitingModeration:input_type -> schedule.ListChangesAwaitingModerationRequest
+	67,  // 174: schedule.ScheduleService.ReviewChange:input_type -> schedule.ReviewChangeRequest
+	70,  // 175: schedule.ScheduleService.SubmitTeacherChangeRequest:input_type -> schedule.SubmitTeacherChangeRequestRequest
+	72,  // 176: schedule.ScheduleService.ListMyTeacherChangeRequests:input_type -> schedule.ListMyTeacherChangeRequestsRequest
+	74,  // 177: schedule.ScheduleService.ListPendingTeacherChangeRequests:input_type -> schedule.ListPendingTeacherChangeRequestsRequest
+	76,  // 178: schedule.ScheduleService.ReviewTeacherChangeRequest:input_type -> schedule.ReviewTeacherChangeRequestRequest
+	35,  // 179: schedule.ScheduleService.ClaimTeacherName:input_type -> schedule.ClaimTeacherNameRequest
+	37,  // 180: schedule.ScheduleService.ListMyTeacherNameClaims:input_type -> schedule.ListMyTeacherNameClaimsRequest
+	39,  // 181: schedule.ScheduleService.ListPendingTeacherNameClaims:input_type -> schedule.ListPendingTeacherNameClaimsRequest
+	41,  // 182: schedule.ScheduleService.ReviewTeacherNameClaim:input_type -> schedule.ReviewTeacherNameClaimRequest
+	78,  // 183: schedule.ScheduleService.GetGroupRoster:input_type -> schedule.GetGroupRosterRequest
+	83,  // 184: schedule.ScheduleService.ListJobs:input_type -> schedule.ListJobsRequest
+	85,  // 185: schedule.ScheduleService.RetryJob:input_type -> schedule.RetryJobRequest
+	88,  // 186: schedule.ScheduleService.ListFeatureFlags:input_type -> schedule.ListFeatureFlagsRequest
+	90,  // 187: schedule.ScheduleService.SetFeatureFlag:input_type -> schedule.SetFeatureFlagRequest
+	92,  // 188: schedule.ScheduleService.ResetFeatureFlag:input_type -> schedule.ResetFeatureFlagRequest
+	94,  // 189: schedule.ScheduleService.GetCalendarSubscription:input_type -> schedule.GetCalendarSubscriptionRequest
+	97,  // 190: schedule.ScheduleService.ListGroupWebhooks:input_type -> schedule.ListGroupWebhooksRequest
+	99,  // 191: schedule.ScheduleService.SetGroupWebhook:input_type -> schedule.SetGroupWebhookRequest
+	101, // 192: schedule.ScheduleService.DeleteGroupWebhook:input_type -> schedule.DeleteGroupWebhookRequest
+	103, // 193: schedule.ScheduleService.GetTimetablePDF:input_type -> schedule.GetTimetablePDFRequest
+	105, // 194: schedule.ScheduleService.ImportTeacherDirectory:input_type -> schedule.ImportTeacherDirectoryRequest
+	107, // 195: schedule.ScheduleService.SetLessonMeetingUrl:input_type -> schedule.SetLessonMeetingUrlRequest
+	111, // 196: schedule.ScheduleService.CreateElectiveCourse:input_type -> schedule.CreateElectiveCourseRequest
+	113, // 197: schedule.ScheduleService.CancelElectiveCourse:input_type -> schedule.CancelElectiveCourseRequest
+	115, // 198: schedule.ScheduleService.ListElectiveCourses:input_type -> schedule.ListElectiveCoursesRequest
+	117, // 199: schedule.ScheduleService.EnrollElective:input_type -> schedule.EnrollElectiveRequest
+	119, // 200: schedule.ScheduleService.UnenrollElective:input_type -> schedule.UnenrollElectiveRequest
+	121, // 201: schedule.ScheduleService.GetTeacherWorkload:input_type -> schedule.GetTeacherWorkloadRequest
+	125, // 202: schedule.ScheduleService.SetLessonNote:input_type -> schedule.SetLessonNoteRequest
+	127, // 203: schedule.ScheduleService.ListLessonNotes:input_type -> schedule.ListLessonNotesRequest
+	129, // 204: schedule.ScheduleService.DeleteLessonNote:input_type -> schedule.DeleteLessonNoteRequest
+	132, // 205: schedule.ScheduleService.ListBuildings:input_type -> schedule.ListBuildingsRequest
+	134, // 206: schedule.ScheduleService.SetBuilding:input_type -> schedule.SetBuildingRequest
+	136, // 207: schedule.ScheduleService.DeleteBuilding:input_type -> schedule.DeleteBuildingRequest
+	139, // 208: schedule.ScheduleService.RunAcademicRollover:input_type -> schedule.RunAcademicRolloverRequest
+	142, // 209: schedule.ScheduleService.SetConsultationHours:input_type -> schedule.SetConsultationHoursRequest
+	144, // 210: schedule.ScheduleService.ListConsultationHours:input_type -> schedule.ListConsultationHoursRequest
+	146, // 211: schedule.ScheduleService.SetConsultationReminder:input_type -> schedule.SetConsultationReminderRequest
+	150, // 212: schedule.ScheduleService.PollUpdates:input_type -> schedule.PollUpdatesRequest
+	153, // 213: schedule.ScheduleService.CreateKioskDisplay:input_type -> schedule.CreateKioskDisplayRequest
+	155, // 214: schedule.ScheduleService.ListKioskDisplays:input_type -> schedule.ListKioskDisplaysRequest
+	157, // 215: schedule.ScheduleService.RevokeKioskDisplay:input_type -> schedule.RevokeKioskDisplayRequest
+	12,  // 216: schedule.ScheduleService.GetScheduleForGroup:output_type -> schedule.GetScheduleForGroupResponse
+	15,  // 217: schedule.ScheduleService.GetActiveScheduleSnapshot:output_type -> schedule.GetActiveScheduleSnapshotResponse
+	18,  // 218: schedule.ScheduleService.GetScheduleSnapshotsHistory:output_type -> schedule.GetScheduleSnapshotsHistoryResponse
+	20,  // 219: schedule.ScheduleService.GetSnapshotData:output_type -> schedule.GetSnapshotDataResponse
+	22,  // 220: schedule.ScheduleService.GetMySchedule:output_type -> schedule.GetMyScheduleResponse
+	161, // 221: schedule.ScheduleService.GetWidgetSummary:output_type -> schedule.GetWidgetSummaryResponse
+	163, // 222: schedule.ScheduleService.GetNextLesson:output_type -> schedule.GetNextLessonResponse
+	165, // 223: schedule.ScheduleService.GetBellStatus:output_type -> schedule.GetBellStatusResponse
+	167, // 224: schedule.ScheduleService.GetTeacherDashboard:output_type -> schedule.GetTeacherDashboardResponse
+	169, // 225: schedule.ScheduleService.ReportScheduleError:output_type -> schedule.ReportScheduleErrorResponse
+	25,  // 226: schedule.ScheduleService.FindFreeSlots:output_type -> schedule.FindFreeSlotsResponse
+	28,  // 227: schedule.ScheduleService.GetWorkloadStats:output_type -> schedule.GetWorkloadStatsResponse
+	33,  // 228: schedule.ScheduleService.GetChangeStats:output_type -> schedule.GetChangeStatsResponse
+	44,  // 229: schedule.ScheduleService.RunMaintenance:output_type -> schedule.RunMaintenanceResponse
+	47,  // 230: schedule.ScheduleService.SearchSchedule:output_type -> schedule.SearchScheduleResponse
+	52,  // 231: schedule.ScheduleService.CompareSnapshots:output_type -> schedule.CompareSnapshotsResponse
+	55,  // 232: schedule.ScheduleService.ListSubjectMetadata:output_type -> schedule.ListSubjectMetadataResponse
+	57,  // 233: schedule.ScheduleService.UpsertSubjectMetadata:output_type -> schedule.UpsertSubjectMetadataResponse
+	59,  // 234: schedule.ScheduleService.DeleteSubjectMetadata:output_type -> schedule.DeleteSubjectMetadataResponse
+	62,  // 235: schedule.ScheduleService.ListOverlappingChanges:output_type -> schedule.ListOverlappingChangesResponse
+	64,  // 236: schedule.ScheduleService.ListSnapshotChanges:output_type -> schedule.ListSnapshotChangesResponse
+	66,  // 237: schedule.ScheduleService.ListChangesAwaitingModeration:output_type -> schedule.ListChangesAwaitingModerationResponse
+	68,  // 238: schedule.ScheduleService.ReviewChange:output_type -> schedule.ReviewChangeResponse
+	71,  // 239: schedule.ScheduleService.SubmitTeacherChangeRequest:output_type -> schedule.SubmitTeacherChangeRequestResponse
+	73,  // 240: schedule.ScheduleService.ListMyTeacherChangeRequests:output_type -> schedule.ListMyTeacherChangeRequestsResponse
+	75,  // 241: schedule.ScheduleService.ListPendingTeacherChangeRequests:output_type -> schedule.ListPendingTeacherChangeRequestsResponse
+	77,  // 242: schedule.ScheduleService.ReviewTeacherChangeRequest:output_type -> schedule.ReviewTeacherChangeRequestResponse
+	36,  // 243: schedule.ScheduleService.ClaimTeacherName:output_type -> schedule.ClaimTeacherNameResponse
+	38,  // 244: schedule.ScheduleService.ListMyTeacherNameClaims:output_type -> schedule.ListMyTeacherNameClaimsResponse
+	40,  // 245: schedule.ScheduleService.ListPendingTeacherNameClaims:output_type -> schedule.ListPendingTeacherNameClaimsResponse
+	42,  // 246: schedule.ScheduleService.ReviewTeacherNameClaim:output_type -> schedule.ReviewTeacherNameClaimResponse
+	80,  // 247: schedule.ScheduleService.GetGroupRoster:output_type -> schedule.GetGroupRosterResponse
+	84,  // 248: schedule.ScheduleService.ListJobs:output_type -> schedule.ListJobsResponse
+	86,  // 249: schedule.ScheduleService.RetryJob:output_type -> schedule.RetryJobResponse
+	89,  // 250: schedule.ScheduleService.ListFeatureFlags:output_type -> schedule.ListFeatureFlagsResponse
+	91,  // 251: schedule.ScheduleService.SetFeatureFlag:output_type -> schedule.SetFeatureFlagResponse
+	93,  // 252: schedule.ScheduleService.ResetFeatureFlag:output_type -> schedule.ResetFeatureFlagResponse
+	95,  // 253: schedule.ScheduleService.GetCalendarSubscription:output_type -> schedule.GetCalendarSubscriptionResponse
+	98,  // 254: schedule.ScheduleService.ListGroupWebhooks:output_type -> schedule.ListGroupWebhooksResponse
+	100, // 255: schedule.ScheduleService.SetGroupWebhook:output_type -> schedule.SetGroupWebhookResponse
+	102, // 256: schedule.ScheduleService.DeleteGroupWebhook:output_type -> schedule.DeleteGroupWebhookResponse
+	104, // 257: schedule.ScheduleService.GetTimetablePDF:output_type -> schedule.GetTimetablePDFResponse
+	106, // 258: schedule.ScheduleService.ImportTeacherDirectory:output_type -> schedule.ImportTeacherDirectoryResponse
+	108, // 259: schedule.ScheduleService.SetLessonMeetingUrl:output_type -> schedule.SetLessonMeetingUrlResponse
+	112, // 260: schedule.ScheduleService.CreateElectiveCourse:output_type -> schedule.CreateElectiveCourseResponse
+	114, // 261: schedule.ScheduleService.CancelElectiveCourse:output_type -> schedule.CancelElectiveCourseResponse
+	116, // 262: schedule.ScheduleService.ListElectiveCourses:output_type -> schedule.ListElectiveCoursesResponse
+	118, // 263: schedule.ScheduleService.EnrollElective:output_type -> schedule.EnrollElectiveResponse
+	120, // 264: schedule.ScheduleService.UnenrollElective:output_type -> schedule.UnenrollElectiveResponse
+	123, // 265: schedule.ScheduleService.GetTeacherWorkload:output_type -> schedule.GetTeacherWorkloadResponse
+	126, // 266: schedule.ScheduleService.SetLessonNote:output_type -> schedule.SetLessonNoteResponse
+	128, // 267: schedule.ScheduleService.ListLessonNotes:output_type -> schedule.ListLessonNotesResponse
+	130, // 268: schedule.ScheduleService.DeleteLessonNote:output_type -> schedule.DeleteLessonNoteResponse
+	133, // 269: schedule.ScheduleService.ListBuildings:output_type -> schedule.ListBuildingsResponse
+	135, // 270: schedule.ScheduleService.SetBuilding:output_type -> schedule.SetBuildingResponse
+	137, // 271: schedule.ScheduleService.DeleteBuilding:output_type -> schedule.DeleteBuildingResponse
+	140, // 272: schedule.ScheduleService.RunAcademicRollover:output_type -> schedule.RunAcademicRolloverResponse
+	143, // 273: schedule.ScheduleService.SetConsultationHours:output_type -> schedule.SetConsultationHoursResponse
+	145, // 274: schedule.ScheduleService.ListConsultationHours:output_type -> schedule.ListConsultationHoursResponse
+	147, // 275: schedule.ScheduleService.SetConsultationReminder:output_type -> schedule.SetConsultationReminderResponse
+	151, // 276: schedule.ScheduleService.PollUpdates:output_type -> schedule.PollUpdatesResponse
+	154, // 277: schedule.ScheduleService.CreateKioskDisplay:output_type -> schedule.CreateKioskDisplayResponse
+	156, // 278: schedule.ScheduleService.ListKioskDisplays:output_type -> schedule.ListKioskDisplaysResponse
+	158, // 279: schedule.ScheduleService.RevokeKioskDisplay:output_type -> schedule.RevokeKioskDisplayResponse
+	216, // [216:280] is the sub-list for method output_type
+	152, // [152:216] is the sub-list for method input_type
+	152, // [152:152] is the sub-list for extension type_name
+	152, // [152:152] is the sub-list for extension extendee
+	0,   // [0:152] is the sub-list for field type_name
 }
 
 func init() { file_schedule_proto_init() }
@@ -807,8 +13161,8 @@ func file_schedule_proto_init() {
 		File: protoimpl.DescBuilder{
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_schedule_proto_rawDesc), len(file_schedule_proto_rawDesc)),
-			NumEnums:      2,
-			NumMessages:   8,
+			NumEnums:      11,
+			NumMessages:   159,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
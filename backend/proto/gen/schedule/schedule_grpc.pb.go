@@ -21,9 +21,70 @@ import (
 const _ = grpc.SupportPackageIsVersion9
 
 const (
-	ScheduleService_GetScheduleForGroup_FullMethodName         = "/schedule.ScheduleService/GetScheduleForGroup"
-	ScheduleService_GetActiveScheduleSnapshot_FullMethodName   = "/schedule.ScheduleService/GetActiveScheduleSnapshot"
-	ScheduleService_GetScheduleSnapshotsHistory_FullMethodName = "/schedule.ScheduleService/GetScheduleSnapshotsHistory"
+	ScheduleService_GetScheduleForGroup_FullMethodName              = "/schedule.ScheduleService/GetScheduleForGroup"
+	ScheduleService_GetActiveScheduleSnapshot_FullMethodName        = "/schedule.ScheduleService/GetActiveScheduleSnapshot"
+	ScheduleService_GetScheduleSnapshotsHistory_FullMethodName      = "/schedule.ScheduleService/GetScheduleSnapshotsHistory"
+	ScheduleService_GetSnapshotData_FullMethodName                  = "/schedule.ScheduleService/GetSnapshotData"
+	ScheduleService_GetMySchedule_FullMethodName                    = "/schedule.ScheduleService/GetMySchedule"
+	ScheduleService_GetWidgetSummary_FullMethodName                 = "/schedule.ScheduleService/GetWidgetSummary"
+	ScheduleService_GetNextLesson_FullMethodName                    = "/schedule.ScheduleService/GetNextLesson"
+	ScheduleService_GetBellStatus_FullMethodName                    = "/schedule.ScheduleService/GetBellStatus"
+	ScheduleService_GetTeacherDashboard_FullMethodName              = "/schedule.ScheduleService/GetTeacherDashboard"
+	ScheduleService_ReportScheduleError_FullMethodName              = "/schedule.ScheduleService/ReportScheduleError"
+	ScheduleService_FindFreeSlots_FullMethodName                    = "/schedule.ScheduleService/FindFreeSlots"
+	ScheduleService_GetWorkloadStats_FullMethodName                 = "/schedule.ScheduleService/GetWorkloadStats"
+	ScheduleService_GetChangeStats_FullMethodName                   = "/schedule.ScheduleService/GetChangeStats"
+	ScheduleService_RunMaintenance_FullMethodName                   = "/schedule.ScheduleService/RunMaintenance"
+	ScheduleService_SearchSchedule_FullMethodName                   = "/schedule.ScheduleService/SearchSchedule"
+	ScheduleService_CompareSnapshots_FullMethodName                 = "/schedule.ScheduleService/CompareSnapshots"
+	ScheduleService_ListSubjectMetadata_FullMethodName              = "/schedule.ScheduleService/ListSubjectMetadata"
+	ScheduleService_UpsertSubjectMetadata_FullMethodName            = "/schedule.ScheduleService/UpsertSubjectMetadata"
+	ScheduleService_DeleteSubjectMetadata_FullMethodName            = "/schedule.ScheduleService/DeleteSubjectMetadata"
+	ScheduleService_ListOverlappingChanges_FullMethodName           = "/schedule.ScheduleService/ListOverlappingChanges"
+	ScheduleService_ListSnapshotChanges_FullMethodName              = "/schedule.ScheduleService/ListSnapshotChanges"
+	ScheduleService_ListChangesAwaitingModeration_FullMethodName    = "/schedule.ScheduleService/ListChangesAwaitingModeration"
+	ScheduleService_ReviewChange_FullMethodName                     = "/schedule.ScheduleService/ReviewChange"
+	ScheduleService_SubmitTeacherChangeRequest_FullMethodName       = "/schedule.ScheduleService/SubmitTeacherChangeRequest"
+	ScheduleService_ListMyTeacherChangeRequests_FullMethodName      = "/schedule.ScheduleService/ListMyTeacherChangeRequests"
+	ScheduleService_ListPendingTeacherChangeRequests_FullMethodName = "/schedule.ScheduleService/ListPendingTeacherChangeRequests"
+	ScheduleService_ReviewTeacherChangeRequest_FullMethodName       = "/schedule.ScheduleService/ReviewTeacherChangeRequest"
+	ScheduleService_ClaimTeacherName_FullMethodName                 = "/schedule.ScheduleService/ClaimTeacherName"
+	ScheduleService_ListMyTeacherNameClaims_FullMethodName          = "/schedule.ScheduleService/ListMyTeacherNameClaims"
+	ScheduleService_ListPendingTeacherNameClaims_FullMethodName     = "/schedule.ScheduleService/ListPendingTeacherNameClaims"
+	ScheduleService_ReviewTeacherNameClaim_FullMethodName           = "/schedule.ScheduleService/ReviewTeacherNameClaim"
+	ScheduleService_GetGroupRoster_FullMethodName                   = "/schedule.ScheduleService/GetGroupRoster"
+	ScheduleService_ListJobs_FullMethodName                         = "/schedule.ScheduleService/ListJobs"
+	ScheduleService_RetryJob_FullMethodName                         = "/schedule.ScheduleService/RetryJob"
+	ScheduleService_ListFeatureFlags_FullMethodName                 = "/schedule.ScheduleService/ListFeatureFlags"
+	ScheduleService_SetFeatureFlag_FullMethodName                   = "/schedule.ScheduleService/SetFeatureFlag"
+	ScheduleService_ResetFeatureFlag_FullMethodName                 = "/schedule.ScheduleService/ResetFeatureFlag"
+	ScheduleService_GetCalendarSubscription_FullMethodName          = "/schedule.ScheduleService/GetCalendarSubscription"
+	ScheduleService_ListGroupWebhooks_FullMethodName                = "/schedule.ScheduleService/ListGroupWebhooks"
+	ScheduleService_SetGroupWebhook_FullMethodName                  = "/schedule.ScheduleService/SetGroupWebhook"
+	ScheduleService_DeleteGroupWebhook_FullMethodName               = "/schedule.ScheduleService/DeleteGroupWebhook"
+	ScheduleService_GetTimetablePDF_FullMethodName                  = "/schedule.ScheduleService/GetTimetablePDF"
+	ScheduleService_ImportTeacherDirectory_FullMethodName           = "/schedule.ScheduleService/ImportTeacherDirectory"
+	ScheduleService_SetLessonMeetingUrl_FullMethodName              = "/schedule.ScheduleService/SetLessonMeetingUrl"
+	ScheduleService_CreateElectiveCourse_FullMethodName             = "/schedule.ScheduleService/CreateElectiveCourse"
+	ScheduleService_CancelElectiveCourse_FullMethodName             = "/schedule.ScheduleService/CancelElectiveCourse"
+	ScheduleService_ListElectiveCourses_FullMethodName              = "/schedule.ScheduleService/ListElectiveCourses"
+	ScheduleService_EnrollElective_FullMethodName                   = "/schedule.ScheduleService/EnrollElective"
+	ScheduleService_UnenrollElective_FullMethodName                 = "/schedule.ScheduleService/UnenrollElective"
+	ScheduleService_GetTeacherWorkload_FullMethodName               = "/schedule.ScheduleService/GetTeacherWorkload"
+	ScheduleService_SetLessonNote_FullMethodName                    = "/schedule.ScheduleService/SetLessonNote"
+	ScheduleService_ListLessonNotes_FullMethodName                  = "/schedule.ScheduleService/ListLessonNotes"
+	ScheduleService_DeleteLessonNote_FullMethodName                 = "/schedule.ScheduleService/DeleteLessonNote"
+	ScheduleService_ListBuildings_FullMethodName                    = "/schedule.ScheduleService/ListBuildings"
+	ScheduleService_SetBuilding_FullMethodName                      = "/schedule.ScheduleService/SetBuilding"
+	ScheduleService_DeleteBuilding_FullMethodName                   = "/schedule.ScheduleService/DeleteBuilding"
+	ScheduleService_RunAcademicRollover_FullMethodName              = "/schedule.ScheduleService/RunAcademicRollover"
+	ScheduleService_SetConsultationHours_FullMethodName             = "/schedule.ScheduleService/SetConsultationHours"
+	ScheduleService_ListConsultationHours_FullMethodName            = "/schedule.ScheduleService/ListConsultationHours"
+	ScheduleService_SetConsultationReminder_FullMethodName          = "/schedule.ScheduleService/SetConsultationReminder"
+	ScheduleService_PollUpdates_FullMethodName                      = "/schedule.ScheduleService/PollUpdates"
+	ScheduleService_CreateKioskDisplay_FullMethodName               = "/schedule.ScheduleService/CreateKioskDisplay"
+	ScheduleService_ListKioskDisplays_FullMethodName                = "/schedule.ScheduleService/ListKioskDisplays"
+	ScheduleService_RevokeKioskDisplay_FullMethodName               = "/schedule.ScheduleService/RevokeKioskDisplay"
 )
 
 // ScheduleServiceClient is the client API for ScheduleService service.
@@ -34,10 +95,162 @@ const (
 type ScheduleServiceClient interface {
 	// Получить расписание для группы на определенную дату
 	GetScheduleForGroup(ctx context.Context, in *GetScheduleForGroupRequest, opts ...grpc.CallOption) (*GetScheduleForGroupResponse, error)
-	// Получить активный снапшот расписания
+	// Получить метаданные активного снапшота расписания
 	GetActiveScheduleSnapshot(ctx context.Context, in *GetActiveScheduleSnapshotRequest, opts ...grpc.CallOption) (*GetActiveScheduleSnapshotResponse, error)
 	// Получить историю снапшотов
 	GetScheduleSnapshotsHistory(ctx context.Context, in *GetScheduleSnapshotsHistoryRequest, opts ...grpc.CallOption) (*GetScheduleSnapshotsHistoryResponse, error)
+	// Получить JSON данные снапшота (метаданные и история их не содержат)
+	GetSnapshotData(ctx context.Context, in *GetSnapshotDataRequest, opts ...grpc.CallOption) (*GetSnapshotDataResponse, error)
+	// Получить расписание текущего пользователя: группа студента
+	// или занятия преподавателя определяются по профилю
+	GetMySchedule(ctx context.Context, in *GetMyScheduleRequest, opts ...grpc.CallOption) (*GetMyScheduleResponse, error)
+	// Получить краткую сводку дня для виджета на главном экране: идущая и следующая
+	// пара, время до звонка и сколько пар осталось. Ответ можно не запрашивать
+	// повторно до valid_until; через REST-фасад приходит с заголовком Cache-Control
+	GetWidgetSummary(ctx context.Context, in *GetWidgetSummaryRequest, opts ...grpc.CallOption) (*GetWidgetSummaryResponse, error)
+	// Получить ближайшее занятие пользователя: пару с учетом изменений и подгруппы,
+	// факультатив или консультацию, о которой пользователь получает напоминания.
+	// Гостю - ближайшую пару группы токена
+	GetNextLesson(ctx context.Context, in *GetNextLessonRequest, opts ...grpc.CallOption) (*GetNextLessonResponse, error)
+	// Получить состояние учебного дня по расписанию звонков колледжа (идет пара,
+	// перемена, пары закончились) и время до его смены - для обратного отсчета
+	// в заголовке приложения
+	GetBellStatus(ctx context.Context, in *GetBellStatusRequest, opts ...grpc.CallOption) (*GetBellStatusResponse, error)
+	// Получить данные главного экрана преподавателя одним запросом: занятия на сегодня,
+	// замены на ближайшую неделю, заявки на рассмотрении и непрочитанные уведомления
+	// (только для преподавателей)
+	GetTeacherDashboard(ctx context.Context, in *GetTeacherDashboardRequest, opts ...grpc.CallOption) (*GetTeacherDashboardResponse, error)
+	// Сообщить об ошибке в расписании конкретной пары (неверный предмет, преподаватель,
+	// аудитория, время или пара не проводится). Жалобы группируются по паре и видны
+	// администратору в панели; набрав порог, жалобы запускают повторный парсинг таблицы
+	ReportScheduleError(ctx context.Context, in *ReportScheduleErrorRequest, opts ...grpc.CallOption) (*ReportScheduleErrorResponse, error)
+	// Найти общие свободные окна для групп и/или преподавателя на дату
+	FindFreeSlots(ctx context.Context, in *FindFreeSlotsRequest, opts ...grpc.CallOption) (*FindFreeSlotsResponse, error)
+	// Получить статистику нагрузки (часы по предметам) группы или преподавателя
+	GetWorkloadStats(ctx context.Context, in *GetWorkloadStatsRequest, opts ...grpc.CallOption) (*GetWorkloadStatsResponse, error)
+	// Статистика изменений расписания для аналитики (только для администраторов)
+	GetChangeStats(ctx context.Context, in *GetChangeStatsRequest, opts ...grpc.CallOption) (*GetChangeStatsResponse, error)
+	// Запустить задачи обслуживания (архивация снапшотов, кэш расписания) вне расписания
+	// (только для администраторов)
+	RunMaintenance(ctx context.Context, in *RunMaintenanceRequest, opts ...grpc.CallOption) (*RunMaintenanceResponse, error)
+	// Нечеткий поиск по предметам, преподавателям и аудиториям
+	SearchSchedule(ctx context.Context, in *SearchScheduleRequest, opts ...grpc.CallOption) (*SearchScheduleResponse, error)
+	// Сравнить два снапшота расписания (только для администраторов)
+	CompareSnapshots(ctx context.Context, in *CompareSnapshotsRequest, opts ...grpc.CallOption) (*CompareSnapshotsResponse, error)
+	// Получить параметры отображения предметов (цвета, короткие названия, иконки)
+	ListSubjectMetadata(ctx context.Context, in *ListSubjectMetadataRequest, opts ...grpc.CallOption) (*ListSubjectMetadataResponse, error)
+	// Создать или изменить параметры отображения предмета (только для администраторов)
+	UpsertSubjectMetadata(ctx context.Context, in *UpsertSubjectMetadataRequest, opts ...grpc.CallOption) (*UpsertSubjectMetadataResponse, error)
+	// Удалить параметры отображения предмета (только для администраторов)
+	DeleteSubjectMetadata(ctx context.Context, in *DeleteSubjectMetadataRequest, opts ...grpc.CallOption) (*DeleteSubjectMetadataResponse, error)
+	// Получить изменения, пересекающиеся с другими занятиями (только для администраторов)
+	ListOverlappingChanges(ctx context.Context, in *ListOverlappingChangesRequest, opts ...grpc.CallOption) (*ListOverlappingChangesResponse, error)
+	// Получить все изменения относительно снапшота
+	ListSnapshotChanges(ctx context.Context, in *ListSnapshotChangesRequest, opts ...grpc.CallOption) (*ListSnapshotChangesResponse, error)
+	// Получить изменения, ожидающие модерации (только для администраторов)
+	ListChangesAwaitingModeration(ctx context.Context, in *ListChangesAwaitingModerationRequest, opts ...grpc.CallOption) (*ListChangesAwaitingModerationResponse, error)
+	// Одобрить (с исправлениями) или отклонить изменение (только для администраторов)
+	ReviewChange(ctx context.Context, in *ReviewChangeRequest, opts ...grpc.CallOption) (*ReviewChangeResponse, error)
+	// Подать заявку на отмену или перенос своей пары (только для преподавателей)
+	SubmitTeacherChangeRequest(ctx context.Context, in *SubmitTeacherChangeRequestRequest, opts ...grpc.CallOption) (*SubmitTeacherChangeRequestResponse, error)
+	// Получить свои заявки (только для преподавателей)
+	ListMyTeacherChangeRequests(ctx context.Context, in *ListMyTeacherChangeRequestsRequest, opts ...grpc.CallOption) (*ListMyTeacherChangeRequestsResponse, error)
+	// Получить заявки преподавателей на рассмотрении (только для администраторов)
+	ListPendingTeacherChangeRequests(ctx context.Context, in *ListPendingTeacherChangeRequestsRequest, opts ...grpc.CallOption) (*ListPendingTeacherChangeRequestsResponse, error)
+	// Одобрить или отклонить заявку преподавателя (только для администраторов)
+	ReviewTeacherChangeRequest(ctx context.Context, in *ReviewTeacherChangeRequestRequest, opts ...grpc.CallOption) (*ReviewTeacherChangeRequestResponse, error)
+	// Заявить вариант своего имени в расписании ("Иванов И.И."). Однозначное совпадение
+	// с ФИО профиля подтверждается сразу, остальные - администратором
+	ClaimTeacherName(ctx context.Context, in *ClaimTeacherNameRequest, opts ...grpc.CallOption) (*ClaimTeacherNameResponse, error)
+	// Получить свои варианты имени (для преподавателя)
+	ListMyTeacherNameClaims(ctx context.Context, in *ListMyTeacherNameClaimsRequest, opts ...grpc.CallOption) (*ListMyTeacherNameClaimsResponse, error)
+	// Получить варианты имени, ожидающие подтверждения (только для администраторов)
+	ListPendingTeacherNameClaims(ctx context.Context, in *ListPendingTeacherNameClaimsRequest, opts ...grpc.CallOption) (*ListPendingTeacherNameClaimsResponse, error)
+	// Подтвердить или отклонить вариант имени (только для администраторов)
+	ReviewTeacherNameClaim(ctx context.Context, in *ReviewTeacherNameClaimRequest, opts ...grpc.CallOption) (*ReviewTeacherNameClaimResponse, error)
+	// Получить список зарегистрированных студентов группы
+	// (администраторам и преподавателям, у которых есть занятия с группой)
+	GetGroupRoster(ctx context.Context, in *GetGroupRosterRequest, opts ...grpc.CallOption) (*GetGroupRosterResponse, error)
+	// Получить фоновые задачи и статистику очереди (только для администраторов)
+	ListJobs(ctx context.Context, in *ListJobsRequest, opts ...grpc.CallOption) (*ListJobsResponse, error)
+	// Вернуть в очередь задачу, исчерпавшую попытки (только для администраторов)
+	RetryJob(ctx context.Context, in *RetryJobRequest, opts ...grpc.CallOption) (*RetryJobResponse, error)
+	// Получить флаги функций (только для администраторов)
+	ListFeatureFlags(ctx context.Context, in *ListFeatureFlagsRequest, opts ...grpc.CallOption) (*ListFeatureFlagsResponse, error)
+	// Включить, выключить или изменить долю пользователей флага (только для администраторов)
+	SetFeatureFlag(ctx context.Context, in *SetFeatureFlagRequest, opts ...grpc.CallOption) (*SetFeatureFlagResponse, error)
+	// Вернуть флагу значение по умолчанию для окружения (только для администраторов)
+	ResetFeatureFlag(ctx context.Context, in *ResetFeatureFlagRequest, opts ...grpc.CallOption) (*ResetFeatureFlagResponse, error)
+	// Получить ссылки для подписки на личное расписание в календаре
+	// (webcal:// и Google Календарь)
+	GetCalendarSubscription(ctx context.Context, in *GetCalendarSubscriptionRequest, opts ...grpc.CallOption) (*GetCalendarSubscriptionResponse, error)
+	// Получить вебхуки групповых чатов (только для администраторов)
+	ListGroupWebhooks(ctx context.Context, in *ListGroupWebhooksRequest, opts ...grpc.CallOption) (*ListGroupWebhooksResponse, error)
+	// Настроить вебхук Discord или Slack для сводок изменений группы
+	// (только для администраторов)
+	SetGroupWebhook(ctx context.Context, in *SetGroupWebhookRequest, opts ...grpc.CallOption) (*SetGroupWebhookResponse, error)
+	// Удалить вебхук группы (только для администраторов)
+	DeleteGroupWebhook(ctx context.Context, in *DeleteGroupWebhookRequest, opts ...grpc.CallOption) (*DeleteGroupWebhookResponse, error)
+	// Получить расписание группы на неделю в PDF для печати
+	GetTimetablePDF(ctx context.Context, in *GetTimetablePDFRequest, opts ...grpc.CallOption) (*GetTimetablePDFResponse, error)
+	// Загрузить официальный список преподавателей (ФИО, кафедра, должность) из CSV
+	// в справочник колледжа (только для администраторов)
+	ImportTeacherDirectory(ctx context.Context, in *ImportTeacherDirectoryRequest, opts ...grpc.CallOption) (*ImportTeacherDirectoryResponse, error)
+	// Задать ссылку на онлайн-занятие для пары (преподаватель - для своих пар,
+	// администратор - для любых); студенты группы получают уведомление
+	SetLessonMeetingUrl(ctx context.Context, in *SetLessonMeetingUrlRequest, opts ...grpc.CallOption) (*SetLessonMeetingUrlResponse, error)
+	// Создать факультатив с еженедельными занятиями (только для администраторов)
+	CreateElectiveCourse(ctx context.Context, in *CreateElectiveCourseRequest, opts ...grpc.CallOption) (*CreateElectiveCourseResponse, error)
+	// Отменить факультатив; записанные студенты получают уведомление
+	// (только для администраторов)
+	CancelElectiveCourse(ctx context.Context, in *CancelElectiveCourseRequest, opts ...grpc.CallOption) (*CancelElectiveCourseResponse, error)
+	// Получить факультативы, которые еще не закончились, с отметкой записи
+	ListElectiveCourses(ctx context.Context, in *ListElectiveCoursesRequest, opts ...grpc.CallOption) (*ListElectiveCoursesResponse, error)
+	// Записаться на факультатив: его занятия появятся в личном расписании (только студенты)
+	EnrollElective(ctx context.Context, in *EnrollElectiveRequest, opts ...grpc.CallOption) (*EnrollElectiveResponse, error)
+	// Отменить запись на факультатив
+	UnenrollElective(ctx context.Context, in *UnenrollElectiveRequest, opts ...grpc.CallOption) (*UnenrollElectiveResponse, error)
+	// Нагрузка всех преподавателей за неделю или семестр: часы по группам и предметам,
+	// по запросу - отчет в PDF (только для администраторов)
+	GetTeacherWorkload(ctx context.Context, in *GetTeacherWorkloadRequest, opts ...grpc.CallOption) (*GetTeacherWorkloadResponse, error)
+	// Создать или изменить личную заметку к паре; видна только автору
+	// и возвращается в поле note записей расписания
+	SetLessonNote(ctx context.Context, in *SetLessonNoteRequest, opts ...grpc.CallOption) (*SetLessonNoteResponse, error)
+	// Получить свои заметки к парам за период
+	ListLessonNotes(ctx context.Context, in *ListLessonNotesRequest, opts ...grpc.CallOption) (*ListLessonNotesResponse, error)
+	// Удалить заметку к паре
+	DeleteLessonNote(ctx context.Context, in *DeleteLessonNoteRequest, opts ...grpc.CallOption) (*DeleteLessonNoteResponse, error)
+	// Получить корпуса колледжа с аудиториями и время перехода между корпусами
+	ListBuildings(ctx context.Context, in *ListBuildingsRequest, opts ...grpc.CallOption) (*ListBuildingsResponse, error)
+	// Создать или изменить корпус по коду и заменить список его аудиторий
+	// (только для администраторов)
+	SetBuilding(ctx context.Context, in *SetBuildingRequest, opts ...grpc.CallOption) (*SetBuildingResponse, error)
+	// Удалить корпус вместе с его аудиториями (только для администраторов)
+	DeleteBuilding(ctx context.Context, in *DeleteBuildingRequest, opts ...grpc.CallOption) (*DeleteBuildingResponse, error)
+	// Перейти на новый учебный год: архивировать расписание прошлого года, перевести
+	// студентов на следующий курс, выпустить и переименовать группы, сбросить подгруппы,
+	// записи на факультативы и вебхуки выпущенных групп (только для администраторов)
+	RunAcademicRollover(ctx context.Context, in *RunAcademicRolloverRequest, opts ...grpc.CallOption) (*RunAcademicRolloverResponse, error)
+	// Опубликовать свои еженедельные консультации вместо прежних (только для преподавателей).
+	// Консультации не должны пересекаться с занятиями преподавателя
+	SetConsultationHours(ctx context.Context, in *SetConsultationHoursRequest, opts ...grpc.CallOption) (*SetConsultationHoursResponse, error)
+	// Получить консультации: студенту - преподавателей его группы, преподавателю - свои,
+	// администратору - всех преподавателей
+	ListConsultationHours(ctx context.Context, in *ListConsultationHoursRequest, opts ...grpc.CallOption) (*ListConsultationHoursResponse, error)
+	// Включить или отключить напоминания о консультациях преподавателя (только для студентов)
+	SetConsultationReminder(ctx context.Context, in *SetConsultationReminderRequest, opts ...grpc.CallOption) (*SetConsultationReminderResponse, error)
+	// Дождаться новых уведомлений (в том числе об изменениях расписания) для клиентов
+	// без push: ответ приходит, как только появится уведомление, или по истечении
+	// timeout_seconds с пустым списком. Через REST-фасад доступен как
+	// POST /api/v1/schedule.ScheduleService/PollUpdates
+	PollUpdates(ctx context.Context, in *PollUpdatesRequest, opts ...grpc.CallOption) (*PollUpdatesResponse, error)
+	// Создать табло расписания для коридора (только для администраторов). Ключ табло
+	// возвращается один раз: табло запрашивает расписание по GET /kiosk?key=<ключ>
+	CreateKioskDisplay(ctx context.Context, in *CreateKioskDisplayRequest, opts ...grpc.CallOption) (*CreateKioskDisplayResponse, error)
+	// Получить действующие табло колледжа (только для администраторов)
+	ListKioskDisplays(ctx context.Context, in *ListKioskDisplaysRequest, opts ...grpc.CallOption) (*ListKioskDisplaysResponse, error)
+	// Отключить табло: его ключ перестает действовать (только для администраторов)
+	RevokeKioskDisplay(ctx context.Context, in *RevokeKioskDisplayRequest, opts ...grpc.CallOption) (*RevokeKioskDisplayResponse, error)
 }
 
 type scheduleServiceClient struct {
@@ -78,6 +291,616 @@ func (c *scheduleServiceClient) GetScheduleSnapshotsHistory(ctx context.Context,
 	return out, nil
 }
 
+func (c *scheduleServiceClient) GetSnapshotData(ctx context.Context, in *GetSnapshotDataRequest, opts ...grpc.CallOption) (*GetSnapshotDataResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetSnapshotDataResponse)
+	err := c.cc.Invoke(ctx, ScheduleService_GetSnapshotData_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *scheduleServiceClient) GetMySchedule(ctx context.Context, in *GetMyScheduleRequest, opts ...grpc.CallOption) (*GetMyScheduleResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetMyScheduleResponse)
+	err := c.cc.Invoke(ctx, ScheduleService_GetMySchedule_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *scheduleServiceClient) GetWidgetSummary(ctx context.Context, in *GetWidgetSummaryRequest, opts ...grpc.CallOption) (*GetWidgetSummaryResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetWidgetSummaryResponse)
+	err := c.cc.Invoke(ctx, ScheduleService_GetWidgetSummary_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *scheduleServiceClient) GetNextLesson(ctx context.Context, in *GetNextLessonRequest, opts ...grpc.CallOption) (*GetNextLessonResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetNextLessonResponse)
+	err := c.cc.Invoke(ctx, ScheduleService_GetNextLesson_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *scheduleServiceClient) GetBellStatus(ctx context.Context, in *GetBellStatusRequest, opts ...grpc.CallOption) (*GetBellStatusResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetBellStatusResponse)
+	err := c.cc.Invoke(ctx, ScheduleService_GetBellStatus_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *scheduleServiceClient) GetTeacherDashboard(ctx context.Context, in *GetTeacherDashboardRequest, opts ...grpc.CallOption) (*GetTeacherDashboardResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetTeacherDashboardResponse)
+	err := c.cc.Invoke(ctx, ScheduleService_GetTeacherDashboard_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *scheduleServiceClient) ReportScheduleError(ctx context.Context, in *ReportScheduleErrorRequest, opts ...grpc.CallOption) (*ReportScheduleErrorResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ReportScheduleErrorResponse)
+	err := c.cc.Invoke(ctx, ScheduleService_ReportScheduleError_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *scheduleServiceClient) FindFreeSlots(ctx context.Context, in *FindFreeSlotsRequest, opts ...grpc.CallOption) (*FindFreeSlotsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(FindFreeSlotsResponse)
+	err := c.cc.Invoke(ctx, ScheduleService_FindFreeSlots_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *scheduleServiceClient) GetWorkloadStats(ctx context.Context, in *GetWorkloadStatsRequest, opts ...grpc.CallOption) (*GetWorkloadStatsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetWorkloadStatsResponse)
+	err := c.cc.Invoke(ctx, ScheduleService_GetWorkloadStats_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *scheduleServiceClient) GetChangeStats(ctx context.Context, in *GetChangeStatsRequest, opts ...grpc.CallOption) (*GetChangeStatsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetChangeStatsResponse)
+	err := c.cc.Invoke(ctx, ScheduleService_GetChangeStats_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *scheduleServiceClient) RunMaintenance(ctx context.Context, in *RunMaintenanceRequest, opts ...grpc.CallOption) (*RunMaintenanceResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(RunMaintenanceResponse)
+	err := c.cc.Invoke(ctx, ScheduleService_RunMaintenance_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *scheduleServiceClient) SearchSchedule(ctx context.Context, in *SearchScheduleRequest, opts ...grpc.CallOption) (*SearchScheduleResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(SearchScheduleResponse)
+	err := c.cc.Invoke(ctx, ScheduleService_SearchSchedule_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *scheduleServiceClient) CompareSnapshots(ctx context.Context, in *CompareSnapshotsRequest, opts ...grpc.CallOption) (*CompareSnapshotsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(CompareSnapshotsResponse)
+	err := c.cc.Invoke(ctx, ScheduleService_CompareSnapshots_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *scheduleServiceClient) ListSubjectMetadata(ctx context.Context, in *ListSubjectMetadataRequest, opts ...grpc.CallOption) (*ListSubjectMetadataResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListSubjectMetadataResponse)
+	err := c.cc.Invoke(ctx, ScheduleService_ListSubjectMetadata_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *scheduleServiceClient) UpsertSubjectMetadata(ctx context.Context, in *UpsertSubjectMetadataRequest, opts ...grpc.CallOption) (*UpsertSubjectMetadataResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(UpsertSubjectMetadataResponse)
+	err := c.cc.Invoke(ctx, ScheduleService_UpsertSubjectMetadata_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *scheduleServiceClient) DeleteSubjectMetadata(ctx context.Context, in *DeleteSubjectMetadataRequest, opts ...grpc.CallOption) (*DeleteSubjectMetadataResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(DeleteSubjectMetadataResponse)
+	err := c.cc.Invoke(ctx, ScheduleService_DeleteSubjectMetadata_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *scheduleServiceClient) ListOverlappingChanges(ctx context.Context, in *ListOverlappingChangesRequest, opts ...grpc.CallOption) (*ListOverlappingChangesResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListOverlappingChangesResponse)
+	err := c.cc.Invoke(ctx, ScheduleService_ListOverlappingChanges_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *scheduleServiceClient) ListSnapshotChanges(ctx context.Context, in *ListSnapshotChangesRequest, opts ...grpc.CallOption) (*ListSnapshotChangesResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListSnapshotChangesResponse)
+	err := c.cc.Invoke(ctx, ScheduleService_ListSnapshotChanges_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *scheduleServiceClient) ListChangesAwaitingModeration(ctx context.Context, in *ListChangesAwaitingModerationRequest, opts ...grpc.CallOption) (*ListChangesAwaitingModerationResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListChangesAwaitingModerationResponse)
+	err := c.cc.Invoke(ctx, ScheduleService_ListChangesAwaitingModeration_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *scheduleServiceClient) ReviewChange(ctx context.Context, in *ReviewChangeRequest, opts ...grpc.CallOption) (*ReviewChangeResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ReviewChangeResponse)
+	err := c.cc.Invoke(ctx, ScheduleService_ReviewChange_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *scheduleServiceClient) SubmitTeacherChangeRequest(ctx context.Context, in *SubmitTeacherChangeRequestRequest, opts ...grpc.CallOption) (*SubmitTeacherChangeRequestResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(SubmitTeacherChangeRequestResponse)
+	err := c.cc.Invoke(ctx, ScheduleService_SubmitTeacherChangeRequest_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *scheduleServiceClient) ListMyTeacherChangeRequests(ctx context.Context, in *ListMyTeacherChangeRequestsRequest, opts ...grpc.CallOption) (*ListMyTeacherChangeRequestsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListMyTeacherChangeRequestsResponse)
+	err := c.cc.Invoke(ctx, ScheduleService_ListMyTeacherChangeRequests_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *scheduleServiceClient) ListPendingTeacherChangeRequests(ctx context.Context, in *ListPendingTeacherChangeRequestsRequest, opts ...grpc.CallOption) (*ListPendingTeacherChangeRequestsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListPendingTeacherChangeRequestsResponse)
+	err := c.cc.Invoke(ctx, ScheduleService_ListPendingTeacherChangeRequests_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *scheduleServiceClient) ReviewTeacherChangeRequest(ctx context.Context, in *ReviewTeacherChangeRequestRequest, opts ...grpc.CallOption) (*ReviewTeacherChangeRequestResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ReviewTeacherChangeRequestResponse)
+	err := c.cc.Invoke(ctx, ScheduleService_ReviewTeacherChangeRequest_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *scheduleServiceClient) ClaimTeacherName(ctx context.Context, in *ClaimTeacherNameRequest, opts ...grpc.CallOption) (*ClaimTeacherNameResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ClaimTeacherNameResponse)
+	err := c.cc.Invoke(ctx, ScheduleService_ClaimTeacherName_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *scheduleServiceClient) ListMyTeacherNameClaims(ctx context.Context, in *ListMyTeacherNameClaimsRequest, opts ...grpc.CallOption) (*ListMyTeacherNameClaimsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListMyTeacherNameClaimsResponse)
+	err := c.cc.Invoke(ctx, ScheduleService_ListMyTeacherNameClaims_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *scheduleServiceClient) ListPendingTeacherNameClaims(ctx context.Context, in *ListPendingTeacherNameClaimsRequest, opts ...grpc.CallOption) (*ListPendingTeacherNameClaimsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListPendingTeacherNameClaimsResponse)
+	err := c.cc.Invoke(ctx, ScheduleService_ListPendingTeacherNameClaims_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *scheduleServiceClient) ReviewTeacherNameClaim(ctx context.Context, in *ReviewTeacherNameClaimRequest, opts ...grpc.CallOption) (*ReviewTeacherNameClaimResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ReviewTeacherNameClaimResponse)
+	err := c.cc.Invoke(ctx, ScheduleService_ReviewTeacherNameClaim_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *scheduleServiceClient) GetGroupRoster(ctx context.Context, in *GetGroupRosterRequest, opts ...grpc.CallOption) (*GetGroupRosterResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetGroupRosterResponse)
+	err := c.cc.Invoke(ctx, ScheduleService_GetGroupRoster_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *scheduleServiceClient) ListJobs(ctx context.Context, in *ListJobsRequest, opts ...grpc.CallOption) (*ListJobsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListJobsResponse)
+	err := c.cc.Invoke(ctx, ScheduleService_ListJobs_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *scheduleServiceClient) RetryJob(ctx context.Context, in *RetryJobRequest, opts ...grpc.CallOption) (*RetryJobResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(RetryJobResponse)
+	err := c.cc.Invoke(ctx, ScheduleService_RetryJob_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *scheduleServiceClient) ListFeatureFlags(ctx context.Context, in *ListFeatureFlagsRequest, opts ...grpc.CallOption) (*ListFeatureFlagsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListFeatureFlagsResponse)
+	err := c.cc.Invoke(ctx, ScheduleService_ListFeatureFlags_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *scheduleServiceClient) SetFeatureFlag(ctx context.Context, in *SetFeatureFlagRequest, opts ...grpc.CallOption) (*SetFeatureFlagResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(SetFeatureFlagResponse)
+	err := c.cc.Invoke(ctx, ScheduleService_SetFeatureFlag_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *scheduleServiceClient) ResetFeatureFlag(ctx context.Context, in *ResetFeatureFlagRequest, opts ...grpc.CallOption) (*ResetFeatureFlagResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ResetFeatureFlagResponse)
+	err := c.cc.Invoke(ctx, ScheduleService_ResetFeatureFlag_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *scheduleServiceClient) GetCalendarSubscription(ctx context.Context, in *GetCalendarSubscriptionRequest, opts ...grpc.CallOption) (*GetCalendarSubscriptionResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetCalendarSubscriptionResponse)
+	err := c.cc.Invoke(ctx, ScheduleService_GetCalendarSubscription_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *scheduleServiceClient) ListGroupWebhooks(ctx context.Context, in *ListGroupWebhooksRequest, opts ...grpc.CallOption) (*ListGroupWebhooksResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListGroupWebhooksResponse)
+	err := c.cc.Invoke(ctx, ScheduleService_ListGroupWebhooks_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *scheduleServiceClient) SetGroupWebhook(ctx context.Context, in *SetGroupWebhookRequest, opts ...grpc.CallOption) (*SetGroupWebhookResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(SetGroupWebhookResponse)
+	err := c.cc.Invoke(ctx, ScheduleService_SetGroupWebhook_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *scheduleServiceClient) DeleteGroupWebhook(ctx context.Context, in *DeleteGroupWebhookRequest, opts ...grpc.CallOption) (*DeleteGroupWebhookResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(DeleteGroupWebhookResponse)
+	err := c.cc.Invoke(ctx, ScheduleService_DeleteGroupWebhook_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *scheduleServiceClient) GetTimetablePDF(ctx context.Context, in *GetTimetablePDFRequest, opts ...grpc.CallOption) (*GetTimetablePDFResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetTimetablePDFResponse)
+	err := c.cc.Invoke(ctx, ScheduleService_GetTimetablePDF_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *scheduleServiceClient) ImportTeacherDirectory(ctx context.Context, in *ImportTeacherDirectoryRequest, opts ...grpc.CallOption) (*ImportTeacherDirectoryResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ImportTeacherDirectoryResponse)
+	err := c.cc.Invoke(ctx, ScheduleService_ImportTeacherDirectory_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *scheduleServiceClient) SetLessonMeetingUrl(ctx context.Context, in *SetLessonMeetingUrlRequest, opts ...grpc.CallOption) (*SetLessonMeetingUrlResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(SetLessonMeetingUrlResponse)
+	err := c.cc.Invoke(ctx, ScheduleService_SetLessonMeetingUrl_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *scheduleServiceClient) CreateElectiveCourse(ctx context.Context, in *CreateElectiveCourseRequest, opts ...grpc.CallOption) (*CreateElectiveCourseResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(CreateElectiveCourseResponse)
+	err := c.cc.Invoke(ctx, ScheduleService_CreateElectiveCourse_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *scheduleServiceClient) CancelElectiveCourse(ctx context.Context, in *CancelElectiveCourseRequest, opts ...grpc.CallOption) (*CancelElectiveCourseResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(CancelElectiveCourseResponse)
+	err := c.cc.Invoke(ctx, ScheduleService_CancelElectiveCourse_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *scheduleServiceClient) ListElectiveCourses(ctx context.Context, in *ListElectiveCoursesRequest, opts ...grpc.CallOption) (*ListElectiveCoursesResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListElectiveCoursesResponse)
+	err := c.cc.Invoke(ctx, ScheduleService_ListElectiveCourses_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *scheduleServiceClient) EnrollElective(ctx context.Context, in *EnrollElectiveRequest, opts ...grpc.CallOption) (*EnrollElectiveResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(EnrollElectiveResponse)
+	err := c.cc.Invoke(ctx, ScheduleService_EnrollElective_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *scheduleServiceClient) UnenrollElective(ctx context.Context, in *UnenrollElectiveRequest, opts ...grpc.CallOption) (*UnenrollElectiveResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(UnenrollElectiveResponse)
+	err := c.cc.Invoke(ctx, ScheduleService_UnenrollElective_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *scheduleServiceClient) GetTeacherWorkload(ctx context.Context, in *GetTeacherWorkloadRequest, opts ...grpc.CallOption) (*GetTeacherWorkloadResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetTeacherWorkloadResponse)
+	err := c.cc.Invoke(ctx, ScheduleService_GetTeacherWorkload_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *scheduleServiceClient) SetLessonNote(ctx context.Context, in *SetLessonNoteRequest, opts ...grpc.CallOption) (*SetLessonNoteResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(SetLessonNoteResponse)
+	err := c.cc.Invoke(ctx, ScheduleService_SetLessonNote_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *scheduleServiceClient) ListLessonNotes(ctx context.Context, in *ListLessonNotesRequest, opts ...grpc.CallOption) (*ListLessonNotesResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListLessonNotesResponse)
+	err := c.cc.Invoke(ctx, ScheduleService_ListLessonNotes_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *scheduleServiceClient) DeleteLessonNote(ctx context.Context, in *DeleteLessonNoteRequest, opts ...grpc.CallOption) (*DeleteLessonNoteResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(DeleteLessonNoteResponse)
+	err := c.cc.Invoke(ctx, ScheduleService_DeleteLessonNote_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *scheduleServiceClient) ListBuildings(ctx context.Context, in *ListBuildingsRequest, opts ...grpc.CallOption) (*ListBuildingsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListBuildingsResponse)
+	err := c.cc.Invoke(ctx, ScheduleService_ListBuildings_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *scheduleServiceClient) SetBuilding(ctx context.Context, in *SetBuildingRequest, opts ...grpc.CallOption) (*SetBuildingResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(SetBuildingResponse)
+	err := c.cc.Invoke(ctx, ScheduleService_SetBuilding_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *scheduleServiceClient) DeleteBuilding(ctx context.Context, in *DeleteBuildingRequest, opts ...grpc.CallOption) (*DeleteBuildingResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(DeleteBuildingResponse)
+	err := c.cc.Invoke(ctx, ScheduleService_DeleteBuilding_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *scheduleServiceClient) RunAcademicRollover(ctx context.Context, in *RunAcademicRolloverRequest, opts ...grpc.CallOption) (*RunAcademicRolloverResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(RunAcademicRolloverResponse)
+	err := c.cc.Invoke(ctx, ScheduleService_RunAcademicRollover_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *scheduleServiceClient) SetConsultationHours(ctx context.Context, in *SetConsultationHoursRequest, opts ...grpc.CallOption) (*SetConsultationHoursResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(SetConsultationHoursResponse)
+	err := c.cc.Invoke(ctx, ScheduleService_SetConsultationHours_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *scheduleServiceClient) ListConsultationHours(ctx context.Context, in *ListConsultationHoursRequest, opts ...grpc.CallOption) (*ListConsultationHoursResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListConsultationHoursResponse)
+	err := c.cc.Invoke(ctx, ScheduleService_ListConsultationHours_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *scheduleServiceClient) SetConsultationReminder(ctx context.Context, in *SetConsultationReminderRequest, opts ...grpc.CallOption) (*SetConsultationReminderResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(SetConsultationReminderResponse)
+	err := c.cc.Invoke(ctx, ScheduleService_SetConsultationReminder_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *scheduleServiceClient) PollUpdates(ctx context.Context, in *PollUpdatesRequest, opts ...grpc.CallOption) (*PollUpdatesResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(PollUpdatesResponse)
+	err := c.cc.Invoke(ctx, ScheduleService_PollUpdates_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *scheduleServiceClient) CreateKioskDisplay(ctx context.Context, in *CreateKioskDisplayRequest, opts ...grpc.CallOption) (*CreateKioskDisplayResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(CreateKioskDisplayResponse)
+	err := c.cc.Invoke(ctx, ScheduleService_CreateKioskDisplay_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *scheduleServiceClient) ListKioskDisplays(ctx context.Context, in *ListKioskDisplaysRequest, opts ...grpc.CallOption) (*ListKioskDisplaysResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListKioskDisplaysResponse)
+	err := c.cc.Invoke(ctx, ScheduleService_ListKioskDisplays_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *scheduleServiceClient) RevokeKioskDisplay(ctx context.Context, in *RevokeKioskDisplayRequest, opts ...grpc.CallOption) (*RevokeKioskDisplayResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(RevokeKioskDisplayResponse)
+	err := c.cc.Invoke(ctx, ScheduleService_RevokeKioskDisplay_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // ScheduleServiceServer is the server API for ScheduleService service.
 // All implementations must embed UnimplementedScheduleServiceServer
 // for forward compatibility.
@@ -86,100 +909,1533 @@ func (c *scheduleServiceClient) GetScheduleSnapshotsHistory(ctx context.Context,
 type ScheduleServiceServer interface {
 	// Получить расписание для группы на определенную дату
 	GetScheduleForGroup(context.Context, *GetScheduleForGroupRequest) (*GetScheduleForGroupResponse, error)
-	// Получить активный снапшот расписания
+	// Получить метаданные активного снапшота расписания
 	GetActiveScheduleSnapshot(context.Context, *GetActiveScheduleSnapshotRequest) (*GetActiveScheduleSnapshotResponse, error)
 	// Получить историю снапшотов
 	GetScheduleSnapshotsHistory(context.Context, *GetScheduleSnapshotsHistoryRequest) (*GetScheduleSnapshotsHistoryResponse, error)
+	// Получить JSON данные снапшота (метаданные и история их не содержат)
+	GetSnapshotData(context.Context, *GetSnapshotDataRequest) (*GetSnapshotDataResponse, error)
+	// Получить расписание текущего пользователя: группа студента
+	// или занятия преподавателя определяются по профилю
+	GetMySchedule(context.Context, *GetMyScheduleRequest) (*GetMyScheduleResponse, error)
+	// Получить краткую сводку дня для виджета на главном экране: идущая и следующая
+	// пара, время до звонка и сколько пар осталось. Ответ можно не запрашивать
+	// повторно до valid_until; через REST-фасад приходит с заголовком Cache-Control
+	GetWidgetSummary(context.Context, *GetWidgetSummaryRequest) (*GetWidgetSummaryResponse, error)
+	// Получить ближайшее занятие пользователя: пару с учетом изменений и подгруппы,
+	// факультатив или консультацию, о которой пользователь получает напоминания.
+	// Гостю - ближайшую пару группы токена
+	GetNextLesson(context.Context, *GetNextLessonRequest) (*GetNextLessonResponse, error)
+	// Получить состояние учебного дня по расписанию звонков колледжа (идет пара,
+	// перемена, пары закончились) и время до его смены - для обратного отсчета
+	// в заголовке приложения
+	GetBellStatus(context.Context, *GetBellStatusRequest) (*GetBellStatusResponse, error)
+	// Получить данные главного экрана преподавателя одним запросом: занятия на сегодня,
+	// замены на ближайшую неделю, заявки на рассмотрении и непрочитанные уведомления
+	// (только для преподавателей)
+	GetTeacherDashboard(context.Context, *GetTeacherDashboardRequest) (*GetTeacherDashboardResponse, error)
+	// Сообщить об ошибке в расписании конкретной пары (неверный предмет, преподаватель,
+	// аудитория, время или пара не проводится). Жалобы группируются по паре и видны
+	// администратору в панели; набрав порог, жалобы запускают повторный парсинг таблицы
+	ReportScheduleError(context.Context, *ReportScheduleErrorRequest) (*ReportScheduleErrorResponse, error)
+	// Найти общие свободные окна для групп и/или преподавателя на дату
+	FindFreeSlots(context.Context, *FindFreeSlotsRequest) (*FindFreeSlotsResponse, error)
+	// Получить статистику нагрузки (часы по предметам) группы или преподавателя
+	GetWorkloadStats(context.Context, *GetWorkloadStatsRequest) (*GetWorkloadStatsResponse, error)
+	// Статистика изменений расписания для аналитики (только для администраторов)
+	GetChangeStats(context.Context, *GetChangeStatsRequest) (*GetChangeStatsResponse, error)
+	// Запустить задачи обслуживания (архивация снапшотов, кэш расписания) вне расписания
+	// (только для администраторов)
+	RunMaintenance(context.Context, *RunMaintenanceRequest) (*RunMaintenanceResponse, error)
+	// Нечеткий поиск по предметам, преподавателям и аудиториям
+	SearchSchedule(context.Context, *SearchScheduleRequest) (*SearchScheduleResponse, error)
+	// Сравнить два снапшота расписания (только для администраторов)
+	CompareSnapshots(context.Context, *CompareSnapshotsRequest) (*CompareSnapshotsResponse, error)
+	// Получить параметры отображения предметов (цвета, короткие названия, иконки)
+	ListSubjectMetadata(context.Context, *ListSubjectMetadataRequest) (*ListSubjectMetadataResponse, error)
+	// Создать или изменить параметры отображения предмета (только для администраторов)
+	UpsertSubjectMetadata(context.Context, *UpsertSubjectMetadataRequest) (*UpsertSubjectMetadataResponse, error)
+	// Удалить параметры отображения предмета (только для администраторов)
+	DeleteSubjectMetadata(context.Context, *DeleteSubjectMetadataRequest) (*DeleteSubjectMetadataResponse, error)
+	// Получить изменения, пересекающиеся с другими занятиями (только для администраторов)
+	ListOverlappingChanges(context.Context, *ListOverlappingChangesRequest) (*ListOverlappingChangesResponse, error)
+	// Получить все изменения относительно снапшота
+	ListSnapshotChanges(context.Context, *ListSnapshotChangesRequest) (*ListSnapshotChangesResponse, error)
+	// Получить изменения, ожидающие модерации (только для администраторов)
+	ListChangesAwaitingModeration(context.Context, *ListChangesAwaitingModerationRequest) (*ListChangesAwaitingModerationResponse, error)
+	// Одобрить (с исправлениями) или отклонить изменение (только для администраторов)
+	ReviewChange(context.Context, *ReviewChangeRequest) (*ReviewChangeResponse, error)
+	// Подать заявку на отмену или перенос своей пары (только для преподавателей)
+	SubmitTeacherChangeRequest(context.Context, *SubmitTeacherChangeRequestRequest) (*SubmitTeacherChangeRequestResponse, error)
+	// Получить свои заявки (только для преподавателей)
+	ListMyTeacherChangeRequests(context.Context, *ListMyTeacherChangeRequestsRequest) (*ListMyTeacherChangeRequestsResponse, error)
+	// Получить заявки преподавателей на рассмотрении (только для администраторов)
+	ListPendingTeacherChangeRequests(context.Context, *ListPendingTeacherChangeRequestsRequest) (*ListPendingTeacherChangeRequestsResponse, error)
+	// Одобрить или отклонить заявку преподавателя (только для администраторов)
+	ReviewTeacherChangeRequest(context.Context, *ReviewTeacherChangeRequestRequest) (*ReviewTeacherChangeRequestResponse, error)
+	// Заявить вариант своего имени в расписании ("Иванов И.И."). Однозначное совпадение
+	// с ФИО профиля подтверждается сразу, остальные - администратором
+	ClaimTeacherName(context.Context, *ClaimTeacherNameRequest) (*ClaimTeacherNameResponse, error)
+	// Получить свои варианты имени (для преподавателя)
+	ListMyTeacherNameClaims(context.Context, *ListMyTeacherNameClaimsRequest) (*ListMyTeacherNameClaimsResponse, error)
+	// Получить варианты имени, ожидающие подтверждения (только для администраторов)
+	ListPendingTeacherNameClaims(context.Context, *ListPendingTeacherNameClaimsRequest) (*ListPendingTeacherNameClaimsResponse, error)
+	// Подтвердить или отклонить вариант имени (только для администраторов)
+	ReviewTeacherNameClaim(context.Context, *ReviewTeacherNameClaimRequest) (*ReviewTeacherNameClaimResponse, error)
+	// Получить список зарегистрированных студентов группы
+	// (администраторам и преподавателям, у которых есть занятия с группой)
+	GetGroupRoster(context.Context, *GetGroupRosterRequest) (*GetGroupRosterResponse, error)
+	// Получить фоновые задачи и статистику очереди (только для администраторов)
+	ListJobs(context.Context, *ListJobsRequest) (*ListJobsResponse, error)
+	// Вернуть в очередь задачу, исчерпавшую попытки (только для администраторов)
+	RetryJob(context.Context, *RetryJobRequest) (*RetryJobResponse, error)
+	// Получить флаги функций (только для администраторов)
+	ListFeatureFlags(context.Context, *ListFeatureFlagsRequest) (*ListFeatureFlagsResponse, error)
+	// Включить, выключить или изменить долю пользователей флага (только для администраторов)
+	SetFeatureFlag(context.Context, *SetFeatureFlagRequest) (*SetFeatureFlagResponse, error)
+	// Вернуть флагу значение по умолчанию для окружения (только для администраторов)
+	ResetFeatureFlag(context.Context, *ResetFeatureFlagRequest) (*ResetFeatureFlagResponse, error)
+	// Получить ссылки для подписки на личное расписание в календаре
+	// (webcal:// и Google Календарь)
+	GetCalendarSubscription(context.Context, *GetCalendarSubscriptionRequest) (*GetCalendarSubscriptionResponse, error)
+	// Получить вебхуки групповых чатов (только для администраторов)
+	ListGroupWebhooks(context.Context, *ListGroupWebhooksRequest) (*ListGroupWebhooksResponse, error)
+	// Настроить вебхук Discord или Slack для сводок изменений группы
+	// (только для администраторов)
+	SetGroupWebhook(context.Context, *SetGroupWebhookRequest) (*SetGroupWebhookResponse, error)
+	// Удалить вебхук группы (только для администраторов)
+	DeleteGroupWebhook(context.Context, *DeleteGroupWebhookRequest) (*DeleteGroupWebhookResponse, error)
+	// Получить расписание группы на неделю в PDF для печати
+	GetTimetablePDF(context.Context, *GetTimetablePDFRequest) (*GetTimetablePDFResponse, error)
+	// Загрузить официальный список преподавателей (ФИО, кафедра, должность) из CSV
+	// в справочник колледжа (только для администраторов)
+	ImportTeacherDirectory(context.Context, *ImportTeacherDirectoryRequest) (*ImportTeacherDirectoryResponse, error)
+	// Задать ссылку на онлайн-занятие для пары (преподаватель - для своих пар,
+	// администратор - для любых); студенты группы получают уведомление
+	SetLessonMeetingUrl(context.Context, *SetLessonMeetingUrlRequest) (*SetLessonMeetingUrlResponse, error)
+	// Создать факультатив с еженедельными занятиями (только для администраторов)
+	CreateElectiveCourse(context.Context, *CreateElectiveCourseRequest) (*CreateElectiveCourseResponse, error)
+	// Отменить факультатив; записанные студенты получают уведомление
+	// (только для администраторов)
+	CancelElectiveCourse(context.Context, *CancelElectiveCourseRequest) (*CancelElectiveCourseResponse, error)
+	// Получить факультативы, которые еще не закончились, с отметкой записи
+	ListElectiveCourses(context.Context, *ListElectiveCoursesRequest) (*ListElectiveCoursesResponse, error)
+	// Записаться на факультатив: его занятия появятся в личном расписании (только студенты)
+	EnrollElective(context.Context, *EnrollElectiveRequest) (*EnrollElectiveResponse, error)
+	// Отменить запись на факультатив
+	UnenrollElective(context.Context, *UnenrollElectiveRequest) (*UnenrollElectiveResponse, error)
+	// Нагрузка всех преподавателей за неделю или семестр: часы по группам и предметам,
+	// по запросу - отчет в PDF (только для администраторов)
+	GetTeacherWorkload(context.Context, *GetTeacherWorkloadRequest) (*GetTeacherWorkloadResponse, error)
+	// Создать или изменить личную заметку к паре; видна только автору
+	// и возвращается в поле note записей расписания
+	SetLessonNote(context.Context, *SetLessonNoteRequest) (*SetLessonNoteResponse, error)
+	// Получить свои заметки к парам за период
+	ListLessonNotes(context.Context, *ListLessonNotesRequest) (*ListLessonNotesResponse, error)
+	// Удалить заметку к паре
+	DeleteLessonNote(context.Context, *DeleteLessonNoteRequest) (*DeleteLessonNoteResponse, error)
+	// Получить корпуса колледжа с аудиториями и время перехода между корпусами
+	ListBuildings(context.Context, *ListBuildingsRequest) (*ListBuildingsResponse, error)
+	// Создать или изменить корпус по коду и заменить список его аудиторий
+	// (только для администраторов)
+	SetBuilding(context.Context, *SetBuildingRequest) (*SetBuildingResponse, error)
+	// Удалить корпус вместе с его аудиториями (только для администраторов)
+	DeleteBuilding(context.Context, *DeleteBuildingRequest) (*DeleteBuildingResponse, error)
+	// Перейти на новый учебный год: архивировать расписание прошлого года, перевести
+	// студентов на следующий курс, выпустить и переименовать группы, сбросить подгруппы,
+	// записи на факультативы и вебхуки выпущенных групп (только для администраторов)
+	RunAcademicRollover(context.Context, *RunAcademicRolloverRequest) (*RunAcademicRolloverResponse, error)
+	// Опубликовать свои еженедельные консультации вместо прежних (только для преподавателей).
+	// Консультации не должны пересекаться с занятиями преподавателя
+	SetConsultationHours(context.Context, *SetConsultationHoursRequest) (*SetConsultationHoursResponse, error)
+	// Получить консультации: студенту - преподавателей его группы, преподавателю - свои,
+	// администратору - всех преподавателей
+	ListConsultationHours(context.Context, *ListConsultationHoursRequest) (*ListConsultationHoursResponse, error)
+	// Включить или отключить напоминания о консультациях преподавателя (только для студентов)
+	SetConsultationReminder(context.Context, *SetConsultationReminderRequest) (*SetConsultationReminderResponse, error)
+	// Дождаться новых уведомлений (в том числе об изменениях расписания) для клиентов
+	// без push: ответ приходит, как только появится уведомление, или по истечении
+	// timeout_seconds с пустым списком. Через REST-фасад доступен как
+	// POST /api/v1/schedule.ScheduleService/PollUpdates
+	PollUpdates(context.Context, *PollUpdatesRequest) (*PollUpdatesResponse, error)
+	// Создать табло расписания для коридора (только для администраторов). Ключ табло
+	// возвращается один раз: табло запрашивает расписание по GET /kiosk?key=<ключ>
+	CreateKioskDisplay(context.Context, *CreateKioskDisplayRequest) (*CreateKioskDisplayResponse, error)
+	// Получить действующие табло колледжа (только для администраторов)
+	ListKioskDisplays(context.Context, *ListKioskDisplaysRequest) (*ListKioskDisplaysResponse, error)
+	// Отключить табло: его ключ перестает действовать (только для администраторов)
+	RevokeKioskDisplay(context.Context, *RevokeKioskDisplayRequest) (*RevokeKioskDisplayResponse, error)
+	mustEmbedUnimplementedScheduleServiceServer()
+}
+
+// UnimplementedScheduleServiceServer must be embedded to have
+// forward compatible implementations.
+//
+// NOTE: this should be embedded by value instead of pointer to avoid a nil
+// pointer dereference when methods are called.
+type UnimplementedScheduleServiceServer struct{}
+
+func (UnimplementedScheduleServiceServer) GetScheduleForGroup(context.Context, *GetScheduleForGroupRequest) (*GetScheduleForGroupResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetScheduleForGroup not implemented")
+}
+func (UnimplementedScheduleServiceServer) GetActiveScheduleSnapshot(context.Context, *GetActiveScheduleSnapshotRequest) (*GetActiveScheduleSnapshotResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetActiveScheduleSnapshot not implemented")
+}
+func (UnimplementedScheduleServiceServer) GetScheduleSnapshotsHistory(context.Context, *GetScheduleSnapshotsHistoryRequest) (*GetScheduleSnapshotsHistoryResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetScheduleSnapshotsHistory not implemented")
+}
+func (UnimplementedScheduleServiceServer) GetSnapshotData(context.Context, *GetSnapshotDataRequest) (*GetSnapshotDataResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetSnapshotData not implemented")
+}
+func (UnimplementedScheduleServiceServer) GetMySchedule(context.Context, *GetMyScheduleRequest) (*GetMyScheduleResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetMySchedule not implemented")
+}
+func (UnimplementedScheduleServiceServer) GetWidgetSummary(context.Context, *GetWidgetSummaryRequest) (*GetWidgetSummaryResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetWidgetSummary not implemented")
+}
+func (UnimplementedScheduleServiceServer) GetNextLesson(context.Context, *GetNextLessonRequest) (*GetNextLessonResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetNextLesson not implemented")
+}
+func (UnimplementedScheduleServiceServer) GetBellStatus(context.Context, *GetBellStatusRequest) (*GetBellStatusResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetBellStatus not implemented")
+}
+func (UnimplementedScheduleServiceServer) GetTeacherDashboard(context.Context, *GetTeacherDashboardRequest) (*GetTeacherDashboardResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetTeacherDashboard not implemented")
+}
+func (UnimplementedScheduleServiceServer) ReportScheduleError(context.Context, *ReportScheduleErrorRequest) (*ReportScheduleErrorResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ReportScheduleError not implemented")
+}
+func (UnimplementedScheduleServiceServer) FindFreeSlots(context.Context, *FindFreeSlotsRequest) (*FindFreeSlotsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method FindFreeSlots not implemented")
+}
+func (UnimplementedScheduleServiceServer) GetWorkloadStats(context.Context, *GetWorkloadStatsRequest) (*GetWorkloadStatsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetWorkloadStats not implemented")
+}
+func (UnimplementedScheduleServiceServer) GetChangeStats(context.Context, *GetChangeStatsRequest) (*GetChangeStatsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetChangeStats not implemented")
+}
+func (UnimplementedScheduleServiceServer) RunMaintenance(context.Context, *RunMaintenanceRequest) (*RunMaintenanceResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RunMaintenance not implemented")
+}
+func (UnimplementedScheduleServiceServer) SearchSchedule(context.Context, *SearchScheduleRequest) (*SearchScheduleResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SearchSchedule not implemented")
+}
+func (UnimplementedScheduleServiceServer) CompareSnapshots(context.Context, *CompareSnapshotsRequest) (*CompareSnapshotsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CompareSnapshots not implemented")
+}
+func (UnimplementedScheduleServiceServer) ListSubjectMetadata(context.Context, *ListSubjectMetadataRequest) (*ListSubjectMetadataResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListSubjectMetadata not implemented")
+}
+func (UnimplementedScheduleServiceServer) UpsertSubjectMetadata(context.Context, *UpsertSubjectMetadataRequest) (*UpsertSubjectMetadataResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UpsertSubjectMetadata not implemented")
+}
+func (UnimplementedScheduleServiceServer) DeleteSubjectMetadata(context.Context, *DeleteSubjectMetadataRequest) (*DeleteSubjectMetadataResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DeleteSubjectMetadata not implemented")
+}
+func (UnimplementedScheduleServiceServer) ListOverlappingChanges(context.Context, *ListOverlappingChangesRequest) (*ListOverlappingChangesResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListOverlappingChanges not implemented")
+}
+func (UnimplementedScheduleServiceServer) ListSnapshotChanges(context.Context, *ListSnapshotChangesRequest) (*ListSnapshotChangesResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListSnapshotChanges not implemented")
+}
+func (UnimplementedScheduleServiceServer) ListChangesAwaitingModeration(context.Context, *ListChangesAwaitingModerationRequest) (*ListChangesAwaitingModerationResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListChangesAwaitingModeration not implemented")
+}
+func (UnimplementedScheduleServiceServer) ReviewChange(context.Context, *ReviewChangeRequest) (*ReviewChangeResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ReviewChange not implemented")
+}
+func (UnimplementedScheduleServiceServer) SubmitTeacherChangeRequest(context.Context, *SubmitTeacherChangeRequestRequest) (*SubmitTeacherChangeRequestResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SubmitTeacherChangeRequest not implemented")
+}
+func (UnimplementedScheduleServiceServer) ListMyTeacherChangeRequests(context.Context, *ListMyTeacherChangeRequestsRequest) (*ListMyTeacherChangeRequestsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListMyTeacherChangeRequests not implemented")
+}
+func (UnimplementedScheduleServiceServer) ListPendingTeacherChangeRequests(context.Context, *ListPendingTeacherChangeRequestsRequest) (*ListPendingTeacherChangeRequestsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListPendingTeacherChangeRequests not implemented")
+}
+func (UnimplementedScheduleServiceServer) ReviewTeacherChangeRequest(context.Context, *ReviewTeacherChangeRequestRequest) (*ReviewTeacherChangeRequestResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ReviewTeacherChangeRequest not implemented")
+}
+func (UnimplementedScheduleServiceServer) ClaimTeacherName(context.Context, *ClaimTeacherNameRequest) (*ClaimTeacherNameResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ClaimTeacherName not implemented")
+}
+func (UnimplementedScheduleServiceServer) ListMyTeacherNameClaims(context.Context, *ListMyTeacherNameClaimsRequest) (*ListMyTeacherNameClaimsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListMyTeacherNameClaims not implemented")
+}
+func (UnimplementedScheduleServiceServer) ListPendingTeacherNameClaims(context.Context, *ListPendingTeacherNameClaimsRequest) (*ListPendingTeacherNameClaimsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListPendingTeacherNameClaims not implemented")
+}
+func (UnimplementedScheduleServiceServer) ReviewTeacherNameClaim(context.Context, *ReviewTeacherNameClaimRequest) (*ReviewTeacherNameClaimResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ReviewTeacherNameClaim not implemented")
+}
+func (UnimplementedScheduleServiceServer) GetGroupRoster(context.Context, *GetGroupRosterRequest) (*GetGroupRosterResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetGroupRoster not implemented")
+}
+func (UnimplementedScheduleServiceServer) ListJobs(context.Context, *ListJobsRequest) (*ListJobsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListJobs not implemented")
+}
+func (UnimplementedScheduleServiceServer) RetryJob(context.Context, *RetryJobRequest) (*RetryJobResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RetryJob not implemented")
+}
+func (UnimplementedScheduleServiceServer) ListFeatureFlags(context.Context, *ListFeatureFlagsRequest) (*ListFeatureFlagsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListFeatureFlags not implemented")
+}
+func (UnimplementedScheduleServiceServer) SetFeatureFlag(context.Context, *SetFeatureFlagRequest) (*SetFeatureFlagResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SetFeatureFlag not implemented")
+}
+func (UnimplementedScheduleServiceServer) ResetFeatureFlag(context.Context, *ResetFeatureFlagRequest) (*ResetFeatureFlagResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ResetFeatureFlag not implemented")
+}
+func (UnimplementedScheduleServiceServer) GetCalendarSubscription(context.Context, *GetCalendarSubscriptionRequest) (*GetCalendarSubscriptionResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetCalendarSubscription not implemented")
+}
+func (UnimplementedScheduleServiceServer) ListGroupWebhooks(context.Context, *ListGroupWebhooksRequest) (*ListGroupWebhooksResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListGroupWebhooks not implemented")
+}
+func (UnimplementedScheduleServiceServer) SetGroupWebhook(context.Context, *SetGroupWebhookRequest) (*SetGroupWebhookResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SetGroupWebhook not implemented")
+}
+func (UnimplementedScheduleServiceServer) DeleteGroupWebhook(context.Context, *DeleteGroupWebhookRequest) (*DeleteGroupWebhookResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DeleteGroupWebhook not implemented")
+}
+func (UnimplementedScheduleServiceServer) GetTimetablePDF(context.Context, *GetTimetablePDFRequest) (*GetTimetablePDFResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetTimetablePDF not implemented")
+}
+func (UnimplementedScheduleServiceServer) ImportTeacherDirectory(context.Context, *ImportTeacherDirectoryRequest) (*ImportTeacherDirectoryResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ImportTeacherDirectory not implemented")
+}
+func (UnimplementedScheduleServiceServer) SetLessonMeetingUrl(context.Context, *SetLessonMeetingUrlRequest) (*SetLessonMeetingUrlResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SetLessonMeetingUrl not implemented")
+}
+func (UnimplementedScheduleServiceServer) CreateElectiveCourse(context.Context, *CreateElectiveCourseRequest) (*CreateElectiveCourseResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CreateElectiveCourse not implemented")
+}
+func (UnimplementedScheduleServiceServer) CancelElectiveCourse(context.Context, *CancelElectiveCourseRequest) (*CancelElectiveCourseResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CancelElectiveCourse not implemented")
+}
+func (UnimplementedScheduleServiceServer) ListElectiveCourses(context.Context, *ListElectiveCoursesRequest) (*ListElectiveCoursesResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListElectiveCourses not implemented")
+}
+func (UnimplementedScheduleServiceServer) EnrollElective(context.Context, *EnrollElectiveRequest) (*EnrollElectiveResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method EnrollElective not implemented")
+}
+func (UnimplementedScheduleServiceServer) UnenrollElective(context.Context, *UnenrollElectiveRequest) (*UnenrollElectiveResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UnenrollElective not implemented")
+}
+func (UnimplementedScheduleServiceServer) GetTeacherWorkload(context.Context, *GetTeacherWorkloadRequest) (*GetTeacherWorkloadResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetTeacherWorkload not implemented")
+}
+func (UnimplementedScheduleServiceServer) SetLessonNote(context.Context, *SetLessonNoteRequest) (*SetLessonNoteResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SetLessonNote not implemented")
+}
+func (UnimplementedScheduleServiceServer) ListLessonNotes(context.Context, *ListLessonNotesRequest) (*ListLessonNotesResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListLessonNotes not implemented")
+}
+func (UnimplementedScheduleServiceServer) DeleteLessonNote(context.Context, *DeleteLessonNoteRequest) (*DeleteLessonNoteResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DeleteLessonNote not implemented")
+}
+func (UnimplementedScheduleServiceServer) ListBuildings(context.Context, *ListBuildingsRequest) (*ListBuildingsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListBuildings not implemented")
+}
+func (UnimplementedScheduleServiceServer) SetBuilding(context.Context, *SetBuildingRequest) (*SetBuildingResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SetBuilding not implemented")
+}
+func (UnimplementedScheduleServiceServer) DeleteBuilding(context.Context, *DeleteBuildingRequest) (*DeleteBuildingResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DeleteBuilding not implemented")
+}
+func (UnimplementedScheduleServiceServer) RunAcademicRollover(context.Context, *RunAcademicRolloverRequest) (*RunAcademicRolloverResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RunAcademicRollover not implemented")
+}
+func (UnimplementedScheduleServiceServer) SetConsultationHours(context.Context, *SetConsultationHoursRequest) (*SetConsultationHoursResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SetConsultationHours not implemented")
+}
+func (UnimplementedScheduleServiceServer) ListConsultationHours(context.Context, *ListConsultationHoursRequest) (*ListConsultationHoursResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListConsultationHours not implemented")
+}
+func (UnimplementedScheduleServiceServer) SetConsultationReminder(context.Context, *SetConsultationReminderRequest) (*SetConsultationReminderResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SetConsultationReminder not implemented")
+}
+func (UnimplementedScheduleServiceServer) PollUpdates(context.Context, *PollUpdatesRequest) (*PollUpdatesResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method PollUpdates not implemented")
+}
+func (UnimplementedScheduleServiceServer) CreateKioskDisplay(context.Context, *CreateKioskDisplayRequest) (*CreateKioskDisplayResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CreateKioskDisplay not implemented")
+}
+func (UnimplementedScheduleServiceServer) ListKioskDisplays(context.Context, *ListKioskDisplaysRequest) (*ListKioskDisplaysResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListKioskDisplays not implemented")
+}
+func (UnimplementedScheduleServiceServer) RevokeKioskDisplay(context.Context, *RevokeKioskDisplayRequest) (*RevokeKioskDisplayResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RevokeKioskDisplay not implemented")
+}
+func (UnimplementedScheduleServiceServer) mustEmbedUnimplementedScheduleServiceServer() {}
+func (UnimplementedScheduleServiceServer) testEmbeddedByValue()                         {}
+
+// UnsafeScheduleServiceServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to ScheduleServiceServer will
+// result in compilation errors.
+type UnsafeScheduleServiceServer interface {
 	mustEmbedUnimplementedScheduleServiceServer()
 }
 
-// UnimplementedScheduleServiceServer must be embedded to have
-// forward compatible implementations.
-//
-// NOTE: this should be embedded by value instead of pointer to avoid a nil
-// pointer dereference when methods are called.
-type UnimplementedScheduleServiceServer struct{}
+func RegisterScheduleServiceServer(s grpc.ServiceRegistrar, srv ScheduleServiceServer) {
+	// If the following call pancis, it indicates UnimplementedScheduleServiceServer was
+	// embedded by pointer and is nil.  This will cause panics if an
+	// unimplemented method is ever invoked, so we test this at initialization
+	// time to prevent it from happening at runtime later due to I/O.
+	if t, ok := srv.(interface{ testEmbeddedByValue() }); ok {
+		t.testEmbeddedByValue()
+	}
+	s.RegisterService(&ScheduleService_ServiceDesc, srv)
+}
+
+func _ScheduleService_GetScheduleForGroup_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetScheduleForGroupRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ScheduleServiceServer).GetScheduleForGroup(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ScheduleService_GetScheduleForGroup_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ScheduleServiceServer).GetScheduleForGroup(ctx, req.(*GetScheduleForGroupRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ScheduleService_GetActiveScheduleSnapshot_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetActiveScheduleSnapshotRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ScheduleServiceServer).GetActiveScheduleSnapshot(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ScheduleService_GetActiveScheduleSnapshot_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ScheduleServiceServer).GetActiveScheduleSnapshot(ctx, req.(*GetActiveScheduleSnapshotRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ScheduleService_GetScheduleSnapshotsHistory_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetScheduleSnapshotsHistoryRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ScheduleServiceServer).GetScheduleSnapshotsHistory(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ScheduleService_GetScheduleSnapshotsHistory_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ScheduleServiceServer).GetScheduleSnapshotsHistory(ctx, req.(*GetScheduleSnapshotsHistoryRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ScheduleService_GetSnapshotData_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetSnapshotDataRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ScheduleServiceServer).GetSnapshotData(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ScheduleService_GetSnapshotData_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ScheduleServiceServer).GetSnapshotData(ctx, req.(*GetSnapshotDataRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ScheduleService_GetMySchedule_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetMyScheduleRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ScheduleServiceServer).GetMySchedule(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ScheduleService_GetMySchedule_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ScheduleServiceServer).GetMySchedule(ctx, req.(*GetMyScheduleRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ScheduleService_GetWidgetSummary_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetWidgetSummaryRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ScheduleServiceServer).GetWidgetSummary(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ScheduleService_GetWidgetSummary_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ScheduleServiceServer).GetWidgetSummary(ctx, req.(*GetWidgetSummaryRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ScheduleService_GetNextLesson_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetNextLessonRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ScheduleServiceServer).GetNextLesson(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ScheduleService_GetNextLesson_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ScheduleServiceServer).GetNextLesson(ctx, req.(*GetNextLessonRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ScheduleService_GetBellStatus_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetBellStatusRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ScheduleServiceServer).GetBellStatus(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ScheduleService_GetBellStatus_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ScheduleServiceServer).GetBellStatus(ctx, req.(*GetBellStatusRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ScheduleService_GetTeacherDashboard_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetTeacherDashboardRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ScheduleServiceServer).GetTeacherDashboard(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ScheduleService_GetTeacherDashboard_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ScheduleServiceServer).GetTeacherDashboard(ctx, req.(*GetTeacherDashboardRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ScheduleService_ReportScheduleError_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ReportScheduleErrorRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ScheduleServiceServer).ReportScheduleError(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ScheduleService_ReportScheduleError_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ScheduleServiceServer).ReportScheduleError(ctx, req.(*ReportScheduleErrorRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ScheduleService_FindFreeSlots_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(FindFreeSlotsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ScheduleServiceServer).FindFreeSlots(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ScheduleService_FindFreeSlots_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ScheduleServiceServer).FindFreeSlots(ctx, req.(*FindFreeSlotsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ScheduleService_GetWorkloadStats_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetWorkloadStatsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ScheduleServiceServer).GetWorkloadStats(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ScheduleService_GetWorkloadStats_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ScheduleServiceServer).GetWorkloadStats(ctx, req.(*GetWorkloadStatsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ScheduleService_GetChangeStats_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetChangeStatsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ScheduleServiceServer).GetChangeStats(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ScheduleService_GetChangeStats_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ScheduleServiceServer).GetChangeStats(ctx, req.(*GetChangeStatsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ScheduleService_RunMaintenance_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RunMaintenanceRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ScheduleServiceServer).RunMaintenance(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ScheduleService_RunMaintenance_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ScheduleServiceServer).RunMaintenance(ctx, req.(*RunMaintenanceRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ScheduleService_SearchSchedule_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SearchScheduleRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ScheduleServiceServer).SearchSchedule(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ScheduleService_SearchSchedule_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ScheduleServiceServer).SearchSchedule(ctx, req.(*SearchScheduleRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ScheduleService_CompareSnapshots_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CompareSnapshotsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ScheduleServiceServer).CompareSnapshots(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ScheduleService_CompareSnapshots_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ScheduleServiceServer).CompareSnapshots(ctx, req.(*CompareSnapshotsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ScheduleService_ListSubjectMetadata_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListSubjectMetadataRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ScheduleServiceServer).ListSubjectMetadata(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ScheduleService_ListSubjectMetadata_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ScheduleServiceServer).ListSubjectMetadata(ctx, req.(*ListSubjectMetadataRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ScheduleService_UpsertSubjectMetadata_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UpsertSubjectMetadataRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ScheduleServiceServer).UpsertSubjectMetadata(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ScheduleService_UpsertSubjectMetadata_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ScheduleServiceServer).UpsertSubjectMetadata(ctx, req.(*UpsertSubjectMetadataRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ScheduleService_DeleteSubjectMetadata_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeleteSubjectMetadataRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ScheduleServiceServer).DeleteSubjectMetadata(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ScheduleService_DeleteSubjectMetadata_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ScheduleServiceServer).DeleteSubjectMetadata(ctx, req.(*DeleteSubjectMetadataRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ScheduleService_ListOverlappingChanges_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListOverlappingChangesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ScheduleServiceServer).ListOverlappingChanges(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ScheduleService_ListOverlappingChanges_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ScheduleServiceServer).ListOverlappingChanges(ctx, req.(*ListOverlappingChangesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ScheduleService_ListSnapshotChanges_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListSnapshotChangesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ScheduleServiceServer).ListSnapshotChanges(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ScheduleService_ListSnapshotChanges_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ScheduleServiceServer).ListSnapshotChanges(ctx, req.(*ListSnapshotChangesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ScheduleService_ListChangesAwaitingModeration_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListChangesAwaitingModerationRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ScheduleServiceServer).ListChangesAwaitingModeration(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ScheduleService_ListChangesAwaitingModeration_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ScheduleServiceServer).ListChangesAwaitingModeration(ctx, req.(*ListChangesAwaitingModerationRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ScheduleService_ReviewChange_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ReviewChangeRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ScheduleServiceServer).ReviewChange(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ScheduleService_ReviewChange_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ScheduleServiceServer).ReviewChange(ctx, req.(*ReviewChangeRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ScheduleService_SubmitTeacherChangeRequest_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SubmitTeacherChangeRequestRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ScheduleServiceServer).SubmitTeacherChangeRequest(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ScheduleService_SubmitTeacherChangeRequest_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ScheduleServiceServer).SubmitTeacherChangeRequest(ctx, req.(*SubmitTeacherChangeRequestRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ScheduleService_ListMyTeacherChangeRequests_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListMyTeacherChangeRequestsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ScheduleServiceServer).ListMyTeacherChangeRequests(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ScheduleService_ListMyTeacherChangeRequests_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ScheduleServiceServer).ListMyTeacherChangeRequests(ctx, req.(*ListMyTeacherChangeRequestsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ScheduleService_ListPendingTeacherChangeRequests_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListPendingTeacherChangeRequestsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ScheduleServiceServer).ListPendingTeacherChangeRequests(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ScheduleService_ListPendingTeacherChangeRequests_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ScheduleServiceServer).ListPendingTeacherChangeRequests(ctx, req.(*ListPendingTeacherChangeRequestsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ScheduleService_ReviewTeacherChangeRequest_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ReviewTeacherChangeRequestRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ScheduleServiceServer).ReviewTeacherChangeRequest(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ScheduleService_ReviewTeacherChangeRequest_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ScheduleServiceServer).ReviewTeacherChangeRequest(ctx, req.(*ReviewTeacherChangeRequestRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ScheduleService_ClaimTeacherName_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ClaimTeacherNameRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ScheduleServiceServer).ClaimTeacherName(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ScheduleService_ClaimTeacherName_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ScheduleServiceServer).ClaimTeacherName(ctx, req.(*ClaimTeacherNameRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ScheduleService_ListMyTeacherNameClaims_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListMyTeacherNameClaimsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ScheduleServiceServer).ListMyTeacherNameClaims(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ScheduleService_ListMyTeacherNameClaims_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ScheduleServiceServer).ListMyTeacherNameClaims(ctx, req.(*ListMyTeacherNameClaimsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ScheduleService_ListPendingTeacherNameClaims_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListPendingTeacherNameClaimsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ScheduleServiceServer).ListPendingTeacherNameClaims(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ScheduleService_ListPendingTeacherNameClaims_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ScheduleServiceServer).ListPendingTeacherNameClaims(ctx, req.(*ListPendingTeacherNameClaimsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ScheduleService_ReviewTeacherNameClaim_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ReviewTeacherNameClaimRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ScheduleServiceServer).ReviewTeacherNameClaim(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ScheduleService_ReviewTeacherNameClaim_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ScheduleServiceServer).ReviewTeacherNameClaim(ctx, req.(*ReviewTeacherNameClaimRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ScheduleService_GetGroupRoster_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetGroupRosterRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ScheduleServiceServer).GetGroupRoster(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ScheduleService_GetGroupRoster_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ScheduleServiceServer).GetGroupRoster(ctx, req.(*GetGroupRosterRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ScheduleService_ListJobs_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListJobsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ScheduleServiceServer).ListJobs(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ScheduleService_ListJobs_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ScheduleServiceServer).ListJobs(ctx, req.(*ListJobsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ScheduleService_RetryJob_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RetryJobRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ScheduleServiceServer).RetryJob(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ScheduleService_RetryJob_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ScheduleServiceServer).RetryJob(ctx, req.(*RetryJobRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ScheduleService_ListFeatureFlags_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListFeatureFlagsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ScheduleServiceServer).ListFeatureFlags(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ScheduleService_ListFeatureFlags_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ScheduleServiceServer).ListFeatureFlags(ctx, req.(*ListFeatureFlagsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ScheduleService_SetFeatureFlag_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SetFeatureFlagRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ScheduleServiceServer).SetFeatureFlag(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ScheduleService_SetFeatureFlag_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ScheduleServiceServer).SetFeatureFlag(ctx, req.(*SetFeatureFlagRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ScheduleService_ResetFeatureFlag_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ResetFeatureFlagRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ScheduleServiceServer).ResetFeatureFlag(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ScheduleService_ResetFeatureFlag_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ScheduleServiceServer).ResetFeatureFlag(ctx, req.(*ResetFeatureFlagRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ScheduleService_GetCalendarSubscription_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetCalendarSubscriptionRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ScheduleServiceServer).GetCalendarSubscription(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ScheduleService_GetCalendarSubscription_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ScheduleServiceServer).GetCalendarSubscription(ctx, req.(*GetCalendarSubscriptionRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ScheduleService_ListGroupWebhooks_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListGroupWebhooksRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ScheduleServiceServer).ListGroupWebhooks(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ScheduleService_ListGroupWebhooks_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ScheduleServiceServer).ListGroupWebhooks(ctx, req.(*ListGroupWebhooksRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ScheduleService_SetGroupWebhook_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SetGroupWebhookRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ScheduleServiceServer).SetGroupWebhook(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ScheduleService_SetGroupWebhook_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ScheduleServiceServer).SetGroupWebhook(ctx, req.(*SetGroupWebhookRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ScheduleService_DeleteGroupWebhook_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeleteGroupWebhookRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ScheduleServiceServer).DeleteGroupWebhook(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ScheduleService_DeleteGroupWebhook_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ScheduleServiceServer).DeleteGroupWebhook(ctx, req.(*DeleteGroupWebhookRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ScheduleService_GetTimetablePDF_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetTimetablePDFRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ScheduleServiceServer).GetTimetablePDF(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ScheduleService_GetTimetablePDF_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ScheduleServiceServer).GetTimetablePDF(ctx, req.(*GetTimetablePDFRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ScheduleService_ImportTeacherDirectory_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ImportTeacherDirectoryRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ScheduleServiceServer).ImportTeacherDirectory(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ScheduleService_ImportTeacherDirectory_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ScheduleServiceServer).ImportTeacherDirectory(ctx, req.(*ImportTeacherDirectoryRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ScheduleService_SetLessonMeetingUrl_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SetLessonMeetingUrlRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ScheduleServiceServer).SetLessonMeetingUrl(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ScheduleService_SetLessonMeetingUrl_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ScheduleServiceServer).SetLessonMeetingUrl(ctx, req.(*SetLessonMeetingUrlRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ScheduleService_CreateElectiveCourse_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateElectiveCourseRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ScheduleServiceServer).CreateElectiveCourse(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ScheduleService_CreateElectiveCourse_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ScheduleServiceServer).CreateElectiveCourse(ctx, req.(*CreateElectiveCourseRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ScheduleService_CancelElectiveCourse_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CancelElectiveCourseRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ScheduleServiceServer).CancelElectiveCourse(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ScheduleService_CancelElectiveCourse_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ScheduleServiceServer).CancelElectiveCourse(ctx, req.(*CancelElectiveCourseRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ScheduleService_ListElectiveCourses_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListElectiveCoursesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ScheduleServiceServer).ListElectiveCourses(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ScheduleService_ListElectiveCourses_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ScheduleServiceServer).ListElectiveCourses(ctx, req.(*ListElectiveCoursesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ScheduleService_EnrollElective_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(EnrollElectiveRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ScheduleServiceServer).EnrollElective(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ScheduleService_EnrollElective_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ScheduleServiceServer).EnrollElective(ctx, req.(*EnrollElectiveRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ScheduleService_UnenrollElective_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UnenrollElectiveRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ScheduleServiceServer).UnenrollElective(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ScheduleService_UnenrollElective_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ScheduleServiceServer).UnenrollElective(ctx, req.(*UnenrollElectiveRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ScheduleService_GetTeacherWorkload_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetTeacherWorkloadRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ScheduleServiceServer).GetTeacherWorkload(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ScheduleService_GetTeacherWorkload_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ScheduleServiceServer).GetTeacherWorkload(ctx, req.(*GetTeacherWorkloadRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ScheduleService_SetLessonNote_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SetLessonNoteRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ScheduleServiceServer).SetLessonNote(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ScheduleService_SetLessonNote_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ScheduleServiceServer).SetLessonNote(ctx, req.(*SetLessonNoteRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ScheduleService_ListLessonNotes_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListLessonNotesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ScheduleServiceServer).ListLessonNotes(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ScheduleService_ListLessonNotes_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ScheduleServiceServer).ListLessonNotes(ctx, req.(*ListLessonNotesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ScheduleService_DeleteLessonNote_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeleteLessonNoteRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ScheduleServiceServer).DeleteLessonNote(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ScheduleService_DeleteLessonNote_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ScheduleServiceServer).DeleteLessonNote(ctx, req.(*DeleteLessonNoteRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ScheduleService_ListBuildings_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListBuildingsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ScheduleServiceServer).ListBuildings(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ScheduleService_ListBuildings_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ScheduleServiceServer).ListBuildings(ctx, req.(*ListBuildingsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ScheduleService_SetBuilding_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SetBuildingRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ScheduleServiceServer).SetBuilding(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ScheduleService_SetBuilding_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ScheduleServiceServer).SetBuilding(ctx, req.(*SetBuildingRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
 
-func (UnimplementedScheduleServiceServer) GetScheduleForGroup(context.Context, *GetScheduleForGroupRequest) (*GetScheduleForGroupResponse, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method GetScheduleForGroup not implemented")
+func _ScheduleService_DeleteBuilding_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeleteBuildingRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ScheduleServiceServer).DeleteBuilding(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ScheduleService_DeleteBuilding_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ScheduleServiceServer).DeleteBuilding(ctx, req.(*DeleteBuildingRequest))
+	}
+	return interceptor(ctx, in, info, handler)
 }
-func (UnimplementedScheduleServiceServer) GetActiveScheduleSnapshot(context.Context, *GetActiveScheduleSnapshotRequest) (*GetActiveScheduleSnapshotResponse, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method GetActiveScheduleSnapshot not implemented")
+
+func _ScheduleService_RunAcademicRollover_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RunAcademicRolloverRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ScheduleServiceServer).RunAcademicRollover(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ScheduleService_RunAcademicRollover_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ScheduleServiceServer).RunAcademicRollover(ctx, req.(*RunAcademicRolloverRequest))
+	}
+	return interceptor(ctx, in, info, handler)
 }
-func (UnimplementedScheduleServiceServer) GetScheduleSnapshotsHistory(context.Context, *GetScheduleSnapshotsHistoryRequest) (*GetScheduleSnapshotsHistoryResponse, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method GetScheduleSnapshotsHistory not implemented")
+
+func _ScheduleService_SetConsultationHours_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SetConsultationHoursRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ScheduleServiceServer).SetConsultationHours(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ScheduleService_SetConsultationHours_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ScheduleServiceServer).SetConsultationHours(ctx, req.(*SetConsultationHoursRequest))
+	}
+	return interceptor(ctx, in, info, handler)
 }
-func (UnimplementedScheduleServiceServer) mustEmbedUnimplementedScheduleServiceServer() {}
-func (UnimplementedScheduleServiceServer) testEmbeddedByValue()                         {}
 
-// UnsafeScheduleServiceServer may be embedded to opt out of forward compatibility for this service.
-// Use of this interface is not recommended, as added methods to ScheduleServiceServer will
-// result in compilation errors.
-type UnsafeScheduleServiceServer interface {
-	mustEmbedUnimplementedScheduleServiceServer()
+func _ScheduleService_ListConsultationHours_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListConsultationHoursRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ScheduleServiceServer).ListConsultationHours(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ScheduleService_ListConsultationHours_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ScheduleServiceServer).ListConsultationHours(ctx, req.(*ListConsultationHoursRequest))
+	}
+	return interceptor(ctx, in, info, handler)
 }
 
-func RegisterScheduleServiceServer(s grpc.ServiceRegistrar, srv ScheduleServiceServer) {
-	// If the following call pancis, it indicates UnimplementedScheduleServiceServer was
-	// embedded by pointer and is nil.  This will cause panics if an
-	// unimplemented method is ever invoked, so we test this at initialization
-	// time to prevent it from happening at runtime later due to I/O.
-	if t, ok := srv.(interface{ testEmbeddedByValue() }); ok {
-		t.testEmbeddedByValue()
+func _ScheduleService_SetConsultationReminder_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SetConsultationReminderRequest)
+	if err := dec(in); err != nil {
+		return nil, err
 	}
-	s.RegisterService(&ScheduleService_ServiceDesc, srv)
+	if interceptor == nil {
+		return srv.(ScheduleServiceServer).SetConsultationReminder(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ScheduleService_SetConsultationReminder_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ScheduleServiceServer).SetConsultationReminder(ctx, req.(*SetConsultationReminderRequest))
+	}
+	return interceptor(ctx, in, info, handler)
 }
 
-func _ScheduleService_GetScheduleForGroup_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(GetScheduleForGroupRequest)
+func _ScheduleService_PollUpdates_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(PollUpdatesRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(ScheduleServiceServer).GetScheduleForGroup(ctx, in)
+		return srv.(ScheduleServiceServer).PollUpdates(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: ScheduleService_GetScheduleForGroup_FullMethodName,
+		FullMethod: ScheduleService_PollUpdates_FullMethodName,
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(ScheduleServiceServer).GetScheduleForGroup(ctx, req.(*GetScheduleForGroupRequest))
+		return srv.(ScheduleServiceServer).PollUpdates(ctx, req.(*PollUpdatesRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _ScheduleService_GetActiveScheduleSnapshot_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(GetActiveScheduleSnapshotRequest)
+func _ScheduleService_CreateKioskDisplay_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateKioskDisplayRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(ScheduleServiceServer).GetActiveScheduleSnapshot(ctx, in)
+		return srv.(ScheduleServiceServer).CreateKioskDisplay(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: ScheduleService_GetActiveScheduleSnapshot_FullMethodName,
+		FullMethod: ScheduleService_CreateKioskDisplay_FullMethodName,
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(ScheduleServiceServer).GetActiveScheduleSnapshot(ctx, req.(*GetActiveScheduleSnapshotRequest))
+		return srv.(ScheduleServiceServer).CreateKioskDisplay(ctx, req.(*CreateKioskDisplayRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _ScheduleService_GetScheduleSnapshotsHistory_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(GetScheduleSnapshotsHistoryRequest)
+func _ScheduleService_ListKioskDisplays_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListKioskDisplaysRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(ScheduleServiceServer).GetScheduleSnapshotsHistory(ctx, in)
+		return srv.(ScheduleServiceServer).ListKioskDisplays(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: ScheduleService_GetScheduleSnapshotsHistory_FullMethodName,
+		FullMethod: ScheduleService_ListKioskDisplays_FullMethodName,
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(ScheduleServiceServer).GetScheduleSnapshotsHistory(ctx, req.(*GetScheduleSnapshotsHistoryRequest))
+		return srv.(ScheduleServiceServer).ListKioskDisplays(ctx, req.(*ListKioskDisplaysRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ScheduleService_RevokeKioskDisplay_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RevokeKioskDisplayRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ScheduleServiceServer).RevokeKioskDisplay(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ScheduleService_RevokeKioskDisplay_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ScheduleServiceServer).RevokeKioskDisplay(ctx, req.(*RevokeKioskDisplayRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
@@ -203,6 +2459,250 @@ var ScheduleService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "GetScheduleSnapshotsHistory",
 			Handler:    _ScheduleService_GetScheduleSnapshotsHistory_Handler,
 		},
+		{
+			MethodName: "GetSnapshotData",
+			Handler:    _ScheduleService_GetSnapshotData_Handler,
+		},
+		{
+			MethodName: "GetMySchedule",
+			Handler:    _ScheduleService_GetMySchedule_Handler,
+		},
+		{
+			MethodName: "GetWidgetSummary",
+			Handler:    _ScheduleService_GetWidgetSummary_Handler,
+		},
+		{
+			MethodName: "GetNextLesson",
+			Handler:    _ScheduleService_GetNextLesson_Handler,
+		},
+		{
+			MethodName: "GetBellStatus",
+			Handler:    _ScheduleService_GetBellStatus_Handler,
+		},
+		{
+			MethodName: "GetTeacherDashboard",
+			Handler:    _ScheduleService_GetTeacherDashboard_Handler,
+		},
+		{
+			MethodName: "ReportScheduleError",
+			Handler:    _ScheduleService_ReportScheduleError_Handler,
+		},
+		{
+			MethodName: "FindFreeSlots",
+			Handler:    _ScheduleService_FindFreeSlots_Handler,
+		},
+		{
+			MethodName: "GetWorkloadStats",
+			Handler:    _ScheduleService_GetWorkloadStats_Handler,
+		},
+		{
+			MethodName: "GetChangeStats",
+			Handler:    _ScheduleService_GetChangeStats_Handler,
+		},
+		{
+			MethodName: "RunMaintenance",
+			Handler:    _ScheduleService_RunMaintenance_Handler,
+		},
+		{
+			MethodName: "SearchSchedule",
+			Handler:    _ScheduleService_SearchSchedule_Handler,
+		},
+		{
+			MethodName: "CompareSnapshots",
+			Handler:    _ScheduleService_CompareSnapshots_Handler,
+		},
+		{
+			MethodName: "ListSubjectMetadata",
+			Handler:    _ScheduleService_ListSubjectMetadata_Handler,
+		},
+		{
+			MethodName: "UpsertSubjectMetadata",
+			Handler:    _ScheduleService_UpsertSubjectMetadata_Handler,
+		},
+		{
+			MethodName: "DeleteSubjectMetadata",
+			Handler:    _ScheduleService_DeleteSubjectMetadata_Handler,
+		},
+		{
+			MethodName: "ListOverlappingChanges",
+			Handler:    _ScheduleService_ListOverlappingChanges_Handler,
+		},
+		{
+			MethodName: "ListSnapshotChanges",
+			Handler:    _ScheduleService_ListSnapshotChanges_Handler,
+		},
+		{
+			MethodName: "ListChangesAwaitingModeration",
+			Handler:    _ScheduleService_ListChangesAwaitingModeration_Handler,
+		},
+		{
+			MethodName: "ReviewChange",
+			Handler:    _ScheduleService_ReviewChange_Handler,
+		},
+		{
+			MethodName: "SubmitTeacherChangeRequest",
+			Handler:    _ScheduleService_SubmitTeacherChangeRequest_Handler,
+		},
+		{
+			MethodName: "ListMyTeacherChangeRequests",
+			Handler:    _ScheduleService_ListMyTeacherChangeRequests_Handler,
+		},
+		{
+			MethodName: "ListPendingTeacherChangeRequests",
+			Handler:    _ScheduleService_ListPendingTeacherChangeRequests_Handler,
+		},
+		{
+			MethodName: "ReviewTeacherChangeRequest",
+			Handler:    _ScheduleService_ReviewTeacherChangeRequest_Handler,
+		},
+		{
+			MethodName: "ClaimTeacherName",
+			Handler:    _ScheduleService_ClaimTeacherName_Handler,
+		},
+		{
+			MethodName: "ListMyTeacherNameClaims",
+			Handler:    _ScheduleService_ListMyTeacherNameClaims_Handler,
+		},
+		{
+			MethodName: "ListPendingTeacherNameClaims",
+			Handler:    _ScheduleService_ListPendingTeacherNameClaims_Handler,
+		},
+		{
+			MethodName: "ReviewTeacherNameClaim",
+			Handler:    _ScheduleService_ReviewTeacherNameClaim_Handler,
+		},
+		{
+			MethodName: "GetGroupRoster",
+			Handler:    _ScheduleService_GetGroupRoster_Handler,
+		},
+		{
+			MethodName: "ListJobs",
+			Handler:    _ScheduleService_ListJobs_Handler,
+		},
+		{
+			MethodName: "RetryJob",
+			Handler:    _ScheduleService_RetryJob_Handler,
+		},
+		{
+			MethodName: "ListFeatureFlags",
+			Handler:    _ScheduleService_ListFeatureFlags_Handler,
+		},
+		{
+			MethodName: "SetFeatureFlag",
+			Handler:    _ScheduleService_SetFeatureFlag_Handler,
+		},
+		{
+			MethodName: "ResetFeatureFlag",
+			Handler:    _ScheduleService_ResetFeatureFlag_Handler,
+		},
+		{
+			MethodName: "GetCalendarSubscription",
+			Handler:    _ScheduleService_GetCalendarSubscription_Handler,
+		},
+		{
+			MethodName: "ListGroupWebhooks",
+			Handler:    _ScheduleService_ListGroupWebhooks_Handler,
+		},
+		{
+			MethodName: "SetGroupWebhook",
+			Handler:    _ScheduleService_SetGroupWebhook_Handler,
+		},
+		{
+			MethodName: "DeleteGroupWebhook",
+			Handler:    _ScheduleService_DeleteGroupWebhook_Handler,
+		},
+		{
+			MethodName: "GetTimetablePDF",
+			Handler:    _ScheduleService_GetTimetablePDF_Handler,
+		},
+		{
+			MethodName: "ImportTeacherDirectory",
+			Handler:    _ScheduleService_ImportTeacherDirectory_Handler,
+		},
+		{
+			MethodName: "SetLessonMeetingUrl",
+			Handler:    _ScheduleService_SetLessonMeetingUrl_Handler,
+		},
+		{
+			MethodName: "CreateElectiveCourse",
+			Handler:    _ScheduleService_CreateElectiveCourse_Handler,
+		},
+		{
+			MethodName: "CancelElectiveCourse",
+			Handler:    _ScheduleService_CancelElectiveCourse_Handler,
+		},
+		{
+			MethodName: "ListElectiveCourses",
+			Handler:    _ScheduleService_ListElectiveCourses_Handler,
+		},
+		{
+			MethodName: "EnrollElective",
+			Handler:    _ScheduleService_EnrollElective_Handler,
+		},
+		{
+			MethodName: "UnenrollElective",
+			Handler:    _ScheduleService_UnenrollElective_Handler,
+		},
+		{
+			MethodName: "GetTeacherWorkload",
+			Handler:    _ScheduleService_GetTeacherWorkload_Handler,
+		},
+		{
+			MethodName: "SetLessonNote",
+			Handler:    _ScheduleService_SetLessonNote_Handler,
+		},
+		{
+			MethodName: "ListLessonNotes",
+			Handler:    _ScheduleService_ListLessonNotes_Handler,
+		},
+		{
+			MethodName: "DeleteLessonNote",
+			Handler:    _ScheduleService_DeleteLessonNote_Handler,
+		},
+		{
+			MethodName: "ListBuildings",
+			Handler:    _ScheduleService_ListBuildings_Handler,
+		},
+		{
+			MethodName: "SetBuilding",
+			Handler:    _ScheduleService_SetBuilding_Handler,
+		},
+		{
+			MethodName: "DeleteBuilding",
+			Handler:    _ScheduleService_DeleteBuilding_Handler,
+		},
+		{
+			MethodName: "RunAcademicRollover",
+			Handler:    _ScheduleService_RunAcademicRollover_Handler,
+		},
+		{
+			MethodName: "SetConsultationHours",
+			Handler:    _ScheduleService_SetConsultationHours_Handler,
+		},
+		{
+			MethodName: "ListConsultationHours",
+			Handler:    _ScheduleService_ListConsultationHours_Handler,
+		},
+		{
+			MethodName: "SetConsultationReminder",
+			Handler:    _ScheduleService_SetConsultationReminder_Handler,
+		},
+		{
+			MethodName: "PollUpdates",
+			Handler:    _ScheduleService_PollUpdates_Handler,
+		},
+		{
+			MethodName: "CreateKioskDisplay",
+			Handler:    _ScheduleService_CreateKioskDisplay_Handler,
+		},
+		{
+			MethodName: "ListKioskDisplays",
+			Handler:    _ScheduleService_ListKioskDisplays_Handler,
+		},
+		{
+			MethodName: "RevokeKioskDisplay",
+			Handler:    _ScheduleService_RevokeKioskDisplay_Handler,
+		},
 	},
 	Streams:  []grpc.StreamDesc{},
 	Metadata: "schedule.proto",
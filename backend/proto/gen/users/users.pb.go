@@ -76,17 +76,95 @@ func (UserRole) EnumDescriptor() ([]byte, []int) {
 	return file_users_proto_rawDescGZIP(), []int{0}
 }
 
+// Типы событий журнала безопасности
+type AuditEventType int32
+
+const (
+	AuditEventType_AUDIT_EVENT_TYPE_UNSPECIFIED       AuditEventType = 0
+	AuditEventType_AUDIT_EVENT_TYPE_REGISTRATION      AuditEventType = 1
+	AuditEventType_AUDIT_EVENT_TYPE_LOGIN             AuditEventType = 2
+	AuditEventType_AUDIT_EVENT_TYPE_LOGIN_FAILED      AuditEventType = 3
+	AuditEventType_AUDIT_EVENT_TYPE_PASSWORD_CHANGE   AuditEventType = 4
+	AuditEventType_AUDIT_EVENT_TYPE_ROLE_CHANGE       AuditEventType = 5
+	AuditEventType_AUDIT_EVENT_TYPE_TOKEN_REVOCATION  AuditEventType = 6
+	AuditEventType_AUDIT_EVENT_TYPE_TWO_FACTOR_CHANGE AuditEventType = 7
+	AuditEventType_AUDIT_EVENT_TYPE_USER_EXPORT       AuditEventType = 8
+	AuditEventType_AUDIT_EVENT_TYPE_USER_DELETION     AuditEventType = 9
+	AuditEventType_AUDIT_EVENT_TYPE_USER_RESTORE      AuditEventType = 10
+)
+
+// Enum value maps for AuditEventType.
+var (
+	AuditEventType_name = map[int32]string{
+		0:  "AUDIT_EVENT_TYPE_UNSPECIFIED",
+		1:  "AUDIT_EVENT_TYPE_REGISTRATION",
+		2:  "AUDIT_EVENT_TYPE_LOGIN",
+		3:  "AUDIT_EVENT_TYPE_LOGIN_FAILED",
+		4:  "AUDIT_EVENT_TYPE_PASSWORD_CHANGE",
+		5:  "AUDIT_EVENT_TYPE_ROLE_CHANGE",
+		6:  "AUDIT_EVENT_TYPE_TOKEN_REVOCATION",
+		7:  "AUDIT_EVENT_TYPE_TWO_FACTOR_CHANGE",
+		8:  "AUDIT_EVENT_TYPE_USER_EXPORT",
+		9:  "AUDIT_EVENT_TYPE_USER_DELETION",
+		10: "AUDIT_EVENT_TYPE_USER_RESTORE",
+	}
+	AuditEventType_value = map[string]int32{
+		"AUDIT_EVENT_TYPE_UNSPECIFIED":       0,
+		"AUDIT_EVENT_TYPE_REGISTRATION":      1,
+		"AUDIT_EVENT_TYPE_LOGIN":             2,
+		"AUDIT_EVENT_TYPE_LOGIN_FAILED":      3,
+		"AUDIT_EVENT_TYPE_PASSWORD_CHANGE":   4,
+		"AUDIT_EVENT_TYPE_ROLE_CHANGE":       5,
+		"AUDIT_EVENT_TYPE_TOKEN_REVOCATION":  6,
+		"AUDIT_EVENT_TYPE_TWO_FACTOR_CHANGE": 7,
+		"AUDIT_EVENT_TYPE_USER_EXPORT":       8,
+		"AUDIT_EVENT_TYPE_USER_DELETION":     9,
+		"AUDIT_EVENT_TYPE_USER_RESTORE":      10,
+	}
+)
+
+func (x AuditEventType) Enum() *AuditEventType {
+	p := new(AuditEventType)
+	*p = x
+	return p
+}
+
+func (x AuditEventType) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (AuditEventType) Descriptor() protoreflect.EnumDescriptor {
+	return file_users_proto_enumTypes[1].Descriptor()
+}
+
+func (AuditEventType) Type() protoreflect.EnumType {
+	return &file_users_proto_enumTypes[1]
+}
+
+func (x AuditEventType) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use AuditEventType.Descriptor instead.
+func (AuditEventType) EnumDescriptor() ([]byte, []int) {
+	return file_users_proto_rawDescGZIP(), []int{1}
+}
+
 // Запрос на регистрацию студента
 type RegisterStudentRequest struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Email         string                 `protobuf:"bytes,1,opt,name=email,proto3" json:"email,omitempty"`
-	Password      string                 `protobuf:"bytes,2,opt,name=password,proto3" json:"password,omitempty"`
-	GroupName     string                 `protobuf:"bytes,3,opt,name=group_name,json=groupName,proto3" json:"group_name,omitempty"`
-	Faculty       string                 `protobuf:"bytes,4,opt,name=faculty,proto3" json:"faculty,omitempty"`
-	Course        int32                  `protobuf:"varint,5,opt,name=course,proto3" json:"course,omitempty"`
-	StudentNumber string                 `protobuf:"bytes,6,opt,name=student_number,json=studentNumber,proto3" json:"student_number,omitempty"`
-	unknownFields protoimpl.UnknownFields
-	sizeCache     protoimpl.SizeCache
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	Email          string                 `protobuf:"bytes,1,opt,name=email,proto3" json:"email,omitempty"`
+	Password       string                 `protobuf:"bytes,2,opt,name=password,proto3" json:"password,omitempty"`
+	GroupName      string                 `protobuf:"bytes,3,opt,name=group_name,json=groupName,proto3" json:"group_name,omitempty"`
+	Faculty        string                 `protobuf:"bytes,4,opt,name=faculty,proto3" json:"faculty,omitempty"`
+	Course         int32                  `protobuf:"varint,5,opt,name=course,proto3" json:"course,omitempty"`
+	StudentNumber  string                 `protobuf:"bytes,6,opt,name=student_number,json=studentNumber,proto3" json:"student_number,omitempty"`
+	Captcha        string                 `protobuf:"bytes,7,opt,name=captcha,proto3" json:"captcha,omitempty"`                                     // Ответ на CAPTCHA, если проверка включена (см. GetCaptchaChallenge)
+	InvitationCode string                 `protobuf:"bytes,8,opt,name=invitation_code,json=invitationCode,proto3" json:"invitation_code,omitempty"` // Код приглашения; группу можно не указывать, если она задана приглашением
+	FullName       string                 `protobuf:"bytes,9,opt,name=full_name,json=fullName,proto3" json:"full_name,omitempty"`
+	Subgroup       int32                  `protobuf:"varint,10,opt,name=subgroup,proto3" json:"subgroup,omitempty"` // Подгруппа (0 - не выбрана)
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
 }
 
 func (x *RegisterStudentRequest) Reset() {
@@ -161,17 +239,47 @@ func (x *RegisterStudentRequest) GetStudentNumber() string {
 	return ""
 }
 
+func (x *RegisterStudentRequest) GetCaptcha() string {
+	if x != nil {
+		return x.Captcha
+	}
+	return ""
+}
+
+func (x *RegisterStudentRequest) GetInvitationCode() string {
+	if x != nil {
+		return x.InvitationCode
+	}
+	return ""
+}
+
+func (x *RegisterStudentRequest) GetFullName() string {
+	if x != nil {
+		return x.FullName
+	}
+	return ""
+}
+
+func (x *RegisterStudentRequest) GetSubgroup() int32 {
+	if x != nil {
+		return x.Subgroup
+	}
+	return 0
+}
+
 // Запрос на регистрацию преподавателя
 type RegisterTeacherRequest struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Email         string                 `protobuf:"bytes,1,opt,name=email,proto3" json:"email,omitempty"`
-	Password      string                 `protobuf:"bytes,2,opt,name=password,proto3" json:"password,omitempty"`
-	FullName      string                 `protobuf:"bytes,3,opt,name=full_name,json=fullName,proto3" json:"full_name,omitempty"`
-	Department    string                 `protobuf:"bytes,4,opt,name=department,proto3" json:"department,omitempty"`
-	Position      string                 `protobuf:"bytes,5,opt,name=position,proto3" json:"position,omitempty"`
-	TeacherId     string                 `protobuf:"bytes,6,opt,name=teacher_id,json=teacherId,proto3" json:"teacher_id,omitempty"`
-	unknownFields protoimpl.UnknownFields
-	sizeCache     protoimpl.SizeCache
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	Email          string                 `protobuf:"bytes,1,opt,name=email,proto3" json:"email,omitempty"`
+	Password       string                 `protobuf:"bytes,2,opt,name=password,proto3" json:"password,omitempty"`
+	FullName       string                 `protobuf:"bytes,3,opt,name=full_name,json=fullName,proto3" json:"full_name,omitempty"`
+	Department     string                 `protobuf:"bytes,4,opt,name=department,proto3" json:"department,omitempty"`
+	Position       string                 `protobuf:"bytes,5,opt,name=position,proto3" json:"position,omitempty"`
+	TeacherId      string                 `protobuf:"bytes,6,opt,name=teacher_id,json=teacherId,proto3" json:"teacher_id,omitempty"`
+	Captcha        string                 `protobuf:"bytes,7,opt,name=captcha,proto3" json:"captcha,omitempty"`                                     // Ответ на CAPTCHA, если проверка включена (см. GetCaptchaChallenge)
+	InvitationCode string                 `protobuf:"bytes,8,opt,name=invitation_code,json=invitationCode,proto3" json:"invitation_code,omitempty"` // Код приглашения, если регистрация по приглашениям
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
 }
 
 func (x *RegisterTeacherRequest) Reset() {
@@ -246,6 +354,20 @@ func (x *RegisterTeacherRequest) GetTeacherId() string {
 	return ""
 }
 
+func (x *RegisterTeacherRequest) GetCaptcha() string {
+	if x != nil {
+		return x.Captcha
+	}
+	return ""
+}
+
+func (x *RegisterTeacherRequest) GetInvitationCode() string {
+	if x != nil {
+		return x.InvitationCode
+	}
+	return ""
+}
+
 // Ответ на регистрацию
 type RegisterResponse struct {
 	state   protoimpl.MessageState `protogen:"open.v1"`
@@ -358,6 +480,7 @@ type LoginRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Email         string                 `protobuf:"bytes,1,opt,name=email,proto3" json:"email,omitempty"`
 	Password      string                 `protobuf:"bytes,2,opt,name=password,proto3" json:"password,omitempty"`
+	Captcha       string                 `protobuf:"bytes,3,opt,name=captcha,proto3" json:"captcha,omitempty"` // Ответ на CAPTCHA, если проверка включена (см. GetCaptchaChallenge)
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -406,15 +529,27 @@ func (x *LoginRequest) GetPassword() string {
 	return ""
 }
 
+func (x *LoginRequest) GetCaptcha() string {
+	if x != nil {
+		return x.Captcha
+	}
+	return ""
+}
+
 // Ответ на вход
 type LoginResponse struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Success       bool                   `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
-	Message       string                 `protobuf:"bytes,2,opt,name=message,proto3" json:"message,omitempty"`
-	Token         string                 `protobuf:"bytes,3,opt,name=token,proto3" json:"token,omitempty"`
-	User          *User                  `protobuf:"bytes,4,opt,name=user,proto3" json:"user,omitempty"`
-	unknownFields protoimpl.UnknownFields
-	sizeCache     protoimpl.SizeCache
+	state   protoimpl.MessageState `protogen:"open.v1"`
+	Success bool                   `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
+	Message string                 `protobuf:"bytes,2,opt,name=message,proto3" json:"message,omitempty"`
+	Token   string                 `protobuf:"bytes,3,opt,name=token,proto3" json:"token,omitempty"`
+	User    *User                  `protobuf:"bytes,4,opt,name=user,proto3" json:"user,omitempty"`
+	// Если у пользователя включена 2FA, token и user не заполняются: вход завершается
+	// вызовом VerifyTwoFactor с two_factor_token и кодом из приложения
+	TwoFactorRequired  bool   `protobuf:"varint,5,opt,name=two_factor_required,json=twoFactorRequired,proto3" json:"two_factor_required,omitempty"`
+	TwoFactorToken     string `protobuf:"bytes,6,opt,name=two_factor_token,json=twoFactorToken,proto3" json:"two_factor_token,omitempty"`
+	TwoFactorExpiresAt string `protobuf:"bytes,7,opt,name=two_factor_expires_at,json=twoFactorExpiresAt,proto3" json:"two_factor_expires_at,omitempty"` // RFC3339
+	unknownFields      protoimpl.UnknownFields
+	sizeCache          protoimpl.SizeCache
 }
 
 func (x *LoginResponse) Reset() {
@@ -475,28 +610,49 @@ func (x *LoginResponse) GetUser() *User {
 	return nil
 }
 
-// Запрос на получение профиля
-type GetProfileRequest struct {
+func (x *LoginResponse) GetTwoFactorRequired() bool {
+	if x != nil {
+		return x.TwoFactorRequired
+	}
+	return false
+}
+
+func (x *LoginResponse) GetTwoFactorToken() string {
+	if x != nil {
+		return x.TwoFactorToken
+	}
+	return ""
+}
+
+func (x *LoginResponse) GetTwoFactorExpiresAt() string {
+	if x != nil {
+		return x.TwoFactorExpiresAt
+	}
+	return ""
+}
+
+// Запрос на гостевой токен
+type IssueGuestTokenRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Token         string                 `protobuf:"bytes,1,opt,name=token,proto3" json:"token,omitempty"`
+	GroupName     string                 `protobuf:"bytes,1,opt,name=group_name,json=groupName,proto3" json:"group_name,omitempty"` // Группа, расписание которой будет доступно
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *GetProfileRequest) Reset() {
-	*x = GetProfileRequest{}
+func (x *IssueGuestTokenRequest) Reset() {
+	*x = IssueGuestTokenRequest{}
 	mi := &file_users_proto_msgTypes[5]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *GetProfileRequest) String() string {
+func (x *IssueGuestTokenRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*GetProfileRequest) ProtoMessage() {}
+func (*IssueGuestTokenRequest) ProtoMessage() {}
 
-func (x *GetProfileRequest) ProtoReflect() protoreflect.Message {
+func (x *IssueGuestTokenRequest) ProtoReflect() protoreflect.Message {
 	mi := &file_users_proto_msgTypes[5]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -508,47 +664,44 @@ func (x *GetProfileRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use GetProfileRequest.ProtoReflect.Descriptor instead.
-func (*GetProfileRequest) Descriptor() ([]byte, []int) {
+// Deprecated: Use IssueGuestTokenRequest.ProtoReflect.Descriptor instead.
+func (*IssueGuestTokenRequest) Descriptor() ([]byte, []int) {
 	return file_users_proto_rawDescGZIP(), []int{5}
 }
 
-func (x *GetProfileRequest) GetToken() string {
+func (x *IssueGuestTokenRequest) GetGroupName() string {
 	if x != nil {
-		return x.Token
+		return x.GroupName
 	}
 	return ""
 }
 
-// Ответ на получение профиля
-type GetProfileResponse struct {
-	state   protoimpl.MessageState `protogen:"open.v1"`
-	Success bool                   `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
-	Message string                 `protobuf:"bytes,2,opt,name=message,proto3" json:"message,omitempty"`
-	User    *User                  `protobuf:"bytes,3,opt,name=user,proto3" json:"user,omitempty"`
-	// Types that are valid to be assigned to Profile:
-	//
-	//	*GetProfileResponse_StudentProfile
-	//	*GetProfileResponse_TeacherProfile
-	Profile       isGetProfileResponse_Profile `protobuf_oneof:"profile"`
+// Ответ на гостевой токен
+type IssueGuestTokenResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Success       bool                   `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
+	Message       string                 `protobuf:"bytes,2,opt,name=message,proto3" json:"message,omitempty"`
+	Token         string                 `protobuf:"bytes,3,opt,name=token,proto3" json:"token,omitempty"`
+	GroupName     string                 `protobuf:"bytes,4,opt,name=group_name,json=groupName,proto3" json:"group_name,omitempty"`
+	ExpiresAt     string                 `protobuf:"bytes,5,opt,name=expires_at,json=expiresAt,proto3" json:"expires_at,omitempty"` // RFC3339
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *GetProfileResponse) Reset() {
-	*x = GetProfileResponse{}
+func (x *IssueGuestTokenResponse) Reset() {
+	*x = IssueGuestTokenResponse{}
 	mi := &file_users_proto_msgTypes[6]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *GetProfileResponse) String() string {
+func (x *IssueGuestTokenResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*GetProfileResponse) ProtoMessage() {}
+func (*IssueGuestTokenResponse) ProtoMessage() {}
 
-func (x *GetProfileResponse) ProtoReflect() protoreflect.Message {
+func (x *IssueGuestTokenResponse) ProtoReflect() protoreflect.Message {
 	mi := &file_users_proto_msgTypes[6]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -560,100 +713,115 @@ func (x *GetProfileResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use GetProfileResponse.ProtoReflect.Descriptor instead.
-func (*GetProfileResponse) Descriptor() ([]byte, []int) {
+// Deprecated: Use IssueGuestTokenResponse.ProtoReflect.Descriptor instead.
+func (*IssueGuestTokenResponse) Descriptor() ([]byte, []int) {
 	return file_users_proto_rawDescGZIP(), []int{6}
 }
 
-func (x *GetProfileResponse) GetSuccess() bool {
+func (x *IssueGuestTokenResponse) GetSuccess() bool {
 	if x != nil {
 		return x.Success
 	}
 	return false
 }
 
-func (x *GetProfileResponse) GetMessage() string {
+func (x *IssueGuestTokenResponse) GetMessage() string {
 	if x != nil {
 		return x.Message
 	}
 	return ""
 }
 
-func (x *GetProfileResponse) GetUser() *User {
+func (x *IssueGuestTokenResponse) GetToken() string {
 	if x != nil {
-		return x.User
+		return x.Token
 	}
-	return nil
+	return ""
 }
 
-func (x *GetProfileResponse) GetProfile() isGetProfileResponse_Profile {
+func (x *IssueGuestTokenResponse) GetGroupName() string {
 	if x != nil {
-		return x.Profile
+		return x.GroupName
 	}
-	return nil
+	return ""
 }
 
-func (x *GetProfileResponse) GetStudentProfile() *StudentProfile {
+func (x *IssueGuestTokenResponse) GetExpiresAt() string {
 	if x != nil {
-		if x, ok := x.Profile.(*GetProfileResponse_StudentProfile); ok {
-			return x.StudentProfile
-		}
+		return x.ExpiresAt
 	}
-	return nil
+	return ""
 }
 
-func (x *GetProfileResponse) GetTeacherProfile() *TeacherProfile {
-	if x != nil {
-		if x, ok := x.Profile.(*GetProfileResponse_TeacherProfile); ok {
-			return x.TeacherProfile
-		}
-	}
-	return nil
+// Запрос параметров CAPTCHA
+type GetCaptchaChallengeRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
 }
 
-type isGetProfileResponse_Profile interface {
-	isGetProfileResponse_Profile()
+func (x *GetCaptchaChallengeRequest) Reset() {
+	*x = GetCaptchaChallengeRequest{}
+	mi := &file_users_proto_msgTypes[7]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
 }
 
-type GetProfileResponse_StudentProfile struct {
-	StudentProfile *StudentProfile `protobuf:"bytes,4,opt,name=student_profile,json=studentProfile,proto3,oneof"`
+func (x *GetCaptchaChallengeRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
 }
 
-type GetProfileResponse_TeacherProfile struct {
-	TeacherProfile *TeacherProfile `protobuf:"bytes,5,opt,name=teacher_profile,json=teacherProfile,proto3,oneof"`
-}
+func (*GetCaptchaChallengeRequest) ProtoMessage() {}
 
-func (*GetProfileResponse_StudentProfile) isGetProfileResponse_Profile() {}
+func (x *GetCaptchaChallengeRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_users_proto_msgTypes[7]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
 
-func (*GetProfileResponse_TeacherProfile) isGetProfileResponse_Profile() {}
+// Deprecated: Use GetCaptchaChallengeRequest.ProtoReflect.Descriptor instead.
+func (*GetCaptchaChallengeRequest) Descriptor() ([]byte, []int) {
+	return file_users_proto_rawDescGZIP(), []int{7}
+}
 
-// Информация о пользователе
-type User struct {
+// Параметры CAPTCHA.
+// Для hcaptcha/turnstile клиент показывает виджет с site_key и передает полученный токен в поле captcha.
+// Для proof_of_work клиент подбирает nonce, при котором SHA-256 от "challenge:nonce"
+// начинается с difficulty нулевых бит, и передает в поле captcha строку "challenge:nonce".
+type GetCaptchaChallengeResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
-	Email         string                 `protobuf:"bytes,2,opt,name=email,proto3" json:"email,omitempty"`
-	Role          UserRole               `protobuf:"varint,3,opt,name=role,proto3,enum=users.UserRole" json:"role,omitempty"`
-	CreatedAt     string                 `protobuf:"bytes,4,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
-	IsActive      bool                   `protobuf:"varint,5,opt,name=is_active,json=isActive,proto3" json:"is_active,omitempty"`
+	Success       bool                   `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
+	Message       string                 `protobuf:"bytes,2,opt,name=message,proto3" json:"message,omitempty"`
+	Provider      string                 `protobuf:"bytes,3,opt,name=provider,proto3" json:"provider,omitempty"` // Пусто, если проверка отключена
+	SiteKey       string                 `protobuf:"bytes,4,opt,name=site_key,json=siteKey,proto3" json:"site_key,omitempty"`
+	Challenge     string                 `protobuf:"bytes,5,opt,name=challenge,proto3" json:"challenge,omitempty"`
+	Difficulty    int32                  `protobuf:"varint,6,opt,name=difficulty,proto3" json:"difficulty,omitempty"`
+	ExpiresAt     string                 `protobuf:"bytes,7,opt,name=expires_at,json=expiresAt,proto3" json:"expires_at,omitempty"` // RFC3339
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *User) Reset() {
-	*x = User{}
-	mi := &file_users_proto_msgTypes[7]
+func (x *GetCaptchaChallengeResponse) Reset() {
+	*x = GetCaptchaChallengeResponse{}
+	mi := &file_users_proto_msgTypes[8]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *User) String() string {
+func (x *GetCaptchaChallengeResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*User) ProtoMessage() {}
+func (*GetCaptchaChallengeResponse) ProtoMessage() {}
 
-func (x *User) ProtoReflect() protoreflect.Message {
-	mi := &file_users_proto_msgTypes[7]
+func (x *GetCaptchaChallengeResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_users_proto_msgTypes[8]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -664,73 +832,84 @@ func (x *User) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use User.ProtoReflect.Descriptor instead.
-func (*User) Descriptor() ([]byte, []int) {
-	return file_users_proto_rawDescGZIP(), []int{7}
+// Deprecated: Use GetCaptchaChallengeResponse.ProtoReflect.Descriptor instead.
+func (*GetCaptchaChallengeResponse) Descriptor() ([]byte, []int) {
+	return file_users_proto_rawDescGZIP(), []int{8}
 }
 
-func (x *User) GetId() string {
+func (x *GetCaptchaChallengeResponse) GetSuccess() bool {
 	if x != nil {
-		return x.Id
+		return x.Success
+	}
+	return false
+}
+
+func (x *GetCaptchaChallengeResponse) GetMessage() string {
+	if x != nil {
+		return x.Message
 	}
 	return ""
 }
 
-func (x *User) GetEmail() string {
+func (x *GetCaptchaChallengeResponse) GetProvider() string {
 	if x != nil {
-		return x.Email
+		return x.Provider
 	}
 	return ""
 }
 
-func (x *User) GetRole() UserRole {
+func (x *GetCaptchaChallengeResponse) GetSiteKey() string {
 	if x != nil {
-		return x.Role
+		return x.SiteKey
 	}
-	return UserRole_ROLE_UNSPECIFIED
+	return ""
 }
 
-func (x *User) GetCreatedAt() string {
+func (x *GetCaptchaChallengeResponse) GetChallenge() string {
 	if x != nil {
-		return x.CreatedAt
+		return x.Challenge
 	}
 	return ""
 }
 
-func (x *User) GetIsActive() bool {
+func (x *GetCaptchaChallengeResponse) GetDifficulty() int32 {
 	if x != nil {
-		return x.IsActive
+		return x.Difficulty
 	}
-	return false
+	return 0
 }
 
-// Профиль студента
-type StudentProfile struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	UserId        string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
-	GroupName     string                 `protobuf:"bytes,2,opt,name=group_name,json=groupName,proto3" json:"group_name,omitempty"`
-	Faculty       string                 `protobuf:"bytes,3,opt,name=faculty,proto3" json:"faculty,omitempty"`
-	Course        int32                  `protobuf:"varint,4,opt,name=course,proto3" json:"course,omitempty"`
-	StudentNumber string                 `protobuf:"bytes,5,opt,name=student_number,json=studentNumber,proto3" json:"student_number,omitempty"`
-	unknownFields protoimpl.UnknownFields
-	sizeCache     protoimpl.SizeCache
+func (x *GetCaptchaChallengeResponse) GetExpiresAt() string {
+	if x != nil {
+		return x.ExpiresAt
+	}
+	return ""
 }
 
-func (x *StudentProfile) Reset() {
-	*x = StudentProfile{}
-	mi := &file_users_proto_msgTypes[8]
+// Запрос второго шага входа
+type VerifyTwoFactorRequest struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	TwoFactorToken string                 `protobuf:"bytes,1,opt,name=two_factor_token,json=twoFactorToken,proto3" json:"two_factor_token,omitempty"` // Токен из ответа Login
+	Code           string                 `protobuf:"bytes,2,opt,name=code,proto3" json:"code,omitempty"`                                             // Код из приложения или код восстановления
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *VerifyTwoFactorRequest) Reset() {
+	*x = VerifyTwoFactorRequest{}
+	mi := &file_users_proto_msgTypes[9]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *StudentProfile) String() string {
+func (x *VerifyTwoFactorRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*StudentProfile) ProtoMessage() {}
+func (*VerifyTwoFactorRequest) ProtoMessage() {}
 
-func (x *StudentProfile) ProtoReflect() protoreflect.Message {
-	mi := &file_users_proto_msgTypes[8]
+func (x *VerifyTwoFactorRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_users_proto_msgTypes[9]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -741,19 +920,2544 @@ func (x *StudentProfile) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use StudentProfile.ProtoReflect.Descriptor instead.
-func (*StudentProfile) Descriptor() ([]byte, []int) {
-	return file_users_proto_rawDescGZIP(), []int{8}
+// Deprecated: Use VerifyTwoFactorRequest.ProtoReflect.Descriptor instead.
+func (*VerifyTwoFactorRequest) Descriptor() ([]byte, []int) {
+	return file_users_proto_rawDescGZIP(), []int{9}
 }
 
-func (x *StudentProfile) GetUserId() string {
+func (x *VerifyTwoFactorRequest) GetTwoFactorToken() string {
 	if x != nil {
-		return x.UserId
+		return x.TwoFactorToken
 	}
 	return ""
 }
 
-func (x *StudentProfile) GetGroupName() string {
+func (x *VerifyTwoFactorRequest) GetCode() string {
+	if x != nil {
+		return x.Code
+	}
+	return ""
+}
+
+// Запрос на подключение 2FA
+type EnrollTwoFactorRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Token         string                 `protobuf:"bytes,1,opt,name=token,proto3" json:"token,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *EnrollTwoFactorRequest) Reset() {
+	*x = EnrollTwoFactorRequest{}
+	mi := &file_users_proto_msgTypes[10]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *EnrollTwoFactorRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*EnrollTwoFactorRequest) ProtoMessage() {}
+
+func (x *EnrollTwoFactorRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_users_proto_msgTypes[10]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use EnrollTwoFactorRequest.ProtoReflect.Descriptor instead.
+func (*EnrollTwoFactorRequest) Descriptor() ([]byte, []int) {
+	return file_users_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *EnrollTwoFactorRequest) GetToken() string {
+	if x != nil {
+		return x.Token
+	}
+	return ""
+}
+
+// Ответ на подключение 2FA
+type EnrollTwoFactorResponse struct {
+	state           protoimpl.MessageState `protogen:"open.v1"`
+	Success         bool                   `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
+	Message         string                 `protobuf:"bytes,2,opt,name=message,proto3" json:"message,omitempty"`
+	Secret          string                 `protobuf:"bytes,3,opt,name=secret,proto3" json:"secret,omitempty"`                                          // Секрет в base32 для ручного ввода
+	ProvisioningUri string                 `protobuf:"bytes,4,opt,name=provisioning_uri,json=provisioningUri,proto3" json:"provisioning_uri,omitempty"` // otpauth:// URI для QR-кода
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *EnrollTwoFactorResponse) Reset() {
+	*x = EnrollTwoFactorResponse{}
+	mi := &file_users_proto_msgTypes[11]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *EnrollTwoFactorResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*EnrollTwoFactorResponse) ProtoMessage() {}
+
+func (x *EnrollTwoFactorResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_users_proto_msgTypes[11]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use EnrollTwoFactorResponse.ProtoReflect.Descriptor instead.
+func (*EnrollTwoFactorResponse) Descriptor() ([]byte, []int) {
+	return file_users_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *EnrollTwoFactorResponse) GetSuccess() bool {
+	if x != nil {
+		return x.Success
+	}
+	return false
+}
+
+func (x *EnrollTwoFactorResponse) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+func (x *EnrollTwoFactorResponse) GetSecret() string {
+	if x != nil {
+		return x.Secret
+	}
+	return ""
+}
+
+func (x *EnrollTwoFactorResponse) GetProvisioningUri() string {
+	if x != nil {
+		return x.ProvisioningUri
+	}
+	return ""
+}
+
+// Запрос на подтверждение подключения 2FA
+type ConfirmTwoFactorRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Token         string                 `protobuf:"bytes,1,opt,name=token,proto3" json:"token,omitempty"`
+	Code          string                 `protobuf:"bytes,2,opt,name=code,proto3" json:"code,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ConfirmTwoFactorRequest) Reset() {
+	*x = ConfirmTwoFactorRequest{}
+	mi := &file_users_proto_msgTypes[12]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ConfirmTwoFactorRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ConfirmTwoFactorRequest) ProtoMessage() {}
+
+func (x *ConfirmTwoFactorRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_users_proto_msgTypes[12]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ConfirmTwoFactorRequest.ProtoReflect.Descriptor instead.
+func (*ConfirmTwoFactorRequest) Descriptor() ([]byte, []int) {
+	return file_users_proto_rawDescGZIP(), []int{12}
+}
+
+func (x *ConfirmTwoFactorRequest) GetToken() string {
+	if x != nil {
+		return x.Token
+	}
+	return ""
+}
+
+func (x *ConfirmTwoFactorRequest) GetCode() string {
+	if x != nil {
+		return x.Code
+	}
+	return ""
+}
+
+// Ответ на подтверждение подключения 2FA
+type ConfirmTwoFactorResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Success       bool                   `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
+	Message       string                 `protobuf:"bytes,2,opt,name=message,proto3" json:"message,omitempty"`
+	RecoveryCodes []string               `protobuf:"bytes,3,rep,name=recovery_codes,json=recoveryCodes,proto3" json:"recovery_codes,omitempty"` // Показываются один раз
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ConfirmTwoFactorResponse) Reset() {
+	*x = ConfirmTwoFactorResponse{}
+	mi := &file_users_proto_msgTypes[13]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ConfirmTwoFactorResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ConfirmTwoFactorResponse) ProtoMessage() {}
+
+func (x *ConfirmTwoFactorResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_users_proto_msgTypes[13]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ConfirmTwoFactorResponse.ProtoReflect.Descriptor instead.
+func (*ConfirmTwoFactorResponse) Descriptor() ([]byte, []int) {
+	return file_users_proto_rawDescGZIP(), []int{13}
+}
+
+func (x *ConfirmTwoFactorResponse) GetSuccess() bool {
+	if x != nil {
+		return x.Success
+	}
+	return false
+}
+
+func (x *ConfirmTwoFactorResponse) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+func (x *ConfirmTwoFactorResponse) GetRecoveryCodes() []string {
+	if x != nil {
+		return x.RecoveryCodes
+	}
+	return nil
+}
+
+// Запрос на отключение 2FA
+type DisableTwoFactorRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Token         string                 `protobuf:"bytes,1,opt,name=token,proto3" json:"token,omitempty"`
+	Code          string                 `protobuf:"bytes,2,opt,name=code,proto3" json:"code,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DisableTwoFactorRequest) Reset() {
+	*x = DisableTwoFactorRequest{}
+	mi := &file_users_proto_msgTypes[14]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DisableTwoFactorRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DisableTwoFactorRequest) ProtoMessage() {}
+
+func (x *DisableTwoFactorRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_users_proto_msgTypes[14]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DisableTwoFactorRequest.ProtoReflect.Descriptor instead.
+func (*DisableTwoFactorRequest) Descriptor() ([]byte, []int) {
+	return file_users_proto_rawDescGZIP(), []int{14}
+}
+
+func (x *DisableTwoFactorRequest) GetToken() string {
+	if x != nil {
+		return x.Token
+	}
+	return ""
+}
+
+func (x *DisableTwoFactorRequest) GetCode() string {
+	if x != nil {
+		return x.Code
+	}
+	return ""
+}
+
+// Ответ на отключение 2FA
+type DisableTwoFactorResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Success       bool                   `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
+	Message       string                 `protobuf:"bytes,2,opt,name=message,proto3" json:"message,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DisableTwoFactorResponse) Reset() {
+	*x = DisableTwoFactorResponse{}
+	mi := &file_users_proto_msgTypes[15]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DisableTwoFactorResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DisableTwoFactorResponse) ProtoMessage() {}
+
+func (x *DisableTwoFactorResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_users_proto_msgTypes[15]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DisableTwoFactorResponse.ProtoReflect.Descriptor instead.
+func (*DisableTwoFactorResponse) Descriptor() ([]byte, []int) {
+	return file_users_proto_rawDescGZIP(), []int{15}
+}
+
+func (x *DisableTwoFactorResponse) GetSuccess() bool {
+	if x != nil {
+		return x.Success
+	}
+	return false
+}
+
+func (x *DisableTwoFactorResponse) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+// Запрос на смену пароля
+type ChangePasswordRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Token         string                 `protobuf:"bytes,1,opt,name=token,proto3" json:"token,omitempty"`
+	OldPassword   string                 `protobuf:"bytes,2,opt,name=old_password,json=oldPassword,proto3" json:"old_password,omitempty"`
+	NewPassword   string                 `protobuf:"bytes,3,opt,name=new_password,json=newPassword,proto3" json:"new_password,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ChangePasswordRequest) Reset() {
+	*x = ChangePasswordRequest{}
+	mi := &file_users_proto_msgTypes[16]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ChangePasswordRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ChangePasswordRequest) ProtoMessage() {}
+
+func (x *ChangePasswordRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_users_proto_msgTypes[16]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ChangePasswordRequest.ProtoReflect.Descriptor instead.
+func (*ChangePasswordRequest) Descriptor() ([]byte, []int) {
+	return file_users_proto_rawDescGZIP(), []int{16}
+}
+
+func (x *ChangePasswordRequest) GetToken() string {
+	if x != nil {
+		return x.Token
+	}
+	return ""
+}
+
+func (x *ChangePasswordRequest) GetOldPassword() string {
+	if x != nil {
+		return x.OldPassword
+	}
+	return ""
+}
+
+func (x *ChangePasswordRequest) GetNewPassword() string {
+	if x != nil {
+		return x.NewPassword
+	}
+	return ""
+}
+
+// Ответ на смену пароля
+type ChangePasswordResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Success       bool                   `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
+	Message       string                 `protobuf:"bytes,2,opt,name=message,proto3" json:"message,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ChangePasswordResponse) Reset() {
+	*x = ChangePasswordResponse{}
+	mi := &file_users_proto_msgTypes[17]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ChangePasswordResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ChangePasswordResponse) ProtoMessage() {}
+
+func (x *ChangePasswordResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_users_proto_msgTypes[17]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ChangePasswordResponse.ProtoReflect.Descriptor instead.
+func (*ChangePasswordResponse) Descriptor() ([]byte, []int) {
+	return file_users_proto_rawDescGZIP(), []int{17}
+}
+
+func (x *ChangePasswordResponse) GetSuccess() bool {
+	if x != nil {
+		return x.Success
+	}
+	return false
+}
+
+func (x *ChangePasswordResponse) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+// Запрос настройки уведомлений о входе с нового устройства
+type SetLoginAlertsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Token         string                 `protobuf:"bytes,1,opt,name=token,proto3" json:"token,omitempty"`
+	Enabled       bool                   `protobuf:"varint,2,opt,name=enabled,proto3" json:"enabled,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SetLoginAlertsRequest) Reset() {
+	*x = SetLoginAlertsRequest{}
+	mi := &file_users_proto_msgTypes[18]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SetLoginAlertsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SetLoginAlertsRequest) ProtoMessage() {}
+
+func (x *SetLoginAlertsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_users_proto_msgTypes[18]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SetLoginAlertsRequest.ProtoReflect.Descriptor instead.
+func (*SetLoginAlertsRequest) Descriptor() ([]byte, []int) {
+	return file_users_proto_rawDescGZIP(), []int{18}
+}
+
+func (x *SetLoginAlertsRequest) GetToken() string {
+	if x != nil {
+		return x.Token
+	}
+	return ""
+}
+
+func (x *SetLoginAlertsRequest) GetEnabled() bool {
+	if x != nil {
+		return x.Enabled
+	}
+	return false
+}
+
+// Ответ на настройку уведомлений о входе
+type SetLoginAlertsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Success       bool                   `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
+	Message       string                 `protobuf:"bytes,2,opt,name=message,proto3" json:"message,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SetLoginAlertsResponse) Reset() {
+	*x = SetLoginAlertsResponse{}
+	mi := &file_users_proto_msgTypes[19]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SetLoginAlertsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SetLoginAlertsResponse) ProtoMessage() {}
+
+func (x *SetLoginAlertsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_users_proto_msgTypes[19]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SetLoginAlertsResponse.ProtoReflect.Descriptor instead.
+func (*SetLoginAlertsResponse) Descriptor() ([]byte, []int) {
+	return file_users_proto_rawDescGZIP(), []int{19}
+}
+
+func (x *SetLoginAlertsResponse) GetSuccess() bool {
+	if x != nil {
+		return x.Success
+	}
+	return false
+}
+
+func (x *SetLoginAlertsResponse) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+// Запрос выбора языка и форматов даты и времени
+type SetFormatPreferencesRequest struct {
+	state      protoimpl.MessageState `protogen:"open.v1"`
+	Token      string                 `protobuf:"bytes,1,opt,name=token,proto3" json:"token,omitempty"`
+	Locale     string                 `protobuf:"bytes,2,opt,name=locale,proto3" json:"locale,omitempty"`                           // ru или en; пусто - ru
+	DateFormat string                 `protobuf:"bytes,3,opt,name=date_format,json=dateFormat,proto3" json:"date_format,omitempty"` // dd.mm.yyyy, dd/mm/yyyy, mm/dd/yyyy, yyyy-mm-dd; пусто - по языку
+	TimeFormat string                 `protobuf:"bytes,4,opt,name=time_format,json=timeFormat,proto3" json:"time_format,omitempty"` // 24h или 12h; пусто - по языку
+	// Часовой пояс IANA ("Europe/Moscow"); пусто - часовой пояс колледжа.
+	// В нем соблюдаются тихие часы push-уведомлений.
+	Timezone      string `protobuf:"bytes,5,opt,name=timezone,proto3" json:"timezone,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SetFormatPreferencesRequest) Reset() {
+	*x = SetFormatPreferencesRequest{}
+	mi := &file_users_proto_msgTypes[20]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SetFormatPreferencesRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SetFormatPreferencesRequest) ProtoMessage() {}
+
+func (x *SetFormatPreferencesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_users_proto_msgTypes[20]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SetFormatPreferencesRequest.ProtoReflect.Descriptor instead.
+func (*SetFormatPreferencesRequest) Descriptor() ([]byte, []int) {
+	return file_users_proto_rawDescGZIP(), []int{20}
+}
+
+func (x *SetFormatPreferencesRequest) GetToken() string {
+	if x != nil {
+		return x.Token
+	}
+	return ""
+}
+
+func (x *SetFormatPreferencesRequest) GetLocale() string {
+	if x != nil {
+		return x.Locale
+	}
+	return ""
+}
+
+func (x *SetFormatPreferencesRequest) GetDateFormat() string {
+	if x != nil {
+		return x.DateFormat
+	}
+	return ""
+}
+
+func (x *SetFormatPreferencesRequest) GetTimeFormat() string {
+	if x != nil {
+		return x.TimeFormat
+	}
+	return ""
+}
+
+func (x *SetFormatPreferencesRequest) GetTimezone() string {
+	if x != nil {
+		return x.Timezone
+	}
+	return ""
+}
+
+// Ответ на выбор языка и форматов
+type SetFormatPreferencesResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Success       bool                   `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
+	Message       string                 `protobuf:"bytes,2,opt,name=message,proto3" json:"message,omitempty"`
+	User          *User                  `protobuf:"bytes,3,opt,name=user,proto3" json:"user,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SetFormatPreferencesResponse) Reset() {
+	*x = SetFormatPreferencesResponse{}
+	mi := &file_users_proto_msgTypes[21]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SetFormatPreferencesResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SetFormatPreferencesResponse) ProtoMessage() {}
+
+func (x *SetFormatPreferencesResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_users_proto_msgTypes[21]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SetFormatPreferencesResponse.ProtoReflect.Descriptor instead.
+func (*SetFormatPreferencesResponse) Descriptor() ([]byte, []int) {
+	return file_users_proto_rawDescGZIP(), []int{21}
+}
+
+func (x *SetFormatPreferencesResponse) GetSuccess() bool {
+	if x != nil {
+		return x.Success
+	}
+	return false
+}
+
+func (x *SetFormatPreferencesResponse) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+func (x *SetFormatPreferencesResponse) GetUser() *User {
+	if x != nil {
+		return x.User
+	}
+	return nil
+}
+
+// Запрос на отзыв токена
+type RevokeTokenRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Token         string                 `protobuf:"bytes,1,opt,name=token,proto3" json:"token,omitempty"` // Отзываемый токен
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RevokeTokenRequest) Reset() {
+	*x = RevokeTokenRequest{}
+	mi := &file_users_proto_msgTypes[22]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RevokeTokenRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RevokeTokenRequest) ProtoMessage() {}
+
+func (x *RevokeTokenRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_users_proto_msgTypes[22]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RevokeTokenRequest.ProtoReflect.Descriptor instead.
+func (*RevokeTokenRequest) Descriptor() ([]byte, []int) {
+	return file_users_proto_rawDescGZIP(), []int{22}
+}
+
+func (x *RevokeTokenRequest) GetToken() string {
+	if x != nil {
+		return x.Token
+	}
+	return ""
+}
+
+// Ответ на отзыв токена
+type RevokeTokenResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Success       bool                   `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
+	Message       string                 `protobuf:"bytes,2,opt,name=message,proto3" json:"message,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RevokeTokenResponse) Reset() {
+	*x = RevokeTokenResponse{}
+	mi := &file_users_proto_msgTypes[23]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RevokeTokenResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RevokeTokenResponse) ProtoMessage() {}
+
+func (x *RevokeTokenResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_users_proto_msgTypes[23]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RevokeTokenResponse.ProtoReflect.Descriptor instead.
+func (*RevokeTokenResponse) Descriptor() ([]byte, []int) {
+	return file_users_proto_rawDescGZIP(), []int{23}
+}
+
+func (x *RevokeTokenResponse) GetSuccess() bool {
+	if x != nil {
+		return x.Success
+	}
+	return false
+}
+
+func (x *RevokeTokenResponse) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+// Запрос на смену роли пользователя
+type SetUserRoleRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Token         string                 `protobuf:"bytes,1,opt,name=token,proto3" json:"token,omitempty"` // JWT токен администратора
+	UserId        string                 `protobuf:"bytes,2,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	Role          UserRole               `protobuf:"varint,3,opt,name=role,proto3,enum=users.UserRole" json:"role,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SetUserRoleRequest) Reset() {
+	*x = SetUserRoleRequest{}
+	mi := &file_users_proto_msgTypes[24]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SetUserRoleRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SetUserRoleRequest) ProtoMessage() {}
+
+func (x *SetUserRoleRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_users_proto_msgTypes[24]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SetUserRoleRequest.ProtoReflect.Descriptor instead.
+func (*SetUserRoleRequest) Descriptor() ([]byte, []int) {
+	return file_users_proto_rawDescGZIP(), []int{24}
+}
+
+func (x *SetUserRoleRequest) GetToken() string {
+	if x != nil {
+		return x.Token
+	}
+	return ""
+}
+
+func (x *SetUserRoleRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *SetUserRoleRequest) GetRole() UserRole {
+	if x != nil {
+		return x.Role
+	}
+	return UserRole_ROLE_UNSPECIFIED
+}
+
+// Ответ на смену роли пользователя
+type SetUserRoleResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Success       bool                   `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
+	Message       string                 `protobuf:"bytes,2,opt,name=message,proto3" json:"message,omitempty"`
+	User          *User                  `protobuf:"bytes,3,opt,name=user,proto3" json:"user,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SetUserRoleResponse) Reset() {
+	*x = SetUserRoleResponse{}
+	mi := &file_users_proto_msgTypes[25]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SetUserRoleResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SetUserRoleResponse) ProtoMessage() {}
+
+func (x *SetUserRoleResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_users_proto_msgTypes[25]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SetUserRoleResponse.ProtoReflect.Descriptor instead.
+func (*SetUserRoleResponse) Descriptor() ([]byte, []int) {
+	return file_users_proto_rawDescGZIP(), []int{25}
+}
+
+func (x *SetUserRoleResponse) GetSuccess() bool {
+	if x != nil {
+		return x.Success
+	}
+	return false
+}
+
+func (x *SetUserRoleResponse) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+func (x *SetUserRoleResponse) GetUser() *User {
+	if x != nil {
+		return x.User
+	}
+	return nil
+}
+
+// Запрос на удаление пользователя
+type DeleteUserRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Token         string                 `protobuf:"bytes,1,opt,name=token,proto3" json:"token,omitempty"` // JWT токен администратора
+	UserId        string                 `protobuf:"bytes,2,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeleteUserRequest) Reset() {
+	*x = DeleteUserRequest{}
+	mi := &file_users_proto_msgTypes[26]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteUserRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteUserRequest) ProtoMessage() {}
+
+func (x *DeleteUserRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_users_proto_msgTypes[26]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteUserRequest.ProtoReflect.Descriptor instead.
+func (*DeleteUserRequest) Descriptor() ([]byte, []int) {
+	return file_users_proto_rawDescGZIP(), []int{26}
+}
+
+func (x *DeleteUserRequest) GetToken() string {
+	if x != nil {
+		return x.Token
+	}
+	return ""
+}
+
+func (x *DeleteUserRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+// Ответ на удаление пользователя
+type DeleteUserResponse struct {
+	state                protoimpl.MessageState `protogen:"open.v1"`
+	Success              bool                   `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
+	Message              string                 `protobuf:"bytes,2,opt,name=message,proto3" json:"message,omitempty"`
+	User                 *User                  `protobuf:"bytes,3,opt,name=user,proto3" json:"user,omitempty"`
+	DeletedSubscriptions int32                  `protobuf:"varint,4,opt,name=deleted_subscriptions,json=deletedSubscriptions,proto3" json:"deleted_subscriptions,omitempty"` // Число удаленных вместе с пользователем подписок
+	unknownFields        protoimpl.UnknownFields
+	sizeCache            protoimpl.SizeCache
+}
+
+func (x *DeleteUserResponse) Reset() {
+	*x = DeleteUserResponse{}
+	mi := &file_users_proto_msgTypes[27]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteUserResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteUserResponse) ProtoMessage() {}
+
+func (x *DeleteUserResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_users_proto_msgTypes[27]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteUserResponse.ProtoReflect.Descriptor instead.
+func (*DeleteUserResponse) Descriptor() ([]byte, []int) {
+	return file_users_proto_rawDescGZIP(), []int{27}
+}
+
+func (x *DeleteUserResponse) GetSuccess() bool {
+	if x != nil {
+		return x.Success
+	}
+	return false
+}
+
+func (x *DeleteUserResponse) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+func (x *DeleteUserResponse) GetUser() *User {
+	if x != nil {
+		return x.User
+	}
+	return nil
+}
+
+func (x *DeleteUserResponse) GetDeletedSubscriptions() int32 {
+	if x != nil {
+		return x.DeletedSubscriptions
+	}
+	return 0
+}
+
+// Запрос на восстановление пользователя
+type RestoreUserRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Token         string                 `protobuf:"bytes,1,opt,name=token,proto3" json:"token,omitempty"` // JWT токен администратора
+	UserId        string                 `protobuf:"bytes,2,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RestoreUserRequest) Reset() {
+	*x = RestoreUserRequest{}
+	mi := &file_users_proto_msgTypes[28]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RestoreUserRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RestoreUserRequest) ProtoMessage() {}
+
+func (x *RestoreUserRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_users_proto_msgTypes[28]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RestoreUserRequest.ProtoReflect.Descriptor instead.
+func (*RestoreUserRequest) Descriptor() ([]byte, []int) {
+	return file_users_proto_rawDescGZIP(), []int{28}
+}
+
+func (x *RestoreUserRequest) GetToken() string {
+	if x != nil {
+		return x.Token
+	}
+	return ""
+}
+
+func (x *RestoreUserRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+// Ответ на восстановление пользователя
+type RestoreUserResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Success       bool                   `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
+	Message       string                 `protobuf:"bytes,2,opt,name=message,proto3" json:"message,omitempty"`
+	User          *User                  `protobuf:"bytes,3,opt,name=user,proto3" json:"user,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RestoreUserResponse) Reset() {
+	*x = RestoreUserResponse{}
+	mi := &file_users_proto_msgTypes[29]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RestoreUserResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RestoreUserResponse) ProtoMessage() {}
+
+func (x *RestoreUserResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_users_proto_msgTypes[29]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RestoreUserResponse.ProtoReflect.Descriptor instead.
+func (*RestoreUserResponse) Descriptor() ([]byte, []int) {
+	return file_users_proto_rawDescGZIP(), []int{29}
+}
+
+func (x *RestoreUserResponse) GetSuccess() bool {
+	if x != nil {
+		return x.Success
+	}
+	return false
+}
+
+func (x *RestoreUserResponse) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+func (x *RestoreUserResponse) GetUser() *User {
+	if x != nil {
+		return x.User
+	}
+	return nil
+}
+
+// Запрос регистрации студентов по списку группы
+type ImportStudentRosterRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	Token string                 `protobuf:"bytes,1,opt,name=token,proto3" json:"token,omitempty"` // JWT токен администратора
+	// Таблица с колонками email, ФИО, группа, номер (студенческого), курс
+	// (разделитель "," или ";"); курс по умолчанию 1
+	Csv           []byte `protobuf:"bytes,2,opt,name=csv,proto3" json:"csv,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ImportStudentRosterRequest) Reset() {
+	*x = ImportStudentRosterRequest{}
+	mi := &file_users_proto_msgTypes[30]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ImportStudentRosterRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ImportStudentRosterRequest) ProtoMessage() {}
+
+func (x *ImportStudentRosterRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_users_proto_msgTypes[30]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ImportStudentRosterRequest.ProtoReflect.Descriptor instead.
+func (*ImportStudentRosterRequest) Descriptor() ([]byte, []int) {
+	return file_users_proto_rawDescGZIP(), []int{30}
+}
+
+func (x *ImportStudentRosterRequest) GetToken() string {
+	if x != nil {
+		return x.Token
+	}
+	return ""
+}
+
+func (x *ImportStudentRosterRequest) GetCsv() []byte {
+	if x != nil {
+		return x.Csv
+	}
+	return nil
+}
+
+// Студент, зарегистрированный по списку группы
+type RosterAccount struct {
+	state             protoimpl.MessageState `protogen:"open.v1"`
+	Line              int32                  `protobuf:"varint,1,opt,name=line,proto3" json:"line,omitempty"` // Строка файла
+	Email             string                 `protobuf:"bytes,2,opt,name=email,proto3" json:"email,omitempty"`
+	FullName          string                 `protobuf:"bytes,3,opt,name=full_name,json=fullName,proto3" json:"full_name,omitempty"`
+	GroupName         string                 `protobuf:"bytes,4,opt,name=group_name,json=groupName,proto3" json:"group_name,omitempty"`
+	UserId            string                 `protobuf:"bytes,5,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	Invited           bool                   `protobuf:"varint,6,opt,name=invited,proto3" json:"invited,omitempty"`                                             // Приглашение с временным паролем отправлено на email
+	TemporaryPassword string                 `protobuf:"bytes,7,opt,name=temporary_password,json=temporaryPassword,proto3" json:"temporary_password,omitempty"` // Временный пароль, если приглашение не отправлено
+	unknownFields     protoimpl.UnknownFields
+	sizeCache         protoimpl.SizeCache
+}
+
+func (x *RosterAccount) Reset() {
+	*x = RosterAccount{}
+	mi := &file_users_proto_msgTypes[31]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RosterAccount) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RosterAccount) ProtoMessage() {}
+
+func (x *RosterAccount) ProtoReflect() protoreflect.Message {
+	mi := &file_users_proto_msgTypes[31]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RosterAccount.ProtoReflect.Descriptor instead.
+func (*RosterAccount) Descriptor() ([]byte, []int) {
+	return file_users_proto_rawDescGZIP(), []int{31}
+}
+
+func (x *RosterAccount) GetLine() int32 {
+	if x != nil {
+		return x.Line
+	}
+	return 0
+}
+
+func (x *RosterAccount) GetEmail() string {
+	if x != nil {
+		return x.Email
+	}
+	return ""
+}
+
+func (x *RosterAccount) GetFullName() string {
+	if x != nil {
+		return x.FullName
+	}
+	return ""
+}
+
+func (x *RosterAccount) GetGroupName() string {
+	if x != nil {
+		return x.GroupName
+	}
+	return ""
+}
+
+func (x *RosterAccount) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *RosterAccount) GetInvited() bool {
+	if x != nil {
+		return x.Invited
+	}
+	return false
+}
+
+func (x *RosterAccount) GetTemporaryPassword() string {
+	if x != nil {
+		return x.TemporaryPassword
+	}
+	return ""
+}
+
+// Студент из списка, который не зарегистрирован
+type RosterSkip struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Line          int32                  `protobuf:"varint,1,opt,name=line,proto3" json:"line,omitempty"`
+	Email         string                 `protobuf:"bytes,2,opt,name=email,proto3" json:"email,omitempty"`
+	Reason        string                 `protobuf:"bytes,3,opt,name=reason,proto3" json:"reason,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RosterSkip) Reset() {
+	*x = RosterSkip{}
+	mi := &file_users_proto_msgTypes[32]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RosterSkip) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RosterSkip) ProtoMessage() {}
+
+func (x *RosterSkip) ProtoReflect() protoreflect.Message {
+	mi := &file_users_proto_msgTypes[32]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RosterSkip.ProtoReflect.Descriptor instead.
+func (*RosterSkip) Descriptor() ([]byte, []int) {
+	return file_users_proto_rawDescGZIP(), []int{32}
+}
+
+func (x *RosterSkip) GetLine() int32 {
+	if x != nil {
+		return x.Line
+	}
+	return 0
+}
+
+func (x *RosterSkip) GetEmail() string {
+	if x != nil {
+		return x.Email
+	}
+	return ""
+}
+
+func (x *RosterSkip) GetReason() string {
+	if x != nil {
+		return x.Reason
+	}
+	return ""
+}
+
+// Итог регистрации студентов по списку группы
+type ImportStudentRosterResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Success       bool                   `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
+	Message       string                 `protobuf:"bytes,2,opt,name=message,proto3" json:"message,omitempty"`
+	Total         int32                  `protobuf:"varint,3,opt,name=total,proto3" json:"total,omitempty"` // Студентов в списке
+	Created       []*RosterAccount       `protobuf:"bytes,4,rep,name=created,proto3" json:"created,omitempty"`
+	Skipped       []*RosterSkip          `protobuf:"bytes,5,rep,name=skipped,proto3" json:"skipped,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ImportStudentRosterResponse) Reset() {
+	*x = ImportStudentRosterResponse{}
+	mi := &file_users_proto_msgTypes[33]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ImportStudentRosterResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ImportStudentRosterResponse) ProtoMessage() {}
+
+func (x *ImportStudentRosterResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_users_proto_msgTypes[33]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ImportStudentRosterResponse.ProtoReflect.Descriptor instead.
+func (*ImportStudentRosterResponse) Descriptor() ([]byte, []int) {
+	return file_users_proto_rawDescGZIP(), []int{33}
+}
+
+func (x *ImportStudentRosterResponse) GetSuccess() bool {
+	if x != nil {
+		return x.Success
+	}
+	return false
+}
+
+func (x *ImportStudentRosterResponse) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+func (x *ImportStudentRosterResponse) GetTotal() int32 {
+	if x != nil {
+		return x.Total
+	}
+	return 0
+}
+
+func (x *ImportStudentRosterResponse) GetCreated() []*RosterAccount {
+	if x != nil {
+		return x.Created
+	}
+	return nil
+}
+
+func (x *ImportStudentRosterResponse) GetSkipped() []*RosterSkip {
+	if x != nil {
+		return x.Skipped
+	}
+	return nil
+}
+
+// Событие журнала безопасности
+type AuditEvent struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Type          AuditEventType         `protobuf:"varint,2,opt,name=type,proto3,enum=users.AuditEventType" json:"type,omitempty"`
+	UserId        string                 `protobuf:"bytes,3,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	ActorId       string                 `protobuf:"bytes,4,opt,name=actor_id,json=actorId,proto3" json:"actor_id,omitempty"` // Кто выполнил действие, если не сам пользователь
+	Email         string                 `protobuf:"bytes,5,opt,name=email,proto3" json:"email,omitempty"`
+	Ip            string                 `protobuf:"bytes,6,opt,name=ip,proto3" json:"ip,omitempty"`
+	UserAgent     string                 `protobuf:"bytes,7,opt,name=user_agent,json=userAgent,proto3" json:"user_agent,omitempty"`
+	Details       string                 `protobuf:"bytes,8,opt,name=details,proto3" json:"details,omitempty"`
+	CreatedAt     string                 `protobuf:"bytes,9,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"` // RFC3339
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *AuditEvent) Reset() {
+	*x = AuditEvent{}
+	mi := &file_users_proto_msgTypes[34]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AuditEvent) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AuditEvent) ProtoMessage() {}
+
+func (x *AuditEvent) ProtoReflect() protoreflect.Message {
+	mi := &file_users_proto_msgTypes[34]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AuditEvent.ProtoReflect.Descriptor instead.
+func (*AuditEvent) Descriptor() ([]byte, []int) {
+	return file_users_proto_rawDescGZIP(), []int{34}
+}
+
+func (x *AuditEvent) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *AuditEvent) GetType() AuditEventType {
+	if x != nil {
+		return x.Type
+	}
+	return AuditEventType_AUDIT_EVENT_TYPE_UNSPECIFIED
+}
+
+func (x *AuditEvent) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *AuditEvent) GetActorId() string {
+	if x != nil {
+		return x.ActorId
+	}
+	return ""
+}
+
+func (x *AuditEvent) GetEmail() string {
+	if x != nil {
+		return x.Email
+	}
+	return ""
+}
+
+func (x *AuditEvent) GetIp() string {
+	if x != nil {
+		return x.Ip
+	}
+	return ""
+}
+
+func (x *AuditEvent) GetUserAgent() string {
+	if x != nil {
+		return x.UserAgent
+	}
+	return ""
+}
+
+func (x *AuditEvent) GetDetails() string {
+	if x != nil {
+		return x.Details
+	}
+	return ""
+}
+
+func (x *AuditEvent) GetCreatedAt() string {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return ""
+}
+
+// Запрос журнала безопасности; пустые фильтры не ограничивают выборку
+type ListAuditEventsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Token         string                 `protobuf:"bytes,1,opt,name=token,proto3" json:"token,omitempty"` // JWT токен администратора
+	UserId        string                 `protobuf:"bytes,2,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	Type          AuditEventType         `protobuf:"varint,3,opt,name=type,proto3,enum=users.AuditEventType" json:"type,omitempty"`
+	From          string                 `protobuf:"bytes,4,opt,name=from,proto3" json:"from,omitempty"`                          // RFC3339
+	To            string                 `protobuf:"bytes,5,opt,name=to,proto3" json:"to,omitempty"`                              // RFC3339
+	PageSize      int32                  `protobuf:"varint,6,opt,name=page_size,json=pageSize,proto3" json:"page_size,omitempty"` // По умолчанию 50, не более 500
+	Offset        int32                  `protobuf:"varint,7,opt,name=offset,proto3" json:"offset,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListAuditEventsRequest) Reset() {
+	*x = ListAuditEventsRequest{}
+	mi := &file_users_proto_msgTypes[35]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListAuditEventsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListAuditEventsRequest) ProtoMessage() {}
+
+func (x *ListAuditEventsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_users_proto_msgTypes[35]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListAuditEventsRequest.ProtoReflect.Descriptor instead.
+func (*ListAuditEventsRequest) Descriptor() ([]byte, []int) {
+	return file_users_proto_rawDescGZIP(), []int{35}
+}
+
+func (x *ListAuditEventsRequest) GetToken() string {
+	if x != nil {
+		return x.Token
+	}
+	return ""
+}
+
+func (x *ListAuditEventsRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *ListAuditEventsRequest) GetType() AuditEventType {
+	if x != nil {
+		return x.Type
+	}
+	return AuditEventType_AUDIT_EVENT_TYPE_UNSPECIFIED
+}
+
+func (x *ListAuditEventsRequest) GetFrom() string {
+	if x != nil {
+		return x.From
+	}
+	return ""
+}
+
+func (x *ListAuditEventsRequest) GetTo() string {
+	if x != nil {
+		return x.To
+	}
+	return ""
+}
+
+func (x *ListAuditEventsRequest) GetPageSize() int32 {
+	if x != nil {
+		return x.PageSize
+	}
+	return 0
+}
+
+func (x *ListAuditEventsRequest) GetOffset() int32 {
+	if x != nil {
+		return x.Offset
+	}
+	return 0
+}
+
+// Ответ с журналом безопасности
+type ListAuditEventsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Success       bool                   `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
+	Message       string                 `protobuf:"bytes,2,opt,name=message,proto3" json:"message,omitempty"`
+	Events        []*AuditEvent          `protobuf:"bytes,3,rep,name=events,proto3" json:"events,omitempty"`
+	Total         int32                  `protobuf:"varint,4,opt,name=total,proto3" json:"total,omitempty"` // Всего событий по фильтру
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListAuditEventsResponse) Reset() {
+	*x = ListAuditEventsResponse{}
+	mi := &file_users_proto_msgTypes[36]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListAuditEventsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListAuditEventsResponse) ProtoMessage() {}
+
+func (x *ListAuditEventsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_users_proto_msgTypes[36]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListAuditEventsResponse.ProtoReflect.Descriptor instead.
+func (*ListAuditEventsResponse) Descriptor() ([]byte, []int) {
+	return file_users_proto_rawDescGZIP(), []int{36}
+}
+
+func (x *ListAuditEventsResponse) GetSuccess() bool {
+	if x != nil {
+		return x.Success
+	}
+	return false
+}
+
+func (x *ListAuditEventsResponse) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+func (x *ListAuditEventsResponse) GetEvents() []*AuditEvent {
+	if x != nil {
+		return x.Events
+	}
+	return nil
+}
+
+func (x *ListAuditEventsResponse) GetTotal() int32 {
+	if x != nil {
+		return x.Total
+	}
+	return 0
+}
+
+// Код приглашения для регистрации
+type Invitation struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Code          string                 `protobuf:"bytes,2,opt,name=code,proto3" json:"code,omitempty"`
+	Role          UserRole               `protobuf:"varint,3,opt,name=role,proto3,enum=users.UserRole" json:"role,omitempty"`       // ROLE_UNSPECIFIED - любая роль
+	GroupName     string                 `protobuf:"bytes,4,opt,name=group_name,json=groupName,proto3" json:"group_name,omitempty"` // Пусто - любая группа
+	MaxUses       int32                  `protobuf:"varint,5,opt,name=max_uses,json=maxUses,proto3" json:"max_uses,omitempty"`      // 0 - без ограничения
+	UsedCount     int32                  `protobuf:"varint,6,opt,name=used_count,json=usedCount,proto3" json:"used_count,omitempty"`
+	ExpiresAt     string                 `protobuf:"bytes,7,opt,name=expires_at,json=expiresAt,proto3" json:"expires_at,omitempty"` // RFC3339, пусто - бессрочно
+	CreatedAt     string                 `protobuf:"bytes,8,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"` // RFC3339
+	RevokedAt     string                 `protobuf:"bytes,9,opt,name=revoked_at,json=revokedAt,proto3" json:"revoked_at,omitempty"` // RFC3339, пусто - не отозвано
+	Active        bool                   `protobuf:"varint,10,opt,name=active,proto3" json:"active,omitempty"`                      // Можно ли сейчас зарегистрироваться по приглашению
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Invitation) Reset() {
+	*x = Invitation{}
+	mi := &file_users_proto_msgTypes[37]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Invitation) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Invitation) ProtoMessage() {}
+
+func (x *Invitation) ProtoReflect() protoreflect.Message {
+	mi := &file_users_proto_msgTypes[37]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Invitation.ProtoReflect.Descriptor instead.
+func (*Invitation) Descriptor() ([]byte, []int) {
+	return file_users_proto_rawDescGZIP(), []int{37}
+}
+
+func (x *Invitation) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *Invitation) GetCode() string {
+	if x != nil {
+		return x.Code
+	}
+	return ""
+}
+
+func (x *Invitation) GetRole() UserRole {
+	if x != nil {
+		return x.Role
+	}
+	return UserRole_ROLE_UNSPECIFIED
+}
+
+func (x *Invitation) GetGroupName() string {
+	if x != nil {
+		return x.GroupName
+	}
+	return ""
+}
+
+func (x *Invitation) GetMaxUses() int32 {
+	if x != nil {
+		return x.MaxUses
+	}
+	return 0
+}
+
+func (x *Invitation) GetUsedCount() int32 {
+	if x != nil {
+		return x.UsedCount
+	}
+	return 0
+}
+
+func (x *Invitation) GetExpiresAt() string {
+	if x != nil {
+		return x.ExpiresAt
+	}
+	return ""
+}
+
+func (x *Invitation) GetCreatedAt() string {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return ""
+}
+
+func (x *Invitation) GetRevokedAt() string {
+	if x != nil {
+		return x.RevokedAt
+	}
+	return ""
+}
+
+func (x *Invitation) GetActive() bool {
+	if x != nil {
+		return x.Active
+	}
+	return false
+}
+
+// Запрос на выпуск приглашения
+type CreateInvitationRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Token         string                 `protobuf:"bytes,1,opt,name=token,proto3" json:"token,omitempty"`                          // JWT токен администратора
+	Role          UserRole               `protobuf:"varint,2,opt,name=role,proto3,enum=users.UserRole" json:"role,omitempty"`       // ROLE_STUDENT, ROLE_TEACHER или не указано (любая роль)
+	GroupName     string                 `protobuf:"bytes,3,opt,name=group_name,json=groupName,proto3" json:"group_name,omitempty"` // Привязка к группе (только для студентов)
+	MaxUses       int32                  `protobuf:"varint,4,opt,name=max_uses,json=maxUses,proto3" json:"max_uses,omitempty"`      // 0 - без ограничения
+	ExpiresAt     string                 `protobuf:"bytes,5,opt,name=expires_at,json=expiresAt,proto3" json:"expires_at,omitempty"` // RFC3339, пусто - бессрочно
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CreateInvitationRequest) Reset() {
+	*x = CreateInvitationRequest{}
+	mi := &file_users_proto_msgTypes[38]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateInvitationRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateInvitationRequest) ProtoMessage() {}
+
+func (x *CreateInvitationRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_users_proto_msgTypes[38]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateInvitationRequest.ProtoReflect.Descriptor instead.
+func (*CreateInvitationRequest) Descriptor() ([]byte, []int) {
+	return file_users_proto_rawDescGZIP(), []int{38}
+}
+
+func (x *CreateInvitationRequest) GetToken() string {
+	if x != nil {
+		return x.Token
+	}
+	return ""
+}
+
+func (x *CreateInvitationRequest) GetRole() UserRole {
+	if x != nil {
+		return x.Role
+	}
+	return UserRole_ROLE_UNSPECIFIED
+}
+
+func (x *CreateInvitationRequest) GetGroupName() string {
+	if x != nil {
+		return x.GroupName
+	}
+	return ""
+}
+
+func (x *CreateInvitationRequest) GetMaxUses() int32 {
+	if x != nil {
+		return x.MaxUses
+	}
+	return 0
+}
+
+func (x *CreateInvitationRequest) GetExpiresAt() string {
+	if x != nil {
+		return x.ExpiresAt
+	}
+	return ""
+}
+
+// Ответ на выпуск приглашения
+type CreateInvitationResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Success       bool                   `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
+	Message       string                 `protobuf:"bytes,2,opt,name=message,proto3" json:"message,omitempty"`
+	Invitation    *Invitation            `protobuf:"bytes,3,opt,name=invitation,proto3" json:"invitation,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CreateInvitationResponse) Reset() {
+	*x = CreateInvitationResponse{}
+	mi := &file_users_proto_msgTypes[39]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateInvitationResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateInvitationResponse) ProtoMessage() {}
+
+func (x *CreateInvitationResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_users_proto_msgTypes[39]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateInvitationResponse.ProtoReflect.Descriptor instead.
+func (*CreateInvitationResponse) Descriptor() ([]byte, []int) {
+	return file_users_proto_rawDescGZIP(), []int{39}
+}
+
+func (x *CreateInvitationResponse) GetSuccess() bool {
+	if x != nil {
+		return x.Success
+	}
+	return false
+}
+
+func (x *CreateInvitationResponse) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+func (x *CreateInvitationResponse) GetInvitation() *Invitation {
+	if x != nil {
+		return x.Invitation
+	}
+	return nil
+}
+
+// Запрос списка приглашений
+type ListInvitationsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Token         string                 `protobuf:"bytes,1,opt,name=token,proto3" json:"token,omitempty"`                              // JWT токен администратора
+	ActiveOnly    bool                   `protobuf:"varint,2,opt,name=active_only,json=activeOnly,proto3" json:"active_only,omitempty"` // Только действующие приглашения
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListInvitationsRequest) Reset() {
+	*x = ListInvitationsRequest{}
+	mi := &file_users_proto_msgTypes[40]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListInvitationsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListInvitationsRequest) ProtoMessage() {}
+
+func (x *ListInvitationsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_users_proto_msgTypes[40]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListInvitationsRequest.ProtoReflect.Descriptor instead.
+func (*ListInvitationsRequest) Descriptor() ([]byte, []int) {
+	return file_users_proto_rawDescGZIP(), []int{40}
+}
+
+func (x *ListInvitationsRequest) GetToken() string {
+	if x != nil {
+		return x.Token
+	}
+	return ""
+}
+
+func (x *ListInvitationsRequest) GetActiveOnly() bool {
+	if x != nil {
+		return x.ActiveOnly
+	}
+	return false
+}
+
+// Ответ со списком приглашений
+type ListInvitationsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Success       bool                   `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
+	Message       string                 `protobuf:"bytes,2,opt,name=message,proto3" json:"message,omitempty"`
+	Invitations   []*Invitation          `protobuf:"bytes,3,rep,name=invitations,proto3" json:"invitations,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListInvitationsResponse) Reset() {
+	*x = ListInvitationsResponse{}
+	mi := &file_users_proto_msgTypes[41]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListInvitationsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListInvitationsResponse) ProtoMessage() {}
+
+func (x *ListInvitationsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_users_proto_msgTypes[41]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListInvitationsResponse.ProtoReflect.Descriptor instead.
+func (*ListInvitationsResponse) Descriptor() ([]byte, []int) {
+	return file_users_proto_rawDescGZIP(), []int{41}
+}
+
+func (x *ListInvitationsResponse) GetSuccess() bool {
+	if x != nil {
+		return x.Success
+	}
+	return false
+}
+
+func (x *ListInvitationsResponse) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+func (x *ListInvitationsResponse) GetInvitations() []*Invitation {
+	if x != nil {
+		return x.Invitations
+	}
+	return nil
+}
+
+// Запрос на отзыв приглашения
+type RevokeInvitationRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Token         string                 `protobuf:"bytes,1,opt,name=token,proto3" json:"token,omitempty"` // JWT токен администратора
+	InvitationId  string                 `protobuf:"bytes,2,opt,name=invitation_id,json=invitationId,proto3" json:"invitation_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RevokeInvitationRequest) Reset() {
+	*x = RevokeInvitationRequest{}
+	mi := &file_users_proto_msgTypes[42]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RevokeInvitationRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RevokeInvitationRequest) ProtoMessage() {}
+
+func (x *RevokeInvitationRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_users_proto_msgTypes[42]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RevokeInvitationRequest.ProtoReflect.Descriptor instead.
+func (*RevokeInvitationRequest) Descriptor() ([]byte, []int) {
+	return file_users_proto_rawDescGZIP(), []int{42}
+}
+
+func (x *RevokeInvitationRequest) GetToken() string {
+	if x != nil {
+		return x.Token
+	}
+	return ""
+}
+
+func (x *RevokeInvitationRequest) GetInvitationId() string {
+	if x != nil {
+		return x.InvitationId
+	}
+	return ""
+}
+
+// Ответ на отзыв приглашения
+type RevokeInvitationResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Success       bool                   `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
+	Message       string                 `protobuf:"bytes,2,opt,name=message,proto3" json:"message,omitempty"`
+	Invitation    *Invitation            `protobuf:"bytes,3,opt,name=invitation,proto3" json:"invitation,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RevokeInvitationResponse) Reset() {
+	*x = RevokeInvitationResponse{}
+	mi := &file_users_proto_msgTypes[43]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RevokeInvitationResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RevokeInvitationResponse) ProtoMessage() {}
+
+func (x *RevokeInvitationResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_users_proto_msgTypes[43]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RevokeInvitationResponse.ProtoReflect.Descriptor instead.
+func (*RevokeInvitationResponse) Descriptor() ([]byte, []int) {
+	return file_users_proto_rawDescGZIP(), []int{43}
+}
+
+func (x *RevokeInvitationResponse) GetSuccess() bool {
+	if x != nil {
+		return x.Success
+	}
+	return false
+}
+
+func (x *RevokeInvitationResponse) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+func (x *RevokeInvitationResponse) GetInvitation() *Invitation {
+	if x != nil {
+		return x.Invitation
+	}
+	return nil
+}
+
+// Запрос на получение профиля
+type GetProfileRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Token         string                 `protobuf:"bytes,1,opt,name=token,proto3" json:"token,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetProfileRequest) Reset() {
+	*x = GetProfileRequest{}
+	mi := &file_users_proto_msgTypes[44]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetProfileRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetProfileRequest) ProtoMessage() {}
+
+func (x *GetProfileRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_users_proto_msgTypes[44]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetProfileRequest.ProtoReflect.Descriptor instead.
+func (*GetProfileRequest) Descriptor() ([]byte, []int) {
+	return file_users_proto_rawDescGZIP(), []int{44}
+}
+
+func (x *GetProfileRequest) GetToken() string {
+	if x != nil {
+		return x.Token
+	}
+	return ""
+}
+
+// Ответ на получение профиля
+type GetProfileResponse struct {
+	state   protoimpl.MessageState `protogen:"open.v1"`
+	Success bool                   `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
+	Message string                 `protobuf:"bytes,2,opt,name=message,proto3" json:"message,omitempty"`
+	User    *User                  `protobuf:"bytes,3,opt,name=user,proto3" json:"user,omitempty"`
+	// Types that are valid to be assigned to Profile:
+	//
+	//	*GetProfileResponse_StudentProfile
+	//	*GetProfileResponse_TeacherProfile
+	Profile       isGetProfileResponse_Profile `protobuf_oneof:"profile"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetProfileResponse) Reset() {
+	*x = GetProfileResponse{}
+	mi := &file_users_proto_msgTypes[45]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetProfileResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetProfileResponse) ProtoMessage() {}
+
+func (x *GetProfileResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_users_proto_msgTypes[45]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetProfileResponse.ProtoReflect.Descriptor instead.
+func (*GetProfileResponse) Descriptor() ([]byte, []int) {
+	return file_users_proto_rawDescGZIP(), []int{45}
+}
+
+func (x *GetProfileResponse) GetSuccess() bool {
+	if x != nil {
+		return x.Success
+	}
+	return false
+}
+
+func (x *GetProfileResponse) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+func (x *GetProfileResponse) GetUser() *User {
+	if x != nil {
+		return x.User
+	}
+	return nil
+}
+
+func (x *GetProfileResponse) GetProfile() isGetProfileResponse_Profile {
+	if x != nil {
+		return x.Profile
+	}
+	return nil
+}
+
+func (x *GetProfileResponse) GetStudentProfile() *StudentProfile {
+	if x != nil {
+		if x, ok := x.Profile.(*GetProfileResponse_StudentProfile); ok {
+			return x.StudentProfile
+		}
+	}
+	return nil
+}
+
+func (x *GetProfileResponse) GetTeacherProfile() *TeacherProfile {
+	if x != nil {
+		if x, ok := x.Profile.(*GetProfileResponse_TeacherProfile); ok {
+			return x.TeacherProfile
+		}
+	}
+	return nil
+}
+
+type isGetProfileResponse_Profile interface {
+	isGetProfileResponse_Profile()
+}
+
+type GetProfileResponse_StudentProfile struct {
+	StudentProfile *StudentProfile `protobuf:"bytes,4,opt,name=student_profile,json=studentProfile,proto3,oneof"`
+}
+
+type GetProfileResponse_TeacherProfile struct {
+	TeacherProfile *TeacherProfile `protobuf:"bytes,5,opt,name=teacher_profile,json=teacherProfile,proto3,oneof"`
+}
+
+func (*GetProfileResponse_StudentProfile) isGetProfileResponse_Profile() {}
+
+func (*GetProfileResponse_TeacherProfile) isGetProfileResponse_Profile() {}
+
+// Информация о пользователе
+type User struct {
+	state                  protoimpl.MessageState `protogen:"open.v1"`
+	Id                     string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Email                  string                 `protobuf:"bytes,2,opt,name=email,proto3" json:"email,omitempty"`
+	Role                   UserRole               `protobuf:"varint,3,opt,name=role,proto3,enum=users.UserRole" json:"role,omitempty"`
+	CreatedAt              string                 `protobuf:"bytes,4,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	IsActive               bool                   `protobuf:"varint,5,opt,name=is_active,json=isActive,proto3" json:"is_active,omitempty"`
+	PasswordChangeRequired bool                   `protobuf:"varint,6,opt,name=password_change_required,json=passwordChangeRequired,proto3" json:"password_change_required,omitempty"` // Временный пароль: нужно сменить после входа
+	LoginAlerts            bool                   `protobuf:"varint,7,opt,name=login_alerts,json=loginAlerts,proto3" json:"login_alerts,omitempty"`                                    // Уведомлять о входе с нового устройства или адреса
+	Locale                 string                 `protobuf:"bytes,8,opt,name=locale,proto3" json:"locale,omitempty"`                                                                  // Язык пользователя
+	DateFormat             string                 `protobuf:"bytes,9,opt,name=date_format,json=dateFormat,proto3" json:"date_format,omitempty"`                                        // Выбранный формат даты (пусто - по языку)
+	TimeFormat             string                 `protobuf:"bytes,10,opt,name=time_format,json=timeFormat,proto3" json:"time_format,omitempty"`                                       // Выбранный формат времени (пусто - по языку)
+	Timezone               string                 `protobuf:"bytes,11,opt,name=timezone,proto3" json:"timezone,omitempty"`                                                             // Выбранный часовой пояс (пусто - часовой пояс колледжа)
+	unknownFields          protoimpl.UnknownFields
+	sizeCache              protoimpl.SizeCache
+}
+
+func (x *User) Reset() {
+	*x = User{}
+	mi := &file_users_proto_msgTypes[46]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *User) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*User) ProtoMessage() {}
+
+func (x *User) ProtoReflect() protoreflect.Message {
+	mi := &file_users_proto_msgTypes[46]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use User.ProtoReflect.Descriptor instead.
+func (*User) Descriptor() ([]byte, []int) {
+	return file_users_proto_rawDescGZIP(), []int{46}
+}
+
+func (x *User) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *User) GetEmail() string {
+	if x != nil {
+		return x.Email
+	}
+	return ""
+}
+
+func (x *User) GetRole() UserRole {
+	if x != nil {
+		return x.Role
+	}
+	return UserRole_ROLE_UNSPECIFIED
+}
+
+func (x *User) GetCreatedAt() string {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return ""
+}
+
+func (x *User) GetIsActive() bool {
+	if x != nil {
+		return x.IsActive
+	}
+	return false
+}
+
+func (x *User) GetPasswordChangeRequired() bool {
+	if x != nil {
+		return x.PasswordChangeRequired
+	}
+	return false
+}
+
+func (x *User) GetLoginAlerts() bool {
+	if x != nil {
+		return x.LoginAlerts
+	}
+	return false
+}
+
+func (x *User) GetLocale() string {
+	if x != nil {
+		return x.Locale
+	}
+	return ""
+}
+
+func (x *User) GetDateFormat() string {
+	if x != nil {
+		return x.DateFormat
+	}
+	return ""
+}
+
+func (x *User) GetTimeFormat() string {
+	if x != nil {
+		return x.TimeFormat
+	}
+	return ""
+}
+
+func (x *User) GetTimezone() string {
+	if x != nil {
+		return x.Timezone
+	}
+	return ""
+}
+
+// Профиль студента
+type StudentProfile struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	UserId        string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	GroupName     string                 `protobuf:"bytes,2,opt,name=group_name,json=groupName,proto3" json:"group_name,omitempty"`
+	Faculty       string                 `protobuf:"bytes,3,opt,name=faculty,proto3" json:"faculty,omitempty"`
+	Course        int32                  `protobuf:"varint,4,opt,name=course,proto3" json:"course,omitempty"`
+	StudentNumber string                 `protobuf:"bytes,5,opt,name=student_number,json=studentNumber,proto3" json:"student_number,omitempty"`
+	FullName      string                 `protobuf:"bytes,6,opt,name=full_name,json=fullName,proto3" json:"full_name,omitempty"`
+	Subgroup      int32                  `protobuf:"varint,7,opt,name=subgroup,proto3" json:"subgroup,omitempty"` // Подгруппа (0 - не выбрана, видны занятия всех подгрупп)
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *StudentProfile) Reset() {
+	*x = StudentProfile{}
+	mi := &file_users_proto_msgTypes[47]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *StudentProfile) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*StudentProfile) ProtoMessage() {}
+
+func (x *StudentProfile) ProtoReflect() protoreflect.Message {
+	mi := &file_users_proto_msgTypes[47]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use StudentProfile.ProtoReflect.Descriptor instead.
+func (*StudentProfile) Descriptor() ([]byte, []int) {
+	return file_users_proto_rawDescGZIP(), []int{47}
+}
+
+func (x *StudentProfile) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *StudentProfile) GetGroupName() string {
 	if x != nil {
 		return x.GroupName
 	}
@@ -781,6 +3485,20 @@ func (x *StudentProfile) GetStudentNumber() string {
 	return ""
 }
 
+func (x *StudentProfile) GetFullName() string {
+	if x != nil {
+		return x.FullName
+	}
+	return ""
+}
+
+func (x *StudentProfile) GetSubgroup() int32 {
+	if x != nil {
+		return x.Subgroup
+	}
+	return 0
+}
+
 // Профиль преподавателя
 type TeacherProfile struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
@@ -795,7 +3513,7 @@ type TeacherProfile struct {
 
 func (x *TeacherProfile) Reset() {
 	*x = TeacherProfile{}
-	mi := &file_users_proto_msgTypes[9]
+	mi := &file_users_proto_msgTypes[48]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -807,7 +3525,7 @@ func (x *TeacherProfile) String() string {
 func (*TeacherProfile) ProtoMessage() {}
 
 func (x *TeacherProfile) ProtoReflect() protoreflect.Message {
-	mi := &file_users_proto_msgTypes[9]
+	mi := &file_users_proto_msgTypes[48]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -820,7 +3538,7 @@ func (x *TeacherProfile) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use TeacherProfile.ProtoReflect.Descriptor instead.
 func (*TeacherProfile) Descriptor() ([]byte, []int) {
-	return file_users_proto_rawDescGZIP(), []int{9}
+	return file_users_proto_rawDescGZIP(), []int{48}
 }
 
 func (x *TeacherProfile) GetUserId() string {
@@ -858,11 +3576,125 @@ func (x *TeacherProfile) GetTeacherId() string {
 	return ""
 }
 
+// Запрос на выбор подгруппы студента
+type SetStudentSubgroupRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Token         string                 `protobuf:"bytes,1,opt,name=token,proto3" json:"token,omitempty"`
+	Subgroup      int32                  `protobuf:"varint,2,opt,name=subgroup,proto3" json:"subgroup,omitempty"` // 0 - сбросить выбор
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SetStudentSubgroupRequest) Reset() {
+	*x = SetStudentSubgroupRequest{}
+	mi := &file_users_proto_msgTypes[49]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SetStudentSubgroupRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SetStudentSubgroupRequest) ProtoMessage() {}
+
+func (x *SetStudentSubgroupRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_users_proto_msgTypes[49]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SetStudentSubgroupRequest.ProtoReflect.Descriptor instead.
+func (*SetStudentSubgroupRequest) Descriptor() ([]byte, []int) {
+	return file_users_proto_rawDescGZIP(), []int{49}
+}
+
+func (x *SetStudentSubgroupRequest) GetToken() string {
+	if x != nil {
+		return x.Token
+	}
+	return ""
+}
+
+func (x *SetStudentSubgroupRequest) GetSubgroup() int32 {
+	if x != nil {
+		return x.Subgroup
+	}
+	return 0
+}
+
+// Ответ на выбор подгруппы
+type SetStudentSubgroupResponse struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	Success        bool                   `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
+	Message        string                 `protobuf:"bytes,2,opt,name=message,proto3" json:"message,omitempty"`
+	StudentProfile *StudentProfile        `protobuf:"bytes,3,opt,name=student_profile,json=studentProfile,proto3" json:"student_profile,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *SetStudentSubgroupResponse) Reset() {
+	*x = SetStudentSubgroupResponse{}
+	mi := &file_users_proto_msgTypes[50]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SetStudentSubgroupResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SetStudentSubgroupResponse) ProtoMessage() {}
+
+func (x *SetStudentSubgroupResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_users_proto_msgTypes[50]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SetStudentSubgroupResponse.ProtoReflect.Descriptor instead.
+func (*SetStudentSubgroupResponse) Descriptor() ([]byte, []int) {
+	return file_users_proto_rawDescGZIP(), []int{50}
+}
+
+func (x *SetStudentSubgroupResponse) GetSuccess() bool {
+	if x != nil {
+		return x.Success
+	}
+	return false
+}
+
+func (x *SetStudentSubgroupResponse) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+func (x *SetStudentSubgroupResponse) GetStudentProfile() *StudentProfile {
+	if x != nil {
+		return x.StudentProfile
+	}
+	return nil
+}
+
 var File_users_proto protoreflect.FileDescriptor
 
 const file_users_proto_rawDesc = "" +
 	"\n" +
-	"\vusers.proto\x12\x05users\"\xc2\x01\n" +
+	"\vusers.proto\x12\x05users\"\xbe\x02\n" +
 	"\x16RegisterStudentRequest\x12\x14\n" +
 	"\x05email\x18\x01 \x01(\tR\x05email\x12\x1a\n" +
 	"\bpassword\x18\x02 \x01(\tR\bpassword\x12\x1d\n" +
@@ -870,7 +3702,12 @@ const file_users_proto_rawDesc = "" +
 	"group_name\x18\x03 \x01(\tR\tgroupName\x12\x18\n" +
 	"\afaculty\x18\x04 \x01(\tR\afaculty\x12\x16\n" +
 	"\x06course\x18\x05 \x01(\x05R\x06course\x12%\n" +
-	"\x0estudent_number\x18\x06 \x01(\tR\rstudentNumber\"\xc2\x01\n" +
+	"\x0estudent_number\x18\x06 \x01(\tR\rstudentNumber\x12\x18\n" +
+	"\acaptcha\x18\a \x01(\tR\acaptcha\x12'\n" +
+	"\x0finvitation_code\x18\b \x01(\tR\x0einvitationCode\x12\x1b\n" +
+	"\tfull_name\x18\t \x01(\tR\bfullName\x12\x1a\n" +
+	"\bsubgroup\x18\n" +
+	" \x01(\x05R\bsubgroup\"\x85\x02\n" +
 	"\x16RegisterTeacherRequest\x12\x14\n" +
 	"\x05email\x18\x01 \x01(\tR\x05email\x12\x1a\n" +
 	"\bpassword\x18\x02 \x01(\tR\bpassword\x12\x1b\n" +
@@ -880,22 +3717,225 @@ const file_users_proto_rawDesc = "" +
 	"department\x12\x1a\n" +
 	"\bposition\x18\x05 \x01(\tR\bposition\x12\x1d\n" +
 	"\n" +
-	"teacher_id\x18\x06 \x01(\tR\tteacherId\"\xf6\x01\n" +
+	"teacher_id\x18\x06 \x01(\tR\tteacherId\x12\x18\n" +
+	"\acaptcha\x18\a \x01(\tR\acaptcha\x12'\n" +
+	"\x0finvitation_code\x18\b \x01(\tR\x0einvitationCode\"\xf6\x01\n" +
 	"\x10RegisterResponse\x12\x18\n" +
 	"\asuccess\x18\x01 \x01(\bR\asuccess\x12\x18\n" +
 	"\amessage\x18\x02 \x01(\tR\amessage\x12\x1f\n" +
 	"\x04user\x18\x03 \x01(\v2\v.users.UserR\x04user\x12@\n" +
 	"\x0fstudent_profile\x18\x04 \x01(\v2\x15.users.StudentProfileH\x00R\x0estudentProfile\x12@\n" +
 	"\x0fteacher_profile\x18\x05 \x01(\v2\x15.users.TeacherProfileH\x00R\x0eteacherProfileB\t\n" +
-	"\aprofile\"@\n" +
+	"\aprofile\"Z\n" +
 	"\fLoginRequest\x12\x14\n" +
 	"\x05email\x18\x01 \x01(\tR\x05email\x12\x1a\n" +
-	"\bpassword\x18\x02 \x01(\tR\bpassword\"z\n" +
+	"\bpassword\x18\x02 \x01(\tR\bpassword\x12\x18\n" +
+	"\acaptcha\x18\x03 \x01(\tR\acaptcha\"\x87\x02\n" +
 	"\rLoginResponse\x12\x18\n" +
 	"\asuccess\x18\x01 \x01(\bR\asuccess\x12\x18\n" +
 	"\amessage\x18\x02 \x01(\tR\amessage\x12\x14\n" +
 	"\x05token\x18\x03 \x01(\tR\x05token\x12\x1f\n" +
-	"\x04user\x18\x04 \x01(\v2\v.users.UserR\x04user\")\n" +
+	"\x04user\x18\x04 \x01(\v2\v.users.UserR\x04user\x12.\n" +
+	"\x13two_factor_required\x18\x05 \x01(\bR\x11twoFactorRequired\x12(\n" +
+	"\x10two_factor_token\x18\x06 \x01(\tR\x0etwoFactorToken\x121\n" +
+	"\x15two_factor_expires_at\x18\a \x01(\tR\x12twoFactorExpiresAt\"7\n" +
+	"\x16IssueGuestTokenRequest\x12\x1d\n" +
+	"\n" +
+	"group_name\x18\x01 \x01(\tR\tgroupName\"\xa1\x01\n" +
+	"\x17IssueGuestTokenResponse\x12\x18\n" +
+	"\asuccess\x18\x01 \x01(\bR\asuccess\x12\x18\n" +
+	"\amessage\x18\x02 \x01(\tR\amessage\x12\x14\n" +
+	"\x05token\x18\x03 \x01(\tR\x05token\x12\x1d\n" +
+	"\n" +
+	"group_name\x18\x04 \x01(\tR\tgroupName\x12\x1d\n" +
+	"\n" +
+	"expires_at\x18\x05 \x01(\tR\texpiresAt\"\x1c\n" +
+	"\x1aGetCaptchaChallengeRequest\"\xe5\x01\n" +
+	"\x1bGetCaptchaChallengeResponse\x12\x18\n" +
+	"\asuccess\x18\x01 \x01(\bR\asuccess\x12\x18\n" +
+	"\amessage\x18\x02 \x01(\tR\amessage\x12\x1a\n" +
+	"\bprovider\x18\x03 \x01(\tR\bprovider\x12\x19\n" +
+	"\bsite_key\x18\x04 \x01(\tR\asiteKey\x12\x1c\n" +
+	"\tchallenge\x18\x05 \x01(\tR\tchallenge\x12\x1e\n" +
+	"\n" +
+	"difficulty\x18\x06 \x01(\x05R\n" +
+	"difficulty\x12\x1d\n" +
+	"\n" +
+	"expires_at\x18\a \x01(\tR\texpiresAt\"V\n" +
+	"\x16VerifyTwoFactorRequest\x12(\n" +
+	"\x10two_factor_token\x18\x01 \x01(\tR\x0etwoFactorToken\x12\x12\n" +
+	"\x04code\x18\x02 \x01(\tR\x04code\".\n" +
+	"\x16EnrollTwoFactorRequest\x12\x14\n" +
+	"\x05token\x18\x01 \x01(\tR\x05token\"\x90\x01\n" +
+	"\x17EnrollTwoFactorResponse\x12\x18\n" +
+	"\asuccess\x18\x01 \x01(\bR\asuccess\x12\x18\n" +
+	"\amessage\x18\x02 \x01(\tR\amessage\x12\x16\n" +
+	"\x06secret\x18\x03 \x01(\tR\x06secret\x12)\n" +
+	"\x10provisioning_uri\x18\x04 \x01(\tR\x0fprovisioningUri\"C\n" +
+	"\x17ConfirmTwoFactorRequest\x12\x14\n" +
+	"\x05token\x18\x01 \x01(\tR\x05token\x12\x12\n" +
+	"\x04code\x18\x02 \x01(\tR\x04code\"u\n" +
+	"\x18ConfirmTwoFactorResponse\x12\x18\n" +
+	"\asuccess\x18\x01 \x01(\bR\asuccess\x12\x18\n" +
+	"\amessage\x18\x02 \x01(\tR\amessage\x12%\n" +
+	"\x0erecovery_codes\x18\x03 \x03(\tR\rrecoveryCodes\"C\n" +
+	"\x17DisableTwoFactorRequest\x12\x14\n" +
+	"\x05token\x18\x01 \x01(\tR\x05token\x12\x12\n" +
+	"\x04code\x18\x02 \x01(\tR\x04code\"N\n" +
+	"\x18DisableTwoFactorResponse\x12\x18\n" +
+	"\asuccess\x18\x01 \x01(\bR\asuccess\x12\x18\n" +
+	"\amessage\x18\x02 \x01(\tR\amessage\"s\n" +
+	"\x15ChangePasswordRequest\x12\x14\n" +
+	"\x05token\x18\x01 \x01(\tR\x05token\x12!\n" +
+	"\fold_password\x18\x02 \x01(\tR\voldPassword\x12!\n" +
+	"\fnew_password\x18\x03 \x01(\tR\vnewPassword\"L\n" +
+	"\x16ChangePasswordResponse\x12\x18\n" +
+	"\asuccess\x18\x01 \x01(\bR\asuccess\x12\x18\n" +
+	"\amessage\x18\x02 \x01(\tR\amessage\"G\n" +
+	"\x15SetLoginAlertsRequest\x12\x14\n" +
+	"\x05token\x18\x01 \x01(\tR\x05token\x12\x18\n" +
+	"\aenabled\x18\x02 \x01(\bR\aenabled\"L\n" +
+	"\x16SetLoginAlertsResponse\x12\x18\n" +
+	"\asuccess\x18\x01 \x01(\bR\asuccess\x12\x18\n" +
+	"\amessage\x18\x02 \x01(\tR\amessage\"\xa9\x01\n" +
+	"\x1bSetFormatPreferencesRequest\x12\x14\n" +
+	"\x05token\x18\x01 \x01(\tR\x05token\x12\x16\n" +
+	"\x06locale\x18\x02 \x01(\tR\x06locale\x12\x1f\n" +
+	"\vdate_format\x18\x03 \x01(\tR\n" +
+	"dateFormat\x12\x1f\n" +
+	"\vtime_format\x18\x04 \x01(\tR\n" +
+	"timeFormat\x12\x1a\n" +
+	"\btimezone\x18\x05 \x01(\tR\btimezone\"s\n" +
+	"\x1cSetFormatPreferencesResponse\x12\x18\n" +
+	"\asuccess\x18\x01 \x01(\bR\asuccess\x12\x18\n" +
+	"\amessage\x18\x02 \x01(\tR\amessage\x12\x1f\n" +
+	"\x04user\x18\x03 \x01(\v2\v.users.UserR\x04user\"*\n" +
+	"\x12RevokeTokenRequest\x12\x14\n" +
+	"\x05token\x18\x01 \x01(\tR\x05token\"I\n" +
+	"\x13RevokeTokenResponse\x12\x18\n" +
+	"\asuccess\x18\x01 \x01(\bR\asuccess\x12\x18\n" +
+	"\amessage\x18\x02 \x01(\tR\amessage\"h\n" +
+	"\x12SetUserRoleRequest\x12\x14\n" +
+	"\x05token\x18\x01 \x01(\tR\x05token\x12\x17\n" +
+	"\auser_id\x18\x02 \x01(\tR\x06userId\x12#\n" +
+	"\x04role\x18\x03 \x01(\x0e2\x0f.users.UserRoleR\x04role\"j\n" +
+	"\x13SetUserRoleResponse\x12\x18\n" +
+	"\asuccess\x18\x01 \x01(\bR\asuccess\x12\x18\n" +
+	"\amessage\x18\x02 \x01(\tR\amessage\x12\x1f\n" +
+	"\x04user\x18\x03 \x01(\v2\v.users.UserR\x04user\"B\n" +
+	"\x11DeleteUserRequest\x12\x14\n" +
+	"\x05token\x18\x01 \x01(\tR\x05token\x12\x17\n" +
+	"\auser_id\x18\x02 \x01(\tR\x06userId\"\x9e\x01\n" +
+	"\x12DeleteUserResponse\x12\x18\n" +
+	"\asuccess\x18\x01 \x01(\bR\asuccess\x12\x18\n" +
+	"\amessage\x18\x02 \x01(\tR\amessage\x12\x1f\n" +
+	"\x04user\x18\x03 \x01(\v2\v.users.UserR\x04user\x123\n" +
+	"\x15deleted_subscriptions\x18\x04 \x01(\x05R\x14deletedSubscriptions\"C\n" +
+	"\x12RestoreUserRequest\x12\x14\n" +
+	"\x05token\x18\x01 \x01(\tR\x05token\x12\x17\n" +
+	"\auser_id\x18\x02 \x01(\tR\x06userId\"j\n" +
+	"\x13RestoreUserResponse\x12\x18\n" +
+	"\asuccess\x18\x01 \x01(\bR\asuccess\x12\x18\n" +
+	"\amessage\x18\x02 \x01(\tR\amessage\x12\x1f\n" +
+	"\x04user\x18\x03 \x01(\v2\v.users.UserR\x04user\"D\n" +
+	"\x1aImportStudentRosterRequest\x12\x14\n" +
+	"\x05token\x18\x01 \x01(\tR\x05token\x12\x10\n" +
+	"\x03csv\x18\x02 \x01(\fR\x03csv\"\xd7\x01\n" +
+	"\rRosterAccount\x12\x12\n" +
+	"\x04line\x18\x01 \x01(\x05R\x04line\x12\x14\n" +
+	"\x05email\x18\x02 \x01(\tR\x05email\x12\x1b\n" +
+	"\tfull_name\x18\x03 \x01(\tR\bfullName\x12\x1d\n" +
+	"\n" +
+	"group_name\x18\x04 \x01(\tR\tgroupName\x12\x17\n" +
+	"\auser_id\x18\x05 \x01(\tR\x06userId\x12\x18\n" +
+	"\ainvited\x18\x06 \x01(\bR\ainvited\x12-\n" +
+	"\x12temporary_password\x18\a \x01(\tR\x11temporaryPassword\"N\n" +
+	"\n" +
+	"RosterSkip\x12\x12\n" +
+	"\x04line\x18\x01 \x01(\x05R\x04line\x12\x14\n" +
+	"\x05email\x18\x02 \x01(\tR\x05email\x12\x16\n" +
+	"\x06reason\x18\x03 \x01(\tR\x06reason\"\xc4\x01\n" +
+	"\x1bImportStudentRosterResponse\x12\x18\n" +
+	"\asuccess\x18\x01 \x01(\bR\asuccess\x12\x18\n" +
+	"\amessage\x18\x02 \x01(\tR\amessage\x12\x14\n" +
+	"\x05total\x18\x03 \x01(\x05R\x05total\x12.\n" +
+	"\acreated\x18\x04 \x03(\v2\x14.users.RosterAccountR\acreated\x12+\n" +
+	"\askipped\x18\x05 \x03(\v2\x11.users.RosterSkipR\askipped\"\xf9\x01\n" +
+	"\n" +
+	"AuditEvent\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12)\n" +
+	"\x04type\x18\x02 \x01(\x0e2\x15.users.AuditEventTypeR\x04type\x12\x17\n" +
+	"\auser_id\x18\x03 \x01(\tR\x06userId\x12\x19\n" +
+	"\bactor_id\x18\x04 \x01(\tR\aactorId\x12\x14\n" +
+	"\x05email\x18\x05 \x01(\tR\x05email\x12\x0e\n" +
+	"\x02ip\x18\x06 \x01(\tR\x02ip\x12\x1d\n" +
+	"\n" +
+	"user_agent\x18\a \x01(\tR\tuserAgent\x12\x18\n" +
+	"\adetails\x18\b \x01(\tR\adetails\x12\x1d\n" +
+	"\n" +
+	"created_at\x18\t \x01(\tR\tcreatedAt\"\xcb\x01\n" +
+	"\x16ListAuditEventsRequest\x12\x14\n" +
+	"\x05token\x18\x01 \x01(\tR\x05token\x12\x17\n" +
+	"\auser_id\x18\x02 \x01(\tR\x06userId\x12)\n" +
+	"\x04type\x18\x03 \x01(\x0e2\x15.users.AuditEventTypeR\x04type\x12\x12\n" +
+	"\x04from\x18\x04 \x01(\tR\x04from\x12\x0e\n" +
+	"\x02to\x18\x05 \x01(\tR\x02to\x12\x1b\n" +
+	"\tpage_size\x18\x06 \x01(\x05R\bpageSize\x12\x16\n" +
+	"\x06offset\x18\a \x01(\x05R\x06offset\"\x8e\x01\n" +
+	"\x17ListAuditEventsResponse\x12\x18\n" +
+	"\asuccess\x18\x01 \x01(\bR\asuccess\x12\x18\n" +
+	"\amessage\x18\x02 \x01(\tR\amessage\x12)\n" +
+	"\x06events\x18\x03 \x03(\v2\x11.users.AuditEventR\x06events\x12\x14\n" +
+	"\x05total\x18\x04 \x01(\x05R\x05total\"\xa3\x02\n" +
+	"\n" +
+	"Invitation\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x12\n" +
+	"\x04code\x18\x02 \x01(\tR\x04code\x12#\n" +
+	"\x04role\x18\x03 \x01(\x0e2\x0f.users.UserRoleR\x04role\x12\x1d\n" +
+	"\n" +
+	"group_name\x18\x04 \x01(\tR\tgroupName\x12\x19\n" +
+	"\bmax_uses\x18\x05 \x01(\x05R\amaxUses\x12\x1d\n" +
+	"\n" +
+	"used_count\x18\x06 \x01(\x05R\tusedCount\x12\x1d\n" +
+	"\n" +
+	"expires_at\x18\a \x01(\tR\texpiresAt\x12\x1d\n" +
+	"\n" +
+	"created_at\x18\b \x01(\tR\tcreatedAt\x12\x1d\n" +
+	"\n" +
+	"revoked_at\x18\t \x01(\tR\trevokedAt\x12\x16\n" +
+	"\x06active\x18\n" +
+	" \x01(\bR\x06active\"\xad\x01\n" +
+	"\x17CreateInvitationRequest\x12\x14\n" +
+	"\x05token\x18\x01 \x01(\tR\x05token\x12#\n" +
+	"\x04role\x18\x02 \x01(\x0e2\x0f.users.UserRoleR\x04role\x12\x1d\n" +
+	"\n" +
+	"group_name\x18\x03 \x01(\tR\tgroupName\x12\x19\n" +
+	"\bmax_uses\x18\x04 \x01(\x05R\amaxUses\x12\x1d\n" +
+	"\n" +
+	"expires_at\x18\x05 \x01(\tR\texpiresAt\"\x81\x01\n" +
+	"\x18CreateInvitationResponse\x12\x18\n" +
+	"\asuccess\x18\x01 \x01(\bR\asuccess\x12\x18\n" +
+	"\amessage\x18\x02 \x01(\tR\amessage\x121\n" +
+	"\n" +
+	"invitation\x18\x03 \x01(\v2\x11.users.InvitationR\n" +
+	"invitation\"O\n" +
+	"\x16ListInvitationsRequest\x12\x14\n" +
+	"\x05token\x18\x01 \x01(\tR\x05token\x12\x1f\n" +
+	"\vactive_only\x18\x02 \x01(\bR\n" +
+	"activeOnly\"\x82\x01\n" +
+	"\x17ListInvitationsResponse\x12\x18\n" +
+	"\asuccess\x18\x01 \x01(\bR\asuccess\x12\x18\n" +
+	"\amessage\x18\x02 \x01(\tR\amessage\x123\n" +
+	"\vinvitations\x18\x03 \x03(\v2\x11.users.InvitationR\vinvitations\"T\n" +
+	"\x17RevokeInvitationRequest\x12\x14\n" +
+	"\x05token\x18\x01 \x01(\tR\x05token\x12#\n" +
+	"\rinvitation_id\x18\x02 \x01(\tR\finvitationId\"\x81\x01\n" +
+	"\x18RevokeInvitationResponse\x12\x18\n" +
+	"\asuccess\x18\x01 \x01(\bR\asuccess\x12\x18\n" +
+	"\amessage\x18\x02 \x01(\tR\amessage\x121\n" +
+	"\n" +
+	"invitation\x18\x03 \x01(\v2\x11.users.InvitationR\n" +
+	"invitation\")\n" +
 	"\x11GetProfileRequest\x12\x14\n" +
 	"\x05token\x18\x01 \x01(\tR\x05token\"\xf8\x01\n" +
 	"\x12GetProfileResponse\x12\x18\n" +
@@ -904,21 +3944,32 @@ const file_users_proto_rawDesc = "" +
 	"\x04user\x18\x03 \x01(\v2\v.users.UserR\x04user\x12@\n" +
 	"\x0fstudent_profile\x18\x04 \x01(\v2\x15.users.StudentProfileH\x00R\x0estudentProfile\x12@\n" +
 	"\x0fteacher_profile\x18\x05 \x01(\v2\x15.users.TeacherProfileH\x00R\x0eteacherProfileB\t\n" +
-	"\aprofile\"\x8d\x01\n" +
+	"\aprofile\"\xe0\x02\n" +
 	"\x04User\x12\x0e\n" +
 	"\x02id\x18\x01 \x01(\tR\x02id\x12\x14\n" +
 	"\x05email\x18\x02 \x01(\tR\x05email\x12#\n" +
 	"\x04role\x18\x03 \x01(\x0e2\x0f.users.UserRoleR\x04role\x12\x1d\n" +
 	"\n" +
 	"created_at\x18\x04 \x01(\tR\tcreatedAt\x12\x1b\n" +
-	"\tis_active\x18\x05 \x01(\bR\bisActive\"\xa1\x01\n" +
+	"\tis_active\x18\x05 \x01(\bR\bisActive\x128\n" +
+	"\x18password_change_required\x18\x06 \x01(\bR\x16passwordChangeRequired\x12!\n" +
+	"\flogin_alerts\x18\a \x01(\bR\vloginAlerts\x12\x16\n" +
+	"\x06locale\x18\b \x01(\tR\x06locale\x12\x1f\n" +
+	"\vdate_format\x18\t \x01(\tR\n" +
+	"dateFormat\x12\x1f\n" +
+	"\vtime_format\x18\n" +
+	" \x01(\tR\n" +
+	"timeFormat\x12\x1a\n" +
+	"\btimezone\x18\v \x01(\tR\btimezone\"\xda\x01\n" +
 	"\x0eStudentProfile\x12\x17\n" +
 	"\auser_id\x18\x01 \x01(\tR\x06userId\x12\x1d\n" +
 	"\n" +
 	"group_name\x18\x02 \x01(\tR\tgroupName\x12\x18\n" +
 	"\afaculty\x18\x03 \x01(\tR\afaculty\x12\x16\n" +
 	"\x06course\x18\x04 \x01(\x05R\x06course\x12%\n" +
-	"\x0estudent_number\x18\x05 \x01(\tR\rstudentNumber\"\xa1\x01\n" +
+	"\x0estudent_number\x18\x05 \x01(\tR\rstudentNumber\x12\x1b\n" +
+	"\tfull_name\x18\x06 \x01(\tR\bfullName\x12\x1a\n" +
+	"\bsubgroup\x18\a \x01(\x05R\bsubgroup\"\xa1\x01\n" +
 	"\x0eTeacherProfile\x12\x17\n" +
 	"\auser_id\x18\x01 \x01(\tR\x06userId\x12\x1b\n" +
 	"\tfull_name\x18\x02 \x01(\tR\bfullName\x12\x1e\n" +
@@ -927,19 +3978,59 @@ const file_users_proto_rawDesc = "" +
 	"department\x12\x1a\n" +
 	"\bposition\x18\x04 \x01(\tR\bposition\x12\x1d\n" +
 	"\n" +
-	"teacher_id\x18\x05 \x01(\tR\tteacherId*T\n" +
+	"teacher_id\x18\x05 \x01(\tR\tteacherId\"M\n" +
+	"\x19SetStudentSubgroupRequest\x12\x14\n" +
+	"\x05token\x18\x01 \x01(\tR\x05token\x12\x1a\n" +
+	"\bsubgroup\x18\x02 \x01(\x05R\bsubgroup\"\x90\x01\n" +
+	"\x1aSetStudentSubgroupResponse\x12\x18\n" +
+	"\asuccess\x18\x01 \x01(\bR\asuccess\x12\x18\n" +
+	"\amessage\x18\x02 \x01(\tR\amessage\x12>\n" +
+	"\x0fstudent_profile\x18\x03 \x01(\v2\x15.users.StudentProfileR\x0estudentProfile*T\n" +
 	"\bUserRole\x12\x14\n" +
 	"\x10ROLE_UNSPECIFIED\x10\x00\x12\x10\n" +
 	"\fROLE_STUDENT\x10\x01\x12\x10\n" +
 	"\fROLE_TEACHER\x10\x02\x12\x0e\n" +
 	"\n" +
-	"ROLE_ADMIN\x10\x032\x9a\x02\n" +
+	"ROLE_ADMIN\x10\x03*\x94\x03\n" +
+	"\x0eAuditEventType\x12 \n" +
+	"\x1cAUDIT_EVENT_TYPE_UNSPECIFIED\x10\x00\x12!\n" +
+	"\x1dAUDIT_EVENT_TYPE_REGISTRATION\x10\x01\x12\x1a\n" +
+	"\x16AUDIT_EVENT_TYPE_LOGIN\x10\x02\x12!\n" +
+	"\x1dAUDIT_EVENT_TYPE_LOGIN_FAILED\x10\x03\x12$\n" +
+	" AUDIT_EVENT_TYPE_PASSWORD_CHANGE\x10\x04\x12 \n" +
+	"\x1cAUDIT_EVENT_TYPE_ROLE_CHANGE\x10\x05\x12%\n" +
+	"!AUDIT_EVENT_TYPE_TOKEN_REVOCATION\x10\x06\x12&\n" +
+	"\"AUDIT_EVENT_TYPE_TWO_FACTOR_CHANGE\x10\a\x12 \n" +
+	"\x1cAUDIT_EVENT_TYPE_USER_EXPORT\x10\b\x12\"\n" +
+	"\x1eAUDIT_EVENT_TYPE_USER_DELETION\x10\t\x12!\n" +
+	"\x1dAUDIT_EVENT_TYPE_USER_RESTORE\x10\n" +
+	"2\xa9\x0e\n" +
 	"\vUserService\x12I\n" +
 	"\x0fRegisterStudent\x12\x1d.users.RegisterStudentRequest\x1a\x17.users.RegisterResponse\x12I\n" +
 	"\x0fRegisterTeacher\x12\x1d.users.RegisterTeacherRequest\x1a\x17.users.RegisterResponse\x122\n" +
 	"\x05Login\x12\x13.users.LoginRequest\x1a\x14.users.LoginResponse\x12A\n" +
 	"\n" +
-	"GetProfile\x12\x18.users.GetProfileRequest\x1a\x19.users.GetProfileResponseB\tZ\a./usersb\x06proto3"
+	"GetProfile\x12\x18.users.GetProfileRequest\x1a\x19.users.GetProfileResponse\x12P\n" +
+	"\x0fIssueGuestToken\x12\x1d.users.IssueGuestTokenRequest\x1a\x1e.users.IssueGuestTokenResponse\x12\\\n" +
+	"\x13GetCaptchaChallenge\x12!.users.GetCaptchaChallengeRequest\x1a\".users.GetCaptchaChallengeResponse\x12F\n" +
+	"\x0fVerifyTwoFactor\x12\x1d.users.VerifyTwoFactorRequest\x1a\x14.users.LoginResponse\x12P\n" +
+	"\x0fEnrollTwoFactor\x12\x1d.users.EnrollTwoFactorRequest\x1a\x1e.users.EnrollTwoFactorResponse\x12S\n" +
+	"\x10ConfirmTwoFactor\x12\x1e.users.ConfirmTwoFactorRequest\x1a\x1f.users.ConfirmTwoFactorResponse\x12S\n" +
+	"\x10DisableTwoFactor\x12\x1e.users.DisableTwoFactorRequest\x1a\x1f.users.DisableTwoFactorResponse\x12M\n" +
+	"\x0eChangePassword\x12\x1c.users.ChangePasswordRequest\x1a\x1d.users.ChangePasswordResponse\x12M\n" +
+	"\x0eSetLoginAlerts\x12\x1c.users.SetLoginAlertsRequest\x1a\x1d.users.SetLoginAlertsResponse\x12_\n" +
+	"\x14SetFormatPreferences\x12\".users.SetFormatPreferencesRequest\x1a#.users.SetFormatPreferencesResponse\x12D\n" +
+	"\vRevokeToken\x12\x19.users.RevokeTokenRequest\x1a\x1a.users.RevokeTokenResponse\x12D\n" +
+	"\vSetUserRole\x12\x19.users.SetUserRoleRequest\x1a\x1a.users.SetUserRoleResponse\x12A\n" +
+	"\n" +
+	"DeleteUser\x12\x18.users.DeleteUserRequest\x1a\x19.users.DeleteUserResponse\x12D\n" +
+	"\vRestoreUser\x12\x19.users.RestoreUserRequest\x1a\x1a.users.RestoreUserResponse\x12\\\n" +
+	"\x13ImportStudentRoster\x12!.users.ImportStudentRosterRequest\x1a\".users.ImportStudentRosterResponse\x12P\n" +
+	"\x0fListAuditEvents\x12\x1d.users.ListAuditEventsRequest\x1a\x1e.users.ListAuditEventsResponse\x12S\n" +
+	"\x10CreateInvitation\x12\x1e.users.CreateInvitationRequest\x1a\x1f.users.CreateInvitationResponse\x12P\n" +
+	"\x0fListInvitations\x12\x1d.users.ListInvitationsRequest\x1a\x1e.users.ListInvitationsResponse\x12S\n" +
+	"\x10RevokeInvitation\x12\x1e.users.RevokeInvitationRequest\x1a\x1f.users.RevokeInvitationResponse\x12Y\n" +
+	"\x12SetStudentSubgroup\x12 .users.SetStudentSubgroupRequest\x1a!.users.SetStudentSubgroupResponseB\tZ\a./usersb\x06proto3"
 
 var (
 	file_users_proto_rawDescOnce sync.Once
@@ -953,43 +4044,139 @@ func file_users_proto_rawDescGZIP() []byte {
 	return file_users_proto_rawDescData
 }
 
-var file_users_proto_enumTypes = make([]protoimpl.EnumInfo, 1)
-var file_users_proto_msgTypes = make([]protoimpl.MessageInfo, 10)
+var file_users_proto_enumTypes = make([]protoimpl.EnumInfo, 2)
+var file_users_proto_msgTypes = make([]protoimpl.MessageInfo, 51)
 var file_users_proto_goTypes = []any{
-	(UserRole)(0),                  // 0: users.UserRole
-	(*RegisterStudentRequest)(nil), // 1: users.RegisterStudentRequest
-	(*RegisterTeacherRequest)(nil), // 2: users.RegisterTeacherRequest
-	(*RegisterResponse)(nil),       // 3: users.RegisterResponse
-	(*LoginRequest)(nil),           // 4: users.LoginRequest
-	(*LoginResponse)(nil),          // 5: users.LoginResponse
-	(*GetProfileRequest)(nil),      // 6: users.GetProfileRequest
-	(*GetProfileResponse)(nil),     // 7: users.GetProfileResponse
-	(*User)(nil),                   // 8: users.User
-	(*StudentProfile)(nil),         // 9: users.StudentProfile
-	(*TeacherProfile)(nil),         // 10: users.TeacherProfile
+	(UserRole)(0),                        // 0: users.UserRole
+	(AuditEventType)(0),                  // 1: users.AuditEventType
+	(*RegisterStudentRequest)(nil),       // 2: users.RegisterStudentRequest
+	(*RegisterTeacherRequest)(nil),       // 3: users.RegisterTeacherRequest
+	(*RegisterResponse)(nil),             // 4: users.RegisterResponse
+	(*LoginRequest)(nil),                 // 5: users.LoginRequest
+	(*LoginResponse)(nil),                // 6: users.LoginResponse
+	(*IssueGuestTokenRequest)(nil),       // 7: users.IssueGuestTokenRequest
+	(*IssueGuestTokenResponse)(nil),      // 8: users.IssueGuestTokenResponse
+	(*GetCaptchaChallengeRequest)(nil),   // 9: users.GetCaptchaChallengeRequest
+	(*GetCaptchaChallengeResponse)(nil),  // 10: users.GetCaptchaChallengeResponse
+	(*VerifyTwoFactorRequest)(nil),       // 11: users.VerifyTwoFactorRequest
+	(*EnrollTwoFactorRequest)(nil),       // 12: users.EnrollTwoFactorRequest
+	(*EnrollTwoFactorResponse)(nil),      // 13: users.EnrollTwoFactorResponse
+	(*ConfirmTwoFactorRequest)(nil),      // 14: users.ConfirmTwoFactorRequest
+	(*ConfirmTwoFactorResponse)(nil),     // 15: users.ConfirmTwoFactorResponse
+	(*DisableTwoFactorRequest)(nil),      // 16: users.DisableTwoFactorRequest
+	(*DisableTwoFactorResponse)(nil),     // 17: users.DisableTwoFactorResponse
+	(*ChangePasswordRequest)(nil),        // 18: users.ChangePasswordRequest
+	(*ChangePasswordResponse)(nil),       // 19: users.ChangePasswordResponse
+	(*SetLoginAlertsRequest)(nil),        // 20: users.SetLoginAlertsRequest
+	(*SetLoginAlertsResponse)(nil),       // 21: users.SetLoginAlertsResponse
+	(*SetFormatPreferencesRequest)(nil),  // 22: users.SetFormatPreferencesRequest
+	(*SetFormatPreferencesResponse)(nil), // 23: users.SetFormatPreferencesResponse
+	(*RevokeTokenRequest)(nil),           // 24: users.RevokeTokenRequest
+	(*RevokeTokenResponse)(nil),          // 25: users.RevokeTokenResponse
+	(*SetUserRoleRequest)(nil),           // 26: users.SetUserRoleRequest
+	(*SetUserRoleResponse)(nil),          // 27: users.SetUserRoleResponse
+	(*DeleteUserRequest)(nil),            // 28: users.DeleteUserRequest
+	(*DeleteUserResponse)(nil),           // 29: users.DeleteUserResponse
+	(*RestoreUserRequest)(nil),           // 30: users.RestoreUserRequest
+	(*RestoreUserResponse)(nil),          // 31: users.RestoreUserResponse
+	(*ImportStudentRosterRequest)(nil),   // 32: users.ImportStudentRosterRequest
+	(*RosterAccount)(nil),                // 33: users.RosterAccount
+	(*RosterSkip)(nil),                   // 34: users.RosterSkip
+	(*ImportStudentRosterResponse)(nil),  // 35: users.ImportStudentRosterResponse
+	(*AuditEvent)(nil),                   // 36: users.AuditEvent
+	(*ListAuditEventsRequest)(nil),       // 37: users.ListAuditEventsRequest
+	(*ListAuditEventsResponse)(nil),      // 38: users.ListAuditEventsResponse
+	(*Invitation)(nil),                   // 39: users.Invitation
+	(*CreateInvitationRequest)(nil),      // 40: users.CreateInvitationRequest
+	(*CreateInvitationResponse)(nil),     // 41: users.CreateInvitationResponse
+	(*ListInvitationsRequest)(nil),       // 42: users.ListInvitationsRequest
+	(*ListInvitationsResponse)(nil),      // 43: users.ListInvitationsResponse
+	(*RevokeInvitationRequest)(nil),      // 44: users.RevokeInvitationRequest
+	(*RevokeInvitationResponse)(nil),     // 45: users.RevokeInvitationResponse
+	(*GetProfileRequest)(nil),            // 46: users.GetProfileRequest
+	(*GetProfileResponse)(nil),           // 47: users.GetProfileResponse
+	(*User)(nil),                         // 48: users.User
+	(*StudentProfile)(nil),               // 49: users.StudentProfile
+	(*TeacherProfile)(nil),               // 50: users.TeacherProfile
+	(*SetStudentSubgroupRequest)(nil),    // 51: users.SetStudentSubgroupRequest
+	(*SetStudentSubgroupResponse)(nil),   // 52: users.SetStudentSubgroupResponse
 }
 var file_users_proto_depIdxs = []int32{
-	8,  // 0: users.RegisterResponse.user:type_name -> users.User
-	9,  // 1: users.RegisterResponse.student_profile:type_name -> users.StudentProfile
-	10, // 2: users.RegisterResponse.teacher_profile:type_name -> users.TeacherProfile
-	8,  // 3: users.LoginResponse.user:type_name -> users.User
-	8,  // 4: users.GetProfileResponse.user:type_name -> users.User
-	9,  // 5: users.GetProfileResponse.student_profile:type_name -> users.StudentProfile
-	10, // 6: users.GetProfileResponse.teacher_profile:type_name -> users.TeacherProfile
-	0,  // 7: users.User.role:type_name -> users.UserRole
-	1,  // 8: users.UserService.RegisterStudent:input_type -> users.RegisterStudentRequest
-	2,  // 9: users.UserService.RegisterTeacher:input_type -> users.RegisterTeacherRequest
-	4,  // 10: users.UserService.Login:input_type -> users.LoginRequest
-	6,  // 11: users.UserService.GetProfile:input_type -> users.GetProfileRequest
-	3,  // 12: users.UserService.RegisterStudent:output_type -> users.RegisterResponse
-	3,  // 13: users.UserService.RegisterTeacher:output_type -> users.RegisterResponse
-	5,  // 14: users.UserService.Login:output_type -> users.LoginResponse
-	7,  // 15: users.UserService.GetProfile:output_type -> users.GetProfileResponse
-	12, // [12:16] is the sub-list for method output_type
-	8,  // [8:12] is the sub-list for method input_type
-	8,  // [8:8] is the sub-list for extension type_name
-	8,  // [8:8] is the sub-list for extension extendee
-	0,  // [0:8] is the sub-list for field type_name
+	48, // 0: users.RegisterResponse.user:type_name -> users.User
+	49, // 1: users.RegisterResponse.student_profile:type_name -> users.StudentProfile
+	50, // 2: users.RegisterResponse.teacher_profile:type_name -> users.TeacherProfile
+	48, // 3: users.LoginResponse.user:type_name -> users.User
+	48, // 4: users.SetFormatPreferencesResponse.user:type_name -> users.User
+	0,  // 5: users.SetUserRoleRequest.role:type_name -> users.UserRole
+	48, // 6: users.SetUserRoleResponse.user:type_name -> users.User
+	48, // 7: users.DeleteUserResponse.user:type_name -> users.User
+	48, // 8: users.RestoreUserResponse.user:type_name -> users.User
+	33, // 9: users.ImportStudentRosterResponse.created:type_name -> users.RosterAccount
+	34, // 10: users.ImportStudentRosterResponse.skipped:type_name -> users.RosterSkip
+	1,  // 11: users.AuditEvent.type:type_name -> users.AuditEventType
+	1,  // 12: users.ListAuditEventsRequest.type:type_name -> users.AuditEventType
+	36, // 13: users.ListAuditEventsResponse.events:type_name -> users.AuditEvent
+	0,  // 14: users.Invitation.role:type_name -> users.UserRole
+	0,  // 15: users.CreateInvitationRequest.role:type_name -> users.UserRole
+	39, // 16: users.CreateInvitationResponse.invitation:type_name -> users.Invitation
+	39, // 17: users.ListInvitationsResponse.invitations:type_name -> users.Invitation
+	39, // 18: users.RevokeInvitationResponse.invitation:type_name -> users.Invitation
+	48, // 19: users.GetProfileResponse.user:type_name -> users.User
+	49, // 20: users.GetProfileResponse.student_profile:type_name -> users.StudentProfile
+	50, // 21: users.GetProfileResponse.teacher_profile:type_name -> users.TeacherProfile
+	0,  // 22: users.User.role:type_name -> users.UserRole
+	49, // 23: users.SetStudentSubgroupResponse.student_profile:type_name -> users.StudentProfile
+	2,  // 24: users.UserService.RegisterStudent:input_type -> users.RegisterStudentRequest
+	3,  // 25: users.UserService.RegisterTeacher:input_type -> users.RegisterTeacherRequest
+	5,  // 26: users.UserService.Login:input_type -> users.LoginRequest
+	46, // 27: users.UserService.GetProfile:input_type -> users.GetProfileRequest
+	7,  // 28: users.UserService.IssueGuestToken:input_type -> users.IssueGuestTokenRequest
+	9,  // 29: users.UserService.GetCaptchaChallenge:input_type -> users.GetCaptchaChallengeRequest
+	11, // 30: users.UserService.VerifyTwoFactor:input_type -> users.VerifyTwoFactorRequest
+	12, // 31: users.UserService.EnrollTwoFactor:input_type -> users.EnrollTwoFactorRequest
+	14, // 32: users.UserService.ConfirmTwoFactor:input_type -> users.ConfirmTwoFactorRequest
+	16, // 33: users.UserService.DisableTwoFactor:input_type -> users.DisableTwoFactorRequest
+	18, // 34: users.UserService.ChangePassword:input_type -> users.ChangePasswordRequest
+	20, // 35: users.UserService.SetLoginAlerts:input_type -> users.SetLoginAlertsRequest
+	22, // 36: users.UserService.SetFormatPreferences:input_type -> users.SetFormatPreferencesRequest
+	24, // 37: users.UserService.RevokeToken:input_type -> users.RevokeTokenRequest
+	26, // 38: users.UserService.SetUserRole:input_type -> users.SetUserRoleRequest
+	28, // 39: users.UserService.DeleteUser:input_type -> users.DeleteUserRequest
+	30, // 40: users.UserService.RestoreUser:input_type -> users.RestoreUserRequest
+	32, // 41: users.UserService.ImportStudentRoster:input_type -> users.ImportStudentRosterRequest
+	37, // 42: users.UserService.ListAuditEvents:input_type -> users.ListAuditEventsRequest
+	40, // 43: users.UserService.CreateInvitation:input_type -> users.CreateInvitationRequest
+	42, // 44: users.UserService.ListInvitations:input_type -> users.ListInvitationsRequest
+	44, // 45: users.UserService.RevokeInvitation:input_type -> users.RevokeInvitationRequest
+	51, // 46: users.UserService.SetStudentSubgroup:input_type -> users.SetStudentSubgroupRequest
+	4,  // 47: users.UserService.RegisterStudent:output_type -> users.RegisterResponse
+	4,  // 48: users.UserService.RegisterTeacher:output_type -> users.RegisterResponse
+	6,  // 49: users.UserService.Login:output_type -> users.LoginResponse
+	47, // 50: users.UserService.GetProfile:output_type -> users.GetProfileResponse
+	8,  // 51: users.UserService.IssueGuestToken:output_type -> users.IssueGuestTokenResponse
+	10, // 52: users.UserService.GetCaptchaChallenge:output_type -> users.GetCaptchaChallengeResponse
+	6,  // 53: users.UserService.VerifyTwoFactor:output_type -> users.LoginResponse
+	13, // 54: users.UserService.EnrollTwoFactor:output_type -> users.EnrollTwoFactorResponse
+	15, // 55: users.UserService.ConfirmTwoFactor:output_type -> users.ConfirmTwoFactorResponse
+	17, // 56: users.UserService.DisableTwoFactor:output_type -> users.DisableTwoFactorResponse
+	19, // 57: users.UserService.ChangePassword:output_type -> users.ChangePasswordResponse
+	21, // 58: users.UserService.SetLoginAlerts:output_type -> users.SetLoginAlertsResponse
+	23, // 59: users.UserService.SetFormatPreferences:output_type -> users.SetFormatPreferencesResponse
+	25, // 60: users.UserService.RevokeToken:output_type -> users.RevokeTokenResponse
+	27, // 61: users.UserService.SetUserRole:output_type -> users.SetUserRoleResponse
+	29, // 62: users.UserService.DeleteUser:output_type -> users.DeleteUserResponse
+	31, // 63: users.UserService.RestoreUser:output_type -> users.RestoreUserResponse
+	35, // 64: users.UserService.ImportStudentRoster:output_type -> users.ImportStudentRosterResponse
+	38, // 65: users.UserService.ListAuditEvents:output_type -> users.ListAuditEventsResponse
+	41, // 66: users.UserService.CreateInvitation:output_type -> users.CreateInvitationResponse
+	43, // 67: users.UserService.ListInvitations:output_type -> users.ListInvitationsResponse
+	45, // 68: users.UserService.RevokeInvitation:output_type -> users.RevokeInvitationResponse
+	52, // 69: users.UserService.SetStudentSubgroup:output_type -> users.SetStudentSubgroupResponse
+	47, // [47:70] is the sub-list for method output_type
+	24, // [24:47] is the sub-list for method input_type
+	24, // [24:24] is the sub-list for extension type_name
+	24, // [24:24] is the sub-list for extension extendee
+	0,  // [0:24] is the sub-list for field type_name
 }
 
 func init() { file_users_proto_init() }
@@ -1001,7 +4188,7 @@ func file_users_proto_init() {
 		(*RegisterResponse_StudentProfile)(nil),
 		(*RegisterResponse_TeacherProfile)(nil),
 	}
-	file_users_proto_msgTypes[6].OneofWrappers = []any{
+	file_users_proto_msgTypes[45].OneofWrappers = []any{
 		(*GetProfileResponse_StudentProfile)(nil),
 		(*GetProfileResponse_TeacherProfile)(nil),
 	}
@@ -1010,8 +4197,8 @@ func file_users_proto_init() {
 		File: protoimpl.DescBuilder{
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_users_proto_rawDesc), len(file_users_proto_rawDesc)),
-			NumEnums:      1,
-			NumMessages:   10,
+			NumEnums:      2,
+			NumMessages:   51,
 			NumExtensions: 0,
 			NumServices:   1,
 		},